@@ -0,0 +1,2715 @@
+// Code generated by go run ./cmd/openapi; DO NOT EDIT.
+// tenantiq API 0.1.0
+
+export interface APIKeyListOutputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Keys, newest first, revoked ones included */
+  items: APIKeyResponse[] | null;
+}
+
+export interface APIKeyResponse {
+  /** Creation timestamp (ISO 8601) */
+  created_at: string;
+  /** Who created the key */
+  created_by: string;
+  /** When the key stops working (ISO 8601); absent when it does not expire */
+  expires_at?: string;
+  /** API key ID */
+  id: string;
+  /** When the key was last verified, to the minute (ISO 8601); absent when it never was */
+  last_used_at?: string;
+  /** What the key is for */
+  name: string;
+  /** Start of the key, to tell keys apart */
+  prefix: string;
+  /** When the key was revoked (ISO 8601) */
+  revoked_at?: string;
+  /** What the key may do */
+  scopes: string[] | null;
+  /** Tenant ID */
+  tenant_id: string;
+}
+
+export interface AddTagsInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Tags to add (lowercase letters, digits, '_', '-', '.' and ':'); tags the tenant already has are ignored */
+  tags: string[] | null;
+}
+
+export interface AdminKeyListOutputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Keys, newest first, revoked ones included */
+  items: AdminKeyResponse[] | null;
+}
+
+export interface AdminKeyResponse {
+  /** Creation timestamp (ISO 8601) */
+  created_at: string;
+  /** Who created the key */
+  created_by: string;
+  /** Admin key ID */
+  id: string;
+  /** When the key was last used, to the minute (ISO 8601); absent when it never was */
+  last_used_at?: string;
+  /** Who holds the key; the actor of the changes made with it */
+  name: string;
+  /** Start of the key, to tell keys apart */
+  prefix: string;
+  /** When the key was revoked (ISO 8601) */
+  revoked_at?: string;
+  /** What the holder may do */
+  role: "viewer" | "operator" | "admin";
+}
+
+export interface ApplySpecInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** All the custom domains of the tenant: missing ones get a certificate, the others are removed (left untouched when omitted) */
+  domains?: string[] | null;
+  /** Feature flags to switch on or off, kept in the metadata as feature.<name> */
+  features?: Record<string, boolean>;
+  /** Metadata to set; an empty value removes the key and unlisted keys are left untouched */
+  metadata?: Record<string, string>;
+  /** Display name */
+  name: string;
+  /** Subscription plan (left untouched when omitted; free for a new tenant) */
+  plan?: string;
+  /** Desired lifecycle state (left untouched when omitted) */
+  status?: "creating" | "active" | "suspended" | "deleting" | "deleted";
+}
+
+export interface ApplySpecResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Changes made to converge to the spec (empty when already in sync) */
+  changes: string[] | null;
+  /** Whether the tenant was created by this request */
+  created: boolean;
+  /** Tenant after the spec was applied */
+  tenant: TenantResponse;
+}
+
+export interface BatchCreateItem {
+  /** Blueprint giving the tenant its plan, metadata, feature flags and template variables */
+  blueprint?: string;
+  /** Integrator-defined key-value data */
+  metadata?: Record<string, string>;
+  /** Display name */
+  name: string;
+  /** Subscription plan; the blueprint's plan, or free, when omitted */
+  plan?: string;
+  /** Region to host the tenant in; its plan must be offered there */
+  region?: string;
+  /** URL-friendly identifier (lowercase, hyphens); derived from the name when omitted */
+  slug?: string;
+}
+
+export interface BatchCreateResult {
+  /** Why the item was rejected */
+  error?: string;
+  /** Outcome for this item */
+  status: "created" | "conflict" | "invalid";
+  /** Created tenant */
+  tenant?: TenantResponse;
+}
+
+export interface BatchCreateTenantsInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Tenants to create */
+  tenants: BatchCreateItem[] | null;
+}
+
+export interface BatchCreateTenantsOutputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Per-item results, in request order */
+  results: BatchCreateResult[] | null;
+}
+
+export interface BatchTransitionResult {
+  /** Why the event failed */
+  error?: string;
+  /** Outcome for this tenant */
+  status: "transitioned" | "failed";
+  /** Tenant after the event */
+  tenant?: TenantResponse;
+}
+
+export interface BatchTransitionTenantsInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Lifecycle event to trigger */
+  event: "provision_complete" | "suspend" | "reactivate" | "delete" | "deletion_complete";
+  /** Suspend or delete past the guardrail on mass disruptions (admin only) */
+  force?: boolean;
+  /** Tenants to trigger the event on */
+  ids: string[] | null;
+}
+
+export interface BatchTransitionTenantsOutputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Per-tenant results, in request order */
+  results: BatchTransitionResult[] | null;
+}
+
+export interface BillingMismatchResponse {
+  /** Human-readable explanation */
+  detail: string;
+  /** What disagrees */
+  kind: "missing_subscription" | "plan_mismatch" | "suspended_paying" | "deleted_paying" | "unknown_tenant";
+  /** Plan billed */
+  subscription_plan?: string;
+  /** Subscription state at the billing provider */
+  subscription_status?: string;
+  /** Tenant ID (as referenced by billing for unknown_tenant) */
+  tenant_id: string;
+  /** Plan in tenantiq */
+  tenant_plan?: string;
+  /** Lifecycle state in tenantiq */
+  tenant_status?: string;
+}
+
+export interface BillingWebhook {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Invoice at the billing provider */
+  invoice_id?: string;
+  /** Tenant the invoice belongs to; required by the handled types */
+  tenant_id?: string;
+  /** Webhook type; invoice.payment_failed and invoice.paid are handled */
+  type: string;
+}
+
+export interface BillingWebhookResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Whether the webhook type is handled */
+  status: "processed" | "ignored";
+}
+
+export interface BlueprintConfig {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Feature flags, set on tenants as metadata feature.<name> ("true" or "false") */
+  features?: Record<string, boolean>;
+  /** Metadata of the tenants created from the blueprint */
+  metadata?: Record<string, string>;
+  /** Plan of tenants created without one */
+  plan: string;
+  /** Defaults of the provisioning template variables, set on tenants as metadata var.<name> */
+  variables?: Record<string, string>;
+}
+
+export interface BlueprintListOutputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Blueprints, by name */
+  items: BlueprintResponse[] | null;
+}
+
+export interface BlueprintResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Creation timestamp (ISO 8601) */
+  created_at: string;
+  /** Feature flags, set on tenants as metadata feature.<name> */
+  features?: Record<string, boolean>;
+  /** Metadata of the tenants created from the blueprint */
+  metadata?: Record<string, string>;
+  /** Unique name, as given at tenant creation */
+  name: string;
+  /** Plan of tenants created without one */
+  plan: string;
+  /** Last update timestamp (ISO 8601) */
+  updated_at: string;
+  /** Defaults of the provisioning template variables, set on tenants as metadata var.<name> */
+  variables?: Record<string, string>;
+}
+
+export interface CertificateListOutputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Certificates, by domain */
+  items: CertificateResponse[] | null;
+}
+
+export interface CertificateResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Creation timestamp (ISO 8601) */
+  created_at: string;
+  /** Host name the certificate is for */
+  domain: string;
+  /** Certificate authority of the certificate last issued */
+  issuer?: string;
+  /** Why the last attempt failed, if it did */
+  last_error?: string;
+  /** Expiry of the certificate last issued (ISO 8601) */
+  not_after?: string;
+  /** Start of the validity of the certificate last issued (ISO 8601) */
+  not_before?: string;
+  /** When the certificate is next requested (ISO 8601) */
+  renew_at: string;
+  /** Serial number of the certificate last issued (hexadecimal) */
+  serial?: string;
+  /** pending until first issued; failed when the last attempt failed, which is retried */
+  status: "pending" | "issued" | "failed";
+  /** Last update timestamp (ISO 8601) */
+  updated_at: string;
+}
+
+export interface CheckQuotaInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** How much of the metric the operation consumes */
+  amount?: number;
+  /** Metric the operation consumes (e.g. projects, api_calls, seats) */
+  metric: string;
+}
+
+export interface CreateAPIKeyInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** When the key stops working (RFC 3339); it does not expire when omitted */
+  expires_at?: string;
+  /** What the key is for */
+  name: string;
+  /** What the key may do, such as tenants:read; the applications accepting the key decide what each scope grants */
+  scopes?: string[] | null;
+}
+
+export interface CreateAdminKeyInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Who holds the key, such as a person or an automation */
+  name: string;
+  /** What the holder may do; admin by default */
+  role?: "viewer" | "operator" | "admin";
+}
+
+export interface CreateBlueprintInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Feature flags, set on tenants as metadata feature.<name> ("true" or "false") */
+  features?: Record<string, boolean>;
+  /** Metadata of the tenants created from the blueprint */
+  metadata?: Record<string, string>;
+  /** Unique name (lowercase, hyphens or underscores), such as enterprise-eu */
+  name: string;
+  /** Plan of tenants created without one */
+  plan: string;
+  /** Defaults of the provisioning template variables, set on tenants as metadata var.<name> */
+  variables?: Record<string, string>;
+}
+
+export interface CreatePlanInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** ISO 4217 currency code */
+  currency?: string;
+  /** Features included in the plan */
+  features?: string[] | null;
+  /** Usage allowed per metric; metrics without a limit are unlimited */
+  limits?: Record<string, number>;
+  /** Unique name (lowercase, hyphens or underscores), as set on tenants */
+  name: string;
+  /** Monthly price in the smallest unit of the currency (e.g. cents) */
+  price?: number;
+  /** Request rate of the plan's tenants, unless overridden per tenant (e.g. 1 per second with a burst of 60 for 60 requests a minute); they are not limited when omitted */
+  rate_limit?: PlanRateLimit;
+  /** Regions the plan is offered in (e.g. eu-west); it is offered everywhere when omitted */
+  regions?: string[] | null;
+}
+
+export interface CreateResellerInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Display name */
+  name: string;
+  /** Max tenants (not yet deleted) the reseller may manage */
+  tenant_quota: number;
+}
+
+export interface CreateResellerTenantInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Display name */
+  name: string;
+  /** Subscription plan */
+  plan?: string;
+  /** URL-friendly identifier (lowercase, hyphens); derived from the name when omitted */
+  slug?: string;
+}
+
+export interface CreateSignedURLInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Validity of the link as a Go duration (e.g. 15m, 24h); 1h by default */
+  expires_in?: string;
+  /** Path and query of the resource under /public (e.g. /public/tenants/ten_123/status) */
+  path: string;
+  /** Reject the link once it has been used */
+  single_use?: boolean;
+}
+
+export interface CreateTenantInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Blueprint giving the tenant its plan, metadata, feature flags and template variables; the request's plan and metadata override it */
+  blueprint?: string;
+  /** Integrator-defined key-value data; keys are letters, digits, '_', '-' and '.' */
+  metadata?: Record<string, string>;
+  /** Display name */
+  name: string;
+  /** Subscription plan; the blueprint's plan, or free, when omitted */
+  plan?: string;
+  /** Region to host the tenant in (e.g. eu-west); its plan must be offered there. It cannot change later */
+  region?: string;
+  /** Provision the tenant with fake adapters only (no Git, DNS or Kubernetes), for testing */
+  simulated?: boolean;
+  /** URL-friendly identifier (lowercase, hyphens); derived from the name when omitted */
+  slug?: string;
+}
+
+export interface CreateWebhookInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Batch the events into one delivery an hour or a day; immediate when omitted */
+  digest?: "immediate" | "hourly" | "daily";
+  /** Events to deliver; every event when omitted */
+  events?: (("provision_complete" | "suspend" | "reactivate" | "delete" | "deletion_complete" | "plan_suggested" | "dunning_warning" | "dunning_final_notice")[]) | null;
+  /** Key of the HMAC-SHA256 signature sent in X-Tenantiq-Signature */
+  secret: string;
+  /** Endpoint receiving the deliveries (http or https) */
+  url: string;
+}
+
+export interface CreatedAPIKeyResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Creation timestamp (ISO 8601) */
+  created_at: string;
+  /** Who created the key */
+  created_by: string;
+  /** When the key stops working (ISO 8601); absent when it does not expire */
+  expires_at?: string;
+  /** API key ID */
+  id: string;
+  /** The key; it is not stored and cannot be retrieved again */
+  key: string;
+  /** When the key was last verified, to the minute (ISO 8601); absent when it never was */
+  last_used_at?: string;
+  /** What the key is for */
+  name: string;
+  /** Start of the key, to tell keys apart */
+  prefix: string;
+  /** When the key was revoked (ISO 8601) */
+  revoked_at?: string;
+  /** What the key may do */
+  scopes: string[] | null;
+  /** Tenant ID */
+  tenant_id: string;
+}
+
+export interface CreatedAdminKeyResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Creation timestamp (ISO 8601) */
+  created_at: string;
+  /** Who created the key */
+  created_by: string;
+  /** Admin key ID */
+  id: string;
+  /** The key; it is not stored and cannot be retrieved again */
+  key: string;
+  /** When the key was last used, to the minute (ISO 8601); absent when it never was */
+  last_used_at?: string;
+  /** Who holds the key; the actor of the changes made with it */
+  name: string;
+  /** Start of the key, to tell keys apart */
+  prefix: string;
+  /** When the key was revoked (ISO 8601) */
+  revoked_at?: string;
+  /** What the holder may do */
+  role: "viewer" | "operator" | "admin";
+}
+
+export interface DunningResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Unpaid invoice at the billing provider */
+  invoice_id: string;
+  /** When the flow moves on (ISO 8601); absent once suspended */
+  next_step_at?: string;
+  /** Current step of the flow */
+  stage: "warned" | "grace" | "suspended";
+  /** When the payment failed (ISO 8601) */
+  started_at: string;
+  /** Whether the flow suspended the tenant (lifted on payment) */
+  suspended_tenant: boolean;
+  /** Tenant in dunning */
+  tenant_id: string;
+  /** Last update timestamp (ISO 8601) */
+  updated_at: string;
+}
+
+export interface EgressResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Integrations whose requests take this route */
+  adapters: string[] | null;
+  /** Hosts, domains and networks reached without the proxy */
+  no_proxy: string[] | null;
+  /** Proxy of outbound requests, without credentials; absent when they go out directly */
+  proxy_url?: string;
+  /** Addresses outbound requests come from, to allowlist; as declared in EGRESS_SOURCE_IPS */
+  source_ips: string[] | null;
+}
+
+export interface EncryptionKeyResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** When the tenant first set its key (ISO 8601) */
+  created_at: string;
+  /** The key at the KMS, such as an AWS KMS key ARN */
+  key_ref: string;
+  /** When the key was found or reported revoked (ISO 8601) */
+  revoked_at?: string;
+  /** When the key was last changed or set again (ISO 8601) */
+  rotated_at?: string;
+  /** revoked while access to the tenant's data is suspended */
+  status: "active" | "revoked";
+  /** Tenant ID */
+  tenant_id: string;
+}
+
+export interface ErrorDetail {
+  /** Where the error occurred, e.g. 'body.items[3].tags' or 'path.thing-id' */
+  location?: string;
+  /** Error message text */
+  message?: string;
+  /** The value at the given location */
+  value?: unknown;
+}
+
+export interface ErrorModel {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** A human-readable explanation specific to this occurrence of the problem. */
+  detail?: string;
+  /** Optional list of individual error details */
+  errors?: ErrorDetail[] | null;
+  /** A URI reference that identifies the specific occurrence of the problem. */
+  instance?: string;
+  /** HTTP status code */
+  status?: number;
+  /** A short, human-readable summary of the problem type. This value should not change between occurrences of the error. */
+  title?: string;
+  /** A URI reference to human-readable documentation for the error. */
+  type?: string;
+}
+
+export interface EventSchemaResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** All event types */
+  events: EventType[] | null;
+}
+
+export interface EventTransition {
+  /** Status before the event */
+  from: string;
+  /** Status after the event */
+  to: string;
+}
+
+export interface EventType {
+  /** Lifecycle event name */
+  name: string;
+  /** JSON Schema of the event payload */
+  schema: Record<string, unknown>;
+  /** State changes the event causes */
+  transitions: EventTransition[] | null;
+  /** Value of the payload's type field for this event */
+  type: string;
+}
+
+export interface GetHistoryOutputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Transitions, oldest first */
+  items: StatusChangeResponse[] | null;
+}
+
+export interface GrowthPeriodResponse {
+  /** Active tenants at the end of the period */
+  active: number;
+  /** Tenants deleted */
+  churned: number;
+  /** End of the period (ISO 8601, exclusive) */
+  end: string;
+  /** Change in the number of active tenants */
+  net: number;
+  /** Tenants created */
+  new: number;
+  /** Start of the period (ISO 8601, inclusive) */
+  start: string;
+  /** Suspensions */
+  suspended: number;
+}
+
+export interface GrowthReportResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Period size */
+  period: string;
+  /** Periods, oldest first */
+  periods: GrowthPeriodResponse[] | null;
+}
+
+export interface IPAllowlistResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Networks in CIDR notation, sorted; empty when the keys may be used from anywhere */
+  networks: string[] | null;
+  /** Tenant ID */
+  tenant_id: string;
+}
+
+export interface ImportItem {
+  /** Blueprint giving the tenant its plan, metadata, feature flags and template variables */
+  blueprint?: string;
+  /** Original creation time (RFC 3339), not in the future; defaults to now */
+  created_at?: string;
+  /** Original tenant ID (letters, digits, '_' and '-'); may lack the tenant ID prefix */
+  id: string;
+  /** Integrator-defined key-value data */
+  metadata?: Record<string, string>;
+  /** Display name */
+  name: string;
+  /** Subscription plan; the blueprint's plan, or free, when omitted */
+  plan?: string;
+  /** Region to host the tenant in; its plan must be offered there */
+  region?: string;
+  /** URL-friendly identifier (lowercase, hyphens); derived from the name when omitted */
+  slug?: string;
+  /** Original last update time (RFC 3339), not before created_at; defaults to created_at */
+  updated_at?: string;
+}
+
+export interface ImportTenantsInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Tenants to import */
+  tenants: ImportItem[] | null;
+}
+
+export interface InviteMemberInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Email address of the person to invite */
+  email: string;
+  /** What the member may do in the tenant */
+  role?: "owner" | "admin" | "member";
+}
+
+export interface ListChangesOutputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Changes, oldest first */
+  changes: TenantChangeResponse[] | null;
+  /** Whether more changes are available now */
+  has_more: boolean;
+  /** Cursor to pass as since for the following changes */
+  next_cursor: string;
+}
+
+export interface LivenessOutputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Always ok while the process serves requests */
+  status: string;
+}
+
+export interface MaintenanceWindowBody {
+  /** Length of the window as a Go duration (e.g. 4h, 90m), at most 168h */
+  duration: string;
+  /** Start time in UTC (HH:MM) */
+  start: string;
+  /** Day the window starts */
+  weekday: "sunday" | "monday" | "tuesday" | "wednesday" | "thursday" | "friday" | "saturday";
+}
+
+export interface MaintenanceWindowsResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Windows during which automation may suspend or delete the tenant; empty means any time */
+  windows: MaintenanceWindowBody[] | null;
+}
+
+export interface MemberListOutputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Members, oldest invitation first */
+  items: MemberResponse[] | null;
+}
+
+export interface MemberResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** When the invitation was accepted (ISO 8601) */
+  accepted_at?: string;
+  /** Invitation timestamp (ISO 8601) */
+  created_at: string;
+  /** Email address, lowercase */
+  email: string;
+  /** Member ID */
+  id: string;
+  /** Who invited the member */
+  invited_by: string;
+  /** What the member may do in the tenant */
+  role: "owner" | "admin" | "member";
+  /** invited until the member accepts the invitation, then active */
+  status: "invited" | "active";
+  /** Tenant ID */
+  tenant_id: string;
+  /** Last update timestamp (ISO 8601) */
+  updated_at: string;
+}
+
+export interface OperationListResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Operations in this page */
+  items: OperationResponse[] | null;
+  /** Max results requested */
+  limit: number;
+  /** Pagination offset requested */
+  offset: number;
+  /** Total number of operations matching the filter */
+  total: number;
+}
+
+export interface OperationResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Creation timestamp (ISO 8601) */
+  created_at: string;
+  /** Why the operation failed */
+  error?: string;
+  /** Unique identifier */
+  id: string;
+  /** Work tracked by the operation */
+  kind: "provision" | "deletion";
+  /** Resource produced by the operation, once it succeeded */
+  result_url?: string;
+  /** Progress of the operation */
+  status: "pending" | "succeeded" | "failed";
+  /** Tenant the operation works on */
+  tenant_id?: string;
+  /** Last update timestamp (ISO 8601) */
+  updated_at: string;
+}
+
+export interface PlanListOutputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Plans, by name */
+  items: PlanResponse[] | null;
+}
+
+export interface PlanRateLimit {
+  /** Requests allowed at once (token bucket size); defaults to requests_per_second */
+  burst?: number;
+  /** Sustained request rate (token bucket refill rate) */
+  requests_per_second: number;
+}
+
+export interface PlanResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Creation timestamp (ISO 8601) */
+  created_at: string;
+  /** ISO 4217 currency code */
+  currency: string;
+  /** Features included in the plan */
+  features: string[] | null;
+  /** Usage allowed per metric; metrics without a limit are unlimited */
+  limits?: Record<string, number>;
+  /** Unique name, as set on tenants */
+  name: string;
+  /** Monthly price in the smallest unit of the currency (e.g. cents) */
+  price: number;
+  /** Request rate of the plan's tenants, unless overridden per tenant; absent when they are not limited */
+  rate_limit?: PlanRateLimit;
+  /** Regions the plan is offered in; empty when it is offered everywhere */
+  regions: string[] | null;
+  /** Last update timestamp (ISO 8601) */
+  updated_at: string;
+}
+
+export interface PlanTerms {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** ISO 4217 currency code */
+  currency?: string;
+  /** Features included in the plan */
+  features?: string[] | null;
+  /** Usage allowed per metric; metrics without a limit are unlimited */
+  limits?: Record<string, number>;
+  /** Monthly price in the smallest unit of the currency (e.g. cents) */
+  price?: number;
+  /** Request rate of the plan's tenants, unless overridden per tenant (e.g. 1 per second with a burst of 60 for 60 requests a minute); they are not limited when omitted */
+  rate_limit?: PlanRateLimit;
+  /** Regions the plan is offered in (e.g. eu-west); it is offered everywhere when omitted */
+  regions?: string[] | null;
+}
+
+export interface PriorityLoadResponse {
+  /** Jobs ready to run */
+  available: number;
+  /** Priority class */
+  priority: "high" | "normal" | "low";
+  /** Jobs being worked */
+  running: number;
+}
+
+export interface PublicStatusResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Display name */
+  name: string;
+  /** Lifecycle state */
+  status: string;
+  /** Last update timestamp (ISO 8601) */
+  updated_at: string;
+}
+
+export interface PutSecretInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** The secret */
+  value: string;
+}
+
+export interface QueueHealth {
+  /** Jobs ready to run but not yet picked up */
+  available: number;
+  /** Backlog threshold (0 = unchecked) */
+  max_available?: number;
+  /** Age threshold (0 = unchecked) */
+  max_oldest_age_seconds?: number;
+  /** How long the oldest available job has waited */
+  oldest_age_seconds: number;
+  /** Whether a threshold is exceeded */
+  saturated: boolean;
+}
+
+export interface QueueLoadResponse {
+  /** Jobs ready to run */
+  available: number;
+  /** Recent processing rate */
+  completed_per_minute: number;
+  /** Available and running jobs per priority, from the highest */
+  priorities?: PriorityLoadResponse[] | null;
+  /** Queue name */
+  queue: string;
+  /** Jobs being worked */
+  running: number;
+}
+
+export interface RateLimitBody {
+  /** Requests allowed at once (token bucket size) */
+  burst: number;
+  /** Sustained request rate (token bucket refill rate) */
+  requests_per_second: number;
+  /** Whether the limit comes from the tenant's plan or a per-tenant override */
+  source: "plan" | "override";
+}
+
+export interface RateLimitsResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Rate limit of every rate-limited active tenant, ordered by slug; tenants not listed are not limited */
+  items: TenantRateLimitResponse[] | null;
+}
+
+export interface ReadOnlyResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Who made that change */
+  actor?: string;
+  /** Whether changes are rejected with 503 */
+  enabled: boolean;
+  /** Why changes are rejected */
+  reason?: string;
+  /** When the mode or its reason last changed (ISO 8601) */
+  since?: string;
+}
+
+export interface ReadinessResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Why the checks could not run */
+  error?: string;
+  /** Job queue backlog */
+  queue?: QueueHealth;
+  /** Overall readiness */
+  status: "ok" | "degraded" | "unavailable";
+}
+
+export interface ReconciliationResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** When the check ran (ISO 8601) */
+  checked_at: string;
+  /** Disagreements, ordered by tenant ID */
+  mismatches: BillingMismatchResponse[] | null;
+  /** Subscriptions read from the billing provider */
+  subscriptions: number;
+  /** Tenants checked */
+  tenants: number;
+}
+
+export interface ReportUsageInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Metric values (e.g. seats, storage_gb); replaces the previous value of each metric */
+  metrics: Record<string, number>;
+}
+
+export interface ResellerResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Creation timestamp (ISO 8601) */
+  created_at: string;
+  /** Unique identifier */
+  id: string;
+  /** Display name */
+  name: string;
+  /** Max tenants (not yet deleted) the reseller may manage */
+  tenant_quota: number;
+}
+
+export interface ResellerUsageResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Tenants counting against the quota, by status */
+  by_status: Record<string, number>;
+  /** Max tenants (not yet deleted) the reseller may manage */
+  quota: number;
+  /** Tenants counting against the quota */
+  tenants: number;
+}
+
+export interface ResolvedTenantResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Creation timestamp (ISO 8601) */
+  created_at: string;
+  /** References in external systems (ArgoCD app, billing customer, ...) keyed by system */
+  external_refs?: Record<string, string>;
+  /** Provisioning Git branch */
+  git_branch?: string;
+  /** Unique identifier */
+  id: string;
+  /** Integrator-defined key-value data (CRM IDs, internal references, ...) */
+  metadata?: Record<string, string>;
+  /** Display name */
+  name: string;
+  /** Subscription plan */
+  plan: string;
+  /** Provisioning pull request */
+  pr_url?: string;
+  /** Request rate allowed to the tenant; absent when it is not rate limited */
+  rate_limit?: RateLimitBody;
+  /** Region the tenant is hosted in, if set */
+  region?: string;
+  /** Reseller managing the tenant, if any */
+  reseller_id?: string;
+  /** Whether the tenant is simulated, provisioned by fakes only */
+  simulated?: boolean;
+  /** URL-friendly identifier */
+  slug: string;
+  /** Lifecycle state */
+  status: string;
+  /** Plan that better fits the tenant's reported usage, if any */
+  suggested_plan?: string;
+  /** Tags grouping the tenant (campaign, cohort, support tier, ...), sorted */
+  tags?: string[] | null;
+  /** When the tenant's trial expires (ISO 8601), if on trial */
+  trial_ends_at?: string;
+  /** Last update timestamp (ISO 8601) */
+  updated_at: string;
+  /** Number of stored changes; increases with every update */
+  version: number;
+}
+
+export interface ScalingResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Available plus running jobs across queues */
+  backlog: number;
+  /** Jobs completed per minute across queues */
+  processing_rate_per_minute: number;
+  /** Load per queue */
+  queues: QueueLoadResponse[] | null;
+  /** Workers needed for the current backlog */
+  suggested_workers: number;
+}
+
+export interface SecretListOutputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Secrets by name, without their values */
+  items: SecretResponse[] | null;
+}
+
+export interface SecretResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Creation timestamp (ISO 8601) */
+  created_at: string;
+  /** The key the secret is sealed with */
+  key_ref: string;
+  /** Secret name */
+  name: string;
+  /** When the secret was last set (ISO 8601) */
+  updated_at: string;
+  /** Who last set the secret */
+  updated_by: string;
+  /** The secret */
+  value?: string;
+}
+
+export interface SetEncryptionKeyInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** The key at the KMS, such as an AWS KMS key ARN */
+  key_ref: string;
+}
+
+export interface SetIPAllowlistInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Networks in CIDR notation, such as 203.0.113.0/24, or single addresses; empty to allow every address */
+  networks: string[] | null;
+}
+
+export interface SetRateLimitInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Requests allowed at once; defaults to requests_per_second */
+  burst?: number;
+  /** Sustained request rate */
+  requests_per_second: number;
+}
+
+export interface SetReadOnlyInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Reject changes (true) or accept them again (false) */
+  enabled: boolean;
+  /** Why changes are rejected, returned to the rejected callers */
+  reason?: string;
+}
+
+export interface SignedURLResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** When the link stops working (ISO 8601) */
+  expires_at: string;
+  /** Whether the link works only once */
+  single_use: boolean;
+  /** Signed path and query; prefix it with the service's public address */
+  url: string;
+}
+
+export interface StatusChangeResponse {
+  /** Who triggered it */
+  actor: string;
+  /** When it happened (ISO 8601) */
+  at: string;
+  /** Lifecycle event that caused it */
+  event: string;
+  /** Status before the transition */
+  from: string;
+  /** Status after the transition */
+  to: string;
+}
+
+export interface StatusCountsResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Tenants per status that has any */
+  by_status: Record<string, number>;
+  /** Tenants in any status, deleted ones included */
+  total: number;
+}
+
+export interface StatusWidgetResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Whether a maintenance window is open; absent when the tenant declared none */
+  in_maintenance_window?: boolean;
+  /** Display name */
+  name: string;
+  /** When the next maintenance window opens (ISO 8601); absent while one is open or when none are declared */
+  next_maintenance_at?: string;
+  /** Whether the tenant is active and serving */
+  operational: boolean;
+  /** Subscription plan */
+  plan: string;
+  /** Where the tenant is hosted; absent when unspecified */
+  region?: string;
+  /** Lifecycle state */
+  status: string;
+  /** Tenant ID */
+  tenant_id: string;
+  /** Last update timestamp (ISO 8601) */
+  updated_at: string;
+}
+
+export interface TenantChangeResponse {
+  /** When the change was made (ISO 8601) */
+  at: string;
+  /** Position of the change in the feed */
+  cursor: string;
+  /** Tenant after the change; absent when deleted */
+  tenant?: TenantResponse;
+  /** Tenant ID */
+  tenant_id: string;
+  /** created, updated (transitions and soft deletion included), or deleted once the tenant is purged */
+  type: "created" | "updated" | "deleted";
+  /** Tenant version after the change; its last version when deleted. Apply a change only when it is newer than the copy */
+  version: number;
+}
+
+export interface TenantListResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Tenants in this page */
+  items: TenantResponse[] | null;
+  /** Max results requested */
+  limit: number;
+  /** Pagination offset requested */
+  offset: number;
+  /** Total number of tenants matching the filter */
+  total: number;
+}
+
+export interface TenantOperationResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Creation timestamp (ISO 8601) */
+  created_at: string;
+  /** References in external systems (ArgoCD app, billing customer, ...) keyed by system */
+  external_refs?: Record<string, string>;
+  /** Provisioning Git branch */
+  git_branch?: string;
+  /** Unique identifier */
+  id: string;
+  /** Integrator-defined key-value data (CRM IDs, internal references, ...) */
+  metadata?: Record<string, string>;
+  /** Display name */
+  name: string;
+  /** Operation tracking the remaining work (asynchronous mode only) */
+  operation_id?: string;
+  /** Subscription plan */
+  plan: string;
+  /** Provisioning pull request */
+  pr_url?: string;
+  /** Region the tenant is hosted in, if set */
+  region?: string;
+  /** Reseller managing the tenant, if any */
+  reseller_id?: string;
+  /** Whether the tenant is simulated, provisioned by fakes only */
+  simulated?: boolean;
+  /** URL-friendly identifier */
+  slug: string;
+  /** Lifecycle state */
+  status: string;
+  /** Plan that better fits the tenant's reported usage, if any */
+  suggested_plan?: string;
+  /** Tags grouping the tenant (campaign, cohort, support tier, ...), sorted */
+  tags?: string[] | null;
+  /** When the tenant's trial expires (ISO 8601), if on trial */
+  trial_ends_at?: string;
+  /** Last update timestamp (ISO 8601) */
+  updated_at: string;
+  /** Number of stored changes; increases with every update */
+  version: number;
+}
+
+export interface TenantRateLimitResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Requests allowed at once (token bucket size) */
+  burst: number;
+  /** Tenant plan */
+  plan: string;
+  /** Sustained request rate (token bucket refill rate) */
+  requests_per_second: number;
+  /** Tenant slug */
+  slug: string;
+  /** Whether the limit comes from the tenant's plan or a per-tenant override */
+  source: "plan" | "override";
+  /** Tenant ID */
+  tenant_id: string;
+}
+
+export interface TenantResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Creation timestamp (ISO 8601) */
+  created_at: string;
+  /** References in external systems (ArgoCD app, billing customer, ...) keyed by system */
+  external_refs?: Record<string, string>;
+  /** Provisioning Git branch */
+  git_branch?: string;
+  /** Unique identifier */
+  id: string;
+  /** Integrator-defined key-value data (CRM IDs, internal references, ...) */
+  metadata?: Record<string, string>;
+  /** Display name */
+  name: string;
+  /** Subscription plan */
+  plan: string;
+  /** Provisioning pull request */
+  pr_url?: string;
+  /** Region the tenant is hosted in, if set */
+  region?: string;
+  /** Reseller managing the tenant, if any */
+  reseller_id?: string;
+  /** Whether the tenant is simulated, provisioned by fakes only */
+  simulated?: boolean;
+  /** URL-friendly identifier */
+  slug: string;
+  /** Lifecycle state */
+  status: string;
+  /** Plan that better fits the tenant's reported usage, if any */
+  suggested_plan?: string;
+  /** Tags grouping the tenant (campaign, cohort, support tier, ...), sorted */
+  tags?: string[] | null;
+  /** When the tenant's trial expires (ISO 8601), if on trial */
+  trial_ends_at?: string;
+  /** Last update timestamp (ISO 8601) */
+  updated_at: string;
+  /** Number of stored changes; increases with every update */
+  version: number;
+}
+
+export interface TransitionInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Lifecycle event to trigger */
+  event: "provision_complete" | "suspend" | "reactivate" | "delete" | "deletion_complete";
+}
+
+export interface UpdateTenantInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** References to merge; an empty value removes the key */
+  external_refs?: Record<string, string>;
+  /** Provisioning Git branch */
+  git_branch?: string;
+  /** Metadata to merge; an empty value removes the key */
+  metadata?: Record<string, string>;
+  /** Subscription plan to move the tenant to */
+  plan?: string;
+  /** Provisioning pull request URL */
+  pr_url?: string;
+  /** When the trial expires (RFC 3339); an empty value takes the tenant off trial */
+  trial_ends_at?: string;
+}
+
+export interface UpdateWebhookInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Batch the events into one delivery an hour or a day; immediate when omitted */
+  digest?: "immediate" | "hourly" | "daily";
+  /** Events to deliver; every event when omitted */
+  events?: (("provision_complete" | "suspend" | "reactivate" | "delete" | "deletion_complete" | "plan_suggested" | "dunning_warning" | "dunning_final_notice")[]) | null;
+  /** New signing key; the current one is kept when omitted */
+  secret?: string;
+  /** Endpoint receiving the deliveries (http or https) */
+  url: string;
+}
+
+export interface UsageResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Latest value of each reported metric */
+  metrics: Record<string, number>;
+}
+
+export interface VerifyAPIKeyInputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** The key presented to the application */
+  key: string;
+}
+
+export interface VerifyAPIKeyOutputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** API key ID */
+  key_id: string;
+  /** What the key may do */
+  scopes: string[] | null;
+  /** Tenant the key belongs to */
+  tenant_id: string;
+  /** Current status of the tenant */
+  tenant_status: string;
+}
+
+export interface WebhookCircuitResponse {
+  /** Failed deliveries since the last successful one */
+  consecutive_failures: number;
+  /** Why the last failed delivery failed */
+  last_error?: string;
+  /** When the last delivery failed (ISO 8601) */
+  last_failure_at?: string;
+  /** When the circuit opened (ISO 8601) */
+  opened_at?: string;
+  /** open after repeated failures: deliveries are held until the subscription is resumed */
+  state: "closed" | "open";
+}
+
+export interface WebhookListOutputBody {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Subscriptions, oldest first */
+  items: WebhookResponse[] | null;
+}
+
+export interface WebhookResponse {
+  /** A URL to the JSON Schema for this object. */
+  readonly $schema?: string;
+  /** Health of the endpoint */
+  circuit: WebhookCircuitResponse;
+  /** Creation timestamp (ISO 8601) */
+  created_at: string;
+  /** Whether events are delivered on their own or batched into an hourly or daily digest */
+  digest: "immediate" | "hourly" | "daily";
+  /** Events delivered; empty means every event */
+  events: string[] | null;
+  /** Unique identifier */
+  id: string;
+  /** Last update timestamp (ISO 8601) */
+  updated_at: string;
+  /** Endpoint receiving the deliveries */
+  url: string;
+}
+
+/** Parameters of createAdminKey. */
+export interface CreateAdminKeyRequest {
+  body: CreateAdminKeyInputBody;
+}
+
+/** Parameters of revokeAdminKey. */
+export interface RevokeAdminKeyRequest {
+  /** Admin key ID */
+  id: string;
+}
+
+/** Parameters of verifyApiKey. */
+export interface VerifyApiKeyRequest {
+  body: VerifyAPIKeyInputBody;
+}
+
+/** Parameters of receiveBillingWebhook. */
+export interface ReceiveBillingWebhookRequest {
+  /** sha256= followed by the hex HMAC-SHA256 of the body */
+  xBillingSignature: string;
+  body: BillingWebhook | Blob;
+}
+
+/** Parameters of createBlueprint. */
+export interface CreateBlueprintRequest {
+  body: CreateBlueprintInputBody;
+}
+
+/** Parameters of getBlueprint. */
+export interface GetBlueprintRequest {
+  /** Blueprint name */
+  name: string;
+}
+
+/** Parameters of updateBlueprint. */
+export interface UpdateBlueprintRequest {
+  /** Blueprint name */
+  name: string;
+  body: BlueprintConfig;
+}
+
+/** Parameters of deleteBlueprint. */
+export interface DeleteBlueprintRequest {
+  /** Blueprint name */
+  name: string;
+}
+
+/** Parameters of listOperations. */
+export interface ListOperationsRequest {
+  /** Only operations on this tenant */
+  tenant_id?: string;
+  /** Filter by kind (comma-separated, matches any) */
+  kind?: ("provision" | "deletion")[];
+  /** Filter by status (comma-separated, matches any) */
+  status?: ("pending" | "succeeded" | "failed")[];
+  /** Max results */
+  limit?: number;
+  /** Pagination offset */
+  offset?: number;
+}
+
+/** Parameters of getOperation. */
+export interface GetOperationRequest {
+  /** Operation ID */
+  id: string;
+}
+
+/** Parameters of listPlans. */
+export interface ListPlansRequest {
+  /** Only the plans available to tenants in this region: those offered there and those offered everywhere */
+  region?: string;
+}
+
+/** Parameters of createPlan. */
+export interface CreatePlanRequest {
+  body: CreatePlanInputBody;
+}
+
+/** Parameters of getPlan. */
+export interface GetPlanRequest {
+  /** Plan name */
+  name: string;
+}
+
+/** Parameters of updatePlan. */
+export interface UpdatePlanRequest {
+  /** Plan name */
+  name: string;
+  body: PlanTerms;
+}
+
+/** Parameters of deletePlan. */
+export interface DeletePlanRequest {
+  /** Plan name */
+  name: string;
+}
+
+/** Parameters of listRateLimits. */
+export interface ListRateLimitsRequest {
+  /** ETag of the snapshot the gateway holds; 304 is returned when it is still current */
+  ifNoneMatch?: string;
+}
+
+/** Parameters of getGrowthReport. */
+export interface GetGrowthReportRequest {
+  /** Period size (UTC; weeks start on Monday) */
+  period?: "day" | "week" | "month";
+  /** Start of the report (RFC 3339); 12 periods before to when omitted */
+  from?: string;
+  /** End of the report (RFC 3339); now when omitted */
+  to?: string;
+}
+
+/** Parameters of exportGrowthReport. */
+export interface ExportGrowthReportRequest {
+  /** Period size (UTC; weeks start on Monday) */
+  period?: "day" | "week" | "month";
+  /** Start of the report (RFC 3339); 12 periods before to when omitted */
+  from?: string;
+  /** End of the report (RFC 3339); now when omitted */
+  to?: string;
+}
+
+/** Parameters of createReseller. */
+export interface CreateResellerRequest {
+  body: CreateResellerInputBody;
+}
+
+/** Parameters of getReseller. */
+export interface GetResellerRequest {
+  /** Reseller ID */
+  reseller_id: string;
+}
+
+/** Parameters of listResellerTenants. */
+export interface ListResellerTenantsRequest {
+  /** Reseller ID */
+  reseller_id: string;
+  /** Filter by status (comma-separated, matches any) */
+  status?: ("creating" | "active" | "suspended" | "deleting" | "deleted")[];
+  /** Max results */
+  limit?: number;
+  /** Pagination offset */
+  offset?: number;
+}
+
+/** Parameters of createResellerTenant. */
+export interface CreateResellerTenantRequest {
+  /** Reseller ID */
+  reseller_id: string;
+  body: CreateResellerTenantInputBody;
+}
+
+/** Parameters of getResellerTenant. */
+export interface GetResellerTenantRequest {
+  /** Reseller ID */
+  reseller_id: string;
+  /** Tenant ID */
+  id: string;
+}
+
+/** Parameters of suspendResellerTenant. */
+export interface SuspendResellerTenantRequest {
+  /** Reseller ID */
+  reseller_id: string;
+  /** Tenant ID */
+  id: string;
+}
+
+/** Parameters of getResellerUsage. */
+export interface GetResellerUsageRequest {
+  /** Reseller ID */
+  reseller_id: string;
+}
+
+/** Parameters of createSignedUrl. */
+export interface CreateSignedUrlRequest {
+  body: CreateSignedURLInputBody;
+}
+
+/** Parameters of setReadOnly. */
+export interface SetReadOnlyRequest {
+  body: SetReadOnlyInputBody;
+}
+
+/** Parameters of listTenants. */
+export interface ListTenantsRequest {
+  /** Filter by status (comma-separated, matches any) */
+  status?: ("creating" | "active" | "suspended" | "deleting" | "deleted")[];
+  /** Filter by plan (comma-separated, matches any) */
+  plan?: string[];
+  /** Only tenants created at or after this time (RFC 3339) */
+  created_after?: string;
+  /** Only tenants created before this time (RFC 3339) */
+  created_before?: string;
+  /** Only simulated (true) or real (false) tenants */
+  simulated?: "true" | "false";
+  /** Only tenants with this tag; repeat to require several (?tag=a&tag=b) */
+  tag?: string[];
+  /** Max results */
+  limit?: number;
+  /** Pagination offset */
+  offset?: number;
+}
+
+/** Parameters of createTenant. */
+export interface CreateTenantRequest {
+  /** Send respond-async to queue provisioning and get 202 with an operation to poll */
+  prefer?: string;
+  body: CreateTenantInputBody;
+}
+
+/** Parameters of listTenantChanges. */
+export interface ListTenantChangesRequest {
+  /** Cursor to read the changes after: next_cursor of the previous page, or now for the latest change. Omitted, the feed is read from its oldest stored change */
+  since?: string;
+  /** Max changes */
+  limit?: number;
+}
+
+/** Parameters of getTenantBySlug. */
+export interface GetTenantBySlugRequest {
+  /** Tenant slug */
+  slug: string;
+}
+
+/** Parameters of getTenant. */
+export interface GetTenantRequest {
+  /** Tenant ID */
+  id: string;
+  /** Return the tenant as it was at this time (RFC 3339), reconstructed from the audit trail */
+  as_of?: string;
+}
+
+/** Parameters of updateTenant. */
+export interface UpdateTenantRequest {
+  /** Tenant ID */
+  id: string;
+  body: UpdateTenantInputBody;
+}
+
+/** Parameters of deleteTenant. */
+export interface DeleteTenantRequest {
+  /** Tenant ID */
+  id: string;
+  /** Send respond-async to queue the completion of the deletion and get an operation to poll */
+  prefer?: string;
+}
+
+/** Parameters of listTenantApiKeys. */
+export interface ListTenantApiKeysRequest {
+  /** Tenant ID */
+  id: string;
+}
+
+/** Parameters of createTenantApiKey. */
+export interface CreateTenantApiKeyRequest {
+  /** Tenant ID */
+  id: string;
+  body: CreateAPIKeyInputBody;
+}
+
+/** Parameters of revokeTenantApiKey. */
+export interface RevokeTenantApiKeyRequest {
+  /** Tenant ID */
+  id: string;
+  /** API key ID */
+  key_id: string;
+}
+
+/** Parameters of listTenantCertificates. */
+export interface ListTenantCertificatesRequest {
+  /** Tenant ID */
+  id: string;
+}
+
+/** Parameters of requestTenantCertificate. */
+export interface RequestTenantCertificateRequest {
+  /** Tenant ID */
+  id: string;
+  /** Host name, such as app.customer.com */
+  domain: string;
+}
+
+/** Parameters of removeTenantCertificate. */
+export interface RemoveTenantCertificateRequest {
+  /** Tenant ID */
+  id: string;
+  /** Host name, such as app.customer.com */
+  domain: string;
+}
+
+/** Parameters of getTenantDunning. */
+export interface GetTenantDunningRequest {
+  /** Tenant ID */
+  id: string;
+}
+
+/** Parameters of getTenantEncryptionKey. */
+export interface GetTenantEncryptionKeyRequest {
+  /** Tenant ID */
+  id: string;
+}
+
+/** Parameters of setTenantEncryptionKey. */
+export interface SetTenantEncryptionKeyRequest {
+  /** Tenant ID */
+  id: string;
+  body: SetEncryptionKeyInputBody;
+}
+
+/** Parameters of revokeTenantEncryptionKey. */
+export interface RevokeTenantEncryptionKeyRequest {
+  /** Tenant ID */
+  id: string;
+}
+
+/** Parameters of transitionTenant. */
+export interface TransitionTenantRequest {
+  /** Tenant ID */
+  id: string;
+  body: TransitionInputBody;
+}
+
+/** Parameters of getTenantHistory. */
+export interface GetTenantHistoryRequest {
+  /** Tenant ID */
+  id: string;
+}
+
+/** Parameters of getTenantIpAllowlist. */
+export interface GetTenantIpAllowlistRequest {
+  /** Tenant ID */
+  id: string;
+}
+
+/** Parameters of setTenantIpAllowlist. */
+export interface SetTenantIpAllowlistRequest {
+  /** Tenant ID */
+  id: string;
+  body: SetIPAllowlistInputBody;
+}
+
+/** Parameters of getTenantMaintenanceWindows. */
+export interface GetTenantMaintenanceWindowsRequest {
+  /** Tenant ID */
+  id: string;
+}
+
+/** Parameters of setTenantMaintenanceWindows. */
+export interface SetTenantMaintenanceWindowsRequest {
+  /** Tenant ID */
+  id: string;
+  body: MaintenanceWindowsResponse;
+}
+
+/** Parameters of listTenantMembers. */
+export interface ListTenantMembersRequest {
+  /** Tenant ID */
+  id: string;
+}
+
+/** Parameters of inviteTenantMember. */
+export interface InviteTenantMemberRequest {
+  /** Tenant ID */
+  id: string;
+  body: InviteMemberInputBody;
+}
+
+/** Parameters of removeTenantMember. */
+export interface RemoveTenantMemberRequest {
+  /** Tenant ID */
+  id: string;
+  /** Member ID */
+  member_id: string;
+}
+
+/** Parameters of acceptTenantMember. */
+export interface AcceptTenantMemberRequest {
+  /** Tenant ID */
+  id: string;
+  /** Member ID */
+  member_id: string;
+}
+
+/** Parameters of checkTenantQuota. */
+export interface CheckTenantQuotaRequest {
+  /** Tenant ID */
+  id: string;
+  body: CheckQuotaInputBody;
+}
+
+/** Parameters of setTenantRateLimit. */
+export interface SetTenantRateLimitRequest {
+  /** Tenant ID */
+  id: string;
+  body: SetRateLimitInputBody;
+}
+
+/** Parameters of clearTenantRateLimit. */
+export interface ClearTenantRateLimitRequest {
+  /** Tenant ID */
+  id: string;
+}
+
+/** Parameters of listTenantSecrets. */
+export interface ListTenantSecretsRequest {
+  /** Tenant ID */
+  id: string;
+}
+
+/** Parameters of getTenantSecret. */
+export interface GetTenantSecretRequest {
+  /** Tenant ID */
+  id: string;
+  /** Secret name */
+  name: string;
+}
+
+/** Parameters of putTenantSecret. */
+export interface PutTenantSecretRequest {
+  /** Tenant ID */
+  id: string;
+  /** Secret name */
+  name: string;
+  body: PutSecretInputBody;
+}
+
+/** Parameters of deleteTenantSecret. */
+export interface DeleteTenantSecretRequest {
+  /** Tenant ID */
+  id: string;
+  /** Secret name */
+  name: string;
+}
+
+/** Parameters of addTenantTags. */
+export interface AddTenantTagsRequest {
+  /** Tenant ID */
+  id: string;
+  body: AddTagsInputBody;
+}
+
+/** Parameters of removeTenantTag. */
+export interface RemoveTenantTagRequest {
+  /** Tenant ID */
+  id: string;
+  /** Tag to remove; removing a tag the tenant does not have succeeds */
+  tag: string;
+}
+
+/** Parameters of getTenantUsage. */
+export interface GetTenantUsageRequest {
+  /** Tenant ID */
+  id: string;
+}
+
+/** Parameters of reportTenantUsage. */
+export interface ReportTenantUsageRequest {
+  /** Tenant ID */
+  id: string;
+  body: ReportUsageInputBody;
+}
+
+/** Parameters of applyTenantSpec. */
+export interface ApplyTenantSpecRequest {
+  /** Tenant slug */
+  slug: string;
+  body: ApplySpecInputBody;
+}
+
+/** Parameters of batchCreateTenants. */
+export interface BatchCreateTenantsRequest {
+  body: BatchCreateTenantsInputBody;
+}
+
+/** Parameters of batchTransitionTenants. */
+export interface BatchTransitionTenantsRequest {
+  body: BatchTransitionTenantsInputBody;
+}
+
+/** Parameters of importTenants. */
+export interface ImportTenantsRequest {
+  /** Bearer followed by the import key */
+  authorization?: string;
+  body: ImportTenantsInputBody;
+}
+
+/** Parameters of createWebhook. */
+export interface CreateWebhookRequest {
+  body: CreateWebhookInputBody;
+}
+
+/** Parameters of getWebhook. */
+export interface GetWebhookRequest {
+  /** Webhook subscription ID */
+  id: string;
+}
+
+/** Parameters of updateWebhook. */
+export interface UpdateWebhookRequest {
+  /** Webhook subscription ID */
+  id: string;
+  body: UpdateWebhookInputBody;
+}
+
+/** Parameters of deleteWebhook. */
+export interface DeleteWebhookRequest {
+  /** Webhook subscription ID */
+  id: string;
+}
+
+/** Parameters of resumeWebhook. */
+export interface ResumeWebhookRequest {
+  /** Webhook subscription ID */
+  id: string;
+}
+
+/** Parameters of downloadGrowthReport. */
+export interface DownloadGrowthReportRequest {
+  /** Period size (UTC; weeks start on Monday) */
+  period?: "day" | "week" | "month";
+  /** Start of the report (RFC 3339); 12 periods before to when omitted */
+  from?: string;
+  /** End of the report (RFC 3339); now when omitted */
+  to?: string;
+  /** Expiry of the link (Unix time) */
+  expires: number;
+  /** Set on single-use links */
+  nonce?: string;
+  /** HMAC-SHA256 of the path and query */
+  signature: string;
+}
+
+/** Parameters of getPublicTenantStatus. */
+export interface GetPublicTenantStatusRequest {
+  /** Tenant ID */
+  id: string;
+  /** Expiry of the link (Unix time) */
+  expires: number;
+  /** Set on single-use links */
+  nonce?: string;
+  /** HMAC-SHA256 of the path and query */
+  signature: string;
+}
+
+/** Parameters of getStatusWidget. */
+export interface GetStatusWidgetRequest {
+  /** Bearer followed by a tenant API key with the status:read scope */
+  authorization?: string;
+  /** The API key, for clients that cannot set Authorization */
+  xAPIKey?: string;
+  /** ETag of the status the client holds; 304 is returned while it is current */
+  ifNoneMatch?: string;
+}
+
+/** A problem details response of the API. */
+export class ApiError extends Error {
+  constructor(
+    /** HTTP status of the response. */
+    readonly status: number,
+    /** Problem details sent by the server. */
+    readonly problem: ErrorModel,
+  ) {
+    const title = problem.title ?? String(status);
+    super(problem.detail ? title + ": " + problem.detail : title);
+    this.name = "ApiError";
+  }
+
+  /** Reads the problem details of a failed response. */
+  static async from(response: Response): Promise<ApiError> {
+    let problem: ErrorModel = { status: response.status, title: response.statusText };
+    try {
+      problem = { ...problem, ...((await response.json()) as ErrorModel) };
+    } catch {
+      // Not a problem details body: keep the status line.
+    }
+    return new ApiError(response.status, problem);
+  }
+}
+
+/** Settings of a client. */
+export interface ClientOptions {
+  /** Base URL of the API, e.g. "https://tenantiq.example.com" or "" for the current origin. */
+  baseUrl: string;
+  /** Headers sent with every request, e.g. X-Actor for the audit log. */
+  headers?: Record<string, string>;
+  /** fetch implementation, the global one by default. */
+  fetch?: typeof fetch;
+}
+
+type Scalar = string | number | boolean;
+
+/** The parts of a request set by a generated method. */
+interface RequestParts {
+  query?: Record<string, Scalar | Scalar[] | null | undefined>;
+  headers?: Record<string, Scalar | null | undefined>;
+  body?: unknown;
+}
+
+/** Request plumbing shared by the generated methods. */
+export class BaseClient {
+  private readonly baseUrl: string;
+  private readonly headers: Record<string, string>;
+  private readonly fetchFn: typeof fetch;
+
+  constructor(options: ClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/+$/, "");
+    this.headers = options.headers ?? {};
+    this.fetchFn = options.fetch ?? globalThis.fetch.bind(globalThis);
+  }
+
+  /** Sends a request and rejects with an ApiError unless it succeeds. */
+  protected async send(method: string, path: string, parts: RequestParts, init?: RequestInit): Promise<Response> {
+    const query = new URLSearchParams();
+    for (const [name, value] of Object.entries(parts.query ?? {})) {
+      if (value === undefined || value === null) {
+        continue;
+      }
+      for (const v of Array.isArray(value) ? value : [value]) {
+        query.append(name, String(v));
+      }
+    }
+
+    const headers = new Headers(this.headers);
+    new Headers(init?.headers).forEach((value, name) => headers.set(name, value));
+    for (const [name, value] of Object.entries(parts.headers ?? {})) {
+      if (value !== undefined && value !== null) {
+        headers.set(name, String(value));
+      }
+    }
+    if (!headers.has("Accept")) {
+      headers.set("Accept", "application/json, application/problem+json");
+    }
+
+    let body: BodyInit | undefined;
+    if (parts.body instanceof Blob) {
+      body = parts.body;
+      headers.set("Content-Type", parts.body.type || "application/octet-stream");
+    } else if (parts.body !== undefined) {
+      body = JSON.stringify(parts.body);
+      headers.set("Content-Type", "application/json");
+    }
+
+    const search = query.toString();
+    const url = this.baseUrl + path + (search ? "?" + search : "");
+    const response = await this.fetchFn(url, { ...init, method, headers, body });
+    if (!response.ok) {
+      throw await ApiError.from(response);
+    }
+    return response;
+  }
+}
+
+/** Calls the tenantiq API. Methods resolve with the decoded success response and reject with an ApiError for error responses. */
+export class TenantiqClient extends BaseClient {
+  /** List the admin API keys */
+  async listAdminKeys(init?: RequestInit): Promise<AdminKeyListOutputBody> {
+    const response = await this.send("GET", "/api/v1/admin-keys", {}, init);
+    return (await response.json()) as AdminKeyListOutputBody;
+  }
+
+  /**
+   * Create an admin API key
+   *
+   * Credential of the management API for a person or an automation; the changes made with it are attributed to its name. The key is returned only in this response: just a hash of it is stored.
+   */
+  async createAdminKey(request: CreateAdminKeyRequest, init?: RequestInit): Promise<CreatedAdminKeyResponse> {
+    const response = await this.send("POST", "/api/v1/admin-keys", { body: request.body }, init);
+    return (await response.json()) as CreatedAdminKeyResponse;
+  }
+
+  /**
+   * Revoke an admin API key
+   *
+   * The key stops working at once and stays listed with its revocation time.
+   */
+  async revokeAdminKey(request: RevokeAdminKeyRequest, init?: RequestInit): Promise<void> {
+    await this.send("DELETE", "/api/v1/admin-keys/" + encodeURIComponent(String(request.id)), {}, init);
+  }
+
+  /**
+   * Verify a tenant API key
+   *
+   * For the tenant's applications: returns the tenant and scopes of a key, and records it as used. A key that is unknown, revoked or expired, or whose tenant is being deleted, is 401. The key is sent in the body so it stays out of access logs. Verifications over the tenant's rate limit get 429 Too Many Requests, with Retry-After.
+   */
+  async verifyApiKey(request: VerifyApiKeyRequest, init?: RequestInit): Promise<VerifyAPIKeyOutputBody> {
+    const response = await this.send("POST", "/api/v1/api-keys:verify", { body: request.body }, init);
+    return (await response.json()) as VerifyAPIKeyOutputBody;
+  }
+
+  /**
+   * Cross-check tenants against billing
+   *
+   * Reads the billing provider's subscriptions and reports tenants billed inconsistently with their plan or lifecycle state. Nothing is changed.
+   */
+  async reconcileBilling(init?: RequestInit): Promise<ReconciliationResponse> {
+    const response = await this.send("GET", "/api/v1/billing/reconciliation", {}, init);
+    return (await response.json()) as ReconciliationResponse;
+  }
+
+  /**
+   * Receive a billing provider webhook
+   *
+   * A failed invoice payment starts the tenant's dunning: a warning, a final notice and finally a suspension. A paid invoice ends it and lifts the suspension. Requests must be signed in X-Billing-Signature; other webhook types are acknowledged and ignored.
+   */
+  async receiveBillingWebhook(request: ReceiveBillingWebhookRequest, init?: RequestInit): Promise<BillingWebhookResponse> {
+    const response = await this.send("POST", "/api/v1/billing/webhooks", { headers: { "X-Billing-Signature": request.xBillingSignature }, body: request.body }, init);
+    return (await response.json()) as BillingWebhookResponse;
+  }
+
+  /** List tenant blueprints */
+  async listBlueprints(init?: RequestInit): Promise<BlueprintListOutputBody> {
+    const response = await this.send("GET", "/api/v1/blueprints", {}, init);
+    return (await response.json()) as BlueprintListOutputBody;
+  }
+
+  /**
+   * Define a tenant blueprint
+   *
+   * Tenants created with `"blueprint": "<name>"` start on its plan, unless they name one, with its metadata, feature flags and template variables, which the request's metadata overrides. The blueprint's name is recorded in their metadata under `blueprint`.
+   */
+  async createBlueprint(request: CreateBlueprintRequest, init?: RequestInit): Promise<BlueprintResponse> {
+    const response = await this.send("POST", "/api/v1/blueprints", { body: request.body }, init);
+    return (await response.json()) as BlueprintResponse;
+  }
+
+  /** Get a tenant blueprint */
+  async getBlueprint(request: GetBlueprintRequest, init?: RequestInit): Promise<BlueprintResponse> {
+    const response = await this.send("GET", "/api/v1/blueprints/" + encodeURIComponent(String(request.name)), {}, init);
+    return (await response.json()) as BlueprintResponse;
+  }
+
+  /**
+   * Replace a tenant blueprint's configuration
+   *
+   * Only tenants created afterwards get the new configuration.
+   */
+  async updateBlueprint(request: UpdateBlueprintRequest, init?: RequestInit): Promise<BlueprintResponse> {
+    const response = await this.send("PUT", "/api/v1/blueprints/" + encodeURIComponent(String(request.name)), { body: request.body }, init);
+    return (await response.json()) as BlueprintResponse;
+  }
+
+  /**
+   * Delete a tenant blueprint
+   *
+   * Tenants created from it keep their configuration.
+   */
+  async deleteBlueprint(request: DeleteBlueprintRequest, init?: RequestInit): Promise<void> {
+    await this.send("DELETE", "/api/v1/blueprints/" + encodeURIComponent(String(request.name)), {}, init);
+  }
+
+  /**
+   * Event type catalog
+   *
+   * Lists every lifecycle event with the JSON Schema of its payload.
+   */
+  async getEventSchema(init?: RequestInit): Promise<EventSchemaResponse> {
+    const response = await this.send("GET", "/api/v1/events/schema", {}, init);
+    return (await response.json()) as EventSchemaResponse;
+  }
+
+  /** List long-running operations */
+  async listOperations(request: ListOperationsRequest = {}, init?: RequestInit): Promise<OperationListResponse> {
+    const response = await this.send("GET", "/api/v1/operations", { query: { tenant_id: request.tenant_id, kind: request.kind?.join(","), status: request.status?.join(","), limit: request.limit, offset: request.offset } }, init);
+    return (await response.json()) as OperationListResponse;
+  }
+
+  /** Get a long-running operation */
+  async getOperation(request: GetOperationRequest, init?: RequestInit): Promise<OperationResponse> {
+    const response = await this.send("GET", "/api/v1/operations/" + encodeURIComponent(String(request.id)), {}, init);
+    return (await response.json()) as OperationResponse;
+  }
+
+  /**
+   * List plans
+   *
+   * With region, lists the catalog of that region, as shown on its pricing page.
+   */
+  async listPlans(request: ListPlansRequest = {}, init?: RequestInit): Promise<PlanListOutputBody> {
+    const response = await this.send("GET", "/api/v1/plans", { query: { region: request.region } }, init);
+    return (await response.json()) as PlanListOutputBody;
+  }
+
+  /**
+   * Define a plan
+   *
+   * Tenants can only be created on, or moved to, a defined plan, offered in their region.
+   */
+  async createPlan(request: CreatePlanRequest, init?: RequestInit): Promise<PlanResponse> {
+    const response = await this.send("POST", "/api/v1/plans", { body: request.body }, init);
+    return (await response.json()) as PlanResponse;
+  }
+
+  /** Get a plan */
+  async getPlan(request: GetPlanRequest, init?: RequestInit): Promise<PlanResponse> {
+    const response = await this.send("GET", "/api/v1/plans/" + encodeURIComponent(String(request.name)), {}, init);
+    return (await response.json()) as PlanResponse;
+  }
+
+  /**
+   * Replace a plan's price, limits, features, regions and rate limit
+   *
+   * The name cannot change: tenants refer to the plan by it. Tenants already on the plan keep it when their region is no longer offered.
+   */
+  async updatePlan(request: UpdatePlanRequest, init?: RequestInit): Promise<PlanResponse> {
+    const response = await this.send("PUT", "/api/v1/plans/" + encodeURIComponent(String(request.name)), { body: request.body }, init);
+    return (await response.json()) as PlanResponse;
+  }
+
+  /**
+   * Delete a plan
+   *
+   * Refused with 409 while tenants, other than deleted ones, are on the plan.
+   */
+  async deletePlan(request: DeletePlanRequest, init?: RequestInit): Promise<void> {
+    await this.send("DELETE", "/api/v1/plans/" + encodeURIComponent(String(request.name)), {}, init);
+  }
+
+  /**
+   * Get the rate limits of all tenants
+   *
+   * Snapshot for API gateways to enforce per-tenant limits without calling back on each request. Poll with If-None-Match to receive 304 Not Modified while nothing changed.
+   */
+  async listRateLimits(request: ListRateLimitsRequest = {}, init?: RequestInit): Promise<RateLimitsResponse> {
+    const response = await this.send("GET", "/api/v1/rate-limits", { headers: { "If-None-Match": request.ifNoneMatch } }, init);
+    return (await response.json()) as RateLimitsResponse;
+  }
+
+  /**
+   * Tenant growth and churn per period
+   *
+   * Counts tenants created, deleted (churned) and suspended per period, with the change in and number of active tenants, from the status history. At most 400 periods are returned.
+   */
+  async getGrowthReport(request: GetGrowthReportRequest = {}, init?: RequestInit): Promise<GrowthReportResponse> {
+    const response = await this.send("GET", "/api/v1/reports/growth", { query: { period: request.period, from: request.from, to: request.to } }, init);
+    return (await response.json()) as GrowthReportResponse;
+  }
+
+  /**
+   * Export tenant growth and churn as CSV
+   *
+   * Counts tenants created, deleted (churned) and suspended per period, with the change in and number of active tenants, from the status history. At most 400 periods are returned. One row per period, with a header row.
+   */
+  async exportGrowthReport(request: ExportGrowthReportRequest = {}, init?: RequestInit): Promise<string> {
+    const response = await this.send("GET", "/api/v1/reports/growth.csv", { query: { period: request.period, from: request.from, to: request.to } }, init);
+    return response.text();
+  }
+
+  /**
+   * Count tenants per status
+   *
+   * Read from counters maintained as tenants change, so it is cheap enough for dashboards to poll. A periodic job corrects any drift from the tenants table.
+   */
+  async getStatusCounts(init?: RequestInit): Promise<StatusCountsResponse> {
+    const response = await this.send("GET", "/api/v1/reports/status-counts", {}, init);
+    return (await response.json()) as StatusCountsResponse;
+  }
+
+  /** Register a reseller */
+  async createReseller(request: CreateResellerRequest, init?: RequestInit): Promise<ResellerResponse> {
+    const response = await this.send("POST", "/api/v1/resellers", { body: request.body }, init);
+    return (await response.json()) as ResellerResponse;
+  }
+
+  /** Get a reseller */
+  async getReseller(request: GetResellerRequest, init?: RequestInit): Promise<ResellerResponse> {
+    const response = await this.send("GET", "/api/v1/resellers/" + encodeURIComponent(String(request.reseller_id)), {}, init);
+    return (await response.json()) as ResellerResponse;
+  }
+
+  /** List a reseller's tenants */
+  async listResellerTenants(request: ListResellerTenantsRequest, init?: RequestInit): Promise<TenantListResponse> {
+    const response = await this.send("GET", "/api/v1/resellers/" + encodeURIComponent(String(request.reseller_id)) + "/tenants", { query: { status: request.status?.join(","), limit: request.limit, offset: request.offset } }, init);
+    return (await response.json()) as TenantListResponse;
+  }
+
+  /**
+   * Create a tenant for a reseller
+   *
+   * Fails with 409 when the reseller already manages as many tenants as its quota allows.
+   */
+  async createResellerTenant(request: CreateResellerTenantRequest, init?: RequestInit): Promise<TenantResponse> {
+    const response = await this.send("POST", "/api/v1/resellers/" + encodeURIComponent(String(request.reseller_id)) + "/tenants", { body: request.body }, init);
+    return (await response.json()) as TenantResponse;
+  }
+
+  /**
+   * Get one of a reseller's tenants
+   *
+   * Tenants managed by someone else are reported as not found.
+   */
+  async getResellerTenant(request: GetResellerTenantRequest, init?: RequestInit): Promise<TenantResponse> {
+    const response = await this.send("GET", "/api/v1/resellers/" + encodeURIComponent(String(request.reseller_id)) + "/tenants/" + encodeURIComponent(String(request.id)), {}, init);
+    return (await response.json()) as TenantResponse;
+  }
+
+  /** Suspend one of a reseller's tenants */
+  async suspendResellerTenant(request: SuspendResellerTenantRequest, init?: RequestInit): Promise<TenantResponse> {
+    const response = await this.send("POST", "/api/v1/resellers/" + encodeURIComponent(String(request.reseller_id)) + "/tenants/" + encodeURIComponent(String(request.id)) + "/suspend", {}, init);
+    return (await response.json()) as TenantResponse;
+  }
+
+  /** Get a reseller's quota usage */
+  async getResellerUsage(request: GetResellerUsageRequest, init?: RequestInit): Promise<ResellerUsageResponse> {
+    const response = await this.send("GET", "/api/v1/resellers/" + encodeURIComponent(String(request.reseller_id)) + "/usage", {}, init);
+    return (await response.json()) as ResellerUsageResponse;
+  }
+
+  /**
+   * Create a signed link to a public resource
+   *
+   * Links grant access without credentials until they expire: public status pages (/public/tenants/{id}/status) and export downloads (/public/reports/growth.csv). The query of the path is signed as well, so it cannot be changed.
+   */
+  async createSignedUrl(request: CreateSignedUrlRequest, init?: RequestInit): Promise<SignedURLResponse> {
+    const response = await this.send("POST", "/api/v1/signed-urls", { body: request.body }, init);
+    return (await response.json()) as SignedURLResponse;
+  }
+
+  /**
+   * Outbound network configuration
+   *
+   * The proxy and source addresses of the requests tenantiq makes: webhook deliveries, billing, certificate and identity provider calls. Give source_ips to the systems that allowlist callers.
+   */
+  async getEgress(init?: RequestInit): Promise<EgressResponse> {
+    const response = await this.send("GET", "/api/v1/system/egress", {}, init);
+    return (await response.json()) as EgressResponse;
+  }
+
+  /** Get the read-only mode */
+  async getReadOnly(init?: RequestInit): Promise<ReadOnlyResponse> {
+    const response = await this.send("GET", "/api/v1/system/read-only", {}, init);
+    return (await response.json()) as ReadOnlyResponse;
+  }
+
+  /**
+   * Switch the read-only mode
+   *
+   * For database migrations and disaster recovery failovers: while enabled, requests changing data get 503 and background jobs are paused; reads keep working. The mode and the pause are stored in the database, so they apply to every instance. Requires the admin role.
+   */
+  async setReadOnly(request: SetReadOnlyRequest, init?: RequestInit): Promise<ReadOnlyResponse> {
+    const response = await this.send("PUT", "/api/v1/system/read-only", { body: request.body }, init);
+    return (await response.json()) as ReadOnlyResponse;
+  }
+
+  /** Worker autoscaling signal */
+  async getScaling(init?: RequestInit): Promise<ScalingResponse> {
+    const response = await this.send("GET", "/api/v1/system/scaling", {}, init);
+    return (await response.json()) as ScalingResponse;
+  }
+
+  /**
+   * List tenants
+   *
+   * Besides the parameters below, `metadata.<key>=<value>` parameters only list the tenants whose metadata has every given key with the given value, e.g. `?metadata.crm_id=42`.
+   */
+  async listTenants(request: ListTenantsRequest = {}, init?: RequestInit): Promise<TenantListResponse> {
+    const response = await this.send("GET", "/api/v1/tenants", { query: { status: request.status?.join(","), plan: request.plan?.join(","), created_after: request.created_after, created_before: request.created_before, simulated: request.simulated, tag: request.tag, limit: request.limit, offset: request.offset } }, init);
+    return (await response.json()) as TenantListResponse;
+  }
+
+  /**
+   * Create a new tenant
+   *
+   * With `Prefer: respond-async` (and asynchronous provisioning enabled), the tenant is returned in the creating state with 202 and an operation_id to poll at /api/v1/operations/{id}. A simulated tenant is provisioned by fake adapters and its events reach neither AMQP nor webhooks; without `Prefer: respond-async` it is returned active.
+   */
+  async createTenant(request: CreateTenantRequest, init?: RequestInit): Promise<TenantOperationResponse> {
+    const response = await this.send("POST", "/api/v1/tenants", { headers: { Prefer: request.prefer }, body: request.body }, init);
+    return (await response.json()) as TenantOperationResponse;
+  }
+
+  /**
+   * List tenant changes since a cursor
+   *
+   * Lets downstream caches sync incrementally instead of re-listing every tenant. To bootstrap, take a cursor with since=now, list the tenants, then follow the changes from the cursor. Changes may overlap the listing, so apply each one only when its version is newer than the cached one. Returns 410 when the changes after the cursor were pruned by retention: list the tenants again.
+   */
+  async listTenantChanges(request: ListTenantChangesRequest = {}, init?: RequestInit): Promise<ListChangesOutputBody> {
+    const response = await this.send("GET", "/api/v1/tenants/changes", { query: { since: request.since, limit: request.limit } }, init);
+    return (await response.json()) as ListChangesOutputBody;
+  }
+
+  /** Get a tenant by slug */
+  async getTenantBySlug(request: GetTenantBySlugRequest, init?: RequestInit): Promise<ResolvedTenantResponse> {
+    const response = await this.send("GET", "/api/v1/tenants/slug/" + encodeURIComponent(String(request.slug)), {}, init);
+    return (await response.json()) as ResolvedTenantResponse;
+  }
+
+  /** Get a tenant by ID */
+  async getTenant(request: GetTenantRequest, init?: RequestInit): Promise<TenantResponse> {
+    const response = await this.send("GET", "/api/v1/tenants/" + encodeURIComponent(String(request.id)), { query: { as_of: request.as_of } }, init);
+    return (await response.json()) as TenantResponse;
+  }
+
+  /** Update a tenant's plan and references */
+  async updateTenant(request: UpdateTenantRequest, init?: RequestInit): Promise<TenantResponse> {
+    const response = await this.send("PATCH", "/api/v1/tenants/" + encodeURIComponent(String(request.id)), { body: request.body }, init);
+    return (await response.json()) as TenantResponse;
+  }
+
+  /** Delete a tenant */
+  async deleteTenant(request: DeleteTenantRequest, init?: RequestInit): Promise<TenantOperationResponse> {
+    const response = await this.send("DELETE", "/api/v1/tenants/" + encodeURIComponent(String(request.id)), { headers: { Prefer: request.prefer } }, init);
+    return (await response.json()) as TenantOperationResponse;
+  }
+
+  /** List a tenant's API keys */
+  async listTenantApiKeys(request: ListTenantApiKeysRequest, init?: RequestInit): Promise<APIKeyListOutputBody> {
+    const response = await this.send("GET", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/api-keys", {}, init);
+    return (await response.json()) as APIKeyListOutputBody;
+  }
+
+  /**
+   * Create an API key for a tenant
+   *
+   * Machine credential for the tenant's applications, which check it with verify-api-key. The key is returned only in this response: just a hash of it is stored.
+   */
+  async createTenantApiKey(request: CreateTenantApiKeyRequest, init?: RequestInit): Promise<CreatedAPIKeyResponse> {
+    const response = await this.send("POST", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/api-keys", { body: request.body }, init);
+    return (await response.json()) as CreatedAPIKeyResponse;
+  }
+
+  /**
+   * Revoke a tenant's API key
+   *
+   * The key stops working at once and stays listed with its revocation time.
+   */
+  async revokeTenantApiKey(request: RevokeTenantApiKeyRequest, init?: RequestInit): Promise<void> {
+    await this.send("DELETE", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/api-keys/" + encodeURIComponent(String(request.key_id)), {}, init);
+  }
+
+  /** List a tenant's TLS certificates */
+  async listTenantCertificates(request: ListTenantCertificatesRequest, init?: RequestInit): Promise<CertificateListOutputBody> {
+    const response = await this.send("GET", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/certificates", {}, init);
+    return (await response.json()) as CertificateListOutputBody;
+  }
+
+  /**
+   * Request a TLS certificate for a tenant domain
+   *
+   * The certificate is obtained in the background over ACME, then renewed ahead of expiry; certificate_issued or certificate_failed is published each time. The domain must already point at tenantiq's ingress, which must route /.well-known/acme-challenge/ to tenantiq: the certificate authority checks it over HTTP. Requesting a domain the tenant already has returns its certificate.
+   */
+  async requestTenantCertificate(request: RequestTenantCertificateRequest, init?: RequestInit): Promise<CertificateResponse> {
+    const response = await this.send("PUT", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/certificates/" + encodeURIComponent(String(request.domain)), {}, init);
+    return (await response.json()) as CertificateResponse;
+  }
+
+  /**
+   * Stop renewing a tenant domain's TLS certificate
+   *
+   * The certificate already issued stays valid until it expires.
+   */
+  async removeTenantCertificate(request: RemoveTenantCertificateRequest, init?: RequestInit): Promise<void> {
+    await this.send("DELETE", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/certificates/" + encodeURIComponent(String(request.domain)), {}, init);
+  }
+
+  /**
+   * Get a tenant's dunning
+   *
+   * Returns where the tenant is in the collection of an unpaid invoice, or 404 when it has none.
+   */
+  async getTenantDunning(request: GetTenantDunningRequest, init?: RequestInit): Promise<DunningResponse> {
+    const response = await this.send("GET", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/dunning", {}, init);
+    return (await response.json()) as DunningResponse;
+  }
+
+  /**
+   * Get the encryption key a tenant brought
+   *
+   * 404 when the tenant's data is encrypted with tenantiq's key.
+   */
+  async getTenantEncryptionKey(request: GetTenantEncryptionKeyRequest, init?: RequestInit): Promise<EncryptionKeyResponse> {
+    const response = await this.send("GET", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/encryption-key", {}, init);
+    return (await response.json()) as EncryptionKeyResponse;
+  }
+
+  /**
+   * Set or rotate the encryption key of a tenant
+   *
+   * Encrypts the tenant's data with its own KMS key (bring your own key), which requires the byok feature in its plan. The secrets' data keys are re-encrypted with the new key, so the previous one must still work. Setting a key again after it was revoked resumes access to the tenant's data. 422 when tenantiq cannot use the key.
+   */
+  async setTenantEncryptionKey(request: SetTenantEncryptionKeyRequest, init?: RequestInit): Promise<EncryptionKeyResponse> {
+    const response = await this.send("PUT", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/encryption-key", { body: request.body }, init);
+    return (await response.json()) as EncryptionKeyResponse;
+  }
+
+  /**
+   * Record a tenant's encryption key as revoked
+   *
+   * For tenants that revoked their key at the KMS: access to their data is suspended, with 403, until a usable key is set. A key found revoked while in use is recorded the same way.
+   */
+  async revokeTenantEncryptionKey(request: RevokeTenantEncryptionKeyRequest, init?: RequestInit): Promise<EncryptionKeyResponse> {
+    const response = await this.send("POST", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/encryption-key/revoke", {}, init);
+    return (await response.json()) as EncryptionKeyResponse;
+  }
+
+  /** Trigger a lifecycle event */
+  async transitionTenant(request: TransitionTenantRequest, init?: RequestInit): Promise<TenantResponse> {
+    const response = await this.send("POST", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/events", { body: request.body }, init);
+    return (await response.json()) as TenantResponse;
+  }
+
+  /**
+   * Get a tenant's status history
+   *
+   * Lists every lifecycle transition with the event and actor that caused it.
+   */
+  async getTenantHistory(request: GetTenantHistoryRequest, init?: RequestInit): Promise<GetHistoryOutputBody> {
+    const response = await this.send("GET", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/history", {}, init);
+    return (await response.json()) as GetHistoryOutputBody;
+  }
+
+  /** Get the networks a tenant's API keys may be used from */
+  async getTenantIpAllowlist(request: GetTenantIpAllowlistRequest, init?: RequestInit): Promise<IPAllowlistResponse> {
+    const response = await this.send("GET", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/ip-allowlist", {}, init);
+    return (await response.json()) as IPAllowlistResponse;
+  }
+
+  /**
+   * Restrict the networks a tenant's API keys may be used from
+   *
+   * Requests authenticated with the tenant's API keys from other addresses get 403. An empty list lifts the restriction.
+   */
+  async setTenantIpAllowlist(request: SetTenantIpAllowlistRequest, init?: RequestInit): Promise<IPAllowlistResponse> {
+    const response = await this.send("PUT", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/ip-allowlist", { body: request.body }, init);
+    return (await response.json()) as IPAllowlistResponse;
+  }
+
+  /** Get a tenant's maintenance windows */
+  async getTenantMaintenanceWindows(request: GetTenantMaintenanceWindowsRequest, init?: RequestInit): Promise<MaintenanceWindowsResponse> {
+    const response = await this.send("GET", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/maintenance-windows", {}, init);
+    return (await response.json()) as MaintenanceWindowsResponse;
+  }
+
+  /**
+   * Declare a tenant's maintenance windows
+   *
+   * Replaces the tenant's weekly windows. Suspensions and deletions scheduled by automation (spec sync, dunning) are deferred to them; changes requested through the API are not.
+   */
+  async setTenantMaintenanceWindows(request: SetTenantMaintenanceWindowsRequest, init?: RequestInit): Promise<MaintenanceWindowsResponse> {
+    const response = await this.send("PUT", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/maintenance-windows", { body: request.body }, init);
+    return (await response.json()) as MaintenanceWindowsResponse;
+  }
+
+  /** List a tenant's members */
+  async listTenantMembers(request: ListTenantMembersRequest, init?: RequestInit): Promise<MemberListOutputBody> {
+    const response = await this.send("GET", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/members", {}, init);
+    return (await response.json()) as MemberListOutputBody;
+  }
+
+  /**
+   * Invite a member to a tenant
+   *
+   * The member is invited by the X-Actor caller and stays invited until the invitation is accepted. Sending the invitation is up to the tenant's applications. An email address the tenant already has, in any case, is a conflict.
+   */
+  async inviteTenantMember(request: InviteTenantMemberRequest, init?: RequestInit): Promise<MemberResponse> {
+    const response = await this.send("POST", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/members", { body: request.body }, init);
+    return (await response.json()) as MemberResponse;
+  }
+
+  /**
+   * Remove a member from a tenant
+   *
+   * Removing an invited member revokes the invitation.
+   */
+  async removeTenantMember(request: RemoveTenantMemberRequest, init?: RequestInit): Promise<void> {
+    await this.send("DELETE", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/members/" + encodeURIComponent(String(request.member_id)), {}, init);
+  }
+
+  /**
+   * Accept a member's invitation
+   *
+   * Makes the member active. Accepting again has no effect.
+   */
+  async acceptTenantMember(request: AcceptTenantMemberRequest, init?: RequestInit): Promise<MemberResponse> {
+    const response = await this.send("POST", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/members/" + encodeURIComponent(String(request.member_id)) + "/accept", {}, init);
+    return (await response.json()) as MemberResponse;
+  }
+
+  /**
+   * Check a tenant's plan quota
+   *
+   * Called before an operation that consumes quota. Answers 204 when the tenant's reported usage plus the amount fits its plan's limit on the metric, 429 when a periodic limit (api_calls) is reached and 403 when another limit is. Metrics the plan does not limit always fit.
+   */
+  async checkTenantQuota(request: CheckTenantQuotaRequest, init?: RequestInit): Promise<void> {
+    await this.send("POST", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/quota-checks", { body: request.body }, init);
+  }
+
+  /**
+   * Override a tenant's rate limit
+   *
+   * Replaces the rate limit of the tenant's plan for this tenant only.
+   */
+  async setTenantRateLimit(request: SetTenantRateLimitRequest, init?: RequestInit): Promise<TenantRateLimitResponse> {
+    const response = await this.send("PUT", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/rate-limit", { body: request.body }, init);
+    return (await response.json()) as TenantRateLimitResponse;
+  }
+
+  /**
+   * Remove a tenant's rate limit override
+   *
+   * The rate limit of the tenant's plan applies again.
+   */
+  async clearTenantRateLimit(request: ClearTenantRateLimitRequest, init?: RequestInit): Promise<void> {
+    await this.send("DELETE", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/rate-limit", {}, init);
+  }
+
+  /** List a tenant's secrets */
+  async listTenantSecrets(request: ListTenantSecretsRequest, init?: RequestInit): Promise<SecretListOutputBody> {
+    const response = await this.send("GET", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/secrets", {}, init);
+    return (await response.json()) as SecretListOutputBody;
+  }
+
+  /**
+   * Get a tenant's secret with its value
+   *
+   * 403 while the tenant's encryption key is revoked.
+   */
+  async getTenantSecret(request: GetTenantSecretRequest, init?: RequestInit): Promise<SecretResponse> {
+    const response = await this.send("GET", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/secrets/" + encodeURIComponent(String(request.name)), {}, init);
+    return (await response.json()) as SecretResponse;
+  }
+
+  /**
+   * Set a tenant's secret
+   *
+   * The value is encrypted with the tenant's key and is not returned.
+   */
+  async putTenantSecret(request: PutTenantSecretRequest, init?: RequestInit): Promise<SecretResponse> {
+    const response = await this.send("PUT", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/secrets/" + encodeURIComponent(String(request.name)), { body: request.body }, init);
+    return (await response.json()) as SecretResponse;
+  }
+
+  /** Delete a tenant's secret */
+  async deleteTenantSecret(request: DeleteTenantSecretRequest, init?: RequestInit): Promise<void> {
+    await this.send("DELETE", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/secrets/" + encodeURIComponent(String(request.name)), {}, init);
+  }
+
+  /**
+   * Tag a tenant
+   *
+   * Tags group tenants by campaign, cohort, support tier, ...; list them with `?tag=`. A tenant has at most 20 tags.
+   */
+  async addTenantTags(request: AddTenantTagsRequest, init?: RequestInit): Promise<TenantResponse> {
+    const response = await this.send("POST", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/tags", { body: request.body }, init);
+    return (await response.json()) as TenantResponse;
+  }
+
+  /** Remove a tag from a tenant */
+  async removeTenantTag(request: RemoveTenantTagRequest, init?: RequestInit): Promise<TenantResponse> {
+    const response = await this.send("DELETE", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/tags/" + encodeURIComponent(String(request.tag)), {}, init);
+    return (await response.json()) as TenantResponse;
+  }
+
+  /** Get a tenant's usage */
+  async getTenantUsage(request: GetTenantUsageRequest, init?: RequestInit): Promise<UsageResponse> {
+    const response = await this.send("GET", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/usage", {}, init);
+    return (await response.json()) as UsageResponse;
+  }
+
+  /**
+   * Report a tenant's usage
+   *
+   * Called by metering. The plan suggestion job matches the latest values against the plan quotas.
+   */
+  async reportTenantUsage(request: ReportTenantUsageRequest, init?: RequestInit): Promise<UsageResponse> {
+    const response = await this.send("PUT", "/api/v1/tenants/" + encodeURIComponent(String(request.id)) + "/usage", { body: request.body }, init);
+    return (await response.json()) as UsageResponse;
+  }
+
+  /**
+   * Apply a desired-state spec to a tenant
+   *
+   * Creates the tenant if missing, updates drifted fields and drives its status through the lifecycle. Idempotent.
+   */
+  async applyTenantSpec(request: ApplyTenantSpecRequest, init?: RequestInit): Promise<ApplySpecResponse> {
+    const response = await this.send("PUT", "/api/v1/tenants/" + encodeURIComponent(String(request.slug)) + "/spec", { body: request.body }, init);
+    return (await response.json()) as ApplySpecResponse;
+  }
+
+  /**
+   * Create many tenants in one request
+   *
+   * Validates every item up front and inserts the accepted ones in a single transaction. Rejected items are reported per item and do not prevent the others from being created.
+   */
+  async batchCreateTenants(request: BatchCreateTenantsRequest, init?: RequestInit): Promise<BatchCreateTenantsOutputBody> {
+    const response = await this.send("POST", "/api/v1/tenants:batchCreate", { body: request.body }, init);
+    return (await response.json()) as BatchCreateTenantsOutputBody;
+  }
+
+  /**
+   * Trigger a lifecycle event on many tenants
+   *
+   * Suspensions and deletions are refused with 409, and nothing is written, when they would disrupt more active tenants than the guardrail allows; an admin may set force to go past it. A tenant the event fails on is reported in its result and does not stop the others.
+   */
+  async batchTransitionTenants(request: BatchTransitionTenantsRequest, init?: RequestInit): Promise<BatchTransitionTenantsOutputBody> {
+    const response = await this.send("POST", "/api/v1/tenants:batchTransition", { body: request.body }, init);
+    return (await response.json()) as BatchTransitionTenantsOutputBody;
+  }
+
+  /**
+   * Import tenants with their original IDs and timestamps
+   *
+   * For migrations from legacy systems: like batch creation, but each tenant keeps the ID and timestamps it had there. IDs already in use are reported as conflicts. Requires the import key as a bearer token.
+   */
+  async importTenants(request: ImportTenantsRequest, init?: RequestInit): Promise<BatchCreateTenantsOutputBody> {
+    const response = await this.send("POST", "/api/v1/tenants:import", { headers: { Authorization: request.authorization }, body: request.body }, init);
+    return (await response.json()) as BatchCreateTenantsOutputBody;
+  }
+
+  /** List webhook subscriptions */
+  async listWebhooks(init?: RequestInit): Promise<WebhookListOutputBody> {
+    const response = await this.send("GET", "/api/v1/webhooks", {}, init);
+    return (await response.json()) as WebhookListOutputBody;
+  }
+
+  /**
+   * Subscribe an endpoint to tenant events
+   *
+   * Each matching event is POSTed to the URL with its JSON payload (see /api/v1/events/schema), signed with the secret, and retried with backoff until the endpoint answers 2xx. After 10 failed deliveries in a row the subscription's circuit opens and deliveries are held until it is resumed. With an hourly or daily digest, the events are instead POSTed together once the hour or UTC day ends, as one io.tenantiq.webhook.digest CloudEvent.
+   */
+  async createWebhook(request: CreateWebhookRequest, init?: RequestInit): Promise<WebhookResponse> {
+    const response = await this.send("POST", "/api/v1/webhooks", { body: request.body }, init);
+    return (await response.json()) as WebhookResponse;
+  }
+
+  /** Get a webhook subscription */
+  async getWebhook(request: GetWebhookRequest, init?: RequestInit): Promise<WebhookResponse> {
+    const response = await this.send("GET", "/api/v1/webhooks/" + encodeURIComponent(String(request.id)), {}, init);
+    return (await response.json()) as WebhookResponse;
+  }
+
+  /**
+   * Replace a webhook subscription
+   *
+   * Pending retries use the new URL and secret.
+   */
+  async updateWebhook(request: UpdateWebhookRequest, init?: RequestInit): Promise<WebhookResponse> {
+    const response = await this.send("PUT", "/api/v1/webhooks/" + encodeURIComponent(String(request.id)), { body: request.body }, init);
+    return (await response.json()) as WebhookResponse;
+  }
+
+  /**
+   * Delete a webhook subscription
+   *
+   * Pending deliveries to the subscription are dropped.
+   */
+  async deleteWebhook(request: DeleteWebhookRequest, init?: RequestInit): Promise<void> {
+    await this.send("DELETE", "/api/v1/webhooks/" + encodeURIComponent(String(request.id)), {}, init);
+  }
+
+  /**
+   * Resume the deliveries held by an open circuit
+   *
+   * Closes the circuit once the endpoint is fixed; the held deliveries are attempted again within a minute. They fail again, and the circuit opens again, if the endpoint still does.
+   */
+  async resumeWebhook(request: ResumeWebhookRequest, init?: RequestInit): Promise<WebhookResponse> {
+    const response = await this.send("POST", "/api/v1/webhooks/" + encodeURIComponent(String(request.id)) + "/resume", {}, init);
+    return (await response.json()) as WebhookResponse;
+  }
+
+  /** Liveness probe */
+  async liveness(init?: RequestInit): Promise<LivenessOutputBody> {
+    const response = await this.send("GET", "/healthz", {}, init);
+    return (await response.json()) as LivenessOutputBody;
+  }
+
+  /**
+   * Download the growth report through a signed link
+   *
+   * Same CSV as /api/v1/reports/growth.csv, for links handed out to people without API access.
+   */
+  async downloadGrowthReport(request: DownloadGrowthReportRequest, init?: RequestInit): Promise<string> {
+    const response = await this.send("GET", "/public/reports/growth.csv", { query: { period: request.period, from: request.from, to: request.to, expires: request.expires, nonce: request.nonce, signature: request.signature } }, init);
+    return response.text();
+  }
+
+  /**
+   * Get a tenant's status through a signed link
+   *
+   * Shares a tenant's state with people without API access, e.g. on a status page.
+   */
+  async getPublicTenantStatus(request: GetPublicTenantStatusRequest, init?: RequestInit): Promise<PublicStatusResponse> {
+    const response = await this.send("GET", "/public/tenants/" + encodeURIComponent(String(request.id)) + "/status", { query: { expires: request.expires, nonce: request.nonce, signature: request.signature } }, init);
+    return (await response.json()) as PublicStatusResponse;
+  }
+
+  /**
+   * Readiness probe
+   *
+   * Returns 503 when the job queue is saturated or cannot be inspected.
+   */
+  async readiness(init?: RequestInit): Promise<ReadinessResponse> {
+    const response = await this.send("GET", "/readyz", {}, init);
+    return (await response.json()) as ReadinessResponse;
+  }
+
+  /**
+   * Get the status of the API key's tenant
+   *
+   * For embedding in the tenant's own dashboards: any origin may call it from a browser. The tenant API key must have the status:read scope, and reveals the status of its own tenant only; as it is handed to browsers, give it no other scope. Responses may be cached for 30 seconds; poll with If-None-Match to receive 304 Not Modified while nothing changed. Requests over the tenant's rate limit get 429 Too Many Requests, with Retry-After.
+   */
+  async getStatusWidget(request: GetStatusWidgetRequest = {}, init?: RequestInit): Promise<StatusWidgetResponse> {
+    const response = await this.send("GET", "/widget/v1/status", { headers: { Authorization: request.authorization, "X-API-Key": request.xAPIKey, "If-None-Match": request.ifNoneMatch } }, init);
+    return (await response.json()) as StatusWidgetResponse;
+  }
+}
@@ -0,0 +1,39 @@
+// Command asyncapi writes the AsyncAPI document of tenantiq's messaging
+// surface (River job kinds and event payloads), generated from the Go types.
+//
+//	go run ./cmd/asyncapi -o api/asyncapi.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/asyncapi"
+)
+
+func main() {
+	out := flag.String("o", "api/asyncapi.json", "output file (- for stdout)")
+	version := flag.String("version", "0.1.0", "API version recorded in the document")
+	flag.Parse()
+
+	if err := run(*out, *version); err != nil {
+		fmt.Fprintf(os.Stderr, "asyncapi: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(out, version string) error {
+	data, err := json.MarshalIndent(asyncapi.Spec(version), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding document: %w", err)
+	}
+	data = append(data, '\n')
+
+	if out == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(out, data, 0o644)
+}
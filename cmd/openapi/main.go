@@ -0,0 +1,152 @@
+// Command openapi writes the OpenAPI document of tenantiq's REST API with
+// every optional feature enabled, and the TypeScript client generated from
+// it.
+//
+//	go run ./cmd/openapi -o api/openapi.json -ts clients/typescript/src/index.ts
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+	"github.com/go-chi/chi/v5"
+	"github.com/pressly/goose/v3"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/asyncapi"
+	"github.com/neomorfeo/tenantiq/internal/adapter/egress"
+	handler "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/jwtauth"
+	"github.com/neomorfeo/tenantiq/internal/adapter/oidc"
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/signedurl"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/adapter/tsclient"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func main() {
+	out := flag.String("o", "api/openapi.json", "OpenAPI output file (- for stdout)")
+	ts := flag.String("ts", "clients/typescript/src/index.ts", "TypeScript client output file (empty to skip)")
+	version := flag.String("version", "0.1.0", "API version recorded in the document")
+	flag.Parse()
+
+	if err := run(*out, *ts, *version); err != nil {
+		fmt.Fprintf(os.Stderr, "openapi: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(out, ts, version string) error {
+	doc, err := spec(version)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding document: %w", err)
+	}
+	if err := write(out, append(data, '\n')); err != nil {
+		return err
+	}
+
+	if ts == "" {
+		return nil
+	}
+	client, err := tsclient.Generate(doc)
+	if err != nil {
+		return fmt.Errorf("generating TypeScript client: %w", err)
+	}
+	return write(ts, client)
+}
+
+func write(path string, data []byte) error {
+	if path == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// spec registers every route of the server, optional ones included, on an
+// API backed by a scratch in-memory database and returns its document. The
+// handlers are never called.
+func spec(version string) (*huma.OpenAPI, error) {
+	goose.SetLogger(goose.NopLogger())
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		return nil, fmt.Errorf("database: %w", err)
+	}
+	defer repo.Close()
+	db := repo.DB()
+
+	operations := app.NewOperationService(sqlite.NewOperationRepository(db))
+	catalog := domain.PlanCatalog{{Plan: "free"}}
+	svc := app.NewTenantService(repo, nil, nil,
+		app.WithStatusHistory(sqlite.NewStatusHistoryRepository(db)),
+		app.WithAuditReader(sqlite.NewAuditLog(db)),
+		app.WithChangeFeed(sqlite.NewAuditLog(db)),
+		app.WithPlanSuggestions(catalog, sqlite.NewUsageRepository(db)),
+		app.WithQuotaChecker(app.NewPlanQuotaChecker(sqlite.NewPlanRepository(db), sqlite.NewUsageRepository(db))),
+		app.WithStatusCounter(sqlite.NewStatusCounter(db)),
+		app.WithRateLimits(catalog, sqlite.NewRateLimitRepository(db)),
+		app.WithIPAllowlists(sqlite.NewIPAllowlistRepository(db)),
+		app.WithMaintenanceWindows(sqlite.NewMaintenanceRepository(db)),
+		app.WithAsyncOperations(operations, nil),
+	)
+	signer, err := signedurl.New(make([]byte, 32), sqlite.NewNonceStore(db))
+	if err != nil {
+		return nil, fmt.Errorf("signed urls: %w", err)
+	}
+	tokens, err := jwtauth.New(jwtauth.Config{Secret: string(make([]byte, 32))}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("tokens: %w", err)
+	}
+	sessions, err := oidc.NewSessions(make([]byte, 32), time.Hour, "sub")
+	if err != nil {
+		return nil, fmt.Errorf("sessions: %w", err)
+	}
+
+	api := humachi.New(chi.NewMux(), huma.DefaultConfig("tenantiq", version))
+	handler.Register(api, svc,
+		handler.WithOperations(operations),
+		handler.WithResellers(app.NewResellerService(sqlite.NewResellerRepository(db), svc)),
+		handler.WithWebhooks(app.NewWebhookService(sqlite.NewWebhookRepository(db))),
+		handler.WithPlans(app.NewPlanService(sqlite.NewPlanRepository(db), repo)),
+		handler.WithBlueprints(app.NewBlueprintService(sqlite.NewBlueprintRepository(db), nil)),
+		handler.WithBilling(app.NewBillingService(nil, svc)),
+		handler.WithDunning(app.NewDunningService(sqlite.NewDunningRepository(db), svc, domain.DunningPolicy{}), "secret"),
+		handler.WithCertificates(app.NewCertificateService(sqlite.NewCertificateRepository(db), nil, svc)),
+		handler.WithMembers(app.NewMemberService(sqlite.NewMemberRepository(db), svc)),
+		handler.WithAPIKeys(app.NewAPIKeyService(sqlite.NewAPIKeyRepository(db), svc)),
+		handler.WithEncryption(app.NewEncryptionService(sqlite.NewEncryptionKeyRepository(db), sqlite.NewSecretRepository(db), nil, "", svc)),
+		handler.WithAuthentication(app.NewAdminKeyService(sqlite.NewAdminKeyRepository(db))),
+		handler.WithTokenAuthentication(tokens, "sub"),
+		handler.WithSessions(oidc.SessionCookie, sessions),
+		handler.WithRoles("roles", domain.RoleViewer),
+		handler.WithSignedURLs(signer, 0),
+		handler.WithImports("key"),
+		handler.WithReadOnly(app.NewReadOnlySwitch(sqlite.NewReadOnlyRepository(db), nil)),
+	)
+	monitor := riveradapter.NewQueueMonitor(db)
+	handler.RegisterHealth(api, monitor, handler.QueueThresholds{})
+	handler.RegisterScaling(api, monitor, domain.ScalingPolicy{})
+	outbound, err := egress.New(egress.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("egress: %w", err)
+	}
+	handler.RegisterEgress(api, outbound)
+	if err := handler.RegisterEventSchema(api, riveradapter.EventJobArgs{}, riveradapter.CloudEventType); err != nil {
+		return nil, fmt.Errorf("event schema: %w", err)
+	}
+	if err := handler.RegisterAsyncAPI(api, asyncapi.Spec(version)); err != nil {
+		return nil, fmt.Errorf("asyncapi: %w", err)
+	}
+	return api.OpenAPI(), nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/tsclient"
+)
+
+// TestGeneratedFilesAreUpToDate keeps the committed OpenAPI document and
+// TypeScript client in sync with the handlers. Regenerate them with:
+// go run ./cmd/openapi
+func TestGeneratedFilesAreUpToDate(t *testing.T) {
+	doc, err := spec("0.1.0")
+	if err != nil {
+		t.Fatalf("spec: %v", err)
+	}
+
+	document, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		t.Fatalf("encoding: %v", err)
+	}
+	client, err := tsclient.Generate(doc)
+	if err != nil {
+		t.Fatalf("generating client: %v", err)
+	}
+
+	for path, generated := range map[string][]byte{
+		"../../api/openapi.json":                append(document, '\n'),
+		"../../clients/typescript/src/index.ts": client,
+	} {
+		committed, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading committed file: %v", err)
+		}
+		if !bytes.Equal(committed, generated) {
+			t.Errorf("%s is stale; run go run ./cmd/openapi", path)
+		}
+	}
+}
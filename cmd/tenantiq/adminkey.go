@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// createAdminKey implements "tenantiq create-admin-key [-role ROLE] NAME":
+// it creates an admin API key for NAME in the database of DATABASE_PATH, migrating it if
+// needed, and prints the key. It is how the first key is made, since the
+// API itself requires one.
+func createAdminKey(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("create-admin-key", flag.ContinueOnError)
+	role := fs.String("role", string(domain.RoleAdmin), "what the holder may do: viewer, operator or admin")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: tenantiq create-admin-key [-role ROLE] NAME\n\nCreates an admin API key for NAME, a person or an automation, and prints it.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected the name of the key's holder")
+	}
+
+	repo, err := sqlite.New(envOrDefault("DATABASE_PATH", "tenantiq.db"))
+	if err != nil {
+		return fmt.Errorf("database: %w", err)
+	}
+	defer repo.Close()
+
+	keys := app.NewAdminKeyService(sqlite.NewAdminKeyRepository(repo.DB()))
+	_, secret, err := keys.Create(domain.WithActor(context.Background(), "cli"), fs.Arg(0), domain.Role(*role))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(stdout, secret)
+	return err
+}
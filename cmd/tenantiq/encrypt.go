@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/configcrypt"
+)
+
+// encryptionKeyEnv holds the base64 key of the encrypted configuration
+// values.
+const encryptionKeyEnv = "CONFIG_ENCRYPTION_KEY"
+
+// configBox returns the box of CONFIG_ENCRYPTION_KEY, or nil when it is
+// unset.
+func configBox() (*configcrypt.Box, error) {
+	v := os.Getenv(encryptionKeyEnv)
+	if v == "" {
+		return nil, nil
+	}
+	key, err := configcrypt.ParseKey(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", encryptionKeyEnv, err)
+	}
+	box, err := configcrypt.New(key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", encryptionKeyEnv, err)
+	}
+	return box, nil
+}
+
+// encryptValue implements "tenantiq encrypt-value NAME": it encrypts the
+// value read from stdin for the variable NAME with CONFIG_ENCRYPTION_KEY,
+// printing the enc: value to put in the configuration.
+func encryptValue(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("encrypt-value", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: tenantiq encrypt-value NAME < value\n\nEncrypts the value of the variable NAME with %s.\n", encryptionKeyEnv)
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected the name of the variable")
+	}
+
+	box, err := configBox()
+	if err != nil {
+		return err
+	}
+	if box == nil {
+		return fmt.Errorf("%s is not set", encryptionKeyEnv)
+	}
+	raw, err := io.ReadAll(stdin)
+	if err != nil {
+		return fmt.Errorf("reading value: %w", err)
+	}
+	// Trailing newlines come from echo and heredocs, not the secret.
+	value := strings.TrimRight(string(raw), "\r\n")
+	if value == "" {
+		return errors.New("empty value on stdin")
+	}
+
+	enc, err := box.Encrypt(fs.Arg(0), value)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(stdout, enc)
+	return err
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncryptValue(t *testing.T) {
+	t.Setenv(encryptionKeyEnv, "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=")
+
+	var out bytes.Buffer
+	if err := encryptValue([]string{"BILLING_API_TOKEN"}, strings.NewReader("s3cret\n"), &out); err != nil {
+		t.Fatalf("encryptValue: %v", err)
+	}
+
+	box, err := configBox()
+	if err != nil {
+		t.Fatalf("configBox: %v", err)
+	}
+	got, err := box.Decrypt("BILLING_API_TOKEN", strings.TrimSpace(out.String()))
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if got != "s3cret" {
+		t.Errorf("decrypted %q, want %q without the newline", got, "s3cret")
+	}
+}
+
+func TestEncryptValue_Errors(t *testing.T) {
+	t.Setenv(encryptionKeyEnv, "")
+	if err := encryptValue([]string{"X"}, strings.NewReader("v"), &bytes.Buffer{}); err == nil {
+		t.Error("expected an error without CONFIG_ENCRYPTION_KEY")
+	}
+
+	t.Setenv(encryptionKeyEnv, "c2hvcnQ=")
+	if err := encryptValue([]string{"X"}, strings.NewReader("v"), &bytes.Buffer{}); err == nil {
+		t.Error("expected an error for a short key")
+	}
+
+	t.Setenv(encryptionKeyEnv, "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=")
+	if err := encryptValue(nil, strings.NewReader("v"), &bytes.Buffer{}); err == nil {
+		t.Error("expected an error without a variable name")
+	}
+	if err := encryptValue([]string{"X"}, strings.NewReader("\n"), &bytes.Buffer{}); err == nil {
+		t.Error("expected an error for an empty value")
+	}
+}
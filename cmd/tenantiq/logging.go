@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	otelsetup "github.com/neomorfeo/tenantiq/internal/adapter/otel"
+)
+
+// setupLogging makes the default logger write at LOG_LEVEL, in LOG_FORMAT,
+// to LOG_OUTPUT, with the trace and span of the context on every record.
+// The returned func closes the log file, if any.
+func setupLogging() (func() error, error) {
+	out, err := logOutput(envOrDefault("LOG_OUTPUT", "stderr"))
+	if err != nil {
+		return nil, err
+	}
+	h, err := logHandler(out, envOrDefault("LOG_LEVEL", "info"), envOrDefault("LOG_FORMAT", "text"))
+	if err != nil {
+		out.Close()
+		return nil, err
+	}
+	slog.SetDefault(slog.New(otelsetup.NewTraceHandler(h)))
+	return out.Close, nil
+}
+
+// logHandler returns a handler writing records from level up to w as JSON
+// lines (format "json") or as key=value text (format "text").
+func logHandler(w io.Writer, level, format string) (slog.Handler, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("LOG_LEVEL: %w", err)
+	}
+	opts := &slog.HandlerOptions{Level: l}
+	switch format {
+	case "json":
+		return slog.NewJSONHandler(w, opts), nil
+	case "text":
+		return slog.NewTextHandler(w, opts), nil
+	}
+	return nil, fmt.Errorf("LOG_FORMAT: unknown format %q, want json or text", format)
+}
+
+// logOutput opens the destination of the logs: "stderr", "stdout" or a
+// file, appended to.
+func logOutput(dest string) (io.WriteCloser, error) {
+	switch dest {
+	case "stderr":
+		return nopWriteCloser{os.Stderr}, nil
+	case "stdout":
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	f, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("LOG_OUTPUT: %w", err)
+	}
+	return f, nil
+}
+
+// nopWriteCloser keeps the standard streams open when the logs are closed.
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
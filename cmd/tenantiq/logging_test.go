@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h, err := logHandler(&buf, "warn", "json")
+	if err != nil {
+		t.Fatalf("logHandler: %v", err)
+	}
+	logger := slog.New(h)
+	logger.InfoContext(context.Background(), "dropped")
+	logger.WarnContext(context.Background(), "kept", "tenant_id", "ten_1")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("output %q is not one JSON record: %v", buf.String(), err)
+	}
+	if record["msg"] != "kept" || record["level"] != "WARN" || record["tenant_id"] != "ten_1" {
+		t.Errorf("record = %v, want the warning only", record)
+	}
+
+	buf.Reset()
+	if h, err = logHandler(&buf, "DEBUG", "text"); err != nil {
+		t.Fatalf("logHandler: %v", err)
+	}
+	slog.New(h).Debug("detail", "n", 1)
+	if !strings.Contains(buf.String(), "level=DEBUG msg=detail n=1") {
+		t.Errorf("output = %q, want a debug text record", buf.String())
+	}
+
+	if _, err := logHandler(&buf, "verbose", "text"); err == nil {
+		t.Error("unknown level accepted")
+	}
+	if _, err := logHandler(&buf, "info", "pretty"); err == nil {
+		t.Error("unknown format accepted")
+	}
+}
+
+func TestSetupLogging_File(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	path := filepath.Join(t.TempDir(), "tenantiq.log")
+	t.Setenv("LOG_OUTPUT", path)
+	t.Setenv("LOG_FORMAT", "json")
+	closeLog, err := setupLogging()
+	if err != nil {
+		t.Fatalf("setupLogging: %v", err)
+	}
+	slog.Info("started")
+	if err := closeLog(); err != nil {
+		t.Fatalf("closing log: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading log: %v", err)
+	}
+	if !strings.Contains(string(data), `"msg":"started"`) {
+		t.Errorf("log file = %q, want the JSON record", data)
+	}
+
+	t.Setenv("LOG_OUTPUT", filepath.Join(t.TempDir(), "missing", "tenantiq.log"))
+	if _, err := setupLogging(); err == nil {
+		t.Error("unwritable log file accepted")
+	}
+}
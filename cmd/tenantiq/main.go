@@ -2,11 +2,16 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -15,32 +20,120 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/riandyrn/otelchi"
+	"github.com/riverqueue/river"
 
+	"github.com/neomorfeo/tenantiq/internal/adapter/acme"
+	amqpadapter "github.com/neomorfeo/tenantiq/internal/adapter/amqp"
+	"github.com/neomorfeo/tenantiq/internal/adapter/asyncapi"
+	billingadapter "github.com/neomorfeo/tenantiq/internal/adapter/billing"
+	"github.com/neomorfeo/tenantiq/internal/adapter/configcrypt"
+	"github.com/neomorfeo/tenantiq/internal/adapter/egress"
 	fsmadapter "github.com/neomorfeo/tenantiq/internal/adapter/fsm"
 	handler "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/jwtauth"
+	"github.com/neomorfeo/tenantiq/internal/adapter/kms"
+	"github.com/neomorfeo/tenantiq/internal/adapter/lifecyclefile"
+	"github.com/neomorfeo/tenantiq/internal/adapter/oidc"
 	otelsetup "github.com/neomorfeo/tenantiq/internal/adapter/otel"
+	"github.com/neomorfeo/tenantiq/internal/adapter/planfile"
+	"github.com/neomorfeo/tenantiq/internal/adapter/policyfile"
+	"github.com/neomorfeo/tenantiq/internal/adapter/retentionfile"
 	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	sentryadapter "github.com/neomorfeo/tenantiq/internal/adapter/sentry"
+	"github.com/neomorfeo/tenantiq/internal/adapter/signedurl"
+	"github.com/neomorfeo/tenantiq/internal/adapter/simulator"
+	"github.com/neomorfeo/tenantiq/internal/adapter/specdir"
 	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/adapter/stripe"
+	"github.com/neomorfeo/tenantiq/internal/adapter/wasmhook"
 	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
 )
 
 func main() {
-	if err := run(); err != nil {
+	closeLog, err := setupLogging()
+	if err != nil {
 		slog.Error("fatal", "error", err)
 		os.Exit(1)
 	}
+
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == "support-bundle":
+		err = supportBundle(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "encrypt-value":
+		err = encryptValue(os.Args[2:], os.Stdin, os.Stdout)
+	case len(os.Args) > 1 && os.Args[1] == "create-admin-key":
+		err = createAdminKey(os.Args[2:], os.Stdout)
+	case len(os.Args) > 1 && os.Args[1] == "migrate":
+		err = migrate(os.Args[2:], os.Stdout)
+	default:
+		err = run()
+	}
+	if err != nil {
+		slog.Error("fatal", "error", err)
+	}
+	closeLog()
+	if err != nil {
+		os.Exit(1)
+	}
 }
 
 func run() error {
+	// --- Encrypted configuration (first, so everything reads plaintext) ---
+	box, err := configBox()
+	if err != nil {
+		return err
+	}
+	decrypted, err := configcrypt.DecryptEnv(box)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
 	port := envOrDefault("PORT", "8080")
 	dbPath := envOrDefault("DATABASE_PATH", "tenantiq.db")
 
+	// --- Tenant lifecycle (before anything reads domain.Transitions) ---
+	if path := os.Getenv("LIFECYCLE_FILE"); path != "" {
+		transitions, err := lifecyclefile.Load(path)
+		if err != nil {
+			return fmt.Errorf("LIFECYCLE_FILE: %w", err)
+		}
+		domain.Transitions = transitions
+		slog.Info("tenant lifecycle loaded", "path", path, "statuses", len(domain.Statuses()), "events", len(domain.Events()))
+	}
+
 	// --- OpenTelemetry (first, so TracerProvider is available globally) ---
 	otelCfg := otelsetup.ConfigFromEnv()
+
+	// Error chains leak internals, so they are never exposed in production.
+	debugErrors := os.Getenv("DEBUG_ERRORS") == "true"
+	if debugErrors && otelCfg.Environment == "production" {
+		return fmt.Errorf("DEBUG_ERRORS must not be enabled in production")
+	}
+	// The management API requires admin keys unless explicitly opened, for
+	// development only.
+	apiAuth := os.Getenv("API_AUTH") != "false"
+	if !apiAuth && otelCfg.Environment == "production" {
+		return fmt.Errorf("API_AUTH must not be disabled in production")
+	}
+
 	providers, err := otelsetup.Setup(context.Background(), otelCfg)
 	if err != nil {
 		return fmt.Errorf("otel: %w", err)
 	}
+	if len(decrypted) > 0 {
+		slog.Info("encrypted configuration decrypted", "variables", decrypted)
+	}
+
+	// --- Error reporting (optional) ---
+	var reporter *sentryadapter.Reporter
+	if sentryCfg := sentryadapter.ConfigFromEnv(otelCfg.Environment, otelCfg.ServiceVersion); sentryCfg.Enabled() {
+		reporter, err = sentryadapter.New(sentryCfg)
+		if err != nil {
+			return fmt.Errorf("sentry: %w", err)
+		}
+		slog.Info("error reporting enabled", "environment", sentryCfg.Environment)
+	}
 
 	// --- Adapters (out) ---
 	db, err := otelsetup.OpenDB(dbPath)
@@ -54,37 +147,762 @@ func run() error {
 		return fmt.Errorf("repository: %w", err)
 	}
 
+	// --- Outbound requests, through the egress proxy when configured ---
+	outbound, err := egress.New(egress.ConfigFromEnv())
+	if err != nil {
+		return err
+	}
+	if e := outbound.Describe(); e.ProxyURL != "" {
+		slog.Info("egress proxy enabled", "proxy", e.ProxyURL, "no_proxy", e.NoProxy)
+	}
+
+	// --- WASM hooks (optional) ---
+	// Customer policy and payload rules, sandboxed, per hook point.
+	wasmHooks := &wasmhook.Hooks{}
+	if path := os.Getenv("WASM_HOOKS_FILE"); path != "" {
+		if wasmHooks, err = wasmhook.Load(context.Background(), path); err != nil {
+			return fmt.Errorf("WASM_HOOKS_FILE: %w", err)
+		}
+		defer wasmHooks.Close(context.Background())
+		slog.Info("wasm hooks loaded", "path", path, "create", len(wasmHooks.Create), "event", len(wasmHooks.Events))
+	}
+
 	// --- River (async job queue) ---
-	riverClient, err := riveradapter.Setup(context.Background(), db)
+	webhookTimeout, err := time.ParseDuration(envOrDefault("WEBHOOK_TIMEOUT", "10s"))
 	if err != nil {
-		return fmt.Errorf("river: %w", err)
+		return fmt.Errorf("WEBHOOK_TIMEOUT: %w", err)
 	}
-	if err := riverClient.Start(context.Background()); err != nil {
-		return fmt.Errorf("river start: %w", err)
+	eventSource := envOrDefault("EVENT_SOURCE", riveradapter.DefaultEventSource)
+	webhooks := app.NewWebhookService(sqlite.NewWebhookRepository(db))
+	webhookClient := outbound.Client("webhooks", webhookTimeout)
+	digestInterval, err := time.ParseDuration(envOrDefault("WEBHOOK_DIGEST_INTERVAL", "5m"))
+	if err != nil {
+		return fmt.Errorf("WEBHOOK_DIGEST_INTERVAL: %w", err)
+	}
+	digests := app.NewDigestService(sqlite.NewWebhookDigestRepository(db), webhooks)
+	workersOpts := []riveradapter.WorkersOption{
+		riveradapter.WithWebhooks(webhooks, webhookClient, wasmHooks.Events...),
+		riveradapter.WithWebhookDigests(digests, webhooks, webhookClient, eventSource),
+	}
+	// Stripe syncs follow the tenant events; their worker is added once the
+	// service exists.
+	stripeKey := os.Getenv("STRIPE_API_KEY")
+	if stripeKey != "" {
+		workersOpts = append(workersOpts, riveradapter.WithEventFollower(stripe.Follow))
+	}
+	workers := riveradapter.NewWorkers(workersOpts...)
+	jobMetrics, err := otelsetup.NewJobMetrics()
+	if err != nil {
+		return err
+	}
+	riverOpts := []riveradapter.SetupOption{riveradapter.WithJobObserver(jobMetrics)}
+	if reporter != nil {
+		riverOpts = append(riverOpts, riveradapter.WithErrorHandler(reporter))
+	}
+
+	// Finished jobs double as the event and webhook delivery logs, so River
+	// keeps them as long as the retention policy asks, and until they are
+	// archived when archival is on.
+	var retention domain.RetentionPolicy
+	var jobRetention time.Duration
+	if path := os.Getenv("RETENTION_FILE"); path != "" {
+		if retention, err = retentionfile.Load(path); err != nil {
+			return fmt.Errorf("RETENTION_FILE: %w", err)
+		}
+		jobRetention = max(retention[domain.RecordEvents], retention[domain.RecordWebhookDeliveries])
+	}
+	var archiveAfter, archiveInterval time.Duration
+	if v := os.Getenv("ARCHIVE_AFTER"); v != "" {
+		if archiveAfter, err = time.ParseDuration(v); err != nil {
+			return fmt.Errorf("ARCHIVE_AFTER: %w", err)
+		}
+		if archiveAfter <= 0 {
+			return fmt.Errorf("ARCHIVE_AFTER must be positive, got %s", archiveAfter)
+		}
+		if archiveInterval, err = time.ParseDuration(envOrDefault("ARCHIVE_INTERVAL", "24h")); err != nil {
+			return fmt.Errorf("ARCHIVE_INTERVAL: %w", err)
+		}
+		// River's cleaner must not delete a job before a run archives it.
+		jobRetention = max(jobRetention, archiveAfter+archiveInterval)
+	}
+	if jobRetention > 0 {
+		riverOpts = append(riverOpts, riveradapter.WithJobRetention(jobRetention))
+	}
+	riverClient, err := riveradapter.Setup(context.Background(), db, workers, riverOpts...)
+	if err != nil {
+		return fmt.Errorf("river: %w", err)
 	}
+	riverClient.PeriodicJobs().Add(riveradapter.WebhookDigestPeriodicJob(digestInterval))
 
 	// Wrap adapters with tracing decorators.
 	repo := otelsetup.NewTracingRepository(sqliteRepo)
-	publisher := otelsetup.NewTracingPublisher(riveradapter.NewPublisher(riverClient))
+	riverPublisher := riveradapter.NewPublisher(riverClient, riveradapter.WithEventSource(eventSource))
+
+	// Events fan out to River, the AMQP exchange when configured and the
+	// WebSocket feed. Targets listed in EVENT_OPTIONAL_TARGETS are best
+	// effort: their failures are logged and counted, not retried.
+	var targets []app.PublishTarget
+	optionalTargets := strings.Split(os.Getenv("EVENT_OPTIONAL_TARGETS"), ",")
+	addTarget := func(name string, p domain.EventPublisher) {
+		targets = append(targets, app.PublishTarget{Name: name, Publisher: p, Optional: slices.Contains(optionalTargets, name)})
+	}
+	addTarget("river", riverPublisher)
+	publishMetrics, err := otelsetup.NewPublishMetrics()
+	if err != nil {
+		return err
+	}
+	observePublish := func(ctx context.Context, target string, event domain.Event, elapsed time.Duration, err error) {
+		publishMetrics.Observe(ctx, target, event, elapsed, err)
+		if err != nil {
+			slog.WarnContext(ctx, "event publish failed", "target", target, "event", event, "error", err)
+		}
+	}
+
+	// Events are stored through the outbox by default, or inserted into
+	// River in the tenant's transaction (EVENT_DELIVERY=transaction). The
+	// latter saves the relay's delay but cannot include an AMQP exchange,
+	// which is outside the database.
+	eventDelivery := envOrDefault("EVENT_DELIVERY", "outbox")
+	switch eventDelivery {
+	case "outbox":
+	case "transaction":
+		if os.Getenv("AMQP_URL") != "" {
+			return errors.New("EVENT_DELIVERY=transaction cannot be combined with AMQP_URL")
+		}
+	default:
+		return fmt.Errorf("EVENT_DELIVERY must be outbox or transaction, got %q", eventDelivery)
+	}
+
+	// Published events also go to an AMQP exchange when configured.
+	if url := os.Getenv("AMQP_URL"); url != "" {
+		confirmTimeout, err := time.ParseDuration(envOrDefault("AMQP_CONFIRM_TIMEOUT", "5s"))
+		if err != nil {
+			return fmt.Errorf("AMQP_CONFIRM_TIMEOUT: %w", err)
+		}
+		exchange := envOrDefault("AMQP_EXCHANGE", "tenantiq.events")
+		broker, err := amqpadapter.NewBroker(amqpadapter.Config{
+			URL:            url,
+			Exchange:       exchange,
+			ExchangeType:   envOrDefault("AMQP_EXCHANGE_TYPE", amqpadapter.DefaultExchangeType),
+			RoutingKey:     envOrDefault("AMQP_ROUTING_KEY", amqpadapter.DefaultRoutingKey),
+			Source:         eventSource,
+			ConfirmTimeout: confirmTimeout,
+			Transforms:     wasmHooks.Events,
+		})
+		if err != nil {
+			return err
+		}
+		defer broker.Close()
+		addTarget("amqp", broker)
+		// AMQP consumers provision real infrastructure: simulated tenants
+		// must not reach them.
+		targets[len(targets)-1].SkipSimulated = true
+		slog.Info("AMQP publishing enabled", "exchange", exchange)
+	}
+
+	// The feed never fails, so it cannot hold back the other targets.
+	feed := handler.NewEventFeed()
+	addTarget("feed", feed)
+	for _, name := range optionalTargets {
+		if name != "" && !slices.ContainsFunc(targets, func(t app.PublishTarget) bool { return t.Name == name }) {
+			return fmt.Errorf("EVENT_OPTIONAL_TARGETS: unknown or disabled target %q", name)
+		}
+	}
+	// Event storms of a single tenant are throttled and identical
+	// consecutive events coalesced; lifecycle events are never dropped.
+	var throttle app.ThrottleConfig
+	if throttle.PerMinute, err = envInt("EVENT_RATE_PER_MINUTE", 60); err != nil {
+		return err
+	}
+	if throttle.Burst, err = envInt("EVENT_BURST", 0); err != nil {
+		return err
+	}
+	if throttle.CoalesceWindow, err = time.ParseDuration(envOrDefault("EVENT_COALESCE_WINDOW", "10s")); err != nil {
+		return fmt.Errorf("EVENT_COALESCE_WINDOW: %w", err)
+	}
+	eventThrottle := app.NewEventThrottle(throttle)
+
+	// fanOut publishes to the targets, with river in place of the River
+	// publisher (e.g. bound to the tenant's transaction).
+	fanOut := func(river domain.EventPublisher) domain.EventPublisher {
+		all := slices.Clone(targets)
+		all[0].Publisher = river
+		return eventThrottle.Publisher(otelsetup.NewTracingPublisher(app.NewFanOutPublisher(all, observePublish)))
+	}
+	publisher := fanOut(riverPublisher)
 
 	// --- Application ---
-	validator := fsmadapter.New()
-	svc := app.NewTenantService(repo, publisher, validator)
+	maxDisrupted, err := strconv.ParseFloat(envOrDefault("GUARDRAIL_MAX_DISRUPTED_PERCENT", "10"), 64)
+	if err != nil {
+		return fmt.Errorf("GUARDRAIL_MAX_DISRUPTED_PERCENT: %w", err)
+	}
+
+	var policies domain.TransitionPolicies
+	if path := os.Getenv("TRANSITION_POLICIES_FILE"); path != "" {
+		if policies, err = policyfile.Load(path); err != nil {
+			return fmt.Errorf("TRANSITION_POLICIES_FILE: %w", err)
+		}
+		slog.Info("transition policies loaded", "path", path, "count", len(policies))
+	}
+
+	var planCatalog domain.PlanCatalog
+	if path := os.Getenv("PLAN_QUOTAS_FILE"); path != "" {
+		if planCatalog, err = planfile.Load(path); err != nil {
+			return fmt.Errorf("PLAN_QUOTAS_FILE: %w", err)
+		}
+	}
+
+	// Tenants may only be on a defined plan. The plans of the quota
+	// catalog are defined at startup when missing, so the catalog and the
+	// plans table cannot disagree on which plans exist.
+	plans := app.NewPlanService(sqlite.NewPlanRepository(db), repo)
+	if n, err := plans.Ensure(context.Background(), planCatalog); err != nil {
+		return fmt.Errorf("defining catalog plans: %w", err)
+	} else if n > 0 {
+		slog.Info("catalog plans defined", "count", n)
+	}
+	blueprints := app.NewBlueprintService(sqlite.NewBlueprintRepository(db), plans)
+
+	// Tenant changes and their events are stored in one transaction; the
+	// relay publishes the events afterwards, so none is lost in a crash. It
+	// runs in transaction mode too, to publish what an earlier run recorded.
+	outboxInterval, err := time.ParseDuration(envOrDefault("OUTBOX_POLL_INTERVAL", "1s"))
+	if err != nil {
+		return fmt.Errorf("OUTBOX_POLL_INTERVAL: %w", err)
+	}
+	outbox := sqlite.NewOutbox(db)
+	relay := app.NewOutboxRelay(outbox, publisher)
+
+	operations := app.NewOperationService(sqlite.NewOperationRepository(db))
+	auditLog := sqlite.NewAuditLog(db)
+	simulationDelay, err := time.ParseDuration(envOrDefault("SIMULATION_STEP_DELAY", "0s"))
+	if err != nil {
+		return fmt.Errorf("SIMULATION_STEP_DELAY: %w", err)
+	}
+	opts := []app.Option{
+		app.WithGuardrail(domain.Guardrail{MaxDisruptedPercent: maxDisrupted}),
+		app.WithTransitionPolicies(policies),
+		app.WithIDGenerator(app.NewIDGenerator(envOrDefault("TENANT_ID_PREFIX", app.DefaultTenantIDPrefix))),
+		app.WithStatusHistory(sqlite.NewStatusHistoryRepository(db)),
+		app.WithServiceTracer(otelsetup.NewTracingService()),
+		app.WithAuditLogger(otelsetup.NewTracingAuditLogger(otelsetup.NewStreamingAuditLogger(auditLog))),
+		app.WithAuditReader(auditLog),
+		app.WithChangeFeed(auditLog),
+		app.WithAsyncOperations(operations, riveradapter.NewOperationQueue(riverClient)),
+		app.WithMaintenanceWindows(sqlite.NewMaintenanceRepository(db)),
+		app.WithPlanValidation(plans),
+		app.WithBlueprints(blueprints),
+		app.WithDomains(sqlite.NewCertificateRepository(db)),
+		app.WithSimulator(simulator.New(simulator.WithDelay(simulationDelay))),
+		app.WithCreateHooks(wasmHooks.Create...),
+	}
+	if eventDelivery == "transaction" {
+		opts = append(opts, app.WithUnitOfWork(sqlite.NewUnitOfWork(db, func(tx *sql.Tx) domain.EventPublisher {
+			return fanOut(riverPublisher.InTx(tx))
+		})))
+	} else {
+		opts = append(opts, app.WithOutbox(outbox, relay))
+	}
+	// Usage is always recorded: plan quotas are enforced against it, and
+	// the suggestion job matches it against the catalog when one is set.
+	usage := sqlite.NewUsageRepository(db)
+	opts = append(opts,
+		app.WithPlanSuggestions(planCatalog, usage),
+		app.WithQuotaChecker(app.NewPlanQuotaChecker(sqlite.NewPlanRepository(db), usage)),
+		app.WithStatusCounter(sqlite.NewStatusCounter(db)),
+	)
+	opts = append(opts,
+		app.WithRateLimits(planCatalog, sqlite.NewRateLimitRepository(db)),
+		app.WithIPAllowlists(sqlite.NewIPAllowlistRepository(db)),
+	)
+	// Business metrics: creations, transitions and slug conflicts as they
+	// happen, and the tenants per status and plan counted periodically.
+	tenantMetrics, err := otelsetup.NewTenantMetrics()
+	if err != nil {
+		return err
+	}
+	opts = append(opts, app.WithTenantObserver(tenantMetrics))
+	tenantCountInterval, err := time.ParseDuration(envOrDefault("TENANT_METRICS_INTERVAL", "1m"))
+	if err != nil {
+		return fmt.Errorf("TENANT_METRICS_INTERVAL: %w", err)
+	}
+	countTenants := func(ctx context.Context) ([]domain.TenantCount, error) {
+		return sqlite.CountByStatusAndPlan(ctx, db)
+	}
+	if err := otelsetup.RegisterTenantCounts(countTenants, tenantCountInterval); err != nil {
+		return fmt.Errorf("tenant metrics: %w", err)
+	}
+	// Transition guards hold events back on more than the tenant's status,
+	// e.g. TRANSITION_GUARDS=delete=no_active_projects,reactivate=billing_current.
+	guards, err := fsmadapter.ParseGuards(os.Getenv("TRANSITION_GUARDS"),
+		app.NewNoActiveProjectsGuard(usage),
+		app.NewBillingCurrentGuard(sqlite.NewDunningRepository(db)),
+	)
+	if err != nil {
+		return fmt.Errorf("TRANSITION_GUARDS: %w", err)
+	}
+	validator := otelsetup.NewTracingValidator(fsmadapter.NewFromTransitions(domain.Transitions, guards...))
+	svc := app.NewTenantService(repo, publisher, validator, opts...)
+
+	// Requests made with tenant API keys are held to the tenant's rate
+	// limit; the refused ones are counted per plan.
+	throttleMetrics, err := otelsetup.NewThrottleMetrics()
+	if err != nil {
+		return err
+	}
+	requestLimiter := app.NewRequestLimiter(svc, throttleMetrics.Observe)
+
+	river.AddWorker(workers, riveradapter.NewOperationWorker(svc, operations))
+
+	// --- Queue health and autoscaling signal ---
+	queueMonitor := riveradapter.NewQueueMonitor(db)
+
+	maxQueueDepth, err := envInt("READYZ_MAX_QUEUE_DEPTH", 1000)
+	if err != nil {
+		return err
+	}
+	maxJobAge, err := time.ParseDuration(envOrDefault("READYZ_MAX_JOB_AGE", "5m"))
+	if err != nil {
+		return fmt.Errorf("READYZ_MAX_JOB_AGE: %w", err)
+	}
+	queueThresholds := handler.QueueThresholds{MaxAvailable: maxQueueDepth, MaxOldestAge: maxJobAge}
+
+	var scaling domain.ScalingPolicy
+	if scaling.JobsPerWorker, err = envInt("SCALING_JOBS_PER_WORKER", 10); err != nil {
+		return err
+	}
+	if scaling.MinWorkers, err = envInt("SCALING_MIN_WORKERS", 1); err != nil {
+		return err
+	}
+	if scaling.MaxWorkers, err = envInt("SCALING_MAX_WORKERS", 10); err != nil {
+		return err
+	}
+	if err := otelsetup.RegisterQueueMetrics(queueMonitor, scaling); err != nil {
+		return fmt.Errorf("queue metrics: %w", err)
+	}
+
+	// --- Declarative spec sync (optional) ---
+	if specDir := os.Getenv("SPEC_SYNC_DIR"); specDir != "" {
+		interval, err := time.ParseDuration(envOrDefault("SPEC_SYNC_INTERVAL", "5m"))
+		if err != nil {
+			return fmt.Errorf("SPEC_SYNC_INTERVAL: %w", err)
+		}
+		dryRun := os.Getenv("SPEC_SYNC_DRY_RUN") == "true"
+		// Only whoever configures the server can force the sync.
+		force := os.Getenv("SPEC_SYNC_FORCE") == "true"
+
+		river.AddWorker(workers, riveradapter.NewSpecSyncWorker(specdir.New(specDir), svc))
+		riverClient.PeriodicJobs().Add(riveradapter.SpecSyncPeriodicJob(interval, dryRun, force))
+		slog.Info("spec sync enabled", "dir", specDir, "interval", interval, "dry_run", dryRun, "force", force)
+	}
+
+	// --- Usage-based plan suggestions (optional) ---
+	if planCatalog != nil {
+		interval, err := time.ParseDuration(envOrDefault("PLAN_SUGGESTION_INTERVAL", "24h"))
+		if err != nil {
+			return fmt.Errorf("PLAN_SUGGESTION_INTERVAL: %w", err)
+		}
+
+		river.AddWorker(workers, riveradapter.NewPlanSuggestionWorker(svc))
+		riverClient.PeriodicJobs().Add(riveradapter.PlanSuggestionPeriodicJob(interval))
+		slog.Info("plan suggestions enabled", "plans", len(planCatalog), "interval", interval)
+	}
+
+	// --- Billing reconciliation (optional) ---
+	var billing *app.BillingService
+	if url := os.Getenv("BILLING_SUBSCRIPTIONS_URL"); url != "" {
+		interval, err := time.ParseDuration(envOrDefault("BILLING_RECONCILIATION_INTERVAL", "24h"))
+		if err != nil {
+			return fmt.Errorf("BILLING_RECONCILIATION_INTERVAL: %w", err)
+		}
+
+		provider := billingadapter.NewProvider(url, os.Getenv("BILLING_API_TOKEN"), outbound.Client("billing", 30*time.Second))
+		billing = app.NewBillingService(provider, svc)
+		river.AddWorker(workers, riveradapter.NewBillingReconciliationWorker(billing))
+		riverClient.PeriodicJobs().Add(riveradapter.BillingReconciliationPeriodicJob(interval))
+		slog.Info("billing reconciliation enabled", "interval", interval)
+	}
+
+	// --- Dunning from billing payment webhooks (optional) ---
+	var dunning *app.DunningService
+	billingWebhookSecret := os.Getenv("BILLING_WEBHOOK_SECRET")
+	if billingWebhookSecret != "" {
+		var policy domain.DunningPolicy
+		if policy.WarningPeriod, err = time.ParseDuration(envOrDefault("DUNNING_WARNING_PERIOD", "72h")); err != nil {
+			return fmt.Errorf("DUNNING_WARNING_PERIOD: %w", err)
+		}
+		if policy.GracePeriod, err = time.ParseDuration(envOrDefault("DUNNING_GRACE_PERIOD", "168h")); err != nil {
+			return fmt.Errorf("DUNNING_GRACE_PERIOD: %w", err)
+		}
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+		interval, err := time.ParseDuration(envOrDefault("DUNNING_INTERVAL", "1h"))
+		if err != nil {
+			return fmt.Errorf("DUNNING_INTERVAL: %w", err)
+		}
+
+		dunning = app.NewDunningService(sqlite.NewDunningRepository(db), svc, policy)
+		river.AddWorker(workers, riveradapter.NewDunningWorker(dunning))
+		riverClient.PeriodicJobs().Add(riveradapter.DunningPeriodicJob(interval))
+		slog.Info("dunning enabled",
+			"warning_period", policy.WarningPeriod,
+			"grace_period", policy.GracePeriod,
+			"interval", interval,
+		)
+	}
+
+	// --- Stripe subscription sync (optional) ---
+	if stripeKey != "" {
+		prices, err := stripe.ParsePrices(os.Getenv("STRIPE_PRICES"))
+		if err != nil {
+			return fmt.Errorf("STRIPE_PRICES: %w", err)
+		}
+		client := stripe.NewClient(envOrDefault("STRIPE_API_URL", stripe.DefaultBaseURL), stripeKey, outbound.Client("stripe", 30*time.Second))
+		river.AddWorker(workers, stripe.NewSyncWorker(client, svc, prices))
+		slog.Info("stripe sync enabled", "prices", len(prices))
+	}
+
+	// --- TLS certificates for tenant domains over ACME (optional) ---
+	var certificates *app.CertificateService
+	var certIssuer *acme.Issuer
+	if pemKey := os.Getenv("ACME_ACCOUNT_KEY"); pemKey != "" {
+		key, err := acme.ParseAccountKey([]byte(pemKey))
+		if err != nil {
+			return fmt.Errorf("ACME_ACCOUNT_KEY: %w", err)
+		}
+		interval, err := time.ParseDuration(envOrDefault("ACME_RENEWAL_INTERVAL", "10m"))
+		if err != nil {
+			return fmt.Errorf("ACME_RENEWAL_INTERVAL: %w", err)
+		}
+		certIssuer, err = acme.New(acme.Config{
+			DirectoryURL: os.Getenv("ACME_DIRECTORY_URL"),
+			AccountKey:   key,
+			Email:        os.Getenv("ACME_EMAIL"),
+			CertDir:      envOrDefault("ACME_CERT_DIR", "certs"),
+			HTTPClient:   outbound.Client("acme", 30*time.Second),
+		})
+		if err != nil {
+			return fmt.Errorf("acme: %w", err)
+		}
+
+		certificates = app.NewCertificateService(sqlite.NewCertificateRepository(db), certIssuer, svc)
+		river.AddWorker(workers, riveradapter.NewCertificateRenewalWorker(certificates))
+		riverClient.PeriodicJobs().Add(riveradapter.CertificateRenewalPeriodicJob(interval))
+		slog.Info("acme certificates enabled", "interval", interval)
+	}
+
+	// --- Data retention (optional) ---
+	if retention != nil {
+		interval, err := time.ParseDuration(envOrDefault("RETENTION_INTERVAL", "24h"))
+		if err != nil {
+			return fmt.Errorf("RETENTION_INTERVAL: %w", err)
+		}
+		dryRun := os.Getenv("RETENTION_DRY_RUN") == "true"
+
+		pruners := map[domain.RecordType]domain.Pruner{
+			domain.RecordAudit:             auditLog,
+			domain.RecordStatusHistory:     sqlite.NewStatusHistoryRepository(db),
+			domain.RecordOperations:        sqlite.NewOperationRepository(db),
+			domain.RecordEvents:            riveradapter.NewJobPruner(riverClient, db, riveradapter.EventJobArgs{}.Kind()),
+			domain.RecordWebhookDeliveries: riveradapter.NewJobPruner(riverClient, db, riveradapter.WebhookDeliveryArgs{}.Kind()),
+		}
+		river.AddWorker(workers, riveradapter.NewRetentionWorker(app.NewRetentionService(retention, pruners)))
+		riverClient.PeriodicJobs().Add(riveradapter.RetentionPeriodicJob(interval, dryRun))
+		slog.Info("data retention enabled", "records", len(retention), "interval", interval, "dry_run", dryRun)
+	}
+
+	// --- Archival to cold tables (optional) ---
+	if archiveAfter > 0 {
+		dryRun := os.Getenv("ARCHIVE_DRY_RUN") == "true"
+
+		archivers := map[domain.RecordType]domain.Archiver{
+			domain.RecordAudit:             auditLog,
+			domain.RecordStatusHistory:     sqlite.NewStatusHistoryRepository(db),
+			domain.RecordEvents:            riveradapter.NewJobArchiver(db, riveradapter.EventJobArgs{}.Kind()),
+			domain.RecordWebhookDeliveries: riveradapter.NewJobArchiver(db, riveradapter.WebhookDeliveryArgs{}.Kind()),
+		}
+		river.AddWorker(workers, riveradapter.NewArchiveWorker(app.NewArchiveService(archiveAfter, archivers)))
+		riverClient.PeriodicJobs().Add(riveradapter.ArchivePeriodicJob(archiveInterval, dryRun))
+		slog.Info("archival enabled", "after", archiveAfter, "interval", archiveInterval, "dry_run", dryRun)
+	}
+
+	// --- Hard purge of deleted tenants (optional) ---
+	if v := os.Getenv("PURGE_DELETED_AFTER"); v != "" {
+		after, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("PURGE_DELETED_AFTER: %w", err)
+		}
+		if after <= 0 {
+			return fmt.Errorf("PURGE_DELETED_AFTER must be positive, got %s", after)
+		}
+		interval, err := time.ParseDuration(envOrDefault("PURGE_INTERVAL", "24h"))
+		if err != nil {
+			return fmt.Errorf("PURGE_INTERVAL: %w", err)
+		}
+		dryRun := os.Getenv("PURGE_DRY_RUN") == "true"
+
+		river.AddWorker(workers, riveradapter.NewPurgeWorker(app.NewPurgeService(sqliteRepo, svc, after)))
+		riverClient.PeriodicJobs().Add(riveradapter.PurgePeriodicJob(interval, dryRun))
+		slog.Info("tenant purge enabled", "after", after, "interval", interval, "dry_run", dryRun)
+	}
+
+	// --- Trial expiry ---
+	{
+		interval, err := time.ParseDuration(envOrDefault("TRIAL_EXPIRY_INTERVAL", "1h"))
+		if err != nil {
+			return fmt.Errorf("TRIAL_EXPIRY_INTERVAL: %w", err)
+		}
+		downgradeTo := os.Getenv("TRIAL_EXPIRED_PLAN")
+		dryRun := os.Getenv("TRIAL_EXPIRY_DRY_RUN") == "true"
+
+		river.AddWorker(workers, riveradapter.NewTrialExpiryWorker(app.NewTrialService(svc, downgradeTo)))
+		riverClient.PeriodicJobs().Add(riveradapter.TrialExpiryPeriodicJob(interval, dryRun))
+		slog.Info("trial expiry enabled", "downgrade_to", downgradeTo, "interval", interval, "dry_run", dryRun)
+	}
+
+	// --- Status counter reconciliation ---
+	{
+		interval, err := time.ParseDuration(envOrDefault("COUNTER_RECONCILIATION_INTERVAL", "1h"))
+		if err != nil {
+			return fmt.Errorf("COUNTER_RECONCILIATION_INTERVAL: %w", err)
+		}
+
+		river.AddWorker(workers, riveradapter.NewCounterReconciliationWorker(svc))
+		riverClient.PeriodicJobs().Add(riveradapter.CounterReconciliationPeriodicJob(interval))
+		slog.Info("status counter reconciliation enabled", "interval", interval)
+	}
+
+	// Workers are registered; start processing jobs.
+	if err := riverClient.Start(context.Background()); err != nil {
+		return fmt.Errorf("river start: %w", err)
+	}
+
+	// --- Read-only mode ---
+	// Enabled after River starts: pausing the jobs needs the queue River
+	// registers on start.
+	readOnly := app.NewReadOnlySwitch(sqlite.NewReadOnlyRepository(db), riveradapter.NewJobPauser(riverClient))
+	if os.Getenv("READ_ONLY") == "true" {
+		if _, err := readOnly.Set(context.Background(), true, os.Getenv("READ_ONLY_REASON")); err != nil {
+			return fmt.Errorf("READ_ONLY: %w", err)
+		}
+	}
+
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		relay.Run(relayCtx, outboxInterval, func(err error) {
+			slog.ErrorContext(relayCtx, "outbox relay error", "error", err)
+		})
+	}()
+
+	// --- Signed URLs for public links (optional) ---
+	var signer *signedurl.Signer
+	if key := os.Getenv("SIGNED_URL_KEY"); key != "" {
+		if signer, err = signedurl.New([]byte(key), sqlite.NewNonceStore(db)); err != nil {
+			return fmt.Errorf("SIGNED_URL_KEY: %w", err)
+		}
+	}
+	signedURLMaxTTL, err := time.ParseDuration(envOrDefault("SIGNED_URL_MAX_TTL", "168h"))
+	if err != nil {
+		return fmt.Errorf("SIGNED_URL_MAX_TTL: %w", err)
+	}
+
+	// --- Tenant encryption keys and secrets (optional) ---
+	// Secrets are sealed with a key of the local keyring: the one the
+	// tenant brought, or ENCRYPTION_DEFAULT_KEY.
+	var encryption *app.EncryptionService
+	if keys := os.Getenv("KMS_LOCAL_KEYS"); keys != "" {
+		keyring, err := kms.Parse(keys)
+		if err != nil {
+			return fmt.Errorf("KMS_LOCAL_KEYS: %w", err)
+		}
+		defaultKey := envOrDefault("ENCRYPTION_DEFAULT_KEY", kms.RefPrefix+"default")
+		if _, err := keyring.Wrap(context.Background(), defaultKey, make([]byte, kms.KeyLength)); err != nil {
+			return fmt.Errorf("ENCRYPTION_DEFAULT_KEY: %w", err)
+		}
+		encryption = app.NewEncryptionService(sqlite.NewEncryptionKeyRepository(db), sqlite.NewSecretRepository(db), keyring, defaultKey, svc)
+	}
+
+	// Behind a load balancer, clients are told apart by the X-Forwarded-For
+	// of the proxies in TRUSTED_PROXIES, for the tenants' IP allowlists.
+	var trustedProxies domain.IPAllowlist
+	if v := os.Getenv("TRUSTED_PROXIES"); v != "" {
+		if trustedProxies, err = domain.ParseIPAllowlist(strings.Split(v, ",")); err != nil {
+			return fmt.Errorf("TRUSTED_PROXIES: %w", err)
+		}
+	}
+	importKey := os.Getenv("IMPORT_API_KEY")
+	if importKey != "" && len(importKey) < minAPIKeyLength {
+		return fmt.Errorf("IMPORT_API_KEY must be at least %d bytes", minAPIKeyLength)
+	}
 
 	// --- Adapters (in) ---
 	router := chi.NewMux()
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.RequestID)
 	router.Use(otelchi.Middleware("tenantiq"))
+	router.Use(otelsetup.AccessLog())
+	if reporter != nil {
+		// Inside Recoverer: reports the panic, then lets Recoverer answer 500.
+		router.Use(reporter.Middleware)
+	}
+	serverCfg, err := handler.ServerConfigFromEnv(port)
+	if err != nil {
+		return err
+	}
+	router.Use(handler.LimitBody(serverCfg.MaxBodyBytes))
+	cors, err := handler.CORSPolicyFromEnv()
+	if err != nil {
+		return err
+	}
+	if cors.Enabled() {
+		// Before authentication: preflights carry no credentials.
+		router.Use(handler.CORS(cors))
+		slog.Info("CORS enabled", "origins", cors.AllowedOrigins, "credentials", cors.AllowCredentials)
+	}
 
 	api := humachi.New(router, huma.DefaultConfig("tenantiq", "0.1.0"))
-	handler.Register(api, svc)
+	latencyMetrics, err := otelsetup.NewLatencyMetrics()
+	if err != nil {
+		return err
+	}
+	resellers := app.NewResellerService(sqlite.NewResellerRepository(db), svc)
+	handlerOpts := []handler.Option{
+		handler.WithDebugErrors(debugErrors),
+		handler.WithMaxBodyBytes(serverCfg.MaxBodyBytes),
+		handler.WithOperations(operations),
+		handler.WithResellers(resellers),
+		handler.WithWebhooks(webhooks),
+		handler.WithPlans(plans),
+		handler.WithBlueprints(blueprints),
+		handler.WithMembers(app.NewMemberService(sqlite.NewMemberRepository(db), svc)),
+		handler.WithAPIKeys(app.NewAPIKeyService(sqlite.NewAPIKeyRepository(db), svc)),
+		handler.WithRequestLimits(requestLimiter),
+		handler.WithLatencyObserver(latencyMetrics.Observe),
+		handler.WithTrustedProxies(trustedProxies...),
+		handler.WithReadOnly(readOnly),
+	}
+	var authOpts []handler.Option
+	if apiAuth {
+		defaultRole, err := domain.ParseRole(envOrDefault("JWT_DEFAULT_ROLE", string(domain.RoleViewer)))
+		if err != nil {
+			return fmt.Errorf("JWT_DEFAULT_ROLE: %w", err)
+		}
+		authOpts = append(authOpts,
+			handler.WithAuthentication(app.NewAdminKeyService(sqlite.NewAdminKeyRepository(db))),
+			handler.WithRoles(envOrDefault("JWT_ROLE_CLAIM", "roles"), defaultRole),
+		)
+		jwtCfg := jwtauth.ConfigFromEnv()
+		oidcCfg, err := oidc.ConfigFromEnv()
+		if err != nil {
+			return err
+		}
+		if oidcCfg.Enabled() {
+			discoverCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			login, err := oidc.New(discoverCtx, oidcCfg, outbound.Client("oidc", 10*time.Second))
+			cancel()
+			if err != nil {
+				return err
+			}
+			login.Mount(router)
+			authOpts = append(authOpts, handler.WithSessions(oidc.SessionCookie, login.Sessions()))
+			// API calls present the issuer's access tokens, verified with
+			// its published keys unless configured otherwise.
+			if !jwtCfg.Enabled() {
+				jwtCfg.JWKSURL = login.Provider().JWKSURI
+				jwtCfg.Issuer = login.Provider().Issuer
+				if jwtCfg.Audience == "" {
+					jwtCfg.Audience = oidcCfg.ClientID
+				}
+			}
+			slog.Info("oidc logins enabled", "issuer", login.Provider().Issuer)
+		}
+		if jwtCfg.Enabled() {
+			verifier, err := jwtauth.New(jwtCfg, outbound.Client("jwks", 10*time.Second))
+			if err != nil {
+				return err
+			}
+			authOpts = append(authOpts, handler.WithTokenAuthentication(verifier, jwtCfg.ActorClaim))
+			slog.Info("token authentication enabled", "jwks", jwtCfg.JWKSURL != "", "issuer", jwtCfg.Issuer)
+		}
+		handlerOpts = append(handlerOpts, authOpts...)
+	} else {
+		slog.Warn("API authentication disabled: the management API is open to anyone reaching it")
+	}
+	if billing != nil {
+		handlerOpts = append(handlerOpts, handler.WithBilling(billing))
+	}
+	if dunning != nil {
+		handlerOpts = append(handlerOpts, handler.WithDunning(dunning, billingWebhookSecret))
+	}
+	if certificates != nil {
+		handlerOpts = append(handlerOpts, handler.WithCertificates(certificates))
+	}
+	if signer != nil {
+		handlerOpts = append(handlerOpts, handler.WithSignedURLs(signer, signedURLMaxTTL))
+	}
+	if importKey != "" {
+		handlerOpts = append(handlerOpts, handler.WithImports(importKey))
+	}
+	if encryption != nil {
+		handlerOpts = append(handlerOpts, handler.WithEncryption(encryption))
+	}
+	handler.Register(api, svc, handlerOpts...)
+	handler.RegisterHealth(api, queueMonitor, queueThresholds)
+	handler.RegisterScaling(api, queueMonitor, scaling)
+	handler.RegisterEgress(api, outbound)
+	if err := handler.RegisterEventSchema(api, riveradapter.EventJobArgs{}, riveradapter.CloudEventType); err != nil {
+		return fmt.Errorf("event schema: %w", err)
+	}
+	if err := handler.RegisterAsyncAPI(api, asyncapi.Spec("0.1.0")); err != nil {
+		return fmt.Errorf("asyncapi: %w", err)
+	}
+	// WebSocket upgrades cannot be described in OpenAPI; mounted outside Huma.
+	router.Handle("/api/v1/ws", handler.RequireAuthentication(authOpts...)(feed))
+	if certIssuer != nil {
+		// Plain-text challenge answers, reached on the tenants' domains.
+		router.Handle(acme.ChallengePath+"*", certIssuer.ChallengeHandler())
+	}
 
 	// --- Server ---
-	srv := &http.Server{
-		Addr:              ":" + port,
-		Handler:           router,
-		ReadHeaderTimeout: 10 * time.Second,
+	srv := handler.NewServer(serverCfg, router)
+	// Shutdown does not close hijacked connections such as WebSockets.
+	srv.RegisterOnShutdown(feed.Close)
+
+	var certs *handler.CertificateReloader
+	if serverCfg.TLS() {
+		certs, err = handler.NewCertificateReloader(serverCfg.TLSCertFile, serverCfg.TLSKeyFile)
+		if err != nil {
+			return err
+		}
+		// SIGHUP reads renewed certificates; connections open keep theirs.
+		reload := make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+		defer signal.Stop(reload)
+		go func() {
+			for range reload {
+				if err := certs.Reload(); err != nil {
+					slog.Error("TLS certificate not reloaded, still serving the previous one", "error", err)
+					continue
+				}
+				slog.Info("TLS certificate reloaded", "cert_file", serverCfg.TLSCertFile)
+			}
+		}()
+	}
+
+	// Profiles are served on their own, internal address.
+	var pprofSrv *http.Server
+	if os.Getenv("ENABLE_PPROF") == "true" {
+		pprofSrv = handler.NewPprofServer(envOrDefault("PPROF_ADDR", "localhost:6060"))
+		go func() {
+			slog.Info("pprof listening", "addr", pprofSrv.Addr)
+			if err := pprofSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				slog.Error("pprof server error", "error", err)
+			}
+		}()
 	}
 
 	// Graceful shutdown.
@@ -92,9 +910,13 @@ func run() error {
 	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
-		slog.Info("tenantiq listening", "port", port)
-		slog.Info("API docs", "url", fmt.Sprintf("http://localhost:%s/docs", port))
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		scheme := "http"
+		if serverCfg.TLS() {
+			scheme = "https"
+		}
+		slog.Info("tenantiq listening", "port", port, "tls", serverCfg.TLS(), "client_certificates", serverCfg.ClientCAs != nil)
+		slog.Info("API docs", "url", fmt.Sprintf("%s://localhost:%s/docs", scheme, port))
+		if err := handler.ListenAndServe(srv, certs); err != nil && err != http.ErrServerClosed {
 			slog.Error("server error", "error", err)
 		}
 	}()
@@ -105,26 +927,58 @@ func run() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Shutdown order: HTTP → River → OTel.
+	// Shutdown order: HTTP → outbox relay → River → error reports → OTel.
 	if err := srv.Shutdown(ctx); err != nil {
-		slog.Error("http shutdown error", "error", err)
+		slog.ErrorContext(ctx, "http shutdown error", "error", err)
+	}
+	if pprofSrv != nil {
+		if err := pprofSrv.Shutdown(ctx); err != nil {
+			slog.ErrorContext(ctx, "pprof shutdown error", "error", err)
+		}
+	}
+
+	stopRelay()
+	<-relayDone
+	if _, err := relay.Drain(ctx); err != nil {
+		slog.ErrorContext(ctx, "outbox drain error", "error", err)
 	}
 
 	if err := riverClient.Stop(ctx); err != nil {
-		slog.Error("river shutdown error", "error", err)
+		slog.ErrorContext(ctx, "river shutdown error", "error", err)
+	}
+
+	if reporter != nil {
+		reporter.Flush(2 * time.Second)
 	}
 
 	if err := providers.Shutdown(ctx); err != nil {
-		slog.Error("otel shutdown error", "error", err)
+		slog.ErrorContext(ctx, "otel shutdown error", "error", err)
 	}
 
 	slog.Info("stopped")
 	return nil
 }
 
+// minAPIKeyLength is the shortest IMPORT_API_KEY accepted, so the key
+// cannot be guessed.
+const minAPIKeyLength = 32
+
 func envOrDefault(key, fallback string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
 	}
 	return fallback
 }
+
+// envInt reads an integer environment variable, returning fallback when unset.
+func envInt(key string, fallback int) (int, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	return n, nil
+}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
@@ -36,24 +38,41 @@ func TestEnvOrDefault_EnvSet(t *testing.T) {
 	}
 }
 
+func TestEnvInt(t *testing.T) {
+	n, err := envInt("TENANTIQ_TEST_NONEXISTENT_KEY", 7)
+	if err != nil || n != 7 {
+		t.Errorf("got %d, %v; want fallback 7", n, err)
+	}
+
+	t.Setenv("TENANTIQ_TEST_INT", "42")
+	if n, err := envInt("TENANTIQ_TEST_INT", 7); err != nil || n != 42 {
+		t.Errorf("got %d, %v; want 42", n, err)
+	}
+
+	t.Setenv("TENANTIQ_TEST_INT", "many")
+	if _, err := envInt("TENANTIQ_TEST_INT", 7); err == nil {
+		t.Error("expected error for a non-integer value")
+	}
+}
+
 // testPublisher is a local EventPublisher for the smoke test.
 // The smoke test verifies HTTP wiring, not River.
 type testPublisher struct{}
 
-func (p *testPublisher) Publish(_ context.Context, _ domain.Event, _ domain.Tenant) error {
+func (p *testPublisher) Publish(_ context.Context, _ domain.TenantEvent) error {
 	return nil
 }
 
 // testValidator is a local TransitionValidator for the smoke test.
 type testValidator struct{}
 
-func (v *testValidator) Apply(_ context.Context, current domain.Status, event domain.Event) (domain.Status, error) {
+func (v *testValidator) Apply(_ context.Context, tenant domain.Tenant, event domain.Event) (domain.Status, error) {
 	for _, t := range domain.Transitions {
-		if t.Event == event && t.Src == current {
+		if t.Event == event && t.Src == tenant.Status {
 			return t.Dst, nil
 		}
 	}
-	return "", &domain.TransitionError{Event: event, Current: current}
+	return "", &domain.TransitionError{Event: event, Current: tenant.Status}
 }
 
 // TestSmoke wires the full stack like main() and verifies it responds.
@@ -91,13 +110,16 @@ func TestSmoke(t *testing.T) {
 		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
 	}
 
-	var tenants []map[string]any
-	if err := json.NewDecoder(resp.Body).Decode(&tenants); err != nil {
+	var page struct {
+		Items []map[string]any `json:"items"`
+		Total int              `json:"total"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
 		t.Fatalf("decode: %v", err)
 	}
 
-	if len(tenants) != 0 {
-		t.Errorf("got %d tenants, want 0 (empty database)", len(tenants))
+	if len(page.Items) != 0 || page.Total != 0 {
+		t.Errorf("got %d tenants (total %d), want 0 (empty database)", len(page.Items), page.Total)
 	}
 }
 
@@ -122,6 +144,11 @@ func TestRun(t *testing.T) {
 		devNull.Close()
 	})
 
+	var key bytes.Buffer
+	if err := createAdminKey([]string{"test"}, &key); err != nil {
+		t.Fatalf("createAdminKey: %v", err)
+	}
+
 	errCh := make(chan error, 1)
 	go func() { errCh <- run() }()
 
@@ -142,7 +169,7 @@ func TestRun(t *testing.T) {
 		t.Fatal("server did not start within 5 seconds")
 	}
 
-	// Verify the API responds correctly.
+	// Verify the API requires the admin key and responds correctly with it.
 	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, serverURL+"/api/v1/tenants", nil)
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -150,6 +177,18 @@ func TestRun(t *testing.T) {
 	}
 	resp.Body.Close()
 
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("status without a key = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	req, _ = http.NewRequestWithContext(context.Background(), http.MethodGet, serverURL+"/api/v1/tenants", nil)
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(key.String()))
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /api/v1/tenants failed: %v", err)
+	}
+	resp.Body.Close()
+
 	if resp.StatusCode != http.StatusOK {
 		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
 	}
@@ -196,3 +235,25 @@ func TestRun_InvalidDB(t *testing.T) {
 		t.Fatal("expected error for invalid database path, got nil")
 	}
 }
+
+// TestRun_DebugErrorsInProduction verifies run() refuses to expose error
+// chains in production.
+func TestRun_DebugErrorsInProduction(t *testing.T) {
+	t.Setenv("OTEL_ENVIRONMENT", "production")
+	t.Setenv("DEBUG_ERRORS", "true")
+
+	if err := run(); err == nil {
+		t.Fatal("expected error for DEBUG_ERRORS in production, got nil")
+	}
+}
+
+// TestRun_AuthDisabledInProduction verifies run() refuses to open the API
+// in production.
+func TestRun_AuthDisabledInProduction(t *testing.T) {
+	t.Setenv("OTEL_ENVIRONMENT", "production")
+	t.Setenv("API_AUTH", "false")
+
+	if err := run(); err == nil {
+		t.Fatal("expected error for API_AUTH=false in production, got nil")
+	}
+}
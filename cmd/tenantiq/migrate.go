@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+)
+
+// migrate implements "tenantiq migrate status": it reports the schema
+// version of the database of DATABASE_PATH and its pending migrations, and
+// rehearses them with a canary migration on a copy. The database is only
+// read. It fails when the canary does, so a deployment can be stopped
+// before the new version migrates the live schema at startup.
+func migrate(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: tenantiq migrate status\n\nReports the schema version and pending migrations, and applies them to a copy of the database to check they succeed.\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || fs.Arg(0) != "status" {
+		fs.Usage()
+		return errors.New("expected status")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	db, err := sql.Open("sqlite", "file:"+envOrDefault("DATABASE_PATH", "tenantiq.db")+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("database: %w", err)
+	}
+	defer db.Close()
+
+	current, pending, err := sqlite.PendingMigrations(ctx, db)
+	if err != nil {
+		return fmt.Errorf("database: %w", err)
+	}
+	fmt.Fprintf(stdout, "schema version: %d\n", current)
+	if len(pending) == 0 {
+		_, err := fmt.Fprintln(stdout, "pending: none")
+		return err
+	}
+	for _, m := range pending {
+		fmt.Fprintf(stdout, "pending: %s\n", m.Name)
+	}
+
+	res, err := sqlite.Canary(ctx, db)
+	if err != nil {
+		fmt.Fprintf(stdout, "canary: failed after applying %d of %d migrations in %s\n",
+			len(res.Applied), len(pending), res.Duration.Round(time.Millisecond))
+		return fmt.Errorf("canary migration: %w", err)
+	}
+	_, err = fmt.Fprintf(stdout, "canary: passed, version %d to %d with %d checks in %s\n",
+		res.From, res.To, res.Checks, res.Duration.Round(time.Millisecond))
+	return err
+}
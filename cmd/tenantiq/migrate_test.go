@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+)
+
+func TestMigrateStatus(t *testing.T) {
+	file := t.TempDir() + "/tenantiq.db"
+	repo, err := sqlite.New(file)
+	if err != nil {
+		t.Fatalf("creating database: %v", err)
+	}
+	repo.Close()
+	t.Setenv("DATABASE_PATH", file)
+
+	var out bytes.Buffer
+	if err := migrate([]string{"status"}, &out); err != nil {
+		t.Fatalf("migrate status: %v", err)
+	}
+	if !strings.Contains(out.String(), "pending: none") {
+		t.Errorf("output = %q, want no pending migrations", out.String())
+	}
+
+	if err := migrate([]string{"up"}, &out); err == nil {
+		t.Error("migrate up: want an error for the unknown action")
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/adapter/supportbundle"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// supportManifest describes the installation a support bundle comes from.
+type supportManifest struct {
+	CreatedAt time.Time `json:"created_at"`
+	Version   string    `json:"version"`
+	GoVersion string    `json:"go_version"`
+	Platform  string    `json:"platform"`
+	// Errors lists the parts of the bundle that could not be collected.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// supportDatabase summarizes the database without its contents.
+type supportDatabase struct {
+	Path          string                `json:"path"`
+	SchemaVersion int64                 `json:"schema_version"`
+	Tenants       map[domain.Status]int `json:"tenants"`
+}
+
+// supportBundle implements "tenantiq support-bundle": it writes an archive
+// to attach to bug reports, with the configuration (secrets redacted), the
+// schema version, tenant counts, failing jobs and, with -logs, the end of a
+// log file. It reads the database without migrating or changing it, and
+// collects what it can: parts that fail are listed in the manifest.
+func supportBundle(args []string) error {
+	now := time.Now().UTC()
+	fs := flag.NewFlagSet("support-bundle", flag.ContinueOnError)
+	out := fs.String("o", "tenantiq-support-"+now.Format("20060102T150405Z")+".tar.gz", "output file (- for stdout)")
+	logs := fs.String("logs", "", "log file whose last lines are included, e.g. a journalctl export")
+	logLines := fs.Int("log-lines", 1000, "number of log lines included")
+	jobs := fs.Int("jobs", 50, "maximum number of failing jobs included")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	config, secrets := supportbundle.RedactEnv(os.Environ())
+	bundle := supportbundle.New(now, secrets)
+	manifest := supportManifest{
+		CreatedAt: now,
+		Version:   buildVersion(),
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	fail := func(part string, err error) {
+		manifest.Errors = append(manifest.Errors, part+": "+err.Error())
+	}
+
+	if err := bundle.AddJSON("config.json", config); err != nil {
+		return err
+	}
+
+	dbPath := envOrDefault("DATABASE_PATH", "tenantiq.db")
+	db, err := sql.Open("sqlite", "file:"+dbPath+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("database: %w", err)
+	}
+	defer db.Close()
+
+	database := supportDatabase{Path: dbPath}
+	if database.SchemaVersion, err = sqlite.SchemaVersion(ctx, db); err != nil {
+		fail("schema version", err)
+	}
+	if database.Tenants, err = sqlite.CountByStatus(ctx, db); err != nil {
+		fail("tenant counts", err)
+	}
+	if err := bundle.AddJSON("database.json", database); err != nil {
+		return err
+	}
+
+	failing, err := riveradapter.NewQueueMonitor(db).FailingJobs(ctx, *jobs)
+	if err != nil {
+		fail("failing jobs", err)
+	}
+	if err := bundle.AddJSON("failing_jobs.json", failing); err != nil {
+		return err
+	}
+
+	if *logs != "" {
+		text, err := tailFile(*logs, *logLines)
+		if err != nil {
+			fail("logs", err)
+		}
+		bundle.AddText("logs.txt", text)
+	}
+
+	if err := bundle.AddJSON("manifest.json", manifest); err != nil {
+		return err
+	}
+	return writeBundle(bundle, *out)
+}
+
+// writeBundle writes the archive to path, or to stdout for "-".
+func writeBundle(bundle *supportbundle.Bundle, path string) error {
+	if path == "-" {
+		return bundle.Write(os.Stdout)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("creating bundle: %w", err)
+	}
+	if err := bundle.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing bundle: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "support bundle written to %s\n", path)
+	return nil
+}
+
+// tailFile returns the last n lines of the file at path.
+func tailFile(path string, n int) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return tail(f, n)
+}
+
+// tail returns the last n lines read from r.
+func tail(r io.Reader, n int) (string, error) {
+	if n <= 0 {
+		return "", nil
+	}
+	lines := make([]string, 0, n)
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		if len(lines) == n {
+			lines = lines[1:]
+		}
+		lines = append(lines, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return "", err
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// buildVersion returns the module version the binary was built from.
+func buildVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Version
+	}
+	return "unknown"
+}
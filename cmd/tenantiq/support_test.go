@@ -0,0 +1,102 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// readBundle returns the files of a support bundle by base name.
+func readBundle(t *testing.T, file string) map[string]string {
+	t.Helper()
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatalf("opening bundle: %v", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("reading gzip: %v", err)
+	}
+
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatalf("reading tar: %v", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("reading %s: %v", hdr.Name, err)
+		}
+		files[path.Base(hdr.Name)] = string(data)
+	}
+}
+
+func TestSupportBundle(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := sqlite.New(dir + "/tenantiq.db")
+	if err != nil {
+		t.Fatalf("creating database: %v", err)
+	}
+	if err := repo.Create(context.Background(), domain.NewTenant("ten_1", "Acme", "acme", "free")); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := riveradapter.Setup(context.Background(), repo.DB(), riveradapter.NewWorkers()); err != nil {
+		t.Fatalf("river setup: %v", err)
+	}
+	repo.Close()
+
+	const secret = "s3cret-token-value"
+	t.Setenv("DATABASE_PATH", dir+"/tenantiq.db")
+	t.Setenv("BILLING_API_TOKEN", secret)
+	logFile := dir + "/app.log"
+	if err := os.WriteFile(logFile, []byte("old\nauthorization: "+secret+"\nlast\n"), 0o600); err != nil {
+		t.Fatalf("writing log: %v", err)
+	}
+
+	out := dir + "/bundle.tar.gz"
+	if err := supportBundle([]string{"-o", out, "-logs", logFile, "-log-lines", "2"}); err != nil {
+		t.Fatalf("supportBundle: %v", err)
+	}
+
+	files := readBundle(t, out)
+	for name, data := range files {
+		if strings.Contains(data, secret) {
+			t.Errorf("%s leaks the secret", name)
+		}
+	}
+	if files["logs.txt"] != "authorization: [REDACTED]\nlast\n" {
+		t.Errorf("logs.txt = %q, want the last two lines redacted", files["logs.txt"])
+	}
+
+	var database supportDatabase
+	if err := json.Unmarshal([]byte(files["database.json"]), &database); err != nil {
+		t.Fatalf("decoding database.json: %v", err)
+	}
+	if database.SchemaVersion == 0 || database.Tenants[domain.StatusCreating] != 1 {
+		t.Errorf("database = %+v, want the schema version and one creating tenant", database)
+	}
+
+	var manifest supportManifest
+	if err := json.Unmarshal([]byte(files["manifest.json"]), &manifest); err != nil {
+		t.Fatalf("decoding manifest.json: %v", err)
+	}
+	if len(manifest.Errors) != 0 {
+		t.Errorf("manifest errors = %v, want none", manifest.Errors)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	handler "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// client calls the tenantiq API on behalf of an operator.
+type client struct {
+	baseURL string
+	actor   string
+	// apiKey is the admin key sent as a bearer token, when set.
+	apiKey string
+	http   *http.Client
+}
+
+// apiError is a problem details response of the API.
+type apiError struct {
+	Status int    `json:"status"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+func (e *apiError) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("%d %s: %s", e.Status, e.Title, e.Detail)
+	}
+	return fmt.Sprintf("%d %s", e.Status, e.Title)
+}
+
+// listTenants returns up to limit tenants in status, or in any status when
+// it is empty, along with the number of matching tenants.
+func (c *client) listTenants(ctx context.Context, status domain.Status, limit int) (handler.TenantListResponse, error) {
+	q := url.Values{"limit": {strconv.Itoa(limit)}}
+	if status != "" {
+		q.Set("status", string(status))
+	}
+	var out handler.TenantListResponse
+	err := c.do(ctx, http.MethodGet, "/api/v1/tenants?"+q.Encode(), nil, &out)
+	return out, err
+}
+
+// transition triggers a lifecycle event and returns the tenant after it.
+func (c *client) transition(ctx context.Context, id string, event domain.Event) (handler.TenantResponse, error) {
+	body := map[string]string{"event": string(event)}
+	var out handler.TenantResponse
+	err := c.do(ctx, http.MethodPost, "/api/v1/tenants/"+url.PathEscape(id)+"/events", body, &out)
+	return out, err
+}
+
+// scaling returns the load of the job queues.
+func (c *client) scaling(ctx context.Context) (handler.ScalingResponse, error) {
+	var out handler.ScalingResponse
+	err := c.do(ctx, http.MethodGet, "/api/v1/system/scaling", nil, &out)
+	return out, err
+}
+
+// do sends a request with in as its JSON body, when not nil, and decodes
+// the JSON response into out.
+func (c *client) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.baseURL, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.actor != "" {
+		req.Header.Set("X-Actor", c.actor)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &apiError{Status: resp.StatusCode, Title: http.StatusText(resp.StatusCode)}
+		_ = json.NewDecoder(resp.Body).Decode(apiErr) // keep the status text otherwise
+		return apiErr
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Command tenantiqctl is the operator's command line for a running
+// tenantiq server.
+//
+//	tenantiqctl [-url http://localhost:8080] tui
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/user"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "tenantiqctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("tenantiqctl", flag.ContinueOnError)
+	baseURL := fs.String("url", envOrDefault("TENANTIQ_URL", "http://localhost:8080"), "tenantiq API base URL (TENANTIQ_URL)")
+	actor := fs.String("actor", defaultActor(), "actor recorded for the changes made (X-Actor), when the server does not authenticate")
+	apiKey := os.Getenv("TENANTIQ_API_KEY")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: tenantiqctl [flags] <command>\n\nCommands:\n  tui    interactive tenant and queue dashboard\n\nThe admin API key is read from TENANTIQ_API_KEY.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c := &client{baseURL: *baseURL, actor: *actor, apiKey: apiKey, http: &http.Client{Timeout: requestTimeout}}
+
+	switch fs.Arg(0) {
+	case "tui":
+		return runTUI(c, fs.Args()[1:])
+	case "":
+		fs.Usage()
+		return errors.New("missing command")
+	default:
+		return fmt.Errorf("unknown command %q", fs.Arg(0))
+	}
+}
+
+// runTUI implements "tenantiqctl tui".
+func runTUI(c *client, args []string) error {
+	fs := flag.NewFlagSet("tui", flag.ContinueOnError)
+	interval := fs.Duration("refresh", 2*time.Second, "how often tenants and queues are refreshed")
+	limit := fs.Int("limit", 200, "maximum number of tenants listed")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *interval <= 0 {
+		return fmt.Errorf("-refresh must be positive, got %s", *interval)
+	}
+
+	_, err := tea.NewProgram(newModel(c, *interval, *limit), tea.WithAltScreen()).Run()
+	return err
+}
+
+// defaultActor attributes changes to the operator's login, so the history
+// tells operators apart.
+func defaultActor() string {
+	if u, err := user.Current(); err == nil {
+		return "tenantiqctl:" + u.Username
+	}
+	return "tenantiqctl"
+}
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
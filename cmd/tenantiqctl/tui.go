@@ -0,0 +1,310 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	handler "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// statusFilters are the tenant lists the operator cycles through with tab;
+// the empty status lists every tenant.
+var statusFilters = []domain.Status{
+	"", domain.StatusCreating, domain.StatusActive, domain.StatusSuspended, domain.StatusDeleting, domain.StatusDeleted,
+}
+
+// requestTimeout bounds every API call, so a stalled server does not
+// freeze the screen.
+const requestTimeout = 10 * time.Second
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	faintStyle    = lipgloss.NewStyle().Faint(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	promptStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11"))
+	statusStyles  = map[string]lipgloss.Style{
+		string(domain.StatusCreating):  lipgloss.NewStyle().Foreground(lipgloss.Color("12")),
+		string(domain.StatusActive):    lipgloss.NewStyle().Foreground(lipgloss.Color("10")),
+		string(domain.StatusSuspended): lipgloss.NewStyle().Foreground(lipgloss.Color("11")),
+		string(domain.StatusDeleting):  lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
+		string(domain.StatusDeleted):   lipgloss.NewStyle().Faint(true),
+	}
+)
+
+// Messages delivered to the model by commands.
+type (
+	tickMsg    struct{}
+	tenantsMsg struct {
+		filter domain.Status
+		list   handler.TenantListResponse
+		err    error
+	}
+	scalingMsg struct {
+		scaling handler.ScalingResponse
+		err     error
+	}
+	transitionMsg struct {
+		tenant handler.TenantResponse
+		event  domain.Event
+		err    error
+	}
+)
+
+// model is the state of the operator TUI: a live tenant list with the
+// queue load, and the transition being chosen or confirmed, if any.
+type model struct {
+	client   *client
+	interval time.Duration
+	limit    int
+
+	filter  int // index into statusFilters
+	tenants []handler.TenantResponse
+	total   int
+	cursor  int
+	scaling *handler.ScalingResponse
+
+	// choices lists the events of the selected tenant while the operator
+	// picks one; pending is the event awaiting confirmation.
+	choices []domain.Event
+	pending domain.Event
+	target  handler.TenantResponse
+
+	message string
+	err     error
+	height  int
+}
+
+func newModel(c *client, interval time.Duration, limit int) model {
+	return model{client: c, interval: interval, limit: limit}
+}
+
+func (m model) Init() tea.Cmd {
+	return tea.Batch(m.fetchTenants(), m.fetchScaling(), m.tick())
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		return m, nil
+
+	case tickMsg:
+		return m, tea.Batch(m.fetchTenants(), m.fetchScaling(), m.tick())
+
+	case tenantsMsg:
+		if msg.filter != statusFilters[m.filter] {
+			return m, nil // the operator switched lists meanwhile
+		}
+		m.err = msg.err
+		if msg.err == nil {
+			m.tenants, m.total = msg.list.Items, msg.list.Total
+			m.cursor = min(m.cursor, max(len(m.tenants)-1, 0))
+		}
+		return m, nil
+
+	case scalingMsg:
+		if msg.err == nil {
+			m.scaling = &msg.scaling
+		}
+		return m, nil
+
+	case transitionMsg:
+		if msg.err != nil {
+			m.message, m.err = "", fmt.Errorf("%s %s: %w", msg.event, m.target.Slug, msg.err)
+			return m, nil
+		}
+		m.message, m.err = fmt.Sprintf("%s: %s is now %s", msg.event, msg.tenant.Slug, msg.tenant.Status), nil
+		return m, m.fetchTenants()
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+// handleKey dispatches a key press according to the current mode: a
+// pending confirmation first, then an open event choice, then the list.
+func (m model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	switch {
+	case m.pending != "":
+		event := m.pending
+		m.pending, m.choices = "", nil
+		if key == "y" {
+			m.message = fmt.Sprintf("sending %s to %s...", event, m.target.Slug)
+			return m, m.transition(m.target.ID, event)
+		}
+		m.message = "cancelled"
+		return m, nil
+
+	case m.choices != nil:
+		if key == "esc" || key == "q" {
+			m.choices = nil
+			return m, nil
+		}
+		if n := int(key[0] - '1'); len(key) == 1 && n >= 0 && n < len(m.choices) {
+			m.pending = m.choices[n]
+		}
+		return m, nil
+	}
+
+	switch key {
+	case "q":
+		return m, tea.Quit
+	case "up", "k":
+		m.cursor = max(m.cursor-1, 0)
+	case "down", "j":
+		m.cursor = min(m.cursor+1, max(len(m.tenants)-1, 0))
+	case "tab", "shift+tab":
+		step := 1
+		if key == "shift+tab" {
+			step = len(statusFilters) - 1
+		}
+		m.filter = (m.filter + step) % len(statusFilters)
+		m.tenants, m.cursor = nil, 0
+		return m, m.fetchTenants()
+	case "r":
+		return m, tea.Batch(m.fetchTenants(), m.fetchScaling())
+	case "enter", "t":
+		if len(m.tenants) == 0 {
+			return m, nil
+		}
+		m.target = m.tenants[m.cursor]
+		m.choices = eventsFrom(domain.Status(m.target.Status))
+		if len(m.choices) == 0 {
+			m.choices, m.message = nil, m.target.Slug+" has no lifecycle event left"
+		}
+	}
+	return m, nil
+}
+
+// eventsFrom returns the lifecycle events accepted in status.
+func eventsFrom(status domain.Status) []domain.Event {
+	var events []domain.Event
+	for _, t := range domain.Transitions {
+		if t.Src == status {
+			events = append(events, t.Event)
+		}
+	}
+	return events
+}
+
+func (m model) View() string {
+	var b strings.Builder
+
+	filter := string(statusFilters[m.filter])
+	if filter == "" {
+		filter = "all"
+	}
+	fmt.Fprintf(&b, "%s  %s  %s\n", titleStyle.Render("tenantiq"), faintStyle.Render(m.client.baseURL),
+		faintStyle.Render(fmt.Sprintf("[%s] %d of %d tenants", filter, len(m.tenants), m.total)))
+	b.WriteString(m.queueLine() + "\n\n")
+
+	fmt.Fprintf(&b, "  %-22s %-24s %-10s %-12s %s\n", "ID", "SLUG", "STATUS", "PLAN", "UPDATED")
+	for i, t := range m.visibleTenants() {
+		status := fmt.Sprintf("%-10s", t.Status)
+		if i+m.offset() == m.cursor {
+			b.WriteString(selectedStyle.Render(fmt.Sprintf("  %-22s %-24s %s %-12s %s", t.ID, t.Slug, status, t.Plan, t.UpdatedAt)) + "\n")
+			continue
+		}
+		if style, ok := statusStyles[t.Status]; ok {
+			status = style.Render(status)
+		}
+		fmt.Fprintf(&b, "  %-22s %-24s %s %-12s %s\n", t.ID, t.Slug, status, t.Plan, t.UpdatedAt)
+	}
+	b.WriteString("\n")
+
+	switch {
+	case m.pending != "":
+		b.WriteString(promptStyle.Render(fmt.Sprintf("Send %s to %s (%s)? [y/N]", m.pending, m.target.Slug, m.target.ID)))
+	case m.choices != nil:
+		b.WriteString(promptStyle.Render("Event for "+m.target.Slug+":") + "\n")
+		for i, e := range m.choices {
+			fmt.Fprintf(&b, "  %d) %s\n", i+1, e)
+		}
+		b.WriteString(faintStyle.Render("  esc to cancel"))
+	case m.err != nil:
+		b.WriteString(errorStyle.Render(m.err.Error()))
+	default:
+		b.WriteString(m.message)
+	}
+	b.WriteString("\n" + faintStyle.Render("↑/↓ select · enter transition · tab status · r refresh · q quit"))
+	return b.String()
+}
+
+// queueLine summarizes the job queues.
+func (m model) queueLine() string {
+	if m.scaling == nil {
+		return faintStyle.Render("queue: unknown")
+	}
+	parts := []string{fmt.Sprintf("queue: %d backlog, %.1f jobs/min, %d workers suggested",
+		m.scaling.Backlog, m.scaling.ProcessingRatePerMinute, m.scaling.SuggestedWorkers)}
+	for _, q := range m.scaling.Queues {
+		parts = append(parts, fmt.Sprintf("%s %d available / %d running", q.Queue, q.Available, q.Running))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// reservedLines is the height taken by everything but the tenant rows.
+const reservedLines = 12
+
+// offset is the index of the first tenant shown, keeping the cursor on
+// screen when the list is taller than the terminal.
+func (m model) offset() int {
+	rows := m.height - reservedLines
+	if rows <= 0 || m.cursor < rows {
+		return 0
+	}
+	return m.cursor - rows + 1
+}
+
+func (m model) visibleTenants() []handler.TenantResponse {
+	tenants := m.tenants[m.offset():]
+	if rows := m.height - reservedLines; rows > 0 && len(tenants) > rows {
+		tenants = tenants[:rows]
+	}
+	return tenants
+}
+
+func (m model) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(time.Time) tea.Msg { return tickMsg{} })
+}
+
+func (m model) fetchTenants() tea.Cmd {
+	filter := statusFilters[m.filter]
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		list, err := m.client.listTenants(ctx, filter, m.limit)
+		return tenantsMsg{filter: filter, list: list, err: err}
+	}
+}
+
+func (m model) fetchScaling() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		scaling, err := m.client.scaling(ctx)
+		return scalingMsg{scaling: scaling, err: err}
+	}
+}
+
+func (m model) transition(id string, event domain.Event) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		tenant, err := m.client.transition(ctx, id, event)
+		return transitionMsg{tenant: tenant, event: event, err: err}
+	}
+}
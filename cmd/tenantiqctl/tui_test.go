@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	handler "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// fakeAPI serves one active tenant and records the events sent to it.
+type fakeAPI struct {
+	events []string
+	actors []string
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tenant := handler.TenantResponse{ID: "ten_1", Slug: "acme", Status: "active", Plan: "pro"}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants":
+		_ = json.NewEncoder(w).Encode(handler.TenantListResponse{Items: []handler.TenantResponse{tenant}, Total: 1})
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/system/scaling":
+		_ = json.NewEncoder(w).Encode(handler.ScalingResponse{Backlog: 7, SuggestedWorkers: 2})
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/ten_1/events":
+		var body struct{ Event string }
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.events = append(f.events, body.Event)
+		f.actors = append(f.actors, r.Header.Get("X-Actor"))
+		if body.Event != string(domain.EventSuspend) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"status": 422, "title": "Unprocessable Entity", "detail": "invalid transition"}`))
+			return
+		}
+		tenant.Status = "suspended"
+		_ = json.NewEncoder(w).Encode(tenant)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// send feeds msg to m and runs the command it returns, feeding back the
+// message the command produced, if any.
+func send(t *testing.T, m tea.Model, msg tea.Msg) tea.Model {
+	t.Helper()
+	m, cmd := m.Update(msg)
+	if cmd == nil {
+		return m
+	}
+	if next := cmd(); next != nil {
+		if _, ok := next.(tea.BatchMsg); !ok {
+			m, _ = m.Update(next)
+		}
+	}
+	return m
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func newTestModel(t *testing.T, api http.Handler) tea.Model {
+	t.Helper()
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	m := newModel(&client{baseURL: srv.URL, actor: "tenantiqctl:alice", http: srv.Client()}, time.Hour, 50)
+	var model tea.Model = m
+	model, _ = model.Update(m.fetchTenants()())
+	model, _ = model.Update(m.fetchScaling()())
+	return model
+}
+
+func TestTUI_ShowsTenantsAndQueue(t *testing.T) {
+	view := newTestModel(t, &fakeAPI{}).View()
+	for _, want := range []string{"ten_1", "acme", "active", "7 backlog", "2 workers suggested", "1 of 1 tenants"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view misses %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestTUI_TransitionNeedsConfirmation(t *testing.T) {
+	api := &fakeAPI{}
+	m := newTestModel(t, api)
+
+	m = send(t, m, key("enter"))
+	if view := m.View(); !strings.Contains(view, "1) suspend") || !strings.Contains(view, "2) delete") {
+		t.Fatalf("view does not offer the active tenant's events:\n%s", view)
+	}
+
+	m = send(t, m, key("2"))
+	m = send(t, m, key("n"))
+	if len(api.events) != 0 || !strings.Contains(m.View(), "cancelled") {
+		t.Fatalf("declined transition sent %v", api.events)
+	}
+
+	m = send(t, m, key("enter"))
+	m = send(t, m, key("1"))
+	if !strings.Contains(m.View(), "Send suspend to acme (ten_1)? [y/N]") {
+		t.Fatalf("no confirmation prompt:\n%s", m.View())
+	}
+	m = send(t, m, key("y"))
+	if len(api.events) != 1 || api.events[0] != "suspend" || api.actors[0] != "tenantiqctl:alice" {
+		t.Fatalf("sent events %v by %v, want one suspend by the operator", api.events, api.actors)
+	}
+	if !strings.Contains(m.View(), "suspend: acme is now suspended") {
+		t.Errorf("view does not report the outcome:\n%s", m.View())
+	}
+
+	m = send(t, m, key("enter"))
+	m = send(t, m, key("2"))
+	m = send(t, m, key("y"))
+	if !strings.Contains(m.View(), "invalid transition") {
+		t.Errorf("view does not report the API error:\n%s", m.View())
+	}
+}
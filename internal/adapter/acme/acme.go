@@ -0,0 +1,289 @@
+// Package acme obtains TLS certificates for tenants' custom domains from an
+// ACME certificate authority such as Let's Encrypt.
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/acme"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: Issuer implements domain.CertificateIssuer.
+var _ domain.CertificateIssuer = (*Issuer)(nil)
+
+// ChallengePath is the path prefix of the HTTP-01 challenges. Requests for
+// it on every custom domain must reach ChallengeHandler.
+const ChallengePath = "/.well-known/acme-challenge/"
+
+// issueTimeout bounds how long obtaining one certificate may take.
+const issueTimeout = 2 * time.Minute
+
+// Config configures an Issuer.
+type Config struct {
+	// DirectoryURL is the ACME directory of the certificate authority;
+	// Let's Encrypt's production directory when empty.
+	DirectoryURL string
+	// AccountKey identifies the ACME account. Every instance answering
+	// challenges must use the same key.
+	AccountKey crypto.Signer
+	// Email is the account's contact for expiry and incident notices;
+	// optional.
+	Email string
+	// CertDir is where certificates and their keys are written, as
+	// <domain>.crt (the PEM chain) and <domain>.key (the PEM private key).
+	CertDir string
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Issuer implements domain.CertificateIssuer with HTTP-01 challenges. The
+// account is registered, agreeing to the authority's terms of service, on
+// the first certificate. Challenges are answered statelessly from the
+// account key, so any instance sharing it can answer them.
+type Issuer struct {
+	client  *acme.Client
+	email   string
+	certDir string
+
+	mu         sync.Mutex
+	registered bool
+}
+
+// New creates an issuer; it does not contact the certificate authority.
+func New(cfg Config) (*Issuer, error) {
+	if cfg.AccountKey == nil {
+		return nil, errors.New("an ACME account key is required")
+	}
+	if cfg.CertDir == "" {
+		return nil, errors.New("a certificate directory is required")
+	}
+	if err := os.MkdirAll(cfg.CertDir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating certificate directory: %w", err)
+	}
+	if cfg.DirectoryURL == "" {
+		cfg.DirectoryURL = acme.LetsEncryptURL
+	}
+	return &Issuer{
+		client: &acme.Client{
+			Key:          cfg.AccountKey,
+			DirectoryURL: cfg.DirectoryURL,
+			HTTPClient:   cfg.HTTPClient,
+			UserAgent:    "tenantiq",
+		},
+		email:   cfg.Email,
+		certDir: cfg.CertDir,
+	}, nil
+}
+
+// ParseAccountKey decodes a PEM private key: PKCS #8, SEC 1 EC or PKCS #1
+// RSA.
+func ParseAccountKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		switch key := key.(type) {
+		case *ecdsa.PrivateKey:
+			return key, nil
+		case *rsa.PrivateKey:
+			return key, nil
+		}
+		return nil, fmt.Errorf("unsupported key type %T (use ECDSA or RSA)", key)
+	}
+	return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+}
+
+// ChallengeHandler answers the authority's HTTP-01 challenges under
+// ChallengePath.
+func (i *Issuer) ChallengeHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.URL.Path, ChallengePath)
+		if !ok || !validToken(token) {
+			http.NotFound(w, r)
+			return
+		}
+		keyAuth, err := i.client.HTTP01ChallengeResponse(token)
+		if err != nil {
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(keyAuth))
+	})
+}
+
+// validToken checks a challenge token is base64url, as RFC 8555 requires.
+func validToken(token string) bool {
+	if token == "" || len(token) > 256 {
+		return false
+	}
+	for _, r := range token {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// Issue orders a certificate for name, answers its HTTP-01 challenge and
+// writes the certificate and a new ECDSA P-256 key to the certificate
+// directory, replacing the previous ones.
+func (i *Issuer) Issue(ctx context.Context, name string) (domain.IssuedCertificate, error) {
+	ctx, cancel := context.WithTimeout(ctx, issueTimeout)
+	defer cancel()
+
+	if err := i.register(ctx); err != nil {
+		return domain.IssuedCertificate{}, err
+	}
+
+	order, err := i.client.AuthorizeOrder(ctx, acme.DomainIDs(name))
+	if err != nil {
+		return domain.IssuedCertificate{}, fmt.Errorf("ordering certificate: %w", err)
+	}
+	for _, url := range order.AuthzURLs {
+		if err := i.authorize(ctx, url); err != nil {
+			return domain.IssuedCertificate{}, err
+		}
+	}
+	if order, err = i.client.WaitOrder(ctx, order.URI); err != nil {
+		return domain.IssuedCertificate{}, fmt.Errorf("waiting for order: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return domain.IssuedCertificate{}, fmt.Errorf("generating key: %w", err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{name}}, key)
+	if err != nil {
+		return domain.IssuedCertificate{}, fmt.Errorf("creating certificate request: %w", err)
+	}
+	chain, _, err := i.client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return domain.IssuedCertificate{}, fmt.Errorf("finalizing order: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(chain[0])
+	if err != nil {
+		return domain.IssuedCertificate{}, fmt.Errorf("parsing certificate: %w", err)
+	}
+	if err := leaf.VerifyHostname(name); err != nil {
+		return domain.IssuedCertificate{}, fmt.Errorf("checking certificate: %w", err)
+	}
+
+	if err := i.write(name, chain, key); err != nil {
+		return domain.IssuedCertificate{}, err
+	}
+	return domain.IssuedCertificate{
+		Issuer:    leaf.Issuer.CommonName,
+		Serial:    leaf.SerialNumber.Text(16),
+		NotBefore: leaf.NotBefore,
+		NotAfter:  leaf.NotAfter,
+	}, nil
+}
+
+// register creates the ACME account once; an account that already exists
+// for the key is reused.
+func (i *Issuer) register(ctx context.Context) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.registered {
+		return nil
+	}
+
+	acct := &acme.Account{}
+	if i.email != "" {
+		acct.Contact = []string{"mailto:" + i.email}
+	}
+	_, err := i.client.Register(ctx, acct, acme.AcceptTOS)
+	if err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return fmt.Errorf("registering ACME account: %w", err)
+	}
+	i.registered = true
+	return nil
+}
+
+// authorize answers the HTTP-01 challenge of a pending authorization and
+// waits for the authority to validate it.
+func (i *Issuer) authorize(ctx context.Context, url string) error {
+	authz, err := i.client.GetAuthorization(ctx, url)
+	if err != nil {
+		return fmt.Errorf("getting authorization: %w", err)
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var challenge *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == "http-01" {
+			challenge = c
+			break
+		}
+	}
+	if challenge == nil {
+		return fmt.Errorf("no http-01 challenge offered for %s", authz.Identifier.Value)
+	}
+	if _, err := i.client.Accept(ctx, challenge); err != nil {
+		return fmt.Errorf("accepting challenge: %w", err)
+	}
+	if _, err := i.client.WaitAuthorization(ctx, authz.URI); err != nil {
+		return fmt.Errorf("validating %s: %w", authz.Identifier.Value, err)
+	}
+	return nil
+}
+
+// write stores the chain and its key in the certificate directory. Each
+// file is written aside and renamed over the previous one, so readers
+// never see a partial file.
+func (i *Issuer) write(name string, chain [][]byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("encoding key: %w", err)
+	}
+	var certPEM []byte
+	for _, der := range chain {
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+
+	if err := writeFile(filepath.Join(i.certDir, name+".key"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})); err != nil {
+		return fmt.Errorf("writing key: %w", err)
+	}
+	if err := writeFile(filepath.Join(i.certDir, name+".crt"), certPEM); err != nil {
+		return fmt.Errorf("writing certificate: %w", err)
+	}
+	return nil
+}
+
+func writeFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,277 @@
+package acme_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	xacme "golang.org/x/crypto/acme"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/acme"
+)
+
+// fakeCA is an ACME server issuing for any domain whose HTTP-01 challenge
+// solve answers with the key authorization of accountKey.
+type fakeCA struct {
+	srv        *httptest.Server
+	accountKey *ecdsa.PrivateKey
+	solve      func(token string) string
+
+	caKey  *ecdsa.PrivateKey
+	caCert *x509.Certificate
+
+	mu      sync.Mutex
+	name    string
+	status  string // of the authorization
+	certDER []byte
+}
+
+const fakeToken = "tok3n_-"
+
+func newFakeCA(t *testing.T, accountKey *ecdsa.PrivateKey) *fakeCA {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Fake CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, _ := x509.ParseCertificate(der)
+
+	ca := &fakeCA{accountKey: accountKey, caKey: caKey, caCert: caCert, status: xacme.StatusPending}
+	ca.srv = httptest.NewServer(http.HandlerFunc(ca.serve))
+	t.Cleanup(ca.srv.Close)
+	return ca
+}
+
+func (ca *fakeCA) serve(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Replay-Nonce", "nonce")
+	url := ca.srv.URL
+
+	var payload []byte
+	if r.Method == http.MethodPost {
+		var jws struct{ Payload string }
+		if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		payload, _ = base64.RawURLEncoding.DecodeString(jws.Payload)
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	switch r.URL.Path {
+	case "/directory":
+		writeJSON(w, http.StatusOK, map[string]string{
+			"newNonce": url + "/nonce", "newAccount": url + "/account", "newOrder": url + "/order",
+		})
+	case "/nonce":
+		w.WriteHeader(http.StatusOK)
+	case "/account":
+		w.Header().Set("Location", url+"/account/1")
+		writeJSON(w, http.StatusCreated, map[string]string{"status": "valid"})
+	case "/order":
+		var req struct{ Identifiers []struct{ Value string } }
+		_ = json.Unmarshal(payload, &req)
+		ca.name = req.Identifiers[0].Value
+		ca.writeOrder(w, http.StatusCreated)
+	case "/order/1":
+		ca.writeOrder(w, http.StatusOK)
+	case "/authz/1":
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status":     ca.status,
+			"identifier": map[string]string{"type": "dns", "value": ca.name},
+			"challenges": []map[string]string{
+				{"type": "dns-01", "url": url + "/chal/2", "token": fakeToken, "status": "pending"},
+				{"type": "http-01", "url": url + "/chal/1", "token": fakeToken, "status": ca.status},
+			},
+		})
+	case "/chal/1":
+		thumbprint, _ := xacme.JWKThumbprint(ca.accountKey.Public())
+		ca.status = xacme.StatusInvalid
+		if ca.solve(fakeToken) == fakeToken+"."+thumbprint {
+			ca.status = xacme.StatusValid
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"type": "http-01", "url": url + "/chal/1", "token": fakeToken, "status": ca.status})
+	case "/finalize":
+		var req struct{ CSR string }
+		_ = json.Unmarshal(payload, &req)
+		der, _ := base64.RawURLEncoding.DecodeString(req.CSR)
+		csr, err := x509.ParseCertificateRequest(der)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		leaf := &x509.Certificate{
+			SerialNumber: big.NewInt(0xbeef),
+			DNSNames:     csr.DNSNames,
+			NotBefore:    time.Now().Add(-time.Minute).Truncate(time.Second),
+			NotAfter:     time.Now().Add(90 * 24 * time.Hour).Truncate(time.Second),
+		}
+		ca.certDER, _ = x509.CreateCertificate(rand.Reader, leaf, ca.caCert, csr.PublicKey, ca.caKey)
+		ca.writeOrder(w, http.StatusOK)
+	case "/cert":
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		_ = pem.Encode(w, &pem.Block{Type: "CERTIFICATE", Bytes: ca.certDER})
+		_ = pem.Encode(w, &pem.Block{Type: "CERTIFICATE", Bytes: ca.caCert.Raw})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (ca *fakeCA) writeOrder(w http.ResponseWriter, status int) {
+	order := map[string]any{
+		"status":         xacme.StatusPending,
+		"identifiers":    []map[string]string{{"type": "dns", "value": ca.name}},
+		"authorizations": []string{ca.srv.URL + "/authz/1"},
+		"finalize":       ca.srv.URL + "/finalize",
+	}
+	switch {
+	case ca.certDER != nil:
+		order["status"], order["certificate"] = xacme.StatusValid, ca.srv.URL+"/cert"
+	case ca.status == xacme.StatusValid:
+		order["status"] = xacme.StatusReady
+	case ca.status == xacme.StatusInvalid:
+		order["status"] = xacme.StatusInvalid
+	}
+	w.Header().Set("Location", ca.srv.URL+"/order/1")
+	writeJSON(w, status, order)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// newIssuer returns an issuer of ca whose challenges are answered by its
+// own ChallengeHandler.
+func newIssuer(t *testing.T, ca *fakeCA, dir string) *acme.Issuer {
+	t.Helper()
+
+	issuer, err := acme.New(acme.Config{
+		DirectoryURL: ca.srv.URL + "/directory",
+		AccountKey:   ca.accountKey,
+		Email:        "ops@example.com",
+		CertDir:      dir,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ca.solve = func(token string) string {
+		rec := httptest.NewRecorder()
+		issuer.ChallengeHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, acme.ChallengePath+token, nil))
+		return rec.Body.String()
+	}
+	return issuer
+}
+
+func newAccountKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestIssuer_Issue(t *testing.T) {
+	ca := newFakeCA(t, newAccountKey(t))
+	dir := t.TempDir()
+	issuer := newIssuer(t, ca, dir)
+
+	got, err := issuer.Issue(context.Background(), "app.acme.com")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if got.Issuer != "Fake CA" || got.Serial != "beef" || time.Until(got.NotAfter) < 89*24*time.Hour {
+		t.Errorf("Issue = %+v, want the 90-day certificate of Fake CA", got)
+	}
+
+	pair, err := tls.LoadX509KeyPair(filepath.Join(dir, "app.acme.com.crt"), filepath.Join(dir, "app.acme.com.key"))
+	if err != nil {
+		t.Fatalf("loading the written certificate and key: %v", err)
+	}
+	if len(pair.Certificate) != 2 {
+		t.Errorf("chain has %d certificates, want the leaf and the CA", len(pair.Certificate))
+	}
+	if info, _ := os.Stat(filepath.Join(dir, "app.acme.com.key")); info.Mode().Perm() != 0o600 {
+		t.Errorf("key mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestIssuer_IssueFailsOnInvalidChallenge(t *testing.T) {
+	ca := newFakeCA(t, newAccountKey(t))
+	dir := t.TempDir()
+	issuer := newIssuer(t, ca, dir)
+	// The domain does not reach the challenge handler.
+	ca.solve = func(string) string { return "404 page not found" }
+
+	if _, err := issuer.Issue(context.Background(), "app.acme.com"); err == nil {
+		t.Fatal("expected an error for a failed challenge")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "app.acme.com.crt")); !os.IsNotExist(err) {
+		t.Errorf("a certificate was written: %v", err)
+	}
+}
+
+func TestIssuer_ChallengeHandlerRejectsMalformedTokens(t *testing.T) {
+	issuer := newIssuer(t, newFakeCA(t, newAccountKey(t)), t.TempDir())
+
+	for _, path := range []string{acme.ChallengePath, acme.ChallengePath + "../etc", "/other/" + fakeToken} {
+		rec := httptest.NewRecorder()
+		issuer.ChallengeHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s: status = %d, want %d", path, rec.Code, http.StatusNotFound)
+		}
+	}
+}
+
+func TestParseAccountKey(t *testing.T) {
+	key := newAccountKey(t)
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(key)
+	sec1, _ := x509.MarshalECPrivateKey(key)
+
+	for _, block := range []*pem.Block{{Type: "PRIVATE KEY", Bytes: pkcs8}, {Type: "EC PRIVATE KEY", Bytes: sec1}} {
+		got, err := acme.ParseAccountKey(pem.EncodeToMemory(block))
+		if err != nil {
+			t.Fatalf("%s: %v", block.Type, err)
+		}
+		if !key.Equal(got) {
+			t.Errorf("%s: parsed a different key", block.Type)
+		}
+	}
+
+	for _, data := range []string{"", "not pem", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{1}}))} {
+		if _, err := acme.ParseAccountKey([]byte(data)); err == nil {
+			t.Errorf("ParseAccountKey(%q): expected an error", data)
+		}
+	}
+}
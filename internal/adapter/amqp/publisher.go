@@ -0,0 +1,183 @@
+// Package amqp publishes tenant events to an AMQP 0-9-1 exchange
+// (RabbitMQ), for consumers that do not read River or webhooks.
+package amqp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	amqp091 "github.com/rabbitmq/amqp091-go"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: Broker implements domain.EventPublisher.
+var _ domain.EventPublisher = (*Broker)(nil)
+
+// Defaults applied by NewBroker to an empty Config field.
+const (
+	DefaultExchangeType   = "topic"
+	DefaultRoutingKey     = "tenant.{event}"
+	DefaultConfirmTimeout = 5 * time.Second
+)
+
+// Config locates the exchange events are published to.
+type Config struct {
+	URL      string
+	Exchange string
+	// ExchangeType is used to declare the exchange (durable) when it does
+	// not exist yet: topic, direct, fanout or headers.
+	ExchangeType string
+	// RoutingKey is the routing key of every message; "{event}" is
+	// replaced by the event name, e.g. "tenant.{event}" -> "tenant.suspend".
+	RoutingKey string
+	// Source is the CloudEvents source of the messages.
+	Source string
+	// ConfirmTimeout bounds the wait for the broker's publisher confirm.
+	ConfirmTimeout time.Duration
+	// Transforms rewrite the message bodies, in order.
+	Transforms []domain.EventTransform
+}
+
+// Broker publishes events as persistent messages on a confirm-mode channel:
+// Publish returns only once the broker has taken responsibility for the
+// message. A lost connection is re-established on the next publish.
+type Broker struct {
+	cfg Config
+
+	mu   sync.Mutex
+	conn *amqp091.Connection
+	ch   *amqp091.Channel
+}
+
+// NewBroker connects to cfg.URL and declares the exchange.
+func NewBroker(cfg Config) (*Broker, error) {
+	if cfg.Exchange == "" {
+		return nil, errors.New("AMQP exchange is required")
+	}
+	if cfg.ExchangeType == "" {
+		cfg.ExchangeType = DefaultExchangeType
+	}
+	if cfg.RoutingKey == "" {
+		cfg.RoutingKey = DefaultRoutingKey
+	}
+	if cfg.Source == "" {
+		cfg.Source = riveradapter.DefaultEventSource
+	}
+	if cfg.ConfirmTimeout <= 0 {
+		cfg.ConfirmTimeout = DefaultConfirmTimeout
+	}
+
+	b := &Broker{cfg: cfg}
+	if _, err := b.channel(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Publish sends the event and waits for the broker to confirm it.
+func (b *Broker) Publish(ctx context.Context, e domain.TenantEvent) error {
+	msg, err := NewMessage(ctx, b.cfg.Source, e)
+	if err != nil {
+		return err
+	}
+	if msg.Body, err = domain.TransformEvent(ctx, b.cfg.Transforms, e.Event, msg.Body); err != nil {
+		return err
+	}
+	ch, err := b.channel()
+	if err != nil {
+		return err
+	}
+
+	confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, b.cfg.Exchange, RoutingKey(b.cfg.RoutingKey, e.Event), false, false, msg)
+	if err != nil {
+		return fmt.Errorf("publishing to exchange %q: %w", b.cfg.Exchange, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, b.cfg.ConfirmTimeout)
+	defer cancel()
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("waiting for publisher confirm: %w", err)
+	}
+	if !acked {
+		return fmt.Errorf("broker rejected event %q", e.Event)
+	}
+	return nil
+}
+
+// Close closes the connection.
+func (b *Broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.conn == nil || b.conn.IsClosed() {
+		return nil
+	}
+	return b.conn.Close()
+}
+
+// channel returns the open confirm-mode channel, connecting first when
+// there is none.
+func (b *Broker) channel() (*amqp091.Channel, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.ch != nil && !b.ch.IsClosed() {
+		return b.ch, nil
+	}
+	if b.conn == nil || b.conn.IsClosed() {
+		conn, err := amqp091.Dial(b.cfg.URL)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to AMQP broker: %w", err)
+		}
+		b.conn = conn
+	}
+
+	ch, err := b.conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("opening AMQP channel: %w", err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		_ = ch.Close()
+		return nil, fmt.Errorf("enabling publisher confirms: %w", err)
+	}
+	if err := ch.ExchangeDeclare(b.cfg.Exchange, b.cfg.ExchangeType, true, false, false, false, nil); err != nil {
+		return nil, fmt.Errorf("declaring exchange %q: %w", b.cfg.Exchange, err)
+	}
+	b.ch = ch
+	return ch, nil
+}
+
+// RoutingKey expands the "{event}" placeholder of pattern.
+func RoutingKey(pattern string, event domain.Event) string {
+	return strings.ReplaceAll(pattern, "{event}", string(event))
+}
+
+// NewMessage encodes an event as a persistent message whose body is the
+// CloudEvent in structured JSON mode, the document River and webhooks carry.
+// The CloudEvent keeps the ID set by domain.WithEventID, if any.
+func NewMessage(ctx context.Context, source string, e domain.TenantEvent) (amqp091.Publishing, error) {
+	ce := riveradapter.NewCloudEvent(source, e)
+	if id := domain.EventIDFromContext(ctx); id != "" {
+		ce.ID = id
+	}
+	body, err := json.Marshal(ce)
+	if err != nil {
+		return amqp091.Publishing{}, fmt.Errorf("encoding event: %w", err)
+	}
+	return amqp091.Publishing{
+		ContentType:  "application/cloudevents+json",
+		DeliveryMode: amqp091.Persistent,
+		MessageId:    ce.ID,
+		Timestamp:    ce.Time,
+		Type:         ce.Type,
+		AppId:        "tenantiq",
+		Body:         body,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package amqp_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	amqp091 "github.com/rabbitmq/amqp091-go"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/amqp"
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestRoutingKey(t *testing.T) {
+	if got := amqp.RoutingKey(amqp.DefaultRoutingKey, domain.EventSuspend); got != "tenant.suspend" {
+		t.Errorf("RoutingKey() = %q, want tenant.suspend", got)
+	}
+	if got := amqp.RoutingKey("tenants", domain.EventSuspend); got != "tenants" {
+		t.Errorf("RoutingKey() without placeholder = %q, want tenants", got)
+	}
+}
+
+func TestNewMessage_IsPersistentCloudEvent(t *testing.T) {
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "pro")
+
+	msg, err := amqp.NewMessage(context.Background(), "/test", domain.TenantEvent{Event: domain.EventSuspend, Tenant: tenant})
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	if msg.DeliveryMode != amqp091.Persistent || msg.ContentType != "application/cloudevents+json" {
+		t.Errorf("message = %+v, want persistent CloudEvent", msg)
+	}
+
+	var ce riveradapter.EventJobArgs
+	if err := json.Unmarshal(msg.Body, &ce); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if ce.ID != msg.MessageId || ce.Type != msg.Type || ce.Source != "/test" || ce.Data.TenantID != "ten_1" {
+		t.Errorf("body = %+v, want the CloudEvent matching the message properties", ce)
+	}
+}
+
+func TestNewMessage_KeepsEventID(t *testing.T) {
+	ctx := domain.WithEventID(context.Background(), "evt_1")
+
+	msg, err := amqp.NewMessage(ctx, "/test", domain.TenantEvent{Event: domain.EventSuspend, Tenant: domain.NewTenant("ten_1", "Acme", "acme", "pro")})
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	if msg.MessageId != "evt_1" {
+		t.Errorf("MessageId = %q, want evt_1", msg.MessageId)
+	}
+}
+
+func TestNewMessage_CarriesChanges(t *testing.T) {
+	msg, err := amqp.NewMessage(context.Background(), "/test", domain.TenantEvent{
+		Event:   domain.EventRenamed,
+		Tenant:  domain.NewTenant("ten_1", "Acme Inc", "acme", "pro"),
+		Changes: []domain.FieldChange{{Field: "name", Before: "Acme", After: "Acme Inc"}},
+	})
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	if want := `"changes":[{"field":"name","before":"Acme","after":"Acme Inc"}]`; !strings.Contains(string(msg.Body), want) {
+		t.Errorf("body = %s, want it to contain %s", msg.Body, want)
+	}
+}
+
+func TestNewBroker_RequiresExchange(t *testing.T) {
+	if _, err := amqp.NewBroker(amqp.Config{URL: "amqp://localhost"}); err == nil {
+		t.Error("NewBroker without exchange succeeded, want an error")
+	}
+}
+
+func TestNewBroker_FailsWhenUnreachable(t *testing.T) {
+	if _, err := amqp.NewBroker(amqp.Config{URL: "amqp://127.0.0.1:1", Exchange: "tenantiq"}); err == nil {
+		t.Error("NewBroker succeeded, want a connection error")
+	}
+}
@@ -0,0 +1,125 @@
+// Package asyncapi builds an AsyncAPI 3.0 document for tenantiq's messaging
+// surface from Go types, the way Huma builds the OpenAPI document for HTTP.
+package asyncapi
+
+import (
+	"reflect"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// Version is the AsyncAPI specification version of generated documents.
+const Version = "3.0.0"
+
+// Action is what the application does on a channel.
+type Action string
+
+const (
+	// ActionSend means tenantiq publishes messages to the channel.
+	ActionSend Action = "send"
+	// ActionReceive means tenantiq consumes messages from the channel.
+	ActionReceive Action = "receive"
+)
+
+// Message is one message type carried on a channel. Payload is a value of
+// the Go type whose JSON encoding is the message body.
+type Message struct {
+	Name    string
+	Summary string
+	Payload any
+}
+
+// Channel is an address messages flow through, such as a River job kind.
+type Channel struct {
+	Name        string
+	Address     string
+	Description string
+	Action      Action
+	Messages    []Message
+}
+
+// Info is the document's metadata.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Document is the subset of an AsyncAPI 3.0 document tenantiq generates.
+type Document struct {
+	AsyncAPI   string                  `json:"asyncapi"`
+	Info       Info                    `json:"info"`
+	Channels   map[string]channelDoc   `json:"channels"`
+	Operations map[string]operationDoc `json:"operations"`
+	Components components              `json:"components"`
+}
+
+type ref struct {
+	Ref string `json:"$ref"`
+}
+
+type channelDoc struct {
+	Address     string         `json:"address"`
+	Description string         `json:"description,omitempty"`
+	Messages    map[string]ref `json:"messages"`
+}
+
+type operationDoc struct {
+	Action   Action `json:"action"`
+	Channel  ref    `json:"channel"`
+	Messages []ref  `json:"messages"`
+}
+
+type messageDoc struct {
+	Name        string       `json:"name"`
+	Summary     string       `json:"summary,omitempty"`
+	ContentType string       `json:"contentType"`
+	Payload     *huma.Schema `json:"payload"`
+}
+
+type components struct {
+	Messages map[string]messageDoc   `json:"messages"`
+	Schemas  map[string]*huma.Schema `json:"schemas"`
+}
+
+// New builds a document describing channels. Payload schemas are generated
+// with Huma's schema registry, so struct tags (json, doc, enum, ...) mean the
+// same as in the HTTP API, and shared types become reusable components.
+func New(info Info, channels []Channel) Document {
+	registry := huma.NewMapRegistry("#/components/schemas/", huma.DefaultSchemaNamer)
+
+	doc := Document{
+		AsyncAPI:   Version,
+		Info:       info,
+		Channels:   make(map[string]channelDoc, len(channels)),
+		Operations: make(map[string]operationDoc, len(channels)),
+		Components: components{Messages: make(map[string]messageDoc)},
+	}
+
+	for _, ch := range channels {
+		cd := channelDoc{
+			Address:     ch.Address,
+			Description: ch.Description,
+			Messages:    make(map[string]ref, len(ch.Messages)),
+		}
+		op := operationDoc{
+			Action:  ch.Action,
+			Channel: ref{Ref: "#/channels/" + ch.Name},
+		}
+		for _, m := range ch.Messages {
+			doc.Components.Messages[m.Name] = messageDoc{
+				Name:        m.Name,
+				Summary:     m.Summary,
+				ContentType: "application/json",
+				Payload:     registry.Schema(reflect.TypeOf(m.Payload), true, m.Name),
+			}
+			cd.Messages[m.Name] = ref{Ref: "#/components/messages/" + m.Name}
+			op.Messages = append(op.Messages, ref{Ref: "#/channels/" + ch.Name + "/messages/" + m.Name})
+		}
+		doc.Channels[ch.Name] = cd
+		doc.Operations[string(ch.Action)+"-"+ch.Name] = op
+	}
+
+	doc.Components.Schemas = registry.Map()
+	return doc
+}
@@ -0,0 +1,55 @@
+package asyncapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/asyncapi"
+)
+
+type testPayload struct {
+	ID string `json:"id" doc:"Identifier"`
+}
+
+func TestNew(t *testing.T) {
+	doc := asyncapi.New(asyncapi.Info{Title: "test", Version: "1.0.0"}, []asyncapi.Channel{{
+		Name:     "jobs",
+		Address:  "job.kind",
+		Action:   asyncapi.ActionReceive,
+		Messages: []asyncapi.Message{{Name: "Job", Payload: testPayload{}}},
+	}})
+
+	if doc.AsyncAPI != asyncapi.Version {
+		t.Errorf("asyncapi = %q, want %q", doc.AsyncAPI, asyncapi.Version)
+	}
+	if got := doc.Channels["jobs"].Address; got != "job.kind" {
+		t.Errorf("channel address = %q, want job.kind", got)
+	}
+	op, ok := doc.Operations["receive-jobs"]
+	if !ok || op.Action != asyncapi.ActionReceive || len(op.Messages) != 1 {
+		t.Errorf("operations = %+v, want receive-jobs with one message", doc.Operations)
+	}
+	schema, ok := doc.Components.Schemas["TestPayload"]
+	if !ok || schema.Properties["id"] == nil {
+		t.Errorf("schemas = %v, want TestPayload with id", doc.Components.Schemas)
+	}
+}
+
+// TestSpecIsUpToDate keeps the committed document in sync with the Go types.
+// Regenerate it with: go run ./cmd/asyncapi
+func TestSpecIsUpToDate(t *testing.T) {
+	committed, err := os.ReadFile("../../../api/asyncapi.json")
+	if err != nil {
+		t.Fatalf("reading committed document: %v", err)
+	}
+
+	generated, err := json.MarshalIndent(asyncapi.Spec("0.1.0"), "", "  ")
+	if err != nil {
+		t.Fatalf("encoding: %v", err)
+	}
+	if !bytes.Equal(bytes.TrimSpace(committed), generated) {
+		t.Error("api/asyncapi.json is stale; run go run ./cmd/asyncapi")
+	}
+}
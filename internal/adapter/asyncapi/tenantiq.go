@@ -0,0 +1,189 @@
+package asyncapi
+
+import (
+	"github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/stripe"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Channels lists tenantiq's messaging surface. Every River job kind belongs
+// here; TestSpecIsUpToDate fails when the committed document drifts from it.
+func Channels() []Channel {
+	events := make([]Message, 0, len(domain.Events()))
+	for _, e := range domain.Events() {
+		events = append(events, Message{
+			Name:    string(e),
+			Summary: "Tenant lifecycle event " + string(e),
+			Payload: river.EventJobArgs{},
+		})
+	}
+	events = append(events,
+		Message{
+			Name:    string(domain.EventPlanSuggested),
+			Summary: "A better fitting plan was suggested from the tenant's usage",
+			Payload: river.EventJobArgs{},
+		},
+		Message{
+			Name:    string(domain.EventDunningWarning),
+			Summary: "A payment failed; the tenant is warned and in dunning",
+			Payload: river.EventJobArgs{},
+		},
+		Message{
+			Name:    string(domain.EventDunningFinalNotice),
+			Summary: "Final notice before the tenant is suspended for non-payment",
+			Payload: river.EventJobArgs{},
+		},
+		Message{
+			Name:    string(domain.EventPurged),
+			Summary: "A long-deleted tenant was permanently removed",
+			Payload: river.EventJobArgs{},
+		},
+		Message{
+			Name:    string(domain.EventTrialExpired),
+			Summary: "The tenant's trial ended; it was suspended or downgraded",
+			Payload: river.EventJobArgs{},
+		},
+		Message{
+			Name:    string(domain.EventRenamed),
+			Summary: "The tenant's name changed",
+			Payload: river.EventJobArgs{},
+		},
+		Message{
+			Name:    string(domain.EventPlanChanged),
+			Summary: "The tenant moved to another plan",
+			Payload: river.EventJobArgs{},
+		},
+		Message{
+			Name:    string(domain.EventMetadataUpdated),
+			Summary: "The tenant's references, pull request, branch or trial changed",
+			Payload: river.EventJobArgs{},
+		},
+		Message{
+			Name:    string(domain.EventCertificateIssued),
+			Summary: "A TLS certificate was obtained or renewed for one of the tenant's domains",
+			Payload: river.EventJobArgs{},
+		},
+		Message{
+			Name:    string(domain.EventCertificateFailed),
+			Summary: "A TLS certificate could not be obtained or renewed; it is retried later",
+			Payload: river.EventJobArgs{},
+		},
+	)
+
+	return []Channel{
+		{
+			Name:        river.EventJobArgs{}.Kind(),
+			Address:     river.EventJobArgs{}.Kind(),
+			Description: "Tenant events as CloudEvents 1.0 (structured JSON): one job per state change, plus plan suggestions, dunning notices, purges, trial expiries, attribute changes (with their before/after values) and certificate issuances and failures.",
+			Action:      ActionSend,
+			Messages:    events,
+		},
+		{
+			Name:        river.OperationArgs{}.Kind(),
+			Address:     river.OperationArgs{}.Kind(),
+			Description: "Asynchronous tenant operations (provisioning, deletion), tracked under /api/v1/operations.",
+			Action:      ActionReceive,
+			Messages:    []Message{{Name: "OperationArgs", Summary: "Run a tenant operation", Payload: river.OperationArgs{}}},
+		},
+		{
+			Name:        river.PlanSuggestionArgs{}.Kind(),
+			Address:     river.PlanSuggestionArgs{}.Kind(),
+			Description: "Periodic matching of tenant usage against plan quotas.",
+			Action:      ActionReceive,
+			Messages:    []Message{{Name: "PlanSuggestionArgs", Summary: "Suggest plans from usage", Payload: river.PlanSuggestionArgs{}}},
+		},
+		{
+			Name:        river.WebhookDeliveryArgs{}.Kind(),
+			Address:     river.WebhookDeliveryArgs{}.Kind(),
+			Description: "Signed HTTP delivery of one event to one webhook subscription, retried with backoff.",
+			Action:      ActionReceive,
+			Messages:    []Message{{Name: "WebhookDeliveryArgs", Summary: "Deliver an event to a webhook", Payload: river.WebhookDeliveryArgs{}}},
+		},
+		{
+			Name:        river.WebhookDigestArgs{}.Kind(),
+			Address:     river.WebhookDigestArgs{}.Kind(),
+			Description: "Periodic delivery of the hourly and daily digests of webhook subscriptions whose window ended.",
+			Action:      ActionReceive,
+			Messages:    []Message{{Name: "WebhookDigestArgs", Summary: "Deliver due webhook digests", Payload: river.WebhookDigestArgs{}}},
+		},
+		{
+			Name:        river.BillingReconciliationArgs{}.Kind(),
+			Address:     river.BillingReconciliationArgs{}.Kind(),
+			Description: "Periodic cross-check of tenants against the billing provider's subscriptions.",
+			Action:      ActionReceive,
+			Messages:    []Message{{Name: "BillingReconciliationArgs", Summary: "Reconcile tenants with billing", Payload: river.BillingReconciliationArgs{}}},
+		},
+		{
+			Name:        river.DunningArgs{}.Kind(),
+			Address:     river.DunningArgs{}.Kind(),
+			Description: "Periodic dunning steps: final notices and suspensions of tenants with unpaid invoices.",
+			Action:      ActionReceive,
+			Messages:    []Message{{Name: "DunningArgs", Summary: "Advance due dunning flows", Payload: river.DunningArgs{}}},
+		},
+		{
+			Name:        river.SpecSyncArgs{}.Kind(),
+			Address:     river.SpecSyncArgs{}.Kind(),
+			Description: "Periodic reconciliation of tenants against declarative specs.",
+			Action:      ActionReceive,
+			Messages:    []Message{{Name: "SpecSyncArgs", Summary: "Reconcile tenant specs", Payload: river.SpecSyncArgs{}}},
+		},
+		{
+			Name:        river.RetentionArgs{}.Kind(),
+			Address:     river.RetentionArgs{}.Kind(),
+			Description: "Periodic pruning of audit entries, status history, operations and finished jobs past their retention.",
+			Action:      ActionReceive,
+			Messages:    []Message{{Name: "RetentionArgs", Summary: "Prune expired records", Payload: river.RetentionArgs{}}},
+		},
+		{
+			Name:        river.ArchiveArgs{}.Kind(),
+			Address:     river.ArchiveArgs{}.Kind(),
+			Description: "Periodic move of old audit entries, status history and finished jobs to the cold archive tables.",
+			Action:      ActionReceive,
+			Messages:    []Message{{Name: "ArchiveArgs", Summary: "Archive old records", Payload: river.ArchiveArgs{}}},
+		},
+		{
+			Name:        river.PurgeArgs{}.Kind(),
+			Address:     river.PurgeArgs{}.Kind(),
+			Description: "Periodic permanent removal of tenants deleted for longer than the purge window.",
+			Action:      ActionReceive,
+			Messages:    []Message{{Name: "PurgeArgs", Summary: "Purge long-deleted tenants", Payload: river.PurgeArgs{}}},
+		},
+		{
+			Name:        river.TrialExpiryArgs{}.Kind(),
+			Address:     river.TrialExpiryArgs{}.Kind(),
+			Description: "Periodic suspension or downgrade of the tenants whose trial has ended.",
+			Action:      ActionReceive,
+			Messages:    []Message{{Name: "TrialExpiryArgs", Summary: "End expired trials", Payload: river.TrialExpiryArgs{}}},
+		},
+		{
+			Name:        river.CounterReconciliationArgs{}.Kind(),
+			Address:     river.CounterReconciliationArgs{}.Kind(),
+			Description: "Periodic correction of the per-status tenant counters from the tenants table.",
+			Action:      ActionReceive,
+			Messages:    []Message{{Name: "CounterReconciliationArgs", Summary: "Reconcile status counters", Payload: river.CounterReconciliationArgs{}}},
+		},
+		{
+			Name:        river.CertificateRenewalArgs{}.Kind(),
+			Address:     river.CertificateRenewalArgs{}.Kind(),
+			Description: "Periodic requests of the pending, expiring and failed TLS certificates of tenant domains when ACME_ACCOUNT_KEY is set.",
+			Action:      ActionReceive,
+			Messages:    []Message{{Name: "CertificateRenewalArgs", Summary: "Obtain and renew due certificates", Payload: river.CertificateRenewalArgs{}}},
+		},
+		{
+			Name:        stripe.SyncArgs{}.Kind(),
+			Address:     stripe.SyncArgs{}.Kind(),
+			Description: "Sync of a tenant's Stripe customer and subscription, queued after its creation, plan changes and deletion when STRIPE_API_KEY is set.",
+			Action:      ActionReceive,
+			Messages:    []Message{{Name: "SyncArgs", Summary: "Sync a tenant with Stripe", Payload: stripe.SyncArgs{}}},
+		},
+	}
+}
+
+// Spec returns the AsyncAPI document for tenantiq at the given version.
+func Spec(version string) Document {
+	return New(Info{
+		Title:       "tenantiq",
+		Version:     version,
+		Description: "Background jobs and lifecycle events of the tenantiq control plane.",
+	}, Channels())
+}
@@ -0,0 +1,86 @@
+// Package billing reads subscriptions from the billing provider through a
+// JSON export endpoint. The endpoint answers GET with every subscription
+// tied to a tenant:
+//
+//	{"subscriptions": [
+//	  {"tenant_id": "ten_123", "plan": "pro", "status": "active"}
+//	]}
+//
+// Status is active, past_due or canceled.
+package billing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// maxErrorBody caps how much of an error response is quoted in errors.
+const maxErrorBody = 512
+
+// Provider implements domain.BillingProvider over HTTP.
+type Provider struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewProvider creates a provider reading subscriptions from url. A non-empty
+// token is sent as a bearer token.
+func NewProvider(url, token string, client *http.Client) *Provider {
+	return &Provider{url: url, token: token, client: client}
+}
+
+type export struct {
+	Subscriptions []subscription `json:"subscriptions"`
+}
+
+type subscription struct {
+	TenantID string `json:"tenant_id"`
+	Plan     string `json:"plan"`
+	Status   string `json:"status"`
+}
+
+// Subscriptions fetches the export and checks every status is known.
+func (p *Provider) Subscriptions(ctx context.Context) ([]domain.Subscription, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching subscriptions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return nil, fmt.Errorf("fetching subscriptions: %s: %s", resp.Status, body)
+	}
+
+	var e export
+	if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
+		return nil, fmt.Errorf("decoding subscriptions: %w", err)
+	}
+
+	subs := make([]domain.Subscription, 0, len(e.Subscriptions))
+	for _, s := range e.Subscriptions {
+		status := domain.SubscriptionStatus(s.Status)
+		switch status {
+		case domain.SubscriptionActive, domain.SubscriptionPastDue, domain.SubscriptionCanceled:
+		default:
+			return nil, fmt.Errorf("subscription of tenant %s: unknown status %q", s.TenantID, s.Status)
+		}
+		subs = append(subs, domain.Subscription{TenantID: s.TenantID, Plan: s.Plan, Status: status})
+	}
+	return subs, nil
+}
@@ -0,0 +1,76 @@
+package billing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/billing"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// serveExport answers every request with status and body, recording the
+// Authorization header.
+func serveExport(t *testing.T, status int, body string, auth *string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*auth = r.Header.Get("Authorization")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestProvider_Subscriptions(t *testing.T) {
+	var auth string
+	url := serveExport(t, http.StatusOK, `{"subscriptions":[
+		{"tenant_id":"ten_1","plan":"pro","status":"active"},
+		{"tenant_id":"ten_2","plan":"free","status":"past_due"}
+	]}`, &auth)
+
+	subs, err := billing.NewProvider(url, "s3cret", http.DefaultClient).Subscriptions(context.Background())
+	if err != nil {
+		t.Fatalf("Subscriptions: %v", err)
+	}
+	if auth != "Bearer s3cret" {
+		t.Errorf("Authorization = %q, want bearer token", auth)
+	}
+	want := []domain.Subscription{
+		{TenantID: "ten_1", Plan: "pro", Status: domain.SubscriptionActive},
+		{TenantID: "ten_2", Plan: "free", Status: domain.SubscriptionPastDue},
+	}
+	if len(subs) != len(want) || subs[0] != want[0] || subs[1] != want[1] {
+		t.Errorf("subs = %+v, want %+v", subs, want)
+	}
+}
+
+func TestProvider_Errors(t *testing.T) {
+	cases := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{"error status", http.StatusBadGateway, "upstream down", "upstream down"},
+		{"malformed body", http.StatusOK, "<html>", "decoding"},
+		{"unknown status", http.StatusOK, `{"subscriptions":[{"tenant_id":"ten_1","status":"trialing"}]}`, `"trialing"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var auth string
+			url := serveExport(t, tc.status, tc.body, &auth)
+
+			_, err := billing.NewProvider(url, "", http.DefaultClient).Subscriptions(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("err = %v, want it to mention %q", err, tc.wantErr)
+			}
+			if auth != "" {
+				t.Errorf("Authorization = %q, want none without token", auth)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Package configcrypt decrypts configuration values stored encrypted, so the
+// files holding the configuration (env files, manifests) can be committed
+// with their webhook secrets and API tokens. An encrypted value is "enc:"
+// followed by the base64 of an AES-256-GCM nonce and ciphertext; the name of
+// the variable is authenticated along, so a value cannot be moved to
+// another variable.
+package configcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Prefix marks an encrypted value.
+const Prefix = "enc:"
+
+// KeyLength is the length of the encryption key, in bytes.
+const KeyLength = 32
+
+// ErrNoKey is returned when encrypted values are found without a key to
+// decrypt them.
+var ErrNoKey = errors.New("encrypted value found but no encryption key is set")
+
+// Box encrypts and decrypts configuration values with one key.
+type Box struct {
+	aead cipher.AEAD
+}
+
+// New creates a box from a KeyLength-byte key.
+func New(key []byte) (*Box, error) {
+	if len(key) != KeyLength {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", KeyLength, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Box{aead: aead}, nil
+}
+
+// ParseKey decodes a base64 key, as generated by
+// "openssl rand -base64 32".
+func ParseKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("decoding encryption key: %w", err)
+	}
+	return key, nil
+}
+
+// Encrypt encrypts the value of the variable name.
+func (b *Box) Encrypt(name, value string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating nonce: %w", err)
+	}
+	sealed := b.aead.Seal(nonce, nonce, []byte(value), []byte(name))
+	return Prefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plaintext of the variable name's value. Values
+// without Prefix are returned unchanged.
+func (b *Box) Decrypt(name, value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, Prefix)
+	if !ok {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("%s: decoding encrypted value: %w", name, err)
+	}
+	size := b.aead.NonceSize()
+	if len(sealed) < size {
+		return "", fmt.Errorf("%s: encrypted value is truncated", name)
+	}
+	plain, err := b.aead.Open(nil, sealed[:size], sealed[size:], []byte(name))
+	if err != nil {
+		return "", fmt.Errorf("%s: decrypting value: wrong key or value of another variable", name)
+	}
+	return string(plain), nil
+}
+
+// DecryptEnv replaces the encrypted values of the environment with their
+// plaintext, so the configuration is read as usual afterwards. It returns
+// the names of the variables decrypted. A nil box fails with ErrNoKey if any
+// value is encrypted.
+func DecryptEnv(box *Box) ([]string, error) {
+	var names []string
+	for _, entry := range os.Environ() {
+		name, value, _ := strings.Cut(entry, "=")
+		if !strings.HasPrefix(value, Prefix) {
+			continue
+		}
+		if box == nil {
+			return nil, fmt.Errorf("%s: %w", name, ErrNoKey)
+		}
+		plain, err := box.Decrypt(name, value)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.Setenv(name, plain); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
@@ -0,0 +1,134 @@
+package configcrypt_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/configcrypt"
+)
+
+func newBox(t *testing.T, fill string) *configcrypt.Box {
+	t.Helper()
+	box, err := configcrypt.New([]byte(strings.Repeat(fill, configcrypt.KeyLength)))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return box
+}
+
+func TestNew_RejectsShortKey(t *testing.T) {
+	if _, err := configcrypt.New([]byte("short")); err == nil {
+		t.Fatal("expected an error for a short key")
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	key, err := configcrypt.ParseKey(" AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=\n")
+	if err != nil {
+		t.Fatalf("ParseKey: %v", err)
+	}
+	if len(key) != configcrypt.KeyLength || key[31] != 31 {
+		t.Errorf("key = %v", key)
+	}
+	if _, err := configcrypt.ParseKey("not base64!"); err == nil {
+		t.Error("expected an error for invalid base64")
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	box := newBox(t, "k")
+
+	enc, err := box.Encrypt("BILLING_API_TOKEN", "s3cret")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if !strings.HasPrefix(enc, configcrypt.Prefix) || strings.Contains(enc, "s3cret") {
+		t.Fatalf("encrypted value = %q", enc)
+	}
+	again, _ := box.Encrypt("BILLING_API_TOKEN", "s3cret")
+	if again == enc {
+		t.Error("encrypting twice gave the same value, want a fresh nonce")
+	}
+
+	got, err := box.Decrypt("BILLING_API_TOKEN", enc)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if got != "s3cret" {
+		t.Errorf("Decrypt = %q, want %q", got, "s3cret")
+	}
+}
+
+func TestDecrypt_Plaintext(t *testing.T) {
+	got, err := newBox(t, "k").Decrypt("PORT", "8080")
+	if err != nil || got != "8080" {
+		t.Errorf("Decrypt = %q, %v; want the value unchanged", got, err)
+	}
+}
+
+func TestDecrypt_Rejects(t *testing.T) {
+	box := newBox(t, "k")
+	enc, err := box.Encrypt("BILLING_API_TOKEN", "s3cret")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	tests := map[string]struct {
+		box   *configcrypt.Box
+		name  string
+		value string
+	}{
+		"other variable": {box, "STRIPE_API_KEY", enc},
+		"wrong key":      {newBox(t, "x"), "BILLING_API_TOKEN", enc},
+		"tampered":       {box, "BILLING_API_TOKEN", enc[:len(enc)-4] + "AAAA"},
+		"truncated":      {box, "BILLING_API_TOKEN", configcrypt.Prefix + "AAAA"},
+		"not base64":     {box, "BILLING_API_TOKEN", configcrypt.Prefix + "!!"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := tt.box.Decrypt(tt.name, tt.value); err == nil {
+				t.Fatal("expected an error")
+			} else if strings.Contains(err.Error(), "s3cret") {
+				t.Errorf("error leaks the plaintext: %v", err)
+			}
+		})
+	}
+}
+
+func TestDecryptEnv(t *testing.T) {
+	box := newBox(t, "k")
+	enc, err := box.Encrypt("TENANTIQ_TEST_SECRET", "s3cret")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	t.Setenv("TENANTIQ_TEST_SECRET", enc)
+	t.Setenv("TENANTIQ_TEST_PLAIN", "plain")
+
+	names, err := configcrypt.DecryptEnv(box)
+	if err != nil {
+		t.Fatalf("DecryptEnv: %v", err)
+	}
+	if len(names) != 1 || names[0] != "TENANTIQ_TEST_SECRET" {
+		t.Errorf("names = %v, want [TENANTIQ_TEST_SECRET]", names)
+	}
+	if got := os.Getenv("TENANTIQ_TEST_SECRET"); got != "s3cret" {
+		t.Errorf("TENANTIQ_TEST_SECRET = %q, want %q", got, "s3cret")
+	}
+	if got := os.Getenv("TENANTIQ_TEST_PLAIN"); got != "plain" {
+		t.Errorf("TENANTIQ_TEST_PLAIN = %q, want %q", got, "plain")
+	}
+}
+
+func TestDecryptEnv_NoKey(t *testing.T) {
+	t.Setenv("TENANTIQ_TEST_SECRET", configcrypt.Prefix+"AAAA")
+
+	_, err := configcrypt.DecryptEnv(nil)
+	if !errors.Is(err, configcrypt.ErrNoKey) {
+		t.Fatalf("err = %v, want ErrNoKey", err)
+	}
+	if !strings.Contains(err.Error(), "TENANTIQ_TEST_SECRET") {
+		t.Errorf("error %q does not name the variable", err)
+	}
+}
@@ -0,0 +1,154 @@
+// Package egress routes the outbound HTTP requests of the adapters
+// (webhooks, billing, certificates, identity providers) through one
+// proxy, so that they leave from stable addresses that the receiving
+// systems can allowlist.
+//
+// Without EGRESS_PROXY_URL, requests follow the standard HTTPS_PROXY,
+// HTTP_PROXY and NO_PROXY variables, like Go's default transport.
+package egress
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Config holds the egress settings.
+type Config struct {
+	// ProxyURL is the http, https or socks5 proxy of every outbound
+	// request, with optional user:password credentials. When empty, the
+	// standard proxy variables apply.
+	ProxyURL string
+	// NoProxy lists hosts, domains (.example.com), IPs and CIDRs reached
+	// directly, comma separated like NO_PROXY.
+	NoProxy string
+	// SourceIPs are the addresses requests leave from, reported to
+	// operators; tenantiq cannot tell them on its own.
+	SourceIPs []string
+}
+
+// ConfigFromEnv reads the configuration from environment variables.
+func ConfigFromEnv() Config {
+	cfg := Config{
+		ProxyURL: os.Getenv("EGRESS_PROXY_URL"),
+		NoProxy:  os.Getenv("EGRESS_NO_PROXY"),
+	}
+	for _, ip := range strings.Split(os.Getenv("EGRESS_SOURCE_IPS"), ",") {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			cfg.SourceIPs = append(cfg.SourceIPs, ip)
+		}
+	}
+	return cfg
+}
+
+// Router hands out the HTTP clients of the adapters, all sharing one
+// transport through the proxy, and remembers which adapters it served.
+type Router struct {
+	cfg       Config
+	proxy     *url.URL // nil when requests go out directly
+	transport *http.Transport
+
+	mu       sync.Mutex
+	adapters []string
+}
+
+// New validates the configuration and returns a Router for it.
+func New(cfg Config) (*Router, error) {
+	for _, ip := range cfg.SourceIPs {
+		if net.ParseIP(ip) == nil {
+			if _, _, err := net.ParseCIDR(ip); err != nil {
+				return nil, fmt.Errorf("egress: source IP %q is neither an address nor a CIDR", ip)
+			}
+		}
+	}
+
+	proxyCfg := &httpproxy.Config{
+		HTTPProxy:  cfg.ProxyURL,
+		HTTPSProxy: cfg.ProxyURL,
+		NoProxy:    cfg.NoProxy,
+	}
+	if cfg.ProxyURL == "" {
+		proxyCfg = httpproxy.FromEnvironment()
+		// Reported as the proxy of HTTPS requests, which most
+		// integrations make.
+		cfg.ProxyURL = cmp.Or(proxyCfg.HTTPSProxy, proxyCfg.HTTPProxy)
+		cfg.NoProxy = proxyCfg.NoProxy
+	}
+
+	r := &Router{cfg: cfg, transport: http.DefaultTransport.(*http.Transport).Clone()}
+	r.transport.Proxy = nil
+	if cfg.ProxyURL == "" {
+		return r, nil
+	}
+	proxy, err := url.Parse(cfg.ProxyURL)
+	if err != nil || proxy.Host == "" {
+		return nil, fmt.Errorf("egress: proxy URL %q is not an absolute URL", redact(cfg.ProxyURL))
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("egress: proxy scheme %q is not http, https or socks5", proxy.Scheme)
+	}
+	r.proxy = proxy
+	// httpproxy applies NoProxy, and never proxies requests to localhost.
+	proxyFunc := proxyCfg.ProxyFunc()
+	r.transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+	return r, nil
+}
+
+// Client returns an HTTP client for the adapter called name, with the
+// request timeout.
+func (r *Router) Client(name string, timeout time.Duration) *http.Client {
+	r.mu.Lock()
+	if !slices.Contains(r.adapters, name) {
+		r.adapters = append(r.adapters, name)
+	}
+	r.mu.Unlock()
+	return &http.Client{Timeout: timeout, Transport: r.transport}
+}
+
+// Describe returns the effective egress configuration, the proxy's
+// credentials left out.
+func (r *Router) Describe() domain.Egress {
+	e := domain.Egress{
+		SourceIPs: slices.Clone(r.cfg.SourceIPs),
+		NoProxy:   []string{},
+	}
+	if e.SourceIPs == nil {
+		e.SourceIPs = []string{}
+	}
+	if r.proxy != nil {
+		e.ProxyURL = redact(r.cfg.ProxyURL)
+		for _, host := range strings.Split(r.cfg.NoProxy, ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				e.NoProxy = append(e.NoProxy, host)
+			}
+		}
+	}
+	r.mu.Lock()
+	e.Adapters = slices.Sorted(slices.Values(r.adapters))
+	r.mu.Unlock()
+	return e
+}
+
+// redact returns raw without the password of its user info.
+func redact(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "(invalid URL)"
+	}
+	return u.Redacted()
+}
@@ -3,6 +3,9 @@ package fsm
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 
 	loopfsm "github.com/looplab/fsm"
 
@@ -12,13 +15,11 @@ import (
 // Compile-time check: Validator implements domain.TransitionValidator.
 var _ domain.TransitionValidator = (*Validator)(nil)
 
-// events converts domain.Transitions into looplab/fsm EventDesc format.
+// buildEvents converts transitions into looplab/fsm EventDesc format.
 // It consolidates transitions with the same event+destination into a single
 // EventDesc with multiple source states (e.g., EventDelete from "active"
 // and "suspended" both go to "deleting").
-var events = buildEvents()
-
-func buildEvents() []loopfsm.EventDesc {
+func buildEvents(transitions []domain.Transition) []loopfsm.EventDesc {
 	type key struct {
 		event string
 		dst   string
@@ -26,7 +27,7 @@ func buildEvents() []loopfsm.EventDesc {
 	grouped := make(map[key][]string)
 	order := make([]key, 0)
 
-	for _, t := range domain.Transitions {
+	for _, t := range transitions {
 		k := key{event: string(t.Event), dst: string(t.Dst)}
 		if _, exists := grouped[k]; !exists {
 			order = append(order, k)
@@ -49,30 +50,104 @@ func buildEvents() []loopfsm.EventDesc {
 // It creates a short-lived FSM instance per Apply call, initialized with
 // the tenant's current state. This is necessary because looplab/fsm is
 // stateful (it tracks the current state internally).
-type Validator struct{}
+type Validator struct {
+	events []loopfsm.EventDesc
+	guards map[domain.Event][]domain.TransitionGuard
+}
+
+// Option configures optional behavior of the Validator.
+type Option func(*Validator)
+
+// WithGuard makes event also require guard, from whichever status the
+// lifecycle allows it. An event's guards are checked in the order they
+// were added, once its transition is known to be valid.
+func WithGuard(event domain.Event, guard domain.TransitionGuard) Option {
+	return func(v *Validator) {
+		v.guards[event] = append(v.guards[event], guard)
+	}
+}
 
-// New creates a new FSM-backed transition validator.
-func New() *Validator {
-	return &Validator{}
+// New creates a new FSM-backed transition validator enforcing
+// domain.Transitions.
+func New(opts ...Option) *Validator {
+	return NewFromTransitions(domain.Transitions, opts...)
 }
 
-// Apply checks if the given event is valid from the current status and
+// NewFromTransitions creates a validator enforcing transitions, such as a
+// lifecycle loaded from a file, instead of domain.Transitions.
+func NewFromTransitions(transitions []domain.Transition, opts ...Option) *Validator {
+	v := &Validator{
+		events: buildEvents(transitions),
+		guards: make(map[domain.Event][]domain.TransitionGuard),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Apply checks if the given event is valid from the tenant's status and
 // returns the destination status. Returns a domain.TransitionError if
-// the transition is not allowed.
-func (v *Validator) Apply(ctx context.Context, current domain.Status, event domain.Event) (domain.Status, error) {
-	machine := loopfsm.NewFSM(string(current), events, nil)
+// the transition is not allowed, or the event is not one of the lifecycle,
+// and a domain.GuardError if one of the event's guards refuses it.
+func (v *Validator) Apply(ctx context.Context, tenant domain.Tenant, event domain.Event) (domain.Status, error) {
+	machine := loopfsm.NewFSM(string(tenant.Status), v.events, nil)
 
 	if err := machine.Event(ctx, string(event)); err != nil {
 		var invalidEvent loopfsm.InvalidEventError
+		var unknownEvent loopfsm.UnknownEventError
 		var noTransition loopfsm.NoTransitionError
-		if errors.As(err, &invalidEvent) || errors.As(err, &noTransition) {
+		if errors.As(err, &invalidEvent) || errors.As(err, &unknownEvent) || errors.As(err, &noTransition) {
 			return "", &domain.TransitionError{
 				Event:   event,
-				Current: current,
+				Current: tenant.Status,
 			}
 		}
 		return "", err
 	}
 
+	for _, guard := range v.guards[event] {
+		reason, err := guard.Check(ctx, tenant)
+		if err != nil {
+			return "", fmt.Errorf("checking guard %q: %w", guard.Name(), err)
+		}
+		if reason != "" {
+			return "", &domain.GuardError{
+				Guard:   guard.Name(),
+				Event:   event,
+				Current: tenant.Status,
+				Reason:  reason,
+			}
+		}
+	}
+
 	return domain.Status(machine.Current()), nil
 }
+
+// ParseGuards parses which events require which of guards from a
+// comma-separated list of event=guard pairs naming them, e.g.
+// "delete=no_active_projects,reactivate=billing_current". An event may be
+// listed with several guards, checked in the listed order.
+func ParseGuards(s string, guards ...domain.TransitionGuard) ([]Option, error) {
+	var opts []Option
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		event, name, ok := strings.Cut(pair, "=")
+		event, name = strings.TrimSpace(event), strings.TrimSpace(name)
+		if !ok || event == "" || name == "" {
+			return nil, fmt.Errorf("invalid guard %q, want event=guard", pair)
+		}
+		if !slices.Contains(domain.Events(), domain.Event(event)) {
+			return nil, fmt.Errorf("guard %q: unknown event %q", pair, event)
+		}
+		i := slices.IndexFunc(guards, func(g domain.TransitionGuard) bool { return g.Name() == name })
+		if i < 0 {
+			return nil, fmt.Errorf("guard %q: unknown guard %q", pair, name)
+		}
+		opts = append(opts, WithGuard(domain.Event(event), guards[i]))
+	}
+	return opts, nil
+}
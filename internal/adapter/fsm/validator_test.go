@@ -3,6 +3,8 @@ package fsm_test
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
 
 	adapter "github.com/neomorfeo/tenantiq/internal/adapter/fsm"
@@ -14,7 +16,7 @@ func TestValidator_AllTransitions(t *testing.T) {
 	ctx := context.Background()
 
 	for _, tr := range domain.Transitions {
-		dst, err := v.Apply(ctx, tr.Src, tr.Event)
+		dst, err := v.Apply(ctx, domain.Tenant{Status: tr.Src}, tr.Event)
 		if err != nil {
 			t.Errorf("Apply(%q, %q) unexpected error: %v", tr.Src, tr.Event, err)
 			continue
@@ -30,7 +32,7 @@ func TestValidator_InvalidTransition(t *testing.T) {
 	ctx := context.Background()
 
 	// Can't suspend from "creating" state.
-	_, err := v.Apply(ctx, domain.StatusCreating, domain.EventSuspend)
+	_, err := v.Apply(ctx, domain.Tenant{Status: domain.StatusCreating}, domain.EventSuspend)
 	var trErr *domain.TransitionError
 	if !errors.As(err, &trErr) {
 		t.Fatalf("expected TransitionError, got %v", err)
@@ -60,7 +62,7 @@ func TestValidator_FullLifecycle(t *testing.T) {
 	}
 
 	for _, step := range steps {
-		got, err := v.Apply(ctx, step.from, step.event)
+		got, err := v.Apply(ctx, domain.Tenant{Status: step.from}, step.event)
 		if err != nil {
 			t.Fatalf("Apply(%q, %q) error: %v", step.from, step.event, err)
 		}
@@ -75,7 +77,7 @@ func TestValidator_DeleteFromSuspended(t *testing.T) {
 	ctx := context.Background()
 
 	// Delete is valid from both "active" and "suspended".
-	got, err := v.Apply(ctx, domain.StatusSuspended, domain.EventDelete)
+	got, err := v.Apply(ctx, domain.Tenant{Status: domain.StatusSuspended}, domain.EventDelete)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -83,3 +85,111 @@ func TestValidator_DeleteFromSuspended(t *testing.T) {
 		t.Errorf("got %q, want %q", got, domain.StatusDeleting)
 	}
 }
+
+func TestNewFromTransitions(t *testing.T) {
+	grace := domain.Status("grace_period")
+	v := adapter.NewFromTransitions(append(slices.Clone(domain.Transitions),
+		domain.Transition{Event: "payment_overdue", Src: domain.StatusActive, Dst: grace},
+		domain.Transition{Event: domain.EventSuspend, Src: grace, Dst: domain.StatusSuspended},
+	))
+	ctx := context.Background()
+
+	got, err := v.Apply(ctx, domain.Tenant{Status: domain.StatusActive}, "payment_overdue")
+	if err != nil || got != grace {
+		t.Fatalf("Apply(active, payment_overdue) = %q, %v; want %q", got, err, grace)
+	}
+	if got, err = v.Apply(ctx, domain.Tenant{Status: grace}, domain.EventSuspend); err != nil || got != domain.StatusSuspended {
+		t.Errorf("Apply(grace_period, suspend) = %q, %v; want %q", got, err, domain.StatusSuspended)
+	}
+
+	// The built-in validator knows nothing of the custom event.
+	var trErr *domain.TransitionError
+	if _, err := adapter.New().Apply(ctx, domain.Tenant{Status: domain.StatusActive}, "payment_overdue"); !errors.As(err, &trErr) {
+		t.Errorf("built-in Apply error = %v, want a TransitionError", err)
+	}
+}
+
+// stubGuard refuses tenants whose slug it is given, and fails to evaluate
+// when err is set.
+type stubGuard struct {
+	refuse string
+	err    error
+	checks int
+}
+
+func (g *stubGuard) Name() string { return "stub" }
+
+func (g *stubGuard) Check(_ context.Context, tenant domain.Tenant) (string, error) {
+	g.checks++
+	if g.err != nil {
+		return "", g.err
+	}
+	if tenant.Slug == g.refuse {
+		return "tenant is on hold", nil
+	}
+	return "", nil
+}
+
+func TestValidator_Guards(t *testing.T) {
+	guard := &stubGuard{refuse: "held"}
+	v := adapter.New(adapter.WithGuard(domain.EventDelete, guard))
+	ctx := context.Background()
+
+	held := domain.Tenant{Slug: "held", Status: domain.StatusSuspended}
+	_, err := v.Apply(ctx, held, domain.EventDelete)
+	var gErr *domain.GuardError
+	if !errors.As(err, &gErr) {
+		t.Fatalf("Apply error = %v, want a GuardError", err)
+	}
+	if gErr.Guard != "stub" || gErr.Current != domain.StatusSuspended || gErr.Reason != "tenant is on hold" {
+		t.Errorf("GuardError = %+v", gErr)
+	}
+
+	// Other events and other tenants are not held back.
+	if got, err := v.Apply(ctx, held, domain.EventReactivate); err != nil || got != domain.StatusActive {
+		t.Errorf("Apply(reactivate) = %q, %v; want active", got, err)
+	}
+	if got, err := v.Apply(ctx, domain.Tenant{Slug: "acme", Status: domain.StatusSuspended}, domain.EventDelete); err != nil || got != domain.StatusDeleting {
+		t.Errorf("Apply(delete) = %q, %v; want deleting", got, err)
+	}
+
+	// Guards are not consulted for transitions the lifecycle refuses.
+	checks := guard.checks
+	if _, err := v.Apply(ctx, domain.Tenant{Slug: "held", Status: domain.StatusCreating}, domain.EventDelete); !errors.As(err, new(*domain.TransitionError)) {
+		t.Errorf("Apply(delete) from creating = %v, want a TransitionError", err)
+	}
+	if guard.checks != checks {
+		t.Error("guard checked for an invalid transition")
+	}
+
+	guard.err = errors.New("usage unavailable")
+	if _, err := v.Apply(ctx, held, domain.EventDelete); !errors.Is(err, guard.err) || errors.As(err, &gErr) {
+		t.Errorf("Apply error = %v, want the guard's own error", err)
+	}
+}
+
+func TestParseGuards(t *testing.T) {
+	guard := &stubGuard{refuse: "held"}
+	opts, err := adapter.ParseGuards(" delete = stub , reactivate=stub,", guard)
+	if err != nil {
+		t.Fatalf("ParseGuards: %v", err)
+	}
+	v := adapter.New(opts...)
+	ctx := context.Background()
+	held := domain.Tenant{Slug: "held", Status: domain.StatusSuspended}
+	for _, event := range []domain.Event{domain.EventDelete, domain.EventReactivate} {
+		if _, err := v.Apply(ctx, held, event); !errors.As(err, new(*domain.GuardError)) {
+			t.Errorf("Apply(%s) error = %v, want a GuardError", event, err)
+		}
+	}
+
+	for spec, want := range map[string]string{
+		"delete":             "want event=guard",
+		"archive=stub":       `unknown event "archive"`,
+		"delete=no_projects": `unknown guard "no_projects"`,
+	} {
+		if _, err := adapter.ParseGuards(spec, guard); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseGuards(%q) error = %v, want %q", spec, err, want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// forwardedForHeader lists the addresses a request went through, the
+// client first, as appended by proxies.
+const forwardedForHeader = "X-Forwarded-For"
+
+// WithTrustedProxies takes the client address of requests from networks
+// from X-Forwarded-For: the last address the trusted proxies did not add.
+// Without trusted proxies, the client is whoever connected.
+func WithTrustedProxies(networks ...netip.Prefix) Option {
+	return func(o *options) { o.trustedProxies = networks }
+}
+
+// clientIPMiddleware records the client address of the request, which the
+// IP allowlists of tenants check their API keys against.
+func clientIPMiddleware(trusted []netip.Prefix) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		addr := clientIP(ctx.RemoteAddr(), ctx.Header(forwardedForHeader), trusted)
+		if !addr.IsValid() {
+			next(ctx)
+			return
+		}
+		next(huma.WithContext(ctx, domain.WithClientIP(ctx.Context(), addr)))
+	}
+}
+
+// clientIP returns the address of the client of a request received from
+// remoteAddr, walking back X-Forwarded-For while the address is a trusted
+// proxy. A malformed entry stops the walk: what precedes it cannot be
+// trusted.
+func clientIP(remoteAddr, forwardedFor string, trusted []netip.Prefix) netip.Addr {
+	var addr netip.Addr
+	if ap, err := netip.ParseAddrPort(remoteAddr); err == nil {
+		addr = ap.Addr()
+	} else if addr, err = netip.ParseAddr(remoteAddr); err != nil {
+		return netip.Addr{}
+	}
+	if forwardedFor == "" {
+		return addr.Unmap()
+	}
+	hops := strings.Split(forwardedFor, ",")
+	for i := len(hops) - 1; i >= 0 && isTrusted(addr, trusted); i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		addr = hop
+	}
+	return addr.Unmap()
+}
+
+func isTrusted(addr netip.Addr, trusted []netip.Prefix) bool {
+	addr = addr.Unmap()
+	for _, p := range trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// IPAllowlistResponse is the networks a tenant's API keys may be used
+// from.
+type IPAllowlistResponse struct {
+	TenantID string   `json:"tenant_id" doc:"Tenant ID"`
+	Networks []string `json:"networks" doc:"Networks in CIDR notation, sorted; empty when the keys may be used from anywhere"`
+}
+
+type GetIPAllowlistInput struct {
+	ID string `path:"id" doc:"Tenant ID"`
+}
+
+type SetIPAllowlistInput struct {
+	ID   string `path:"id" doc:"Tenant ID"`
+	Body struct {
+		Networks []string `json:"networks" maxItems:"100" doc:"Networks in CIDR notation, such as 203.0.113.0/24, or single addresses; empty to allow every address"`
+	}
+}
+
+type IPAllowlistOutput struct {
+	Body IPAllowlistResponse
+}
+
+func registerIPAllowlists(api huma.API, svc *app.TenantService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-tenant-ip-allowlist",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/tenants/{id}/ip-allowlist",
+		Summary:     "Get the networks a tenant's API keys may be used from",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *GetIPAllowlistInput) (*IPAllowlistOutput, error) {
+		l, err := svc.IPAllowlist(ctx, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &IPAllowlistOutput{Body: IPAllowlistResponse{TenantID: input.ID, Networks: l.Strings()}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-tenant-ip-allowlist",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/tenants/{id}/ip-allowlist",
+		Summary:     "Restrict the networks a tenant's API keys may be used from",
+		Description: "Requests authenticated with the tenant's API keys from other addresses get 403. " +
+			"An empty list lifts the restriction.",
+		Tags: []string{"Tenants"},
+	}, func(ctx context.Context, input *SetIPAllowlistInput) (*IPAllowlistOutput, error) {
+		l, err := svc.SetIPAllowlist(ctx, input.ID, input.Body.Networks)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &IPAllowlistOutput{Body: IPAllowlistResponse{TenantID: input.ID, Networks: l.Strings()}}, nil
+	})
+}
@@ -0,0 +1,86 @@
+package http_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+	"testing"
+	"time"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+func TestIPAllowlist_RestrictsAPIKeys(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	db := repo.DB()
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{},
+		app.WithIPAllowlists(sqlite.NewIPAllowlistRepository(db)))
+	ks := app.NewAPIKeyService(sqlite.NewAPIKeyRepository(db), svc)
+	// The test client connects from 127.0.0.1, standing for a proxy.
+	srv := serveService(t, svc, adapter.WithAPIKeys(ks),
+		adapter.WithTrustedProxies(netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("10.0.0.0/8")))
+
+	acme := mustCreateTenant(t, srv, "Acme", "acme", "pro")
+	_, key, err := ks.Create(t.Context(), acme.ID, "CI", nil, time.Time{})
+	if err != nil {
+		t.Fatalf("creating key: %v", err)
+	}
+	base := srv.URL + "/api/v1/tenants/" + acme.ID + "/ip-allowlist"
+
+	resp := doRequest(t, http.MethodPut, base, `{"networks":["203.0.113.7/24","198.51.100.1"]}`)
+	var list adapter.IPAllowlistResponse
+	_ = json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !slices.Equal(list.Networks, []string{"198.51.100.1/32", "203.0.113.0/24"}) {
+		t.Fatalf("set: status = %d, networks = %v", resp.StatusCode, list.Networks)
+	}
+	resp = doRequest(t, http.MethodPut, base, `{"networks":["example.com"]}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("set of a bad network: status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+
+	verify := func(forwardedFor string) int {
+		t.Helper()
+		headers := map[string]string{}
+		if forwardedFor != "" {
+			headers["X-Forwarded-For"] = forwardedFor
+		}
+		resp := doRequestWithHeaders(t, http.MethodPost, srv.URL+"/api/v1/api-keys:verify", fmt.Sprintf(`{"key":%q}`, key), headers)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	for forwardedFor, want := range map[string]int{
+		"203.0.113.9":            http.StatusOK,
+		"192.0.2.1, 203.0.113.9": http.StatusOK,
+		"203.0.113.9, 10.1.2.3":  http.StatusOK,
+		"203.0.113.9, 192.0.2.1": http.StatusForbidden,
+		"203.0.113.9, not-an-ip": http.StatusForbidden,
+		"":                       http.StatusForbidden,
+	} {
+		if got := verify(forwardedFor); got != want {
+			t.Errorf("verify forwarded for %q: status = %d, want %d", forwardedFor, got, want)
+		}
+	}
+
+	resp = doRequest(t, http.MethodPut, base, `{"networks":[]}`)
+	resp.Body.Close()
+	if got := verify(""); resp.StatusCode != http.StatusOK || got != http.StatusOK {
+		t.Errorf("after clearing: set %d, verify %d; want 200 and 200", resp.StatusCode, got)
+	}
+	resp = doRequest(t, http.MethodGet, base, "")
+	list = adapter.IPAllowlistResponse{}
+	_ = json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || len(list.Networks) != 0 {
+		t.Errorf("get after clearing: status = %d, networks = %v", resp.StatusCode, list.Networks)
+	}
+}
@@ -0,0 +1,193 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithAPIKeys exposes the API keys of tenants under
+// /api/v1/tenants/{id}/api-keys, their verification, and the status widget
+// they give access to.
+func WithAPIKeys(ks *app.APIKeyService) Option {
+	return func(o *options) { o.apiKeys = ks }
+}
+
+// APIKeyResponse is the API representation of a tenant API key. The key
+// itself is only returned when it is created.
+type APIKeyResponse struct {
+	ID         string   `json:"id" doc:"API key ID"`
+	TenantID   string   `json:"tenant_id" doc:"Tenant ID"`
+	Name       string   `json:"name" doc:"What the key is for"`
+	Prefix     string   `json:"prefix" doc:"Start of the key, to tell keys apart"`
+	Scopes     []string `json:"scopes" doc:"What the key may do"`
+	CreatedBy  string   `json:"created_by" doc:"Who created the key"`
+	CreatedAt  string   `json:"created_at" doc:"Creation timestamp (ISO 8601)"`
+	ExpiresAt  string   `json:"expires_at,omitempty" doc:"When the key stops working (ISO 8601); absent when it does not expire"`
+	LastUsedAt string   `json:"last_used_at,omitempty" doc:"When the key was last verified, to the minute (ISO 8601); absent when it never was"`
+	RevokedAt  string   `json:"revoked_at,omitempty" doc:"When the key was revoked (ISO 8601)"`
+}
+
+func toAPIKeyResponse(k domain.APIKey) APIKeyResponse {
+	resp := APIKeyResponse{
+		ID:        k.ID,
+		TenantID:  k.TenantID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes,
+		CreatedBy: k.CreatedBy,
+		CreatedAt: k.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if resp.Scopes == nil {
+		resp.Scopes = []string{}
+	}
+	if !k.ExpiresAt.IsZero() {
+		resp.ExpiresAt = k.ExpiresAt.Format("2006-01-02T15:04:05Z")
+	}
+	if !k.LastUsedAt.IsZero() {
+		resp.LastUsedAt = k.LastUsedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if !k.RevokedAt.IsZero() {
+		resp.RevokedAt = k.RevokedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return resp
+}
+
+// CreatedAPIKeyResponse is a new API key, with the key itself.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key" doc:"The key; it is not stored and cannot be retrieved again"`
+}
+
+type CreateAPIKeyInput struct {
+	ID   string `path:"id" doc:"Tenant ID"`
+	Body struct {
+		Name      string   `json:"name" minLength:"1" maxLength:"100" doc:"What the key is for"`
+		Scopes    []string `json:"scopes,omitempty" doc:"What the key may do, such as tenants:read; the applications accepting the key decide what each scope grants"`
+		ExpiresAt string   `json:"expires_at,omitempty" doc:"When the key stops working (RFC 3339); it does not expire when omitted"`
+	}
+}
+
+type ListAPIKeysInput struct {
+	ID string `path:"id" doc:"Tenant ID"`
+}
+
+type APIKeyIDInput struct {
+	ID    string `path:"id" doc:"Tenant ID"`
+	KeyID string `path:"key_id" doc:"API key ID"`
+}
+
+type VerifyAPIKeyInput struct {
+	Body struct {
+		Key string `json:"key" minLength:"1" doc:"The key presented to the application"`
+	}
+}
+
+type CreatedAPIKeyOutput struct {
+	Body CreatedAPIKeyResponse
+}
+
+type APIKeyListOutput struct {
+	Body struct {
+		Items []APIKeyResponse `json:"items" doc:"Keys, newest first, revoked ones included"`
+	}
+}
+
+type VerifyAPIKeyOutput struct {
+	Body struct {
+		KeyID        string   `json:"key_id" doc:"API key ID"`
+		TenantID     string   `json:"tenant_id" doc:"Tenant the key belongs to"`
+		TenantStatus string   `json:"tenant_status" doc:"Current status of the tenant"`
+		Scopes       []string `json:"scopes" doc:"What the key may do"`
+	}
+}
+
+func registerAPIKeys(api huma.API, ks *app.APIKeyService, limiter *app.RequestLimiter, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "create-tenant-api-key",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/tenants/{id}/api-keys",
+		Summary:     "Create an API key for a tenant",
+		Description: "Machine credential for the tenant's applications, which check it with verify-api-key. " +
+			"The key is returned only in this response: just a hash of it is stored.",
+		Tags: []string{"Tenants"},
+	}, func(ctx context.Context, input *CreateAPIKeyInput) (*CreatedAPIKeyOutput, error) {
+		var expiresAt time.Time
+		if v := input.Body.ExpiresAt; v != "" {
+			var err error
+			if expiresAt, err = time.Parse(time.RFC3339, v); err != nil {
+				return nil, huma.Error422UnprocessableEntity("expires_at must be an RFC 3339 time")
+			}
+		}
+		k, secret, err := ks.Create(ctx, input.ID, input.Body.Name, input.Body.Scopes, expiresAt)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &CreatedAPIKeyOutput{Body: CreatedAPIKeyResponse{APIKeyResponse: toAPIKeyResponse(k), Key: secret}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-tenant-api-keys",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/tenants/{id}/api-keys",
+		Summary:     "List a tenant's API keys",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *ListAPIKeysInput) (*APIKeyListOutput, error) {
+		keys, err := ks.List(ctx, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		out := &APIKeyListOutput{}
+		out.Body.Items = make([]APIKeyResponse, len(keys))
+		for i, k := range keys {
+			out.Body.Items[i] = toAPIKeyResponse(k)
+		}
+		return out, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "revoke-tenant-api-key",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/tenants/{id}/api-keys/{key_id}",
+		Summary:       "Revoke a tenant's API key",
+		Description:   "The key stops working at once and stays listed with its revocation time.",
+		Tags:          []string{"Tenants"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *APIKeyIDInput) (*struct{}, error) {
+		if err := ks.Revoke(ctx, input.ID, input.KeyID); err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "verify-api-key",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/api-keys:verify",
+		Summary:     "Verify a tenant API key",
+		Description: "For the tenant's applications: returns the tenant and scopes of a key, and records it as used. " +
+			"A key that is unknown, revoked or expired, or whose tenant is being deleted, is 401. " +
+			"The key is sent in the body so it stays out of access logs. " +
+			"Verifications over the tenant's rate limit get 429 Too Many Requests, with Retry-After.",
+		Tags: []string{"Tenants"},
+	}, func(ctx context.Context, input *VerifyAPIKeyInput) (*VerifyAPIKeyOutput, error) {
+		k, tenant, err := ks.Verify(ctx, input.Body.Key)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		if err := limitRequest(ctx, limiter, tenant, "verify-api-key"); err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		out := &VerifyAPIKeyOutput{}
+		out.Body.KeyID = k.ID
+		out.Body.TenantID = tenant.ID
+		out.Body.TenantStatus = string(tenant.Status)
+		out.Body.Scopes = toAPIKeyResponse(k).Scopes
+		return out, nil
+	})
+}
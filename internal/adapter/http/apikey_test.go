@@ -0,0 +1,92 @@
+package http_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+func TestAPIKeys_CreateVerifyRevoke(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{})
+	ks := app.NewAPIKeyService(sqlite.NewAPIKeyRepository(repo.DB()), svc)
+	srv := serveService(t, svc, adapter.WithAPIKeys(ks))
+
+	acme := mustCreateTenant(t, srv, "Acme", "acme", "pro")
+	base := srv.URL + "/api/v1/tenants/" + acme.ID + "/api-keys"
+
+	resp := doRequest(t, http.MethodPost, base, `{"name":"CI","scopes":["tenants:read"]}`)
+	var created adapter.CreatedAPIKeyResponse
+	_ = json.NewDecoder(resp.Body).Decode(&created)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(created.Key, app.APIKeySecretPrefix) || !strings.HasPrefix(created.Key, created.Prefix) {
+		t.Fatalf("create: status = %d, key = %+v; want 200 and a tqk_ key", resp.StatusCode, created)
+	}
+
+	for body, want := range map[string]int{
+		`{"name":"CI","scopes":["Tenants Read"]}`:               http.StatusUnprocessableEntity,
+		`{"name":"CI","expires_at":"tomorrow"}`:                 http.StatusUnprocessableEntity,
+		`{"name":"CI","expires_at":"2000-01-01T00:00:00Z"}`:     http.StatusUnprocessableEntity,
+		`{"name":"Deploy","expires_at":"2999-01-01T00:00:00Z"}`: http.StatusOK,
+	} {
+		resp := doRequest(t, http.MethodPost, base, body)
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("create %s: status = %d, want %d", body, resp.StatusCode, want)
+		}
+	}
+
+	verify := func(key string) (int, map[string]any) {
+		t.Helper()
+		resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/api-keys:verify", fmt.Sprintf(`{"key":%q}`, key))
+		defer resp.Body.Close()
+		var body map[string]any
+		_ = json.NewDecoder(resp.Body).Decode(&body)
+		return resp.StatusCode, body
+	}
+	status, body := verify(created.Key)
+	if status != http.StatusOK || body["tenant_id"] != acme.ID || body["key_id"] != created.ID || fmt.Sprint(body["scopes"]) != "[tenants:read]" {
+		t.Errorf("verify = %d %v, want 200 with acme's key", status, body)
+	}
+	if status, _ := verify("tqk_unknown"); status != http.StatusUnauthorized {
+		t.Errorf("verify of an unknown key: status = %d, want %d", status, http.StatusUnauthorized)
+	}
+
+	resp = doRequest(t, http.MethodGet, base, "")
+	var list struct {
+		Items []adapter.APIKeyResponse `json:"items"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if len(list.Items) != 2 || list.Items[1].ID != created.ID || list.Items[1].LastUsedAt == "" {
+		t.Errorf("list = %+v, want two keys, CI used", list.Items)
+	}
+	raw, _ := json.Marshal(list)
+	if strings.Contains(string(raw), created.Key) {
+		t.Error("list returned the key")
+	}
+
+	resp = doRequest(t, http.MethodDelete, base+"/"+created.ID, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("revoke: status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+	if status, _ := verify(created.Key); status != http.StatusUnauthorized {
+		t.Errorf("verify of a revoked key: status = %d, want %d", status, http.StatusUnauthorized)
+	}
+	resp = doRequest(t, http.MethodDelete, base+"/key_missing", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("revoke of a missing key: status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,27 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// RegisterAsyncAPI serves doc as JSON at /asyncapi.json, next to Huma's
+// /openapi.json. Like the OpenAPI document it is not itself an operation.
+func RegisterAsyncAPI(api huma.API, doc any) error {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("encoding AsyncAPI document: %w", err)
+	}
+
+	api.Adapter().Handle(&huma.Operation{
+		Method: http.MethodGet,
+		Path:   "/asyncapi.json",
+	}, func(ctx huma.Context) {
+		ctx.SetHeader("Content-Type", "application/json")
+		_, _ = ctx.BodyWriter().Write(data)
+	})
+	return nil
+}
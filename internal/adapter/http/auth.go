@@ -0,0 +1,379 @@
+package http
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Headers carrying a credential: a bearer token, or an admin key alone for
+// clients that cannot set Authorization.
+const (
+	authorizationHeader = "Authorization"
+	apiKeyHeader        = "X-API-Key"
+)
+
+// protectedPrefix is the path prefix of the operations requiring a
+// credential. Health checks and signed public links live outside it.
+const protectedPrefix = "/api/v1/"
+
+// WithAuthentication requires an admin key, checked against keys, on every
+// operation under /api/v1/ and exposes the keys at /api/v1/admin-keys. The
+// key's name becomes the actor of the request, in place of X-Actor.
+func WithAuthentication(keys *app.AdminKeyService) Option {
+	return func(o *options) { o.adminKeys = keys }
+}
+
+// WithTokenAuthentication requires a bearer token from an identity
+// provider, checked by tokens, on every operation under /api/v1/. With
+// WithAuthentication, either credential is accepted. The token's claims
+// are carried by the request context (see domain.ClaimsFromContext) and
+// its actorClaim, or its subject when it lacks it, becomes the actor.
+func WithTokenAuthentication(tokens domain.TokenVerifier, actorClaim string) Option {
+	return func(o *options) {
+		o.tokens = tokens
+		o.actorClaim = actorClaim
+	}
+}
+
+// WithSessions also accepts, from browsers, the session cookie named
+// cookieName, checked by sessions, when no other credential is presented.
+// Its claims are handled like a token's. The cookie must be SameSite so
+// that other sites cannot make changes on its behalf.
+func WithSessions(cookieName string, sessions domain.TokenVerifier) Option {
+	return func(o *options) {
+		o.sessions = sessions
+		o.sessionCookie = cookieName
+	}
+}
+
+// authenticationEnabled reports whether a credential is required.
+func (o *options) authenticationEnabled() bool {
+	return o.adminKeys != nil || o.tokens != nil || o.sessions != nil
+}
+
+// selfAuthenticatedOperations check their own credentials, so they do not
+// take an admin key: the import key and the billing provider's signature.
+// Verifying a tenant API key is for the tenants' applications, which hold
+// no admin key; it only tells about the key presented.
+var selfAuthenticatedOperations = map[string]bool{
+	"import-tenants":          true,
+	"receive-billing-webhook": true,
+	"verify-api-key":          true,
+}
+
+// AdminKeyResponse is the API representation of an admin key. The key
+// itself is only returned when it is created.
+type AdminKeyResponse struct {
+	ID         string `json:"id" doc:"Admin key ID"`
+	Name       string `json:"name" doc:"Who holds the key; the actor of the changes made with it"`
+	Prefix     string `json:"prefix" doc:"Start of the key, to tell keys apart"`
+	Role       string `json:"role" enum:"viewer,operator,admin" doc:"What the holder may do"`
+	CreatedBy  string `json:"created_by" doc:"Who created the key"`
+	CreatedAt  string `json:"created_at" doc:"Creation timestamp (ISO 8601)"`
+	LastUsedAt string `json:"last_used_at,omitempty" doc:"When the key was last used, to the minute (ISO 8601); absent when it never was"`
+	RevokedAt  string `json:"revoked_at,omitempty" doc:"When the key was revoked (ISO 8601)"`
+}
+
+func toAdminKeyResponse(k domain.AdminKey) AdminKeyResponse {
+	resp := AdminKeyResponse{
+		ID:        k.ID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Role:      string(k.Role),
+		CreatedBy: k.CreatedBy,
+		CreatedAt: k.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if !k.LastUsedAt.IsZero() {
+		resp.LastUsedAt = k.LastUsedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if !k.RevokedAt.IsZero() {
+		resp.RevokedAt = k.RevokedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return resp
+}
+
+// CreatedAdminKeyResponse is a new admin key, with the key itself.
+type CreatedAdminKeyResponse struct {
+	AdminKeyResponse
+	Key string `json:"key" doc:"The key; it is not stored and cannot be retrieved again"`
+}
+
+type CreateAdminKeyInput struct {
+	Body struct {
+		Name string `json:"name" minLength:"1" maxLength:"100" doc:"Who holds the key, such as a person or an automation"`
+		Role string `json:"role,omitempty" enum:"viewer,operator,admin" doc:"What the holder may do; admin by default"`
+	}
+}
+
+type AdminKeyIDInput struct {
+	ID string `path:"id" doc:"Admin key ID"`
+}
+
+type CreatedAdminKeyOutput struct {
+	Body CreatedAdminKeyResponse
+}
+
+type AdminKeyListOutput struct {
+	Body struct {
+		Items []AdminKeyResponse `json:"items" doc:"Keys, newest first, revoked ones included"`
+	}
+}
+
+// presentedCredential returns the Authorization bearer token or, failing
+// that, the key sent in X-API-Key.
+func presentedCredential(header func(string) string) string {
+	if token, ok := strings.CutPrefix(header(authorizationHeader), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return strings.TrimSpace(header(apiKeyHeader))
+}
+
+// isJWT reports whether credential has the three dot-separated parts of a
+// JWT; admin keys have none.
+func isJWT(credential string) bool {
+	return strings.Count(credential, ".") == 2
+}
+
+// authenticate returns ctx attributed to the admin key, token or session
+// presented in the headers. When the credential is missing or cannot be
+// accepted, it returns instead the status and message to answer with.
+func authenticate(ctx context.Context, o *options, header func(string) string) (context.Context, int, string) {
+	credential := presentedCredential(header)
+	switch {
+	case credential == "":
+		if session := sessionCookie(o, header); session != "" {
+			return authenticateClaims(ctx, o, o.sessions, session, "session")
+		}
+		return nil, http.StatusUnauthorized, o.missingCredential()
+	case o.tokens != nil && isJWT(credential):
+		return authenticateClaims(ctx, o, o.tokens, credential, "token")
+	case o.adminKeys == nil:
+		return nil, http.StatusUnauthorized, "invalid token"
+	}
+
+	k, err := o.adminKeys.Authenticate(ctx, credential)
+	if errors.Is(err, domain.ErrAPIKeyInvalid) {
+		return nil, http.StatusUnauthorized, "invalid admin API key"
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "authenticating admin key", "error", err)
+		return nil, http.StatusInternalServerError, "authentication failed"
+	}
+	return domain.WithRole(domain.WithActor(ctx, k.Name), k.Role), 0, ""
+}
+
+// sessionCookie returns the session cookie sent in the headers, if
+// sessions are accepted.
+func sessionCookie(o *options, header func(string) string) string {
+	if o.sessions == nil {
+		return ""
+	}
+	cookies, err := http.ParseCookie(header("Cookie"))
+	if err != nil {
+		return ""
+	}
+	for _, c := range cookies {
+		if c.Name == o.sessionCookie {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+// authenticateClaims is authenticate for a bearer token or a session,
+// which kind names, verified by verifier.
+func authenticateClaims(ctx context.Context, o *options, verifier domain.TokenVerifier, credential, kind string) (context.Context, int, string) {
+	claims, err := verifier.Verify(ctx, credential)
+	if errors.Is(err, domain.ErrTokenInvalid) {
+		slog.InfoContext(ctx, kind+" rejected", "error", err)
+		return nil, http.StatusUnauthorized, "invalid " + kind
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "verifying "+kind, "error", err)
+		return nil, http.StatusServiceUnavailable, kind + " verification unavailable"
+	}
+	actor := claims.String(o.actorClaim)
+	if actor == "" {
+		actor = claims.Subject
+	}
+	if actor == "" {
+		return nil, http.StatusUnauthorized, "the " + kind + " identifies no one: it has no " + o.actorClaim + " or sub claim"
+	}
+	ctx = domain.WithRole(domain.WithActor(ctx, actor), o.claimsRole(claims))
+	return domain.WithClaims(ctx, claims), 0, ""
+}
+
+// missingCredential is the message answering requests without one.
+func (o *options) missingCredential() string {
+	var accepted []string
+	if o.adminKeys != nil {
+		accepted = append(accepted, "an admin API key")
+	}
+	if o.tokens != nil {
+		accepted = append(accepted, "a bearer token")
+	}
+	if o.sessions != nil {
+		accepted = append(accepted, "a session")
+	}
+	switch len(accepted) {
+	case 1:
+		return accepted[0] + " is required"
+	case 2:
+		return accepted[0] + " or " + accepted[1] + " is required"
+	}
+	return strings.Join(accepted[:len(accepted)-1], ", ") + " or " + accepted[len(accepted)-1] + " is required"
+}
+
+// authMiddleware rejects with 401 the operations under /api/v1/ sent
+// without a valid credential, and with 403 those the credential's role
+// does not allow. It attributes the others to the credential.
+func authMiddleware(api huma.API, o *options) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		op := ctx.Operation()
+		if !strings.HasPrefix(op.Path, protectedPrefix) || selfAuthenticatedOperations[op.OperationID] {
+			next(ctx)
+			return
+		}
+		c, status, msg := authenticate(ctx.Context(), o, ctx.Header)
+		if status == 0 {
+			status, msg = authorize(c, op.Method, op.OperationID)
+		}
+		if status != 0 {
+			if status == http.StatusUnauthorized {
+				ctx.SetHeader("WWW-Authenticate", `Bearer realm="tenantiq"`)
+			}
+			_ = huma.WriteErr(api, ctx, status, msg)
+			return
+		}
+		next(huma.WithContext(ctx, c))
+	}
+}
+
+// RequireAuthentication protects the routes mounted outside Huma, such as
+// the WebSocket feed, like the options (WithAuthentication,
+// WithTokenAuthentication and WithSessions) protect the operations. Without
+// any, it lets every request through.
+func RequireAuthentication(opts ...Option) func(http.Handler) http.Handler {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return func(next http.Handler) http.Handler {
+		if !o.authenticationEnabled() {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, status, msg := authenticate(r.Context(), &o, r.Header.Get)
+			if status == 0 {
+				status, msg = authorize(ctx, r.Method, "")
+			}
+			if status != 0 {
+				if status == http.StatusUnauthorized {
+					w.Header().Set("WWW-Authenticate", `Bearer realm="tenantiq"`)
+				}
+				w.Header().Set("Content-Type", "application/problem+json")
+				w.WriteHeader(status)
+				_ = json.NewEncoder(w).Encode(huma.ErrorModel{Status: status, Title: http.StatusText(status), Detail: msg})
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// documentAuthentication declares the accepted credentials in the OpenAPI
+// document, any one of them being enough.
+func documentAuthentication(api huma.API, o *options) {
+	oapi := api.OpenAPI()
+	if oapi.Components.SecuritySchemes == nil {
+		oapi.Components.SecuritySchemes = map[string]*huma.SecurityScheme{}
+	}
+	if o.adminKeys != nil {
+		oapi.Components.SecuritySchemes["adminKey"] = &huma.SecurityScheme{
+			Type:        "http",
+			Scheme:      "bearer",
+			Description: "Admin API key, also accepted in X-API-Key. Created with `tenantiq create-admin-key` or /api/v1/admin-keys.",
+		}
+		oapi.Security = append(oapi.Security, map[string][]string{"adminKey": {}})
+	}
+	if o.tokens != nil {
+		oapi.Components.SecuritySchemes["bearerToken"] = &huma.SecurityScheme{
+			Type:         "http",
+			Scheme:       "bearer",
+			BearerFormat: "JWT",
+			Description:  "Token of the identity provider, signed with HS256 or RS256.",
+		}
+		oapi.Security = append(oapi.Security, map[string][]string{"bearerToken": {}})
+	}
+	if o.sessions != nil {
+		oapi.Components.SecuritySchemes["session"] = &huma.SecurityScheme{
+			Type:        "apiKey",
+			In:          "cookie",
+			Name:        o.sessionCookie,
+			Description: "Session of a browser, set by logging in at /auth/login.",
+		}
+		oapi.Security = append(oapi.Security, map[string][]string{"session": {}})
+	}
+}
+
+func registerAdminKeys(api huma.API, keys *app.AdminKeyService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "create-admin-key",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/admin-keys",
+		Summary:     "Create an admin API key",
+		Description: "Credential of the management API for a person or an automation; the changes made with it " +
+			"are attributed to its name. The key is returned only in this response: just a hash of it is stored.",
+		Tags: []string{"System"},
+	}, func(ctx context.Context, input *CreateAdminKeyInput) (*CreatedAdminKeyOutput, error) {
+		k, secret, err := keys.Create(ctx, input.Body.Name, domain.Role(cmp.Or(input.Body.Role, string(domain.RoleAdmin))))
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &CreatedAdminKeyOutput{Body: CreatedAdminKeyResponse{AdminKeyResponse: toAdminKeyResponse(k), Key: secret}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-admin-keys",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin-keys",
+		Summary:     "List the admin API keys",
+		Tags:        []string{"System"},
+	}, func(ctx context.Context, _ *struct{}) (*AdminKeyListOutput, error) {
+		list, err := keys.List(ctx)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		out := &AdminKeyListOutput{}
+		out.Body.Items = make([]AdminKeyResponse, len(list))
+		for i, k := range list {
+			out.Body.Items[i] = toAdminKeyResponse(k)
+		}
+		return out, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "revoke-admin-key",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/admin-keys/{id}",
+		Summary:       "Revoke an admin API key",
+		Description:   "The key stops working at once and stays listed with its revocation time.",
+		Tags:          []string{"System"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *AdminKeyIDInput) (*struct{}, error) {
+		if err := keys.Revoke(ctx, input.ID); err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithBilling exposes the billing reconciliation report under /api/v1/billing.
+func WithBilling(bs *app.BillingService) Option {
+	return func(o *options) { o.billing = bs }
+}
+
+// BillingMismatchResponse is one disagreement between a tenant and billing.
+type BillingMismatchResponse struct {
+	Kind               string `json:"kind" enum:"missing_subscription,plan_mismatch,suspended_paying,deleted_paying,unknown_tenant" doc:"What disagrees"`
+	TenantID           string `json:"tenant_id" doc:"Tenant ID (as referenced by billing for unknown_tenant)"`
+	TenantPlan         string `json:"tenant_plan,omitempty" doc:"Plan in tenantiq"`
+	TenantStatus       string `json:"tenant_status,omitempty" doc:"Lifecycle state in tenantiq"`
+	SubscriptionPlan   string `json:"subscription_plan,omitempty" doc:"Plan billed"`
+	SubscriptionStatus string `json:"subscription_status,omitempty" doc:"Subscription state at the billing provider"`
+	Detail             string `json:"detail" doc:"Human-readable explanation"`
+}
+
+// ReconciliationResponse is the outcome of a billing reconciliation.
+type ReconciliationResponse struct {
+	CheckedAt     string                    `json:"checked_at" doc:"When the check ran (ISO 8601)"`
+	Tenants       int                       `json:"tenants" doc:"Tenants checked"`
+	Subscriptions int                       `json:"subscriptions" doc:"Subscriptions read from the billing provider"`
+	Mismatches    []BillingMismatchResponse `json:"mismatches" doc:"Disagreements, ordered by tenant ID"`
+}
+
+type ReconciliationOutput struct {
+	Body ReconciliationResponse
+}
+
+func registerBilling(api huma.API, bs *app.BillingService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "reconcile-billing",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/billing/reconciliation",
+		Summary:     "Cross-check tenants against billing",
+		Description: "Reads the billing provider's subscriptions and reports tenants billed inconsistently " +
+			"with their plan or lifecycle state. Nothing is changed.",
+		Tags: []string{"Billing"},
+	}, func(ctx context.Context, _ *struct{}) (*ReconciliationOutput, error) {
+		report, err := bs.Reconcile(ctx)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &ReconciliationOutput{Body: toReconciliationResponse(report)}, nil
+	})
+}
+
+func toReconciliationResponse(r app.ReconciliationReport) ReconciliationResponse {
+	resp := ReconciliationResponse{
+		CheckedAt:     r.CheckedAt.Format("2006-01-02T15:04:05Z"),
+		Tenants:       r.Tenants,
+		Subscriptions: r.Subscriptions,
+		Mismatches:    make([]BillingMismatchResponse, 0, len(r.Mismatches)),
+	}
+	for _, m := range r.Mismatches {
+		resp.Mismatches = append(resp.Mismatches, toBillingMismatchResponse(m))
+	}
+	return resp
+}
+
+func toBillingMismatchResponse(m domain.BillingMismatch) BillingMismatchResponse {
+	return BillingMismatchResponse{
+		Kind:               string(m.Kind),
+		TenantID:           m.TenantID,
+		TenantPlan:         m.TenantPlan,
+		TenantStatus:       string(m.TenantStatus),
+		SubscriptionPlan:   m.SubscriptionPlan,
+		SubscriptionStatus: string(m.SubscriptionStatus),
+		Detail:             m.Detail,
+	}
+}
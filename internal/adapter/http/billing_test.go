@@ -0,0 +1,86 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// stubBilling returns fixed subscriptions, or err.
+type stubBilling struct {
+	subs []domain.Subscription
+	err  error
+}
+
+func (s *stubBilling) Subscriptions(context.Context) ([]domain.Subscription, error) {
+	return s.subs, s.err
+}
+
+func newBillingTestServer(t *testing.T, billing domain.BillingProvider) (*httptest.Server, *app.TenantService) {
+	t.Helper()
+
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{})
+	return serveService(t, svc, adapter.WithBilling(app.NewBillingService(billing, svc))), svc
+}
+
+func TestBillingReconciliation(t *testing.T) {
+	billing := &stubBilling{subs: []domain.Subscription{
+		{TenantID: "ten_gone", Plan: "pro", Status: domain.SubscriptionActive},
+	}}
+	srv, svc := newBillingTestServer(t, billing)
+	ctx := context.Background()
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/billing/reconciliation", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var report adapter.ReconciliationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if report.Tenants != 1 || report.Subscriptions != 1 || len(report.Mismatches) != 2 {
+		t.Fatalf("report = %+v, want 2 mismatches", report)
+	}
+	kinds := map[string]string{}
+	for _, m := range report.Mismatches {
+		kinds[m.TenantID] = m.Kind
+	}
+	if kinds[tenant.ID] != "missing_subscription" || kinds["ten_gone"] != "unknown_tenant" {
+		t.Errorf("mismatches = %+v", report.Mismatches)
+	}
+}
+
+func TestBillingReconciliation_ProviderDown(t *testing.T) {
+	srv, _ := newBillingTestServer(t, &stubBilling{err: errors.New("connection refused")})
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/billing/reconciliation", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadGateway)
+	}
+}
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithBlueprints exposes the tenant blueprints under /api/v1/blueprints.
+// Creating tenants from them also needs app.WithBlueprints.
+func WithBlueprints(bs *app.BlueprintService) Option {
+	return func(o *options) { o.blueprints = bs }
+}
+
+// BlueprintResponse is the API representation of a blueprint.
+type BlueprintResponse struct {
+	Name      string            `json:"name" doc:"Unique name, as given at tenant creation"`
+	Plan      string            `json:"plan" doc:"Plan of tenants created without one"`
+	Metadata  map[string]string `json:"metadata,omitempty" doc:"Metadata of the tenants created from the blueprint"`
+	Features  map[string]bool   `json:"features,omitempty" doc:"Feature flags, set on tenants as metadata feature.<name>"`
+	Variables map[string]string `json:"variables,omitempty" doc:"Defaults of the provisioning template variables, set on tenants as metadata var.<name>"`
+	CreatedAt string            `json:"created_at" doc:"Creation timestamp (ISO 8601)"`
+	UpdatedAt string            `json:"updated_at" doc:"Last update timestamp (ISO 8601)"`
+}
+
+func toBlueprintResponse(b domain.Blueprint) BlueprintResponse {
+	return BlueprintResponse{
+		Name:      b.Name,
+		Plan:      b.Plan,
+		Metadata:  b.Metadata,
+		Features:  b.Features,
+		Variables: b.Variables,
+		CreatedAt: b.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: b.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// BlueprintConfig is the configuration a blueprint gives tenants.
+type BlueprintConfig struct {
+	Plan      string            `json:"plan" minLength:"1" doc:"Plan of tenants created without one"`
+	Metadata  map[string]string `json:"metadata,omitempty" doc:"Metadata of the tenants created from the blueprint"`
+	Features  map[string]bool   `json:"features,omitempty" doc:"Feature flags, set on tenants as metadata feature.<name> (\"true\" or \"false\")"`
+	Variables map[string]string `json:"variables,omitempty" doc:"Defaults of the provisioning template variables, set on tenants as metadata var.<name>"`
+}
+
+type CreateBlueprintInput struct {
+	Body struct {
+		Name string `json:"name" minLength:"1" maxLength:"63" doc:"Unique name (lowercase, hyphens or underscores), such as enterprise-eu"`
+		BlueprintConfig
+	}
+}
+
+type UpdateBlueprintInput struct {
+	Name string `path:"name" doc:"Blueprint name"`
+	Body BlueprintConfig
+}
+
+type BlueprintNameInput struct {
+	Name string `path:"name" doc:"Blueprint name"`
+}
+
+type BlueprintOutput struct {
+	Body BlueprintResponse
+}
+
+type BlueprintListOutput struct {
+	Body struct {
+		Items []BlueprintResponse `json:"items" doc:"Blueprints, by name"`
+	}
+}
+
+func registerBlueprints(api huma.API, bs *app.BlueprintService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "create-blueprint",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/blueprints",
+		Summary:     "Define a tenant blueprint",
+		Description: "Tenants created with `\"blueprint\": \"<name>\"` start on its plan, unless they name one, " +
+			"with its metadata, feature flags and template variables, which the request's metadata overrides. " +
+			"The blueprint's name is recorded in their metadata under `blueprint`.",
+		Tags: []string{"Blueprints"},
+	}, func(ctx context.Context, input *CreateBlueprintInput) (*BlueprintOutput, error) {
+		b := input.Body
+		bp, err := bs.Create(ctx, b.Name, b.Plan, b.Metadata, b.Features, b.Variables)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &BlueprintOutput{Body: toBlueprintResponse(bp)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-blueprints",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/blueprints",
+		Summary:     "List tenant blueprints",
+		Tags:        []string{"Blueprints"},
+	}, func(ctx context.Context, _ *struct{}) (*BlueprintListOutput, error) {
+		blueprints, err := bs.List(ctx)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		out := &BlueprintListOutput{}
+		out.Body.Items = make([]BlueprintResponse, len(blueprints))
+		for i, bp := range blueprints {
+			out.Body.Items[i] = toBlueprintResponse(bp)
+		}
+		return out, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-blueprint",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/blueprints/{name}",
+		Summary:     "Get a tenant blueprint",
+		Tags:        []string{"Blueprints"},
+	}, func(ctx context.Context, input *BlueprintNameInput) (*BlueprintOutput, error) {
+		bp, err := bs.Get(ctx, input.Name)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &BlueprintOutput{Body: toBlueprintResponse(bp)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-blueprint",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/blueprints/{name}",
+		Summary:     "Replace a tenant blueprint's configuration",
+		Description: "Only tenants created afterwards get the new configuration.",
+		Tags:        []string{"Blueprints"},
+	}, func(ctx context.Context, input *UpdateBlueprintInput) (*BlueprintOutput, error) {
+		b := input.Body
+		bp, err := bs.Update(ctx, input.Name, b.Plan, b.Metadata, b.Features, b.Variables)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &BlueprintOutput{Body: toBlueprintResponse(bp)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-blueprint",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/blueprints/{name}",
+		Summary:       "Delete a tenant blueprint",
+		Description:   "Tenants created from it keep their configuration.",
+		Tags:          []string{"Blueprints"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *BlueprintNameInput) (*struct{}, error) {
+		if err := bs.Delete(ctx, input.Name); err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,81 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+func newBlueprintTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	plans := app.NewPlanService(sqlite.NewPlanRepository(repo.DB()), repo)
+	bs := app.NewBlueprintService(sqlite.NewBlueprintRepository(repo.DB()), plans)
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{}, app.WithBlueprints(bs))
+	return serveService(t, svc, adapter.WithBlueprints(bs))
+}
+
+func TestBlueprints_CRUDAndCreateTenant(t *testing.T) {
+	srv := newBlueprintTestServer(t)
+	base := srv.URL + "/api/v1/blueprints"
+
+	resp := doRequest(t, http.MethodPost, base,
+		`{"name":"starter-eu","plan":"free","metadata":{"region":"eu"},"features":{"sso":true},"variables":{"replicas":"2"}}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("create blueprint: status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	for _, tc := range []struct {
+		method, url, body string
+		want              int
+	}{
+		{http.MethodPost, base, `{"name":"starter-eu","plan":"free"}`, http.StatusConflict},
+		{http.MethodPost, base, `{"name":"other","plan":"missing"}`, http.StatusUnprocessableEntity},
+		{http.MethodPut, base + "/missing", `{"plan":"free"}`, http.StatusNotFound},
+		{http.MethodPost, srv.URL + "/api/v1/tenants", `{"name":"Beta","blueprint":"missing"}`, http.StatusUnprocessableEntity},
+	} {
+		resp := doRequest(t, tc.method, tc.url, tc.body)
+		resp.Body.Close()
+		if resp.StatusCode != tc.want {
+			t.Errorf("%s %s: status = %d, want %d", tc.method, tc.url, resp.StatusCode, tc.want)
+		}
+	}
+
+	resp = doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants",
+		`{"name":"Acme","blueprint":"starter-eu","metadata":{"var.replicas":"4"}}`)
+	var tenant adapter.TenantResponse
+	_ = json.NewDecoder(resp.Body).Decode(&tenant)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || tenant.Plan != "free" || tenant.Metadata["region"] != "eu" ||
+		tenant.Metadata["feature.sso"] != "true" || tenant.Metadata["var.replicas"] != "4" || tenant.Metadata["blueprint"] != "starter-eu" {
+		t.Fatalf("create tenant: status = %d, tenant = %+v; want the blueprint's configuration", resp.StatusCode, tenant)
+	}
+
+	// Without a plan or blueprint, tenants are on free.
+	if plain := mustCreateTenant(t, srv, "Plain", "plain", ""); plain.Plan != "free" {
+		t.Errorf("plan = %q, want free", plain.Plan)
+	}
+
+	resp = doRequest(t, http.MethodDelete, base+"/starter-eu", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("delete: status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+	resp = doRequest(t, http.MethodGet, base+"/starter-eu", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("get after delete: status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
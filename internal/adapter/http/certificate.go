@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithCertificates exposes the TLS certificates of tenant domains under
+// /api/v1/tenants/{id}/certificates.
+func WithCertificates(cs *app.CertificateService) Option {
+	return func(o *options) { o.certificates = cs }
+}
+
+// CertificateResponse is the API representation of a domain's certificate.
+// The certificate and its key are not exposed.
+type CertificateResponse struct {
+	Domain    string `json:"domain" doc:"Host name the certificate is for"`
+	Status    string `json:"status" enum:"pending,issued,failed" doc:"pending until first issued; failed when the last attempt failed, which is retried"`
+	Issuer    string `json:"issuer,omitempty" doc:"Certificate authority of the certificate last issued"`
+	Serial    string `json:"serial,omitempty" doc:"Serial number of the certificate last issued (hexadecimal)"`
+	NotBefore string `json:"not_before,omitempty" doc:"Start of the validity of the certificate last issued (ISO 8601)"`
+	NotAfter  string `json:"not_after,omitempty" doc:"Expiry of the certificate last issued (ISO 8601)"`
+	RenewAt   string `json:"renew_at" doc:"When the certificate is next requested (ISO 8601)"`
+	LastError string `json:"last_error,omitempty" doc:"Why the last attempt failed, if it did"`
+	CreatedAt string `json:"created_at" doc:"Creation timestamp (ISO 8601)"`
+	UpdatedAt string `json:"updated_at" doc:"Last update timestamp (ISO 8601)"`
+}
+
+func toCertificateResponse(c domain.Certificate) CertificateResponse {
+	resp := CertificateResponse{
+		Domain:    c.Domain,
+		Status:    string(c.Status),
+		Issuer:    c.Issuer,
+		Serial:    c.Serial,
+		RenewAt:   c.RenewAt.Format("2006-01-02T15:04:05Z"),
+		LastError: c.LastError,
+		CreatedAt: c.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: c.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if !c.NotAfter.IsZero() {
+		resp.NotBefore = c.NotBefore.Format("2006-01-02T15:04:05Z")
+		resp.NotAfter = c.NotAfter.Format("2006-01-02T15:04:05Z")
+	}
+	return resp
+}
+
+type ListCertificatesInput struct {
+	ID string `path:"id" doc:"Tenant ID"`
+}
+
+type CertificateDomainInput struct {
+	ID     string `path:"id" doc:"Tenant ID"`
+	Domain string `path:"domain" doc:"Host name, such as app.customer.com"`
+}
+
+type CertificateOutput struct {
+	Body CertificateResponse
+}
+
+type CertificateListOutput struct {
+	Body struct {
+		Items []CertificateResponse `json:"items" doc:"Certificates, by domain"`
+	}
+}
+
+func registerCertificates(api huma.API, cs *app.CertificateService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "request-tenant-certificate",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/tenants/{id}/certificates/{domain}",
+		Summary:     "Request a TLS certificate for a tenant domain",
+		Description: "The certificate is obtained in the background over ACME, then renewed ahead of expiry; " +
+			"certificate_issued or certificate_failed is published each time. " +
+			"The domain must already point at tenantiq's ingress, which must route " +
+			"/.well-known/acme-challenge/ to tenantiq: the certificate authority checks it over HTTP. " +
+			"Requesting a domain the tenant already has returns its certificate.",
+		Tags:          []string{"Tenants"},
+		DefaultStatus: http.StatusAccepted,
+	}, func(ctx context.Context, input *CertificateDomainInput) (*CertificateOutput, error) {
+		cert, err := cs.Request(ctx, input.ID, input.Domain)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &CertificateOutput{Body: toCertificateResponse(cert)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-tenant-certificates",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/tenants/{id}/certificates",
+		Summary:     "List a tenant's TLS certificates",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *ListCertificatesInput) (*CertificateListOutput, error) {
+		certs, err := cs.List(ctx, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		out := &CertificateListOutput{}
+		out.Body.Items = make([]CertificateResponse, len(certs))
+		for i, c := range certs {
+			out.Body.Items[i] = toCertificateResponse(c)
+		}
+		return out, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "remove-tenant-certificate",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/tenants/{id}/certificates/{domain}",
+		Summary:       "Stop renewing a tenant domain's TLS certificate",
+		Description:   "The certificate already issued stays valid until it expires.",
+		Tags:          []string{"Tenants"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *CertificateDomainInput) (*struct{}, error) {
+		if err := cs.Remove(ctx, input.ID, input.Domain); err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,69 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+func newCertificateTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{})
+	cs := app.NewCertificateService(sqlite.NewCertificateRepository(repo.DB()), nil, svc)
+	return serveService(t, svc, adapter.WithCertificates(cs))
+}
+
+func TestCertificates_RequestListRemove(t *testing.T) {
+	srv := newCertificateTestServer(t)
+	acme := mustCreateTenant(t, srv, "Acme", "acme", "pro")
+	other := mustCreateTenant(t, srv, "Other", "other", "pro")
+	base := srv.URL + "/api/v1/tenants/" + acme.ID + "/certificates"
+
+	resp := doRequest(t, http.MethodPut, base+"/App.Acme.com", "")
+	var cert adapter.CertificateResponse
+	_ = json.NewDecoder(resp.Body).Decode(&cert)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted || cert.Domain != "app.acme.com" || cert.Status != "pending" || cert.NotAfter != "" {
+		t.Fatalf("request: status = %d, certificate = %+v; want 202 and a pending app.acme.com", resp.StatusCode, cert)
+	}
+
+	resp = doRequest(t, http.MethodGet, base, "")
+	var list struct {
+		Items []adapter.CertificateResponse `json:"items"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if len(list.Items) != 1 || list.Items[0].Domain != "app.acme.com" {
+		t.Errorf("list = %+v, want app.acme.com", list.Items)
+	}
+
+	for _, tc := range []struct {
+		method, url string
+		want        int
+	}{
+		{http.MethodPut, srv.URL + "/api/v1/tenants/" + other.ID + "/certificates/app.acme.com", http.StatusConflict},
+		{http.MethodPut, base + "/localhost", http.StatusUnprocessableEntity},
+		{http.MethodPut, srv.URL + "/api/v1/tenants/ten_missing/certificates/app.acme.com", http.StatusNotFound},
+		{http.MethodDelete, srv.URL + "/api/v1/tenants/" + other.ID + "/certificates/app.acme.com", http.StatusNotFound},
+		{http.MethodDelete, base + "/app.acme.com", http.StatusNoContent},
+		{http.MethodDelete, base + "/app.acme.com", http.StatusNotFound},
+	} {
+		resp := doRequest(t, tc.method, tc.url, "")
+		resp.Body.Close()
+		if resp.StatusCode != tc.want {
+			t.Errorf("%s %s: status = %d, want %d", tc.method, tc.url, resp.StatusCode, tc.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// TenantChangeResponse is one entry of the tenant change feed.
+type TenantChangeResponse struct {
+	Cursor   string          `json:"cursor" doc:"Position of the change in the feed"`
+	Type     string          `json:"type" enum:"created,updated,deleted" doc:"created, updated (transitions and soft deletion included), or deleted once the tenant is purged"`
+	TenantID string          `json:"tenant_id" doc:"Tenant ID"`
+	Version  int             `json:"version" doc:"Tenant version after the change; its last version when deleted. Apply a change only when it is newer than the copy"`
+	Tenant   *TenantResponse `json:"tenant,omitempty" doc:"Tenant after the change; absent when deleted"`
+	At       string          `json:"at" doc:"When the change was made (ISO 8601)"`
+}
+
+func toTenantChangeResponse(c domain.TenantChange) TenantChangeResponse {
+	resp := TenantChangeResponse{
+		Cursor:   strconv.FormatInt(c.Cursor, 10),
+		Type:     string(c.Type),
+		TenantID: c.TenantID,
+		Version:  c.Version,
+		At:       c.At.Format("2006-01-02T15:04:05Z"),
+	}
+	if c.Tenant != nil {
+		t := toTenantResponse(*c.Tenant)
+		resp.Tenant = &t
+	}
+	return resp
+}
+
+type ListChangesInput struct {
+	Since string `query:"since" required:"false" doc:"Cursor to read the changes after: next_cursor of the previous page, or now for the latest change. Omitted, the feed is read from its oldest stored change"`
+	Limit int    `query:"limit" required:"false" default:"100" minimum:"1" maximum:"1000" doc:"Max changes"`
+}
+
+type ListChangesOutput struct {
+	Body struct {
+		Changes    []TenantChangeResponse `json:"changes" doc:"Changes, oldest first"`
+		NextCursor string                 `json:"next_cursor" doc:"Cursor to pass as since for the following changes"`
+		HasMore    bool                   `json:"has_more" doc:"Whether more changes are available now"`
+	}
+}
+
+func registerChanges(api huma.API, svc *app.TenantService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-tenant-changes",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/tenants/changes",
+		Summary:     "List tenant changes since a cursor",
+		Description: "Lets downstream caches sync incrementally instead of re-listing every tenant. " +
+			"To bootstrap, take a cursor with since=now, list the tenants, then follow the changes from the cursor. " +
+			"Changes may overlap the listing, so apply each one only when its version is newer than the cached one. " +
+			"Returns 410 when the changes after the cursor were pruned by retention: list the tenants again.",
+		Tags: []string{"Tenants"},
+	}, func(ctx context.Context, input *ListChangesInput) (*ListChangesOutput, error) {
+		page, err := svc.Changes(ctx, input.Since, input.Limit)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		out := &ListChangesOutput{}
+		out.Body.Changes = make([]TenantChangeResponse, 0, len(page.Changes))
+		for _, c := range page.Changes {
+			out.Body.Changes = append(out.Body.Changes, toTenantChangeResponse(c))
+		}
+		out.Body.NextCursor = page.Cursor
+		out.Body.HasMore = page.HasMore
+		return out, nil
+	})
+}
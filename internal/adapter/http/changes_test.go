@@ -0,0 +1,93 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+type changesBody struct {
+	Changes    []adapter.TenantChangeResponse `json:"changes"`
+	NextCursor string                         `json:"next_cursor"`
+	HasMore    bool                           `json:"has_more"`
+}
+
+func TestListTenantChanges(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	auditLog := sqlite.NewAuditLog(repo.DB())
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{},
+		app.WithAuditLogger(auditLog), app.WithChangeFeed(auditLog))
+	srv := serveService(t, svc)
+
+	listChanges := func(query string) (int, changesBody) {
+		t.Helper()
+		resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/changes"+query, "")
+		defer resp.Body.Close()
+		var body changesBody
+		if resp.StatusCode == http.StatusOK {
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decoding changes: %v", err)
+			}
+		}
+		return resp.StatusCode, body
+	}
+
+	first := mustCreateTenant(t, srv, "Acme", "acme", "free")
+	_, now := listChanges("?since=now")
+	if len(now.Changes) != 0 || now.NextCursor != "1" {
+		t.Fatalf("since=now = %+v, want no changes and cursor 1", now)
+	}
+
+	second := mustCreateTenant(t, srv, "Globex", "globex", "free")
+	resp := doRequest(t, http.MethodPatch, srv.URL+"/api/v1/tenants/"+first.ID, `{"plan":"pro"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("update: status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	status, page := listChanges("?since=" + now.NextCursor + "&limit=1")
+	if status != http.StatusOK || len(page.Changes) != 1 || !page.HasMore {
+		t.Fatalf("first page = %d %+v, want one change and more", status, page)
+	}
+	if c := page.Changes[0]; c.Type != "created" || c.TenantID != second.ID || c.Tenant == nil || c.Tenant.Slug != "globex" {
+		t.Errorf("change = %+v, want globex created", c)
+	}
+
+	_, page = listChanges("?since=" + page.NextCursor)
+	if len(page.Changes) != 1 || page.HasMore || page.NextCursor != "3" {
+		t.Fatalf("second page = %+v, want one change, cursor 3", page)
+	}
+	if c := page.Changes[0]; c.Type != "updated" || c.TenantID != first.ID || c.Version != 2 || c.Tenant.Plan != "pro" {
+		t.Errorf("change = %+v, want %s updated to pro at version 2", c, first.ID)
+	}
+
+	for query, want := range map[string]int{
+		"?since=abc": http.StatusUnprocessableEntity,
+		"?since=4":   http.StatusUnprocessableEntity,
+		"?limit=0":   http.StatusUnprocessableEntity,
+	} {
+		if status, _ := listChanges(query); status != want {
+			t.Errorf("%s: status = %d, want %d", query, status, want)
+		}
+	}
+
+	if _, err := auditLog.Prune(context.Background(), time.Now().Add(time.Minute), false); err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if status, _ := listChanges("?since=1"); status != http.StatusGone {
+		t.Errorf("pruned cursor: status = %d, want %d", status, http.StatusGone)
+	}
+	if status, page := listChanges("?since=3"); status != http.StatusOK || len(page.Changes) != 0 {
+		t.Errorf("latest cursor after pruning = %d %+v, want no changes", status, page)
+	}
+}
@@ -0,0 +1,203 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CORSPolicy lists what browser code served from other origins, such as a
+// single-page frontend, may do with the API. Origins are exact
+// ("https://admin.example.com"), a wildcard subdomain
+// ("https://*.example.com") or "*" for any origin.
+type CORSPolicy struct {
+	AllowedOrigins []string `yaml:"allowed_origins"`
+	AllowedMethods []string `yaml:"allowed_methods"`
+	AllowedHeaders []string `yaml:"allowed_headers"`
+	// ExposedHeaders are the response headers scripts may read besides the
+	// CORS-safelisted ones.
+	ExposedHeaders []string `yaml:"exposed_headers"`
+	// AllowCredentials lets browsers send the session cookie along;
+	// refused with the "*" origin.
+	AllowCredentials bool `yaml:"allow_credentials"`
+	// MaxAge is how long browsers may cache the answer to a preflight.
+	MaxAge time.Duration `yaml:"max_age"`
+}
+
+// DefaultCORSPolicy returns the methods and headers of the API, for no
+// origin: CORS is disabled until origins are allowed.
+func DefaultCORSPolicy() CORSPolicy {
+	return CORSPolicy{
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+		AllowedHeaders: []string{
+			authorizationHeader, apiKeyHeader, "Content-Type", "If-None-Match", "Prefer",
+			actorHeader, approverHeader, priorityHeader, "X-Request-Id",
+		},
+		ExposedHeaders: []string{"ETag", "Location", "Retry-After", "Preference-Applied", "Content-Disposition", "X-Request-Id"},
+		MaxAge:         10 * time.Minute,
+	}
+}
+
+// CORSPolicyFromEnv reads the YAML file named by CORS_FILE, if any, over
+// the defaults of DefaultCORSPolicy, then the CORS_* environment variables
+// over both; lists are comma-separated.
+func CORSPolicyFromEnv() (CORSPolicy, error) {
+	p := DefaultCORSPolicy()
+	if path := os.Getenv("CORS_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return CORSPolicy{}, fmt.Errorf("CORS_FILE: %w", err)
+		}
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&p); err != nil {
+			return CORSPolicy{}, fmt.Errorf("CORS_FILE: %s: %w", path, err)
+		}
+	}
+	for key, list := range map[string]*[]string{
+		"CORS_ALLOWED_ORIGINS": &p.AllowedOrigins,
+		"CORS_ALLOWED_METHODS": &p.AllowedMethods,
+		"CORS_ALLOWED_HEADERS": &p.AllowedHeaders,
+		"CORS_EXPOSED_HEADERS": &p.ExposedHeaders,
+	} {
+		if v := os.Getenv(key); v != "" {
+			*list = splitList(v)
+		}
+	}
+	if v := os.Getenv("CORS_ALLOW_CREDENTIALS"); v != "" {
+		allow, err := strconv.ParseBool(v)
+		if err != nil {
+			return CORSPolicy{}, fmt.Errorf("CORS_ALLOW_CREDENTIALS: %w", err)
+		}
+		p.AllowCredentials = allow
+	}
+	if v := os.Getenv("CORS_MAX_AGE"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return CORSPolicy{}, fmt.Errorf("CORS_MAX_AGE: %w", err)
+		}
+		p.MaxAge = d
+	}
+	if err := p.Validate(); err != nil {
+		return CORSPolicy{}, err
+	}
+	return p, nil
+}
+
+// splitList splits a comma-separated list, dropping blank entries.
+func splitList(s string) []string {
+	var out []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// Enabled reports whether any origin is allowed.
+func (p CORSPolicy) Enabled() bool {
+	return len(p.AllowedOrigins) > 0
+}
+
+// Validate rejects origins that are not URLs without a path, and
+// credentials allowed to every origin, which browsers refuse.
+func (p CORSPolicy) Validate() error {
+	for _, o := range p.AllowedOrigins {
+		if o == "*" {
+			if p.AllowCredentials {
+				return errors.New(`CORS: credentials cannot be allowed to the "*" origin; list the origins`)
+			}
+			continue
+		}
+		scheme, host, ok := strings.Cut(o, "://")
+		if !ok || (scheme != "http" && scheme != "https") || host == "" || strings.ContainsAny(host, "/?#") {
+			return fmt.Errorf("CORS: invalid origin %q, want e.g. https://admin.example.com", o)
+		}
+		if rest, ok := strings.CutPrefix(host, "*."); ok && (rest == "" || strings.Contains(rest, "*")) {
+			return fmt.Errorf("CORS: invalid wildcard origin %q, want e.g. https://*.example.com", o)
+		}
+	}
+	if p.MaxAge < 0 {
+		return errors.New("CORS: max age must not be negative")
+	}
+	return nil
+}
+
+// allows reports whether origin is allowed.
+func (p CORSPolicy) allows(origin string) bool {
+	for _, o := range p.AllowedOrigins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+		// https://*.example.com matches https://a.example.com, not
+		// https://example.com.
+		if prefix, suffix, ok := strings.Cut(o, "*"); ok &&
+			len(origin) > len(prefix)+len(suffix) &&
+			strings.HasPrefix(strings.ToLower(origin), strings.ToLower(prefix)) &&
+			strings.HasSuffix(strings.ToLower(origin), strings.ToLower(suffix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// CORS applies p to every route. Preflight requests from allowed origins
+// are answered here, before authentication, with 204; other requests get
+// the headers that let the browser hand the response to the script.
+// Requests from other origins pass through unchanged, so the browser
+// blocks the response. Routes with a policy of their own, such as the
+// status widget open to every origin, set their headers over these.
+func CORS(p CORSPolicy) func(http.Handler) http.Handler {
+	methods := strings.Join(p.AllowedMethods, ", ")
+	headers := strings.Join(p.AllowedHeaders, ", ")
+	exposed := strings.Join(p.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(p.MaxAge.Seconds()))
+	anyOrigin := slices.Contains(p.AllowedOrigins, "*")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			h := w.Header()
+			h.Add("Vary", "Origin")
+			if !p.allows(origin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if anyOrigin {
+				h.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				h.Set("Access-Control-Allow-Origin", origin)
+			}
+			if p.AllowCredentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				h.Add("Vary", "Access-Control-Request-Method")
+				h.Add("Vary", "Access-Control-Request-Headers")
+				h.Set("Access-Control-Allow-Methods", methods)
+				h.Set("Access-Control-Allow-Headers", headers)
+				h.Set("Access-Control-Max-Age", maxAge)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			if exposed != "" {
+				h.Set("Access-Control-Expose-Headers", exposed)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
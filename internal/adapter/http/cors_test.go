@@ -0,0 +1,125 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+func TestCORSPolicyFromEnv(t *testing.T) {
+	p, err := adapter.CORSPolicyFromEnv()
+	if err != nil {
+		t.Fatalf("CORSPolicyFromEnv: %v", err)
+	}
+	if p.Enabled() || !slices.Contains(p.AllowedHeaders, "X-API-Key") {
+		t.Errorf("defaults = %+v, want disabled with the API's headers", p)
+	}
+
+	file := writeFile(t, "cors.yaml", "allowed_origins: [https://admin.example.com]\nallow_credentials: true\nmax_age: 1h\n")
+	t.Setenv("CORS_FILE", file)
+	t.Setenv("CORS_ALLOWED_METHODS", "GET, POST")
+	if p, err = adapter.CORSPolicyFromEnv(); err != nil {
+		t.Fatalf("CORSPolicyFromEnv: %v", err)
+	}
+	if !p.Enabled() || !p.AllowCredentials || p.MaxAge != time.Hour || !slices.Equal(p.AllowedMethods, []string{"GET", "POST"}) {
+		t.Errorf("policy = %+v, want the file with the methods of the environment", p)
+	}
+
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://*.example.com,http://localhost:5173")
+	if p, err = adapter.CORSPolicyFromEnv(); err != nil {
+		t.Fatalf("CORSPolicyFromEnv: %v", err)
+	}
+	if !slices.Equal(p.AllowedOrigins, []string{"https://*.example.com", "http://localhost:5173"}) {
+		t.Errorf("origins = %v, want those of the environment", p.AllowedOrigins)
+	}
+}
+
+func TestCORSPolicyFromEnv_Invalid(t *testing.T) {
+	tests := map[string]map[string]string{
+		"origin with a path":    {"CORS_ALLOWED_ORIGINS": "https://admin.example.com/app"},
+		"origin without scheme": {"CORS_ALLOWED_ORIGINS": "admin.example.com"},
+		"bare wildcard domain":  {"CORS_ALLOWED_ORIGINS": "https://*."},
+		"credentials for any":   {"CORS_ALLOWED_ORIGINS": "*", "CORS_ALLOW_CREDENTIALS": "true"},
+		"max age":               {"CORS_MAX_AGE": "forever"},
+		"unknown file field":    {"CORS_FILE": writeFile(t, "cors.yaml", "origins: [https://admin.example.com]\n")},
+	}
+	for name, env := range tests {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range env {
+				t.Setenv(k, v)
+			}
+			if _, err := adapter.CORSPolicyFromEnv(); err == nil {
+				t.Error("accepted, want an error")
+			}
+		})
+	}
+}
+
+func TestCORS(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{})
+	srv := serveService(t, svc)
+
+	policy := adapter.DefaultCORSPolicy()
+	policy.AllowedOrigins = []string{"https://admin.example.com", "https://*.preview.example.com"}
+	policy.AllowCredentials = true
+	cors := httptest.NewServer(adapter.CORS(policy)(srv.Config.Handler))
+	t.Cleanup(cors.Close)
+
+	request := func(method, origin string, headers map[string]string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), method, cors.URL+"/api/v1/tenants", nil)
+		if err != nil {
+			t.Fatalf("creating request: %v", err)
+		}
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s failed: %v", method, err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+	preflight := map[string]string{"Access-Control-Request-Method": "POST", "Access-Control-Request-Headers": "content-type"}
+
+	resp := request(http.MethodOptions, "https://admin.example.com", preflight)
+	if resp.StatusCode != http.StatusNoContent ||
+		resp.Header.Get("Access-Control-Allow-Origin") != "https://admin.example.com" ||
+		resp.Header.Get("Access-Control-Allow-Credentials") != "true" ||
+		resp.Header.Get("Access-Control-Allow-Methods") == "" || resp.Header.Get("Access-Control-Max-Age") != "600" {
+		t.Errorf("preflight = %d %v, want 204 allowing the origin", resp.StatusCode, resp.Header)
+	}
+
+	resp = request(http.MethodGet, "https://pr-42.preview.example.com", nil)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Access-Control-Allow-Origin") != "https://pr-42.preview.example.com" ||
+		resp.Header.Get("Access-Control-Expose-Headers") == "" || resp.Header.Get("Vary") != "Origin" {
+		t.Errorf("GET from a wildcard origin = %d %v, want it allowed", resp.StatusCode, resp.Header)
+	}
+
+	for _, origin := range []string{"https://evil.example.com", "https://preview.example.com"} {
+		resp = request(http.MethodOptions, origin, preflight)
+		if resp.Header.Get("Access-Control-Allow-Origin") != "" || resp.StatusCode == http.StatusNoContent {
+			t.Errorf("preflight from %s = %d %v, want no CORS headers", origin, resp.StatusCode, resp.Header)
+		}
+	}
+
+	if resp = request(http.MethodGet, "", nil); resp.StatusCode != http.StatusOK || resp.Header.Get("Vary") != "" {
+		t.Errorf("same-origin GET = %d %v, want it untouched", resp.StatusCode, resp.Header)
+	}
+}
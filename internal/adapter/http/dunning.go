@@ -0,0 +1,150 @@
+package http
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Billing webhook types that drive the dunning flow; others are ignored.
+const (
+	invoicePaymentFailed = "invoice.payment_failed"
+	invoicePaid          = "invoice.paid"
+)
+
+// WithDunning accepts the billing provider's payment webhooks under
+// /api/v1/billing/webhooks, verified with secret, and exposes each tenant's
+// dunning under /api/v1/tenants/{id}/dunning.
+func WithDunning(ds *app.DunningService, secret string) Option {
+	return func(o *options) {
+		o.dunning = ds
+		o.billingWebhookSecret = secret
+	}
+}
+
+// SignBillingWebhook returns the X-Billing-Signature value of body:
+// "sha256=" followed by the hex HMAC-SHA256 of the body keyed with secret.
+func SignBillingWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// DunningResponse is the API representation of a tenant's dunning.
+type DunningResponse struct {
+	TenantID        string `json:"tenant_id" doc:"Tenant in dunning"`
+	InvoiceID       string `json:"invoice_id" doc:"Unpaid invoice at the billing provider"`
+	Stage           string `json:"stage" enum:"warned,grace,suspended" doc:"Current step of the flow"`
+	SuspendedTenant bool   `json:"suspended_tenant" doc:"Whether the flow suspended the tenant (lifted on payment)"`
+	StartedAt       string `json:"started_at" doc:"When the payment failed (ISO 8601)"`
+	NextStepAt      string `json:"next_step_at,omitempty" doc:"When the flow moves on (ISO 8601); absent once suspended"`
+	UpdatedAt       string `json:"updated_at" doc:"Last update timestamp (ISO 8601)"`
+}
+
+func toDunningResponse(d domain.Dunning) DunningResponse {
+	resp := DunningResponse{
+		TenantID:        d.TenantID,
+		InvoiceID:       d.InvoiceID,
+		Stage:           string(d.Stage),
+		SuspendedTenant: d.SuspendedTenant,
+		StartedAt:       d.StartedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:       d.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if !d.NextStepAt.IsZero() {
+		resp.NextStepAt = d.NextStepAt.Format("2006-01-02T15:04:05Z")
+	}
+	return resp
+}
+
+type GetDunningInput struct {
+	ID string `path:"id" doc:"Tenant ID"`
+}
+
+type GetDunningOutput struct {
+	Body DunningResponse
+}
+
+// BillingWebhook is the part of a billing provider webhook tenantiq reads;
+// other fields are accepted and ignored.
+type BillingWebhook struct {
+	_ struct{} `json:"-" additionalProperties:"true"`
+
+	Type      string `json:"type" doc:"Webhook type; invoice.payment_failed and invoice.paid are handled"`
+	TenantID  string `json:"tenant_id,omitempty" doc:"Tenant the invoice belongs to; required by the handled types"`
+	InvoiceID string `json:"invoice_id,omitempty" doc:"Invoice at the billing provider"`
+}
+
+type BillingWebhookInput struct {
+	Signature string `header:"X-Billing-Signature" required:"true" doc:"sha256= followed by the hex HMAC-SHA256 of the body"`
+	Body      BillingWebhook
+	// RawBody is the body as signed, kept alongside the decoded Body.
+	RawBody []byte
+}
+
+// BillingWebhookResponse tells the billing provider what was done.
+type BillingWebhookResponse struct {
+	Status string `json:"status" enum:"processed,ignored" doc:"Whether the webhook type is handled"`
+}
+
+type BillingWebhookOutput struct {
+	Body BillingWebhookResponse
+}
+
+func registerDunning(api huma.API, ds *app.DunningService, secret string, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "receive-billing-webhook",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/billing/webhooks",
+		Summary:     "Receive a billing provider webhook",
+		Description: "A failed invoice payment starts the tenant's dunning: a warning, a final notice " +
+			"and finally a suspension. A paid invoice ends it and lifts the suspension. " +
+			"Requests must be signed in X-Billing-Signature; other webhook types are acknowledged and ignored.",
+		Tags: []string{"Billing"},
+	}, func(ctx context.Context, input *BillingWebhookInput) (*BillingWebhookOutput, error) {
+		if !hmac.Equal([]byte(input.Signature), []byte(SignBillingWebhook(secret, input.RawBody))) {
+			return nil, huma.Error401Unauthorized("invalid billing webhook signature")
+		}
+
+		hook := input.Body
+		if hook.Type != invoicePaymentFailed && hook.Type != invoicePaid {
+			return &BillingWebhookOutput{Body: BillingWebhookResponse{Status: "ignored"}}, nil
+		}
+		if hook.TenantID == "" {
+			return nil, huma.Error422UnprocessableEntity("tenant_id is required")
+		}
+
+		ctx = domain.WithActor(ctx, "billing")
+		var err error
+		if hook.Type == invoicePaymentFailed {
+			_, err = ds.PaymentFailed(ctx, hook.TenantID, hook.InvoiceID)
+		} else {
+			err = ds.PaymentSucceeded(ctx, hook.TenantID)
+		}
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &BillingWebhookOutput{Body: BillingWebhookResponse{Status: "processed"}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-tenant-dunning",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/tenants/{id}/dunning",
+		Summary:     "Get a tenant's dunning",
+		Description: "Returns where the tenant is in the collection of an unpaid invoice, or 404 when it has none.",
+		Tags:        []string{"Billing"},
+	}, func(ctx context.Context, input *GetDunningInput) (*GetDunningOutput, error) {
+		d, err := ds.Get(ctx, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &GetDunningOutput{Body: toDunningResponse(d)}, nil
+	})
+}
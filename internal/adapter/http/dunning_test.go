@@ -0,0 +1,123 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+const billingSecret = "whsec_billing_test"
+
+func newDunningTestServer(t *testing.T) (*httptest.Server, *app.TenantService) {
+	t.Helper()
+
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{})
+	ds := app.NewDunningService(sqlite.NewDunningRepository(repo.DB()), svc,
+		domain.DunningPolicy{WarningPeriod: time.Hour, GracePeriod: time.Hour})
+	return serveService(t, svc, adapter.WithDunning(ds, billingSecret)), svc
+}
+
+func postBillingWebhook(t *testing.T, srv *httptest.Server, body, signature string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+		srv.URL+"/api/v1/billing/webhooks", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Billing-Signature", signature)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST billing webhook: %v", err)
+	}
+	return resp
+}
+
+func TestBillingWebhook_PaymentFailedStartsDunning(t *testing.T) {
+	srv, svc := newDunningTestServer(t)
+	tenant, err := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	body := `{"type":"invoice.payment_failed","tenant_id":"` + tenant.ID + `","invoice_id":"in_1"}`
+	resp := postBillingWebhook(t, srv, body, adapter.SignBillingWebhook(billingSecret, []byte(body)))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	resp = doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/"+tenant.ID+"/dunning", "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("get dunning: status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var d adapter.DunningResponse
+	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if d.Stage != "warned" || d.InvoiceID != "in_1" || d.NextStepAt == "" {
+		t.Errorf("dunning = %+v, want warned for in_1", d)
+	}
+
+	// Paying ends it.
+	body = `{"type":"invoice.paid","tenant_id":"` + tenant.ID + `"}`
+	resp = postBillingWebhook(t, srv, body, adapter.SignBillingWebhook(billingSecret, []byte(body)))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("paid: status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	resp = doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/"+tenant.ID+"/dunning", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("get dunning after payment: status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestBillingWebhook_RejectsBadSignature(t *testing.T) {
+	srv, _ := newDunningTestServer(t)
+
+	body := `{"type":"invoice.payment_failed","tenant_id":"t1","invoice_id":"in_1"}`
+	resp := postBillingWebhook(t, srv, body, adapter.SignBillingWebhook("another-secret", []byte(body)))
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestBillingWebhook_IgnoresOtherTypes(t *testing.T) {
+	srv, _ := newDunningTestServer(t)
+
+	body := `{"type":"customer.updated","customer":{"id":"cus_1"}}`
+	resp := postBillingWebhook(t, srv, body, adapter.SignBillingWebhook(billingSecret, []byte(body)))
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var out adapter.BillingWebhookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if out.Status != "ignored" {
+		t.Errorf("status = %q, want ignored", out.Status)
+	}
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/egress"
+)
+
+// EgressResponse is how tenantiq reaches other systems.
+type EgressResponse struct {
+	ProxyURL  string   `json:"proxy_url,omitempty" doc:"Proxy of outbound requests, without credentials; absent when they go out directly"`
+	NoProxy   []string `json:"no_proxy" doc:"Hosts, domains and networks reached without the proxy"`
+	SourceIPs []string `json:"source_ips" doc:"Addresses outbound requests come from, to allowlist; as declared in EGRESS_SOURCE_IPS"`
+	Adapters  []string `json:"adapters" doc:"Integrations whose requests take this route"`
+}
+
+type EgressOutput struct {
+	Body EgressResponse
+}
+
+// RegisterEgress adds the endpoint reporting the effective egress
+// configuration, for the operators of the systems tenantiq calls, such as
+// webhook receivers, to allowlist it.
+func RegisterEgress(api huma.API, router *egress.Router) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-egress",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/system/egress",
+		Summary:     "Outbound network configuration",
+		Description: "The proxy and source addresses of the requests tenantiq makes: webhook deliveries, billing, " +
+			"certificate and identity provider calls. Give source_ips to the systems that allowlist callers.",
+		Tags: []string{"System"},
+	}, func(ctx context.Context, _ *struct{}) (*EgressOutput, error) {
+		e := router.Describe()
+		return &EgressOutput{Body: EgressResponse{
+			ProxyURL:  e.ProxyURL,
+			NoProxy:   e.NoProxy,
+			SourceIPs: e.SourceIPs,
+			Adapters:  e.Adapters,
+		}}, nil
+	})
+}
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithEncryption exposes the encryption keys tenants bring under
+// /api/v1/tenants/{id}/encryption-key and their secrets, sealed with
+// them, under /api/v1/tenants/{id}/secrets.
+func WithEncryption(es *app.EncryptionService) Option {
+	return func(o *options) { o.encryption = es }
+}
+
+// EncryptionKeyResponse is the API representation of a tenant's own
+// encryption key.
+type EncryptionKeyResponse struct {
+	TenantID  string `json:"tenant_id" doc:"Tenant ID"`
+	KeyRef    string `json:"key_ref" doc:"The key at the KMS, such as an AWS KMS key ARN"`
+	Status    string `json:"status" enum:"active,revoked" doc:"revoked while access to the tenant's data is suspended"`
+	CreatedAt string `json:"created_at" doc:"When the tenant first set its key (ISO 8601)"`
+	RotatedAt string `json:"rotated_at,omitempty" doc:"When the key was last changed or set again (ISO 8601)"`
+	RevokedAt string `json:"revoked_at,omitempty" doc:"When the key was found or reported revoked (ISO 8601)"`
+}
+
+func toEncryptionKeyResponse(k domain.EncryptionKey) EncryptionKeyResponse {
+	resp := EncryptionKeyResponse{
+		TenantID:  k.TenantID,
+		KeyRef:    k.KeyRef,
+		Status:    "active",
+		CreatedAt: k.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if !k.RotatedAt.IsZero() {
+		resp.RotatedAt = k.RotatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if k.Revoked() {
+		resp.Status = "revoked"
+		resp.RevokedAt = k.RevokedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return resp
+}
+
+// SecretResponse is the API representation of a tenant secret. The value
+// is only returned when the secret is read on its own.
+type SecretResponse struct {
+	Name      string `json:"name" doc:"Secret name"`
+	Value     string `json:"value,omitempty" doc:"The secret"`
+	KeyRef    string `json:"key_ref" doc:"The key the secret is sealed with"`
+	UpdatedBy string `json:"updated_by" doc:"Who last set the secret"`
+	CreatedAt string `json:"created_at" doc:"Creation timestamp (ISO 8601)"`
+	UpdatedAt string `json:"updated_at" doc:"When the secret was last set (ISO 8601)"`
+}
+
+func toSecretResponse(s domain.TenantSecret) SecretResponse {
+	return SecretResponse{
+		Name:      s.Name,
+		KeyRef:    s.Value.KeyRef,
+		UpdatedBy: s.UpdatedBy,
+		CreatedAt: s.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: s.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+type EncryptionKeyInput struct {
+	ID string `path:"id" doc:"Tenant ID"`
+}
+
+type SetEncryptionKeyInput struct {
+	ID   string `path:"id" doc:"Tenant ID"`
+	Body struct {
+		KeyRef string `json:"key_ref" minLength:"1" maxLength:"2048" doc:"The key at the KMS, such as an AWS KMS key ARN"`
+	}
+}
+
+type SecretInput struct {
+	ID   string `path:"id" doc:"Tenant ID"`
+	Name string `path:"name" doc:"Secret name"`
+}
+
+type PutSecretInput struct {
+	ID   string `path:"id" doc:"Tenant ID"`
+	Name string `path:"name" doc:"Secret name"`
+	Body struct {
+		Value string `json:"value" minLength:"1" maxLength:"65536" doc:"The secret"`
+	}
+}
+
+type EncryptionKeyOutput struct {
+	Body EncryptionKeyResponse
+}
+
+type SecretOutput struct {
+	Body SecretResponse
+}
+
+type SecretListOutput struct {
+	Body struct {
+		Items []SecretResponse `json:"items" doc:"Secrets by name, without their values"`
+	}
+}
+
+func registerEncryption(api huma.API, es *app.EncryptionService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-tenant-encryption-key",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/tenants/{id}/encryption-key",
+		Summary:     "Get the encryption key a tenant brought",
+		Description: "404 when the tenant's data is encrypted with tenantiq's key.",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *EncryptionKeyInput) (*EncryptionKeyOutput, error) {
+		k, err := es.Key(ctx, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &EncryptionKeyOutput{Body: toEncryptionKeyResponse(k)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-tenant-encryption-key",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/tenants/{id}/encryption-key",
+		Summary:     "Set or rotate the encryption key of a tenant",
+		Description: "Encrypts the tenant's data with its own KMS key (bring your own key), which requires the byok feature in its plan. " +
+			"The secrets' data keys are re-encrypted with the new key, so the previous one must still work. " +
+			"Setting a key again after it was revoked resumes access to the tenant's data. 422 when tenantiq cannot use the key.",
+		Tags: []string{"Tenants"},
+	}, func(ctx context.Context, input *SetEncryptionKeyInput) (*EncryptionKeyOutput, error) {
+		k, err := es.SetKey(ctx, input.ID, input.Body.KeyRef)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &EncryptionKeyOutput{Body: toEncryptionKeyResponse(k)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-tenant-encryption-key",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/tenants/{id}/encryption-key/revoke",
+		Summary:     "Record a tenant's encryption key as revoked",
+		Description: "For tenants that revoked their key at the KMS: access to their data is suspended, with 403, until a usable key is set. " +
+			"A key found revoked while in use is recorded the same way.",
+		Tags: []string{"Tenants"},
+	}, func(ctx context.Context, input *EncryptionKeyInput) (*EncryptionKeyOutput, error) {
+		k, err := es.RevokeKey(ctx, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &EncryptionKeyOutput{Body: toEncryptionKeyResponse(k)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-tenant-secrets",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/tenants/{id}/secrets",
+		Summary:     "List a tenant's secrets",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *EncryptionKeyInput) (*SecretListOutput, error) {
+		secrets, err := es.Secrets(ctx, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		out := &SecretListOutput{}
+		out.Body.Items = make([]SecretResponse, len(secrets))
+		for i, s := range secrets {
+			out.Body.Items[i] = toSecretResponse(s)
+		}
+		return out, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "put-tenant-secret",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/tenants/{id}/secrets/{name}",
+		Summary:     "Set a tenant's secret",
+		Description: "The value is encrypted with the tenant's key and is not returned.",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *PutSecretInput) (*SecretOutput, error) {
+		s, err := es.PutSecret(ctx, input.ID, input.Name, []byte(input.Body.Value))
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &SecretOutput{Body: toSecretResponse(s)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-tenant-secret",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/tenants/{id}/secrets/{name}",
+		Summary:     "Get a tenant's secret with its value",
+		Description: "403 while the tenant's encryption key is revoked.",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *SecretInput) (*SecretOutput, error) {
+		s, value, err := es.Secret(ctx, input.ID, input.Name)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		resp := toSecretResponse(s)
+		resp.Value = string(value)
+		return &SecretOutput{Body: resp}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-tenant-secret",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/tenants/{id}/secrets/{name}",
+		Summary:       "Delete a tenant's secret",
+		Tags:          []string{"Tenants"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *SecretInput) (*struct{}, error) {
+		if err := es.DeleteSecret(ctx, input.ID, input.Name); err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,87 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/kms"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+func TestEncryption_SecretsFollowTheTenantKey(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	db := repo.DB()
+	keyring, err := kms.Parse("default=" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, kms.KeyLength)) +
+		",acme=" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, kms.KeyLength)))
+	if err != nil {
+		t.Fatalf("keyring: %v", err)
+	}
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{})
+	es := app.NewEncryptionService(sqlite.NewEncryptionKeyRepository(db), sqlite.NewSecretRepository(db), keyring, "local:default", svc)
+	srv := serveService(t, svc, adapter.WithEncryption(es))
+
+	acme := mustCreateTenant(t, srv, "Acme", "acme", "enterprise")
+	base := srv.URL + "/api/v1/tenants/" + acme.ID
+
+	resp := doRequest(t, http.MethodGet, base+"/encryption-key", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("key before BYOK: status = %d, want 404", resp.StatusCode)
+	}
+	resp = doRequest(t, http.MethodPut, base+"/secrets/DATABASE_URL", `{"value":"postgres://acme"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("put secret: status = %d", resp.StatusCode)
+	}
+
+	resp = doRequest(t, http.MethodPut, base+"/encryption-key", `{"key_ref":"local:acme"}`)
+	var key adapter.EncryptionKeyResponse
+	_ = json.NewDecoder(resp.Body).Decode(&key)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || key.KeyRef != "local:acme" || key.Status != "active" {
+		t.Fatalf("set key: %d %+v", resp.StatusCode, key)
+	}
+	resp = doRequest(t, http.MethodPut, base+"/encryption-key", `{"key_ref":"local:missing"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("set unusable key: status = %d, want 422", resp.StatusCode)
+	}
+
+	resp = doRequest(t, http.MethodGet, base+"/secrets/DATABASE_URL", "")
+	var secret adapter.SecretResponse
+	_ = json.NewDecoder(resp.Body).Decode(&secret)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || secret.Value != "postgres://acme" || secret.KeyRef != "local:acme" {
+		t.Fatalf("get secret: %d %+v", resp.StatusCode, secret)
+	}
+
+	resp = doRequest(t, http.MethodPost, base+"/encryption-key/revoke", "")
+	_ = json.NewDecoder(resp.Body).Decode(&key)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || key.Status != "revoked" || key.RevokedAt == "" {
+		t.Fatalf("revoke: %d %+v", resp.StatusCode, key)
+	}
+	resp = doRequest(t, http.MethodGet, base+"/secrets/DATABASE_URL", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("get secret with a revoked key: status = %d, want 403", resp.StatusCode)
+	}
+	resp = doRequest(t, http.MethodGet, base+"/secrets", "")
+	var list struct {
+		Items []adapter.SecretResponse `json:"items"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || len(list.Items) != 1 || list.Items[0].Value != "" {
+		t.Errorf("list secrets: %d %+v, want the secret without its value", resp.StatusCode, list.Items)
+	}
+}
@@ -0,0 +1,393 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/signedurl"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Option configures the tenant API.
+type Option func(*options)
+
+type options struct {
+	debugErrors  bool
+	operations   *app.OperationService
+	resellers    *app.ResellerService
+	billing      *app.BillingService
+	webhooks     *app.WebhookService
+	plans        *app.PlanService
+	dunning      *app.DunningService
+	certificates *app.CertificateService
+	blueprints   *app.BlueprintService
+	members      *app.MemberService
+	apiKeys      *app.APIKeyService
+	// requestLimiter holds requests made with tenant API keys to the
+	// tenants' rate limits.
+	requestLimiter *app.RequestLimiter
+	encryption     *app.EncryptionService
+	// trustedProxies are the networks whose X-Forwarded-For is believed.
+	trustedProxies []netip.Prefix
+	// latencyObserver receives the latency of every request against its
+	// operation's budget.
+	latencyObserver LatencyObserver
+	// maxBodyBytes bounds the request bodies of operations; Huma's default
+	// when 0.
+	maxBodyBytes int64
+	adminKeys    *app.AdminKeyService
+	tokens       domain.TokenVerifier
+	// actorClaim names the token claim that becomes the actor.
+	actorClaim string
+	// sessions verifies the session cookie named sessionCookie.
+	sessions      domain.TokenVerifier
+	sessionCookie string
+	// roleClaim and defaultRole grant the roles of tokens and sessions.
+	roleClaim   string
+	defaultRole domain.Role
+	signer      *signedurl.Signer
+	// billingWebhookSecret verifies payment webhooks from the billing provider.
+	billingWebhookSecret string
+	// signedURLMaxTTL caps the validity of the links signed by signer.
+	signedURLMaxTTL time.Duration
+	// importKey authorizes tenant imports.
+	importKey string
+	readOnly  *app.ReadOnlySwitch
+}
+
+// WithDebugErrors includes the wrapped error chain and the trace ID in 500
+// responses. It leaks internals to clients, so enable it only in development.
+func WithDebugErrors(enabled bool) Option {
+	return func(o *options) { o.debugErrors = enabled }
+}
+
+// errorMapper translates domain errors to Huma HTTP errors.
+type errorMapper struct {
+	debug bool
+}
+
+func (m errorMapper) toHuma(ctx context.Context, err error) error {
+	if errors.Is(err, domain.ErrTenantNotFound) {
+		return huma.Error404NotFound("tenant not found")
+	}
+	if errors.Is(err, domain.ErrOperationNotFound) {
+		return huma.Error404NotFound("operation not found")
+	}
+	if errors.Is(err, domain.ErrResellerNotFound) {
+		return huma.Error404NotFound("reseller not found")
+	}
+	if errors.Is(err, domain.ErrWebhookNotFound) {
+		return huma.Error404NotFound("webhook subscription not found")
+	}
+	if errors.Is(err, domain.ErrPlanNotFound) {
+		return huma.Error404NotFound(domain.ErrPlanNotFound.Error())
+	}
+	if errors.Is(err, domain.ErrDunningNotFound) {
+		return huma.Error404NotFound(domain.ErrDunningNotFound.Error())
+	}
+	if errors.Is(err, domain.ErrBlueprintNotFound) {
+		return huma.Error404NotFound(domain.ErrBlueprintNotFound.Error())
+	}
+	if errors.Is(err, domain.ErrCertificateNotFound) {
+		return huma.Error404NotFound(domain.ErrCertificateNotFound.Error())
+	}
+	if errors.Is(err, domain.ErrMemberNotFound) {
+		return huma.Error404NotFound(domain.ErrMemberNotFound.Error())
+	}
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return huma.Error404NotFound(domain.ErrAPIKeyNotFound.Error())
+	}
+	if errors.Is(err, domain.ErrEncryptionKeyNotFound) {
+		return huma.Error404NotFound(domain.ErrEncryptionKeyNotFound.Error())
+	}
+	if errors.Is(err, domain.ErrSecretNotFound) {
+		return huma.Error404NotFound(domain.ErrSecretNotFound.Error())
+	}
+	if errors.Is(err, domain.ErrAdminKeyNotFound) {
+		return huma.Error404NotFound(domain.ErrAdminKeyNotFound.Error())
+	}
+	if errors.Is(err, domain.ErrAPIKeyInvalid) {
+		return huma.Error401Unauthorized(domain.ErrAPIKeyInvalid.Error())
+	}
+	if errors.Is(err, domain.ErrConcurrentModification) {
+		return huma.Error409Conflict(domain.ErrConcurrentModification.Error() + "; retry the request")
+	}
+	if errors.Is(err, domain.ErrSimulationDisabled) {
+		return huma.Error422UnprocessableEntity(domain.ErrSimulationDisabled.Error())
+	}
+	if errors.Is(err, domain.ErrCursorExpired) {
+		return huma.Error410Gone(domain.ErrCursorExpired.Error())
+	}
+	if errors.Is(err, domain.ErrBillingUnavailable) {
+		return huma.Error502BadGateway(domain.ErrBillingUnavailable.Error())
+	}
+
+	var idErr *domain.InvalidIDError
+	if errors.As(err, &idErr) {
+		return huma.Error422UnprocessableEntity(idErr.Error())
+	}
+
+	var slugErr *domain.SlugConflictError
+	if errors.As(err, &slugErr) {
+		return huma.Error409Conflict(slugErr.Error())
+	}
+
+	var idConflictErr *domain.IDConflictError
+	if errors.As(err, &idConflictErr) {
+		return huma.Error409Conflict(idConflictErr.Error())
+	}
+
+	var invalidSlugErr *domain.InvalidSlugError
+	if errors.As(err, &invalidSlugErr) {
+		return huma.Error422UnprocessableEntity(invalidSlugErr.Error())
+	}
+
+	var metadataErr *domain.InvalidMetadataError
+	if errors.As(err, &metadataErr) {
+		return huma.Error422UnprocessableEntity(metadataErr.Error())
+	}
+
+	var tagErr *domain.InvalidTagError
+	if errors.As(err, &tagErr) {
+		return huma.Error422UnprocessableEntity(tagErr.Error())
+	}
+
+	var trErr *domain.TransitionError
+	if errors.As(err, &trErr) {
+		return huma.Error422UnprocessableEntity(trErr.Error())
+	}
+
+	var transitionGuardErr *domain.GuardError
+	if errors.As(err, &transitionGuardErr) {
+		return huma.Error409Conflict(transitionGuardErr.Error())
+	}
+
+	var unreachableErr *domain.UnreachableStatusError
+	if errors.As(err, &unreachableErr) {
+		return huma.Error422UnprocessableEntity(unreachableErr.Error())
+	}
+
+	var forbiddenErr *domain.ForbiddenError
+	if errors.As(err, &forbiddenErr) {
+		return huma.Error403Forbidden(forbiddenErr.Error())
+	}
+
+	var revokedErr *domain.EncryptionKeyRevokedError
+	if errors.As(err, &revokedErr) {
+		return huma.Error403Forbidden(revokedErr.Error())
+	}
+
+	var ipErr *domain.IPNotAllowedError
+	if errors.As(err, &ipErr) {
+		return huma.Error403Forbidden(ipErr.Error())
+	}
+
+	var featureErr *domain.FeatureNotInPlanError
+	if errors.As(err, &featureErr) {
+		return huma.Error403Forbidden(featureErr.Error())
+	}
+
+	var scopeErr *domain.MissingScopeError
+	if errors.As(err, &scopeErr) {
+		return huma.Error403Forbidden(scopeErr.Error())
+	}
+
+	var policyErr *domain.PolicyError
+	if errors.As(err, &policyErr) {
+		return huma.Error403Forbidden(policyErr.Error())
+	}
+
+	var webhookErr *domain.InvalidWebhookError
+	if errors.As(err, &webhookErr) {
+		return huma.Error422UnprocessableEntity(webhookErr.Error())
+	}
+
+	var invalidPlanErr *domain.InvalidPlanError
+	if errors.As(err, &invalidPlanErr) {
+		return huma.Error422UnprocessableEntity(invalidPlanErr.Error())
+	}
+
+	var unknownPlanErr *domain.UnknownPlanError
+	if errors.As(err, &unknownPlanErr) {
+		return huma.Error422UnprocessableEntity(unknownPlanErr.Error())
+	}
+
+	var notInRegionErr *domain.PlanNotInRegionError
+	if errors.As(err, &notInRegionErr) {
+		return huma.Error422UnprocessableEntity(notInRegionErr.Error())
+	}
+
+	var regionErr *domain.InvalidRegionError
+	if errors.As(err, &regionErr) {
+		return huma.Error422UnprocessableEntity(regionErr.Error())
+	}
+
+	var planConflictErr *domain.PlanConflictError
+	if errors.As(err, &planConflictErr) {
+		return huma.Error409Conflict(planConflictErr.Error())
+	}
+
+	var planInUseErr *domain.PlanInUseError
+	if errors.As(err, &planInUseErr) {
+		return huma.Error409Conflict(planInUseErr.Error())
+	}
+
+	var cursorErr *domain.InvalidCursorError
+	if errors.As(err, &cursorErr) {
+		return huma.Error422UnprocessableEntity(cursorErr.Error())
+	}
+
+	var invalidBlueprintErr *domain.InvalidBlueprintError
+	if errors.As(err, &invalidBlueprintErr) {
+		return huma.Error422UnprocessableEntity(invalidBlueprintErr.Error())
+	}
+
+	var unknownBlueprintErr *domain.UnknownBlueprintError
+	if errors.As(err, &unknownBlueprintErr) {
+		return huma.Error422UnprocessableEntity(unknownBlueprintErr.Error())
+	}
+
+	var blueprintConflictErr *domain.BlueprintConflictError
+	if errors.As(err, &blueprintConflictErr) {
+		return huma.Error409Conflict(blueprintConflictErr.Error())
+	}
+
+	var invalidMemberErr *domain.InvalidMemberError
+	if errors.As(err, &invalidMemberErr) {
+		return huma.Error422UnprocessableEntity(invalidMemberErr.Error())
+	}
+
+	var memberConflictErr *domain.MemberConflictError
+	if errors.As(err, &memberConflictErr) {
+		return huma.Error409Conflict(memberConflictErr.Error())
+	}
+
+	var apiKeyErr *domain.InvalidAPIKeyError
+	if errors.As(err, &apiKeyErr) {
+		return huma.Error422UnprocessableEntity(apiKeyErr.Error())
+	}
+
+	var keyErr *domain.InvalidEncryptionKeyError
+	if errors.As(err, &keyErr) {
+		return huma.Error422UnprocessableEntity(keyErr.Error())
+	}
+
+	var allowlistErr *domain.InvalidIPAllowlistError
+	if errors.As(err, &allowlistErr) {
+		return huma.Error422UnprocessableEntity(allowlistErr.Error())
+	}
+
+	var secretErr *domain.InvalidSecretError
+	if errors.As(err, &secretErr) {
+		return huma.Error422UnprocessableEntity(secretErr.Error())
+	}
+
+	var windowErr *domain.InvalidMaintenanceWindowError
+	if errors.As(err, &windowErr) {
+		return huma.Error422UnprocessableEntity(windowErr.Error())
+	}
+
+	var rateLimitErr *domain.InvalidRateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return huma.Error422UnprocessableEntity(rateLimitErr.Error())
+	}
+
+	var invalidDomainErr *domain.InvalidDomainError
+	if errors.As(err, &invalidDomainErr) {
+		return huma.Error422UnprocessableEntity(invalidDomainErr.Error())
+	}
+
+	var domainConflictErr *domain.DomainConflictError
+	if errors.As(err, &domainConflictErr) {
+		return huma.Error409Conflict(domainConflictErr.Error())
+	}
+
+	var deferredErr *domain.MaintenanceDeferredError
+	if errors.As(err, &deferredErr) {
+		return huma.Error409Conflict(deferredErr.Error())
+	}
+
+	var quotaErr *domain.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		switch {
+		case quotaErr.Metric == "":
+			return huma.Error409Conflict(quotaErr.Error())
+		case quotaErr.Periodic():
+			return huma.Error429TooManyRequests(quotaErr.Error())
+		}
+		return huma.Error403Forbidden(quotaErr.Error())
+	}
+
+	var limitedErr *domain.RateLimitedError
+	if errors.As(err, &limitedErr) {
+		retryAfter := strconv.Itoa(int(limitedErr.RetryAfter.Seconds()))
+		return huma.ErrorWithHeaders(huma.Error429TooManyRequests(limitedErr.Error()), http.Header{"Retry-After": {retryAfter}})
+	}
+
+	var guardErr *domain.GuardrailError
+	if errors.As(err, &guardErr) {
+		return huma.Error409Conflict(guardErr.Error())
+	}
+
+	var hookErr *domain.HookRejectedError
+	if errors.As(err, &hookErr) {
+		return huma.Error422UnprocessableEntity(hookErr.Error())
+	}
+
+	var sizeErr *domain.BatchTooLargeError
+	if errors.As(err, &sizeErr) {
+		return huma.Error422UnprocessableEntity(sizeErr.Error())
+	}
+
+	if !m.debug {
+		return huma.Error500InternalServerError("internal server error")
+	}
+	return huma.Error500InternalServerError("internal server error", debugDetails(ctx, err)...)
+}
+
+// debugDetails renders the error chain, outermost first, followed by the
+// trace ID of the request when one is recorded.
+func debugDetails(ctx context.Context, err error) []error {
+	var details []error
+	for i, cause := range errorChain(err) {
+		details = append(details, &huma.ErrorDetail{
+			Message:  cause.Error(),
+			Location: fmt.Sprintf("cause[%d]", i),
+		})
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		details = append(details, &huma.ErrorDetail{
+			Message:  "trace ID",
+			Location: "trace_id",
+			Value:    sc.TraceID().String(),
+		})
+	}
+	return details
+}
+
+// errorChain flattens err and everything it wraps, depth first. Joined
+// errors contribute each of their branches.
+func errorChain(err error) []error {
+	if err == nil {
+		return nil
+	}
+	chain := []error{err}
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		chain = append(chain, errorChain(u.Unwrap())...)
+	case interface{ Unwrap() []error }:
+		for _, e := range u.Unwrap() {
+			chain = append(chain, errorChain(e)...)
+		}
+	}
+	return chain
+}
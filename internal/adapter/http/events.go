@@ -0,0 +1,95 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// EventTransition is one state change an event can cause.
+type EventTransition struct {
+	From string `json:"from" doc:"Status before the event"`
+	To   string `json:"to" doc:"Status after the event"`
+}
+
+// EventType describes one event consumers may receive.
+type EventType struct {
+	Name        string            `json:"name" doc:"Lifecycle event name"`
+	Type        string            `json:"type" doc:"Value of the payload's type field for this event"`
+	Transitions []EventTransition `json:"transitions" doc:"State changes the event causes"`
+	Schema      map[string]any    `json:"schema" doc:"JSON Schema of the event payload"`
+}
+
+// EventSchemaResponse is the catalog of published events.
+type EventSchemaResponse struct {
+	Events []EventType `json:"events" doc:"All event types"`
+}
+
+type EventSchemaOutput struct {
+	Body EventSchemaResponse
+}
+
+// RegisterEventSchema adds the event catalog endpoint. The payload schema is
+// generated from the Go type of payload (the envelope published for every
+// event) and its type field narrowed per event to eventType(event), so
+// webhook consumers can generate typed handlers the same way they do from
+// the OpenAPI document.
+func RegisterEventSchema(api huma.API, payload any, eventType func(domain.Event) string) error {
+	catalog, err := eventCatalog(reflect.TypeOf(payload), eventType)
+	if err != nil {
+		return err
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-event-schema",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/events/schema",
+		Summary:     "Event type catalog",
+		Description: "Lists every lifecycle event with the JSON Schema of its payload.",
+		Tags:        []string{"Events"},
+	}, func(_ context.Context, _ *struct{}) (*EventSchemaOutput, error) {
+		return &EventSchemaOutput{Body: catalog}, nil
+	})
+	return nil
+}
+
+// eventCatalog builds the catalog once at registration; it never changes
+// while the process runs.
+func eventCatalog(payload reflect.Type, eventType func(domain.Event) string) (EventSchemaResponse, error) {
+	registry := huma.NewMapRegistry("#/components/schemas/", huma.DefaultSchemaNamer)
+	raw, err := json.Marshal(registry.Schema(payload, false, ""))
+	if err != nil {
+		return EventSchemaResponse{}, fmt.Errorf("encoding event payload schema: %w", err)
+	}
+
+	var catalog EventSchemaResponse
+	for _, event := range domain.Events() {
+		// Decode per event so each entry owns its copy of the schema.
+		var schema map[string]any
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			return EventSchemaResponse{}, fmt.Errorf("decoding event payload schema: %w", err)
+		}
+		typ := eventType(event)
+		if props, ok := schema["properties"].(map[string]any); ok {
+			if field, ok := props["type"].(map[string]any); ok {
+				field["enum"] = []string{typ}
+			}
+		}
+		schema["title"] = typ
+
+		entry := EventType{Name: string(event), Type: typ, Schema: schema}
+		for _, t := range domain.Transitions {
+			if t.Event == event {
+				entry.Transitions = append(entry.Transitions, EventTransition{From: string(t.Src), To: string(t.Dst)})
+			}
+		}
+		catalog.Events = append(catalog.Events, entry)
+	}
+	return catalog, nil
+}
@@ -0,0 +1,96 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+	"github.com/go-chi/chi/v5"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+type testPayload struct {
+	Type     string `json:"type" doc:"Event type"`
+	TenantID string `json:"tenant_id"`
+}
+
+func TestEventSchema(t *testing.T) {
+	router := chi.NewMux()
+	api := humachi.New(router, huma.DefaultConfig("tenantiq", "0.1.0"))
+	eventType := func(e domain.Event) string { return "test." + string(e) }
+	if err := adapter.RegisterEventSchema(api, testPayload{}, eventType); err != nil {
+		t.Fatalf("RegisterEventSchema: %v", err)
+	}
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/events/schema", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var body adapter.EventSchemaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Events) != 5 {
+		t.Fatalf("got %d events, want 5", len(body.Events))
+	}
+
+	var del *adapter.EventType
+	for i := range body.Events {
+		if body.Events[i].Name == "delete" {
+			del = &body.Events[i]
+		}
+	}
+	if del == nil {
+		t.Fatal("delete event missing from catalog")
+	}
+	if len(del.Transitions) != 2 {
+		t.Errorf("delete transitions = %+v, want 2", del.Transitions)
+	}
+
+	if del.Type != "test.delete" {
+		t.Errorf("delete type = %q, want test.delete", del.Type)
+	}
+
+	props, _ := del.Schema["properties"].(map[string]any)
+	typ, _ := props["type"].(map[string]any)
+	if enum, _ := typ["enum"].([]any); len(enum) != 1 || enum[0] != "test.delete" {
+		t.Errorf("type enum = %v, want [test.delete]", typ["enum"])
+	}
+	if _, ok := props["tenant_id"]; !ok {
+		t.Errorf("schema properties = %v, want tenant_id", props)
+	}
+}
+
+func TestAsyncAPI(t *testing.T) {
+	router := chi.NewMux()
+	api := humachi.New(router, huma.DefaultConfig("tenantiq", "0.1.0"))
+	if err := adapter.RegisterAsyncAPI(api, map[string]string{"asyncapi": "3.0.0"}); err != nil {
+		t.Fatalf("RegisterAsyncAPI: %v", err)
+	}
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/asyncapi.json", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+	var doc map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil || doc["asyncapi"] != "3.0.0" {
+		t.Errorf("doc = %v, err = %v", doc, err)
+	}
+}
@@ -0,0 +1,242 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+const (
+	// feedBuffer is how many frames may wait for a slow client before it
+	// is disconnected.
+	feedBuffer = 64
+	// feedWriteTimeout bounds how long writing one frame may take.
+	feedWriteTimeout = 10 * time.Second
+)
+
+// FeedCommand is a message from a client of the event feed. Subscribing
+// adds tenant IDs and statuses to the client's subscription; unsubscribing
+// removes them. An event is delivered when its tenant's ID or status is
+// subscribed.
+type FeedCommand struct {
+	Action    string   `json:"action"`
+	TenantIDs []string `json:"tenant_ids,omitempty"`
+	Statuses  []string `json:"statuses,omitempty"`
+}
+
+// FeedFrame is a message to a client of the event feed. Type is "event"
+// for a tenant event, "subscription" with the client's current subscription
+// after each command, or "error" for a rejected command.
+type FeedFrame struct {
+	Type      string          `json:"type"`
+	Event     string          `json:"event,omitempty"`
+	Tenant    *TenantResponse `json:"tenant,omitempty"`
+	TenantIDs []string        `json:"tenant_ids,omitempty"`
+	Statuses  []string        `json:"statuses,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// EventFeed streams tenant events to WebSocket clients, which subscribe to
+// specific tenants or statuses. It is an event publisher itself, among the
+// targets of the service's publisher, so only events of this process are
+// delivered.
+type EventFeed struct {
+	mu      sync.Mutex
+	clients map[*feedClient]struct{}
+	closed  bool
+}
+
+// NewEventFeed creates a feed without clients.
+func NewEventFeed() *EventFeed {
+	return &EventFeed{clients: make(map[*feedClient]struct{})}
+}
+
+// Publish delivers an event to the subscribed clients. It never fails: a
+// client too slow to keep up is disconnected instead.
+func (f *EventFeed) Publish(_ context.Context, e domain.TenantEvent) error {
+	f.broadcast(e.Event, e.Tenant)
+	return nil
+}
+
+// Close disconnects every client and refuses new ones. Hijacked WebSocket
+// connections are not closed by http.Server.Shutdown, so register it with
+// RegisterOnShutdown.
+func (f *EventFeed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	for c := range f.clients {
+		c.stop(websocket.StatusGoingAway, "server shutting down")
+	}
+	clear(f.clients)
+}
+
+// ServeHTTP upgrades the request to a WebSocket and streams events until
+// the client disconnects.
+func (f *EventFeed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return // Accept has already written the error response.
+	}
+
+	c := newFeedClient()
+	if !f.add(c) {
+		conn.Close(websocket.StatusGoingAway, "server shutting down")
+		return
+	}
+	defer f.remove(c)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	go c.readCommands(ctx, cancel, conn)
+
+	for {
+		select {
+		case <-ctx.Done():
+			conn.Close(websocket.StatusNormalClosure, "")
+			return
+		case <-c.done:
+			conn.Close(c.closeCode, c.closeReason)
+			return
+		case frame := <-c.send:
+			writeCtx, cancelWrite := context.WithTimeout(ctx, feedWriteTimeout)
+			err := wsjson.Write(writeCtx, conn, frame)
+			cancelWrite()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (f *EventFeed) add(c *feedClient) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return false
+	}
+	f.clients[c] = struct{}{}
+	return true
+}
+
+func (f *EventFeed) remove(c *feedClient) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.clients, c)
+}
+
+func (f *EventFeed) broadcast(event domain.Event, tenant domain.Tenant) {
+	resp := toTenantResponse(tenant)
+	frame := FeedFrame{Type: "event", Event: string(event), Tenant: &resp}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for c := range f.clients {
+		if c.matches(tenant) {
+			c.deliver(frame)
+		}
+	}
+}
+
+// feedClient is one WebSocket connection and its subscription.
+type feedClient struct {
+	send chan FeedFrame
+
+	mu        sync.Mutex
+	tenantIDs []string
+	statuses  []string
+
+	stopOnce    sync.Once
+	done        chan struct{}
+	closeCode   websocket.StatusCode
+	closeReason string
+}
+
+func newFeedClient() *feedClient {
+	return &feedClient{
+		send: make(chan FeedFrame, feedBuffer),
+		done: make(chan struct{}),
+	}
+}
+
+func (c *feedClient) matches(t domain.Tenant) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Contains(c.tenantIDs, t.ID) || slices.Contains(c.statuses, string(t.Status))
+}
+
+// deliver queues a frame, disconnecting the client when it does not keep up.
+func (c *feedClient) deliver(frame FeedFrame) {
+	select {
+	case c.send <- frame:
+	default:
+		c.stop(websocket.StatusPolicyViolation, "client too slow")
+	}
+}
+
+func (c *feedClient) stop(code websocket.StatusCode, reason string) {
+	c.stopOnce.Do(func() {
+		c.closeCode, c.closeReason = code, reason
+		close(c.done)
+	})
+}
+
+// readCommands applies the client's commands until the connection fails,
+// then cancels the connection's context.
+func (c *feedClient) readCommands(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn) {
+	defer cancel()
+	for {
+		var cmd FeedCommand
+		if err := wsjson.Read(ctx, conn, &cmd); err != nil {
+			return
+		}
+		c.deliver(c.apply(cmd))
+	}
+}
+
+// apply updates the subscription and returns the reply to the command.
+func (c *feedClient) apply(cmd FeedCommand) FeedFrame {
+	for _, st := range cmd.Statuses {
+		if !validStatus(domain.Status(st)) {
+			return FeedFrame{Type: "error", Error: "unknown status " + st}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch cmd.Action {
+	case "subscribe":
+		c.tenantIDs = union(c.tenantIDs, cmd.TenantIDs)
+		c.statuses = union(c.statuses, cmd.Statuses)
+	case "unsubscribe":
+		c.tenantIDs = slices.DeleteFunc(c.tenantIDs, func(id string) bool { return slices.Contains(cmd.TenantIDs, id) })
+		c.statuses = slices.DeleteFunc(c.statuses, func(st string) bool { return slices.Contains(cmd.Statuses, st) })
+	default:
+		return FeedFrame{Type: "error", Error: "unknown action " + cmd.Action + " (use subscribe or unsubscribe)"}
+	}
+	return FeedFrame{Type: "subscription", TenantIDs: slices.Clone(c.tenantIDs), Statuses: slices.Clone(c.statuses)}
+}
+
+// union appends the values of add missing from set.
+func union(set, add []string) []string {
+	for _, v := range add {
+		if !slices.Contains(set, v) {
+			set = append(set, v)
+		}
+	}
+	return set
+}
+
+// validStatus reports whether s is a status of the tenant lifecycle.
+func validStatus(s domain.Status) bool {
+	return slices.ContainsFunc(domain.Transitions, func(t domain.Transition) bool {
+		return t.Src == s || t.Dst == s
+	})
+}
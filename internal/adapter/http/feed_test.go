@@ -0,0 +1,163 @@
+package http_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// newFeedTestServer serves feed on an httptest.Server and returns a service
+// whose events are delivered to it.
+func newFeedTestServer(t *testing.T) (*httptest.Server, *app.TenantService) {
+	t.Helper()
+
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	feed := adapter.NewEventFeed()
+	t.Cleanup(feed.Close)
+	svc := app.NewTenantService(repo, feed, &testValidator{})
+
+	srv := httptest.NewServer(feed)
+	t.Cleanup(srv.Close)
+	return srv, svc
+}
+
+func dialFeed(t *testing.T, srv *httptest.Server) *websocket.Conn {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.CloseNow() })
+	return conn
+}
+
+// command sends cmd and returns the feed's reply.
+func command(t *testing.T, conn *websocket.Conn, cmd adapter.FeedCommand) adapter.FeedFrame {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := wsjson.Write(ctx, conn, cmd); err != nil {
+		t.Fatalf("write command: %v", err)
+	}
+	return readFrame(t, conn)
+}
+
+func readFrame(t *testing.T, conn *websocket.Conn) adapter.FeedFrame {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var frame adapter.FeedFrame
+	if err := wsjson.Read(ctx, conn, &frame); err != nil {
+		t.Fatalf("read frame: %v", err)
+	}
+	return frame
+}
+
+func TestEventFeed_DeliversSubscribedTenants(t *testing.T) {
+	srv, svc := newFeedTestServer(t)
+	conn := dialFeed(t, srv)
+	ctx := context.Background()
+
+	acme, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	globex, err := svc.Create(ctx, app.CreateInput{Name: "Globex", Slug: "globex", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	reply := command(t, conn, adapter.FeedCommand{Action: "subscribe", TenantIDs: []string{acme.ID}})
+	if reply.Type != "subscription" || len(reply.TenantIDs) != 1 || reply.TenantIDs[0] != acme.ID {
+		t.Fatalf("reply = %+v, want subscription to %s", reply, acme.ID)
+	}
+
+	if _, err := svc.Transition(ctx, globex.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+	if _, err := svc.Transition(ctx, acme.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+
+	frame := readFrame(t, conn)
+	if frame.Type != "event" || frame.Event != string(domain.EventProvisionComplete) {
+		t.Fatalf("frame = %+v, want provision_complete event", frame)
+	}
+	if frame.Tenant == nil || frame.Tenant.ID != acme.ID || frame.Tenant.Status != "active" {
+		t.Errorf("tenant = %+v, want active %s", frame.Tenant, acme.ID)
+	}
+}
+
+func TestEventFeed_StatusSubscriptionAndUnsubscribe(t *testing.T) {
+	srv, svc := newFeedTestServer(t)
+	conn := dialFeed(t, srv)
+	ctx := context.Background()
+
+	command(t, conn, adapter.FeedCommand{Action: "subscribe", Statuses: []string{"active", "suspended"}})
+
+	acme, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := svc.Transition(ctx, acme.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+	if frame := readFrame(t, conn); frame.Tenant == nil || frame.Tenant.Status != "active" {
+		t.Fatalf("frame = %+v, want the activation (creation is not subscribed)", frame)
+	}
+
+	reply := command(t, conn, adapter.FeedCommand{Action: "unsubscribe", Statuses: []string{"suspended"}})
+	if len(reply.Statuses) != 1 || reply.Statuses[0] != "active" {
+		t.Fatalf("reply = %+v, want only active left", reply)
+	}
+
+	if _, err := svc.Transition(ctx, acme.ID, domain.EventSuspend); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+	if _, err := svc.Transition(ctx, acme.ID, domain.EventReactivate); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+	if frame := readFrame(t, conn); frame.Event != string(domain.EventReactivate) {
+		t.Errorf("frame = %+v, want the reactivation (suspension was unsubscribed)", frame)
+	}
+}
+
+func TestEventFeed_RejectsInvalidCommands(t *testing.T) {
+	srv, _ := newFeedTestServer(t)
+	conn := dialFeed(t, srv)
+
+	tests := []struct {
+		name string
+		cmd  adapter.FeedCommand
+	}{
+		{"unknown action", adapter.FeedCommand{Action: "watch"}},
+		{"unknown status", adapter.FeedCommand{Action: "subscribe", Statuses: []string{"frozen"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if reply := command(t, conn, tt.cmd); reply.Type != "error" || reply.Error == "" {
+				t.Errorf("reply = %+v, want an error", reply)
+			}
+		})
+	}
+}
@@ -2,8 +2,9 @@ package http
 
 import (
 	"context"
-	"errors"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 
@@ -13,61 +14,262 @@ import (
 
 // TenantResponse is the API representation of a tenant.
 type TenantResponse struct {
-	ID        string `json:"id" doc:"Unique identifier"`
-	Name      string `json:"name" doc:"Display name"`
-	Slug      string `json:"slug" doc:"URL-friendly identifier"`
-	Status    string `json:"status" doc:"Lifecycle state"`
-	Plan      string `json:"plan" doc:"Subscription plan"`
-	CreatedAt string `json:"created_at" doc:"Creation timestamp (ISO 8601)"`
-	UpdatedAt string `json:"updated_at" doc:"Last update timestamp (ISO 8601)"`
+	ID            string            `json:"id" doc:"Unique identifier"`
+	Name          string            `json:"name" doc:"Display name"`
+	Slug          string            `json:"slug" doc:"URL-friendly identifier"`
+	Status        string            `json:"status" doc:"Lifecycle state"`
+	Plan          string            `json:"plan" doc:"Subscription plan"`
+	Region        string            `json:"region,omitempty" doc:"Region the tenant is hosted in, if set"`
+	PRURL         string            `json:"pr_url,omitempty" doc:"Provisioning pull request"`
+	GitBranch     string            `json:"git_branch,omitempty" doc:"Provisioning Git branch"`
+	ExternalRefs  map[string]string `json:"external_refs,omitempty" doc:"References in external systems (ArgoCD app, billing customer, ...) keyed by system"`
+	Metadata      map[string]string `json:"metadata,omitempty" doc:"Integrator-defined key-value data (CRM IDs, internal references, ...)"`
+	Tags          []string          `json:"tags,omitempty" doc:"Tags grouping the tenant (campaign, cohort, support tier, ...), sorted"`
+	ResellerID    string            `json:"reseller_id,omitempty" doc:"Reseller managing the tenant, if any"`
+	SuggestedPlan string            `json:"suggested_plan,omitempty" doc:"Plan that better fits the tenant's reported usage, if any"`
+	TrialEndsAt   string            `json:"trial_ends_at,omitempty" doc:"When the tenant's trial expires (ISO 8601), if on trial"`
+	Simulated     bool              `json:"simulated,omitempty" doc:"Whether the tenant is simulated, provisioned by fakes only"`
+	Version       int               `json:"version" doc:"Number of stored changes; increases with every update"`
+	CreatedAt     string            `json:"created_at" doc:"Creation timestamp (ISO 8601)"`
+	UpdatedAt     string            `json:"updated_at" doc:"Last update timestamp (ISO 8601)"`
 }
 
 func toTenantResponse(t domain.Tenant) TenantResponse {
-	return TenantResponse{
-		ID:        t.ID,
-		Name:      t.Name,
-		Slug:      t.Slug,
-		Status:    string(t.Status),
-		Plan:      t.Plan,
-		CreatedAt: t.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt: t.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	resp := TenantResponse{
+		ID:            t.ID,
+		Name:          t.Name,
+		Slug:          t.Slug,
+		Status:        string(t.Status),
+		Plan:          t.Plan,
+		Region:        t.Region,
+		PRURL:         t.PRURL,
+		GitBranch:     t.GitBranch,
+		ExternalRefs:  t.ExternalRefs,
+		Metadata:      t.Metadata,
+		Tags:          t.Tags,
+		ResellerID:    t.ResellerID,
+		SuggestedPlan: t.SuggestedPlan,
+		Simulated:     t.Simulated,
+		Version:       t.Version,
+		CreatedAt:     t.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:     t.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	}
+	if !t.TrialEndsAt.IsZero() {
+		resp.TrialEndsAt = t.TrialEndsAt.Format("2006-01-02T15:04:05Z")
+	}
+	return resp
 }
 
 // --- Create Tenant ---
 
 type CreateTenantInput struct {
-	Body struct {
-		Name string `json:"name" minLength:"1" maxLength:"255" doc:"Display name"`
-		Slug string `json:"slug" minLength:"1" maxLength:"100" pattern:"^[a-z0-9]+(?:-[a-z0-9]+)*$" doc:"URL-friendly identifier (lowercase, hyphens)"`
-		Plan string `json:"plan,omitempty" default:"free" doc:"Subscription plan"`
+	Prefer string `header:"Prefer" doc:"Send respond-async to queue provisioning and get 202 with an operation to poll"`
+	Body   struct {
+		Name      string            `json:"name" minLength:"1" maxLength:"255" doc:"Display name"`
+		Slug      string            `json:"slug,omitempty" doc:"URL-friendly identifier (lowercase, hyphens); derived from the name when omitted"`
+		Plan      string            `json:"plan,omitempty" doc:"Subscription plan; the blueprint's plan, or free, when omitted"`
+		Blueprint string            `json:"blueprint,omitempty" doc:"Blueprint giving the tenant its plan, metadata, feature flags and template variables; the request's plan and metadata override it"`
+		Region    string            `json:"region,omitempty" maxLength:"32" doc:"Region to host the tenant in (e.g. eu-west); its plan must be offered there. It cannot change later"`
+		Simulated bool              `json:"simulated,omitempty" doc:"Provision the tenant with fake adapters only (no Git, DNS or Kubernetes), for testing"`
+		Metadata  map[string]string `json:"metadata,omitempty" doc:"Integrator-defined key-value data; keys are letters, digits, '_', '-' and '.'"`
 	}
 }
 
+// TenantOperationResponse is a tenant and, when the request was handled
+// asynchronously, the operation tracking the remaining work.
+type TenantOperationResponse struct {
+	TenantResponse
+	OperationID string `json:"operation_id,omitempty" doc:"Operation tracking the remaining work (asynchronous mode only)"`
+}
+
 type CreateTenantOutput struct {
-	Body TenantResponse
+	Status            int
+	Location          string `header:"Location"`
+	PreferenceApplied string `header:"Preference-Applied"`
+	Body              TenantOperationResponse
+}
+
+// --- Batch Create Tenants ---
+
+// BatchCreateItem is one tenant of a batch. Slugs are validated per item so
+// a malformed one is reported in its result instead of failing the batch.
+type BatchCreateItem struct {
+	Name      string            `json:"name" minLength:"1" maxLength:"255" doc:"Display name"`
+	Slug      string            `json:"slug,omitempty" doc:"URL-friendly identifier (lowercase, hyphens); derived from the name when omitted"`
+	Plan      string            `json:"plan,omitempty" doc:"Subscription plan; the blueprint's plan, or free, when omitted"`
+	Metadata  map[string]string `json:"metadata,omitempty" doc:"Integrator-defined key-value data"`
+	Blueprint string            `json:"blueprint,omitempty" doc:"Blueprint giving the tenant its plan, metadata, feature flags and template variables"`
+	Region    string            `json:"region,omitempty" maxLength:"32" doc:"Region to host the tenant in; its plan must be offered there"`
+}
+
+type BatchCreateTenantsInput struct {
+	Body struct {
+		Tenants []BatchCreateItem `json:"tenants" minItems:"1" maxItems:"100" doc:"Tenants to create"`
+	}
+}
+
+// BatchCreateResult is the outcome for the tenant at the same index.
+type BatchCreateResult struct {
+	Status string          `json:"status" enum:"created,conflict,invalid" doc:"Outcome for this item"`
+	Tenant *TenantResponse `json:"tenant,omitempty" doc:"Created tenant"`
+	Error  string          `json:"error,omitempty" doc:"Why the item was rejected"`
+}
+
+type BatchCreateTenantsOutput struct {
+	Body struct {
+		Results []BatchCreateResult `json:"results" doc:"Per-item results, in request order"`
+	}
+}
+
+func toBatchCreateOutput(results []app.BatchCreateResult) *BatchCreateTenantsOutput {
+	out := &BatchCreateTenantsOutput{}
+	out.Body.Results = make([]BatchCreateResult, len(results))
+	for i, r := range results {
+		out.Body.Results[i] = BatchCreateResult{Status: string(r.Status), Error: r.Error}
+		if r.Status == app.BatchCreated {
+			tenant := toTenantResponse(r.Tenant)
+			out.Body.Results[i].Tenant = &tenant
+		}
+	}
+	return out
+}
+
+// --- Batch Transition Tenants ---
+
+type BatchTransitionTenantsInput struct {
+	Body struct {
+		IDs   []string       `json:"ids" minItems:"1" maxItems:"100" doc:"Tenants to trigger the event on"`
+		Event lifecycleEvent `json:"event" doc:"Lifecycle event to trigger"`
+		Force bool           `json:"force,omitempty" doc:"Suspend or delete past the guardrail on mass disruptions (admin only)"`
+	}
+}
+
+// BatchTransitionResult is the outcome for the tenant at the same index.
+type BatchTransitionResult struct {
+	Status string          `json:"status" enum:"transitioned,failed" doc:"Outcome for this tenant"`
+	Tenant *TenantResponse `json:"tenant,omitempty" doc:"Tenant after the event"`
+	Error  string          `json:"error,omitempty" doc:"Why the event failed"`
+}
+
+type BatchTransitionTenantsOutput struct {
+	Body struct {
+		Results []BatchTransitionResult `json:"results" doc:"Per-tenant results, in request order"`
+	}
+}
+
+func toBatchTransitionOutput(results []app.BatchTransitionResult) *BatchTransitionTenantsOutput {
+	out := &BatchTransitionTenantsOutput{}
+	out.Body.Results = make([]BatchTransitionResult, len(results))
+	for i, r := range results {
+		out.Body.Results[i] = BatchTransitionResult{Status: string(r.Status), Error: r.Error}
+		if r.Status == app.BatchTransitioned {
+			tenant := toTenantResponse(r.Tenant)
+			out.Body.Results[i].Tenant = &tenant
+		}
+	}
+	return out
 }
 
 // --- Get Tenant ---
 
 type GetTenantInput struct {
-	ID string `path:"id" doc:"Tenant ID"`
+	ID   string    `path:"id" doc:"Tenant ID"`
+	AsOf time.Time `query:"as_of" doc:"Return the tenant as it was at this time (RFC 3339), reconstructed from the audit trail"`
 }
 
 type GetTenantOutput struct {
 	Body TenantResponse
 }
 
+// --- Update Tenant ---
+
+type UpdateTenantInput struct {
+	ID   string `path:"id" doc:"Tenant ID"`
+	Body struct {
+		Plan         *string           `json:"plan,omitempty" doc:"Subscription plan to move the tenant to"`
+		PRURL        *string           `json:"pr_url,omitempty" doc:"Provisioning pull request URL"`
+		GitBranch    *string           `json:"git_branch,omitempty" doc:"Provisioning Git branch"`
+		ExternalRefs map[string]string `json:"external_refs,omitempty" doc:"References to merge; an empty value removes the key"`
+		Metadata     map[string]string `json:"metadata,omitempty" doc:"Metadata to merge; an empty value removes the key"`
+		TrialEndsAt  *string           `json:"trial_ends_at,omitempty" doc:"When the trial expires (RFC 3339); an empty value takes the tenant off trial"`
+	}
+}
+
+type UpdateTenantOutput struct {
+	Body TenantResponse
+}
+
+// --- Get Tenant by Slug ---
+
+type GetTenantBySlugInput struct {
+	Slug string `path:"slug" doc:"Tenant slug"`
+}
+
+// ResolvedTenantResponse is a tenant resolved by slug, with what an API
+// gateway needs to route its traffic.
+type ResolvedTenantResponse struct {
+	TenantResponse
+	RateLimit *RateLimitBody `json:"rate_limit,omitempty" doc:"Request rate allowed to the tenant; absent when it is not rate limited"`
+}
+
+type GetTenantBySlugOutput struct {
+	Body ResolvedTenantResponse
+}
+
 // --- List Tenants ---
 
 type ListTenantsInput struct {
-	Status string `query:"status" required:"false" doc:"Filter by status"`
-	Limit  int    `query:"limit" required:"false" default:"50" doc:"Max results"`
-	Offset int    `query:"offset" required:"false" default:"0" doc:"Pagination offset"`
+	Status        []lifecycleStatus `query:"status" required:"false" doc:"Filter by status (comma-separated, matches any)"`
+	Plan          []string          `query:"plan" required:"false" doc:"Filter by plan (comma-separated, matches any)"`
+	CreatedAfter  time.Time         `query:"created_after" required:"false" doc:"Only tenants created at or after this time (RFC 3339)"`
+	CreatedBefore time.Time         `query:"created_before" required:"false" doc:"Only tenants created before this time (RFC 3339)"`
+	Simulated     string            `query:"simulated" required:"false" enum:"true,false" doc:"Only simulated (true) or real (false) tenants"`
+	Tag           []string          `query:"tag,explode" required:"false" doc:"Only tenants with this tag; repeat to require several (?tag=a&tag=b)"`
+	Limit         int               `query:"limit" required:"false" default:"50" minimum:"1" maximum:"1000" doc:"Max results"`
+	Offset        int               `query:"offset" required:"false" default:"0" minimum:"0" doc:"Pagination offset"`
+
+	// Metadata holds the metadata.<key>=<value> query parameters, which
+	// OpenAPI cannot declare one by one.
+	Metadata map[string]string
+}
+
+// metadataParamPrefix prefixes the query parameters filtering tenants by
+// metadata.
+const metadataParamPrefix = "metadata."
+
+// Resolve collects the metadata filters from the query string.
+func (i *ListTenantsInput) Resolve(ctx huma.Context) []error {
+	u := ctx.URL()
+	for name, values := range u.Query() {
+		key, ok := strings.CutPrefix(name, metadataParamPrefix)
+		if !ok {
+			continue
+		}
+		if key == "" || len(values) != 1 {
+			return []error{&huma.ErrorDetail{
+				Message:  "expected a single metadata.<key>=<value> parameter per key",
+				Location: "query." + name,
+			}}
+		}
+		if i.Metadata == nil {
+			i.Metadata = make(map[string]string)
+		}
+		i.Metadata[key] = values[0]
+	}
+	return nil
+}
+
+// TenantListResponse is a page of tenants with the metadata needed to
+// render pagination controls.
+type TenantListResponse struct {
+	Items  TenantList `json:"items" doc:"Tenants in this page"`
+	Total  int        `json:"total" doc:"Total number of tenants matching the filter"`
+	Limit  int        `json:"limit" doc:"Max results requested"`
+	Offset int        `json:"offset" doc:"Pagination offset requested"`
 }
 
 type ListTenantsOutput struct {
-	Body []TenantResponse
+	Body TenantListResponse
 }
 
 // --- Transition ---
@@ -75,7 +277,7 @@ type ListTenantsOutput struct {
 type TransitionInput struct {
 	ID   string `path:"id" doc:"Tenant ID"`
 	Body struct {
-		Event string `json:"event" doc:"Lifecycle event to trigger" enum:"provision_complete,suspend,reactivate,delete,deletion_complete"`
+		Event lifecycleEvent `json:"event" doc:"Lifecycle event to trigger"`
 	}
 }
 
@@ -83,20 +285,225 @@ type TransitionOutput struct {
 	Body TenantResponse
 }
 
+// --- Delete Tenant ---
+
+type DeleteTenantInput struct {
+	ID     string `path:"id" doc:"Tenant ID"`
+	Prefer string `header:"Prefer" doc:"Send respond-async to queue the completion of the deletion and get an operation to poll"`
+}
+
+type DeleteTenantOutput struct {
+	Location          string `header:"Location"`
+	PreferenceApplied string `header:"Preference-Applied"`
+	Body              TenantOperationResponse
+}
+
+// --- Apply Spec ---
+
+type ApplySpecInput struct {
+	Slug string `path:"slug" pattern:"^[a-z0-9]+(?:-[a-z0-9]+)*$" maxLength:"100" doc:"Tenant slug"`
+	Body struct {
+		Name     string            `json:"name" minLength:"1" maxLength:"255" doc:"Display name"`
+		Plan     string            `json:"plan,omitempty" doc:"Subscription plan (left untouched when omitted; free for a new tenant)"`
+		Status   lifecycleStatus   `json:"status,omitempty" doc:"Desired lifecycle state (left untouched when omitted)"`
+		Metadata map[string]string `json:"metadata,omitempty" doc:"Metadata to set; an empty value removes the key and unlisted keys are left untouched"`
+		Features map[string]bool   `json:"features,omitempty" doc:"Feature flags to switch on or off, kept in the metadata as feature.<name>"`
+		Domains  []string          `json:"domains,omitempty" maxItems:"100" doc:"All the custom domains of the tenant: missing ones get a certificate, the others are removed (left untouched when omitted)"`
+	}
+}
+
+// ApplySpecResponse reports the outcome of applying a tenant spec.
+type ApplySpecResponse struct {
+	Tenant  TenantResponse `json:"tenant" doc:"Tenant after the spec was applied"`
+	Created bool           `json:"created" doc:"Whether the tenant was created by this request"`
+	Changes []string       `json:"changes" doc:"Changes made to converge to the spec (empty when already in sync)"`
+}
+
+type ApplySpecOutput struct {
+	Body ApplySpecResponse
+}
+
 // Register adds all tenant API routes to the Huma API.
-func Register(api huma.API, svc *app.TenantService) {
+func Register(api huma.API, svc *app.TenantService, opts ...Option) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	errs := errorMapper{debug: o.debugErrors}
+
+	// Registered first: Huma binds middlewares when an operation is registered.
+	tagLatencyBudgets(api)
+	if o.maxBodyBytes > 0 {
+		limitBodies(api, o.maxBodyBytes)
+	}
+	api.UseMiddleware(latencyBudgetMiddleware(o.latencyObserver))
+	api.UseMiddleware(callerMiddleware)
+	api.UseMiddleware(clientIPMiddleware(o.trustedProxies))
+	if o.authenticationEnabled() {
+		api.UseMiddleware(authMiddleware(api, &o))
+		documentAuthentication(api, &o)
+	}
+	if o.readOnly != nil {
+		api.UseMiddleware(readOnlyMiddleware(api, o.readOnly, errs))
+		registerReadOnly(api, o.readOnly, errs)
+	}
+
+	registerHistory(api, svc, errs)
+	registerTags(api, svc, errs)
+	if svc.ReportsEnabled() {
+		registerReports(api, svc, errs)
+	}
+	if svc.ChangesEnabled() {
+		registerChanges(api, svc, errs)
+	}
+	if svc.StatusCountsEnabled() {
+		registerStatusCounts(api, svc, errs)
+	}
+	if svc.UsageEnabled() {
+		registerUsage(api, svc, errs)
+	}
+	if svc.QuotasEnabled() {
+		registerQuotas(api, svc, errs)
+	}
+	if svc.MaintenanceEnabled() {
+		registerMaintenance(api, svc, errs)
+	}
+	if svc.RateLimitsEnabled() {
+		registerRateLimits(api, svc, errs)
+	}
+	if svc.IPAllowlistsEnabled() {
+		registerIPAllowlists(api, svc, errs)
+	}
+	if o.operations != nil {
+		registerOperations(api, o.operations, errs)
+	}
+	if o.resellers != nil {
+		registerResellers(api, o.resellers, errs)
+	}
+	if o.billing != nil {
+		registerBilling(api, o.billing, errs)
+	}
+	if o.webhooks != nil {
+		registerWebhooks(api, o.webhooks, errs)
+	}
+	if o.plans != nil {
+		registerPlans(api, o.plans, errs)
+	}
+	if o.blueprints != nil {
+		registerBlueprints(api, o.blueprints, errs)
+	}
+	if o.dunning != nil {
+		registerDunning(api, o.dunning, o.billingWebhookSecret, errs)
+	}
+	if o.certificates != nil {
+		registerCertificates(api, o.certificates, errs)
+	}
+	if o.members != nil {
+		registerMembers(api, o.members, errs)
+	}
+	if o.encryption != nil {
+		registerEncryption(api, o.encryption, errs)
+	}
+	if o.apiKeys != nil {
+		registerAPIKeys(api, o.apiKeys, o.requestLimiter, errs)
+		registerStatusWidget(api, svc, o.apiKeys, o.requestLimiter, errs)
+	}
+	if o.adminKeys != nil {
+		registerAdminKeys(api, o.adminKeys, errs)
+	}
+	if o.signer != nil {
+		registerSignedURLs(api, svc, o.signer, o.signedURLMaxTTL, errs)
+	}
+	if o.importKey != "" {
+		registerImports(api, svc, o.importKey, errs)
+	}
+
 	huma.Register(api, huma.Operation{
 		OperationID: "create-tenant",
 		Method:      http.MethodPost,
 		Path:        "/api/v1/tenants",
 		Summary:     "Create a new tenant",
-		Tags:        []string{"Tenants"},
+		Description: "With `Prefer: respond-async` (and asynchronous provisioning enabled), the tenant is " +
+			"returned in the creating state with 202 and an operation_id to poll at /api/v1/operations/{id}. " +
+			"A simulated tenant is provisioned by fake adapters and its events reach neither AMQP nor webhooks; " +
+			"without `Prefer: respond-async` it is returned active.",
+		Tags: []string{"Tenants"},
 	}, func(ctx context.Context, input *CreateTenantInput) (*CreateTenantOutput, error) {
-		tenant, err := svc.Create(ctx, input.Body.Name, input.Body.Slug, input.Body.Plan)
+		in := app.CreateInput{
+			Name:      input.Body.Name,
+			Slug:      input.Body.Slug,
+			Plan:      input.Body.Plan,
+			Metadata:  input.Body.Metadata,
+			Region:    input.Body.Region,
+			Blueprint: input.Body.Blueprint,
+			Simulated: input.Body.Simulated,
+		}
+		if in.Blueprint == "" && in.Plan == "" {
+			in.Plan = app.DefaultPlan
+		}
+		if prefersAsync(input.Prefer) && svc.AsyncEnabled() {
+			tenant, op, err := svc.CreateAsync(ctx, in)
+			if err != nil {
+				return nil, errs.toHuma(ctx, err)
+			}
+			return &CreateTenantOutput{
+				Status:            http.StatusAccepted,
+				Location:          "/api/v1/operations/" + op.ID,
+				PreferenceApplied: "respond-async",
+				Body:              TenantOperationResponse{TenantResponse: toTenantResponse(tenant), OperationID: op.ID},
+			}, nil
+		}
+
+		tenant, err := svc.Create(ctx, in)
 		if err != nil {
-			return nil, toHumaError(err)
+			return nil, errs.toHuma(ctx, err)
 		}
-		return &CreateTenantOutput{Body: toTenantResponse(tenant)}, nil
+		return &CreateTenantOutput{
+			Status: http.StatusOK,
+			Body:   TenantOperationResponse{TenantResponse: toTenantResponse(tenant)},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "batch-create-tenants",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/tenants:batchCreate",
+		Summary:     "Create many tenants in one request",
+		Description: "Validates every item up front and inserts the accepted ones in a single transaction. " +
+			"Rejected items are reported per item and do not prevent the others from being created.",
+		Tags: []string{"Tenants"},
+	}, func(ctx context.Context, input *BatchCreateTenantsInput) (*BatchCreateTenantsOutput, error) {
+		items := make([]app.BatchCreateItem, len(input.Body.Tenants))
+		for i, item := range input.Body.Tenants {
+			plan := item.Plan
+			if plan == "" && item.Blueprint == "" {
+				plan = app.DefaultPlan
+			}
+			items[i] = app.BatchCreateItem{Name: item.Name, Slug: item.Slug, Plan: plan, Metadata: item.Metadata, Region: item.Region, Blueprint: item.Blueprint}
+		}
+
+		results, err := svc.BatchCreate(ctx, items)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return toBatchCreateOutput(results), nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "batch-transition-tenants",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/tenants:batchTransition",
+		Summary:     "Trigger a lifecycle event on many tenants",
+		Description: "Suspensions and deletions are refused with 409, and nothing is written, when they would " +
+			"disrupt more active tenants than the guardrail allows; an admin may set force to go past it. " +
+			"A tenant the event fails on is reported in its result and does not stop the others.",
+		Tags: []string{"Tenants"},
+	}, func(ctx context.Context, input *BatchTransitionTenantsInput) (*BatchTransitionTenantsOutput, error) {
+		results, err := svc.BatchTransition(ctx, input.Body.IDs, domain.Event(input.Body.Event), input.Body.Force)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return toBatchTransitionOutput(results), nil
 	})
 
 	huma.Register(api, huma.Operation{
@@ -106,39 +513,126 @@ func Register(api huma.API, svc *app.TenantService) {
 		Summary:     "Get a tenant by ID",
 		Tags:        []string{"Tenants"},
 	}, func(ctx context.Context, input *GetTenantInput) (*GetTenantOutput, error) {
+		if !input.AsOf.IsZero() {
+			if !svc.AsOfEnabled() {
+				return nil, huma.Error400BadRequest("as_of requires the audit trail, which is not configured")
+			}
+			tenant, err := svc.GetAsOf(ctx, input.ID, input.AsOf)
+			if err != nil {
+				return nil, errs.toHuma(ctx, err)
+			}
+			return &GetTenantOutput{Body: toTenantResponse(tenant)}, nil
+		}
+
 		tenant, err := svc.GetByID(ctx, input.ID)
 		if err != nil {
-			return nil, toHumaError(err)
+			return nil, errs.toHuma(ctx, err)
 		}
 		return &GetTenantOutput{Body: toTenantResponse(tenant)}, nil
 	})
 
+	huma.Register(api, huma.Operation{
+		OperationID: "update-tenant",
+		Method:      http.MethodPatch,
+		Path:        "/api/v1/tenants/{id}",
+		Summary:     "Update a tenant's plan and references",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *UpdateTenantInput) (*UpdateTenantOutput, error) {
+		patch := domain.TenantPatch{
+			Plan:         input.Body.Plan,
+			PRURL:        input.Body.PRURL,
+			GitBranch:    input.Body.GitBranch,
+			ExternalRefs: input.Body.ExternalRefs,
+			Metadata:     input.Body.Metadata,
+		}
+		if v := input.Body.TrialEndsAt; v != nil {
+			var ends time.Time
+			if *v != "" {
+				var err error
+				if ends, err = time.Parse(time.RFC3339, *v); err != nil {
+					return nil, huma.Error422UnprocessableEntity("trial_ends_at must be an RFC 3339 time or empty")
+				}
+			}
+			patch.TrialEndsAt = &ends
+		}
+		tenant, err := svc.Update(ctx, input.ID, patch)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &UpdateTenantOutput{Body: toTenantResponse(tenant)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-tenant-by-slug",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/tenants/slug/{slug}",
+		Summary:     "Get a tenant by slug",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *GetTenantBySlugInput) (*GetTenantBySlugOutput, error) {
+		tenant, err := svc.GetBySlug(ctx, input.Slug)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		body := ResolvedTenantResponse{TenantResponse: toTenantResponse(tenant)}
+		if svc.RateLimitsEnabled() {
+			limit, ok, err := svc.RateLimit(ctx, tenant)
+			if err != nil {
+				return nil, errs.toHuma(ctx, err)
+			}
+			if ok {
+				rl := toRateLimitBody(limit)
+				body.RateLimit = &rl
+			}
+		}
+		return &GetTenantBySlugOutput{Body: body}, nil
+	})
+
 	huma.Register(api, huma.Operation{
 		OperationID: "list-tenants",
 		Method:      http.MethodGet,
 		Path:        "/api/v1/tenants",
 		Summary:     "List tenants",
-		Tags:        []string{"Tenants"},
+		Description: "Besides the parameters below, `metadata.<key>=<value>` parameters only list the tenants " +
+			"whose metadata has every given key with the given value, e.g. `?metadata.crm_id=42`.",
+		Tags: []string{"Tenants"},
 	}, func(ctx context.Context, input *ListTenantsInput) (*ListTenantsOutput, error) {
 		filter := domain.ListFilter{
 			Limit:  input.Limit,
 			Offset: input.Offset,
 		}
-		if input.Status != "" {
-			s := domain.Status(input.Status)
-			filter.Status = &s
+		for _, st := range input.Status {
+			filter.Statuses = append(filter.Statuses, domain.Status(st))
 		}
+		filter.Plans = input.Plan
+		filter.CreatedAfter = input.CreatedAfter
+		filter.CreatedBefore = input.CreatedBefore
+		if input.Simulated != "" {
+			simulated := input.Simulated == "true"
+			filter.Simulated = &simulated
+		}
+		filter.Metadata = input.Metadata
+		filter.Tags = input.Tag
 
 		tenants, err := svc.List(ctx, filter)
 		if err != nil {
-			return nil, toHumaError(err)
+			return nil, errs.toHuma(ctx, err)
+		}
+
+		total, err := listTotal(ctx, svc, filter, len(tenants))
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
 		}
 
-		resp := make([]TenantResponse, len(tenants))
+		items := make(TenantList, len(tenants))
 		for i, t := range tenants {
-			resp[i] = toTenantResponse(t)
+			items[i] = toTenantResponse(t)
 		}
-		return &ListTenantsOutput{Body: resp}, nil
+		return &ListTenantsOutput{Body: TenantListResponse{
+			Items:  items,
+			Total:  total,
+			Limit:  input.Limit,
+			Offset: input.Offset,
+		}}, nil
 	})
 
 	huma.Register(api, huma.Operation{
@@ -150,27 +644,80 @@ func Register(api huma.API, svc *app.TenantService) {
 	}, func(ctx context.Context, input *TransitionInput) (*TransitionOutput, error) {
 		tenant, err := svc.Transition(ctx, input.ID, domain.Event(input.Body.Event))
 		if err != nil {
-			return nil, toHumaError(err)
+			return nil, errs.toHuma(ctx, err)
 		}
 		return &TransitionOutput{Body: toTenantResponse(tenant)}, nil
 	})
-}
 
-// toHumaError translates domain errors to Huma HTTP errors.
-func toHumaError(err error) error {
-	if errors.Is(err, domain.ErrTenantNotFound) {
-		return huma.Error404NotFound("tenant not found")
-	}
+	huma.Register(api, huma.Operation{
+		OperationID: "apply-tenant-spec",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/tenants/{slug}/spec",
+		Summary:     "Apply a desired-state spec to a tenant",
+		Description: "Creates the tenant if missing, updates drifted fields and drives its status through the lifecycle. Idempotent.",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *ApplySpecInput) (*ApplySpecOutput, error) {
+		result, err := svc.Apply(ctx, domain.TenantSpec{
+			Slug:     input.Slug,
+			Name:     input.Body.Name,
+			Plan:     input.Body.Plan,
+			Status:   domain.Status(input.Body.Status),
+			Metadata: input.Body.Metadata,
+			Features: input.Body.Features,
+			Domains:  input.Body.Domains,
+		})
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		changes := result.Changes
+		if changes == nil {
+			changes = []string{}
+		}
+		return &ApplySpecOutput{Body: ApplySpecResponse{
+			Tenant:  toTenantResponse(result.Tenant),
+			Created: result.Created,
+			Changes: changes,
+		}}, nil
+	})
 
-	var slugErr *domain.SlugConflictError
-	if errors.As(err, &slugErr) {
-		return huma.Error409Conflict(slugErr.Error())
-	}
+	// DELETE is sugar for the "delete" lifecycle event. The tenant is not
+	// removed: it moves to "deleting" and cleanup continues asynchronously,
+	// hence 202 Accepted. With respond-async the completion is queued and
+	// tracked by an operation.
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-tenant",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/tenants/{id}",
+		Summary:       "Delete a tenant",
+		Tags:          []string{"Tenants"},
+		DefaultStatus: http.StatusAccepted,
+	}, func(ctx context.Context, input *DeleteTenantInput) (*DeleteTenantOutput, error) {
+		if prefersAsync(input.Prefer) && svc.AsyncEnabled() {
+			tenant, op, err := svc.DeleteAsync(ctx, input.ID)
+			if err != nil {
+				return nil, errs.toHuma(ctx, err)
+			}
+			return &DeleteTenantOutput{
+				Location:          "/api/v1/operations/" + op.ID,
+				PreferenceApplied: "respond-async",
+				Body:              TenantOperationResponse{TenantResponse: toTenantResponse(tenant), OperationID: op.ID},
+			}, nil
+		}
 
-	var trErr *domain.TransitionError
-	if errors.As(err, &trErr) {
-		return huma.Error422UnprocessableEntity(trErr.Error())
-	}
+		tenant, err := svc.Transition(ctx, input.ID, domain.EventDelete)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &DeleteTenantOutput{Body: TenantOperationResponse{TenantResponse: toTenantResponse(tenant)}}, nil
+	})
+}
 
-	return huma.Error500InternalServerError("internal server error")
+// listTotal returns how many tenants match filter. A short page that is
+// not past the end is the last one, so the total follows from it without
+// counting again.
+func listTotal(ctx context.Context, svc *app.TenantService, filter domain.ListFilter, page int) (int, error) {
+	if filter.Limit > 0 && page < filter.Limit && (page > 0 || filter.Offset == 0) {
+		return filter.Offset + page, nil
+	}
+	return svc.Count(ctx, filter)
 }
@@ -9,12 +9,14 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humachi"
 	"github.com/go-chi/chi/v5"
 
 	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/simulator"
 	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
 	"github.com/neomorfeo/tenantiq/internal/app"
 	"github.com/neomorfeo/tenantiq/internal/domain"
@@ -23,24 +25,24 @@ import (
 // noopPublisher is a no-op EventPublisher for tests.
 type noopPublisher struct{}
 
-func (p *noopPublisher) Publish(_ context.Context, _ domain.Event, _ domain.Tenant) error {
+func (p *noopPublisher) Publish(_ context.Context, _ domain.TenantEvent) error {
 	return nil
 }
 
 // testValidator implements domain.TransitionValidator for tests.
 type testValidator struct{}
 
-func (v *testValidator) Apply(_ context.Context, current domain.Status, event domain.Event) (domain.Status, error) {
+func (v *testValidator) Apply(_ context.Context, tenant domain.Tenant, event domain.Event) (domain.Status, error) {
 	for _, t := range domain.Transitions {
-		if t.Event == event && t.Src == current {
+		if t.Event == event && t.Src == tenant.Status {
 			return t.Dst, nil
 		}
 	}
-	return "", &domain.TransitionError{Event: event, Current: current}
+	return "", &domain.TransitionError{Event: event, Current: tenant.Status}
 }
 
 // newTestServer creates a full-stack httptest.Server with SQLite in-memory.
-func newTestServer(t *testing.T) *httptest.Server {
+func newTestServer(t *testing.T, opts ...adapter.Option) *httptest.Server {
 	t.Helper()
 
 	repo, err := sqlite.New(":memory:")
@@ -49,11 +51,23 @@ func newTestServer(t *testing.T) *httptest.Server {
 	}
 	t.Cleanup(func() { repo.Close() })
 
-	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{})
+	return serve(t, repo, opts...)
+}
+
+// serve exposes the tenant API backed by repo on an httptest.Server.
+func serve(t *testing.T, repo domain.TenantRepository, opts ...adapter.Option) *httptest.Server {
+	t.Helper()
+
+	return serveService(t, app.NewTenantService(repo, &noopPublisher{}, &testValidator{}), opts...)
+}
+
+// serveService exposes the tenant API backed by svc on an httptest.Server.
+func serveService(t *testing.T, svc *app.TenantService, opts ...adapter.Option) *httptest.Server {
+	t.Helper()
 
 	router := chi.NewMux()
 	api := humachi.New(router, huma.DefaultConfig("tenantiq", "0.1.0"))
-	adapter.Register(api, svc)
+	adapter.Register(api, svc, opts...)
 
 	srv := httptest.NewServer(router)
 	t.Cleanup(srv.Close)
@@ -113,8 +127,8 @@ func TestCreate(t *testing.T) {
 	srv := newTestServer(t)
 	tenant := mustCreateTenant(t, srv, "Acme Corp", "acme-corp", "pro")
 
-	if tenant.ID == "" {
-		t.Error("ID should not be empty")
+	if !strings.HasPrefix(tenant.ID, "ten_") {
+		t.Errorf("ID = %q, want ten_ prefix", tenant.ID)
 	}
 	if tenant.Name != "Acme Corp" {
 		t.Errorf("Name = %q, want %q", tenant.Name, "Acme Corp")
@@ -176,6 +190,44 @@ func TestCreate_InvalidSlug(t *testing.T) {
 	}
 }
 
+func TestCreate_InvalidSlugExplainsWhy(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants", `{"name":"Zürich","slug":"Zürich"}`)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+	var problem struct {
+		Detail string `json:"detail"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !strings.Contains(problem.Detail, "uppercase") || !strings.Contains(problem.Detail, `"zurich"`) {
+		t.Errorf("detail = %q, want the reason and a suggestion", problem.Detail)
+	}
+}
+
+func TestCreate_DerivesSlugFromName(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants", `{"name":"Café Zürich"}`)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var tenant adapter.TenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tenant); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if tenant.Slug != "cafe-zurich" {
+		t.Errorf("slug = %q, want cafe-zurich", tenant.Slug)
+	}
+}
+
 func TestCreate_MissingName(t *testing.T) {
 	srv := newTestServer(t)
 
@@ -224,6 +276,158 @@ func TestGet_NotFound(t *testing.T) {
 	}
 }
 
+func TestGetBySlug(t *testing.T) {
+	srv := newTestServer(t)
+	created := mustCreateTenant(t, srv, "Acme", "acme", "pro")
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/slug/acme", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var tenant adapter.TenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tenant); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if tenant.ID != created.ID {
+		t.Errorf("ID = %q, want %q", tenant.ID, created.ID)
+	}
+}
+
+func TestGetBySlug_NotFound(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/slug/nonexistent", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+// --- Update ---
+
+func TestUpdate_References(t *testing.T) {
+	srv := newTestServer(t)
+	created := mustCreateTenant(t, srv, "Acme", "acme", "pro")
+
+	body := `{"pr_url":"https://github.com/org/infra/pull/42","git_branch":"tenant/acme","external_refs":{"argocd_app":"acme-prod"}}`
+	resp := doRequest(t, http.MethodPatch, srv.URL+"/api/v1/tenants/"+created.ID, body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	resp = doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/"+created.ID, "")
+	defer resp.Body.Close()
+
+	var tenant adapter.TenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tenant); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if tenant.PRURL != "https://github.com/org/infra/pull/42" {
+		t.Errorf("PRURL = %q", tenant.PRURL)
+	}
+	if tenant.GitBranch != "tenant/acme" {
+		t.Errorf("GitBranch = %q", tenant.GitBranch)
+	}
+	if tenant.ExternalRefs["argocd_app"] != "acme-prod" {
+		t.Errorf("ExternalRefs = %v", tenant.ExternalRefs)
+	}
+}
+
+func TestUpdate_UpdatedAt(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "free")
+	tenant.CreatedAt = time.Now().UTC().Add(-time.Hour)
+	tenant.UpdatedAt = tenant.CreatedAt
+	if err := repo.Create(context.Background(), tenant); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	srv := serve(t, repo)
+
+	resp := doRequest(t, http.MethodPatch, srv.URL+"/api/v1/tenants/ten_1", `{"git_branch":"tenant/acme"}`)
+	var patched adapter.TenantResponse
+	err = json.NewDecoder(resp.Body).Decode(&patched)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	resp = doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/ten_1", "")
+	var stored adapter.TenantResponse
+	err = json.NewDecoder(resp.Body).Decode(&stored)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if patched.UpdatedAt == patched.CreatedAt {
+		t.Errorf("updated_at = %s, want the time of the update", patched.UpdatedAt)
+	}
+	if patched.UpdatedAt != stored.UpdatedAt {
+		t.Errorf("PATCH updated_at = %s, stored %s", patched.UpdatedAt, stored.UpdatedAt)
+	}
+}
+
+func TestUpdate_Trial(t *testing.T) {
+	srv := newTestServer(t)
+	created := mustCreateTenant(t, srv, "Acme", "acme", "pro")
+	url := srv.URL + "/api/v1/tenants/" + created.ID
+
+	patch := func(body string) (int, adapter.TenantResponse) {
+		t.Helper()
+		resp := doRequest(t, http.MethodPatch, url, body)
+		defer resp.Body.Close()
+		var tenant adapter.TenantResponse
+		if resp.StatusCode == http.StatusOK {
+			if err := json.NewDecoder(resp.Body).Decode(&tenant); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+		}
+		return resp.StatusCode, tenant
+	}
+
+	if status, tenant := patch(`{"trial_ends_at":"2026-03-01T13:00:00+01:00"}`); status != http.StatusOK || tenant.TrialEndsAt != "2026-03-01T12:00:00Z" {
+		t.Errorf("set trial: status = %d, trial_ends_at = %q; want 200 and the time in UTC", status, tenant.TrialEndsAt)
+	}
+	if status, _ := patch(`{"trial_ends_at":"next week"}`); status != http.StatusUnprocessableEntity {
+		t.Errorf("invalid time: status = %d, want %d", status, http.StatusUnprocessableEntity)
+	}
+	if status, tenant := patch(`{"trial_ends_at":""}`); status != http.StatusOK || tenant.TrialEndsAt != "" {
+		t.Errorf("end trial: status = %d, trial_ends_at = %q; want 200 and no trial", status, tenant.TrialEndsAt)
+	}
+}
+
+func TestUpdate_NotFound(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp := doRequest(t, http.MethodPatch, srv.URL+"/api/v1/tenants/nonexistent", `{"git_branch":"main"}`)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestGet_MismatchedIDPrefix(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/prj_0123abcd", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+}
+
 // --- List ---
 
 func TestList(t *testing.T) {
@@ -238,13 +442,62 @@ func TestList(t *testing.T) {
 		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
 	}
 
-	var tenants []adapter.TenantResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tenants); err != nil {
+	var page adapter.TenantListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if len(page.Items) != 2 {
+		t.Errorf("got %d tenants, want 2", len(page.Items))
+	}
+	if page.Total != 2 {
+		t.Errorf("Total = %d, want 2", page.Total)
+	}
+}
+
+func TestList_PaginationMetadata(t *testing.T) {
+	srv := newTestServer(t)
+	mustCreateTenant(t, srv, "Acme", "acme", "free")
+	mustCreateTenant(t, srv, "Globex", "globex", "pro")
+	mustCreateTenant(t, srv, "Initech", "initech", "pro")
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants?limit=2&offset=1", "")
+	defer resp.Body.Close()
+
+	var page adapter.TenantListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
 		t.Fatalf("decode: %v", err)
 	}
 
-	if len(tenants) != 2 {
-		t.Errorf("got %d tenants, want 2", len(tenants))
+	if len(page.Items) != 2 {
+		t.Errorf("got %d items, want 2", len(page.Items))
+	}
+	if page.Total != 3 {
+		t.Errorf("Total = %d, want 3", page.Total)
+	}
+	if page.Limit != 2 || page.Offset != 1 {
+		t.Errorf("Limit/Offset = %d/%d, want 2/1", page.Limit, page.Offset)
+	}
+}
+
+func TestList_TotalOfShortPages(t *testing.T) {
+	srv := newTestServer(t)
+	mustCreateTenant(t, srv, "Acme", "acme", "free")
+	mustCreateTenant(t, srv, "Globex", "globex", "pro")
+	mustCreateTenant(t, srv, "Initech", "initech", "pro")
+
+	// The last page and a page past the end both report the full total.
+	for query, want := range map[string]int{"limit=2&offset=2": 1, "limit=2&offset=5": 0} {
+		resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants?"+query, "")
+		var page adapter.TenantListResponse
+		err := json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(page.Items) != want || page.Total != 3 {
+			t.Errorf("%s: got %d items of %d, want %d of 3", query, len(page.Items), page.Total, want)
+		}
 	}
 }
 
@@ -261,16 +514,121 @@ func TestList_FilterByStatus(t *testing.T) {
 	resp = doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants?status=active", "")
 	defer resp.Body.Close()
 
-	var tenants []adapter.TenantResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tenants); err != nil {
+	var page adapter.TenantListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
 		t.Fatalf("decode: %v", err)
 	}
 
-	if len(tenants) != 1 {
-		t.Fatalf("got %d tenants, want 1", len(tenants))
+	if len(page.Items) != 1 {
+		t.Fatalf("got %d tenants, want 1", len(page.Items))
 	}
-	if tenants[0].Status != "active" {
-		t.Errorf("Status = %q, want %q", tenants[0].Status, "active")
+	if page.Items[0].Status != "active" {
+		t.Errorf("Status = %q, want %q", page.Items[0].Status, "active")
+	}
+	if page.Total != 1 {
+		t.Errorf("Total = %d, want 1", page.Total)
+	}
+}
+
+func TestList_FilterByMultipleStatusesAndPlan(t *testing.T) {
+	srv := newTestServer(t)
+	acme := mustCreateTenant(t, srv, "Acme", "acme", "pro")
+	globex := mustCreateTenant(t, srv, "Globex", "globex", "pro")
+	mustCreateTenant(t, srv, "Initech", "initech", "pro")
+	free := mustCreateTenant(t, srv, "Hooli", "hooli", "free")
+
+	// acme → active, globex → suspended, initech stays creating, hooli → active (free).
+	for _, step := range []struct{ id, event string }{
+		{acme.ID, "provision_complete"},
+		{globex.ID, "provision_complete"},
+		{globex.ID, "suspend"},
+		{free.ID, "provision_complete"},
+	} {
+		resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/"+step.id+"/events", fmt.Sprintf(`{"event":%q}`, step.event))
+		resp.Body.Close()
+	}
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants?status=active,suspended&plan=pro", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var page adapter.TenantListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if page.Total != 2 {
+		t.Fatalf("Total = %d, want 2", page.Total)
+	}
+	for _, tenant := range page.Items {
+		if tenant.Slug != "acme" && tenant.Slug != "globex" {
+			t.Errorf("unexpected tenant %q in result", tenant.Slug)
+		}
+	}
+}
+
+func TestList_FilterByCreatedAt(t *testing.T) {
+	srv := newTestServer(t)
+	mustCreateTenant(t, srv, "Acme", "acme", "free")
+
+	cases := []struct {
+		query string
+		want  int
+	}{
+		{"created_after=2000-01-01T00:00:00Z", 1},
+		{"created_before=2000-01-01T00:00:00Z", 0},
+		{"created_after=2000-01-01T00:00:00Z&created_before=2999-01-01T00:00:00Z", 1},
+	}
+
+	for _, tc := range cases {
+		resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants?"+tc.query, "")
+
+		var page adapter.TenantListResponse
+		err := json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("%s: decode: %v", tc.query, err)
+		}
+		if page.Total != tc.want {
+			t.Errorf("%s: Total = %d, want %d", tc.query, page.Total, tc.want)
+		}
+	}
+}
+
+func TestList_InvalidCreatedAt(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants?created_after=yesterday", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestList_InvalidStatus(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants?status=active,bogus", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestList_InvalidPagination(t *testing.T) {
+	srv := newTestServer(t)
+
+	for _, query := range []string{"limit=-1", "limit=0", "limit=0&offset=10", "limit=1001", "offset=-1"} {
+		resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants?"+query, "")
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnprocessableEntity {
+			t.Errorf("%s: status = %d, want %d", query, resp.StatusCode, http.StatusUnprocessableEntity)
+		}
 	}
 }
 
@@ -333,3 +691,493 @@ func TestTransition_InvalidEventValue(t *testing.T) {
 		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
 	}
 }
+
+// --- Delete ---
+
+func TestDelete(t *testing.T) {
+	srv := newTestServer(t)
+	created := mustCreateTenant(t, srv, "Acme", "acme", "free")
+
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/"+created.ID+"/events", `{"event":"provision_complete"}`)
+	resp.Body.Close()
+
+	resp = doRequest(t, http.MethodDelete, srv.URL+"/api/v1/tenants/"+created.ID, "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusAccepted)
+	}
+
+	var tenant adapter.TenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tenant); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if tenant.Status != "deleting" {
+		t.Errorf("Status = %q, want %q", tenant.Status, "deleting")
+	}
+}
+
+func TestDelete_InvalidState(t *testing.T) {
+	srv := newTestServer(t)
+	created := mustCreateTenant(t, srv, "Acme", "acme", "free")
+
+	// "delete" is not valid from "creating" state.
+	resp := doRequest(t, http.MethodDelete, srv.URL+"/api/v1/tenants/"+created.ID, "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestDelete_PolicyRequiresApproval(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	srv := serveService(t, app.NewTenantService(repo, &noopPublisher{}, &testValidator{},
+		app.WithTransitionPolicies(domain.TransitionPolicies{
+			{Plan: "enterprise", Event: domain.EventDelete, Effect: domain.PolicyRequireApproval, Reason: "ask the account owner"},
+		}),
+	))
+	created := mustCreateTenant(t, srv, "Acme", "acme", "enterprise")
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/"+created.ID+"/events", `{"event":"provision_complete"}`)
+	resp.Body.Close()
+
+	deleteAs := func(approver string) *http.Response {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, srv.URL+"/api/v1/tenants/"+created.ID, nil)
+		if err != nil {
+			t.Fatalf("creating request: %v", err)
+		}
+		req.Header.Set("X-Actor", "alice")
+		if approver != "" {
+			req.Header.Set("X-Approved-By", approver)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("DELETE failed: %v", err)
+		}
+		return resp
+	}
+
+	resp = deleteAs("")
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+	if !strings.Contains(string(body), "ask the account owner") {
+		t.Errorf("body = %s, want the policy reason", body)
+	}
+
+	resp = deleteAs("bob")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("approved: status = %d, want %d", resp.StatusCode, http.StatusAccepted)
+	}
+}
+
+func TestDelete_NotFound(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp := doRequest(t, http.MethodDelete, srv.URL+"/api/v1/tenants/nonexistent", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+// --- Apply Spec ---
+
+func applySpec(t *testing.T, srv *httptest.Server, slug, body string) (int, adapter.ApplySpecResponse) {
+	t.Helper()
+
+	resp := doRequest(t, http.MethodPut, srv.URL+"/api/v1/tenants/"+slug+"/spec", body)
+	defer resp.Body.Close()
+
+	var out adapter.ApplySpecResponse
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+	}
+	return resp.StatusCode, out
+}
+
+func TestApplySpec_CreatesAndConverges(t *testing.T) {
+	srv := newTestServer(t)
+
+	status, out := applySpec(t, srv, "acme", `{"name":"Acme","plan":"pro","status":"suspended"}`)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want %d", status, http.StatusOK)
+	}
+	if !out.Created {
+		t.Error("Created = false, want true")
+	}
+	if out.Tenant.Status != "suspended" {
+		t.Errorf("Status = %q, want %q", out.Tenant.Status, "suspended")
+	}
+	if out.Tenant.Plan != "pro" {
+		t.Errorf("Plan = %q, want %q", out.Tenant.Plan, "pro")
+	}
+}
+
+func TestApplySpec_Idempotent(t *testing.T) {
+	srv := newTestServer(t)
+	body := `{"name":"Acme","plan":"pro","status":"active"}`
+
+	if status, _ := applySpec(t, srv, "acme", body); status != http.StatusOK {
+		t.Fatalf("first apply: status = %d, want %d", status, http.StatusOK)
+	}
+
+	status, out := applySpec(t, srv, "acme", body)
+	if status != http.StatusOK {
+		t.Fatalf("second apply: status = %d, want %d", status, http.StatusOK)
+	}
+	if out.Created {
+		t.Error("Created = true on second apply, want false")
+	}
+	if len(out.Changes) != 0 {
+		t.Errorf("Changes = %v, want none", out.Changes)
+	}
+}
+
+func TestApplySpec_UpdatesDrift(t *testing.T) {
+	srv := newTestServer(t)
+	mustCreateTenant(t, srv, "Acme", "acme", "free")
+
+	status, out := applySpec(t, srv, "acme", `{"name":"Acme Inc","plan":"enterprise"}`)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want %d", status, http.StatusOK)
+	}
+	if out.Tenant.Name != "Acme Inc" || out.Tenant.Plan != "enterprise" {
+		t.Errorf("tenant = %+v, want name/plan updated", out.Tenant)
+	}
+	if out.Tenant.Status != "creating" {
+		t.Errorf("Status = %q, want unchanged %q", out.Tenant.Status, "creating")
+	}
+}
+
+func TestApplySpec_KeepsPlanWhenOmitted(t *testing.T) {
+	srv := newTestServer(t)
+	mustCreateTenant(t, srv, "Acme", "acme", "pro")
+
+	status, out := applySpec(t, srv, "acme", `{"name":"Acme","metadata":{"crm_id":"42"},"features":{"sso":true}}`)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want %d", status, http.StatusOK)
+	}
+	if out.Tenant.Plan != "pro" {
+		t.Errorf("Plan = %q, want %q left untouched", out.Tenant.Plan, "pro")
+	}
+	if out.Tenant.Metadata["crm_id"] != "42" || out.Tenant.Metadata["feature.sso"] != "true" {
+		t.Errorf("Metadata = %v, want crm_id and feature.sso set", out.Tenant.Metadata)
+	}
+}
+
+func TestApplySpec_UnreachableStatus(t *testing.T) {
+	srv := newTestServer(t)
+
+	if status, _ := applySpec(t, srv, "acme", `{"name":"Acme","status":"deleted"}`); status != http.StatusOK {
+		t.Fatalf("status = %d, want %d", status, http.StatusOK)
+	}
+
+	status, _ := applySpec(t, srv, "acme", `{"name":"Acme","status":"active"}`)
+	if status != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", status, http.StatusUnprocessableEntity)
+	}
+}
+
+// newBrokenServer serves the API from a closed database so every query fails
+// with an unmapped error.
+func newBrokenServer(t *testing.T, opts ...adapter.Option) *httptest.Server {
+	t.Helper()
+
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	repo.Close()
+
+	return serve(t, repo, opts...)
+}
+
+func TestInternalError_HidesCause(t *testing.T) {
+	srv := newBrokenServer(t)
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusInternalServerError)
+	}
+
+	var model huma.ErrorModel
+	if err := json.NewDecoder(resp.Body).Decode(&model); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(model.Errors) != 0 {
+		t.Errorf("errors = %v, want none outside debug mode", model.Errors)
+	}
+}
+
+func TestInternalError_DebugIncludesCauseChain(t *testing.T) {
+	srv := newBrokenServer(t, adapter.WithDebugErrors(true))
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusInternalServerError)
+	}
+
+	var model huma.ErrorModel
+	if err := json.NewDecoder(resp.Body).Decode(&model); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(model.Errors) < 2 {
+		t.Fatalf("got %d error details, want the wrapped chain", len(model.Errors))
+	}
+	if model.Errors[0].Location != "cause[0]" || !strings.Contains(model.Errors[0].Message, "listing tenants") {
+		t.Errorf("errors[0] = %+v, want outermost cause", model.Errors[0])
+	}
+	if model.Errors[1].Location != "cause[1]" || !strings.Contains(model.Errors[1].Message, "database is closed") {
+		t.Errorf("errors[1] = %+v, want wrapped cause", model.Errors[1])
+	}
+}
+
+func TestBatchCreate(t *testing.T) {
+	srv := newTestServer(t)
+	mustCreateTenant(t, srv, "Existing", "existing", "free")
+
+	body := `{"tenants": [
+		{"name": "Acme", "slug": "acme", "plan": "pro"},
+		{"name": "Existing", "slug": "existing"},
+		{"name": "Bad", "slug": "Bad Slug"},
+		{"name": "Globex", "slug": "globex"}
+	]}`
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants:batchCreate", body)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		t.Fatalf("status = %d, want %d: %s", resp.StatusCode, http.StatusOK, b)
+	}
+
+	var out struct {
+		Results []adapter.BatchCreateResult `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	want := []string{"created", "conflict", "invalid", "created"}
+	if len(out.Results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(out.Results), len(want))
+	}
+	for i, status := range want {
+		if out.Results[i].Status != status {
+			t.Errorf("results[%d].Status = %q, want %q", i, out.Results[i].Status, status)
+		}
+	}
+	if out.Results[0].Tenant == nil || out.Results[0].Tenant.Plan != "pro" {
+		t.Errorf("results[0].Tenant = %+v", out.Results[0].Tenant)
+	}
+	if out.Results[3].Tenant == nil || out.Results[3].Tenant.Plan != "free" {
+		t.Errorf("results[3].Tenant = %+v, want default plan", out.Results[3].Tenant)
+	}
+	if out.Results[1].Tenant != nil || out.Results[1].Error == "" {
+		t.Errorf("results[1] = %+v, want error without tenant", out.Results[1])
+	}
+}
+
+func TestBatchCreate_TooMany(t *testing.T) {
+	srv := newTestServer(t)
+
+	items := make([]string, 101)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"name": "T", "slug": "t-%d"}`, i)
+	}
+	body := `{"tenants": [` + strings.Join(items, ",") + `]}`
+
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants:batchCreate", body)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestBatchTransition_Guardrail(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	srv := serveService(t, app.NewTenantService(repo, &noopPublisher{}, &testValidator{},
+		app.WithGuardrail(domain.Guardrail{MaxDisruptedPercent: 10})))
+
+	var ids []string
+	for _, slug := range []string{"acme", "globex"} {
+		tenant := mustCreateTenant(t, srv, slug, slug, "free")
+		resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/"+tenant.ID+"/events", `{"event":"provision_complete"}`)
+		resp.Body.Close()
+		ids = append(ids, tenant.ID)
+	}
+	ids = append(ids, "ten_missing")
+
+	body := fmt.Sprintf(`{"ids":[%q,%q,%q],"event":"suspend"}`, ids[0], ids[1], ids[2])
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants:batchTransition", body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("status = %d, want %d past the guardrail", resp.StatusCode, http.StatusConflict)
+	}
+
+	body = fmt.Sprintf(`{"ids":[%q,%q,%q],"event":"suspend","force":true}`, ids[0], ids[1], ids[2])
+	resp = doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants:batchTransition", body)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		t.Fatalf("forced: status = %d, want %d: %s", resp.StatusCode, http.StatusOK, b)
+	}
+	var out struct {
+		Results []adapter.BatchTransitionResult `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := []string{"transitioned", "transitioned", "failed"}
+	if len(out.Results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(out.Results), len(want))
+	}
+	for i, status := range want {
+		if out.Results[i].Status != status {
+			t.Errorf("results[%d].Status = %q, want %q (%s)", i, out.Results[i].Status, status, out.Results[i].Error)
+		}
+	}
+	if out.Results[0].Tenant == nil || out.Results[0].Tenant.Status != "suspended" {
+		t.Errorf("results[0].Tenant = %+v, want suspended", out.Results[0].Tenant)
+	}
+}
+
+func BenchmarkList(b *testing.B) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		b.Fatalf("creating repo: %v", err)
+	}
+	b.Cleanup(func() { repo.Close() })
+
+	ctx := context.Background()
+	for i := range 500 {
+		slug := fmt.Sprintf("tenant-%d", i)
+		if err := repo.Create(ctx, domain.NewTenant("ten_"+slug, slug, slug, "pro")); err != nil {
+			b.Fatalf("Create: %v", err)
+		}
+	}
+
+	router := chi.NewMux()
+	api := humachi.New(router, huma.DefaultConfig("tenantiq", "0.1.0"))
+	adapter.Register(api, app.NewTenantService(repo, &noopPublisher{}, &testValidator{}))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tenants?limit=1000", nil)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			b.Fatalf("status = %d", rec.Code)
+		}
+	}
+}
+
+func TestCreate_Simulated(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	srv := serveService(t, app.NewTenantService(repo, &noopPublisher{}, &testValidator{},
+		app.WithSimulator(simulator.New())))
+
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants", `{"name":"Acme","simulated":true}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var tenant adapter.TenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tenant); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !tenant.Simulated || tenant.Status != "active" || tenant.ExternalRefs["dns"] != "acme.simulated.invalid" {
+		t.Errorf("tenant = %+v, want simulated, active and provisioned by the simulator", tenant)
+	}
+
+	mustCreateTenant(t, srv, "Real", "real", "free")
+	resp = doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants?simulated=true", "")
+	defer resp.Body.Close()
+	var list adapter.TenantListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if list.Total != 1 || list.Items[0].ID != tenant.ID {
+		t.Errorf("simulated tenants = %+v, want only %s", list.Items, tenant.ID)
+	}
+}
+
+func TestCreate_SimulatedNotConfigured(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants", `{"name":"Acme","simulated":true}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants", `{"name":"Acme","metadata":{"crm_id":"42","region":"eu"}}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var created adapter.TenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if created.Metadata["crm_id"] != "42" {
+		t.Errorf("Metadata = %v", created.Metadata)
+	}
+	mustCreateTenant(t, srv, "Globex", "globex", "pro")
+
+	// Merged like external references: an empty value removes the key.
+	resp = doRequest(t, http.MethodPatch, srv.URL+"/api/v1/tenants/"+created.ID, `{"metadata":{"region":"","owner":"ops"}}`)
+	defer resp.Body.Close()
+	var updated adapter.TenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&updated); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := map[string]string{"crm_id": "42", "owner": "ops"}
+	if len(updated.Metadata) != len(want) || updated.Metadata["crm_id"] != "42" || updated.Metadata["owner"] != "ops" {
+		t.Errorf("Metadata = %v, want %v", updated.Metadata, want)
+	}
+
+	resp = doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants?metadata.crm_id=42&metadata.owner=ops", "")
+	defer resp.Body.Close()
+	var page adapter.TenantListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if page.Total != 1 || len(page.Items) != 1 || page.Items[0].ID != created.ID {
+		t.Errorf("page = %+v, want only %s", page, created.ID)
+	}
+
+	resp = doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants", `{"name":"Initech","metadata":{"bad key":"x"}}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("invalid key: status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+}
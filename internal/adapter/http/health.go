@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// QueueThresholds bound the job backlog a ready instance may have.
+// A zero value disables the corresponding check.
+type QueueThresholds struct {
+	MaxAvailable int
+	MaxOldestAge time.Duration
+}
+
+// QueueHealth is the job backlog as seen by the readiness probe.
+type QueueHealth struct {
+	Available           int     `json:"available" doc:"Jobs ready to run but not yet picked up"`
+	OldestAgeSeconds    float64 `json:"oldest_age_seconds" doc:"How long the oldest available job has waited"`
+	MaxAvailable        int     `json:"max_available,omitempty" doc:"Backlog threshold (0 = unchecked)"`
+	MaxOldestAgeSeconds float64 `json:"max_oldest_age_seconds,omitempty" doc:"Age threshold (0 = unchecked)"`
+	Saturated           bool    `json:"saturated" doc:"Whether a threshold is exceeded"`
+}
+
+// ReadinessResponse reports whether the instance should receive traffic.
+type ReadinessResponse struct {
+	Status string       `json:"status" enum:"ok,degraded,unavailable" doc:"Overall readiness"`
+	Queue  *QueueHealth `json:"queue,omitempty" doc:"Job queue backlog"`
+	Error  string       `json:"error,omitempty" doc:"Why the checks could not run"`
+}
+
+type ReadinessOutput struct {
+	Status int
+	Body   ReadinessResponse
+}
+
+type LivenessOutput struct {
+	Body struct {
+		Status string `json:"status" doc:"Always ok while the process serves requests"`
+	}
+}
+
+// RegisterHealth adds the liveness (/healthz) and readiness (/readyz) probes.
+// Readiness degrades with 503 when the job backlog or the age of its oldest
+// job exceeds the thresholds, so autoscaling and alerting can react to
+// worker saturation.
+func RegisterHealth(api huma.API, queue domain.QueueMonitor, thresholds QueueThresholds) {
+	huma.Register(api, huma.Operation{
+		OperationID: "liveness",
+		Method:      http.MethodGet,
+		Path:        "/healthz",
+		Summary:     "Liveness probe",
+		Tags:        []string{"Health"},
+	}, func(_ context.Context, _ *struct{}) (*LivenessOutput, error) {
+		out := &LivenessOutput{}
+		out.Body.Status = "ok"
+		return out, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "readiness",
+		Method:      http.MethodGet,
+		Path:        "/readyz",
+		Summary:     "Readiness probe",
+		Description: "Returns 503 when the job queue is saturated or cannot be inspected.",
+		Tags:        []string{"Health"},
+	}, func(ctx context.Context, _ *struct{}) (*ReadinessOutput, error) {
+		stats, err := queue.Stats(ctx)
+		if err != nil {
+			return &ReadinessOutput{
+				Status: http.StatusServiceUnavailable,
+				Body:   ReadinessResponse{Status: "unavailable", Error: err.Error()},
+			}, nil
+		}
+
+		health := queueHealth(stats, thresholds)
+		out := &ReadinessOutput{
+			Status: http.StatusOK,
+			Body:   ReadinessResponse{Status: "ok", Queue: &health},
+		}
+		if health.Saturated {
+			out.Status = http.StatusServiceUnavailable
+			out.Body.Status = "degraded"
+		}
+		return out, nil
+	})
+}
+
+func queueHealth(stats domain.QueueStats, th QueueThresholds) QueueHealth {
+	return QueueHealth{
+		Available:           stats.Available,
+		OldestAgeSeconds:    stats.OldestAvailableAge.Seconds(),
+		MaxAvailable:        th.MaxAvailable,
+		MaxOldestAgeSeconds: th.MaxOldestAge.Seconds(),
+		Saturated: (th.MaxAvailable > 0 && stats.Available > th.MaxAvailable) ||
+			(th.MaxOldestAge > 0 && stats.OldestAvailableAge > th.MaxOldestAge),
+	}
+}
@@ -0,0 +1,120 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+	"github.com/go-chi/chi/v5"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// stubQueue is a QueueMonitor returning fixed stats.
+type stubQueue struct {
+	stats domain.QueueStats
+	loads []domain.QueueLoad
+	err   error
+}
+
+func (q *stubQueue) Stats(_ context.Context) (domain.QueueStats, error) {
+	return q.stats, q.err
+}
+
+func (q *stubQueue) Loads(_ context.Context) ([]domain.QueueLoad, error) {
+	return q.loads, q.err
+}
+
+func newHealthServer(t *testing.T, queue domain.QueueMonitor) *httptest.Server {
+	t.Helper()
+
+	router := chi.NewMux()
+	api := humachi.New(router, huma.DefaultConfig("tenantiq", "0.1.0"))
+	adapter.RegisterHealth(api, queue, adapter.QueueThresholds{
+		MaxAvailable: 100,
+		MaxOldestAge: 5 * time.Minute,
+	})
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func getReadiness(t *testing.T, srv *httptest.Server) (int, adapter.ReadinessResponse) {
+	t.Helper()
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/readyz", "")
+	defer resp.Body.Close()
+
+	var body adapter.ReadinessResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestLiveness(t *testing.T) {
+	srv := newHealthServer(t, &stubQueue{})
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/healthz", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestReadiness_OK(t *testing.T) {
+	srv := newHealthServer(t, &stubQueue{stats: domain.QueueStats{Available: 3, OldestAvailableAge: time.Second}})
+
+	status, body := getReadiness(t, srv)
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+	if body.Status != "ok" || body.Queue == nil || body.Queue.Available != 3 || body.Queue.Saturated {
+		t.Errorf("body = %+v, queue = %+v", body, body.Queue)
+	}
+}
+
+func TestReadiness_DegradedOnBacklog(t *testing.T) {
+	srv := newHealthServer(t, &stubQueue{stats: domain.QueueStats{Available: 101}})
+
+	status, body := getReadiness(t, srv)
+	if status != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", status, http.StatusServiceUnavailable)
+	}
+	if body.Status != "degraded" || !body.Queue.Saturated {
+		t.Errorf("body = %+v, want degraded", body)
+	}
+}
+
+func TestReadiness_DegradedOnOldJob(t *testing.T) {
+	srv := newHealthServer(t, &stubQueue{stats: domain.QueueStats{Available: 1, OldestAvailableAge: 6 * time.Minute}})
+
+	status, body := getReadiness(t, srv)
+	if status != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", status, http.StatusServiceUnavailable)
+	}
+	if body.Queue.OldestAgeSeconds != 360 {
+		t.Errorf("OldestAgeSeconds = %v, want 360", body.Queue.OldestAgeSeconds)
+	}
+}
+
+func TestReadiness_UnavailableOnError(t *testing.T) {
+	srv := newHealthServer(t, &stubQueue{err: errors.New("database is locked")})
+
+	status, body := getReadiness(t, srv)
+	if status != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", status, http.StatusServiceUnavailable)
+	}
+	if body.Status != "unavailable" || body.Error == "" {
+		t.Errorf("body = %+v, want unavailable with error", body)
+	}
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// actorHeader names the caller recorded in the status history. Until the
+// API authenticates callers it is taken on trust.
+const actorHeader = "X-Actor"
+
+// approverHeader names who approved a change for transitions whose policy
+// requires approval. Like actorHeader it is taken on trust.
+const approverHeader = "X-Approved-By"
+
+// priorityHeader sets the priority of the jobs a request queues (high,
+// normal or low), overriding the priority of the tenant's plan. Other
+// values are ignored.
+const priorityHeader = "X-Priority"
+
+// defaultActor attributes API changes made without an actorHeader.
+const defaultActor = "api"
+
+// StatusChangeResponse is one lifecycle transition of a tenant.
+type StatusChangeResponse struct {
+	From  string `json:"from" doc:"Status before the transition"`
+	To    string `json:"to" doc:"Status after the transition"`
+	Event string `json:"event" doc:"Lifecycle event that caused it"`
+	Actor string `json:"actor" doc:"Who triggered it"`
+	At    string `json:"at" doc:"When it happened (ISO 8601)"`
+}
+
+type GetHistoryInput struct {
+	ID string `path:"id" doc:"Tenant ID"`
+}
+
+type GetHistoryOutput struct {
+	Body struct {
+		Items []StatusChangeResponse `json:"items" doc:"Transitions, oldest first"`
+	}
+}
+
+// callerMiddleware attributes the request's changes to the X-Actor caller
+// and to the request ID assigned by chi's RequestID middleware, for the
+// status history and the audit log, and records the X-Approved-By approver,
+// the X-Priority of the request's jobs and the common name of the client
+// certificate, if any.
+func callerMiddleware(ctx huma.Context, next func(huma.Context)) {
+	actor := ctx.Header(actorHeader)
+	if actor == "" {
+		actor = defaultActor
+	}
+	c := domain.WithActor(ctx.Context(), actor)
+	if id := middleware.GetReqID(c); id != "" {
+		c = domain.WithRequestID(c, id)
+	}
+	if approver := ctx.Header(approverHeader); approver != "" {
+		c = domain.WithApprover(c, approver)
+	}
+	if p, err := domain.ParsePriority(ctx.Header(priorityHeader)); err == nil {
+		c = domain.WithPriority(c, p)
+	}
+	// Verified by the server when it requires client certificates.
+	if state := ctx.TLS(); state != nil && len(state.PeerCertificates) > 0 {
+		c = domain.WithClientCN(c, state.PeerCertificates[0].Subject.CommonName)
+	}
+	next(huma.WithContext(ctx, c))
+}
+
+func registerHistory(api huma.API, svc *app.TenantService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-tenant-history",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/tenants/{id}/history",
+		Summary:     "Get a tenant's status history",
+		Description: "Lists every lifecycle transition with the event and actor that caused it.",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *GetHistoryInput) (*GetHistoryOutput, error) {
+		changes, err := svc.History(ctx, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+
+		out := &GetHistoryOutput{}
+		out.Body.Items = make([]StatusChangeResponse, len(changes))
+		for i, c := range changes {
+			out.Body.Items[i] = StatusChangeResponse{
+				From:  string(c.From),
+				To:    string(c.To),
+				Event: string(c.Event),
+				Actor: c.Actor,
+				At:    c.At.Format(time.RFC3339),
+			}
+		}
+		return out, nil
+	})
+}
@@ -0,0 +1,194 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func newHistoryTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{},
+		app.WithStatusHistory(sqlite.NewStatusHistoryRepository(repo.DB())),
+	)
+	return serveService(t, svc)
+}
+
+// triggerEventAs posts a lifecycle event on behalf of actor ("" for none).
+func triggerEventAs(t *testing.T, srv *httptest.Server, id, event, actor string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+		srv.URL+"/api/v1/tenants/"+id+"/events", strings.NewReader(`{"event":"`+event+`"}`))
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if actor != "" {
+		req.Header.Set("X-Actor", actor)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST events failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("event %s: status = %d, want %d", event, resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	srv := newHistoryTestServer(t)
+	tenant := mustCreateTenant(t, srv, "Acme", "acme", "pro")
+
+	triggerEventAs(t, srv, tenant.ID, "provision_complete", "")
+	triggerEventAs(t, srv, tenant.ID, "suspend", "alice@example.com")
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/"+tenant.ID+"/history", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var body struct {
+		Items []adapter.StatusChangeResponse `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Items) != 2 {
+		t.Fatalf("got %d items, want 2", len(body.Items))
+	}
+	if got := body.Items[0]; got.From != "creating" || got.To != "active" || got.Actor != "api" {
+		t.Errorf("first item = %+v", got)
+	}
+	if got := body.Items[1]; got.Event != "suspend" || got.To != "suspended" || got.Actor != "alice@example.com" || got.At == "" {
+		t.Errorf("second item = %+v", got)
+	}
+}
+
+func TestHistory_NotFound(t *testing.T) {
+	srv := newHistoryTestServer(t)
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/ten_missing/history", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+// recordingAudit keeps audit entries in memory.
+type recordingAudit struct {
+	entries []domain.AuditEntry
+}
+
+func (a *recordingAudit) Log(_ context.Context, e domain.AuditEntry) error {
+	a.entries = append(a.entries, e)
+	return nil
+}
+
+func TestAudit_AttributesActorAndRequestID(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	audit := &recordingAudit{}
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{}, app.WithAuditLogger(audit))
+
+	router := chi.NewMux()
+	router.Use(middleware.RequestID)
+	adapter.Register(humachi.New(router, huma.DefaultConfig("tenantiq", "0.1.0")), svc)
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL+"/api/v1/tenants",
+		strings.NewReader(`{"name":"Acme","slug":"acme"}`))
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Actor", "alice")
+	req.Header.Set(middleware.RequestIDHeader, "req-42")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(audit.entries) != 1 {
+		t.Fatalf("got %d audit entries, want 1", len(audit.entries))
+	}
+	if e := audit.entries[0]; e.Action != domain.AuditCreate || e.Actor != "alice" || e.RequestID != "req-42" {
+		t.Errorf("entry = %+v, want create by alice in req-42", e)
+	}
+}
+
+func TestGetTenant_AsOf(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	auditLog := sqlite.NewAuditLog(repo.DB())
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{},
+		app.WithAuditLogger(auditLog), app.WithAuditReader(auditLog))
+	srv := serveService(t, svc)
+	created := mustCreateTenant(t, srv, "Acme", "acme", "free")
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/"+created.ID+"?as_of=2000-01-01T00:00:00Z", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("before creation: status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+
+	asOf := time.Now().UTC().Add(time.Minute).Format(time.RFC3339)
+	resp = doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/"+created.ID+"?as_of="+asOf, "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var got adapter.TenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.ID != created.ID || got.Status != "creating" {
+		t.Errorf("tenant = %+v, want %s as created", got, created.ID)
+	}
+}
+
+func TestGetTenant_AsOfRequiresAuditTrail(t *testing.T) {
+	srv := newTestServer(t)
+	created := mustCreateTenant(t, srv, "Acme", "acme", "free")
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/"+created.ID+"?as_of=2024-06-01T00:00:00Z", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
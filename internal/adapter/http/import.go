@@ -0,0 +1,67 @@
+package http
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+// WithImports exposes POST /api/v1/tenants:import to the callers presenting
+// key as a bearer token. Imports keep IDs and timestamps from other systems,
+// so the key must only be given to administrators.
+func WithImports(key string) Option {
+	return func(o *options) { o.importKey = key }
+}
+
+// ImportItem is one tenant of an import, with its original identity.
+type ImportItem struct {
+	BatchCreateItem
+	ID        string    `json:"id" minLength:"1" maxLength:"64" doc:"Original tenant ID (letters, digits, '_' and '-'); may lack the tenant ID prefix"`
+	CreatedAt time.Time `json:"created_at,omitzero" doc:"Original creation time (RFC 3339), not in the future; defaults to now"`
+	UpdatedAt time.Time `json:"updated_at,omitzero" doc:"Original last update time (RFC 3339), not before created_at; defaults to created_at"`
+}
+
+type ImportTenantsInput struct {
+	Authorization string `header:"Authorization" doc:"Bearer followed by the import key"`
+	Body          struct {
+		Tenants []ImportItem `json:"tenants" minItems:"1" maxItems:"100" doc:"Tenants to import"`
+	}
+}
+
+func registerImports(api huma.API, svc *app.TenantService, key string, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "import-tenants",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/tenants:import",
+		Summary:     "Import tenants with their original IDs and timestamps",
+		Description: "For migrations from legacy systems: like batch creation, but each tenant keeps the ID and " +
+			"timestamps it had there. IDs already in use are reported as conflicts. Requires the import key " +
+			"as a bearer token.",
+		Tags: []string{"Tenants"},
+	}, func(ctx context.Context, input *ImportTenantsInput) (*BatchCreateTenantsOutput, error) {
+		if subtle.ConstantTimeCompare([]byte(input.Authorization), []byte("Bearer "+key)) != 1 {
+			return nil, huma.Error401Unauthorized("a valid import key is required")
+		}
+
+		items := make([]app.ImportItem, len(input.Body.Tenants))
+		for i, item := range input.Body.Tenants {
+			items[i] = app.ImportItem{
+				BatchCreateItem: app.BatchCreateItem{Name: item.Name, Slug: item.Slug, Plan: item.Plan, Metadata: item.Metadata, Region: item.Region},
+				ID:              item.ID,
+				CreatedAt:       item.CreatedAt,
+				UpdatedAt:       item.UpdatedAt,
+			}
+		}
+
+		results, err := svc.Import(ctx, items)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return toBatchCreateOutput(results), nil
+	})
+}
@@ -0,0 +1,84 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+)
+
+const importKey = "0123456789abcdef0123456789abcdef"
+
+func postImport(t *testing.T, srv *httptest.Server, body, key string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+		srv.URL+"/api/v1/tenants:import", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST import: %v", err)
+	}
+	return resp
+}
+
+func TestImport(t *testing.T) {
+	srv := newTestServer(t, adapter.WithImports(importKey))
+	body := `{"tenants":[
+		{"id":"legacy-42","name":"Acme","plan":"pro","created_at":"2019-04-01T09:30:00Z"},
+		{"id":"legacy-42","name":"Globex"}
+	]}`
+
+	for _, key := range []string{"", "wrong"} {
+		resp := postImport(t, srv, body, key)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("key %q: status = %d, want %d", key, resp.StatusCode, http.StatusUnauthorized)
+		}
+	}
+
+	resp := postImport(t, srv, body, importKey)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var out struct {
+		Results []adapter.BatchCreateResult `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(out.Results) != 2 || out.Results[0].Status != "created" || out.Results[1].Status != "conflict" {
+		t.Fatalf("results = %+v, want created then conflict", out.Results)
+	}
+
+	resp = doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/legacy-42", "")
+	defer resp.Body.Close()
+	var tenant adapter.TenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tenant); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if tenant.Name != "Acme" || tenant.CreatedAt != "2019-04-01T09:30:00Z" || tenant.UpdatedAt != tenant.CreatedAt {
+		t.Errorf("tenant = %+v, want Acme created and updated at 2019-04-01T09:30:00Z", tenant)
+	}
+}
+
+func TestImport_DisabledWithoutKey(t *testing.T) {
+	srv := newTestServer(t)
+	resp := postImport(t, srv, `{"tenants":[{"id":"legacy-42","name":"Acme"}]}`, importKey)
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		t.Error("import succeeded without an import key configured")
+	}
+}
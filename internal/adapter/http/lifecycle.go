@@ -0,0 +1,40 @@
+package http
+
+import (
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// lifecycleStatus is a tenant status in API inputs. Its schema lists the
+// statuses of domain.Transitions as they are when the operation is
+// registered, so statuses added by a lifecycle file are accepted and
+// documented.
+type lifecycleStatus string
+
+func (lifecycleStatus) Schema(huma.Registry) *huma.Schema {
+	return &huma.Schema{Type: huma.TypeString, Enum: enumOf(domain.Statuses())}
+}
+
+// lifecycleEvent is a lifecycle event in API inputs, listed like
+// lifecycleStatus.
+type lifecycleEvent string
+
+func (lifecycleEvent) Schema(huma.Registry) *huma.Schema {
+	return &huma.Schema{Type: huma.TypeString, Enum: enumOf(domain.Events())}
+}
+
+// enumOf converts values to the enum of a schema.
+func enumOf[T ~string](values []T) []any {
+	enum := make([]any, len(values))
+	for i, v := range values {
+		enum[i] = string(v)
+	}
+	return enum
+}
+
+// Compile-time checks: the types provide their own schemas.
+var (
+	_ huma.SchemaProvider = lifecycleStatus("")
+	_ huma.SchemaProvider = lifecycleEvent("")
+)
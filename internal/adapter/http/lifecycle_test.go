@@ -0,0 +1,87 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestCustomLifecycle(t *testing.T) {
+	builtin := domain.Transitions
+	t.Cleanup(func() { domain.Transitions = builtin })
+	onboarding := domain.Status("onboarding")
+	domain.Transitions = append(slices.Clone(builtin),
+		domain.Transition{Event: "provision_start", Src: domain.StatusCreating, Dst: onboarding},
+		domain.Transition{Event: domain.EventProvisionComplete, Src: onboarding, Dst: domain.StatusActive},
+	)
+
+	srv := newTestServer(t)
+	created := mustCreateTenant(t, srv, "Acme", "acme", "free")
+	mustCreateTenant(t, srv, "Globex", "globex", "pro")
+
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/"+created.ID+"/events", `{"event":"provision_start"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("custom event = %d, want 200", resp.StatusCode)
+	}
+
+	resp = doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants?status=onboarding", "")
+	var page adapter.TenantListResponse
+	err := json.NewDecoder(resp.Body).Decode(&page)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Status != "onboarding" {
+		t.Errorf("onboarding tenants = %+v, want Acme", page.Items)
+	}
+
+	resp = doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants?status=archived", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status outside the lifecycle = %d, want 422", resp.StatusCode)
+	}
+	resp = doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/"+created.ID+"/events", `{"event":"archive"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("event outside the lifecycle = %d, want 422", resp.StatusCode)
+	}
+}
+
+// heldValidator refuses suspending tenants as a guard would.
+type heldValidator struct{ testValidator }
+
+func (v *heldValidator) Apply(ctx context.Context, tenant domain.Tenant, event domain.Event) (domain.Status, error) {
+	if event == domain.EventSuspend {
+		return "", &domain.GuardError{Guard: "billing_current", Event: event, Current: tenant.Status, Reason: "invoice in_1 is unpaid"}
+	}
+	return v.testValidator.Apply(ctx, tenant, event)
+}
+
+func TestTransitionGuardRefusal(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	srv := serveService(t, app.NewTenantService(repo, &noopPublisher{}, &heldValidator{}))
+	created := mustCreateTenant(t, srv, "Acme", "acme", "free")
+
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/"+created.ID+"/events", `{"event":"provision_complete"}`)
+	resp.Body.Close()
+	resp = doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/"+created.ID+"/events", `{"event":"suspend"}`)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict || !strings.Contains(string(body), "billing_current") {
+		t.Errorf("guarded event = %d %s, want 409 naming the guard", resp.StatusCode, body)
+	}
+}
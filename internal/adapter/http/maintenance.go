@@ -0,0 +1,120 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// MaintenanceWindowBody is a weekly maintenance window, in UTC.
+type MaintenanceWindowBody struct {
+	Weekday  string `json:"weekday" enum:"sunday,monday,tuesday,wednesday,thursday,friday,saturday" doc:"Day the window starts"`
+	Start    string `json:"start" pattern:"^([01][0-9]|2[0-3]):[0-5][0-9]$" doc:"Start time in UTC (HH:MM)"`
+	Duration string `json:"duration" doc:"Length of the window as a Go duration (e.g. 4h, 90m), at most 168h"`
+}
+
+// MaintenanceWindowsResponse lists the windows declared by a tenant.
+type MaintenanceWindowsResponse struct {
+	Windows []MaintenanceWindowBody `json:"windows" doc:"Windows during which automation may suspend or delete the tenant; empty means any time"`
+}
+
+type GetMaintenanceWindowsInput struct {
+	ID string `path:"id" doc:"Tenant ID"`
+}
+
+type SetMaintenanceWindowsInput struct {
+	ID   string `path:"id" doc:"Tenant ID"`
+	Body MaintenanceWindowsResponse
+}
+
+type MaintenanceWindowsOutput struct {
+	Body MaintenanceWindowsResponse
+}
+
+func registerMaintenance(api huma.API, svc *app.TenantService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "set-tenant-maintenance-windows",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/tenants/{id}/maintenance-windows",
+		Summary:     "Declare a tenant's maintenance windows",
+		Description: "Replaces the tenant's weekly windows. Suspensions and deletions scheduled by automation " +
+			"(spec sync, dunning) are deferred to them; changes requested through the API are not.",
+		Tags: []string{"Tenants"},
+	}, func(ctx context.Context, input *SetMaintenanceWindowsInput) (*MaintenanceWindowsOutput, error) {
+		schedule, err := toMaintenanceSchedule(input.Body.Windows)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		schedule, err = svc.SetMaintenanceWindows(ctx, input.ID, schedule)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &MaintenanceWindowsOutput{Body: toMaintenanceWindowsResponse(schedule)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-tenant-maintenance-windows",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/tenants/{id}/maintenance-windows",
+		Summary:     "Get a tenant's maintenance windows",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *GetMaintenanceWindowsInput) (*MaintenanceWindowsOutput, error) {
+		schedule, err := svc.MaintenanceWindows(ctx, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &MaintenanceWindowsOutput{Body: toMaintenanceWindowsResponse(schedule)}, nil
+	})
+}
+
+func toMaintenanceSchedule(windows []MaintenanceWindowBody) (domain.MaintenanceSchedule, error) {
+	schedule := make(domain.MaintenanceSchedule, 0, len(windows))
+	for i, w := range windows {
+		weekday, ok := parseWeekday(w.Weekday)
+		if !ok {
+			return nil, &domain.InvalidMaintenanceWindowError{Reason: fmt.Sprintf("window %d: unknown weekday %q", i, w.Weekday)}
+		}
+		start, err := time.Parse("15:04", w.Start)
+		if err != nil {
+			return nil, &domain.InvalidMaintenanceWindowError{Reason: fmt.Sprintf("window %d: start %q is not HH:MM", i, w.Start)}
+		}
+		duration, err := time.ParseDuration(w.Duration)
+		if err != nil {
+			return nil, &domain.InvalidMaintenanceWindowError{Reason: fmt.Sprintf("window %d: %v", i, err)}
+		}
+		schedule = append(schedule, domain.MaintenanceWindow{
+			Weekday:  weekday,
+			Start:    time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute,
+			Duration: duration,
+		})
+	}
+	return schedule, nil
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), s) {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+func toMaintenanceWindowsResponse(s domain.MaintenanceSchedule) MaintenanceWindowsResponse {
+	resp := MaintenanceWindowsResponse{Windows: make([]MaintenanceWindowBody, 0, len(s))}
+	for _, w := range s {
+		resp.Windows = append(resp.Windows, MaintenanceWindowBody{
+			Weekday:  strings.ToLower(w.Weekday.String()),
+			Start:    fmt.Sprintf("%02d:%02d", int(w.Start.Hours()), int(w.Start.Minutes())%60),
+			Duration: w.Duration.String(),
+		})
+	}
+	return resp
+}
@@ -0,0 +1,61 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+func newMaintenanceTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{},
+		app.WithMaintenanceWindows(sqlite.NewMaintenanceRepository(repo.DB())))
+	return serveService(t, svc)
+}
+
+func TestMaintenanceWindows_SetAndGet(t *testing.T) {
+	srv := newMaintenanceTestServer(t)
+	created := mustCreateTenant(t, srv, "Acme", "acme", "free")
+	url := srv.URL + "/api/v1/tenants/" + created.ID + "/maintenance-windows"
+
+	resp := doRequest(t, http.MethodPut, url, `{"windows":[{"weekday":"sunday","start":"02:30","duration":"4h"}]}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("set: status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	resp = doRequest(t, http.MethodGet, url, "")
+	defer resp.Body.Close()
+	var got adapter.MaintenanceWindowsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := adapter.MaintenanceWindowBody{Weekday: "sunday", Start: "02:30", Duration: "4h0m0s"}
+	if len(got.Windows) != 1 || got.Windows[0] != want {
+		t.Errorf("windows = %+v, want [%+v]", got.Windows, want)
+	}
+}
+
+func TestMaintenanceWindows_RejectsInvalidDuration(t *testing.T) {
+	srv := newMaintenanceTestServer(t)
+	created := mustCreateTenant(t, srv, "Acme", "acme", "free")
+
+	resp := doRequest(t, http.MethodPut, srv.URL+"/api/v1/tenants/"+created.ID+"/maintenance-windows",
+		`{"windows":[{"weekday":"monday","start":"01:00","duration":"200h"}]}`)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+}
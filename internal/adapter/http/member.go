@@ -0,0 +1,142 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithMembers exposes the members of tenants under
+// /api/v1/tenants/{id}/members.
+func WithMembers(ms *app.MemberService) Option {
+	return func(o *options) { o.members = ms }
+}
+
+// MemberResponse is the API representation of a tenant member.
+type MemberResponse struct {
+	ID         string `json:"id" doc:"Member ID"`
+	TenantID   string `json:"tenant_id" doc:"Tenant ID"`
+	Email      string `json:"email" doc:"Email address, lowercase"`
+	Role       string `json:"role" enum:"owner,admin,member" doc:"What the member may do in the tenant"`
+	Status     string `json:"status" enum:"invited,active" doc:"invited until the member accepts the invitation, then active"`
+	InvitedBy  string `json:"invited_by" doc:"Who invited the member"`
+	AcceptedAt string `json:"accepted_at,omitempty" doc:"When the invitation was accepted (ISO 8601)"`
+	CreatedAt  string `json:"created_at" doc:"Invitation timestamp (ISO 8601)"`
+	UpdatedAt  string `json:"updated_at" doc:"Last update timestamp (ISO 8601)"`
+}
+
+func toMemberResponse(m domain.Member) MemberResponse {
+	resp := MemberResponse{
+		ID:        m.ID,
+		TenantID:  m.TenantID,
+		Email:     m.Email,
+		Role:      string(m.Role),
+		Status:    string(m.Status),
+		InvitedBy: m.InvitedBy,
+		CreatedAt: m.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: m.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if !m.AcceptedAt.IsZero() {
+		resp.AcceptedAt = m.AcceptedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return resp
+}
+
+type InviteMemberInput struct {
+	ID   string `path:"id" doc:"Tenant ID"`
+	Body struct {
+		Email string `json:"email" minLength:"3" maxLength:"254" doc:"Email address of the person to invite"`
+		Role  string `json:"role,omitempty" enum:"owner,admin,member" default:"member" doc:"What the member may do in the tenant"`
+	}
+}
+
+type ListMembersInput struct {
+	ID string `path:"id" doc:"Tenant ID"`
+}
+
+type MemberIDInput struct {
+	ID       string `path:"id" doc:"Tenant ID"`
+	MemberID string `path:"member_id" doc:"Member ID"`
+}
+
+type MemberOutput struct {
+	Body MemberResponse
+}
+
+type MemberListOutput struct {
+	Body struct {
+		Items []MemberResponse `json:"items" doc:"Members, oldest invitation first"`
+	}
+}
+
+func registerMembers(api huma.API, ms *app.MemberService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "invite-tenant-member",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/tenants/{id}/members",
+		Summary:     "Invite a member to a tenant",
+		Description: "The member is invited by the X-Actor caller and stays invited until the invitation is accepted. " +
+			"Sending the invitation is up to the tenant's applications. " +
+			"An email address the tenant already has, in any case, is a conflict.",
+		Tags: []string{"Tenants"},
+	}, func(ctx context.Context, input *InviteMemberInput) (*MemberOutput, error) {
+		m, err := ms.Invite(ctx, input.ID, input.Body.Email, domain.MemberRole(input.Body.Role))
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &MemberOutput{Body: toMemberResponse(m)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-tenant-members",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/tenants/{id}/members",
+		Summary:     "List a tenant's members",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *ListMembersInput) (*MemberListOutput, error) {
+		members, err := ms.List(ctx, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		out := &MemberListOutput{}
+		out.Body.Items = make([]MemberResponse, len(members))
+		for i, m := range members {
+			out.Body.Items[i] = toMemberResponse(m)
+		}
+		return out, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "accept-tenant-member",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/tenants/{id}/members/{member_id}/accept",
+		Summary:     "Accept a member's invitation",
+		Description: "Makes the member active. Accepting again has no effect.",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *MemberIDInput) (*MemberOutput, error) {
+		m, err := ms.Accept(ctx, input.ID, input.MemberID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &MemberOutput{Body: toMemberResponse(m)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "remove-tenant-member",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/tenants/{id}/members/{member_id}",
+		Summary:       "Remove a member from a tenant",
+		Description:   "Removing an invited member revokes the invitation.",
+		Tags:          []string{"Tenants"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *MemberIDInput) (*struct{}, error) {
+		if err := ms.Remove(ctx, input.ID, input.MemberID); err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return nil, nil
+	})
+}
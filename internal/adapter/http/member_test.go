@@ -0,0 +1,75 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+func TestMembers_InviteListAcceptRemove(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{})
+	ms := app.NewMemberService(sqlite.NewMemberRepository(repo.DB()), svc)
+	srv := serveService(t, svc, adapter.WithMembers(ms))
+
+	acme := mustCreateTenant(t, srv, "Acme", "acme", "pro")
+	base := srv.URL + "/api/v1/tenants/" + acme.ID + "/members"
+
+	resp := doRequest(t, http.MethodPost, base, `{"email":"Bob@Acme.com"}`)
+	var member adapter.MemberResponse
+	_ = json.NewDecoder(resp.Body).Decode(&member)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || member.Email != "bob@acme.com" || member.Role != "member" || member.Status != "invited" {
+		t.Fatalf("invite: status = %d, member = %+v; want 200 and bob@acme.com invited as member", resp.StatusCode, member)
+	}
+
+	for body, want := range map[string]int{
+		`{"email":"bob@acme.com","role":"admin"}`:  http.StatusConflict,
+		`{"email":"not an address"}`:               http.StatusUnprocessableEntity,
+		`{"email":"carol@acme.com","role":"root"}`: http.StatusUnprocessableEntity,
+	} {
+		resp := doRequest(t, http.MethodPost, base, body)
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("invite %s: status = %d, want %d", body, resp.StatusCode, want)
+		}
+	}
+	resp = doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/ten_missing/members", `{"email":"carol@acme.com"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("invite to a missing tenant: status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+
+	resp = doRequest(t, http.MethodPost, base+"/"+member.ID+"/accept", "")
+	_ = json.NewDecoder(resp.Body).Decode(&member)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || member.Status != "active" || member.AcceptedAt == "" {
+		t.Errorf("accept: status = %d, member = %+v; want 200 and active", resp.StatusCode, member)
+	}
+
+	resp = doRequest(t, http.MethodGet, base, "")
+	var list struct {
+		Items []adapter.MemberResponse `json:"items"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if len(list.Items) != 1 || list.Items[0].ID != member.ID {
+		t.Errorf("list = %+v, want bob only", list.Items)
+	}
+
+	for _, want := range []int{http.StatusNoContent, http.StatusNotFound} {
+		resp := doRequest(t, http.MethodDelete, base+"/"+member.ID, "")
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("remove: status = %d, want %d", resp.StatusCode, want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithOperations exposes the long-running operations API under
+// /api/v1/operations.
+func WithOperations(ops *app.OperationService) Option {
+	return func(o *options) { o.operations = ops }
+}
+
+// OperationResponse is the API representation of a long-running operation.
+type OperationResponse struct {
+	ID        string `json:"id" doc:"Unique identifier"`
+	Kind      string `json:"kind" enum:"provision,deletion" doc:"Work tracked by the operation"`
+	TenantID  string `json:"tenant_id,omitempty" doc:"Tenant the operation works on"`
+	Status    string `json:"status" enum:"pending,succeeded,failed" doc:"Progress of the operation"`
+	Error     string `json:"error,omitempty" doc:"Why the operation failed"`
+	ResultURL string `json:"result_url,omitempty" doc:"Resource produced by the operation, once it succeeded"`
+	CreatedAt string `json:"created_at" doc:"Creation timestamp (ISO 8601)"`
+	UpdatedAt string `json:"updated_at" doc:"Last update timestamp (ISO 8601)"`
+}
+
+func toOperationResponse(op domain.Operation) OperationResponse {
+	return OperationResponse{
+		ID:        op.ID,
+		Kind:      string(op.Kind),
+		TenantID:  op.TenantID,
+		Status:    string(op.Status),
+		Error:     op.Error,
+		ResultURL: resultURL(op),
+		CreatedAt: op.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: op.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// resultURL links the result of a succeeded operation to the API resource
+// it designates.
+func resultURL(op domain.Operation) string {
+	if op.Status != domain.OperationSucceeded || op.Result == "" {
+		return ""
+	}
+	switch op.Kind {
+	case domain.OperationProvision:
+		return "/api/v1/tenants/" + op.Result
+	default:
+		return ""
+	}
+}
+
+type GetOperationInput struct {
+	ID string `path:"id" doc:"Operation ID"`
+}
+
+type GetOperationOutput struct {
+	Body OperationResponse
+}
+
+type ListOperationsInput struct {
+	TenantID string   `query:"tenant_id" required:"false" doc:"Only operations on this tenant"`
+	Kind     []string `query:"kind" required:"false" enum:"provision,deletion" doc:"Filter by kind (comma-separated, matches any)"`
+	Status   []string `query:"status" required:"false" enum:"pending,succeeded,failed" doc:"Filter by status (comma-separated, matches any)"`
+	Limit    int      `query:"limit" required:"false" default:"50" minimum:"1" maximum:"1000" doc:"Max results"`
+	Offset   int      `query:"offset" required:"false" default:"0" minimum:"0" doc:"Pagination offset"`
+}
+
+// OperationListResponse is a page of operations, newest first.
+type OperationListResponse struct {
+	Items  []OperationResponse `json:"items" doc:"Operations in this page"`
+	Total  int                 `json:"total" doc:"Total number of operations matching the filter"`
+	Limit  int                 `json:"limit" doc:"Max results requested"`
+	Offset int                 `json:"offset" doc:"Pagination offset requested"`
+}
+
+type ListOperationsOutput struct {
+	Body OperationListResponse
+}
+
+func registerOperations(api huma.API, ops *app.OperationService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-operations",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/operations",
+		Summary:     "List long-running operations",
+		Tags:        []string{"Operations"},
+	}, func(ctx context.Context, input *ListOperationsInput) (*ListOperationsOutput, error) {
+		filter := domain.OperationFilter{
+			TenantID: input.TenantID,
+			Limit:    input.Limit,
+			Offset:   input.Offset,
+		}
+		for _, k := range input.Kind {
+			filter.Kinds = append(filter.Kinds, domain.OperationKind(k))
+		}
+		for _, st := range input.Status {
+			filter.Statuses = append(filter.Statuses, domain.OperationStatus(st))
+		}
+
+		list, err := ops.List(ctx, filter)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+
+		total, err := ops.Count(ctx, filter)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+
+		items := make([]OperationResponse, len(list))
+		for i, op := range list {
+			items[i] = toOperationResponse(op)
+		}
+		return &ListOperationsOutput{Body: OperationListResponse{
+			Items:  items,
+			Total:  total,
+			Limit:  input.Limit,
+			Offset: input.Offset,
+		}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-operation",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/operations/{id}",
+		Summary:     "Get a long-running operation",
+		Tags:        []string{"Operations"},
+	}, func(ctx context.Context, input *GetOperationInput) (*GetOperationOutput, error) {
+		op, err := ops.Get(ctx, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &GetOperationOutput{Body: toOperationResponse(op)}, nil
+	})
+}
+
+// prefersAsync reports whether a Prefer header (RFC 7240) asks for
+// asynchronous processing.
+func prefersAsync(prefer string) bool {
+	for _, pref := range strings.Split(prefer, ",") {
+		token, _, _ := strings.Cut(pref, ";")
+		if strings.EqualFold(strings.TrimSpace(token), "respond-async") {
+			return true
+		}
+	}
+	return false
+}
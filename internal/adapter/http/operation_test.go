@@ -0,0 +1,219 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// queuedOperations is an OperationQueue that only records operations;
+// tests run them through the service themselves.
+type queuedOperations struct {
+	ops []domain.Operation
+}
+
+func (q *queuedOperations) Enqueue(_ context.Context, op domain.Operation) error {
+	q.ops = append(q.ops, op)
+	return nil
+}
+
+func newAsyncTestServer(t *testing.T) (*httptest.Server, *app.TenantService) {
+	t.Helper()
+
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	ops := app.NewOperationService(sqlite.NewOperationRepository(repo.DB()))
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{},
+		app.WithAsyncOperations(ops, &queuedOperations{}),
+	)
+	return serveService(t, svc, adapter.WithOperations(ops)), svc
+}
+
+// doAsyncRequest sends a request with Prefer: respond-async.
+func doAsyncRequest(t *testing.T, method, url, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Prefer", "respond-async, wait=10")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, url, err)
+	}
+	return resp
+}
+
+// createAsync posts a tenant with Prefer: respond-async.
+func createAsync(t *testing.T, srv *httptest.Server, body string) *http.Response {
+	t.Helper()
+	return doAsyncRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants", body)
+}
+
+func getOperation(t *testing.T, srv *httptest.Server, id string) adapter.OperationResponse {
+	t.Helper()
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/operations/"+id, "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("get operation: status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var op adapter.OperationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&op); err != nil {
+		t.Fatalf("decode operation: %v", err)
+	}
+	return op
+}
+
+func TestCreate_AsyncReturnsOperation(t *testing.T) {
+	srv, svc := newAsyncTestServer(t)
+
+	resp := createAsync(t, srv, `{"name":"Acme","slug":"acme","plan":"pro"}`)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusAccepted)
+	}
+	if got := resp.Header.Get("Preference-Applied"); got != "respond-async" {
+		t.Errorf("Preference-Applied = %q, want respond-async", got)
+	}
+
+	var created adapter.TenantOperationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if created.Status != "creating" || !strings.HasPrefix(created.OperationID, "op_") {
+		t.Fatalf("created = %+v, want creating tenant with an operation", created)
+	}
+	if got := resp.Header.Get("Location"); got != "/api/v1/operations/"+created.OperationID {
+		t.Errorf("Location = %q", got)
+	}
+
+	op := getOperation(t, srv, created.OperationID)
+	if op.Status != "pending" || op.TenantID != created.ID || op.Kind != "provision" {
+		t.Errorf("operation = %+v, want pending provision", op)
+	}
+
+	// Simulate the worker.
+	if err := svc.RunOperation(context.Background(), created.OperationID); err != nil {
+		t.Fatalf("RunOperation: %v", err)
+	}
+	op = getOperation(t, srv, created.OperationID)
+	if op.Status != "succeeded" || op.ResultURL != "/api/v1/tenants/"+created.ID {
+		t.Errorf("operation = %+v, want succeeded with a link to the tenant", op)
+	}
+}
+
+func TestCreate_PreferAsyncIgnoredWhenDisabled(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp := createAsync(t, srv, `{"name":"Acme","slug":"acme"}`)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := resp.Header.Get("Preference-Applied"); got != "" {
+		t.Errorf("Preference-Applied = %q, want none", got)
+	}
+}
+
+func TestGetOperation_NotFound(t *testing.T) {
+	srv, _ := newAsyncTestServer(t)
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/operations/op_missing", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestDelete_AsyncReturnsOperation(t *testing.T) {
+	srv, svc := newAsyncTestServer(t)
+	ctx := context.Background()
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+
+	resp := doAsyncRequest(t, http.MethodDelete, srv.URL+"/api/v1/tenants/"+tenant.ID, "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusAccepted)
+	}
+	var deleted adapter.TenantOperationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&deleted); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if deleted.Status != "deleting" || deleted.OperationID == "" {
+		t.Fatalf("deleted = %+v, want deleting tenant with an operation", deleted)
+	}
+	if got := resp.Header.Get("Location"); got != "/api/v1/operations/"+deleted.OperationID {
+		t.Errorf("Location = %q", got)
+	}
+
+	if err := svc.RunOperation(ctx, deleted.OperationID); err != nil {
+		t.Fatalf("RunOperation: %v", err)
+	}
+	op := getOperation(t, srv, deleted.OperationID)
+	if op.Kind != "deletion" || op.Status != "succeeded" || op.ResultURL != "" {
+		t.Errorf("operation = %+v, want succeeded deletion without result", op)
+	}
+}
+
+func TestListOperations(t *testing.T) {
+	srv, _ := newAsyncTestServer(t)
+
+	for _, slug := range []string{"acme", "globex"} {
+		resp := createAsync(t, srv, `{"name":"T","slug":"`+slug+`"}`)
+		resp.Body.Close()
+	}
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/operations?kind=provision&status=pending&limit=1", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var list adapter.OperationListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if list.Total != 2 || len(list.Items) != 1 || list.Limit != 1 {
+		t.Errorf("list = %+v, want 1 of 2 operations", list)
+	}
+}
+
+func TestOperations_NotRegisteredWithoutOption(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/operations", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
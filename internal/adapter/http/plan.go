@@ -0,0 +1,200 @@
+package http
+
+import (
+	"cmp"
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithPlans exposes the plan definitions under /api/v1/plans.
+func WithPlans(ps *app.PlanService) Option {
+	return func(o *options) { o.plans = ps }
+}
+
+// PlanResponse is the API representation of a plan.
+type PlanResponse struct {
+	Name      string           `json:"name" doc:"Unique name, as set on tenants"`
+	Price     int64            `json:"price" doc:"Monthly price in the smallest unit of the currency (e.g. cents)"`
+	Currency  string           `json:"currency" doc:"ISO 4217 currency code"`
+	Limits    map[string]int64 `json:"limits,omitempty" doc:"Usage allowed per metric; metrics without a limit are unlimited"`
+	Features  []string         `json:"features" doc:"Features included in the plan"`
+	Regions   []string         `json:"regions" doc:"Regions the plan is offered in; empty when it is offered everywhere"`
+	RateLimit *PlanRateLimit   `json:"rate_limit,omitempty" doc:"Request rate of the plan's tenants, unless overridden per tenant; absent when they are not limited"`
+	CreatedAt string           `json:"created_at" doc:"Creation timestamp (ISO 8601)"`
+	UpdatedAt string           `json:"updated_at" doc:"Last update timestamp (ISO 8601)"`
+}
+
+// PlanRateLimit is the request rate allowed to the tenants of a plan.
+type PlanRateLimit struct {
+	RequestsPerSecond int `json:"requests_per_second" minimum:"1" doc:"Sustained request rate (token bucket refill rate)"`
+	Burst             int `json:"burst,omitempty" minimum:"0" doc:"Requests allowed at once (token bucket size); defaults to requests_per_second"`
+}
+
+func (l *PlanRateLimit) toDomain() domain.RateLimit {
+	if l == nil {
+		return domain.RateLimit{}
+	}
+	return domain.RateLimit{RequestsPerSecond: l.RequestsPerSecond, Burst: cmp.Or(l.Burst, l.RequestsPerSecond)}
+}
+
+func toPlanResponse(p domain.Plan) PlanResponse {
+	features := p.Features
+	if features == nil {
+		features = []string{}
+	}
+	regions := p.Regions
+	if regions == nil {
+		regions = []string{}
+	}
+	resp := PlanResponse{
+		Name:      p.Name,
+		Price:     p.Price,
+		Currency:  p.Currency,
+		Limits:    p.Limits,
+		Features:  features,
+		Regions:   regions,
+		CreatedAt: p.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: p.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if !p.RateLimit.IsZero() {
+		resp.RateLimit = &PlanRateLimit{RequestsPerSecond: p.RateLimit.RequestsPerSecond, Burst: p.RateLimit.Burst}
+	}
+	return resp
+}
+
+// PlanTerms are the mutable attributes of a plan.
+type PlanTerms struct {
+	Price     int64            `json:"price,omitempty" minimum:"0" doc:"Monthly price in the smallest unit of the currency (e.g. cents)"`
+	Currency  string           `json:"currency,omitempty" default:"USD" doc:"ISO 4217 currency code"`
+	Limits    map[string]int64 `json:"limits,omitempty" doc:"Usage allowed per metric; metrics without a limit are unlimited"`
+	Features  []string         `json:"features,omitempty" doc:"Features included in the plan"`
+	Regions   []string         `json:"regions,omitempty" doc:"Regions the plan is offered in (e.g. eu-west); it is offered everywhere when omitted"`
+	RateLimit *PlanRateLimit   `json:"rate_limit,omitempty" doc:"Request rate of the plan's tenants, unless overridden per tenant (e.g. 1 per second with a burst of 60 for 60 requests a minute); they are not limited when omitted"`
+}
+
+type CreatePlanInput struct {
+	Body struct {
+		Name string `json:"name" minLength:"1" maxLength:"63" doc:"Unique name (lowercase, hyphens or underscores), as set on tenants"`
+		PlanTerms
+	}
+}
+
+type UpdatePlanInput struct {
+	Name string `path:"name" doc:"Plan name"`
+	Body PlanTerms
+}
+
+type ListPlansInput struct {
+	Region string `query:"region" doc:"Only the plans available to tenants in this region: those offered there and those offered everywhere"`
+}
+
+type PlanNameInput struct {
+	Name string `path:"name" doc:"Plan name"`
+}
+
+type PlanOutput struct {
+	Body PlanResponse
+}
+
+type PlanListOutput struct {
+	Body struct {
+		Items []PlanResponse `json:"items" doc:"Plans, by name"`
+	}
+}
+
+func registerPlans(api huma.API, ps *app.PlanService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "create-plan",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/plans",
+		Summary:     "Define a plan",
+		Description: "Tenants can only be created on, or moved to, a defined plan, offered in their region.",
+		Tags:        []string{"Plans"},
+	}, func(ctx context.Context, input *CreatePlanInput) (*PlanOutput, error) {
+		b := input.Body
+		p, err := ps.Create(ctx, b.Name, b.Price, b.Currency, b.Limits, b.Features, b.Regions, b.RateLimit.toDomain())
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &PlanOutput{Body: toPlanResponse(p)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-plans",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/plans",
+		Summary:     "List plans",
+		Description: "With region, lists the catalog of that region, as shown on its pricing page.",
+		Tags:        []string{"Plans"},
+	}, func(ctx context.Context, input *ListPlansInput) (*PlanListOutput, error) {
+		var (
+			plans []domain.Plan
+			err   error
+		)
+		if input.Region != "" {
+			plans, err = ps.ListIn(ctx, input.Region)
+		} else {
+			plans, err = ps.List(ctx)
+		}
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		out := &PlanListOutput{}
+		out.Body.Items = make([]PlanResponse, len(plans))
+		for i, p := range plans {
+			out.Body.Items[i] = toPlanResponse(p)
+		}
+		return out, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-plan",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/plans/{name}",
+		Summary:     "Get a plan",
+		Tags:        []string{"Plans"},
+	}, func(ctx context.Context, input *PlanNameInput) (*PlanOutput, error) {
+		p, err := ps.Get(ctx, input.Name)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &PlanOutput{Body: toPlanResponse(p)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-plan",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/plans/{name}",
+		Summary:     "Replace a plan's price, limits, features, regions and rate limit",
+		Description: "The name cannot change: tenants refer to the plan by it. " +
+			"Tenants already on the plan keep it when their region is no longer offered.",
+		Tags: []string{"Plans"},
+	}, func(ctx context.Context, input *UpdatePlanInput) (*PlanOutput, error) {
+		b := input.Body
+		p, err := ps.Update(ctx, input.Name, b.Price, b.Currency, b.Limits, b.Features, b.Regions, b.RateLimit.toDomain())
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &PlanOutput{Body: toPlanResponse(p)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-plan",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/plans/{name}",
+		Summary:       "Delete a plan",
+		Description:   "Refused with 409 while tenants, other than deleted ones, are on the plan.",
+		Tags:          []string{"Plans"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *PlanNameInput) (*struct{}, error) {
+		if err := ps.Delete(ctx, input.Name); err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return nil, nil
+	})
+}
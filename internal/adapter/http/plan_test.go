@@ -0,0 +1,190 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+// newPlanTestServer serves the plans API with tenant plans validated
+// against it. The migrations define the free plan.
+func newPlanTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	ps := app.NewPlanService(sqlite.NewPlanRepository(repo.DB()), repo)
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{}, app.WithPlanValidation(ps))
+	return serveService(t, svc, adapter.WithPlans(ps))
+}
+
+func decodePlan(t *testing.T, resp *http.Response) adapter.PlanResponse {
+	t.Helper()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var p adapter.PlanResponse
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return p
+}
+
+func TestPlans_CRUD(t *testing.T) {
+	srv := newPlanTestServer(t)
+	base := srv.URL + "/api/v1/plans"
+
+	created := decodePlan(t, doRequest(t, http.MethodPost, base,
+		`{"name":"professional","price":4900,"limits":{"seats":50},"features":["sso"]}`))
+	if created.Currency != "USD" || created.Limits["seats"] != 50 || len(created.Features) != 1 {
+		t.Fatalf("created = %+v", created)
+	}
+
+	resp := doRequest(t, http.MethodPost, base, `{"name":"professional"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("duplicate create: status = %d, want %d", resp.StatusCode, http.StatusConflict)
+	}
+
+	updated := decodePlan(t, doRequest(t, http.MethodPut, base+"/professional",
+		`{"price":5900,"currency":"EUR","rate_limit":{"requests_per_second":10}}`))
+	if updated.Price != 5900 || updated.Currency != "EUR" || len(updated.Features) != 0 {
+		t.Errorf("updated = %+v, want the new price and no features", updated)
+	}
+	// The burst defaults to a second's worth of requests.
+	if updated.RateLimit == nil || *updated.RateLimit != (adapter.PlanRateLimit{RequestsPerSecond: 10, Burst: 10}) {
+		t.Errorf("rate limit = %+v, want 10/10", updated.RateLimit)
+	}
+
+	resp = doRequest(t, http.MethodGet, base, "")
+	var list struct {
+		Items []adapter.PlanResponse `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	resp.Body.Close()
+	if len(list.Items) != 2 || list.Items[0].Name != "free" || list.Items[1].Name != "professional" {
+		t.Errorf("list = %+v, want free and professional", list.Items)
+	}
+
+	resp = doRequest(t, http.MethodDelete, base+"/professional", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("delete: status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+	resp = doRequest(t, http.MethodGet, base+"/professional", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("get after delete: status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestPlans_ValidateTenantPlans(t *testing.T) {
+	srv := newPlanTestServer(t)
+
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants", `{"name":"Acme","plan":"fre"}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("create on a typo: status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+	var problem struct {
+		Detail string `json:"detail"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !strings.Contains(problem.Detail, `did you mean "free"`) {
+		t.Errorf("detail = %q, want a suggestion", problem.Detail)
+	}
+
+	tenant := mustCreateTenant(t, srv, "Acme", "acme", "free")
+
+	resp = doRequest(t, http.MethodPatch, srv.URL+"/api/v1/tenants/"+tenant.ID, `{"plan":"enterprise"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("patch to an unknown plan: status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+
+	resp = doRequest(t, http.MethodDelete, srv.URL+"/api/v1/plans/free", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("delete a plan in use: status = %d, want %d", resp.StatusCode, http.StatusConflict)
+	}
+}
+
+func TestPlans_RegionalCatalogs(t *testing.T) {
+	srv := newPlanTestServer(t)
+	base := srv.URL + "/api/v1/plans"
+
+	created := decodePlan(t, doRequest(t, http.MethodPost, base,
+		`{"name":"eu-pro","price":4900,"currency":"EUR","regions":["eu-west","eu-central"]}`))
+	if len(created.Regions) != 2 || created.Regions[0] != "eu-central" {
+		t.Fatalf("created regions = %v, want them sorted", created.Regions)
+	}
+
+	listIn := func(region string) []string {
+		t.Helper()
+		resp := doRequest(t, http.MethodGet, base+"?region="+region, "")
+		defer resp.Body.Close()
+		var list struct {
+			Items []adapter.PlanResponse `json:"items"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		var names []string
+		for _, p := range list.Items {
+			names = append(names, p.Name)
+		}
+		return names
+	}
+	if got := listIn("eu-west"); len(got) != 2 {
+		t.Errorf("plans in eu-west = %v, want eu-pro and free", got)
+	}
+	if got := listIn("us-east"); len(got) != 1 || got[0] != "free" {
+		t.Errorf("plans in us-east = %v, want only free", got)
+	}
+	resp := doRequest(t, http.MethodGet, base+"?region=EU", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("list in a malformed region: status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+
+	resp = doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants", `{"name":"Acme","plan":"eu-pro","region":"us-east"}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("create outside the plan's regions: status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+	var problem struct {
+		Detail string `json:"detail"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !strings.Contains(problem.Detail, "eu-central, eu-west") {
+		t.Errorf("detail = %q, want the plan's regions", problem.Detail)
+	}
+
+	resp = doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants", `{"name":"Acme","plan":"eu-pro","region":"eu-west"}`)
+	var tenant adapter.TenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tenant); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || tenant.Region != "eu-west" {
+		t.Errorf("create in eu-west: status = %d, tenant = %+v", resp.StatusCode, tenant)
+	}
+}
@@ -0,0 +1,28 @@
+package http
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"time"
+)
+
+// NewPprofServer returns a server of the net/http/pprof profiles under
+// /debug/pprof/ on addr, apart from the API so they are neither exposed
+// with it nor behind its authentication. Listen on a loopback or internal
+// address only: profiles reveal the code and memory of the process.
+//
+// There is no write timeout, as CPU profiles and traces take as long as
+// their seconds parameter asks (30s by default).
+func NewPprofServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+type CheckQuotaInput struct {
+	ID   string `path:"id" doc:"Tenant ID"`
+	Body struct {
+		Metric string `json:"metric" minLength:"1" doc:"Metric the operation consumes (e.g. projects, api_calls, seats)"`
+		Amount int64  `json:"amount,omitempty" minimum:"1" default:"1" doc:"How much of the metric the operation consumes"`
+	}
+}
+
+func registerQuotas(api huma.API, svc *app.TenantService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "check-tenant-quota",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/tenants/{id}/quota-checks",
+		Summary:     "Check a tenant's plan quota",
+		Description: "Called before an operation that consumes quota. Answers 204 when the tenant's reported usage " +
+			"plus the amount fits its plan's limit on the metric, 429 when a periodic limit (api_calls) is reached " +
+			"and 403 when another limit is. Metrics the plan does not limit always fit.",
+		Tags:          []string{"Tenants"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *CheckQuotaInput) (*struct{}, error) {
+		if err := svc.CheckQuota(ctx, input.ID, input.Body.Metric, input.Body.Amount); err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,50 @@
+package http_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestCheckQuota(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	db := repo.DB()
+
+	usage := sqlite.NewUsageRepository(db)
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{},
+		app.WithPlanSuggestions(nil, usage),
+		app.WithQuotaChecker(app.NewPlanQuotaChecker(sqlite.NewPlanRepository(db), usage)))
+	srv := serveService(t, svc)
+
+	ps := app.NewPlanService(sqlite.NewPlanRepository(db), repo)
+	if _, err := ps.Create(t.Context(), "starter", 0, "USD", map[string]int64{"projects": 2, "api_calls": 100}, nil, nil, domain.RateLimit{}); err != nil {
+		t.Fatalf("creating plan: %v", err)
+	}
+	tenant := mustCreateTenant(t, srv, "Acme", "acme", "starter")
+	resp := doRequest(t, http.MethodPut, srv.URL+"/api/v1/tenants/"+tenant.ID+"/usage", `{"metrics":{"projects":2,"api_calls":100}}`)
+	resp.Body.Close()
+
+	check := srv.URL + "/api/v1/tenants/" + tenant.ID + "/quota-checks"
+	for _, tc := range []struct {
+		body string
+		want int
+	}{
+		{`{"metric":"seats","amount":10}`, http.StatusNoContent},
+		{`{"metric":"projects","amount":0}`, http.StatusUnprocessableEntity},
+		{`{"metric":"projects"}`, http.StatusForbidden},
+		{`{"metric":"api_calls"}`, http.StatusTooManyRequests},
+	} {
+		resp := doRequest(t, http.MethodPost, check, tc.body)
+		resp.Body.Close()
+		if resp.StatusCode != tc.want {
+			t.Errorf("POST %s: status = %d, want %d", tc.body, resp.StatusCode, tc.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithRequestLimits holds the requests tenants make with their API keys
+// (see WithAPIKeys) to their rate limits, answering those over it with 429
+// Too Many Requests and Retry-After.
+func WithRequestLimits(l *app.RequestLimiter) Option {
+	return func(o *options) { o.requestLimiter = l }
+}
+
+// limitRequest spends a request of the tenant's rate limit on operation,
+// when requests are limited.
+func limitRequest(ctx context.Context, l *app.RequestLimiter, tenant domain.Tenant, operation string) error {
+	if l == nil {
+		return nil
+	}
+	return l.Allow(ctx, tenant, operation)
+}
+
+// RateLimitBody is the request rate an API gateway allows a tenant.
+type RateLimitBody struct {
+	RequestsPerSecond int    `json:"requests_per_second" doc:"Sustained request rate (token bucket refill rate)"`
+	Burst             int    `json:"burst" doc:"Requests allowed at once (token bucket size)"`
+	Source            string `json:"source" enum:"plan,override" doc:"Whether the limit comes from the tenant's plan or a per-tenant override"`
+}
+
+// TenantRateLimitResponse is the rate limit in effect for a tenant.
+type TenantRateLimitResponse struct {
+	TenantID string `json:"tenant_id" doc:"Tenant ID"`
+	Slug     string `json:"slug" doc:"Tenant slug"`
+	Plan     string `json:"plan" doc:"Tenant plan"`
+	RateLimitBody
+}
+
+// RateLimitsResponse is the snapshot gateways poll.
+type RateLimitsResponse struct {
+	Items []TenantRateLimitResponse `json:"items" doc:"Rate limit of every rate-limited active tenant, ordered by slug; tenants not listed are not limited"`
+}
+
+type GetRateLimitsInput struct {
+	IfNoneMatch string `header:"If-None-Match" doc:"ETag of the snapshot the gateway holds; 304 is returned when it is still current"`
+}
+
+type RateLimitsOutput struct {
+	Status int
+	ETag   string `header:"ETag" doc:"Version of the snapshot"`
+	Body   RateLimitsResponse
+}
+
+type SetRateLimitInput struct {
+	ID   string `path:"id" doc:"Tenant ID"`
+	Body struct {
+		RequestsPerSecond int `json:"requests_per_second" minimum:"1" doc:"Sustained request rate"`
+		Burst             int `json:"burst,omitempty" minimum:"0" doc:"Requests allowed at once; defaults to requests_per_second"`
+	}
+}
+
+type TenantRateLimitOutput struct {
+	Body TenantRateLimitResponse
+}
+
+type ClearRateLimitInput struct {
+	ID string `path:"id" doc:"Tenant ID"`
+}
+
+func registerRateLimits(api huma.API, svc *app.TenantService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-rate-limits",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/rate-limits",
+		Summary:     "Get the rate limits of all tenants",
+		Description: "Snapshot for API gateways to enforce per-tenant limits without calling back on each request. " +
+			"Poll with If-None-Match to receive 304 Not Modified while nothing changed.",
+		Tags: []string{"Rate limits"},
+	}, func(ctx context.Context, input *GetRateLimitsInput) (*RateLimitsOutput, error) {
+		limits, err := svc.RateLimits(ctx)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		body := RateLimitsResponse{Items: make([]TenantRateLimitResponse, len(limits))}
+		for i, l := range limits {
+			body.Items[i] = toTenantRateLimitResponse(l)
+		}
+
+		etag, err := contentETag(body)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		if etagMatches(input.IfNoneMatch, etag) {
+			return &RateLimitsOutput{Status: http.StatusNotModified, ETag: etag}, nil
+		}
+		return &RateLimitsOutput{Status: http.StatusOK, ETag: etag, Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-tenant-rate-limit",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/tenants/{id}/rate-limit",
+		Summary:     "Override a tenant's rate limit",
+		Description: "Replaces the rate limit of the tenant's plan for this tenant only.",
+		Tags:        []string{"Rate limits"},
+	}, func(ctx context.Context, input *SetRateLimitInput) (*TenantRateLimitOutput, error) {
+		l := domain.RateLimit{RequestsPerSecond: input.Body.RequestsPerSecond, Burst: input.Body.Burst}
+		if l.Burst == 0 {
+			l.Burst = l.RequestsPerSecond
+		}
+		limit, err := svc.SetRateLimit(ctx, input.ID, l)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &TenantRateLimitOutput{Body: toTenantRateLimitResponse(limit)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "clear-tenant-rate-limit",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/tenants/{id}/rate-limit",
+		Summary:       "Remove a tenant's rate limit override",
+		Description:   "The rate limit of the tenant's plan applies again.",
+		Tags:          []string{"Rate limits"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *ClearRateLimitInput) (*struct{}, error) {
+		if err := svc.ClearRateLimit(ctx, input.ID); err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return nil, nil
+	})
+}
+
+func toRateLimitBody(l domain.TenantRateLimit) RateLimitBody {
+	return RateLimitBody{
+		RequestsPerSecond: l.Limit.RequestsPerSecond,
+		Burst:             l.Limit.Burst,
+		Source:            string(l.Source),
+	}
+}
+
+func toTenantRateLimitResponse(l domain.TenantRateLimit) TenantRateLimitResponse {
+	return TenantRateLimitResponse{
+		TenantID:      l.TenantID,
+		Slug:          l.Slug,
+		Plan:          l.Plan,
+		RateLimitBody: toRateLimitBody(l),
+	}
+}
+
+// contentETag returns a strong ETag derived from the content of body, so
+// every replica returns the same ETag for the same content.
+func contentETag(body any) (string, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("encoding response: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// etagMatches reports whether an If-None-Match header lists etag. Weak
+// comparison applies, as RFC 9110 requires for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
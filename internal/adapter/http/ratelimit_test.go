@@ -0,0 +1,128 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func newRateLimitTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	catalog := domain.PlanCatalog{{Plan: "free", RateLimit: domain.RateLimit{RequestsPerSecond: 10, Burst: 20}}}
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{},
+		app.WithRateLimits(catalog, sqlite.NewRateLimitRepository(repo.DB())))
+	return serveService(t, svc)
+}
+
+func mustActivateTenant(t *testing.T, srv *httptest.Server, slug string) adapter.TenantResponse {
+	t.Helper()
+	tenant := mustCreateTenant(t, srv, slug, slug, "free")
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/"+tenant.ID+"/events", `{"event":"provision_complete"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("activate: status = %d", resp.StatusCode)
+	}
+	return tenant
+}
+
+func getRateLimits(t *testing.T, srv *httptest.Server, ifNoneMatch string) (*http.Response, adapter.RateLimitsResponse) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/api/v1/rate-limits", nil)
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body adapter.RateLimitsResponse
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+	}
+	return resp, body
+}
+
+func TestRateLimits_SnapshotWithETag(t *testing.T) {
+	srv := newRateLimitTestServer(t)
+	acme := mustActivateTenant(t, srv, "acme")
+
+	resp, body := getRateLimits(t, srv, "")
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" {
+		t.Fatalf("status = %d, ETag = %q", resp.StatusCode, etag)
+	}
+	if len(body.Items) != 1 || body.Items[0].TenantID != acme.ID || body.Items[0].RequestsPerSecond != 10 || body.Items[0].Source != "plan" {
+		t.Errorf("items = %+v", body.Items)
+	}
+
+	if resp, _ := getRateLimits(t, srv, etag); resp.StatusCode != http.StatusNotModified {
+		t.Errorf("unchanged snapshot: status = %d, want %d", resp.StatusCode, http.StatusNotModified)
+	}
+
+	put := doRequest(t, http.MethodPut, srv.URL+"/api/v1/tenants/"+acme.ID+"/rate-limit", `{"requests_per_second":50}`)
+	put.Body.Close()
+	if put.StatusCode != http.StatusOK {
+		t.Fatalf("override: status = %d", put.StatusCode)
+	}
+
+	resp, body = getRateLimits(t, srv, etag)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("ETag") == etag {
+		t.Fatalf("changed snapshot: status = %d, ETag = %q", resp.StatusCode, resp.Header.Get("ETag"))
+	}
+	if got := body.Items[0]; got.RequestsPerSecond != 50 || got.Burst != 50 || got.Source != "override" {
+		t.Errorf("item = %+v, want the override with burst defaulted", got)
+	}
+}
+
+func TestGetBySlug_IncludesRateLimit(t *testing.T) {
+	srv := newRateLimitTestServer(t)
+	mustActivateTenant(t, srv, "acme")
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/slug/acme", "")
+	defer resp.Body.Close()
+
+	var got adapter.ResolvedTenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Slug != "acme" || got.RateLimit == nil || got.RateLimit.Burst != 20 {
+		t.Errorf("resolved = %+v, rate limit = %+v", got, got.RateLimit)
+	}
+}
+
+func TestSetRateLimit_Validation(t *testing.T) {
+	srv := newRateLimitTestServer(t)
+	acme := mustActivateTenant(t, srv, "acme")
+
+	resp := doRequest(t, http.MethodPut, srv.URL+"/api/v1/tenants/"+acme.ID+"/rate-limit", `{"requests_per_second":0}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+
+	resp = doRequest(t, http.MethodDelete, srv.URL+"/api/v1/tenants/ten_missing/rate-limit", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("delete unknown tenant: status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"cmp"
+	"context"
+	"net/http"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithRoles sets how bearer tokens and sessions grant roles: the roles
+// named in their claim (a string or a list; a dotted path such as
+// realm_access.roles reaches into objects) and fallback for those naming
+// none. Admin keys carry their own role. Without it, tokens are viewers.
+func WithRoles(claim string, fallback domain.Role) Option {
+	return func(o *options) {
+		o.roleClaim = claim
+		o.defaultRole = fallback
+	}
+}
+
+// operationRoles are the operations requiring another role than their
+// method does (see requiredRole).
+var operationRoles = map[string]domain.Role{
+	// Admin keys grant roles, so only admins hand them out or see them.
+	"create-admin-key": domain.RoleAdmin,
+	"list-admin-keys":  domain.RoleAdmin,
+	// Tenant API keys are credentials of the tenants' applications.
+	"list-tenant-api-keys": domain.RoleOperator,
+	// Revoking a credential or clearing an override undoes something an
+	// operator may do again.
+	"revoke-tenant-api-key":   domain.RoleOperator,
+	"clear-tenant-rate-limit": domain.RoleOperator,
+	"remove-tenant-tag":       domain.RoleOperator,
+	// A tenant's key decides who can read its data, and secret values are
+	// credentials.
+	"set-tenant-encryption-key": domain.RoleAdmin,
+	"get-tenant-secret":         domain.RoleAdmin,
+}
+
+// requiredRole returns the role an operation requires: viewer to read,
+// operator to create and change, admin to delete, unless operationRoles
+// says otherwise.
+func requiredRole(method, operationID string) domain.Role {
+	if r, ok := operationRoles[operationID]; ok {
+		return r
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return domain.RoleViewer
+	case http.MethodDelete:
+		return domain.RoleAdmin
+	}
+	return domain.RoleOperator
+}
+
+// claimsRole returns the role the claims of a token or session grant.
+func (o *options) claimsRole(c domain.Claims) domain.Role {
+	if r, ok := domain.HighestRole(c.Strings(o.roleClaim)); ok {
+		return r
+	}
+	if o.defaultRole != "" {
+		return o.defaultRole
+	}
+	return domain.RoleViewer
+}
+
+// authorize returns the status and message rejecting a caller whose role,
+// set in ctx by authenticate, does not allow the operation; 0 otherwise.
+// Routes outside the API have no operation ID and go by their method.
+func authorize(ctx context.Context, method, operationID string) (int, string) {
+	role, _ := domain.RoleFromContext(ctx)
+	required := requiredRole(method, operationID)
+	if role.Includes(required) {
+		return 0, ""
+	}
+	err := &domain.ForbiddenError{Role: role, Required: required, Action: cmp.Or(operationID, "this request")}
+	return http.StatusForbidden, err.Error()
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithReadOnly rejects the changes with 503 while sw is enabled and exposes
+// its state at /api/v1/system/read-only, where admins switch it.
+func WithReadOnly(sw *app.ReadOnlySwitch) Option {
+	return func(o *options) { o.readOnly = sw }
+}
+
+// readOnlySafeOperations are the operations sent with a method other than
+// GET, HEAD or OPTIONS that change nothing, so they keep working in
+// read-only mode. Verifying an API key records its use, but only on a
+// best-effort basis, and tenants' applications cannot authenticate without
+// it.
+var readOnlySafeOperations = map[string]bool{
+	"check-tenant-quota": true,
+	"create-signed-url":  true,
+	"set-read-only":      true,
+	"verify-api-key":     true,
+}
+
+// ReadOnlyResponse is the read-only mode of the service.
+type ReadOnlyResponse struct {
+	Enabled bool   `json:"enabled" doc:"Whether changes are rejected with 503"`
+	Reason  string `json:"reason,omitempty" doc:"Why changes are rejected"`
+	Since   string `json:"since,omitempty" doc:"When the mode or its reason last changed (ISO 8601)"`
+	Actor   string `json:"actor,omitempty" doc:"Who made that change"`
+}
+
+func toReadOnlyResponse(s domain.ReadOnlyState) ReadOnlyResponse {
+	resp := ReadOnlyResponse{Enabled: s.Enabled, Reason: s.Reason, Actor: s.Actor}
+	if !s.Since.IsZero() {
+		resp.Since = s.Since.Format(time.RFC3339)
+	}
+	return resp
+}
+
+type ReadOnlyOutput struct {
+	Body ReadOnlyResponse
+}
+
+type SetReadOnlyInput struct {
+	Body struct {
+		Enabled bool   `json:"enabled" doc:"Reject changes (true) or accept them again (false)"`
+		Reason  string `json:"reason,omitempty" maxLength:"255" doc:"Why changes are rejected, returned to the rejected callers"`
+	}
+}
+
+// readOnlyMiddleware answers 503 to the operations changing data while sw
+// is enabled, and 500 when its mode cannot be read.
+func readOnlyMiddleware(api huma.API, sw *app.ReadOnlySwitch, errs errorMapper) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		switch ctx.Method() {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next(ctx)
+			return
+		}
+		if readOnlySafeOperations[ctx.Operation().OperationID] {
+			next(ctx)
+			return
+		}
+		state, err := sw.State(ctx.Context())
+		if err != nil {
+			if errs.debug {
+				_ = huma.WriteErr(api, ctx, http.StatusInternalServerError, "internal server error", debugDetails(ctx.Context(), err)...)
+			} else {
+				_ = huma.WriteErr(api, ctx, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+		if !state.Enabled {
+			next(ctx)
+			return
+		}
+		msg := "service is read-only"
+		if state.Reason != "" {
+			msg += ": " + state.Reason
+		}
+		_ = huma.WriteErr(api, ctx, http.StatusServiceUnavailable, msg)
+	}
+}
+
+func registerReadOnly(api huma.API, sw *app.ReadOnlySwitch, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-read-only",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/system/read-only",
+		Summary:     "Get the read-only mode",
+		Tags:        []string{"System"},
+	}, func(ctx context.Context, _ *struct{}) (*ReadOnlyOutput, error) {
+		state, err := sw.State(ctx)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &ReadOnlyOutput{Body: toReadOnlyResponse(state)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-read-only",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/system/read-only",
+		Summary:     "Switch the read-only mode",
+		Description: "For database migrations and disaster recovery failovers: while enabled, requests changing " +
+			"data get 503 and background jobs are paused; reads keep working. The mode and the pause are " +
+			"stored in the database, so they apply to every instance. Requires the admin role.",
+		Tags: []string{"System"},
+	}, func(ctx context.Context, input *SetReadOnlyInput) (*ReadOnlyOutput, error) {
+		state, err := sw.Set(ctx, input.Body.Enabled, input.Body.Reason)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &ReadOnlyOutput{Body: toReadOnlyResponse(state)}, nil
+	})
+}
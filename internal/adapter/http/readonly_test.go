@@ -0,0 +1,112 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func putReadOnly(t *testing.T, url, body, secret string) *http.Response {
+	t.Helper()
+
+	headers := map[string]string{"X-Actor": "ops@example.com"}
+	if secret != "" {
+		headers["Authorization"] = "Bearer " + secret
+	}
+	return doRequestWithHeaders(t, http.MethodPut, url+"/api/v1/system/read-only", body, headers)
+}
+
+func TestReadOnly(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	keys := app.NewAdminKeyService(sqlite.NewAdminKeyRepository(repo.DB()))
+	secrets := map[domain.Role]string{}
+	for _, role := range []domain.Role{domain.RoleOperator, domain.RoleAdmin} {
+		_, secret, err := keys.Create(context.Background(), "ops@example.com", role)
+		if err != nil {
+			t.Fatalf("Create %s key: %v", role, err)
+		}
+		secrets[role] = secret
+	}
+	srv := serve(t, repo, adapter.WithAuthentication(keys),
+		adapter.WithReadOnly(app.NewReadOnlySwitch(sqlite.NewReadOnlyRepository(repo.DB()), nil)))
+	admin := map[string]string{"Authorization": "Bearer " + secrets[domain.RoleAdmin]}
+
+	resp := doRequestWithHeaders(t, http.MethodPost, srv.URL+"/api/v1/tenants", `{"name":"Acme"}`, admin)
+	var tenant adapter.TenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tenant); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	resp.Body.Close()
+
+	for secret, want := range map[string]int{
+		"":                           http.StatusUnauthorized,
+		"wrong":                      http.StatusUnauthorized,
+		secrets[domain.RoleOperator]: http.StatusForbidden,
+	} {
+		resp := putReadOnly(t, srv.URL, `{"enabled":true}`, secret)
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("key %q: status = %d, want %d", secret, resp.StatusCode, want)
+		}
+	}
+
+	resp = putReadOnly(t, srv.URL, `{"enabled":true,"reason":"failover"}`, secrets[domain.RoleAdmin])
+	defer resp.Body.Close()
+	var state adapter.ReadOnlyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&state); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !state.Enabled || state.Actor != "ops@example.com" {
+		t.Fatalf("state = %+v, want enabled by ops@example.com", state)
+	}
+
+	resp = doRequestWithHeaders(t, http.MethodPost, srv.URL+"/api/v1/tenants", `{"name":"Globex"}`, admin)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || !strings.Contains(string(body), "failover") {
+		t.Errorf("create: status = %d, body = %s, want 503 with the reason", resp.StatusCode, body)
+	}
+
+	for _, url := range []string{"/api/v1/tenants/" + tenant.ID, "/api/v1/tenants", "/api/v1/system/read-only"} {
+		resp = doRequestWithHeaders(t, http.MethodGet, srv.URL+url, "", admin)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GET %s: status = %d, want %d", url, resp.StatusCode, http.StatusOK)
+		}
+	}
+
+	resp = putReadOnly(t, srv.URL, `{"enabled":false}`, secrets[domain.RoleAdmin])
+	resp.Body.Close()
+	resp = doRequestWithHeaders(t, http.MethodPost, srv.URL+"/api/v1/tenants", `{"name":"Globex"}`, admin)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("create after disabling: status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestReadOnly_WithoutAuthentication(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	srv := serve(t, repo, adapter.WithReadOnly(app.NewReadOnlySwitch(sqlite.NewReadOnlyRepository(repo.DB()), nil)))
+
+	resp := putReadOnly(t, srv.URL, `{"enabled":true}`, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d: without authentication every caller is trusted", resp.StatusCode, http.StatusOK)
+	}
+}
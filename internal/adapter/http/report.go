@@ -0,0 +1,157 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// defaultReportPeriods is how many periods a report covers when from is omitted.
+const defaultReportPeriods = 12
+
+// GrowthPeriodResponse summarizes how the tenant base changed over a period.
+type GrowthPeriodResponse struct {
+	Start     string `json:"start" doc:"Start of the period (ISO 8601, inclusive)"`
+	End       string `json:"end" doc:"End of the period (ISO 8601, exclusive)"`
+	New       int    `json:"new" doc:"Tenants created"`
+	Churned   int    `json:"churned" doc:"Tenants deleted"`
+	Suspended int    `json:"suspended" doc:"Suspensions"`
+	Net       int    `json:"net" doc:"Change in the number of active tenants"`
+	Active    int    `json:"active" doc:"Active tenants at the end of the period"`
+}
+
+// GrowthReportResponse is the growth report of consecutive periods.
+type GrowthReportResponse struct {
+	Period  string                 `json:"period" doc:"Period size"`
+	Periods []GrowthPeriodResponse `json:"periods" doc:"Periods, oldest first"`
+}
+
+type GrowthReportInput struct {
+	Period string    `query:"period" enum:"day,week,month" default:"month" doc:"Period size (UTC; weeks start on Monday)"`
+	From   time.Time `query:"from" doc:"Start of the report (RFC 3339); 12 periods before to when omitted"`
+	To     time.Time `query:"to" doc:"End of the report (RFC 3339); now when omitted"`
+}
+
+type GrowthReportOutput struct {
+	Body GrowthReportResponse
+}
+
+type GrowthReportCSVOutput struct {
+	ContentType        string `header:"Content-Type"`
+	ContentDisposition string `header:"Content-Disposition"`
+	Body               []byte
+}
+
+func registerReports(api huma.API, svc *app.TenantService, errs errorMapper) {
+	description := "Counts tenants created, deleted (churned) and suspended per period, with the change in " +
+		"and number of active tenants, from the status history. At most 400 periods are returned."
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-growth-report",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/reports/growth",
+		Summary:     "Tenant growth and churn per period",
+		Description: description,
+		Tags:        []string{"Reports"},
+	}, func(ctx context.Context, input *GrowthReportInput) (*GrowthReportOutput, error) {
+		periods, err := growthReport(ctx, svc, input, errs)
+		if err != nil {
+			return nil, err
+		}
+		resp := GrowthReportResponse{Period: input.Period, Periods: make([]GrowthPeriodResponse, 0, len(periods))}
+		for _, p := range periods {
+			resp.Periods = append(resp.Periods, toGrowthPeriodResponse(p))
+		}
+		return &GrowthReportOutput{Body: resp}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "export-growth-report",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/reports/growth.csv",
+		Summary:     "Export tenant growth and churn as CSV",
+		Description: description + " One row per period, with a header row.",
+		Tags:        []string{"Reports"},
+		Responses: map[string]*huma.Response{
+			"200": {Description: "CSV report", Content: map[string]*huma.MediaType{"text/csv": {}}},
+		},
+	}, exportGrowthCSV(svc, errs))
+}
+
+// exportGrowthCSV returns the handler of the CSV report, shared with its
+// signed download link.
+func exportGrowthCSV(svc *app.TenantService, errs errorMapper) func(context.Context, *GrowthReportInput) (*GrowthReportCSVOutput, error) {
+	return func(ctx context.Context, input *GrowthReportInput) (*GrowthReportCSVOutput, error) {
+		periods, err := growthReport(ctx, svc, input, errs)
+		if err != nil {
+			return nil, err
+		}
+		body, err := growthCSV(periods)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &GrowthReportCSVOutput{
+			ContentType:        "text/csv; charset=utf-8",
+			ContentDisposition: `attachment; filename="growth-` + input.Period + `.csv"`,
+			Body:               body,
+		}, nil
+	}
+}
+
+func growthReport(ctx context.Context, svc *app.TenantService, input *GrowthReportInput, errs errorMapper) ([]domain.GrowthPeriod, error) {
+	period := domain.ReportPeriod(input.Period)
+	to, from := input.To, input.From
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.IsZero() {
+		from = period.Add(period.Truncate(to), 1-defaultReportPeriods)
+	}
+	if !from.Before(to) {
+		return nil, huma.Error422UnprocessableEntity("from must be before to")
+	}
+	periods, err := svc.GrowthReport(ctx, period, from, to)
+	if err != nil {
+		return nil, errs.toHuma(ctx, err)
+	}
+	return periods, nil
+}
+
+func toGrowthPeriodResponse(p domain.GrowthPeriod) GrowthPeriodResponse {
+	return GrowthPeriodResponse{
+		Start:     p.Start.Format("2006-01-02T15:04:05Z"),
+		End:       p.End.Format("2006-01-02T15:04:05Z"),
+		New:       p.New,
+		Churned:   p.Churned,
+		Suspended: p.Suspended,
+		Net:       p.Net,
+		Active:    p.Active,
+	}
+}
+
+func growthCSV(periods []domain.GrowthPeriod) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"period_start", "period_end", "new", "churned", "suspended", "net", "active"})
+	for _, p := range periods {
+		_ = w.Write([]string{
+			p.Start.Format("2006-01-02"),
+			p.End.Format("2006-01-02"),
+			strconv.Itoa(p.New),
+			strconv.Itoa(p.Churned),
+			strconv.Itoa(p.Suspended),
+			strconv.Itoa(p.Net),
+			strconv.Itoa(p.Active),
+		})
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,104 @@
+package http_test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+func newReportTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{},
+		app.WithStatusHistory(sqlite.NewStatusHistoryRepository(repo.DB())),
+		app.WithStatusCounter(sqlite.NewStatusCounter(repo.DB())))
+	return serveService(t, svc)
+}
+
+func TestGrowthReport_JSON(t *testing.T) {
+	srv := newReportTestServer(t)
+	mustCreateTenant(t, srv, "Acme", "acme", "free")
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/reports/growth?period=day", "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var report adapter.GrowthReportResponse
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if report.Period != "day" || len(report.Periods) != 12 {
+		t.Fatalf("report has %d %s periods, want 12 days", len(report.Periods), report.Period)
+	}
+	if today := report.Periods[11]; today.New != 1 {
+		t.Errorf("today = %+v, want 1 new tenant", today)
+	}
+}
+
+func TestGrowthReport_CSV(t *testing.T) {
+	srv := newReportTestServer(t)
+
+	resp := doRequest(t, http.MethodGet,
+		srv.URL+"/api/v1/reports/growth.csv?period=month&from=2026-01-01T00:00:00Z&to=2026-03-01T00:00:00Z", "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "text/csv; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	if len(records) != 3 || records[0][0] != "period_start" || records[1][0] != "2026-01-01" || records[2][1] != "2026-03-01" {
+		t.Errorf("records = %v, want a header and January and February", records)
+	}
+}
+
+func TestGrowthReport_RejectsInvertedRange(t *testing.T) {
+	srv := newReportTestServer(t)
+
+	resp := doRequest(t, http.MethodGet,
+		srv.URL+"/api/v1/reports/growth?from=2026-03-01T00:00:00Z&to=2026-01-01T00:00:00Z", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestStatusCounts(t *testing.T) {
+	srv := newReportTestServer(t)
+	tenant := mustCreateTenant(t, srv, "Acme", "acme", "free")
+	mustCreateTenant(t, srv, "Globex", "globex", "free")
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/"+tenant.ID+"/events", `{"event":"provision_complete"}`)
+	resp.Body.Close()
+
+	resp = doRequest(t, http.MethodGet, srv.URL+"/api/v1/reports/status-counts", "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var counts adapter.StatusCountsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&counts); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if counts.Total != 2 || counts.ByStatus["active"] != 1 || counts.ByStatus["creating"] != 1 {
+		t.Errorf("counts = %+v, want one active and one creating", counts)
+	}
+}
@@ -0,0 +1,206 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithResellers exposes the delegated admin API for resellers under
+// /api/v1/resellers.
+func WithResellers(rs *app.ResellerService) Option {
+	return func(o *options) { o.resellers = rs }
+}
+
+// ResellerResponse is the API representation of a reseller.
+type ResellerResponse struct {
+	ID          string `json:"id" doc:"Unique identifier"`
+	Name        string `json:"name" doc:"Display name"`
+	TenantQuota int    `json:"tenant_quota" doc:"Max tenants (not yet deleted) the reseller may manage"`
+	CreatedAt   string `json:"created_at" doc:"Creation timestamp (ISO 8601)"`
+}
+
+func toResellerResponse(r domain.Reseller) ResellerResponse {
+	return ResellerResponse{
+		ID:          r.ID,
+		Name:        r.Name,
+		TenantQuota: r.TenantQuota,
+		CreatedAt:   r.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+type CreateResellerInput struct {
+	Body struct {
+		Name        string `json:"name" minLength:"1" maxLength:"255" doc:"Display name"`
+		TenantQuota int    `json:"tenant_quota" minimum:"0" doc:"Max tenants (not yet deleted) the reseller may manage"`
+	}
+}
+
+type ResellerOutput struct {
+	Body ResellerResponse
+}
+
+type GetResellerInput struct {
+	ResellerID string `path:"reseller_id" doc:"Reseller ID"`
+}
+
+type CreateResellerTenantInput struct {
+	ResellerID string `path:"reseller_id" doc:"Reseller ID"`
+	Body       struct {
+		Name string `json:"name" minLength:"1" maxLength:"255" doc:"Display name"`
+		Slug string `json:"slug,omitempty" doc:"URL-friendly identifier (lowercase, hyphens); derived from the name when omitted"`
+		Plan string `json:"plan,omitempty" default:"free" doc:"Subscription plan"`
+	}
+}
+
+type ListResellerTenantsInput struct {
+	ResellerID string            `path:"reseller_id" doc:"Reseller ID"`
+	Status     []lifecycleStatus `query:"status" required:"false" doc:"Filter by status (comma-separated, matches any)"`
+	Limit      int               `query:"limit" required:"false" default:"50" minimum:"1" maximum:"1000" doc:"Max results"`
+	Offset     int               `query:"offset" required:"false" default:"0" minimum:"0" doc:"Pagination offset"`
+}
+
+type ResellerTenantInput struct {
+	ResellerID string `path:"reseller_id" doc:"Reseller ID"`
+	ID         string `path:"id" doc:"Tenant ID"`
+}
+
+// ResellerUsageResponse is a reseller's consumption of its tenant quota.
+type ResellerUsageResponse struct {
+	Quota    int            `json:"quota" doc:"Max tenants (not yet deleted) the reseller may manage"`
+	Tenants  int            `json:"tenants" doc:"Tenants counting against the quota"`
+	ByStatus map[string]int `json:"by_status" doc:"Tenants counting against the quota, by status"`
+}
+
+type ResellerUsageOutput struct {
+	Body ResellerUsageResponse
+}
+
+func registerResellers(api huma.API, rs *app.ResellerService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "create-reseller",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/resellers",
+		Summary:     "Register a reseller",
+		Tags:        []string{"Resellers"},
+	}, func(ctx context.Context, input *CreateResellerInput) (*ResellerOutput, error) {
+		reseller, err := rs.Create(ctx, input.Body.Name, input.Body.TenantQuota)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &ResellerOutput{Body: toResellerResponse(reseller)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-reseller",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/resellers/{reseller_id}",
+		Summary:     "Get a reseller",
+		Tags:        []string{"Resellers"},
+	}, func(ctx context.Context, input *GetResellerInput) (*ResellerOutput, error) {
+		reseller, err := rs.Get(ctx, input.ResellerID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &ResellerOutput{Body: toResellerResponse(reseller)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-reseller-tenant",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/resellers/{reseller_id}/tenants",
+		Summary:     "Create a tenant for a reseller",
+		Description: "Fails with 409 when the reseller already manages as many tenants as its quota allows.",
+		Tags:        []string{"Resellers"},
+	}, func(ctx context.Context, input *CreateResellerTenantInput) (*GetTenantOutput, error) {
+		tenant, err := rs.CreateTenant(ctx, input.ResellerID, input.Body.Name, input.Body.Slug, input.Body.Plan)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &GetTenantOutput{Body: toTenantResponse(tenant)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-reseller-tenants",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/resellers/{reseller_id}/tenants",
+		Summary:     "List a reseller's tenants",
+		Tags:        []string{"Resellers"},
+	}, func(ctx context.Context, input *ListResellerTenantsInput) (*ListTenantsOutput, error) {
+		filter := domain.ListFilter{Limit: input.Limit, Offset: input.Offset}
+		for _, st := range input.Status {
+			filter.Statuses = append(filter.Statuses, domain.Status(st))
+		}
+
+		tenants, total, err := rs.Tenants(ctx, input.ResellerID, filter)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+
+		items := make(TenantList, len(tenants))
+		for i, t := range tenants {
+			items[i] = toTenantResponse(t)
+		}
+		return &ListTenantsOutput{Body: TenantListResponse{
+			Items:  items,
+			Total:  total,
+			Limit:  input.Limit,
+			Offset: input.Offset,
+		}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-reseller-tenant",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/resellers/{reseller_id}/tenants/{id}",
+		Summary:     "Get one of a reseller's tenants",
+		Description: "Tenants managed by someone else are reported as not found.",
+		Tags:        []string{"Resellers"},
+	}, func(ctx context.Context, input *ResellerTenantInput) (*GetTenantOutput, error) {
+		tenant, err := rs.Tenant(ctx, input.ResellerID, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &GetTenantOutput{Body: toTenantResponse(tenant)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "suspend-reseller-tenant",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/resellers/{reseller_id}/tenants/{id}/suspend",
+		Summary:     "Suspend one of a reseller's tenants",
+		Tags:        []string{"Resellers"},
+	}, func(ctx context.Context, input *ResellerTenantInput) (*GetTenantOutput, error) {
+		tenant, err := rs.Suspend(ctx, input.ResellerID, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &GetTenantOutput{Body: toTenantResponse(tenant)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-reseller-usage",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/resellers/{reseller_id}/usage",
+		Summary:     "Get a reseller's quota usage",
+		Tags:        []string{"Resellers"},
+	}, func(ctx context.Context, input *GetResellerInput) (*ResellerUsageOutput, error) {
+		usage, err := rs.Usage(ctx, input.ResellerID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		byStatus := make(map[string]int, len(usage.ByStatus))
+		for st, n := range usage.ByStatus {
+			byStatus[string(st)] = n
+		}
+		return &ResellerUsageOutput{Body: ResellerUsageResponse{
+			Quota:    usage.Quota,
+			Tenants:  usage.Tenants,
+			ByStatus: byStatus,
+		}}, nil
+	})
+}
@@ -0,0 +1,124 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+func newResellerTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{})
+	rs := app.NewResellerService(sqlite.NewResellerRepository(repo.DB()), svc)
+	return serveService(t, svc, adapter.WithResellers(rs))
+}
+
+func mustCreateReseller(t *testing.T, srv *httptest.Server, name string, quota int) adapter.ResellerResponse {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]any{"name": name, "tenant_quota": quota})
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/resellers", string(body))
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("create reseller: status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var reseller adapter.ResellerResponse
+	if err := json.NewDecoder(resp.Body).Decode(&reseller); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return reseller
+}
+
+func TestResellerTenants_CreateWithinQuota(t *testing.T) {
+	srv := newResellerTestServer(t)
+	reseller := mustCreateReseller(t, srv, "Partner", 1)
+	base := srv.URL + "/api/v1/resellers/" + reseller.ID
+
+	resp := doRequest(t, http.MethodPost, base+"/tenants", `{"name":"Acme","slug":"acme"}`)
+	var tenant adapter.TenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tenant); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || tenant.ResellerID != reseller.ID {
+		t.Fatalf("create: status = %d, tenant = %+v", resp.StatusCode, tenant)
+	}
+
+	resp = doRequest(t, http.MethodPost, base+"/tenants", `{"name":"Globex","slug":"globex"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("over quota: status = %d, want %d", resp.StatusCode, http.StatusConflict)
+	}
+
+	resp = doRequest(t, http.MethodGet, base+"/usage", "")
+	defer resp.Body.Close()
+	var usage adapter.ResellerUsageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&usage); err != nil {
+		t.Fatalf("decode usage: %v", err)
+	}
+	if usage.Quota != 1 || usage.Tenants != 1 || usage.ByStatus["creating"] != 1 {
+		t.Errorf("usage = %+v, want 1 creating tenant of 1", usage)
+	}
+}
+
+func TestResellerTenants_ScopedToReseller(t *testing.T) {
+	srv := newResellerTestServer(t)
+	mine := mustCreateReseller(t, srv, "Mine", 5)
+	other := mustCreateReseller(t, srv, "Other", 5)
+	direct := mustCreateTenant(t, srv, "Direct", "direct", "pro")
+
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/resellers/"+other.ID+"/tenants", `{"name":"Globex"}`)
+	var foreign adapter.TenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&foreign); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	resp.Body.Close()
+
+	base := srv.URL + "/api/v1/resellers/" + mine.ID
+	for _, id := range []string{foreign.ID, direct.ID} {
+		resp := doRequest(t, http.MethodGet, base+"/tenants/"+id, "")
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("get %s: status = %d, want %d", id, resp.StatusCode, http.StatusNotFound)
+		}
+		resp = doRequest(t, http.MethodPost, base+"/tenants/"+id+"/suspend", "")
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("suspend %s: status = %d, want %d", id, resp.StatusCode, http.StatusNotFound)
+		}
+	}
+
+	resp = doRequest(t, http.MethodGet, base+"/tenants", "")
+	defer resp.Body.Close()
+	var list adapter.TenantListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if list.Total != 0 || len(list.Items) != 0 {
+		t.Errorf("list = %+v, want no tenants", list)
+	}
+}
+
+func TestReseller_NotFound(t *testing.T) {
+	srv := newResellerTestServer(t)
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/resellers/rsl_missing/usage", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// QueueLoadResponse is the load of one job queue.
+type QueueLoadResponse struct {
+	Queue              string                 `json:"queue" doc:"Queue name"`
+	Available          int                    `json:"available" doc:"Jobs ready to run"`
+	Running            int                    `json:"running" doc:"Jobs being worked"`
+	CompletedPerMinute float64                `json:"completed_per_minute" doc:"Recent processing rate"`
+	Priorities         []PriorityLoadResponse `json:"priorities,omitempty" doc:"Available and running jobs per priority, from the highest"`
+}
+
+// PriorityLoadResponse is the part of a queue's load at one priority.
+type PriorityLoadResponse struct {
+	Priority  string `json:"priority" enum:"high,normal,low" doc:"Priority class"`
+	Available int    `json:"available" doc:"Jobs ready to run"`
+	Running   int    `json:"running" doc:"Jobs being worked"`
+}
+
+// ScalingResponse is the autoscaling signal for the worker deployment.
+type ScalingResponse struct {
+	Queues                  []QueueLoadResponse `json:"queues" doc:"Load per queue"`
+	Backlog                 int                 `json:"backlog" doc:"Available plus running jobs across queues"`
+	ProcessingRatePerMinute float64             `json:"processing_rate_per_minute" doc:"Jobs completed per minute across queues"`
+	SuggestedWorkers        int                 `json:"suggested_workers" doc:"Workers needed for the current backlog"`
+}
+
+type ScalingOutput struct {
+	Body ScalingResponse
+}
+
+// RegisterScaling adds the autoscaling signal endpoint. It is meant for
+// KEDA's metrics-api scaler (valueLocation: suggested_workers) and mirrors
+// the tenantiq.workers.suggested metric.
+func RegisterScaling(api huma.API, queue domain.QueueMonitor, policy domain.ScalingPolicy) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-scaling",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/system/scaling",
+		Summary:     "Worker autoscaling signal",
+		Tags:        []string{"System"},
+	}, func(ctx context.Context, _ *struct{}) (*ScalingOutput, error) {
+		loads, err := queue.Loads(ctx)
+		if err != nil {
+			return nil, huma.Error503ServiceUnavailable("queue load unavailable")
+		}
+
+		out := &ScalingOutput{}
+		out.Body.Queues = make([]QueueLoadResponse, len(loads))
+		for i, l := range loads {
+			out.Body.Queues[i] = QueueLoadResponse{
+				Queue:              l.Queue,
+				Available:          l.Available,
+				Running:            l.Running,
+				CompletedPerMinute: l.CompletedPerMinute,
+			}
+			for _, p := range l.Priorities {
+				out.Body.Queues[i].Priorities = append(out.Body.Queues[i].Priorities, PriorityLoadResponse{
+					Priority:  string(p.Priority),
+					Available: p.Available,
+					Running:   p.Running,
+				})
+			}
+			out.Body.Backlog += l.Backlog()
+			out.Body.ProcessingRatePerMinute += l.CompletedPerMinute
+		}
+		out.Body.SuggestedWorkers = policy.SuggestWorkers(loads)
+		return out, nil
+	})
+}
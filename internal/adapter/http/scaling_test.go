@@ -0,0 +1,70 @@
+package http_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+	"github.com/go-chi/chi/v5"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func newScalingServer(t *testing.T, queue domain.QueueMonitor) *httptest.Server {
+	t.Helper()
+
+	router := chi.NewMux()
+	api := humachi.New(router, huma.DefaultConfig("tenantiq", "0.1.0"))
+	adapter.RegisterScaling(api, queue, domain.ScalingPolicy{JobsPerWorker: 10, MinWorkers: 1})
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestScaling(t *testing.T) {
+	srv := newScalingServer(t, &stubQueue{loads: []domain.QueueLoad{
+		{Queue: "default", Available: 20, Running: 2, CompletedPerMinute: 3},
+		{Queue: "sync", Available: 3, CompletedPerMinute: 0.5},
+	}})
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/system/scaling", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var body adapter.ScalingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Queues) != 2 {
+		t.Fatalf("got %d queues, want 2", len(body.Queues))
+	}
+	if body.Backlog != 25 {
+		t.Errorf("Backlog = %d, want 25", body.Backlog)
+	}
+	if body.ProcessingRatePerMinute != 3.5 {
+		t.Errorf("ProcessingRatePerMinute = %v, want 3.5", body.ProcessingRatePerMinute)
+	}
+	if body.SuggestedWorkers != 3 {
+		t.Errorf("SuggestedWorkers = %d, want 3", body.SuggestedWorkers)
+	}
+}
+
+func TestScaling_QueueUnavailable(t *testing.T) {
+	srv := newScalingServer(t, &stubQueue{err: errors.New("database is locked")})
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/system/scaling", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+}
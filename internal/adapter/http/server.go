@@ -0,0 +1,264 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ServerConfig holds the limits of the HTTP server. The defaults suit a
+// production deployment behind a load balancer: slow or stalled clients
+// are cut off, and idle keep-alive connections outlive the balancer's own
+// idle timeout (60s on most) so it never reuses a connection the server
+// is closing.
+type ServerConfig struct {
+	// Addr is the listen address, e.g. ":8080".
+	Addr string
+	// ReadHeaderTimeout bounds reading the request headers, against
+	// slowloris clients. Default 10s.
+	ReadHeaderTimeout time.Duration
+	// ReadTimeout bounds reading the whole request, body included.
+	// Default 30s; 0 means no limit.
+	ReadTimeout time.Duration
+	// WriteTimeout bounds the time from the end of the request headers to
+	// the end of the response. Default 60s; 0 means no limit. WebSocket
+	// connections are exempt once upgraded.
+	WriteTimeout time.Duration
+	// IdleTimeout is how long a keep-alive connection waits for the next
+	// request. Default 120s.
+	IdleTimeout time.Duration
+	// MaxHeaderBytes bounds the size of the request headers. Default 1 MiB.
+	MaxHeaderBytes int
+	// MaxBodyBytes bounds the size of request bodies, enforced by
+	// LimitBody and WithMaxBodyBytes. Default 1 MiB.
+	MaxBodyBytes int64
+	// KeepAlives enables HTTP keep-alive. Default true; disabling it makes
+	// every request open a new connection.
+	KeepAlives bool
+	// TLSCertFile and TLSKeyFile hold the PEM certificate chain and key the
+	// server is reached with over HTTPS; plain HTTP when unset. They are
+	// read by a CertificateReloader, so renewed certificates can be loaded
+	// without a restart.
+	TLSCertFile string
+	TLSKeyFile  string
+	// ClientCAs, when set, are the CAs the clients' certificates must be
+	// issued by (mutual TLS): connections without a valid one are refused
+	// during the handshake. Requires TLSCertFile.
+	ClientCAs *x509.CertPool
+}
+
+// TLS reports whether the server is reached over HTTPS.
+func (c ServerConfig) TLS() bool {
+	return c.TLSCertFile != ""
+}
+
+// DefaultServerConfig returns the production defaults listening on port.
+func DefaultServerConfig(port string) ServerConfig {
+	return ServerConfig{
+		Addr:              ":" + port,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      60 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		MaxHeaderBytes:    http.DefaultMaxHeaderBytes,
+		MaxBodyBytes:      1 << 20,
+		KeepAlives:        true,
+	}
+}
+
+// ServerConfigFromEnv reads the HTTP_* environment variables over the
+// defaults of DefaultServerConfig.
+func ServerConfigFromEnv(port string) (ServerConfig, error) {
+	cfg := DefaultServerConfig(port)
+	for key, d := range map[string]*time.Duration{
+		"HTTP_READ_HEADER_TIMEOUT": &cfg.ReadHeaderTimeout,
+		"HTTP_READ_TIMEOUT":        &cfg.ReadTimeout,
+		"HTTP_WRITE_TIMEOUT":       &cfg.WriteTimeout,
+		"HTTP_IDLE_TIMEOUT":        &cfg.IdleTimeout,
+	} {
+		if v := os.Getenv(key); v != "" {
+			parsed, err := time.ParseDuration(v)
+			if err != nil {
+				return ServerConfig{}, fmt.Errorf("%s: %w", key, err)
+			}
+			*d = parsed
+		}
+	}
+	if v := os.Getenv("HTTP_MAX_HEADER_BYTES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return ServerConfig{}, fmt.Errorf("HTTP_MAX_HEADER_BYTES: must be a positive integer, got %q", v)
+		}
+		cfg.MaxHeaderBytes = n
+	}
+	if v := os.Getenv("HTTP_MAX_BODY_BYTES"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			return ServerConfig{}, fmt.Errorf("HTTP_MAX_BODY_BYTES: must be a positive integer, got %q", v)
+		}
+		cfg.MaxBodyBytes = n
+	}
+	if v := os.Getenv("HTTP_KEEP_ALIVES"); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return ServerConfig{}, fmt.Errorf("HTTP_KEEP_ALIVES: %w", err)
+		}
+		cfg.KeepAlives = enabled
+	}
+	cfg.TLSCertFile, cfg.TLSKeyFile = os.Getenv("TLS_CERT_FILE"), os.Getenv("TLS_KEY_FILE")
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return ServerConfig{}, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if path := os.Getenv("TLS_CLIENT_CA_FILE"); path != "" {
+		if !cfg.TLS() {
+			return ServerConfig{}, errors.New("TLS_CLIENT_CA_FILE requires TLS_CERT_FILE and TLS_KEY_FILE")
+		}
+		pool, err := LoadCertPool(path)
+		if err != nil {
+			return ServerConfig{}, fmt.Errorf("TLS_CLIENT_CA_FILE: %w", err)
+		}
+		cfg.ClientCAs = pool
+	}
+	return cfg, nil
+}
+
+// LoadCertPool reads a bundle of PEM certificates, such as the CAs of
+// client certificates.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificate in %s", path)
+	}
+	return pool, nil
+}
+
+// NewServer returns a server for handler configured with cfg.
+func NewServer(cfg ServerConfig, handler http.Handler) *http.Server {
+	srv := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+	srv.SetKeepAlivesEnabled(cfg.KeepAlives)
+	if cfg.TLS() {
+		srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if cfg.ClientCAs != nil {
+		srv.TLSConfig.ClientCAs = cfg.ClientCAs
+		srv.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return srv
+}
+
+// LimitBody rejects requests whose body is over maxBytes with 413 Payload
+// Too Large before they reach a handler, and cuts off bodies sent without
+// a Content-Length (chunked) once they pass it. API operations answer 413
+// for those too when held to the same limit (see WithMaxBodyBytes).
+func LimitBody(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				status := http.StatusRequestEntityTooLarge
+				w.Header().Set("Content-Type", "application/problem+json")
+				w.Header().Set("Connection", "close")
+				w.WriteHeader(status)
+				_ = json.NewEncoder(w).Encode(huma.ErrorModel{
+					Status: status,
+					Title:  http.StatusText(status),
+					Detail: fmt.Sprintf("request body is too large limit=%d bytes", maxBytes),
+				})
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// WithMaxBodyBytes holds the request bodies of every API operation to
+// maxBytes instead of Huma's default of 1 MiB.
+func WithMaxBodyBytes(maxBytes int64) Option {
+	return func(o *options) { o.maxBodyBytes = maxBytes }
+}
+
+// limitBodies sets the body limit of every operation registered from now
+// on. Huma reads bodies up to the limit and answers 413 past it.
+func limitBodies(api huma.API, maxBytes int64) {
+	oapi := api.OpenAPI()
+	oapi.OnAddOperation = append(oapi.OnAddOperation, func(_ *huma.OpenAPI, op *huma.Operation) {
+		if op.RequestBody != nil {
+			op.MaxBodyBytes = maxBytes
+		}
+	})
+}
+
+// ListenAndServe serves srv over HTTPS with the certificate of certs, or
+// over plain HTTP when certs is nil.
+func ListenAndServe(srv *http.Server, certs *CertificateReloader) error {
+	if certs == nil {
+		return srv.ListenAndServe()
+	}
+	if srv.TLSConfig == nil {
+		srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	srv.TLSConfig.GetCertificate = certs.GetCertificate
+	return srv.ListenAndServeTLS("", "")
+}
+
+// CertificateReloader holds the server certificate read from a pair of PEM
+// files, and reads them again on Reload, so certificates renewed on disk
+// (by cert-manager, certbot, ...) are served to new connections without a
+// restart.
+type CertificateReloader struct {
+	certFile, keyFile string
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+// NewCertificateReloader reads the certificate chain in certFile and its
+// key in keyFile.
+func NewCertificateReloader(certFile, keyFile string) (*CertificateReloader, error) {
+	r := &CertificateReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the files again. The certificate served so far is kept when
+// they cannot be read or do not hold a matching pair.
+func (r *CertificateReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("loading TLS certificate: %w", err)
+	}
+	r.mu.Lock()
+	r.cert = &cert
+	r.mu.Unlock()
+	return nil
+}
+
+// GetCertificate returns the certificate loaded last, for tls.Config.
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
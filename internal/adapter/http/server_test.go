@@ -0,0 +1,384 @@
+package http_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+	"github.com/go-chi/chi/v5"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+func TestServerConfigFromEnv_Defaults(t *testing.T) {
+	cfg, err := adapter.ServerConfigFromEnv("8080")
+	if err != nil {
+		t.Fatalf("ServerConfigFromEnv: %v", err)
+	}
+	if cfg != adapter.DefaultServerConfig("8080") {
+		t.Errorf("cfg = %+v, want the defaults", cfg)
+	}
+	if cfg.Addr != ":8080" || cfg.ReadHeaderTimeout != 10*time.Second || !cfg.KeepAlives {
+		t.Errorf("defaults = %+v", cfg)
+	}
+}
+
+func TestServerConfigFromEnv_CustomValues(t *testing.T) {
+	t.Setenv("HTTP_READ_TIMEOUT", "5s")
+	t.Setenv("HTTP_WRITE_TIMEOUT", "0")
+	t.Setenv("HTTP_IDLE_TIMEOUT", "75s")
+	t.Setenv("HTTP_MAX_HEADER_BYTES", "16384")
+	t.Setenv("HTTP_MAX_BODY_BYTES", "65536")
+	t.Setenv("HTTP_KEEP_ALIVES", "false")
+
+	cfg, err := adapter.ServerConfigFromEnv("9090")
+	if err != nil {
+		t.Fatalf("ServerConfigFromEnv: %v", err)
+	}
+	if cfg.ReadTimeout != 5*time.Second || cfg.WriteTimeout != 0 || cfg.IdleTimeout != 75*time.Second ||
+		cfg.MaxHeaderBytes != 16384 || cfg.MaxBodyBytes != 65536 || cfg.KeepAlives {
+		t.Errorf("cfg = %+v", cfg)
+	}
+
+	srv := adapter.NewServer(cfg, http.NotFoundHandler())
+	if srv.Addr != ":9090" || srv.ReadTimeout != cfg.ReadTimeout || srv.MaxHeaderBytes != 16384 {
+		t.Errorf("server = %+v, want the configured limits", srv)
+	}
+}
+
+func TestServerConfigFromEnv_Invalid(t *testing.T) {
+	for key, value := range map[string]string{
+		"HTTP_READ_TIMEOUT":     "soon",
+		"HTTP_MAX_HEADER_BYTES": "-1",
+		"HTTP_MAX_BODY_BYTES":   "1MB",
+		"HTTP_KEEP_ALIVES":      "maybe",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, value)
+			if _, err := adapter.ServerConfigFromEnv("8080"); err == nil {
+				t.Errorf("%s=%s accepted, want an error", key, value)
+			}
+		})
+	}
+}
+
+func TestEventFeed_OutlivesWriteTimeout(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	feed := adapter.NewEventFeed()
+	t.Cleanup(feed.Close)
+	svc := app.NewTenantService(repo, feed, &testValidator{})
+
+	cfg := adapter.DefaultServerConfig("0")
+	cfg.ReadTimeout, cfg.WriteTimeout = 100*time.Millisecond, 100*time.Millisecond
+	srv := httptest.NewUnstartedServer(feed)
+	srv.Config = adapter.NewServer(cfg, feed)
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	conn := dialFeed(t, srv)
+	time.Sleep(300 * time.Millisecond)
+
+	if reply := command(t, conn, adapter.FeedCommand{Action: "subscribe", Statuses: []string{"creating"}}); reply.Type != "subscription" {
+		t.Fatalf("reply = %+v, want the subscription", reply)
+	}
+	if _, err := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if frame := readFrame(t, conn); frame.Type != "event" {
+		t.Errorf("frame = %+v, want the event after the timeouts passed", frame)
+	}
+}
+
+func TestLimitBody(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{})
+
+	const limit = 64
+	router := chi.NewMux()
+	router.Use(adapter.LimitBody(limit))
+	adapter.Register(humachi.New(router, huma.DefaultConfig("tenantiq", "0.1.0")), svc, adapter.WithMaxBodyBytes(limit))
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	post := func(body io.Reader) int {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL+"/api/v1/tenants", body)
+		if err != nil {
+			t.Fatalf("creating request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("POST failed: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	large := `{"name":"Acme","slug":"acme","metadata":{"note":"` + strings.Repeat("x", limit) + `"}}`
+
+	if status := post(strings.NewReader(`{"name":"Acme","slug":"acme"}`)); status != http.StatusOK {
+		t.Errorf("small body: status = %d, want 200", status)
+	}
+	if status := post(strings.NewReader(large)); status != http.StatusRequestEntityTooLarge {
+		t.Errorf("large body: status = %d, want 413", status)
+	}
+	// Without a Content-Length, the body is only known to be too large
+	// once read.
+	if status := post(io.MultiReader(strings.NewReader(large))); status != http.StatusRequestEntityTooLarge {
+		t.Errorf("large chunked body: status = %d, want 413", status)
+	}
+}
+
+func TestServerConfigFromEnv_TLS(t *testing.T) {
+	caFile := writeCA(t, newTestCA(t))
+	t.Setenv("TLS_CERT_FILE", "server.pem")
+	t.Setenv("TLS_KEY_FILE", "server-key.pem")
+	t.Setenv("TLS_CLIENT_CA_FILE", caFile)
+
+	cfg, err := adapter.ServerConfigFromEnv("8443")
+	if err != nil {
+		t.Fatalf("ServerConfigFromEnv: %v", err)
+	}
+	if !cfg.TLS() || cfg.ClientCAs == nil {
+		t.Errorf("cfg = %+v, want TLS with client CAs", cfg)
+	}
+	srv := adapter.NewServer(cfg, http.NotFoundHandler())
+	if srv.TLSConfig == nil || srv.TLSConfig.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Errorf("TLS config = %+v, want client certificates required", srv.TLSConfig)
+	}
+}
+
+func TestServerConfigFromEnv_InvalidTLS(t *testing.T) {
+	tests := map[string]map[string]string{
+		"cert without key":  {"TLS_CERT_FILE": "server.pem"},
+		"client CA alone":   {"TLS_CLIENT_CA_FILE": writeCA(t, newTestCA(t))},
+		"missing client CA": {"TLS_CERT_FILE": "server.pem", "TLS_KEY_FILE": "key.pem", "TLS_CLIENT_CA_FILE": filepath.Join(t.TempDir(), "none.pem")},
+		"client CA not PEM": {"TLS_CERT_FILE": "server.pem", "TLS_KEY_FILE": "key.pem", "TLS_CLIENT_CA_FILE": writeFile(t, "ca.pem", "not a certificate")},
+	}
+	for name, env := range tests {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range env {
+				t.Setenv(k, v)
+			}
+			if _, err := adapter.ServerConfigFromEnv("8443"); err == nil {
+				t.Error("accepted, want an error")
+			}
+		})
+	}
+}
+
+func TestNewServer_MutualTLS(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	audit := &recordingAudit{}
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{}, app.WithAuditLogger(audit))
+	router := chi.NewMux()
+	adapter.Register(humachi.New(router, huma.DefaultConfig("tenantiq", "0.1.0")), svc)
+
+	ca := newTestCA(t)
+	t.Setenv("TLS_CERT_FILE", "unused.pem")
+	t.Setenv("TLS_KEY_FILE", "unused-key.pem")
+	t.Setenv("TLS_CLIENT_CA_FILE", writeCA(t, ca))
+	cfg, err := adapter.ServerConfigFromEnv("0")
+	if err != nil {
+		t.Fatalf("ServerConfigFromEnv: %v", err)
+	}
+	// httptest serves its own certificate, with the client checks of cfg.
+	srv := httptest.NewUnstartedServer(router)
+	srv.Config = adapter.NewServer(cfg, router)
+	srv.TLS = srv.Config.TLSConfig
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	create := func(client *http.Client) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL+"/api/v1/tenants",
+			strings.NewReader(`{"name":"Acme","slug":"acme"}`))
+		if err != nil {
+			t.Fatalf("creating request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return client.Do(req)
+	}
+
+	if resp, err := create(srv.Client()); err == nil {
+		resp.Body.Close()
+		t.Fatal("request without a client certificate succeeded, want the handshake refused")
+	}
+
+	client := srv.Client()
+	client.Transport.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{ca.issue(t, "billing.internal")}
+	resp, err := create(client)
+	if err != nil {
+		t.Fatalf("POST with a client certificate: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if len(audit.entries) != 1 || audit.entries[0].ClientCN != "billing.internal" {
+		t.Errorf("entries = %+v, want the create attributed to the client certificate", audit.entries)
+	}
+}
+
+func TestCertificateReloader(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeKeyPair(t, ca.issue(t, "old.example.com"), certFile, keyFile)
+
+	certs, err := adapter.NewCertificateReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("NewCertificateReloader: %v", err)
+	}
+	servedCN := func() string {
+		t.Helper()
+		cert, err := certs.GetCertificate(nil)
+		if err != nil {
+			t.Fatalf("GetCertificate: %v", err)
+		}
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			t.Fatalf("parsing certificate: %v", err)
+		}
+		return leaf.Subject.CommonName
+	}
+	if cn := servedCN(); cn != "old.example.com" {
+		t.Fatalf("served %q, want old.example.com", cn)
+	}
+
+	writeKeyPair(t, ca.issue(t, "new.example.com"), certFile, keyFile)
+	if err := certs.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if cn := servedCN(); cn != "new.example.com" {
+		t.Errorf("served %q after reload, want new.example.com", cn)
+	}
+
+	if err := os.WriteFile(keyFile, []byte("truncated"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := certs.Reload(); err == nil {
+		t.Error("Reload of a broken key succeeded, want an error")
+	}
+	if cn := servedCN(); cn != "new.example.com" {
+		t.Errorf("served %q after a failed reload, want the previous certificate", cn)
+	}
+
+	if _, err := adapter.NewCertificateReloader(certFile, keyFile); err == nil {
+		t.Error("NewCertificateReloader with a broken key succeeded, want an error")
+	}
+}
+
+// testCA issues client and server certificates.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating CA key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing CA certificate: %v", err)
+	}
+	return testCA{cert: cert, key: key}
+}
+
+// issue returns a client certificate for cn signed by the CA.
+func (ca testCA) issue(t *testing.T, cn string) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating client key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("creating client certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// writeKeyPair writes cert and its key as PEM files.
+func writeKeyPair(t *testing.T, cert tls.Certificate, certFile, keyFile string) {
+	t.Helper()
+	key, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+	if err != nil {
+		t.Fatalf("encoding key: %v", err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: key}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeCA writes the CA certificate as PEM and returns its path.
+func writeCA(t *testing.T, ca testCA) string {
+	t.Helper()
+	return writeFile(t, "ca.pem", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})))
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing %s: %v", name, err)
+	}
+	return path
+}
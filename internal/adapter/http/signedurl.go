@@ -0,0 +1,180 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/signedurl"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+// publicPrefix is where the routes reachable through signed URLs live;
+// only URLs under it can be signed.
+const publicPrefix = "/public/"
+
+// defaultSignedURLTTL is how long a signed URL is valid when the request
+// does not say.
+const defaultSignedURLTTL = time.Hour
+
+// WithSignedURLs enables POST /api/v1/signed-urls and the routes under
+// /public, which accept only URLs signed by signer. Links are valid for at
+// most maxTTL.
+func WithSignedURLs(signer *signedurl.Signer, maxTTL time.Duration) Option {
+	return func(o *options) {
+		o.signer = signer
+		o.signedURLMaxTTL = maxTTL
+	}
+}
+
+// SignedURLParams are the query parameters added by signing; they are
+// declared so the public routes document them.
+type SignedURLParams struct {
+	Expires   int64  `query:"expires" required:"true" doc:"Expiry of the link (Unix time)"`
+	Nonce     string `query:"nonce" doc:"Set on single-use links"`
+	Signature string `query:"signature" required:"true" doc:"HMAC-SHA256 of the path and query"`
+}
+
+type CreateSignedURLInput struct {
+	Body struct {
+		Path      string `json:"path" pattern:"^/public/" doc:"Path and query of the resource under /public (e.g. /public/tenants/ten_123/status)"`
+		ExpiresIn string `json:"expires_in,omitempty" doc:"Validity of the link as a Go duration (e.g. 15m, 24h); 1h by default"`
+		SingleUse bool   `json:"single_use,omitempty" doc:"Reject the link once it has been used"`
+	}
+}
+
+// SignedURLResponse is a link granting access to a public resource.
+type SignedURLResponse struct {
+	URL       string `json:"url" doc:"Signed path and query; prefix it with the service's public address"`
+	ExpiresAt string `json:"expires_at" doc:"When the link stops working (ISO 8601)"`
+	SingleUse bool   `json:"single_use" doc:"Whether the link works only once"`
+}
+
+type SignedURLOutput struct {
+	Body SignedURLResponse
+}
+
+// PublicStatusResponse is what a public status link reveals of a tenant.
+type PublicStatusResponse struct {
+	Name      string `json:"name" doc:"Display name"`
+	Status    string `json:"status" doc:"Lifecycle state"`
+	UpdatedAt string `json:"updated_at" doc:"Last update timestamp (ISO 8601)"`
+}
+
+type PublicStatusInput struct {
+	ID string `path:"id" doc:"Tenant ID"`
+	SignedURLParams
+}
+
+type PublicStatusOutput struct {
+	Body PublicStatusResponse
+}
+
+type PublicGrowthReportInput struct {
+	GrowthReportInput
+	SignedURLParams
+}
+
+func registerSignedURLs(api huma.API, svc *app.TenantService, signer *signedurl.Signer, maxTTL time.Duration, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "create-signed-url",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/signed-urls",
+		Summary:     "Create a signed link to a public resource",
+		Description: "Links grant access without credentials until they expire: public status pages " +
+			"(/public/tenants/{id}/status) and export downloads (/public/reports/growth.csv). " +
+			"The query of the path is signed as well, so it cannot be changed.",
+		Tags: []string{"Signed URLs"},
+	}, func(ctx context.Context, input *CreateSignedURLInput) (*SignedURLOutput, error) {
+		ttl := defaultSignedURLTTL
+		if input.Body.ExpiresIn != "" {
+			var err error
+			if ttl, err = time.ParseDuration(input.Body.ExpiresIn); err != nil || ttl <= 0 {
+				return nil, huma.Error422UnprocessableEntity("expires_in must be a positive duration such as 15m or 24h")
+			}
+		}
+		if ttl > maxTTL {
+			return nil, huma.Error422UnprocessableEntity("expires_in must be at most " + maxTTL.String())
+		}
+		if strings.Contains(input.Body.Path, "://") {
+			return nil, huma.Error422UnprocessableEntity("path must not include a scheme or host")
+		}
+
+		expires := time.Now().Add(ttl).Truncate(time.Second)
+		signed, err := signer.Sign(input.Body.Path, expires, input.Body.SingleUse)
+		if err != nil {
+			return nil, huma.Error422UnprocessableEntity(err.Error())
+		}
+		return &SignedURLOutput{Body: SignedURLResponse{
+			URL:       signed,
+			ExpiresAt: expires.UTC().Format("2006-01-02T15:04:05Z"),
+			SingleUse: input.Body.SingleUse,
+		}}, nil
+	})
+
+	verify := huma.Middlewares{signedURLMiddleware(api, signer, errs)}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-public-tenant-status",
+		Method:      http.MethodGet,
+		Path:        publicPrefix + "tenants/{id}/status",
+		Summary:     "Get a tenant's status through a signed link",
+		Description: "Shares a tenant's state with people without API access, e.g. on a status page.",
+		Tags:        []string{"Signed URLs"},
+		Middlewares: verify,
+	}, func(ctx context.Context, input *PublicStatusInput) (*PublicStatusOutput, error) {
+		tenant, err := svc.GetByID(ctx, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &PublicStatusOutput{Body: PublicStatusResponse{
+			Name:      tenant.Name,
+			Status:    string(tenant.Status),
+			UpdatedAt: tenant.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		}}, nil
+	})
+
+	if svc.ReportsEnabled() {
+		export := exportGrowthCSV(svc, errs)
+		huma.Register(api, huma.Operation{
+			OperationID: "download-growth-report",
+			Method:      http.MethodGet,
+			Path:        publicPrefix + "reports/growth.csv",
+			Summary:     "Download the growth report through a signed link",
+			Description: "Same CSV as /api/v1/reports/growth.csv, for links handed out to people without API access.",
+			Tags:        []string{"Signed URLs"},
+			Middlewares: verify,
+			Responses: map[string]*huma.Response{
+				"200": {Description: "CSV report", Content: map[string]*huma.MediaType{"text/csv": {}}},
+			},
+		}, func(ctx context.Context, input *PublicGrowthReportInput) (*GrowthReportCSVOutput, error) {
+			return export(ctx, &input.GrowthReportInput)
+		})
+	}
+}
+
+// signedURLMiddleware rejects requests whose URL is not signed by signer:
+// 403 for a missing or wrong signature, 410 once the link has expired or,
+// for single-use links, has been used.
+func signedURLMiddleware(api huma.API, signer *signedurl.Signer, errs errorMapper) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		u := ctx.URL()
+		err := signer.Verify(ctx.Context(), &u, time.Now())
+		switch {
+		case err == nil:
+			next(ctx)
+		case errors.Is(err, signedurl.ErrExpired), errors.Is(err, signedurl.ErrAlreadyUsed):
+			_ = huma.WriteErr(api, ctx, http.StatusGone, err.Error())
+		case errors.Is(err, signedurl.ErrInvalidSignature):
+			_ = huma.WriteErr(api, ctx, http.StatusForbidden, err.Error())
+		case errs.debug:
+			_ = huma.WriteErr(api, ctx, http.StatusInternalServerError, "internal server error", debugDetails(ctx.Context(), err)...)
+		default:
+			_ = huma.WriteErr(api, ctx, http.StatusInternalServerError, "internal server error")
+		}
+	}
+}
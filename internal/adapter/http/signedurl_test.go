@@ -0,0 +1,105 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/signedurl"
+	"github.com/neomorfeo/tenantiq/pkg/memory"
+)
+
+func newSignedURLTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	signer, err := signedurl.New([]byte(strings.Repeat("k", signedurl.MinKeyLength)), memory.NewNonceStore())
+	if err != nil {
+		t.Fatalf("signer: %v", err)
+	}
+	return newTestServer(t, adapter.WithSignedURLs(signer, 24*time.Hour))
+}
+
+func mustSignURL(t *testing.T, srv *httptest.Server, body string) adapter.SignedURLResponse {
+	t.Helper()
+	resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/signed-urls", body)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("sign: status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var signed adapter.SignedURLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&signed); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return signed
+}
+
+func TestSignedURL_PublicStatus(t *testing.T) {
+	srv := newSignedURLTestServer(t)
+	created := mustCreateTenant(t, srv, "Acme", "acme", "free")
+	path := "/public/tenants/" + created.ID + "/status"
+
+	signed := mustSignURL(t, srv, `{"path":"`+path+`","expires_in":"15m"}`)
+
+	resp := doRequest(t, http.MethodGet, srv.URL+signed.URL, "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var got adapter.PublicStatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Name != "Acme" || got.Status != "creating" {
+		t.Errorf("status = %+v", got)
+	}
+
+	unsigned := doRequest(t, http.MethodGet, srv.URL+path, "")
+	unsigned.Body.Close()
+	if unsigned.StatusCode != http.StatusForbidden {
+		t.Errorf("unsigned: status = %d, want %d", unsigned.StatusCode, http.StatusForbidden)
+	}
+
+	other := strings.Replace(signed.URL, created.ID, "ten_other", 1)
+	forged := doRequest(t, http.MethodGet, srv.URL+other, "")
+	forged.Body.Close()
+	if forged.StatusCode != http.StatusForbidden {
+		t.Errorf("other tenant: status = %d, want %d", forged.StatusCode, http.StatusForbidden)
+	}
+}
+
+func TestSignedURL_SingleUse(t *testing.T) {
+	srv := newSignedURLTestServer(t)
+	created := mustCreateTenant(t, srv, "Acme", "acme", "free")
+
+	signed := mustSignURL(t, srv, `{"path":"/public/tenants/`+created.ID+`/status","single_use":true}`)
+
+	first := doRequest(t, http.MethodGet, srv.URL+signed.URL, "")
+	first.Body.Close()
+	second := doRequest(t, http.MethodGet, srv.URL+signed.URL, "")
+	second.Body.Close()
+	if first.StatusCode != http.StatusOK || second.StatusCode != http.StatusGone {
+		t.Errorf("statuses = %d, %d; want %d, %d", first.StatusCode, second.StatusCode, http.StatusOK, http.StatusGone)
+	}
+}
+
+func TestSignedURL_Rejected(t *testing.T) {
+	srv := newSignedURLTestServer(t)
+
+	cases := map[string]string{
+		"not public":      `{"path":"/api/v1/tenants"}`,
+		"too long":        `{"path":"/public/tenants/ten_1/status","expires_in":"48h"}`,
+		"invalid expires": `{"path":"/public/tenants/ten_1/status","expires_in":"soon"}`,
+	}
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/signed-urls", body)
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusUnprocessableEntity {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+			}
+		})
+	}
+}
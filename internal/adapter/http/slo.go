@@ -0,0 +1,109 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// latencyBudgetExtension documents the latency budget of each operation in
+// the OpenAPI document, e.g. "300ms".
+const latencyBudgetExtension = "x-slo-latency-budget"
+
+// Latency budgets by method: reads answer from the database, changes may
+// also publish events.
+const (
+	readLatencyBudget  = 300 * time.Millisecond
+	writeLatencyBudget = time.Second
+)
+
+// operationBudgets are the operations held to another latency budget than
+// their method's (see latencyBudget).
+var operationBudgets = map[string]time.Duration{
+	// Checked by gateways and browsers on their tenants' requests.
+	"verify-api-key":           50 * time.Millisecond,
+	"get-status-widget":        100 * time.Millisecond,
+	"get-public-tenant-status": 100 * time.Millisecond,
+	"list-rate-limits":         100 * time.Millisecond,
+	"liveness":                 50 * time.Millisecond,
+	"readiness":                200 * time.Millisecond,
+	// Reports and bulk changes go through many tenants.
+	"get-growth-report":      2 * time.Second,
+	"export-growth-report":   5 * time.Second,
+	"download-growth-report": 5 * time.Second,
+	"batch-create-tenants":   5 * time.Second,
+	"import-tenants":         10 * time.Second,
+	"reconcile-billing":      10 * time.Second,
+	// The KMS is called for every secret of the tenant.
+	"set-tenant-encryption-key": 5 * time.Second,
+}
+
+// latencyBudget returns the latency an operation is expected to answer
+// within: the budget of its method unless operationBudgets says otherwise.
+func latencyBudget(method, operationID string) time.Duration {
+	if b, ok := operationBudgets[operationID]; ok {
+		return b
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return readLatencyBudget
+	}
+	return writeLatencyBudget
+}
+
+// LatencyObserver records how long a request to operation took against
+// its latency budget.
+type LatencyObserver func(ctx context.Context, operation string, budget, elapsed time.Duration)
+
+// WithLatencyObserver reports the latency of every request against the
+// budget of its operation to observe, such as metrics of budget burn.
+func WithLatencyObserver(observe LatencyObserver) Option {
+	return func(o *options) { o.latencyObserver = observe }
+}
+
+// tagLatencyBudgets documents the budget of every operation registered
+// from now on under latencyBudgetExtension.
+func tagLatencyBudgets(api huma.API) {
+	oapi := api.OpenAPI()
+	oapi.OnAddOperation = append(oapi.OnAddOperation, func(_ *huma.OpenAPI, op *huma.Operation) {
+		if op.Extensions == nil {
+			op.Extensions = map[string]any{}
+		}
+		op.Extensions[latencyBudgetExtension] = latencyBudget(op.Method, op.OperationID).String()
+	})
+}
+
+// latencyBudgetMiddleware times each request against the budget of its
+// operation. Requests over budget are logged and flagged on their span
+// (slo.budget_exceeded), so their traces can be found and sampled.
+func latencyBudgetMiddleware(observe LatencyObserver) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		start := time.Now()
+		next(ctx)
+		elapsed := time.Since(start)
+
+		op := ctx.Operation()
+		budget := latencyBudget(op.Method, op.OperationID)
+		if observe != nil {
+			observe(ctx.Context(), op.OperationID, budget, elapsed)
+		}
+		if elapsed <= budget {
+			return
+		}
+		trace.SpanFromContext(ctx.Context()).SetAttributes(
+			attribute.Bool("slo.budget_exceeded", true),
+			attribute.Float64("slo.budget", budget.Seconds()),
+		)
+		slog.WarnContext(ctx.Context(), "request over its latency budget",
+			"operation", op.OperationID,
+			"budget", budget,
+			"elapsed", elapsed,
+			"status", ctx.Status(),
+		)
+	}
+}
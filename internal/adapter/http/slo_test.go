@@ -0,0 +1,64 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+func TestLatencyBudgets(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{})
+	var (
+		mu       sync.Mutex
+		observed = map[string]time.Duration{}
+	)
+	srv := serveService(t, svc, adapter.WithLatencyObserver(func(_ context.Context, operation string, budget, _ time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		observed[operation] = budget
+	}))
+
+	acme := mustCreateTenant(t, srv, "Acme", "acme", "pro")
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/"+acme.ID, "")
+	resp.Body.Close()
+	resp = doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/missing", "")
+	resp.Body.Close()
+
+	mu.Lock()
+	if observed["create-tenant"] != time.Second || observed["get-tenant"] != 300*time.Millisecond {
+		t.Errorf("observed budgets = %v, want 1s to create and 300ms to get", observed)
+	}
+	mu.Unlock()
+
+	// Every operation carries its budget in the OpenAPI document.
+	resp = doRequest(t, http.MethodGet, srv.URL+"/openapi.json", "")
+	defer resp.Body.Close()
+	var doc struct {
+		Paths map[string]map[string]map[string]any `json:"paths"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatalf("decoding OpenAPI document: %v", err)
+	}
+	for path, ops := range doc.Paths {
+		for method, op := range ops {
+			if _, ok := op["x-slo-latency-budget"]; !ok {
+				t.Errorf("%s %s has no x-slo-latency-budget", method, path)
+			}
+		}
+	}
+	if got := doc.Paths["/api/v1/tenants/{id}"]["get"]["x-slo-latency-budget"]; got != "300ms" {
+		t.Errorf("get-tenant budget = %v, want 300ms", got)
+	}
+}
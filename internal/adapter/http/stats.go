@@ -0,0 +1,43 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+// StatusCountsResponse is the number of tenants in each status.
+type StatusCountsResponse struct {
+	Total    int            `json:"total" doc:"Tenants in any status, deleted ones included"`
+	ByStatus map[string]int `json:"by_status" doc:"Tenants per status that has any"`
+}
+
+type StatusCountsOutput struct {
+	Body StatusCountsResponse
+}
+
+func registerStatusCounts(api huma.API, svc *app.TenantService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-status-counts",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/reports/status-counts",
+		Summary:     "Count tenants per status",
+		Description: "Read from counters maintained as tenants change, so it is cheap enough for dashboards to poll. " +
+			"A periodic job corrects any drift from the tenants table.",
+		Tags: []string{"Reports"},
+	}, func(ctx context.Context, _ *struct{}) (*StatusCountsOutput, error) {
+		counts, err := svc.StatusCounts(ctx)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		resp := StatusCountsResponse{ByStatus: make(map[string]int, len(counts))}
+		for status, n := range counts {
+			resp.ByStatus[string(status)] = n
+			resp.Total += n
+		}
+		return &StatusCountsOutput{Body: resp}, nil
+	})
+}
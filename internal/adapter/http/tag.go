@@ -0,0 +1,59 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+type AddTagsInput struct {
+	ID   string `path:"id" doc:"Tenant ID"`
+	Body struct {
+		Tags []string `json:"tags" minItems:"1" maxItems:"20" doc:"Tags to add (lowercase letters, digits, '_', '-', '.' and ':'); tags the tenant already has are ignored"`
+	}
+}
+
+type RemoveTagInput struct {
+	ID  string `path:"id" doc:"Tenant ID"`
+	Tag string `path:"tag" doc:"Tag to remove; removing a tag the tenant does not have succeeds"`
+}
+
+type TagsOutput struct {
+	Body TenantResponse
+}
+
+func registerTags(api huma.API, svc *app.TenantService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "add-tenant-tags",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/tenants/{id}/tags",
+		Summary:     "Tag a tenant",
+		Description: "Tags group tenants by campaign, cohort, support tier, ...; list them with `?tag=`. " +
+			"A tenant has at most 20 tags.",
+		Tags: []string{"Tenants"},
+	}, func(ctx context.Context, input *AddTagsInput) (*TagsOutput, error) {
+		tenant, err := svc.Update(ctx, input.ID, domain.TenantPatch{AddTags: input.Body.Tags})
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &TagsOutput{Body: toTenantResponse(tenant)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "remove-tenant-tag",
+		Method:      http.MethodDelete,
+		Path:        "/api/v1/tenants/{id}/tags/{tag}",
+		Summary:     "Remove a tag from a tenant",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *RemoveTagInput) (*TagsOutput, error) {
+		tenant, err := svc.Update(ctx, input.ID, domain.TenantPatch{RemoveTags: []string{input.Tag}})
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &TagsOutput{Body: toTenantResponse(tenant)}, nil
+	})
+}
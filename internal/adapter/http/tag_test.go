@@ -0,0 +1,86 @@
+package http_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+)
+
+func TestTags(t *testing.T) {
+	srv := newTestServer(t)
+	acme := mustCreateTenant(t, srv, "Acme", "acme", "pro")
+	globex := mustCreateTenant(t, srv, "Globex", "globex", "pro")
+	mustCreateTenant(t, srv, "Initech", "initech", "pro")
+
+	tag := func(id, body string) adapter.TenantResponse {
+		t.Helper()
+		resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/"+id+"/tags", body)
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("tagging %s: status = %d, want %d", id, resp.StatusCode, http.StatusOK)
+		}
+		var tenant adapter.TenantResponse
+		if err := json.NewDecoder(resp.Body).Decode(&tenant); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return tenant
+	}
+	list := func(query string) []string {
+		t.Helper()
+		resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants"+query, "")
+		defer resp.Body.Close()
+		var page adapter.TenantListResponse
+		if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		var slugs []string
+		for _, item := range page.Items {
+			slugs = append(slugs, item.Slug)
+		}
+		slices.Sort(slugs) // created within the same second
+		return slugs
+	}
+
+	if got := tag(acme.ID, `{"tags":["tier:gold","beta"]}`); fmt.Sprint(got.Tags) != "[beta tier:gold]" {
+		t.Errorf("Tags = %v, want [beta tier:gold]", got.Tags)
+	}
+	// Tags already present are ignored.
+	if got := tag(globex.ID, `{"tags":["tier:gold","tier:gold"]}`); fmt.Sprint(got.Tags) != "[tier:gold]" {
+		t.Errorf("Tags = %v, want [tier:gold]", got.Tags)
+	}
+
+	if got := list("?tag=tier:gold"); fmt.Sprint(got) != "[acme globex]" {
+		t.Errorf("?tag=tier:gold listed %v, want [acme globex]", got)
+	}
+	if got := list("?tag=tier:gold&tag=beta"); fmt.Sprint(got) != "[acme]" {
+		t.Errorf("?tag=tier:gold&tag=beta listed %v, want [acme]", got)
+	}
+
+	resp := doRequest(t, http.MethodDelete, srv.URL+"/api/v1/tenants/"+acme.ID+"/tags/beta", "")
+	defer resp.Body.Close()
+	var untagged adapter.TenantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&untagged); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if fmt.Sprint(untagged.Tags) != "[tier:gold]" {
+		t.Errorf("Tags after removal = %v, want [tier:gold]", untagged.Tags)
+	}
+	if got := list("?tag=beta"); len(got) != 0 {
+		t.Errorf("?tag=beta listed %v, want none", got)
+	}
+
+	resp = doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/"+acme.ID+"/tags", `{"tags":["Tier Gold"]}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("invalid tag: status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+	resp = doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/missing/tags", `{"tags":["beta"]}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown tenant: status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
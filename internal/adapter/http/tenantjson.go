@@ -0,0 +1,176 @@
+package http
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// TenantList is a page of tenants. It encodes itself without reflection
+// into a pooled buffer: pages of up to a thousand tenants are the hot path
+// of the API, and encoding/json allocates for every field and map key of
+// every tenant.
+type TenantList []TenantResponse
+
+// tenantListBufs holds the buffers pages are encoded into.
+var tenantListBufs = sync.Pool{New: func() any { return new([]byte) }}
+
+// maxPooledTenantList caps the buffers kept for reuse, so one huge page
+// does not pin its memory.
+const maxPooledTenantList = 1 << 20
+
+// MarshalJSON encodes the tenants as encoding/json would. The output is
+// not HTML-escaped; encoding/json escapes it when asked to.
+func (l TenantList) MarshalJSON() ([]byte, error) {
+	if l == nil {
+		return []byte("null"), nil
+	}
+	bp := tenantListBufs.Get().(*[]byte)
+	b := append((*bp)[:0], '[')
+	for i := range l {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendTenantJSON(b, &l[i])
+	}
+	b = append(b, ']')
+
+	out := slices.Clone(b)
+	if cap(b) <= maxPooledTenantList {
+		*bp = b
+		tenantListBufs.Put(bp)
+	}
+	return out, nil
+}
+
+// appendTenantJSON appends r as a JSON object, with the fields, order and
+// omitempty rules of its struct tags.
+func appendTenantJSON(b []byte, r *TenantResponse) []byte {
+	b = append(b, `{"id":`...)
+	b = appendJSONString(b, r.ID)
+	b = append(b, `,"name":`...)
+	b = appendJSONString(b, r.Name)
+	b = append(b, `,"slug":`...)
+	b = appendJSONString(b, r.Slug)
+	b = append(b, `,"status":`...)
+	b = appendJSONString(b, r.Status)
+	b = append(b, `,"plan":`...)
+	b = appendJSONString(b, r.Plan)
+	b = appendOptionalString(b, `,"region":`, r.Region)
+	b = appendOptionalString(b, `,"pr_url":`, r.PRURL)
+	b = appendOptionalString(b, `,"git_branch":`, r.GitBranch)
+	if len(r.ExternalRefs) > 0 {
+		b = append(b, `,"external_refs":`...)
+		b = appendStringMap(b, r.ExternalRefs)
+	}
+	if len(r.Metadata) > 0 {
+		b = append(b, `,"metadata":`...)
+		b = appendStringMap(b, r.Metadata)
+	}
+	if len(r.Tags) > 0 {
+		b = append(b, `,"tags":[`...)
+		for i, tag := range r.Tags {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendJSONString(b, tag)
+		}
+		b = append(b, ']')
+	}
+	b = appendOptionalString(b, `,"reseller_id":`, r.ResellerID)
+	b = appendOptionalString(b, `,"suggested_plan":`, r.SuggestedPlan)
+	b = appendOptionalString(b, `,"trial_ends_at":`, r.TrialEndsAt)
+	if r.Simulated {
+		b = append(b, `,"simulated":true`...)
+	}
+	b = append(b, `,"version":`...)
+	b = strconv.AppendInt(b, int64(r.Version), 10)
+	b = append(b, `,"created_at":`...)
+	b = appendJSONString(b, r.CreatedAt)
+	b = append(b, `,"updated_at":`...)
+	b = appendJSONString(b, r.UpdatedAt)
+	return append(b, '}')
+}
+
+// appendOptionalString appends the key and s unless s is empty.
+func appendOptionalString(b []byte, key, s string) []byte {
+	if s == "" {
+		return b
+	}
+	return appendJSONString(append(b, key...), s)
+}
+
+// appendStringMap appends m as a JSON object with sorted keys.
+func appendStringMap(b []byte, m map[string]string) []byte {
+	var stack [16]string
+	keys := stack[:0]
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	b = append(b, '{')
+	for i, k := range keys {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendJSONString(b, k)
+		b = append(b, ':')
+		b = appendJSONString(b, m[k])
+	}
+	return append(b, '}')
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends s as a JSON string, escaped like encoding/json
+// without HTML escaping: quotes, backslashes and control characters,
+// invalid UTF-8 as U+FFFD, and U+2028 and U+2029.
+func appendJSONString(b []byte, s string) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\b':
+				b = append(b, '\\', 'b')
+			case '\f':
+				b = append(b, '\\', 'f')
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				b = append(b, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, s[start:i]...)
+			b = append(b, `\ufffd`...)
+		} else if r == '\u2028' || r == '\u2029' {
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', hexDigits[r&0xF])
+		} else {
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
@@ -0,0 +1,55 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+)
+
+func TestTenantList_MarshalJSONMatchesEncodingJSON(t *testing.T) {
+	list := adapter.TenantList{
+		{ID: "ten_1", Name: "Acme", Slug: "acme", Status: "active", Plan: "free", Version: 1, CreatedAt: "2026-01-01T00:00:00Z", UpdatedAt: "2026-01-01T00:00:00Z"},
+		{
+			ID: "ten_2", Name: "Quote \" back\\slash <b>&\n\t\x01 é \u2028\u2029", Slug: "odd", Status: "suspended", Plan: "pro",
+			Region: "eu", PRURL: "https://git.example.com/pr/1", GitBranch: "tenant/odd",
+			ExternalRefs: map[string]string{"dns": "odd.example.com", "argocd_app": "odd"},
+			Metadata:     map[string]string{"z": "last", "a": "first", "crm.id": "<42>"},
+			Tags:         []string{"beta", "vip"},
+			ResellerID:   "res_1", SuggestedPlan: "enterprise", TrialEndsAt: "2026-02-01T00:00:00Z",
+			Simulated: true, Version: 7, CreatedAt: "2026-01-01T00:00:00Z", UpdatedAt: "2026-01-02T00:00:00Z",
+		},
+	}
+
+	for _, escapeHTML := range []bool{true, false} {
+		encode := func(v any) []byte {
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(escapeHTML)
+			if err := enc.Encode(v); err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			return buf.Bytes()
+		}
+		got, want := encode(list), encode([]adapter.TenantResponse(list))
+		if !bytes.Equal(got, want) {
+			t.Errorf("escapeHTML=%v:\ngot  %s\nwant %s", escapeHTML, got, want)
+		}
+	}
+
+	// Invalid UTF-8 becomes U+FFFD; encoding/json writes it escaped or
+	// raw depending on the toolchain, so compare it decoded.
+	raw, err := json.Marshal(adapter.TenantList{{Name: "bad \xff byte"}})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var decoded []adapter.TenantResponse
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded[0].Name != "bad \ufffd byte" {
+		t.Errorf("invalid UTF-8 = %s, %v; want U+FFFD", raw, err)
+	}
+
+	if got, _ := json.Marshal(adapter.TenantList(nil)); string(got) != "null" {
+		t.Errorf("nil list = %s, want null", got)
+	}
+}
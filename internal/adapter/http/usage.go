@@ -0,0 +1,68 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// UsageResponse is the latest usage reported for a tenant.
+type UsageResponse struct {
+	Metrics map[string]int64 `json:"metrics" doc:"Latest value of each reported metric"`
+}
+
+type ReportUsageInput struct {
+	ID   string `path:"id" doc:"Tenant ID"`
+	Body struct {
+		Metrics map[string]int64 `json:"metrics" minProperties:"1" doc:"Metric values (e.g. seats, storage_gb); replaces the previous value of each metric"`
+	}
+}
+
+type GetUsageInput struct {
+	ID string `path:"id" doc:"Tenant ID"`
+}
+
+type UsageOutput struct {
+	Body UsageResponse
+}
+
+func registerUsage(api huma.API, svc *app.TenantService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "report-tenant-usage",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/tenants/{id}/usage",
+		Summary:     "Report a tenant's usage",
+		Description: "Called by metering. The plan suggestion job matches the latest values against the plan quotas.",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *ReportUsageInput) (*UsageOutput, error) {
+		if err := svc.ReportUsage(ctx, input.ID, domain.Usage(input.Body.Metrics)); err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return usageOutput(ctx, svc, input.ID, errs)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-tenant-usage",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/tenants/{id}/usage",
+		Summary:     "Get a tenant's usage",
+		Tags:        []string{"Tenants"},
+	}, func(ctx context.Context, input *GetUsageInput) (*UsageOutput, error) {
+		return usageOutput(ctx, svc, input.ID, errs)
+	})
+}
+
+func usageOutput(ctx context.Context, svc *app.TenantService, id string, errs errorMapper) (*UsageOutput, error) {
+	usage, err := svc.Usage(ctx, id)
+	if err != nil {
+		return nil, errs.toHuma(ctx, err)
+	}
+	if usage == nil {
+		usage = domain.Usage{}
+	}
+	return &UsageOutput{Body: UsageResponse{Metrics: usage}}, nil
+}
@@ -0,0 +1,55 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestUsage_ReportAndGet(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{},
+		app.WithPlanSuggestions(domain.PlanCatalog{{Plan: "free"}}, sqlite.NewUsageRepository(repo.DB())))
+	srv := serveService(t, svc)
+	created := mustCreateTenant(t, srv, "Acme", "acme", "free")
+
+	resp := doRequest(t, http.MethodPut, srv.URL+"/api/v1/tenants/"+created.ID+"/usage", `{"metrics":{"seats":3}}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("report: status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	resp = doRequest(t, http.MethodPut, srv.URL+"/api/v1/tenants/"+created.ID+"/usage", `{"metrics":{"storage_gb":20}}`)
+	resp.Body.Close()
+
+	resp = doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/"+created.ID+"/usage", "")
+	defer resp.Body.Close()
+	var usage struct {
+		Metrics map[string]int64 `json:"metrics"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&usage); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if usage.Metrics["seats"] != 3 || usage.Metrics["storage_gb"] != 20 {
+		t.Errorf("metrics = %v, want seats 3 and storage_gb 20", usage.Metrics)
+	}
+}
+
+func TestUsage_NotRegisteredWithoutPlanSuggestions(t *testing.T) {
+	srv := newTestServer(t)
+	created := mustCreateTenant(t, srv, "Acme", "acme", "free")
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/api/v1/tenants/"+created.ID+"/usage", "")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,208 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithWebhooks exposes the webhook subscriptions under /api/v1/webhooks.
+func WithWebhooks(ws *app.WebhookService) Option {
+	return func(o *options) { o.webhooks = ws }
+}
+
+// WebhookResponse is the API representation of a webhook subscription. The
+// secret is write-only.
+type WebhookResponse struct {
+	ID        string                 `json:"id" doc:"Unique identifier"`
+	URL       string                 `json:"url" doc:"Endpoint receiving the deliveries"`
+	Events    []string               `json:"events" doc:"Events delivered; empty means every event"`
+	Digest    string                 `json:"digest" enum:"immediate,hourly,daily" doc:"Whether events are delivered on their own or batched into an hourly or daily digest"`
+	Circuit   WebhookCircuitResponse `json:"circuit" doc:"Health of the endpoint"`
+	CreatedAt string                 `json:"created_at" doc:"Creation timestamp (ISO 8601)"`
+	UpdatedAt string                 `json:"updated_at" doc:"Last update timestamp (ISO 8601)"`
+}
+
+// WebhookCircuitResponse is the health of a subscription's endpoint.
+type WebhookCircuitResponse struct {
+	State               string `json:"state" enum:"closed,open" doc:"open after repeated failures: deliveries are held until the subscription is resumed"`
+	ConsecutiveFailures int    `json:"consecutive_failures" doc:"Failed deliveries since the last successful one"`
+	LastError           string `json:"last_error,omitempty" doc:"Why the last failed delivery failed"`
+	LastFailureAt       string `json:"last_failure_at,omitempty" doc:"When the last delivery failed (ISO 8601)"`
+	OpenedAt            string `json:"opened_at,omitempty" doc:"When the circuit opened (ISO 8601)"`
+}
+
+func toWebhookCircuitResponse(c domain.WebhookCircuit) WebhookCircuitResponse {
+	resp := WebhookCircuitResponse{State: "closed", ConsecutiveFailures: c.ConsecutiveFailures, LastError: c.LastError}
+	if c.Open() {
+		resp.State = "open"
+		resp.OpenedAt = c.OpenedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if !c.LastFailureAt.IsZero() {
+		resp.LastFailureAt = c.LastFailureAt.Format("2006-01-02T15:04:05Z")
+	}
+	return resp
+}
+
+func toWebhookResponse(w domain.WebhookSubscription) WebhookResponse {
+	events := make([]string, len(w.Events))
+	for i, e := range w.Events {
+		events[i] = string(e)
+	}
+	return WebhookResponse{
+		ID:        w.ID,
+		URL:       w.URL,
+		Events:    events,
+		Digest:    string(w.Digest),
+		Circuit:   toWebhookCircuitResponse(w.Circuit),
+		CreatedAt: w.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: w.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+type CreateWebhookInput struct {
+	Body struct {
+		URL    string   `json:"url" format:"uri" doc:"Endpoint receiving the deliveries (http or https)"`
+		Secret string   `json:"secret" minLength:"16" doc:"Key of the HMAC-SHA256 signature sent in X-Tenantiq-Signature"`
+		Events []string `json:"events,omitempty" enum:"provision_complete,suspend,reactivate,delete,deletion_complete,plan_suggested,dunning_warning,dunning_final_notice" doc:"Events to deliver; every event when omitted"`
+		Digest string   `json:"digest,omitempty" enum:"immediate,hourly,daily" doc:"Batch the events into one delivery an hour or a day; immediate when omitted"`
+	}
+}
+
+type UpdateWebhookInput struct {
+	ID   string `path:"id" doc:"Webhook subscription ID"`
+	Body struct {
+		URL    string   `json:"url" format:"uri" doc:"Endpoint receiving the deliveries (http or https)"`
+		Secret string   `json:"secret,omitempty" minLength:"16" doc:"New signing key; the current one is kept when omitted"`
+		Events []string `json:"events,omitempty" enum:"provision_complete,suspend,reactivate,delete,deletion_complete,plan_suggested,dunning_warning,dunning_final_notice" doc:"Events to deliver; every event when omitted"`
+		Digest string   `json:"digest,omitempty" enum:"immediate,hourly,daily" doc:"Batch the events into one delivery an hour or a day; immediate when omitted"`
+	}
+}
+
+type WebhookIDInput struct {
+	ID string `path:"id" doc:"Webhook subscription ID"`
+}
+
+type WebhookOutput struct {
+	Body WebhookResponse
+}
+
+type WebhookListOutput struct {
+	Body struct {
+		Items []WebhookResponse `json:"items" doc:"Subscriptions, oldest first"`
+	}
+}
+
+func registerWebhooks(api huma.API, ws *app.WebhookService, errs errorMapper) {
+	huma.Register(api, huma.Operation{
+		OperationID: "create-webhook",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/webhooks",
+		Summary:     "Subscribe an endpoint to tenant events",
+		Description: "Each matching event is POSTed to the URL with its JSON payload (see /api/v1/events/schema), " +
+			"signed with the secret, and retried with backoff until the endpoint answers 2xx. " +
+			"After 10 failed deliveries in a row the subscription's circuit opens and deliveries are held until it is resumed. " +
+			"With an hourly or daily digest, the events are instead POSTed together once the hour or UTC day ends, as one io.tenantiq.webhook.digest CloudEvent.",
+		Tags: []string{"Webhooks"},
+	}, func(ctx context.Context, input *CreateWebhookInput) (*WebhookOutput, error) {
+		w, err := ws.Create(ctx, input.Body.URL, input.Body.Secret, toEvents(input.Body.Events), domain.DigestMode(input.Body.Digest))
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &WebhookOutput{Body: toWebhookResponse(w)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-webhooks",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/webhooks",
+		Summary:     "List webhook subscriptions",
+		Tags:        []string{"Webhooks"},
+	}, func(ctx context.Context, _ *struct{}) (*WebhookListOutput, error) {
+		subs, err := ws.List(ctx)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		out := &WebhookListOutput{}
+		out.Body.Items = make([]WebhookResponse, len(subs))
+		for i, w := range subs {
+			out.Body.Items[i] = toWebhookResponse(w)
+		}
+		return out, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-webhook",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/webhooks/{id}",
+		Summary:     "Get a webhook subscription",
+		Tags:        []string{"Webhooks"},
+	}, func(ctx context.Context, input *WebhookIDInput) (*WebhookOutput, error) {
+		w, err := ws.Get(ctx, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &WebhookOutput{Body: toWebhookResponse(w)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-webhook",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/webhooks/{id}",
+		Summary:     "Replace a webhook subscription",
+		Description: "Pending retries use the new URL and secret.",
+		Tags:        []string{"Webhooks"},
+	}, func(ctx context.Context, input *UpdateWebhookInput) (*WebhookOutput, error) {
+		w, err := ws.Update(ctx, input.ID, input.Body.URL, input.Body.Secret, toEvents(input.Body.Events), domain.DigestMode(input.Body.Digest))
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &WebhookOutput{Body: toWebhookResponse(w)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "resume-webhook",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/webhooks/{id}/resume",
+		Summary:     "Resume the deliveries held by an open circuit",
+		Description: "Closes the circuit once the endpoint is fixed; the held deliveries are attempted again within a minute. " +
+			"They fail again, and the circuit opens again, if the endpoint still does.",
+		Tags: []string{"Webhooks"},
+	}, func(ctx context.Context, input *WebhookIDInput) (*WebhookOutput, error) {
+		w, err := ws.Resume(ctx, input.ID)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return &WebhookOutput{Body: toWebhookResponse(w)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-webhook",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/webhooks/{id}",
+		Summary:       "Delete a webhook subscription",
+		Description:   "Pending deliveries to the subscription are dropped.",
+		Tags:          []string{"Webhooks"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *WebhookIDInput) (*struct{}, error) {
+		if err := ws.Delete(ctx, input.ID); err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		return nil, nil
+	})
+}
+
+func toEvents(names []string) []domain.Event {
+	if len(names) == 0 {
+		return nil
+	}
+	events := make([]domain.Event, len(names))
+	for i, n := range names {
+		events[i] = domain.Event(n)
+	}
+	return events
+}
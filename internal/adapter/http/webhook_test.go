@@ -0,0 +1,154 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func newWebhookTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{})
+	ws := app.NewWebhookService(sqlite.NewWebhookRepository(repo.DB()))
+	return serveService(t, svc, adapter.WithWebhooks(ws))
+}
+
+func decodeWebhook(t *testing.T, resp *http.Response) adapter.WebhookResponse {
+	t.Helper()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var w adapter.WebhookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&w); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return w
+}
+
+func TestWebhooks_CRUD(t *testing.T) {
+	srv := newWebhookTestServer(t)
+	base := srv.URL + "/api/v1/webhooks"
+
+	resp := doRequest(t, http.MethodPost, base,
+		`{"url":"https://example.com/hook","secret":"0123456789abcdef","events":["suspend","delete"]}`)
+	created := decodeWebhook(t, resp)
+	if !strings.HasPrefix(created.ID, "wh_") || len(created.Events) != 2 || created.Digest != "immediate" {
+		t.Fatalf("created = %+v", created)
+	}
+
+	resp = doRequest(t, http.MethodPut, base+"/"+created.ID, `{"url":"https://example.com/v2","digest":"daily"}`)
+	updated := decodeWebhook(t, resp)
+	if updated.URL != "https://example.com/v2" || len(updated.Events) != 0 || updated.Digest != "daily" {
+		t.Errorf("updated = %+v, want new URL, every event and a daily digest", updated)
+	}
+	resp = doRequest(t, http.MethodPut, base+"/"+created.ID, `{"url":"https://example.com/v2","digest":"weekly"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("weekly digest: status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+
+	resp = doRequest(t, http.MethodGet, base, "")
+	var list struct {
+		Items []map[string]any `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	resp.Body.Close()
+	if len(list.Items) != 1 {
+		t.Fatalf("list = %+v, want 1 subscription", list)
+	}
+	if _, ok := list.Items[0]["secret"]; ok {
+		t.Error("secret must not be returned")
+	}
+
+	resp = doRequest(t, http.MethodDelete, base+"/"+created.ID, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("delete: status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+
+	resp = doRequest(t, http.MethodGet, base+"/"+created.ID, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("get after delete: status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestWebhooks_RejectsInvalidSubscription(t *testing.T) {
+	srv := newWebhookTestServer(t)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"short secret", `{"url":"https://example.com/hook","secret":"short"}`},
+		{"unknown event", `{"url":"https://example.com/hook","secret":"0123456789abcdef","events":["exploded"]}`},
+		{"non-http url", `{"url":"ftp://example.com/hook","secret":"0123456789abcdef"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/webhooks", tt.body)
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusUnprocessableEntity {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+			}
+		})
+	}
+}
+
+func TestWebhooks_CircuitAndResume(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{})
+	ws := app.NewWebhookService(sqlite.NewWebhookRepository(repo.DB()))
+	srv := serveService(t, svc, adapter.WithWebhooks(ws))
+	base := srv.URL + "/api/v1/webhooks"
+
+	created := decodeWebhook(t, doRequest(t, http.MethodPost, base, `{"url":"https://example.com/hook","secret":"0123456789abcdef"}`))
+	if created.Circuit.State != "closed" || created.Circuit.ConsecutiveFailures != 0 {
+		t.Fatalf("new circuit = %+v, want closed", created.Circuit)
+	}
+
+	for range domain.WebhookCircuitThreshold {
+		if err := ws.RecordFailure(context.Background(), created.ID, errors.New("503 Service Unavailable")); err != nil {
+			t.Fatalf("RecordFailure: %v", err)
+		}
+	}
+	got := decodeWebhook(t, doRequest(t, http.MethodGet, base+"/"+created.ID, ""))
+	if got.Circuit.State != "open" || got.Circuit.OpenedAt == "" || got.Circuit.LastError != "503 Service Unavailable" {
+		t.Errorf("circuit = %+v, want open with the last error", got.Circuit)
+	}
+
+	resumed := decodeWebhook(t, doRequest(t, http.MethodPost, base+"/"+created.ID+"/resume", ""))
+	if resumed.Circuit.State != "closed" || resumed.Circuit.ConsecutiveFailures != 0 || resumed.Circuit.LastError == "" {
+		t.Errorf("resumed circuit = %+v, want closed, keeping the last error", resumed.Circuit)
+	}
+
+	resp := doRequest(t, http.MethodPost, base+"/wh_missing/resume", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("resume of a missing subscription: status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
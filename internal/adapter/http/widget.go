@@ -0,0 +1,145 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// widgetPath is the status widget's route. It lives outside /api/v1/: it
+// takes a tenant API key, not an admin credential.
+const widgetPath = "/widget/v1/status"
+
+// widgetMaxAge is how long browsers may reuse a status before asking
+// again, with If-None-Match.
+const widgetMaxAge = "private, max-age=30"
+
+// StatusWidgetResponse is what the status widget shows of the key's
+// tenant.
+type StatusWidgetResponse struct {
+	TenantID            string `json:"tenant_id" doc:"Tenant ID"`
+	Name                string `json:"name" doc:"Display name"`
+	Status              string `json:"status" doc:"Lifecycle state"`
+	Operational         bool   `json:"operational" doc:"Whether the tenant is active and serving"`
+	Plan                string `json:"plan" doc:"Subscription plan"`
+	Region              string `json:"region,omitempty" doc:"Where the tenant is hosted; absent when unspecified"`
+	UpdatedAt           string `json:"updated_at" doc:"Last update timestamp (ISO 8601)"`
+	InMaintenanceWindow *bool  `json:"in_maintenance_window,omitempty" doc:"Whether a maintenance window is open; absent when the tenant declared none"`
+	NextMaintenanceAt   string `json:"next_maintenance_at,omitempty" doc:"When the next maintenance window opens (ISO 8601); absent while one is open or when none are declared"`
+}
+
+type StatusWidgetInput struct {
+	Authorization string `header:"Authorization" doc:"Bearer followed by a tenant API key with the status:read scope"`
+	APIKey        string `header:"X-API-Key" doc:"The API key, for clients that cannot set Authorization"`
+	IfNoneMatch   string `header:"If-None-Match" doc:"ETag of the status the client holds; 304 is returned while it is current"`
+}
+
+type StatusWidgetOutput struct {
+	Status       int
+	ETag         string `header:"ETag" doc:"Version of the status"`
+	CacheControl string `header:"Cache-Control"`
+	Vary         string `header:"Vary"`
+	Body         StatusWidgetResponse
+}
+
+func registerStatusWidget(api huma.API, svc *app.TenantService, ks *app.APIKeyService, limiter *app.RequestLimiter, errs errorMapper) {
+	cors := huma.Middlewares{widgetCORS}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-status-widget",
+		Method:      http.MethodGet,
+		Path:        widgetPath,
+		Summary:     "Get the status of the API key's tenant",
+		Description: "For embedding in the tenant's own dashboards: any origin may call it from a browser. " +
+			"The tenant API key must have the status:read scope, and reveals the status of its own tenant only; " +
+			"as it is handed to browsers, give it no other scope. " +
+			"Responses may be cached for 30 seconds; poll with If-None-Match to receive 304 Not Modified while nothing changed. " +
+			"Requests over the tenant's rate limit get 429 Too Many Requests, with Retry-After.",
+		Tags:        []string{"Status widget"},
+		Middlewares: cors,
+	}, func(ctx context.Context, input *StatusWidgetInput) (*StatusWidgetOutput, error) {
+		key := presentedCredential(func(name string) string {
+			if name == authorizationHeader {
+				return input.Authorization
+			}
+			return input.APIKey
+		})
+		if key == "" {
+			return nil, huma.Error401Unauthorized("a tenant API key is required")
+		}
+		_, tenant, err := ks.Authorize(ctx, key, domain.ScopeStatusRead)
+		if err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		if err := limitRequest(ctx, limiter, tenant, "get-status-widget"); err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+
+		body := StatusWidgetResponse{
+			TenantID:    tenant.ID,
+			Name:        tenant.Name,
+			Status:      string(tenant.Status),
+			Operational: tenant.Status == domain.StatusActive,
+			Plan:        tenant.Plan,
+			Region:      tenant.Region,
+			UpdatedAt:   tenant.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+		if svc.MaintenanceEnabled() {
+			schedule, err := svc.MaintenanceWindows(ctx, tenant.ID)
+			if err != nil {
+				return nil, errs.toHuma(ctx, err)
+			}
+			if len(schedule) > 0 {
+				now := time.Now().UTC()
+				open := schedule.Open(now)
+				body.InMaintenanceWindow = &open
+				if !open {
+					body.NextMaintenanceAt = schedule.Next(now).Format("2006-01-02T15:04:05Z")
+				}
+			}
+		}
+
+		out := &StatusWidgetOutput{Status: http.StatusOK, CacheControl: widgetMaxAge, Vary: "Authorization, X-API-Key"}
+		if out.ETag, err = contentETag(body); err != nil {
+			return nil, errs.toHuma(ctx, err)
+		}
+		if etagMatches(input.IfNoneMatch, out.ETag) {
+			out.Status = http.StatusNotModified
+			return out, nil
+		}
+		out.Body = body
+		return out, nil
+	})
+
+	// Browsers send a preflight before the GET, as it carries the key in
+	// a header; widgetCORS answers it.
+	huma.Register(api, huma.Operation{
+		OperationID:   "preflight-status-widget",
+		Method:        http.MethodOptions,
+		Path:          widgetPath,
+		Hidden:        true,
+		DefaultStatus: http.StatusNoContent,
+		Middlewares:   cors,
+	}, func(ctx context.Context, _ *struct{}) (*struct{}, error) {
+		return nil, nil
+	})
+}
+
+// widgetCORS lets every origin read the status widget. Credentials are
+// API keys sent explicitly, never cookies, so other sites can only read
+// what they hold a key for.
+func widgetCORS(ctx huma.Context, next func(huma.Context)) {
+	ctx.SetHeader("Access-Control-Allow-Origin", "*")
+	ctx.SetHeader("Access-Control-Expose-Headers", "ETag, Retry-After")
+	if ctx.Method() == http.MethodOptions {
+		ctx.SetHeader("Access-Control-Allow-Methods", "GET, OPTIONS")
+		ctx.SetHeader("Access-Control-Allow-Headers", "Authorization, X-API-Key, If-None-Match")
+		ctx.SetHeader("Access-Control-Max-Age", "86400")
+	}
+	next(ctx)
+}
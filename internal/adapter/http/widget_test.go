@@ -0,0 +1,119 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/http"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestStatusWidget(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{})
+	ks := app.NewAPIKeyService(sqlite.NewAPIKeyRepository(repo.DB()), svc)
+	srv := serveService(t, svc, adapter.WithAPIKeys(ks))
+
+	acme := mustCreateTenant(t, srv, "Acme", "acme", "pro")
+	createKey := func(body string) string {
+		t.Helper()
+		resp := doRequest(t, http.MethodPost, srv.URL+"/api/v1/tenants/"+acme.ID+"/api-keys", body)
+		defer resp.Body.Close()
+		var created adapter.CreatedAPIKeyResponse
+		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil || resp.StatusCode != http.StatusOK {
+			t.Fatalf("create key: status = %d, err %v", resp.StatusCode, err)
+		}
+		return created.Key
+	}
+	widgetKey := createKey(`{"name":"Dashboard","scopes":["status:read"]}`)
+	otherKey := createKey(`{"name":"CI","scopes":["tenants:read"]}`)
+
+	resp := doRequestWithHeaders(t, http.MethodGet, srv.URL+"/widget/v1/status", "",
+		map[string]string{"Authorization": "Bearer " + widgetKey, "Origin": "https://dashboard.acme.test"})
+	var status adapter.StatusWidgetResponse
+	_ = json.NewDecoder(resp.Body).Decode(&status)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || status.TenantID != acme.ID || status.Plan != "pro" || status.InMaintenanceWindow != nil {
+		t.Fatalf("status: %d %+v", resp.StatusCode, status)
+	}
+	etag := resp.Header.Get("ETag")
+	if resp.Header.Get("Access-Control-Allow-Origin") != "*" || etag == "" || resp.Header.Get("Cache-Control") != "private, max-age=30" {
+		t.Errorf("headers = %v, want CORS, ETag and Cache-Control", resp.Header)
+	}
+
+	resp = doRequestWithHeaders(t, http.MethodGet, srv.URL+"/widget/v1/status", "",
+		map[string]string{"X-API-Key": widgetKey, "If-None-Match": etag})
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("If-None-Match: status = %d, want 304", resp.StatusCode)
+	}
+
+	for name, tc := range map[string]struct {
+		headers map[string]string
+		status  int
+	}{
+		"no key":        {map[string]string{}, http.StatusUnauthorized},
+		"unknown key":   {map[string]string{"Authorization": "Bearer tqk_nope"}, http.StatusUnauthorized},
+		"missing scope": {map[string]string{"Authorization": "Bearer " + otherKey}, http.StatusForbidden},
+	} {
+		resp := doRequestWithHeaders(t, http.MethodGet, srv.URL+"/widget/v1/status", "", tc.headers)
+		resp.Body.Close()
+		if resp.StatusCode != tc.status || resp.Header.Get("Access-Control-Allow-Origin") != "*" {
+			t.Errorf("%s: status = %d, CORS %q; want %d readable by any origin", name, resp.StatusCode, resp.Header.Get("Access-Control-Allow-Origin"), tc.status)
+		}
+	}
+
+	resp = doRequestWithHeaders(t, http.MethodOptions, srv.URL+"/widget/v1/status", "", map[string]string{
+		"Origin":                         "https://dashboard.acme.test",
+		"Access-Control-Request-Method":  "GET",
+		"Access-Control-Request-Headers": "authorization",
+	})
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent || resp.Header.Get("Access-Control-Allow-Headers") == "" {
+		t.Errorf("preflight: status = %d, headers %v", resp.StatusCode, resp.Header)
+	}
+}
+
+func TestStatusWidget_RateLimited(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating test repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	db := repo.DB()
+	svc := app.NewTenantService(repo, &noopPublisher{}, &testValidator{},
+		app.WithPlanValidation(app.NewPlanService(sqlite.NewPlanRepository(db), repo)),
+		app.WithRateLimits(nil, sqlite.NewRateLimitRepository(db)))
+	ks := app.NewAPIKeyService(sqlite.NewAPIKeyRepository(db), svc)
+	srv := serveService(t, svc, adapter.WithAPIKeys(ks), adapter.WithRequestLimits(app.NewRequestLimiter(svc, nil)))
+
+	acme := mustCreateTenant(t, srv, "Acme", "acme", "free")
+	if _, err := svc.SetRateLimit(t.Context(), acme.ID, domain.RateLimit{RequestsPerSecond: 1, Burst: 2}); err != nil {
+		t.Fatalf("SetRateLimit: %v", err)
+	}
+	_, key, err := ks.Create(t.Context(), acme.ID, "Dashboard", []string{domain.ScopeStatusRead}, time.Time{})
+	if err != nil {
+		t.Fatalf("creating key: %v", err)
+	}
+
+	for i := range 2 {
+		resp := doRequestWithHeaders(t, http.MethodGet, srv.URL+"/widget/v1/status", "", map[string]string{"X-API-Key": key})
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("request %d within the burst: status = %d", i+1, resp.StatusCode)
+		}
+	}
+	resp := doRequestWithHeaders(t, http.MethodGet, srv.URL+"/widget/v1/status", "", map[string]string{"X-API-Key": key})
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "1" {
+		t.Errorf("over the burst: status = %d, Retry-After %q; want 429 and 1", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+}
@@ -0,0 +1,146 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// keysTTL is how long fetched keys are trusted before being fetched
+	// again, so revoked keys stop working.
+	keysTTL = time.Hour
+	// minRefresh spaces the fetches caused by unknown key IDs, so tokens
+	// naming made-up keys cannot hammer the identity provider.
+	minRefresh = time.Minute
+	// maxJWKSSize bounds the JWKS document read.
+	maxJWKSSize = 1 << 20
+)
+
+// errUnknownKey is returned for a key ID the JWKS does not publish.
+var errUnknownKey = errors.New("unknown signing key")
+
+// keySet caches the RSA keys of a JWKS by key ID. It fetches them on first
+// use, once they are older than keysTTL, and when a token names a key it
+// does not know, in case the provider rotated its keys.
+type keySet struct {
+	url    string
+	client *http.Client
+
+	mu      sync.Mutex
+	keys    map[string]*rsa.PublicKey
+	fetched time.Time
+	// attempted and lastErr record the latest fetch, successful or not.
+	attempted time.Time
+	lastErr   error
+}
+
+func newKeySet(url string, client *http.Client) *keySet {
+	return &keySet{url: url, client: client}
+}
+
+// key returns the key with ID kid. Tokens without a kid match the only key
+// of a JWKS publishing one. While the JWKS cannot be fetched, keys older
+// than keysTTL are no longer trusted.
+func (s *keySet) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if k, ok := s.lookup(kid); ok && time.Since(s.fetched) < keysTTL {
+		return k, nil
+	}
+	if time.Since(s.attempted) >= minRefresh {
+		s.attempted = time.Now()
+		if s.lastErr = s.fetch(ctx); s.lastErr != nil {
+			return nil, s.lastErr
+		}
+	}
+	if s.lastErr != nil {
+		return nil, s.lastErr
+	}
+	if k, ok := s.lookup(kid); ok && time.Since(s.fetched) < keysTTL {
+		return k, nil
+	}
+	return nil, fmt.Errorf("%w %q", errUnknownKey, kid)
+}
+
+func (s *keySet) lookup(kid string) (*rsa.PublicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, k := range s.keys {
+			return k, true
+		}
+	}
+	k, ok := s.keys[kid]
+	return k, ok
+}
+
+// jwk is the part of a JSON Web Key used for RS256.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// fetch replaces the cached keys with the RSA signing keys of the JWKS.
+// Keys of other types are skipped.
+func (s *keySet) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return fmt.Errorf("creating JWKS request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetching JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching JWKS: status %d", resp.StatusCode)
+	}
+
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxJWKSSize)).Decode(&doc); err != nil {
+		return fmt.Errorf("decoding JWKS: %w", err)
+	}
+	keys := make(map[string]*rsa.PublicKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		pub, err := k.rsa()
+		if err != nil {
+			return fmt.Errorf("JWKS key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = pub
+	}
+	s.keys, s.fetched = keys, time.Now()
+	return nil
+}
+
+// rsa decodes the modulus and exponent of the key.
+func (k jwk) rsa() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("decoding modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("decoding exponent: %w", err)
+	}
+	exp := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exp.IsInt64() || exp.Int64() < 3 || exp.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid RSA key")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+}
@@ -0,0 +1,140 @@
+// Package jwtauth verifies the JWT bearer tokens of an identity provider,
+// signed with a shared secret (HS256) or with the provider's RSA keys
+// (RS256) published at a JWKS URL.
+//
+// It is optional: when neither is configured the Verifier is not created
+// and requests are authenticated with admin keys only.
+package jwtauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: Verifier implements domain.TokenVerifier.
+var _ domain.TokenVerifier = (*Verifier)(nil)
+
+// minSecretLength is the shortest HS256 secret accepted, the size of the
+// hash: shorter ones can be brute-forced from a single token.
+const minSecretLength = 32
+
+// leeway tolerates clock skew with the identity provider on the expiry
+// and not-before times.
+const leeway = 30 * time.Second
+
+// Config holds the token verification settings.
+type Config struct {
+	// Secret verifies HS256 tokens.
+	Secret string
+	// JWKSURL publishes the keys verifying RS256 tokens.
+	JWKSURL string
+	// Issuer and Audience, when set, must match the iss and aud claims.
+	Issuer   string
+	Audience string
+	// ActorClaim names the claim attributing the changes made with a
+	// token, such as email; sub by default. The API applies it.
+	ActorClaim string
+}
+
+// ConfigFromEnv reads the configuration from environment variables.
+func ConfigFromEnv() Config {
+	actor := os.Getenv("JWT_ACTOR_CLAIM")
+	if actor == "" {
+		actor = "sub"
+	}
+	return Config{
+		Secret:     os.Getenv("JWT_SECRET"),
+		JWKSURL:    os.Getenv("JWT_JWKS_URL"),
+		Issuer:     os.Getenv("JWT_ISSUER"),
+		Audience:   os.Getenv("JWT_AUDIENCE"),
+		ActorClaim: actor,
+	}
+}
+
+// Enabled reports whether a secret or a JWKS URL is configured.
+func (c Config) Enabled() bool {
+	return c.Secret != "" || c.JWKSURL != ""
+}
+
+// Verifier checks the signature, expiry, issuer and audience of tokens.
+type Verifier struct {
+	cfg    Config
+	keys   *keySet // nil without a JWKS URL
+	parser *jwt.Parser
+}
+
+// New creates a Verifier from the configuration. client fetches the JWKS;
+// http.DefaultClient when nil.
+func New(cfg Config, client *http.Client) (*Verifier, error) {
+	if !cfg.Enabled() {
+		return nil, errors.New("jwt: a secret or a JWKS URL is required")
+	}
+	if cfg.Secret != "" && len(cfg.Secret) < minSecretLength {
+		return nil, fmt.Errorf("jwt: the secret must be at least %d bytes", minSecretLength)
+	}
+
+	var methods []string
+	if cfg.Secret != "" {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	v := &Verifier{cfg: cfg}
+	if cfg.JWKSURL != "" {
+		if client == nil {
+			client = http.DefaultClient
+		}
+		v.keys = newKeySet(cfg.JWKSURL, client)
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	v.parser = jwt.NewParser(opts...)
+	return v, nil
+}
+
+// Verify returns the claims of token. A token that is malformed, expired,
+// signed with another key or algorithm, or issued by or for someone else
+// is domain.ErrTokenInvalid. Failing to fetch the JWKS is another error.
+func (v *Verifier) Verify(ctx context.Context, token string) (domain.Claims, error) {
+	var fetchErr error
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		if t.Method.Alg() == jwt.SigningMethodHS256.Alg() {
+			return []byte(v.cfg.Secret), nil
+		}
+		kid, _ := t.Header["kid"].(string)
+		key, err := v.keys.key(ctx, kid)
+		if err != nil && !errors.Is(err, errUnknownKey) {
+			fetchErr = err
+		}
+		return key, err
+	})
+	if fetchErr != nil {
+		return domain.Claims{}, fetchErr
+	}
+	if err != nil {
+		return domain.Claims{}, fmt.Errorf("%w: %v", domain.ErrTokenInvalid, err)
+	}
+
+	c := domain.Claims{Values: claims}
+	c.Subject, _ = claims.GetSubject()
+	c.Issuer, _ = claims.GetIssuer()
+	return c, nil
+}
@@ -0,0 +1,157 @@
+package jwtauth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/jwtauth"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+const secret = "0123456789abcdef0123456789abcdef"
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("signing token: %v", err)
+	}
+	return s
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   "https://id.example.com",
+		"aud":   "tenantiq",
+		"email": "alice@example.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestVerifier_HS256(t *testing.T) {
+	v, err := jwtauth.New(jwtauth.Config{Secret: secret, Issuer: "https://id.example.com", Audience: "tenantiq"}, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+
+	c, err := v.Verify(ctx, sign(t, jwt.SigningMethodHS256, []byte(secret), "", validClaims()))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if c.Subject != "user-1" || c.Issuer != "https://id.example.com" || c.String("email") != "alice@example.com" {
+		t.Errorf("claims = %+v", c)
+	}
+
+	expired := validClaims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	otherIssuer := validClaims()
+	otherIssuer["iss"] = "https://evil.example.com"
+	noExpiry := validClaims()
+	delete(noExpiry, "exp")
+	for name, token := range map[string]string{
+		"expired":      sign(t, jwt.SigningMethodHS256, []byte(secret), "", expired),
+		"other issuer": sign(t, jwt.SigningMethodHS256, []byte(secret), "", otherIssuer),
+		"no expiry":    sign(t, jwt.SigningMethodHS256, []byte(secret), "", noExpiry),
+		"other secret": sign(t, jwt.SigningMethodHS256, []byte("another-secret-another-secret-xx"), "", validClaims()),
+		"HS512":        sign(t, jwt.SigningMethodHS512, []byte(secret), "", validClaims()),
+		"malformed":    "not.a.token",
+	} {
+		if _, err := v.Verify(ctx, token); !errors.Is(err, domain.ErrTokenInvalid) {
+			t.Errorf("%s: Verify = %v, want ErrTokenInvalid", name, err)
+		}
+	}
+}
+
+func TestNew_RejectsWeakConfigurations(t *testing.T) {
+	if _, err := jwtauth.New(jwtauth.Config{}, nil); err == nil {
+		t.Error("New without a secret or JWKS URL: want an error")
+	}
+	if _, err := jwtauth.New(jwtauth.Config{Secret: "short"}, nil); err == nil {
+		t.Error("New with a short secret: want an error")
+	}
+}
+
+// serveJWKS publishes key under kid and counts the fetches.
+func serveJWKS(t *testing.T, kid string, key *rsa.PublicKey, fetches *int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*fetches++
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestVerifier_RS256WithJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	var fetches int
+	jwks := serveJWKS(t, "k1", &key.PublicKey, &fetches)
+	v, err := jwtauth.New(jwtauth.Config{JWKSURL: jwks.URL}, jwks.Client())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := v.Verify(ctx, sign(t, jwt.SigningMethodRS256, key, "k1", validClaims())); err != nil {
+			t.Fatalf("Verify: %v", err)
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("JWKS fetched %d times, want once", fetches)
+	}
+
+	if _, err := v.Verify(ctx, sign(t, jwt.SigningMethodRS256, key, "k2", validClaims())); !errors.Is(err, domain.ErrTokenInvalid) {
+		t.Errorf("unknown kid: Verify = %v, want ErrTokenInvalid", err)
+	}
+	// HS256 is not accepted without a secret, whatever the key used.
+	if _, err := v.Verify(ctx, sign(t, jwt.SigningMethodHS256, []byte(secret), "k1", validClaims())); !errors.Is(err, domain.ErrTokenInvalid) {
+		t.Errorf("HS256 token: Verify = %v, want ErrTokenInvalid", err)
+	}
+}
+
+func TestVerifier_JWKSUnavailable(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(srv.Close)
+	v, err := jwtauth.New(jwtauth.Config{JWKSURL: srv.URL}, srv.Client())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	_, err = v.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, key, "k1", validClaims()))
+	if err == nil || errors.Is(err, domain.ErrTokenInvalid) {
+		t.Errorf("Verify = %v, want an error other than ErrTokenInvalid", err)
+	}
+}
@@ -0,0 +1,111 @@
+// Package kms implements domain.KeyManager with a local keyring: named
+// AES-256 keys given in the configuration, referenced as "local:<name>".
+// It stands in for a cloud KMS in development and single-host setups; a
+// key removed from the keyring is revoked, as a key disabled at a KMS is.
+package kms
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// RefPrefix starts the references of the keys of a Keyring.
+const RefPrefix = "local:"
+
+// KeyLength is the length of the keys of a Keyring, in bytes.
+const KeyLength = 32
+
+// Compile-time check: Keyring implements domain.KeyManager.
+var _ domain.KeyManager = (*Keyring)(nil)
+
+// Keyring wraps data keys with named keys held in memory.
+type Keyring struct {
+	keys map[string]cipher.AEAD
+}
+
+// Parse creates a keyring from "name=key" entries separated by commas,
+// each key the base64 of KeyLength bytes.
+func Parse(spec string) (*Keyring, error) {
+	k := &Keyring{keys: make(map[string]cipher.AEAD)}
+	for entry := range strings.SplitSeq(spec, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, encoded, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("keyring entry %q must be name=key", entry)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("decoding key %s: %w", name, err)
+		}
+		if len(key) != KeyLength {
+			return nil, fmt.Errorf("key %s must be %d bytes, got %d", name, KeyLength, len(key))
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", name, err)
+		}
+		if k.keys[name], err = cipher.NewGCM(block); err != nil {
+			return nil, fmt.Errorf("key %s: %w", name, err)
+		}
+	}
+	if len(k.keys) == 0 {
+		return nil, errors.New("keyring has no keys")
+	}
+	return k, nil
+}
+
+// Wrap encrypts dataKey with the key keyRef. The reference is
+// authenticated along, so a wrapped key only unwraps with its own key.
+func (k *Keyring) Wrap(_ context.Context, keyRef string, dataKey []byte) ([]byte, error) {
+	aead, err := k.key(keyRef)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, dataKey, []byte(keyRef)), nil
+}
+
+// Unwrap decrypts a data key wrapped by Wrap with the key keyRef.
+func (k *Keyring) Unwrap(_ context.Context, keyRef string, wrapped []byte) ([]byte, error) {
+	aead, err := k.key(keyRef)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, errors.New("wrapped key too short")
+	}
+	nonce, ciphertext := wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():]
+	dataKey, err := aead.Open(nil, nonce, ciphertext, []byte(keyRef))
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping data key: %w", err)
+	}
+	return dataKey, nil
+}
+
+// key returns the key keyRef refers to; keys not in the keyring are
+// revoked.
+func (k *Keyring) key(keyRef string) (cipher.AEAD, error) {
+	name, ok := strings.CutPrefix(keyRef, RefPrefix)
+	if !ok {
+		return nil, fmt.Errorf("key %q is not a local key (%s<name>)", keyRef, RefPrefix)
+	}
+	aead, ok := k.keys[name]
+	if !ok {
+		return nil, fmt.Errorf("key %q: %w", keyRef, domain.ErrKeyRevoked)
+	}
+	return aead, nil
+}
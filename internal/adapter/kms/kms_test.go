@@ -0,0 +1,54 @@
+package kms_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/kms"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, kms.KeyLength))
+}
+
+func TestKeyring_WrapUnwrap(t *testing.T) {
+	ring, err := kms.Parse("acme=" + testKey(1) + ", default=" + testKey(2))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	ctx := context.Background()
+	dataKey := bytes.Repeat([]byte{7}, 32)
+
+	wrapped, err := ring.Wrap(ctx, "local:acme", dataKey)
+	if err != nil {
+		t.Fatalf("Wrap: %v", err)
+	}
+	got, err := ring.Unwrap(ctx, "local:acme", wrapped)
+	if err != nil || !bytes.Equal(got, dataKey) {
+		t.Fatalf("Unwrap = %x, %v; want the data key", got, err)
+	}
+	if _, err := ring.Unwrap(ctx, "local:default", wrapped); err == nil {
+		t.Error("a key wrapped by acme unwrapped with default")
+	}
+
+	// Keys no longer in the keyring are revoked.
+	revoked, _ := kms.Parse("default=" + testKey(2))
+	if _, err := revoked.Unwrap(ctx, "local:acme", wrapped); !errors.Is(err, domain.ErrKeyRevoked) {
+		t.Errorf("Unwrap with a removed key = %v, want ErrKeyRevoked", err)
+	}
+	if _, err := ring.Wrap(ctx, "arn:aws:kms:eu-west-1:1:key/x", dataKey); err == nil || errors.Is(err, domain.ErrKeyRevoked) {
+		t.Errorf("Wrap with a foreign reference = %v, want an error other than revoked", err)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "acme", "=" + testKey(1), "acme=not-base64!", "acme=" + base64.StdEncoding.EncodeToString([]byte("short"))} {
+		if _, err := kms.Parse(spec); err == nil {
+			t.Errorf("Parse(%q) succeeded", spec)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Package lifecyclefile loads the tenant lifecycle from a YAML or JSON file,
+// so platform teams can add statuses and events to the built-in ones
+// without a fork. Every status and event is declared, then used by the
+// transitions, each moving tenants in any of its from statuses to its to
+// status:
+//
+//	states: [creating, onboarding, active, grace_period, suspended, deleting, deleted]
+//	events: [provision_complete, onboarding_complete, payment_overdue, payment_received,
+//	         suspend, reactivate, delete, deletion_complete]
+//	transitions:
+//	  - {event: provision_complete, from: [creating], to: onboarding}
+//	  - {event: onboarding_complete, from: [onboarding], to: active}
+//	  - {event: payment_overdue, from: [active], to: grace_period}
+//	  - {event: payment_received, from: [grace_period], to: active}
+//	  - {event: suspend, from: [active, grace_period], to: suspended}
+//	  - {event: reactivate, from: [suspended], to: active}
+//	  - {event: delete, from: [onboarding, active, suspended], to: deleting}
+//	  - {event: deletion_complete, from: [deleting], to: deleted}
+package lifecyclefile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// namePattern is what status and event names look like: they are stored,
+// published and used in URLs.
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// file is the on-disk representation of the lifecycle.
+type file struct {
+	States      []string     `yaml:"states"`
+	Events      []string     `yaml:"events"`
+	Transitions []transition `yaml:"transitions"`
+}
+
+type transition struct {
+	Event string   `yaml:"event"`
+	From  []string `yaml:"from"`
+	To    string   `yaml:"to"`
+}
+
+// Load reads and validates the lifecycle in path. Unknown fields, and
+// statuses or events used without being declared or declared without
+// being used, are rejected so a typo cannot change the lifecycle.
+func Load(path string) ([]domain.Transition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading lifecycle: %w", err)
+	}
+
+	// JSON is valid YAML, so either format decodes here.
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var f file
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	transitions, err := f.transitions()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return transitions, nil
+}
+
+func (f file) transitions() ([]domain.Transition, error) {
+	if len(f.Transitions) == 0 {
+		return nil, errors.New("no transitions")
+	}
+	if err := checkNames("state", f.States); err != nil {
+		return nil, err
+	}
+	if err := checkNames("event", f.Events); err != nil {
+		return nil, err
+	}
+
+	var out []domain.Transition
+	usedStates := make(map[string]bool)
+	usedEvents := make(map[string]bool)
+	for i, t := range f.Transitions {
+		if !slices.Contains(f.Events, t.Event) {
+			return nil, fmt.Errorf("transitions[%d]: undeclared event %q", i, t.Event)
+		}
+		if len(t.From) == 0 {
+			return nil, fmt.Errorf("transitions[%d]: no from state", i)
+		}
+		for _, s := range append(slices.Clone(t.From), t.To) {
+			if !slices.Contains(f.States, s) {
+				return nil, fmt.Errorf("transitions[%d]: undeclared state %q", i, s)
+			}
+			usedStates[s] = true
+		}
+		usedEvents[t.Event] = true
+		for _, from := range t.From {
+			out = append(out, domain.Transition{Event: domain.Event(t.Event), Src: domain.Status(from), Dst: domain.Status(t.To)})
+		}
+	}
+	for _, s := range f.States {
+		if !usedStates[s] {
+			return nil, fmt.Errorf("state %q is in no transition", s)
+		}
+	}
+	for _, e := range f.Events {
+		if !usedEvents[e] {
+			return nil, fmt.Errorf("event %q is in no transition", e)
+		}
+	}
+
+	if err := domain.ValidateTransitions(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// checkNames rejects malformed and duplicate names.
+func checkNames(kind string, names []string) error {
+	for i, n := range names {
+		if !namePattern.MatchString(n) {
+			return fmt.Errorf("invalid %s %q, want lowercase letters, digits and underscores", kind, n)
+		}
+		if slices.Contains(names[:i], n) {
+			return fmt.Errorf("%s %q is declared twice", kind, n)
+		}
+	}
+	return nil
+}
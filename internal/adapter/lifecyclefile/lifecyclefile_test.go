@@ -0,0 +1,121 @@
+package lifecyclefile_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/lifecyclefile"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func writeLifecycle(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
+
+const builtin = `
+states: [creating, active, suspended, deleting, deleted]
+events: [provision_complete, suspend, reactivate, delete, deletion_complete]
+transitions:
+  - {event: provision_complete, from: [creating], to: active}
+  - {event: suspend, from: [active], to: suspended}
+  - {event: reactivate, from: [suspended], to: active}
+  - {event: delete, from: [active, suspended], to: deleting}
+  - {event: deletion_complete, from: [deleting], to: deleted}
+`
+
+func TestLoad(t *testing.T) {
+	transitions, err := lifecyclefile.Load(writeLifecycle(t, "lifecycle.yaml", builtin))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !slices.Equal(transitions, domain.Transitions) {
+		t.Errorf("transitions = %v, want the built-in ones", transitions)
+	}
+}
+
+func TestLoad_CustomStatesFromJSON(t *testing.T) {
+	path := writeLifecycle(t, "lifecycle.json", `{
+  "states": ["creating", "onboarding", "active", "suspended", "deleting", "deleted"],
+  "events": ["provision_complete", "onboarding_complete", "suspend", "reactivate", "delete", "deletion_complete"],
+  "transitions": [
+    {"event": "provision_complete", "from": ["creating"], "to": "onboarding"},
+    {"event": "onboarding_complete", "from": ["onboarding"], "to": "active"},
+    {"event": "suspend", "from": ["active"], "to": "suspended"},
+    {"event": "reactivate", "from": ["suspended"], "to": "active"},
+    {"event": "delete", "from": ["onboarding", "active", "suspended"], "to": "deleting"},
+    {"event": "deletion_complete", "from": ["deleting"], "to": "deleted"}
+  ]
+}`)
+
+	transitions, err := lifecyclefile.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	onboarding := domain.Status("onboarding")
+	for _, want := range []domain.Transition{
+		{Event: domain.EventProvisionComplete, Src: domain.StatusCreating, Dst: onboarding},
+		{Event: "onboarding_complete", Src: onboarding, Dst: domain.StatusActive},
+		{Event: domain.EventDelete, Src: onboarding, Dst: domain.StatusDeleting},
+	} {
+		if !slices.Contains(transitions, want) {
+			t.Errorf("transitions lack %+v", want)
+		}
+	}
+	if len(transitions) != 8 {
+		t.Errorf("got %d transitions, want 8", len(transitions))
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	cases := map[string]struct{ content, want string }{
+		"unknown field": {
+			content: strings.Replace(builtin, "to: deleted", "dst: deleted", 1),
+			want:    "dst",
+		},
+		"undeclared state": {
+			content: strings.Replace(builtin, "to: suspended", "to: paused", 1),
+			want:    `undeclared state "paused"`,
+		},
+		"undeclared event": {
+			content: strings.Replace(builtin, "event: suspend,", "event: pause,", 1),
+			want:    `undeclared event "pause"`,
+		},
+		"unused state": {
+			content: strings.Replace(builtin, "deleted]", "deleted, archived]", 1),
+			want:    `state "archived" is in no transition`,
+		},
+		"duplicate state": {
+			content: strings.Replace(builtin, "deleted]", "deleted, active]", 1),
+			want:    `state "active" is declared twice`,
+		},
+		"malformed name": {
+			content: strings.Replace(builtin, "deleted]", "deleted, Grace Period]", 1),
+			want:    `invalid state "Grace Period"`,
+		},
+		"missing built-in event": {
+			content: strings.NewReplacer("reactivate, ", "", "  - {event: reactivate, from: [suspended], to: active}\n", "").Replace(builtin),
+			want:    `event "reactivate" is missing`,
+		},
+		"no transitions": {
+			content: "states: [creating]\nevents: []\n",
+			want:    "no transitions",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := lifecyclefile.Load(writeLifecycle(t, "lifecycle.yaml", c.content))
+			if err == nil || !strings.Contains(err.Error(), c.want) {
+				t.Errorf("error = %v, want %q", err, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,420 @@
+// Package oidc logs people in with an OpenID Connect issuer, such as
+// Keycloak or Auth0, through the authorization-code flow with PKCE, and
+// keeps them logged in with signed session cookies, for browser clients
+// such as an admin UI.
+//
+// It is optional: when no issuer is configured the Client is not created
+// and the /auth routes are not mounted.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/jwtauth"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Cookies set by the login flow.
+const (
+	// SessionCookie holds the session of a logged-in person.
+	SessionCookie = "tenantiq_session"
+	// stateCookie holds the state, nonce and PKCE verifier of a login in
+	// progress, for the callback to check.
+	stateCookie = "tenantiq_login"
+)
+
+// loginTimeout is how long a person has to log in at the issuer.
+const loginTimeout = 10 * time.Minute
+
+// maxResponseSize bounds the discovery and token responses read.
+const maxResponseSize = 1 << 20
+
+// Config holds the OpenID Connect settings.
+type Config struct {
+	// IssuerURL is where the issuer publishes its discovery document,
+	// under /.well-known/openid-configuration.
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the callback registered with the issuer, ending in
+	// /auth/callback.
+	RedirectURL string
+	Scopes      []string
+	// SessionKey signs the session cookies, SessionTTL long.
+	SessionKey []byte
+	SessionTTL time.Duration
+	// ActorClaim is the claim attributing the changes made in a session
+	// (see jwtauth.Config.ActorClaim).
+	ActorClaim string
+	// RoleClaim is the claim, or dotted path into one, naming the roles
+	// of the person logged in; sessions keep it for the management API to
+	// authorize them.
+	RoleClaim string
+}
+
+// ConfigFromEnv reads the configuration from environment variables.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		IssuerURL:    strings.TrimSuffix(os.Getenv("OIDC_ISSUER_URL"), "/"),
+		ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+		Scopes:       strings.Fields(envOrDefault("OIDC_SCOPES", "openid email profile")),
+		SessionKey:   []byte(os.Getenv("SESSION_KEY")),
+		ActorClaim:   envOrDefault("JWT_ACTOR_CLAIM", "sub"),
+		RoleClaim:    envOrDefault("JWT_ROLE_CLAIM", "roles"),
+	}
+	ttl, err := time.ParseDuration(envOrDefault("SESSION_TTL", "8h"))
+	if err != nil {
+		return Config{}, fmt.Errorf("SESSION_TTL: %w", err)
+	}
+	cfg.SessionTTL = ttl
+	return cfg, nil
+}
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// Enabled reports whether an issuer is configured.
+func (c Config) Enabled() bool {
+	return c.IssuerURL != ""
+}
+
+// Provider is the part of the issuer's discovery document used here.
+type Provider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Discover fetches the discovery document of issuerURL.
+func Discover(ctx context.Context, issuerURL string, client *http.Client) (Provider, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuerURL+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return Provider{}, fmt.Errorf("creating discovery request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Provider{}, fmt.Errorf("fetching discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Provider{}, fmt.Errorf("fetching discovery document: status %d", resp.StatusCode)
+	}
+
+	var p Provider
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&p); err != nil {
+		return Provider{}, fmt.Errorf("decoding discovery document: %w", err)
+	}
+	// The issuer must be the one configured, or its tokens are someone
+	// else's.
+	if p.Issuer != issuerURL {
+		return Provider{}, fmt.Errorf("discovery document is for issuer %q, not %q", p.Issuer, issuerURL)
+	}
+	if p.AuthorizationEndpoint == "" || p.TokenEndpoint == "" || p.JWKSURI == "" {
+		return Provider{}, errors.New("discovery document lacks the authorization, token or JWKS endpoint")
+	}
+	return p, nil
+}
+
+// Client runs the login flow against the issuer.
+type Client struct {
+	cfg      Config
+	provider Provider
+	http     *http.Client
+	idTokens *jwtauth.Verifier
+	sessions *Sessions
+	state    cookieCodec
+	// secure marks cookies Secure when the callback is served over HTTPS.
+	secure bool
+}
+
+// New discovers the issuer and returns a client for it. client calls the
+// issuer; http.DefaultClient when nil.
+func New(ctx context.Context, cfg Config, client *http.Client) (*Client, error) {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" || cfg.RedirectURL == "" {
+		return nil, errors.New("oidc: a client ID, a client secret and a redirect URL are required")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	// A dotted role claim lives in a top-level claim, kept whole.
+	roleClaim, _, _ := strings.Cut(cfg.RoleClaim, ".")
+	sessions, err := NewSessions(cfg.SessionKey, cfg.SessionTTL, cfg.ActorClaim, roleClaim)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %w", err)
+	}
+	provider, err := Discover(ctx, cfg.IssuerURL, client)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %w", err)
+	}
+	idTokens, err := jwtauth.New(jwtauth.Config{
+		JWKSURL:  provider.JWKSURI,
+		Issuer:   provider.Issuer,
+		Audience: cfg.ClientID,
+	}, client)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %w", err)
+	}
+	return &Client{
+		cfg:      cfg,
+		provider: provider,
+		http:     client,
+		idTokens: idTokens,
+		sessions: sessions,
+		// A key of its own, so that a login state cannot pass for a
+		// session.
+		state:  cookieCodec{key: sessions.codec.mac("login-state")},
+		secure: strings.HasPrefix(cfg.RedirectURL, "https://"),
+	}, nil
+}
+
+// Provider returns the discovered issuer configuration, whose JWKS also
+// verifies the access tokens of API calls.
+func (c *Client) Provider() Provider {
+	return c.provider
+}
+
+// Sessions returns the verifier of the session cookies.
+func (c *Client) Sessions() *Sessions {
+	return c.sessions
+}
+
+// Mount adds the login routes under /auth: login, callback, logout and
+// session.
+func (c *Client) Mount(r chi.Router) {
+	r.Get("/auth/login", c.login)
+	r.Get("/auth/callback", c.callback)
+	r.Post("/auth/logout", c.logout)
+	r.Get("/auth/session", c.session)
+}
+
+// loginState is what the callback checks the issuer's answer against.
+type loginState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	ReturnTo string `json:"return_to"`
+}
+
+// login redirects to the issuer. The return_to query parameter, a path on
+// this server, is where the callback sends the person once logged in.
+func (c *Client) login(w http.ResponseWriter, r *http.Request) {
+	ls := loginState{
+		State:    randomString(),
+		Nonce:    randomString(),
+		Verifier: randomString(),
+		ReturnTo: safeReturnPath(r.URL.Query().Get("return_to")),
+	}
+	expires := time.Now().Add(loginTimeout)
+	value, err := encodeCookie(c.state, ls, expires)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, "login failed")
+		return
+	}
+	http.SetCookie(w, c.cookie(stateCookie, value, expires))
+
+	challenge := sha256.Sum256([]byte(ls.Verifier))
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.cfg.ClientID},
+		"redirect_uri":          {c.cfg.RedirectURL},
+		"scope":                 {strings.Join(c.cfg.Scopes, " ")},
+		"state":                 {ls.State},
+		"nonce":                 {ls.Nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	http.Redirect(w, r, c.provider.AuthorizationEndpoint+"?"+q.Encode(), http.StatusFound)
+}
+
+// callback exchanges the code sent back by the issuer for an ID token and
+// starts a session with its claims.
+func (c *Client) callback(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if e := q.Get("error"); e != "" {
+		slog.InfoContext(r.Context(), "login refused by the issuer", "error", e, "description", q.Get("error_description"))
+		writeProblem(w, http.StatusUnauthorized, "login refused: "+e)
+		return
+	}
+	cookie, err := r.Cookie(stateCookie)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "no login in progress; start again at /auth/login")
+		return
+	}
+	ls, err := decodeCookie[loginState](c.state, cookie.Value, time.Now())
+	if err != nil || subtle.ConstantTimeCompare([]byte(ls.State), []byte(q.Get("state"))) != 1 {
+		writeProblem(w, http.StatusBadRequest, "login state mismatch or expired; start again at /auth/login")
+		return
+	}
+	http.SetCookie(w, c.cookie(stateCookie, "", time.Unix(0, 0)))
+
+	claims, err := c.exchange(r.Context(), q.Get("code"), ls)
+	if errors.Is(err, domain.ErrTokenInvalid) {
+		slog.WarnContext(r.Context(), "ID token rejected", "error", err)
+		writeProblem(w, http.StatusUnauthorized, "invalid ID token")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "exchanging authorization code", "error", err)
+		writeProblem(w, http.StatusBadGateway, "the issuer could not be reached")
+		return
+	}
+
+	value, expires, err := c.sessions.issue(claims, time.Now())
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, "login failed")
+		return
+	}
+	http.SetCookie(w, c.cookie(SessionCookie, value, expires))
+	slog.InfoContext(r.Context(), "logged in", "subject", claims.Subject)
+	http.Redirect(w, r, ls.ReturnTo, http.StatusSeeOther)
+}
+
+// exchange redeems code at the token endpoint and returns the claims of
+// the ID token, checked against the nonce of the login.
+func (c *Client) exchange(ctx context.Context, code string, ls loginState) (domain.Claims, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.cfg.RedirectURL},
+		"client_id":     {c.cfg.ClientID},
+		"client_secret": {c.cfg.ClientSecret},
+		"code_verifier": {ls.Verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.provider.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return domain.Claims{}, fmt.Errorf("creating token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return domain.Claims{}, fmt.Errorf("requesting tokens: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var tokens struct {
+		IDToken string `json:"id_token"`
+		Error   string `json:"error"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&tokens); err != nil {
+		return domain.Claims{}, fmt.Errorf("decoding token response (status %d): %w", resp.StatusCode, err)
+	}
+	// A code that is unknown, used or expired is the person's to retry.
+	if resp.StatusCode == http.StatusBadRequest && tokens.Error == "invalid_grant" {
+		return domain.Claims{}, fmt.Errorf("%w: the authorization code was refused", domain.ErrTokenInvalid)
+	}
+	if resp.StatusCode != http.StatusOK || tokens.IDToken == "" {
+		return domain.Claims{}, fmt.Errorf("requesting tokens: status %d %s", resp.StatusCode, tokens.Error)
+	}
+
+	claims, err := c.idTokens.Verify(ctx, tokens.IDToken)
+	if err != nil {
+		return domain.Claims{}, err
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.String("nonce")), []byte(ls.Nonce)) != 1 {
+		return domain.Claims{}, fmt.Errorf("%w: nonce mismatch", domain.ErrTokenInvalid)
+	}
+	return claims, nil
+}
+
+// logout ends the session in this browser and sends it to the root. The
+// session at the issuer is left as is.
+func (c *Client) logout(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, c.cookie(SessionCookie, "", time.Unix(0, 0)))
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// SessionResponse is who is logged in.
+type SessionResponse struct {
+	Subject string `json:"subject"`
+	Issuer  string `json:"issuer"`
+	Email   string `json:"email,omitempty"`
+	Name    string `json:"name,omitempty"`
+}
+
+// session returns who is logged in, for a UI to greet them, or 401.
+func (c *Client) session(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie(SessionCookie)
+	if err != nil {
+		writeProblem(w, http.StatusUnauthorized, "not logged in")
+		return
+	}
+	claims, err := c.sessions.Verify(r.Context(), cookie.Value)
+	if err != nil {
+		writeProblem(w, http.StatusUnauthorized, "not logged in")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(SessionResponse{
+		Subject: claims.Subject,
+		Issuer:  claims.Issuer,
+		Email:   claims.String("email"),
+		Name:    claims.String("name"),
+	})
+}
+
+// cookie returns an HTTP-only cookie for the whole server. SameSite=Lax
+// keeps it off cross-site requests other than top-level navigations, such
+// as the issuer's redirect to the callback, so it cannot be used for
+// cross-site request forgery of changes.
+func (c *Client) cookie(name, value string, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   c.secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// safeReturnPath returns path when it is a path on this server, and "/"
+// otherwise, so the login cannot redirect elsewhere.
+func safeReturnPath(path string) string {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.HasPrefix(path, "/\\") {
+		return "/"
+	}
+	return path
+}
+
+// randomString returns 32 random bytes, base64 encoded for URLs.
+func randomString() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b) // never fails
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// writeProblem answers with an RFC 9457 problem, like the API.
+func writeProblem(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"status": status,
+		"title":  http.StatusText(status),
+		"detail": detail,
+	})
+}
@@ -0,0 +1,309 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/oidc"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+const sessionKey = "0123456789abcdef0123456789abcdef"
+
+// fakeIssuer is an OpenID Connect issuer granting a code to whoever asks,
+// for the nonce and PKCE challenge of the authorization request.
+type fakeIssuer struct {
+	srv *httptest.Server
+	key *rsa.PrivateKey
+	// nonce overrides the nonce put in the ID token when set.
+	nonce string
+	codes map[string]url.Values
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	f := &fakeIssuer{key: key, codes: map[string]url.Values{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 f.srv.URL,
+			"authorization_endpoint": f.srv.URL + "/authorize",
+			"token_endpoint":         f.srv.URL + "/token",
+			"jwks_uri":               f.srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		auth, ok := f.codes[r.FormValue("code")]
+		delete(f.codes, r.FormValue("code"))
+		challenge := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if !ok || r.FormValue("client_secret") != "secret" ||
+			base64.RawURLEncoding.EncodeToString(challenge[:]) != auth.Get("code_challenge") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		nonce := auth.Get("nonce")
+		if f.nonce != "" {
+			nonce = f.nonce
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":          f.srv.URL,
+			"sub":          "user-1",
+			"aud":          "tenantiq",
+			"email":        "alice@example.com",
+			"realm_access": map[string]any{"roles": []string{"operator"}},
+			"nonce":        nonce,
+			"exp":          time.Now().Add(time.Hour).Unix(),
+		})
+		token.Header["kid"] = "k1"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Errorf("signing ID token: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": signed, "token_type": "Bearer"})
+	})
+	f.srv = httptest.NewServer(mux)
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+// authorize grants a code for the authorization request at location and
+// returns the callback query.
+func (f *fakeIssuer) authorize(t *testing.T, location string) url.Values {
+	t.Helper()
+	u, err := url.Parse(location)
+	if err != nil {
+		t.Fatalf("parsing %q: %v", location, err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("client_id") != "tenantiq" {
+		t.Fatalf("authorization request = %v", q)
+	}
+	code := "code-" + q.Get("state")[:8]
+	f.codes[code] = q
+	return url.Values{"code": {code}, "state": {q.Get("state")}}
+}
+
+// newApp serves the login routes of a client of f.
+func newApp(t *testing.T, f *fakeIssuer) (*httptest.Server, *oidc.Client) {
+	t.Helper()
+	client, err := oidc.New(context.Background(), oidc.Config{
+		IssuerURL:    f.srv.URL,
+		ClientID:     "tenantiq",
+		ClientSecret: "secret",
+		RedirectURL:  "http://app.example.com/auth/callback",
+		Scopes:       []string{"openid", "email"},
+		SessionKey:   []byte(sessionKey),
+		SessionTTL:   time.Hour,
+		ActorClaim:   "email",
+		RoleClaim:    "realm_access.roles",
+	}, f.srv.Client())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	r := chi.NewMux()
+	client.Mount(r)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv, client
+}
+
+// noRedirects returns the redirects instead of following them.
+var noRedirects = &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}}
+
+// login starts a login at the app, returning the state cookie and the
+// issuer's callback query.
+func login(t *testing.T, app *httptest.Server, f *fakeIssuer, returnTo string) (*http.Cookie, url.Values) {
+	t.Helper()
+	resp, err := noRedirects.Get(app.URL + "/auth/login?return_to=" + url.QueryEscape(returnTo))
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound || len(resp.Cookies()) != 1 {
+		t.Fatalf("login: status = %d, cookies %v", resp.StatusCode, resp.Cookies())
+	}
+	return resp.Cookies()[0], f.authorize(t, resp.Header.Get("Location"))
+}
+
+func callback(t *testing.T, app *httptest.Server, state *http.Cookie, q url.Values) *http.Response {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, app.URL+"/auth/callback?"+q.Encode(), nil)
+	if state != nil {
+		req.AddCookie(state)
+	}
+	resp, err := noRedirects.Do(req)
+	if err != nil {
+		t.Fatalf("callback: %v", err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func sessionCookie(resp *http.Response) *http.Cookie {
+	for _, c := range resp.Cookies() {
+		if c.Name == oidc.SessionCookie && c.Value != "" {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestLogin(t *testing.T) {
+	f := newFakeIssuer(t)
+	app, client := newApp(t, f)
+
+	state, q := login(t, app, f, "/admin/tenants?page=2")
+	resp := callback(t, app, state, q)
+	if resp.StatusCode != http.StatusSeeOther || resp.Header.Get("Location") != "/admin/tenants?page=2" {
+		t.Fatalf("callback: status = %d, location %q", resp.StatusCode, resp.Header.Get("Location"))
+	}
+	session := sessionCookie(resp)
+	if session == nil || !session.HttpOnly || session.SameSite != http.SameSiteLaxMode {
+		t.Fatalf("session cookie = %+v", session)
+	}
+
+	claims, err := client.Sessions().Verify(context.Background(), session.Value)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims.Subject != "user-1" || claims.Issuer != f.srv.URL || claims.String("email") != "alice@example.com" {
+		t.Errorf("claims = %+v", claims)
+	}
+	if _, ok := claims.Values["nonce"]; ok {
+		t.Error("the session keeps the nonce; want only the identity claims")
+	}
+	if roles := claims.Strings("realm_access.roles"); len(roles) != 1 || roles[0] != "operator" {
+		t.Errorf("session roles = %v, want the role claim kept", roles)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, app.URL+"/auth/session", nil)
+	req.AddCookie(session)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("session: %v", err)
+	}
+	var who oidc.SessionResponse
+	_ = json.NewDecoder(resp.Body).Decode(&who)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || who.Subject != "user-1" || who.Email != "alice@example.com" {
+		t.Errorf("session: status = %d, body %+v", resp.StatusCode, who)
+	}
+
+	// The code is used up.
+	if resp := callback(t, app, state, q); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("replayed callback: status = %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestLogin_Rejections(t *testing.T) {
+	f := newFakeIssuer(t)
+	app, _ := newApp(t, f)
+
+	state, q := login(t, app, f, "/")
+	if resp := callback(t, app, nil, q); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("without the state cookie: status = %d, want 400", resp.StatusCode)
+	}
+
+	other, _ := login(t, app, f, "/")
+	if resp := callback(t, app, other, q); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("with another login's state: status = %d, want 400", resp.StatusCode)
+	}
+
+	f.nonce = "replayed"
+	resp := callback(t, app, state, q)
+	if resp.StatusCode != http.StatusUnauthorized || sessionCookie(resp) != nil {
+		t.Errorf("nonce mismatch: status = %d, want 401 without a session", resp.StatusCode)
+	}
+
+	if resp := callback(t, app, state, url.Values{"error": {"access_denied"}}); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("refused by the issuer: status = %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestLogin_ReturnsOnlyToLocalPaths(t *testing.T) {
+	f := newFakeIssuer(t)
+	app, _ := newApp(t, f)
+
+	for _, returnTo := range []string{"https://evil.example.com", "//evil.example.com", `/\evil.example.com`, ""} {
+		state, q := login(t, app, f, returnTo)
+		if resp := callback(t, app, state, q); resp.Header.Get("Location") != "/" {
+			t.Errorf("return_to %q: location = %q, want /", returnTo, resp.Header.Get("Location"))
+		}
+	}
+}
+
+func TestSessions_Verify(t *testing.T) {
+	f := newFakeIssuer(t)
+	app, client := newApp(t, f)
+	state, q := login(t, app, f, "/")
+	session := sessionCookie(callback(t, app, state, q))
+	ctx := context.Background()
+
+	payload, sig, _ := strings.Cut(session.Value, ".")
+	for name, value := range map[string]string{
+		"tampered":    payload + "x." + sig,
+		"unsigned":    payload,
+		"login state": state.Value,
+	} {
+		if _, err := client.Sessions().Verify(ctx, value); !errors.Is(err, domain.ErrTokenInvalid) {
+			t.Errorf("%s: Verify = %v, want ErrTokenInvalid", name, err)
+		}
+	}
+
+	other, err := oidc.NewSessions([]byte(strings.Repeat("k", 32)), time.Hour, "sub")
+	if err != nil {
+		t.Fatalf("NewSessions: %v", err)
+	}
+	if _, err := other.Verify(ctx, session.Value); !errors.Is(err, domain.ErrTokenInvalid) {
+		t.Errorf("signed with another key: Verify = %v, want ErrTokenInvalid", err)
+	}
+	if _, err := oidc.NewSessions([]byte("short"), time.Hour, "sub"); err == nil {
+		t.Error("NewSessions with a short key: want an error")
+	}
+}
+
+func TestDiscover_RejectsOtherIssuer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 "https://evil.example.com",
+			"authorization_endpoint": "https://evil.example.com/authorize",
+			"token_endpoint":         "https://evil.example.com/token",
+			"jwks_uri":               "https://evil.example.com/jwks",
+		})
+	}))
+	t.Cleanup(srv.Close)
+	_, err := oidc.Discover(context.Background(), srv.URL, srv.Client())
+	if err == nil || !strings.Contains(err.Error(), "evil.example.com") {
+		t.Errorf("Discover = %v, want an issuer mismatch", err)
+	}
+}
@@ -0,0 +1,136 @@
+package oidc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: Sessions implements domain.TokenVerifier.
+var _ domain.TokenVerifier = (*Sessions)(nil)
+
+// MinSessionKeyLength is the shortest session signing key accepted, in
+// bytes.
+const MinSessionKeyLength = 32
+
+// sessionClaims are the claims of the ID token kept in the session, on top
+// of the configured actor claim. Cookies are limited to about 4 KB, so the
+// rest is dropped.
+var sessionClaims = []string{"sub", "iss", "email", "name", "preferred_username", "groups"}
+
+// errInvalidCookie is returned for a cookie that was not signed with the
+// key, or has expired.
+var errInvalidCookie = errors.New("invalid or expired cookie")
+
+// cookieCodec signs the values stored in cookies, as the base64 JSON of
+// the value and its HMAC-SHA256, separated by a dot. Values are signed, not
+// encrypted: they must hold nothing secret from the browser.
+type cookieCodec struct {
+	key []byte
+}
+
+// signedValue is a value with its expiry.
+type signedValue[T any] struct {
+	Value   T     `json:"v"`
+	Expires int64 `json:"exp"`
+}
+
+func encodeCookie[T any](c cookieCodec, v T, expires time.Time) (string, error) {
+	data, err := json.Marshal(signedValue[T]{Value: v, Expires: expires.Unix()})
+	if err != nil {
+		return "", fmt.Errorf("encoding cookie: %w", err)
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(c.mac(payload)), nil
+}
+
+func decodeCookie[T any](c cookieCodec, s string, now time.Time) (T, error) {
+	var zero T
+	payload, sig, ok := strings.Cut(s, ".")
+	if !ok {
+		return zero, errInvalidCookie
+	}
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, c.mac(payload)) {
+		return zero, errInvalidCookie
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return zero, errInvalidCookie
+	}
+	var sv signedValue[T]
+	if err := json.Unmarshal(data, &sv); err != nil {
+		return zero, errInvalidCookie
+	}
+	if now.Unix() >= sv.Expires {
+		return zero, errInvalidCookie
+	}
+	return sv.Value, nil
+}
+
+func (c cookieCodec) mac(payload string) []byte {
+	m := hmac.New(sha256.New, c.key)
+	m.Write([]byte(payload))
+	return m.Sum(nil)
+}
+
+// Sessions issues and verifies the session cookies of interactive logins.
+// Sessions are stateless: they cannot be revoked before they expire, short
+// of changing the key, which ends all of them.
+type Sessions struct {
+	codec cookieCodec
+	ttl   time.Duration
+	keep  []string
+}
+
+// NewSessions returns sessions signed with key and lasting ttl. They keep
+// the claims of the ID token named in keep, such as the actor and role
+// claims, besides the usual identity claims.
+func NewSessions(key []byte, ttl time.Duration, keep ...string) (*Sessions, error) {
+	if len(key) < MinSessionKeyLength {
+		return nil, fmt.Errorf("session key must be at least %d bytes, got %d", MinSessionKeyLength, len(key))
+	}
+	if ttl <= 0 {
+		return nil, errors.New("session lifetime must be positive")
+	}
+	return &Sessions{codec: cookieCodec{key: key}, ttl: ttl, keep: keep}, nil
+}
+
+// issue returns the cookie value of a session for claims, and when it
+// expires.
+func (s *Sessions) issue(claims domain.Claims, now time.Time) (string, time.Time, error) {
+	kept := make(map[string]any, len(sessionClaims)+len(s.keep))
+	for _, name := range slices.Concat(sessionClaims, s.keep) {
+		if v, ok := claims.Values[name]; ok {
+			kept[name] = v
+		}
+	}
+	expires := now.Add(s.ttl)
+	value, err := encodeCookie(s.codec, kept, expires)
+	return value, expires, err
+}
+
+// Verify returns the claims of the session cookie value. A cookie that was
+// tampered with or has expired is domain.ErrTokenInvalid.
+func (s *Sessions) Verify(_ context.Context, value string) (domain.Claims, error) {
+	values, err := decodeCookie[map[string]any](s.codec, value, time.Now())
+	if err != nil {
+		return domain.Claims{}, fmt.Errorf("%w: %v", domain.ErrTokenInvalid, err)
+	}
+	c := domain.Claims{Values: values}
+	c.Subject = c.String("sub")
+	c.Issuer = c.String("iss")
+	if c.Subject == "" {
+		return domain.Claims{}, fmt.Errorf("%w: session without a subject", domain.ErrTokenInvalid)
+	}
+	return c, nil
+}
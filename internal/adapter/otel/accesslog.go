@@ -0,0 +1,75 @@
+package otel
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+// tenantRoutePrefix starts the routes whose id parameter is a tenant ID.
+const tenantRoutePrefix = "/api/v1/tenants/{id}"
+
+// actorHeader names the caller, as recorded in the audit trail.
+const actorHeader = "X-Actor"
+
+// AccessLog returns a chi middleware emitting one OpenTelemetry log record
+// per request on the AccessLoggerName stream, with the route, status and
+// duration as attributes, and tenant.id on the routes of a tenant. It
+// must run inside middleware.RequestID to record request IDs.
+func AccessLog() func(http.Handler) http.Handler {
+	logger := global.Logger(AccessLoggerName)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK // nothing written
+			}
+			route := r.URL.Path
+			var tenantID string
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				if pattern := rctx.RoutePattern(); pattern != "" {
+					route = pattern
+				}
+				if strings.HasPrefix(route, tenantRoutePrefix) {
+					tenantID = rctx.URLParam("id")
+				}
+			}
+
+			var rec log.Record
+			rec.SetEventName("http.access")
+			rec.SetTimestamp(start)
+			rec.SetSeverity(log.SeverityInfo)
+			rec.SetSeverityText("INFO")
+			rec.SetBody(log.StringValue(fmt.Sprintf("%s %s %d", r.Method, r.URL.Path, status)))
+			rec.AddAttributes(
+				log.String("http.request.method", r.Method),
+				log.String("http.route", route),
+				log.String("url.path", r.URL.Path),
+				log.Int("http.response.status_code", status),
+				log.Int("http.response.body.size", ww.BytesWritten()),
+				log.Float64("http.server.request.duration", time.Since(start).Seconds()),
+				log.String("client.address", r.RemoteAddr),
+			)
+			if tenantID != "" {
+				rec.AddAttributes(log.String("tenant.id", tenantID))
+			}
+			if actor := r.Header.Get(actorHeader); actor != "" {
+				rec.AddAttributes(log.String("audit.actor", actor))
+			}
+			if id := middleware.GetReqID(r.Context()); id != "" {
+				rec.AddAttributes(log.String("request.id", id))
+			}
+			logger.Emit(r.Context(), rec)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package otel_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/otel"
+)
+
+func TestAccessLog(t *testing.T) {
+	recorder := setupTestLogger(t)
+
+	router := chi.NewMux()
+	router.Use(middleware.RequestID)
+	router.Use(adapter.AccessLog())
+	router.Get("/api/v1/tenants/{id}/history", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	router.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tenants/ten_1/history", nil)
+	req.Header.Set("X-Actor", "alice")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	records := recorder.Records()
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+
+	tenant := records[0]
+	if tenant.InstrumentationScope().Name != adapter.AccessLoggerName {
+		t.Errorf("logger = %q, want %q", tenant.InstrumentationScope().Name, adapter.AccessLoggerName)
+	}
+	assertLogAttribute(t, tenant, "http.route", "/api/v1/tenants/{id}/history")
+	assertLogAttribute(t, tenant, "tenant.id", "ten_1")
+	assertLogAttribute(t, tenant, "audit.actor", "alice")
+	attrs := logAttributes(tenant)
+	if got := attrs["http.response.status_code"].AsInt64(); got != http.StatusNotFound {
+		t.Errorf("status code = %d, want %d", got, http.StatusNotFound)
+	}
+	if attrs["request.id"].AsString() == "" {
+		t.Error("request.id missing")
+	}
+
+	health := records[1]
+	assertLogAttribute(t, health, "http.route", "/healthz")
+	if _, ok := logAttributes(health)["tenant.id"]; ok {
+		t.Error("tenant.id set on a route without a tenant")
+	}
+	if got := logAttributes(health)["http.response.status_code"].AsInt64(); got != http.StatusOK {
+		t.Errorf("status code = %d, want %d", got, http.StatusOK)
+	}
+}
@@ -0,0 +1,123 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// TracingAuditLogger wraps a domain.AuditLogger with OpenTelemetry tracing.
+type TracingAuditLogger struct {
+	next   domain.AuditLogger
+	tracer trace.Tracer
+}
+
+// Compile-time check: TracingAuditLogger implements domain.AuditLogger.
+var _ domain.AuditLogger = (*TracingAuditLogger)(nil)
+
+// NewTracingAuditLogger creates a tracing decorator around the given audit logger.
+func NewTracingAuditLogger(next domain.AuditLogger) *TracingAuditLogger {
+	return &TracingAuditLogger{
+		next:   next,
+		tracer: otel.Tracer(tracerName),
+	}
+}
+
+func (l *TracingAuditLogger) Log(ctx context.Context, e domain.AuditEntry) error {
+	ctx, span := l.tracer.Start(ctx, "AuditLogger.Log",
+		trace.WithAttributes(
+			attribute.String("audit.action", string(e.Action)),
+			attribute.String("audit.actor", e.Actor),
+			attribute.String("tenant.id", e.TenantID),
+		),
+	)
+	defer span.End()
+
+	err := l.next.Log(ctx, e)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// StreamingAuditLogger wraps a domain.AuditLogger and emits each entry it
+// stores as an OpenTelemetry log record on the AuditLoggerName stream, with
+// its tenant, actor and action as attributes, so SIEM queries do not parse
+// message text.
+type StreamingAuditLogger struct {
+	next   domain.AuditLogger
+	logger log.Logger
+}
+
+// Compile-time check: StreamingAuditLogger implements domain.AuditLogger.
+var _ domain.AuditLogger = (*StreamingAuditLogger)(nil)
+
+// NewStreamingAuditLogger creates a streaming decorator around the given
+// audit logger, emitting through the global LoggerProvider.
+func NewStreamingAuditLogger(next domain.AuditLogger) *StreamingAuditLogger {
+	return &StreamingAuditLogger{
+		next:   next,
+		logger: global.Logger(AuditLoggerName),
+	}
+}
+
+// Log stores the entry and, once stored, emits it: the stream only carries
+// changes that happened.
+func (l *StreamingAuditLogger) Log(ctx context.Context, e domain.AuditEntry) error {
+	if err := l.next.Log(ctx, e); err != nil {
+		return err
+	}
+
+	var r log.Record
+	r.SetEventName("tenant.audit")
+	r.SetTimestamp(e.At)
+	r.SetSeverity(log.SeverityInfo)
+	r.SetSeverityText("INFO")
+	r.SetBody(log.StringValue(fmt.Sprintf("tenant %s %s by %s", e.TenantID, e.Action, e.Actor)))
+	r.AddAttributes(
+		log.String("tenant.id", e.TenantID),
+		log.String("audit.actor", e.Actor),
+		log.String("audit.action", string(e.Action)),
+	)
+	if t := auditTenant(e); t != nil {
+		r.AddAttributes(log.String("tenant.slug", t.Slug), log.String("tenant.status", string(t.Status)))
+	}
+	if e.Event != "" {
+		r.AddAttributes(log.String("audit.event", string(e.Event)))
+	}
+	if e.RequestID != "" {
+		r.AddAttributes(log.String("request.id", e.RequestID))
+	}
+	if e.ClientCN != "" {
+		r.AddAttributes(log.String("audit.client_cn", e.ClientCN))
+	}
+	if e.Before != nil && e.After != nil {
+		if changes := domain.Diff(*e.Before, *e.After); len(changes) > 0 {
+			fields := make([]log.Value, len(changes))
+			for i, c := range changes {
+				fields[i] = log.StringValue(c.Field)
+			}
+			r.AddAttributes(log.Slice("audit.changed_fields", fields...))
+		}
+	}
+	l.logger.Emit(ctx, r)
+	return nil
+}
+
+// auditTenant returns the tenant an entry is about: its state after the
+// change, or before it for purges.
+func auditTenant(e domain.AuditEntry) *domain.Tenant {
+	if e.After != nil {
+		return e.After
+	}
+	return e.Before
+}
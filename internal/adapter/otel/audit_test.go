@@ -0,0 +1,122 @@
+package otel_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/otel"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+type auditFunc func(context.Context, domain.AuditEntry) error
+
+func (f auditFunc) Log(ctx context.Context, e domain.AuditEntry) error { return f(ctx, e) }
+
+func TestTracingAuditLogger_Log_RecordsSpan(t *testing.T) {
+	exporter := setupTestTracer(t)
+	var logged []domain.AuditEntry
+	log := adapter.NewTracingAuditLogger(auditFunc(func(_ context.Context, e domain.AuditEntry) error {
+		logged = append(logged, e)
+		return nil
+	}))
+
+	entry := domain.AuditEntry{Action: domain.AuditUpdate, TenantID: "t-1", Actor: "alice"}
+	if err := log.Log(context.Background(), entry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if spans[0].Name != "AuditLogger.Log" {
+		t.Errorf("span name = %q, want %q", spans[0].Name, "AuditLogger.Log")
+	}
+	assertAttribute(t, spans[0], "audit.action", "update")
+	assertAttribute(t, spans[0], "audit.actor", "alice")
+	assertAttribute(t, spans[0], "tenant.id", "t-1")
+
+	if len(logged) != 1 {
+		t.Errorf("inner logger got %d entries, want 1", len(logged))
+	}
+}
+
+func TestTracingAuditLogger_Log_RecordsError(t *testing.T) {
+	exporter := setupTestTracer(t)
+	log := adapter.NewTracingAuditLogger(auditFunc(func(context.Context, domain.AuditEntry) error {
+		return fmt.Errorf("disk full")
+	}))
+
+	if err := log.Log(context.Background(), domain.AuditEntry{Action: domain.AuditCreate}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if spans[0].Status.Code != codes.Error {
+		t.Errorf("span status = %v, want Error", spans[0].Status.Code)
+	}
+}
+
+func TestStreamingAuditLogger_EmitsRecord(t *testing.T) {
+	recorder := setupTestLogger(t)
+	log := adapter.NewStreamingAuditLogger(auditFunc(func(context.Context, domain.AuditEntry) error { return nil }))
+
+	before := domain.NewTenant("t-1", "Acme", "acme", "free")
+	after := before
+	after.Plan = "pro"
+	after.Tags = []string{"beta"}
+	entry := domain.AuditEntry{
+		Action: domain.AuditUpdate, TenantID: "t-1", Actor: "alice", RequestID: "req-1", ClientCN: "billing.internal",
+		Before: &before, After: &after, At: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	if err := log.Log(context.Background(), entry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := recorder.Records()
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	r := records[0]
+	if r.InstrumentationScope().Name != adapter.AuditLoggerName {
+		t.Errorf("logger = %q, want %q", r.InstrumentationScope().Name, adapter.AuditLoggerName)
+	}
+	if r.EventName() != "tenant.audit" || !r.Timestamp().Equal(entry.At) {
+		t.Errorf("event = %q at %v", r.EventName(), r.Timestamp())
+	}
+	assertLogAttribute(t, r, "tenant.id", "t-1")
+	assertLogAttribute(t, r, "tenant.slug", "acme")
+	assertLogAttribute(t, r, "audit.actor", "alice")
+	assertLogAttribute(t, r, "audit.action", "update")
+	assertLogAttribute(t, r, "request.id", "req-1")
+	assertLogAttribute(t, r, "audit.client_cn", "billing.internal")
+
+	var fields []string
+	for _, v := range logAttributes(r)["audit.changed_fields"].AsSlice() {
+		fields = append(fields, v.AsString())
+	}
+	if fmt.Sprint(fields) != "[plan tags]" {
+		t.Errorf("audit.changed_fields = %v, want [plan tags]", fields)
+	}
+}
+
+func TestStreamingAuditLogger_SkipsFailedEntries(t *testing.T) {
+	recorder := setupTestLogger(t)
+	log := adapter.NewStreamingAuditLogger(auditFunc(func(context.Context, domain.AuditEntry) error {
+		return fmt.Errorf("disk full")
+	}))
+
+	if err := log.Log(context.Background(), domain.AuditEntry{Action: domain.AuditCreate}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if n := len(recorder.Records()); n != 0 {
+		t.Errorf("got %d records for an entry that was not stored, want 0", n)
+	}
+}
@@ -0,0 +1,78 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// JobMetrics counts the jobs the queue takes in and works, by kind and
+// queue:
+//
+//   - tenantiq.jobs.inserted{kind, queue}: jobs queued
+//   - tenantiq.jobs.worked{kind, queue, outcome}: attempts, by outcome
+//     (completed, failed, discarded, cancelled, snoozed)
+//   - tenantiq.jobs.retries{kind, queue}: attempts after the first
+//   - tenantiq.jobs.duration{kind, queue, outcome}: how long attempts ran
+//
+// Its methods match river.JobObserver. tenantiq.queue.jobs (see
+// RegisterQueueMetrics) shows the backlog these leave.
+type JobMetrics struct {
+	inserted metric.Int64Counter
+	worked   metric.Int64Counter
+	retries  metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// NewJobMetrics creates the instruments of JobMetrics.
+func NewJobMetrics() (*JobMetrics, error) {
+	meter := otel.Meter(meterName)
+
+	inserted, err := meter.Int64Counter("tenantiq.jobs.inserted",
+		metric.WithDescription("Jobs queued per kind"),
+		metric.WithUnit("{job}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating inserted jobs counter: %w", err)
+	}
+	worked, err := meter.Int64Counter("tenantiq.jobs.worked",
+		metric.WithDescription("Job attempts per kind and outcome"),
+		metric.WithUnit("{attempt}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating worked jobs counter: %w", err)
+	}
+	retries, err := meter.Int64Counter("tenantiq.jobs.retries",
+		metric.WithDescription("Job attempts after the first per kind"),
+		metric.WithUnit("{attempt}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating job retries counter: %w", err)
+	}
+	duration, err := meter.Float64Histogram("tenantiq.jobs.duration",
+		metric.WithDescription("Duration of job attempts per kind and outcome"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating job duration histogram: %w", err)
+	}
+	return &JobMetrics{inserted: inserted, worked: worked, retries: retries, duration: duration}, nil
+}
+
+func (m *JobMetrics) JobInserted(ctx context.Context, kind, queue string) {
+	m.inserted.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", kind), attribute.String("queue", queue)))
+}
+
+func (m *JobMetrics) JobWorked(ctx context.Context, kind, queue string, attempt int, elapsed time.Duration, outcome string) {
+	job := []attribute.KeyValue{attribute.String("kind", kind), attribute.String("queue", queue)}
+	if attempt > 1 {
+		m.retries.Add(ctx, 1, metric.WithAttributes(job...))
+	}
+	attrs := metric.WithAttributes(append(job, attribute.String("outcome", outcome))...)
+	m.worked.Add(ctx, 1, attrs)
+	m.duration.Record(ctx, elapsed.Seconds(), attrs)
+}
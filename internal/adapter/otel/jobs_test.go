@@ -0,0 +1,55 @@
+package otel_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/otel"
+)
+
+func TestJobMetrics(t *testing.T) {
+	reader := setupTestMeter(t)
+	metrics, err := adapter.NewJobMetrics()
+	if err != nil {
+		t.Fatalf("NewJobMetrics failed: %v", err)
+	}
+	ctx := context.Background()
+
+	metrics.JobInserted(ctx, "tenant.event", "default")
+	metrics.JobInserted(ctx, "tenant.event", "default")
+	metrics.JobWorked(ctx, "tenant.event", "default", 1, 20*time.Millisecond, "completed")
+	metrics.JobWorked(ctx, "webhook.deliver", "default", 1, time.Second, "failed")
+	metrics.JobWorked(ctx, "webhook.deliver", "default", 2, 2*time.Second, "discarded")
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if got := collectInt64(t, rm, "tenantiq.jobs.inserted", "kind", "queue"); got["tenant.event/default"] != 2 || len(got) != 1 {
+		t.Errorf("inserted = %v, want 2 event jobs", got)
+	}
+	worked := collectInt64(t, rm, "tenantiq.jobs.worked", "kind", "outcome")
+	if worked["tenant.event/completed"] != 1 || worked["webhook.deliver/failed"] != 1 || worked["webhook.deliver/discarded"] != 1 {
+		t.Errorf("worked = %v, want one attempt per outcome", worked)
+	}
+	if got := collectInt64(t, rm, "tenantiq.jobs.retries", "kind"); got["webhook.deliver"] != 1 || len(got) != 1 {
+		t.Errorf("retries = %v, want the second webhook attempt", got)
+	}
+
+	var durations uint64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if h, ok := m.Data.(metricdata.Histogram[float64]); ok && m.Name == "tenantiq.jobs.duration" {
+				for _, dp := range h.DataPoints {
+					durations += dp.Count
+				}
+			}
+		}
+	}
+	if durations != 3 {
+		t.Errorf("duration recorded %d attempts, want 3", durations)
+	}
+}
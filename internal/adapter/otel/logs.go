@@ -0,0 +1,137 @@
+package otel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// Logger names of the log streams, kept apart from the application logs
+// (slog) so SIEMs can ingest them alone.
+const (
+	AuditLoggerName  = "tenantiq.audit"
+	AccessLoggerName = "tenantiq.access"
+)
+
+func newLoggerProvider(ctx context.Context, cfg Config, res *resource.Resource, stdout io.Writer) (*sdklog.LoggerProvider, error) {
+	var exporter sdklog.Exporter
+	var err error
+
+	switch cfg.Exporter {
+	case "otlp":
+		var opts []otlploghttp.Option
+		if cfg.Insecure {
+			opts = append(opts, otlploghttp.WithInsecure())
+		}
+		exporter, err = otlploghttp.New(ctx, opts...)
+	case "stdout":
+		exporter = &jsonLogExporter{w: stdout}
+	default:
+		return nil, fmt.Errorf("unsupported exporter: %q (use \"stdout\" or \"otlp\")", cfg.Exporter)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sdklog.NewLoggerProvider(
+		sdklog.WithResource(res),
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+	), nil
+}
+
+// jsonLogExporter writes log records as JSON lines, one per record, for
+// development without a collector.
+type jsonLogExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// jsonLogRecord is the JSON form of a log record.
+type jsonLogRecord struct {
+	Timestamp  time.Time      `json:"timestamp"`
+	Logger     string         `json:"logger"`
+	EventName  string         `json:"event_name,omitempty"`
+	Severity   string         `json:"severity,omitempty"`
+	Body       any            `json:"body,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	TraceID    string         `json:"trace_id,omitempty"`
+	SpanID     string         `json:"span_id,omitempty"`
+}
+
+func (e *jsonLogExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	enc := json.NewEncoder(e.w)
+	for _, r := range records {
+		out := jsonLogRecord{
+			Timestamp: r.Timestamp(),
+			Logger:    r.InstrumentationScope().Name,
+			EventName: r.EventName(),
+			Severity:  r.SeverityText(),
+			Body:      logValue(r.Body()),
+		}
+		if out.Severity == "" && r.Severity() != log.SeverityUndefined {
+			out.Severity = r.Severity().String()
+		}
+		if r.AttributesLen() > 0 {
+			out.Attributes = make(map[string]any, r.AttributesLen())
+			r.WalkAttributes(func(kv log.KeyValue) bool {
+				out.Attributes[kv.Key] = logValue(kv.Value)
+				return true
+			})
+		}
+		if id := r.TraceID(); id.IsValid() {
+			out.TraceID = id.String()
+		}
+		if id := r.SpanID(); id.IsValid() {
+			out.SpanID = id.String()
+		}
+		if err := enc.Encode(out); err != nil {
+			return fmt.Errorf("writing log record: %w", err)
+		}
+	}
+	return nil
+}
+
+func (e *jsonLogExporter) Shutdown(context.Context) error   { return nil }
+func (e *jsonLogExporter) ForceFlush(context.Context) error { return nil }
+
+// logValue converts a log value to its JSON counterpart.
+func logValue(v log.Value) any {
+	switch v.Kind() {
+	case log.KindBool:
+		return v.AsBool()
+	case log.KindInt64:
+		return v.AsInt64()
+	case log.KindFloat64:
+		return v.AsFloat64()
+	case log.KindString:
+		return v.AsString()
+	case log.KindBytes:
+		return v.AsBytes()
+	case log.KindSlice:
+		values := v.AsSlice()
+		out := make([]any, len(values))
+		for i, item := range values {
+			out[i] = logValue(item)
+		}
+		return out
+	case log.KindMap:
+		out := make(map[string]any)
+		for _, kv := range v.AsMap() {
+			out[kv.Key] = logValue(kv.Value)
+		}
+		return out
+	}
+	return nil
+}
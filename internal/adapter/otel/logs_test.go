@@ -0,0 +1,68 @@
+package otel_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// logRecorder is an in-memory log exporter.
+type logRecorder struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (r *logRecorder) Export(_ context.Context, records []sdklog.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rec := range records {
+		r.records = append(r.records, rec.Clone())
+	}
+	return nil
+}
+
+func (r *logRecorder) Shutdown(context.Context) error   { return nil }
+func (r *logRecorder) ForceFlush(context.Context) error { return nil }
+
+func (r *logRecorder) Records() []sdklog.Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]sdklog.Record(nil), r.records...)
+}
+
+// setupTestLogger registers a global LoggerProvider exporting to the
+// returned recorder synchronously. Loggers must be obtained afterwards.
+func setupTestLogger(t *testing.T) *logRecorder {
+	t.Helper()
+	recorder := &logRecorder{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(recorder)))
+	global.SetLoggerProvider(lp)
+	t.Cleanup(func() { _ = lp.Shutdown(context.Background()) })
+	return recorder
+}
+
+// logAttributes returns the attributes of a record by key.
+func logAttributes(r sdklog.Record) map[string]log.Value {
+	attrs := make(map[string]log.Value)
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+func assertLogAttribute(t *testing.T, r sdklog.Record, key, want string) {
+	t.Helper()
+	v, ok := logAttributes(r)[key]
+	if !ok {
+		t.Errorf("attribute %q missing", key)
+		return
+	}
+	if got := v.AsString(); got != want {
+		t.Errorf("attribute %q = %q, want %q", key, got, want)
+	}
+}
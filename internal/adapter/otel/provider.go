@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/log/global"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -24,6 +27,20 @@ type Config struct {
 	Environment    string // "development" or "production"
 	Exporter       string // "stdout" or "otlp"
 	Insecure       bool   // use HTTP instead of HTTPS for OTLP
+	// Sampler picks the traces recorded, named as OTEL_TRACES_SAMPLER:
+	// "always_on", "always_off", "traceidratio" or their "parentbased_"
+	// variants, which follow the decision of the caller when there is one.
+	// Empty means "parentbased_always_on".
+	Sampler string
+	// SamplerArg is the ratio of traces the traceidratio samplers record,
+	// from 0 to 1; empty means 1.
+	SamplerArg string
+	// ResourceAttributes describe where the service runs, such as
+	// "deployment.region", "k8s.cluster.name" or "team", so the telemetry of
+	// each deployment can be told apart. They are merged over those of
+	// OTEL_RESOURCE_ATTRIBUTES, which Setup always reads; the service name,
+	// version and environment above take precedence over both.
+	ResourceAttributes map[string]string
 }
 
 // ConfigFromEnv builds Config from environment variables with sensible defaults.
@@ -35,6 +52,8 @@ func ConfigFromEnv() Config {
 		Environment:    env,
 		Exporter:       envOrDefault("OTEL_EXPORTER", "stdout"),
 		Insecure:       env == "development",
+		Sampler:        envOrDefault("OTEL_TRACES_SAMPLER", "parentbased_always_on"),
+		SamplerArg:     os.Getenv("OTEL_TRACES_SAMPLER_ARG"),
 	}
 }
 
@@ -43,11 +62,18 @@ type Providers struct {
 	Shutdown func(ctx context.Context) error
 }
 
-// Setup initializes TracerProvider and MeterProvider based on Config.
-// It registers them globally and returns a Providers whose Shutdown must
+// Setup initializes TracerProvider, MeterProvider and LoggerProvider based
+// on Config. It registers them globally and returns a Providers whose Shutdown must
 // be called on application exit to flush pending telemetry.
 func Setup(ctx context.Context, cfg Config) (*Providers, error) {
+	extra := make([]attribute.KeyValue, 0, len(cfg.ResourceAttributes))
+	for k, v := range cfg.ResourceAttributes {
+		extra = append(extra, attribute.String(k, v))
+	}
+	// Later options take precedence over earlier ones.
 	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithAttributes(extra...),
 		resource.WithAttributes(
 			semconv.ServiceName(cfg.ServiceName),
 			semconv.ServiceVersion(cfg.ServiceVersion),
@@ -68,9 +94,15 @@ func Setup(ctx context.Context, cfg Config) (*Providers, error) {
 		return nil, fmt.Errorf("creating meter provider: %w", err)
 	}
 
+	lp, err := newLoggerProvider(ctx, cfg, res, os.Stdout)
+	if err != nil {
+		return nil, fmt.Errorf("creating logger provider: %w", err)
+	}
+
 	// Register globally so any package can obtain a tracer via otel.Tracer("name").
 	otel.SetTracerProvider(tp)
 	otel.SetMeterProvider(mp)
+	global.SetLoggerProvider(lp)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
 		propagation.TraceContext{},
 		propagation.Baggage{},
@@ -84,6 +116,9 @@ func Setup(ctx context.Context, cfg Config) (*Providers, error) {
 		if err := mp.Shutdown(ctx); err != nil {
 			errs = append(errs, fmt.Errorf("meter shutdown: %w", err))
 		}
+		if err := lp.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("logger shutdown: %w", err))
+		}
 		if len(errs) > 0 {
 			return fmt.Errorf("otel shutdown: %v", errs)
 		}
@@ -114,12 +149,47 @@ func newTracerProvider(ctx context.Context, cfg Config, res *resource.Resource)
 		return nil, err
 	}
 
+	sampler, err := newSampler(cfg.Sampler, cfg.SamplerArg)
+	if err != nil {
+		return nil, err
+	}
+
 	return trace.NewTracerProvider(
 		trace.WithResource(res),
 		trace.WithBatcher(exporter),
+		trace.WithSampler(sampler),
 	), nil
 }
 
+// newSampler returns the sampler named as OTEL_TRACES_SAMPLER, with the
+// ratio arg for the traceidratio samplers.
+func newSampler(name, arg string) (trace.Sampler, error) {
+	ratio := 1.0
+	if arg != "" {
+		var err error
+		ratio, err = strconv.ParseFloat(arg, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("invalid sampler ratio %q: want a number from 0 to 1", arg)
+		}
+	}
+
+	switch name {
+	case "always_on":
+		return trace.AlwaysSample(), nil
+	case "always_off":
+		return trace.NeverSample(), nil
+	case "traceidratio":
+		return trace.TraceIDRatioBased(ratio), nil
+	case "", "parentbased_always_on":
+		return trace.ParentBased(trace.AlwaysSample()), nil
+	case "parentbased_always_off":
+		return trace.ParentBased(trace.NeverSample()), nil
+	case "parentbased_traceidratio":
+		return trace.ParentBased(trace.TraceIDRatioBased(ratio)), nil
+	}
+	return nil, fmt.Errorf("unsupported sampler: %q (use \"always_on\", \"always_off\", \"traceidratio\" or their \"parentbased_\" variants)", name)
+}
+
 func newMeterProvider(ctx context.Context, cfg Config, res *resource.Resource) (*metric.MeterProvider, error) {
 	var exporter metric.Exporter
 	var err error
@@ -4,6 +4,9 @@ import (
 	"context"
 	"testing"
 
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
 	adapter "github.com/neomorfeo/tenantiq/internal/adapter/otel"
 )
 
@@ -50,6 +53,9 @@ func TestConfigFromEnv_Defaults(t *testing.T) {
 	if cfg.Exporter != "stdout" {
 		t.Errorf("Exporter = %q, want %q", cfg.Exporter, "stdout")
 	}
+	if cfg.Sampler != "parentbased_always_on" || cfg.SamplerArg != "" {
+		t.Errorf("Sampler = %q (%q), want %q", cfg.Sampler, cfg.SamplerArg, "parentbased_always_on")
+	}
 }
 
 func TestConfigFromEnv_CustomValues(t *testing.T) {
@@ -73,3 +79,90 @@ func TestConfigFromEnv_CustomValues(t *testing.T) {
 		t.Errorf("Exporter = %q, want %q", cfg.Exporter, "otlp")
 	}
 }
+
+func TestSetup_Sampler(t *testing.T) {
+	tests := []struct {
+		sampler, arg string
+		sampled      bool
+	}{
+		{"always_on", "", true},
+		{"always_off", "", false},
+		{"traceidratio", "0", false},
+		{"parentbased_traceidratio", "1", true},
+		{"parentbased_always_off", "", false},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sampler+tt.arg, func(t *testing.T) {
+			providers, err := adapter.Setup(context.Background(), adapter.Config{
+				ServiceName: "test",
+				Exporter:    "stdout",
+				Sampler:     tt.sampler,
+				SamplerArg:  tt.arg,
+			})
+			if err != nil {
+				t.Fatalf("Setup failed: %v", err)
+			}
+			t.Cleanup(func() { providers.Shutdown(context.Background()) })
+
+			_, span := otel.Tracer("test").Start(context.Background(), "op")
+			span.End()
+			if got := span.SpanContext().IsSampled(); got != tt.sampled {
+				t.Errorf("sampled = %v, want %v", got, tt.sampled)
+			}
+		})
+	}
+}
+
+func TestSetup_InvalidSampler(t *testing.T) {
+	for _, cfg := range []adapter.Config{
+		{Exporter: "stdout", Sampler: "sometimes"},
+		{Exporter: "stdout", Sampler: "traceidratio", SamplerArg: "5%"},
+		{Exporter: "stdout", Sampler: "traceidratio", SamplerArg: "1.5"},
+	} {
+		if _, err := adapter.Setup(context.Background(), cfg); err == nil {
+			t.Errorf("Setup(%q, %q) succeeded, want an error", cfg.Sampler, cfg.SamplerArg)
+		}
+	}
+}
+
+func TestSetup_ResourceAttributes(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "deployment.region=eu-west-1,k8s.cluster.name=prod-a,team=billing,service.version=9.9.9")
+	providers, err := adapter.Setup(context.Background(), adapter.Config{
+		ServiceName:        "test",
+		ServiceVersion:     "0.0.1",
+		Environment:        "test",
+		Exporter:           "stdout",
+		Sampler:            "always_on",
+		ResourceAttributes: map[string]string{"team": "platform"},
+	})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	t.Cleanup(func() { providers.Shutdown(context.Background()) })
+
+	_, span := otel.Tracer("test").Start(context.Background(), "op")
+	span.End()
+	attrs := make(map[string]string)
+	for _, kv := range span.(sdktrace.ReadOnlySpan).Resource().Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	for k, want := range map[string]string{
+		"deployment.region": "eu-west-1",
+		"k8s.cluster.name":  "prod-a",
+		"team":              "platform",
+		"service.name":      "test",
+		"service.version":   "0.0.1",
+	} {
+		if attrs[k] != want {
+			t.Errorf("resource %s = %q, want %q", k, attrs[k], want)
+		}
+	}
+}
+
+func TestSetup_InvalidResourceAttributes(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "region")
+	if _, err := adapter.Setup(context.Background(), adapter.Config{Exporter: "stdout"}); err == nil {
+		t.Error("Setup succeeded, want an error for the malformed attributes")
+	}
+}
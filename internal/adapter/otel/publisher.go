@@ -2,10 +2,13 @@ package otel
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/neomorfeo/tenantiq/internal/domain"
@@ -28,20 +31,65 @@ func NewTracingPublisher(next domain.EventPublisher) *TracingPublisher {
 	}
 }
 
-func (p *TracingPublisher) Publish(ctx context.Context, event domain.Event, tenant domain.Tenant) error {
+func (p *TracingPublisher) Publish(ctx context.Context, e domain.TenantEvent) error {
 	ctx, span := p.tracer.Start(ctx, "EventPublisher.Publish",
 		trace.WithAttributes(
-			attribute.String("event.type", string(event)),
-			attribute.String("tenant.id", tenant.ID),
-			attribute.String("tenant.slug", tenant.Slug),
+			attribute.String("event.type", string(e.Event)),
+			attribute.String("tenant.id", e.Tenant.ID),
+			attribute.String("tenant.slug", e.Tenant.Slug),
 		),
 	)
 	defer span.End()
 
-	err := p.next.Publish(ctx, event, tenant)
+	err := p.next.Publish(ctx, e)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 	}
 	return err
 }
+
+// PublishMetrics records the outcome of publishing to each event target:
+//
+//   - tenantiq.events.published{target, event, outcome}: publishes, where
+//     outcome is "ok" or "error"
+//   - tenantiq.events.publish.duration{target}: time spent per publish
+type PublishMetrics struct {
+	published metric.Int64Counter
+	duration  metric.Float64Histogram
+}
+
+// NewPublishMetrics creates the instruments of PublishMetrics.
+func NewPublishMetrics() (*PublishMetrics, error) {
+	meter := otel.Meter(meterName)
+
+	published, err := meter.Int64Counter("tenantiq.events.published",
+		metric.WithDescription("Events published per target and outcome"),
+		metric.WithUnit("{event}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating published events counter: %w", err)
+	}
+	duration, err := meter.Float64Histogram("tenantiq.events.publish.duration",
+		metric.WithDescription("Time spent publishing an event to a target"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating publish duration histogram: %w", err)
+	}
+	return &PublishMetrics{published: published, duration: duration}, nil
+}
+
+// Observe records one publish; its signature matches app.PublishObserver.
+func (m *PublishMetrics) Observe(ctx context.Context, target string, event domain.Event, elapsed time.Duration, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	m.published.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("target", target),
+		attribute.String("event", string(event)),
+		attribute.String("outcome", outcome),
+	))
+	m.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(attribute.String("target", target)))
+}
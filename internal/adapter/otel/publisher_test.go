@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	adapter "github.com/neomorfeo/tenantiq/internal/adapter/otel"
 	"github.com/neomorfeo/tenantiq/internal/domain"
@@ -22,14 +24,14 @@ type publishedEvent struct {
 	tenant domain.Tenant
 }
 
-func (m *mockPublisher) Publish(_ context.Context, e domain.Event, t domain.Tenant) error {
-	m.events = append(m.events, publishedEvent{event: e, tenant: t})
+func (m *mockPublisher) Publish(_ context.Context, e domain.TenantEvent) error {
+	m.events = append(m.events, publishedEvent{event: e.Event, tenant: e.Tenant})
 	return nil
 }
 
 type failingPublisher struct{}
 
-func (p *failingPublisher) Publish(_ context.Context, _ domain.Event, _ domain.Tenant) error {
+func (p *failingPublisher) Publish(_ context.Context, _ domain.TenantEvent) error {
 	return fmt.Errorf("publish failed")
 }
 
@@ -41,7 +43,7 @@ func TestTracingPublisher_Publish_RecordsSpan(t *testing.T) {
 	pub := adapter.NewTracingPublisher(inner)
 
 	tenant := domain.NewTenant("t-1", "Acme", "acme", "free")
-	if err := pub.Publish(context.Background(), domain.EventProvisionComplete, tenant); err != nil {
+	if err := pub.Publish(context.Background(), domain.TenantEvent{Event: domain.EventProvisionComplete, Tenant: tenant}); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -67,7 +69,7 @@ func TestTracingPublisher_Publish_RecordsError(t *testing.T) {
 	pub := adapter.NewTracingPublisher(&failingPublisher{})
 
 	tenant := domain.NewTenant("t-1", "Acme", "acme", "free")
-	err := pub.Publish(context.Background(), domain.EventProvisionComplete, tenant)
+	err := pub.Publish(context.Background(), domain.TenantEvent{Event: domain.EventProvisionComplete, Tenant: tenant})
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -81,3 +83,41 @@ func TestTracingPublisher_Publish_RecordsError(t *testing.T) {
 		t.Errorf("span status = %v, want %v", spans[0].Status.Code, codes.Error)
 	}
 }
+
+func TestPublishMetrics_Observe(t *testing.T) {
+	reader := setupTestMeter(t)
+	metrics, err := adapter.NewPublishMetrics()
+	if err != nil {
+		t.Fatalf("NewPublishMetrics failed: %v", err)
+	}
+	ctx := context.Background()
+
+	metrics.Observe(ctx, "river", domain.EventSuspend, 5*time.Millisecond, nil)
+	metrics.Observe(ctx, "amqp", domain.EventSuspend, time.Second, fmt.Errorf("broker down"))
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	got := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			got[m.Name] = m.Data
+		}
+	}
+
+	published, ok := got["tenantiq.events.published"].(metricdata.Sum[int64])
+	if !ok || len(published.DataPoints) != 2 {
+		t.Fatalf("tenantiq.events.published = %#v, want 2 data points", got["tenantiq.events.published"])
+	}
+	for _, dp := range published.DataPoints {
+		target, _ := dp.Attributes.Value("target")
+		outcome, _ := dp.Attributes.Value("outcome")
+		if want := map[string]string{"river": "ok", "amqp": "error"}[target.AsString()]; outcome.AsString() != want {
+			t.Errorf("outcome for %s = %s, want %s", target.AsString(), outcome.AsString(), want)
+		}
+	}
+	if _, ok := got["tenantiq.events.publish.duration"].(metricdata.Histogram[float64]); !ok {
+		t.Errorf("tenantiq.events.publish.duration missing: %#v", got)
+	}
+}
@@ -0,0 +1,79 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+const meterName = "github.com/neomorfeo/tenantiq/internal/adapter/otel"
+
+// RegisterQueueMetrics publishes the job queue load and the suggested worker
+// count as observable gauges, read from the monitor at each collection:
+//
+//   - tenantiq.queue.jobs{queue, state}: available and running jobs
+//   - tenantiq.queue.priority_jobs{queue, priority, state}: the same, split
+//     by priority
+//   - tenantiq.queue.completed_rate{queue}: jobs completed per minute
+//   - tenantiq.workers.suggested: workers needed for the current backlog
+func RegisterQueueMetrics(monitor domain.QueueMonitor, policy domain.ScalingPolicy) error {
+	meter := otel.Meter(meterName)
+
+	jobs, err := meter.Int64ObservableGauge("tenantiq.queue.jobs",
+		metric.WithDescription("Jobs per queue and state"),
+		metric.WithUnit("{job}"),
+	)
+	if err != nil {
+		return fmt.Errorf("creating queue jobs gauge: %w", err)
+	}
+	priorityJobs, err := meter.Int64ObservableGauge("tenantiq.queue.priority_jobs",
+		metric.WithDescription("Jobs per queue, priority and state"),
+		metric.WithUnit("{job}"),
+	)
+	if err != nil {
+		return fmt.Errorf("creating queue priority jobs gauge: %w", err)
+	}
+	rate, err := meter.Float64ObservableGauge("tenantiq.queue.completed_rate",
+		metric.WithDescription("Jobs completed per minute per queue"),
+		metric.WithUnit("{job}/min"),
+	)
+	if err != nil {
+		return fmt.Errorf("creating completed rate gauge: %w", err)
+	}
+	suggested, err := meter.Int64ObservableGauge("tenantiq.workers.suggested",
+		metric.WithDescription("Workers needed for the current backlog"),
+		metric.WithUnit("{worker}"),
+	)
+	if err != nil {
+		return fmt.Errorf("creating suggested workers gauge: %w", err)
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		loads, err := monitor.Loads(ctx)
+		if err != nil {
+			return err
+		}
+		for _, l := range loads {
+			queue := attribute.String("queue", l.Queue)
+			o.ObserveInt64(jobs, int64(l.Available), metric.WithAttributes(queue, attribute.String("state", "available")))
+			o.ObserveInt64(jobs, int64(l.Running), metric.WithAttributes(queue, attribute.String("state", "running")))
+			o.ObserveFloat64(rate, l.CompletedPerMinute, metric.WithAttributes(queue))
+			for _, p := range l.Priorities {
+				priority := attribute.String("priority", string(p.Priority))
+				o.ObserveInt64(priorityJobs, int64(p.Available), metric.WithAttributes(queue, priority, attribute.String("state", "available")))
+				o.ObserveInt64(priorityJobs, int64(p.Running), metric.WithAttributes(queue, priority, attribute.String("state", "running")))
+			}
+		}
+		o.ObserveInt64(suggested, int64(policy.SuggestWorkers(loads)))
+		return nil
+	}, jobs, priorityJobs, rate, suggested)
+	if err != nil {
+		return fmt.Errorf("registering queue metrics callback: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package otel_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/otel"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// stubQueue is a QueueMonitor returning fixed loads.
+type stubQueue struct {
+	loads []domain.QueueLoad
+}
+
+func (q *stubQueue) Stats(_ context.Context) (domain.QueueStats, error) {
+	return domain.QueueStats{}, nil
+}
+
+func (q *stubQueue) Loads(_ context.Context) ([]domain.QueueLoad, error) {
+	return q.loads, nil
+}
+
+func setupTestMeter(t *testing.T) *sdkmetric.ManualReader {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(mp)
+	t.Cleanup(func() { _ = mp.Shutdown(context.Background()) })
+	return reader
+}
+
+func TestRegisterQueueMetrics(t *testing.T) {
+	reader := setupTestMeter(t)
+	queue := &stubQueue{loads: []domain.QueueLoad{
+		{Queue: "default", Available: 25, Running: 5, CompletedPerMinute: 2},
+	}}
+
+	if err := adapter.RegisterQueueMetrics(queue, domain.ScalingPolicy{JobsPerWorker: 10}); err != nil {
+		t.Fatalf("RegisterQueueMetrics failed: %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+
+	got := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			got[m.Name] = m.Data
+		}
+	}
+
+	jobs, ok := got["tenantiq.queue.jobs"].(metricdata.Gauge[int64])
+	if !ok || len(jobs.DataPoints) != 2 {
+		t.Fatalf("tenantiq.queue.jobs = %#v, want 2 data points", got["tenantiq.queue.jobs"])
+	}
+
+	suggested, ok := got["tenantiq.workers.suggested"].(metricdata.Gauge[int64])
+	if !ok || len(suggested.DataPoints) != 1 || suggested.DataPoints[0].Value != 3 {
+		t.Errorf("tenantiq.workers.suggested = %#v, want 3", got["tenantiq.workers.suggested"])
+	}
+
+	if _, ok := got["tenantiq.queue.completed_rate"].(metricdata.Gauge[float64]); !ok {
+		t.Errorf("tenantiq.queue.completed_rate missing: %#v", got)
+	}
+}
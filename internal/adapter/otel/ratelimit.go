@@ -0,0 +1,46 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// ThrottleMetrics records the requests refused for exceeding a tenant's
+// rate limit:
+//
+//   - tenantiq.requests.throttled{plan, source, operation}: refused
+//     requests, where source tells whether the limit is the plan's or an
+//     override
+//
+// Tenants are left out of the attributes to bound their cardinality.
+type ThrottleMetrics struct {
+	throttled metric.Int64Counter
+}
+
+// NewThrottleMetrics creates the instruments of ThrottleMetrics.
+func NewThrottleMetrics() (*ThrottleMetrics, error) {
+	throttled, err := otel.Meter(meterName).Int64Counter("tenantiq.requests.throttled",
+		metric.WithDescription("Requests refused for exceeding the tenant's rate limit"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating throttled requests counter: %w", err)
+	}
+	return &ThrottleMetrics{throttled: throttled}, nil
+}
+
+// Observe records one refused request; its signature matches
+// app.ThrottleObserver.
+func (m *ThrottleMetrics) Observe(ctx context.Context, limit domain.TenantRateLimit, operation string) {
+	m.throttled.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("plan", limit.Plan),
+		attribute.String("source", string(limit.Source)),
+		attribute.String("operation", operation),
+	))
+}
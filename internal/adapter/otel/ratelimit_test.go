@@ -0,0 +1,50 @@
+package otel_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/otel"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestThrottleMetrics_Observe(t *testing.T) {
+	reader := setupTestMeter(t)
+	metrics, err := adapter.NewThrottleMetrics()
+	if err != nil {
+		t.Fatalf("NewThrottleMetrics failed: %v", err)
+	}
+	ctx := context.Background()
+
+	free := domain.TenantRateLimit{TenantID: "ten_1", Plan: "free", Source: domain.RateLimitFromPlan}
+	metrics.Observe(ctx, free, "verify-api-key")
+	metrics.Observe(ctx, free, "verify-api-key")
+	metrics.Observe(ctx, free, "get-status-widget")
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	var throttled metricdata.Sum[int64]
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == "tenantiq.requests.throttled" {
+				throttled, _ = m.Data.(metricdata.Sum[int64])
+			}
+		}
+	}
+	if len(throttled.DataPoints) != 2 {
+		t.Fatalf("tenantiq.requests.throttled = %#v, want 2 data points", throttled)
+	}
+	for _, dp := range throttled.DataPoints {
+		operation, _ := dp.Attributes.Value("operation")
+		plan, _ := dp.Attributes.Value("plan")
+		source, _ := dp.Attributes.Value("source")
+		want := map[string]int64{"verify-api-key": 2, "get-status-widget": 1}[operation.AsString()]
+		if dp.Value != want || plan.AsString() != "free" || source.AsString() != "plan" {
+			t.Errorf("%s: value = %d, plan = %s, source = %s; want %d of the free plan", operation.AsString(), dp.Value, plan.AsString(), source.AsString(), want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package otel
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -48,6 +49,20 @@ func (r *TracingRepository) Create(ctx context.Context, tenant domain.Tenant) er
 	return err
 }
 
+func (r *TracingRepository) CreateMany(ctx context.Context, tenants []domain.Tenant) error {
+	ctx, span := r.tracer.Start(ctx, "TenantRepository.CreateMany",
+		trace.WithAttributes(attribute.Int("batch.size", len(tenants))),
+	)
+	defer span.End()
+
+	err := r.next.CreateMany(ctx, tenants)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
 func (r *TracingRepository) GetByID(ctx context.Context, id string) (domain.Tenant, error) {
 	ctx, span := r.tracer.Start(ctx, "TenantRepository.GetByID",
 		trace.WithAttributes(attribute.String("tenant.id", id)),
@@ -85,9 +100,7 @@ func (r *TracingRepository) List(ctx context.Context, filter domain.ListFilter)
 	)
 	defer span.End()
 
-	if filter.Status != nil {
-		span.SetAttributes(attribute.String("filter.status", string(*filter.Status)))
-	}
+	setFilterAttributes(span, filter)
 
 	tenants, err := r.next.List(ctx, filter)
 	if err != nil {
@@ -99,6 +112,22 @@ func (r *TracingRepository) List(ctx context.Context, filter domain.ListFilter)
 	return tenants, err
 }
 
+func (r *TracingRepository) Count(ctx context.Context, filter domain.ListFilter) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "TenantRepository.Count")
+	defer span.End()
+
+	setFilterAttributes(span, filter)
+
+	n, err := r.next.Count(ctx, filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetAttributes(attribute.Int("result.count", n))
+	}
+	return n, err
+}
+
 func (r *TracingRepository) Update(ctx context.Context, tenant domain.Tenant) error {
 	ctx, span := r.tracer.Start(ctx, "TenantRepository.Update",
 		trace.WithAttributes(
@@ -115,3 +144,26 @@ func (r *TracingRepository) Update(ctx context.Context, tenant domain.Tenant) er
 	}
 	return err
 }
+
+// setFilterAttributes records the non-empty criteria of a list filter on span.
+func setFilterAttributes(span trace.Span, filter domain.ListFilter) {
+	if filter.Status != nil {
+		span.SetAttributes(attribute.String("filter.status", string(*filter.Status)))
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, st := range filter.Statuses {
+			statuses[i] = string(st)
+		}
+		span.SetAttributes(attribute.StringSlice("filter.statuses", statuses))
+	}
+	if len(filter.Plans) > 0 {
+		span.SetAttributes(attribute.StringSlice("filter.plans", filter.Plans))
+	}
+	if !filter.CreatedAfter.IsZero() {
+		span.SetAttributes(attribute.String("filter.created_after", filter.CreatedAfter.UTC().Format(time.RFC3339)))
+	}
+	if !filter.CreatedBefore.IsZero() {
+		span.SetAttributes(attribute.String("filter.created_before", filter.CreatedBefore.UTC().Format(time.RFC3339)))
+	}
+}
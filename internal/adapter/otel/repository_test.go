@@ -12,6 +12,7 @@ import (
 
 	adapter "github.com/neomorfeo/tenantiq/internal/adapter/otel"
 	"github.com/neomorfeo/tenantiq/internal/domain"
+	"github.com/neomorfeo/tenantiq/pkg/memory"
 )
 
 // --- Test tracer setup ---
@@ -25,64 +26,22 @@ func setupTestTracer(t *testing.T) *tracetest.InMemoryExporter {
 	return exporter
 }
 
-// --- Mock repository ---
+// --- Fixtures ---
 
-type mockRepo struct {
-	tenants map[string]domain.Tenant
-	slugs   map[string]domain.Tenant
-}
-
-func newMockRepo() *mockRepo {
-	return &mockRepo{
-		tenants: make(map[string]domain.Tenant),
-		slugs:   make(map[string]domain.Tenant),
-	}
-}
-
-func (m *mockRepo) Create(_ context.Context, t domain.Tenant) error {
-	m.tenants[t.ID] = t
-	m.slugs[t.Slug] = t
-	return nil
-}
-
-func (m *mockRepo) GetByID(_ context.Context, id string) (domain.Tenant, error) {
-	t, ok := m.tenants[id]
-	if !ok {
-		return domain.Tenant{}, domain.ErrTenantNotFound
-	}
-	return t, nil
-}
-
-func (m *mockRepo) GetBySlug(_ context.Context, slug string) (domain.Tenant, error) {
-	t, ok := m.slugs[slug]
-	if !ok {
-		return domain.Tenant{}, domain.ErrTenantNotFound
-	}
-	return t, nil
-}
-
-func (m *mockRepo) List(_ context.Context, _ domain.ListFilter) ([]domain.Tenant, error) {
-	out := make([]domain.Tenant, 0, len(m.tenants))
-	for _, t := range m.tenants {
-		out = append(out, t)
-	}
-	return out, nil
-}
-
-func (m *mockRepo) Update(_ context.Context, t domain.Tenant) error {
-	if _, ok := m.tenants[t.ID]; !ok {
-		return domain.ErrTenantNotFound
+// seed stores tenants in an in-memory repository behind the tracing wrapper,
+// so the setup records no spans.
+func seed(t *testing.T, repo *memory.TenantRepository, tenants ...domain.Tenant) {
+	t.Helper()
+	if err := repo.CreateMany(context.Background(), tenants); err != nil {
+		t.Fatalf("seeding tenants: %v", err)
 	}
-	m.tenants[t.ID] = t
-	m.slugs[t.Slug] = t
-	return nil
 }
 
 // --- Tests ---
 
 func TestTracingRepository_Create_RecordsSpan(t *testing.T) {
 	exporter := setupTestTracer(t)
-	inner := newMockRepo()
+	inner := memory.NewTenantRepository()
 	repo := adapter.NewTracingRepository(inner)
 
 	tenant := domain.NewTenant("t-1", "Acme", "acme", "free")
@@ -102,13 +61,36 @@ func TestTracingRepository_Create_RecordsSpan(t *testing.T) {
 	assertAttribute(t, spans[0], "tenant.slug", "acme")
 }
 
+func TestTracingRepository_CreateMany_RecordsBatchSize(t *testing.T) {
+	exporter := setupTestTracer(t)
+	repo := adapter.NewTracingRepository(memory.NewTenantRepository())
+
+	tenants := []domain.Tenant{
+		domain.NewTenant("t-1", "A", "a", "free"),
+		domain.NewTenant("t-2", "B", "b", "free"),
+	}
+	if err := repo.CreateMany(context.Background(), tenants); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if spans[0].Name != "TenantRepository.CreateMany" {
+		t.Errorf("span name = %q, want %q", spans[0].Name, "TenantRepository.CreateMany")
+	}
+
+	assertAttribute(t, spans[0], "batch.size", "2")
+}
+
 func TestTracingRepository_GetByID_RecordsSpan(t *testing.T) {
 	exporter := setupTestTracer(t)
-	inner := newMockRepo()
+	inner := memory.NewTenantRepository()
 	repo := adapter.NewTracingRepository(inner)
 
 	tenant := domain.NewTenant("t-1", "Acme", "acme", "free")
-	inner.tenants["t-1"] = tenant
+	seed(t, inner, tenant)
 
 	got, err := repo.GetByID(context.Background(), "t-1")
 	if err != nil {
@@ -129,7 +111,7 @@ func TestTracingRepository_GetByID_RecordsSpan(t *testing.T) {
 
 func TestTracingRepository_GetByID_RecordsError(t *testing.T) {
 	exporter := setupTestTracer(t)
-	inner := newMockRepo()
+	inner := memory.NewTenantRepository()
 	repo := adapter.NewTracingRepository(inner)
 
 	_, err := repo.GetByID(context.Background(), "nonexistent")
@@ -153,11 +135,11 @@ func TestTracingRepository_GetByID_RecordsError(t *testing.T) {
 
 func TestTracingRepository_List_RecordsResultCount(t *testing.T) {
 	exporter := setupTestTracer(t)
-	inner := newMockRepo()
+	inner := memory.NewTenantRepository()
 	repo := adapter.NewTracingRepository(inner)
 
-	inner.tenants["t-1"] = domain.NewTenant("t-1", "A", "a", "free")
-	inner.tenants["t-2"] = domain.NewTenant("t-2", "B", "b", "pro")
+	seed(t, inner, domain.NewTenant("t-1", "A", "a", "free"))
+	seed(t, inner, domain.NewTenant("t-2", "B", "b", "pro"))
 
 	tenants, err := repo.List(context.Background(), domain.ListFilter{})
 	if err != nil {
@@ -175,13 +157,39 @@ func TestTracingRepository_List_RecordsResultCount(t *testing.T) {
 	assertAttribute(t, spans[0], "result.count", "2")
 }
 
+func TestTracingRepository_Count_RecordsSpan(t *testing.T) {
+	exporter := setupTestTracer(t)
+	inner := memory.NewTenantRepository()
+	repo := adapter.NewTracingRepository(inner)
+
+	seed(t, inner, domain.NewTenant("t-1", "A", "a", "free"))
+
+	n, err := repo.Count(context.Background(), domain.ListFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Count = %d, want 1", n)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if spans[0].Name != "TenantRepository.Count" {
+		t.Errorf("span name = %q, want %q", spans[0].Name, "TenantRepository.Count")
+	}
+
+	assertAttribute(t, spans[0], "result.count", "1")
+}
+
 func TestTracingRepository_Update_RecordsSpan(t *testing.T) {
 	exporter := setupTestTracer(t)
-	inner := newMockRepo()
+	inner := memory.NewTenantRepository()
 	repo := adapter.NewTracingRepository(inner)
 
 	tenant := domain.NewTenant("t-1", "Acme", "acme", "free")
-	inner.tenants["t-1"] = tenant
+	seed(t, inner, tenant)
 
 	tenant.Status = domain.StatusActive
 	if err := repo.Update(context.Background(), tenant); err != nil {
@@ -201,11 +209,11 @@ func TestTracingRepository_Update_RecordsSpan(t *testing.T) {
 
 func TestTracingRepository_GetBySlug_RecordsSpan(t *testing.T) {
 	exporter := setupTestTracer(t)
-	inner := newMockRepo()
+	inner := memory.NewTenantRepository()
 	repo := adapter.NewTracingRepository(inner)
 
 	tenant := domain.NewTenant("t-1", "Acme", "acme", "free")
-	inner.slugs["acme"] = tenant
+	seed(t, inner, tenant)
 
 	got, err := repo.GetBySlug(context.Background(), "acme")
 	if err != nil {
@@ -0,0 +1,77 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// TracingService traces the operations of app.TenantService as spans
+// named after them (TenantService.Create, TenantService.Transition, ...)
+// carrying their business attributes, between the HTTP span and the
+// repository and publisher spans. TenantService is used by every adapter
+// as a concrete type, so it is not wrapped: it calls TracingService
+// around its operations once given to it with app.WithServiceTracer.
+type TracingService struct {
+	tracer trace.Tracer
+}
+
+// Compile-time check: TracingService implements app.ServiceTracer.
+var _ app.ServiceTracer = (*TracingService)(nil)
+
+// NewTracingService creates a service tracer using the global
+// TracerProvider.
+func NewTracingService() *TracingService {
+	return &TracingService{tracer: otel.Tracer(tracerName)}
+}
+
+// Start begins the span of operation. Business rejections, such as an
+// invalid transition or a taken slug, are recorded as errors like
+// failures are: the span shows where the request was refused.
+func (s *TracingService) Start(ctx context.Context, operation string, kv ...any) (context.Context, app.SpanEnd) {
+	ctx, span := s.tracer.Start(ctx, operation, trace.WithAttributes(attributes(kv)...))
+	return ctx, func(err error, kv ...any) {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return
+		}
+		span.SetAttributes(attributes(kv)...)
+	}
+}
+
+// attributes converts alternating keys and values to span attributes,
+// skipping empty strings, such as the ID of a tenant that was not created.
+func attributes(kv []any) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		key := attribute.Key(fmt.Sprint(kv[i]))
+		switch v := kv[i+1].(type) {
+		case int:
+			attrs = append(attrs, key.Int(v))
+		case bool:
+			attrs = append(attrs, key.Bool(v))
+		case string:
+			if v != "" {
+				attrs = append(attrs, key.String(v))
+			}
+		case domain.Status:
+			if v != "" {
+				attrs = append(attrs, key.String(string(v)))
+			}
+		case domain.Event:
+			attrs = append(attrs, key.String(string(v)))
+		default:
+			attrs = append(attrs, key.String(fmt.Sprint(v)))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,65 @@
+package otel_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+
+	fsmadapter "github.com/neomorfeo/tenantiq/internal/adapter/fsm"
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/otel"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+	"github.com/neomorfeo/tenantiq/pkg/memory"
+)
+
+func TestTracingService(t *testing.T) {
+	exporter := setupTestTracer(t)
+	repo := adapter.NewTracingRepository(memory.NewTenantRepository())
+	svc := app.NewTenantService(repo, &mockPublisher{}, fsmadapter.New(), app.WithServiceTracer(adapter.NewTracingService()))
+	ctx := context.Background()
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err == nil {
+		t.Fatal("invalid transition succeeded")
+	}
+	if _, err := svc.List(ctx, domain.ListFilter{Limit: 10}); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	spans := make(map[string][]int)
+	all := exporter.GetSpans()
+	for i, s := range all {
+		spans[s.Name] = append(spans[s.Name], i)
+	}
+	if len(spans["TenantService.Create"]) != 1 || len(spans["TenantService.Transition"]) != 2 || len(spans["TenantService.List"]) != 1 {
+		t.Fatalf("spans = %v, want one per service call", spans)
+	}
+
+	create := all[spans["TenantService.Create"][0]]
+	assertAttribute(t, create, "tenant.slug", "acme")
+	assertAttribute(t, create, "tenant.plan", "pro")
+	assertAttribute(t, create, "tenant.id", tenant.ID)
+	assertAttribute(t, create, "tenant.status", "creating")
+	repoCreate := all[spans["TenantRepository.Create"][0]]
+	if repoCreate.Parent.SpanID() != create.SpanContext.SpanID() {
+		t.Error("TenantRepository.Create is not a child of TenantService.Create")
+	}
+
+	transition := all[spans["TenantService.Transition"][0]]
+	assertAttribute(t, transition, "tenant.event", "provision_complete")
+	assertAttribute(t, transition, "tenant.status", "active")
+	if refused := all[spans["TenantService.Transition"][1]]; refused.Status.Code != codes.Error {
+		t.Errorf("refused transition status = %v, want Error", refused.Status.Code)
+	}
+
+	list := all[spans["TenantService.List"][0]]
+	assertAttribute(t, list, "list.limit", "10")
+	assertAttribute(t, list, "list.count", "1")
+}
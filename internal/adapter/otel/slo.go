@@ -0,0 +1,61 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// budgetBurnBuckets bound the budget burn histogram around 1, where a
+// request uses up its budget.
+var budgetBurnBuckets = []float64{0.1, 0.25, 0.5, 0.75, 1, 1.5, 2, 5, 10}
+
+// LatencyMetrics records the latency of API operations against their SLO
+// latency budget:
+//
+//   - tenantiq.http.server.budget_burn{operation}: the share of its budget
+//     each request used; over 1 it exceeded it
+//   - tenantiq.http.server.budget_exceeded{operation}: requests over budget
+//
+// Per-operation burn points at the endpoints to look at, which a single
+// latency histogram of the whole API hides.
+type LatencyMetrics struct {
+	burn     metric.Float64Histogram
+	exceeded metric.Int64Counter
+}
+
+// NewLatencyMetrics creates the instruments of LatencyMetrics.
+func NewLatencyMetrics() (*LatencyMetrics, error) {
+	meter := otel.Meter(meterName)
+
+	burn, err := meter.Float64Histogram("tenantiq.http.server.budget_burn",
+		metric.WithDescription("Share of its operation's latency budget a request used"),
+		metric.WithUnit("1"),
+		metric.WithExplicitBucketBoundaries(budgetBurnBuckets...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating budget burn histogram: %w", err)
+	}
+	exceeded, err := meter.Int64Counter("tenantiq.http.server.budget_exceeded",
+		metric.WithDescription("Requests slower than their operation's latency budget"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating budget exceeded counter: %w", err)
+	}
+	return &LatencyMetrics{burn: burn, exceeded: exceeded}, nil
+}
+
+// Observe records one request; its signature matches
+// http.LatencyObserver.
+func (m *LatencyMetrics) Observe(ctx context.Context, operation string, budget, elapsed time.Duration) {
+	attrs := metric.WithAttributes(attribute.String("operation", operation))
+	m.burn.Record(ctx, elapsed.Seconds()/budget.Seconds(), attrs)
+	if elapsed > budget {
+		m.exceeded.Add(ctx, 1, attrs)
+	}
+}
@@ -0,0 +1,64 @@
+package otel_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/otel"
+)
+
+func TestLatencyMetrics_Observe(t *testing.T) {
+	reader := setupTestMeter(t)
+	metrics, err := adapter.NewLatencyMetrics()
+	if err != nil {
+		t.Fatalf("NewLatencyMetrics failed: %v", err)
+	}
+	ctx := context.Background()
+
+	budget := 100 * time.Millisecond
+	metrics.Observe(ctx, "verify-api-key", budget, 50*time.Millisecond)
+	metrics.Observe(ctx, "verify-api-key", budget, 250*time.Millisecond)
+	metrics.Observe(ctx, "get-tenant", time.Second, 100*time.Millisecond)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	var (
+		burn     metricdata.Histogram[float64]
+		exceeded metricdata.Sum[int64]
+	)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch m.Name {
+			case "tenantiq.http.server.budget_burn":
+				burn, _ = m.Data.(metricdata.Histogram[float64])
+			case "tenantiq.http.server.budget_exceeded":
+				exceeded, _ = m.Data.(metricdata.Sum[int64])
+			}
+		}
+	}
+
+	if len(burn.DataPoints) != 2 {
+		t.Fatalf("budget_burn = %#v, want 2 operations", burn)
+	}
+	for _, dp := range burn.DataPoints {
+		operation, _ := dp.Attributes.Value("operation")
+		want := map[string]struct {
+			count uint64
+			sum   float64
+		}{"verify-api-key": {2, 3}, "get-tenant": {1, 0.1}}[operation.AsString()]
+		if dp.Count != want.count || dp.Sum < want.sum-1e-9 || dp.Sum > want.sum+1e-9 {
+			t.Errorf("%s burn: count = %d, sum = %v; want %d and %v", operation.AsString(), dp.Count, dp.Sum, want.count, want.sum)
+		}
+	}
+	if len(exceeded.DataPoints) != 1 || exceeded.DataPoints[0].Value != 1 {
+		t.Fatalf("budget_exceeded = %#v, want the slow verify-api-key request", exceeded)
+	}
+	if op, _ := exceeded.DataPoints[0].Attributes.Value("operation"); op.AsString() != "verify-api-key" {
+		t.Errorf("exceeded operation = %s, want verify-api-key", op.AsString())
+	}
+}
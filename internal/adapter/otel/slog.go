@@ -0,0 +1,47 @@
+package otel
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceHandler is a slog.Handler adding the trace_id and span_id of the
+// span in the context of each record, so a log line written with
+// InfoContext and the like leads to its trace. Records logged without a
+// context, or outside a span, are passed on unchanged.
+type TraceHandler struct {
+	next slog.Handler
+}
+
+// Compile-time check: TraceHandler implements slog.Handler.
+var _ slog.Handler = (*TraceHandler)(nil)
+
+// NewTraceHandler wraps next, which writes the records.
+func NewTraceHandler(next slog.Handler) *TraceHandler {
+	return &TraceHandler{next: next}
+}
+
+func (h *TraceHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *TraceHandler) Handle(ctx context.Context, r slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r = r.Clone()
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *TraceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &TraceHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *TraceHandler) WithGroup(name string) slog.Handler {
+	return &TraceHandler{next: h.next.WithGroup(name)}
+}
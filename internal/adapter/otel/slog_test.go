@@ -0,0 +1,45 @@
+package otel_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/otel"
+)
+
+func TestTraceHandler(t *testing.T) {
+	setupTestTracer(t)
+	var buf bytes.Buffer
+	logger := slog.New(adapter.NewTraceHandler(slog.NewJSONHandler(&buf, nil))).With("component", "test")
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "work")
+	logger.InfoContext(ctx, "in a span")
+	span.End()
+	logger.InfoContext(context.Background(), "outside a span")
+
+	var lines []map[string]any
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var line map[string]any
+		if err := dec.Decode(&line); err != nil {
+			t.Fatalf("decoding log line: %v", err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+
+	sc := span.SpanContext()
+	if lines[0]["trace_id"] != sc.TraceID().String() || lines[0]["span_id"] != sc.SpanID().String() || lines[0]["component"] != "test" {
+		t.Errorf("line in a span = %v, want its trace and span IDs", lines[0])
+	}
+	if _, ok := lines[1]["trace_id"]; ok {
+		t.Errorf("line outside a span = %v, want no trace ID", lines[1])
+	}
+}
@@ -0,0 +1,120 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// TenantMetrics counts what TenantService does to tenants:
+//
+//   - tenantiq.tenants.created{plan}: tenants created
+//   - tenantiq.tenants.transitions{event, from, to}: lifecycle transitions
+//   - tenantiq.tenants.slug_conflicts: creations refused as the slug was
+//     taken
+//
+// Its methods match app.TenantObserver.
+type TenantMetrics struct {
+	created       metric.Int64Counter
+	transitions   metric.Int64Counter
+	slugConflicts metric.Int64Counter
+}
+
+// NewTenantMetrics creates the instruments of TenantMetrics.
+func NewTenantMetrics() (*TenantMetrics, error) {
+	meter := otel.Meter(meterName)
+
+	created, err := meter.Int64Counter("tenantiq.tenants.created",
+		metric.WithDescription("Tenants created per plan"),
+		metric.WithUnit("{tenant}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating created tenants counter: %w", err)
+	}
+	transitions, err := meter.Int64Counter("tenantiq.tenants.transitions",
+		metric.WithDescription("Tenant lifecycle transitions per event"),
+		metric.WithUnit("{transition}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating transitions counter: %w", err)
+	}
+	slugConflicts, err := meter.Int64Counter("tenantiq.tenants.slug_conflicts",
+		metric.WithDescription("Tenant creations refused because the slug was taken"),
+		metric.WithUnit("{tenant}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating slug conflicts counter: %w", err)
+	}
+	return &TenantMetrics{created: created, transitions: transitions, slugConflicts: slugConflicts}, nil
+}
+
+func (m *TenantMetrics) TenantCreated(ctx context.Context, tenant domain.Tenant) {
+	m.created.Add(ctx, 1, metric.WithAttributes(attribute.String("plan", tenant.Plan)))
+}
+
+func (m *TenantMetrics) TenantTransitioned(ctx context.Context, from domain.Status, event domain.Event, tenant domain.Tenant) {
+	m.transitions.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("event", string(event)),
+		attribute.String("from", string(from)),
+		attribute.String("to", string(tenant.Status)),
+	))
+}
+
+// SlugConflict leaves the slug out: it would make every conflict a
+// series of its own.
+func (m *TenantMetrics) SlugConflict(ctx context.Context, _ string) {
+	m.slugConflicts.Add(ctx, 1)
+}
+
+// TenantCounter counts the tenants in each status and plan.
+type TenantCounter func(ctx context.Context) ([]domain.TenantCount, error)
+
+// RegisterTenantCounts publishes the tenantiq.tenants{status, plan} gauge.
+// The tenants are counted again at most every interval, however often the
+// metrics are collected, so frequent scrapes do not load the database.
+func RegisterTenantCounts(count TenantCounter, interval time.Duration) error {
+	meter := otel.Meter(meterName)
+
+	tenants, err := meter.Int64ObservableGauge("tenantiq.tenants",
+		metric.WithDescription("Tenants per status and plan"),
+		metric.WithUnit("{tenant}"),
+	)
+	if err != nil {
+		return fmt.Errorf("creating tenants gauge: %w", err)
+	}
+
+	var (
+		mu        sync.Mutex
+		counts    []domain.TenantCount
+		countedAt time.Time
+	)
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if countedAt.IsZero() || time.Since(countedAt) >= interval {
+			fresh, err := count(ctx)
+			if err != nil {
+				return err
+			}
+			counts, countedAt = fresh, time.Now()
+		}
+		for _, c := range counts {
+			o.ObserveInt64(tenants, int64(c.Count), metric.WithAttributes(
+				attribute.String("status", string(c.Status)),
+				attribute.String("plan", c.Plan),
+			))
+		}
+		return nil
+	}, tenants)
+	if err != nil {
+		return fmt.Errorf("registering tenants gauge callback: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package otel_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/otel"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// collectInt64 returns the data points of the int64 sum or gauge named
+// name, keyed by the values of attrs joined with "/".
+func collectInt64(t *testing.T, rm metricdata.ResourceMetrics, name string, attrs ...string) map[string]int64 {
+	t.Helper()
+	points := make(map[string]int64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			var dps []metricdata.DataPoint[int64]
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				dps = data.DataPoints
+			case metricdata.Gauge[int64]:
+				dps = data.DataPoints
+			}
+			for _, dp := range dps {
+				key := ""
+				for i, a := range attrs {
+					v, _ := dp.Attributes.Value(attribute.Key(a))
+					if i > 0 {
+						key += "/"
+					}
+					key += v.AsString()
+				}
+				points[key] = dp.Value
+			}
+		}
+	}
+	return points
+}
+
+func TestTenantMetrics(t *testing.T) {
+	reader := setupTestMeter(t)
+	metrics, err := adapter.NewTenantMetrics()
+	if err != nil {
+		t.Fatalf("NewTenantMetrics failed: %v", err)
+	}
+	ctx := context.Background()
+
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "pro")
+	metrics.TenantCreated(ctx, tenant)
+	metrics.TenantCreated(ctx, domain.NewTenant("ten_2", "Globex", "globex", "free"))
+	tenant.Status = domain.StatusActive
+	metrics.TenantTransitioned(ctx, domain.StatusCreating, domain.EventProvisionComplete, tenant)
+	metrics.SlugConflict(ctx, "acme")
+	metrics.SlugConflict(ctx, "globex")
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if got := collectInt64(t, rm, "tenantiq.tenants.created", "plan"); got["pro"] != 1 || got["free"] != 1 {
+		t.Errorf("created = %v, want one per plan", got)
+	}
+	if got := collectInt64(t, rm, "tenantiq.tenants.transitions", "event", "from", "to"); got["provision_complete/creating/active"] != 1 || len(got) != 1 {
+		t.Errorf("transitions = %v, want the provisioning", got)
+	}
+	if got := collectInt64(t, rm, "tenantiq.tenants.slug_conflicts"); got[""] != 2 {
+		t.Errorf("slug_conflicts = %v, want 2 in one series", got)
+	}
+}
+
+func TestRegisterTenantCounts(t *testing.T) {
+	reader := setupTestMeter(t)
+	calls := 0
+	counts := []domain.TenantCount{
+		{Status: domain.StatusActive, Plan: "pro", Count: 3},
+		{Status: domain.StatusSuspended, Plan: "free", Count: 1},
+	}
+	count := func(context.Context) ([]domain.TenantCount, error) {
+		calls++
+		return counts, nil
+	}
+	if err := adapter.RegisterTenantCounts(count, time.Hour); err != nil {
+		t.Fatalf("RegisterTenantCounts failed: %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	for range 3 {
+		if err := reader.Collect(context.Background(), &rm); err != nil {
+			t.Fatalf("collect: %v", err)
+		}
+	}
+	got := collectInt64(t, rm, "tenantiq.tenants", "status", "plan")
+	if got["active/pro"] != 3 || got["suspended/free"] != 1 || len(got) != 2 {
+		t.Errorf("tenants = %v, want the counts per status and plan", got)
+	}
+	if calls != 1 {
+		t.Errorf("tenants counted %d times in 3 collections, want once within the interval", calls)
+	}
+}
@@ -0,0 +1,58 @@
+package otel
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// TracingValidator wraps a domain.TransitionValidator with OpenTelemetry
+// tracing: each decision is a span carrying tenant.id, event.type,
+// tenant.status.from and, when the transition is allowed,
+// tenant.status.to. Refused transitions end the span with an error, and
+// carry transition.guard when a guard refused them.
+type TracingValidator struct {
+	next   domain.TransitionValidator
+	tracer trace.Tracer
+}
+
+// Compile-time check: TracingValidator implements domain.TransitionValidator.
+var _ domain.TransitionValidator = (*TracingValidator)(nil)
+
+// NewTracingValidator creates a tracing decorator around the given validator.
+func NewTracingValidator(next domain.TransitionValidator) *TracingValidator {
+	return &TracingValidator{
+		next:   next,
+		tracer: otel.Tracer(tracerName),
+	}
+}
+
+func (v *TracingValidator) Apply(ctx context.Context, tenant domain.Tenant, event domain.Event) (domain.Status, error) {
+	ctx, span := v.tracer.Start(ctx, "TransitionValidator.Apply",
+		trace.WithAttributes(
+			attribute.String("tenant.id", tenant.ID),
+			attribute.String("event.type", string(event)),
+			attribute.String("tenant.status.from", string(tenant.Status)),
+		),
+	)
+	defer span.End()
+
+	next, err := v.next.Apply(ctx, tenant, event)
+	if err != nil {
+		var guardErr *domain.GuardError
+		if errors.As(err, &guardErr) {
+			span.SetAttributes(attribute.String("transition.guard", guardErr.Guard))
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return next, err
+	}
+	span.SetAttributes(attribute.String("tenant.status.to", string(next)))
+	return next, nil
+}
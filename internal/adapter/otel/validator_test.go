@@ -0,0 +1,77 @@
+package otel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/otel"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// stubValidator allows suspending active tenants only, and deleting them
+// once they have no projects.
+type stubValidator struct{}
+
+func (stubValidator) Apply(_ context.Context, tenant domain.Tenant, event domain.Event) (domain.Status, error) {
+	if tenant.Status == domain.StatusActive && event == domain.EventSuspend {
+		return domain.StatusSuspended, nil
+	}
+	if tenant.Status == domain.StatusActive && event == domain.EventDelete {
+		return "", &domain.GuardError{Guard: "no_active_projects", Event: event, Current: tenant.Status, Reason: "3 active projects"}
+	}
+	return "", &domain.TransitionError{Event: event, Current: tenant.Status}
+}
+
+func TestTracingValidator_Apply(t *testing.T) {
+	exporter := setupTestTracer(t)
+	validator := adapter.NewTracingValidator(stubValidator{})
+	ctx := context.Background()
+
+	status, err := validator.Apply(ctx, domain.Tenant{Status: domain.StatusActive}, domain.EventSuspend)
+	if err != nil || status != domain.StatusSuspended {
+		t.Fatalf("Apply = %q, %v; want suspended", status, err)
+	}
+	_, err = validator.Apply(ctx, domain.Tenant{Status: domain.StatusSuspended}, domain.EventSuspend)
+	var terr *domain.TransitionError
+	if !errors.As(err, &terr) {
+		t.Fatalf("Apply error = %v, want the TransitionError of the validator", err)
+	}
+
+	var gerr *domain.GuardError
+	if _, err := validator.Apply(ctx, domain.Tenant{ID: "t-1", Status: domain.StatusActive}, domain.EventDelete); !errors.As(err, &gerr) {
+		t.Fatalf("Apply error = %v, want the GuardError of the validator", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+	if spans[0].Name != "TransitionValidator.Apply" {
+		t.Errorf("span name = %q, want %q", spans[0].Name, "TransitionValidator.Apply")
+	}
+	assertAttribute(t, spans[0], "event.type", "suspend")
+	assertAttribute(t, spans[0], "tenant.status.from", "active")
+	assertAttribute(t, spans[0], "tenant.status.to", "suspended")
+	if spans[0].Status.Code == codes.Error {
+		t.Error("allowed transition ended its span with an error")
+	}
+
+	assertAttribute(t, spans[1], "tenant.status.from", "suspended")
+	for _, attr := range spans[1].Attributes {
+		if attr.Key == "tenant.status.to" {
+			t.Errorf("refused transition has tenant.status.to = %q", attr.Value.Emit())
+		}
+	}
+	if spans[1].Status.Code != codes.Error {
+		t.Errorf("span status = %v, want %v", spans[1].Status.Code, codes.Error)
+	}
+
+	assertAttribute(t, spans[2], "tenant.id", "t-1")
+	assertAttribute(t, spans[2], "transition.guard", "no_active_projects")
+	if spans[2].Status.Code != codes.Error {
+		t.Errorf("guarded span status = %v, want %v", spans[2].Status.Code, codes.Error)
+	}
+}
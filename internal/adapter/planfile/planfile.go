@@ -0,0 +1,77 @@
+// Package planfile loads the plan catalog used for usage-based plan
+// suggestions from a YAML file. Plans are listed from the smallest to the
+// largest; a metric without a limit is unlimited on that plan. A plan may
+// also set the request rate API gateways allow its tenants, where burst
+// defaults to requests_per_second, and the priority of its tenants' jobs
+// (high, normal or low; normal by default):
+//
+//	plans:
+//	  - plan: free
+//	    limits: {seats: 5, storage_gb: 10}
+//	    rate_limit: {requests_per_second: 10}
+//	  - plan: pro
+//	    limits: {seats: 50, storage_gb: 500}
+//	    rate_limit: {requests_per_second: 100, burst: 200}
+//	  - plan: enterprise
+//	    priority: high
+package planfile
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// file is the on-disk representation of the catalog.
+type file struct {
+	Plans []plan `yaml:"plans"`
+}
+
+type plan struct {
+	Plan      string           `yaml:"plan"`
+	Limits    map[string]int64 `yaml:"limits"`
+	RateLimit *rateLimit       `yaml:"rate_limit"`
+	Priority  string           `yaml:"priority"`
+}
+
+type rateLimit struct {
+	RequestsPerSecond int `yaml:"requests_per_second"`
+	Burst             int `yaml:"burst"`
+}
+
+// Load reads and validates the plan catalog in path.
+func Load(path string) (domain.PlanCatalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading plan catalog: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var f file
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	catalog := make(domain.PlanCatalog, 0, len(f.Plans))
+	for _, p := range f.Plans {
+		q := domain.PlanQuota{Plan: p.Plan, Limits: p.Limits, Priority: domain.Priority(p.Priority)}
+		if rl := p.RateLimit; rl != nil {
+			q.RateLimit = domain.RateLimit{RequestsPerSecond: rl.RequestsPerSecond, Burst: cmp.Or(rl.Burst, rl.RequestsPerSecond)}
+			if q.RateLimit.IsZero() {
+				return nil, fmt.Errorf("%s: plan %q rate limit: requests_per_second is required", path, p.Plan)
+			}
+		}
+		catalog = append(catalog, q)
+	}
+	if err := catalog.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return catalog, nil
+}
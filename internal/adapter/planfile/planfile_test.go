@@ -0,0 +1,69 @@
+package planfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/planfile"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func writeCatalog(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "plans.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeCatalog(t, `
+plans:
+  - plan: free
+    limits: {seats: 5, storage_gb: 10}
+    rate_limit: {requests_per_second: 10}
+  - plan: enterprise
+    rate_limit: {requests_per_second: 100, burst: 500}
+    priority: high
+`)
+
+	catalog, err := planfile.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(catalog) != 2 || catalog[0].Plan != "free" || catalog[0].Limits["seats"] != 5 || catalog[1].Limits != nil {
+		t.Errorf("catalog = %+v", catalog)
+	}
+	if got := catalog[0].RateLimit; got != (domain.RateLimit{RequestsPerSecond: 10, Burst: 10}) {
+		t.Errorf("free rate limit = %+v, want burst defaulted to the rate", got)
+	}
+	if got := catalog[1].RateLimit; got != (domain.RateLimit{RequestsPerSecond: 100, Burst: 500}) {
+		t.Errorf("enterprise rate limit = %+v", got)
+	}
+	if catalog[0].Priority != "" || catalog[1].Priority != domain.PriorityHigh {
+		t.Errorf("priorities = %q, %q; want none and high", catalog[0].Priority, catalog[1].Priority)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"unknown field":  "plans:\n  - plan: free\n    limit: {seats: 5}\n",
+		"duplicate plan": "plans:\n  - plan: free\n  - plan: free\n",
+		"bad limit":      "plans:\n  - plan: free\n    limits: {seats: many}\n",
+		"empty rate":     "plans:\n  - plan: free\n    rate_limit: {burst: 5}\n",
+		"negative rate":  "plans:\n  - plan: free\n    rate_limit: {requests_per_second: -1}\n",
+		"bad priority":   "plans:\n  - plan: free\n    priority: urgent\n",
+	}
+
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := planfile.Load(writeCatalog(t, content))
+			if err == nil || !strings.Contains(err.Error(), "plans.yaml") {
+				t.Errorf("Load = %v, want error naming the file", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Package policyfile loads per-plan transition policies from a YAML file,
+// so operators can change them without a release:
+//
+//	policies:
+//	  - plan: free
+//	    to: suspended
+//	    effect: deny
+//	    reason: free tenants are deleted, not suspended
+//	  - plan: enterprise
+//	    event: delete
+//	    effect: require_approval
+package policyfile
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// file is the on-disk representation of the policy set.
+type file struct {
+	Policies []policy `yaml:"policies"`
+}
+
+type policy struct {
+	Plan   string `yaml:"plan"`
+	Event  string `yaml:"event"`
+	To     string `yaml:"to"`
+	Effect string `yaml:"effect"`
+	Reason string `yaml:"reason"`
+}
+
+// Load reads and validates the policies in path. Unknown fields, events
+// and statuses are rejected so a typo cannot silently disable a policy.
+func Load(path string) (domain.TransitionPolicies, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading policies: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var f file
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	policies := make(domain.TransitionPolicies, 0, len(f.Policies))
+	for i, p := range f.Policies {
+		tp := domain.TransitionPolicy{
+			Plan:   p.Plan,
+			Event:  domain.Event(p.Event),
+			To:     domain.Status(p.To),
+			Effect: domain.PolicyEffect(p.Effect),
+			Reason: p.Reason,
+		}
+		if err := tp.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: policies[%d]: %w", path, i, err)
+		}
+		policies = append(policies, tp)
+	}
+	return policies, nil
+}
@@ -0,0 +1,68 @@
+package policyfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/policyfile"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func writePolicies(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policies.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writePolicies(t, `
+policies:
+  - plan: free
+    to: suspended
+    effect: deny
+    reason: free tenants are deleted, not suspended
+  - plan: enterprise
+    event: delete
+    effect: require_approval
+`)
+
+	policies, err := policyfile.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	want := domain.TransitionPolicies{
+		{Plan: "free", To: domain.StatusSuspended, Effect: domain.PolicyDeny, Reason: "free tenants are deleted, not suspended"},
+		{Plan: "enterprise", Event: domain.EventDelete, Effect: domain.PolicyRequireApproval},
+	}
+	if len(policies) != len(want) {
+		t.Fatalf("got %d policies, want %d", len(policies), len(want))
+	}
+	for i := range want {
+		if policies[i] != want[i] {
+			t.Errorf("policies[%d] = %+v, want %+v", i, policies[i], want[i])
+		}
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"unknown field":  "policies:\n  - plan: free\n    efect: deny\n",
+		"unknown event":  "policies:\n  - plan: free\n    event: archive\n    effect: deny\n",
+		"unknown effect": "policies:\n  - plan: free\n    effect: allow\n",
+	}
+
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := policyfile.Load(writePolicies(t, content))
+			if err == nil || !strings.Contains(err.Error(), "policies.yaml") {
+				t.Errorf("Load = %v, want error naming the file", err)
+			}
+		})
+	}
+}
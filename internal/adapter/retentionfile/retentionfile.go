@@ -0,0 +1,86 @@
+// Package retentionfile loads the data retention policy from a YAML file
+// mapping record types to how long they are kept. Retentions are a whole
+// number of years (y, 365 days), months (mo, 30 days), weeks (w) or days
+// (d), or a Go duration; record types left out are kept forever:
+//
+//	audit: 7y
+//	status_history: 2y
+//	operations: 90d
+//	events: 6mo
+//	webhook_deliveries: 30d
+package retentionfile
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+const day = 24 * time.Hour
+
+// units are the calendar units accepted besides Go durations, longest
+// suffix first so "mo" is not read as minutes.
+var units = []struct {
+	suffix string
+	length time.Duration
+}{
+	{"mo", 30 * day},
+	{"y", 365 * day},
+	{"w", 7 * day},
+	{"d", day},
+}
+
+// Load reads and validates the retention policy in path.
+func Load(path string) (domain.RetentionPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading retention policy: %w", err)
+	}
+
+	var f map[string]string
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	policy := make(domain.RetentionPolicy, len(f))
+	for record, value := range f {
+		d, err := ParseRetention(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", path, record, err)
+		}
+		policy[domain.RecordType(record)] = d
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// ParseRetention parses a retention such as "7y", "6mo", "30d" or "36h".
+func ParseRetention(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for _, u := range units {
+		n, ok := strings.CutSuffix(s, u.suffix)
+		if !ok {
+			continue
+		}
+		count, err := strconv.Atoi(n)
+		if err != nil {
+			break
+		}
+		return time.Duration(count) * u.length, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid retention %q, want e.g. 7y, 6mo, 30d or 36h", s)
+	}
+	return d, nil
+}
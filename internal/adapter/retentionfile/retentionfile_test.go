@@ -0,0 +1,67 @@
+package retentionfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/retentionfile"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "retention.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writePolicy(t, `
+audit: 7y
+events: 6mo
+webhook_deliveries: 30d
+operations: 36h
+`)
+
+	policy, err := retentionfile.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := domain.RetentionPolicy{
+		domain.RecordAudit:             7 * 365 * 24 * time.Hour,
+		domain.RecordEvents:            6 * 30 * 24 * time.Hour,
+		domain.RecordWebhookDeliveries: 30 * 24 * time.Hour,
+		domain.RecordOperations:        36 * time.Hour,
+	}
+	if len(policy) != len(want) {
+		t.Fatalf("policy = %v, want %v", policy, want)
+	}
+	for record, d := range want {
+		if policy[record] != d {
+			t.Errorf("%s = %s, want %s", record, policy[record], d)
+		}
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"unknown record": "tenants: 1y\n",
+		"bad unit":       "audit: 7 years\n",
+		"zero":           "events: 0d\n",
+		"not a map":      "- audit\n",
+	}
+
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := retentionfile.Load(writePolicy(t, content))
+			if err == nil || !strings.Contains(err.Error(), "retention.yaml") {
+				t.Errorf("Load = %v, want error naming the file", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package river
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: JobArchiver implements domain.Archiver.
+var _ domain.Archiver = (*JobArchiver)(nil)
+
+// finishedJob matches the finished jobs of one kind finalized before a
+// cutoff; River never touches them again.
+const finishedJob = `kind = ? AND state IN ('completed', 'cancelled', 'discarded') AND finalized_at < ?`
+
+// JobArchiver moves the finished jobs of one kind from river_job to
+// river_job_archive, so River's queries keep to the jobs in flight and the
+// recent log.
+type JobArchiver struct {
+	db   *sql.DB
+	kind string
+}
+
+// NewJobArchiver creates an archiver for the jobs of the given kind.
+func NewJobArchiver(db *sql.DB, kind string) *JobArchiver {
+	return &JobArchiver{db: db, kind: kind}
+}
+
+// Archive moves the jobs of the archiver's kind finalized before the
+// cutoff. The copy and the delete share a transaction, so a job is never
+// lost or in both tables.
+func (a *JobArchiver) Archive(ctx context.Context, before time.Time, dryRun bool) (int, error) {
+	cutoff := before.UTC().Format(riverTimeFormat)
+
+	if dryRun {
+		var n int
+		if err := a.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM river_job WHERE `+finishedJob, a.kind, cutoff).Scan(&n); err != nil {
+			return 0, fmt.Errorf("counting finished %s jobs: %w", a.kind, err)
+		}
+		return n, nil
+	}
+
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // no-op after Commit
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO river_job_archive (id, kind, args, state, attempt, errors, metadata, created_at, finalized_at)
+		 SELECT id, kind, args, state, attempt, errors, metadata, created_at, finalized_at
+		 FROM river_job WHERE `+finishedJob, a.kind, cutoff,
+	); err != nil {
+		return 0, fmt.Errorf("archiving %s jobs: %w", a.kind, err)
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM river_job WHERE `+finishedJob, a.kind, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("deleting archived %s jobs: %w", a.kind, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("checking rows affected: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+	return int(n), nil
+}
+
+// ArchiveArgs triggers an archival run.
+type ArchiveArgs struct {
+	// DryRun reports the old records without moving them.
+	DryRun bool `json:"dry_run"`
+}
+
+// Kind returns the unique job type identifier used by River's job routing.
+func (ArchiveArgs) Kind() string { return "archive.move" }
+
+// ArchiveWorker moves the old records to the archive tables.
+type ArchiveWorker struct {
+	river.WorkerDefaults[ArchiveArgs]
+	archive *app.ArchiveService
+}
+
+// NewArchiveWorker creates an archive worker.
+func NewArchiveWorker(archive *app.ArchiveService) *ArchiveWorker {
+	return &ArchiveWorker{archive: archive}
+}
+
+// Work runs the archival once and logs what moved.
+func (w *ArchiveWorker) Work(ctx context.Context, job *river.Job[ArchiveArgs]) error {
+	report := w.archive.Archive(ctx, time.Now().UTC(), job.Args.DryRun)
+
+	archived, failed := 0, 0
+	for _, item := range report.Items {
+		if item.Error != "" {
+			failed++
+		}
+		archived += item.Archived
+		slog.InfoContext(ctx, "archive moved",
+			"record", item.Record,
+			"before", item.Before,
+			"archived", item.Archived,
+			"dry_run", report.DryRun,
+			"error", item.Error,
+		)
+	}
+	slog.InfoContext(ctx, "archive finished",
+		"archived", archived,
+		"failed", failed,
+		"dry_run", report.DryRun,
+		"job_id", job.ID,
+	)
+	return nil
+}
+
+// ArchivePeriodicJob schedules archival every interval, starting at boot.
+func ArchivePeriodicJob(interval time.Duration, dryRun bool) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(interval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return ArchiveArgs{DryRun: dryRun}, periodicJobOpts()
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
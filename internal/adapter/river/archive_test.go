@@ -0,0 +1,59 @@
+package river_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestJobArchiver_Archive(t *testing.T) {
+	db := setupTestDB(t)
+	if _, err := sqlite.NewFromDB(db); err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+	client := setupClient(t, db)
+	ctx := context.Background()
+
+	pub := riveradapter.NewPublisher(client)
+	for _, slug := range []string{"a", "b", "c"} {
+		tenant := domain.NewTenant("ten_"+slug, slug, slug, "free")
+		if err := pub.Publish(ctx, domain.TenantEvent{Event: domain.EventProvisionComplete, Tenant: tenant}); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	// Two events were delivered long ago, the third is still queued.
+	if _, err := db.ExecContext(ctx, `UPDATE river_job SET state = 'completed', finalized_at = datetime('now', '-40 days') WHERE id IN (1, 2)`); err != nil {
+		t.Fatalf("updating jobs: %v", err)
+	}
+
+	kind := riveradapter.EventJobArgs{}.Kind()
+	archiver := riveradapter.NewJobArchiver(db, kind)
+	before := time.Now().UTC().Add(-30 * 24 * time.Hour)
+
+	n, err := archiver.Archive(ctx, before, true)
+	if err != nil || n != 2 {
+		t.Fatalf("dry-run Archive = %d, %v; want 2", n, err)
+	}
+	n, err = archiver.Archive(ctx, before, false)
+	if err != nil || n != 2 {
+		t.Fatalf("Archive = %d, %v; want 2", n, err)
+	}
+
+	var hot, archived int
+	if err := db.QueryRowContext(ctx, `SELECT (SELECT COUNT(*) FROM river_job), (SELECT COUNT(*) FROM river_job_archive)`).Scan(&hot, &archived); err != nil {
+		t.Fatalf("counting jobs: %v", err)
+	}
+	if hot != 1 || archived != 2 {
+		t.Errorf("%d hot and %d archived jobs, want the queued one hot and 2 archived", hot, archived)
+	}
+
+	// Retention reaches the archived jobs too.
+	n, err = riveradapter.NewJobPruner(client, db, kind).Prune(ctx, before, false)
+	if err != nil || n != 2 {
+		t.Fatalf("Prune = %d, %v; want the 2 archived jobs", n, err)
+	}
+}
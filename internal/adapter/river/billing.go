@@ -0,0 +1,69 @@
+package river
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+// BillingReconciliationArgs triggers a cross-check of tenants against billing.
+type BillingReconciliationArgs struct{}
+
+// Kind returns the unique job type identifier used by River's job routing.
+func (BillingReconciliationArgs) Kind() string { return "tenant.billing_reconciliation" }
+
+// BillingReconciliationWorker reconciles tenants with the billing provider
+// and logs every mismatch for finance. An unreachable provider fails the
+// job, so River retries it.
+type BillingReconciliationWorker struct {
+	river.WorkerDefaults[BillingReconciliationArgs]
+	billing *app.BillingService
+}
+
+// NewBillingReconciliationWorker creates a billing reconciliation worker.
+func NewBillingReconciliationWorker(billing *app.BillingService) *BillingReconciliationWorker {
+	return &BillingReconciliationWorker{billing: billing}
+}
+
+// Work runs a single reconciliation.
+func (w *BillingReconciliationWorker) Work(ctx context.Context, job *river.Job[BillingReconciliationArgs]) error {
+	report, err := w.billing.Reconcile(ctx)
+	if err != nil {
+		return fmt.Errorf("reconciling billing: %w", err)
+	}
+
+	for _, m := range report.Mismatches {
+		slog.WarnContext(ctx, "billing mismatch",
+			"kind", m.Kind,
+			"tenant_id", m.TenantID,
+			"tenant_plan", m.TenantPlan,
+			"tenant_status", m.TenantStatus,
+			"subscription_plan", m.SubscriptionPlan,
+			"subscription_status", m.SubscriptionStatus,
+			"detail", m.Detail,
+		)
+	}
+	slog.InfoContext(ctx, "billing reconciliation finished",
+		"tenants", report.Tenants,
+		"subscriptions", report.Subscriptions,
+		"mismatches", len(report.Mismatches),
+		"job_id", job.ID,
+	)
+	return nil
+}
+
+// BillingReconciliationPeriodicJob schedules a reconciliation every interval, starting at boot.
+func BillingReconciliationPeriodicJob(interval time.Duration) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(interval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return BillingReconciliationArgs{}, periodicJobOpts()
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
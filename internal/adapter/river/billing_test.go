@@ -0,0 +1,50 @@
+package river_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	goriver "github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// stubBilling returns fixed subscriptions, or err.
+type stubBilling struct {
+	subs []domain.Subscription
+	err  error
+}
+
+func (s stubBilling) Subscriptions(context.Context) ([]domain.Subscription, error) {
+	return s.subs, s.err
+}
+
+func TestBillingReconciliationWorker(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	svc := app.NewTenantService(repo, noopPublisher{}, tableValidator{})
+	job := &goriver.Job[riveradapter.BillingReconciliationArgs]{JobRow: &rivertype.JobRow{ID: 1}}
+
+	t.Run("reports mismatches", func(t *testing.T) {
+		billing := stubBilling{subs: []domain.Subscription{{TenantID: "ten_gone", Plan: "pro", Status: domain.SubscriptionActive}}}
+		worker := riveradapter.NewBillingReconciliationWorker(app.NewBillingService(billing, svc))
+		if err := worker.Work(context.Background(), job); err != nil {
+			t.Errorf("Work: %v", err)
+		}
+	})
+
+	t.Run("retries when billing is down", func(t *testing.T) {
+		worker := riveradapter.NewBillingReconciliationWorker(app.NewBillingService(stubBilling{err: errors.New("timeout")}, svc))
+		if err := worker.Work(context.Background(), job); !errors.Is(err, domain.ErrBillingUnavailable) {
+			t.Errorf("Work = %v, want ErrBillingUnavailable", err)
+		}
+	})
+}
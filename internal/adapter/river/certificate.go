@@ -0,0 +1,72 @@
+package river
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// CertificateRenewalArgs triggers the certificate requests that are due.
+type CertificateRenewalArgs struct{}
+
+// Kind returns the unique job type identifier used by River's job routing.
+func (CertificateRenewalArgs) Kind() string { return "tenant.certificate_renewal" }
+
+// CertificateRenewalWorker obtains the pending certificates, renews those
+// close to expiry and retries failures.
+type CertificateRenewalWorker struct {
+	river.WorkerDefaults[CertificateRenewalArgs]
+	certs *app.CertificateService
+}
+
+// NewCertificateRenewalWorker creates a certificate renewal worker.
+func NewCertificateRenewalWorker(certs *app.CertificateService) *CertificateRenewalWorker {
+	return &CertificateRenewalWorker{certs: certs}
+}
+
+// Work requests the due certificates once.
+func (w *CertificateRenewalWorker) Work(ctx context.Context, job *river.Job[CertificateRenewalArgs]) error {
+	ctx = domain.WithActor(ctx, "certificate-renewal")
+
+	report, err := w.certs.Renew(ctx, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("renewing certificates: %w", err)
+	}
+
+	failed := 0
+	for _, item := range report.Items {
+		if item.Error != "" {
+			failed++
+		}
+		slog.InfoContext(ctx, "certificate renewal item",
+			"domain", item.Domain,
+			"tenant_id", item.TenantID,
+			"not_after", item.NotAfter,
+			"error", item.Error,
+		)
+	}
+	slog.InfoContext(ctx, "certificate renewal finished",
+		"issued", len(report.Items)-failed,
+		"failed", failed,
+		"job_id", job.ID,
+	)
+	return nil
+}
+
+// CertificateRenewalPeriodicJob schedules certificate requests every
+// interval, starting at boot.
+func CertificateRenewalPeriodicJob(interval time.Duration) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(interval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return CertificateRenewalArgs{}, periodicJobOpts()
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
@@ -0,0 +1,56 @@
+package river
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+// CounterReconciliationArgs triggers the reconciliation of the per-status
+// tenant counters.
+type CounterReconciliationArgs struct{}
+
+// Kind returns the unique job type identifier used by River's job routing.
+func (CounterReconciliationArgs) Kind() string { return "tenant.counter_reconciliation" }
+
+// CounterReconciliationWorker corrects drifted status counters and logs
+// the drift, which should not happen while the counters are maintained.
+type CounterReconciliationWorker struct {
+	river.WorkerDefaults[CounterReconciliationArgs]
+	svc *app.TenantService
+}
+
+// NewCounterReconciliationWorker creates a counter reconciliation worker.
+func NewCounterReconciliationWorker(svc *app.TenantService) *CounterReconciliationWorker {
+	return &CounterReconciliationWorker{svc: svc}
+}
+
+// Work recounts the tenants once.
+func (w *CounterReconciliationWorker) Work(ctx context.Context, job *river.Job[CounterReconciliationArgs]) error {
+	drift, err := w.svc.ReconcileStatusCounts(ctx)
+	if err != nil {
+		return fmt.Errorf("reconciling status counts: %w", err)
+	}
+	for status, d := range drift {
+		slog.WarnContext(ctx, "status counter drift corrected", "status", status, "drift", d, "job_id", job.ID)
+	}
+	slog.InfoContext(ctx, "status counter reconciliation finished", "drifted", len(drift), "job_id", job.ID)
+	return nil
+}
+
+// CounterReconciliationPeriodicJob schedules counter reconciliations every
+// interval, starting at boot.
+func CounterReconciliationPeriodicJob(interval time.Duration) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(interval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return CounterReconciliationArgs{}, periodicJobOpts()
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
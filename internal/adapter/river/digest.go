@@ -0,0 +1,165 @@
+package river
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// DigestEventType is the CloudEvents type of digest deliveries; their
+// X-Tenantiq-Event header is DigestEventName.
+const (
+	DigestEventType = "io.tenantiq.webhook.digest"
+	DigestEventName = "digest"
+)
+
+// DigestEvent is the body of a digest delivery: one CloudEvent
+// summarizing the events a subscription matched during its windows.
+type DigestEvent struct {
+	SpecVersion     string          `json:"specversion" enum:"1.0" doc:"CloudEvents specification version"`
+	ID              string          `json:"id" doc:"Unique identifier of the delivery; the events inside keep their own"`
+	Source          string          `json:"source" doc:"Instance that built the digest"`
+	Type            string          `json:"type" enum:"io.tenantiq.webhook.digest" doc:"CloudEvents type of digests"`
+	Subject         string          `json:"subject" doc:"ID of the webhook subscription"`
+	Time            time.Time       `json:"time" doc:"When the digest was built"`
+	DataContentType string          `json:"datacontenttype" enum:"application/json" doc:"Media type of data"`
+	Data            DigestEventData `json:"data" doc:"The summary and its events"`
+}
+
+// DigestEventData summarizes the events of a digest.
+type DigestEventData struct {
+	Mode    string            `json:"mode" enum:"immediate,hourly,daily" doc:"Digest mode of the subscription when the digest was built"`
+	Until   time.Time         `json:"until" doc:"End of the last window covered: the digest holds the events before it"`
+	Total   int               `json:"total" doc:"Number of events"`
+	Tenants int               `json:"tenants" doc:"Number of tenants the events are about"`
+	Counts  map[string]int    `json:"counts" doc:"Number of events of each lifecycle event"`
+	Events  []json.RawMessage `json:"events" doc:"The events, oldest first, as delivered on their own"`
+}
+
+// NewDigestEvent wraps a digest built by source.
+func NewDigestEvent(source string, d domain.Digest) DigestEvent {
+	data := DigestEventData{
+		Mode:   string(d.Webhook.Digest),
+		Until:  d.Until,
+		Total:  len(d.Entries),
+		Counts: make(map[string]int),
+		Events: make([]json.RawMessage, len(d.Entries)),
+	}
+	tenants := make(map[string]bool)
+	for i, e := range d.Entries {
+		tenants[e.TenantID] = true
+		data.Events[i] = e.Payload
+	}
+	for event, n := range d.Counts() {
+		data.Counts[string(event)] = n
+	}
+	data.Tenants = len(tenants)
+	return DigestEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              uuid.NewString(),
+		Source:          source,
+		Type:            DigestEventType,
+		Subject:         d.Webhook.ID,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+// WebhookDigestArgs triggers the delivery of the digests that are due.
+type WebhookDigestArgs struct{}
+
+// Kind returns the unique job type identifier used by River's job routing.
+func (WebhookDigestArgs) Kind() string { return "webhook.digest" }
+
+// WebhookDigestWorker delivers the due digest of every subscription as one
+// signed POST. A digest whose delivery fails, or whose subscription's
+// circuit is open, keeps its events for the next run, which also picks up
+// the events held since.
+type WebhookDigestWorker struct {
+	river.WorkerDefaults[WebhookDigestArgs]
+	digests  *app.DigestService
+	webhooks *app.WebhookService
+	client   *http.Client
+	source   string
+}
+
+// NewWebhookDigestWorker creates a digest worker sending requests with
+// client.
+func NewWebhookDigestWorker(ds *app.DigestService, ws *app.WebhookService, client *http.Client, source string) *WebhookDigestWorker {
+	return &WebhookDigestWorker{digests: ds, webhooks: ws, client: client, source: source}
+}
+
+// Work delivers the due digests once.
+func (w *WebhookDigestWorker) Work(ctx context.Context, job *river.Job[WebhookDigestArgs]) error {
+	digests, err := w.digests.Due(ctx, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("building webhook digests: %w", err)
+	}
+
+	delivered, held, failed := 0, 0, 0
+	for _, d := range digests {
+		if d.Webhook.Circuit.Open() {
+			held++
+			continue
+		}
+		event := NewDigestEvent(w.source, d)
+		body, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("encoding digest: %w", err)
+		}
+		if err := postWebhook(ctx, w.client, d.Webhook, DigestEventName, event.ID, body); err != nil {
+			failed++
+			slog.WarnContext(ctx, "webhook digest failed, kept for the next run",
+				"webhook_id", d.Webhook.ID, "events", len(d.Entries), "error", err, "job_id", job.ID)
+			if rerr := w.webhooks.RecordFailure(ctx, d.Webhook.ID, err); rerr != nil {
+				slog.WarnContext(ctx, "webhook failure not recorded",
+					"webhook_id", d.Webhook.ID, "error", rerr, "job_id", job.ID)
+			}
+			continue
+		}
+		if err := w.webhooks.RecordSuccess(ctx, d.Webhook); err != nil {
+			slog.WarnContext(ctx, "webhook success not recorded",
+				"webhook_id", d.Webhook.ID, "error", err, "job_id", job.ID)
+		}
+		// Events not released are delivered again with the next digest.
+		if err := w.digests.Delivered(ctx, d); err != nil {
+			return err
+		}
+		delivered++
+		slog.InfoContext(ctx, "webhook digest delivered",
+			"webhook_id", d.Webhook.ID,
+			"mode", d.Webhook.Digest,
+			"events", len(d.Entries),
+			"job_id", job.ID,
+		)
+	}
+	slog.InfoContext(ctx, "webhook digests finished",
+		"delivered", delivered,
+		"held", held,
+		"failed", failed,
+		"job_id", job.ID,
+	)
+	return nil
+}
+
+// WebhookDigestPeriodicJob checks for due digests every interval, starting
+// at boot. Digests go out within interval of the end of their window.
+func WebhookDigestPeriodicJob(interval time.Duration) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(interval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return WebhookDigestArgs{}, periodicJobOpts()
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
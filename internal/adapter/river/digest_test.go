@@ -0,0 +1,152 @@
+package river_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	goriver "github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestWebhookDigestWorker_DeliversDueDigests(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	db := repo.DB()
+	ws := app.NewWebhookService(sqlite.NewWebhookRepository(db))
+	digests := sqlite.NewWebhookDigestRepository(db)
+	ds := app.NewDigestService(digests, ws)
+	ctx := context.Background()
+
+	ok, received := webhookEndpoint(t, http.StatusNoContent)
+	down, _ := webhookEndpoint(t, http.StatusServiceUnavailable)
+	daily, err := ws.Create(ctx, ok.URL, webhookSecret, nil, domain.DigestDaily)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	failing, err := ws.Create(ctx, down.URL, webhookSecret, nil, domain.DigestHourly)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	yesterday := time.Now().UTC().AddDate(0, 0, -1)
+	hold := func(sub domain.WebhookSubscription, event domain.Event, tenantID string, at time.Time) {
+		t.Helper()
+		ce := riveradapter.NewCloudEvent("/test", domain.TenantEvent{Event: event, Tenant: domain.Tenant{ID: tenantID}})
+		payload, _ := json.Marshal(ce)
+		entry := domain.DigestEntry{WebhookID: sub.ID, EventID: ce.ID, Event: event, TenantID: tenantID, Payload: payload, OccurredAt: at}
+		if err := ds.Hold(ctx, entry); err != nil {
+			t.Fatalf("Hold: %v", err)
+		}
+	}
+	hold(daily, domain.EventSuspend, "ten_1", yesterday)
+	hold(daily, domain.EventSuspend, "ten_2", yesterday.Add(time.Second))
+	hold(daily, domain.EventDelete, "ten_1", time.Now().UTC()) // Today's window has not ended.
+	hold(failing, domain.EventDelete, "ten_1", yesterday)
+
+	job := &goriver.Job[riveradapter.WebhookDigestArgs]{JobRow: &rivertype.JobRow{ID: 1}}
+	worker := riveradapter.NewWebhookDigestWorker(ds, ws, ok.Client(), "/test")
+	if err := worker.Work(ctx, job); err != nil {
+		t.Fatalf("Work: %v", err)
+	}
+
+	select {
+	case d := <-received:
+		if got := d.header.Get(riveradapter.WebhookEventHeader); got != riveradapter.DigestEventName {
+			t.Errorf("event header = %q, want digest", got)
+		}
+		want := riveradapter.SignWebhook(webhookSecret, d.header.Get(riveradapter.WebhookTimestampHeader), d.body)
+		if got := d.header.Get(riveradapter.WebhookSignatureHeader); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+		var digest riveradapter.DigestEvent
+		if err := json.Unmarshal(d.body, &digest); err != nil {
+			t.Fatalf("decoding digest: %v", err)
+		}
+		if digest.Type != riveradapter.DigestEventType || digest.Subject != daily.ID || digest.Data.Mode != "daily" ||
+			digest.Data.Total != 2 || digest.Data.Tenants != 2 || digest.Data.Counts["suspend"] != 2 || len(digest.Data.Events) != 2 {
+			t.Errorf("digest = %+v, want yesterday's two suspensions", digest)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("digest not delivered")
+	}
+
+	// Delivered events are released; today's and the failed digest's are kept.
+	far := time.Now().AddDate(0, 0, 2)
+	if pending, _ := digests.Pending(ctx, daily.ID, far); len(pending) != 1 || pending[0].Event != domain.EventDelete {
+		t.Errorf("held for the daily digest = %+v, want today's event", pending)
+	}
+	if pending, _ := digests.Pending(ctx, failing.ID, far); len(pending) != 1 {
+		t.Errorf("held for the failing digest = %+v, want its event kept", pending)
+	}
+	if sub, _ := ws.Get(ctx, failing.ID); sub.Circuit.ConsecutiveFailures != 1 {
+		t.Errorf("failures of the failing subscription = %d, want 1", sub.Circuit.ConsecutiveFailures)
+	}
+}
+
+func TestEventWorker_HoldsEventsForDigests(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	if _, err := sqlite.NewFromDB(db); err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+
+	srv, received := webhookEndpoint(t, http.StatusNoContent)
+	ws := app.NewWebhookService(sqlite.NewWebhookRepository(db))
+	sub, err := ws.Create(ctx, srv.URL, webhookSecret, nil, domain.DigestHourly)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	digests := sqlite.NewWebhookDigestRepository(db)
+	ds := app.NewDigestService(digests, ws)
+
+	client, err := riveradapter.Setup(ctx, db, riveradapter.NewWorkers(
+		riveradapter.WithWebhooks(ws, srv.Client()),
+		riveradapter.WithWebhookDigests(ds, ws, srv.Client(), "/test"),
+	))
+	if err != nil {
+		t.Fatalf("river setup: %v", err)
+	}
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("river start: %v", err)
+	}
+	t.Cleanup(func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = client.Stop(stopCtx)
+	})
+
+	if err := riveradapter.NewPublisher(client).Publish(ctx, domain.TenantEvent{Event: domain.EventSuspend, Tenant: domain.NewTenant("ten_1", "Acme", "acme", "pro")}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		pending, err := digests.Pending(ctx, sub.ID, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatalf("Pending: %v", err)
+		}
+		if len(pending) == 1 && pending[0].Event == domain.EventSuspend && pending[0].TenantID == "ten_1" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("event not held for the digest: %+v", pending)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	select {
+	case d := <-received:
+		t.Errorf("unexpected delivery of %s", d.header.Get(riveradapter.WebhookEventHeader))
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -0,0 +1,70 @@
+package river
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// DunningArgs triggers the dunning steps that are due.
+type DunningArgs struct{}
+
+// Kind returns the unique job type identifier used by River's job routing.
+func (DunningArgs) Kind() string { return "tenant.dunning" }
+
+// DunningWorker sends the final notices and suspensions that are due.
+type DunningWorker struct {
+	river.WorkerDefaults[DunningArgs]
+	dunning *app.DunningService
+}
+
+// NewDunningWorker creates a dunning worker.
+func NewDunningWorker(dunning *app.DunningService) *DunningWorker {
+	return &DunningWorker{dunning: dunning}
+}
+
+// Work runs the due steps once.
+func (w *DunningWorker) Work(ctx context.Context, job *river.Job[DunningArgs]) error {
+	ctx = domain.WithAutomation(domain.WithActor(ctx, "dunning"))
+
+	report, err := w.dunning.Advance(ctx, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("advancing dunning: %w", err)
+	}
+
+	failed := 0
+	for _, item := range report.Items {
+		if item.Error != "" {
+			failed++
+		}
+		slog.InfoContext(ctx, "dunning step",
+			"tenant_id", item.TenantID,
+			"stage", item.Stage,
+			"deferred_until", item.DeferredUntil,
+			"error", item.Error,
+		)
+	}
+	slog.InfoContext(ctx, "dunning finished",
+		"advanced", len(report.Items)-failed,
+		"failed", failed,
+		"job_id", job.ID,
+	)
+	return nil
+}
+
+// DunningPeriodicJob schedules the dunning steps every interval, starting at boot.
+func DunningPeriodicJob(interval time.Duration) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(interval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return DunningArgs{}, periodicJobOpts()
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
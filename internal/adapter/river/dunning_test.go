@@ -0,0 +1,64 @@
+package river_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	goriver "github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestDunningWorker_SuspendsAfterGracePeriod(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	svc := app.NewTenantService(repo, noopPublisher{}, tableValidator{},
+		app.WithStatusHistory(sqlite.NewStatusHistoryRepository(repo.DB())))
+	dunningRepo := sqlite.NewDunningRepository(repo.DB())
+	policy := domain.DunningPolicy{WarningPeriod: time.Hour, GracePeriod: time.Hour}
+	ds := app.NewDunningService(dunningRepo, svc, policy)
+	ctx := context.Background()
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+	// Failed long enough ago for the grace period to be over.
+	d := domain.StartDunning(tenant.ID, "in_1", policy, time.Now().UTC().Add(-3*time.Hour))
+	d.Advance(policy, time.Now().UTC().Add(-2*time.Hour))
+	if err := dunningRepo.Save(ctx, d); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	job := &goriver.Job[riveradapter.DunningArgs]{JobRow: &rivertype.JobRow{ID: 1}}
+	if err := riveradapter.NewDunningWorker(ds).Work(ctx, job); err != nil {
+		t.Fatalf("Work: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, tenant.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Status != domain.StatusSuspended {
+		t.Errorf("status = %q, want suspended", got.Status)
+	}
+	changes, err := sqlite.NewStatusHistoryRepository(repo.DB()).ListByTenant(ctx, tenant.ID)
+	if err != nil {
+		t.Fatalf("ListByTenant: %v", err)
+	}
+	if last := changes[len(changes)-1]; last.Actor != "dunning" {
+		t.Errorf("suspension actor = %q, want dunning", last.Actor)
+	}
+}
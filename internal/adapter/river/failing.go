@@ -0,0 +1,47 @@
+package river
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// FailingJob is a job whose last attempt failed: it is waiting to be
+// retried or was discarded after its last attempt.
+type FailingJob struct {
+	ID          int64     `json:"id"`
+	Kind        string    `json:"kind"`
+	Queue       string    `json:"queue"`
+	State       string    `json:"state"`
+	Attempt     int       `json:"attempt"`
+	MaxAttempts int       `json:"max_attempts"`
+	CreatedAt   time.Time `json:"created_at"`
+	// LastError is the error of the latest attempt, without its trace.
+	LastError string `json:"last_error"`
+}
+
+// FailingJobs returns up to limit retryable or discarded jobs, most recent
+// first. Job arguments are left out: they may carry customer data.
+func (m *QueueMonitor) FailingJobs(ctx context.Context, limit int) ([]FailingJob, error) {
+	rows, err := m.db.QueryContext(ctx,
+		`SELECT id, kind, queue, state, attempt, max_attempts, created_at,
+		        COALESCE(json_extract(errors, '$[#-1].error'), '')
+		 FROM river_job
+		 WHERE state IN ('retryable', 'discarded')
+		 ORDER BY id DESC
+		 LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing failing jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []FailingJob
+	for rows.Next() {
+		var job FailingJob
+		if err := rows.Scan(&job.ID, &job.Kind, &job.Queue, &job.State, &job.Attempt, &job.MaxAttempts, &job.CreatedAt, &job.LastError); err != nil {
+			return nil, fmt.Errorf("scanning failing job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
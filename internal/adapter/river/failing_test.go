@@ -0,0 +1,48 @@
+package river_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestQueueMonitor_FailingJobs(t *testing.T) {
+	db := setupTestDB(t)
+	client := setupClient(t, db)
+	ctx := context.Background()
+
+	pub := riveradapter.NewPublisher(client)
+	for _, slug := range []string{"a", "b", "c"} {
+		if err := pub.Publish(ctx, domain.TenantEvent{Event: domain.EventSuspend, Tenant: domain.NewTenant("ten_"+slug, slug, slug, "free")}); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	for _, stmt := range []string{
+		`UPDATE river_job SET state = 'retryable', attempt = 1,
+		 errors = json('[{"at": "2026-01-01T00:00:00Z", "attempt": 1, "error": "broker down", "trace": ""}]') WHERE id = 1`,
+		`UPDATE river_job SET state = 'discarded', attempt = 2, finalized_at = datetime('now'), errors = json('[
+		 {"at": "2026-01-01T00:00:00Z", "attempt": 1, "error": "timeout", "trace": ""},
+		 {"at": "2026-01-01T00:01:00Z", "attempt": 2, "error": "refused", "trace": ""}]') WHERE id = 2`,
+	} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("updating jobs: %v", err)
+		}
+	}
+
+	jobs, err := riveradapter.NewQueueMonitor(db).FailingJobs(ctx, 10)
+	if err != nil {
+		t.Fatalf("FailingJobs failed: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d failing jobs, want 2", len(jobs))
+	}
+	if jobs[0].ID != 2 || jobs[0].State != "discarded" || jobs[0].LastError != "refused" || jobs[0].Kind != (riveradapter.EventJobArgs{}).Kind() {
+		t.Errorf("first job = %+v, want the discarded job with its last error", jobs[0])
+	}
+	if jobs[1].LastError != "broker down" || time.Since(jobs[1].CreatedAt) > time.Minute {
+		t.Errorf("second job = %+v, want the retryable job created just now", jobs[1])
+	}
+}
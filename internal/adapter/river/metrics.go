@@ -0,0 +1,90 @@
+package river
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// Outcomes of a job attempt, as reported to JobObserver.JobWorked.
+const (
+	JobCompleted = "completed"
+	// JobFailed attempts are retried; JobDiscarded ones were the last.
+	JobFailed    = "failed"
+	JobDiscarded = "discarded"
+	JobCancelled = "cancelled"
+	JobSnoozed   = "snoozed"
+)
+
+// JobObserver is told about the jobs River queues and works, such as
+// metrics of queue health.
+type JobObserver interface {
+	// JobInserted is called for each job queued; jobs skipped as duplicates
+	// of a unique job are not.
+	JobInserted(ctx context.Context, kind, queue string)
+	// JobWorked is called after each attempt at a job, attempt 1 being the
+	// first, with how long it ran and its outcome.
+	JobWorked(ctx context.Context, kind, queue string, attempt int, elapsed time.Duration, outcome string)
+}
+
+// WithJobObserver reports the jobs inserted and worked by the client to
+// observe.
+func WithJobObserver(observe JobObserver) SetupOption {
+	return func(cfg *river.Config) {
+		cfg.Middleware = append(cfg.Middleware, &jobObserverMiddleware{observe: observe})
+	}
+}
+
+// jobObserverMiddleware reports to a JobObserver around job inserts and
+// attempts.
+type jobObserverMiddleware struct {
+	river.MiddlewareDefaults
+	observe JobObserver
+}
+
+func (m *jobObserverMiddleware) InsertMany(ctx context.Context, params []*rivertype.JobInsertParams, doInner func(context.Context) ([]*rivertype.JobInsertResult, error)) ([]*rivertype.JobInsertResult, error) {
+	results, err := doInner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range results {
+		if !r.UniqueSkippedAsDuplicate {
+			m.observe.JobInserted(ctx, r.Job.Kind, r.Job.Queue)
+		}
+	}
+	return results, nil
+}
+
+func (m *jobObserverMiddleware) Work(ctx context.Context, job *rivertype.JobRow, doInner func(context.Context) error) error {
+	start := time.Now()
+	err := doInner(ctx)
+	m.observe.JobWorked(ctx, job.Kind, job.Queue, job.Attempt, time.Since(start), jobOutcome(job, err))
+	return err
+}
+
+// jobOutcome returns what River does with job after an attempt that
+// returned err.
+func jobOutcome(job *rivertype.JobRow, err error) string {
+	var cancel *river.JobCancelError
+	var snooze *river.JobSnoozeError
+	switch {
+	case err == nil:
+		return JobCompleted
+	case errors.As(err, &cancel):
+		return JobCancelled
+	case errors.As(err, &snooze):
+		return JobSnoozed
+	case job.Attempt >= job.MaxAttempts:
+		return JobDiscarded
+	}
+	return JobFailed
+}
+
+// Compile-time checks: the middleware wraps both inserts and work.
+var (
+	_ rivertype.JobInsertMiddleware = (*jobObserverMiddleware)(nil)
+	_ rivertype.WorkerMiddleware    = (*jobObserverMiddleware)(nil)
+)
@@ -0,0 +1,82 @@
+package river_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	goriver "github.com/riverqueue/river"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+)
+
+// recordingJobs records what a JobObserver is told, as "kind/outcome".
+type recordingJobs struct {
+	mu       sync.Mutex
+	inserted []string
+	worked   []string
+	attempts []int
+}
+
+func (r *recordingJobs) JobInserted(_ context.Context, kind, _ string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inserted = append(r.inserted, kind)
+}
+
+func (r *recordingJobs) JobWorked(_ context.Context, kind, _ string, attempt int, _ time.Duration, outcome string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.worked = append(r.worked, kind+"/"+outcome)
+	r.attempts = append(r.attempts, attempt)
+}
+
+type flakyArgs struct{}
+
+func (flakyArgs) Kind() string { return "test.flaky" }
+
+func TestWithJobObserver(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	workers := riveradapter.NewWorkers()
+	goriver.AddWorker(workers, goriver.WorkFunc(func(context.Context, *goriver.Job[flakyArgs]) error {
+		return errors.New("upstream down")
+	}))
+	observer := &recordingJobs{}
+	client, err := riveradapter.Setup(ctx, db, workers, riveradapter.WithJobObserver(observer))
+	if err != nil {
+		t.Fatalf("river setup: %v", err)
+	}
+
+	finished, cancel := client.Subscribe(goriver.EventKindJobCompleted, goriver.EventKindJobFailed)
+	defer cancel()
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("river start: %v", err)
+	}
+	t.Cleanup(func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = client.Stop(stopCtx)
+	})
+
+	if _, err := client.Insert(ctx, flakyArgs{}, &goriver.InsertOpts{MaxAttempts: 1}); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job not worked within 5 seconds")
+	}
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	if len(observer.inserted) != 1 || observer.inserted[0] != "test.flaky" {
+		t.Errorf("inserted = %v, want the flaky job", observer.inserted)
+	}
+	if len(observer.worked) != 1 || observer.worked[0] != "test.flaky/"+riveradapter.JobDiscarded || observer.attempts[0] != 1 {
+		t.Errorf("worked = %v (attempts %v), want its only attempt discarded", observer.worked, observer.attempts)
+	}
+}
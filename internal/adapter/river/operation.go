@@ -0,0 +1,87 @@
+package river
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: OperationQueue implements domain.OperationQueue.
+var _ domain.OperationQueue = (*OperationQueue)(nil)
+
+// OperationArgs asks a worker to run the work tracked by an operation.
+type OperationArgs struct {
+	OperationID   string `json:"operation_id" doc:"Operation tracking the work"`
+	OperationKind string `json:"kind" enum:"provision,deletion" doc:"Kind of work"`
+	TenantID      string `json:"tenant_id" doc:"Tenant the work applies to"`
+}
+
+// Kind returns the unique job type identifier used by River's job routing.
+func (OperationArgs) Kind() string { return "tenant.operation" }
+
+// OperationQueue implements domain.OperationQueue by enqueuing River jobs.
+type OperationQueue struct {
+	client *Client
+}
+
+// NewOperationQueue creates a queue backed by the given River client.
+func NewOperationQueue(client *Client) *OperationQueue {
+	return &OperationQueue{client: client}
+}
+
+// Enqueue inserts a job running the operation, at the priority set by
+// domain.WithPriority.
+func (q *OperationQueue) Enqueue(ctx context.Context, op domain.Operation) error {
+	_, err := q.client.Insert(ctx, OperationArgs{
+		OperationID:   op.ID,
+		OperationKind: string(op.Kind),
+		TenantID:      op.TenantID,
+	}, &river.InsertOpts{Priority: jobPriority(ctx)})
+	if err != nil {
+		return fmt.Errorf("enqueuing %s job: %w", op.Kind, err)
+	}
+	return nil
+}
+
+// OperationWorker runs asynchronous tenant operations (provisioning,
+// deletion).
+type OperationWorker struct {
+	river.WorkerDefaults[OperationArgs]
+	svc *app.TenantService
+	ops *app.OperationService
+}
+
+// NewOperationWorker creates a worker running operations through svc and
+// recording final failures through ops.
+func NewOperationWorker(svc *app.TenantService, ops *app.OperationService) *OperationWorker {
+	return &OperationWorker{svc: svc, ops: ops}
+}
+
+// Work runs the operation. Errors are retried by River; once the last
+// attempt fails the operation is marked as failed so pollers see the outcome.
+func (w *OperationWorker) Work(ctx context.Context, job *river.Job[OperationArgs]) error {
+	ctx = domain.WithActor(ctx, "operation:"+job.Args.OperationID)
+
+	err := w.svc.RunOperation(ctx, job.Args.OperationID)
+	if err == nil {
+		slog.InfoContext(ctx, "operation finished",
+			"operation_id", job.Args.OperationID,
+			"kind", job.Args.OperationKind,
+			"tenant_id", job.Args.TenantID,
+			"job_id", job.ID,
+		)
+		return nil
+	}
+
+	if job.Attempt >= job.MaxAttempts {
+		if failErr := w.ops.Fail(ctx, job.Args.OperationID, err.Error()); failErr != nil {
+			slog.ErrorContext(ctx, "marking operation failed", "error", failErr, "operation_id", job.Args.OperationID)
+		}
+	}
+	return fmt.Errorf("%s of tenant %s: %w", job.Args.OperationKind, job.Args.TenantID, err)
+}
@@ -0,0 +1,106 @@
+package river_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	goriver "github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestOperationWorker_Provision(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	repo, err := sqlite.NewFromDB(db)
+	if err != nil {
+		t.Fatalf("creating repo: %v", err)
+	}
+
+	workers := riveradapter.NewWorkers()
+	client, err := riveradapter.Setup(ctx, db, workers)
+	if err != nil {
+		t.Fatalf("river setup: %v", err)
+	}
+
+	ops := app.NewOperationService(sqlite.NewOperationRepository(db))
+	svc := app.NewTenantService(repo, noopPublisher{}, tableValidator{},
+		app.WithAsyncOperations(ops, riveradapter.NewOperationQueue(client)),
+	)
+	goriver.AddWorker(workers, riveradapter.NewOperationWorker(svc, ops))
+
+	completed, cancel := client.Subscribe(goriver.EventKindJobCompleted)
+	defer cancel()
+
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("river start: %v", err)
+	}
+	t.Cleanup(func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = client.Stop(stopCtx)
+	})
+
+	tenant, op, err := svc.CreateAsync(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("CreateAsync: %v", err)
+	}
+
+	select {
+	case event := <-completed:
+		if event.Job.Kind != "tenant.operation" {
+			t.Fatalf("completed job kind = %q, want tenant.operation", event.Job.Kind)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("provisioning job did not complete within 5 seconds")
+	}
+
+	got, err := ops.Get(ctx, op.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != domain.OperationSucceeded || got.Result != tenant.ID {
+		t.Errorf("operation status = %q, want %q", got.Status, domain.OperationSucceeded)
+	}
+	stored, err := repo.GetByID(ctx, tenant.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if stored.Status != domain.StatusActive {
+		t.Errorf("tenant status = %q, want %q", stored.Status, domain.StatusActive)
+	}
+}
+
+func TestOperationWorker_FailsOperationForMissingTenant(t *testing.T) {
+	_, repo := newSyncService(t)
+	ops := sqlite.NewOperationRepository(repo.DB())
+	opSvc := app.NewOperationService(ops)
+	svc := app.NewTenantService(repo, noopPublisher{}, tableValidator{},
+		app.WithAsyncOperations(opSvc, nil),
+	)
+	ctx := context.Background()
+
+	op := domain.NewOperation("op_1", domain.OperationProvision, "ten_missing")
+	if err := ops.Create(ctx, op); err != nil {
+		t.Fatalf("creating operation: %v", err)
+	}
+
+	job := &goriver.Job[riveradapter.OperationArgs]{
+		JobRow: &rivertype.JobRow{ID: 1, Attempt: 1, MaxAttempts: 25},
+		Args:   riveradapter.OperationArgs{OperationID: op.ID, OperationKind: string(op.Kind), TenantID: op.TenantID},
+	}
+	if err := riveradapter.NewOperationWorker(svc, opSvc).Work(ctx, job); err != nil {
+		t.Fatalf("Work should not retry a missing tenant, got %v", err)
+	}
+
+	got, _ := ops.GetByID(ctx, op.ID)
+	if got.Status != domain.OperationFailed {
+		t.Errorf("operation status = %q, want %q", got.Status, domain.OperationFailed)
+	}
+}
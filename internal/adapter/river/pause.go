@@ -0,0 +1,42 @@
+package river
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: JobPauser implements domain.JobPauser.
+var _ domain.JobPauser = (*JobPauser)(nil)
+
+// JobPauser implements domain.JobPauser by pausing River's queue. The pause
+// is stored in the database, so it stops every client working the queue and
+// survives restarts until resumed.
+type JobPauser struct {
+	client *Client
+}
+
+// NewJobPauser creates a pauser for the queue of client. The client must be
+// started before pausing, which registers the queue.
+func NewJobPauser(client *Client) *JobPauser {
+	return &JobPauser{client: client}
+}
+
+// Pause stops clients from fetching jobs; running jobs finish.
+func (p *JobPauser) Pause(ctx context.Context) error {
+	if err := p.client.QueuePause(ctx, river.QueueDefault, nil); err != nil {
+		return fmt.Errorf("pausing queue %s: %w", river.QueueDefault, err)
+	}
+	return nil
+}
+
+// Resume lets clients fetch jobs again.
+func (p *JobPauser) Resume(ctx context.Context) error {
+	if err := p.client.QueueResume(ctx, river.QueueDefault, nil); err != nil {
+		return fmt.Errorf("resuming queue %s: %w", river.QueueDefault, err)
+	}
+	return nil
+}
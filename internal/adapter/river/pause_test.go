@@ -0,0 +1,62 @@
+package river_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	goriver "github.com/riverqueue/river"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestJobPauser(t *testing.T) {
+	db := setupTestDB(t)
+	client := setupClient(t, db)
+	ctx := context.Background()
+
+	completed, cancel := client.Subscribe(goriver.EventKindJobCompleted)
+	defer cancel()
+	queueEvents, cancelQueueEvents := client.Subscribe(goriver.EventKindQueuePaused)
+	defer cancelQueueEvents()
+
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("river start: %v", err)
+	}
+	t.Cleanup(func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = client.Stop(stopCtx)
+	})
+
+	pauser := riveradapter.NewJobPauser(client)
+	if err := pauser.Pause(ctx); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	// The pause is stored at once, but the client's producer picks it up
+	// asynchronously and may still fetch a job until it does.
+	select {
+	case <-queueEvents:
+	case <-time.After(15 * time.Second):
+		t.Fatal("the client did not pause the queue within 15 seconds")
+	}
+	if err := riveradapter.NewPublisher(client).Publish(ctx, domain.TenantEvent{Event: domain.EventSuspend, Tenant: domain.NewTenant("ten_a", "A", "a", "free")}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	select {
+	case event := <-completed:
+		t.Fatalf("job %s completed while the queue was paused", event.Job.Kind)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	if err := pauser.Resume(ctx); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	select {
+	case <-completed:
+	case <-time.After(15 * time.Second):
+		t.Fatal("job did not complete within 15 seconds of resuming")
+	}
+}
@@ -0,0 +1,73 @@
+package river
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// PlanSuggestionArgs triggers a run of the usage-based plan suggestions.
+type PlanSuggestionArgs struct{}
+
+// Kind returns the unique job type identifier used by River's job routing.
+func (PlanSuggestionArgs) Kind() string { return "tenant.plan_suggestions" }
+
+// PlanSuggestionWorker matches tenant usage against the plan catalog and
+// logs the suggestions that changed.
+type PlanSuggestionWorker struct {
+	river.WorkerDefaults[PlanSuggestionArgs]
+	svc *app.TenantService
+}
+
+// NewPlanSuggestionWorker creates a plan suggestion worker.
+func NewPlanSuggestionWorker(svc *app.TenantService) *PlanSuggestionWorker {
+	return &PlanSuggestionWorker{svc: svc}
+}
+
+// Work runs a single suggestion pass over the active tenants.
+func (w *PlanSuggestionWorker) Work(ctx context.Context, job *river.Job[PlanSuggestionArgs]) error {
+	ctx = domain.WithActor(ctx, "plan-suggestions")
+
+	report, err := w.svc.SuggestPlans(ctx)
+	if err != nil {
+		return fmt.Errorf("suggesting plans: %w", err)
+	}
+
+	failed := 0
+	for _, item := range report.Items {
+		if item.Error != "" {
+			failed++
+		}
+		slog.InfoContext(ctx, "plan suggestion item",
+			"tenant_id", item.TenantID,
+			"plan", item.Plan,
+			"suggested_plan", item.SuggestedPlan,
+			"reason", item.Reason,
+			"error", item.Error,
+		)
+	}
+	slog.InfoContext(ctx, "plan suggestions finished",
+		"checked", report.Checked,
+		"changed", len(report.Items)-failed,
+		"failed", failed,
+		"job_id", job.ID,
+	)
+	return nil
+}
+
+// PlanSuggestionPeriodicJob schedules plan suggestions every interval, starting at boot.
+func PlanSuggestionPeriodicJob(interval time.Duration) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(interval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return PlanSuggestionArgs{}, periodicJobOpts()
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
@@ -0,0 +1,54 @@
+package river_test
+
+import (
+	"context"
+	"testing"
+
+	goriver "github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestPlanSuggestionWorker_StoresSuggestion(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	catalog := domain.PlanCatalog{
+		{Plan: "free", Limits: map[string]int64{"seats": 5}},
+		{Plan: "pro"},
+	}
+	svc := app.NewTenantService(repo, noopPublisher{}, tableValidator{},
+		app.WithPlanSuggestions(catalog, sqlite.NewUsageRepository(repo.DB())))
+	ctx := context.Background()
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+	if err := svc.ReportUsage(ctx, tenant.ID, domain.Usage{"seats": 9}); err != nil {
+		t.Fatalf("ReportUsage: %v", err)
+	}
+
+	job := &goriver.Job[riveradapter.PlanSuggestionArgs]{JobRow: &rivertype.JobRow{ID: 1}}
+	if err := riveradapter.NewPlanSuggestionWorker(svc).Work(ctx, job); err != nil {
+		t.Fatalf("Work: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, tenant.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.SuggestedPlan != "pro" {
+		t.Errorf("SuggestedPlan = %q, want pro", got.SuggestedPlan)
+	}
+}
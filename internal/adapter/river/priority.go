@@ -0,0 +1,44 @@
+package river
+
+import (
+	"context"
+
+	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// River runs the jobs of a lower priority number first. Tenant work uses
+// the first three levels; River's default, 1, would put every job inserted
+// without options among the high priority work.
+var riverPriorities = map[domain.Priority]int{
+	domain.PriorityHigh:   1,
+	domain.PriorityNormal: 2,
+	domain.PriorityLow:    3,
+}
+
+// jobPriority returns the River priority of the work queued with ctx:
+// normal unless domain.WithPriority set another.
+func jobPriority(ctx context.Context) int {
+	if p, ok := riverPriorities[domain.PriorityFromContext(ctx)]; ok {
+		return p
+	}
+	return riverPriorities[domain.PriorityNormal]
+}
+
+// priorityOf returns the domain priority of a River priority; levels past
+// low count as low.
+func priorityOf(n int) domain.Priority {
+	for p, level := range riverPriorities {
+		if level == n {
+			return p
+		}
+	}
+	return domain.PriorityLow
+}
+
+// periodicJobOpts queues periodic jobs, which no tenant asked for, at
+// normal priority.
+func periodicJobOpts() *river.InsertOpts {
+	return &river.InsertOpts{Priority: riverPriorities[domain.PriorityNormal]}
+}
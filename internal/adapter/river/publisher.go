@@ -4,7 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/riverqueue/river"
 
 	"github.com/neomorfeo/tenantiq/internal/domain"
@@ -13,46 +16,203 @@ import (
 // Compile-time check: Publisher implements domain.EventPublisher.
 var _ domain.EventPublisher = (*Publisher)(nil)
 
-// EventJobArgs carries the data needed to process a domain event asynchronously.
-// River serializes this as JSON into its job queue table. It includes a snapshot
-// of the tenant at the time the event was published, so the worker never needs
-// to query the database.
+const (
+	// CloudEventsSpecVersion is the CloudEvents version of published events.
+	CloudEventsSpecVersion = "1.0"
+	// CloudEventTypePrefix precedes the event name in the CloudEvents type,
+	// e.g. "io.tenantiq.tenant.suspend".
+	CloudEventTypePrefix = "io.tenantiq.tenant."
+	// DefaultEventSource is the CloudEvents source unless configured otherwise.
+	DefaultEventSource = "/tenantiq"
+)
+
+// CloudEventType returns the CloudEvents type of a domain event.
+func CloudEventType(event domain.Event) string {
+	return CloudEventTypePrefix + string(event)
+}
+
+// TenantEventData is the data of every tenant event: a snapshot of the
+// tenant when the event was published, so consumers never need to query it.
+type TenantEventData struct {
+	TenantID      string `json:"tenant_id" doc:"Tenant identifier"`
+	Name          string `json:"name" doc:"Tenant display name"`
+	Slug          string `json:"slug" doc:"Tenant slug"`
+	Status        string `json:"status" doc:"Tenant status when the event was published"`
+	Plan          string `json:"plan" doc:"Subscription plan"`
+	SuggestedPlan string `json:"suggested_plan,omitempty" doc:"Plan that best fits the tenant's usage (plan_suggested events)"`
+	TrialEndsAt   string `json:"trial_ends_at,omitempty" doc:"End of the tenant's trial (RFC 3339), if on trial"`
+	Simulated     bool   `json:"simulated,omitempty" doc:"Set for simulated tenants, which must not be provisioned for real"`
+	// Changes is only set on the events of attribute changes.
+	Changes []FieldChangeData `json:"changes,omitempty" doc:"Attributes the event changed, with their values before and after (renamed, plan_changed, metadata_updated and trial_expired events)"`
+	// Certificate is only set on the certificate events.
+	Certificate *CertificateEventData `json:"certificate,omitempty" doc:"Certificate of the tenant's domain (certificate_issued and certificate_failed events)"`
+}
+
+// CertificateEventData is the certificate a certificate event is about.
+type CertificateEventData struct {
+	Domain   string `json:"domain" doc:"Domain the certificate is for"`
+	Status   string `json:"status" enum:"issued,failed" doc:"Outcome of the attempt"`
+	NotAfter string `json:"not_after,omitempty" doc:"Expiry of the last issued certificate (RFC 3339); on failure, the certificate still served until then"`
+	Error    string `json:"error,omitempty" doc:"Why the attempt failed; it is retried within the hour"`
+}
+
+// EventCertificate converts the certificate of an event to event data, or
+// returns nil when there is none.
+func EventCertificate(cert *domain.Certificate) *CertificateEventData {
+	if cert == nil {
+		return nil
+	}
+	data := &CertificateEventData{Domain: cert.Domain, Status: string(cert.Status), Error: cert.LastError}
+	if !cert.NotAfter.IsZero() {
+		data.NotAfter = cert.NotAfter.UTC().Format(time.RFC3339)
+	}
+	return data
+}
+
+// FieldChangeData is one changed attribute of a tenant event.
+type FieldChangeData struct {
+	Field  string `json:"field" doc:"Changed attribute; external references are named external_refs.<key>"`
+	Before string `json:"before" doc:"Value before the change, empty if it was unset"`
+	After  string `json:"after" doc:"Value after the change, empty if it was cleared"`
+}
+
+// EventChanges converts the changes of an event to event data, or returns
+// nil when there are none.
+func EventChanges(changes []domain.FieldChange) []FieldChangeData {
+	if len(changes) == 0 {
+		return nil
+	}
+	data := make([]FieldChangeData, len(changes))
+	for i, c := range changes {
+		data[i] = FieldChangeData{Field: c.Field, Before: c.Before, After: c.After}
+	}
+	return data
+}
+
+// EventJobArgs is a domain event as a CloudEvents 1.0 envelope in
+// structured JSON mode. River stores it as the job's JSON, and webhooks
+// send it unchanged, so queue consumers and HTTP receivers (Knative,
+// EventBridge, ...) read the same document. The doc tags feed the
+// published event schema.
 type EventJobArgs struct {
-	Event    string `json:"event"`
-	TenantID string `json:"tenant_id"`
-	Name     string `json:"name"`
-	Slug     string `json:"slug"`
-	Status   string `json:"status"`
-	Plan     string `json:"plan"`
+	SpecVersion     string          `json:"specversion" enum:"1.0" doc:"CloudEvents specification version"`
+	ID              string          `json:"id" river:"unique" doc:"Unique event identifier; identical on redeliveries"`
+	Source          string          `json:"source" doc:"Instance that published the event"`
+	Type            string          `json:"type" doc:"io.tenantiq.tenant. followed by the lifecycle event"`
+	Subject         string          `json:"subject" doc:"ID of the tenant the event is about"`
+	Time            time.Time       `json:"time" doc:"When the event was published"`
+	DataContentType string          `json:"datacontenttype" enum:"application/json" doc:"Media type of data"`
+	Data            TenantEventData `json:"data" doc:"The tenant after the event"`
 }
 
 // Kind returns the unique job type identifier used by River's job routing.
 func (EventJobArgs) Kind() string { return "event.published" }
 
+// Event returns the domain event named by the envelope's type.
+func (a EventJobArgs) Event() domain.Event {
+	return domain.Event(strings.TrimPrefix(a.Type, CloudEventTypePrefix))
+}
+
+// NewCloudEvent wraps a domain event published by source.
+func NewCloudEvent(source string, e domain.TenantEvent) EventJobArgs {
+	tenant := e.Tenant
+	return EventJobArgs{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              uuid.NewString(),
+		Source:          source,
+		Type:            CloudEventType(e.Event),
+		Subject:         tenant.ID,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		Data: TenantEventData{
+			TenantID:      tenant.ID,
+			Name:          tenant.Name,
+			Slug:          tenant.Slug,
+			Status:        string(tenant.Status),
+			Plan:          tenant.Plan,
+			SuggestedPlan: tenant.SuggestedPlan,
+			TrialEndsAt:   formatTrialEnd(tenant.TrialEndsAt),
+			Simulated:     tenant.Simulated,
+			Changes:       EventChanges(e.Changes),
+			Certificate:   EventCertificate(e.Certificate),
+		},
+	}
+}
+
+// formatTrialEnd formats the end of a trial, or returns "" when the
+// tenant is not on trial.
+func formatTrialEnd(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
 // Client is the River client type parameterized for SQLite (*sql.Tx).
 type Client = river.Client[*sql.Tx]
 
 // Publisher implements domain.EventPublisher by enqueuing River jobs.
 type Publisher struct {
 	client *Client
+	source string
+}
+
+// PublisherOption configures a Publisher.
+type PublisherOption func(*Publisher)
+
+// WithEventSource sets the CloudEvents source of published events
+// (default DefaultEventSource), e.g. to tell environments apart.
+func WithEventSource(source string) PublisherOption {
+	return func(p *Publisher) { p.source = source }
 }
 
 // NewPublisher creates a publisher backed by the given River client.
-func NewPublisher(client *Client) *Publisher {
-	return &Publisher{client: client}
-}
-
-// Publish enqueues a domain event as an async job in River.
-func (p *Publisher) Publish(ctx context.Context, event domain.Event, tenant domain.Tenant) error {
-	_, err := p.client.Insert(ctx, EventJobArgs{
-		Event:    string(event),
-		TenantID: tenant.ID,
-		Name:     tenant.Name,
-		Slug:     tenant.Slug,
-		Status:   string(tenant.Status),
-		Plan:     tenant.Plan,
-	}, nil)
-	if err != nil {
+func NewPublisher(client *Client, opts ...PublisherOption) *Publisher {
+	p := &Publisher{client: client, source: DefaultEventSource}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Publish enqueues a domain event as an async job in River, at the
+// priority set by domain.WithPriority. An event with an ID set by
+// domain.WithEventID (e.g. relayed from the outbox) is enqueued at most
+// once while River keeps its job.
+func (p *Publisher) Publish(ctx context.Context, e domain.TenantEvent) error {
+	args, opts := p.job(ctx, e)
+	if _, err := p.client.Insert(ctx, args, opts); err != nil {
+		return fmt.Errorf("enqueuing event job: %w", err)
+	}
+	return nil
+}
+
+// InTx returns a publisher that enqueues jobs within tx, so they are
+// committed or rolled back with the other writes of the transaction (see
+// sqlite.UnitOfWork).
+func (p *Publisher) InTx(tx *sql.Tx) domain.EventPublisher {
+	return txPublisher{p: p, tx: tx}
+}
+
+// job returns the job args and insert options of an event.
+func (p *Publisher) job(ctx context.Context, e domain.TenantEvent) (EventJobArgs, *river.InsertOpts) {
+	args := NewCloudEvent(p.source, e)
+	opts := &river.InsertOpts{Priority: jobPriority(ctx)}
+	if id := domain.EventIDFromContext(ctx); id != "" {
+		args.ID = id
+		opts.UniqueOpts = river.UniqueOpts{ByArgs: true}
+	}
+	return args, opts
+}
+
+type txPublisher struct {
+	p  *Publisher
+	tx *sql.Tx
+}
+
+func (t txPublisher) Publish(ctx context.Context, e domain.TenantEvent) error {
+	args, opts := t.p.job(ctx, e)
+	if _, err := t.p.client.InsertTx(ctx, t.tx, args, opts); err != nil {
 		return fmt.Errorf("enqueuing event job: %w", err)
 	}
 	return nil
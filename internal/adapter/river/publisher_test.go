@@ -3,6 +3,8 @@ package river_test
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -12,6 +14,7 @@ import (
 	_ "modernc.org/sqlite"
 
 	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
 	"github.com/neomorfeo/tenantiq/internal/domain"
 )
 
@@ -36,7 +39,7 @@ func setupTestDB(t *testing.T) *sql.DB {
 func setupClient(t *testing.T, db *sql.DB) *riveradapter.Client {
 	t.Helper()
 
-	client, err := riveradapter.Setup(context.Background(), db)
+	client, err := riveradapter.Setup(context.Background(), db, riveradapter.NewWorkers())
 	if err != nil {
 		t.Fatalf("river setup: %v", err)
 	}
@@ -67,7 +70,7 @@ func TestPublisher_Publish_EnqueuesJob(t *testing.T) {
 	pub := riveradapter.NewPublisher(client)
 	tenant := domain.NewTenant("t-1", "Acme", "acme", "free")
 
-	if err := pub.Publish(ctx, domain.EventProvisionComplete, tenant); err != nil {
+	if err := pub.Publish(ctx, domain.TenantEvent{Event: domain.EventProvisionComplete, Tenant: tenant}); err != nil {
 		t.Fatalf("Publish failed: %v", err)
 	}
 
@@ -104,7 +107,7 @@ func TestPublisher_Publish_PreservesEventData(t *testing.T) {
 	pub := riveradapter.NewPublisher(client)
 	tenant := domain.NewTenant("t-42", "Test Corp", "test-corp", "pro")
 
-	if err := pub.Publish(ctx, domain.EventSuspend, tenant); err != nil {
+	if err := pub.Publish(ctx, domain.TenantEvent{Event: domain.EventSuspend, Tenant: tenant}); err != nil {
 		t.Fatalf("Publish failed: %v", err)
 	}
 
@@ -117,7 +120,10 @@ func TestPublisher_Publish_PreservesEventData(t *testing.T) {
 		}
 		// The args are stored as JSON; verify key fields are present.
 		argsStr := string(args)
-		for _, want := range []string{`"event":"suspend"`, `"tenant_id":"t-42"`, `"slug":"test-corp"`, `"plan":"pro"`} {
+		for _, want := range []string{
+			`"specversion":"1.0"`, `"type":"io.tenantiq.tenant.suspend"`, `"source":"/tenantiq"`, `"subject":"t-42"`,
+			`"tenant_id":"t-42"`, `"slug":"test-corp"`, `"plan":"pro"`,
+		} {
 			if !strings.Contains(argsStr, want) {
 				t.Errorf("encoded args missing %s, got: %s", want, argsStr)
 			}
@@ -126,3 +132,84 @@ func TestPublisher_Publish_PreservesEventData(t *testing.T) {
 		t.Fatal("timed out waiting for job completion")
 	}
 }
+
+func TestPublisher_Publish_RelayedEventOnce(t *testing.T) {
+	db := setupTestDB(t)
+	client := setupClient(t, db)
+	ctx := domain.WithEventID(context.Background(), "evt_1")
+
+	pub := riveradapter.NewPublisher(client)
+	tenant := domain.NewTenant("t-1", "Acme", "acme", "free")
+	for range 2 {
+		if err := pub.Publish(ctx, domain.TenantEvent{Event: domain.EventSuspend, Tenant: tenant}); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+
+	res, err := client.JobList(ctx, goriver.NewJobListParams())
+	if err != nil {
+		t.Fatalf("JobList: %v", err)
+	}
+	if len(res.Jobs) != 1 {
+		t.Fatalf("got %d jobs, want the relayed event enqueued once", len(res.Jobs))
+	}
+	var args riveradapter.EventJobArgs
+	if err := json.Unmarshal(res.Jobs[0].EncodedArgs, &args); err != nil {
+		t.Fatalf("decode args: %v", err)
+	}
+	if args.ID != "evt_1" {
+		t.Errorf("event id = %q, want evt_1", args.ID)
+	}
+}
+
+func TestPublisher_InTx_CommitsWithTenant(t *testing.T) {
+	db := setupTestDB(t)
+	repo, err := sqlite.NewFromDB(db)
+	if err != nil {
+		t.Fatalf("sqlite: %v", err)
+	}
+	client := setupClient(t, db)
+	uow := sqlite.NewUnitOfWork(db, riveradapter.NewPublisher(client).InTx)
+	ctx := context.Background()
+
+	store := func(tenant domain.Tenant, fail error) error {
+		return uow.Do(ctx, func(tenants domain.TenantRepository, events domain.EventPublisher) error {
+			if err := tenants.Create(ctx, tenant); err != nil {
+				return err
+			}
+			if err := events.Publish(ctx, domain.TenantEvent{Event: domain.EventProvisionComplete, Tenant: tenant}); err != nil {
+				return err
+			}
+			return fail
+		})
+	}
+
+	if err := store(domain.NewTenant("t-1", "Acme", "acme", "free"), nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	boom := errors.New("boom")
+	if err := store(domain.NewTenant("t-2", "Globex", "globex", "free"), boom); !errors.Is(err, boom) {
+		t.Fatalf("Do error = %v, want boom", err)
+	}
+
+	if _, err := repo.GetByID(ctx, "t-1"); err != nil {
+		t.Errorf("committed tenant: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "t-2"); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("rolled back tenant: got %v, want ErrTenantNotFound", err)
+	}
+	res, err := client.JobList(ctx, goriver.NewJobListParams())
+	if err != nil {
+		t.Fatalf("JobList: %v", err)
+	}
+	if len(res.Jobs) != 1 {
+		t.Fatalf("got %d jobs, want only the committed tenant's event", len(res.Jobs))
+	}
+	var args riveradapter.EventJobArgs
+	if err := json.Unmarshal(res.Jobs[0].EncodedArgs, &args); err != nil {
+		t.Fatalf("decode args: %v", err)
+	}
+	if args.Subject != "t-1" {
+		t.Errorf("job subject = %q, want t-1", args.Subject)
+	}
+}
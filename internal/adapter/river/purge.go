@@ -0,0 +1,76 @@
+package river
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// PurgeArgs triggers a purge of the tenants deleted past the purge window.
+type PurgeArgs struct {
+	// DryRun reports the expired tenants without removing them.
+	DryRun bool `json:"dry_run"`
+}
+
+// Kind returns the unique job type identifier used by River's job routing.
+func (PurgeArgs) Kind() string { return "tenant.purge" }
+
+// PurgeWorker permanently removes long-deleted tenants and logs each one.
+type PurgeWorker struct {
+	river.WorkerDefaults[PurgeArgs]
+	purge *app.PurgeService
+}
+
+// NewPurgeWorker creates a purge worker.
+func NewPurgeWorker(purge *app.PurgeService) *PurgeWorker {
+	return &PurgeWorker{purge: purge}
+}
+
+// Work runs a single purge pass over the deleted tenants.
+func (w *PurgeWorker) Work(ctx context.Context, job *river.Job[PurgeArgs]) error {
+	ctx = domain.WithActor(ctx, "purge")
+
+	report, err := w.purge.Purge(ctx, time.Now().UTC(), job.Args.DryRun)
+	if err != nil {
+		return fmt.Errorf("purging tenants: %w", err)
+	}
+
+	failed := 0
+	for _, item := range report.Items {
+		if item.Error != "" {
+			failed++
+		}
+		slog.InfoContext(ctx, "purge item",
+			"tenant_id", item.TenantID,
+			"slug", item.Slug,
+			"deleted_at", item.DeletedAt,
+			"dry_run", report.DryRun,
+			"error", item.Error,
+		)
+	}
+	slog.InfoContext(ctx, "purge finished",
+		"before", report.Before,
+		"purged", len(report.Items)-failed,
+		"failed", failed,
+		"dry_run", report.DryRun,
+		"job_id", job.ID,
+	)
+	return nil
+}
+
+// PurgePeriodicJob schedules purges every interval, starting at boot.
+func PurgePeriodicJob(interval time.Duration, dryRun bool) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(interval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return PurgeArgs{DryRun: dryRun}, periodicJobOpts()
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
@@ -0,0 +1,134 @@
+package river
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// rateWindow is how far back completed jobs count towards the processing rate.
+const rateWindow = 5 * time.Minute
+
+// QueueMonitor implements domain.QueueMonitor by reading River's job table.
+type QueueMonitor struct {
+	db *sql.DB
+}
+
+// NewQueueMonitor creates a monitor over the database River runs on.
+func NewQueueMonitor(db *sql.DB) *QueueMonitor {
+	return &QueueMonitor{db: db}
+}
+
+// Stats counts the available jobs whose scheduled time has passed and
+// measures how long the oldest of them has been waiting. River stores
+// timestamps as UTC text, so the age is computed by SQLite itself.
+func (m *QueueMonitor) Stats(ctx context.Context) (domain.QueueStats, error) {
+	var (
+		available  int
+		oldestSecs float64
+	)
+	err := m.db.QueryRowContext(ctx,
+		`SELECT COUNT(*),
+		        COALESCE(MAX((julianday('now', 'subsec') - julianday(scheduled_at)) * 86400.0), 0)
+		 FROM river_job
+		 WHERE state = 'available' AND scheduled_at <= datetime('now', 'subsec')`,
+	).Scan(&available, &oldestSecs)
+	if err != nil {
+		return domain.QueueStats{}, fmt.Errorf("reading queue stats: %w", err)
+	}
+
+	return domain.QueueStats{
+		Available:          available,
+		OldestAvailableAge: time.Duration(oldestSecs * float64(time.Second)),
+	}, nil
+}
+
+// Loads groups jobs by queue, then by priority. The processing rate is the
+// number of jobs completed during the last five minutes, per minute.
+func (m *QueueMonitor) Loads(ctx context.Context) ([]domain.QueueLoad, error) {
+	rows, err := m.db.QueryContext(ctx,
+		`SELECT queue,
+		        SUM(state = 'available' AND scheduled_at <= datetime('now', 'subsec')),
+		        SUM(state = 'running'),
+		        SUM(state = 'completed' AND finalized_at >= datetime('now', 'subsec', ?))
+		 FROM river_job
+		 GROUP BY queue
+		 ORDER BY queue`,
+		fmt.Sprintf("-%d seconds", int(rateWindow.Seconds())),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("reading queue loads: %w", err)
+	}
+	defer rows.Close()
+
+	var loads []domain.QueueLoad
+	for rows.Next() {
+		var (
+			load      domain.QueueLoad
+			completed int
+		)
+		if err := rows.Scan(&load.Queue, &load.Available, &load.Running, &completed); err != nil {
+			return nil, fmt.Errorf("scanning queue load: %w", err)
+		}
+		load.CompletedPerMinute = float64(completed) / rateWindow.Minutes()
+		loads = append(loads, load)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating queue loads: %w", err)
+	}
+	if err := m.splitByPriority(ctx, loads); err != nil {
+		return nil, err
+	}
+	return loads, nil
+}
+
+// splitByPriority fills the Priorities of loads.
+func (m *QueueMonitor) splitByPriority(ctx context.Context, loads []domain.QueueLoad) error {
+	rows, err := m.db.QueryContext(ctx,
+		`SELECT queue, priority,
+		        SUM(state = 'available' AND scheduled_at <= datetime('now', 'subsec')),
+		        SUM(state = 'running')
+		 FROM river_job
+		 WHERE state IN ('available', 'running')
+		 GROUP BY queue, priority
+		 ORDER BY queue, priority`,
+	)
+	if err != nil {
+		return fmt.Errorf("reading queue loads by priority: %w", err)
+	}
+	defer rows.Close()
+
+	byQueue := make(map[string]*domain.QueueLoad, len(loads))
+	for i := range loads {
+		byQueue[loads[i].Queue] = &loads[i]
+	}
+	for rows.Next() {
+		var (
+			queue    string
+			priority int
+			pl       domain.PriorityLoad
+		)
+		if err := rows.Scan(&queue, &priority, &pl.Available, &pl.Running); err != nil {
+			return fmt.Errorf("scanning queue load by priority: %w", err)
+		}
+		load, ok := byQueue[queue]
+		if !ok || pl.Available+pl.Running == 0 {
+			continue
+		}
+		pl.Priority = priorityOf(priority)
+		// Levels past low all count as low.
+		if n := len(load.Priorities); n > 0 && load.Priorities[n-1].Priority == pl.Priority {
+			load.Priorities[n-1].Available += pl.Available
+			load.Priorities[n-1].Running += pl.Running
+			continue
+		}
+		load.Priorities = append(load.Priorities, pl)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating queue loads by priority: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package river_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestQueueMonitor_Stats(t *testing.T) {
+	db := setupTestDB(t)
+	client := setupClient(t, db)
+	ctx := context.Background()
+	monitor := riveradapter.NewQueueMonitor(db)
+
+	stats, err := monitor.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.Available != 0 || stats.OldestAvailableAge != 0 {
+		t.Errorf("empty queue stats = %+v, want zero", stats)
+	}
+
+	// The client is not started, so published jobs stay available.
+	pub := riveradapter.NewPublisher(client)
+	for _, slug := range []string{"a", "b", "c"} {
+		tenant := domain.NewTenant("ten_"+slug, slug, slug, "free")
+		if err := pub.Publish(ctx, domain.TenantEvent{Event: domain.EventProvisionComplete, Tenant: tenant}); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	if _, err := db.ExecContext(ctx,
+		`UPDATE river_job SET scheduled_at = datetime('now', '-10 minutes') WHERE id = (SELECT MIN(id) FROM river_job)`,
+	); err != nil {
+		t.Fatalf("backdating job: %v", err)
+	}
+
+	stats, err = monitor.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.Available != 3 {
+		t.Errorf("Available = %d, want 3", stats.Available)
+	}
+	if stats.OldestAvailableAge < 9*time.Minute || stats.OldestAvailableAge > 11*time.Minute {
+		t.Errorf("OldestAvailableAge = %v, want about 10m", stats.OldestAvailableAge)
+	}
+}
+
+func TestQueueMonitor_Loads(t *testing.T) {
+	db := setupTestDB(t)
+	client := setupClient(t, db)
+	ctx := context.Background()
+	monitor := riveradapter.NewQueueMonitor(db)
+
+	loads, err := monitor.Loads(ctx)
+	if err != nil {
+		t.Fatalf("Loads failed: %v", err)
+	}
+	if len(loads) != 0 {
+		t.Errorf("got %d loads on an empty queue, want 0", len(loads))
+	}
+
+	pub := riveradapter.NewPublisher(client)
+	for _, slug := range []string{"a", "b", "c", "d", "e"} {
+		tenant := domain.NewTenant("ten_"+slug, slug, slug, "free")
+		if err := pub.Publish(ctx, domain.TenantEvent{Event: domain.EventProvisionComplete, Tenant: tenant}); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	// Pretend workers took one job and finished two others a minute ago.
+	for _, stmt := range []string{
+		`UPDATE river_job SET state = 'running' WHERE id = 1`,
+		`UPDATE river_job SET state = 'completed', finalized_at = datetime('now', '-1 minutes') WHERE id IN (2, 3)`,
+	} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("updating jobs: %v", err)
+		}
+	}
+
+	loads, err = monitor.Loads(ctx)
+	if err != nil {
+		t.Fatalf("Loads failed: %v", err)
+	}
+	if len(loads) != 1 {
+		t.Fatalf("got %d loads, want 1", len(loads))
+	}
+	got := loads[0]
+	if got.Queue != "default" || got.Available != 2 || got.Running != 1 {
+		t.Errorf("load = %+v, want default queue with 2 available and 1 running", got)
+	}
+	if got.CompletedPerMinute != 0.4 {
+		t.Errorf("CompletedPerMinute = %v, want 0.4 (2 jobs over 5 minutes)", got.CompletedPerMinute)
+	}
+	if len(got.Priorities) != 1 || got.Priorities[0] != (domain.PriorityLoad{Priority: domain.PriorityNormal, Available: 2, Running: 1}) {
+		t.Errorf("priorities = %+v, want every job at normal priority", got.Priorities)
+	}
+}
+
+func TestQueueMonitor_LoadsByPriority(t *testing.T) {
+	db := setupTestDB(t)
+	client := setupClient(t, db)
+	ctx := context.Background()
+
+	pub := riveradapter.NewPublisher(client)
+	for _, p := range []domain.Priority{domain.PriorityLow, domain.PriorityHigh, domain.PriorityHigh} {
+		tenant := domain.NewTenant("ten_1", "a", "a", "free")
+		if err := pub.Publish(domain.WithPriority(ctx, p), domain.TenantEvent{Event: domain.EventProvisionComplete, Tenant: tenant}); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+
+	loads, err := riveradapter.NewQueueMonitor(db).Loads(ctx)
+	if err != nil {
+		t.Fatalf("Loads failed: %v", err)
+	}
+	want := []domain.PriorityLoad{
+		{Priority: domain.PriorityHigh, Available: 2},
+		{Priority: domain.PriorityLow, Available: 1},
+	}
+	if len(loads) != 1 || !slices.Equal(loads[0].Priorities, want) {
+		t.Errorf("loads = %+v, want %+v", loads, want)
+	}
+}
@@ -0,0 +1,179 @@
+package river
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// River's own defaults for keeping finished jobs.
+const (
+	defaultFinishedJobRetention  = 24 * time.Hour
+	defaultDiscardedJobRetention = 7 * 24 * time.Hour
+)
+
+// WithJobRetention makes River keep finished jobs for at least d, so a
+// JobPruner can enforce a longer retention on some kinds. Jobs of kinds
+// without a pruner are removed by River's cleaner once d has passed.
+func WithJobRetention(d time.Duration) SetupOption {
+	return func(cfg *river.Config) {
+		cfg.CompletedJobRetentionPeriod = max(d, defaultFinishedJobRetention)
+		cfg.CancelledJobRetentionPeriod = max(d, defaultFinishedJobRetention)
+		cfg.DiscardedJobRetentionPeriod = max(d, defaultDiscardedJobRetention)
+	}
+}
+
+// Compile-time check: JobPruner implements domain.Pruner.
+var _ domain.Pruner = (*JobPruner)(nil)
+
+// pruneBatchSize bounds the jobs listed and deleted per round trip.
+const pruneBatchSize = 500
+
+// riverTimeFormat is the layout River's SQLite driver stores timestamps
+// in, so they compare in chronological order as text.
+const riverTimeFormat = "2006-01-02 15:04:05.000"
+
+// JobPruner deletes the finished jobs of one kind, e.g. the published
+// events or the webhook deliveries, which double as their log.
+type JobPruner struct {
+	client *Client
+	db     *sql.DB
+	kind   string
+}
+
+// NewJobPruner creates a pruner for the jobs of the given kind, in River
+// and in the archive a JobArchiver moves them to.
+func NewJobPruner(client *Client, db *sql.DB, kind string) *JobPruner {
+	return &JobPruner{client: client, db: db, kind: kind}
+}
+
+// Prune deletes the jobs of the pruner's kind finalized before the cutoff,
+// archived or not.
+func (p *JobPruner) Prune(ctx context.Context, before time.Time, dryRun bool) (int, error) {
+	cutoff := before.UTC().Format(riverTimeFormat)
+	pruned, err := p.pruneArchived(ctx, cutoff, dryRun)
+	if err != nil {
+		return 0, err
+	}
+
+	params := river.NewJobListParams().
+		Kinds(p.kind).
+		States(rivertype.JobStateCompleted, rivertype.JobStateCancelled, rivertype.JobStateDiscarded).
+		Where("finalized_at < @cutoff", river.NamedArgs{"cutoff": cutoff}).
+		First(pruneBatchSize)
+
+	for {
+		res, err := p.client.JobList(ctx, params)
+		if err != nil {
+			return pruned, fmt.Errorf("listing %s jobs: %w", p.kind, err)
+		}
+		if len(res.Jobs) == 0 {
+			return pruned, nil
+		}
+
+		if !dryRun {
+			ids := make([]int64, 0, len(res.Jobs))
+			for _, job := range res.Jobs {
+				ids = append(ids, job.ID)
+			}
+			if _, err := p.client.JobDeleteMany(ctx, river.NewJobDeleteManyParams().IDs(ids...).First(len(ids))); err != nil {
+				return pruned, fmt.Errorf("deleting %s jobs: %w", p.kind, err)
+			}
+		}
+		pruned += len(res.Jobs)
+
+		if len(res.Jobs) < pruneBatchSize {
+			return pruned, nil
+		}
+		params = params.After(res.LastCursor)
+	}
+}
+
+// pruneArchived deletes, or with dryRun counts, the archived jobs of the
+// pruner's kind finalized before the cutoff.
+func (p *JobPruner) pruneArchived(ctx context.Context, cutoff string, dryRun bool) (int, error) {
+	const where = ` FROM river_job_archive WHERE kind = ? AND finalized_at < ?`
+	if dryRun {
+		var n int
+		if err := p.db.QueryRowContext(ctx, `SELECT COUNT(*)`+where, p.kind, cutoff).Scan(&n); err != nil {
+			return 0, fmt.Errorf("counting archived %s jobs: %w", p.kind, err)
+		}
+		return n, nil
+	}
+
+	result, err := p.db.ExecContext(ctx, `DELETE`+where, p.kind, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("pruning archived %s jobs: %w", p.kind, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("checking rows affected: %w", err)
+	}
+	return int(n), nil
+}
+
+// RetentionArgs triggers a pruning run.
+type RetentionArgs struct {
+	// DryRun reports the expired records without deleting them.
+	DryRun bool `json:"dry_run"`
+}
+
+// Kind returns the unique job type identifier used by River's job routing.
+func (RetentionArgs) Kind() string { return "retention.prune" }
+
+// RetentionWorker prunes the records that outlived the retention policy.
+type RetentionWorker struct {
+	river.WorkerDefaults[RetentionArgs]
+	retention *app.RetentionService
+}
+
+// NewRetentionWorker creates a retention worker.
+func NewRetentionWorker(retention *app.RetentionService) *RetentionWorker {
+	return &RetentionWorker{retention: retention}
+}
+
+// Work runs the pruning once and logs what expired.
+func (w *RetentionWorker) Work(ctx context.Context, job *river.Job[RetentionArgs]) error {
+	report := w.retention.Enforce(ctx, time.Now().UTC(), job.Args.DryRun)
+
+	pruned, failed := 0, 0
+	for _, item := range report.Items {
+		if item.Error != "" {
+			failed++
+		}
+		pruned += item.Pruned
+		slog.InfoContext(ctx, "retention pruned",
+			"record", item.Record,
+			"before", item.Before,
+			"pruned", item.Pruned,
+			"dry_run", report.DryRun,
+			"error", item.Error,
+		)
+	}
+	slog.InfoContext(ctx, "retention finished",
+		"pruned", pruned,
+		"failed", failed,
+		"dry_run", report.DryRun,
+		"job_id", job.ID,
+	)
+	return nil
+}
+
+// RetentionPeriodicJob schedules pruning every interval, starting at boot.
+func RetentionPeriodicJob(interval time.Duration, dryRun bool) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(interval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return RetentionArgs{DryRun: dryRun}, periodicJobOpts()
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
@@ -0,0 +1,57 @@
+package river_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestJobPruner_Prune(t *testing.T) {
+	db := setupTestDB(t)
+	if _, err := sqlite.NewFromDB(db); err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+	client := setupClient(t, db)
+	ctx := context.Background()
+
+	pub := riveradapter.NewPublisher(client)
+	for _, slug := range []string{"a", "b", "c", "d"} {
+		tenant := domain.NewTenant("ten_"+slug, slug, slug, "free")
+		if err := pub.Publish(ctx, domain.TenantEvent{Event: domain.EventProvisionComplete, Tenant: tenant}); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	// Two events were delivered long ago, one recently, one is still queued.
+	for _, stmt := range []string{
+		`UPDATE river_job SET state = 'completed', finalized_at = datetime('now', '-40 days') WHERE id IN (1, 2)`,
+		`UPDATE river_job SET state = 'completed', finalized_at = datetime('now', '-1 days') WHERE id = 3`,
+	} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("updating jobs: %v", err)
+		}
+	}
+
+	pruner := riveradapter.NewJobPruner(client, db, riveradapter.EventJobArgs{}.Kind())
+	before := time.Now().UTC().Add(-30 * 24 * time.Hour)
+
+	n, err := pruner.Prune(ctx, before, true)
+	if err != nil || n != 2 {
+		t.Fatalf("dry-run Prune = %d, %v; want 2", n, err)
+	}
+	n, err = pruner.Prune(ctx, before, false)
+	if err != nil || n != 2 {
+		t.Fatalf("Prune = %d, %v; want 2", n, err)
+	}
+
+	var left int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM river_job`).Scan(&left); err != nil {
+		t.Fatalf("counting jobs: %v", err)
+	}
+	if left != 2 {
+		t.Errorf("%d jobs left, want the recent and the queued one", left)
+	}
+}
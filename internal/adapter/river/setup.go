@@ -4,16 +4,75 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/http"
 
 	"github.com/riverqueue/river"
 	"github.com/riverqueue/river/riverdriver/riversqlite"
 	"github.com/riverqueue/river/rivermigrate"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
 )
 
-// Setup creates a River client with the event worker registered and runs
-// River's internal migrations. The caller must call client.Start() to begin
+// WorkersOption adds optional behavior to the bundle built by NewWorkers.
+type WorkersOption func(*river.Workers, *EventWorker)
+
+// WithWebhooks makes the event worker fan events out to the webhook
+// subscriptions of ws and registers the worker delivering them with client.
+// Delivered and digested payloads go through transforms, in order.
+func WithWebhooks(ws *app.WebhookService, client *http.Client, transforms ...domain.EventTransform) WorkersOption {
+	return func(workers *river.Workers, events *EventWorker) {
+		events.webhooks = ws
+		events.transforms = transforms
+		river.AddWorker(workers, NewWebhookDeliveryWorker(ws, client, transforms...))
+	}
+}
+
+// WithWebhookDigests holds the events of subscriptions in a digest mode
+// for ds and registers the worker delivering the digests with client, as
+// CloudEvents from source. Schedule it with WebhookDigestPeriodicJob.
+func WithWebhookDigests(ds *app.DigestService, ws *app.WebhookService, client *http.Client, source string) WorkersOption {
+	return func(workers *river.Workers, events *EventWorker) {
+		events.digests = ds
+		river.AddWorker(workers, NewWebhookDigestWorker(ds, ws, client, source))
+	}
+}
+
+// WithEventFollower queues the jobs follow returns for each event along
+// with its webhook deliveries. Their workers are registered by the caller.
+func WithEventFollower(follow EventFollower) WorkersOption {
+	return func(_ *river.Workers, events *EventWorker) {
+		events.followers = append(events.followers, follow)
+	}
+}
+
+// NewWorkers returns a worker bundle with the event worker registered.
+func NewWorkers(opts ...WorkersOption) *river.Workers {
+	workers := river.NewWorkers()
+	events := &EventWorker{}
+	for _, opt := range opts {
+		opt(workers, events)
+	}
+	river.AddWorker(workers, events)
+	return workers
+}
+
+// SetupOption customizes the River client configuration.
+type SetupOption func(*river.Config)
+
+// WithErrorHandler reports job errors and panics (e.g., to an error tracker).
+func WithErrorHandler(h river.ErrorHandler) SetupOption {
+	return func(cfg *river.Config) {
+		cfg.ErrorHandler = h
+	}
+}
+
+// Setup creates a River client for the given worker bundle and runs River's
+// internal migrations. Workers whose dependencies need the client (e.g.,
+// through the application service) may still be added to the bundle until
+// the client is started. The caller must call client.Start() to begin
 // processing jobs and client.Stop() for graceful shutdown.
-func Setup(ctx context.Context, db *sql.DB) (*Client, error) {
+func Setup(ctx context.Context, db *sql.DB, workers *river.Workers, opts ...SetupOption) (*Client, error) {
 	driver := riversqlite.New(db)
 
 	// Run River's own migrations (creates river_job, river_leader, etc.).
@@ -26,15 +85,17 @@ func Setup(ctx context.Context, db *sql.DB) (*Client, error) {
 		return nil, fmt.Errorf("running river migrations: %w", err)
 	}
 
-	workers := river.NewWorkers()
-	river.AddWorker(workers, &EventWorker{})
-
-	client, err := river.NewClient(driver, &river.Config{
+	cfg := &river.Config{
 		Queues: map[string]river.QueueConfig{
 			river.QueueDefault: {MaxWorkers: 2},
 		},
 		Workers: workers,
-	})
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	client, err := river.NewClient(driver, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("creating river client: %w", err)
 	}
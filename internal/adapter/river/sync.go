@@ -0,0 +1,97 @@
+package river
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// SpecSyncArgs triggers a reconciliation of stored tenants against their
+// declared specs.
+type SpecSyncArgs struct {
+	DryRun bool `json:"dry_run" doc:"Report changes without applying them"`
+	Force  bool `json:"force" doc:"Apply changes even when guardrails would block them"`
+}
+
+// Kind returns the unique job type identifier used by River's job routing.
+func (SpecSyncArgs) Kind() string { return "tenant.spec_sync" }
+
+// SpecSyncWorker loads specs from a domain.SpecSource and reconciles the
+// database with them, logging the resulting report.
+type SpecSyncWorker struct {
+	river.WorkerDefaults[SpecSyncArgs]
+	source domain.SpecSource
+	svc    *app.TenantService
+}
+
+// NewSpecSyncWorker creates a sync worker reading specs from source.
+func NewSpecSyncWorker(source domain.SpecSource, svc *app.TenantService) *SpecSyncWorker {
+	return &SpecSyncWorker{source: source, svc: svc}
+}
+
+// Work runs a single reconciliation.
+func (w *SpecSyncWorker) Work(ctx context.Context, job *river.Job[SpecSyncArgs]) error {
+	ctx = domain.WithAutomation(domain.WithActor(ctx, "spec-sync"))
+
+	specs, err := w.source.Specs(ctx)
+	if err != nil {
+		return fmt.Errorf("loading specs: %w", err)
+	}
+
+	if job.Args.Force {
+		slog.WarnContext(ctx, "spec sync forced past the guardrail", "job_id", job.ID)
+	}
+	report, err := w.svc.Sync(ctx, specs, app.SyncOptions{DryRun: job.Args.DryRun, Force: job.Args.Force})
+	var guardErr *domain.GuardrailError
+	if errors.As(err, &guardErr) {
+		// Retrying cannot help until a human looks at the specs.
+		slog.WarnContext(ctx, "spec sync blocked by guardrail", "error", err, "job_id", job.ID)
+		return river.JobCancel(err)
+	}
+	if err != nil {
+		return fmt.Errorf("syncing tenants: %w", err)
+	}
+
+	for _, item := range report.Items {
+		if item.Action == app.SyncUnchanged {
+			continue
+		}
+		slog.InfoContext(ctx, "spec sync item",
+			"dry_run", report.DryRun,
+			"tenant_slug", item.Slug,
+			"action", string(item.Action),
+			"changes", item.Changes,
+			"error", item.Error,
+		)
+	}
+	slog.InfoContext(ctx, "spec sync finished",
+		"dry_run", report.DryRun,
+		"created", report.Count(app.SyncCreate),
+		"updated", report.Count(app.SyncUpdate),
+		"unchanged", report.Count(app.SyncUnchanged),
+		"extraneous", report.Count(app.SyncExtraneous),
+		"deferred", report.Count(app.SyncDeferred),
+		"failed", report.Count(app.SyncFailed),
+		"job_id", job.ID,
+	)
+	return nil
+}
+
+// SpecSyncPeriodicJob schedules a spec sync every interval, starting at
+// boot. With force, the runs go past the guardrail.
+func SpecSyncPeriodicJob(interval time.Duration, dryRun, force bool) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(interval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return SpecSyncArgs{DryRun: dryRun, Force: force}, periodicJobOpts()
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
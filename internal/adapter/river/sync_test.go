@@ -0,0 +1,114 @@
+package river_test
+
+import (
+	"context"
+	"testing"
+
+	goriver "github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+type staticSource struct {
+	specs []domain.TenantSpec
+}
+
+func (s *staticSource) Specs(_ context.Context) ([]domain.TenantSpec, error) {
+	return s.specs, nil
+}
+
+type noopPublisher struct{}
+
+func (noopPublisher) Publish(_ context.Context, _ domain.TenantEvent) error { return nil }
+
+type tableValidator struct{}
+
+func (tableValidator) Apply(_ context.Context, tenant domain.Tenant, event domain.Event) (domain.Status, error) {
+	for _, t := range domain.Transitions {
+		if t.Event == event && t.Src == tenant.Status {
+			return t.Dst, nil
+		}
+	}
+	return "", &domain.TransitionError{Event: event, Current: tenant.Status}
+}
+
+func newSyncService(t *testing.T, opts ...app.Option) (*app.TenantService, *sqlite.TenantRepository) {
+	t.Helper()
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return app.NewTenantService(repo, noopPublisher{}, tableValidator{}, opts...), repo
+}
+
+func syncJob(dryRun bool) *goriver.Job[riveradapter.SpecSyncArgs] {
+	return &goriver.Job[riveradapter.SpecSyncArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   riveradapter.SpecSyncArgs{DryRun: dryRun},
+	}
+}
+
+func TestSpecSyncWorker_CreatesMissingTenants(t *testing.T) {
+	svc, repo := newSyncService(t)
+	source := &staticSource{specs: []domain.TenantSpec{
+		{Slug: "acme", Name: "Acme", Plan: "pro", Status: domain.StatusActive},
+	}}
+
+	if err := riveradapter.NewSpecSyncWorker(source, svc).Work(context.Background(), syncJob(false)); err != nil {
+		t.Fatalf("Work failed: %v", err)
+	}
+
+	got, err := repo.GetBySlug(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("tenant not created: %v", err)
+	}
+	if got.Status != domain.StatusActive {
+		t.Errorf("Status = %q, want %q", got.Status, domain.StatusActive)
+	}
+}
+
+func TestSpecSyncWorker_DryRunWritesNothing(t *testing.T) {
+	svc, repo := newSyncService(t)
+	source := &staticSource{specs: []domain.TenantSpec{{Slug: "acme", Name: "Acme", Plan: "pro"}}}
+
+	if err := riveradapter.NewSpecSyncWorker(source, svc).Work(context.Background(), syncJob(true)); err != nil {
+		t.Fatalf("Work failed: %v", err)
+	}
+
+	if _, err := repo.GetBySlug(context.Background(), "acme"); err == nil {
+		t.Error("dry run should not create tenants")
+	}
+}
+
+func TestSpecSyncWorker_ForceGoesPastGuardrail(t *testing.T) {
+	svc, repo := newSyncService(t, app.WithGuardrail(domain.Guardrail{MaxDisruptedPercent: 10}))
+	ctx := context.Background()
+	source := &staticSource{specs: []domain.TenantSpec{
+		{Slug: "acme", Name: "Acme", Status: domain.StatusActive},
+		{Slug: "globex", Name: "Globex", Status: domain.StatusActive},
+	}}
+	worker := riveradapter.NewSpecSyncWorker(source, svc)
+	if err := worker.Work(ctx, syncJob(false)); err != nil {
+		t.Fatalf("Work failed: %v", err)
+	}
+
+	for i := range source.specs {
+		source.specs[i].Status = domain.StatusSuspended
+	}
+	if err := worker.Work(ctx, syncJob(false)); err == nil {
+		t.Fatal("Work succeeded past the guardrail, want the job cancelled")
+	}
+	job := syncJob(false)
+	job.Args.Force = true
+	if err := worker.Work(ctx, job); err != nil {
+		t.Fatalf("forced Work failed: %v", err)
+	}
+	if got, _ := repo.GetBySlug(ctx, "acme"); got.Status != domain.StatusSuspended {
+		t.Errorf("Status = %q, want %q", got.Status, domain.StatusSuspended)
+	}
+}
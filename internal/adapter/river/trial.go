@@ -0,0 +1,79 @@
+package river
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// TrialExpiryArgs triggers the expiry of the trials that have ended.
+type TrialExpiryArgs struct {
+	// DryRun reports the expired trials without ending them.
+	DryRun bool `json:"dry_run"`
+}
+
+// Kind returns the unique job type identifier used by River's job routing.
+func (TrialExpiryArgs) Kind() string { return "tenant.trial_expiry" }
+
+// TrialExpiryWorker ends expired trials and logs each one.
+type TrialExpiryWorker struct {
+	river.WorkerDefaults[TrialExpiryArgs]
+	trials *app.TrialService
+}
+
+// NewTrialExpiryWorker creates a trial expiry worker.
+func NewTrialExpiryWorker(trials *app.TrialService) *TrialExpiryWorker {
+	return &TrialExpiryWorker{trials: trials}
+}
+
+// Work runs a single pass over the tenants on trial. Suspensions are
+// automated, so they wait for the tenant's maintenance window.
+func (w *TrialExpiryWorker) Work(ctx context.Context, job *river.Job[TrialExpiryArgs]) error {
+	ctx = domain.WithAutomation(domain.WithActor(ctx, "trial-expiry"))
+
+	report, err := w.trials.Expire(ctx, time.Now().UTC(), job.Args.DryRun)
+	if err != nil {
+		return fmt.Errorf("expiring trials: %w", err)
+	}
+
+	failed := 0
+	for _, item := range report.Items {
+		if item.Error != "" {
+			failed++
+		}
+		slog.InfoContext(ctx, "trial expiry item",
+			"tenant_id", item.TenantID,
+			"slug", item.Slug,
+			"trial_ends_at", item.TrialEndsAt,
+			"action", item.Action,
+			"deferred_until", item.DeferredUntil,
+			"dry_run", report.DryRun,
+			"error", item.Error,
+		)
+	}
+	slog.InfoContext(ctx, "trial expiry finished",
+		"expired", len(report.Items)-failed,
+		"failed", failed,
+		"dry_run", report.DryRun,
+		"job_id", job.ID,
+	)
+	return nil
+}
+
+// TrialExpiryPeriodicJob schedules trial expiries every interval, starting
+// at boot.
+func TrialExpiryPeriodicJob(interval time.Duration, dryRun bool) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(interval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return TrialExpiryArgs{DryRun: dryRun}, periodicJobOpts()
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
@@ -0,0 +1,51 @@
+package river_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	goriver "github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestTrialExpiryWorker_DowngradesExpiredTrial(t *testing.T) {
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	svc := app.NewTenantService(repo, noopPublisher{}, tableValidator{})
+	ctx := context.Background()
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "trial"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+	ended := time.Now().UTC().Add(-time.Hour)
+	if _, err := svc.Update(ctx, tenant.ID, domain.TenantPatch{TrialEndsAt: &ended}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	job := &goriver.Job[riveradapter.TrialExpiryArgs]{JobRow: &rivertype.JobRow{ID: 1}}
+	if err := riveradapter.NewTrialExpiryWorker(app.NewTrialService(svc, "free")).Work(ctx, job); err != nil {
+		t.Fatalf("Work: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, tenant.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Status != domain.StatusActive || got.Plan != "free" || !got.TrialEndsAt.IsZero() {
+		t.Errorf("tenant = %s on %s, trial ends %v; want active on free with no trial", got.Status, got.Plan, got.TrialEndsAt)
+	}
+}
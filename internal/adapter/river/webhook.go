@@ -0,0 +1,167 @@
+package river
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Headers of webhook deliveries.
+const (
+	WebhookEventHeader     = "X-Tenantiq-Event"
+	WebhookEventIDHeader   = "X-Tenantiq-Event-Id"
+	WebhookTimestampHeader = "X-Tenantiq-Timestamp"
+	WebhookSignatureHeader = "X-Tenantiq-Signature"
+)
+
+// webhookMaxAttempts bounds retries of a delivery; with River's backoff the
+// last attempt happens about a day after the event.
+const webhookMaxAttempts = 12
+
+// webhookHeldRecheck is how often a delivery held by an open circuit checks
+// whether the circuit was resumed. Snoozing does not use up attempts.
+const webhookHeldRecheck = time.Minute
+
+// WebhookDeliveryArgs delivers one event to one webhook subscription.
+type WebhookDeliveryArgs struct {
+	WebhookID string       `json:"webhook_id" doc:"Webhook subscription to deliver to"`
+	Payload   EventJobArgs `json:"payload" doc:"CloudEvent sent as the request body"`
+}
+
+// Kind returns the unique job type identifier used by River's job routing.
+func (WebhookDeliveryArgs) Kind() string { return "webhook.delivery" }
+
+// InsertOpts gives up on an endpoint after webhookMaxAttempts failures.
+func (WebhookDeliveryArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{MaxAttempts: webhookMaxAttempts}
+}
+
+// WebhookDeliveryWorker POSTs an event to a subscription's URL, signed with
+// its secret. A non-2xx answer fails the job, so River retries it with
+// backoff. The subscription is read at delivery time: a rotated secret or
+// changed URL applies to pending retries, and a deleted subscription cancels
+// them. Every outcome feeds the subscription's circuit; while it is open,
+// deliveries are held until it is resumed. The payload goes through the
+// worker's transforms; a failing transform fails the job without counting
+// against the endpoint.
+type WebhookDeliveryWorker struct {
+	river.WorkerDefaults[WebhookDeliveryArgs]
+	webhooks   *app.WebhookService
+	client     *http.Client
+	transforms []domain.EventTransform
+}
+
+// NewWebhookDeliveryWorker creates a delivery worker sending requests with
+// client, with the payloads rewritten by transforms, in order.
+func NewWebhookDeliveryWorker(webhooks *app.WebhookService, client *http.Client, transforms ...domain.EventTransform) *WebhookDeliveryWorker {
+	return &WebhookDeliveryWorker{webhooks: webhooks, client: client, transforms: transforms}
+}
+
+// Work delivers the event once.
+func (w *WebhookDeliveryWorker) Work(ctx context.Context, job *river.Job[WebhookDeliveryArgs]) error {
+	sub, err := w.webhooks.Get(ctx, job.Args.WebhookID)
+	if errors.Is(err, domain.ErrWebhookNotFound) {
+		return river.JobCancel(err)
+	}
+	if err != nil {
+		return fmt.Errorf("getting webhook subscription: %w", err)
+	}
+	event := job.Args.Payload.Event()
+	if !sub.Matches(event) {
+		slog.InfoContext(ctx, "webhook delivery skipped: event no longer subscribed",
+			"webhook_id", sub.ID, "event", event, "job_id", job.ID)
+		return nil
+	}
+	if sub.Circuit.Open() {
+		return river.JobSnooze(webhookHeldRecheck)
+	}
+
+	body, err := w.payload(ctx, job.Args.Payload)
+	if err != nil {
+		return err
+	}
+	if err := postWebhook(ctx, w.client, sub, string(event), job.Args.Payload.ID, body); err != nil {
+		if rerr := w.webhooks.RecordFailure(ctx, sub.ID, err); rerr != nil {
+			slog.WarnContext(ctx, "webhook failure not recorded",
+				"webhook_id", sub.ID, "error", rerr, "job_id", job.ID)
+		}
+		return err
+	}
+	if err := w.webhooks.RecordSuccess(ctx, sub); err != nil {
+		slog.WarnContext(ctx, "webhook success not recorded",
+			"webhook_id", sub.ID, "error", err, "job_id", job.ID)
+	}
+	slog.InfoContext(ctx, "webhook delivered",
+		"webhook_id", sub.ID,
+		"event", event,
+		"tenant_id", job.Args.Payload.Data.TenantID,
+		"attempt", job.Attempt,
+		"job_id", job.ID,
+	)
+	return nil
+}
+
+// payload encodes the event as the request body, rewritten by the
+// worker's transforms.
+func (w *WebhookDeliveryWorker) payload(ctx context.Context, event EventJobArgs) ([]byte, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("encoding payload: %w", err)
+	}
+	return domain.TransformEvent(ctx, w.transforms, event.Event(), body)
+}
+
+// postWebhook POSTs a CloudEvent to the subscription once, signed with its
+// secret.
+func postWebhook(ctx context.Context, client *http.Client, sub domain.WebhookSubscription, event, eventID string, body []byte) error {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookEventIDHeader, eventID)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(sub.Secret, timestamp, body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delivering to %s: %w", sub.URL, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096)) // Lets the connection be reused.
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("delivering to %s: %s", sub.URL, resp.Status)
+	}
+	return nil
+}
+
+// SignWebhook returns the X-Tenantiq-Signature of a delivery: "sha256="
+// followed by the hex HMAC-SHA256, keyed with the subscription's secret, of
+// the timestamp header, a dot and the raw body. Covering the timestamp lets
+// receivers reject replayed deliveries.
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,294 @@
+package river_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	goriver "github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+const webhookSecret = "0123456789abcdef"
+
+// delivery is a request received by a webhook endpoint.
+type delivery struct {
+	header http.Header
+	body   []byte
+}
+
+// webhookEndpoint answers every request with status and forwards it to the
+// returned channel.
+func webhookEndpoint(t *testing.T, status int) (*httptest.Server, <-chan delivery) {
+	t.Helper()
+	received := make(chan delivery, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- delivery{header: r.Header.Clone(), body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, received
+}
+
+func newWebhookService(t *testing.T) *app.WebhookService {
+	t.Helper()
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return app.NewWebhookService(sqlite.NewWebhookRepository(repo.DB()))
+}
+
+func TestWebhooks_EventIsDeliveredSigned(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	if _, err := sqlite.NewFromDB(db); err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+
+	srv, received := webhookEndpoint(t, http.StatusNoContent)
+	ws := app.NewWebhookService(sqlite.NewWebhookRepository(db))
+	if _, err := ws.Create(ctx, srv.URL, webhookSecret, []domain.Event{domain.EventSuspend}, ""); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	client, err := riveradapter.Setup(ctx, db, riveradapter.NewWorkers(riveradapter.WithWebhooks(ws, srv.Client())))
+	if err != nil {
+		t.Fatalf("river setup: %v", err)
+	}
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("river start: %v", err)
+	}
+	t.Cleanup(func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = client.Stop(stopCtx)
+	})
+
+	pub := riveradapter.NewPublisher(client)
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "pro")
+	// Not subscribed: must not be delivered.
+	if err := pub.Publish(ctx, domain.TenantEvent{Event: domain.EventReactivate, Tenant: tenant}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if err := pub.Publish(ctx, domain.TenantEvent{Event: domain.EventSuspend, Tenant: tenant}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	select {
+	case d := <-received:
+		if got := d.header.Get(riveradapter.WebhookEventHeader); got != string(domain.EventSuspend) {
+			t.Errorf("event header = %q, want suspend", got)
+		}
+		if d.header.Get(riveradapter.WebhookEventIDHeader) == "" {
+			t.Error("missing event ID header")
+		}
+		want := riveradapter.SignWebhook(webhookSecret, d.header.Get(riveradapter.WebhookTimestampHeader), d.body)
+		if got := d.header.Get(riveradapter.WebhookSignatureHeader); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook not delivered within 5 seconds")
+	}
+
+	select {
+	case d := <-received:
+		t.Errorf("unexpected delivery of %s", d.header.Get(riveradapter.WebhookEventHeader))
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestWebhookDeliveryWorker_FailsOnErrorStatus(t *testing.T) {
+	ws := newWebhookService(t)
+	srv, _ := webhookEndpoint(t, http.StatusServiceUnavailable)
+	sub, err := ws.Create(context.Background(), srv.URL, webhookSecret, nil, "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	job := &goriver.Job[riveradapter.WebhookDeliveryArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   riveradapter.WebhookDeliveryArgs{WebhookID: sub.ID, Payload: riveradapter.NewCloudEvent("/test", domain.TenantEvent{Event: domain.EventDelete, Tenant: domain.Tenant{ID: "ten_1"}})},
+	}
+	if err := riveradapter.NewWebhookDeliveryWorker(ws, srv.Client()).Work(context.Background(), job); err == nil {
+		t.Error("Work succeeded on 503, want an error so River retries")
+	}
+}
+
+func TestWebhookDeliveryWorker_HoldsDeliveriesWhileCircuitOpen(t *testing.T) {
+	ws := newWebhookService(t)
+	ctx := context.Background()
+	srv, received := webhookEndpoint(t, http.StatusServiceUnavailable)
+	sub, err := ws.Create(ctx, srv.URL, webhookSecret, nil, "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	worker := riveradapter.NewWebhookDeliveryWorker(ws, srv.Client())
+	job := &goriver.Job[riveradapter.WebhookDeliveryArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   riveradapter.WebhookDeliveryArgs{WebhookID: sub.ID, Payload: riveradapter.NewCloudEvent("/test", domain.TenantEvent{Event: domain.EventDelete, Tenant: domain.Tenant{ID: "ten_1"}})},
+	}
+
+	for range domain.WebhookCircuitThreshold {
+		_ = worker.Work(ctx, job)
+		<-received
+	}
+	got, _ := ws.Get(ctx, sub.ID)
+	if !got.Circuit.Open() || got.Circuit.LastError == "" {
+		t.Fatalf("circuit = %+v, want open with the last error", got.Circuit)
+	}
+
+	var snoozeErr *rivertype.JobSnoozeError
+	if err := worker.Work(ctx, job); !errors.As(err, &snoozeErr) {
+		t.Errorf("Work with the circuit open = %v, want the job snoozed", err)
+	}
+	select {
+	case <-received:
+		t.Error("delivered while the circuit was open")
+	default:
+	}
+
+	if _, err := ws.Resume(ctx, sub.ID); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if err := worker.Work(ctx, job); err == nil || errors.As(err, &snoozeErr) {
+		t.Errorf("Work after Resume = %v, want a delivery failing on 503", err)
+	}
+	<-received
+}
+
+// redactTransform replaces every payload, or fails with err.
+type redactTransform struct{ err error }
+
+func (redactTransform) Name() string { return "redact" }
+
+func (t redactTransform) Transform(context.Context, domain.Event, []byte) ([]byte, error) {
+	return []byte(`{"redacted":true}`), t.err
+}
+
+func TestWebhookDeliveryWorker_TransformsPayload(t *testing.T) {
+	ws := newWebhookService(t)
+	ctx := context.Background()
+	srv, received := webhookEndpoint(t, http.StatusNoContent)
+	sub, err := ws.Create(ctx, srv.URL, webhookSecret, nil, "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	job := &goriver.Job[riveradapter.WebhookDeliveryArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   riveradapter.WebhookDeliveryArgs{WebhookID: sub.ID, Payload: riveradapter.NewCloudEvent("/test", domain.TenantEvent{Event: domain.EventDelete, Tenant: domain.Tenant{ID: "ten_1"}})},
+	}
+
+	if err := riveradapter.NewWebhookDeliveryWorker(ws, srv.Client(), redactTransform{}).Work(ctx, job); err != nil {
+		t.Fatalf("Work failed: %v", err)
+	}
+	d := <-received
+	if string(d.body) != `{"redacted":true}` {
+		t.Errorf("body = %s, want the transformed payload", d.body)
+	}
+	if got := d.header.Get(riveradapter.WebhookSignatureHeader); got != riveradapter.SignWebhook(webhookSecret, d.header.Get(riveradapter.WebhookTimestampHeader), d.body) {
+		t.Errorf("signature %q does not cover the transformed payload", got)
+	}
+
+	// A failing transform is retried without tripping the endpoint's circuit.
+	err = riveradapter.NewWebhookDeliveryWorker(ws, srv.Client(), redactTransform{err: errors.New("trap")}).Work(ctx, job)
+	var tErr *domain.EventTransformError
+	if !errors.As(err, &tErr) {
+		t.Fatalf("Work = %v, want an EventTransformError", err)
+	}
+	if got, _ := ws.Get(ctx, sub.ID); got.Circuit.ConsecutiveFailures != 0 {
+		t.Errorf("circuit = %+v, want no failure recorded", got.Circuit)
+	}
+}
+
+func TestWebhookDeliveryWorker_CancelsForDeletedSubscription(t *testing.T) {
+	ws := newWebhookService(t)
+
+	job := &goriver.Job[riveradapter.WebhookDeliveryArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   riveradapter.WebhookDeliveryArgs{WebhookID: "wh_gone", Payload: riveradapter.NewCloudEvent("/test", domain.TenantEvent{Event: domain.EventDelete, Tenant: domain.Tenant{ID: "ten_1"}})},
+	}
+	err := riveradapter.NewWebhookDeliveryWorker(ws, http.DefaultClient).Work(context.Background(), job)
+	var cancelErr *rivertype.JobCancelError
+	if !errors.As(err, &cancelErr) {
+		t.Errorf("Work = %v, want the job cancelled", err)
+	}
+}
+
+// followedArgs is the job a test follower queues after each event.
+type followedArgs struct {
+	Event string `json:"event"`
+}
+
+func (followedArgs) Kind() string { return "test.followed" }
+
+type followedWorker struct {
+	goriver.WorkerDefaults[followedArgs]
+	done chan<- string
+}
+
+func (w *followedWorker) Work(_ context.Context, job *goriver.Job[followedArgs]) error {
+	w.done <- job.Args.Event
+	return nil
+}
+
+func TestEventWorker_QueuesFollowerJobs(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	follow := func(event riveradapter.EventJobArgs) []goriver.JobArgs {
+		return []goriver.JobArgs{followedArgs{Event: string(event.Event())}}
+	}
+	workers := riveradapter.NewWorkers(riveradapter.WithEventFollower(follow))
+	done := make(chan string, 10)
+	goriver.AddWorker(workers, &followedWorker{done: done})
+
+	client, err := riveradapter.Setup(ctx, db, workers)
+	if err != nil {
+		t.Fatalf("river setup: %v", err)
+	}
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("river start: %v", err)
+	}
+	t.Cleanup(func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = client.Stop(stopCtx)
+	})
+
+	pub := riveradapter.NewPublisher(client)
+	simulated := domain.NewTenant("ten_2", "Sim", "sim", "pro")
+	simulated.Simulated = true
+	// Simulated: must not be followed.
+	if err := pub.Publish(ctx, domain.TenantEvent{Event: domain.EventReactivate, Tenant: simulated}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if err := pub.Publish(ctx, domain.TenantEvent{Event: domain.EventSuspend, Tenant: domain.NewTenant("ten_1", "Acme", "acme", "pro")}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	select {
+	case event := <-done:
+		if event != string(domain.EventSuspend) {
+			t.Errorf("followed event = %q, want suspend", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("follower job not worked within 5 seconds")
+	}
+	select {
+	case event := <-done:
+		t.Errorf("unexpected follower job for %s", event)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
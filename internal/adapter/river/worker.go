@@ -2,26 +2,107 @@ package river
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
 	"log/slog"
 
 	"github.com/riverqueue/river"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
 )
 
-// EventWorker processes domain event jobs from the River queue.
-// For now it logs the event; future versions will dispatch to
-// webhooks, provisioning logic, or notification systems.
+// EventFollower returns the jobs that follow an event, e.g. the sync of an
+// external system, or none.
+type EventFollower func(event EventJobArgs) []river.JobArgs
+
+// EventWorker processes domain event jobs from the River queue. It logs
+// the event and, when webhooks are configured, queues one delivery per
+// matching subscription, so a slow or failing endpoint never delays the
+// others, along with the jobs of its followers. Subscriptions in a digest
+// mode have the event held for their digest instead, when digests are
+// configured. The events of simulated tenants are neither delivered nor
+// followed.
 type EventWorker struct {
 	river.WorkerDefaults[EventJobArgs]
+	webhooks   *app.WebhookService
+	digests    *app.DigestService
+	transforms []domain.EventTransform
+	followers  []EventFollower
 }
 
 // Work processes a single event job.
 func (w *EventWorker) Work(ctx context.Context, job *river.Job[EventJobArgs]) error {
 	slog.InfoContext(ctx, "processing event",
-		"event", job.Args.Event,
-		"tenant_id", job.Args.TenantID,
-		"tenant_slug", job.Args.Slug,
+		"event", job.Args.Event(),
+		"event_id", job.Args.ID,
+		"tenant_id", job.Args.Data.TenantID,
+		"tenant_slug", job.Args.Data.Slug,
 		"job_id", job.ID,
 		"attempt", job.Attempt,
+		"simulated", job.Args.Data.Simulated,
 	)
+	if job.Args.Data.Simulated {
+		return nil
+	}
+
+	var jobs []river.InsertManyParams
+	if w.webhooks != nil {
+		subs, err := w.webhooks.ForEvent(ctx, job.Args.Event())
+		if err != nil {
+			return fmt.Errorf("finding webhook subscriptions: %w", err)
+		}
+		for _, sub := range subs {
+			if sub.Digest.Batched() && w.digests != nil {
+				if err := w.holdForDigest(ctx, sub, job.Args); err != nil {
+					return err
+				}
+				continue
+			}
+			jobs = append(jobs, river.InsertManyParams{
+				Args: WebhookDeliveryArgs{
+					WebhookID: sub.ID,
+					Payload:   job.Args,
+				},
+				// Deliveries keep the priority of their event.
+				InsertOpts: &river.InsertOpts{Priority: job.Priority},
+			})
+		}
+	}
+	for _, follow := range w.followers {
+		for _, args := range follow(job.Args) {
+			jobs = append(jobs, river.InsertManyParams{Args: args, InsertOpts: &river.InsertOpts{Priority: job.Priority}})
+		}
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	// Queued in one transaction: a retried event never queues only some.
+	if _, err := river.ClientFromContext[*sql.Tx](ctx).InsertMany(ctx, jobs); err != nil {
+		return fmt.Errorf("enqueuing event jobs: %w", err)
+	}
 	return nil
 }
+
+// holdForDigest keeps the event, as it would have been delivered on its
+// own, for the next digest of sub. Holding is idempotent, so a retried
+// event job holds it once.
+func (w *EventWorker) holdForDigest(ctx context.Context, sub domain.WebhookSubscription, event EventJobArgs) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+	if payload, err = domain.TransformEvent(ctx, w.transforms, event.Event(), payload); err != nil {
+		return err
+	}
+	return w.digests.Hold(ctx, domain.DigestEntry{
+		WebhookID:  sub.ID,
+		EventID:    event.ID,
+		Event:      event.Event(),
+		TenantID:   event.Data.TenantID,
+		Payload:    payload,
+		OccurredAt: event.Time,
+	})
+}
@@ -0,0 +1,167 @@
+// Package sentry reports panics and job failures to Sentry.
+//
+// It is optional: when no DSN is configured the Reporter is not created and
+// nothing is wired. The Reporter owns its own Hub, so it never touches the
+// global Sentry client.
+package sentry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// Config holds the error reporting settings.
+type Config struct {
+	DSN         string
+	Environment string
+	Release     string
+}
+
+// ConfigFromEnv reads the configuration from environment variables.
+// Environment and Release fall back to the given defaults (usually the
+// OpenTelemetry deployment environment and service version).
+func ConfigFromEnv(environment, release string) Config {
+	return Config{
+		DSN:         os.Getenv("SENTRY_DSN"),
+		Environment: envOrDefault("SENTRY_ENVIRONMENT", environment),
+		Release:     envOrDefault("SENTRY_RELEASE", release),
+	}
+}
+
+// Enabled reports whether a DSN is configured.
+func (c Config) Enabled() bool {
+	return c.DSN != ""
+}
+
+// Reporter sends panics and errors to Sentry with tenant and job context.
+type Reporter struct {
+	hub *sentry.Hub
+}
+
+// New creates a Reporter from the configuration.
+func New(cfg Config) (*Reporter, error) {
+	return NewWithOptions(sentry.ClientOptions{
+		Dsn:         cfg.DSN,
+		Environment: cfg.Environment,
+		Release:     cfg.Release,
+	})
+}
+
+// NewWithOptions creates a Reporter from raw client options (e.g., a custom
+// transport in tests).
+func NewWithOptions(opts sentry.ClientOptions) (*Reporter, error) {
+	client, err := sentry.NewClient(opts)
+	if err != nil {
+		return nil, fmt.Errorf("creating sentry client: %w", err)
+	}
+	return &Reporter{hub: sentry.NewHub(client, sentry.NewScope())}, nil
+}
+
+// Flush waits until buffered reports are sent or the timeout expires.
+func (r *Reporter) Flush(timeout time.Duration) bool {
+	return r.hub.Flush(timeout)
+}
+
+// Middleware reports panics raised by downstream handlers, then re-panics so
+// an outer Recoverer still writes the 500 response. Register it after
+// middleware.Recoverer so it sits inside it.
+func (r *Reporter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		defer func() {
+			rvr := recover()
+			if rvr == nil {
+				return
+			}
+			if rvr != http.ErrAbortHandler {
+				r.reportPanic(req, rvr)
+			}
+			panic(rvr)
+		}()
+		next.ServeHTTP(w, req)
+	})
+}
+
+func (r *Reporter) reportPanic(req *http.Request, rvr any) {
+	hub := r.hub.Clone()
+	hub.ConfigureScope(func(scope *sentry.Scope) {
+		scope.SetRequest(req)
+		if id := middleware.GetReqID(req.Context()); id != "" {
+			scope.SetTag("request_id", id)
+		}
+		// The route context is filled in while routing, so URL parameters
+		// of the matched route are available once the handler has run.
+		if rctx := chi.RouteContext(req.Context()); rctx != nil {
+			if id := rctx.URLParam("id"); id != "" {
+				scope.SetTag("tenant.id", id)
+			}
+			if slug := rctx.URLParam("slug"); slug != "" {
+				scope.SetTag("tenant.slug", slug)
+			}
+		}
+	})
+	hub.RecoverWithContext(req.Context(), rvr)
+}
+
+// HandleError implements river.ErrorHandler. Failed jobs keep following the
+// retry schedule.
+func (r *Reporter) HandleError(_ context.Context, job *rivertype.JobRow, err error) *river.ErrorHandlerResult {
+	hub := r.hub.Clone()
+	hub.ConfigureScope(func(scope *sentry.Scope) { setJobContext(scope, job) })
+	hub.CaptureException(err)
+	return nil
+}
+
+// HandlePanic implements river.ErrorHandler.
+func (r *Reporter) HandlePanic(_ context.Context, job *rivertype.JobRow, panicVal any, trace string) *river.ErrorHandlerResult {
+	hub := r.hub.Clone()
+	hub.ConfigureScope(func(scope *sentry.Scope) {
+		setJobContext(scope, job)
+		scope.SetExtra("stack", trace)
+	})
+	hub.CaptureException(fmt.Errorf("panic: %v", panicVal))
+	return nil
+}
+
+// setJobContext tags the report with the job identity and, when the job
+// arguments carry one, the tenant it was working on.
+func setJobContext(scope *sentry.Scope, job *rivertype.JobRow) {
+	scope.SetTag("job.kind", job.Kind)
+	scope.SetTag("job.queue", job.Queue)
+	scope.SetTag("job.id", strconv.FormatInt(job.ID, 10))
+	scope.SetContext("job", sentry.Context{
+		"attempt":      job.Attempt,
+		"max_attempts": job.MaxAttempts,
+	})
+
+	var args struct {
+		TenantID string `json:"tenant_id"`
+		Slug     string `json:"slug"`
+	}
+	if err := json.Unmarshal(job.EncodedArgs, &args); err != nil {
+		return
+	}
+	if args.TenantID != "" {
+		scope.SetTag("tenant.id", args.TenantID)
+	}
+	if args.Slug != "" {
+		scope.SetTag("tenant.slug", args.Slug)
+	}
+}
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
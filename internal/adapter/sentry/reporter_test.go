@@ -0,0 +1,172 @@
+package sentry_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/riverqueue/river/rivertype"
+
+	adapter "github.com/neomorfeo/tenantiq/internal/adapter/sentry"
+)
+
+// memoryTransport keeps the events in memory instead of sending them.
+type memoryTransport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *memoryTransport) Configure(sentry.ClientOptions)        {}
+func (t *memoryTransport) Flush(time.Duration) bool              { return true }
+func (t *memoryTransport) FlushWithContext(context.Context) bool { return true }
+func (t *memoryTransport) Close()                                {}
+
+func (t *memoryTransport) SendEvent(e *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, e)
+}
+
+func (t *memoryTransport) captured() []*sentry.Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.events
+}
+
+// only returns the single captured event.
+func (t *memoryTransport) only(tb testing.TB) *sentry.Event {
+	tb.Helper()
+	events := t.captured()
+	if len(events) != 1 {
+		tb.Fatalf("got %d events, want 1", len(events))
+	}
+	return events[0]
+}
+
+func newTestReporter(t *testing.T) (*adapter.Reporter, *memoryTransport) {
+	t.Helper()
+	transport := &memoryTransport{}
+	reporter, err := adapter.NewWithOptions(sentry.ClientOptions{
+		Dsn:       "https://key@sentry.example.com/1",
+		Transport: transport,
+	})
+	if err != nil {
+		t.Fatalf("creating reporter: %v", err)
+	}
+	return reporter, transport
+}
+
+func assertTag(t *testing.T, event *sentry.Event, key, want string) {
+	t.Helper()
+	if got := event.Tags[key]; got != want {
+		t.Errorf("tag %q = %q, want %q", key, got, want)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("SENTRY_DSN", "")
+
+	cfg := adapter.ConfigFromEnv("development", "0.1.0")
+	if cfg.Enabled() {
+		t.Error("reporting should be disabled without a DSN")
+	}
+	if cfg.Environment != "development" || cfg.Release != "0.1.0" {
+		t.Errorf("got %+v, want fallbacks", cfg)
+	}
+
+	t.Setenv("SENTRY_DSN", "https://key@sentry.example.com/1")
+	t.Setenv("SENTRY_ENVIRONMENT", "staging")
+	cfg = adapter.ConfigFromEnv("development", "0.1.0")
+	if !cfg.Enabled() || cfg.Environment != "staging" {
+		t.Errorf("got %+v, want enabled staging", cfg)
+	}
+}
+
+func TestMiddleware_ReportsPanicWithTenantContext(t *testing.T) {
+	reporter, transport := newTestReporter(t)
+
+	router := chi.NewMux()
+	router.Use(middleware.Recoverer)
+	router.Use(middleware.RequestID)
+	router.Use(reporter.Middleware)
+	router.Get("/api/v1/tenants/{id}", func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/tenants/ten_123", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+
+	event := transport.only(t)
+	assertTag(t, event, "tenant.id", "ten_123")
+	if event.Tags["request_id"] == "" {
+		t.Error("expected request_id tag")
+	}
+	if event.Request == nil || event.Request.Method != http.MethodGet {
+		t.Errorf("Request = %+v, want the failing request", event.Request)
+	}
+}
+
+func TestMiddleware_NoPanicNoReport(t *testing.T) {
+	reporter, transport := newTestReporter(t)
+
+	handler := reporter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if n := len(transport.captured()); n != 0 {
+		t.Errorf("got %d events, want 0", n)
+	}
+}
+
+func TestHandleError_ReportsJobContext(t *testing.T) {
+	reporter, transport := newTestReporter(t)
+
+	job := &rivertype.JobRow{
+		ID:          42,
+		Kind:        "tenant.event",
+		Queue:       "default",
+		Attempt:     2,
+		MaxAttempts: 25,
+		EncodedArgs: []byte(`{"event":"suspend","tenant_id":"ten_123","slug":"acme"}`),
+	}
+	if res := reporter.HandleError(context.Background(), job, errors.New("provisioning failed")); res != nil {
+		t.Errorf("result = %+v, want nil (keep retrying)", res)
+	}
+
+	event := transport.only(t)
+	assertTag(t, event, "job.kind", "tenant.event")
+	assertTag(t, event, "job.id", "42")
+	assertTag(t, event, "tenant.id", "ten_123")
+	assertTag(t, event, "tenant.slug", "acme")
+	if len(event.Exception) == 0 || event.Exception[len(event.Exception)-1].Value != "provisioning failed" {
+		t.Errorf("Exception = %+v, want the job error", event.Exception)
+	}
+}
+
+func TestHandlePanic_ReportsStack(t *testing.T) {
+	reporter, transport := newTestReporter(t)
+
+	job := &rivertype.JobRow{ID: 7, Kind: "tenant.spec_sync", EncodedArgs: []byte(`{"dry_run":false}`)}
+	reporter.HandlePanic(context.Background(), job, "nil map", "goroutine 1 [running]")
+
+	event := transport.only(t)
+	assertTag(t, event, "job.kind", "tenant.spec_sync")
+	if _, ok := event.Tags["tenant.id"]; ok {
+		t.Error("tenant.id should not be set for jobs without a tenant")
+	}
+	if event.Extra["stack"] != "goroutine 1 [running]" {
+		t.Errorf("stack extra = %v", event.Extra["stack"])
+	}
+}
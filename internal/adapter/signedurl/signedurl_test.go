@@ -0,0 +1,107 @@
+package signedurl_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/signedurl"
+	"github.com/neomorfeo/tenantiq/pkg/memory"
+)
+
+var testKey = []byte(strings.Repeat("k", signedurl.MinKeyLength))
+
+func newSigner(t *testing.T) *signedurl.Signer {
+	t.Helper()
+	s, err := signedurl.New(testKey, memory.NewNonceStore())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return s
+}
+
+func mustParse(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parse %q: %v", raw, err)
+	}
+	return u
+}
+
+func TestNew_RejectsShortKey(t *testing.T) {
+	if _, err := signedurl.New([]byte("short"), nil); err == nil {
+		t.Error("expected an error for a short key")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	s := newSigner(t)
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	signed, err := s.Sign("/public/reports/growth.csv?period=week", now.Add(time.Hour), false)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	u := mustParse(t, signed)
+	if u.Query().Get("period") != "week" {
+		t.Fatalf("signed URL %q lost the original query", signed)
+	}
+
+	if err := s.Verify(ctx, u, now); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	if err := s.Verify(ctx, u, now.Add(time.Hour)); !errors.Is(err, signedurl.ErrExpired) {
+		t.Errorf("at expiry: got %v, want ErrExpired", err)
+	}
+
+	tampered := mustParse(t, strings.Replace(signed, "period=week", "period=day", 1))
+	if err := s.Verify(ctx, tampered, now); !errors.Is(err, signedurl.ErrInvalidSignature) {
+		t.Errorf("tampered query: got %v, want ErrInvalidSignature", err)
+	}
+	moved := mustParse(t, strings.Replace(signed, "/public/reports/", "/public/other/", 1))
+	if err := s.Verify(ctx, moved, now); !errors.Is(err, signedurl.ErrInvalidSignature) {
+		t.Errorf("changed path: got %v, want ErrInvalidSignature", err)
+	}
+	if err := s.Verify(ctx, mustParse(t, "/public/reports/growth.csv"), now); !errors.Is(err, signedurl.ErrInvalidSignature) {
+		t.Errorf("unsigned: got %v, want ErrInvalidSignature", err)
+	}
+
+	other, _ := signedurl.New([]byte(strings.Repeat("x", signedurl.MinKeyLength)), nil)
+	if err := other.Verify(ctx, u, now); !errors.Is(err, signedurl.ErrInvalidSignature) {
+		t.Errorf("other key: got %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestVerify_SingleUse(t *testing.T) {
+	s := newSigner(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	signed, err := s.Sign("/public/tenants/ten_1/status", now.Add(time.Minute), true)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	u := mustParse(t, signed)
+
+	if err := s.Verify(ctx, u, now); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if err := s.Verify(ctx, u, now); !errors.Is(err, signedurl.ErrAlreadyUsed) {
+		t.Errorf("second use: got %v, want ErrAlreadyUsed", err)
+	}
+}
+
+func TestSign_Errors(t *testing.T) {
+	withoutStore, _ := signedurl.New(testKey, nil)
+	if _, err := withoutStore.Sign("/public/x", time.Now().Add(time.Minute), true); err == nil {
+		t.Error("single-use without a nonce store should fail")
+	}
+	if _, err := newSigner(t).Sign("/public/x?signature=forged", time.Now().Add(time.Minute), false); err == nil {
+		t.Error("signing a URL that already has a signature should fail")
+	}
+}
@@ -0,0 +1,110 @@
+// Package simulator provisions simulated tenants without touching any
+// infrastructure. Each step the real pipeline would take (Git branch and
+// pull request, DNS record, Kubernetes namespace) is logged, and the
+// references it would produce are made up under a reserved domain, so
+// simulated tenants look like provisioned ones without existing anywhere.
+package simulator
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: Provisioner implements domain.Provisioner.
+var _ domain.Provisioner = (*Provisioner)(nil)
+
+// DefaultDomain is the domain of the made-up references. The .invalid
+// top-level domain is reserved, so they can never resolve.
+const DefaultDomain = "simulated.invalid"
+
+// Provisioner implements domain.Provisioner with fake steps.
+type Provisioner struct {
+	domain string
+	delay  time.Duration
+}
+
+// Option configures a Provisioner.
+type Option func(*Provisioner)
+
+// WithDomain sets the domain of the made-up references.
+func WithDomain(d string) Option {
+	return func(p *Provisioner) { p.domain = d }
+}
+
+// WithDelay makes every step take d, to exercise flows with realistic
+// timing (e.g. polling an operation). The context cancels the wait.
+func WithDelay(d time.Duration) Option {
+	return func(p *Provisioner) { p.delay = d }
+}
+
+// New creates a fake provisioner.
+func New(opts ...Option) *Provisioner {
+	p := &Provisioner{domain: DefaultDomain}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Provision pretends to open the tenant's provisioning pull request,
+// create its DNS record and apply its Kubernetes namespace.
+func (p *Provisioner) Provision(ctx context.Context, tenant domain.Tenant) (domain.Provisioning, error) {
+	branch := "tenant/" + tenant.Slug
+	host := tenant.Slug + "." + p.domain
+	namespace := "tenant-" + tenant.Slug
+
+	for _, step := range []struct{ name, target string }{
+		{"git", branch},
+		{"dns", host},
+		{"kubernetes", namespace},
+	} {
+		if err := p.step(ctx, tenant, "provision", step.name, step.target); err != nil {
+			return domain.Provisioning{}, err
+		}
+	}
+	return domain.Provisioning{
+		PRURL:     "https://git." + p.domain + "/tenants/pull/" + tenant.ID,
+		GitBranch: branch,
+		ExternalRefs: map[string]string{
+			"dns":       host,
+			"namespace": namespace,
+		},
+	}, nil
+}
+
+// Deprovision pretends to tear down what Provision set up, in reverse.
+func (p *Provisioner) Deprovision(ctx context.Context, tenant domain.Tenant) error {
+	for _, step := range []struct{ name, target string }{
+		{"kubernetes", "tenant-" + tenant.Slug},
+		{"dns", tenant.Slug + "." + p.domain},
+		{"git", "tenant/" + tenant.Slug},
+	} {
+		if err := p.step(ctx, tenant, "deprovision", step.name, step.target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// step logs a fake step, after the configured delay.
+func (p *Provisioner) step(ctx context.Context, tenant domain.Tenant, action, system, target string) error {
+	if p.delay > 0 {
+		timer := time.NewTimer(p.delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	slog.InfoContext(ctx, "simulated provisioning step",
+		"action", action,
+		"system", system,
+		"target", target,
+		"tenant_id", tenant.ID,
+	)
+	return nil
+}
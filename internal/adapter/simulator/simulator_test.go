@@ -0,0 +1,39 @@
+package simulator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/simulator"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestProvision(t *testing.T) {
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "free")
+
+	p, err := simulator.New().Provision(context.Background(), tenant)
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	if p.GitBranch != "tenant/acme" || p.PRURL != "https://git.simulated.invalid/tenants/pull/ten_1" {
+		t.Errorf("Git references = %q, %q", p.GitBranch, p.PRURL)
+	}
+	if p.ExternalRefs["dns"] != "acme.simulated.invalid" || p.ExternalRefs["namespace"] != "tenant-acme" {
+		t.Errorf("external refs = %v", p.ExternalRefs)
+	}
+	if err := simulator.New().Deprovision(context.Background(), tenant); err != nil {
+		t.Errorf("Deprovision: %v", err)
+	}
+}
+
+func TestProvision_DelayHonoursCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := simulator.New(simulator.WithDelay(time.Hour)).Provision(ctx, domain.NewTenant("ten_1", "Acme", "acme", "free"))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Provision = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,119 @@
+package specdir
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: Source implements domain.SpecSource.
+var _ domain.SpecSource = (*Source)(nil)
+
+// specFile is the on-disk representation of a tenant spec.
+type specFile struct {
+	Slug     string            `yaml:"slug"`
+	Name     string            `yaml:"name"`
+	Plan     string            `yaml:"plan"`
+	Status   string            `yaml:"status"`
+	Metadata map[string]string `yaml:"metadata"`
+	Features map[string]bool   `yaml:"features"`
+	Domains  []string          `yaml:"domains"`
+}
+
+// Source reads tenant specs from YAML files in a directory, typically a
+// checkout of a Git repository kept up to date by an external process
+// (git-sync sidecar, CI job, etc.). Each *.yaml/*.yml file may contain one
+// or more YAML documents, one tenant per document. Hidden directories such
+// as .git are skipped.
+type Source struct {
+	dir string
+}
+
+// New creates a spec source rooted at dir.
+func New(dir string) *Source {
+	return &Source{dir: dir}
+}
+
+// Specs loads and validates every spec under the directory.
+func (s *Source) Specs(_ context.Context) ([]domain.TenantSpec, error) {
+	var specs []domain.TenantSpec
+	seen := make(map[string]string)
+
+	err := filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != s.dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(path)
+		if ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+
+		fileSpecs, err := readFile(path)
+		if err != nil {
+			return err
+		}
+		for _, spec := range fileSpecs {
+			if prev, dup := seen[spec.Slug]; dup {
+				return fmt.Errorf("%s: slug %q already declared in %s", path, spec.Slug, prev)
+			}
+			seen[spec.Slug] = path
+			specs = append(specs, spec)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading specs from %s: %w", s.dir, err)
+	}
+
+	return specs, nil
+}
+
+func readFile(path string) ([]domain.TenantSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var specs []domain.TenantSpec
+	for {
+		var f specFile
+		if err := dec.Decode(&f); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if f.Slug == "" {
+			return nil, fmt.Errorf("%s: slug is required", path)
+		}
+		specs = append(specs, domain.TenantSpec{
+			Slug:     f.Slug,
+			Name:     f.Name,
+			Plan:     f.Plan,
+			Status:   domain.Status(f.Status),
+			Metadata: f.Metadata,
+			Features: f.Features,
+			Domains:  f.Domains,
+		})
+	}
+	return specs, nil
+}
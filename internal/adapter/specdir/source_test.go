@@ -0,0 +1,72 @@
+package specdir_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/specdir"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+func TestSpecs_LoadsNestedFilesAndDocuments(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "acme.yaml", "slug: acme\nname: Acme\nplan: pro\nstatus: active\n")
+	writeFile(t, dir, "eu/multi.yml", "slug: globex\nname: Globex\n---\nslug: initech\nname: Initech\n")
+	writeFile(t, dir, ".git/config.yaml", "not: a spec\n")
+	writeFile(t, dir, "README.md", "# tenants\n")
+
+	specs, err := specdir.New(dir).Specs(context.Background())
+	if err != nil {
+		t.Fatalf("Specs failed: %v", err)
+	}
+
+	if len(specs) != 3 {
+		t.Fatalf("got %d specs, want 3", len(specs))
+	}
+	if specs[0].Slug != "acme" || specs[0].Plan != "pro" || specs[0].Status != domain.StatusActive {
+		t.Errorf("specs[0] = %+v", specs[0])
+	}
+}
+
+func TestSpecs_DuplicateSlug(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.yaml", "slug: acme\nname: Acme\n")
+	writeFile(t, dir, "b.yaml", "slug: acme\nname: Acme again\n")
+
+	_, err := specdir.New(dir).Specs(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "already declared") {
+		t.Fatalf("expected duplicate slug error, got %v", err)
+	}
+}
+
+func TestSpecs_UnknownField(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.yaml", "slug: acme\nnmae: Acme\n")
+
+	if _, err := specdir.New(dir).Specs(context.Background()); err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+}
+
+func TestSpecs_MissingSlug(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.yaml", "name: Acme\n")
+
+	if _, err := specdir.New(dir).Specs(context.Background()); err == nil {
+		t.Fatal("expected error for missing slug")
+	}
+}
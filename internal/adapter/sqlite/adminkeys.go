@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: AdminKeyRepository implements domain.AdminKeyRepository.
+var _ domain.AdminKeyRepository = (*AdminKeyRepository)(nil)
+
+// AdminKeyRepository implements domain.AdminKeyRepository using SQLite.
+// Keys are looked up by their unique hash.
+type AdminKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAdminKeyRepository wraps a database already migrated by New or NewFromDB.
+func NewAdminKeyRepository(db *sql.DB) *AdminKeyRepository {
+	return &AdminKeyRepository{db: db}
+}
+
+const adminKeyColumns = `id, name, prefix, hash, role, created_by, created_at, last_used_at, revoked_at`
+
+func (r *AdminKeyRepository) Create(ctx context.Context, k domain.AdminKey) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO admin_api_keys (`+adminKeyColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		k.ID, k.Name, k.Prefix, k.Hash, string(k.Role), k.CreatedBy, k.CreatedAt.UTC().Format(timeFormat),
+		formatOptionalTime(k.LastUsedAt), formatOptionalTime(k.RevokedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting admin key: %w", err)
+	}
+	return nil
+}
+
+func (r *AdminKeyRepository) GetByHash(ctx context.Context, hash string) (domain.AdminKey, error) {
+	k, err := scanAdminKey(r.db.QueryRowContext(ctx,
+		`SELECT `+adminKeyColumns+` FROM admin_api_keys WHERE hash = ?`, hash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.AdminKey{}, domain.ErrAdminKeyNotFound
+		}
+		return domain.AdminKey{}, fmt.Errorf("scanning admin key: %w", err)
+	}
+	return k, nil
+}
+
+func (r *AdminKeyRepository) List(ctx context.Context) ([]domain.AdminKey, error) {
+	// rowid breaks ties between keys created within the same second.
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+adminKeyColumns+` FROM admin_api_keys ORDER BY created_at DESC, rowid DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("querying admin keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []domain.AdminKey
+	for rows.Next() {
+		k, err := scanAdminKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning admin key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+func (r *AdminKeyRepository) Revoke(ctx context.Context, id string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE admin_api_keys SET revoked_at = CASE WHEN revoked_at = '' THEN ? ELSE revoked_at END
+		 WHERE id = ?`,
+		at.UTC().Format(timeFormat), id,
+	)
+	if err != nil {
+		return fmt.Errorf("revoking admin key: %w", err)
+	}
+	return requireRow(result, domain.ErrAdminKeyNotFound)
+}
+
+func (r *AdminKeyRepository) Touch(ctx context.Context, id string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE admin_api_keys SET last_used_at = ? WHERE id = ?`, at.UTC().Format(timeFormat), id)
+	if err != nil {
+		return fmt.Errorf("touching admin key: %w", err)
+	}
+	return requireRow(result, domain.ErrAdminKeyNotFound)
+}
+
+func scanAdminKey(row rowScanner) (domain.AdminKey, error) {
+	var (
+		k                                      domain.AdminKey
+		role, createdAt, lastUsedAt, revokedAt string
+	)
+	err := row.Scan(&k.ID, &k.Name, &k.Prefix, &k.Hash, &role, &k.CreatedBy, &createdAt, &lastUsedAt, &revokedAt)
+	if err != nil {
+		return domain.AdminKey{}, err
+	}
+	k.Role = domain.Role(role)
+	k.CreatedAt, _ = time.Parse(timeFormat, createdAt)
+	k.LastUsedAt, _ = time.Parse(timeFormat, lastUsedAt) // Zero when empty.
+	k.RevokedAt, _ = time.Parse(timeFormat, revokedAt)
+	return k, nil
+}
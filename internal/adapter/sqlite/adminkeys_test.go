@@ -0,0 +1,60 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestAdminKeys(t *testing.T) {
+	keys := sqlite.NewAdminKeyRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	alice, _ := domain.NewAdminKey("adk_1", "alice", "tqa_secret1", domain.RoleAdmin, "cli")
+	terraform, _ := domain.NewAdminKey("adk_2", "terraform", "tqa_secret2", domain.RoleViewer, "alice")
+	for _, k := range []domain.AdminKey{alice, terraform} {
+		if err := keys.Create(ctx, k); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	got, err := keys.GetByHash(ctx, domain.HashAPIKey("tqa_secret1"))
+	if err != nil {
+		t.Fatalf("GetByHash: %v", err)
+	}
+	if got.ID != "adk_1" || got.Name != "alice" || got.Prefix != "tqa_secret1" || got.CreatedBy != "cli" || got.Role != domain.RoleAdmin || !got.Active() {
+		t.Errorf("GetByHash = %+v, want the active key of alice", got)
+	}
+	if _, err := keys.GetByHash(ctx, domain.HashAPIKey("tqa_other")); !errors.Is(err, domain.ErrAdminKeyNotFound) {
+		t.Errorf("GetByHash of an unknown key = %v, want ErrAdminKeyNotFound", err)
+	}
+
+	used := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := keys.Touch(ctx, "adk_2", used); err != nil {
+		t.Fatalf("Touch: %v", err)
+	}
+	if err := keys.Revoke(ctx, "adk_2", used.Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if err := keys.Revoke(ctx, "adk_2", used.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Revoke again: %v", err)
+	}
+	if err := keys.Revoke(ctx, "adk_missing", used); !errors.Is(err, domain.ErrAdminKeyNotFound) {
+		t.Errorf("Revoke of an unknown key = %v, want ErrAdminKeyNotFound", err)
+	}
+
+	list, err := keys.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "adk_2" || list[1].ID != "adk_1" {
+		t.Fatalf("List = %+v, want adk_2 then adk_1", list)
+	}
+	if !list[0].LastUsedAt.Equal(used) || !list[0].RevokedAt.Equal(used.Add(time.Hour)) {
+		t.Errorf("adk_2 = %+v, want used at %s and revoked an hour later", list[0], used)
+	}
+}
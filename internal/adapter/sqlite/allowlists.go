@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: IPAllowlistRepository implements domain.IPAllowlistRepository.
+var _ domain.IPAllowlistRepository = (*IPAllowlistRepository)(nil)
+
+// IPAllowlistRepository implements domain.IPAllowlistRepository using
+// SQLite, one row per network of a tenant. It shares the tenants database,
+// whose migrations create its table.
+type IPAllowlistRepository struct {
+	db *sql.DB
+}
+
+// NewIPAllowlistRepository wraps a database already migrated by New or
+// NewFromDB.
+func NewIPAllowlistRepository(db *sql.DB) *IPAllowlistRepository {
+	return &IPAllowlistRepository{db: db}
+}
+
+func (r *IPAllowlistRepository) Get(ctx context.Context, tenantID string) (domain.IPAllowlist, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT cidr FROM tenant_ip_allowlists WHERE tenant_id = ?`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("querying IP allowlist: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []string
+	for rows.Next() {
+		var cidr string
+		if err := rows.Scan(&cidr); err != nil {
+			return nil, fmt.Errorf("scanning IP allowlist: %w", err)
+		}
+		entries = append(entries, cidr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// Parsing sorts the networks the way they were validated.
+	return domain.ParseIPAllowlist(entries)
+}
+
+func (r *IPAllowlistRepository) Set(ctx context.Context, tenantID string, l domain.IPAllowlist) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // no-op after Commit
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tenant_ip_allowlists WHERE tenant_id = ?`, tenantID); err != nil {
+		return fmt.Errorf("clearing IP allowlist: %w", err)
+	}
+	now := time.Now().UTC().Format(timeFormat)
+	for _, p := range l {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO tenant_ip_allowlists (tenant_id, cidr, created_at) VALUES (?, ?, ?)`,
+			tenantID, p.String(), now)
+		if err != nil {
+			return fmt.Errorf("inserting IP allowlist: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
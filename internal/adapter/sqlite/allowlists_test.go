@@ -0,0 +1,44 @@
+package sqlite_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestIPAllowlists_SetGet(t *testing.T) {
+	repo := sqlite.NewIPAllowlistRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	if got, err := repo.Get(ctx, "ten_1"); err != nil || len(got) != 0 {
+		t.Fatalf("Get without a list = %v, %v; want empty", got, err)
+	}
+
+	first, _ := domain.ParseIPAllowlist([]string{"198.51.100.0/24"})
+	want, _ := domain.ParseIPAllowlist([]string{"203.0.113.0/24", "2001:db8::/32"})
+	for _, l := range []domain.IPAllowlist{first, want} {
+		if err := repo.Set(ctx, "ten_1", l); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+	}
+	if err := repo.Set(ctx, "ten_2", first); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	if got, err := repo.Get(ctx, "ten_1"); err != nil || !slices.Equal(got, want) {
+		t.Errorf("Get = %v, %v; want %v", got, err, want)
+	}
+
+	if err := repo.Set(ctx, "ten_1", nil); err != nil {
+		t.Fatalf("clearing failed: %v", err)
+	}
+	if got, _ := repo.Get(ctx, "ten_1"); len(got) != 0 {
+		t.Errorf("Get after clearing = %v, want empty", got)
+	}
+	if got, _ := repo.Get(ctx, "ten_2"); !slices.Equal(got, first) {
+		t.Errorf("Get of another tenant = %v, want %v", got, first)
+	}
+}
@@ -0,0 +1,133 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: APIKeyRepository implements domain.APIKeyRepository.
+var _ domain.APIKeyRepository = (*APIKeyRepository)(nil)
+
+// APIKeyRepository implements domain.APIKeyRepository using SQLite. Keys
+// are looked up by their unique hash; scopes are stored as a JSON array.
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository wraps a database already migrated by New or NewFromDB.
+func NewAPIKeyRepository(db *sql.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+const apiKeyColumns = `id, tenant_id, name, prefix, hash, scopes, created_by, created_at, expires_at, last_used_at, revoked_at`
+
+func (r *APIKeyRepository) Create(ctx context.Context, k domain.APIKey) error {
+	scopes := k.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	encoded, err := json.Marshal(scopes)
+	if err != nil {
+		return fmt.Errorf("encoding API key scopes: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO tenant_api_keys (`+apiKeyColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		k.ID, k.TenantID, k.Name, k.Prefix, k.Hash, string(encoded), k.CreatedBy,
+		k.CreatedAt.UTC().Format(timeFormat), formatOptionalTime(k.ExpiresAt),
+		formatOptionalTime(k.LastUsedAt), formatOptionalTime(k.RevokedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting API key: %w", err)
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) Get(ctx context.Context, tenantID, id string) (domain.APIKey, error) {
+	return r.get(ctx, `SELECT `+apiKeyColumns+` FROM tenant_api_keys WHERE tenant_id = ? AND id = ?`, tenantID, id)
+}
+
+func (r *APIKeyRepository) GetByHash(ctx context.Context, hash string) (domain.APIKey, error) {
+	return r.get(ctx, `SELECT `+apiKeyColumns+` FROM tenant_api_keys WHERE hash = ?`, hash)
+}
+
+func (r *APIKeyRepository) ListByTenant(ctx context.Context, tenantID string) ([]domain.APIKey, error) {
+	// rowid breaks ties between keys created within the same second.
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+apiKeyColumns+` FROM tenant_api_keys WHERE tenant_id = ? ORDER BY created_at DESC, rowid DESC`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("querying API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []domain.APIKey
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning API key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, tenantID, id string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE tenant_api_keys SET revoked_at = CASE WHEN revoked_at = '' THEN ? ELSE revoked_at END
+		 WHERE tenant_id = ? AND id = ?`,
+		at.UTC().Format(timeFormat), tenantID, id,
+	)
+	if err != nil {
+		return fmt.Errorf("revoking API key: %w", err)
+	}
+	return requireRow(result, domain.ErrAPIKeyNotFound)
+}
+
+func (r *APIKeyRepository) Touch(ctx context.Context, id string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE tenant_api_keys SET last_used_at = ? WHERE id = ?`, at.UTC().Format(timeFormat), id)
+	if err != nil {
+		return fmt.Errorf("touching API key: %w", err)
+	}
+	return requireRow(result, domain.ErrAPIKeyNotFound)
+}
+
+func (r *APIKeyRepository) get(ctx context.Context, query string, args ...any) (domain.APIKey, error) {
+	k, err := scanAPIKey(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.APIKey{}, domain.ErrAPIKeyNotFound
+		}
+		return domain.APIKey{}, fmt.Errorf("scanning API key: %w", err)
+	}
+	return k, nil
+}
+
+func scanAPIKey(row rowScanner) (domain.APIKey, error) {
+	var (
+		k                                           domain.APIKey
+		scopes                                      string
+		createdAt, expiresAt, lastUsedAt, revokedAt string
+	)
+	err := row.Scan(&k.ID, &k.TenantID, &k.Name, &k.Prefix, &k.Hash, &scopes, &k.CreatedBy,
+		&createdAt, &expiresAt, &lastUsedAt, &revokedAt)
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+	if err := json.Unmarshal([]byte(scopes), &k.Scopes); err != nil {
+		return domain.APIKey{}, fmt.Errorf("decoding API key scopes: %w", err)
+	}
+	if len(k.Scopes) == 0 {
+		k.Scopes = nil
+	}
+	k.CreatedAt, _ = time.Parse(timeFormat, createdAt)
+	k.ExpiresAt, _ = time.Parse(timeFormat, expiresAt) // Zero when empty.
+	k.LastUsedAt, _ = time.Parse(timeFormat, lastUsedAt)
+	k.RevokedAt, _ = time.Parse(timeFormat, revokedAt)
+	return k, nil
+}
@@ -0,0 +1,66 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestAPIKeys(t *testing.T) {
+	keys := sqlite.NewAPIKeyRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+	expires := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Second)
+
+	ci, _ := domain.NewAPIKey("key_1", "ten_1", "CI", "tqk_secret1", []string{"tenants:read"}, time.Time{}, "alice")
+	deploy, _ := domain.NewAPIKey("key_2", "ten_1", "Deploy", "tqk_secret2", nil, expires, "alice")
+	for _, k := range []domain.APIKey{ci, deploy} {
+		if err := keys.Create(ctx, k); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	got, err := keys.GetByHash(ctx, domain.HashAPIKey("tqk_secret1"))
+	if err != nil {
+		t.Fatalf("GetByHash: %v", err)
+	}
+	if got.ID != "key_1" || fmt.Sprint(got.Scopes) != "[tenants:read]" || !got.ExpiresAt.IsZero() || got.CreatedBy != "alice" {
+		t.Errorf("GetByHash = %+v, want key_1 with scope tenants:read and no expiry", got)
+	}
+	if _, err := keys.GetByHash(ctx, domain.HashAPIKey("tqk_other")); !errors.Is(err, domain.ErrAPIKeyNotFound) {
+		t.Errorf("GetByHash of an unknown key = %v, want ErrAPIKeyNotFound", err)
+	}
+
+	used := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := keys.Touch(ctx, "key_2", used); err != nil {
+		t.Fatalf("Touch: %v", err)
+	}
+	if err := keys.Revoke(ctx, "ten_1", "key_2", used.Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if err := keys.Revoke(ctx, "ten_1", "key_2", used.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Revoke again: %v", err)
+	}
+	got, err = keys.Get(ctx, "ten_1", "key_2")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !got.LastUsedAt.Equal(used) || !got.RevokedAt.Equal(used.Add(time.Hour)) || !got.ExpiresAt.Equal(expires) {
+		t.Errorf("Get = %+v, want used at %s and revoked an hour later", got, used)
+	}
+	if err := keys.Revoke(ctx, "ten_2", "key_2", used); !errors.Is(err, domain.ErrAPIKeyNotFound) {
+		t.Errorf("Revoke from another tenant = %v, want ErrAPIKeyNotFound", err)
+	}
+
+	list, err := keys.ListByTenant(ctx, "ten_1")
+	if err != nil {
+		t.Fatalf("ListByTenant: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "key_2" || list[1].ID != "key_1" {
+		t.Errorf("ListByTenant = %+v, want key_2 then key_1", list)
+	}
+}
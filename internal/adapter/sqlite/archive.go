@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time checks: the append-only tables can be archived.
+var (
+	_ domain.Archiver = (*AuditLog)(nil)
+	_ domain.Archiver = (*StatusHistoryRepository)(nil)
+)
+
+// Reads of the archived tables go through these, which add the archived
+// rows to the hot ones. Archiving keeps the rows' IDs, so they sort as they
+// did before.
+const (
+	auditLogWithArchive      = `(SELECT * FROM audit_log UNION ALL SELECT * FROM audit_log_archive)`
+	statusHistoryWithArchive = `(SELECT * FROM tenant_status_history UNION ALL SELECT * FROM tenant_status_history_archive)`
+)
+
+// Archive moves the audit entries recorded before the cutoff to
+// audit_log_archive.
+func (l *AuditLog) Archive(ctx context.Context, before time.Time, dryRun bool) (int, error) {
+	return archive(ctx, l.db, "audit_log", "created_at < ?", before, dryRun)
+}
+
+// Archive moves the status changes made before the cutoff to
+// tenant_status_history_archive.
+func (r *StatusHistoryRepository) Archive(ctx context.Context, before time.Time, dryRun bool) (int, error) {
+	return archive(ctx, r.db, "tenant_status_history", "changed_at < ?", before, dryRun)
+}
+
+// archive moves, or with dryRun counts, the rows of table matching where
+// to its archive table, whose columns mirror it. The copy and the delete
+// share a transaction, so a row is never lost or in both tables.
+func archive(ctx context.Context, db *sql.DB, table, where string, before time.Time, dryRun bool) (int, error) {
+	if dryRun {
+		return prune(ctx, db, table, where, before, true)
+	}
+	cutoff := before.UTC().Format(timeFormat)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // no-op after Commit
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO `+table+`_archive SELECT * FROM `+table+` WHERE `+where, cutoff); err != nil {
+		return 0, fmt.Errorf("archiving %s: %w", table, err)
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE `+where, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("deleting archived %s rows: %w", table, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("checking rows affected: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+	return int(n), nil
+}
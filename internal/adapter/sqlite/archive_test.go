@@ -0,0 +1,96 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestStatusHistory_Archive(t *testing.T) {
+	db := newTestRepo(t).DB()
+	history := sqlite.NewStatusHistoryRepository(db)
+	ctx := context.Background()
+	cutoff := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, at := range []time.Time{cutoff.Add(-48 * time.Hour), cutoff.Add(-time.Second), cutoff} {
+		c := domain.StatusChange{TenantID: "ten_1", From: domain.StatusActive, To: domain.StatusSuspended, Event: domain.EventSuspend, At: at}
+		if err := history.Record(ctx, c); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	n, err := history.Archive(ctx, cutoff, true)
+	if err != nil || n != 2 {
+		t.Fatalf("dry-run Archive = %d, %v; want 2", n, err)
+	}
+	if got, _ := history.ListByTenant(ctx, "ten_1"); len(got) != 3 {
+		t.Fatalf("dry run moved changes: %d left", len(got))
+	}
+
+	n, err = history.Archive(ctx, cutoff, false)
+	if err != nil || n != 2 {
+		t.Fatalf("Archive = %d, %v; want 2", n, err)
+	}
+	var hot, archived int
+	if err := db.QueryRowContext(ctx, `SELECT (SELECT COUNT(*) FROM tenant_status_history), (SELECT COUNT(*) FROM tenant_status_history_archive)`).Scan(&hot, &archived); err != nil {
+		t.Fatalf("counting changes: %v", err)
+	}
+	if hot != 1 || archived != 2 {
+		t.Errorf("%d hot and %d archived changes, want 1 and 2", hot, archived)
+	}
+
+	// The history still reads the archived changes, in order.
+	got, err := history.ListByTenant(ctx, "ten_1")
+	if err != nil || len(got) != 3 || !got[0].At.Equal(cutoff.Add(-48*time.Hour)) || !got[2].At.Equal(cutoff) {
+		t.Errorf("ListByTenant = %+v, %v; want the 3 changes, oldest first", got, err)
+	}
+	if got, err := history.ListSince(ctx, cutoff.Add(-time.Hour)); err != nil || len(got) != 2 {
+		t.Errorf("ListSince = %+v, %v; want the archived and the hot change", got, err)
+	}
+
+	// Retention reaches the archived changes too.
+	n, err = history.Prune(ctx, cutoff.Add(time.Hour), false)
+	if err != nil || n != 3 {
+		t.Fatalf("Prune = %d, %v; want the 2 archived and the hot change", n, err)
+	}
+}
+
+func TestAuditLog_Archive(t *testing.T) {
+	db := newTestRepo(t).DB()
+	audit := sqlite.NewAuditLog(db)
+	ctx := context.Background()
+
+	created := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "free")
+	if err := audit.Log(ctx, domain.AuditEntry{Action: domain.AuditCreate, TenantID: "ten_1", Actor: "alice", After: &tenant, At: created}); err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+
+	n, err := audit.Archive(ctx, time.Now().UTC(), false)
+	if err != nil || n != 1 {
+		t.Fatalf("Archive = %d, %v; want 1", n, err)
+	}
+	var hot, archived int
+	if err := db.QueryRowContext(ctx, `SELECT (SELECT COUNT(*) FROM audit_log), (SELECT COUNT(*) FROM audit_log_archive WHERE actor = 'alice')`).Scan(&hot, &archived); err != nil {
+		t.Fatalf("counting entries: %v", err)
+	}
+	if hot != 0 || archived != 1 {
+		t.Errorf("%d hot and %d archived entries, want 0 and 1", hot, archived)
+	}
+
+	// The archived entry is still read as of its time and in the feed.
+	got, err := audit.TenantAsOf(ctx, "ten_1", created)
+	if err != nil || got.Slug != "acme" {
+		t.Errorf("TenantAsOf = %+v, %v; want the archived snapshot", got, err)
+	}
+	changes, err := audit.ChangesAfter(ctx, 0, 10)
+	if err != nil || len(changes) != 1 || changes[0].Tenant == nil || changes[0].Tenant.ID != "ten_1" {
+		t.Errorf("ChangesAfter = %+v, %v; want the archived change", changes, err)
+	}
+	if oldest, latest, err := audit.Cursors(ctx); err != nil || oldest != 0 || latest != 1 {
+		t.Errorf("Cursors = %d, %d, %v; want 0 and 1", oldest, latest, err)
+	}
+}
@@ -0,0 +1,151 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time checks: AuditLog implements domain.AuditLogger and domain.AuditReader.
+var (
+	_ domain.AuditLogger = (*AuditLog)(nil)
+	_ domain.AuditReader = (*AuditLog)(nil)
+)
+
+// AuditLog implements domain.AuditLogger using SQLite. Entries are
+// append-only; tenant snapshots are stored as JSON so the table does not
+// need to follow every change to the tenant schema.
+type AuditLog struct {
+	db *sql.DB
+}
+
+// NewAuditLog wraps a database already migrated by New or NewFromDB.
+func NewAuditLog(db *sql.DB) *AuditLog {
+	return &AuditLog{db: db}
+}
+
+// auditSnapshot is the stored JSON form of a tenant.
+type auditSnapshot struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	Slug          string            `json:"slug"`
+	Status        domain.Status     `json:"status"`
+	Plan          string            `json:"plan"`
+	PRURL         string            `json:"pr_url,omitempty"`
+	GitBranch     string            `json:"git_branch,omitempty"`
+	ExternalRefs  map[string]string `json:"external_refs,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	Tags          []string          `json:"tags,omitempty"`
+	ResellerID    string            `json:"reseller_id,omitempty"`
+	SuggestedPlan string            `json:"suggested_plan,omitempty"`
+	TrialEndsAt   time.Time         `json:"trial_ends_at,omitzero"`
+	Region        string            `json:"region,omitempty"`
+	Simulated     bool              `json:"simulated,omitempty"`
+	Version       int               `json:"version,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+func (l *AuditLog) Log(ctx context.Context, e domain.AuditEntry) error {
+	before, err := marshalSnapshot(e.Before)
+	if err != nil {
+		return err
+	}
+	after, err := marshalSnapshot(e.After)
+	if err != nil {
+		return err
+	}
+
+	_, err = l.db.ExecContext(ctx,
+		`INSERT INTO audit_log (action, tenant_id, actor, request_id, subject, issuer, client_cn, event, before, after, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		string(e.Action), e.TenantID, e.Actor, e.RequestID, e.Subject, e.Issuer, e.ClientCN, string(e.Event), before, after, e.At.UTC().Format(timeFormat),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting audit entry: %w", err)
+	}
+	return nil
+}
+
+// TenantAsOf returns the snapshot left by the tenant's latest entry at or
+// before at, archived or not. Entries are stored with second precision.
+func (l *AuditLog) TenantAsOf(ctx context.Context, tenantID string, at time.Time) (domain.Tenant, error) {
+	var after sql.NullString
+	err := l.db.QueryRowContext(ctx,
+		`SELECT after FROM `+auditLogWithArchive+` WHERE tenant_id = ? AND created_at <= ? AND after IS NOT NULL
+		 ORDER BY created_at DESC, id DESC LIMIT 1`,
+		tenantID, at.UTC().Format(timeFormat),
+	).Scan(&after)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Tenant{}, domain.ErrTenantNotFound
+	}
+	if err != nil {
+		return domain.Tenant{}, fmt.Errorf("querying audit log: %w", err)
+	}
+
+	return unmarshalSnapshot(after.String)
+}
+
+// marshalSnapshot returns the JSON for t, or NULL when there is no snapshot.
+func marshalSnapshot(t *domain.Tenant) (sql.NullString, error) {
+	if t == nil {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(auditSnapshot{
+		ID:            t.ID,
+		Name:          t.Name,
+		Slug:          t.Slug,
+		Status:        t.Status,
+		Plan:          t.Plan,
+		PRURL:         t.PRURL,
+		GitBranch:     t.GitBranch,
+		ExternalRefs:  t.ExternalRefs,
+		Metadata:      t.Metadata,
+		Tags:          t.Tags,
+		ResellerID:    t.ResellerID,
+		SuggestedPlan: t.SuggestedPlan,
+		TrialEndsAt:   t.TrialEndsAt,
+		Region:        t.Region,
+		Simulated:     t.Simulated,
+		Version:       t.Version,
+		CreatedAt:     t.CreatedAt,
+		UpdatedAt:     t.UpdatedAt,
+	})
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("encoding tenant snapshot: %w", err)
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// unmarshalSnapshot decodes a tenant stored by marshalSnapshot.
+func unmarshalSnapshot(data string) (domain.Tenant, error) {
+	var snap auditSnapshot
+	if err := json.Unmarshal([]byte(data), &snap); err != nil {
+		return domain.Tenant{}, fmt.Errorf("decoding tenant snapshot: %w", err)
+	}
+	return domain.Tenant{
+		ID:            snap.ID,
+		Name:          snap.Name,
+		Slug:          snap.Slug,
+		Status:        snap.Status,
+		Plan:          snap.Plan,
+		PRURL:         snap.PRURL,
+		GitBranch:     snap.GitBranch,
+		ExternalRefs:  snap.ExternalRefs,
+		Metadata:      snap.Metadata,
+		Tags:          snap.Tags,
+		ResellerID:    snap.ResellerID,
+		SuggestedPlan: snap.SuggestedPlan,
+		TrialEndsAt:   snap.TrialEndsAt,
+		Region:        snap.Region,
+		Simulated:     snap.Simulated,
+		Version:       snap.Version,
+		CreatedAt:     snap.CreatedAt,
+		UpdatedAt:     snap.UpdatedAt,
+	}, nil
+}
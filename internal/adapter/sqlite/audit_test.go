@@ -0,0 +1,116 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestAuditLog_Log(t *testing.T) {
+	db := newTestRepo(t).DB()
+	log := sqlite.NewAuditLog(db)
+	ctx := domain.WithRequestID(domain.WithActor(context.Background(), "alice"), "req-1")
+	ctx = domain.WithClaims(ctx, domain.Claims{Subject: "user-42", Issuer: "https://id.example.com"})
+	ctx = domain.WithClientCN(ctx, "billing.internal")
+
+	before := domain.NewTenant("ten_1", "Acme", "acme", "free")
+	after := before
+	after.Status = domain.StatusSuspended
+
+	entries := []domain.AuditEntry{
+		domain.NewAuditEntry(ctx, domain.AuditCreate, nil, &before),
+		domain.NewAuditEntry(ctx, domain.AuditTransition, &before, &after),
+	}
+	entries[1].Event = domain.EventSuspend
+	for _, e := range entries {
+		if err := log.Log(ctx, e); err != nil {
+			t.Fatalf("Log failed: %v", err)
+		}
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT action, tenant_id, actor, request_id, subject, issuer, client_cn, event, before, after FROM audit_log ORDER BY id`)
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer rows.Close()
+
+	var got []map[string]any
+	for rows.Next() {
+		var (
+			action, tenantID, actor, requestID, subject, issuer, clientCN, event string
+			beforeJSON, afterJSON                                                sql.NullString
+		)
+		if err := rows.Scan(&action, &tenantID, &actor, &requestID, &subject, &issuer, &clientCN, &event, &beforeJSON, &afterJSON); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		if tenantID != "ten_1" || actor != "alice" || requestID != "req-1" {
+			t.Errorf("row = %s/%s/%s, want ten_1/alice/req-1", tenantID, actor, requestID)
+		}
+		if subject != "user-42" || issuer != "https://id.example.com" {
+			t.Errorf("identity = %s/%s, want the token's subject and issuer", subject, issuer)
+		}
+		if clientCN != "billing.internal" {
+			t.Errorf("client_cn = %q, want the client certificate's common name", clientCN)
+		}
+		got = append(got, map[string]any{"action": action, "event": event, "before": beforeJSON, "after": afterJSON})
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d rows, want 2", len(got))
+	}
+
+	if b := got[0]["before"].(sql.NullString); b.Valid {
+		t.Errorf("create before = %q, want NULL", b.String)
+	}
+	if got[1]["action"] != "transition" || got[1]["event"] != "suspend" {
+		t.Errorf("second row = %v, want suspend transition", got[1])
+	}
+
+	var snapshot struct {
+		Slug   string `json:"slug"`
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal([]byte(got[1]["after"].(sql.NullString).String), &snapshot); err != nil {
+		t.Fatalf("decode after: %v", err)
+	}
+	if snapshot.Slug != "acme" || snapshot.Status != "suspended" {
+		t.Errorf("after snapshot = %+v, want acme suspended", snapshot)
+	}
+}
+
+func TestAuditLog_TenantAsOf(t *testing.T) {
+	log := sqlite.NewAuditLog(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	created := domain.NewTenant("ten_1", "Acme", "acme", "free")
+	suspended := created
+	suspended.Status = domain.StatusSuspended
+
+	t0 := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	entries := []domain.AuditEntry{
+		{Action: domain.AuditCreate, TenantID: "ten_1", After: &created, At: t0},
+		{Action: domain.AuditTransition, TenantID: "ten_1", Before: &created, After: &suspended, At: t0.Add(24 * time.Hour)},
+	}
+	for _, e := range entries {
+		if err := log.Log(ctx, e); err != nil {
+			t.Fatalf("Log failed: %v", err)
+		}
+	}
+
+	if _, err := log.TenantAsOf(ctx, "ten_1", t0.Add(-time.Second)); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("before creation: err = %v, want ErrTenantNotFound", err)
+	}
+	got, err := log.TenantAsOf(ctx, "ten_1", t0.Add(time.Hour))
+	if err != nil || got.Status != domain.StatusCreating || got.Slug != "acme" {
+		t.Errorf("after creation = %+v, %v; want creating acme", got, err)
+	}
+	got, err = log.TenantAsOf(ctx, "ten_1", t0.Add(24*time.Hour))
+	if err != nil || got.Status != domain.StatusSuspended {
+		t.Errorf("at suspension = %+v, %v; want suspended", got.Status, err)
+	}
+}
@@ -0,0 +1,160 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: BlueprintRepository implements domain.BlueprintRepository.
+var _ domain.BlueprintRepository = (*BlueprintRepository)(nil)
+
+// BlueprintRepository implements domain.BlueprintRepository using SQLite.
+// Metadata, features and variables are stored as JSON objects.
+type BlueprintRepository struct {
+	db *sql.DB
+}
+
+// NewBlueprintRepository wraps a database already migrated by New or NewFromDB.
+func NewBlueprintRepository(db *sql.DB) *BlueprintRepository {
+	return &BlueprintRepository{db: db}
+}
+
+const blueprintColumns = `name, plan, metadata, features, variables, created_at, updated_at`
+
+func (r *BlueprintRepository) Create(ctx context.Context, b domain.Blueprint) error {
+	metadata, features, variables, err := encodeBlueprint(b)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO blueprints (`+blueprintColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		b.Name, b.Plan, metadata, features, variables, b.CreatedAt.Format(timeFormat), b.UpdatedAt.Format(timeFormat),
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return &domain.BlueprintConflictError{Name: b.Name}
+		}
+		return fmt.Errorf("inserting blueprint: %w", err)
+	}
+	return nil
+}
+
+func (r *BlueprintRepository) Get(ctx context.Context, name string) (domain.Blueprint, error) {
+	b, err := scanBlueprint(r.db.QueryRowContext(ctx,
+		`SELECT `+blueprintColumns+` FROM blueprints WHERE name = ?`, name,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Blueprint{}, domain.ErrBlueprintNotFound
+		}
+		return domain.Blueprint{}, fmt.Errorf("scanning blueprint: %w", err)
+	}
+	return b, nil
+}
+
+func (r *BlueprintRepository) List(ctx context.Context) ([]domain.Blueprint, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+blueprintColumns+` FROM blueprints ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("querying blueprints: %w", err)
+	}
+	defer rows.Close()
+
+	var blueprints []domain.Blueprint
+	for rows.Next() {
+		b, err := scanBlueprint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning blueprint: %w", err)
+		}
+		blueprints = append(blueprints, b)
+	}
+	return blueprints, rows.Err()
+}
+
+func (r *BlueprintRepository) Update(ctx context.Context, b domain.Blueprint) error {
+	metadata, features, variables, err := encodeBlueprint(b)
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE blueprints SET plan = ?, metadata = ?, features = ?, variables = ?, updated_at = ? WHERE name = ?`,
+		b.Plan, metadata, features, variables, b.UpdatedAt.Format(timeFormat), b.Name,
+	)
+	if err != nil {
+		return fmt.Errorf("updating blueprint: %w", err)
+	}
+	return requireRow(result, domain.ErrBlueprintNotFound)
+}
+
+func (r *BlueprintRepository) Delete(ctx context.Context, name string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM blueprints WHERE name = ?`, name)
+	if err != nil {
+		return fmt.Errorf("deleting blueprint: %w", err)
+	}
+	return requireRow(result, domain.ErrBlueprintNotFound)
+}
+
+func encodeBlueprint(b domain.Blueprint) (metadata, features, variables string, err error) {
+	m := b.Metadata
+	if m == nil {
+		m = map[string]string{}
+	}
+	f := b.Features
+	if f == nil {
+		f = map[string]bool{}
+	}
+	v := b.Variables
+	if v == nil {
+		v = map[string]string{}
+	}
+	mb, err := json.Marshal(m)
+	if err != nil {
+		return "", "", "", fmt.Errorf("encoding blueprint metadata: %w", err)
+	}
+	fb, err := json.Marshal(f)
+	if err != nil {
+		return "", "", "", fmt.Errorf("encoding blueprint features: %w", err)
+	}
+	vb, err := json.Marshal(v)
+	if err != nil {
+		return "", "", "", fmt.Errorf("encoding blueprint variables: %w", err)
+	}
+	return string(mb), string(fb), string(vb), nil
+}
+
+func scanBlueprint(row rowScanner) (domain.Blueprint, error) {
+	var (
+		b                             domain.Blueprint
+		metadata, features, variables string
+		createdAt, updatedAt          string
+	)
+	if err := row.Scan(&b.Name, &b.Plan, &metadata, &features, &variables, &createdAt, &updatedAt); err != nil {
+		return domain.Blueprint{}, err
+	}
+	if err := json.Unmarshal([]byte(metadata), &b.Metadata); err != nil {
+		return domain.Blueprint{}, fmt.Errorf("decoding blueprint metadata: %w", err)
+	}
+	if err := json.Unmarshal([]byte(features), &b.Features); err != nil {
+		return domain.Blueprint{}, fmt.Errorf("decoding blueprint features: %w", err)
+	}
+	if err := json.Unmarshal([]byte(variables), &b.Variables); err != nil {
+		return domain.Blueprint{}, fmt.Errorf("decoding blueprint variables: %w", err)
+	}
+	if len(b.Metadata) == 0 {
+		b.Metadata = nil
+	}
+	if len(b.Features) == 0 {
+		b.Features = nil
+	}
+	if len(b.Variables) == 0 {
+		b.Variables = nil
+	}
+	b.CreatedAt, _ = time.Parse(timeFormat, createdAt)
+	b.UpdatedAt, _ = time.Parse(timeFormat, updatedAt)
+	return b, nil
+}
@@ -0,0 +1,60 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestBlueprints_CRUD(t *testing.T) {
+	blueprints := sqlite.NewBlueprintRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	eu, err := domain.NewBlueprint("enterprise-eu", "enterprise",
+		map[string]string{"region": "eu"}, map[string]bool{"sso": true, "beta": false}, map[string]string{"replicas": "3"})
+	if err != nil {
+		t.Fatalf("NewBlueprint: %v", err)
+	}
+	if err := blueprints.Create(ctx, eu); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	var conflict *domain.BlueprintConflictError
+	if err := blueprints.Create(ctx, eu); !errors.As(err, &conflict) {
+		t.Errorf("second Create = %v, want *BlueprintConflictError", err)
+	}
+
+	got, err := blueprints.Get(ctx, "enterprise-eu")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Plan != "enterprise" || got.Metadata["region"] != "eu" || !got.Features["sso"] || got.Features["beta"] ||
+		len(got.Features) != 2 || got.Variables["replicas"] != "3" || got.CreatedAt.IsZero() {
+		t.Errorf("got %+v", got)
+	}
+
+	got.Plan = "pro"
+	got.Metadata, got.Features = nil, nil
+	if err := blueprints.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	all, err := blueprints.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(all) != 1 || all[0].Plan != "pro" || all[0].Metadata != nil || all[0].Features != nil || all[0].Variables["replicas"] != "3" {
+		t.Errorf("List = %+v, want the updated blueprint", all)
+	}
+
+	if err := blueprints.Delete(ctx, "enterprise-eu"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := blueprints.Get(ctx, "enterprise-eu"); !errors.Is(err, domain.ErrBlueprintNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrBlueprintNotFound", err)
+	}
+	if err := blueprints.Update(ctx, eu); !errors.Is(err, domain.ErrBlueprintNotFound) {
+		t.Errorf("Update of a missing blueprint = %v, want ErrBlueprintNotFound", err)
+	}
+}
@@ -0,0 +1,114 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: CertificateRepository implements domain.CertificateRepository.
+var _ domain.CertificateRepository = (*CertificateRepository)(nil)
+
+// CertificateRepository implements domain.CertificateRepository using
+// SQLite. A certificate never issued has empty validity times.
+type CertificateRepository struct {
+	db *sql.DB
+}
+
+// NewCertificateRepository wraps a database already migrated by New or NewFromDB.
+func NewCertificateRepository(db *sql.DB) *CertificateRepository {
+	return &CertificateRepository{db: db}
+}
+
+const certificateColumns = `domain, tenant_id, status, issuer, serial, not_before, not_after, renew_at, last_error, created_at, updated_at`
+
+func (r *CertificateRepository) Save(ctx context.Context, c domain.Certificate) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO certificates (`+certificateColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT (domain) DO UPDATE SET
+		 tenant_id = excluded.tenant_id, status = excluded.status, issuer = excluded.issuer, serial = excluded.serial,
+		 not_before = excluded.not_before, not_after = excluded.not_after, renew_at = excluded.renew_at,
+		 last_error = excluded.last_error, updated_at = excluded.updated_at`,
+		c.Domain, c.TenantID, string(c.Status), c.Issuer, c.Serial,
+		formatOptionalTime(c.NotBefore), formatOptionalTime(c.NotAfter), c.RenewAt.UTC().Format(timeFormat),
+		c.LastError, c.CreatedAt.UTC().Format(timeFormat), c.UpdatedAt.UTC().Format(timeFormat),
+	)
+	if err != nil {
+		return fmt.Errorf("saving certificate: %w", err)
+	}
+	return nil
+}
+
+func (r *CertificateRepository) Get(ctx context.Context, domainName string) (domain.Certificate, error) {
+	c, err := scanCertificate(r.db.QueryRowContext(ctx,
+		`SELECT `+certificateColumns+` FROM certificates WHERE domain = ?`, domainName,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Certificate{}, domain.ErrCertificateNotFound
+		}
+		return domain.Certificate{}, fmt.Errorf("scanning certificate: %w", err)
+	}
+	return c, nil
+}
+
+func (r *CertificateRepository) ListByTenant(ctx context.Context, tenantID string) ([]domain.Certificate, error) {
+	return r.list(ctx,
+		`SELECT `+certificateColumns+` FROM certificates WHERE tenant_id = ? ORDER BY domain`, tenantID)
+}
+
+func (r *CertificateRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]domain.Certificate, error) {
+	return r.list(ctx,
+		`SELECT `+certificateColumns+` FROM certificates WHERE renew_at <= ? ORDER BY renew_at, domain LIMIT ?`,
+		now.UTC().Format(timeFormat), limit)
+}
+
+func (r *CertificateRepository) Delete(ctx context.Context, domainName string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM certificates WHERE domain = ?`, domainName)
+	if err != nil {
+		return fmt.Errorf("deleting certificate: %w", err)
+	}
+	return requireRow(result, domain.ErrCertificateNotFound)
+}
+
+func (r *CertificateRepository) list(ctx context.Context, query string, args ...any) ([]domain.Certificate, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying certificates: %w", err)
+	}
+	defer rows.Close()
+
+	var certs []domain.Certificate
+	for rows.Next() {
+		c, err := scanCertificate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning certificate: %w", err)
+		}
+		certs = append(certs, c)
+	}
+	return certs, rows.Err()
+}
+
+func scanCertificate(row rowScanner) (domain.Certificate, error) {
+	var (
+		c                                                  domain.Certificate
+		status                                             string
+		notBefore, notAfter, renewAt, createdAt, updatedAt string
+	)
+	err := row.Scan(&c.Domain, &c.TenantID, &status, &c.Issuer, &c.Serial,
+		&notBefore, &notAfter, &renewAt, &c.LastError, &createdAt, &updatedAt)
+	if err != nil {
+		return domain.Certificate{}, err
+	}
+	c.Status = domain.CertificateStatus(status)
+	c.NotBefore, _ = time.Parse(timeFormat, notBefore) // Zero when empty.
+	c.NotAfter, _ = time.Parse(timeFormat, notAfter)
+	c.RenewAt, _ = time.Parse(timeFormat, renewAt)
+	c.CreatedAt, _ = time.Parse(timeFormat, createdAt)
+	c.UpdatedAt, _ = time.Parse(timeFormat, updatedAt)
+	return c, nil
+}
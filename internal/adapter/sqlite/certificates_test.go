@@ -0,0 +1,68 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestCertificates(t *testing.T) {
+	certs := sqlite.NewCertificateRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	pending := domain.NewCertificate("www.acme.com", "ten_1", now.Add(-time.Minute))
+	issued := domain.NewCertificate("app.acme.com", "ten_1", now.Add(-time.Hour))
+	issued.Issued(domain.IssuedCertificate{
+		Issuer: "R11", Serial: "0a1b", NotBefore: now.Add(-time.Hour), NotAfter: now.Add(90 * 24 * time.Hour),
+	}, now.Add(-time.Hour))
+	other := domain.NewCertificate("shop.globex.com", "ten_2", now.Add(time.Minute))
+	for _, c := range []domain.Certificate{pending, issued, other} {
+		if err := certs.Save(ctx, c); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	due, err := certs.ListDue(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("ListDue: %v", err)
+	}
+	if len(due) != 1 || due[0].Domain != "www.acme.com" || due[0].Status != domain.CertificatePending {
+		t.Errorf("ListDue = %+v, want only the pending www.acme.com", due)
+	}
+
+	stored, err := certs.Get(ctx, "app.acme.com")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if stored.Serial != "0a1b" || !stored.NotAfter.Equal(issued.NotAfter) || !stored.RenewAt.Equal(issued.RenewAt) {
+		t.Errorf("Get = %+v, want %+v", stored, issued)
+	}
+
+	list, err := certs.ListByTenant(ctx, "ten_1")
+	if err != nil {
+		t.Fatalf("ListByTenant: %v", err)
+	}
+	if len(list) != 2 || list[0].Domain != "app.acme.com" || list[1].Domain != "www.acme.com" {
+		t.Errorf("ListByTenant = %+v, want ten_1's certificates by domain", list)
+	}
+
+	pending.Failed(errors.New("connection refused"), now)
+	if err := certs.Save(ctx, pending); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if due, _ := certs.ListDue(ctx, now, 10); len(due) != 0 {
+		t.Errorf("ListDue after the failure = %+v, want the retry later", due)
+	}
+
+	if err := certs.Delete(ctx, "www.acme.com"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := certs.Delete(ctx, "www.acme.com"); !errors.Is(err, domain.ErrCertificateNotFound) {
+		t.Errorf("second Delete = %v, want ErrCertificateNotFound", err)
+	}
+}
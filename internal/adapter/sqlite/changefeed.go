@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: AuditLog implements domain.ChangeFeed.
+var _ domain.ChangeFeed = (*AuditLog)(nil)
+
+// ChangesAfter reads the feed from the audit log and its archive, whose
+// IDs are the cursors. Writes are serialized, so an entry is visible before any entry
+// with a larger ID and a consumer cannot skip one.
+func (l *AuditLog) ChangesAfter(ctx context.Context, cursor int64, limit int) ([]domain.TenantChange, error) {
+	rows, err := l.db.QueryContext(ctx,
+		`SELECT id, action, tenant_id, before, after, created_at FROM `+auditLogWithArchive+`
+		 WHERE id > ? ORDER BY id LIMIT ?`,
+		cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying audit log: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []domain.TenantChange
+	for rows.Next() {
+		var (
+			c             domain.TenantChange
+			action, at    string
+			before, after sql.NullString
+		)
+		if err := rows.Scan(&c.Cursor, &action, &c.TenantID, &before, &after, &at); err != nil {
+			return nil, fmt.Errorf("scanning audit entry: %w", err)
+		}
+		c.Type = domain.ChangeTypeOf(domain.AuditAction(action))
+		c.At, _ = time.Parse(timeFormat, at)
+
+		switch {
+		case after.Valid:
+			tenant, err := unmarshalSnapshot(after.String)
+			if err != nil {
+				return nil, err
+			}
+			c.Tenant, c.Version = &tenant, tenant.Version
+		case before.Valid:
+			last, err := unmarshalSnapshot(before.String)
+			if err != nil {
+				return nil, err
+			}
+			c.Version = last.Version
+		}
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
+
+// Cursors reads the bounds of the audit log. Archived entries are the
+// oldest, so the oldest cursor is in the archive while it has any. The
+// latest cursor comes from the AUTOINCREMENT sequence, so it survives
+// pruning every entry.
+func (l *AuditLog) Cursors(ctx context.Context) (oldest, latest int64, err error) {
+	err = l.db.QueryRowContext(ctx,
+		`SELECT COALESCE((SELECT MIN(id) FROM audit_log_archive), (SELECT MIN(id) FROM audit_log), s.seq + 1) - 1, s.seq
+		 FROM (SELECT COALESCE((SELECT seq FROM sqlite_sequence WHERE name = 'audit_log'), 0) AS seq) s`,
+	).Scan(&oldest, &latest)
+	if err != nil {
+		return 0, 0, fmt.Errorf("querying audit log bounds: %w", err)
+	}
+	return oldest, latest, nil
+}
@@ -0,0 +1,78 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestAuditLog_ChangesAfter(t *testing.T) {
+	log := sqlite.NewAuditLog(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	if oldest, latest, err := log.Cursors(ctx); err != nil || oldest != 0 || latest != 0 {
+		t.Fatalf("empty Cursors = %d, %d, %v; want 0, 0", oldest, latest, err)
+	}
+
+	created := domain.NewTenant("ten_1", "Acme", "acme", "free")
+	updated := created
+	updated.Name, updated.Version = "Acme Inc", 2
+
+	t0 := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	entries := []domain.AuditEntry{
+		{Action: domain.AuditCreate, TenantID: "ten_1", After: &created, At: t0},
+		{Action: domain.AuditUpdate, TenantID: "ten_1", Before: &created, After: &updated, At: t0.Add(time.Hour)},
+		{Action: domain.AuditPurge, TenantID: "ten_1", Before: &updated, At: t0.Add(48 * time.Hour)},
+	}
+	for _, e := range entries {
+		if err := log.Log(ctx, e); err != nil {
+			t.Fatalf("Log failed: %v", err)
+		}
+	}
+
+	changes, err := log.ChangesAfter(ctx, 0, 10)
+	if err != nil {
+		t.Fatalf("ChangesAfter failed: %v", err)
+	}
+	want := []struct {
+		typ     domain.ChangeType
+		version int
+		name    string
+	}{
+		{domain.ChangeCreated, 1, "Acme"},
+		{domain.ChangeUpdated, 2, "Acme Inc"},
+		{domain.ChangeDeleted, 2, ""},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d", len(changes), len(want))
+	}
+	for i, w := range want {
+		c := changes[i]
+		if c.Cursor != int64(i+1) || c.Type != w.typ || c.TenantID != "ten_1" || c.Version != w.version {
+			t.Errorf("change %d = %d/%s/%s/v%d, want %d/%s/ten_1/v%d", i, c.Cursor, c.Type, c.TenantID, c.Version, i+1, w.typ, w.version)
+		}
+		if (c.Tenant == nil) != (w.name == "") || c.Tenant != nil && c.Tenant.Name != w.name {
+			t.Errorf("change %d tenant = %+v, want name %q", i, c.Tenant, w.name)
+		}
+	}
+
+	if changes, _ := log.ChangesAfter(ctx, 1, 1); len(changes) != 1 || changes[0].Cursor != 2 {
+		t.Errorf("ChangesAfter(1, 1) = %+v, want the change at cursor 2", changes)
+	}
+
+	if _, err := log.Prune(ctx, t0.Add(24*time.Hour), false); err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if oldest, latest, err := log.Cursors(ctx); err != nil || oldest != 2 || latest != 3 {
+		t.Errorf("pruned Cursors = %d, %d, %v; want 2, 3", oldest, latest, err)
+	}
+	if _, err := log.Prune(ctx, t0.Add(72*time.Hour), false); err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if oldest, latest, err := log.Cursors(ctx); err != nil || oldest != 3 || latest != 3 {
+		t.Errorf("emptied Cursors = %d, %d, %v; want 3, 3", oldest, latest, err)
+	}
+}
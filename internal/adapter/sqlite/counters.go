@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: StatusCounter implements domain.StatusCounter.
+var _ domain.StatusCounter = (*StatusCounter)(nil)
+
+// StatusCounter implements domain.StatusCounter with the
+// tenant_status_counts table, which triggers on the tenants table keep
+// current whatever writes the tenants.
+type StatusCounter struct {
+	db *sql.DB
+}
+
+// NewStatusCounter wraps a database already migrated by New or NewFromDB.
+func NewStatusCounter(db *sql.DB) *StatusCounter {
+	return &StatusCounter{db: db}
+}
+
+func (c *StatusCounter) Counts(ctx context.Context) (map[domain.Status]int, error) {
+	return readStatusCounts(ctx, c.db, `SELECT status, count FROM tenant_status_counts WHERE count != 0`)
+}
+
+func (c *StatusCounter) Reconcile(ctx context.Context) (map[domain.Status]int, error) {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // no-op after Commit
+
+	maintained, err := readStatusCounts(ctx, tx, `SELECT status, count FROM tenant_status_counts`)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := readStatusCounts(ctx, tx, `SELECT status, COUNT(*) FROM tenants GROUP BY status`)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := make(map[domain.Status]int)
+	for status, n := range maintained {
+		if d := n - actual[status]; d != 0 {
+			drift[status] = d
+		}
+	}
+	for status, n := range actual {
+		if _, ok := maintained[status]; !ok {
+			drift[status] = -n
+		}
+	}
+	if len(drift) == 0 {
+		return drift, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tenant_status_counts`); err != nil {
+		return nil, fmt.Errorf("clearing status counts: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO tenant_status_counts (status, count) SELECT status, COUNT(*) FROM tenants GROUP BY status`,
+	); err != nil {
+		return nil, fmt.Errorf("recounting tenants: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return drift, nil
+}
+
+// readStatusCounts runs query, which selects a status and a count per row.
+func readStatusCounts(ctx context.Context, q querier, query string) (map[domain.Status]int, error) {
+	rows, err := q.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("counting tenants: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[domain.Status]int)
+	for rows.Next() {
+		var status string
+		var n int
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, fmt.Errorf("scanning tenant count: %w", err)
+		}
+		counts[domain.Status(status)] = n
+	}
+	return counts, rows.Err()
+}
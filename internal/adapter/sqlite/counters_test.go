@@ -0,0 +1,68 @@
+package sqlite_test
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestStatusCounter_FollowsTenantChanges(t *testing.T) {
+	repo := newTestRepo(t)
+	counter := sqlite.NewStatusCounter(repo.DB())
+	ctx := context.Background()
+
+	mustCreate(t, repo, domain.NewTenant("t-1", "One", "one", "free"))
+	mustCreate(t, repo, domain.NewTenant("t-2", "Two", "two", "free"))
+	active, _ := repo.GetByID(ctx, "t-1")
+	active.Status = domain.StatusActive
+	mustUpdate(t, repo, active)
+	if err := repo.Purge(ctx, "t-2"); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+
+	counts, err := counter.Counts(ctx)
+	if err != nil {
+		t.Fatalf("Counts: %v", err)
+	}
+	if want := map[domain.Status]int{domain.StatusActive: 1}; !maps.Equal(counts, want) {
+		t.Errorf("Counts = %v, want %v", counts, want)
+	}
+}
+
+func TestStatusCounter_ReconcileCorrectsDrift(t *testing.T) {
+	repo := newTestRepo(t)
+	counter := sqlite.NewStatusCounter(repo.DB())
+	ctx := context.Background()
+
+	mustCreate(t, repo, domain.NewTenant("t-1", "One", "one", "free"))
+	mustCreate(t, repo, domain.NewTenant("t-2", "Two", "two", "pro"))
+	if _, err := repo.DB().ExecContext(ctx, `UPDATE tenant_status_counts SET count = 5`); err != nil {
+		t.Fatalf("corrupting counts: %v", err)
+	}
+
+	// Status-only counts read the counters; other filters count tenants.
+	creating := domain.StatusCreating
+	if n, _ := repo.Count(ctx, domain.ListFilter{Status: &creating, Limit: 10}); n != 5 {
+		t.Errorf("Count(creating) = %d, want the drifted 5 from the counters", n)
+	}
+	if n, _ := repo.Count(ctx, domain.ListFilter{Status: &creating, Plans: []string{"pro"}}); n != 1 {
+		t.Errorf("Count(creating, pro) = %d, want 1", n)
+	}
+
+	drift, err := counter.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if want := map[domain.Status]int{domain.StatusCreating: 3}; !maps.Equal(drift, want) {
+		t.Errorf("drift = %v, want %v", drift, want)
+	}
+	if n, _ := repo.Count(ctx, domain.ListFilter{}); n != 2 {
+		t.Errorf("Count() after reconciling = %d, want 2", n)
+	}
+	if drift, _ := counter.Reconcile(ctx); len(drift) != 0 {
+		t.Errorf("second Reconcile drift = %v, want none", drift)
+	}
+}
@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// SchemaVersion returns the latest migration applied to db, read without
+// running or recording any migration.
+func SchemaVersion(ctx context.Context, db *sql.DB) (int64, error) {
+	var version sql.NullInt64
+	if err := db.QueryRowContext(ctx,
+		`SELECT MAX(version_id) FROM goose_db_version WHERE is_applied`,
+	).Scan(&version); err != nil {
+		return 0, fmt.Errorf("reading schema version: %w", err)
+	}
+	return version.Int64, nil
+}
+
+// CountByStatus returns the number of tenants in each status that has any,
+// counted from the tenants themselves rather than the maintained counts.
+func CountByStatus(ctx context.Context, db *sql.DB) (map[domain.Status]int, error) {
+	return readStatusCounts(ctx, db, `SELECT status, COUNT(*) FROM tenants GROUP BY status`)
+}
+
+// CountByStatusAndPlan returns the number of tenants in each status and
+// plan that has any, for business metrics.
+func CountByStatusAndPlan(ctx context.Context, db *sql.DB) ([]domain.TenantCount, error) {
+	rows, err := db.QueryContext(ctx, `SELECT status, plan, COUNT(*) FROM tenants GROUP BY status, plan ORDER BY status, plan`)
+	if err != nil {
+		return nil, fmt.Errorf("counting tenants: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []domain.TenantCount
+	for rows.Next() {
+		var (
+			c      domain.TenantCount
+			status string
+		)
+		if err := rows.Scan(&status, &c.Plan, &c.Count); err != nil {
+			return nil, fmt.Errorf("scanning tenant count: %w", err)
+		}
+		c.Status = domain.Status(status)
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,33 @@
+package sqlite_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestCountByStatusAndPlan(t *testing.T) {
+	repo := newTestRepo(t)
+	for i, plan := range []string{"pro", "pro", "free"} {
+		mustCreate(t, repo, domain.NewTenant(fmt.Sprintf("ten_%d", i), "Tenant", fmt.Sprintf("tenant-%d", i), plan))
+	}
+	suspended := domain.NewTenant("ten_9", "Suspended", "suspended", "pro")
+	suspended.Status = domain.StatusSuspended
+	mustCreate(t, repo, suspended)
+
+	counts, err := sqlite.CountByStatusAndPlan(context.Background(), repo.DB())
+	if err != nil {
+		t.Fatalf("CountByStatusAndPlan: %v", err)
+	}
+	want := []domain.TenantCount{
+		{Status: domain.StatusCreating, Plan: "free", Count: 1},
+		{Status: domain.StatusCreating, Plan: "pro", Count: 2},
+		{Status: domain.StatusSuspended, Plan: "pro", Count: 1},
+	}
+	if fmt.Sprint(counts) != fmt.Sprint(want) {
+		t.Errorf("counts = %v, want %v", counts, want)
+	}
+}
@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: WebhookDigestRepository implements domain.WebhookDigestRepository.
+var _ domain.WebhookDigestRepository = (*WebhookDigestRepository)(nil)
+
+// WebhookDigestRepository implements domain.WebhookDigestRepository using
+// SQLite.
+type WebhookDigestRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookDigestRepository wraps a database already migrated by New or NewFromDB.
+func NewWebhookDigestRepository(db *sql.DB) *WebhookDigestRepository {
+	return &WebhookDigestRepository{db: db}
+}
+
+const digestEntryColumns = `webhook_id, event_id, event, tenant_id, payload, occurred_at`
+
+func (r *WebhookDigestRepository) Add(ctx context.Context, e domain.DigestEntry) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO webhook_digest_entries (`+digestEntryColumns+`)
+		 VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT (webhook_id, event_id) DO NOTHING`,
+		e.WebhookID, e.EventID, string(e.Event), e.TenantID, e.Payload, e.OccurredAt.UTC().Format(timeFormat),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting webhook digest entry: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookDigestRepository) Pending(ctx context.Context, webhookID string, before time.Time) ([]domain.DigestEntry, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+digestEntryColumns+` FROM webhook_digest_entries
+		 WHERE webhook_id = ? AND occurred_at < ? ORDER BY occurred_at, event_id`,
+		webhookID, before.UTC().Format(timeFormat),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying webhook digest entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []domain.DigestEntry
+	for rows.Next() {
+		var (
+			e         domain.DigestEntry
+			event, at string
+		)
+		if err := rows.Scan(&e.WebhookID, &e.EventID, &event, &e.TenantID, &e.Payload, &at); err != nil {
+			return nil, fmt.Errorf("scanning webhook digest entry: %w", err)
+		}
+		e.Event = domain.Event(event)
+		e.OccurredAt, _ = time.Parse(timeFormat, at)
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func (r *WebhookDigestRepository) Remove(ctx context.Context, webhookID string, eventIDs []string) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(eventIDs)+1)
+	args = append(args, webhookID)
+	for _, id := range eventIDs {
+		args = append(args, id)
+	}
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM webhook_digest_entries WHERE webhook_id = ? AND event_id IN (`+placeholders(len(eventIDs))+`)`, args...)
+	if err != nil {
+		return fmt.Errorf("deleting webhook digest entries: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestWebhookDigests_HoldAndRelease(t *testing.T) {
+	db := newTestRepo(t).DB()
+	webhooks := sqlite.NewWebhookRepository(db)
+	digests := sqlite.NewWebhookDigestRepository(db)
+	ctx := context.Background()
+
+	w := mustWebhook(t, "wh_1")
+	w.Digest = domain.DigestHourly
+	if err := webhooks.Create(ctx, w); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if got, _ := webhooks.GetByID(ctx, "wh_1"); got.Digest != domain.DigestHourly {
+		t.Errorf("Digest = %q, want hourly", got.Digest)
+	}
+
+	base := time.Date(2026, 3, 14, 15, 0, 0, 0, time.UTC)
+	for i, id := range []string{"evt_2", "evt_1", "evt_3", "evt_1"} {
+		entry := domain.DigestEntry{
+			WebhookID:  "wh_1",
+			EventID:    id,
+			Event:      domain.EventSuspend,
+			TenantID:   "ten_1",
+			Payload:    []byte(`{"id":"` + id + `"}`),
+			OccurredAt: base.Add(time.Duration(i) * 40 * time.Minute),
+		}
+		if err := digests.Add(ctx, entry); err != nil {
+			t.Fatalf("Add %s: %v", id, err)
+		}
+	}
+
+	// evt_1 was held once, when first processed; evt_3 is not due yet.
+	pending, err := digests.Pending(ctx, "wh_1", base.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if len(pending) != 2 || pending[0].EventID != "evt_2" || pending[1].EventID != "evt_1" ||
+		string(pending[1].Payload) != `{"id":"evt_1"}` || !pending[1].OccurredAt.Equal(base.Add(40*time.Minute)) {
+		t.Fatalf("Pending = %+v, want evt_2 then evt_1", pending)
+	}
+
+	if err := digests.Remove(ctx, "wh_1", []string{"evt_2", "evt_1"}); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if pending, _ := digests.Pending(ctx, "wh_1", base.Add(2*time.Hour)); len(pending) != 1 || pending[0].EventID != "evt_3" {
+		t.Errorf("Pending after Remove = %+v, want evt_3", pending)
+	}
+
+	// Deleting the subscription drops what it held.
+	if err := webhooks.Delete(ctx, "wh_1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if pending, _ := digests.Pending(ctx, "wh_1", base.Add(2*time.Hour)); len(pending) != 0 {
+		t.Errorf("Pending after Delete = %+v, want none", pending)
+	}
+}
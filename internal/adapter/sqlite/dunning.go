@@ -0,0 +1,110 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: DunningRepository implements domain.DunningRepository.
+var _ domain.DunningRepository = (*DunningRepository)(nil)
+
+// DunningRepository implements domain.DunningRepository using SQLite. A
+// suspended dunning has no next step, stored as an empty next_step_at.
+type DunningRepository struct {
+	db *sql.DB
+}
+
+// NewDunningRepository wraps a database already migrated by New or NewFromDB.
+func NewDunningRepository(db *sql.DB) *DunningRepository {
+	return &DunningRepository{db: db}
+}
+
+const dunningColumns = `tenant_id, invoice_id, stage, suspended_tenant, started_at, next_step_at, updated_at`
+
+func (r *DunningRepository) Save(ctx context.Context, d domain.Dunning) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO dunning (`+dunningColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT (tenant_id) DO UPDATE SET
+		 invoice_id = excluded.invoice_id, stage = excluded.stage, suspended_tenant = excluded.suspended_tenant,
+		 started_at = excluded.started_at, next_step_at = excluded.next_step_at, updated_at = excluded.updated_at`,
+		d.TenantID, d.InvoiceID, string(d.Stage), d.SuspendedTenant,
+		d.StartedAt.Format(timeFormat), formatOptionalTime(d.NextStepAt), d.UpdatedAt.Format(timeFormat),
+	)
+	if err != nil {
+		return fmt.Errorf("saving dunning: %w", err)
+	}
+	return nil
+}
+
+func (r *DunningRepository) Get(ctx context.Context, tenantID string) (domain.Dunning, error) {
+	d, err := scanDunning(r.db.QueryRowContext(ctx,
+		`SELECT `+dunningColumns+` FROM dunning WHERE tenant_id = ?`, tenantID,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Dunning{}, domain.ErrDunningNotFound
+		}
+		return domain.Dunning{}, fmt.Errorf("scanning dunning: %w", err)
+	}
+	return d, nil
+}
+
+func (r *DunningRepository) ListDue(ctx context.Context, now time.Time) ([]domain.Dunning, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+dunningColumns+` FROM dunning
+		 WHERE next_step_at != '' AND next_step_at <= ? ORDER BY started_at, tenant_id`,
+		now.UTC().Format(timeFormat),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying due dunnings: %w", err)
+	}
+	defer rows.Close()
+
+	var due []domain.Dunning
+	for rows.Next() {
+		d, err := scanDunning(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning dunning: %w", err)
+		}
+		due = append(due, d)
+	}
+	return due, rows.Err()
+}
+
+func (r *DunningRepository) Delete(ctx context.Context, tenantID string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM dunning WHERE tenant_id = ?`, tenantID)
+	if err != nil {
+		return fmt.Errorf("deleting dunning: %w", err)
+	}
+	return requireRow(result, domain.ErrDunningNotFound)
+}
+
+// formatOptionalTime stores a zero time as an empty string.
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(timeFormat)
+}
+
+func scanDunning(row rowScanner) (domain.Dunning, error) {
+	var (
+		d                                domain.Dunning
+		stage                            string
+		startedAt, nextStepAt, updatedAt string
+	)
+	err := row.Scan(&d.TenantID, &d.InvoiceID, &stage, &d.SuspendedTenant, &startedAt, &nextStepAt, &updatedAt)
+	if err != nil {
+		return domain.Dunning{}, err
+	}
+	d.Stage = domain.DunningStage(stage)
+	d.StartedAt, _ = time.Parse(timeFormat, startedAt)
+	d.NextStepAt, _ = time.Parse(timeFormat, nextStepAt) // Zero when empty.
+	d.UpdatedAt, _ = time.Parse(timeFormat, updatedAt)
+	return d, nil
+}
@@ -0,0 +1,72 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestDunning_SaveAndListDue(t *testing.T) {
+	dunning := sqlite.NewDunningRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+	policy := domain.DunningPolicy{WarningPeriod: time.Hour, GracePeriod: time.Hour}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	due := domain.StartDunning("ten_due", "in_1", policy, now.Add(-2*time.Hour))
+	later := domain.StartDunning("ten_later", "in_2", policy, now)
+	suspended := domain.StartDunning("ten_suspended", "in_3", policy, now.Add(-3*time.Hour))
+	suspended.Stage, suspended.NextStepAt, suspended.SuspendedTenant = domain.DunningSuspended, time.Time{}, true
+	for _, d := range []domain.Dunning{due, later, suspended} {
+		if err := dunning.Save(ctx, d); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	got, err := dunning.ListDue(ctx, now)
+	if err != nil {
+		t.Fatalf("ListDue: %v", err)
+	}
+	if len(got) != 1 || got[0].TenantID != "ten_due" || !got[0].NextStepAt.Equal(due.NextStepAt) {
+		t.Errorf("ListDue = %+v, want only ten_due", got)
+	}
+
+	stored, err := dunning.Get(ctx, "ten_suspended")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !stored.SuspendedTenant || !stored.NextStepAt.IsZero() || stored.Stage != domain.DunningSuspended {
+		t.Errorf("Get = %+v", stored)
+	}
+
+	// Saving again replaces the row.
+	due.Advance(policy, now)
+	if err := dunning.Save(ctx, due); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if stored, _ := dunning.Get(ctx, "ten_due"); stored.Stage != domain.DunningGrace {
+		t.Errorf("stage after update = %q, want grace", stored.Stage)
+	}
+}
+
+func TestDunning_Delete(t *testing.T) {
+	dunning := sqlite.NewDunningRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	d := domain.StartDunning("ten_1", "in_1", domain.DunningPolicy{WarningPeriod: time.Hour, GracePeriod: time.Hour}, time.Now().UTC())
+	if err := dunning.Save(ctx, d); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := dunning.Delete(ctx, "ten_1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := dunning.Get(ctx, "ten_1"); !errors.Is(err, domain.ErrDunningNotFound) {
+		t.Errorf("Get after delete = %v, want ErrDunningNotFound", err)
+	}
+	if err := dunning.Delete(ctx, "ten_1"); !errors.Is(err, domain.ErrDunningNotFound) {
+		t.Errorf("second Delete = %v, want ErrDunningNotFound", err)
+	}
+}
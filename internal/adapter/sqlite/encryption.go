@@ -0,0 +1,148 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time checks: the repositories implement their domain ports.
+var (
+	_ domain.EncryptionKeyRepository = (*EncryptionKeyRepository)(nil)
+	_ domain.SecretRepository        = (*SecretRepository)(nil)
+)
+
+// EncryptionKeyRepository implements domain.EncryptionKeyRepository using
+// SQLite, one row per tenant.
+type EncryptionKeyRepository struct {
+	db *sql.DB
+}
+
+// NewEncryptionKeyRepository wraps a database already migrated by New or
+// NewFromDB.
+func NewEncryptionKeyRepository(db *sql.DB) *EncryptionKeyRepository {
+	return &EncryptionKeyRepository{db: db}
+}
+
+const encryptionKeyColumns = `tenant_id, key_ref, created_at, rotated_at, revoked_at`
+
+func (r *EncryptionKeyRepository) Get(ctx context.Context, tenantID string) (domain.EncryptionKey, error) {
+	var (
+		k                               domain.EncryptionKey
+		createdAt, rotatedAt, revokedAt string
+	)
+	err := r.db.QueryRowContext(ctx,
+		`SELECT `+encryptionKeyColumns+` FROM tenant_encryption_keys WHERE tenant_id = ?`, tenantID,
+	).Scan(&k.TenantID, &k.KeyRef, &createdAt, &rotatedAt, &revokedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.EncryptionKey{}, domain.ErrEncryptionKeyNotFound
+	}
+	if err != nil {
+		return domain.EncryptionKey{}, fmt.Errorf("scanning encryption key: %w", err)
+	}
+	k.CreatedAt, _ = time.Parse(timeFormat, createdAt)
+	k.RotatedAt, _ = time.Parse(timeFormat, rotatedAt) // Zero when empty.
+	k.RevokedAt, _ = time.Parse(timeFormat, revokedAt)
+	return k, nil
+}
+
+func (r *EncryptionKeyRepository) Save(ctx context.Context, k domain.EncryptionKey) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO tenant_encryption_keys (`+encryptionKeyColumns+`) VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT (tenant_id) DO UPDATE SET key_ref = excluded.key_ref,
+		 rotated_at = excluded.rotated_at, revoked_at = excluded.revoked_at`,
+		k.TenantID, k.KeyRef, k.CreatedAt.UTC().Format(timeFormat),
+		formatOptionalTime(k.RotatedAt), formatOptionalTime(k.RevokedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("saving encryption key: %w", err)
+	}
+	return nil
+}
+
+// SecretRepository implements domain.SecretRepository using SQLite. Values
+// are stored as sealed, never in clear.
+type SecretRepository struct {
+	db *sql.DB
+}
+
+// NewSecretRepository wraps a database already migrated by New or
+// NewFromDB.
+func NewSecretRepository(db *sql.DB) *SecretRepository {
+	return &SecretRepository{db: db}
+}
+
+const secretColumns = `tenant_id, name, key_ref, wrapped_key, ciphertext, updated_by, created_at, updated_at`
+
+func (r *SecretRepository) Put(ctx context.Context, s domain.TenantSecret) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO tenant_secrets (`+secretColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT (tenant_id, name) DO UPDATE SET key_ref = excluded.key_ref,
+		 wrapped_key = excluded.wrapped_key, ciphertext = excluded.ciphertext,
+		 updated_by = excluded.updated_by, updated_at = excluded.updated_at`,
+		s.TenantID, s.Name, s.Value.KeyRef, s.Value.WrappedKey, s.Value.Ciphertext, s.UpdatedBy,
+		s.CreatedAt.UTC().Format(timeFormat), s.UpdatedAt.UTC().Format(timeFormat),
+	)
+	if err != nil {
+		return fmt.Errorf("saving secret: %w", err)
+	}
+	return nil
+}
+
+func (r *SecretRepository) Get(ctx context.Context, tenantID, name string) (domain.TenantSecret, error) {
+	s, err := scanSecret(r.db.QueryRowContext(ctx,
+		`SELECT `+secretColumns+` FROM tenant_secrets WHERE tenant_id = ? AND name = ?`, tenantID, name))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.TenantSecret{}, domain.ErrSecretNotFound
+	}
+	if err != nil {
+		return domain.TenantSecret{}, fmt.Errorf("scanning secret: %w", err)
+	}
+	return s, nil
+}
+
+func (r *SecretRepository) ListByTenant(ctx context.Context, tenantID string) ([]domain.TenantSecret, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+secretColumns+` FROM tenant_secrets WHERE tenant_id = ? ORDER BY name`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("querying secrets: %w", err)
+	}
+	defer rows.Close()
+
+	var secrets []domain.TenantSecret
+	for rows.Next() {
+		s, err := scanSecret(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning secret: %w", err)
+		}
+		secrets = append(secrets, s)
+	}
+	return secrets, rows.Err()
+}
+
+func (r *SecretRepository) Delete(ctx context.Context, tenantID, name string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tenant_secrets WHERE tenant_id = ? AND name = ?`, tenantID, name)
+	if err != nil {
+		return fmt.Errorf("deleting secret: %w", err)
+	}
+	return requireRow(result, domain.ErrSecretNotFound)
+}
+
+func scanSecret(row rowScanner) (domain.TenantSecret, error) {
+	var (
+		s                    domain.TenantSecret
+		createdAt, updatedAt string
+	)
+	err := row.Scan(&s.TenantID, &s.Name, &s.Value.KeyRef, &s.Value.WrappedKey, &s.Value.Ciphertext,
+		&s.UpdatedBy, &createdAt, &updatedAt)
+	if err != nil {
+		return domain.TenantSecret{}, err
+	}
+	s.CreatedAt, _ = time.Parse(timeFormat, createdAt)
+	s.UpdatedAt, _ = time.Parse(timeFormat, updatedAt)
+	return s, nil
+}
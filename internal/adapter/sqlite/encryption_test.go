@@ -0,0 +1,73 @@
+package sqlite_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestEncryptionKeys_SaveAndGet(t *testing.T) {
+	keys := sqlite.NewEncryptionKeyRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	if _, err := keys.Get(ctx, "ten_1"); !errors.Is(err, domain.ErrEncryptionKeyNotFound) {
+		t.Fatalf("Get before Save = %v, want ErrEncryptionKeyNotFound", err)
+	}
+	k := domain.EncryptionKey{TenantID: "ten_1", KeyRef: "arn:1", CreatedAt: at}
+	if err := keys.Save(ctx, k); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	k.KeyRef, k.RotatedAt, k.RevokedAt = "arn:2", at.Add(time.Hour), at.Add(2*time.Hour)
+	if err := keys.Save(ctx, k); err != nil {
+		t.Fatalf("Save again: %v", err)
+	}
+	if got, err := keys.Get(ctx, "ten_1"); err != nil || got != k {
+		t.Errorf("Get = %+v, %v; want %+v", got, err, k)
+	}
+}
+
+func TestSecrets_PutListDelete(t *testing.T) {
+	secrets := sqlite.NewSecretRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, s := range []domain.TenantSecret{
+		{TenantID: "ten_1", Name: "TOKEN", Value: domain.Sealed{KeyRef: "arn:1", WrappedKey: []byte{1}, Ciphertext: []byte{2}}, CreatedAt: at, UpdatedAt: at},
+		{TenantID: "ten_1", Name: "DATABASE_URL", Value: domain.Sealed{KeyRef: "arn:1", WrappedKey: []byte{3}, Ciphertext: []byte{4}}, CreatedAt: at, UpdatedAt: at},
+		{TenantID: "ten_2", Name: "TOKEN", Value: domain.Sealed{KeyRef: "arn:2", WrappedKey: []byte{5}, Ciphertext: []byte{6}}, CreatedAt: at, UpdatedAt: at},
+		// Putting a secret again replaces its value.
+		{TenantID: "ten_1", Name: "TOKEN", Value: domain.Sealed{KeyRef: "arn:9", WrappedKey: []byte{7}, Ciphertext: []byte{8}}, UpdatedBy: "alice", CreatedAt: at.Add(time.Hour), UpdatedAt: at.Add(time.Hour)},
+	} {
+		if err := secrets.Put(ctx, s); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+
+	got, err := secrets.Get(ctx, "ten_1", "TOKEN")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Value.KeyRef != "arn:9" || !bytes.Equal(got.Value.Ciphertext, []byte{8}) || got.UpdatedBy != "alice" || !got.CreatedAt.Equal(at) {
+		t.Errorf("Get = %+v, want the replaced value created at first", got)
+	}
+	list, err := secrets.ListByTenant(ctx, "ten_1")
+	if err != nil || len(list) != 2 || list[0].Name != "DATABASE_URL" || list[1].Name != "TOKEN" {
+		t.Fatalf("ListByTenant = %+v, %v; want the two secrets of ten_1 by name", list, err)
+	}
+
+	if err := secrets.Delete(ctx, "ten_1", "TOKEN"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := secrets.Get(ctx, "ten_1", "TOKEN"); !errors.Is(err, domain.ErrSecretNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrSecretNotFound", err)
+	}
+	if err := secrets.Delete(ctx, "ten_1", "TOKEN"); !errors.Is(err, domain.ErrSecretNotFound) {
+		t.Errorf("second Delete = %v, want ErrSecretNotFound", err)
+	}
+}
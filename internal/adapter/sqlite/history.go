@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: StatusHistoryRepository implements domain.StatusHistoryRepository.
+var _ domain.StatusHistoryRepository = (*StatusHistoryRepository)(nil)
+
+// StatusHistoryRepository implements domain.StatusHistoryRepository using
+// SQLite. It shares the tenants database, whose migrations create its table.
+// Entries are append-only and kept after the tenant is deleted; reads
+// include the archived ones.
+type StatusHistoryRepository struct {
+	db *sql.DB
+}
+
+// NewStatusHistoryRepository wraps a database already migrated by New or NewFromDB.
+func NewStatusHistoryRepository(db *sql.DB) *StatusHistoryRepository {
+	return &StatusHistoryRepository{db: db}
+}
+
+func (r *StatusHistoryRepository) Record(ctx context.Context, c domain.StatusChange) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO tenant_status_history (tenant_id, from_status, to_status, event, actor, changed_at)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		c.TenantID, string(c.From), string(c.To), string(c.Event), c.Actor, c.At.UTC().Format(timeFormat),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting status change: %w", err)
+	}
+	return nil
+}
+
+func (r *StatusHistoryRepository) ListByTenant(ctx context.Context, tenantID string) ([]domain.StatusChange, error) {
+	return r.list(ctx,
+		`SELECT tenant_id, from_status, to_status, event, actor, changed_at
+		 FROM `+statusHistoryWithArchive+` WHERE tenant_id = ? ORDER BY id`, tenantID,
+	)
+}
+
+func (r *StatusHistoryRepository) ListSince(ctx context.Context, since time.Time) ([]domain.StatusChange, error) {
+	return r.list(ctx,
+		`SELECT tenant_id, from_status, to_status, event, actor, changed_at
+		 FROM `+statusHistoryWithArchive+` WHERE changed_at >= ? ORDER BY changed_at, id`, since.UTC().Format(timeFormat),
+	)
+}
+
+func (r *StatusHistoryRepository) list(ctx context.Context, query string, args ...any) ([]domain.StatusChange, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing status history: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []domain.StatusChange
+	for rows.Next() {
+		var (
+			c                   domain.StatusChange
+			from, to, event, at string
+		)
+		if err := rows.Scan(&c.TenantID, &from, &to, &event, &c.Actor, &at); err != nil {
+			return nil, fmt.Errorf("scanning status change: %w", err)
+		}
+		c.From = domain.Status(from)
+		c.To = domain.Status(to)
+		c.Event = domain.Event(event)
+		c.At, _ = time.Parse(timeFormat, at)
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
@@ -0,0 +1,47 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestStatusHistory_RecordAndList(t *testing.T) {
+	history := sqlite.NewStatusHistoryRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	changes := []domain.StatusChange{
+		{TenantID: "ten_1", From: domain.StatusCreating, To: domain.StatusActive, Event: domain.EventProvisionComplete, Actor: "system", At: at},
+		{TenantID: "ten_2", From: domain.StatusCreating, To: domain.StatusActive, Event: domain.EventProvisionComplete, Actor: "system", At: at},
+		{TenantID: "ten_1", From: domain.StatusActive, To: domain.StatusSuspended, Event: domain.EventSuspend, Actor: "alice", At: at.Add(time.Hour)},
+	}
+	for _, c := range changes {
+		if err := history.Record(ctx, c); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	got, err := history.ListByTenant(ctx, "ten_1")
+	if err != nil {
+		t.Fatalf("ListByTenant failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d changes, want 2", len(got))
+	}
+	if got[1] != changes[2] {
+		t.Errorf("second change = %+v, want %+v", got[1], changes[2])
+	}
+}
+
+func TestStatusHistory_Empty(t *testing.T) {
+	history := sqlite.NewStatusHistoryRepository(newTestRepo(t).DB())
+
+	got, err := history.ListByTenant(context.Background(), "ten_missing")
+	if err != nil || len(got) != 0 {
+		t.Errorf("ListByTenant = %v, %v; want empty", got, err)
+	}
+}
@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: MaintenanceRepository implements domain.MaintenanceRepository.
+var _ domain.MaintenanceRepository = (*MaintenanceRepository)(nil)
+
+// MaintenanceRepository implements domain.MaintenanceRepository using
+// SQLite, one row per window with minute precision. It shares the tenants
+// database, whose migrations create its table.
+type MaintenanceRepository struct {
+	db *sql.DB
+}
+
+// NewMaintenanceRepository wraps a database already migrated by New or NewFromDB.
+func NewMaintenanceRepository(db *sql.DB) *MaintenanceRepository {
+	return &MaintenanceRepository{db: db}
+}
+
+func (r *MaintenanceRepository) Get(ctx context.Context, tenantID string) (domain.MaintenanceSchedule, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT weekday, start_minute, duration_minutes FROM tenant_maintenance_windows
+		 WHERE tenant_id = ? ORDER BY weekday, start_minute`, tenantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying maintenance windows: %w", err)
+	}
+	defer rows.Close()
+
+	var s domain.MaintenanceSchedule
+	for rows.Next() {
+		var weekday, start, duration int
+		if err := rows.Scan(&weekday, &start, &duration); err != nil {
+			return nil, fmt.Errorf("scanning maintenance window: %w", err)
+		}
+		s = append(s, domain.MaintenanceWindow{
+			Weekday:  time.Weekday(weekday),
+			Start:    time.Duration(start) * time.Minute,
+			Duration: time.Duration(duration) * time.Minute,
+		})
+	}
+	return s, rows.Err()
+}
+
+func (r *MaintenanceRepository) Set(ctx context.Context, tenantID string, s domain.MaintenanceSchedule) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // no-op after Commit
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tenant_maintenance_windows WHERE tenant_id = ?`, tenantID); err != nil {
+		return fmt.Errorf("clearing maintenance windows: %w", err)
+	}
+	for _, w := range s {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO tenant_maintenance_windows (tenant_id, weekday, start_minute, duration_minutes)
+			 VALUES (?, ?, ?, ?)`,
+			tenantID, int(w.Weekday), int(w.Start/time.Minute), int(w.Duration/time.Minute),
+		)
+		if err != nil {
+			return fmt.Errorf("inserting maintenance window: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package sqlite_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestMaintenance_SetReplacesWindows(t *testing.T) {
+	repo := sqlite.NewMaintenanceRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	first := domain.MaintenanceSchedule{{Weekday: time.Monday, Start: time.Hour, Duration: time.Hour}}
+	if err := repo.Set(ctx, "ten_1", first); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	want := domain.MaintenanceSchedule{
+		{Weekday: time.Sunday, Start: 2*time.Hour + 30*time.Minute, Duration: 4 * time.Hour},
+		{Weekday: time.Wednesday, Start: 22 * time.Hour, Duration: 3 * time.Hour},
+	}
+	if err := repo.Set(ctx, "ten_1", want); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	got, err := repo.Get(ctx, "ten_1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("windows = %v, want %v", got, want)
+	}
+
+	if err := repo.Set(ctx, "ten_1", nil); err != nil {
+		t.Fatalf("Set(nil) failed: %v", err)
+	}
+	if got, err := repo.Get(ctx, "ten_1"); err != nil || len(got) != 0 {
+		t.Errorf("Get after clearing = %v, %v; want no windows", got, err)
+	}
+}
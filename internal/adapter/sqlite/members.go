@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: MemberRepository implements domain.MemberRepository.
+var _ domain.MemberRepository = (*MemberRepository)(nil)
+
+// MemberRepository implements domain.MemberRepository using SQLite. The
+// (tenant_id, email) unique constraint rejects duplicate members.
+type MemberRepository struct {
+	db *sql.DB
+}
+
+// NewMemberRepository wraps a database already migrated by New or NewFromDB.
+func NewMemberRepository(db *sql.DB) *MemberRepository {
+	return &MemberRepository{db: db}
+}
+
+const memberColumns = `id, tenant_id, email, role, status, invited_by, accepted_at, created_at, updated_at`
+
+func (r *MemberRepository) Create(ctx context.Context, m domain.Member) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO tenant_members (`+memberColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		m.ID, m.TenantID, m.Email, string(m.Role), string(m.Status), m.InvitedBy,
+		formatOptionalTime(m.AcceptedAt), m.CreatedAt.UTC().Format(timeFormat), m.UpdatedAt.UTC().Format(timeFormat),
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return &domain.MemberConflictError{TenantID: m.TenantID, Email: m.Email}
+		}
+		return fmt.Errorf("inserting member: %w", err)
+	}
+	return nil
+}
+
+func (r *MemberRepository) Get(ctx context.Context, tenantID, id string) (domain.Member, error) {
+	m, err := scanMember(r.db.QueryRowContext(ctx,
+		`SELECT `+memberColumns+` FROM tenant_members WHERE tenant_id = ? AND id = ?`, tenantID, id,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Member{}, domain.ErrMemberNotFound
+		}
+		return domain.Member{}, fmt.Errorf("scanning member: %w", err)
+	}
+	return m, nil
+}
+
+func (r *MemberRepository) ListByTenant(ctx context.Context, tenantID string) ([]domain.Member, error) {
+	// rowid breaks ties between members invited within the same second.
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+memberColumns+` FROM tenant_members WHERE tenant_id = ? ORDER BY created_at, rowid`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("querying members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []domain.Member
+	for rows.Next() {
+		m, err := scanMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning member: %w", err)
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+func (r *MemberRepository) Update(ctx context.Context, m domain.Member) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE tenant_members SET role = ?, status = ?, accepted_at = ?, updated_at = ? WHERE tenant_id = ? AND id = ?`,
+		string(m.Role), string(m.Status), formatOptionalTime(m.AcceptedAt), m.UpdatedAt.UTC().Format(timeFormat),
+		m.TenantID, m.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("updating member: %w", err)
+	}
+	return requireRow(result, domain.ErrMemberNotFound)
+}
+
+func (r *MemberRepository) Delete(ctx context.Context, tenantID, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tenant_members WHERE tenant_id = ? AND id = ?`, tenantID, id)
+	if err != nil {
+		return fmt.Errorf("deleting member: %w", err)
+	}
+	return requireRow(result, domain.ErrMemberNotFound)
+}
+
+func scanMember(row rowScanner) (domain.Member, error) {
+	var (
+		m                                domain.Member
+		role, status                     string
+		acceptedAt, createdAt, updatedAt string
+	)
+	err := row.Scan(&m.ID, &m.TenantID, &m.Email, &role, &status, &m.InvitedBy, &acceptedAt, &createdAt, &updatedAt)
+	if err != nil {
+		return domain.Member{}, err
+	}
+	m.Role = domain.MemberRole(role)
+	m.Status = domain.MemberStatus(status)
+	m.AcceptedAt, _ = time.Parse(timeFormat, acceptedAt) // Zero when empty.
+	m.CreatedAt, _ = time.Parse(timeFormat, createdAt)
+	m.UpdatedAt, _ = time.Parse(timeFormat, updatedAt)
+	return m, nil
+}
@@ -0,0 +1,62 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestMembers(t *testing.T) {
+	members := sqlite.NewMemberRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	alice, _ := domain.NewMember("mem_1", "ten_1", "alice@acme.com", domain.MemberRoleOwner, "api")
+	bob, _ := domain.NewMember("mem_2", "ten_1", "bob@acme.com", domain.MemberRoleMember, "alice")
+	other, _ := domain.NewMember("mem_3", "ten_2", "alice@acme.com", domain.MemberRoleAdmin, "api")
+	for _, m := range []domain.Member{alice, bob, other} {
+		if err := members.Create(ctx, m); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	dup, _ := domain.NewMember("mem_4", "ten_1", "alice@acme.com", domain.MemberRoleAdmin, "api")
+	var conflictErr *domain.MemberConflictError
+	if err := members.Create(ctx, dup); !errors.As(err, &conflictErr) {
+		t.Errorf("Create duplicate = %v, want MemberConflictError", err)
+	}
+
+	list, err := members.ListByTenant(ctx, "ten_1")
+	if err != nil {
+		t.Fatalf("ListByTenant: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "mem_1" || list[1].ID != "mem_2" {
+		t.Errorf("ListByTenant = %+v, want mem_1 and mem_2", list)
+	}
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	bob.Accept(now)
+	if err := members.Update(ctx, bob); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	stored, err := members.Get(ctx, "ten_1", "mem_2")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if stored.Status != domain.MemberActive || !stored.AcceptedAt.Equal(now) || stored.InvitedBy != "alice" {
+		t.Errorf("Get = %+v, want bob active since %s, invited by alice", stored, now)
+	}
+
+	if _, err := members.Get(ctx, "ten_2", "mem_2"); !errors.Is(err, domain.ErrMemberNotFound) {
+		t.Errorf("Get from another tenant = %v, want ErrMemberNotFound", err)
+	}
+	if err := members.Delete(ctx, "ten_1", "mem_2"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := members.Delete(ctx, "ten_1", "mem_2"); !errors.Is(err, domain.ErrMemberNotFound) {
+		t.Errorf("Delete again = %v, want ErrMemberNotFound", err)
+	}
+}
@@ -0,0 +1,197 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/pressly/goose/v3"
+)
+
+// Migration is a schema migration embedded in the binary.
+type Migration struct {
+	Version int64
+	// Name is the migration's file name, such as 030_add_regions.sql.
+	Name string
+}
+
+// smokeQueries read every column the repositories scan, so a migration
+// that drops or renames one fails the canary instead of the first request
+// after it.
+var smokeQueries = []struct{ table, columns string }{
+	{"tenants", selectColumns},
+	{"plans", planColumns},
+	{"blueprints", blueprintColumns},
+	{"operations", operationColumns},
+	{"webhook_subscriptions", webhookColumns},
+	{"webhook_digest_entries", digestEntryColumns},
+	{"dunning", dunningColumns},
+	{"certificates", certificateColumns},
+	{"tenant_members", memberColumns},
+	{"tenant_api_keys", apiKeyColumns},
+	{"admin_api_keys", adminKeyColumns},
+	{"tenant_encryption_keys", encryptionKeyColumns},
+	{"tenant_secrets", secretColumns},
+}
+
+// CanaryResult is the outcome of a canary migration: the pending
+// migrations applied to a copy of the database, which smoke queries then
+// read.
+type CanaryResult struct {
+	// From and To are the schema versions of the copy before and after.
+	From, To int64
+	Applied  []Migration
+	// Checks is the number of smoke queries and integrity checks passed.
+	Checks   int
+	Duration time.Duration
+}
+
+// Migrations returns the migrations embedded in the binary, oldest first.
+func Migrations() ([]Migration, error) {
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("listing migrations: %w", err)
+	}
+	out := make([]Migration, 0, len(names))
+	for _, name := range names {
+		version, err := goose.NumericComponent(name)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", name, err)
+		}
+		out = append(out, Migration{Version: version, Name: path.Base(name)})
+	}
+	return out, nil
+}
+
+// PendingMigrations returns the schema version of db and the embedded
+// migrations newer than it, oldest first. It reads db without changing it:
+// a database never migrated is at version 0.
+func PendingMigrations(ctx context.Context, db *sql.DB) (int64, []Migration, error) {
+	var tables int
+	if err := db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'goose_db_version'`,
+	).Scan(&tables); err != nil {
+		return 0, nil, fmt.Errorf("reading schema version: %w", err)
+	}
+	var current int64
+	if tables > 0 {
+		var err error
+		if current, err = SchemaVersion(ctx, db); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	all, err := Migrations()
+	if err != nil {
+		return 0, nil, err
+	}
+	var pending []Migration
+	for _, m := range all {
+		if m.Version > current {
+			pending = append(pending, m)
+		}
+	}
+	return current, pending, nil
+}
+
+// Canary applies the pending migrations to a copy of db in a temporary
+// directory, then runs the smoke queries and SQLite's integrity and
+// foreign key checks on the copy. db itself is only read, and the copy is
+// removed afterwards, so a failing migration never reaches the live
+// schema. The result covers the steps that ran, even when one failed.
+func Canary(ctx context.Context, db *sql.DB) (res CanaryResult, err error) {
+	start := time.Now()
+	defer func() { res.Duration = time.Since(start) }()
+
+	dir, err := os.MkdirTemp("", "tenantiq-canary-")
+	if err != nil {
+		return res, fmt.Errorf("creating canary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "canary.db")
+	if _, err := db.ExecContext(ctx, `VACUUM INTO ?`, file); err != nil {
+		return res, fmt.Errorf("copying database: %w", err)
+	}
+	shadow, err := sql.Open("sqlite", file)
+	if err != nil {
+		return res, fmt.Errorf("opening copy: %w", err)
+	}
+	defer shadow.Close()
+	if _, err := shadow.ExecContext(ctx, "PRAGMA foreign_keys=ON"); err != nil {
+		return res, fmt.Errorf("enabling foreign keys: %w", err)
+	}
+
+	current, pending, err := PendingMigrations(ctx, shadow)
+	if err != nil {
+		return res, err
+	}
+	res.From, res.To = current, current
+
+	sub, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return res, fmt.Errorf("reading migrations: %w", err)
+	}
+	provider, err := goose.NewProvider(goose.DialectSQLite3, shadow, sub, goose.WithDisableGlobalRegistry(true))
+	if err != nil {
+		return res, fmt.Errorf("creating migration provider: %w", err)
+	}
+	results, err := provider.Up(ctx)
+	var partial *goose.PartialError
+	if errors.As(err, &partial) {
+		results = partial.Applied
+	}
+	for _, r := range results {
+		res.To = r.Source.Version
+		for _, m := range pending {
+			if m.Version == r.Source.Version {
+				res.Applied = append(res.Applied, m)
+			}
+		}
+	}
+	if err != nil {
+		return res, fmt.Errorf("migrating copy: %w", err)
+	}
+
+	for _, q := range smokeQueries {
+		if err := drain(ctx, shadow, `SELECT `+q.columns+` FROM `+q.table); err != nil {
+			return res, fmt.Errorf("smoke query on %s: %w", q.table, err)
+		}
+		res.Checks++
+	}
+	var integrity string
+	if err := shadow.QueryRowContext(ctx, `PRAGMA integrity_check`).Scan(&integrity); err != nil {
+		return res, fmt.Errorf("checking integrity: %w", err)
+	}
+	if integrity != "ok" {
+		return res, fmt.Errorf("integrity check: %s", integrity)
+	}
+	res.Checks++
+	var violations int
+	if err := shadow.QueryRowContext(ctx, `SELECT COUNT(*) FROM pragma_foreign_key_check`).Scan(&violations); err != nil {
+		return res, fmt.Errorf("checking foreign keys: %w", err)
+	}
+	if violations > 0 {
+		return res, fmt.Errorf("foreign key check: %d rows reference missing rows", violations)
+	}
+	res.Checks++
+	return res, nil
+}
+
+// drain runs query and reads every row it returns.
+func drain(ctx context.Context, db *sql.DB, query string) error {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+	}
+	return rows.Err()
+}
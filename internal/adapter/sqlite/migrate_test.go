@@ -0,0 +1,105 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+)
+
+// openOutdated returns a database file migrated up to the migration before
+// the latest one, with a tenant.
+func openOutdated(t *testing.T) (*sql.DB, string, int64) {
+	t.Helper()
+	all, err := sqlite.Migrations()
+	if err != nil {
+		t.Fatalf("Migrations: %v", err)
+	}
+	previous := all[len(all)-2].Version
+
+	file := filepath.Join(t.TempDir(), "tenantiq.db")
+	db, err := sql.Open("sqlite", file)
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	provider, err := goose.NewProvider(goose.DialectSQLite3, db, os.DirFS("migrations"), goose.WithDisableGlobalRegistry(true))
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	if _, err := provider.UpTo(context.Background(), previous); err != nil {
+		t.Fatalf("UpTo(%d): %v", previous, err)
+	}
+	if _, err := db.Exec(`INSERT INTO tenants (id, name, slug, status, plan, created_at, updated_at)
+		VALUES ('ten_1', 'Acme', 'acme', 'active', 'free', '2026-01-01T00:00:00Z', '2026-01-01T00:00:00Z')`); err != nil {
+		t.Fatalf("inserting tenant: %v", err)
+	}
+	return db, file, previous
+}
+
+func TestCanary_AppliesPendingMigrationsToACopy(t *testing.T) {
+	db, file, previous := openOutdated(t)
+	ctx := context.Background()
+
+	current, pending, err := sqlite.PendingMigrations(ctx, db)
+	if err != nil || current != previous || len(pending) != 1 {
+		t.Fatalf("PendingMigrations = %d, %v, %v; want %d and one pending", current, pending, err, previous)
+	}
+
+	res, err := sqlite.Canary(ctx, db)
+	if err != nil {
+		t.Fatalf("Canary: %v", err)
+	}
+	if res.From != previous || res.To != pending[0].Version || len(res.Applied) != 1 || res.Checks == 0 {
+		t.Errorf("result = %+v, want the pending migration applied and checks run", res)
+	}
+	if v, err := sqlite.SchemaVersion(ctx, db); err != nil || v != previous {
+		t.Errorf("live schema version = %d, %v; want it unchanged at %d", v, err, previous)
+	}
+	db.Close()
+
+	repo, err := sqlite.New(file)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer repo.Close()
+	if _, err := repo.GetByID(ctx, "ten_1"); err != nil {
+		t.Errorf("GetByID after migrating: %v", err)
+	}
+}
+
+func TestCanary_FailureLeavesTheLiveSchemaUnchanged(t *testing.T) {
+	db, file, previous := openOutdated(t)
+	ctx := context.Background()
+	// A table the repositories read is gone: the smoke queries catch it.
+	if _, err := db.Exec(`DROP TABLE tenant_members`); err != nil {
+		t.Fatalf("dropping table: %v", err)
+	}
+
+	res, err := sqlite.Canary(ctx, db)
+	if err == nil || !strings.Contains(err.Error(), "tenant_members") {
+		t.Fatalf("Canary = %v, want the smoke query on tenant_members to fail", err)
+	}
+	if len(res.Applied) != 1 {
+		t.Errorf("applied = %v, want the pending migration applied to the copy", res.Applied)
+	}
+	db.Close()
+
+	if _, err := sqlite.New(file); err == nil || !strings.Contains(err.Error(), "canary") {
+		t.Fatalf("New = %v, want the canary failure", err)
+	}
+	db, err = sql.Open("sqlite", file)
+	if err != nil {
+		t.Fatalf("reopening database: %v", err)
+	}
+	defer db.Close()
+	if v, err := sqlite.SchemaVersion(ctx, db); err != nil || v != previous {
+		t.Errorf("live schema version = %d, %v; want it unchanged at %d", v, err, previous)
+	}
+}
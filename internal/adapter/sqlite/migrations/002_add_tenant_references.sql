@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE tenants ADD COLUMN pr_url        TEXT NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN git_branch    TEXT NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN external_refs TEXT NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE tenants DROP COLUMN external_refs;
+ALTER TABLE tenants DROP COLUMN git_branch;
+ALTER TABLE tenants DROP COLUMN pr_url;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE operations (
+    id         TEXT PRIMARY KEY,
+    kind       TEXT NOT NULL,
+    tenant_id  TEXT NOT NULL DEFAULT '',
+    status     TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'succeeded', 'failed')),
+    error      TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+);
+
+CREATE INDEX idx_operations_tenant_id ON operations (tenant_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS operations;
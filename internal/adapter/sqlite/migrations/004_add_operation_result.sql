@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE operations ADD COLUMN result TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_operations_created_at ON operations (created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_operations_created_at;
+ALTER TABLE operations DROP COLUMN result;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE tenant_status_history (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    tenant_id   TEXT NOT NULL,
+    from_status TEXT NOT NULL,
+    to_status   TEXT NOT NULL,
+    event       TEXT NOT NULL,
+    actor       TEXT NOT NULL DEFAULT 'system',
+    changed_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+);
+
+CREATE INDEX idx_tenant_status_history_tenant_id ON tenant_status_history (tenant_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS tenant_status_history;
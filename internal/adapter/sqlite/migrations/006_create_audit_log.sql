@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE audit_log (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    action      TEXT NOT NULL,
+    tenant_id   TEXT NOT NULL,
+    actor       TEXT NOT NULL DEFAULT 'system',
+    request_id  TEXT NOT NULL DEFAULT '',
+    event       TEXT NOT NULL DEFAULT '',
+    before      TEXT,
+    after       TEXT,
+    created_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+);
+
+CREATE INDEX idx_audit_log_tenant_id ON audit_log (tenant_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE resellers (
+    id           TEXT PRIMARY KEY,
+    name         TEXT NOT NULL,
+    tenant_quota INTEGER NOT NULL DEFAULT 0 CHECK (tenant_quota >= 0),
+    created_at   TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+);
+
+ALTER TABLE tenants ADD COLUMN reseller_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_tenants_reseller_id ON tenants (reseller_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tenants_reseller_id;
+ALTER TABLE tenants DROP COLUMN reseller_id;
+DROP TABLE IF EXISTS resellers;
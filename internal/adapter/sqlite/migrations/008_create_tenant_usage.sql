@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE tenant_usage (
+    tenant_id   TEXT NOT NULL,
+    metric      TEXT NOT NULL,
+    value       INTEGER NOT NULL,
+    reported_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    PRIMARY KEY (tenant_id, metric)
+);
+
+ALTER TABLE tenants ADD COLUMN suggested_plan TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE tenants DROP COLUMN suggested_plan;
+DROP TABLE IF EXISTS tenant_usage;
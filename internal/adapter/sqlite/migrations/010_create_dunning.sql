@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE dunning (
+    tenant_id        TEXT PRIMARY KEY,
+    invoice_id       TEXT NOT NULL DEFAULT '',
+    stage            TEXT NOT NULL,
+    suspended_tenant INTEGER NOT NULL DEFAULT 0,
+    started_at       TEXT NOT NULL,
+    next_step_at     TEXT NOT NULL DEFAULT '',
+    updated_at       TEXT NOT NULL
+);
+
+CREATE INDEX idx_dunning_next_step_at ON dunning (next_step_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_dunning_next_step_at;
+DROP TABLE IF EXISTS dunning;
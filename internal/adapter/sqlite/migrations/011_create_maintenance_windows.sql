@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE tenant_maintenance_windows (
+    tenant_id        TEXT NOT NULL,
+    weekday          INTEGER NOT NULL,
+    start_minute     INTEGER NOT NULL,
+    duration_minutes INTEGER NOT NULL
+);
+
+CREATE INDEX idx_tenant_maintenance_windows_tenant_id ON tenant_maintenance_windows (tenant_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tenant_maintenance_windows_tenant_id;
+DROP TABLE IF EXISTS tenant_maintenance_windows;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Time-range scans (retention pruning, growth reports, as_of lookups) stay
+-- index-only as the append-only tables grow.
+CREATE INDEX idx_audit_log_tenant_id_created_at ON audit_log (tenant_id, created_at);
+CREATE INDEX idx_audit_log_created_at ON audit_log (created_at);
+CREATE INDEX idx_tenant_status_history_changed_at ON tenant_status_history (changed_at);
+CREATE INDEX idx_operations_updated_at ON operations (updated_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_operations_updated_at;
+DROP INDEX IF EXISTS idx_tenant_status_history_changed_at;
+DROP INDEX IF EXISTS idx_audit_log_created_at;
+DROP INDEX IF EXISTS idx_audit_log_tenant_id_created_at;
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE outbox (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id   TEXT NOT NULL UNIQUE,
+    event      TEXT NOT NULL,
+    tenant     TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox;
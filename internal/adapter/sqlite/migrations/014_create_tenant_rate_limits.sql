@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE tenant_rate_limits (
+    tenant_id           TEXT PRIMARY KEY,
+    requests_per_second INTEGER NOT NULL,
+    burst               INTEGER NOT NULL,
+    updated_at          TEXT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS tenant_rate_limits;
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE signed_url_nonces (
+    nonce      TEXT PRIMARY KEY,
+    expires_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_signed_url_nonces_expires_at ON signed_url_nonces (expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_signed_url_nonces_expires_at;
+DROP TABLE IF EXISTS signed_url_nonces;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE tenants ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE tenants DROP COLUMN version;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE outbox ADD COLUMN priority TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE outbox DROP COLUMN priority;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Empty when the tenant is not on trial.
+ALTER TABLE tenants ADD COLUMN trial_ends_at TEXT NOT NULL DEFAULT '';
+CREATE INDEX idx_tenants_trial_ends_at ON tenants (trial_ends_at) WHERE trial_ends_at != '';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tenants_trial_ends_at;
+ALTER TABLE tenants DROP COLUMN trial_ends_at;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE plans (
+    name       TEXT PRIMARY KEY,
+    price      INTEGER NOT NULL DEFAULT 0 CHECK (price >= 0),
+    currency   TEXT NOT NULL DEFAULT 'USD',
+    limits     TEXT NOT NULL DEFAULT '{}',
+    features   TEXT NOT NULL DEFAULT '[]',
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+);
+
+-- Plans were free-form until now: define the default plan and every plan a
+-- tenant is on, so existing tenants stay valid. Typos show up in the list
+-- and can be fixed before the phantom plan is deleted.
+INSERT INTO plans (name) VALUES ('free');
+INSERT OR IGNORE INTO plans (name) SELECT DISTINCT plan FROM tenants WHERE plan != '';
+
+-- +goose Down
+DROP TABLE IF EXISTS plans;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE tenants ADD COLUMN simulated INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX idx_tenants_simulated ON tenants (simulated) WHERE simulated = 1;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tenants_simulated;
+ALTER TABLE tenants DROP COLUMN simulated;
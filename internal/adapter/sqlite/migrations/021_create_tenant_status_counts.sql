@@ -0,0 +1,42 @@
+-- +goose Up
+-- Number of tenants per status, kept current by triggers so counting
+-- tenants by status never scans the tenants table. The reconciliation job
+-- corrects any drift.
+CREATE TABLE tenant_status_counts (
+    status TEXT PRIMARY KEY,
+    count  INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT INTO tenant_status_counts (status, count)
+SELECT status, COUNT(*) FROM tenants GROUP BY status;
+
+-- +goose StatementBegin
+CREATE TRIGGER tenant_status_counts_insert AFTER INSERT ON tenants
+BEGIN
+    INSERT INTO tenant_status_counts (status, count) VALUES (NEW.status, 1)
+    ON CONFLICT (status) DO UPDATE SET count = count + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER tenant_status_counts_update AFTER UPDATE OF status ON tenants
+WHEN OLD.status != NEW.status
+BEGIN
+    UPDATE tenant_status_counts SET count = count - 1 WHERE status = OLD.status;
+    INSERT INTO tenant_status_counts (status, count) VALUES (NEW.status, 1)
+    ON CONFLICT (status) DO UPDATE SET count = count + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER tenant_status_counts_delete AFTER DELETE ON tenants
+BEGIN
+    UPDATE tenant_status_counts SET count = count - 1 WHERE status = OLD.status;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS tenant_status_counts_delete;
+DROP TRIGGER IF EXISTS tenant_status_counts_update;
+DROP TRIGGER IF EXISTS tenant_status_counts_insert;
+DROP TABLE IF EXISTS tenant_status_counts;
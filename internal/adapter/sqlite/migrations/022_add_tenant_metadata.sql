@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE tenants ADD COLUMN metadata TEXT NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE tenants DROP COLUMN metadata;
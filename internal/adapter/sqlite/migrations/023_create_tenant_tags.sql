@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE tenant_tags (
+    tenant_id TEXT NOT NULL,
+    tag       TEXT NOT NULL,
+    PRIMARY KEY (tenant_id, tag)
+);
+
+-- Tag filters look tenants up by tag.
+CREATE INDEX idx_tenant_tags_tag ON tenant_tags (tag, tenant_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS tenant_tags;
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE certificates (
+    domain     TEXT PRIMARY KEY,
+    tenant_id  TEXT NOT NULL,
+    status     TEXT NOT NULL,
+    issuer     TEXT NOT NULL DEFAULT '',
+    serial     TEXT NOT NULL DEFAULT '',
+    not_before TEXT NOT NULL DEFAULT '',
+    not_after  TEXT NOT NULL DEFAULT '',
+    renew_at   TEXT NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_certificates_tenant_id ON certificates (tenant_id);
+CREATE INDEX idx_certificates_renew_at ON certificates (renew_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_certificates_renew_at;
+DROP INDEX IF EXISTS idx_certificates_tenant_id;
+DROP TABLE IF EXISTS certificates;
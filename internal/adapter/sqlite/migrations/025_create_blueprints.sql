@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE blueprints (
+    name       TEXT PRIMARY KEY,
+    plan       TEXT NOT NULL,
+    metadata   TEXT NOT NULL DEFAULT '{}',
+    features   TEXT NOT NULL DEFAULT '{}',
+    variables  TEXT NOT NULL DEFAULT '{}',
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS blueprints;
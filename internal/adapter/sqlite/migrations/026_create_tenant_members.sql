@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE tenant_members (
+    id          TEXT PRIMARY KEY,
+    tenant_id   TEXT NOT NULL,
+    email       TEXT NOT NULL,
+    role        TEXT NOT NULL,
+    status      TEXT NOT NULL,
+    invited_by  TEXT NOT NULL DEFAULT '',
+    accepted_at TEXT NOT NULL DEFAULT '',
+    created_at  TEXT NOT NULL,
+    updated_at  TEXT NOT NULL,
+    UNIQUE (tenant_id, email)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS tenant_members;
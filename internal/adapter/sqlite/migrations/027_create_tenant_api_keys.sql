@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE tenant_api_keys (
+    id           TEXT PRIMARY KEY,
+    tenant_id    TEXT NOT NULL,
+    name         TEXT NOT NULL,
+    prefix       TEXT NOT NULL,
+    hash         TEXT NOT NULL UNIQUE,
+    scopes       TEXT NOT NULL DEFAULT '[]',
+    created_by   TEXT NOT NULL DEFAULT '',
+    created_at   TEXT NOT NULL,
+    expires_at   TEXT NOT NULL DEFAULT '',
+    last_used_at TEXT NOT NULL DEFAULT '',
+    revoked_at   TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_tenant_api_keys_tenant_id ON tenant_api_keys (tenant_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tenant_api_keys_tenant_id;
+DROP TABLE IF EXISTS tenant_api_keys;
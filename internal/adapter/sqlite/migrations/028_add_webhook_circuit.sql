@@ -0,0 +1,13 @@
+-- +goose Up
+-- The timestamps are empty until a delivery fails and while the circuit is
+-- closed.
+ALTER TABLE webhook_subscriptions ADD COLUMN consecutive_failures INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE webhook_subscriptions ADD COLUMN last_error TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhook_subscriptions ADD COLUMN last_failure_at TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhook_subscriptions ADD COLUMN circuit_opened_at TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE webhook_subscriptions DROP COLUMN circuit_opened_at;
+ALTER TABLE webhook_subscriptions DROP COLUMN last_failure_at;
+ALTER TABLE webhook_subscriptions DROP COLUMN last_error;
+ALTER TABLE webhook_subscriptions DROP COLUMN consecutive_failures;
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE admin_api_keys (
+    id           TEXT PRIMARY KEY,
+    name         TEXT NOT NULL,
+    prefix       TEXT NOT NULL,
+    hash         TEXT NOT NULL UNIQUE,
+    created_by   TEXT NOT NULL DEFAULT '',
+    created_at   TEXT NOT NULL,
+    last_used_at TEXT NOT NULL DEFAULT '',
+    revoked_at   TEXT NOT NULL DEFAULT ''
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS admin_api_keys;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Existing tenants have no region and existing plans are offered in every
+-- region.
+ALTER TABLE tenants ADD COLUMN region TEXT NOT NULL DEFAULT '';
+ALTER TABLE plans ADD COLUMN regions TEXT NOT NULL DEFAULT '[]';
+
+-- +goose Down
+ALTER TABLE plans DROP COLUMN regions;
+ALTER TABLE tenants DROP COLUMN region;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- The caller's identity at its identity provider, for changes requested
+-- with a token.
+ALTER TABLE audit_log ADD COLUMN subject TEXT NOT NULL DEFAULT '';
+ALTER TABLE audit_log ADD COLUMN issuer TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE audit_log DROP COLUMN issuer;
+ALTER TABLE audit_log DROP COLUMN subject;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- What the holder of an admin key may do. Keys created before roles keep
+-- every right.
+ALTER TABLE admin_api_keys ADD COLUMN role TEXT NOT NULL DEFAULT 'admin';
+
+-- +goose Down
+ALTER TABLE admin_api_keys DROP COLUMN role;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- The provisioning steps completed by an operation, for its retries to skip.
+CREATE TABLE operation_steps (
+    operation_id TEXT NOT NULL,
+    step         TEXT NOT NULL,
+    fingerprint  TEXT NOT NULL,
+    ref          TEXT NOT NULL DEFAULT '',
+    completed_at TEXT NOT NULL,
+    PRIMARY KEY (operation_id, step)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS operation_steps;
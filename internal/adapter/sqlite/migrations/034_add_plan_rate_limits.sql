@@ -0,0 +1,12 @@
+-- +goose Up
+-- The request rate of each plan's tenants; zero leaves them unlimited.
+-- The free and pro plans start at 60 and 600 requests a minute, with a
+-- minute's worth of burst.
+ALTER TABLE plans ADD COLUMN rate_limit_rps INTEGER NOT NULL DEFAULT 0 CHECK (rate_limit_rps >= 0);
+ALTER TABLE plans ADD COLUMN rate_limit_burst INTEGER NOT NULL DEFAULT 0 CHECK (rate_limit_burst >= 0);
+UPDATE plans SET rate_limit_rps = 1, rate_limit_burst = 60 WHERE name = 'free';
+UPDATE plans SET rate_limit_rps = 10, rate_limit_burst = 600 WHERE name = 'pro';
+
+-- +goose Down
+ALTER TABLE plans DROP COLUMN rate_limit_burst;
+ALTER TABLE plans DROP COLUMN rate_limit_rps;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- The KMS keys tenants brought to encrypt their data with, one per tenant.
+CREATE TABLE tenant_encryption_keys (
+    tenant_id  TEXT PRIMARY KEY,
+    key_ref    TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    rotated_at TEXT NOT NULL DEFAULT '',
+    revoked_at TEXT NOT NULL DEFAULT ''
+);
+
+-- Secrets of tenants, encrypted with a data key that key_ref wraps.
+CREATE TABLE tenant_secrets (
+    tenant_id   TEXT NOT NULL,
+    name        TEXT NOT NULL,
+    key_ref     TEXT NOT NULL,
+    wrapped_key BLOB NOT NULL,
+    ciphertext  BLOB NOT NULL,
+    updated_by  TEXT NOT NULL DEFAULT '',
+    created_at  TEXT NOT NULL,
+    updated_at  TEXT NOT NULL,
+    PRIMARY KEY (tenant_id, name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS tenant_secrets;
+DROP TABLE IF EXISTS tenant_encryption_keys;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- The networks each tenant's API keys may be used from, one row per
+-- network; tenants without rows are unrestricted.
+CREATE TABLE tenant_ip_allowlists (
+    tenant_id  TEXT NOT NULL,
+    cidr       TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    PRIMARY KEY (tenant_id, cidr)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS tenant_ip_allowlists;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- How each subscription receives its events: on their own, or batched
+-- into an hourly or daily digest.
+ALTER TABLE webhook_subscriptions ADD COLUMN digest TEXT NOT NULL DEFAULT 'immediate'
+    CHECK (digest IN ('immediate', 'hourly', 'daily'));
+
+-- Events held for the next digest of their subscription, as they would
+-- have been delivered on their own.
+CREATE TABLE webhook_digest_entries (
+    webhook_id  TEXT NOT NULL,
+    event_id    TEXT NOT NULL,
+    event       TEXT NOT NULL,
+    tenant_id   TEXT NOT NULL,
+    payload     BLOB NOT NULL,
+    occurred_at TEXT NOT NULL,
+    PRIMARY KEY (webhook_id, event_id)
+);
+CREATE INDEX idx_webhook_digest_entries_occurred ON webhook_digest_entries (webhook_id, occurred_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS webhook_digest_entries;
+ALTER TABLE webhook_subscriptions DROP COLUMN digest;
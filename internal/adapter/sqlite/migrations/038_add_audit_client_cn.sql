@@ -0,0 +1,7 @@
+-- +goose Up
+-- The common name of the caller's TLS client certificate, for changes
+-- requested over mutual TLS.
+ALTER TABLE audit_log ADD COLUMN client_cn TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE audit_log DROP COLUMN client_cn;
@@ -0,0 +1,128 @@
+-- +goose Up
+-- Statuses come from the lifecycle, which LIFECYCLE_FILE may extend, so
+-- the database no longer lists them. SQLite cannot drop a constraint: the
+-- table is rebuilt with its indexes and triggers.
+CREATE TABLE tenants_new (
+    id             TEXT PRIMARY KEY,
+    name           TEXT    NOT NULL,
+    slug           TEXT    NOT NULL UNIQUE,
+    status         TEXT    NOT NULL DEFAULT 'creating',
+    plan           TEXT    NOT NULL DEFAULT 'free',
+    created_at     TEXT    NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    updated_at     TEXT    NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    pr_url         TEXT    NOT NULL DEFAULT '',
+    git_branch     TEXT    NOT NULL DEFAULT '',
+    external_refs  TEXT    NOT NULL DEFAULT '{}',
+    reseller_id    TEXT    NOT NULL DEFAULT '',
+    suggested_plan TEXT    NOT NULL DEFAULT '',
+    version        INTEGER NOT NULL DEFAULT 1,
+    trial_ends_at  TEXT    NOT NULL DEFAULT '',
+    simulated      INTEGER NOT NULL DEFAULT 0,
+    metadata       TEXT    NOT NULL DEFAULT '{}',
+    region         TEXT    NOT NULL DEFAULT ''
+);
+
+INSERT INTO tenants_new
+    (id, name, slug, status, plan, created_at, updated_at, pr_url, git_branch, external_refs,
+     reseller_id, suggested_plan, version, trial_ends_at, simulated, metadata, region)
+SELECT id, name, slug, status, plan, created_at, updated_at, pr_url, git_branch, external_refs,
+       reseller_id, suggested_plan, version, trial_ends_at, simulated, metadata, region
+FROM tenants;
+
+DROP TABLE tenants;
+ALTER TABLE tenants_new RENAME TO tenants;
+
+CREATE INDEX idx_tenants_status ON tenants (status);
+CREATE INDEX idx_tenants_slug   ON tenants (slug);
+CREATE INDEX idx_tenants_reseller_id ON tenants (reseller_id);
+CREATE INDEX idx_tenants_trial_ends_at ON tenants (trial_ends_at) WHERE trial_ends_at != '';
+CREATE INDEX idx_tenants_simulated ON tenants (simulated) WHERE simulated = 1;
+
+-- +goose StatementBegin
+CREATE TRIGGER tenant_status_counts_insert AFTER INSERT ON tenants
+BEGIN
+    INSERT INTO tenant_status_counts (status, count) VALUES (NEW.status, 1)
+    ON CONFLICT (status) DO UPDATE SET count = count + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER tenant_status_counts_update AFTER UPDATE OF status ON tenants
+WHEN OLD.status != NEW.status
+BEGIN
+    UPDATE tenant_status_counts SET count = count - 1 WHERE status = OLD.status;
+    INSERT INTO tenant_status_counts (status, count) VALUES (NEW.status, 1)
+    ON CONFLICT (status) DO UPDATE SET count = count + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER tenant_status_counts_delete AFTER DELETE ON tenants
+BEGIN
+    UPDATE tenant_status_counts SET count = count - 1 WHERE status = OLD.status;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- Fails while tenants are in a custom status.
+CREATE TABLE tenants_old (
+    id             TEXT PRIMARY KEY,
+    name           TEXT    NOT NULL,
+    slug           TEXT    NOT NULL UNIQUE,
+    status         TEXT    NOT NULL DEFAULT 'creating'
+        CHECK (status IN ('creating', 'active', 'suspended', 'deleting', 'deleted')),
+    plan           TEXT    NOT NULL DEFAULT 'free',
+    created_at     TEXT    NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    updated_at     TEXT    NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    pr_url         TEXT    NOT NULL DEFAULT '',
+    git_branch     TEXT    NOT NULL DEFAULT '',
+    external_refs  TEXT    NOT NULL DEFAULT '{}',
+    reseller_id    TEXT    NOT NULL DEFAULT '',
+    suggested_plan TEXT    NOT NULL DEFAULT '',
+    version        INTEGER NOT NULL DEFAULT 1,
+    trial_ends_at  TEXT    NOT NULL DEFAULT '',
+    simulated      INTEGER NOT NULL DEFAULT 0,
+    metadata       TEXT    NOT NULL DEFAULT '{}',
+    region         TEXT    NOT NULL DEFAULT ''
+);
+
+INSERT INTO tenants_old
+    (id, name, slug, status, plan, created_at, updated_at, pr_url, git_branch, external_refs,
+     reseller_id, suggested_plan, version, trial_ends_at, simulated, metadata, region)
+SELECT id, name, slug, status, plan, created_at, updated_at, pr_url, git_branch, external_refs,
+       reseller_id, suggested_plan, version, trial_ends_at, simulated, metadata, region
+FROM tenants;
+
+DROP TABLE tenants;
+ALTER TABLE tenants_old RENAME TO tenants;
+
+CREATE INDEX idx_tenants_status ON tenants (status);
+CREATE INDEX idx_tenants_slug   ON tenants (slug);
+CREATE INDEX idx_tenants_reseller_id ON tenants (reseller_id);
+CREATE INDEX idx_tenants_trial_ends_at ON tenants (trial_ends_at) WHERE trial_ends_at != '';
+CREATE INDEX idx_tenants_simulated ON tenants (simulated) WHERE simulated = 1;
+
+-- +goose StatementBegin
+CREATE TRIGGER tenant_status_counts_insert AFTER INSERT ON tenants
+BEGIN
+    INSERT INTO tenant_status_counts (status, count) VALUES (NEW.status, 1)
+    ON CONFLICT (status) DO UPDATE SET count = count + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER tenant_status_counts_update AFTER UPDATE OF status ON tenants
+WHEN OLD.status != NEW.status
+BEGIN
+    UPDATE tenant_status_counts SET count = count - 1 WHERE status = OLD.status;
+    INSERT INTO tenant_status_counts (status, count) VALUES (NEW.status, 1)
+    ON CONFLICT (status) DO UPDATE SET count = count + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER tenant_status_counts_delete AFTER DELETE ON tenants
+BEGIN
+    UPDATE tenant_status_counts SET count = count - 1 WHERE status = OLD.status;
+END;
+-- +goose StatementEnd
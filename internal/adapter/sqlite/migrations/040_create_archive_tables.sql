@@ -0,0 +1,31 @@
+-- +goose Up
+-- Cold copies of the append-only tables: rows older than ARCHIVE_AFTER
+-- move here, out of the way of hot queries, until retention prunes them.
+-- They mirror their hot table column for column, so a column added to
+-- one must be added to the other.
+CREATE TABLE audit_log_archive AS SELECT * FROM audit_log WHERE 0;
+CREATE INDEX idx_audit_log_archive_created_at ON audit_log_archive (created_at);
+
+CREATE TABLE tenant_status_history_archive AS SELECT * FROM tenant_status_history WHERE 0;
+CREATE INDEX idx_tenant_status_history_archive_changed_at ON tenant_status_history_archive (changed_at);
+
+-- Finished River jobs, i.e. the published events and webhook deliveries.
+-- River creates river_job after these migrations run, so the columns
+-- worth keeping are spelled out.
+CREATE TABLE river_job_archive (
+    id           INTEGER PRIMARY KEY,
+    kind         TEXT NOT NULL,
+    args         BLOB NOT NULL,
+    state        TEXT NOT NULL,
+    attempt      INTEGER NOT NULL,
+    errors       BLOB,
+    metadata     BLOB NOT NULL,
+    created_at   TIMESTAMP NOT NULL,
+    finalized_at TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_river_job_archive_kind_finalized_at ON river_job_archive (kind, finalized_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS river_job_archive;
+DROP TABLE IF EXISTS tenant_status_history_archive;
+DROP TABLE IF EXISTS audit_log_archive;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- The read-only mode, in a single row shared by every instance; no row
+-- means it was never switched.
+CREATE TABLE read_only_mode (
+    id      INTEGER PRIMARY KEY CHECK (id = 1),
+    enabled INTEGER NOT NULL,
+    reason  TEXT NOT NULL DEFAULT '',
+    since   TEXT NOT NULL,
+    actor   TEXT NOT NULL DEFAULT ''
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS read_only_mode;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- History, as-of and change feed queries read the archives along with
+-- their hot tables, by the keys the hot tables are read by.
+CREATE INDEX idx_audit_log_archive_id ON audit_log_archive (id);
+CREATE INDEX idx_audit_log_archive_tenant_created_at ON audit_log_archive (tenant_id, created_at);
+CREATE INDEX idx_tenant_status_history_archive_tenant_id ON tenant_status_history_archive (tenant_id, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tenant_status_history_archive_tenant_id;
+DROP INDEX IF EXISTS idx_audit_log_archive_tenant_created_at;
+DROP INDEX IF EXISTS idx_audit_log_archive_id;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- The changed attributes of change events, as JSON; empty for the others.
+ALTER TABLE outbox ADD COLUMN changes TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE outbox DROP COLUMN changes;
@@ -0,0 +1,46 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: NonceStore implements domain.NonceStore.
+var _ domain.NonceStore = (*NonceStore)(nil)
+
+// NonceStore implements domain.NonceStore using SQLite, so single-use
+// signed URLs are consumed once across every replica sharing the database.
+// Expired nonces are deleted as new ones are recorded. It shares the
+// tenants database, whose migrations create its table.
+type NonceStore struct {
+	db *sql.DB
+}
+
+// NewNonceStore wraps a database already migrated by New or NewFromDB.
+func NewNonceStore(db *sql.DB) *NonceStore {
+	return &NonceStore{db: db}
+}
+
+func (s *NonceStore) Use(ctx context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	now := time.Now().UTC().Format(timeFormat)
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM signed_url_nonces WHERE expires_at < ?`, now); err != nil {
+		return false, fmt.Errorf("deleting expired nonces: %w", err)
+	}
+
+	result, err := s.db.ExecContext(ctx,
+		`INSERT INTO signed_url_nonces (nonce, expires_at) VALUES (?, ?) ON CONFLICT (nonce) DO NOTHING`,
+		nonce, expiresAt.UTC().Format(timeFormat),
+	)
+	if err != nil {
+		return false, fmt.Errorf("recording nonce: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("checking rows affected: %w", err)
+	}
+	return n == 1, nil
+}
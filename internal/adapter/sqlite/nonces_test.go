@@ -0,0 +1,46 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+)
+
+func TestNonceStore_UsesOnce(t *testing.T) {
+	store := sqlite.NewNonceStore(newTestRepo(t).DB())
+	ctx := context.Background()
+	expires := time.Now().Add(time.Hour)
+
+	if unused, err := store.Use(ctx, "n1", expires); err != nil || !unused {
+		t.Fatalf("first use = %v, %v; want unused", unused, err)
+	}
+	if unused, err := store.Use(ctx, "n1", expires); err != nil || unused {
+		t.Errorf("second use = %v, %v; want used", unused, err)
+	}
+	if unused, _ := store.Use(ctx, "n2", expires); !unused {
+		t.Error("another nonce should be unused")
+	}
+}
+
+func TestNonceStore_ForgetsExpired(t *testing.T) {
+	db := newTestRepo(t).DB()
+	store := sqlite.NewNonceStore(db)
+	ctx := context.Background()
+
+	if _, err := store.Use(ctx, "old", time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("Use: %v", err)
+	}
+	if _, err := store.Use(ctx, "new", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Use: %v", err)
+	}
+
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM signed_url_nonces`).Scan(&n); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("stored nonces = %d, want the expired one deleted", n)
+	}
+}
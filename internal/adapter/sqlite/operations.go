@@ -0,0 +1,167 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: OperationRepository implements domain.OperationRepository.
+var _ domain.OperationRepository = (*OperationRepository)(nil)
+
+// OperationRepository implements domain.OperationRepository using SQLite.
+// It shares the tenants database, whose migrations create its table.
+type OperationRepository struct {
+	db *sql.DB
+}
+
+// NewOperationRepository wraps a database already migrated by New or NewFromDB.
+func NewOperationRepository(db *sql.DB) *OperationRepository {
+	return &OperationRepository{db: db}
+}
+
+// operationColumns lists the operation columns in the order expected by scanOperation.
+const operationColumns = `id, kind, tenant_id, status, error, result, created_at, updated_at`
+
+func (r *OperationRepository) Create(ctx context.Context, op domain.Operation) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO operations (`+operationColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		op.ID, string(op.Kind), op.TenantID, string(op.Status), op.Error, op.Result,
+		op.CreatedAt.Format(timeFormat),
+		op.UpdatedAt.Format(timeFormat),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting operation: %w", err)
+	}
+	return nil
+}
+
+func (r *OperationRepository) GetByID(ctx context.Context, id string) (domain.Operation, error) {
+	op, err := scanOperation(r.db.QueryRowContext(ctx,
+		`SELECT `+operationColumns+` FROM operations WHERE id = ?`, id,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Operation{}, domain.ErrOperationNotFound
+		}
+		return domain.Operation{}, fmt.Errorf("scanning operation: %w", err)
+	}
+	return op, nil
+}
+
+func (r *OperationRepository) Update(ctx context.Context, op domain.Operation) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE operations SET status = ?, error = ?, result = ?, updated_at = ? WHERE id = ?`,
+		string(op.Status), op.Error, op.Result, op.UpdatedAt.Format(timeFormat), op.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("updating operation: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("checking rows affected: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrOperationNotFound
+	}
+	return nil
+}
+
+func (r *OperationRepository) List(ctx context.Context, filter domain.OperationFilter) ([]domain.Operation, error) {
+	where, args := operationWhereClause(filter)
+	// rowid breaks ties between operations started within the same second.
+	query := `SELECT ` + operationColumns + ` FROM operations` + where + ` ORDER BY created_at DESC, rowid DESC`
+
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	} else if filter.Offset > 0 {
+		// SQLite takes an OFFSET only after a LIMIT; -1 is no limit.
+		query += ` LIMIT -1`
+	}
+
+	if filter.Offset > 0 {
+		query += ` OFFSET ?`
+		args = append(args, filter.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing operations: %w", err)
+	}
+	defer rows.Close()
+
+	var ops []domain.Operation
+	for rows.Next() {
+		op, err := scanOperation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning operation: %w", err)
+		}
+		ops = append(ops, op)
+	}
+	return ops, rows.Err()
+}
+
+func (r *OperationRepository) Count(ctx context.Context, filter domain.OperationFilter) (int, error) {
+	where, args := operationWhereClause(filter)
+
+	var n int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM operations`+where, args...).Scan(&n); err != nil {
+		return 0, fmt.Errorf("counting operations: %w", err)
+	}
+	return n, nil
+}
+
+// operationWhereClause builds the WHERE clause shared by List and Count.
+func operationWhereClause(filter domain.OperationFilter) (string, []any) {
+	var conds []string
+	var args []any
+
+	if filter.TenantID != "" {
+		conds = append(conds, `tenant_id = ?`)
+		args = append(args, filter.TenantID)
+	}
+
+	if len(filter.Kinds) > 0 {
+		conds = append(conds, `kind IN (`+placeholders(len(filter.Kinds))+`)`)
+		for _, k := range filter.Kinds {
+			args = append(args, string(k))
+		}
+	}
+
+	if len(filter.Statuses) > 0 {
+		conds = append(conds, `status IN (`+placeholders(len(filter.Statuses))+`)`)
+		for _, st := range filter.Statuses {
+			args = append(args, string(st))
+		}
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return ` WHERE ` + strings.Join(conds, ` AND `), args
+}
+
+func scanOperation(row rowScanner) (domain.Operation, error) {
+	var (
+		op                   domain.Operation
+		kind, status         string
+		createdAt, updatedAt string
+	)
+	err := row.Scan(&op.ID, &kind, &op.TenantID, &status, &op.Error, &op.Result, &createdAt, &updatedAt)
+	if err != nil {
+		return domain.Operation{}, err
+	}
+
+	op.Kind = domain.OperationKind(kind)
+	op.Status = domain.OperationStatus(status)
+	op.CreatedAt, _ = time.Parse(timeFormat, createdAt)
+	op.UpdatedAt, _ = time.Parse(timeFormat, updatedAt)
+	return op, nil
+}
@@ -0,0 +1,137 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func newTestOperations(t *testing.T) *sqlite.OperationRepository {
+	t.Helper()
+	return sqlite.NewOperationRepository(newTestRepo(t).DB())
+}
+
+func TestOperation_CreateAndGet(t *testing.T) {
+	ops := newTestOperations(t)
+	ctx := context.Background()
+
+	op := domain.NewOperation("op_1", domain.OperationProvision, "ten_1")
+	if err := ops.Create(ctx, op); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	got, err := ops.GetByID(ctx, "op_1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Kind != domain.OperationProvision || got.TenantID != "ten_1" || got.Status != domain.OperationPending {
+		t.Errorf("got %+v", got)
+	}
+	if got.CreatedAt.IsZero() {
+		t.Error("CreatedAt should not be zero")
+	}
+}
+
+func TestOperation_Update(t *testing.T) {
+	ops := newTestOperations(t)
+	ctx := context.Background()
+
+	op := domain.NewOperation("op_1", domain.OperationProvision, "ten_1")
+	if err := ops.Create(ctx, op); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := ops.Update(ctx, op.Fail("tenant not found")); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	got, err := ops.GetByID(ctx, "op_1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Status != domain.OperationFailed || got.Error != "tenant not found" {
+		t.Errorf("got %+v, want failed", got)
+	}
+}
+
+func TestOperation_NotFound(t *testing.T) {
+	ops := newTestOperations(t)
+	ctx := context.Background()
+
+	if _, err := ops.GetByID(ctx, "op_missing"); !errors.Is(err, domain.ErrOperationNotFound) {
+		t.Errorf("GetByID: expected ErrOperationNotFound, got %v", err)
+	}
+	op := domain.NewOperation("op_missing", domain.OperationProvision, "ten_1")
+	if err := ops.Update(ctx, op); !errors.Is(err, domain.ErrOperationNotFound) {
+		t.Errorf("Update: expected ErrOperationNotFound, got %v", err)
+	}
+}
+
+func TestOperation_SucceedStoresResult(t *testing.T) {
+	ops := newTestOperations(t)
+	ctx := context.Background()
+
+	op := domain.NewOperation("op_1", domain.OperationProvision, "ten_1")
+	if err := ops.Create(ctx, op); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := ops.Update(ctx, op.Succeed("ten_1")); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	got, _ := ops.GetByID(ctx, "op_1")
+	if got.Status != domain.OperationSucceeded || got.Result != "ten_1" {
+		t.Errorf("got %+v, want succeeded with result", got)
+	}
+}
+
+func TestOperation_ListAndCount(t *testing.T) {
+	ops := newTestOperations(t)
+	ctx := context.Background()
+
+	for _, op := range []domain.Operation{
+		domain.NewOperation("op_1", domain.OperationProvision, "ten_1"),
+		domain.NewOperation("op_2", domain.OperationDeletion, "ten_1"),
+		domain.NewOperation("op_3", domain.OperationProvision, "ten_2").Fail("boom"),
+	} {
+		if err := ops.Create(ctx, op); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	all, err := ops.List(ctx, domain.OperationFilter{})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(all) != 3 || all[0].ID != "op_3" {
+		t.Errorf("List() = %+v, want 3 operations newest first", all)
+	}
+
+	cases := []struct {
+		name   string
+		filter domain.OperationFilter
+		want   int
+	}{
+		{"tenant", domain.OperationFilter{TenantID: "ten_1"}, 2},
+		{"kind", domain.OperationFilter{Kinds: []domain.OperationKind{domain.OperationProvision}}, 2},
+		{"status", domain.OperationFilter{Statuses: []domain.OperationStatus{domain.OperationFailed}}, 1},
+		{"combined", domain.OperationFilter{TenantID: "ten_1", Kinds: []domain.OperationKind{domain.OperationDeletion}}, 1},
+	}
+	for _, tc := range cases {
+		n, err := ops.Count(ctx, tc.filter)
+		if err != nil {
+			t.Fatalf("%s: Count failed: %v", tc.name, err)
+		}
+		if n != tc.want {
+			t.Errorf("%s: Count = %d, want %d", tc.name, n, tc.want)
+		}
+	}
+
+	page, _ := ops.List(ctx, domain.OperationFilter{Limit: 1, Offset: 1})
+	if len(page) != 1 || page[0].ID != "op_2" {
+		t.Errorf("page = %+v, want op_2", page)
+	}
+}
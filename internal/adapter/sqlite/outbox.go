@@ -0,0 +1,151 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: Outbox implements domain.Outbox.
+var _ domain.Outbox = (*Outbox)(nil)
+
+// Outbox implements domain.Outbox using SQLite. It writes to the tenants
+// table of the same database, so the tenant and its event share a
+// transaction.
+type Outbox struct {
+	db *sql.DB
+}
+
+// NewOutbox wraps a database already migrated by New or NewFromDB.
+func NewOutbox(db *sql.DB) *Outbox {
+	return &Outbox{db: db}
+}
+
+// outboxChange is the stored JSON form of a domain.FieldChange.
+type outboxChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+func (o *Outbox) CreateTenant(ctx context.Context, tenant domain.Tenant, msgs ...domain.OutboxMessage) error {
+	return o.withMessages(ctx, msgs, func(tx *sql.Tx) error {
+		return insert(ctx, tx, tenant)
+	})
+}
+
+func (o *Outbox) UpdateTenant(ctx context.Context, tenant domain.Tenant, msgs ...domain.OutboxMessage) error {
+	return o.withMessages(ctx, msgs, func(tx *sql.Tx) error {
+		return update(ctx, tx, tenant)
+	})
+}
+
+// withMessages runs change and records msgs in one transaction.
+func (o *Outbox) withMessages(ctx context.Context, msgs []domain.OutboxMessage, change func(*sql.Tx) error) error {
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // no-op after Commit
+
+	if err := change(tx); err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		tenant, err := marshalSnapshot(&msg.Tenant)
+		if err != nil {
+			return err
+		}
+		changes, err := marshalChanges(msg.Changes)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO outbox (event_id, event, tenant, changes, priority, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+			msg.EventID, string(msg.Event), tenant.String, changes, string(msg.Priority), msg.CreatedAt.UTC().Format(timeFormat),
+		); err != nil {
+			return fmt.Errorf("inserting outbox message: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+func (o *Outbox) Pending(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	rows, err := o.db.QueryContext(ctx,
+		`SELECT id, event_id, event, tenant, changes, priority, created_at FROM outbox ORDER BY id LIMIT ?`, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying outbox: %w", err)
+	}
+	defer rows.Close()
+
+	var msgs []domain.OutboxMessage
+	for rows.Next() {
+		var (
+			msg                                       domain.OutboxMessage
+			event, tenant, changes, priority, created string
+		)
+		if err := rows.Scan(&msg.ID, &msg.EventID, &event, &tenant, &changes, &priority, &created); err != nil {
+			return nil, fmt.Errorf("scanning outbox message: %w", err)
+		}
+		msg.Event = domain.Event(event)
+		msg.Priority = domain.Priority(priority)
+		if msg.Tenant, err = unmarshalSnapshot(tenant); err != nil {
+			return nil, err
+		}
+		if msg.Changes, err = unmarshalChanges(changes); err != nil {
+			return nil, err
+		}
+		msg.CreatedAt, _ = time.Parse(timeFormat, created)
+		msgs = append(msgs, msg)
+	}
+	return msgs, rows.Err()
+}
+
+func (o *Outbox) Delete(ctx context.Context, id int64) error {
+	if _, err := o.db.ExecContext(ctx, `DELETE FROM outbox WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("deleting outbox message: %w", err)
+	}
+	return nil
+}
+
+// marshalChanges returns the JSON for changes, or an empty string when
+// there are none.
+func marshalChanges(changes []domain.FieldChange) (string, error) {
+	if len(changes) == 0 {
+		return "", nil
+	}
+	stored := make([]outboxChange, len(changes))
+	for i, c := range changes {
+		stored[i] = outboxChange(c)
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return "", fmt.Errorf("encoding changes: %w", err)
+	}
+	return string(data), nil
+}
+
+// unmarshalChanges decodes changes stored by marshalChanges.
+func unmarshalChanges(data string) ([]domain.FieldChange, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var stored []outboxChange
+	if err := json.Unmarshal([]byte(data), &stored); err != nil {
+		return nil, fmt.Errorf("decoding changes: %w", err)
+	}
+	changes := make([]domain.FieldChange, len(stored))
+	for i, c := range stored {
+		changes[i] = domain.FieldChange(c)
+	}
+	return changes, nil
+}
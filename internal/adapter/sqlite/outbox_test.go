@@ -0,0 +1,69 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestOutbox_StoresTenantAndMessage(t *testing.T) {
+	repo := newTestRepo(t)
+	outbox := sqlite.NewOutbox(repo.DB())
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "pro")
+	if err := outbox.CreateTenant(ctx, tenant, domain.OutboxMessage{EventID: "evt_1", Event: domain.EventProvisionComplete, Tenant: tenant, Priority: domain.PriorityHigh, CreatedAt: now}); err != nil {
+		t.Fatalf("CreateTenant failed: %v", err)
+	}
+	tenant.Status = domain.StatusActive
+	if err := outbox.UpdateTenant(ctx, tenant,
+		domain.OutboxMessage{EventID: "evt_2", Event: domain.EventProvisionComplete, Tenant: tenant, CreatedAt: now},
+		domain.OutboxMessage{EventID: "evt_3", Event: domain.EventPlanChanged, Tenant: tenant, Changes: []domain.FieldChange{{Field: "plan", Before: "free", After: "pro"}}, CreatedAt: now}); err != nil {
+		t.Fatalf("UpdateTenant failed: %v", err)
+	}
+
+	if got, err := repo.GetByID(ctx, "ten_1"); err != nil || got.Status != domain.StatusActive {
+		t.Fatalf("GetByID = %+v, %v; want the active tenant", got, err)
+	}
+
+	msgs, err := outbox.Pending(ctx, 10)
+	if err != nil {
+		t.Fatalf("Pending failed: %v", err)
+	}
+	if len(msgs) != 3 || msgs[0].EventID != "evt_1" || msgs[1].Tenant.Status != domain.StatusActive || !msgs[0].CreatedAt.Equal(now) ||
+		msgs[0].Priority != domain.PriorityHigh || msgs[1].Priority != "" || msgs[1].Changes != nil {
+		t.Fatalf("Pending = %+v", msgs)
+	}
+	if want := []domain.FieldChange{{Field: "plan", Before: "free", After: "pro"}}; !reflect.DeepEqual(msgs[2].Changes, want) {
+		t.Errorf("Changes = %+v, want %+v", msgs[2].Changes, want)
+	}
+
+	if err := outbox.Delete(ctx, msgs[0].ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if msgs, _ := outbox.Pending(ctx, 10); len(msgs) != 2 || msgs[0].EventID != "evt_2" {
+		t.Errorf("after Delete, Pending = %+v", msgs)
+	}
+}
+
+func TestOutbox_FailedChangeRecordsNothing(t *testing.T) {
+	repo := newTestRepo(t)
+	outbox := sqlite.NewOutbox(repo.DB())
+	ctx := context.Background()
+
+	missing := domain.NewTenant("ten_missing", "Ghost", "ghost", "free")
+	err := outbox.UpdateTenant(ctx, missing, domain.OutboxMessage{EventID: "evt_1", Event: domain.EventSuspend, Tenant: missing})
+	if !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Fatalf("UpdateTenant error = %v, want ErrTenantNotFound", err)
+	}
+
+	if msgs, _ := outbox.Pending(ctx, 10); len(msgs) != 0 {
+		t.Errorf("Pending = %+v, want no message for a change that failed", msgs)
+	}
+}
@@ -0,0 +1,165 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: PlanRepository implements domain.PlanRepository.
+var _ domain.PlanRepository = (*PlanRepository)(nil)
+
+// PlanRepository implements domain.PlanRepository using SQLite. Limits,
+// features and regions are stored as JSON.
+type PlanRepository struct {
+	db *sql.DB
+}
+
+// NewPlanRepository wraps a database already migrated by New or NewFromDB.
+func NewPlanRepository(db *sql.DB) *PlanRepository {
+	return &PlanRepository{db: db}
+}
+
+const planColumns = `name, price, currency, limits, features, regions, rate_limit_rps, rate_limit_burst, created_at, updated_at`
+
+func (r *PlanRepository) Create(ctx context.Context, p domain.Plan) error {
+	limits, features, regions, err := encodePlan(p)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO plans (`+planColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		p.Name, p.Price, p.Currency, limits, features, regions, p.RateLimit.RequestsPerSecond, p.RateLimit.Burst,
+		p.CreatedAt.Format(timeFormat), p.UpdatedAt.Format(timeFormat),
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return &domain.PlanConflictError{Name: p.Name}
+		}
+		return fmt.Errorf("inserting plan: %w", err)
+	}
+	return nil
+}
+
+func (r *PlanRepository) Get(ctx context.Context, name string) (domain.Plan, error) {
+	p, err := scanPlan(r.db.QueryRowContext(ctx,
+		`SELECT `+planColumns+` FROM plans WHERE name = ?`, name,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Plan{}, domain.ErrPlanNotFound
+		}
+		return domain.Plan{}, fmt.Errorf("scanning plan: %w", err)
+	}
+	return p, nil
+}
+
+func (r *PlanRepository) List(ctx context.Context) ([]domain.Plan, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+planColumns+` FROM plans ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("querying plans: %w", err)
+	}
+	defer rows.Close()
+
+	var plans []domain.Plan
+	for rows.Next() {
+		p, err := scanPlan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning plan: %w", err)
+		}
+		plans = append(plans, p)
+	}
+	return plans, rows.Err()
+}
+
+func (r *PlanRepository) Update(ctx context.Context, p domain.Plan) error {
+	limits, features, regions, err := encodePlan(p)
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE plans SET price = ?, currency = ?, limits = ?, features = ?, regions = ?, rate_limit_rps = ?, rate_limit_burst = ?,
+		 updated_at = ? WHERE name = ?`,
+		p.Price, p.Currency, limits, features, regions, p.RateLimit.RequestsPerSecond, p.RateLimit.Burst,
+		p.UpdatedAt.Format(timeFormat), p.Name,
+	)
+	if err != nil {
+		return fmt.Errorf("updating plan: %w", err)
+	}
+	return requireRow(result, domain.ErrPlanNotFound)
+}
+
+func (r *PlanRepository) Delete(ctx context.Context, name string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM plans WHERE name = ?`, name)
+	if err != nil {
+		return fmt.Errorf("deleting plan: %w", err)
+	}
+	return requireRow(result, domain.ErrPlanNotFound)
+}
+
+func encodePlan(p domain.Plan) (limits, features, regions string, err error) {
+	l := p.Limits
+	if l == nil {
+		l = map[string]int64{}
+	}
+	f := p.Features
+	if f == nil {
+		f = []string{}
+	}
+	rg := p.Regions
+	if rg == nil {
+		rg = []string{}
+	}
+	lb, err := json.Marshal(l)
+	if err != nil {
+		return "", "", "", fmt.Errorf("encoding plan limits: %w", err)
+	}
+	fb, err := json.Marshal(f)
+	if err != nil {
+		return "", "", "", fmt.Errorf("encoding plan features: %w", err)
+	}
+	rb, err := json.Marshal(rg)
+	if err != nil {
+		return "", "", "", fmt.Errorf("encoding plan regions: %w", err)
+	}
+	return string(lb), string(fb), string(rb), nil
+}
+
+func scanPlan(row rowScanner) (domain.Plan, error) {
+	var (
+		p                         domain.Plan
+		limits, features, regions string
+		createdAt, updatedAt      string
+	)
+	err := row.Scan(&p.Name, &p.Price, &p.Currency, &limits, &features, &regions,
+		&p.RateLimit.RequestsPerSecond, &p.RateLimit.Burst, &createdAt, &updatedAt)
+	if err != nil {
+		return domain.Plan{}, err
+	}
+	if err := json.Unmarshal([]byte(limits), &p.Limits); err != nil {
+		return domain.Plan{}, fmt.Errorf("decoding plan limits: %w", err)
+	}
+	if err := json.Unmarshal([]byte(features), &p.Features); err != nil {
+		return domain.Plan{}, fmt.Errorf("decoding plan features: %w", err)
+	}
+	if err := json.Unmarshal([]byte(regions), &p.Regions); err != nil {
+		return domain.Plan{}, fmt.Errorf("decoding plan regions: %w", err)
+	}
+	if len(p.Limits) == 0 {
+		p.Limits = nil
+	}
+	if len(p.Features) == 0 {
+		p.Features = nil
+	}
+	if len(p.Regions) == 0 {
+		p.Regions = nil
+	}
+	p.CreatedAt, _ = time.Parse(timeFormat, createdAt)
+	p.UpdatedAt, _ = time.Parse(timeFormat, updatedAt)
+	return p, nil
+}
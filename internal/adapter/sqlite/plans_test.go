@@ -0,0 +1,76 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestPlans_CRUD(t *testing.T) {
+	plans := sqlite.NewPlanRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	pro, err := domain.NewPlan("pro", 4900, "EUR", map[string]int64{"seats": 50}, []string{"sso"})
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	if err := plans.Create(ctx, pro); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	var conflict *domain.PlanConflictError
+	if err := plans.Create(ctx, pro); !errors.As(err, &conflict) {
+		t.Errorf("second Create = %v, want *PlanConflictError", err)
+	}
+
+	got, err := plans.Get(ctx, "pro")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Price != 4900 || got.Currency != "EUR" || got.Limits["seats"] != 50 || len(got.Features) != 1 || got.CreatedAt.IsZero() {
+		t.Errorf("got %+v", got)
+	}
+
+	if got.Regions != nil {
+		t.Errorf("Regions = %v, want none: offered everywhere", got.Regions)
+	}
+
+	if !got.RateLimit.IsZero() {
+		t.Errorf("RateLimit = %+v, want none", got.RateLimit)
+	}
+
+	got.Price = 5900
+	got.Features = nil
+	got.RateLimit = domain.RateLimit{RequestsPerSecond: 10, Burst: 600}
+	got.Regions = []string{"eu", "uk"}
+	if err := plans.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	all, err := plans.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	// The migration defines the default plan.
+	if len(all) != 2 || all[0].Name != "free" || all[1].Price != 5900 || all[1].Features != nil {
+		t.Errorf("List = %+v, want free and the updated pro", all)
+	}
+	if len(all) == 2 && (len(all[1].Regions) != 2 || all[1].Regions[0] != "eu" || all[0].Regions != nil) {
+		t.Errorf("regions = %v and %v, want none for free and eu, uk for pro", all[0].Regions, all[1].Regions)
+	}
+	// The migration starts the free plan at 60 requests a minute.
+	if len(all) == 2 && (all[0].RateLimit != domain.RateLimit{RequestsPerSecond: 1, Burst: 60} || all[1].RateLimit.Burst != 600) {
+		t.Errorf("rate limits = %+v and %+v, want 1/60 for free and 10/600 for pro", all[0].RateLimit, all[1].RateLimit)
+	}
+
+	if err := plans.Delete(ctx, "pro"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := plans.Get(ctx, "pro"); !errors.Is(err, domain.ErrPlanNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrPlanNotFound", err)
+	}
+	if err := plans.Update(ctx, pro); !errors.Is(err, domain.ErrPlanNotFound) {
+		t.Errorf("Update of a missing plan = %v, want ErrPlanNotFound", err)
+	}
+}
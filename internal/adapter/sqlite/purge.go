@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: TenantRepository implements domain.TenantPurger.
+var _ domain.TenantPurger = (*TenantRepository)(nil)
+
+// purgedTables hold records keyed by tenant that are meaningless once the
+// tenant is gone. The audit log and status history are left to retention.
+var purgedTables = []string{"tenant_usage", "tenant_maintenance_windows", "tenant_rate_limits", "dunning", "tenant_tags", "certificates", "tenant_members", "tenant_api_keys", "tenant_secrets", "tenant_encryption_keys", "tenant_ip_allowlists"}
+
+// Purge deletes the tenant and its records in purgedTables in one
+// transaction.
+func (r *TenantRepository) Purge(ctx context.Context, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // no-op after Commit
+
+	for _, table := range purgedTables {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE tenant_id = ?`, id); err != nil {
+			return fmt.Errorf("purging %s: %w", table, err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM tenants WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("purging tenant: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("checking rows affected: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrTenantNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestPurge_RemovesTenantAndItsRecords(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+	acme := domain.NewTenant("ten_1", "Acme", "acme", "pro")
+	acme.Tags = []string{"beta"}
+	mustCreate(t, repo, acme)
+	mustCreate(t, repo, domain.NewTenant("ten_2", "Globex", "globex", "pro"))
+
+	usage := sqlite.NewUsageRepository(repo.DB())
+	limits := sqlite.NewRateLimitRepository(repo.DB())
+	for _, id := range []string{"ten_1", "ten_2"} {
+		if err := usage.Record(ctx, id, domain.Usage{"seats": 3}); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+		if err := limits.Set(ctx, id, domain.RateLimit{RequestsPerSecond: 10, Burst: 10}); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+	}
+
+	certs := sqlite.NewCertificateRepository(repo.DB())
+	if err := certs.Save(ctx, domain.NewCertificate("app.acme.com", "ten_1", time.Now())); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	members := sqlite.NewMemberRepository(repo.DB())
+	bob, _ := domain.NewMember("mem_1", "ten_1", "bob@acme.com", domain.MemberRoleOwner, "api")
+	if err := members.Create(ctx, bob); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	keys := sqlite.NewAPIKeyRepository(repo.DB())
+	key, _ := domain.NewAPIKey("key_1", "ten_1", "CI", "tqk_secret", nil, time.Time{}, "api")
+	if err := keys.Create(ctx, key); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if err := repo.Purge(ctx, "ten_1"); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+
+	if _, err := repo.GetByID(ctx, "ten_1"); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("GetByID = %v, want ErrTenantNotFound", err)
+	}
+	if got, _ := usage.Get(ctx, "ten_1"); len(got) != 0 {
+		t.Errorf("usage of the purged tenant = %v, want none", got)
+	}
+	all, err := limits.All(ctx)
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if _, ok := all["ten_1"]; ok || len(all) != 1 {
+		t.Errorf("rate limits = %v, want only ten_2's", all)
+	}
+	if _, err := certs.Get(ctx, "app.acme.com"); !errors.Is(err, domain.ErrCertificateNotFound) {
+		t.Errorf("certificate of the purged tenant: Get = %v, want ErrCertificateNotFound", err)
+	}
+	if list, _ := members.ListByTenant(ctx, "ten_1"); len(list) != 0 {
+		t.Errorf("members of the purged tenant = %+v, want none", list)
+	}
+	if _, err := keys.GetByHash(ctx, key.Hash); !errors.Is(err, domain.ErrAPIKeyNotFound) {
+		t.Errorf("API key of the purged tenant: GetByHash = %v, want ErrAPIKeyNotFound", err)
+	}
+
+	if err := repo.Purge(ctx, "ten_1"); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("second Purge = %v, want ErrTenantNotFound", err)
+	}
+
+	// Nothing is left for a tenant imported again under the same ID.
+	mustCreate(t, repo, domain.NewTenant("ten_1", "Acme", "acme", "pro"))
+	if n, _ := repo.Count(ctx, domain.ListFilter{Tags: []string{"beta"}}); n != 0 {
+		t.Errorf("tenants tagged beta = %d, want the purged tenant's tags gone", n)
+	}
+}
@@ -0,0 +1,81 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: RateLimitRepository implements domain.RateLimitRepository.
+var _ domain.RateLimitRepository = (*RateLimitRepository)(nil)
+
+// RateLimitRepository implements domain.RateLimitRepository using SQLite,
+// one row per tenant with an override. It shares the tenants database,
+// whose migrations create its table.
+type RateLimitRepository struct {
+	db *sql.DB
+}
+
+// NewRateLimitRepository wraps a database already migrated by New or NewFromDB.
+func NewRateLimitRepository(db *sql.DB) *RateLimitRepository {
+	return &RateLimitRepository{db: db}
+}
+
+func (r *RateLimitRepository) Get(ctx context.Context, tenantID string) (domain.RateLimit, error) {
+	var l domain.RateLimit
+	err := r.db.QueryRowContext(ctx,
+		`SELECT requests_per_second, burst FROM tenant_rate_limits WHERE tenant_id = ?`, tenantID,
+	).Scan(&l.RequestsPerSecond, &l.Burst)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.RateLimit{}, nil
+	}
+	if err != nil {
+		return domain.RateLimit{}, fmt.Errorf("querying rate limit: %w", err)
+	}
+	return l, nil
+}
+
+func (r *RateLimitRepository) Set(ctx context.Context, tenantID string, l domain.RateLimit) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO tenant_rate_limits (tenant_id, requests_per_second, burst, updated_at) VALUES (?, ?, ?, ?)
+		 ON CONFLICT (tenant_id) DO UPDATE SET requests_per_second = excluded.requests_per_second,
+		 burst = excluded.burst, updated_at = excluded.updated_at`,
+		tenantID, l.RequestsPerSecond, l.Burst, time.Now().UTC().Format(timeFormat),
+	)
+	if err != nil {
+		return fmt.Errorf("setting rate limit: %w", err)
+	}
+	return nil
+}
+
+func (r *RateLimitRepository) Delete(ctx context.Context, tenantID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM tenant_rate_limits WHERE tenant_id = ?`, tenantID); err != nil {
+		return fmt.Errorf("deleting rate limit: %w", err)
+	}
+	return nil
+}
+
+func (r *RateLimitRepository) All(ctx context.Context) (map[string]domain.RateLimit, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT tenant_id, requests_per_second, burst FROM tenant_rate_limits`)
+	if err != nil {
+		return nil, fmt.Errorf("querying rate limits: %w", err)
+	}
+	defer rows.Close()
+
+	limits := make(map[string]domain.RateLimit)
+	for rows.Next() {
+		var (
+			tenantID string
+			l        domain.RateLimit
+		)
+		if err := rows.Scan(&tenantID, &l.RequestsPerSecond, &l.Burst); err != nil {
+			return nil, fmt.Errorf("scanning rate limit: %w", err)
+		}
+		limits[tenantID] = l
+	}
+	return limits, rows.Err()
+}
@@ -0,0 +1,50 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestRateLimits_SetGetDelete(t *testing.T) {
+	repo := sqlite.NewRateLimitRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	if got, err := repo.Get(ctx, "ten_1"); err != nil || !got.IsZero() {
+		t.Fatalf("Get without override = %v, %v; want zero", got, err)
+	}
+
+	if err := repo.Set(ctx, "ten_1", domain.RateLimit{RequestsPerSecond: 10, Burst: 10}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	want := domain.RateLimit{RequestsPerSecond: 50, Burst: 100}
+	if err := repo.Set(ctx, "ten_1", want); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := repo.Set(ctx, "ten_2", domain.RateLimit{RequestsPerSecond: 1, Burst: 1}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	if got, err := repo.Get(ctx, "ten_1"); err != nil || got != want {
+		t.Errorf("Get = %v, %v; want %v", got, err, want)
+	}
+	all, err := repo.All(ctx)
+	if err != nil {
+		t.Fatalf("All failed: %v", err)
+	}
+	if len(all) != 2 || all["ten_1"] != want {
+		t.Errorf("All = %v", all)
+	}
+
+	if err := repo.Delete(ctx, "ten_1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repo.Delete(ctx, "ten_1"); err != nil {
+		t.Fatalf("Delete without override failed: %v", err)
+	}
+	if got, _ := repo.Get(ctx, "ten_1"); !got.IsZero() {
+		t.Errorf("Get after Delete = %v, want zero", got)
+	}
+}
@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: ReadOnlyRepository implements domain.ReadOnlyRepository.
+var _ domain.ReadOnlyRepository = (*ReadOnlyRepository)(nil)
+
+// ReadOnlyRepository implements domain.ReadOnlyRepository using SQLite, in
+// a single row. It shares the tenants database, whose migrations create its
+// table.
+type ReadOnlyRepository struct {
+	db *sql.DB
+}
+
+// NewReadOnlyRepository wraps a database already migrated by New or NewFromDB.
+func NewReadOnlyRepository(db *sql.DB) *ReadOnlyRepository {
+	return &ReadOnlyRepository{db: db}
+}
+
+func (r *ReadOnlyRepository) Get(ctx context.Context) (domain.ReadOnlyState, error) {
+	var (
+		s     domain.ReadOnlyState
+		since string
+	)
+	err := r.db.QueryRowContext(ctx,
+		`SELECT enabled, reason, since, actor FROM read_only_mode WHERE id = 1`,
+	).Scan(&s.Enabled, &s.Reason, &since, &s.Actor)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ReadOnlyState{}, nil
+	}
+	if err != nil {
+		return domain.ReadOnlyState{}, fmt.Errorf("querying read-only mode: %w", err)
+	}
+	s.Since, _ = time.Parse(timeFormat, since)
+	return s, nil
+}
+
+func (r *ReadOnlyRepository) Set(ctx context.Context, s domain.ReadOnlyState) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO read_only_mode (id, enabled, reason, since, actor) VALUES (1, ?, ?, ?, ?)
+		 ON CONFLICT (id) DO UPDATE SET
+		   enabled = excluded.enabled, reason = excluded.reason, since = excluded.since, actor = excluded.actor`,
+		s.Enabled, s.Reason, s.Since.UTC().Format(timeFormat), s.Actor,
+	)
+	if err != nil {
+		return fmt.Errorf("saving read-only mode: %w", err)
+	}
+	return nil
+}
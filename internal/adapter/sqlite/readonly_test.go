@@ -0,0 +1,32 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestReadOnly_SetOverwritesMode(t *testing.T) {
+	repo := sqlite.NewReadOnlyRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	if got, err := repo.Get(ctx); err != nil || got != (domain.ReadOnlyState{}) {
+		t.Fatalf("Get = %+v, %v; want disabled before any switch", got, err)
+	}
+
+	since := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := repo.Set(ctx, domain.ReadOnlyState{Enabled: true, Reason: "failover", Since: since, Actor: "ops"}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	want := domain.ReadOnlyState{Since: since.Add(time.Hour), Actor: "ops"}
+	if err := repo.Set(ctx, want); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	if got, err := repo.Get(ctx); err != nil || got != want {
+		t.Errorf("Get = %+v, %v; want %+v", got, err, want)
+	}
+}
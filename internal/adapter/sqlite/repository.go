@@ -4,7 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"embed"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,6 +24,14 @@ var migrations embed.FS
 // TenantRepository implements domain.TenantRepository using SQLite.
 type TenantRepository struct {
 	db *sql.DB
+	q  querier // db, or the transaction of a UnitOfWork
+}
+
+// querier is satisfied by both *sql.DB and *sql.Tx.
+type querier interface {
+	execer
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
 // New opens a SQLite database, runs migrations, and returns a ready repository.
@@ -49,7 +61,7 @@ func NewFromDB(db *sql.DB) (*TenantRepository, error) {
 		return nil, err
 	}
 
-	return &TenantRepository{db: db}, nil
+	return &TenantRepository{db: db, q: db}, nil
 }
 
 // Close closes the underlying database connection.
@@ -62,7 +74,21 @@ func (r *TenantRepository) DB() *sql.DB {
 	return r.db
 }
 
+// runMigrations brings db to the latest schema. A database that was
+// migrated before is only changed once Canary passed on a copy of it; a
+// new one has nothing to protect.
 func runMigrations(db *sql.DB) error {
+	ctx := context.Background()
+	current, pending, err := PendingMigrations(ctx, db)
+	if err != nil {
+		return err
+	}
+	if current > 0 && len(pending) > 0 {
+		if _, err := Canary(ctx, db); err != nil {
+			return fmt.Errorf("canary migration failed, schema left at version %d: %w", current, err)
+		}
+	}
+
 	goose.SetBaseFS(migrations)
 
 	if err := goose.SetDialect("sqlite3"); err != nil {
@@ -78,51 +104,110 @@ func runMigrations(db *sql.DB) error {
 
 const timeFormat = "2006-01-02T15:04:05Z"
 
+// Create inserts the tenant and its tags in a single transaction.
 func (r *TenantRepository) Create(ctx context.Context, t domain.Tenant) error {
-	_, err := r.db.ExecContext(ctx,
-		`INSERT INTO tenants (id, name, slug, status, plan, created_at, updated_at)
-		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+	return r.inTx(ctx, func(tx *sql.Tx) error { return insert(ctx, tx, t) })
+}
+
+// CreateMany inserts all tenants in a single transaction: either every
+// tenant is persisted or none is. Within a UnitOfWork the tenants join its
+// transaction.
+func (r *TenantRepository) CreateMany(ctx context.Context, tenants []domain.Tenant) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error { return insertAll(ctx, tx, tenants) })
+}
+
+// inTx runs fn in the transaction of the UnitOfWork, if any, or in a new
+// one committed when fn succeeds.
+func (r *TenantRepository) inTx(ctx context.Context, fn func(*sql.Tx) error) error {
+	if tx, ok := r.q.(*sql.Tx); ok {
+		return fn(tx)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // no-op after Commit
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func insertAll(ctx context.Context, db execer, tenants []domain.Tenant) error {
+	for _, t := range tenants {
+		if err := insert(ctx, db, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insert(ctx context.Context, db execer, t domain.Tenant) error {
+	refs, err := encodeRefs(t.ExternalRefs)
+	if err != nil {
+		return err
+	}
+	metadata, err := encodeMetadata(t.Metadata)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx,
+		`INSERT INTO tenants (`+tenantColumns+`)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		t.ID, t.Name, t.Slug, string(t.Status), t.Plan,
+		t.PRURL, t.GitBranch, refs, metadata, t.ResellerID, t.SuggestedPlan, formatOptionalTime(t.TrialEndsAt), t.Region, t.Simulated, t.Version,
 		t.CreatedAt.Format(timeFormat),
 		t.UpdatedAt.Format(timeFormat),
 	)
 	if err != nil {
+		// Generated IDs do not collide; imported ones may.
+		if isUniqueViolation(err) && strings.Contains(err.Error(), "tenants.id") {
+			return &domain.IDConflictError{ID: t.ID}
+		}
 		if isUniqueViolation(err) {
 			return &domain.SlugConflictError{Slug: t.Slug}
 		}
 		return fmt.Errorf("inserting tenant: %w", err)
 	}
-	return nil
+	return insertTags(ctx, db, t)
 }
 
 func (r *TenantRepository) GetByID(ctx context.Context, id string) (domain.Tenant, error) {
-	return r.scanTenant(r.db.QueryRowContext(ctx,
-		`SELECT id, name, slug, status, plan, created_at, updated_at
-		 FROM tenants WHERE id = ?`, id,
+	return r.scanTenant(r.q.QueryRowContext(ctx,
+		`SELECT `+selectColumns+` FROM tenants WHERE id = ?`, id,
 	))
 }
 
 func (r *TenantRepository) GetBySlug(ctx context.Context, slug string) (domain.Tenant, error) {
-	return r.scanTenant(r.db.QueryRowContext(ctx,
-		`SELECT id, name, slug, status, plan, created_at, updated_at
-		 FROM tenants WHERE slug = ?`, slug,
+	return r.scanTenant(r.q.QueryRowContext(ctx,
+		`SELECT `+selectColumns+` FROM tenants WHERE slug = ?`, slug,
 	))
 }
 
 func (r *TenantRepository) List(ctx context.Context, filter domain.ListFilter) ([]domain.Tenant, error) {
-	query := `SELECT id, name, slug, status, plan, created_at, updated_at FROM tenants`
-	var args []any
-
-	if filter.Status != nil {
-		query += ` WHERE status = ?`
-		args = append(args, string(*filter.Status))
-	}
+	where, args := whereClause(filter)
+	query := `SELECT ` + selectColumns + ` FROM tenants` + where
 
 	query += ` ORDER BY created_at DESC`
 
 	if filter.Limit > 0 {
 		query += ` LIMIT ?`
 		args = append(args, filter.Limit)
+	} else if filter.Offset > 0 {
+		// SQLite takes an OFFSET only after a LIMIT; -1 is no limit.
+		query += ` LIMIT -1`
 	}
 
 	if filter.Offset > 0 {
@@ -130,13 +215,13 @@ func (r *TenantRepository) List(ctx context.Context, filter domain.ListFilter) (
 		args = append(args, filter.Offset)
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.q.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("listing tenants: %w", err)
 	}
 	defer rows.Close()
 
-	var tenants []domain.Tenant
+	tenants := make([]domain.Tenant, 0, max(0, min(filter.Limit, maxListPrealloc)))
 	for rows.Next() {
 		t, err := r.scanTenantFromRows(rows)
 		if err != nil {
@@ -148,12 +233,161 @@ func (r *TenantRepository) List(ctx context.Context, filter domain.ListFilter) (
 	return tenants, rows.Err()
 }
 
+// maxListPrealloc caps the capacity reserved for a page of tenants, so a
+// large limit does not allocate for rows that may not exist.
+const maxListPrealloc = 500
+
+// Count reads the maintained status counts (see StatusCounter) when filter
+// only restricts the status, and counts the matching tenants otherwise.
+func (r *TenantRepository) Count(ctx context.Context, filter domain.ListFilter) (int, error) {
+	if statuses, ok := statusOnly(filter); ok {
+		where, args := ``, []any(nil)
+		if len(statuses) > 0 {
+			where = ` WHERE status IN (` + placeholders(len(statuses)) + `)`
+			for _, st := range statuses {
+				args = append(args, string(st))
+			}
+		}
+		var n int
+		if err := r.q.QueryRowContext(ctx, `SELECT COALESCE(SUM(count), 0) FROM tenant_status_counts`+where, args...).Scan(&n); err != nil {
+			return 0, fmt.Errorf("counting tenants: %w", err)
+		}
+		return n, nil
+	}
+
+	where, args := whereClause(filter)
+
+	var n int
+	if err := r.q.QueryRowContext(ctx, `SELECT COUNT(*) FROM tenants`+where, args...).Scan(&n); err != nil {
+		return 0, fmt.Errorf("counting tenants: %w", err)
+	}
+	return n, nil
+}
+
+// statusOnly returns the statuses filter restricts the tenants to (none
+// for all tenants), or false when it filters on anything else. Pagination
+// does not change a count. Every other ListFilter field must be checked
+// here, or Count would ignore it.
+func statusOnly(filter domain.ListFilter) ([]domain.Status, bool) {
+	if len(filter.Plans) > 0 || len(filter.IDs) > 0 || filter.ResellerID != "" ||
+		!filter.CreatedAfter.IsZero() || !filter.CreatedBefore.IsZero() ||
+		filter.Simulated != nil || !filter.TrialEndsBy.IsZero() || len(filter.Metadata) > 0 || len(filter.Tags) > 0 {
+		return nil, false
+	}
+	if filter.Status == nil {
+		return filter.Statuses, true
+	}
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, *filter.Status) {
+		return nil, false
+	}
+	return []domain.Status{*filter.Status}, true
+}
+
+// whereClause builds the WHERE clause shared by List and Count.
+func whereClause(filter domain.ListFilter) (string, []any) {
+	var conds []string
+	var args []any
+
+	if filter.Status != nil {
+		conds = append(conds, `status = ?`)
+		args = append(args, string(*filter.Status))
+	}
+
+	if len(filter.Statuses) > 0 {
+		conds = append(conds, `status IN (`+placeholders(len(filter.Statuses))+`)`)
+		for _, st := range filter.Statuses {
+			args = append(args, string(st))
+		}
+	}
+
+	if len(filter.Plans) > 0 {
+		conds = append(conds, `plan IN (`+placeholders(len(filter.Plans))+`)`)
+		for _, p := range filter.Plans {
+			args = append(args, p)
+		}
+	}
+
+	if len(filter.IDs) > 0 {
+		conds = append(conds, `id IN (`+placeholders(len(filter.IDs))+`)`)
+		for _, id := range filter.IDs {
+			args = append(args, id)
+		}
+	}
+
+	if filter.ResellerID != "" {
+		conds = append(conds, `reseller_id = ?`)
+		args = append(args, filter.ResellerID)
+	}
+
+	if !filter.CreatedAfter.IsZero() {
+		conds = append(conds, `created_at >= ?`)
+		args = append(args, filter.CreatedAfter.UTC().Format(timeFormat))
+	}
+
+	if !filter.CreatedBefore.IsZero() {
+		conds = append(conds, `created_at < ?`)
+		args = append(args, filter.CreatedBefore.UTC().Format(timeFormat))
+	}
+
+	if filter.Simulated != nil {
+		conds = append(conds, `simulated = ?`)
+		args = append(args, *filter.Simulated)
+	}
+
+	if !filter.TrialEndsBy.IsZero() {
+		conds = append(conds, `trial_ends_at != '' AND trial_ends_at <= ?`)
+		args = append(args, filter.TrialEndsBy.UTC().Format(timeFormat))
+	}
+
+	// Sorted, so the same filter always builds the same query.
+	for _, k := range slices.Sorted(maps.Keys(filter.Metadata)) {
+		conds = append(conds, `json_extract(metadata, ?) = ?`)
+		args = append(args, metadataPath(k), filter.Metadata[k])
+	}
+
+	for _, tag := range filter.Tags {
+		conds = append(conds, `id IN (SELECT tenant_id FROM tenant_tags WHERE tag = ?)`)
+		args = append(args, tag)
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return ` WHERE ` + strings.Join(conds, ` AND `), args
+}
+
+// placeholders returns n comma-separated "?" bind parameters.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// Update stores the tenant and replaces its tags in a single transaction.
 func (r *TenantRepository) Update(ctx context.Context, t domain.Tenant) error {
-	result, err := r.db.ExecContext(ctx,
-		`UPDATE tenants SET name = ?, slug = ?, status = ?, plan = ?, updated_at = ?
-		 WHERE id = ?`,
+	return r.inTx(ctx, func(tx *sql.Tx) error { return update(ctx, tx, t) })
+}
+
+func update(ctx context.Context, db querier, t domain.Tenant) error {
+	updatedAt := t.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+	refs, err := encodeRefs(t.ExternalRefs)
+	if err != nil {
+		return err
+	}
+	metadata, err := encodeMetadata(t.Metadata)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.ExecContext(ctx,
+		`UPDATE tenants SET name = ?, slug = ?, status = ?, plan = ?,
+		 pr_url = ?, git_branch = ?, external_refs = ?, metadata = ?, suggested_plan = ?, trial_ends_at = ?, updated_at = ?,
+		 version = version + 1
+		 WHERE id = ? AND version = ?`,
 		t.Name, t.Slug, string(t.Status), t.Plan,
-		time.Now().UTC().Format(timeFormat), t.ID,
+		t.PRURL, t.GitBranch, refs, metadata, t.SuggestedPlan, formatOptionalTime(t.TrialEndsAt),
+		updatedAt.UTC().Format(timeFormat), t.ID, t.Version,
 	)
 	if err != nil {
 		if isUniqueViolation(err) {
@@ -167,49 +401,142 @@ func (r *TenantRepository) Update(ctx context.Context, t domain.Tenant) error {
 		return fmt.Errorf("checking rows affected: %w", err)
 	}
 	if rows == 0 {
+		// Either the tenant is gone or another update bumped its version.
+		var exists bool
+		if err := db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM tenants WHERE id = ?)`, t.ID).Scan(&exists); err != nil {
+			return fmt.Errorf("checking tenant: %w", err)
+		}
+		if exists {
+			return domain.ErrConcurrentModification
+		}
 		return domain.ErrTenantNotFound
 	}
 
+	if _, err := db.ExecContext(ctx, `DELETE FROM tenant_tags WHERE tenant_id = ?`, t.ID); err != nil {
+		return fmt.Errorf("clearing tags: %w", err)
+	}
+	return insertTags(ctx, db, t)
+}
+
+// insertTags stores the tags of the tenant.
+func insertTags(ctx context.Context, db execer, t domain.Tenant) error {
+	if len(t.Tags) == 0 {
+		return nil
+	}
+	query := `INSERT OR IGNORE INTO tenant_tags (tenant_id, tag) VALUES ` +
+		strings.TrimSuffix(strings.Repeat("(?, ?), ", len(t.Tags)), ", ")
+	args := make([]any, 0, 2*len(t.Tags))
+	for _, tag := range t.Tags {
+		args = append(args, t.ID, tag)
+	}
+	if _, err := db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("storing tags: %w", err)
+	}
 	return nil
 }
 
+// tenantColumns lists the columns of the tenants table, in the order
+// expected by scan.
+const tenantColumns = `id, name, slug, status, plan, pr_url, git_branch, external_refs, metadata, reseller_id, suggested_plan, trial_ends_at, region, simulated, version, created_at, updated_at`
+
+// selectColumns is tenantColumns followed by the tenant's tags, as a
+// sorted JSON array.
+const selectColumns = tenantColumns +
+	`, (SELECT json_group_array(tag) FROM (SELECT tag FROM tenant_tags WHERE tenant_id = tenants.id ORDER BY tag))`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
 // scanTenant scans a single row from QueryRow into a domain.Tenant.
 func (r *TenantRepository) scanTenant(row *sql.Row) (domain.Tenant, error) {
-	var t domain.Tenant
-	var status, createdAt, updatedAt string
-
-	err := row.Scan(&t.ID, &t.Name, &t.Slug, &status, &t.Plan, &createdAt, &updatedAt)
+	t, err := scan(row)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Tenant{}, domain.ErrTenantNotFound
 		}
 		return domain.Tenant{}, fmt.Errorf("scanning tenant: %w", err)
 	}
-
-	t.Status = domain.Status(status)
-	t.CreatedAt, _ = time.Parse(timeFormat, createdAt)
-	t.UpdatedAt, _ = time.Parse(timeFormat, updatedAt)
-
 	return t, nil
 }
 
 // scanTenantFromRows scans a single row from Rows (used in List).
 func (r *TenantRepository) scanTenantFromRows(rows *sql.Rows) (domain.Tenant, error) {
+	t, err := scan(rows)
+	if err != nil {
+		return domain.Tenant{}, fmt.Errorf("scanning tenant row: %w", err)
+	}
+	return t, nil
+}
+
+func scan(row rowScanner) (domain.Tenant, error) {
 	var t domain.Tenant
-	var status, createdAt, updatedAt string
+	var status, refs, metadata, trialEndsAt, createdAt, updatedAt, tags string
 
-	err := rows.Scan(&t.ID, &t.Name, &t.Slug, &status, &t.Plan, &createdAt, &updatedAt)
+	err := row.Scan(&t.ID, &t.Name, &t.Slug, &status, &t.Plan,
+		&t.PRURL, &t.GitBranch, &refs, &metadata, &t.ResellerID, &t.SuggestedPlan, &trialEndsAt, &t.Region, &t.Simulated, &t.Version, &createdAt, &updatedAt,
+		&tags)
 	if err != nil {
-		return domain.Tenant{}, fmt.Errorf("scanning tenant row: %w", err)
+		return domain.Tenant{}, err
 	}
 
 	t.Status = domain.Status(status)
+	// Most tenants have no references; skip decoding the empty object.
+	if refs != "{}" && refs != "" {
+		if err := json.Unmarshal([]byte(refs), &t.ExternalRefs); err != nil {
+			return domain.Tenant{}, fmt.Errorf("decoding external refs: %w", err)
+		}
+	}
+	if metadata != "{}" && metadata != "" {
+		if err := json.Unmarshal([]byte(metadata), &t.Metadata); err != nil {
+			return domain.Tenant{}, fmt.Errorf("decoding metadata: %w", err)
+		}
+	}
+	if tags != "[]" && tags != "" {
+		if err := json.Unmarshal([]byte(tags), &t.Tags); err != nil {
+			return domain.Tenant{}, fmt.Errorf("decoding tags: %w", err)
+		}
+	}
+	if trialEndsAt != "" {
+		t.TrialEndsAt, _ = time.Parse(timeFormat, trialEndsAt)
+	}
 	t.CreatedAt, _ = time.Parse(timeFormat, createdAt)
 	t.UpdatedAt, _ = time.Parse(timeFormat, updatedAt)
 
 	return t, nil
 }
 
+// encodeRefs serializes external references as a JSON object.
+func encodeRefs(refs map[string]string) (string, error) {
+	if len(refs) == 0 {
+		return "{}", nil
+	}
+	b, err := json.Marshal(refs)
+	if err != nil {
+		return "", fmt.Errorf("encoding external refs: %w", err)
+	}
+	return string(b), nil
+}
+
+// encodeMetadata serializes tenant metadata as a JSON object.
+func encodeMetadata(metadata map[string]string) (string, error) {
+	if len(metadata) == 0 {
+		return "{}", nil
+	}
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return "", fmt.Errorf("encoding metadata: %w", err)
+	}
+	return string(b), nil
+}
+
+// metadataPath returns the JSON path of a metadata key, quoted so keys
+// with dots are not taken for nested objects.
+func metadataPath(key string) string {
+	return `$."` + strings.ReplaceAll(key, `"`, `\"`) + `"`
+}
+
 // isUniqueViolation checks if a SQLite error is a UNIQUE constraint violation.
 func isUniqueViolation(err error) bool {
 	return strings.Contains(err.Error(), "UNIQUE constraint failed")
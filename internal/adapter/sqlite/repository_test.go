@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
 	"github.com/neomorfeo/tenantiq/internal/domain"
@@ -121,6 +123,47 @@ func TestCreate_DuplicateSlug(t *testing.T) {
 	}
 }
 
+func TestCreateMany(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	tenants := []domain.Tenant{
+		domain.NewTenant("t-1", "A", "a", "free"),
+		domain.NewTenant("t-2", "B", "b", "pro"),
+	}
+	if err := repo.CreateMany(ctx, tenants); err != nil {
+		t.Fatalf("CreateMany failed: %v", err)
+	}
+
+	n, err := repo.Count(ctx, domain.ListFilter{})
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Count = %d, want 2", n)
+	}
+}
+
+func TestCreateMany_RollsBackOnConflict(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	mustCreate(t, repo, domain.NewTenant("t-0", "Taken", "taken", "free"))
+
+	err := repo.CreateMany(ctx, []domain.Tenant{
+		domain.NewTenant("t-1", "A", "a", "free"),
+		domain.NewTenant("t-2", "Taken", "taken", "free"),
+	})
+
+	var slugErr *domain.SlugConflictError
+	if !errors.As(err, &slugErr) {
+		t.Fatalf("expected SlugConflictError, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "t-1"); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("t-1 should have been rolled back, got %v", err)
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
@@ -147,6 +190,30 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdate_References(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	tenant := domain.NewTenant("t-1", "Acme", "acme", "pro")
+	mustCreate(t, repo, tenant)
+
+	tenant.PRURL = "https://github.com/org/infra/pull/42"
+	tenant.GitBranch = "tenant/acme"
+	tenant.ExternalRefs = map[string]string{"billing_customer": "cus_123"}
+	mustUpdate(t, repo, tenant)
+
+	got, err := repo.GetByID(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.PRURL != tenant.PRURL || got.GitBranch != tenant.GitBranch {
+		t.Errorf("got PRURL=%q GitBranch=%q", got.PRURL, got.GitBranch)
+	}
+	if got.ExternalRefs["billing_customer"] != "cus_123" {
+		t.Errorf("ExternalRefs = %v", got.ExternalRefs)
+	}
+}
+
 func TestUpdate_NotFound(t *testing.T) {
 	repo := newTestRepo(t)
 
@@ -157,6 +224,28 @@ func TestUpdate_NotFound(t *testing.T) {
 	}
 }
 
+func TestUpdate_ConcurrentModification(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	mustCreate(t, repo, domain.NewTenant("t-1", "Acme", "acme", "free"))
+	first, _ := repo.GetByID(ctx, "t-1")
+	second, _ := repo.GetByID(ctx, "t-1")
+
+	first.Status = domain.StatusActive
+	mustUpdate(t, repo, first)
+
+	second.Name = "Stale"
+	if err := repo.Update(ctx, second); !errors.Is(err, domain.ErrConcurrentModification) {
+		t.Fatalf("stale Update error = %v, want ErrConcurrentModification", err)
+	}
+
+	got, _ := repo.GetByID(ctx, "t-1")
+	if got.Version != 2 || got.Name != "Acme" || got.Status != domain.StatusActive {
+		t.Errorf("got version %d, name %q, status %q; want the first update only", got.Version, got.Name, got.Status)
+	}
+}
+
 func TestList_All(t *testing.T) {
 	repo := newTestRepo(t)
 
@@ -214,3 +303,354 @@ func TestList_Pagination(t *testing.T) {
 		t.Errorf("got %d tenants, want 2", len(tenants))
 	}
 }
+
+func TestList_NegativeLimitAndOffset(t *testing.T) {
+	repo := newTestRepo(t)
+
+	for i := range 3 {
+		mustCreate(t, repo, domain.NewTenant(fmt.Sprintf("t-%d", i), "T", fmt.Sprintf("s-%d", i), "free"))
+	}
+
+	// Negative pagination is ignored rather than panicking.
+	tenants, err := repo.List(context.Background(), domain.ListFilter{Limit: -1, Offset: -1})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(tenants) != 3 {
+		t.Errorf("got %d tenants, want 3", len(tenants))
+	}
+}
+
+func TestList_OffsetWithoutLimit(t *testing.T) {
+	repo := newTestRepo(t)
+
+	for i := range 3 {
+		mustCreate(t, repo, domain.NewTenant(fmt.Sprintf("t-%d", i), "T", fmt.Sprintf("s-%d", i), "free"))
+	}
+
+	tenants, err := repo.List(context.Background(), domain.ListFilter{Offset: 1})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(tenants) != 2 {
+		t.Errorf("got %d tenants, want the 2 past the offset", len(tenants))
+	}
+}
+
+func TestCount(t *testing.T) {
+	repo := newTestRepo(t)
+
+	for i := range 3 {
+		mustCreate(t, repo, domain.NewTenant(fmt.Sprintf("t-%d", i), "T", fmt.Sprintf("s-%d", i), "free"))
+	}
+	active := domain.NewTenant("t-active", "A", "active", "pro")
+	mustCreate(t, repo, active)
+	active.Status = domain.StatusActive
+	mustUpdate(t, repo, active)
+
+	n, err := repo.Count(context.Background(), domain.ListFilter{Limit: 1, Offset: 1})
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if n != 4 {
+		t.Errorf("Count = %d, want 4 (pagination must be ignored)", n)
+	}
+
+	status := domain.StatusActive
+	n, err = repo.Count(context.Background(), domain.ListFilter{Status: &status})
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Count(active) = %d, want 1", n)
+	}
+}
+
+func TestList_FilterByStatusesAndPlans(t *testing.T) {
+	repo := newTestRepo(t)
+
+	for _, tc := range []struct {
+		id, plan string
+		status   domain.Status
+	}{
+		{"t-1", "pro", domain.StatusActive},
+		{"t-2", "pro", domain.StatusSuspended},
+		{"t-3", "pro", domain.StatusDeleted},
+		{"t-4", "free", domain.StatusActive},
+		{"t-5", "enterprise", domain.StatusActive},
+	} {
+		tenant := domain.NewTenant(tc.id, "T", tc.id, tc.plan)
+		mustCreate(t, repo, tenant)
+		tenant.Status = tc.status
+		mustUpdate(t, repo, tenant)
+	}
+
+	filter := domain.ListFilter{
+		Statuses: []domain.Status{domain.StatusActive, domain.StatusSuspended},
+		Plans:    []string{"pro", "enterprise"},
+	}
+	tenants, err := repo.List(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(tenants) != 3 {
+		t.Errorf("got %d tenants, want 3", len(tenants))
+	}
+
+	n, err := repo.Count(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Count = %d, want 3", n)
+	}
+}
+
+func TestList_FilterByCreatedAtRange(t *testing.T) {
+	repo := newTestRepo(t)
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	for i, day := range []int{0, 10, 20, 30} {
+		tenant := domain.NewTenant(fmt.Sprintf("t-%d", i), "T", fmt.Sprintf("s-%d", i), "free")
+		tenant.CreatedAt = base.AddDate(0, 0, day)
+		mustCreate(t, repo, tenant)
+	}
+
+	filter := domain.ListFilter{
+		CreatedAfter:  base.AddDate(0, 0, 10),
+		CreatedBefore: base.AddDate(0, 0, 30),
+	}
+	tenants, err := repo.List(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(tenants) != 2 {
+		t.Fatalf("got %d tenants, want 2 (after is inclusive, before is exclusive)", len(tenants))
+	}
+	if tenants[0].ID != "t-2" || tenants[1].ID != "t-1" {
+		t.Errorf("got %s, %s; want t-2, t-1", tenants[0].ID, tenants[1].ID)
+	}
+}
+
+func TestList_FilterByTrialEnd(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for i, ends := range []time.Time{{}, now.Add(-time.Hour), now, now.Add(time.Hour)} {
+		tenant := domain.NewTenant(fmt.Sprintf("t-%d", i), "T", fmt.Sprintf("s-%d", i), "free")
+		tenant.TrialEndsAt = ends
+		mustCreate(t, repo, tenant)
+	}
+
+	got, err := repo.GetByID(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if !got.TrialEndsAt.Equal(now.Add(-time.Hour)) {
+		t.Errorf("TrialEndsAt = %v, want %v", got.TrialEndsAt, now.Add(-time.Hour))
+	}
+
+	tenants, err := repo.List(ctx, domain.ListFilter{TrialEndsBy: now})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	var expired []string
+	for _, tenant := range tenants {
+		expired = append(expired, tenant.ID)
+	}
+	slices.Sort(expired)
+	if fmt.Sprint(expired) != "[t-1 t-2]" {
+		t.Errorf("got %v, want t-1 and t-2 (ending at or before now)", expired)
+	}
+
+	// Ending the trial clears the column.
+	got.TrialEndsAt = time.Time{}
+	mustUpdate(t, repo, got)
+	if again, _ := repo.GetByID(ctx, "t-1"); !again.TrialEndsAt.IsZero() {
+		t.Errorf("TrialEndsAt = %v after ending the trial, want zero", again.TrialEndsAt)
+	}
+}
+
+func TestList_ScopedByResellerAndIDs(t *testing.T) {
+	repo := newTestRepo(t)
+
+	for _, tc := range []struct{ id, reseller string }{
+		{"t-1", "rsl_a"},
+		{"t-2", "rsl_a"},
+		{"t-3", "rsl_b"},
+		{"t-4", ""},
+	} {
+		tenant := domain.NewTenant(tc.id, "T", tc.id, "pro")
+		tenant.ResellerID = tc.reseller
+		mustCreate(t, repo, tenant)
+	}
+
+	tenants, err := repo.List(context.Background(), domain.ListFilter{ResellerID: "rsl_a"})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(tenants) != 2 || tenants[0].ResellerID != "rsl_a" {
+		t.Errorf("got %+v, want the 2 tenants of rsl_a", tenants)
+	}
+
+	n, err := repo.Count(context.Background(), domain.ListFilter{ResellerID: "rsl_a", IDs: []string{"t-1", "t-3"}})
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Count = %d, want 1", n)
+	}
+}
+
+func TestList_FilterBySimulated(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	regular := domain.NewTenant("t-real", "Real", "real", "free")
+	simulated := domain.NewTenant("t-sim", "Sim", "sim", "free")
+	simulated.Simulated = true
+	mustCreate(t, repo, regular)
+	mustCreate(t, repo, simulated)
+
+	for _, want := range []bool{true, false} {
+		tenants, err := repo.List(ctx, domain.ListFilter{Simulated: &want})
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(tenants) != 1 || tenants[0].Simulated != want {
+			t.Errorf("List(simulated=%v) = %+v, want one matching tenant", want, tenants)
+		}
+	}
+}
+
+func TestCreate_KeepsRegion(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	tenant := domain.NewTenant("t-eu", "Euro", "euro", "free")
+	tenant.Region = "eu"
+	mustCreate(t, repo, tenant)
+	mustCreate(t, repo, domain.NewTenant("t-any", "Any", "any", "free"))
+
+	for id, want := range map[string]string{"t-eu": "eu", "t-any": ""} {
+		got, err := repo.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("GetByID failed: %v", err)
+		}
+		if got.Region != want {
+			t.Errorf("%s: Region = %q, want %q", id, got.Region, want)
+		}
+	}
+}
+
+func TestList_FilterByMetadata(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	acme := domain.NewTenant("t-1", "Acme", "acme", "free")
+	acme.Metadata = map[string]string{"crm.id": "42", "region": "eu"}
+	globex := domain.NewTenant("t-2", "Globex", "globex", "free")
+	globex.Metadata = map[string]string{"crm.id": "7", "region": "eu"}
+	mustCreate(t, repo, acme)
+	mustCreate(t, repo, globex)
+	mustCreate(t, repo, domain.NewTenant("t-3", "Initech", "initech", "free"))
+
+	cases := []struct {
+		metadata map[string]string
+		want     int
+	}{
+		{map[string]string{"region": "eu"}, 2},
+		{map[string]string{"region": "eu", "crm.id": "42"}, 1},
+		{map[string]string{"region": "us"}, 0},
+	}
+	for _, tc := range cases {
+		filter := domain.ListFilter{Metadata: tc.metadata}
+		tenants, err := repo.List(ctx, filter)
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		n, err := repo.Count(ctx, filter)
+		if err != nil {
+			t.Fatalf("Count failed: %v", err)
+		}
+		if len(tenants) != tc.want || n != tc.want {
+			t.Errorf("List(%v) = %d tenants, Count = %d; want %d", tc.metadata, len(tenants), n, tc.want)
+		}
+	}
+
+	got, err := repo.GetByID(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if len(got.Metadata) != 2 || got.Metadata["crm.id"] != "42" {
+		t.Errorf("Metadata = %v", got.Metadata)
+	}
+}
+
+func TestTags(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	acme := domain.NewTenant("t-1", "Acme", "acme", "free")
+	acme.Tags = []string{"beta", "tier:gold"}
+	globex := domain.NewTenant("t-2", "Globex", "globex", "free")
+	globex.Tags = []string{"tier:gold"}
+	mustCreate(t, repo, acme)
+	mustCreate(t, repo, globex)
+	mustCreate(t, repo, domain.NewTenant("t-3", "Initech", "initech", "free"))
+
+	cases := []struct {
+		tags []string
+		want int
+	}{
+		{[]string{"tier:gold"}, 2},
+		{[]string{"tier:gold", "beta"}, 1},
+		{[]string{"churned"}, 0},
+	}
+	for _, tc := range cases {
+		filter := domain.ListFilter{Tags: tc.tags}
+		tenants, err := repo.List(ctx, filter)
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		n, err := repo.Count(ctx, filter)
+		if err != nil {
+			t.Fatalf("Count failed: %v", err)
+		}
+		if len(tenants) != tc.want || n != tc.want {
+			t.Errorf("List(%v) = %d tenants, Count = %d; want %d", tc.tags, len(tenants), n, tc.want)
+		}
+	}
+
+	got, err := repo.GetByID(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if fmt.Sprint(got.Tags) != "[beta tier:gold]" {
+		t.Errorf("Tags = %v, want [beta tier:gold]", got.Tags)
+	}
+
+	// Update replaces the tags with the tenant's.
+	got.Tags = []string{"alpha", "beta"}
+	mustUpdate(t, repo, got)
+	if got, _ = repo.GetByID(ctx, "t-1"); fmt.Sprint(got.Tags) != "[alpha beta]" {
+		t.Errorf("Tags after update = %v, want [alpha beta]", got.Tags)
+	}
+	got.Tags = nil
+	mustUpdate(t, repo, got)
+	if got, _ = repo.GetByID(ctx, "t-1"); got.Tags != nil {
+		t.Errorf("Tags after clearing = %v, want none", got.Tags)
+	}
+
+	// A stale update changes neither the tenant nor its tags.
+	stale := globex
+	stale.Tags = []string{"stale"}
+	mustUpdate(t, repo, globex)
+	if err := repo.Update(ctx, stale); !errors.Is(err, domain.ErrConcurrentModification) {
+		t.Fatalf("stale Update = %v, want ErrConcurrentModification", err)
+	}
+	if got, _ := repo.GetByID(ctx, "t-2"); fmt.Sprint(got.Tags) != "[tier:gold]" {
+		t.Errorf("Tags after a stale update = %v, want [tier:gold]", got.Tags)
+	}
+}
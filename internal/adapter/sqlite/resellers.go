@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: ResellerRepository implements domain.ResellerRepository.
+var _ domain.ResellerRepository = (*ResellerRepository)(nil)
+
+// ResellerRepository implements domain.ResellerRepository using SQLite.
+// It shares the tenants database, whose migrations create its table.
+type ResellerRepository struct {
+	db *sql.DB
+}
+
+// NewResellerRepository wraps a database already migrated by New or NewFromDB.
+func NewResellerRepository(db *sql.DB) *ResellerRepository {
+	return &ResellerRepository{db: db}
+}
+
+func (r *ResellerRepository) Create(ctx context.Context, rs domain.Reseller) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO resellers (id, name, tenant_quota, created_at) VALUES (?, ?, ?, ?)`,
+		rs.ID, rs.Name, rs.TenantQuota, rs.CreatedAt.Format(timeFormat),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting reseller: %w", err)
+	}
+	return nil
+}
+
+func (r *ResellerRepository) GetByID(ctx context.Context, id string) (domain.Reseller, error) {
+	var (
+		rs        domain.Reseller
+		createdAt string
+	)
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, name, tenant_quota, created_at FROM resellers WHERE id = ?`, id,
+	).Scan(&rs.ID, &rs.Name, &rs.TenantQuota, &createdAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Reseller{}, domain.ErrResellerNotFound
+		}
+		return domain.Reseller{}, fmt.Errorf("scanning reseller: %w", err)
+	}
+	rs.CreatedAt, _ = time.Parse(timeFormat, createdAt)
+	return rs, nil
+}
@@ -0,0 +1,36 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestResellers_CreateAndGet(t *testing.T) {
+	resellers := sqlite.NewResellerRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	reseller := domain.NewReseller("rsl_1", "Partner", 10)
+	if err := resellers.Create(ctx, reseller); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	got, err := resellers.GetByID(ctx, "rsl_1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Name != "Partner" || got.TenantQuota != 10 || got.CreatedAt.IsZero() {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestResellers_NotFound(t *testing.T) {
+	resellers := sqlite.NewResellerRepository(newTestRepo(t).DB())
+
+	if _, err := resellers.GetByID(context.Background(), "rsl_missing"); !errors.Is(err, domain.ErrResellerNotFound) {
+		t.Errorf("expected ErrResellerNotFound, got %v", err)
+	}
+}
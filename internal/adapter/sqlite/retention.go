@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time checks: the append-only tables can be pruned.
+var (
+	_ domain.Pruner = (*AuditLog)(nil)
+	_ domain.Pruner = (*StatusHistoryRepository)(nil)
+	_ domain.Pruner = (*OperationRepository)(nil)
+)
+
+// Prune deletes the audit entries recorded before the cutoff, archived
+// or not.
+func (l *AuditLog) Prune(ctx context.Context, before time.Time, dryRun bool) (int, error) {
+	return pruneWithArchive(ctx, l.db, "audit_log", "created_at < ?", before, dryRun)
+}
+
+// Prune deletes the status changes made before the cutoff, archived or
+// not.
+func (r *StatusHistoryRepository) Prune(ctx context.Context, before time.Time, dryRun bool) (int, error) {
+	return pruneWithArchive(ctx, r.db, "tenant_status_history", "changed_at < ?", before, dryRun)
+}
+
+// Prune deletes the operations that finished before the cutoff, with
+// their recorded steps. Pending operations are kept however old they are,
+// so their callers can still poll them.
+func (r *OperationRepository) Prune(ctx context.Context, before time.Time, dryRun bool) (int, error) {
+	const expired = "status != 'pending' AND updated_at < ?"
+	if !dryRun {
+		// The steps go first, while their operations still tell which.
+		steps := "operation_id IN (SELECT id FROM operations WHERE " + expired + ")"
+		if _, err := prune(ctx, r.db, "operation_steps", steps, before, false); err != nil {
+			return 0, err
+		}
+	}
+	return prune(ctx, r.db, "operations", expired, before, dryRun)
+}
+
+// pruneWithArchive prunes the rows of table and of its archive table.
+func pruneWithArchive(ctx context.Context, db *sql.DB, table, where string, before time.Time, dryRun bool) (int, error) {
+	archived, err := prune(ctx, db, table+"_archive", where, before, dryRun)
+	if err != nil {
+		return 0, err
+	}
+	n, err := prune(ctx, db, table, where, before, dryRun)
+	return archived + n, err
+}
+
+// prune deletes, or with dryRun counts, the rows of table matching where,
+// whose only placeholder is bound to the cutoff.
+func prune(ctx context.Context, db *sql.DB, table, where string, before time.Time, dryRun bool) (int, error) {
+	cutoff := before.UTC().Format(timeFormat)
+
+	if dryRun {
+		var n int
+		if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+table+` WHERE `+where, cutoff).Scan(&n); err != nil {
+			return 0, fmt.Errorf("counting expired %s rows: %w", table, err)
+		}
+		return n, nil
+	}
+
+	result, err := db.ExecContext(ctx, `DELETE FROM `+table+` WHERE `+where, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("pruning %s: %w", table, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("checking rows affected: %w", err)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,68 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestStatusHistory_Prune(t *testing.T) {
+	history := sqlite.NewStatusHistoryRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+	cutoff := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, at := range []time.Time{cutoff.Add(-48 * time.Hour), cutoff.Add(-time.Second), cutoff, cutoff.Add(time.Hour)} {
+		c := domain.StatusChange{TenantID: "ten_1", From: domain.StatusActive, To: domain.StatusSuspended, Event: domain.EventSuspend, At: at}
+		if err := history.Record(ctx, c); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	n, err := history.Prune(ctx, cutoff, true)
+	if err != nil || n != 2 {
+		t.Fatalf("dry-run Prune = %d, %v; want 2", n, err)
+	}
+	if got, _ := history.ListByTenant(ctx, "ten_1"); len(got) != 4 {
+		t.Fatalf("dry run deleted changes: %d left", len(got))
+	}
+
+	n, err = history.Prune(ctx, cutoff, false)
+	if err != nil || n != 2 {
+		t.Fatalf("Prune = %d, %v; want 2", n, err)
+	}
+	got, _ := history.ListByTenant(ctx, "ten_1")
+	if len(got) != 2 || !got[0].At.Equal(cutoff) {
+		t.Errorf("kept %+v, want the changes from the cutoff on", got)
+	}
+}
+
+func TestOperation_PruneKeepsPending(t *testing.T) {
+	ops := newTestOperations(t)
+	ctx := context.Background()
+	old := time.Now().UTC().Add(-30 * 24 * time.Hour)
+
+	pending := domain.NewOperation("op_pending", domain.OperationProvision, "ten_1")
+	pending.UpdatedAt = old
+	done := domain.NewOperation("op_done", domain.OperationProvision, "ten_2")
+	done.Status, done.UpdatedAt = domain.OperationSucceeded, old
+	for _, op := range []domain.Operation{pending, done} {
+		if err := ops.Create(ctx, op); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	n, err := ops.Prune(ctx, time.Now().UTC(), false)
+	if err != nil || n != 1 {
+		t.Fatalf("Prune = %d, %v; want 1", n, err)
+	}
+	if _, err := ops.GetByID(ctx, "op_done"); !errors.Is(err, domain.ErrOperationNotFound) {
+		t.Errorf("finished operation: err = %v, want ErrOperationNotFound", err)
+	}
+	if _, err := ops.GetByID(ctx, "op_pending"); err != nil {
+		t.Errorf("pending operation was pruned: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: StepRepository implements domain.StepRepository.
+var _ domain.StepRepository = (*StepRepository)(nil)
+
+// StepRepository implements domain.StepRepository using SQLite. It shares
+// the tenants database, whose migrations create its table; the records of
+// an operation are pruned with it.
+type StepRepository struct {
+	db *sql.DB
+}
+
+// NewStepRepository wraps a database already migrated by New or NewFromDB.
+func NewStepRepository(db *sql.DB) *StepRepository {
+	return &StepRepository{db: db}
+}
+
+func (r *StepRepository) List(ctx context.Context, operationID string) ([]domain.StepRecord, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT operation_id, step, fingerprint, ref, completed_at FROM operation_steps
+		 WHERE operation_id = ? ORDER BY completed_at, rowid`, operationID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing operation steps: %w", err)
+	}
+	defer rows.Close()
+
+	var recs []domain.StepRecord
+	for rows.Next() {
+		var (
+			rec         domain.StepRecord
+			completedAt string
+		)
+		if err := rows.Scan(&rec.OperationID, &rec.Step, &rec.Fingerprint, &rec.Ref, &completedAt); err != nil {
+			return nil, fmt.Errorf("scanning operation step: %w", err)
+		}
+		rec.CompletedAt, _ = time.Parse(timeFormat, completedAt)
+		recs = append(recs, rec)
+	}
+	return recs, rows.Err()
+}
+
+func (r *StepRepository) Save(ctx context.Context, rec domain.StepRecord) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO operation_steps (operation_id, step, fingerprint, ref, completed_at) VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT (operation_id, step) DO UPDATE SET fingerprint = excluded.fingerprint,
+		 ref = excluded.ref, completed_at = excluded.completed_at`,
+		rec.OperationID, rec.Step, rec.Fingerprint, rec.Ref, rec.CompletedAt.UTC().Format(timeFormat),
+	)
+	if err != nil {
+		return fmt.Errorf("saving operation step: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestSteps_SaveAndList(t *testing.T) {
+	steps := sqlite.NewStepRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, rec := range []domain.StepRecord{
+		{OperationID: "op_1", Step: "git", Fingerprint: "a", Ref: "https://git.example.com/pull/1", CompletedAt: at},
+		{OperationID: "op_1", Step: "dns", Fingerprint: "b", CompletedAt: at.Add(time.Second)},
+		{OperationID: "op_2", Step: "git", Fingerprint: "c", CompletedAt: at},
+		// Recording a step again replaces it.
+		{OperationID: "op_1", Step: "dns", Fingerprint: "d", Ref: "rec-1", CompletedAt: at.Add(time.Minute)},
+	} {
+		if err := steps.Save(ctx, rec); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	got, err := steps.List(ctx, "op_1")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	want := []domain.StepRecord{
+		{OperationID: "op_1", Step: "git", Fingerprint: "a", Ref: "https://git.example.com/pull/1", CompletedAt: at},
+		{OperationID: "op_1", Step: "dns", Fingerprint: "d", Ref: "rec-1", CompletedAt: at.Add(time.Minute)},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("List = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("step %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSteps_PrunedWithTheirOperation(t *testing.T) {
+	db := newTestRepo(t).DB()
+	ops := sqlite.NewOperationRepository(db)
+	steps := sqlite.NewStepRepository(db)
+	ctx := context.Background()
+
+	done := domain.NewOperation("op_done", domain.OperationProvision, "ten_1")
+	done.Status, done.UpdatedAt = domain.OperationSucceeded, time.Now().UTC().Add(-30*24*time.Hour)
+	pending := domain.NewOperation("op_pending", domain.OperationProvision, "ten_2")
+	for _, op := range []domain.Operation{done, pending} {
+		if err := ops.Create(ctx, op); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		if err := steps.Save(ctx, domain.StepRecord{OperationID: op.ID, Step: "git", Fingerprint: "a", CompletedAt: op.CreatedAt}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	if n, err := ops.Prune(ctx, time.Now().UTC().Add(-time.Hour), false); err != nil || n != 1 {
+		t.Fatalf("Prune = %d, %v; want 1", n, err)
+	}
+	if got, _ := steps.List(ctx, "op_done"); len(got) != 0 {
+		t.Errorf("steps of the pruned operation were kept: %+v", got)
+	}
+	if got, _ := steps.List(ctx, "op_pending"); len(got) != 1 {
+		t.Errorf("steps of the pending operation = %+v, want kept", got)
+	}
+}
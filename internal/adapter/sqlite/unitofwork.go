@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: UnitOfWork implements domain.UnitOfWork.
+var _ domain.UnitOfWork = (*UnitOfWork)(nil)
+
+// UnitOfWork implements domain.UnitOfWork with a SQLite transaction. The
+// tenants table and the publisher's storage (e.g. River's job table) must
+// live in the same database.
+type UnitOfWork struct {
+	db        *sql.DB
+	publisher func(*sql.Tx) domain.EventPublisher
+}
+
+// NewUnitOfWork wraps a database already migrated by New or NewFromDB.
+// publisher returns a publisher writing within the given transaction, such
+// as the River publisher's InTx.
+func NewUnitOfWork(db *sql.DB, publisher func(*sql.Tx) domain.EventPublisher) *UnitOfWork {
+	return &UnitOfWork{db: db, publisher: publisher}
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(domain.TenantRepository, domain.EventPublisher) error) error {
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // no-op after Commit
+
+	if err := fn(&TenantRepository{db: u.db, q: tx}, u.publisher(tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: UsageRepository implements domain.UsageRepository.
+var _ domain.UsageRepository = (*UsageRepository)(nil)
+
+// UsageRepository implements domain.UsageRepository using SQLite, keeping
+// the latest value of each metric per tenant. It shares the tenants
+// database, whose migrations create its table.
+type UsageRepository struct {
+	db *sql.DB
+}
+
+// NewUsageRepository wraps a database already migrated by New or NewFromDB.
+func NewUsageRepository(db *sql.DB) *UsageRepository {
+	return &UsageRepository{db: db}
+}
+
+func (r *UsageRepository) Record(ctx context.Context, tenantID string, usage domain.Usage) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // no-op after Commit
+
+	now := time.Now().UTC().Format(timeFormat)
+	for metric, value := range usage {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO tenant_usage (tenant_id, metric, value, reported_at) VALUES (?, ?, ?, ?)
+			 ON CONFLICT (tenant_id, metric) DO UPDATE SET value = excluded.value, reported_at = excluded.reported_at`,
+			tenantID, metric, value, now,
+		)
+		if err != nil {
+			return fmt.Errorf("recording usage of %q: %w", metric, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *UsageRepository) Get(ctx context.Context, tenantID string) (domain.Usage, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT metric, value FROM tenant_usage WHERE tenant_id = ?`, tenantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := domain.Usage{}
+	for rows.Next() {
+		var (
+			metric string
+			value  int64
+		)
+		if err := rows.Scan(&metric, &value); err != nil {
+			return nil, fmt.Errorf("scanning usage: %w", err)
+		}
+		usage[metric] = value
+	}
+	return usage, rows.Err()
+}
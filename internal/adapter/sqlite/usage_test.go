@@ -0,0 +1,34 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestUsage_RecordMergesMetrics(t *testing.T) {
+	usage := sqlite.NewUsageRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	if err := usage.Record(ctx, "ten_1", domain.Usage{"seats": 3, "storage_gb": 10}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := usage.Record(ctx, "ten_1", domain.Usage{"seats": 7}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	got, err := usage.Get(ctx, "ten_1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if len(got) != 2 || got["seats"] != 7 || got["storage_gb"] != 10 {
+		t.Errorf("usage = %v, want seats 7 and storage_gb 10", got)
+	}
+
+	empty, err := usage.Get(ctx, "ten_2")
+	if err != nil || len(empty) != 0 {
+		t.Errorf("Get(ten_2) = %v, %v; want empty usage", empty, err)
+	}
+}
@@ -0,0 +1,221 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: WebhookRepository implements domain.WebhookRepository.
+var _ domain.WebhookRepository = (*WebhookRepository)(nil)
+
+// WebhookRepository implements domain.WebhookRepository using SQLite. The
+// event filter is stored as a JSON array. Secrets are stored as given: they
+// are needed in clear to sign deliveries.
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository wraps a database already migrated by New or NewFromDB.
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+const webhookColumns = `id, url, secret, events, digest, created_at, updated_at,
+	consecutive_failures, last_error, last_failure_at, circuit_opened_at`
+
+func (r *WebhookRepository) Create(ctx context.Context, w domain.WebhookSubscription) error {
+	events, err := encodeEvents(w.Events)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO webhook_subscriptions (id, url, secret, events, digest, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		w.ID, w.URL, w.Secret, events, digestMode(w.Digest), w.CreatedAt.Format(timeFormat), w.UpdatedAt.Format(timeFormat),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) GetByID(ctx context.Context, id string) (domain.WebhookSubscription, error) {
+	w, err := scanWebhook(r.db.QueryRowContext(ctx,
+		`SELECT `+webhookColumns+` FROM webhook_subscriptions WHERE id = ?`, id,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WebhookSubscription{}, domain.ErrWebhookNotFound
+		}
+		return domain.WebhookSubscription{}, fmt.Errorf("scanning webhook subscription: %w", err)
+	}
+	return w, nil
+}
+
+func (r *WebhookRepository) List(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+webhookColumns+` FROM webhook_subscriptions ORDER BY created_at, id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []domain.WebhookSubscription
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning webhook subscription: %w", err)
+		}
+		subs = append(subs, w)
+	}
+	return subs, rows.Err()
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, w domain.WebhookSubscription) error {
+	events, err := encodeEvents(w.Events)
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE webhook_subscriptions SET url = ?, secret = ?, events = ?, digest = ?, updated_at = ? WHERE id = ?`,
+		w.URL, w.Secret, events, digestMode(w.Digest), w.UpdatedAt.Format(timeFormat), w.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("updating webhook subscription: %w", err)
+	}
+	return requireRow(result, domain.ErrWebhookNotFound)
+}
+
+// Delete removes the subscription along with the events held for its
+// digest.
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // no-op after Commit
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("deleting webhook subscription: %w", err)
+	}
+	if err := requireRow(result, domain.ErrWebhookNotFound); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM webhook_digest_entries WHERE webhook_id = ?`, id); err != nil {
+		return fmt.Errorf("deleting webhook digest entries: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// RecordFailure counts the failure and opens the circuit in one statement,
+// so concurrent deliveries cannot lose a count or both open the circuit.
+func (r *WebhookRepository) RecordFailure(ctx context.Context, id, reason string, at time.Time, threshold int) (domain.WebhookCircuit, error) {
+	ts := at.UTC().Format(timeFormat)
+	var (
+		c                  domain.WebhookCircuit
+		failedAt, openedAt string
+	)
+	err := r.db.QueryRowContext(ctx,
+		`UPDATE webhook_subscriptions SET
+		 consecutive_failures = consecutive_failures + 1, last_error = ?, last_failure_at = ?,
+		 circuit_opened_at = CASE WHEN circuit_opened_at = '' AND consecutive_failures + 1 >= ? THEN ? ELSE circuit_opened_at END
+		 WHERE id = ?
+		 RETURNING consecutive_failures, last_error, last_failure_at, circuit_opened_at`,
+		reason, ts, threshold, ts, id,
+	).Scan(&c.ConsecutiveFailures, &c.LastError, &failedAt, &openedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WebhookCircuit{}, domain.ErrWebhookNotFound
+		}
+		return domain.WebhookCircuit{}, fmt.Errorf("recording webhook failure: %w", err)
+	}
+	c.LastFailureAt, _ = time.Parse(timeFormat, failedAt)
+	c.OpenedAt, _ = time.Parse(timeFormat, openedAt) // Zero when closed.
+	return c, nil
+}
+
+func (r *WebhookRepository) RecordSuccess(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE webhook_subscriptions SET consecutive_failures = 0 WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("recording webhook success: %w", err)
+	}
+	return requireRow(result, domain.ErrWebhookNotFound)
+}
+
+func (r *WebhookRepository) CloseCircuit(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE webhook_subscriptions SET consecutive_failures = 0, circuit_opened_at = '' WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("closing webhook circuit: %w", err)
+	}
+	return requireRow(result, domain.ErrWebhookNotFound)
+}
+
+// requireRow returns notFound when result affected no row.
+func requireRow(result sql.Result, notFound error) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("checking rows affected: %w", err)
+	}
+	if rows == 0 {
+		return notFound
+	}
+	return nil
+}
+
+func encodeEvents(events []domain.Event) (string, error) {
+	if events == nil {
+		events = []domain.Event{}
+	}
+	data, err := json.Marshal(events)
+	if err != nil {
+		return "", fmt.Errorf("encoding webhook events: %w", err)
+	}
+	return string(data), nil
+}
+
+// digestMode stores the immediate mode of subscriptions created without
+// one explicitly.
+func digestMode(m domain.DigestMode) string {
+	if m == "" {
+		return string(domain.DigestImmediate)
+	}
+	return string(m)
+}
+
+func scanWebhook(row rowScanner) (domain.WebhookSubscription, error) {
+	var (
+		w                    domain.WebhookSubscription
+		events, digest       string
+		createdAt, updatedAt string
+		failedAt, openedAt   string
+	)
+	err := row.Scan(&w.ID, &w.URL, &w.Secret, &events, &digest, &createdAt, &updatedAt,
+		&w.Circuit.ConsecutiveFailures, &w.Circuit.LastError, &failedAt, &openedAt)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	if err := json.Unmarshal([]byte(events), &w.Events); err != nil {
+		return domain.WebhookSubscription{}, fmt.Errorf("decoding webhook events: %w", err)
+	}
+	if len(w.Events) == 0 {
+		w.Events = nil
+	}
+	w.Digest = domain.DigestMode(digest)
+	w.CreatedAt, _ = time.Parse(timeFormat, createdAt)
+	w.UpdatedAt, _ = time.Parse(timeFormat, updatedAt)
+	w.Circuit.LastFailureAt, _ = time.Parse(timeFormat, failedAt) // Zero when empty.
+	w.Circuit.OpenedAt, _ = time.Parse(timeFormat, openedAt)
+	return w, nil
+}
@@ -0,0 +1,133 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func mustWebhook(t *testing.T, id string, events ...domain.Event) domain.WebhookSubscription {
+	t.Helper()
+	w, err := domain.NewWebhookSubscription(id, "https://example.com/"+id, "0123456789abcdef", events)
+	if err != nil {
+		t.Fatalf("NewWebhookSubscription: %v", err)
+	}
+	return w
+}
+
+func TestWebhooks_CRUD(t *testing.T) {
+	webhooks := sqlite.NewWebhookRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	for _, w := range []domain.WebhookSubscription{
+		mustWebhook(t, "wh_1", domain.EventSuspend, domain.EventDelete),
+		mustWebhook(t, "wh_2"),
+	} {
+		if err := webhooks.Create(ctx, w); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	got, err := webhooks.GetByID(ctx, "wh_1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.URL != "https://example.com/wh_1" || len(got.Events) != 2 || got.Events[1] != domain.EventDelete {
+		t.Errorf("got %+v", got)
+	}
+
+	got.Events = nil
+	got.Secret = "fedcba9876543210"
+	if err := webhooks.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	all, err := webhooks.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(all) != 2 || all[0].ID != "wh_1" || all[0].Events != nil || all[0].Secret != "fedcba9876543210" {
+		t.Errorf("List = %+v", all)
+	}
+
+	if err := webhooks.Delete(ctx, "wh_1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := webhooks.GetByID(ctx, "wh_1"); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("GetByID after delete = %v, want ErrWebhookNotFound", err)
+	}
+}
+
+func TestWebhooks_NotFound(t *testing.T) {
+	webhooks := sqlite.NewWebhookRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+
+	if err := webhooks.Update(ctx, mustWebhook(t, "wh_missing")); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("Update = %v, want ErrWebhookNotFound", err)
+	}
+	if err := webhooks.Delete(ctx, "wh_missing"); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("Delete = %v, want ErrWebhookNotFound", err)
+	}
+}
+
+func TestWebhooks_Circuit(t *testing.T) {
+	webhooks := sqlite.NewWebhookRepository(newTestRepo(t).DB())
+	ctx := context.Background()
+	if err := webhooks.Create(ctx, mustWebhook(t, "wh_1")); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := webhooks.RecordFailure(ctx, "wh_1", "503 Service Unavailable", at.Add(time.Duration(i)*time.Second), 5); err != nil {
+				t.Errorf("RecordFailure: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	c, err := webhooks.RecordFailure(ctx, "wh_1", "connection refused", at.Add(time.Minute), 4)
+	if err != nil {
+		t.Fatalf("RecordFailure: %v", err)
+	}
+	if c.ConsecutiveFailures != 4 || !c.OpenedAt.Equal(at.Add(time.Minute)) || c.LastError != "connection refused" {
+		t.Errorf("circuit = %+v, want 4 failures, opened by the last one", c)
+	}
+	c, _ = webhooks.RecordFailure(ctx, "wh_1", "connection refused", at.Add(time.Hour), 4)
+	if !c.OpenedAt.Equal(at.Add(time.Minute)) {
+		t.Errorf("OpenedAt = %s, want it kept at the opening", c.OpenedAt)
+	}
+
+	if err := webhooks.RecordSuccess(ctx, "wh_1"); err != nil {
+		t.Fatalf("RecordSuccess: %v", err)
+	}
+	got, _ := webhooks.GetByID(ctx, "wh_1")
+	if got.Circuit.ConsecutiveFailures != 0 || !got.Circuit.Open() || !got.Circuit.LastFailureAt.Equal(at.Add(time.Hour)) {
+		t.Errorf("after a success = %+v, want no failures and the circuit still open", got.Circuit)
+	}
+
+	// Updating the subscription leaves its circuit alone.
+	got.URL = "https://example.com/moved"
+	if err := webhooks.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := webhooks.CloseCircuit(ctx, "wh_1"); err != nil {
+		t.Fatalf("CloseCircuit: %v", err)
+	}
+	got, _ = webhooks.GetByID(ctx, "wh_1")
+	if got.Circuit.Open() || got.URL != "https://example.com/moved" {
+		t.Errorf("after CloseCircuit = %+v, want closed", got)
+	}
+
+	if _, err := webhooks.RecordFailure(ctx, "wh_gone", "x", at, 1); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("RecordFailure of a missing subscription = %v, want ErrWebhookNotFound", err)
+	}
+}
@@ -0,0 +1,222 @@
+// Package stripe keeps a Stripe customer and subscription in sync with
+// each tenant. A SyncWorker, queued after the events that matter to
+// billing (see Follow), creates the tenant's customer, subscribes it to the
+// price of its plan and cancels the subscription when the tenant is
+// deleted. The Stripe IDs are stored as the tenant's external references
+// stripe_customer_id and stripe_subscription_id.
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBaseURL is the URL of the Stripe API.
+const DefaultBaseURL = "https://api.stripe.com"
+
+// maxErrorBody caps how much of an error response is read.
+const maxErrorBody = 4096
+
+// Client is a minimal client of the Stripe REST API, covering customers
+// and subscriptions.
+type Client struct {
+	baseURL string
+	key     string
+	client  *http.Client
+}
+
+// NewClient creates a client calling the API at baseURL with the secret
+// key.
+func NewClient(baseURL, key string, client *http.Client) *Client {
+	return &Client{baseURL: strings.TrimRight(baseURL, "/"), key: key, client: client}
+}
+
+// Subscription is the state of a subscription with a single item.
+type Subscription struct {
+	ID     string
+	Status string
+	// ItemID and Price are those of the subscription's first item.
+	ItemID string
+	Price  string
+}
+
+// Canceled reports whether the subscription is over and a new one is
+// needed to bill the customer again.
+func (s Subscription) Canceled() bool {
+	return s.Status == "canceled" || s.Status == "incomplete_expired"
+}
+
+type customerResponse struct {
+	ID string `json:"id"`
+}
+
+type subscriptionResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Items  struct {
+		Data []struct {
+			ID    string `json:"id"`
+			Price struct {
+				ID string `json:"id"`
+			} `json:"price"`
+		} `json:"data"`
+	} `json:"items"`
+}
+
+func (r subscriptionResponse) subscription() Subscription {
+	sub := Subscription{ID: r.ID, Status: r.Status}
+	if len(r.Items.Data) > 0 {
+		sub.ItemID = r.Items.Data[0].ID
+		sub.Price = r.Items.Data[0].Price.ID
+	}
+	return sub
+}
+
+type errorResponse struct {
+	Error struct {
+		Type    string `json:"type"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CreateCustomer creates the customer of a tenant. Retries with the same
+// idempotency key return the customer created first.
+func (c *Client) CreateCustomer(ctx context.Context, tenantID, name, idempotencyKey string) (string, error) {
+	form := url.Values{
+		"name":                {name},
+		"metadata[tenant_id]": {tenantID},
+	}
+	var resp customerResponse
+	if err := c.do(ctx, http.MethodPost, "/v1/customers", form, idempotencyKey, &resp); err != nil {
+		return "", fmt.Errorf("creating customer: %w", err)
+	}
+	return resp.ID, nil
+}
+
+// CreateSubscription subscribes a customer to price. The first invoice is
+// left for the customer to pay, so a customer without a payment method can
+// be subscribed.
+func (c *Client) CreateSubscription(ctx context.Context, customer, tenantID, price, idempotencyKey string) (Subscription, error) {
+	form := url.Values{
+		"customer":            {customer},
+		"items[0][price]":     {price},
+		"payment_behavior":    {"default_incomplete"},
+		"metadata[tenant_id]": {tenantID},
+	}
+	var resp subscriptionResponse
+	if err := c.do(ctx, http.MethodPost, "/v1/subscriptions", form, idempotencyKey, &resp); err != nil {
+		return Subscription{}, fmt.Errorf("creating subscription: %w", err)
+	}
+	return resp.subscription(), nil
+}
+
+// GetSubscription returns a subscription.
+func (c *Client) GetSubscription(ctx context.Context, id string) (Subscription, error) {
+	var resp subscriptionResponse
+	if err := c.do(ctx, http.MethodGet, "/v1/subscriptions/"+url.PathEscape(id), nil, "", &resp); err != nil {
+		return Subscription{}, fmt.Errorf("getting subscription: %w", err)
+	}
+	return resp.subscription(), nil
+}
+
+// ChangePrice moves the subscription's item to price, prorating the
+// change.
+func (c *Client) ChangePrice(ctx context.Context, sub Subscription, price string) (Subscription, error) {
+	form := url.Values{
+		"items[0][id]":       {sub.ItemID},
+		"items[0][price]":    {price},
+		"proration_behavior": {"create_prorations"},
+	}
+	var resp subscriptionResponse
+	if err := c.do(ctx, http.MethodPost, "/v1/subscriptions/"+url.PathEscape(sub.ID), form, "", &resp); err != nil {
+		return Subscription{}, fmt.Errorf("changing subscription price: %w", err)
+	}
+	return resp.subscription(), nil
+}
+
+// CancelSubscription cancels a subscription immediately. Cancelling one
+// that is already canceled succeeds.
+func (c *Client) CancelSubscription(ctx context.Context, id string) error {
+	var resp subscriptionResponse
+	err := c.do(ctx, http.MethodDelete, "/v1/subscriptions/"+url.PathEscape(id), nil, "", &resp)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.alreadyCanceled() {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("canceling subscription: %w", err)
+	}
+	return nil
+}
+
+// APIError is an error answered by the Stripe API.
+type APIError struct {
+	StatusCode int
+	Type       string
+	Code       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("stripe: %d %s: %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("stripe: %d: %s", e.StatusCode, e.Message)
+}
+
+// alreadyCanceled reports whether the error is Stripe refusing to cancel a
+// subscription that is already canceled, or gone.
+func (e *APIError) alreadyCanceled() bool {
+	return e.StatusCode == http.StatusNotFound ||
+		(e.Type == "invalid_request_error" && strings.Contains(e.Message, "canceled subscription"))
+}
+
+// do sends a form-encoded request and decodes the JSON response into out.
+// A non-empty idempotencyKey makes a retried POST safe.
+func (c *Client) do(ctx context.Context, method, path string, form url.Values, idempotencyKey string, out any) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.key)
+	req.Header.Set("Accept", "application/json")
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		var e errorResponse
+		if json.Unmarshal(raw, &e) == nil && e.Error.Message != "" {
+			apiErr.Type, apiErr.Code, apiErr.Message = e.Error.Type, e.Error.Code, e.Error.Message
+		} else {
+			apiErr.Message = strings.TrimSpace(string(raw))
+		}
+		return apiErr
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
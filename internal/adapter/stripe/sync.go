@@ -0,0 +1,192 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/riverqueue/river"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// External references holding a tenant's Stripe IDs.
+const (
+	CustomerRef     = "stripe_customer_id"
+	SubscriptionRef = "stripe_subscription_id"
+)
+
+// SyncArgs triggers the sync of a tenant with Stripe.
+type SyncArgs struct {
+	TenantID string `json:"tenant_id"`
+	// Event is the domain event that triggered the sync, for the logs.
+	Event string `json:"event"`
+}
+
+// Kind returns the unique job type identifier used by River's job routing.
+func (SyncArgs) Kind() string { return "stripe.sync" }
+
+// Follow queues a sync after the events that change what the tenant is
+// billed for: its creation, a plan change (trial expiries may downgrade)
+// and its deletion. It is the
+// follower of the event worker (see riveradapter.WithEventFollower).
+// The sync's own updates publish metadata_updated events, which are not
+// followed.
+func Follow(event riveradapter.EventJobArgs) []river.JobArgs {
+	switch event.Event() {
+	case domain.EventProvisionComplete, domain.EventPlanChanged, domain.EventTrialExpired, domain.EventDelete, domain.EventDeletionComplete:
+		return []river.JobArgs{SyncArgs{TenantID: event.Data.TenantID, Event: string(event.Event())}}
+	}
+	return nil
+}
+
+// SyncWorker reconciles a tenant's Stripe customer and subscription with
+// its current state, so a sync can be retried or run out of order.
+type SyncWorker struct {
+	river.WorkerDefaults[SyncArgs]
+	client *Client
+	svc    *app.TenantService
+	prices map[string]string
+}
+
+// NewSyncWorker creates a sync worker subscribing tenants to prices, the
+// Stripe price of each plan. Tenants on a plan without a price are
+// customers without a subscription.
+func NewSyncWorker(client *Client, svc *app.TenantService, prices map[string]string) *SyncWorker {
+	return &SyncWorker{client: client, svc: svc, prices: prices}
+}
+
+// Work syncs one tenant. A tenant being deleted has its subscription
+// canceled; any other has a customer and, if its plan has a price, a
+// subscription to it.
+func (w *SyncWorker) Work(ctx context.Context, job *river.Job[SyncArgs]) error {
+	ctx = domain.WithActor(ctx, "stripe-sync")
+
+	tenant, err := w.svc.GetByID(ctx, job.Args.TenantID)
+	if errors.Is(err, domain.ErrTenantNotFound) {
+		// Purged: nothing is left to bill.
+		slog.InfoContext(ctx, "stripe sync skipped", "tenant_id", job.Args.TenantID, "reason", "tenant not found")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("getting tenant: %w", err)
+	}
+	if tenant.Simulated {
+		return nil
+	}
+
+	switch tenant.Status {
+	case domain.StatusDeleting, domain.StatusDeleted:
+		err = w.cancel(ctx, tenant)
+	default:
+		err = w.subscribe(ctx, tenant)
+	}
+	if err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "stripe sync finished",
+		"tenant_id", tenant.ID,
+		"event", job.Args.Event,
+		"status", tenant.Status,
+		"plan", tenant.Plan,
+		"job_id", job.ID,
+	)
+	return nil
+}
+
+// subscribe ensures the tenant has a customer and a subscription to the
+// price of its plan.
+func (w *SyncWorker) subscribe(ctx context.Context, tenant domain.Tenant) error {
+	customer := tenant.ExternalRefs[CustomerRef]
+	if customer == "" {
+		var err error
+		customer, err = w.client.CreateCustomer(ctx, tenant.ID, tenant.Name, "tenantiq-customer-"+tenant.ID)
+		if err != nil {
+			return err
+		}
+		if tenant, err = w.setRef(ctx, tenant.ID, CustomerRef, customer); err != nil {
+			return err
+		}
+	}
+
+	subID := tenant.ExternalRefs[SubscriptionRef]
+	price, ok := w.prices[tenant.Plan]
+	if !ok {
+		if subID == "" {
+			return nil
+		}
+		return w.cancel(ctx, tenant)
+	}
+
+	if subID != "" {
+		sub, err := w.client.GetSubscription(ctx, subID)
+		if err != nil {
+			return err
+		}
+		if !sub.Canceled() {
+			if sub.Price == price {
+				return nil
+			}
+			_, err := w.client.ChangePrice(ctx, sub, price)
+			return err
+		}
+	}
+
+	// Retries of this sync replay the same creation; the tenant's version,
+	// bumped by storing the subscription or forgetting a canceled one, keys
+	// a new one on the next.
+	sub, err := w.client.CreateSubscription(ctx, customer, tenant.ID, price,
+		fmt.Sprintf("tenantiq-subscription-%s-%d", tenant.ID, tenant.Version))
+	if err != nil {
+		return err
+	}
+	_, err = w.setRef(ctx, tenant.ID, SubscriptionRef, sub.ID)
+	return err
+}
+
+// cancel cancels the tenant's subscription, if any, and forgets it. The
+// customer is kept for its invoices.
+func (w *SyncWorker) cancel(ctx context.Context, tenant domain.Tenant) error {
+	subID := tenant.ExternalRefs[SubscriptionRef]
+	if subID == "" {
+		return nil
+	}
+	if err := w.client.CancelSubscription(ctx, subID); err != nil {
+		return err
+	}
+	_, err := w.setRef(ctx, tenant.ID, SubscriptionRef, "")
+	return err
+}
+
+// setRef stores a Stripe ID as an external reference of the tenant; an
+// empty id removes it. It returns the updated tenant.
+func (w *SyncWorker) setRef(ctx context.Context, tenantID, ref, id string) (domain.Tenant, error) {
+	tenant, err := w.svc.Update(ctx, tenantID, domain.TenantPatch{ExternalRefs: map[string]string{ref: id}})
+	if err != nil {
+		return domain.Tenant{}, fmt.Errorf("storing %s: %w", ref, err)
+	}
+	return tenant, nil
+}
+
+// ParsePrices parses the Stripe price of each plan from a comma-separated
+// list of plan=price pairs, e.g. "pro=price_123,enterprise=price_456".
+func ParsePrices(s string) (map[string]string, error) {
+	prices := make(map[string]string)
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		plan, price, ok := strings.Cut(pair, "=")
+		plan, price = strings.TrimSpace(plan), strings.TrimSpace(price)
+		if !ok || plan == "" || price == "" {
+			return nil, fmt.Errorf("invalid plan price %q, want plan=price", pair)
+		}
+		prices[plan] = price
+	}
+	return prices, nil
+}
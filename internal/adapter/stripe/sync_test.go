@@ -0,0 +1,352 @@
+package stripe_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+
+	riveradapter "github.com/neomorfeo/tenantiq/internal/adapter/river"
+	"github.com/neomorfeo/tenantiq/internal/adapter/sqlite"
+	"github.com/neomorfeo/tenantiq/internal/adapter/stripe"
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+const apiKey = "sk_test_123"
+
+// fakeStripe is an in-memory Stripe API with customers and single-item
+// subscriptions.
+type fakeStripe struct {
+	url string
+
+	mu            sync.Mutex
+	customers     int
+	subscriptions map[string]*fakeSubscription
+	// byKey answers replayed idempotency keys with the first response.
+	byKey map[string]string
+}
+
+type fakeSubscription struct {
+	customer string
+	status   string
+	price    string
+}
+
+func newFakeStripe(t *testing.T) (*fakeStripe, *stripe.Client) {
+	t.Helper()
+	f := &fakeStripe{subscriptions: make(map[string]*fakeSubscription), byKey: make(map[string]string)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/customers", f.createCustomer)
+	mux.HandleFunc("POST /v1/subscriptions", f.createSubscription)
+	mux.HandleFunc("GET /v1/subscriptions/{id}", f.getSubscription)
+	mux.HandleFunc("POST /v1/subscriptions/{id}", f.updateSubscription)
+	mux.HandleFunc("DELETE /v1/subscriptions/{id}", f.cancelSubscription)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+apiKey {
+			stripeError(w, http.StatusUnauthorized, "Invalid API Key provided")
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	f.url = srv.URL
+	return f, stripe.NewClient(srv.URL, apiKey, srv.Client())
+}
+
+func stripeError(w http.ResponseWriter, status int, message string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `{"error":{"type":"invalid_request_error","message":%q}}`, message)
+}
+
+func (f *fakeStripe) createCustomer(w http.ResponseWriter, r *http.Request) {
+	if id, ok := f.byKey[r.Header.Get("Idempotency-Key")]; ok {
+		fmt.Fprintf(w, `{"id":%q}`, id)
+		return
+	}
+	f.customers++
+	id := fmt.Sprintf("cus_%d", f.customers)
+	f.byKey[r.Header.Get("Idempotency-Key")] = id
+	fmt.Fprintf(w, `{"id":%q}`, id)
+}
+
+func (f *fakeStripe) createSubscription(w http.ResponseWriter, r *http.Request) {
+	id, ok := f.byKey[r.Header.Get("Idempotency-Key")]
+	if !ok {
+		id = fmt.Sprintf("sub_%d", len(f.subscriptions)+1)
+		f.subscriptions[id] = &fakeSubscription{
+			customer: r.FormValue("customer"),
+			status:   "incomplete",
+			price:    r.FormValue("items[0][price]"),
+		}
+		f.byKey[r.Header.Get("Idempotency-Key")] = id
+	}
+	f.writeSubscription(w, id)
+}
+
+func (f *fakeStripe) getSubscription(w http.ResponseWriter, r *http.Request) {
+	if _, ok := f.subscriptions[r.PathValue("id")]; !ok {
+		stripeError(w, http.StatusNotFound, "No such subscription")
+		return
+	}
+	f.writeSubscription(w, r.PathValue("id"))
+}
+
+func (f *fakeStripe) updateSubscription(w http.ResponseWriter, r *http.Request) {
+	sub, ok := f.subscriptions[r.PathValue("id")]
+	if !ok {
+		stripeError(w, http.StatusNotFound, "No such subscription")
+		return
+	}
+	if r.FormValue("items[0][id]") != "si_"+r.PathValue("id") {
+		stripeError(w, http.StatusBadRequest, "No such subscription item")
+		return
+	}
+	sub.price = r.FormValue("items[0][price]")
+	f.writeSubscription(w, r.PathValue("id"))
+}
+
+func (f *fakeStripe) cancelSubscription(w http.ResponseWriter, r *http.Request) {
+	sub, ok := f.subscriptions[r.PathValue("id")]
+	if !ok {
+		stripeError(w, http.StatusNotFound, "No such subscription")
+		return
+	}
+	if sub.status == "canceled" {
+		stripeError(w, http.StatusBadRequest, "A canceled subscription can only update its cancellation_details and metadata.")
+		return
+	}
+	sub.status = "canceled"
+	f.writeSubscription(w, r.PathValue("id"))
+}
+
+func (f *fakeStripe) writeSubscription(w http.ResponseWriter, id string) {
+	sub := f.subscriptions[id]
+	fmt.Fprintf(w, `{"id":%q,"customer":%q,"status":%q,"items":{"data":[{"id":"si_%s","price":{"id":%q}}]}}`,
+		id, sub.customer, sub.status, id, sub.price)
+}
+
+// subscription returns a copy of a subscription's state.
+func (f *fakeStripe) subscription(id string) fakeSubscription {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if sub, ok := f.subscriptions[id]; ok {
+		return *sub
+	}
+	return fakeSubscription{}
+}
+
+type tableValidator struct{}
+
+func (tableValidator) Apply(_ context.Context, tenant domain.Tenant, event domain.Event) (domain.Status, error) {
+	for _, t := range domain.Transitions {
+		if t.Event == event && t.Src == tenant.Status {
+			return t.Dst, nil
+		}
+	}
+	return "", &domain.TransitionError{Event: event, Current: tenant.Status}
+}
+
+type noopPublisher struct{}
+
+func (noopPublisher) Publish(_ context.Context, _ domain.TenantEvent) error { return nil }
+
+func newService(t *testing.T) *app.TenantService {
+	t.Helper()
+	repo, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("creating repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return app.NewTenantService(repo, noopPublisher{}, tableValidator{})
+}
+
+func syncJob(tenantID string, event domain.Event) *river.Job[stripe.SyncArgs] {
+	return &river.Job[stripe.SyncArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   stripe.SyncArgs{TenantID: tenantID, Event: string(event)},
+	}
+}
+
+func TestSyncWorker_Lifecycle(t *testing.T) {
+	fake, client := newFakeStripe(t)
+	svc := newService(t)
+	ctx := context.Background()
+	worker := stripe.NewSyncWorker(client, svc, map[string]string{"pro": "price_pro", "enterprise": "price_ent"})
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+	sync := func(event domain.Event) domain.Tenant {
+		t.Helper()
+		if err := worker.Work(ctx, syncJob(tenant.ID, event)); err != nil {
+			t.Fatalf("Work after %s: %v", event, err)
+		}
+		got, err := svc.GetByID(ctx, tenant.ID)
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		return got
+	}
+
+	// Created: a customer subscribed to the plan's price.
+	got := sync(domain.EventProvisionComplete)
+	customer, subID := got.ExternalRefs[stripe.CustomerRef], got.ExternalRefs[stripe.SubscriptionRef]
+	if customer == "" || subID == "" {
+		t.Fatalf("refs = %v, want customer and subscription", got.ExternalRefs)
+	}
+	if sub := fake.subscription(subID); sub.customer != customer || sub.price != "price_pro" {
+		t.Errorf("subscription = %+v, want %s on price_pro", sub, customer)
+	}
+
+	// A second sync changes nothing.
+	if again := sync(domain.EventProvisionComplete); again.ExternalRefs[stripe.CustomerRef] != customer || again.ExternalRefs[stripe.SubscriptionRef] != subID {
+		t.Errorf("refs after resync = %v, want unchanged", again.ExternalRefs)
+	}
+	if fake.customers != 1 {
+		t.Errorf("customers = %d, want 1", fake.customers)
+	}
+
+	// Plan changed: the subscription moves to the new price.
+	plan := "enterprise"
+	if _, err := svc.Update(ctx, tenant.ID, domain.TenantPatch{Plan: &plan}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	sync(domain.EventPlanChanged)
+	if sub := fake.subscription(subID); sub.price != "price_ent" {
+		t.Errorf("price = %q, want price_ent", sub.price)
+	}
+
+	// Deleted: the subscription is canceled and forgotten, the customer kept.
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventDelete); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	got = sync(domain.EventDelete)
+	if sub := fake.subscription(subID); sub.status != "canceled" {
+		t.Errorf("subscription status = %q, want canceled", sub.status)
+	}
+	if got.ExternalRefs[stripe.SubscriptionRef] != "" || got.ExternalRefs[stripe.CustomerRef] != customer {
+		t.Errorf("refs after delete = %v, want only the customer", got.ExternalRefs)
+	}
+}
+
+func TestSyncWorker_PlanWithoutPrice(t *testing.T) {
+	fake, client := newFakeStripe(t)
+	svc := newService(t)
+	ctx := context.Background()
+	worker := stripe.NewSyncWorker(client, svc, map[string]string{"pro": "price_pro"})
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := worker.Work(ctx, syncJob(tenant.ID, domain.EventProvisionComplete)); err != nil {
+		t.Fatalf("Work: %v", err)
+	}
+	tenant, _ = svc.GetByID(ctx, tenant.ID)
+	subID := tenant.ExternalRefs[stripe.SubscriptionRef]
+
+	// Downgraded to a free plan: no subscription left to bill.
+	plan := "free"
+	if _, err := svc.Update(ctx, tenant.ID, domain.TenantPatch{Plan: &plan}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := worker.Work(ctx, syncJob(tenant.ID, domain.EventPlanChanged)); err != nil {
+		t.Fatalf("Work: %v", err)
+	}
+	got, _ := svc.GetByID(ctx, tenant.ID)
+	if got.ExternalRefs[stripe.SubscriptionRef] != "" || got.ExternalRefs[stripe.CustomerRef] == "" {
+		t.Errorf("refs = %v, want only the customer", got.ExternalRefs)
+	}
+	if sub := fake.subscription(subID); sub.status != "canceled" {
+		t.Errorf("subscription status = %q, want canceled", sub.status)
+	}
+
+	// Back on a paid plan: subscribed again.
+	plan = "pro"
+	if _, err := svc.Update(ctx, tenant.ID, domain.TenantPatch{Plan: &plan}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := worker.Work(ctx, syncJob(tenant.ID, domain.EventPlanChanged)); err != nil {
+		t.Fatalf("Work: %v", err)
+	}
+	got, _ = svc.GetByID(ctx, tenant.ID)
+	if newID := got.ExternalRefs[stripe.SubscriptionRef]; newID == "" || newID == subID {
+		t.Errorf("subscription = %q, want a new one", newID)
+	}
+}
+
+func TestSyncWorker_MissingTenant(t *testing.T) {
+	_, client := newFakeStripe(t)
+	worker := stripe.NewSyncWorker(client, newService(t), nil)
+	if err := worker.Work(context.Background(), syncJob("ten_gone", domain.EventDeletionComplete)); err != nil {
+		t.Errorf("Work = %v, want nil for a purged tenant", err)
+	}
+}
+
+func TestSyncWorker_APIError(t *testing.T) {
+	fake, _ := newFakeStripe(t)
+	svc := newService(t)
+	ctx := context.Background()
+	worker := stripe.NewSyncWorker(stripe.NewClient(fake.url, "sk_wrong", http.DefaultClient), svc, nil)
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	err = worker.Work(ctx, syncJob(tenant.ID, domain.EventProvisionComplete))
+	var apiErr *stripe.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || apiErr.Message != "Invalid API Key provided" {
+		t.Errorf("Work = %v, want the API error so River retries", err)
+	}
+}
+
+func TestFollow(t *testing.T) {
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "pro")
+	for _, tc := range []struct {
+		event domain.Event
+		want  bool
+	}{
+		{domain.EventProvisionComplete, true},
+		{domain.EventPlanChanged, true},
+		{domain.EventTrialExpired, true},
+		{domain.EventDelete, true},
+		{domain.EventDeletionComplete, true},
+		{domain.EventSuspend, false},
+		{domain.EventMetadataUpdated, false},
+	} {
+		jobs := stripe.Follow(riveradapter.NewCloudEvent("/test", domain.TenantEvent{Event: tc.event, Tenant: tenant}))
+		if got := len(jobs) == 1; got != tc.want {
+			t.Errorf("Follow(%s) = %v, want followed %v", tc.event, jobs, tc.want)
+			continue
+		}
+		if tc.want && jobs[0] != (stripe.SyncArgs{TenantID: "ten_1", Event: string(tc.event)}) {
+			t.Errorf("Follow(%s) = %+v", tc.event, jobs[0])
+		}
+	}
+}
+
+func TestParsePrices(t *testing.T) {
+	prices, err := stripe.ParsePrices(" pro=price_1, enterprise = price_2 ,")
+	if err != nil {
+		t.Fatalf("ParsePrices: %v", err)
+	}
+	if len(prices) != 2 || prices["pro"] != "price_1" || prices["enterprise"] != "price_2" {
+		t.Errorf("prices = %v", prices)
+	}
+	if _, err := stripe.ParsePrices("pro"); err == nil {
+		t.Error("ParsePrices accepted a pair without a price")
+	}
+}
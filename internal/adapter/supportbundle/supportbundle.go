@@ -0,0 +1,154 @@
+// Package supportbundle builds the archive operators attach to bug reports:
+// a gzipped tar of JSON and text files describing an installation. Secrets
+// never reach the archive: configuration values that look like credentials
+// are redacted, and every redacted value is also removed from the other
+// files, such as logs that happened to print it.
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Redacted replaces secret values in the bundle.
+const Redacted = "[REDACTED]"
+
+// secretMarkers are the parts of a variable name that mark its value as a
+// secret, e.g. BILLING_API_TOKEN or SENTRY_DSN. OTLP headers usually carry
+// an API key.
+var secretMarkers = []string{"SECRET", "TOKEN", "PASSWORD", "PASSWD", "KEY", "CREDENTIAL", "DSN", "AUTH", "HEADERS"}
+
+// minSecretLen is the length below which a value is not searched for in
+// the other files: "1" or "true" would redact unrelated text.
+const minSecretLen = 4
+
+// RedactEnv turns environment entries ("NAME=value") into configuration
+// with the secrets redacted. Values of variables named like secrets are
+// replaced whole; passwords in URLs (e.g. AMQP_URL) are replaced in place.
+// It also returns the redacted values, for New.
+func RedactEnv(env []string) (config map[string]string, secrets []string) {
+	config = make(map[string]string, len(env))
+	for _, entry := range env {
+		name, value, _ := strings.Cut(entry, "=")
+		switch {
+		case value == "":
+			config[name] = value
+		case isSecretName(name):
+			config[name] = Redacted
+			secrets = append(secrets, value)
+		default:
+			redacted, password := redactURL(value)
+			if password != "" {
+				secrets = append(secrets, password)
+			}
+			config[name] = redacted
+		}
+	}
+	return config, secrets
+}
+
+func isSecretName(name string) bool {
+	upper := strings.ToUpper(name)
+	return slices.ContainsFunc(secretMarkers, func(m string) bool { return strings.Contains(upper, m) })
+}
+
+// redactURL replaces the password of a URL with Redacted and returns it.
+// Values that are not URLs with a password are returned unchanged.
+func redactURL(value string) (string, string) {
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value, ""
+	}
+	password, ok := u.User.Password()
+	if !ok || password == "" {
+		return value, ""
+	}
+	u.User = url.UserPassword(u.User.Username(), Redacted)
+	// Keep the placeholder readable rather than percent-encoded.
+	return strings.Replace(u.String(), url.QueryEscape(Redacted), Redacted, 1), password
+}
+
+// Bundle collects the files of a support archive.
+type Bundle struct {
+	createdAt time.Time
+	secrets   []string
+	files     []file
+}
+
+type file struct {
+	name string
+	data []byte
+}
+
+// New creates an empty bundle removing secrets from every file added.
+func New(createdAt time.Time, secrets []string) *Bundle {
+	b := &Bundle{createdAt: createdAt}
+	for _, s := range secrets {
+		if len(s) >= minSecretLen {
+			b.secrets = append(b.secrets, s)
+		}
+	}
+	// Longest first, so a secret containing another is removed whole.
+	slices.SortFunc(b.secrets, func(a, b string) int { return len(b) - len(a) })
+	return b
+}
+
+// AddJSON adds v as an indented JSON file.
+func (b *Bundle) AddJSON(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", name, err)
+	}
+	b.add(name, append(data, '\n'))
+	return nil
+}
+
+// AddText adds a text file.
+func (b *Bundle) AddText(name, text string) {
+	b.add(name, []byte(text))
+}
+
+func (b *Bundle) add(name string, data []byte) {
+	for _, s := range b.secrets {
+		data = []byte(strings.ReplaceAll(string(data), s, Redacted))
+	}
+	b.files = append(b.files, file{name: name, data: data})
+}
+
+// Write writes the bundle to w as a gzipped tar, with the files in the
+// order they were added under a directory named after the creation time.
+func (b *Bundle) Write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	dir := "tenantiq-support-" + b.createdAt.UTC().Format("20060102T150405Z") + "/"
+	for _, f := range b.files {
+		hdr := &tar.Header{
+			Name:    dir + f.name,
+			Mode:    0o644,
+			Size:    int64(len(f.data)),
+			ModTime: b.createdAt,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("writing %s: %w", f.name, err)
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return fmt.Errorf("writing %s: %w", f.name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("closing archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("compressing archive: %w", err)
+	}
+	return nil
+}
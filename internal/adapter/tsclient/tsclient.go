@@ -0,0 +1,645 @@
+// Package tsclient generates a dependency-free TypeScript client from the
+// OpenAPI document Huma builds for the REST API: one type per component
+// schema, and a client class with one typed method per operation, calling
+// the API with fetch.
+package tsclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+const (
+	schemaPrefix = "#/components/schemas/"
+	// errorSchema is the problem details schema Huma registers for error
+	// responses; the generated ApiError carries one.
+	errorSchema = "ErrorModel"
+)
+
+// methods lists the HTTP methods in the order operations of one path are
+// generated.
+var methods = []string{"GET", "PUT", "POST", "PATCH", "DELETE", "HEAD", "OPTIONS", "TRACE"}
+
+// reserved lists the names the generated types must not take: those of the
+// runtime and the globals it uses.
+var reserved = []string{
+	"ApiError", "ClientOptions", "Scalar", "RequestParts", "BaseClient",
+	"Blob", "BodyInit", "Error", "Headers", "Record", "RequestInit", "Response", "URLSearchParams",
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// Generate returns the TypeScript source of a client for doc. The output
+// only depends on the document, so it can be committed and checked for
+// drift.
+func Generate(doc *huma.OpenAPI) ([]byte, error) {
+	if doc.Components == nil || doc.Components.Schemas == nil {
+		return nil, fmt.Errorf("document has no component schemas")
+	}
+	schemas := doc.Components.Schemas.Map()
+	if _, ok := schemas[errorSchema]; !ok {
+		return nil, fmt.Errorf("document has no %s schema", errorSchema)
+	}
+
+	client := pascal(titleOf(doc)) + "Client"
+	for name := range schemas {
+		if slices.Contains(reserved, name) || name == client {
+			return nil, fmt.Errorf("schema %s collides with a name of the client", name)
+		}
+	}
+
+	g := &generator{}
+	g.line("// Code generated by go run ./cmd/openapi; DO NOT EDIT.")
+	if doc.Info != nil {
+		g.line("// %s API %s", doc.Info.Title, doc.Info.Version)
+	}
+	g.line("")
+
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		g.schema(name, schemas[name])
+	}
+
+	ops, err := operations(doc)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		if _, ok := schemas[op.request]; ok {
+			return nil, fmt.Errorf("operation %s: request type %s collides with a schema", op.OperationID, op.request)
+		}
+		g.request(op)
+	}
+
+	g.b.WriteString(runtime)
+	g.line("")
+	g.comment("", "Calls the "+titleOf(doc)+" API. Methods resolve with the decoded success response and reject with an ApiError for error responses.")
+	g.line("export class %s extends BaseClient {", client)
+	for i, op := range ops {
+		if i > 0 {
+			g.line("")
+		}
+		g.method(op)
+	}
+	g.line("}")
+	return []byte(g.b.String()), nil
+}
+
+func titleOf(doc *huma.OpenAPI) string {
+	if doc.Info == nil || doc.Info.Title == "" {
+		return "HTTP"
+	}
+	return doc.Info.Title
+}
+
+// operation is an OpenAPI operation with the names used in the client.
+type operation struct {
+	*huma.Operation
+	method, path string
+	// name is the client method, request the type of its argument.
+	name, request string
+	params        []param
+	body          *huma.RequestBody
+}
+
+// param is an operation parameter and the request field it is read from.
+type param struct {
+	*huma.Param
+	field string
+}
+
+// operations returns the operations of doc sorted by path and method.
+func operations(doc *huma.OpenAPI) ([]operation, error) {
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	var ops []operation
+	seen := map[string]bool{}
+	for _, path := range paths {
+		item := doc.Paths[path]
+		byMethod := map[string]*huma.Operation{
+			"GET": item.Get, "PUT": item.Put, "POST": item.Post, "PATCH": item.Patch,
+			"DELETE": item.Delete, "HEAD": item.Head, "OPTIONS": item.Options, "TRACE": item.Trace,
+		}
+		for _, method := range methods {
+			o := byMethod[method]
+			if o == nil {
+				continue
+			}
+			if o.OperationID == "" {
+				return nil, fmt.Errorf("%s %s has no operation ID", method, path)
+			}
+			op := operation{
+				Operation: o,
+				method:    method,
+				path:      path,
+				name:      camel(o.OperationID),
+				request:   pascal(o.OperationID) + "Request",
+				body:      o.RequestBody,
+			}
+			if seen[op.name] {
+				return nil, fmt.Errorf("operation %s: duplicate method %s", o.OperationID, op.name)
+			}
+			seen[op.name] = true
+
+			fields := map[string]bool{"body": op.body != nil}
+			for _, p := range o.Parameters {
+				if p.In == "cookie" {
+					continue // the browser owns cookies
+				}
+				field := p.Name
+				if p.In == "header" || !identifier.MatchString(field) {
+					field = camel(p.Name)
+				}
+				if fields[field] {
+					return nil, fmt.Errorf("operation %s: duplicate request field %s", o.OperationID, field)
+				}
+				fields[field] = true
+				op.params = append(op.params, param{Param: p, field: field})
+			}
+			ops = append(ops, op)
+		}
+	}
+	return ops, nil
+}
+
+type generator struct {
+	b strings.Builder
+}
+
+func (g *generator) line(format string, args ...any) {
+	if len(args) > 0 {
+		format = fmt.Sprintf(format, args...)
+	}
+	g.b.WriteString(format)
+	g.b.WriteByte('\n')
+}
+
+// comment writes text as a JSDoc comment at indent; empty text writes
+// nothing.
+func (g *generator) comment(indent, text string, tags ...string) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "*/", "*\\/"))
+	var lines []string
+	if text != "" {
+		lines = strings.Split(text, "\n")
+	}
+	if len(tags) > 0 && len(lines) > 0 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, tags...)
+	switch len(lines) {
+	case 0:
+		return
+	case 1:
+		g.line("%s/** %s */", indent, lines[0])
+		return
+	}
+	g.line("%s/**", indent)
+	for _, l := range lines {
+		g.line("%s", strings.TrimRight(indent+" * "+l, " "))
+	}
+	g.line("%s */", indent)
+}
+
+// schema writes a component schema as an interface for objects with
+// properties, or a type alias otherwise.
+func (g *generator) schema(name string, s *huma.Schema) {
+	g.comment("", s.Description, deprecated(s.Deprecated)...)
+	if s.Ref == "" && s.Type == huma.TypeObject && len(s.Properties) > 0 && !s.Nullable {
+		g.line("export interface %s {", name)
+		g.properties("  ", s)
+		g.line("}")
+	} else {
+		g.line("export type %s = %s;", name, typeOf(s, "", s.Nullable))
+	}
+	g.line("")
+}
+
+// properties writes the properties of an object schema, one per line.
+func (g *generator) properties(indent string, s *huma.Schema) {
+	for _, name := range sortedKeys(s.Properties) {
+		p := s.Properties[name]
+		g.comment(indent, p.Description, deprecated(p.Deprecated)...)
+		g.line("%s%s;", indent, property(name, p, slices.Contains(s.Required, name), indent))
+	}
+}
+
+func property(name string, s *huma.Schema, required bool, indent string) string {
+	var b strings.Builder
+	if s.ReadOnly {
+		b.WriteString("readonly ")
+	}
+	b.WriteString(key(name))
+	if !required {
+		b.WriteByte('?')
+	}
+	b.WriteString(": ")
+	b.WriteString(typeOf(s, indent, s.Nullable))
+	return b.String()
+}
+
+// request writes the argument type of an operation: its parameters and
+// body, if any.
+func (g *generator) request(op operation) {
+	if len(op.params) == 0 && op.body == nil {
+		return
+	}
+	g.comment("", "Parameters of "+op.name+".")
+	g.line("export interface %s {", op.request)
+	for _, p := range op.params {
+		g.comment("  ", p.Description, deprecated(p.Deprecated)...)
+		opt := "?"
+		if p.Required || p.In == "path" {
+			opt = ""
+		}
+		g.line("  %s%s: %s;", key(p.field), opt, typeOf(p.Schema, "  ", false))
+	}
+	if op.body != nil {
+		g.comment("  ", op.body.Description)
+		opt := "?"
+		if op.body.Required {
+			opt = ""
+		}
+		g.line("  body%s: %s;", opt, bodyType(op.body))
+	}
+	g.line("}")
+	g.line("")
+}
+
+// bodyType is the type of a request body: its JSON schema, or a Blob when
+// the operation also takes raw bytes.
+func bodyType(body *huma.RequestBody) string {
+	t := "unknown"
+	if mt, ok := body.Content["application/json"]; ok && mt.Schema != nil {
+		t = typeOf(mt.Schema, "  ", false)
+	}
+	if _, ok := body.Content["application/octet-stream"]; ok {
+		if t == "unknown" {
+			return "Blob"
+		}
+		t += " | Blob"
+	}
+	return t
+}
+
+// method writes the client method of an operation.
+func (g *generator) method(op operation) {
+	var doc []string
+	if op.Summary != "" {
+		doc = append(doc, op.Summary)
+	}
+	if op.Description != "" && op.Description != op.Summary {
+		doc = append(doc, op.Description)
+	}
+	g.comment("  ", strings.Join(doc, "\n\n"), deprecated(op.Deprecated)...)
+
+	result, decode := response(op)
+	args := "init?: RequestInit"
+	if len(op.params) > 0 || op.body != nil {
+		arg := "request: " + op.request
+		if !requiresRequest(op) {
+			arg += " = {}"
+		}
+		args = arg + ", " + args
+	}
+	g.line("  async %s(%s): Promise<%s> {", op.name, args, result)
+
+	var parts []string
+	if q := g.locations(op, "query"); q != "" {
+		parts = append(parts, "query: "+q)
+	}
+	if h := g.locations(op, "header"); h != "" {
+		parts = append(parts, "headers: "+h)
+	}
+	if op.body != nil {
+		parts = append(parts, "body: request.body")
+	}
+	request := "{}"
+	if len(parts) > 0 {
+		request = "{ " + strings.Join(parts, ", ") + " }"
+	}
+	call := fmt.Sprintf("this.send(%s, %s, %s, init)", strconv.Quote(op.method), pathExpr(op), request)
+	if decode == "" {
+		g.line("    await %s;", call)
+	} else {
+		g.line("    const response = await %s;", call)
+		g.line("    %s", decode)
+	}
+	g.line("  }")
+}
+
+func requiresRequest(op operation) bool {
+	if op.body != nil && op.body.Required {
+		return true
+	}
+	return slices.ContainsFunc(op.params, func(p param) bool { return p.Required || p.In == "path" })
+}
+
+// locations returns an object literal mapping the names of the parameters
+// of op in location to their request fields, or "" when there are none.
+// Arrays of query parameters that are not exploded are sent comma-separated.
+func (g *generator) locations(op operation, in string) string {
+	var fields []string
+	for _, p := range op.params {
+		if p.In != in {
+			continue
+		}
+		value := "request." + p.field
+		if !identifier.MatchString(p.field) {
+			value = "request[" + strconv.Quote(p.field) + "]"
+		}
+		if in == "query" && p.Schema != nil && p.Schema.Type == huma.TypeArray && p.Explode != nil && !*p.Explode {
+			value += "?.join(\",\")"
+		}
+		fields = append(fields, key(p.Name)+": "+value)
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return "{ " + strings.Join(fields, ", ") + " }"
+}
+
+// pathExpr returns the expression building the path of op, with its path
+// parameters escaped.
+func pathExpr(op operation) string {
+	var parts []string
+	rest := op.path
+	for {
+		start := strings.IndexByte(rest, '{')
+		end := strings.IndexByte(rest, '}')
+		if start < 0 || end < start {
+			break
+		}
+		if start > 0 {
+			parts = append(parts, strconv.Quote(rest[:start]))
+		}
+		name := rest[start+1 : end]
+		field := name
+		for _, p := range op.params {
+			if p.In == "path" && p.Name == name {
+				field = p.field
+			}
+		}
+		parts = append(parts, "encodeURIComponent(String(request."+field+"))")
+		rest = rest[end+1:]
+	}
+	if rest != "" || len(parts) == 0 {
+		parts = append(parts, strconv.Quote(rest))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// response returns the result type of op and the statement returning it
+// from the fetch response, based on its lowest success status; the
+// statement is empty for responses without a body.
+func response(op operation) (string, string) {
+	var codes []string
+	for code := range op.Responses {
+		if len(code) == 3 && code[0] == '2' {
+			codes = append(codes, code)
+		}
+	}
+	slices.Sort(codes)
+	if len(codes) == 0 || len(op.Responses[codes[0]].Content) == 0 {
+		return "void", ""
+	}
+	content := op.Responses[codes[0]].Content
+	if mt, ok := content["application/json"]; ok {
+		t := "unknown"
+		if mt.Schema != nil {
+			t = typeOf(mt.Schema, "  ", mt.Schema.Nullable)
+		}
+		return t, "return (await response.json()) as " + t + ";"
+	}
+	for _, ct := range sortedKeys(content) {
+		if strings.HasPrefix(ct, "text/") {
+			return "string", "return response.text();"
+		}
+	}
+	return "Blob", "return response.blob();"
+}
+
+// typeOf returns the TypeScript type of a schema. Nested object literals
+// are indented past indent.
+func typeOf(s *huma.Schema, indent string, nullable bool) string {
+	if s == nil {
+		return "unknown"
+	}
+	var t string
+	switch {
+	case s.Ref != "":
+		t = strings.TrimPrefix(s.Ref, schemaPrefix)
+	case len(s.Enum) > 0:
+		literals := make([]string, 0, len(s.Enum))
+		for _, v := range s.Enum {
+			data, err := json.Marshal(v)
+			if err != nil {
+				return "unknown"
+			}
+			literals = append(literals, string(data))
+		}
+		t = strings.Join(literals, " | ")
+	case s.Type == huma.TypeString && s.Format == "binary":
+		t = "Blob"
+	case s.Type == huma.TypeString:
+		t = "string"
+	case s.Type == huma.TypeInteger, s.Type == huma.TypeNumber:
+		t = "number"
+	case s.Type == huma.TypeBoolean:
+		t = "boolean"
+	case s.Type == huma.TypeArray:
+		t = typeOf(s.Items, indent, s.Items != nil && s.Items.Nullable)
+		if strings.ContainsAny(t, " |") {
+			t = "(" + t + ")"
+		}
+		t += "[]"
+	case s.Type == huma.TypeObject && len(s.Properties) > 0:
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, name := range sortedKeys(s.Properties) {
+			p := s.Properties[name]
+			fmt.Fprintf(&b, "%s  %s;\n", indent, property(name, p, slices.Contains(s.Required, name), indent+"  "))
+		}
+		b.WriteString(indent + "}")
+		t = b.String()
+	case s.Type == huma.TypeObject:
+		value := "unknown"
+		if ap, ok := s.AdditionalProperties.(*huma.Schema); ok {
+			value = typeOf(ap, indent, ap.Nullable)
+		}
+		t = "Record<string, " + value + ">"
+	default:
+		t = "unknown"
+	}
+	if nullable && t != "unknown" {
+		if strings.Contains(t, " | ") {
+			t = "(" + t + ")"
+		}
+		t += " | null"
+	}
+	return t
+}
+
+func deprecated(d bool) []string {
+	if d {
+		return []string{"@deprecated"}
+	}
+	return nil
+}
+
+// key returns name as an object key, quoted when it is not an identifier.
+func key(name string) string {
+	if identifier.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// words splits an identifier such as "list-tenants", "tenant_id" or
+// "If-None-Match" into its words.
+func words(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
+
+// pascal returns s in PascalCase, e.g. ListTenants for list-tenants.
+func pascal(s string) string {
+	var b strings.Builder
+	for _, w := range words(s) {
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// camel returns s in camelCase, e.g. listTenants for list-tenants or
+// ifNoneMatch for If-None-Match.
+func camel(s string) string {
+	p := pascal(s)
+	if p == "" {
+		return p
+	}
+	return strings.ToLower(p[:1]) + p[1:]
+}
+
+// runtime is the hand-written part of the client: the error type and the
+// request plumbing shared by the generated methods.
+const runtime = `/** A problem details response of the API. */
+export class ApiError extends Error {
+  constructor(
+    /** HTTP status of the response. */
+    readonly status: number,
+    /** Problem details sent by the server. */
+    readonly problem: ErrorModel,
+  ) {
+    const title = problem.title ?? String(status);
+    super(problem.detail ? title + ": " + problem.detail : title);
+    this.name = "ApiError";
+  }
+
+  /** Reads the problem details of a failed response. */
+  static async from(response: Response): Promise<ApiError> {
+    let problem: ErrorModel = { status: response.status, title: response.statusText };
+    try {
+      problem = { ...problem, ...((await response.json()) as ErrorModel) };
+    } catch {
+      // Not a problem details body: keep the status line.
+    }
+    return new ApiError(response.status, problem);
+  }
+}
+
+/** Settings of a client. */
+export interface ClientOptions {
+  /** Base URL of the API, e.g. "https://tenantiq.example.com" or "" for the current origin. */
+  baseUrl: string;
+  /** Headers sent with every request, e.g. X-Actor for the audit log. */
+  headers?: Record<string, string>;
+  /** fetch implementation, the global one by default. */
+  fetch?: typeof fetch;
+}
+
+type Scalar = string | number | boolean;
+
+/** The parts of a request set by a generated method. */
+interface RequestParts {
+  query?: Record<string, Scalar | Scalar[] | null | undefined>;
+  headers?: Record<string, Scalar | null | undefined>;
+  body?: unknown;
+}
+
+/** Request plumbing shared by the generated methods. */
+export class BaseClient {
+  private readonly baseUrl: string;
+  private readonly headers: Record<string, string>;
+  private readonly fetchFn: typeof fetch;
+
+  constructor(options: ClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/+$/, "");
+    this.headers = options.headers ?? {};
+    this.fetchFn = options.fetch ?? globalThis.fetch.bind(globalThis);
+  }
+
+  /** Sends a request and rejects with an ApiError unless it succeeds. */
+  protected async send(method: string, path: string, parts: RequestParts, init?: RequestInit): Promise<Response> {
+    const query = new URLSearchParams();
+    for (const [name, value] of Object.entries(parts.query ?? {})) {
+      if (value === undefined || value === null) {
+        continue;
+      }
+      for (const v of Array.isArray(value) ? value : [value]) {
+        query.append(name, String(v));
+      }
+    }
+
+    const headers = new Headers(this.headers);
+    new Headers(init?.headers).forEach((value, name) => headers.set(name, value));
+    for (const [name, value] of Object.entries(parts.headers ?? {})) {
+      if (value !== undefined && value !== null) {
+        headers.set(name, String(value));
+      }
+    }
+    if (!headers.has("Accept")) {
+      headers.set("Accept", "application/json, application/problem+json");
+    }
+
+    let body: BodyInit | undefined;
+    if (parts.body instanceof Blob) {
+      body = parts.body;
+      headers.set("Content-Type", parts.body.type || "application/octet-stream");
+    } else if (parts.body !== undefined) {
+      body = JSON.stringify(parts.body);
+      headers.set("Content-Type", "application/json");
+    }
+
+    const search = query.toString();
+    const url = this.baseUrl + path + (search ? "?" + search : "");
+    const response = await this.fetchFn(url, { ...init, method, headers, body });
+    if (!response.ok) {
+      throw await ApiError.from(response);
+    }
+    return response;
+  }
+}
+`
@@ -0,0 +1,125 @@
+package tsclient_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/tsclient"
+)
+
+type widget struct {
+	ID     string            `json:"id" doc:"Unique identifier"`
+	Kind   string            `json:"kind" enum:"small,large"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Parts  []int             `json:"parts"`
+	Note   *string           `json:"note,omitempty" nullable:"true"`
+}
+
+type listInput struct {
+	Kind  []string `query:"kind" doc:"Filter by kind"`
+	Limit int      `query:"limit"`
+	Match string   `header:"If-None-Match"`
+}
+
+type getInput struct {
+	ID string `path:"id"`
+}
+
+type createInput struct {
+	Body struct {
+		Kind string `json:"kind"`
+	}
+}
+
+type widgetOutput struct {
+	Body widget
+}
+
+type listOutput struct {
+	Body []widget
+}
+
+func generate(t *testing.T) string {
+	t.Helper()
+	api := humachi.New(chi.NewMux(), huma.DefaultConfig("widgets", "1.0.0"))
+	huma.Register(api, huma.Operation{OperationID: "list-widgets", Method: http.MethodGet, Path: "/widgets", Summary: "List widgets"},
+		func(context.Context, *listInput) (*listOutput, error) { return nil, nil })
+	huma.Register(api, huma.Operation{OperationID: "create-widget", Method: http.MethodPost, Path: "/widgets"},
+		func(context.Context, *createInput) (*widgetOutput, error) { return nil, nil })
+	huma.Register(api, huma.Operation{OperationID: "get-widget", Method: http.MethodGet, Path: "/widgets/{id}"},
+		func(context.Context, *getInput) (*widgetOutput, error) { return nil, nil })
+	huma.Register(api, huma.Operation{OperationID: "delete-widget", Method: http.MethodDelete, Path: "/widgets/{id}", DefaultStatus: http.StatusNoContent},
+		func(context.Context, *getInput) (*struct{}, error) { return nil, nil })
+
+	out, err := tsclient.Generate(api.OpenAPI())
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	return string(out)
+}
+
+func assertContains(t *testing.T, src string, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		if !strings.Contains(src, w) {
+			t.Errorf("generated client lacks %q", w)
+		}
+	}
+}
+
+func TestGenerateTypes(t *testing.T) {
+	src := generate(t)
+
+	assertContains(t, src,
+		"// Code generated by go run ./cmd/openapi; DO NOT EDIT.",
+		"export interface Widget {",
+		"  /** Unique identifier */\n  id: string;",
+		`  kind: "small" | "large";`,
+		"  labels?: Record<string, string>;",
+		"  parts: number[] | null;",
+		"  note?: string | null;",
+		"  readonly $schema?: string;",
+		"export interface ErrorModel {",
+	)
+}
+
+func TestGenerateClient(t *testing.T) {
+	src := generate(t)
+
+	assertContains(t, src,
+		"export class ApiError extends Error {",
+		"export class WidgetsClient extends BaseClient {",
+		// Optional parameters only: the request can be omitted.
+		"export interface ListWidgetsRequest {\n  /** Filter by kind */\n  kind?: string[];\n  limit?: number;\n  ifNoneMatch?: string;\n}",
+		"  /** List widgets */\n  async listWidgets(request: ListWidgetsRequest = {}, init?: RequestInit): Promise<Widget[] | null> {",
+		`{ query: { kind: request.kind?.join(","), limit: request.limit }, headers: { "If-None-Match": request.ifNoneMatch } }`,
+		"  async createWidget(request: CreateWidgetRequest, init?: RequestInit): Promise<Widget> {",
+		"  body: CreateInputBody;",
+		`this.send("GET", "/widgets/" + encodeURIComponent(String(request.id)), {}, init);`,
+		"  async deleteWidget(request: DeleteWidgetRequest, init?: RequestInit): Promise<void> {\n    await this.send(",
+	)
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	if generate(t) != generate(t) {
+		t.Error("two generations of the same API differ")
+	}
+}
+
+func TestGenerateRejectsReservedNames(t *testing.T) {
+	api := humachi.New(chi.NewMux(), huma.DefaultConfig("widgets", "1.0.0"))
+	huma.Register(api, huma.Operation{OperationID: "get-widget", Method: http.MethodGet, Path: "/widgets/{id}"},
+		func(context.Context, *getInput) (*widgetOutput, error) { return nil, nil })
+	doc := api.OpenAPI()
+	doc.Components.Schemas.Map()["Response"] = &huma.Schema{Type: huma.TypeString}
+
+	if _, err := tsclient.Generate(doc); err == nil {
+		t.Error("Generate accepted a schema named Response")
+	}
+}
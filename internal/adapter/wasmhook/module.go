@@ -0,0 +1,111 @@
+package wasmhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+// wasmPageSize is the size of a WebAssembly memory page.
+const wasmPageSize = 64 << 10
+
+// module is a compiled hook module with its own runtime, which bounds its
+// memory.
+type module struct {
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+	entry    string
+	timeout  time.Duration
+}
+
+// compile compiles wasm and checks that it exports the memory, alloc and
+// entry functions.
+func compile(ctx context.Context, wasm []byte, entry string, maxMemoryMB uint32, timeout time.Duration) (*module, error) {
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(maxMemoryMB*(1<<20)/wasmPageSize).
+		// Lets the per-call timeout stop a module stuck in a loop.
+		WithCloseOnContextDone(true))
+	m := &module{runtime: runtime, entry: entry, timeout: timeout}
+
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		_ = m.close(ctx)
+		return nil, fmt.Errorf("instantiating WASI: %w", err)
+	}
+	compiled, err := runtime.CompileModule(ctx, wasm)
+	if err != nil {
+		_ = m.close(ctx)
+		return nil, fmt.Errorf("compiling: %w", err)
+	}
+	m.compiled = compiled
+
+	if _, ok := compiled.ExportedMemories()["memory"]; !ok {
+		_ = m.close(ctx)
+		return nil, errors.New(`missing export "memory"`)
+	}
+	for _, fn := range []string{"alloc", entry} {
+		if _, ok := compiled.ExportedFunctions()[fn]; !ok {
+			_ = m.close(ctx)
+			return nil, fmt.Errorf("missing export %q", fn)
+		}
+	}
+	return m, nil
+}
+
+// call runs the module's entry function on input in a fresh instance, so
+// no state leaks from one call to the next, and returns its result, nil
+// when there is none.
+func (m *module) call(ctx context.Context, input []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	out, err := m.run(ctx, input)
+	if err != nil && ctx.Err() != nil {
+		return nil, fmt.Errorf("%s timed out after %s", m.entry, m.timeout)
+	}
+	return out, err
+}
+
+func (m *module) run(ctx context.Context, input []byte) ([]byte, error) {
+	// Reactor modules (TinyGo, Rust cdylib) initialize in _initialize;
+	// a _start would run a command module's main and exit.
+	instance, err := m.runtime.InstantiateModule(ctx, m.compiled,
+		wazero.NewModuleConfig().WithName("").WithStartFunctions("_initialize"))
+	if err != nil {
+		return nil, fmt.Errorf("instantiating: %w", err)
+	}
+	defer instance.Close(ctx)
+
+	res, err := instance.ExportedFunction("alloc").Call(ctx, uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("alloc: %w", err)
+	}
+	ptr := uint32(res[0])
+	if !instance.Memory().Write(ptr, input) {
+		return nil, fmt.Errorf("alloc returned %d bytes out of memory", len(input))
+	}
+
+	res, err = instance.ExportedFunction(m.entry).Call(ctx, uint64(ptr), uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", m.entry, err)
+	}
+	if res[0] == 0 {
+		return nil, nil
+	}
+	outPtr, outLen := uint32(res[0]>>32), uint32(res[0])
+	out, ok := instance.Memory().Read(outPtr, outLen)
+	if !ok {
+		return nil, fmt.Errorf("%s returned %d bytes out of memory", m.entry, outLen)
+	}
+	// The view dies with the instance.
+	return bytes.Clone(out), nil
+}
+
+// close releases the runtime and the compiled module.
+func (m *module) close(ctx context.Context) error {
+	return m.runtime.Close(ctx)
+}
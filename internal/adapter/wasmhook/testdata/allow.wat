@@ -0,0 +1,13 @@
+;; Accepts every tenant and leaves every event payload unchanged.
+(module
+  (memory (export "memory") 1)
+  (global $heap (mut i32) (i32.const 1024))
+  (func (export "alloc") (param $size i32) (result i32)
+    (local $ptr i32)
+    (local.set $ptr (global.get $heap))
+    (global.set $heap (i32.add (global.get $heap) (local.get $size)))
+    (local.get $ptr))
+  (func (export "validate") (param i32 i32) (result i64)
+    (i64.const 0))
+  (func (export "transform") (param i32 i32) (result i64)
+    (i64.const 0)))
@@ -0,0 +1,17 @@
+;; Rejects every tenant and replaces every event payload.
+(module
+  (memory (export "memory") 1)
+  (global $heap (mut i32) (i32.const 1024))
+  (data (i32.const 8) "slug is reserved")
+  (data (i32.const 32) "{\"redacted\":true}")
+  (func (export "alloc") (param $size i32) (result i32)
+    (local $ptr i32)
+    (local.set $ptr (global.get $heap))
+    (global.set $heap (i32.add (global.get $heap) (local.get $size)))
+    (local.get $ptr))
+  ;; The reason at 8, 16 bytes long.
+  (func (export "validate") (param i32 i32) (result i64)
+    (i64.const 0x0000000800000010))
+  ;; The payload at 32, 17 bytes long.
+  (func (export "transform") (param i32 i32) (result i64)
+    (i64.const 0x0000002000000011)))
@@ -0,0 +1,277 @@
+// Package wasmhook runs deployment-specific hooks compiled to WebAssembly,
+// so customers can ship policy and payload rules in any language that
+// targets WASM instead of writing Go. Hooks are configured per hook point
+// in a YAML file:
+//
+//	hooks:
+//	  - name: reserved-slugs
+//	    point: create          # validates tenants before they are created
+//	    module: reserved-slugs.wasm
+//	  - name: redact-metadata
+//	    point: event           # rewrites outbound event payloads
+//	    module: redact.wasm
+//	    events: [metadata_updated, suspend]
+//	    timeout: 50ms
+//	    max_memory_mb: 4
+//
+// Module paths are relative to the file. A module exports its memory as
+// "memory", an "alloc(size i32) i32" function the host copies the input
+// into, and the function of its hook point, called with the input's
+// pointer and length:
+//
+//   - validate(ptr, len i32) i64, for create hooks, gets the tenant as
+//     JSON and returns the reason it is rejected, or nothing to accept it.
+//   - transform(ptr, len i32) i64, for event hooks, gets the CloudEvent as
+//     JSON and returns the payload to deliver instead, or nothing to leave
+//     it unchanged.
+//
+// Results are packed as ptr<<32 | len into the i64; zero means nothing.
+//
+// Modules are sandboxed by wazero: every call runs in a fresh instance
+// bounded in time and memory, and the only imports are WASI's, without
+// filesystem, network, environment or real clock, so modules built for
+// wasip1 (TinyGo, Rust) load as well as bare ones.
+package wasmhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time checks: the hooks plug into the service's ports.
+var (
+	_ domain.CreateHook     = (*CreateHook)(nil)
+	_ domain.EventTransform = (*EventTransform)(nil)
+)
+
+// Hook points a module can be configured for.
+const (
+	PointCreate = "create"
+	PointEvent  = "event"
+)
+
+// Defaults applied to a hook without a timeout or memory limit.
+const (
+	DefaultTimeout     = 100 * time.Millisecond
+	DefaultMaxMemoryMB = 16
+)
+
+// file is the on-disk representation of the hooks.
+type file struct {
+	Hooks []hook `yaml:"hooks"`
+}
+
+type hook struct {
+	Name        string   `yaml:"name"`
+	Point       string   `yaml:"point"`
+	Module      string   `yaml:"module"`
+	Events      []string `yaml:"events"`
+	Timeout     string   `yaml:"timeout"`
+	MaxMemoryMB uint32   `yaml:"max_memory_mb"`
+}
+
+// Hooks are the hooks loaded from a file, by hook point, in the file's
+// order. The zero value has none.
+type Hooks struct {
+	Create []domain.CreateHook
+	Events []domain.EventTransform
+
+	modules []*module
+}
+
+// Load reads the hooks in path and compiles their modules. Unknown fields,
+// hook points and events are rejected so a typo cannot silently disable a
+// hook, and so are modules missing an export their hook point needs.
+func Load(ctx context.Context, path string) (*Hooks, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading hooks: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var f file
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	hooks := &Hooks{}
+	names := make(map[string]bool, len(f.Hooks))
+	for i, h := range f.Hooks {
+		if err := hooks.add(ctx, filepath.Dir(path), h, names); err != nil {
+			_ = hooks.Close(ctx)
+			return nil, fmt.Errorf("%s: hooks[%d]: %w", path, i, err)
+		}
+	}
+	return hooks, nil
+}
+
+// add validates h and compiles its module for its hook point.
+func (hs *Hooks) add(ctx context.Context, dir string, h hook, names map[string]bool) error {
+	if h.Name == "" {
+		return errors.New("name is required")
+	}
+	if names[h.Name] {
+		return fmt.Errorf("duplicate hook %q", h.Name)
+	}
+	names[h.Name] = true
+
+	var entry string
+	switch h.Point {
+	case PointCreate:
+		entry = "validate"
+		if len(h.Events) > 0 {
+			return errors.New("events only apply to event hooks")
+		}
+	case PointEvent:
+		entry = "transform"
+	default:
+		return fmt.Errorf("point must be %s or %s, got %q", PointCreate, PointEvent, h.Point)
+	}
+	events := make([]domain.Event, 0, len(h.Events))
+	for _, e := range h.Events {
+		if !slices.Contains(domain.PublishedEvents(), domain.Event(e)) {
+			return fmt.Errorf("unknown event %q", e)
+		}
+		events = append(events, domain.Event(e))
+	}
+
+	timeout := DefaultTimeout
+	if h.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(h.Timeout); err != nil {
+			return fmt.Errorf("timeout: %w", err)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("timeout must be positive, got %s", timeout)
+		}
+	}
+	maxMemoryMB := h.MaxMemoryMB
+	if maxMemoryMB == 0 {
+		maxMemoryMB = DefaultMaxMemoryMB
+	}
+
+	if h.Module == "" {
+		return errors.New("module is required")
+	}
+	path := h.Module
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	wasm, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading module: %w", err)
+	}
+	m, err := compile(ctx, wasm, entry, maxMemoryMB, timeout)
+	if err != nil {
+		return fmt.Errorf("module %s: %w", h.Module, err)
+	}
+	hs.modules = append(hs.modules, m)
+
+	if h.Point == PointCreate {
+		hs.Create = append(hs.Create, &CreateHook{name: h.Name, module: m})
+	} else {
+		hs.Events = append(hs.Events, &EventTransform{name: h.Name, module: m, events: events})
+	}
+	return nil
+}
+
+// Close releases the compiled modules.
+func (hs *Hooks) Close(ctx context.Context) error {
+	var errs []error
+	for _, m := range hs.modules {
+		errs = append(errs, m.close(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// CreateHook validates tenants before they are created with a module's
+// validate function. A module that fails or times out rejects the tenant.
+type CreateHook struct {
+	name   string
+	module *module
+}
+
+// Name returns the hook's name from the file.
+func (h *CreateHook) Name() string { return h.name }
+
+// tenantInput is the tenant as create hooks get it.
+type tenantInput struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Slug       string            `json:"slug"`
+	Plan       string            `json:"plan"`
+	Region     string            `json:"region,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	ResellerID string            `json:"reseller_id,omitempty"`
+	Simulated  bool              `json:"simulated"`
+}
+
+// BeforeCreate runs the module on the tenant and returns its reason to
+// reject it, if any.
+func (h *CreateHook) BeforeCreate(ctx context.Context, tenant domain.Tenant) error {
+	input, err := json.Marshal(tenantInput{
+		ID:         tenant.ID,
+		Name:       tenant.Name,
+		Slug:       tenant.Slug,
+		Plan:       tenant.Plan,
+		Region:     tenant.Region,
+		Metadata:   tenant.Metadata,
+		ResellerID: tenant.ResellerID,
+		Simulated:  tenant.Simulated,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding tenant: %w", err)
+	}
+	reason, err := h.module.call(ctx, input)
+	if err != nil {
+		return err
+	}
+	if len(reason) > 0 {
+		return errors.New(string(reason))
+	}
+	return nil
+}
+
+// EventTransform rewrites outbound event payloads with a module's
+// transform function, for the configured events or all of them.
+type EventTransform struct {
+	name   string
+	module *module
+	events []domain.Event
+}
+
+// Name returns the hook's name from the file.
+func (t *EventTransform) Name() string { return t.name }
+
+// Transform runs the module on the payload and returns its replacement,
+// which must be JSON, or the payload itself when the module leaves it
+// unchanged or the event is not one of the hook's.
+func (t *EventTransform) Transform(ctx context.Context, event domain.Event, payload []byte) ([]byte, error) {
+	if len(t.events) > 0 && !slices.Contains(t.events, event) {
+		return payload, nil
+	}
+	out, err := t.module.call(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return payload, nil
+	}
+	if !json.Valid(out) {
+		return nil, errors.New("transform returned invalid JSON")
+	}
+	return out, nil
+}
@@ -0,0 +1,135 @@
+package wasmhook_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/adapter/wasmhook"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// loadHooks writes content as a hooks file next to copies of the testdata
+// modules and loads it.
+func loadHooks(t *testing.T, content string) (*wasmhook.Hooks, error) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range []string{"allow.wasm", "deny.wasm", "spin.wasm"} {
+		wasm, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatalf("reading module: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), wasm, 0o600); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	path := filepath.Join(dir, "hooks.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	hooks, err := wasmhook.Load(context.Background(), path)
+	if hooks != nil {
+		t.Cleanup(func() { _ = hooks.Close(context.Background()) })
+	}
+	return hooks, err
+}
+
+func TestCreateHook(t *testing.T) {
+	hooks, err := loadHooks(t, `
+hooks:
+  - name: open
+    point: create
+    module: allow.wasm
+  - name: reserved-slugs
+    point: create
+    module: deny.wasm
+`)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(hooks.Create) != 2 || len(hooks.Events) != 0 {
+		t.Fatalf("loaded %d create and %d event hooks, want 2 and 0", len(hooks.Create), len(hooks.Events))
+	}
+
+	ctx := context.Background()
+	tenant := domain.NewTenant("ten_1", "Admin", "admin", "free")
+	if err := hooks.Create[0].BeforeCreate(ctx, tenant); err != nil {
+		t.Errorf("allow: BeforeCreate = %v, want nil", err)
+	}
+	err = hooks.Create[1].BeforeCreate(ctx, tenant)
+	if err == nil || err.Error() != "slug is reserved" {
+		t.Errorf("deny: BeforeCreate = %v, want the module's reason", err)
+	}
+	if hooks.Create[1].Name() != "reserved-slugs" {
+		t.Errorf("Name = %q, want reserved-slugs", hooks.Create[1].Name())
+	}
+}
+
+func TestEventTransform(t *testing.T) {
+	hooks, err := loadHooks(t, `
+hooks:
+  - name: passthrough
+    point: event
+    module: allow.wasm
+  - name: redact
+    point: event
+    module: deny.wasm
+    events: [suspend]
+`)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	ctx := context.Background()
+	payload := []byte(`{"type":"io.tenantiq.tenant.suspend"}`)
+	got, err := domain.TransformEvent(ctx, hooks.Events, domain.EventSuspend, payload)
+	if err != nil || string(got) != `{"redacted":true}` {
+		t.Errorf("suspend: TransformEvent = %s, %v; want the module's payload", got, err)
+	}
+	got, err = domain.TransformEvent(ctx, hooks.Events, domain.EventReactivate, payload)
+	if err != nil || string(got) != string(payload) {
+		t.Errorf("reactivate: TransformEvent = %s, %v; want the payload unchanged", got, err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	hooks, err := loadHooks(t, `
+hooks:
+  - name: stuck
+    point: create
+    module: spin.wasm
+    timeout: 20ms
+`)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	err = hooks.Create[0].BeforeCreate(context.Background(), domain.NewTenant("ten_1", "Acme", "acme", "free"))
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("BeforeCreate = %v, want a timeout", err)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"unknown field":         "hooks:\n  - name: a\n    point: create\n    modul: allow.wasm\n",
+		"unknown point":         "hooks:\n  - name: a\n    point: update\n    module: allow.wasm\n",
+		"unknown event":         "hooks:\n  - name: a\n    point: event\n    module: allow.wasm\n    events: [archive]\n",
+		"events on create hook": "hooks:\n  - name: a\n    point: create\n    module: allow.wasm\n    events: [suspend]\n",
+		"duplicate name":        "hooks:\n  - name: a\n    point: create\n    module: allow.wasm\n  - name: a\n    point: event\n    module: allow.wasm\n",
+		"missing module":        "hooks:\n  - name: a\n    point: create\n    module: missing.wasm\n",
+		"not a module":          "hooks:\n  - name: a\n    point: create\n    module: hooks.yaml\n",
+		"bad timeout":           "hooks:\n  - name: a\n    point: create\n    module: allow.wasm\n    timeout: soon\n",
+	}
+
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := loadHooks(t, content)
+			if err == nil || !strings.Contains(err.Error(), "hooks.yaml") {
+				t.Errorf("Load = %v, want error naming the file", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// AdminKeyService issues the keys of the management API and authenticates
+// the requests made with them. As with tenant API keys, only hashes are
+// stored: a key is shown once, when it is created.
+type AdminKeyService struct {
+	repo domain.AdminKeyRepository
+	ids  IDGenerator
+}
+
+// NewAdminKeyService creates an admin key service.
+func NewAdminKeyService(repo domain.AdminKeyRepository) *AdminKeyService {
+	return &AdminKeyService{repo: repo, ids: NewIDGenerator(AdminKeyIDPrefix)}
+}
+
+// Create issues a key with role for name, created by the actor of ctx,
+// who must be an admin. It returns the key itself along with its record;
+// the key cannot be retrieved later.
+func (s *AdminKeyService) Create(ctx context.Context, name string, role domain.Role) (domain.AdminKey, string, error) {
+	if err := domain.RequireRole(ctx, domain.RoleAdmin, "creating admin keys"); err != nil {
+		return domain.AdminKey{}, "", err
+	}
+	id, err := s.ids.New()
+	if err != nil {
+		return domain.AdminKey{}, "", fmt.Errorf("generating admin key id: %w", err)
+	}
+	secret, err := generateSecret(AdminKeySecretPrefix)
+	if err != nil {
+		return domain.AdminKey{}, "", fmt.Errorf("generating admin key: %w", err)
+	}
+
+	k, err := domain.NewAdminKey(id, name, secret, role, domain.ActorFromContext(ctx))
+	if err != nil {
+		return domain.AdminKey{}, "", err
+	}
+	if err := s.repo.Create(ctx, k); err != nil {
+		return domain.AdminKey{}, "", err
+	}
+	slog.InfoContext(ctx, "admin key created",
+		"key_id", k.ID,
+		"name", k.Name,
+		"role", k.Role,
+		"actor", k.CreatedBy,
+	)
+	return k, secret, nil
+}
+
+// List returns every key, newest first, revoked ones included.
+func (s *AdminKeyService) List(ctx context.Context) ([]domain.AdminKey, error) {
+	return s.repo.List(ctx)
+}
+
+// Revoke stops the key from working; only admins may. Revoking it again
+// keeps the first revocation time.
+func (s *AdminKeyService) Revoke(ctx context.Context, id string) error {
+	if err := domain.RequireRole(ctx, domain.RoleAdmin, "revoking admin keys"); err != nil {
+		return err
+	}
+	if err := s.repo.Revoke(ctx, id, time.Now()); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "admin key revoked",
+		"key_id", id,
+		"actor", domain.ActorFromContext(ctx),
+	)
+	return nil
+}
+
+// Authenticate returns the key secret is and records it as used. A key
+// that is unknown or revoked is domain.ErrAPIKeyInvalid.
+func (s *AdminKeyService) Authenticate(ctx context.Context, secret string) (domain.AdminKey, error) {
+	k, err := s.repo.GetByHash(ctx, domain.HashAPIKey(secret))
+	if errors.Is(err, domain.ErrAdminKeyNotFound) {
+		return domain.AdminKey{}, domain.ErrAPIKeyInvalid
+	}
+	if err != nil {
+		return domain.AdminKey{}, fmt.Errorf("getting admin key: %w", err)
+	}
+	if !k.Active() {
+		return domain.AdminKey{}, domain.ErrAPIKeyInvalid
+	}
+
+	// Every request is authenticated; recording each use would make every
+	// read a write.
+	now := time.Now().UTC()
+	if now.Sub(k.LastUsedAt) >= domain.APIKeyTouchInterval {
+		if err := s.repo.Touch(ctx, k.ID, now); err != nil {
+			slog.WarnContext(ctx, "admin key use not recorded",
+				"key_id", k.ID,
+				"error", err,
+			)
+		} else {
+			k.LastUsedAt = now
+		}
+	}
+	return k, nil
+}
@@ -0,0 +1,110 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockAdminKeys keeps admin keys in memory and counts the uses recorded.
+type mockAdminKeys struct {
+	keys    map[string]domain.AdminKey
+	touches int
+}
+
+func (m *mockAdminKeys) Create(_ context.Context, k domain.AdminKey) error {
+	m.keys[k.ID] = k
+	return nil
+}
+
+func (m *mockAdminKeys) GetByHash(_ context.Context, hash string) (domain.AdminKey, error) {
+	for _, k := range m.keys {
+		if k.Hash == hash {
+			return k, nil
+		}
+	}
+	return domain.AdminKey{}, domain.ErrAdminKeyNotFound
+}
+
+func (m *mockAdminKeys) List(_ context.Context) ([]domain.AdminKey, error) {
+	var keys []domain.AdminKey
+	for _, k := range m.keys {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func (m *mockAdminKeys) Revoke(_ context.Context, id string, at time.Time) error {
+	k, ok := m.keys[id]
+	if !ok {
+		return domain.ErrAdminKeyNotFound
+	}
+	if k.RevokedAt.IsZero() {
+		k.RevokedAt = at
+	}
+	m.keys[id] = k
+	return nil
+}
+
+func (m *mockAdminKeys) Touch(_ context.Context, id string, at time.Time) error {
+	k := m.keys[id]
+	k.LastUsedAt = at
+	m.keys[id] = k
+	m.touches++
+	return nil
+}
+
+func TestAdminKeys_Authenticate(t *testing.T) {
+	repo := &mockAdminKeys{keys: map[string]domain.AdminKey{}}
+	svc := app.NewAdminKeyService(repo)
+	ctx := domain.WithActor(context.Background(), "alice")
+
+	k, secret, err := svc.Create(ctx, "terraform", domain.RoleOperator)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !strings.HasPrefix(k.ID, app.AdminKeyIDPrefix) || !strings.HasPrefix(secret, app.AdminKeySecretPrefix) {
+		t.Errorf("Create = %s, %s, want prefixes %s and %s", k.ID, secret, app.AdminKeyIDPrefix, app.AdminKeySecretPrefix)
+	}
+	if k.CreatedBy != "alice" || k.Role != domain.RoleOperator || k.Hash == secret || !strings.HasPrefix(secret, k.Prefix) {
+		t.Errorf("Create = %+v, want an operator key created by alice, hashed and with the key's prefix", k)
+	}
+	if _, _, err := svc.Create(ctx, "", domain.RoleAdmin); err == nil {
+		t.Error("Create without a name: want an error")
+	}
+	if _, _, err := svc.Create(ctx, "root", "superuser"); err == nil {
+		t.Error("Create with an unknown role: want an error")
+	}
+	var forbidden *domain.ForbiddenError
+	if _, _, err := svc.Create(domain.WithRole(ctx, domain.RoleOperator), "escalated", domain.RoleAdmin); !errors.As(err, &forbidden) {
+		t.Errorf("Create by an operator = %v, want ForbiddenError", err)
+	}
+
+	for range 2 {
+		got, err := svc.Authenticate(context.Background(), secret)
+		if err != nil {
+			t.Fatalf("Authenticate: %v", err)
+		}
+		if got.Name != "terraform" || got.LastUsedAt.IsZero() {
+			t.Errorf("Authenticate = %+v, want terraform's key, used", got)
+		}
+	}
+	if repo.touches != 1 {
+		t.Errorf("uses recorded = %d, want 1 within %s", repo.touches, domain.APIKeyTouchInterval)
+	}
+
+	if _, err := svc.Authenticate(context.Background(), "tqa_unknown"); !errors.Is(err, domain.ErrAPIKeyInvalid) {
+		t.Errorf("Authenticate of an unknown key = %v, want ErrAPIKeyInvalid", err)
+	}
+	if err := svc.Revoke(ctx, k.ID); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if _, err := svc.Authenticate(context.Background(), secret); !errors.Is(err, domain.ErrAPIKeyInvalid) {
+		t.Errorf("Authenticate of a revoked key = %v, want ErrAPIKeyInvalid", err)
+	}
+}
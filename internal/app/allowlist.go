@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithIPAllowlists lets tenants restrict the addresses their API keys may
+// be used from, storing the allowlists in repo.
+func WithIPAllowlists(repo domain.IPAllowlistRepository) Option {
+	return func(s *TenantService) {
+		s.allowlists = repo
+	}
+}
+
+// IPAllowlistsEnabled reports whether IP allowlists are configured.
+func (s *TenantService) IPAllowlistsEnabled() bool {
+	return s.allowlists != nil
+}
+
+// IPAllowlist returns the networks the tenant's API keys may be used
+// from; empty when they may be used from anywhere.
+func (s *TenantService) IPAllowlist(ctx context.Context, id string) (domain.IPAllowlist, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	l, err := s.allowlists.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting IP allowlist: %w", err)
+	}
+	return l, nil
+}
+
+// SetIPAllowlist replaces the tenant's allowlist with the networks in
+// entries (see domain.ParseIPAllowlist). No entries lift the restriction.
+func (s *TenantService) SetIPAllowlist(ctx context.Context, id string, entries []string) (domain.IPAllowlist, error) {
+	l, err := domain.ParseIPAllowlist(entries)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.allowlists.Set(ctx, id, l); err != nil {
+		return nil, fmt.Errorf("setting IP allowlist: %w", err)
+	}
+	slog.InfoContext(ctx, "tenant IP allowlist set",
+		"tenant_id", id,
+		"networks", len(l),
+		"actor", domain.ActorFromContext(ctx),
+	)
+	return l, nil
+}
+
+// checkClientIP returns an IPNotAllowedError when the request of ctx comes
+// from outside the tenant's allowlist. Work not requested over the
+// network, and tenants without a list, pass.
+func (s *TenantService) checkClientIP(ctx context.Context, tenantID string) error {
+	addr, ok := domain.ClientIPFromContext(ctx)
+	if s.allowlists == nil || !ok {
+		return nil
+	}
+	l, err := s.allowlists.Get(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("getting IP allowlist: %w", err)
+	}
+	if !l.Allows(addr) {
+		return &domain.IPNotAllowedError{TenantID: tenantID, IP: addr}
+	}
+	return nil
+}
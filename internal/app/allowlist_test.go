@@ -0,0 +1,67 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+type mockAllowlists struct {
+	lists map[string]domain.IPAllowlist
+}
+
+func (m *mockAllowlists) Get(_ context.Context, tenantID string) (domain.IPAllowlist, error) {
+	return m.lists[tenantID], nil
+}
+
+func (m *mockAllowlists) Set(_ context.Context, tenantID string, l domain.IPAllowlist) error {
+	m.lists[tenantID] = l
+	return nil
+}
+
+func TestIPAllowlists_RestrictAPIKeys(t *testing.T) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithIPAllowlists(&mockAllowlists{lists: map[string]domain.IPAllowlist{}}))
+	ks := app.NewAPIKeyService(&mockAPIKeys{keys: map[string]domain.APIKey{}}, svc)
+	ctx := context.Background()
+	newActiveTenant(t, repo, "ten_1", "pro")
+	_, secret, err := ks.Create(ctx, "ten_1", "CI", nil, time.Time{})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	l, err := svc.SetIPAllowlist(ctx, "ten_1", []string{"203.0.113.0/24"})
+	if err != nil || len(l) != 1 {
+		t.Fatalf("SetIPAllowlist = %v, %v", l, err)
+	}
+	if _, err := svc.SetIPAllowlist(ctx, "missing", nil); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("SetIPAllowlist of a missing tenant = %v, want ErrTenantNotFound", err)
+	}
+
+	inside := domain.WithClientIP(ctx, netip.MustParseAddr("203.0.113.9"))
+	if _, _, err := ks.Verify(inside, secret); err != nil {
+		t.Errorf("Verify from the allowlist: %v", err)
+	}
+	outside := domain.WithClientIP(ctx, netip.MustParseAddr("198.51.100.1"))
+	var notAllowed *domain.IPNotAllowedError
+	if _, _, err := ks.Verify(outside, secret); !errors.As(err, &notAllowed) || notAllowed.TenantID != "ten_1" {
+		t.Errorf("Verify from elsewhere = %v, want IPNotAllowedError", err)
+	}
+	// Work not requested over the network is not restricted.
+	if _, _, err := ks.Verify(ctx, secret); err != nil {
+		t.Errorf("Verify without a client address: %v", err)
+	}
+
+	if _, err := svc.SetIPAllowlist(ctx, "ten_1", nil); err != nil {
+		t.Fatalf("clearing the allowlist: %v", err)
+	}
+	if _, _, err := ks.Verify(outside, secret); err != nil {
+		t.Errorf("Verify after clearing the allowlist: %v", err)
+	}
+}
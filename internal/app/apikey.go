@@ -0,0 +1,145 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// APIKeyService issues the API keys of tenants and verifies them for the
+// tenants' applications. Only hashes are stored, so a key is shown once,
+// when it is created, and a lost key is replaced rather than recovered.
+type APIKeyService struct {
+	repo    domain.APIKeyRepository
+	tenants *TenantService
+	ids     IDGenerator
+}
+
+// NewAPIKeyService creates an API key service for the tenants of svc.
+func NewAPIKeyService(repo domain.APIKeyRepository, svc *TenantService) *APIKeyService {
+	return &APIKeyService{repo: repo, tenants: svc, ids: NewIDGenerator(APIKeyIDPrefix)}
+}
+
+// Create issues a key of the tenant with scopes, created by the actor of
+// ctx and valid until expiresAt (forever when zero). It returns the key
+// itself along with its record; the key cannot be retrieved later.
+func (s *APIKeyService) Create(ctx context.Context, tenantID, name string, scopes []string, expiresAt time.Time) (domain.APIKey, string, error) {
+	tenant, err := s.tenants.GetByID(ctx, tenantID)
+	if err != nil {
+		return domain.APIKey{}, "", err
+	}
+	id, err := s.ids.New()
+	if err != nil {
+		return domain.APIKey{}, "", fmt.Errorf("generating API key id: %w", err)
+	}
+	secret, err := generateSecret(APIKeySecretPrefix)
+	if err != nil {
+		return domain.APIKey{}, "", fmt.Errorf("generating API key: %w", err)
+	}
+
+	k, err := domain.NewAPIKey(id, tenant.ID, name, secret, scopes, expiresAt, domain.ActorFromContext(ctx))
+	if err != nil {
+		return domain.APIKey{}, "", err
+	}
+	if err := s.repo.Create(ctx, k); err != nil {
+		return domain.APIKey{}, "", err
+	}
+	return k, secret, nil
+}
+
+// List returns the tenant's keys, newest first, revoked ones included.
+func (s *APIKeyService) List(ctx context.Context, tenantID string) ([]domain.APIKey, error) {
+	if _, err := s.tenants.GetByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByTenant(ctx, tenantID)
+}
+
+// Revoke stops the key from working. Revoking it again keeps the first
+// revocation time.
+func (s *APIKeyService) Revoke(ctx context.Context, tenantID, id string) error {
+	return s.repo.Revoke(ctx, tenantID, id, time.Now())
+}
+
+// Verify returns the key secret is, with its tenant, and records it as
+// used. A key that is unknown, revoked or expired, or whose tenant is
+// being deleted, is domain.ErrAPIKeyInvalid; one used from outside the
+// tenant's IP allowlist is a domain.IPNotAllowedError.
+func (s *APIKeyService) Verify(ctx context.Context, secret string) (domain.APIKey, domain.Tenant, error) {
+	k, err := s.repo.GetByHash(ctx, domain.HashAPIKey(secret))
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return domain.APIKey{}, domain.Tenant{}, domain.ErrAPIKeyInvalid
+	}
+	if err != nil {
+		return domain.APIKey{}, domain.Tenant{}, fmt.Errorf("getting API key: %w", err)
+	}
+	now := time.Now().UTC()
+	if !k.Active(now) {
+		return domain.APIKey{}, domain.Tenant{}, domain.ErrAPIKeyInvalid
+	}
+
+	tenant, err := s.tenants.GetByID(ctx, k.TenantID)
+	if errors.Is(err, domain.ErrTenantNotFound) {
+		return domain.APIKey{}, domain.Tenant{}, domain.ErrAPIKeyInvalid
+	}
+	if err != nil {
+		return domain.APIKey{}, domain.Tenant{}, err
+	}
+	if tenant.Status == domain.StatusDeleting || tenant.Status == domain.StatusDeleted {
+		return domain.APIKey{}, domain.Tenant{}, domain.ErrAPIKeyInvalid
+	}
+	if err := s.tenants.checkClientIP(ctx, tenant.ID); err != nil {
+		slog.WarnContext(ctx, "API key used from outside its tenant's IP allowlist",
+			"key_id", k.ID,
+			"tenant_id", tenant.ID,
+			"error", err,
+		)
+		return domain.APIKey{}, domain.Tenant{}, err
+	}
+
+	// Recording every use would write on every request of the tenant's
+	// applications; a failure to record one does not fail it.
+	if now.Sub(k.LastUsedAt) >= domain.APIKeyTouchInterval {
+		if err := s.repo.Touch(ctx, k.ID, now); err != nil {
+			slog.WarnContext(ctx, "API key use not recorded",
+				"key_id", k.ID,
+				"error", err,
+			)
+		} else {
+			k.LastUsedAt = now
+		}
+	}
+	return k, tenant, nil
+}
+
+// Authorize is Verify for the operations tenantiq serves to the tenants'
+// applications: the key must also have scope, or it is a
+// domain.MissingScopeError.
+func (s *APIKeyService) Authorize(ctx context.Context, secret, scope string) (domain.APIKey, domain.Tenant, error) {
+	k, tenant, err := s.Verify(ctx, secret)
+	if err != nil {
+		return domain.APIKey{}, domain.Tenant{}, err
+	}
+	if !k.HasScope(scope) {
+		return domain.APIKey{}, domain.Tenant{}, &domain.MissingScopeError{Scope: scope}
+	}
+	return k, tenant, nil
+}
+
+// generateSecret returns a new key: prefix followed by 256 random bits in
+// hex.
+func generateSecret(prefix string) (string, error) {
+	a, err := generateID()
+	if err != nil {
+		return "", err
+	}
+	b, err := generateID()
+	if err != nil {
+		return "", err
+	}
+	return prefix + a + b, nil
+}
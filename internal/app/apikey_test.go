@@ -0,0 +1,145 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockAPIKeys keeps API keys in memory and counts the uses recorded.
+type mockAPIKeys struct {
+	keys    map[string]domain.APIKey
+	touches int
+}
+
+func (m *mockAPIKeys) Create(_ context.Context, k domain.APIKey) error {
+	m.keys[k.ID] = k
+	return nil
+}
+
+func (m *mockAPIKeys) Get(_ context.Context, tenantID, id string) (domain.APIKey, error) {
+	k, ok := m.keys[id]
+	if !ok || k.TenantID != tenantID {
+		return domain.APIKey{}, domain.ErrAPIKeyNotFound
+	}
+	return k, nil
+}
+
+func (m *mockAPIKeys) GetByHash(_ context.Context, hash string) (domain.APIKey, error) {
+	for _, k := range m.keys {
+		if k.Hash == hash {
+			return k, nil
+		}
+	}
+	return domain.APIKey{}, domain.ErrAPIKeyNotFound
+}
+
+func (m *mockAPIKeys) ListByTenant(_ context.Context, tenantID string) ([]domain.APIKey, error) {
+	var keys []domain.APIKey
+	for _, k := range m.keys {
+		if k.TenantID == tenantID {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+func (m *mockAPIKeys) Revoke(ctx context.Context, tenantID, id string, at time.Time) error {
+	k, err := m.Get(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if k.RevokedAt.IsZero() {
+		k.RevokedAt = at
+	}
+	m.keys[id] = k
+	return nil
+}
+
+func (m *mockAPIKeys) Touch(_ context.Context, id string, at time.Time) error {
+	k := m.keys[id]
+	k.LastUsedAt = at
+	m.keys[id] = k
+	m.touches++
+	return nil
+}
+
+func TestAPIKeys_CreateVerifyRevoke(t *testing.T) {
+	repo := newMockRepo()
+	keys := &mockAPIKeys{keys: map[string]domain.APIKey{}}
+	ks := app.NewAPIKeyService(keys, app.NewTenantService(repo, &mockPublisher{}, &mockValidator{}))
+	ctx := domain.WithActor(context.Background(), "alice")
+	newActiveTenant(t, repo, "ten_1", "pro")
+
+	k, secret, err := ks.Create(ctx, "ten_1", "CI", []string{"tenants:read"}, time.Time{})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !strings.HasPrefix(secret, app.APIKeySecretPrefix) || !strings.HasPrefix(secret, k.Prefix) || k.Hash == secret || k.CreatedBy != "alice" {
+		t.Errorf("Create = %+v, %q; want a hashed tqk_ key created by alice", k, secret)
+	}
+	if strings.Contains(keys.keys[k.ID].Hash, secret) {
+		t.Error("the key is stored in clear")
+	}
+
+	verified, tenant, err := ks.Verify(ctx, secret)
+	if err != nil || verified.ID != k.ID || tenant.ID != "ten_1" || verified.LastUsedAt.IsZero() {
+		t.Fatalf("Verify = %+v, %s, %v; want the key of ten_1, used now", verified, tenant.ID, err)
+	}
+	// A second use within APIKeyTouchInterval is not recorded.
+	if _, _, err := ks.Verify(ctx, secret); err != nil || keys.touches != 1 {
+		t.Errorf("second Verify: err = %v, touches = %d; want 1", err, keys.touches)
+	}
+
+	if _, _, err := ks.Verify(ctx, secret+"0"); !errors.Is(err, domain.ErrAPIKeyInvalid) {
+		t.Errorf("Verify of a wrong key = %v, want ErrAPIKeyInvalid", err)
+	}
+	var invalidErr *domain.InvalidAPIKeyError
+	if _, _, err := ks.Create(ctx, "ten_1", "CI", []string{"Tenants Read"}, time.Time{}); !errors.As(err, &invalidErr) {
+		t.Errorf("Create with a bad scope = %v, want InvalidAPIKeyError", err)
+	}
+	if _, _, err := ks.Create(ctx, "ten_1", "CI", nil, time.Now().Add(-time.Hour)); !errors.As(err, &invalidErr) {
+		t.Errorf("Create expired = %v, want InvalidAPIKeyError", err)
+	}
+
+	if err := ks.Revoke(ctx, "ten_1", k.ID); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if _, _, err := ks.Verify(ctx, secret); !errors.Is(err, domain.ErrAPIKeyInvalid) {
+		t.Errorf("Verify of a revoked key = %v, want ErrAPIKeyInvalid", err)
+	}
+
+	_, other, _ := ks.Create(ctx, "ten_1", "Deploy", nil, time.Time{})
+	deleting := domain.NewTenant("ten_1", "ten_1", "ten_1", "pro")
+	deleting.Status = domain.StatusDeleting
+	repo.set(t, deleting)
+	if _, _, err := ks.Verify(ctx, other); !errors.Is(err, domain.ErrAPIKeyInvalid) {
+		t.Errorf("Verify for a deleting tenant = %v, want ErrAPIKeyInvalid", err)
+	}
+}
+
+func TestAPIKeys_Authorize(t *testing.T) {
+	repo := newMockRepo()
+	ks := app.NewAPIKeyService(&mockAPIKeys{keys: map[string]domain.APIKey{}}, app.NewTenantService(repo, &mockPublisher{}, &mockValidator{}))
+	ctx := context.Background()
+	newActiveTenant(t, repo, "ten_1", "pro")
+
+	_, widget, _ := ks.Create(ctx, "ten_1", "Dashboard", []string{domain.ScopeStatusRead}, time.Time{})
+	_, ci, _ := ks.Create(ctx, "ten_1", "CI", []string{"tenants:read"}, time.Time{})
+
+	if _, tenant, err := ks.Authorize(ctx, widget, domain.ScopeStatusRead); err != nil || tenant.ID != "ten_1" {
+		t.Errorf("Authorize = %s, %v; want ten_1", tenant.ID, err)
+	}
+	var scopeErr *domain.MissingScopeError
+	if _, _, err := ks.Authorize(ctx, ci, domain.ScopeStatusRead); !errors.As(err, &scopeErr) {
+		t.Errorf("Authorize without the scope = %v, want MissingScopeError", err)
+	}
+	if _, _, err := ks.Authorize(ctx, "tqk_nope", domain.ScopeStatusRead); !errors.Is(err, domain.ErrAPIKeyInvalid) {
+		t.Errorf("Authorize of an unknown key = %v, want ErrAPIKeyInvalid", err)
+	}
+}
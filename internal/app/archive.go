@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// ArchiveService moves the records older than a cutoff to cold archive
+// tables, so hot queries on audit, history and job tables stay fast as
+// volume grows. Retention keeps pruning the archived records.
+type ArchiveService struct {
+	after     time.Duration
+	archivers map[domain.RecordType]domain.Archiver
+}
+
+// NewArchiveService creates an archive service moving the records older
+// than after with the archiver registered for each record type. Record
+// types without an archiver stay where they are.
+func NewArchiveService(after time.Duration, archivers map[domain.RecordType]domain.Archiver) *ArchiveService {
+	return &ArchiveService{after: after, archivers: archivers}
+}
+
+// ArchiveItem is the outcome of archiving one record type.
+type ArchiveItem struct {
+	Record domain.RecordType
+	// Before is the cutoff: records older than it are archived.
+	Before time.Time
+	// Archived is the number of records moved, or that would be moved if
+	// the run was a dry run.
+	Archived int
+	Error    string
+}
+
+// ArchiveReport summarizes an archival run.
+type ArchiveReport struct {
+	DryRun bool
+	Items  []ArchiveItem
+}
+
+// Archive moves the old records of every record type with an archiver at
+// now. With dryRun nothing is moved and the report tells what would be. A
+// record type that fails is reported and archived on the next run; it does
+// not stop the others.
+func (s *ArchiveService) Archive(ctx context.Context, now time.Time, dryRun bool) ArchiveReport {
+	report := ArchiveReport{DryRun: dryRun}
+	before := now.Add(-s.after)
+	for _, record := range domain.RecordTypes() {
+		archiver, ok := s.archivers[record]
+		if !ok {
+			continue
+		}
+
+		item := ArchiveItem{Record: record, Before: before}
+		n, err := archiver.Archive(ctx, before, dryRun)
+		if err != nil {
+			item.Error = err.Error()
+		}
+		item.Archived = n
+		report.Items = append(report.Items, item)
+	}
+	return report
+}
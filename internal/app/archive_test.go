@@ -0,0 +1,51 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockArchiver records the cutoffs it was asked to archive.
+type mockArchiver struct {
+	old     int
+	err     error
+	calls   []time.Time
+	dryRuns []bool
+}
+
+func (m *mockArchiver) Archive(_ context.Context, before time.Time, dryRun bool) (int, error) {
+	m.calls = append(m.calls, before)
+	m.dryRuns = append(m.dryRuns, dryRun)
+	return m.old, m.err
+}
+
+func TestArchive_Archive(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	audit := &mockArchiver{old: 3}
+	events := &mockArchiver{err: errors.New("database is locked")}
+	svc := app.NewArchiveService(90*24*time.Hour, map[domain.RecordType]domain.Archiver{
+		domain.RecordAudit:  audit,
+		domain.RecordEvents: events,
+	})
+
+	report := svc.Archive(context.Background(), now, false)
+
+	if report.DryRun || len(report.Items) != 2 {
+		t.Fatalf("report = %+v, want a real run with 2 items", report)
+	}
+	want := now.Add(-90 * 24 * time.Hour)
+	if got := report.Items[0]; got.Record != domain.RecordAudit || got.Archived != 3 || !got.Before.Equal(want) {
+		t.Errorf("audit item = %+v", got)
+	}
+	if got := report.Items[1]; got.Record != domain.RecordEvents || got.Error != "database is locked" {
+		t.Errorf("events item = %+v", got)
+	}
+	if len(audit.calls) != 1 || !audit.calls[0].Equal(want) || audit.dryRuns[0] {
+		t.Errorf("audit archiver calls = %v, dry runs = %v", audit.calls, audit.dryRuns)
+	}
+}
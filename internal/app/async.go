@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// AsyncEnabled reports whether CreateAsync and DeleteAsync are available.
+func (s *TenantService) AsyncEnabled() bool {
+	return s.operations != nil && s.queue != nil
+}
+
+// CreateAsync persists a new tenant in the "creating" state and queues its
+// provisioning. The returned operation is pending until a worker runs it
+// through RunOperation. It requires WithAsyncOperations.
+func (s *TenantService) CreateAsync(ctx context.Context, in CreateInput) (domain.Tenant, domain.Operation, error) {
+	if !s.AsyncEnabled() {
+		return domain.Tenant{}, domain.Operation{}, errors.New("asynchronous operations are not configured")
+	}
+
+	tenant, err := s.create(ctx, in, "", "")
+	if err != nil {
+		return domain.Tenant{}, domain.Operation{}, err
+	}
+
+	op, err := s.startOperation(ctx, domain.OperationProvision, tenant)
+	if err != nil {
+		return domain.Tenant{}, domain.Operation{}, err
+	}
+	return tenant, op, nil
+}
+
+// DeleteAsync moves a tenant to "deleting" and queues the completion of its
+// deletion. It requires WithAsyncOperations.
+func (s *TenantService) DeleteAsync(ctx context.Context, id string) (domain.Tenant, domain.Operation, error) {
+	if !s.AsyncEnabled() {
+		return domain.Tenant{}, domain.Operation{}, errors.New("asynchronous operations are not configured")
+	}
+
+	// The operation completes the deletion, simulated or not.
+	tenant, err := s.transition(ctx, id, domain.EventDelete)
+	if err != nil {
+		return domain.Tenant{}, domain.Operation{}, err
+	}
+
+	op, err := s.startOperation(ctx, domain.OperationDeletion, tenant)
+	if err != nil {
+		return domain.Tenant{}, domain.Operation{}, err
+	}
+	return tenant, op, nil
+}
+
+// startOperation records an operation and queues its work at the
+// tenant's priority. If the work cannot be queued the operation fails,
+// leaving a trace for pollers.
+func (s *TenantService) startOperation(ctx context.Context, kind domain.OperationKind, tenant domain.Tenant) (domain.Operation, error) {
+	op, err := s.operations.Start(ctx, kind, tenant.ID)
+	if err != nil {
+		return domain.Operation{}, err
+	}
+
+	if err := s.queue.Enqueue(s.withPriority(ctx, tenant), op); err != nil {
+		_ = s.operations.Fail(ctx, op.ID, "could not queue "+string(kind))
+		return domain.Operation{}, fmt.Errorf("queueing %s: %w", kind, err)
+	}
+	return op, nil
+}
+
+// RunOperation performs the work tracked by a pending operation, after its
+// steps (see WithOperationSteps): provisioning activates the tenant,
+// deletion completes its removal. If the tenant can no longer be
+// transitioned (gone, or in another state) the operation fails and nil is
+// returned, since retrying cannot help. Other errors, a failed step's
+// included, are returned so the caller can retry; finished operations are
+// left untouched.
+func (s *TenantService) RunOperation(ctx context.Context, operationID string) error {
+	op, err := s.operations.Get(ctx, operationID)
+	if err != nil {
+		return err
+	}
+	if op.Done() {
+		return nil
+	}
+
+	var (
+		event  domain.Event
+		result string
+	)
+	switch op.Kind {
+	case domain.OperationProvision:
+		event, result = domain.EventProvisionComplete, op.TenantID
+	case domain.OperationDeletion:
+		event = domain.EventDeletionComplete
+	default:
+		return s.operations.Fail(ctx, op.ID, fmt.Sprintf("unsupported operation kind %q", op.Kind))
+	}
+
+	if err := s.simulateOperation(ctx, op); err != nil {
+		return err
+	}
+	if err := s.runSteps(ctx, op); err != nil {
+		return err
+	}
+
+	_, err = s.Transition(ctx, op.TenantID, event)
+	var (
+		trErr     *domain.TransitionError
+		policyErr *domain.PolicyError
+	)
+	switch {
+	case errors.Is(err, domain.ErrTenantNotFound), errors.As(err, &trErr), errors.As(err, &policyErr):
+		return s.operations.Fail(ctx, op.ID, err.Error())
+	case err != nil:
+		return err
+	}
+
+	return s.operations.Succeed(ctx, op.ID, result)
+}
@@ -0,0 +1,204 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockQueue records the operations queued for processing.
+type mockQueue struct {
+	queued     []domain.Operation
+	priorities []domain.Priority
+	enqueueErr error
+}
+
+func (m *mockQueue) Enqueue(ctx context.Context, op domain.Operation) error {
+	if m.enqueueErr != nil {
+		return m.enqueueErr
+	}
+	m.queued = append(m.queued, op)
+	m.priorities = append(m.priorities, domain.PriorityFromContext(ctx))
+	return nil
+}
+
+func newAsyncService(repo *mockRepo, ops *mockOperations, queue *mockQueue, pub *mockPublisher) (*app.TenantService, *app.OperationService) {
+	opSvc := app.NewOperationService(ops)
+	return app.NewTenantService(repo, pub, &mockValidator{}, app.WithAsyncOperations(opSvc, queue)), opSvc
+}
+
+func TestCreateAsync_QueuesProvisioning(t *testing.T) {
+	repo, ops, queue, pub := newMockRepo(), newMockOperations(), &mockQueue{}, &mockPublisher{}
+	svc, _ := newAsyncService(repo, ops, queue, pub)
+
+	tenant, op, err := svc.CreateAsync(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tenant.Status != domain.StatusCreating {
+		t.Errorf("Status = %q, want %q", tenant.Status, domain.StatusCreating)
+	}
+	if op.TenantID != tenant.ID || op.Kind != domain.OperationProvision || op.Status != domain.OperationPending {
+		t.Errorf("operation = %+v", op)
+	}
+	if len(queue.queued) != 1 || queue.queued[0].ID != op.ID {
+		t.Errorf("queued = %+v, want the operation", queue.queued)
+	}
+	if len(pub.events) != 0 {
+		t.Errorf("published %d events, want 0 until provisioned", len(pub.events))
+	}
+}
+
+func TestCreateAsync_NotConfigured(t *testing.T) {
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{})
+
+	if svc.AsyncEnabled() {
+		t.Error("AsyncEnabled() = true without WithAsyncOperations")
+	}
+	if _, _, err := svc.CreateAsync(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"}); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestCreateAsync_EnqueueFailureFailsOperation(t *testing.T) {
+	ops := newMockOperations()
+	svc, _ := newAsyncService(newMockRepo(), ops, &mockQueue{enqueueErr: errors.New("queue down")}, &mockPublisher{})
+
+	if _, _, err := svc.CreateAsync(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	for _, op := range ops.ops {
+		if op.Status != domain.OperationFailed {
+			t.Errorf("operation status = %q, want %q", op.Status, domain.OperationFailed)
+		}
+	}
+}
+
+func TestRunOperation_Provision(t *testing.T) {
+	repo, ops, queue, pub := newMockRepo(), newMockOperations(), &mockQueue{}, &mockPublisher{}
+	svc, opSvc := newAsyncService(repo, ops, queue, pub)
+	ctx := context.Background()
+
+	tenant, op, err := svc.CreateAsync(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("CreateAsync: %v", err)
+	}
+
+	if err := svc.RunOperation(ctx, op.ID); err != nil {
+		t.Fatalf("RunOperation: %v", err)
+	}
+
+	got, _ := opSvc.Get(ctx, op.ID)
+	if got.Status != domain.OperationSucceeded || got.Result != tenant.ID {
+		t.Errorf("operation = %+v, want succeeded with the tenant as result", got)
+	}
+	stored, _ := repo.GetByID(ctx, tenant.ID)
+	if stored.Status != domain.StatusActive {
+		t.Errorf("tenant status = %q, want %q", stored.Status, domain.StatusActive)
+	}
+	if len(pub.events) != 1 || pub.events[0].event != domain.EventProvisionComplete {
+		t.Errorf("events = %+v, want provision_complete", pub.events)
+	}
+
+	// Redelivery of the job is a no-op.
+	if err := svc.RunOperation(ctx, op.ID); err != nil {
+		t.Fatalf("second RunOperation: %v", err)
+	}
+	if len(pub.events) != 1 {
+		t.Errorf("published %d events after redelivery, want 1", len(pub.events))
+	}
+}
+
+func TestRunOperation_Deletion(t *testing.T) {
+	repo, ops, queue, pub := newMockRepo(), newMockOperations(), &mockQueue{}, &mockPublisher{}
+	svc, opSvc := newAsyncService(repo, ops, queue, pub)
+	ctx := context.Background()
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+
+	deleting, op, err := svc.DeleteAsync(ctx, tenant.ID)
+	if err != nil {
+		t.Fatalf("DeleteAsync: %v", err)
+	}
+	if deleting.Status != domain.StatusDeleting || op.Kind != domain.OperationDeletion {
+		t.Fatalf("tenant = %+v, operation = %+v", deleting, op)
+	}
+
+	if err := svc.RunOperation(ctx, op.ID); err != nil {
+		t.Fatalf("RunOperation: %v", err)
+	}
+	got, _ := opSvc.Get(ctx, op.ID)
+	if got.Status != domain.OperationSucceeded {
+		t.Errorf("operation status = %q, want %q", got.Status, domain.OperationSucceeded)
+	}
+	stored, _ := repo.GetByID(ctx, tenant.ID)
+	if stored.Status != domain.StatusDeleted {
+		t.Errorf("tenant status = %q, want %q", stored.Status, domain.StatusDeleted)
+	}
+}
+
+func TestDeleteAsync_InvalidTransition(t *testing.T) {
+	repo, ops, queue := newMockRepo(), newMockOperations(), &mockQueue{}
+	svc, _ := newAsyncService(repo, ops, queue, &mockPublisher{})
+	ctx := context.Background()
+
+	tenant, _ := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+
+	var trErr *domain.TransitionError
+	if _, _, err := svc.DeleteAsync(ctx, tenant.ID); !errors.As(err, &trErr) {
+		t.Fatalf("expected TransitionError, got %v", err)
+	}
+	if len(ops.ops) != 0 || len(queue.queued) != 0 {
+		t.Errorf("operations = %d, queued = %d, want none", len(ops.ops), len(queue.queued))
+	}
+}
+
+func TestRunOperation_TenantGoneFailsOperation(t *testing.T) {
+	repo, ops := newMockRepo(), newMockOperations()
+	svc, opSvc := newAsyncService(repo, ops, &mockQueue{}, &mockPublisher{})
+	ctx := context.Background()
+
+	tenant, op, err := svc.CreateAsync(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("CreateAsync: %v", err)
+	}
+	_ = repo.Delete(ctx, tenant.ID)
+
+	if err := svc.RunOperation(ctx, op.ID); err != nil {
+		t.Fatalf("RunOperation should record the failure, got %v", err)
+	}
+	got, _ := opSvc.Get(ctx, op.ID)
+	if got.Status != domain.OperationFailed || got.Error == "" {
+		t.Errorf("operation = %+v, want failed with error", got)
+	}
+}
+
+func TestRunOperation_TransientErrorIsReturned(t *testing.T) {
+	repo, ops := newMockRepo(), newMockOperations()
+	svc, opSvc := newAsyncService(repo, ops, &mockQueue{}, &mockPublisher{})
+	ctx := context.Background()
+
+	_, op, err := svc.CreateAsync(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("CreateAsync: %v", err)
+	}
+	repo.updateErr = errors.New("database is locked")
+
+	if err := svc.RunOperation(ctx, op.ID); err == nil {
+		t.Fatal("expected error so the job is retried")
+	}
+	got, _ := opSvc.Get(ctx, op.ID)
+	if got.Status != domain.OperationPending {
+		t.Errorf("operation status = %q, want still %q", got.Status, domain.OperationPending)
+	}
+}
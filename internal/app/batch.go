@@ -0,0 +1,260 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// MaxBatchCreate is the largest number of tenants BatchCreate accepts.
+const MaxBatchCreate = 100
+
+// BatchStatus is the outcome of a single item of a batch creation.
+type BatchStatus string
+
+const (
+	BatchCreated      BatchStatus = "created"
+	BatchConflict     BatchStatus = "conflict"
+	BatchInvalid      BatchStatus = "invalid"
+	BatchTransitioned BatchStatus = "transitioned"
+	BatchFailed       BatchStatus = "failed"
+)
+
+// BatchCreateItem describes one tenant to create.
+type BatchCreateItem struct {
+	Name     string
+	Slug     string
+	Plan     string
+	Metadata map[string]string
+	// Region, when set, is where the tenant is hosted (see Tenant.Region).
+	Region string
+	// Blueprint, when set, names the blueprint the tenant is created from.
+	Blueprint string
+}
+
+// BatchCreateResult reports what happened to the item at the same index.
+// Tenant is only set for created items; Error explains the others.
+type BatchCreateResult struct {
+	Status BatchStatus
+	Tenant domain.Tenant
+	Error  string
+}
+
+// BatchCreate creates many tenants at once. Every item is validated up
+// front (slug format, duplicates within the batch, slugs already in use,
+// metadata, create hooks); the accepted ones are then inserted in a single
+// transaction and a creation event is published for each.
+//
+// Rejected items do not prevent the others from being created: they are
+// reported as conflict or invalid in the result at the same index. An
+// error is returned only when the batch as a whole could not be stored.
+func (s *TenantService) BatchCreate(ctx context.Context, items []BatchCreateItem) ([]BatchCreateResult, error) {
+	if len(items) > MaxBatchCreate {
+		return nil, &domain.BatchTooLargeError{Size: len(items), Max: MaxBatchCreate}
+	}
+	return s.batchCreate(ctx, len(items), func(i int, seen map[string]bool) (domain.Tenant, error) {
+		id, err := s.ids.New()
+		if err != nil {
+			return domain.Tenant{}, fmt.Errorf("generating tenant id: %w", err)
+		}
+		return s.prepareBatchItem(ctx, items[i], id, seen)
+	})
+}
+
+// batchCreate validates n items with prepare, which builds the tenant of
+// the item at an index, then stores the accepted ones in one transaction
+// and publishes their creation events. seen holds the slugs accepted
+// earlier in the batch.
+func (s *TenantService) batchCreate(ctx context.Context, n int, prepare func(i int, seen map[string]bool) (domain.Tenant, error)) ([]BatchCreateResult, error) {
+	results := make([]BatchCreateResult, n)
+	accepted := make([]domain.Tenant, 0, n)
+	indexes := make([]int, 0, n)
+	seen := make(map[string]bool, n)
+
+	for i := range n {
+		tenant, err := prepare(i, seen)
+		if err != nil {
+			result, rejected := batchRejection(s.observeConflict(ctx, err))
+			if !rejected {
+				return nil, err
+			}
+			results[i] = result
+			continue
+		}
+		seen[tenant.Slug] = true
+		accepted = append(accepted, tenant)
+		indexes = append(indexes, i)
+	}
+
+	if len(accepted) == 0 {
+		return results, nil
+	}
+
+	if err := s.repo.CreateMany(ctx, accepted); err != nil {
+		return nil, fmt.Errorf("creating tenants: %w", err)
+	}
+	s.observeCreated(ctx, accepted...)
+
+	for j, tenant := range accepted {
+		results[indexes[j]] = BatchCreateResult{Status: BatchCreated, Tenant: tenant}
+	}
+
+	for i := range accepted {
+		if err := s.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditCreate, nil, &accepted[i])); err != nil {
+			return results, err
+		}
+	}
+
+	for _, tenant := range accepted {
+		if err := s.publisher.Publish(s.withPriority(ctx, tenant), domain.TenantEvent{Event: domain.EventProvisionComplete, Tenant: tenant}); err != nil {
+			return results, fmt.Errorf("publishing creation event for %q: %w", tenant.Slug, err)
+		}
+	}
+
+	return results, nil
+}
+
+// prepareBatchItem validates an item and builds the tenant to insert
+// under id. seen holds the slugs already accepted earlier in the batch.
+func (s *TenantService) prepareBatchItem(ctx context.Context, item BatchCreateItem, id string, seen map[string]bool) (domain.Tenant, error) {
+	name := NormalizeName(item.Name)
+	slug, err := resolveSlug(name, item.Slug)
+	if err != nil {
+		return domain.Tenant{}, err
+	}
+	if seen[slug] {
+		return domain.Tenant{}, &domain.SlugConflictError{Slug: slug}
+	}
+	if _, err := s.repo.GetBySlug(ctx, slug); err == nil {
+		return domain.Tenant{}, &domain.SlugConflictError{Slug: slug}
+	} else if !errors.Is(err, domain.ErrTenantNotFound) {
+		return domain.Tenant{}, fmt.Errorf("checking slug: %w", err)
+	}
+
+	plan, metadata, err := s.applyBlueprint(ctx, item.Blueprint, item.Plan, item.Metadata)
+	if err != nil {
+		return domain.Tenant{}, err
+	}
+	if item.Region != "" {
+		if err := domain.ValidateRegion(item.Region); err != nil {
+			return domain.Tenant{}, err
+		}
+	}
+	if err := s.checkPlan(ctx, plan, item.Region); err != nil {
+		return domain.Tenant{}, err
+	}
+	if err := domain.ValidateMetadata(metadata); err != nil {
+		return domain.Tenant{}, err
+	}
+
+	tenant := domain.NewTenant(id, name, slug, plan)
+	tenant.Metadata = metadata
+	tenant.Region = item.Region
+
+	for _, hook := range s.createHooks {
+		if err := hook.BeforeCreate(ctx, tenant); err != nil {
+			return domain.Tenant{}, &domain.HookRejectedError{Hook: hook.Name(), Reason: err.Error()}
+		}
+	}
+
+	return tenant, nil
+}
+
+// batchRejection reports the result for an item rejected by validation.
+// It returns false for infrastructure errors, which abort the batch.
+func batchRejection(err error) (BatchCreateResult, bool) {
+	var (
+		slugErr    *domain.SlugConflictError
+		idErr      *domain.IDConflictError
+		invalidErr *domain.InvalidSlugError
+		importErr  *domain.InvalidImportError
+		hookErr    *domain.HookRejectedError
+		planErr    *domain.UnknownPlanError
+		regionErr  *domain.PlanNotInRegionError
+		badRegion  *domain.InvalidRegionError
+		bpErr      *domain.UnknownBlueprintError
+		metaErr    *domain.InvalidMetadataError
+	)
+	switch {
+	case errors.As(err, &slugErr), errors.As(err, &idErr):
+		return BatchCreateResult{Status: BatchConflict, Error: err.Error()}, true
+	case errors.As(err, &invalidErr), errors.As(err, &hookErr), errors.As(err, &planErr), errors.As(err, &metaErr),
+		errors.As(err, &importErr), errors.As(err, &bpErr), errors.As(err, &regionErr), errors.As(err, &badRegion):
+		return BatchCreateResult{Status: BatchInvalid, Error: err.Error()}, true
+	default:
+		return BatchCreateResult{}, false
+	}
+}
+
+// BatchTransitionResult reports what happened to the tenant at the same
+// index: transitioned, with the tenant after the event, or failed, with
+// the reason.
+type BatchTransitionResult struct {
+	Status BatchStatus
+	Tenant domain.Tenant
+	Error  string
+}
+
+// BatchTransition triggers event on many tenants at once. A disruptive
+// event (suspension, deletion) is first checked against the service
+// guardrail: if the tenants it would disrupt exceed it, nothing is written
+// and a GuardrailError is returned. Forcing past the guardrail takes the
+// admin role.
+//
+// A tenant the event fails on is reported in its result and does not stop
+// the others.
+func (s *TenantService) BatchTransition(ctx context.Context, ids []string, event domain.Event, force bool) ([]BatchTransitionResult, error) {
+	if len(ids) > MaxBatchCreate {
+		return nil, &domain.BatchTooLargeError{Size: len(ids), Max: MaxBatchCreate}
+	}
+	if force {
+		if err := domain.RequireRole(ctx, domain.RoleAdmin, "forcing a batch transition past the guardrail"); err != nil {
+			return nil, err
+		}
+	} else if domain.IsDisruptive(event) {
+		disrupted, err := s.countDisrupted(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkGuardrail(ctx, disrupted); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]BatchTransitionResult, len(ids))
+	for i, id := range ids {
+		tenant, err := s.Transition(ctx, id, event)
+		if err != nil {
+			results[i] = BatchTransitionResult{Status: BatchFailed, Error: err.Error()}
+			continue
+		}
+		results[i] = BatchTransitionResult{Status: BatchTransitioned, Tenant: tenant}
+	}
+	return results, nil
+}
+
+// countDisrupted counts the distinct stored tenants among ids that are
+// still in service, which a disruptive event would take out of it.
+func (s *TenantService) countDisrupted(ctx context.Context, ids []string) (int, error) {
+	seen := make(map[string]bool, len(ids))
+	n := 0
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		tenant, err := s.repo.GetByID(ctx, id)
+		if errors.Is(err, domain.ErrTenantNotFound) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("getting tenant: %w", err)
+		}
+		if tenant.Status == domain.StatusActive {
+			n++
+		}
+	}
+	return n, nil
+}
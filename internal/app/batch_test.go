@@ -0,0 +1,142 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestBatchCreate_PerItemResults(t *testing.T) {
+	repo := newMockRepo()
+	pub := &mockPublisher{}
+	svc := app.NewTenantService(repo, pub, &mockValidator{})
+
+	if _, err := svc.Create(context.Background(), app.CreateInput{Name: "Existing", Slug: "existing", Plan: "free"}); err != nil {
+		t.Fatalf("seeding: %v", err)
+	}
+	pub.events = nil
+
+	results, err := svc.BatchCreate(context.Background(), []app.BatchCreateItem{
+		{Name: "Acme", Slug: "acme", Plan: "pro"},
+		{Name: "Existing", Slug: "existing", Plan: "free"},
+		{Name: "Bad", Slug: "Not A Slug", Plan: "free"},
+		{Name: "Acme again", Slug: "acme", Plan: "free"},
+		{Name: "Globex", Slug: "globex", Plan: "free"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []app.BatchStatus{app.BatchCreated, app.BatchConflict, app.BatchInvalid, app.BatchConflict, app.BatchCreated}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, status := range want {
+		if results[i].Status != status {
+			t.Errorf("results[%d].Status = %q, want %q (%s)", i, results[i].Status, status, results[i].Error)
+		}
+	}
+
+	if results[0].Tenant.ID == "" || results[0].Tenant.Plan != "pro" {
+		t.Errorf("created tenant = %+v", results[0].Tenant)
+	}
+	if results[1].Error == "" {
+		t.Error("conflict result should explain the rejection")
+	}
+	if repo.len() != 3 {
+		t.Errorf("repo has %d tenants, want 3", repo.len())
+	}
+	if len(pub.events) != 2 {
+		t.Errorf("published %d events, want 2", len(pub.events))
+	}
+}
+
+func TestBatchCreate_HookRejectionIsInvalid(t *testing.T) {
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{},
+		app.WithCreateHooks(&rejectingHook{reason: "reserved"}),
+	)
+
+	results, err := svc.BatchCreate(context.Background(), []app.BatchCreateItem{
+		{Name: "Acme", Slug: "acme", Plan: "free"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].Status != app.BatchInvalid {
+		t.Errorf("Status = %q, want %q", results[0].Status, app.BatchInvalid)
+	}
+}
+
+func TestBatchCreate_TooLarge(t *testing.T) {
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{})
+
+	items := make([]app.BatchCreateItem, app.MaxBatchCreate+1)
+	_, err := svc.BatchCreate(context.Background(), items)
+
+	var sizeErr *domain.BatchTooLargeError
+	if !errors.As(err, &sizeErr) {
+		t.Fatalf("expected BatchTooLargeError, got %v", err)
+	}
+}
+
+func TestBatchCreate_StoreFailureAbortsBatch(t *testing.T) {
+	repo := newMockRepo()
+	repo.createErr = errors.New("disk full")
+	pub := &mockPublisher{}
+	svc := app.NewTenantService(repo, pub, &mockValidator{})
+
+	_, err := svc.BatchCreate(context.Background(), []app.BatchCreateItem{
+		{Name: "Acme", Slug: "acme", Plan: "free"},
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if len(pub.events) != 0 {
+		t.Errorf("published %d events, want 0", len(pub.events))
+	}
+}
+
+func TestBatchTransition_Guardrail(t *testing.T) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithGuardrail(domain.Guardrail{MaxDisruptedPercent: 50}),
+	)
+	ctx := context.Background()
+	for _, id := range []string{"ten_1", "ten_2", "ten_3", "ten_4"} {
+		newActiveTenant(t, repo, id, "free")
+	}
+
+	// Half the fleet, counted once per tenant, is within the guardrail.
+	results, err := svc.BatchTransition(ctx, []string{"ten_1", "ten_2", "ten_2", "ten_missing"}, domain.EventSuspend, false)
+	if err != nil {
+		t.Fatalf("BatchTransition: %v", err)
+	}
+	want := []app.BatchStatus{app.BatchTransitioned, app.BatchTransitioned, app.BatchFailed, app.BatchFailed}
+	for i, status := range want {
+		if results[i].Status != status {
+			t.Errorf("results[%d].Status = %q, want %q (%s)", i, results[i].Status, status, results[i].Error)
+		}
+	}
+
+	var guardErr *domain.GuardrailError
+	if _, err := svc.BatchTransition(ctx, []string{"ten_3", "ten_4"}, domain.EventSuspend, false); !errors.As(err, &guardErr) {
+		t.Fatalf("BatchTransition past the guardrail = %v, want *GuardrailError", err)
+	}
+	if got := repo.get("ten_3"); got.Status != domain.StatusActive {
+		t.Errorf("blocked batch must not write: status = %q", got.Status)
+	}
+
+	operator := domain.WithRole(ctx, domain.RoleOperator)
+	if _, err := svc.BatchTransition(operator, []string{"ten_3", "ten_4"}, domain.EventSuspend, true); !errors.As(err, new(*domain.ForbiddenError)) {
+		t.Fatalf("operator forcing = %v, want *ForbiddenError", err)
+	}
+	if _, err := svc.BatchTransition(domain.WithRole(ctx, domain.RoleAdmin), []string{"ten_3", "ten_4"}, domain.EventSuspend, true); err != nil {
+		t.Fatalf("admin forcing: %v", err)
+	}
+	if got := repo.get("ten_4"); got.Status != domain.StatusSuspended {
+		t.Errorf("forced batch: status = %q, want suspended", got.Status)
+	}
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// BillingService cross-checks tenants against the billing provider so
+// finance can chase tenants nobody pays for and customers charged for a
+// tenant they cannot use.
+type BillingService struct {
+	billing domain.BillingProvider
+	tenants *TenantService
+}
+
+// NewBillingService creates a billing service reading tenants through svc.
+func NewBillingService(billing domain.BillingProvider, svc *TenantService) *BillingService {
+	return &BillingService{billing: billing, tenants: svc}
+}
+
+// ReconciliationReport is the outcome of a billing reconciliation.
+type ReconciliationReport struct {
+	CheckedAt     time.Time
+	Tenants       int
+	Subscriptions int
+	Mismatches    []domain.BillingMismatch
+}
+
+// Reconcile compares every tenant with the provider's subscriptions. It
+// only reports mismatches; fixing them is left to finance.
+func (s *BillingService) Reconcile(ctx context.Context) (ReconciliationReport, error) {
+	report := ReconciliationReport{CheckedAt: time.Now().UTC()}
+
+	subs, err := s.billing.Subscriptions(ctx)
+	if err != nil {
+		return report, fmt.Errorf("%w: %w", domain.ErrBillingUnavailable, err)
+	}
+
+	tenants, err := s.tenants.repo.List(ctx, domain.ListFilter{})
+	if err != nil {
+		return report, fmt.Errorf("listing tenants: %w", err)
+	}
+
+	report.Tenants = len(tenants)
+	report.Subscriptions = len(subs)
+	report.Mismatches = domain.Reconcile(tenants, subs)
+	return report, nil
+}
@@ -0,0 +1,52 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockBilling returns fixed subscriptions, or err.
+type mockBilling struct {
+	subs []domain.Subscription
+	err  error
+}
+
+func (m *mockBilling) Subscriptions(context.Context) ([]domain.Subscription, error) {
+	return m.subs, m.err
+}
+
+func TestBilling_ReconcileReportsMismatches(t *testing.T) {
+	repo := newMockRepo()
+	repo.set(t, domain.Tenant{ID: "ten_paid", Slug: "paid", Plan: "pro", Status: domain.StatusActive})
+	repo.set(t, domain.Tenant{ID: "ten_free_ride", Slug: "free-ride", Plan: "pro", Status: domain.StatusActive})
+	billing := &mockBilling{subs: []domain.Subscription{
+		{TenantID: "ten_paid", Plan: "pro", Status: domain.SubscriptionActive},
+	}}
+	svc := app.NewBillingService(billing, app.NewTenantService(repo, &mockPublisher{}, &mockValidator{}))
+
+	report, err := svc.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if report.Tenants != 2 || report.Subscriptions != 1 || report.CheckedAt.IsZero() {
+		t.Errorf("report = %+v, want 2 tenants and 1 subscription checked", report)
+	}
+	if len(report.Mismatches) != 1 || report.Mismatches[0].TenantID != "ten_free_ride" ||
+		report.Mismatches[0].Kind != domain.MismatchMissingSubscription {
+		t.Errorf("mismatches = %+v, want ten_free_ride without subscription", report.Mismatches)
+	}
+}
+
+func TestBilling_ProviderErrorIsUnavailable(t *testing.T) {
+	billing := &mockBilling{err: errors.New("connection refused")}
+	svc := app.NewBillingService(billing, app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{}))
+
+	_, err := svc.Reconcile(context.Background())
+	if !errors.Is(err, domain.ErrBillingUnavailable) {
+		t.Errorf("err = %v, want ErrBillingUnavailable", err)
+	}
+}
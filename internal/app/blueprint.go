@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// BlueprintService manages tenant blueprints. With WithBlueprints the
+// tenant service creates tenants from them.
+type BlueprintService struct {
+	repo  domain.BlueprintRepository
+	plans *PlanService
+}
+
+// NewBlueprintService creates a blueprint service backed by repo. When
+// plans is not nil, blueprints must be on a plan it defines.
+func NewBlueprintService(repo domain.BlueprintRepository, plans *PlanService) *BlueprintService {
+	return &BlueprintService{repo: repo, plans: plans}
+}
+
+// Create defines a new blueprint.
+func (s *BlueprintService) Create(ctx context.Context, name, plan string, metadata map[string]string, features map[string]bool, variables map[string]string) (domain.Blueprint, error) {
+	b, err := domain.NewBlueprint(name, plan, metadata, features, variables)
+	if err != nil {
+		return domain.Blueprint{}, err
+	}
+	if err := s.checkPlan(ctx, plan); err != nil {
+		return domain.Blueprint{}, err
+	}
+	if err := s.repo.Create(ctx, b); err != nil {
+		return domain.Blueprint{}, err
+	}
+	return b, nil
+}
+
+// Get returns a blueprint by name.
+func (s *BlueprintService) Get(ctx context.Context, name string) (domain.Blueprint, error) {
+	return s.repo.Get(ctx, name)
+}
+
+// List returns every blueprint, by name.
+func (s *BlueprintService) List(ctx context.Context) ([]domain.Blueprint, error) {
+	return s.repo.List(ctx)
+}
+
+// Update replaces a blueprint's plan, metadata, features and variables.
+// Tenants already created from it are left unchanged.
+func (s *BlueprintService) Update(ctx context.Context, name, plan string, metadata map[string]string, features map[string]bool, variables map[string]string) (domain.Blueprint, error) {
+	current, err := s.repo.Get(ctx, name)
+	if err != nil {
+		return domain.Blueprint{}, err
+	}
+
+	b, err := domain.NewBlueprint(name, plan, metadata, features, variables)
+	if err != nil {
+		return domain.Blueprint{}, err
+	}
+	if err := s.checkPlan(ctx, plan); err != nil {
+		return domain.Blueprint{}, err
+	}
+	b.CreatedAt = current.CreatedAt
+
+	if err := s.repo.Update(ctx, b); err != nil {
+		return domain.Blueprint{}, fmt.Errorf("updating blueprint: %w", err)
+	}
+	return b, nil
+}
+
+// Delete removes a blueprint. Tenants created from it keep their
+// configuration.
+func (s *BlueprintService) Delete(ctx context.Context, name string) error {
+	return s.repo.Delete(ctx, name)
+}
+
+func (s *BlueprintService) checkPlan(ctx context.Context, plan string) error {
+	if s.plans == nil {
+		return nil
+	}
+	return s.plans.Check(ctx, plan)
+}
+
+// WithBlueprints creates tenants from the blueprints of bs named in
+// CreateInput or in batch items.
+func WithBlueprints(bs *BlueprintService) Option {
+	return func(s *TenantService) {
+		s.blueprints = bs
+	}
+}
+
+// applyBlueprint returns the plan and metadata of a tenant created from
+// the named blueprint with the given ones (see domain.Blueprint.Apply), or
+// them unchanged when name is empty.
+func (s *TenantService) applyBlueprint(ctx context.Context, name, plan string, metadata map[string]string) (string, map[string]string, error) {
+	if name == "" {
+		return plan, metadata, nil
+	}
+	if s.blueprints == nil {
+		return "", nil, &domain.UnknownBlueprintError{Name: name}
+	}
+	b, err := s.blueprints.Get(ctx, name)
+	if errors.Is(err, domain.ErrBlueprintNotFound) {
+		return "", nil, &domain.UnknownBlueprintError{Name: name}
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("getting blueprint: %w", err)
+	}
+	plan, metadata = b.Apply(plan, metadata)
+	return plan, metadata, nil
+}
@@ -0,0 +1,143 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockBlueprints keeps blueprints in memory, keyed by name.
+type mockBlueprints struct {
+	blueprints map[string]domain.Blueprint
+}
+
+func (m *mockBlueprints) Create(_ context.Context, b domain.Blueprint) error {
+	if _, ok := m.blueprints[b.Name]; ok {
+		return &domain.BlueprintConflictError{Name: b.Name}
+	}
+	m.blueprints[b.Name] = b
+	return nil
+}
+
+func (m *mockBlueprints) Get(_ context.Context, name string) (domain.Blueprint, error) {
+	b, ok := m.blueprints[name]
+	if !ok {
+		return domain.Blueprint{}, domain.ErrBlueprintNotFound
+	}
+	return b, nil
+}
+
+func (m *mockBlueprints) List(context.Context) ([]domain.Blueprint, error) {
+	var out []domain.Blueprint
+	for _, b := range m.blueprints {
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+func (m *mockBlueprints) Update(_ context.Context, b domain.Blueprint) error {
+	if _, ok := m.blueprints[b.Name]; !ok {
+		return domain.ErrBlueprintNotFound
+	}
+	m.blueprints[b.Name] = b
+	return nil
+}
+
+func (m *mockBlueprints) Delete(_ context.Context, name string) error {
+	if _, ok := m.blueprints[name]; !ok {
+		return domain.ErrBlueprintNotFound
+	}
+	delete(m.blueprints, name)
+	return nil
+}
+
+func newBlueprintService(t *testing.T) (*app.BlueprintService, *app.TenantService) {
+	t.Helper()
+	repo := newMockRepo()
+	plans := app.NewPlanService(newMockPlans("free", "enterprise"), repo)
+	bs := app.NewBlueprintService(&mockBlueprints{blueprints: map[string]domain.Blueprint{}}, plans)
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithPlanValidation(plans), app.WithBlueprints(bs))
+
+	if _, err := bs.Create(context.Background(), "enterprise-eu", "enterprise",
+		map[string]string{"region": "eu", "tier": "gold"}, map[string]bool{"sso": true}, map[string]string{"replicas": "3"}); err != nil {
+		t.Fatalf("Create blueprint: %v", err)
+	}
+	return bs, svc
+}
+
+func TestBlueprints_CreateTenant(t *testing.T) {
+	_, svc := newBlueprintService(t)
+	ctx := context.Background()
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Blueprint: "enterprise-eu"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	want := map[string]string{
+		"region": "eu", "tier": "gold", "feature.sso": "true", "var.replicas": "3", "blueprint": "enterprise-eu",
+	}
+	if tenant.Plan != "enterprise" || len(tenant.Metadata) != len(want) {
+		t.Fatalf("tenant = %+v, want the blueprint's plan and metadata %v", tenant, want)
+	}
+	for k, v := range want {
+		if tenant.Metadata[k] != v {
+			t.Errorf("metadata %s = %q, want %q", k, tenant.Metadata[k], v)
+		}
+	}
+
+	// The request's plan and metadata override the blueprint's.
+	tenant, err = svc.Create(ctx, app.CreateInput{
+		Name: "Beta", Slug: "beta", Plan: "free", Blueprint: "enterprise-eu",
+		Metadata: map[string]string{"region": "eu-west", "tier": "", "var.replicas": "5"},
+	})
+	if err != nil {
+		t.Fatalf("Create with overrides: %v", err)
+	}
+	if _, ok := tenant.Metadata["tier"]; tenant.Plan != "free" || tenant.Metadata["region"] != "eu-west" || ok ||
+		tenant.Metadata["var.replicas"] != "5" || tenant.Metadata["feature.sso"] != "true" {
+		t.Errorf("tenant = %+v, want the request's plan and metadata over the blueprint's", tenant)
+	}
+}
+
+func TestBlueprints_UnknownBlueprint(t *testing.T) {
+	_, svc := newBlueprintService(t)
+
+	var unknown *domain.UnknownBlueprintError
+	if _, err := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Blueprint: "missing"}); !errors.As(err, &unknown) {
+		t.Errorf("Create = %v, want *UnknownBlueprintError", err)
+	}
+
+	results, err := svc.BatchCreate(context.Background(), []app.BatchCreateItem{
+		{Name: "Acme", Blueprint: "enterprise-eu"},
+		{Name: "Beta", Blueprint: "missing"},
+	})
+	if err != nil {
+		t.Fatalf("BatchCreate: %v", err)
+	}
+	if results[0].Status != app.BatchCreated || results[0].Tenant.Plan != "enterprise" || results[1].Status != app.BatchInvalid {
+		t.Errorf("results = %+v, want the first created on enterprise and the second invalid", results)
+	}
+}
+
+func TestBlueprints_RequireDefinedPlan(t *testing.T) {
+	bs, _ := newBlueprintService(t)
+	ctx := context.Background()
+
+	var unknown *domain.UnknownPlanError
+	if _, err := bs.Create(ctx, "startup", "growth", nil, nil, nil); !errors.As(err, &unknown) {
+		t.Errorf("Create on an undefined plan = %v, want *UnknownPlanError", err)
+	}
+	var conflict *domain.BlueprintConflictError
+	if _, err := bs.Create(ctx, "enterprise-eu", "free", nil, nil, nil); !errors.As(err, &conflict) {
+		t.Errorf("Create of an existing name = %v, want *BlueprintConflictError", err)
+	}
+
+	updated, err := bs.Update(ctx, "enterprise-eu", "free", nil, map[string]bool{"sso": false}, nil)
+	if err != nil || updated.Plan != "free" || updated.Features["sso"] || updated.CreatedAt.IsZero() {
+		t.Errorf("Update = %+v, %v; want the blueprint on free without sso", updated, err)
+	}
+}
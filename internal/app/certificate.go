@@ -0,0 +1,188 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// certificateBatch bounds the certificates a run of Renew requests, so a
+// run stays short and under the certificate authority's rate limits; the
+// rest wait for the next run.
+const certificateBatch = 20
+
+// CertificateService obtains and renews the TLS certificates of tenants'
+// custom domains. Requesting a certificate records it as pending; the
+// renewal job obtains it, renews it ahead of expiry and retries failures,
+// publishing EventCertificateIssued or EventCertificateFailed each time.
+type CertificateService struct {
+	repo    domain.CertificateRepository
+	issuer  domain.CertificateIssuer
+	tenants *TenantService
+}
+
+// NewCertificateService creates a certificate service for the tenants of
+// svc, obtaining certificates from issuer.
+func NewCertificateService(repo domain.CertificateRepository, issuer domain.CertificateIssuer, svc *TenantService) *CertificateService {
+	return &CertificateService{repo: repo, issuer: issuer, tenants: svc}
+}
+
+// WithDomains converges the custom domains of tenant specs (see Apply)
+// by requesting and removing their certificates in repo.
+func WithDomains(repo domain.CertificateRepository) Option {
+	return func(s *TenantService) {
+		s.domains = repo
+	}
+}
+
+// Request asks for a certificate for domainName, which the tenant's
+// traffic must already reach: the certificate authority checks it over
+// HTTP. Requesting a domain the tenant already has returns its
+// certificate; one of another tenant is a DomainConflictError.
+func (s *CertificateService) Request(ctx context.Context, tenantID, domainName string) (domain.Certificate, error) {
+	tenant, err := s.tenants.GetByID(ctx, tenantID)
+	if err != nil {
+		return domain.Certificate{}, err
+	}
+	domainName = normalizeDomain(domainName)
+	if err := domain.ValidateDomainName(domainName); err != nil {
+		return domain.Certificate{}, err
+	}
+
+	existing, err := s.repo.Get(ctx, domainName)
+	switch {
+	case err == nil && existing.TenantID == tenant.ID:
+		return existing, nil
+	case err == nil:
+		return domain.Certificate{}, &domain.DomainConflictError{Domain: domainName}
+	case !errors.Is(err, domain.ErrCertificateNotFound):
+		return domain.Certificate{}, fmt.Errorf("getting certificate: %w", err)
+	}
+
+	cert := domain.NewCertificate(domainName, tenant.ID, time.Now())
+	if err := s.repo.Save(ctx, cert); err != nil {
+		return domain.Certificate{}, fmt.Errorf("saving certificate: %w", err)
+	}
+	return cert, nil
+}
+
+// List returns the tenant's certificates.
+func (s *CertificateService) List(ctx context.Context, tenantID string) ([]domain.Certificate, error) {
+	if _, err := s.tenants.GetByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByTenant(ctx, tenantID)
+}
+
+// Remove stops renewing the certificate of the tenant's domain. The
+// certificate already issued stays valid until it expires.
+func (s *CertificateService) Remove(ctx context.Context, tenantID, domainName string) error {
+	domainName = normalizeDomain(domainName)
+	cert, err := s.repo.Get(ctx, domainName)
+	if err != nil {
+		return err
+	}
+	if cert.TenantID != tenantID {
+		return domain.ErrCertificateNotFound
+	}
+	return s.repo.Delete(ctx, domainName)
+}
+
+// normalizeDomain lowercases a host name and drops its root dot.
+func normalizeDomain(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// CertificateItem is a certificate that was obtained, or failed to be.
+type CertificateItem struct {
+	Domain   string
+	TenantID string
+	NotAfter time.Time
+	Error    string
+}
+
+// CertificateReport summarizes a run of Renew.
+type CertificateReport struct {
+	Items []CertificateItem
+}
+
+// Renew requests the certificates due at now: pending ones, issued ones
+// close to expiry and failed ones whose retry is due. A failure is
+// recorded on the certificate and reported, and does not stop the others.
+// The certificates of deleted tenants are removed instead.
+func (s *CertificateService) Renew(ctx context.Context, now time.Time) (CertificateReport, error) {
+	var report CertificateReport
+
+	due, err := s.repo.ListDue(ctx, now, certificateBatch)
+	if err != nil {
+		return report, fmt.Errorf("listing due certificates: %w", err)
+	}
+
+	for _, cert := range due {
+		item := CertificateItem{Domain: cert.Domain, TenantID: cert.TenantID}
+		if err := s.renew(ctx, &cert, now); err != nil {
+			item.Error = err.Error()
+		}
+		item.NotAfter = cert.NotAfter
+		report.Items = append(report.Items, item)
+	}
+	return report, nil
+}
+
+// renew obtains a new certificate for cert, saves the outcome and
+// announces it.
+func (s *CertificateService) renew(ctx context.Context, cert *domain.Certificate, now time.Time) error {
+	tenant, err := s.tenants.GetByID(ctx, cert.TenantID)
+	if errors.Is(err, domain.ErrTenantNotFound) ||
+		err == nil && (tenant.Status == domain.StatusDeleting || tenant.Status == domain.StatusDeleted) {
+		if err := s.repo.Delete(ctx, cert.Domain); err != nil && !errors.Is(err, domain.ErrCertificateNotFound) {
+			return fmt.Errorf("deleting certificate: %w", err)
+		}
+		return errors.New("tenant deleted; certificate removed")
+	}
+	if err != nil {
+		return err
+	}
+
+	var issued domain.IssuedCertificate
+	var issueErr error
+	if tenant.Simulated {
+		issued = simulatedCertificate(now)
+	} else {
+		issued, issueErr = s.issuer.Issue(ctx, cert.Domain)
+	}
+	event := domain.EventCertificateIssued
+	if issueErr != nil {
+		cert.Failed(issueErr, now)
+		event = domain.EventCertificateFailed
+	} else {
+		cert.Issued(issued, now)
+	}
+
+	if err := s.repo.Save(ctx, *cert); err != nil {
+		return fmt.Errorf("saving certificate: %w", err)
+	}
+	ctx = s.tenants.withPriority(ctx, tenant)
+	if err := s.tenants.publisher.Publish(ctx, domain.TenantEvent{Event: event, Tenant: tenant, Certificate: cert}); err != nil {
+		return fmt.Errorf("publishing event %q: %w", event, err)
+	}
+	if issueErr != nil {
+		return fmt.Errorf("issuing certificate: %w", issueErr)
+	}
+	return nil
+}
+
+// simulatedCertificate stands for the certificate of a simulated tenant,
+// which is never requested from the certificate authority.
+func simulatedCertificate(now time.Time) domain.IssuedCertificate {
+	return domain.IssuedCertificate{
+		Issuer:    "simulation",
+		Serial:    "simulated",
+		NotBefore: now,
+		NotAfter:  now.Add(90 * 24 * time.Hour),
+	}
+}
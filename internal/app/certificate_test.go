@@ -0,0 +1,181 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockCertificates keeps certificates in memory.
+type mockCertificates struct {
+	certs map[string]domain.Certificate
+}
+
+func (m *mockCertificates) Save(_ context.Context, c domain.Certificate) error {
+	m.certs[c.Domain] = c
+	return nil
+}
+
+func (m *mockCertificates) Get(_ context.Context, name string) (domain.Certificate, error) {
+	c, ok := m.certs[name]
+	if !ok {
+		return domain.Certificate{}, domain.ErrCertificateNotFound
+	}
+	return c, nil
+}
+
+func (m *mockCertificates) ListByTenant(_ context.Context, tenantID string) ([]domain.Certificate, error) {
+	var certs []domain.Certificate
+	for _, c := range m.certs {
+		if c.TenantID == tenantID {
+			certs = append(certs, c)
+		}
+	}
+	sort.Slice(certs, func(i, j int) bool { return certs[i].Domain < certs[j].Domain })
+	return certs, nil
+}
+
+func (m *mockCertificates) ListDue(_ context.Context, now time.Time, limit int) ([]domain.Certificate, error) {
+	var due []domain.Certificate
+	for _, c := range m.certs {
+		if !c.RenewAt.After(now) && len(due) < limit {
+			due = append(due, c)
+		}
+	}
+	return due, nil
+}
+
+func (m *mockCertificates) Delete(_ context.Context, name string) error {
+	if _, ok := m.certs[name]; !ok {
+		return domain.ErrCertificateNotFound
+	}
+	delete(m.certs, name)
+	return nil
+}
+
+// fakeIssuer issues 90-day certificates, or fails with err.
+type fakeIssuer struct {
+	err    error
+	issued []string
+}
+
+func (f *fakeIssuer) Issue(_ context.Context, name string) (domain.IssuedCertificate, error) {
+	if f.err != nil {
+		return domain.IssuedCertificate{}, f.err
+	}
+	f.issued = append(f.issued, name)
+	now := time.Now()
+	return domain.IssuedCertificate{Issuer: "Fake CA", Serial: "1", NotBefore: now, NotAfter: now.Add(90 * 24 * time.Hour)}, nil
+}
+
+func newCertificateService(t *testing.T) (*app.CertificateService, *mockCertificates, *fakeIssuer, *mockRepo, *mockPublisher) {
+	t.Helper()
+	repo := newMockRepo()
+	pub := &mockPublisher{}
+	certs := &mockCertificates{certs: map[string]domain.Certificate{}}
+	issuer := &fakeIssuer{}
+	svc := app.NewTenantService(repo, pub, &mockValidator{})
+	return app.NewCertificateService(certs, issuer, svc), certs, issuer, repo, pub
+}
+
+func TestCertificates_RequestIssueAndRetry(t *testing.T) {
+	cs, certs, issuer, repo, pub := newCertificateService(t)
+	ctx := context.Background()
+	newActiveTenant(t, repo, "ten_1", "pro")
+
+	cert, err := cs.Request(ctx, "ten_1", "App.Acme.com.")
+	if err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	if cert.Domain != "app.acme.com" || cert.Status != domain.CertificatePending {
+		t.Fatalf("Request = %+v, want pending app.acme.com", cert)
+	}
+
+	issuer.err = errors.New("connection refused")
+	report, err := cs.Renew(ctx, time.Now())
+	if err != nil || len(report.Items) != 1 || report.Items[0].Error == "" {
+		t.Fatalf("Renew = %+v, %v; want one failed item", report, err)
+	}
+	failed := certs.certs["app.acme.com"]
+	if failed.Status != domain.CertificateFailed || failed.LastError != "connection refused" {
+		t.Errorf("after failure = %+v", failed)
+	}
+
+	// The retry waits for CertificateRetryAfter.
+	if report, _ := cs.Renew(ctx, time.Now()); len(report.Items) != 0 {
+		t.Errorf("retried immediately: %+v", report.Items)
+	}
+
+	issuer.err = nil
+	report, _ = cs.Renew(ctx, time.Now().Add(domain.CertificateRetryAfter))
+	if len(report.Items) != 1 || report.Items[0].Error != "" {
+		t.Fatalf("retry = %+v, want one issued item", report.Items)
+	}
+	issued := certs.certs["app.acme.com"]
+	if issued.Status != domain.CertificateIssued || issued.LastError != "" || !issued.RenewAt.Equal(issued.NotAfter.Add(-domain.CertificateRenewBefore)) {
+		t.Errorf("after issuance = %+v", issued)
+	}
+
+	want := []domain.Event{domain.EventCertificateFailed, domain.EventCertificateIssued}
+	if got := publishedNames(pub); len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	if cert := pub.events[0].cert; cert == nil || cert.LastError != "connection refused" {
+		t.Errorf("failed event certificate = %+v, want the failed certificate", cert)
+	}
+	if cert := pub.events[1].cert; cert == nil || cert.Status != domain.CertificateIssued {
+		t.Errorf("issued event certificate = %+v, want the issued certificate", cert)
+	}
+}
+
+func TestCertificates_RequestConflictsAcrossTenants(t *testing.T) {
+	cs, _, _, repo, _ := newCertificateService(t)
+	ctx := context.Background()
+	newActiveTenant(t, repo, "ten_1", "pro")
+	newActiveTenant(t, repo, "ten_2", "pro")
+
+	if _, err := cs.Request(ctx, "ten_1", "app.acme.com"); err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	if _, err := cs.Request(ctx, "ten_1", "app.acme.com"); err != nil {
+		t.Errorf("requesting the same domain again: %v", err)
+	}
+
+	var conflict *domain.DomainConflictError
+	if _, err := cs.Request(ctx, "ten_2", "app.acme.com"); !errors.As(err, &conflict) {
+		t.Errorf("Request by another tenant: err = %v, want DomainConflictError", err)
+	}
+	if err := cs.Remove(ctx, "ten_2", "app.acme.com"); !errors.Is(err, domain.ErrCertificateNotFound) {
+		t.Errorf("Remove by another tenant: err = %v, want ErrCertificateNotFound", err)
+	}
+
+	var invalid *domain.InvalidDomainError
+	if _, err := cs.Request(ctx, "ten_2", "10.0.0.1"); !errors.As(err, &invalid) {
+		t.Errorf("Request for an IP: err = %v, want InvalidDomainError", err)
+	}
+}
+
+func TestCertificates_RenewRemovesDeletedTenants(t *testing.T) {
+	cs, certs, issuer, repo, pub := newCertificateService(t)
+	ctx := context.Background()
+	newActiveTenant(t, repo, "ten_1", "pro")
+
+	if _, err := cs.Request(ctx, "ten_1", "app.acme.com"); err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	tenant, _ := repo.GetByID(ctx, "ten_1")
+	tenant.Status = domain.StatusDeleted
+	repo.set(t, tenant)
+
+	if _, err := cs.Renew(ctx, time.Now()); err != nil {
+		t.Fatalf("Renew: %v", err)
+	}
+	if len(certs.certs) != 0 || len(issuer.issued) != 0 || len(pub.events) != 0 {
+		t.Errorf("certificates = %v, issued = %v, events = %v; want the certificate removed silently", certs.certs, issuer.issued, pub.events)
+	}
+}
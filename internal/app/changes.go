@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// CursorNow is the change feed cursor of the latest change, so a consumer
+// can take a cursor before listing the tenants and follow from there.
+const CursorNow = "now"
+
+// WithChangeFeed enables Changes, which reads the tenant changes recorded
+// in f.
+func WithChangeFeed(f domain.ChangeFeed) Option {
+	return func(s *TenantService) {
+		s.changes = f
+	}
+}
+
+// ChangesEnabled reports whether the change feed is configured.
+func (s *TenantService) ChangesEnabled() bool {
+	return s.changes != nil
+}
+
+// ChangePage is a page of the tenant change feed.
+type ChangePage struct {
+	Changes []domain.TenantChange
+	// Cursor is the cursor to read the next page from: the last change's,
+	// or the requested one when there were no changes.
+	Cursor string
+	// HasMore reports whether changes follow the page.
+	HasMore bool
+}
+
+// Changes returns up to limit tenant changes recorded after since, in
+// order. An empty since reads from the start of the feed, CursorNow from
+// its end. Changes after a cursor that retention has pruned are incomplete
+// and return domain.ErrCursorExpired.
+func (s *TenantService) Changes(ctx context.Context, since string, limit int) (ChangePage, error) {
+	oldest, latest, err := s.changes.Cursors(ctx)
+	if err != nil {
+		return ChangePage{}, fmt.Errorf("reading change feed cursors: %w", err)
+	}
+
+	var cursor int64
+	switch since {
+	case "":
+		cursor = oldest
+	case CursorNow:
+		cursor = latest
+	default:
+		cursor, err = strconv.ParseInt(since, 10, 64)
+		if err != nil || cursor < 0 || cursor > latest {
+			return ChangePage{}, &domain.InvalidCursorError{Cursor: since}
+		}
+		if cursor < oldest {
+			return ChangePage{}, domain.ErrCursorExpired
+		}
+	}
+
+	changes, err := s.changes.ChangesAfter(ctx, cursor, limit+1)
+	if err != nil {
+		return ChangePage{}, fmt.Errorf("reading changes: %w", err)
+	}
+	page := ChangePage{Changes: changes}
+	if len(changes) > limit {
+		page.Changes, page.HasMore = changes[:limit], true
+	}
+	if n := len(page.Changes); n > 0 {
+		cursor = page.Changes[n-1].Cursor
+	}
+	page.Cursor = strconv.FormatInt(cursor, 10)
+	return page, nil
+}
@@ -0,0 +1,81 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockFeed holds the changes at cursors oldest+1 to oldest+len(changes).
+type mockFeed struct {
+	oldest  int64
+	changes []domain.TenantChange
+}
+
+func newMockFeed(oldest int64, n int) *mockFeed {
+	f := &mockFeed{oldest: oldest}
+	for i := range n {
+		f.changes = append(f.changes, domain.TenantChange{Cursor: oldest + int64(i) + 1, Type: domain.ChangeUpdated, TenantID: "ten_1"})
+	}
+	return f
+}
+
+func (f *mockFeed) ChangesAfter(_ context.Context, cursor int64, limit int) ([]domain.TenantChange, error) {
+	var out []domain.TenantChange
+	for _, c := range f.changes {
+		if c.Cursor > cursor && len(out) < limit {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (f *mockFeed) Cursors(context.Context) (int64, int64, error) {
+	return f.oldest, f.oldest + int64(len(f.changes)), nil
+}
+
+func TestTenantService_Changes(t *testing.T) {
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{}, app.WithChangeFeed(newMockFeed(10, 5)))
+	ctx := context.Background()
+
+	cases := []struct {
+		since      string
+		limit      int
+		wantFirst  int64
+		wantCount  int
+		wantCursor string
+		wantMore   bool
+	}{
+		{"", 3, 11, 3, "13", true},
+		{"13", 3, 14, 2, "15", false},
+		{"10", 10, 11, 5, "15", false},
+		{"15", 10, 0, 0, "15", false},
+		{app.CursorNow, 10, 0, 0, "15", false},
+	}
+	for _, tc := range cases {
+		page, err := svc.Changes(ctx, tc.since, tc.limit)
+		if err != nil {
+			t.Fatalf("Changes(%q) failed: %v", tc.since, err)
+		}
+		if len(page.Changes) != tc.wantCount || page.Cursor != tc.wantCursor || page.HasMore != tc.wantMore {
+			t.Errorf("Changes(%q, %d) = %d changes, cursor %s, more %v; want %d, %s, %v",
+				tc.since, tc.limit, len(page.Changes), page.Cursor, page.HasMore, tc.wantCount, tc.wantCursor, tc.wantMore)
+		}
+		if tc.wantCount > 0 && page.Changes[0].Cursor != tc.wantFirst {
+			t.Errorf("Changes(%q) starts at %d, want %d", tc.since, page.Changes[0].Cursor, tc.wantFirst)
+		}
+	}
+
+	for _, since := range []string{"abc", "-1", "16"} {
+		var cursorErr *domain.InvalidCursorError
+		if _, err := svc.Changes(ctx, since, 10); !errors.As(err, &cursorErr) {
+			t.Errorf("Changes(%q) err = %v, want InvalidCursorError", since, err)
+		}
+	}
+	if _, err := svc.Changes(ctx, "9", 10); !errors.Is(err, domain.ErrCursorExpired) {
+		t.Errorf("Changes(9) err = %v, want ErrCursorExpired", err)
+	}
+}
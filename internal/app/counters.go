@@ -0,0 +1,41 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithStatusCounter enables StatusCounts, read from the per-status
+// counters c maintains instead of counting tenants, and their
+// reconciliation.
+func WithStatusCounter(c domain.StatusCounter) Option {
+	return func(s *TenantService) {
+		s.counter = c
+	}
+}
+
+// StatusCountsEnabled reports whether status counters are configured.
+func (s *TenantService) StatusCountsEnabled() bool {
+	return s.counter != nil
+}
+
+// StatusCounts returns the number of tenants in each status.
+func (s *TenantService) StatusCounts(ctx context.Context) (map[domain.Status]int, error) {
+	counts, err := s.counter.Counts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading status counts: %w", err)
+	}
+	return counts, nil
+}
+
+// ReconcileStatusCounts recounts the tenants and corrects the counters,
+// returning the drift per status (counted minus actual) it corrected.
+func (s *TenantService) ReconcileStatusCounts(ctx context.Context) (map[domain.Status]int, error) {
+	drift, err := s.counter.Reconcile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reconciling status counts: %w", err)
+	}
+	return drift, nil
+}
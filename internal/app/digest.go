@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// DigestService batches the events of webhook subscriptions in an hourly
+// or daily digest mode: events are held as they are published, and the
+// queue adapter delivers each subscription's held events as one digest
+// once their window ends.
+type DigestService struct {
+	repo     domain.WebhookDigestRepository
+	webhooks *WebhookService
+}
+
+// NewDigestService creates a digest service for the subscriptions of ws.
+func NewDigestService(repo domain.WebhookDigestRepository, ws *WebhookService) *DigestService {
+	return &DigestService{repo: repo, webhooks: ws}
+}
+
+// Hold keeps entry for the next digest of its subscription.
+func (s *DigestService) Hold(ctx context.Context, entry domain.DigestEntry) error {
+	if err := s.repo.Add(ctx, entry); err != nil {
+		return fmt.Errorf("holding event for digest: %w", err)
+	}
+	return nil
+}
+
+// Due returns the digests to deliver at now: for every subscription, the
+// events held before the start of its current window. A subscription that
+// stopped batching has all its held events due.
+func (s *DigestService) Due(ctx context.Context, now time.Time) ([]domain.Digest, error) {
+	subs, err := s.webhooks.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var digests []domain.Digest
+	for _, sub := range subs {
+		until := sub.Digest.WindowStart(now)
+		entries, err := s.repo.Pending(ctx, sub.ID, until)
+		if err != nil {
+			return nil, fmt.Errorf("listing events held for webhook %s: %w", sub.ID, err)
+		}
+		if len(entries) > 0 {
+			digests = append(digests, domain.Digest{Webhook: sub, Until: until, Entries: entries})
+		}
+	}
+	return digests, nil
+}
+
+// Delivered releases the events of a delivered digest.
+func (s *DigestService) Delivered(ctx context.Context, d domain.Digest) error {
+	ids := make([]string, len(d.Entries))
+	for i, e := range d.Entries {
+		ids[i] = e.EventID
+	}
+	if err := s.repo.Remove(ctx, d.Webhook.ID, ids); err != nil {
+		return fmt.Errorf("releasing digest events: %w", err)
+	}
+	return nil
+}
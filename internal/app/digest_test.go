@@ -0,0 +1,82 @@
+package app_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockDigests holds digest entries in memory, oldest first.
+type mockDigests struct {
+	entries []domain.DigestEntry
+}
+
+func (m *mockDigests) Add(_ context.Context, e domain.DigestEntry) error {
+	m.entries = append(m.entries, e)
+	return nil
+}
+
+func (m *mockDigests) Pending(_ context.Context, webhookID string, before time.Time) ([]domain.DigestEntry, error) {
+	var out []domain.DigestEntry
+	for _, e := range m.entries {
+		if e.WebhookID == webhookID && e.OccurredAt.Before(before) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockDigests) Remove(_ context.Context, webhookID string, eventIDs []string) error {
+	m.entries = slices.DeleteFunc(m.entries, func(e domain.DigestEntry) bool {
+		return e.WebhookID == webhookID && slices.Contains(eventIDs, e.EventID)
+	})
+	return nil
+}
+
+func TestDigests_DueAtTheEndOfTheirWindow(t *testing.T) {
+	ws := app.NewWebhookService(&mockWebhooks{})
+	repo := &mockDigests{}
+	ds := app.NewDigestService(repo, ws)
+	ctx := context.Background()
+
+	hourly, _ := ws.Create(ctx, "https://example.com/hourly", testWebhookSecret, nil, domain.DigestHourly)
+	daily, _ := ws.Create(ctx, "https://example.com/daily", testWebhookSecret, nil, domain.DigestDaily)
+	now := time.Date(2026, 3, 14, 15, 30, 0, 0, time.UTC)
+	for i, e := range []domain.DigestEntry{
+		{WebhookID: hourly.ID, EventID: "evt_1", OccurredAt: now.Add(-45 * time.Minute)},
+		{WebhookID: hourly.ID, EventID: "evt_2", OccurredAt: now.Add(-10 * time.Minute)},
+		{WebhookID: daily.ID, EventID: "evt_3", OccurredAt: now.Add(-2 * time.Hour)},
+	} {
+		if err := ds.Hold(ctx, e); err != nil {
+			t.Fatalf("Hold %d: %v", i, err)
+		}
+	}
+
+	// At 15:30 the 14:00 hour has ended, not the day.
+	due, err := ds.Due(ctx, now)
+	if err != nil {
+		t.Fatalf("Due: %v", err)
+	}
+	if len(due) != 1 || due[0].Webhook.ID != hourly.ID || len(due[0].Entries) != 1 || due[0].Entries[0].EventID != "evt_1" ||
+		!due[0].Until.Equal(time.Date(2026, 3, 14, 15, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Due = %+v, want the hourly digest of evt_1 until 15:00", due)
+	}
+	if err := ds.Delivered(ctx, due[0]); err != nil {
+		t.Fatalf("Delivered: %v", err)
+	}
+	if due, _ := ds.Due(ctx, now); len(due) != 0 {
+		t.Errorf("Due after delivery = %+v, want none", due)
+	}
+
+	// A subscription that stops batching has its held events due at once.
+	if _, err := ws.Update(ctx, daily.ID, daily.URL, "", nil, domain.DigestImmediate); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if due, _ := ds.Due(ctx, now); len(due) != 1 || due[0].Webhook.ID != daily.ID || len(due[0].Entries) != 1 {
+		t.Errorf("Due after stopping the daily digest = %+v, want evt_3", due)
+	}
+}
@@ -0,0 +1,181 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// DunningService runs the collection of unpaid invoices: a failed payment
+// warns the customer, a final notice follows, and the tenant is suspended
+// when the grace period ends. Paying at any point ends the flow and lifts
+// a suspension the flow caused. Notifications are published as events, so
+// they reach webhooks like any other event.
+type DunningService struct {
+	repo    domain.DunningRepository
+	tenants *TenantService
+	policy  domain.DunningPolicy
+}
+
+// NewDunningService creates a dunning service acting on tenants through svc.
+func NewDunningService(repo domain.DunningRepository, svc *TenantService, policy domain.DunningPolicy) *DunningService {
+	return &DunningService{repo: repo, tenants: svc, policy: policy}
+}
+
+// Get returns the tenant's dunning, or ErrDunningNotFound when it has none.
+func (s *DunningService) Get(ctx context.Context, tenantID string) (domain.Dunning, error) {
+	return s.repo.Get(ctx, tenantID)
+}
+
+// PaymentFailed starts the dunning flow and publishes EventDunningWarning.
+// A tenant already in dunning keeps its current flow, so redelivered or
+// repeated failures do not restart the clock.
+func (s *DunningService) PaymentFailed(ctx context.Context, tenantID, invoiceID string) (domain.Dunning, error) {
+	tenant, err := s.tenants.GetByID(ctx, tenantID)
+	if err != nil {
+		return domain.Dunning{}, err
+	}
+
+	d, err := s.repo.Get(ctx, tenantID)
+	if err == nil {
+		return d, nil
+	}
+	if !errors.Is(err, domain.ErrDunningNotFound) {
+		return domain.Dunning{}, fmt.Errorf("getting dunning: %w", err)
+	}
+
+	d = domain.StartDunning(tenantID, invoiceID, s.policy, time.Now().UTC())
+	if err := s.repo.Save(ctx, d); err != nil {
+		return domain.Dunning{}, fmt.Errorf("saving dunning: %w", err)
+	}
+	if err := s.tenants.publisher.Publish(s.tenants.withPriority(ctx, tenant), domain.TenantEvent{Event: domain.EventDunningWarning, Tenant: tenant}); err != nil {
+		return domain.Dunning{}, fmt.Errorf("publishing event %q: %w", domain.EventDunningWarning, err)
+	}
+	return d, nil
+}
+
+// PaymentSucceeded ends the tenant's dunning, reactivating the tenant when
+// the flow suspended it. It does nothing for a tenant not in dunning. The
+// dunning ends before the reactivation, so a BillingCurrentGuard lets it
+// through; when the reactivation fails the dunning is restored, so a retry
+// can complete it.
+func (s *DunningService) PaymentSucceeded(ctx context.Context, tenantID string) error {
+	d, err := s.repo.Get(ctx, tenantID)
+	if errors.Is(err, domain.ErrDunningNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("getting dunning: %w", err)
+	}
+
+	if err := s.repo.Delete(ctx, tenantID); err != nil && !errors.Is(err, domain.ErrDunningNotFound) {
+		return fmt.Errorf("deleting dunning: %w", err)
+	}
+	if !d.SuspendedTenant {
+		return nil
+	}
+
+	tenant, err := s.tenants.GetByID(ctx, tenantID)
+	if err == nil && tenant.Status == domain.StatusSuspended {
+		if _, err = s.tenants.Transition(ctx, tenantID, domain.EventReactivate); err != nil {
+			err = fmt.Errorf("reactivating tenant: %w", err)
+		}
+	}
+	if err != nil {
+		if saveErr := s.repo.Save(ctx, d); saveErr != nil {
+			return errors.Join(err, fmt.Errorf("restoring dunning: %w", saveErr))
+		}
+		return err
+	}
+	return nil
+}
+
+// DunningItem is a dunning that moved forward, or failed to.
+type DunningItem struct {
+	TenantID string
+	Stage    domain.DunningStage
+	// DeferredUntil is set when the suspension waits for the tenant's
+	// maintenance window.
+	DeferredUntil time.Time
+	Error         string
+}
+
+// DunningReport summarizes a run of Advance.
+type DunningReport struct {
+	Items []DunningItem
+}
+
+// Advance runs the steps due at now: due warnings get a final notice, due
+// final notices suspend the tenant. A step that fails is reported and
+// retried on the next run; it does not stop the others. A suspension
+// outside the tenant's maintenance windows is postponed to the next one.
+func (s *DunningService) Advance(ctx context.Context, now time.Time) (DunningReport, error) {
+	var report DunningReport
+
+	due, err := s.repo.ListDue(ctx, now)
+	if err != nil {
+		return report, fmt.Errorf("listing due dunnings: %w", err)
+	}
+
+	for _, d := range due {
+		item := DunningItem{TenantID: d.TenantID}
+		var deferred *domain.MaintenanceDeferredError
+		err := s.advance(ctx, &d, now)
+		switch {
+		case errors.As(err, &deferred):
+			item.DeferredUntil, err = deferred.Until, s.deferTo(ctx, &d, deferred.Until)
+			if err != nil {
+				item.Error = err.Error()
+			}
+		case err != nil:
+			item.Error = err.Error()
+		}
+		item.Stage = d.Stage
+		report.Items = append(report.Items, item)
+	}
+	return report, nil
+}
+
+// advance moves d one stage forward and saves it once the step succeeded.
+func (s *DunningService) advance(ctx context.Context, d *domain.Dunning, now time.Time) error {
+	tenant, err := s.tenants.GetByID(ctx, d.TenantID)
+	if err != nil {
+		return err
+	}
+
+	next := *d
+	switch next.Advance(s.policy, now) {
+	case domain.DunningGrace:
+		if err := s.tenants.publisher.Publish(s.tenants.withPriority(ctx, tenant), domain.TenantEvent{Event: domain.EventDunningFinalNotice, Tenant: tenant}); err != nil {
+			return fmt.Errorf("publishing event %q: %w", domain.EventDunningFinalNotice, err)
+		}
+	case domain.DunningSuspended:
+		// A tenant suspended (or deleted) by someone else is left alone,
+		// and will not be reactivated on payment either.
+		if tenant.Status == domain.StatusActive {
+			if _, err := s.tenants.Transition(ctx, tenant.ID, domain.EventSuspend); err != nil {
+				return fmt.Errorf("suspending tenant: %w", err)
+			}
+			next.SuspendedTenant = true
+		}
+	}
+
+	if err := s.repo.Save(ctx, next); err != nil {
+		return fmt.Errorf("saving dunning: %w", err)
+	}
+	*d = next
+	return nil
+}
+
+// deferTo postpones d's next step to until.
+func (s *DunningService) deferTo(ctx context.Context, d *domain.Dunning, until time.Time) error {
+	d.NextStepAt = until
+	d.UpdatedAt = time.Now().UTC()
+	if err := s.repo.Save(ctx, *d); err != nil {
+		return fmt.Errorf("saving dunning: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockDunning keeps dunnings in memory.
+type mockDunning struct {
+	dunnings map[string]domain.Dunning
+}
+
+func (m *mockDunning) Save(_ context.Context, d domain.Dunning) error {
+	m.dunnings[d.TenantID] = d
+	return nil
+}
+
+func (m *mockDunning) Get(_ context.Context, tenantID string) (domain.Dunning, error) {
+	d, ok := m.dunnings[tenantID]
+	if !ok {
+		return domain.Dunning{}, domain.ErrDunningNotFound
+	}
+	return d, nil
+}
+
+func (m *mockDunning) ListDue(_ context.Context, now time.Time) ([]domain.Dunning, error) {
+	var due []domain.Dunning
+	for _, d := range m.dunnings {
+		if d.Due(now) {
+			due = append(due, d)
+		}
+	}
+	return due, nil
+}
+
+func (m *mockDunning) Delete(_ context.Context, tenantID string) error {
+	if _, ok := m.dunnings[tenantID]; !ok {
+		return domain.ErrDunningNotFound
+	}
+	delete(m.dunnings, tenantID)
+	return nil
+}
+
+var testDunningPolicy = domain.DunningPolicy{WarningPeriod: 72 * time.Hour, GracePeriod: 7 * 24 * time.Hour}
+
+func newDunningService(t *testing.T) (*app.DunningService, *mockRepo, *mockPublisher) {
+	t.Helper()
+	repo := newMockRepo()
+	pub := &mockPublisher{}
+	svc := app.NewTenantService(repo, pub, &mockValidator{})
+	return app.NewDunningService(&mockDunning{dunnings: map[string]domain.Dunning{}}, svc, testDunningPolicy), repo, pub
+}
+
+func publishedNames(pub *mockPublisher) []domain.Event {
+	var names []domain.Event
+	for _, e := range pub.events {
+		names = append(names, e.event)
+	}
+	return names
+}
+
+func TestDunning_WarnGraceSuspendThenResume(t *testing.T) {
+	ds, repo, pub := newDunningService(t)
+	ctx := context.Background()
+	newActiveTenant(t, repo, "ten_1", "pro")
+
+	d, err := ds.PaymentFailed(ctx, "ten_1", "in_1")
+	if err != nil {
+		t.Fatalf("PaymentFailed: %v", err)
+	}
+	// A repeated failure keeps the running flow.
+	if again, err := ds.PaymentFailed(ctx, "ten_1", "in_2"); err != nil || again.InvoiceID != "in_1" {
+		t.Fatalf("repeated PaymentFailed = %+v, %v; want the first dunning", again, err)
+	}
+
+	if report, _ := ds.Advance(ctx, d.StartedAt.Add(time.Hour)); len(report.Items) != 0 {
+		t.Fatalf("Advance before the warning period = %+v, want nothing due", report)
+	}
+
+	finalNotice := d.NextStepAt
+	report, err := ds.Advance(ctx, finalNotice)
+	if err != nil || len(report.Items) != 1 || report.Items[0].Stage != domain.DunningGrace {
+		t.Fatalf("Advance = %+v, %v; want the final notice", report, err)
+	}
+
+	report, err = ds.Advance(ctx, finalNotice.Add(testDunningPolicy.GracePeriod))
+	if err != nil || len(report.Items) != 1 || report.Items[0].Stage != domain.DunningSuspended {
+		t.Fatalf("Advance = %+v, %v; want the suspension", report, err)
+	}
+	if got := repo.get("ten_1").Status; got != domain.StatusSuspended {
+		t.Fatalf("status = %q, want suspended", got)
+	}
+
+	if err := ds.PaymentSucceeded(ctx, "ten_1"); err != nil {
+		t.Fatalf("PaymentSucceeded: %v", err)
+	}
+	if got := repo.get("ten_1").Status; got != domain.StatusActive {
+		t.Errorf("status = %q, want active again", got)
+	}
+	if _, err := ds.Get(ctx, "ten_1"); err == nil {
+		t.Error("dunning still open after payment")
+	}
+
+	want := []domain.Event{domain.EventDunningWarning, domain.EventDunningFinalNotice, domain.EventSuspend, domain.EventReactivate}
+	got := publishedNames(pub)
+	if len(got) != len(want) {
+		t.Fatalf("published %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("published %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestDunning_PaymentLeavesManualSuspensionAlone(t *testing.T) {
+	ds, repo, _ := newDunningService(t)
+	ctx := context.Background()
+	newActiveTenant(t, repo, "ten_1", "pro")
+
+	d, err := ds.PaymentFailed(ctx, "ten_1", "in_1")
+	if err != nil {
+		t.Fatalf("PaymentFailed: %v", err)
+	}
+	tenant := repo.get("ten_1")
+	tenant.Status = domain.StatusSuspended // Suspended by an operator meanwhile.
+	repo.set(t, tenant)
+
+	end := d.NextStepAt.Add(testDunningPolicy.GracePeriod)
+	if _, err := ds.Advance(ctx, d.NextStepAt); err != nil {
+		t.Fatalf("Advance: %v", err)
+	}
+	if _, err := ds.Advance(ctx, end); err != nil {
+		t.Fatalf("Advance: %v", err)
+	}
+	if err := ds.PaymentSucceeded(ctx, "ten_1"); err != nil {
+		t.Fatalf("PaymentSucceeded: %v", err)
+	}
+	if got := repo.get("ten_1").Status; got != domain.StatusSuspended {
+		t.Errorf("status = %q, want the operator's suspension kept", got)
+	}
+}
+
+func TestDunning_PaymentWithoutDunningIsNoop(t *testing.T) {
+	ds, _, pub := newDunningService(t)
+
+	if err := ds.PaymentSucceeded(context.Background(), "ten_unknown"); err != nil {
+		t.Errorf("PaymentSucceeded: %v", err)
+	}
+	if len(pub.events) != 0 {
+		t.Errorf("published %v, want nothing", publishedNames(pub))
+	}
+}
@@ -0,0 +1,272 @@
+package app
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// dataKeyLength is the length of the AES-256 keys data is sealed with.
+const dataKeyLength = 32
+
+// EncryptionService encrypts the data tenantiq keeps for tenants, such as
+// their secrets, with the tenant's own KMS key (bring your own key) or
+// else tenantiq's. A tenant that revokes its key at the KMS, or reports it
+// revoked, loses access to its data until it sets a usable key again.
+type EncryptionService struct {
+	keys          domain.EncryptionKeyRepository
+	secrets       domain.SecretRepository
+	kms           domain.KeyManager
+	defaultKeyRef string
+	tenants       *TenantService
+}
+
+// NewEncryptionService creates an encryption service for the tenants of
+// svc, sealing their data with keys of kms: the key a tenant brought, or
+// defaultKeyRef.
+func NewEncryptionService(keys domain.EncryptionKeyRepository, secrets domain.SecretRepository, kms domain.KeyManager, defaultKeyRef string, svc *TenantService) *EncryptionService {
+	return &EncryptionService{keys: keys, secrets: secrets, kms: kms, defaultKeyRef: defaultKeyRef, tenants: svc}
+}
+
+// Key returns the key the tenant brought, or ErrEncryptionKeyNotFound when
+// its data is sealed with tenantiq's.
+func (s *EncryptionService) Key(ctx context.Context, tenantID string) (domain.EncryptionKey, error) {
+	if _, err := s.tenants.GetByID(ctx, tenantID); err != nil {
+		return domain.EncryptionKey{}, err
+	}
+	return s.keys.Get(ctx, tenantID)
+}
+
+// SetKey has the tenant's data sealed with the key keyRef from now on,
+// which requires its plan to include FeatureBYOK when plans are defined.
+// The data keys of its secrets are re-encrypted with keyRef, so rotating
+// needs the previous key to still work; setting a key again after it was
+// revoked resumes access once the KMS allows it.
+func (s *EncryptionService) SetKey(ctx context.Context, tenantID, keyRef string) (domain.EncryptionKey, error) {
+	if err := domain.ValidateKeyRef(keyRef); err != nil {
+		return domain.EncryptionKey{}, err
+	}
+	tenant, err := s.tenants.GetByID(ctx, tenantID)
+	if err != nil {
+		return domain.EncryptionKey{}, err
+	}
+	if err := s.requireBYOK(ctx, tenant); err != nil {
+		return domain.EncryptionKey{}, err
+	}
+	current, err := s.keys.Get(ctx, tenantID)
+	if errors.Is(err, domain.ErrEncryptionKeyNotFound) {
+		current = s.tenantiqKey(tenantID)
+	} else if err != nil {
+		return domain.EncryptionKey{}, fmt.Errorf("getting encryption key: %w", err)
+	}
+
+	probe := make([]byte, dataKeyLength)
+	if _, err := s.kms.Wrap(ctx, keyRef, probe); err != nil {
+		return domain.EncryptionKey{}, &domain.InvalidEncryptionKeyError{Reason: fmt.Sprintf("key %q cannot be used: %v", keyRef, err)}
+	}
+	secrets, err := s.secrets.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return domain.EncryptionKey{}, fmt.Errorf("listing secrets: %w", err)
+	}
+	for _, secret := range secrets {
+		if secret.Value.KeyRef == keyRef && !current.Revoked() {
+			continue
+		}
+		dataKey, err := s.unwrap(ctx, current, secret.Value)
+		if err != nil {
+			return domain.EncryptionKey{}, err
+		}
+		if secret.Value.WrappedKey, err = s.kms.Wrap(ctx, keyRef, dataKey); err != nil {
+			return domain.EncryptionKey{}, fmt.Errorf("re-encrypting the key of secret %s: %w", secret.Name, err)
+		}
+		secret.Value.KeyRef = keyRef
+		if err := s.secrets.Put(ctx, secret); err != nil {
+			return domain.EncryptionKey{}, fmt.Errorf("storing secret %s: %w", secret.Name, err)
+		}
+	}
+
+	key, now := current, time.Now().UTC()
+	if key.CreatedAt.IsZero() {
+		key.CreatedAt = now
+	} else if key.KeyRef != keyRef || key.Revoked() {
+		key.RotatedAt = now
+	}
+	key.KeyRef, key.RevokedAt = keyRef, time.Time{}
+	if err := s.keys.Save(ctx, key); err != nil {
+		return domain.EncryptionKey{}, fmt.Errorf("saving encryption key: %w", err)
+	}
+	slog.InfoContext(ctx, "tenant encryption key set",
+		"tenant_id", tenantID,
+		"key_ref", keyRef,
+		"secrets", len(secrets),
+		"actor", domain.ActorFromContext(ctx),
+	)
+	return key, nil
+}
+
+// RevokeKey records the tenant's key as revoked, as its owner reports,
+// suspending access to the tenant's data.
+func (s *EncryptionService) RevokeKey(ctx context.Context, tenantID string) (domain.EncryptionKey, error) {
+	key, err := s.Key(ctx, tenantID)
+	if err != nil {
+		return domain.EncryptionKey{}, err
+	}
+	if !key.Revoked() {
+		if key, err = s.revoke(ctx, key); err != nil {
+			return domain.EncryptionKey{}, err
+		}
+	}
+	return key, nil
+}
+
+// Seal encrypts plaintext for the tenant with a new data key, wrapped with
+// the tenant's key. aad is authenticated along, so sealed data cannot be
+// moved to another tenant or record.
+func (s *EncryptionService) Seal(ctx context.Context, tenantID string, plaintext, aad []byte) (domain.Sealed, error) {
+	key, err := s.activeKey(ctx, tenantID)
+	if err != nil {
+		return domain.Sealed{}, err
+	}
+	dataKey := make([]byte, dataKeyLength)
+	if _, err := rand.Read(dataKey); err != nil {
+		return domain.Sealed{}, fmt.Errorf("generating data key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return domain.Sealed{}, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return domain.Sealed{}, fmt.Errorf("generating nonce: %w", err)
+	}
+	wrapped, err := s.kms.Wrap(ctx, key.KeyRef, dataKey)
+	if err != nil {
+		return domain.Sealed{}, s.keyFailure(ctx, key, err)
+	}
+	return domain.Sealed{
+		KeyRef:     key.KeyRef,
+		WrappedKey: wrapped,
+		Ciphertext: aead.Seal(nonce, nonce, plaintext, sealedAAD(tenantID, aad)),
+	}, nil
+}
+
+// Open decrypts what Seal sealed for the tenant with the same aad.
+func (s *EncryptionService) Open(ctx context.Context, tenantID string, sealed domain.Sealed, aad []byte) ([]byte, error) {
+	key, err := s.activeKey(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := s.unwrap(ctx, key, sealed)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed.Ciphertext) < aead.NonceSize() {
+		return nil, errors.New("opening sealed data: ciphertext too short")
+	}
+	nonce, ciphertext := sealed.Ciphertext[:aead.NonceSize()], sealed.Ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, sealedAAD(tenantID, aad))
+	if err != nil {
+		return nil, fmt.Errorf("opening sealed data: %w", err)
+	}
+	return plaintext, nil
+}
+
+// activeKey returns the key the tenant's data is sealed with: its own,
+// unless revoked, or tenantiq's.
+func (s *EncryptionService) activeKey(ctx context.Context, tenantID string) (domain.EncryptionKey, error) {
+	key, err := s.keys.Get(ctx, tenantID)
+	if errors.Is(err, domain.ErrEncryptionKeyNotFound) {
+		return s.tenantiqKey(tenantID), nil
+	}
+	if err != nil {
+		return domain.EncryptionKey{}, fmt.Errorf("getting encryption key: %w", err)
+	}
+	if key.Revoked() {
+		return domain.EncryptionKey{}, &domain.EncryptionKeyRevokedError{TenantID: tenantID, KeyRef: key.KeyRef}
+	}
+	return key, nil
+}
+
+// tenantiqKey returns tenantiq's key as the key of a tenant without one
+// of its own; being stored nowhere, it has no CreatedAt.
+func (s *EncryptionService) tenantiqKey(tenantID string) domain.EncryptionKey {
+	return domain.EncryptionKey{TenantID: tenantID, KeyRef: s.defaultKeyRef}
+}
+
+// unwrap decrypts the data key of sealed, recorded for the tenant's key.
+func (s *EncryptionService) unwrap(ctx context.Context, key domain.EncryptionKey, sealed domain.Sealed) ([]byte, error) {
+	dataKey, err := s.kms.Unwrap(ctx, sealed.KeyRef, sealed.WrappedKey)
+	if err != nil {
+		return nil, s.keyFailure(ctx, key, err)
+	}
+	return dataKey, nil
+}
+
+// keyFailure handles an error of the KMS with the tenant's key: when the
+// key a tenant brought turns out revoked, access to its data is
+// suspended. tenantiq's own key failing is left to operators.
+func (s *EncryptionService) keyFailure(ctx context.Context, key domain.EncryptionKey, err error) error {
+	if !errors.Is(err, domain.ErrKeyRevoked) || key.CreatedAt.IsZero() {
+		return fmt.Errorf("using encryption key %q: %w", key.KeyRef, err)
+	}
+	if !key.Revoked() {
+		if _, err := s.revoke(ctx, key); err != nil {
+			return err
+		}
+	}
+	return &domain.EncryptionKeyRevokedError{TenantID: key.TenantID, KeyRef: key.KeyRef}
+}
+
+// revoke records key as revoked now.
+func (s *EncryptionService) revoke(ctx context.Context, key domain.EncryptionKey) (domain.EncryptionKey, error) {
+	key.RevokedAt = time.Now().UTC()
+	if err := s.keys.Save(ctx, key); err != nil {
+		return domain.EncryptionKey{}, fmt.Errorf("saving encryption key: %w", err)
+	}
+	slog.WarnContext(ctx, "tenant encryption key revoked, data access suspended",
+		"tenant_id", key.TenantID,
+		"key_ref", key.KeyRef,
+	)
+	return key, nil
+}
+
+// requireBYOK returns a FeatureNotInPlanError unless the tenant's plan
+// includes FeatureBYOK. Without defined plans, every tenant may.
+func (s *EncryptionService) requireBYOK(ctx context.Context, tenant domain.Tenant) error {
+	if s.tenants.planRegistry == nil {
+		return nil
+	}
+	plan, err := s.tenants.planRegistry.Get(ctx, tenant.Plan)
+	if err != nil && !errors.Is(err, domain.ErrPlanNotFound) {
+		return fmt.Errorf("getting plan: %w", err)
+	}
+	if !slices.Contains(plan.Features, domain.FeatureBYOK) {
+		return &domain.FeatureNotInPlanError{Plan: tenant.Plan, Feature: domain.FeatureBYOK}
+	}
+	return nil
+}
+
+// sealedAAD binds sealed data to its tenant as well as to aad.
+func sealedAAD(tenantID string, aad []byte) []byte {
+	return append(append([]byte(tenantID), 0), aad...)
+}
+
+func newAEAD(dataKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
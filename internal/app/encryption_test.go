@@ -0,0 +1,218 @@
+package app_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockKMS "wraps" data keys by prefixing them with the key reference;
+// revoked keys fail.
+type mockKMS struct {
+	revoked map[string]bool
+}
+
+func (m *mockKMS) Wrap(_ context.Context, keyRef string, dataKey []byte) ([]byte, error) {
+	if m.revoked[keyRef] {
+		return nil, fmt.Errorf("key %s: %w", keyRef, domain.ErrKeyRevoked)
+	}
+	return append([]byte(keyRef+"|"), dataKey...), nil
+}
+
+func (m *mockKMS) Unwrap(_ context.Context, keyRef string, wrapped []byte) ([]byte, error) {
+	if m.revoked[keyRef] {
+		return nil, fmt.Errorf("key %s: %w", keyRef, domain.ErrKeyRevoked)
+	}
+	dataKey, ok := bytes.CutPrefix(wrapped, []byte(keyRef+"|"))
+	if !ok {
+		return nil, errors.New("wrapped by another key")
+	}
+	return dataKey, nil
+}
+
+type mockEncryptionKeys struct {
+	keys map[string]domain.EncryptionKey
+}
+
+func (m *mockEncryptionKeys) Get(_ context.Context, tenantID string) (domain.EncryptionKey, error) {
+	k, ok := m.keys[tenantID]
+	if !ok {
+		return domain.EncryptionKey{}, domain.ErrEncryptionKeyNotFound
+	}
+	return k, nil
+}
+
+func (m *mockEncryptionKeys) Save(_ context.Context, k domain.EncryptionKey) error {
+	m.keys[k.TenantID] = k
+	return nil
+}
+
+type mockSecrets struct {
+	secrets map[string]domain.TenantSecret
+}
+
+func (m *mockSecrets) Put(_ context.Context, s domain.TenantSecret) error {
+	m.secrets[s.TenantID+"/"+s.Name] = s
+	return nil
+}
+
+func (m *mockSecrets) Get(_ context.Context, tenantID, name string) (domain.TenantSecret, error) {
+	s, ok := m.secrets[tenantID+"/"+name]
+	if !ok {
+		return domain.TenantSecret{}, domain.ErrSecretNotFound
+	}
+	return s, nil
+}
+
+func (m *mockSecrets) ListByTenant(_ context.Context, tenantID string) ([]domain.TenantSecret, error) {
+	var out []domain.TenantSecret
+	for _, s := range m.secrets {
+		if s.TenantID == tenantID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockSecrets) Delete(_ context.Context, tenantID, name string) error {
+	if _, ok := m.secrets[tenantID+"/"+name]; !ok {
+		return domain.ErrSecretNotFound
+	}
+	delete(m.secrets, tenantID+"/"+name)
+	return nil
+}
+
+func newEncryptionService(t *testing.T) (*app.EncryptionService, *mockKMS) {
+	t.Helper()
+	repo := newMockRepo()
+	plans := newMockPlans("free")
+	plans.plans["enterprise"] = domain.Plan{Name: "enterprise", Currency: "USD", Features: []string{domain.FeatureBYOK}}
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{}, app.WithPlanValidation(app.NewPlanService(plans, repo)))
+	newActiveTenant(t, repo, "acme", "enterprise")
+	newActiveTenant(t, repo, "beta", "free")
+	kms := &mockKMS{revoked: map[string]bool{}}
+	es := app.NewEncryptionService(&mockEncryptionKeys{keys: map[string]domain.EncryptionKey{}},
+		&mockSecrets{secrets: map[string]domain.TenantSecret{}}, kms, "tenantiq", svc)
+	return es, kms
+}
+
+func TestEncryption_BringYourOwnKey(t *testing.T) {
+	es, kms := newEncryptionService(t)
+	ctx := context.Background()
+
+	if _, err := es.PutSecret(ctx, "acme", "DATABASE_URL", []byte("postgres://acme")); err != nil {
+		t.Fatalf("PutSecret: %v", err)
+	}
+	if _, err := es.Key(ctx, "acme"); !errors.Is(err, domain.ErrEncryptionKeyNotFound) {
+		t.Errorf("Key before BYOK = %v, want ErrEncryptionKeyNotFound", err)
+	}
+
+	// The secret sealed with tenantiq's key moves to the tenant's.
+	key, err := es.SetKey(ctx, "acme", "arn:acme:1")
+	if err != nil {
+		t.Fatalf("SetKey: %v", err)
+	}
+	if key.CreatedAt.IsZero() || !key.RotatedAt.IsZero() {
+		t.Errorf("first key = %+v, want created, not rotated", key)
+	}
+	kms.revoked["tenantiq"] = true
+	secret, value, err := es.Secret(ctx, "acme", "DATABASE_URL")
+	if err != nil || string(value) != "postgres://acme" || secret.Value.KeyRef != "arn:acme:1" {
+		t.Fatalf("Secret = %+v %q, %v; want the value sealed with the tenant's key", secret, value, err)
+	}
+
+	// Rotating re-encrypts with the new key.
+	if key, err = es.SetKey(ctx, "acme", "arn:acme:2"); err != nil || key.RotatedAt.IsZero() {
+		t.Fatalf("rotate: %+v, %v", key, err)
+	}
+	kms.revoked["arn:acme:1"] = true
+	if _, value, err = es.Secret(ctx, "acme", "DATABASE_URL"); err != nil || string(value) != "postgres://acme" {
+		t.Fatalf("Secret after rotation = %q, %v", value, err)
+	}
+
+	// Revoking the key at the KMS suspends access, and records it.
+	kms.revoked["arn:acme:2"] = true
+	var revoked *domain.EncryptionKeyRevokedError
+	if _, _, err := es.Secret(ctx, "acme", "DATABASE_URL"); !errors.As(err, &revoked) {
+		t.Fatalf("Secret with a revoked key = %v, want *EncryptionKeyRevokedError", err)
+	}
+	if key, _ := es.Key(ctx, "acme"); !key.Revoked() {
+		t.Errorf("key = %+v, want recorded revoked", key)
+	}
+	if _, err := es.PutSecret(ctx, "acme", "API_TOKEN", []byte("t")); !errors.As(err, &revoked) {
+		t.Errorf("PutSecret with a revoked key = %v, want *EncryptionKeyRevokedError", err)
+	}
+	if _, err := es.SetKey(ctx, "acme", "arn:acme:3"); !errors.As(err, &revoked) {
+		t.Errorf("rotating away from a revoked key = %v, want *EncryptionKeyRevokedError", err)
+	}
+
+	// Once the key works again, setting it resumes access.
+	kms.revoked["arn:acme:2"] = false
+	if key, err := es.SetKey(ctx, "acme", "arn:acme:2"); err != nil || key.Revoked() {
+		t.Fatalf("SetKey again = %+v, %v; want active", key, err)
+	}
+	if _, value, err = es.Secret(ctx, "acme", "DATABASE_URL"); err != nil || string(value) != "postgres://acme" {
+		t.Errorf("Secret after the key was set again = %q, %v", value, err)
+	}
+
+	// The tenant reporting its key revoked suspends access at once.
+	if key, err := es.RevokeKey(ctx, "acme"); err != nil || !key.Revoked() {
+		t.Fatalf("RevokeKey = %+v, %v", key, err)
+	}
+	if _, _, err := es.Secret(ctx, "acme", "DATABASE_URL"); !errors.As(err, &revoked) {
+		t.Errorf("Secret after RevokeKey = %v, want *EncryptionKeyRevokedError", err)
+	}
+	if secrets, err := es.Secrets(ctx, "acme"); err != nil || len(secrets) != 1 {
+		t.Errorf("Secrets while revoked = %d, %v; want still listed", len(secrets), err)
+	}
+}
+
+func TestEncryption_SetKeyChecks(t *testing.T) {
+	es, kms := newEncryptionService(t)
+	ctx := context.Background()
+
+	var notInPlan *domain.FeatureNotInPlanError
+	if _, err := es.SetKey(ctx, "beta", "arn:beta:1"); !errors.As(err, &notInPlan) {
+		t.Errorf("SetKey on the free plan = %v, want *FeatureNotInPlanError", err)
+	}
+	var invalid *domain.InvalidEncryptionKeyError
+	if _, err := es.SetKey(ctx, "acme", "arn acme"); !errors.As(err, &invalid) {
+		t.Errorf("SetKey with a malformed reference = %v, want *InvalidEncryptionKeyError", err)
+	}
+	kms.revoked["arn:acme:disabled"] = true
+	if _, err := es.SetKey(ctx, "acme", "arn:acme:disabled"); !errors.As(err, &invalid) {
+		t.Errorf("SetKey with an unusable key = %v, want *InvalidEncryptionKeyError", err)
+	}
+	if _, err := es.Key(ctx, "acme"); !errors.Is(err, domain.ErrEncryptionKeyNotFound) {
+		t.Errorf("Key after failed SetKey = %v, want none set", err)
+	}
+
+	var invalidSecret *domain.InvalidSecretError
+	if _, err := es.PutSecret(ctx, "acme", "1password", []byte("x")); !errors.As(err, &invalidSecret) {
+		t.Errorf("PutSecret with a bad name = %v, want *InvalidSecretError", err)
+	}
+}
+
+func TestEncryption_SealedDataStaysWithItsRecord(t *testing.T) {
+	es, _ := newEncryptionService(t)
+	ctx := context.Background()
+
+	sealed, err := es.Seal(ctx, "acme", []byte("archive"), []byte("export-1"))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if got, err := es.Open(ctx, "acme", sealed, []byte("export-1")); err != nil || string(got) != "archive" {
+		t.Fatalf("Open = %q, %v", got, err)
+	}
+	if _, err := es.Open(ctx, "beta", sealed, []byte("export-1")); err == nil {
+		t.Error("data sealed for acme opened for beta")
+	}
+	if _, err := es.Open(ctx, "acme", sealed, []byte("export-2")); err == nil {
+		t.Error("data sealed for one record opened for another")
+	}
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: FanOutPublisher implements domain.EventPublisher.
+var _ domain.EventPublisher = (*FanOutPublisher)(nil)
+
+// PublishTarget is one destination of a FanOutPublisher.
+type PublishTarget struct {
+	// Name identifies the target in errors and to the observer, e.g. "river".
+	Name      string
+	Publisher domain.EventPublisher
+	// Optional targets are best effort: their failures are only reported to
+	// the observer, while a failing required target fails the publish.
+	Optional bool
+	// SkipSimulated keeps the events of simulated tenants from the target,
+	// e.g. one whose consumers provision real infrastructure.
+	SkipSimulated bool
+}
+
+// PublishObserver is told the outcome of every publish to a target, e.g. to
+// record metrics or log the failures of optional targets.
+type PublishObserver func(ctx context.Context, target string, event domain.Event, elapsed time.Duration, err error)
+
+// FanOutPublisher publishes every event to several targets, in order. A
+// failing target does not keep the event from the others. When a required
+// target fails the publish fails, and a retry (e.g. by the outbox relay)
+// reaches every target again, so targets receive events at least once.
+type FanOutPublisher struct {
+	targets  []PublishTarget
+	observer PublishObserver
+}
+
+// NewFanOutPublisher creates a publisher to targets. observer may be nil.
+func NewFanOutPublisher(targets []PublishTarget, observer PublishObserver) *FanOutPublisher {
+	return &FanOutPublisher{targets: targets, observer: observer}
+}
+
+func (p *FanOutPublisher) Publish(ctx context.Context, e domain.TenantEvent) error {
+	var errs []error
+	for _, t := range p.targets {
+		if t.SkipSimulated && e.Tenant.Simulated {
+			continue
+		}
+		start := time.Now()
+		err := t.Publisher.Publish(ctx, e)
+		if p.observer != nil {
+			p.observer(ctx, t.Name, e.Event, time.Since(start), err)
+		}
+		if err != nil && !t.Optional {
+			errs = append(errs, fmt.Errorf("%s: %w", t.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,69 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestFanOutPublisher_IsolatesTargets(t *testing.T) {
+	down := errors.New("broker down")
+	queue, feed := &mockPublisher{}, &mockPublisher{}
+	outcomes := map[string]error{}
+	pub := app.NewFanOutPublisher([]app.PublishTarget{
+		{Name: "amqp", Publisher: &mockPublisher{publishErr: down}, Optional: true},
+		{Name: "river", Publisher: queue},
+		{Name: "feed", Publisher: feed, Optional: true},
+	}, func(_ context.Context, target string, _ domain.Event, _ time.Duration, err error) {
+		outcomes[target] = err
+	})
+
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "free")
+	if err := pub.Publish(context.Background(), domain.TenantEvent{Event: domain.EventSuspend, Tenant: tenant}); err != nil {
+		t.Fatalf("Publish = %v, want the optional target's failure ignored", err)
+	}
+	if len(queue.events) != 1 || len(feed.events) != 1 {
+		t.Errorf("river got %d events, feed %d; want both despite the failing broker", len(queue.events), len(feed.events))
+	}
+	if len(outcomes) != 3 || !errors.Is(outcomes["amqp"], down) || outcomes["river"] != nil {
+		t.Errorf("observed outcomes = %v", outcomes)
+	}
+}
+
+func TestFanOutPublisher_RequiredTargetFails(t *testing.T) {
+	down := errors.New("queue down")
+	feed := &mockPublisher{}
+	pub := app.NewFanOutPublisher([]app.PublishTarget{
+		{Name: "river", Publisher: &mockPublisher{publishErr: down}},
+		{Name: "feed", Publisher: feed, Optional: true},
+	}, nil)
+
+	err := pub.Publish(context.Background(), domain.TenantEvent{Event: domain.EventSuspend, Tenant: domain.NewTenant("ten_1", "Acme", "acme", "free")})
+	if !errors.Is(err, down) {
+		t.Fatalf("Publish = %v, want the required target's failure", err)
+	}
+	if len(feed.events) != 1 {
+		t.Errorf("feed got %d events, want the event despite the failing queue", len(feed.events))
+	}
+}
+
+func TestFanOutPublisher_SkipsSimulatedTenants(t *testing.T) {
+	broker, feed := &mockPublisher{}, &mockPublisher{}
+	pub := app.NewFanOutPublisher([]app.PublishTarget{
+		{Name: "amqp", Publisher: broker, SkipSimulated: true},
+		{Name: "feed", Publisher: feed},
+	}, nil)
+
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "free")
+	tenant.Simulated = true
+	if err := pub.Publish(context.Background(), domain.TenantEvent{Event: domain.EventSuspend, Tenant: tenant}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if len(broker.events) != 0 || len(feed.events) != 1 {
+		t.Errorf("broker got %d events, feed %d; want only the feed", len(broker.events), len(feed.events))
+	}
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time checks: the guards implement domain.TransitionGuard.
+var (
+	_ domain.TransitionGuard = (*NoActiveProjectsGuard)(nil)
+	_ domain.TransitionGuard = (*BillingCurrentGuard)(nil)
+)
+
+// NoActiveProjectsGuard holds back tenants whose metering still reports
+// active projects, typically so they are not deleted with their projects.
+type NoActiveProjectsGuard struct {
+	usage domain.UsageRepository
+}
+
+// NewNoActiveProjectsGuard creates a guard reading the tenant's projects
+// from its reported usage.
+func NewNoActiveProjectsGuard(usage domain.UsageRepository) *NoActiveProjectsGuard {
+	return &NoActiveProjectsGuard{usage: usage}
+}
+
+// Name returns "no_active_projects".
+func (g *NoActiveProjectsGuard) Name() string { return "no_active_projects" }
+
+// Check refuses tenants with a positive domain.MetricProjects usage. A
+// tenant that never reported projects has none.
+func (g *NoActiveProjectsGuard) Check(ctx context.Context, tenant domain.Tenant) (string, error) {
+	usage, err := g.usage.Get(ctx, tenant.ID)
+	if err != nil {
+		return "", fmt.Errorf("getting usage: %w", err)
+	}
+	if projects := usage[domain.MetricProjects]; projects > 0 {
+		return fmt.Sprintf("tenant has %d active projects", projects), nil
+	}
+	return "", nil
+}
+
+// BillingCurrentGuard holds back tenants with an unpaid invoice, typically
+// so they are not reactivated before paying.
+type BillingCurrentGuard struct {
+	dunnings domain.DunningRepository
+}
+
+// NewBillingCurrentGuard creates a guard reading unpaid invoices from the
+// dunning flow.
+func NewBillingCurrentGuard(dunnings domain.DunningRepository) *BillingCurrentGuard {
+	return &BillingCurrentGuard{dunnings: dunnings}
+}
+
+// Name returns "billing_current".
+func (g *BillingCurrentGuard) Name() string { return "billing_current" }
+
+// Check refuses tenants in dunning, whatever its stage.
+func (g *BillingCurrentGuard) Check(ctx context.Context, tenant domain.Tenant) (string, error) {
+	d, err := g.dunnings.Get(ctx, tenant.ID)
+	if errors.Is(err, domain.ErrDunningNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("getting dunning: %w", err)
+	}
+	return fmt.Sprintf("invoice %s is unpaid", d.InvoiceID), nil
+}
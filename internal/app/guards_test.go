@@ -0,0 +1,133 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// guardedValidator checks guard for event after the transitions table,
+// like the FSM validator does.
+type guardedValidator struct {
+	mockValidator
+	event domain.Event
+	guard domain.TransitionGuard
+}
+
+func (v *guardedValidator) Apply(ctx context.Context, tenant domain.Tenant, event domain.Event) (domain.Status, error) {
+	next, err := v.mockValidator.Apply(ctx, tenant, event)
+	if err != nil || event != v.event {
+		return next, err
+	}
+	reason, err := v.guard.Check(ctx, tenant)
+	if err != nil {
+		return "", err
+	}
+	if reason != "" {
+		return "", &domain.GuardError{Guard: v.guard.Name(), Event: event, Current: tenant.Status, Reason: reason}
+	}
+	return next, nil
+}
+
+func TestNoActiveProjectsGuard(t *testing.T) {
+	usage := &mockUsage{usage: map[string]domain.Usage{
+		"ten_1": {domain.MetricProjects: 2, domain.MetricSeats: 5},
+		"ten_2": {domain.MetricProjects: 0, domain.MetricSeats: 5},
+	}}
+	guard := app.NewNoActiveProjectsGuard(usage)
+	ctx := context.Background()
+
+	if reason, err := guard.Check(ctx, domain.Tenant{ID: "ten_1"}); err != nil || reason != "tenant has 2 active projects" {
+		t.Errorf("Check(ten_1) = %q, %v; want refused for 2 projects", reason, err)
+	}
+	for _, id := range []string{"ten_2", "ten_unreported"} {
+		if reason, err := guard.Check(ctx, domain.Tenant{ID: id}); err != nil || reason != "" {
+			t.Errorf("Check(%s) = %q, %v; want allowed", id, reason, err)
+		}
+	}
+
+	usage.getErr = errors.New("disk I/O error")
+	if _, err := guard.Check(ctx, domain.Tenant{ID: "ten_2"}); !errors.Is(err, usage.getErr) {
+		t.Errorf("Check error = %v, want the usage error", err)
+	}
+}
+
+func TestBillingCurrentGuard(t *testing.T) {
+	dunnings := &mockDunning{dunnings: map[string]domain.Dunning{
+		"ten_1": {TenantID: "ten_1", InvoiceID: "in_1", Stage: domain.DunningSuspended},
+	}}
+	guard := app.NewBillingCurrentGuard(dunnings)
+	ctx := context.Background()
+
+	if reason, err := guard.Check(ctx, domain.Tenant{ID: "ten_1"}); err != nil || !strings.Contains(reason, "in_1") {
+		t.Errorf("Check(ten_1) = %q, %v; want refused for invoice in_1", reason, err)
+	}
+	if reason, err := guard.Check(ctx, domain.Tenant{ID: "ten_2"}); err != nil || reason != "" {
+		t.Errorf("Check(ten_2) = %q, %v; want allowed", reason, err)
+	}
+}
+
+func TestTransition_GuardRefusal(t *testing.T) {
+	repo := newMockRepo()
+	pub := &mockPublisher{}
+	usage := &mockUsage{usage: map[string]domain.Usage{"ten_1": {domain.MetricProjects: 1}}}
+	svc := app.NewTenantService(repo, pub, &guardedValidator{
+		event: domain.EventDelete,
+		guard: app.NewNoActiveProjectsGuard(usage),
+	})
+	ctx := domain.WithRole(context.Background(), domain.RoleAdmin)
+	newActiveTenant(t, repo, "ten_1", "pro")
+
+	var guardErr *domain.GuardError
+	if _, err := svc.Transition(ctx, "ten_1", domain.EventDelete); !errors.As(err, &guardErr) || guardErr.Guard != "no_active_projects" {
+		t.Fatalf("Transition(delete) error = %v, want a no_active_projects GuardError", err)
+	}
+	if got := repo.get("ten_1").Status; got != domain.StatusActive {
+		t.Errorf("status = %q, want active after the refusal", got)
+	}
+	if len(pub.events) != 0 {
+		t.Errorf("published %v for a refused transition", publishedNames(pub))
+	}
+
+	usage.usage["ten_1"][domain.MetricProjects] = 0
+	if tenant, err := svc.Transition(ctx, "ten_1", domain.EventDelete); err != nil || tenant.Status != domain.StatusDeleting {
+		t.Errorf("Transition(delete) = %q, %v; want deleting once the projects are gone", tenant.Status, err)
+	}
+}
+
+func TestDunning_PaymentReactivatesPastBillingGuard(t *testing.T) {
+	repo := newMockRepo()
+	dunnings := &mockDunning{dunnings: map[string]domain.Dunning{}}
+	svc := app.NewTenantService(repo, &mockPublisher{}, &guardedValidator{
+		event: domain.EventReactivate,
+		guard: app.NewBillingCurrentGuard(dunnings),
+	})
+	ds := app.NewDunningService(dunnings, svc, testDunningPolicy)
+	ctx := context.Background()
+	newActiveTenant(t, repo, "ten_1", "pro")
+
+	d, err := ds.PaymentFailed(ctx, "ten_1", "in_1")
+	if err != nil {
+		t.Fatalf("PaymentFailed: %v", err)
+	}
+	if _, err := ds.Advance(ctx, d.NextStepAt); err != nil {
+		t.Fatalf("Advance: %v", err)
+	}
+	if _, err := ds.Advance(ctx, d.NextStepAt.Add(testDunningPolicy.GracePeriod)); err != nil {
+		t.Fatalf("Advance: %v", err)
+	}
+	if _, err := svc.Transition(ctx, "ten_1", domain.EventReactivate); !errors.As(err, new(*domain.GuardError)) {
+		t.Fatalf("Transition(reactivate) error = %v, want a GuardError while unpaid", err)
+	}
+
+	if err := ds.PaymentSucceeded(ctx, "ten_1"); err != nil {
+		t.Fatalf("PaymentSucceeded: %v", err)
+	}
+	if got := repo.get("ten_1").Status; got != domain.StatusActive {
+		t.Errorf("status = %q, want active once paid", got)
+	}
+}
@@ -1,9 +1,84 @@
 package app
 
-import "crypto/rand"
+import (
+	"crypto/rand"
+	"strings"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// DefaultTenantIDPrefix is the prefix of tenant IDs unless configured otherwise.
+const DefaultTenantIDPrefix = "ten_"
+
+// OperationIDPrefix is the prefix of long-running operation IDs.
+const OperationIDPrefix = "op_"
+
+// ResellerIDPrefix is the prefix of reseller IDs.
+const ResellerIDPrefix = "rsl_"
+
+// WebhookIDPrefix is the prefix of webhook subscription IDs.
+const WebhookIDPrefix = "wh_"
+
+// MemberIDPrefix is the prefix of tenant member IDs.
+const MemberIDPrefix = "mem_"
+
+// APIKeyIDPrefix is the prefix of tenant API key IDs.
+const APIKeyIDPrefix = "key_"
+
+// APIKeySecretPrefix is the prefix of the tenant API keys themselves, so
+// leaked keys are recognizable by secret scanners.
+const APIKeySecretPrefix = "tqk_"
+
+// AdminKeyIDPrefix is the prefix of management API key IDs.
+const AdminKeyIDPrefix = "adk_"
+
+// AdminKeySecretPrefix is the prefix of the management API keys themselves.
+const AdminKeySecretPrefix = "tqa_"
+
+// EventIDPrefix is the prefix of the IDs given to events in the outbox.
+const EventIDPrefix = "evt_"
+
+// IDGenerator produces typed identifiers of the form <prefix><random hex>
+// (Stripe-style, e.g. "ten_3f2a..."), so IDs are self-describing in logs and
+// support tickets. Isolated here so the ID strategy can evolve independently.
+type IDGenerator struct {
+	prefix string
+}
+
+// NewIDGenerator creates a generator for the given prefix (e.g. "ten_", "prj_").
+// An empty prefix produces bare hex identifiers.
+func NewIDGenerator(prefix string) IDGenerator {
+	return IDGenerator{prefix: prefix}
+}
+
+// Prefix returns the prefix prepended to generated IDs.
+func (g IDGenerator) Prefix() string {
+	return g.prefix
+}
+
+// New returns a fresh random identifier.
+func (g IDGenerator) New() (string, error) {
+	hex, err := generateID()
+	if err != nil {
+		return "", err
+	}
+	return g.prefix + hex, nil
+}
+
+// Check rejects IDs that carry a different type prefix (e.g. a "prj_" ID
+// passed where a tenant ID is expected). Unprefixed IDs are accepted so
+// identifiers issued before prefixing was enabled keep working.
+func (g IDGenerator) Check(id string) error {
+	if g.prefix == "" || strings.HasPrefix(id, g.prefix) {
+		return nil
+	}
+	if strings.Contains(id, "_") {
+		return &domain.InvalidIDError{ID: id, Prefix: g.prefix}
+	}
+	return nil
+}
 
 // generateID produces a random hex identifier.
-// Isolated here so the ID strategy can evolve independently.
 func generateID() (string, error) {
 	b := make([]byte, 16)
 	if _, err := rand.Read(b); err != nil {
@@ -0,0 +1,39 @@
+package app_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestIDGenerator_New(t *testing.T) {
+	id, err := app.NewIDGenerator("ten_").New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(id, "ten_") || len(id) != len("ten_")+32 {
+		t.Errorf("id = %q, want ten_ followed by 32 hex chars", id)
+	}
+}
+
+func TestIDGenerator_Check(t *testing.T) {
+	gen := app.NewIDGenerator("ten_")
+
+	for _, id := range []string{"ten_0123abcd", "0123abcd"} {
+		if err := gen.Check(id); err != nil {
+			t.Errorf("Check(%q) = %v, want nil", id, err)
+		}
+	}
+
+	err := gen.Check("prj_0123abcd")
+	var idErr *domain.InvalidIDError
+	if !errors.As(err, &idErr) {
+		t.Fatalf("Check(prj_...) = %v, want InvalidIDError", err)
+	}
+	if idErr.Prefix != "ten_" {
+		t.Errorf("Prefix = %q, want %q", idErr.Prefix, "ten_")
+	}
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// maxImportIDLength bounds the original IDs accepted by Import.
+const maxImportIDLength = 64
+
+// ImportItem is a tenant migrated from another system with its original
+// identity, so the references to it elsewhere stay valid.
+type ImportItem struct {
+	BatchCreateItem
+	// ID is the tenant's original ID: letters, digits, '_' and '-'. It may
+	// lack the tenant ID prefix but not carry another type's prefix.
+	ID string
+	// CreatedAt is when the tenant was originally created, not in the
+	// future; it defaults to now. UpdatedAt defaults to CreatedAt and may
+	// not precede it.
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Import creates tenants like BatchCreate, but under the IDs and creation
+// times they had in the system they are migrated from. An ID already in use,
+// by a stored tenant or earlier in the batch, is reported as a conflict.
+// It is meant for administrators: the caller must make sure only they
+// reach it.
+func (s *TenantService) Import(ctx context.Context, items []ImportItem) ([]BatchCreateResult, error) {
+	if len(items) > MaxBatchCreate {
+		return nil, &domain.BatchTooLargeError{Size: len(items), Max: MaxBatchCreate}
+	}
+	now := time.Now().UTC()
+	ids := make(map[string]bool, len(items))
+	return s.batchCreate(ctx, len(items), func(i int, seen map[string]bool) (domain.Tenant, error) {
+		item := items[i]
+		if err := s.checkImportID(ctx, item.ID, ids); err != nil {
+			return domain.Tenant{}, err
+		}
+		created, updated, err := importTimes(item, now)
+		if err != nil {
+			return domain.Tenant{}, err
+		}
+
+		tenant, err := s.prepareBatchItem(ctx, item.BatchCreateItem, item.ID, seen)
+		if err != nil {
+			return domain.Tenant{}, err
+		}
+		tenant.CreatedAt, tenant.UpdatedAt = created, updated
+		ids[item.ID] = true
+		return tenant, nil
+	})
+}
+
+// checkImportID checks an original ID is well formed and not in use, in
+// the store or among the ids accepted earlier in the batch.
+func (s *TenantService) checkImportID(ctx context.Context, id string, ids map[string]bool) error {
+	if id == "" || len(id) > maxImportIDLength {
+		return &domain.InvalidImportError{ID: id, Reason: fmt.Sprintf("id must be 1 to %d characters", maxImportIDLength)}
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return &domain.InvalidImportError{ID: id, Reason: fmt.Sprintf("unexpected %q in id (letters, digits, '_' and '-' only)", r)}
+		}
+	}
+	if err := s.ids.Check(id); err != nil {
+		return &domain.InvalidImportError{ID: id, Reason: err.Error()}
+	}
+
+	if ids[id] {
+		return &domain.IDConflictError{ID: id}
+	}
+	if _, err := s.repo.GetByID(ctx, id); err == nil {
+		return &domain.IDConflictError{ID: id}
+	} else if !errors.Is(err, domain.ErrTenantNotFound) {
+		return fmt.Errorf("checking id: %w", err)
+	}
+	return nil
+}
+
+// importTimes returns the creation and update times of an imported
+// tenant, defaulted and checked against now.
+func importTimes(item ImportItem, now time.Time) (created, updated time.Time, err error) {
+	created, updated = item.CreatedAt.UTC(), item.UpdatedAt.UTC()
+	if item.CreatedAt.IsZero() {
+		created = now
+	}
+	if item.UpdatedAt.IsZero() {
+		updated = created
+	}
+	switch {
+	case created.After(now):
+		return time.Time{}, time.Time{}, &domain.InvalidImportError{ID: item.ID, Reason: "created_at is in the future"}
+	case updated.After(now):
+		return time.Time{}, time.Time{}, &domain.InvalidImportError{ID: item.ID, Reason: "updated_at is in the future"}
+	case updated.Before(created):
+		return time.Time{}, time.Time{}, &domain.InvalidImportError{ID: item.ID, Reason: "updated_at precedes created_at"}
+	}
+	return created, updated, nil
+}
@@ -0,0 +1,73 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+)
+
+func TestImport_KeepsOriginalIdentity(t *testing.T) {
+	repo := newMockRepo()
+	pub := &mockPublisher{}
+	svc := app.NewTenantService(repo, pub, &mockValidator{})
+	ctx := context.Background()
+
+	existing, err := svc.Create(ctx, app.CreateInput{Name: "Existing", Slug: "existing", Plan: "free"})
+	if err != nil {
+		t.Fatalf("seeding: %v", err)
+	}
+	pub.events = nil
+
+	created := time.Date(2019, 4, 1, 9, 30, 0, 0, time.UTC)
+	updated := created.Add(24 * time.Hour)
+	item := func(id, slug string) app.ImportItem {
+		return app.ImportItem{BatchCreateItem: app.BatchCreateItem{Name: slug, Slug: slug, Plan: "pro"}, ID: id}
+	}
+	withTimes := item("legacy-42", "acme")
+	withTimes.CreatedAt, withTimes.UpdatedAt = created, updated
+	future := item("legacy-50", "future")
+	future.CreatedAt = time.Now().Add(time.Hour)
+	backwards := item("legacy-51", "backwards")
+	backwards.CreatedAt, backwards.UpdatedAt = updated, created
+
+	results, err := svc.Import(ctx, []app.ImportItem{
+		withTimes,
+		item("ten_0001", "globex"),
+		item(existing.ID, "copy"),
+		item("legacy-42", "again"),
+		item("prj_1", "other-type"),
+		item("has space", "spaced"),
+		future,
+		backwards,
+	})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	want := []app.BatchStatus{
+		app.BatchCreated, app.BatchCreated, app.BatchConflict, app.BatchConflict,
+		app.BatchInvalid, app.BatchInvalid, app.BatchInvalid, app.BatchInvalid,
+	}
+	for i, status := range want {
+		if results[i].Status != status {
+			t.Errorf("results[%d].Status = %q, want %q (%s)", i, results[i].Status, status, results[i].Error)
+		}
+	}
+
+	got := results[0].Tenant
+	if got.ID != "legacy-42" || !got.CreatedAt.Equal(created) || !got.UpdatedAt.Equal(updated) {
+		t.Errorf("imported tenant = %s created %v updated %v, want legacy-42 created %v updated %v",
+			got.ID, got.CreatedAt, got.UpdatedAt, created, updated)
+	}
+	if globex := results[1].Tenant; globex.ID != "ten_0001" || !globex.UpdatedAt.Equal(globex.CreatedAt) {
+		t.Errorf("tenant without times = %+v, want ten_0001 updated when created", globex)
+	}
+	if repo.len() != 3 {
+		t.Errorf("repo has %d tenants, want 3", repo.len())
+	}
+	if len(pub.events) != 2 {
+		t.Errorf("published %d events, want 2", len(pub.events))
+	}
+}
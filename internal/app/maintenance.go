@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithMaintenanceWindows lets tenants declare maintenance windows, and
+// defers disruptive events scheduled by automation (see domain.WithAutomation)
+// to them.
+func WithMaintenanceWindows(repo domain.MaintenanceRepository) Option {
+	return func(s *TenantService) {
+		s.maintenance = repo
+	}
+}
+
+// MaintenanceEnabled reports whether maintenance windows are configured.
+func (s *TenantService) MaintenanceEnabled() bool {
+	return s.maintenance != nil
+}
+
+// MaintenanceWindows returns the windows declared by a tenant.
+func (s *TenantService) MaintenanceWindows(ctx context.Context, id string) (domain.MaintenanceSchedule, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.maintenance.Get(ctx, id)
+}
+
+// SetMaintenanceWindows replaces the windows declared by a tenant. An empty
+// schedule lifts the restriction.
+func (s *TenantService) SetMaintenanceWindows(ctx context.Context, id string, schedule domain.MaintenanceSchedule) (domain.MaintenanceSchedule, error) {
+	if err := schedule.Validate(); err != nil {
+		return nil, &domain.InvalidMaintenanceWindowError{Reason: err.Error()}
+	}
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.maintenance.Set(ctx, id, schedule); err != nil {
+		return nil, fmt.Errorf("setting maintenance windows: %w", err)
+	}
+	return s.maintenance.Get(ctx, id)
+}
+
+// checkMaintenance returns a MaintenanceDeferredError when automation
+// attempts a disruptive event outside the tenant's windows. Changes
+// requested by someone are never deferred.
+func (s *TenantService) checkMaintenance(ctx context.Context, tenantID string, event domain.Event) error {
+	if s.maintenance == nil || !domain.IsAutomated(ctx) || !domain.IsDisruptive(event) {
+		return nil
+	}
+	schedule, err := s.maintenance.Get(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("getting maintenance windows: %w", err)
+	}
+	now := time.Now().UTC()
+	if schedule.Open(now) {
+		return nil
+	}
+	return &domain.MaintenanceDeferredError{TenantID: tenantID, Event: event, Until: schedule.Next(now)}
+}
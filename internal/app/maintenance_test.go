@@ -0,0 +1,106 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockMaintenance keeps maintenance windows in memory.
+type mockMaintenance struct {
+	windows map[string]domain.MaintenanceSchedule
+}
+
+func (m *mockMaintenance) Get(_ context.Context, tenantID string) (domain.MaintenanceSchedule, error) {
+	return m.windows[tenantID], nil
+}
+
+func (m *mockMaintenance) Set(_ context.Context, tenantID string, s domain.MaintenanceSchedule) error {
+	m.windows[tenantID] = s
+	return nil
+}
+
+// closedSchedule returns a one-hour window that is not open now.
+func closedSchedule() domain.MaintenanceSchedule {
+	now := time.Now().UTC()
+	return domain.MaintenanceSchedule{{Weekday: (now.Weekday() + 3) % 7, Start: 0, Duration: time.Hour}}
+}
+
+func TestTransition_AutomationDeferredOutsideMaintenanceWindow(t *testing.T) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithMaintenanceWindows(&mockMaintenance{windows: map[string]domain.MaintenanceSchedule{}}))
+	ctx := context.Background()
+	newActiveTenant(t, repo, "ten_1", "pro")
+
+	if _, err := svc.SetMaintenanceWindows(ctx, "ten_1", closedSchedule()); err != nil {
+		t.Fatalf("SetMaintenanceWindows: %v", err)
+	}
+
+	_, err := svc.Transition(domain.WithAutomation(ctx), "ten_1", domain.EventSuspend)
+	var deferred *domain.MaintenanceDeferredError
+	if !errors.As(err, &deferred) {
+		t.Fatalf("automated suspend: err = %v, want MaintenanceDeferredError", err)
+	}
+	if !deferred.Until.After(time.Now()) {
+		t.Errorf("Until = %s, want the next window", deferred.Until)
+	}
+
+	// Someone asking for it is not deferred.
+	tenant, err := svc.Transition(ctx, "ten_1", domain.EventSuspend)
+	if err != nil || tenant.Status != domain.StatusSuspended {
+		t.Fatalf("manual suspend = %v, %v; want suspended", tenant.Status, err)
+	}
+	// Nor is a non-disruptive event.
+	if _, err := svc.Transition(domain.WithAutomation(ctx), "ten_1", domain.EventReactivate); err != nil {
+		t.Errorf("automated reactivate: %v", err)
+	}
+}
+
+func TestSetMaintenanceWindows_RejectsInvalidWindow(t *testing.T) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithMaintenanceWindows(&mockMaintenance{windows: map[string]domain.MaintenanceSchedule{}}))
+	newActiveTenant(t, repo, "ten_1", "pro")
+
+	_, err := svc.SetMaintenanceWindows(context.Background(), "ten_1",
+		domain.MaintenanceSchedule{{Weekday: time.Monday, Start: 25 * time.Hour, Duration: time.Hour}})
+	var invalid *domain.InvalidMaintenanceWindowError
+	if !errors.As(err, &invalid) {
+		t.Errorf("err = %v, want InvalidMaintenanceWindowError", err)
+	}
+}
+
+func TestDunning_SuspensionDeferredToMaintenanceWindow(t *testing.T) {
+	repo := newMockRepo()
+	maintenance := &mockMaintenance{windows: map[string]domain.MaintenanceSchedule{"ten_1": closedSchedule()}}
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{}, app.WithMaintenanceWindows(maintenance))
+	dunnings := &mockDunning{dunnings: map[string]domain.Dunning{}}
+	ds := app.NewDunningService(dunnings, svc, testDunningPolicy)
+	ctx := domain.WithAutomation(context.Background())
+	newActiveTenant(t, repo, "ten_1", "pro")
+
+	now := time.Now().UTC()
+	d := domain.StartDunning("ten_1", "in_1", testDunningPolicy, now.Add(-30*24*time.Hour))
+	d.Advance(testDunningPolicy, now.Add(-20*24*time.Hour))
+	dunnings.dunnings["ten_1"] = d
+
+	report, err := ds.Advance(ctx, now)
+	if err != nil {
+		t.Fatalf("Advance: %v", err)
+	}
+	if len(report.Items) != 1 || report.Items[0].DeferredUntil.IsZero() || report.Items[0].Error != "" {
+		t.Fatalf("report = %+v, want one deferred item", report)
+	}
+	if got := repo.get("ten_1").Status; got != domain.StatusActive {
+		t.Errorf("status = %q, want still active", got)
+	}
+	saved := dunnings.dunnings["ten_1"]
+	if saved.Stage != domain.DunningGrace || !saved.NextStepAt.Equal(report.Items[0].DeferredUntil) {
+		t.Errorf("dunning = %+v, want grace until the window", saved)
+	}
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// MemberService manages the people attached to tenants. Members are
+// invited by email and become active once they accept; sending the
+// invitation is left to the tenant's applications.
+type MemberService struct {
+	repo    domain.MemberRepository
+	tenants *TenantService
+	ids     IDGenerator
+}
+
+// NewMemberService creates a member service for the tenants of svc.
+func NewMemberService(repo domain.MemberRepository, svc *TenantService) *MemberService {
+	return &MemberService{repo: repo, tenants: svc, ids: NewIDGenerator(MemberIDPrefix)}
+}
+
+// Invite adds email to the tenant with role, invited by the actor of ctx.
+// Addresses are compared case-insensitively; one the tenant already has
+// is a MemberConflictError.
+func (s *MemberService) Invite(ctx context.Context, tenantID, email string, role domain.MemberRole) (domain.Member, error) {
+	tenant, err := s.tenants.GetByID(ctx, tenantID)
+	if err != nil {
+		return domain.Member{}, err
+	}
+	id, err := s.ids.New()
+	if err != nil {
+		return domain.Member{}, fmt.Errorf("generating member id: %w", err)
+	}
+
+	m, err := domain.NewMember(id, tenant.ID, strings.ToLower(strings.TrimSpace(email)), role, domain.ActorFromContext(ctx))
+	if err != nil {
+		return domain.Member{}, err
+	}
+	if err := s.repo.Create(ctx, m); err != nil {
+		return domain.Member{}, err
+	}
+	return m, nil
+}
+
+// List returns the tenant's members, oldest first.
+func (s *MemberService) List(ctx context.Context, tenantID string) ([]domain.Member, error) {
+	if _, err := s.tenants.GetByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByTenant(ctx, tenantID)
+}
+
+// Accept records the member's invitation as accepted, making them active.
+func (s *MemberService) Accept(ctx context.Context, tenantID, id string) (domain.Member, error) {
+	m, err := s.repo.Get(ctx, tenantID, id)
+	if err != nil {
+		return domain.Member{}, err
+	}
+	if m.Status == domain.MemberActive {
+		return m, nil
+	}
+	m.Accept(time.Now())
+	if err := s.repo.Update(ctx, m); err != nil {
+		return domain.Member{}, err
+	}
+	return m, nil
+}
+
+// Remove detaches the member from the tenant, revoking a pending
+// invitation.
+func (s *MemberService) Remove(ctx context.Context, tenantID, id string) error {
+	return s.repo.Delete(ctx, tenantID, id)
+}
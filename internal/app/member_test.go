@@ -0,0 +1,114 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockMembers keeps members in memory, in invitation order.
+type mockMembers struct {
+	members []domain.Member
+}
+
+func (m *mockMembers) Create(_ context.Context, member domain.Member) error {
+	for _, existing := range m.members {
+		if existing.TenantID == member.TenantID && existing.Email == member.Email {
+			return &domain.MemberConflictError{TenantID: member.TenantID, Email: member.Email}
+		}
+	}
+	m.members = append(m.members, member)
+	return nil
+}
+
+func (m *mockMembers) Get(_ context.Context, tenantID, id string) (domain.Member, error) {
+	for _, member := range m.members {
+		if member.TenantID == tenantID && member.ID == id {
+			return member, nil
+		}
+	}
+	return domain.Member{}, domain.ErrMemberNotFound
+}
+
+func (m *mockMembers) ListByTenant(_ context.Context, tenantID string) ([]domain.Member, error) {
+	var members []domain.Member
+	for _, member := range m.members {
+		if member.TenantID == tenantID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (m *mockMembers) Update(_ context.Context, member domain.Member) error {
+	for i, existing := range m.members {
+		if existing.TenantID == member.TenantID && existing.ID == member.ID {
+			m.members[i] = member
+			return nil
+		}
+	}
+	return domain.ErrMemberNotFound
+}
+
+func (m *mockMembers) Delete(_ context.Context, tenantID, id string) error {
+	for i, member := range m.members {
+		if member.TenantID == tenantID && member.ID == id {
+			m.members = append(m.members[:i], m.members[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrMemberNotFound
+}
+
+func TestMembers_InviteAcceptRemove(t *testing.T) {
+	repo := newMockRepo()
+	members := &mockMembers{}
+	ms := app.NewMemberService(members, app.NewTenantService(repo, &mockPublisher{}, &mockValidator{}))
+	ctx := domain.WithActor(context.Background(), "alice")
+	newActiveTenant(t, repo, "ten_1", "pro")
+
+	m, err := ms.Invite(ctx, "ten_1", " Bob@Acme.com ", domain.MemberRoleAdmin)
+	if err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+	if !strings.HasPrefix(m.ID, app.MemberIDPrefix) || m.Email != "bob@acme.com" || m.Status != domain.MemberInvited || m.InvitedBy != "alice" {
+		t.Errorf("Invite = %+v, want bob@acme.com invited by alice", m)
+	}
+
+	var conflictErr *domain.MemberConflictError
+	if _, err := ms.Invite(ctx, "ten_1", "BOB@acme.com", domain.MemberRoleMember); !errors.As(err, &conflictErr) {
+		t.Errorf("Invite again = %v, want MemberConflictError", err)
+	}
+	var invalidErr *domain.InvalidMemberError
+	if _, err := ms.Invite(ctx, "ten_1", "carol@acme.com", "superuser"); !errors.As(err, &invalidErr) {
+		t.Errorf("Invite with an unknown role = %v, want InvalidMemberError", err)
+	}
+	if _, err := ms.Invite(ctx, "ten_1", "Carol <carol@acme.com>", domain.MemberRoleMember); !errors.As(err, &invalidErr) {
+		t.Errorf("Invite with a display name = %v, want InvalidMemberError", err)
+	}
+	if _, err := ms.Invite(ctx, "ten_missing", "carol@acme.com", domain.MemberRoleMember); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("Invite to a missing tenant = %v, want ErrTenantNotFound", err)
+	}
+
+	accepted, err := ms.Accept(ctx, "ten_1", m.ID)
+	if err != nil || accepted.Status != domain.MemberActive || accepted.AcceptedAt.IsZero() {
+		t.Fatalf("Accept = %+v, %v; want active", accepted, err)
+	}
+	if again, _ := ms.Accept(ctx, "ten_1", m.ID); !again.AcceptedAt.Equal(accepted.AcceptedAt) {
+		t.Errorf("Accept again moved AcceptedAt to %s", again.AcceptedAt)
+	}
+
+	if err := ms.Remove(ctx, "ten_1", m.ID); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if list, _ := ms.List(ctx, "ten_1"); len(list) != 0 {
+		t.Errorf("List after Remove = %+v, want none", list)
+	}
+	if err := ms.Remove(ctx, "ten_1", m.ID); !errors.Is(err, domain.ErrMemberNotFound) {
+		t.Errorf("Remove again = %v, want ErrMemberNotFound", err)
+	}
+}
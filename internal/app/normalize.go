@@ -0,0 +1,114 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// NormalizeName returns name in Unicode NFC with surrounding whitespace
+// removed, so the same name typed on different systems (precomposed "é" vs
+// "e" + combining accent) is stored identically.
+func NormalizeName(name string) string {
+	return strings.TrimSpace(norm.NFC.String(name))
+}
+
+// transliterations covers letters that do not decompose to ASCII, plus the
+// Cyrillic and Greek alphabets. Other scripts are rejected by Slugify.
+var transliterations = map[rune]string{
+	// Latin
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'þ': "th", 'ł': "l", 'ı': "i",
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'ґ': "g", 'д': "d", 'е': "e", 'ё': "e", 'є': "ye",
+	'ж': "zh", 'з': "z", 'и': "i", 'і': "i", 'ї': "yi", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh",
+	'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i",
+	'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s",
+	'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// errNotTransliterable is wrapped in the reason returned by Slugify.
+var errNotTransliterable = errors.New("cannot be transliterated")
+
+// Slugify derives a slug from free text: accents are stripped, Cyrillic and
+// Greek are transliterated, and runs of anything else that is not a letter
+// or digit become single hyphens. Letters of other scripts (CJK, Arabic, ...)
+// have no unambiguous Latin spelling and make it fail.
+func Slugify(s string) (string, error) {
+	var (
+		b          strings.Builder
+		pending    bool // a separator is due before the next word
+		unexpected []rune
+	)
+	write := func(part string) {
+		if part == "" {
+			return
+		}
+		if pending && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		pending = false
+		b.WriteString(part)
+	}
+
+	for _, r := range norm.NFKD.String(s) {
+		lower := unicode.ToLower(r)
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining marks left by NFKD decomposition ("é" -> "e" + "´").
+		case lower < unicode.MaxASCII && (unicode.IsLetter(lower) || unicode.IsDigit(lower)):
+			write(string(lower))
+		case transliterations[lower] != "":
+			write(transliterations[lower])
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if _, silent := transliterations[lower]; !silent {
+				unexpected = append(unexpected, r)
+			}
+		default:
+			pending = true
+		}
+	}
+
+	if len(unexpected) > 0 {
+		return "", fmt.Errorf("%q %w", string(unexpected), errNotTransliterable)
+	}
+
+	slug := b.String()
+	if len(slug) > domain.MaxSlugLength {
+		slug = strings.TrimRight(slug[:domain.MaxSlugLength], "-")
+	}
+	return slug, nil
+}
+
+// resolveSlug returns the slug to use for a tenant: the requested one if it
+// is valid, or one derived from the name when none was requested. An invalid
+// slug is rejected with the reason and, when possible, a valid suggestion.
+func resolveSlug(name, slug string) (string, error) {
+	if slug == "" {
+		derived, err := Slugify(name)
+		if err != nil {
+			return "", &domain.InvalidSlugError{Slug: slug, Reason: "name " + err.Error() + "; provide a slug"}
+		}
+		if derived == "" {
+			return "", &domain.InvalidSlugError{Slug: slug, Reason: "name has no letters or digits; provide a slug"}
+		}
+		return derived, nil
+	}
+
+	err := domain.ValidateSlug(slug)
+	var slugErr *domain.InvalidSlugError
+	if errors.As(err, &slugErr) {
+		if suggestion, sErr := Slugify(slug); sErr == nil && suggestion != "" {
+			slugErr.Suggestion = suggestion
+		}
+	}
+	return slug, err
+}
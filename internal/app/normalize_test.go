@@ -0,0 +1,93 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestNormalizeName(t *testing.T) {
+	decomposed := "Café Zürich " // e + combining acute, u + combining diaeresis
+	if got, want := app.NormalizeName(decomposed), "Café Zürich"; got != want {
+		t.Errorf("NormalizeName(%q) = %q, want %q", decomposed, got, want)
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	cases := map[string]string{
+		"Acme Corp":          "acme-corp",
+		"Café Zürich":        "cafe-zurich",
+		"Straße & Søn":       "strasse-son",
+		"  --Łódź__Works-- ": "lodz-works",
+		"Рога и копыта":      "roga-i-kopyta",
+		"Αθήνα":              "athina",
+		"Ⅸ ｆｕｌｌｗｉｄｔｈ 42":     "ix-fullwidth-42",
+		"!!!":                "",
+	}
+	for in, want := range cases {
+		got, err := app.Slugify(in)
+		if err != nil {
+			t.Errorf("Slugify(%q) error: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Slugify(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSlugify_NonTransliterable(t *testing.T) {
+	_, err := app.Slugify("東京 Tokyo")
+	if err == nil || !strings.Contains(err.Error(), `"東京"`) {
+		t.Errorf("Slugify error = %v, want one naming the characters", err)
+	}
+}
+
+func TestSlugify_Truncates(t *testing.T) {
+	got, err := app.Slugify(strings.Repeat("ab ", 60))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) > domain.MaxSlugLength || strings.HasSuffix(got, "-") {
+		t.Errorf("Slugify = %q (%d bytes), want at most %d without trailing hyphen", got, len(got), domain.MaxSlugLength)
+	}
+}
+
+func TestCreate_NormalizesNameAndDerivesSlug(t *testing.T) {
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{})
+
+	tenant, err := svc.Create(context.Background(), app.CreateInput{Name: " Müller GmbH ", Plan: "free"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tenant.Name != "Müller GmbH" || tenant.Slug != "muller-gmbh" {
+		t.Errorf("tenant = %q / %q, want normalized name and derived slug", tenant.Name, tenant.Slug)
+	}
+}
+
+func TestCreate_InvalidSlugSuggestsOne(t *testing.T) {
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{})
+
+	_, err := svc.Create(context.Background(), app.CreateInput{Name: "Zürich", Slug: "Zürich", Plan: "free"})
+	var slugErr *domain.InvalidSlugError
+	if !errors.As(err, &slugErr) {
+		t.Fatalf("expected InvalidSlugError, got %v", err)
+	}
+	if slugErr.Reason == "" || slugErr.Suggestion != "zurich" {
+		t.Errorf("error = %+v, want reason and suggestion", slugErr)
+	}
+}
+
+func TestCreate_NameWithoutSlugNotTransliterable(t *testing.T) {
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{})
+
+	_, err := svc.Create(context.Background(), app.CreateInput{Name: "東京", Plan: "free"})
+	var slugErr *domain.InvalidSlugError
+	if !errors.As(err, &slugErr) || !strings.Contains(slugErr.Reason, "provide a slug") {
+		t.Errorf("expected InvalidSlugError asking for a slug, got %v", err)
+	}
+}
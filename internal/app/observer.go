@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// TenantObserver is told of the tenants TenantService creates and
+// transitions, and of the creations it refuses because the slug is taken,
+// e.g. to count them as business metrics.
+type TenantObserver interface {
+	TenantCreated(ctx context.Context, tenant domain.Tenant)
+	// TenantTransitioned is called once tenant, stored, went from from to
+	// its current status with event.
+	TenantTransitioned(ctx context.Context, from domain.Status, event domain.Event, tenant domain.Tenant)
+	SlugConflict(ctx context.Context, slug string)
+}
+
+// WithTenantObserver reports creations, transitions and slug conflicts to o.
+func WithTenantObserver(o TenantObserver) Option {
+	return func(s *TenantService) {
+		s.observer = o
+	}
+}
+
+// observeCreated reports stored tenants to the observer, if any.
+func (s *TenantService) observeCreated(ctx context.Context, tenants ...domain.Tenant) {
+	if s.observer == nil {
+		return
+	}
+	for _, t := range tenants {
+		s.observer.TenantCreated(ctx, t)
+	}
+}
+
+// observeConflict reports err to the observer, if any, when it is a
+// slug conflict, and returns it.
+func (s *TenantService) observeConflict(ctx context.Context, err error) error {
+	var conflict *domain.SlugConflictError
+	if s.observer != nil && errors.As(err, &conflict) {
+		s.observer.SlugConflict(ctx, conflict.Slug)
+	}
+	return err
+}
@@ -0,0 +1,68 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// recordingObserver keeps what the service reported, one line per call.
+type recordingObserver struct {
+	calls []string
+}
+
+func (o *recordingObserver) TenantCreated(_ context.Context, t domain.Tenant) {
+	o.calls = append(o.calls, "created "+t.Slug+" on "+t.Plan)
+}
+
+func (o *recordingObserver) TenantTransitioned(_ context.Context, from domain.Status, event domain.Event, t domain.Tenant) {
+	o.calls = append(o.calls, fmt.Sprintf("%s: %s -> %s", event, from, t.Status))
+}
+
+func (o *recordingObserver) SlugConflict(_ context.Context, slug string) {
+	o.calls = append(o.calls, "conflict "+slug)
+}
+
+func TestTenantObserver(t *testing.T) {
+	observer := &recordingObserver{}
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{}, app.WithTenantObserver(observer))
+	ctx := context.Background()
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	var conflict *domain.SlugConflictError
+	if _, err := svc.Create(ctx, app.CreateInput{Name: "Acme again", Slug: "acme", Plan: "free"}); !errors.As(err, &conflict) {
+		t.Fatalf("Create with a taken slug: err = %v, want a slug conflict", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err == nil {
+		t.Fatal("invalid transition succeeded")
+	}
+	if _, err := svc.BatchCreate(ctx, []app.BatchCreateItem{
+		{Name: "Globex", Slug: "globex", Plan: "free"},
+		{Name: "Acme", Slug: "acme", Plan: "free"},
+		{Name: "Globex 2", Slug: "globex", Plan: "free"},
+	}); err != nil {
+		t.Fatalf("BatchCreate: %v", err)
+	}
+
+	want := []string{
+		"created acme on pro",
+		"conflict acme",
+		"provision_complete: creating -> active",
+		"conflict acme",
+		"conflict globex",
+		"created globex on free",
+	}
+	if fmt.Sprint(observer.calls) != fmt.Sprint(want) {
+		t.Errorf("calls = %q\nwant    %q", observer.calls, want)
+	}
+}
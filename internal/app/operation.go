@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// OperationService records long-running operations so every asynchronous
+// flow (provisioning, deletion, ...) can be polled the same way.
+type OperationService struct {
+	repo domain.OperationRepository
+	ids  IDGenerator
+}
+
+// NewOperationService creates an operation service backed by repo.
+func NewOperationService(repo domain.OperationRepository) *OperationService {
+	return &OperationService{
+		repo: repo,
+		// Operation IDs use their own prefix so they can never be mistaken
+		// for tenant IDs.
+		ids: NewIDGenerator(OperationIDPrefix),
+	}
+}
+
+// Start records a new pending operation of the given kind on a tenant.
+func (s *OperationService) Start(ctx context.Context, kind domain.OperationKind, tenantID string) (domain.Operation, error) {
+	id, err := s.ids.New()
+	if err != nil {
+		return domain.Operation{}, fmt.Errorf("generating operation id: %w", err)
+	}
+
+	op := domain.NewOperation(id, kind, tenantID)
+	if err := s.repo.Create(ctx, op); err != nil {
+		return domain.Operation{}, fmt.Errorf("creating operation: %w", err)
+	}
+	return op, nil
+}
+
+// Get returns an operation by its identifier.
+func (s *OperationService) Get(ctx context.Context, id string) (domain.Operation, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// List returns operations matching the filter, newest first.
+func (s *OperationService) List(ctx context.Context, filter domain.OperationFilter) ([]domain.Operation, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// Count returns the number of operations matching the filter, ignoring
+// Limit and Offset.
+func (s *OperationService) Count(ctx context.Context, filter domain.OperationFilter) (int, error) {
+	return s.repo.Count(ctx, filter)
+}
+
+// Succeed marks a pending operation as succeeded with the given result.
+// Finished operations are left untouched, so redelivered jobs are harmless.
+func (s *OperationService) Succeed(ctx context.Context, id, result string) error {
+	return s.finish(ctx, id, func(op domain.Operation) domain.Operation { return op.Succeed(result) })
+}
+
+// Fail marks a pending operation as failed, e.g., once its job has
+// exhausted its retries.
+func (s *OperationService) Fail(ctx context.Context, id, reason string) error {
+	return s.finish(ctx, id, func(op domain.Operation) domain.Operation { return op.Fail(reason) })
+}
+
+func (s *OperationService) finish(ctx context.Context, id string, done func(domain.Operation) domain.Operation) error {
+	op, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if op.Done() {
+		return nil
+	}
+	if err := s.repo.Update(ctx, done(op)); err != nil {
+		return fmt.Errorf("updating operation: %w", err)
+	}
+	return nil
+}
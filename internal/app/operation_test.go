@@ -0,0 +1,131 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+type mockOperations struct {
+	ops map[string]domain.Operation
+}
+
+func newMockOperations() *mockOperations {
+	return &mockOperations{ops: make(map[string]domain.Operation)}
+}
+
+func (m *mockOperations) Create(_ context.Context, op domain.Operation) error {
+	m.ops[op.ID] = op
+	return nil
+}
+
+func (m *mockOperations) GetByID(_ context.Context, id string) (domain.Operation, error) {
+	op, ok := m.ops[id]
+	if !ok {
+		return domain.Operation{}, domain.ErrOperationNotFound
+	}
+	return op, nil
+}
+
+func (m *mockOperations) Update(_ context.Context, op domain.Operation) error {
+	if _, ok := m.ops[op.ID]; !ok {
+		return domain.ErrOperationNotFound
+	}
+	m.ops[op.ID] = op
+	return nil
+}
+
+func (m *mockOperations) List(_ context.Context, filter domain.OperationFilter) ([]domain.Operation, error) {
+	var result []domain.Operation
+	for _, op := range m.ops {
+		if filter.TenantID != "" && op.TenantID != filter.TenantID {
+			continue
+		}
+		if len(filter.Kinds) > 0 && !slices.Contains(filter.Kinds, op.Kind) {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, op.Status) {
+			continue
+		}
+		result = append(result, op)
+	}
+	return result, nil
+}
+
+func (m *mockOperations) Count(ctx context.Context, filter domain.OperationFilter) (int, error) {
+	ops, err := m.List(ctx, filter)
+	return len(ops), err
+}
+
+func TestOperationService_Start(t *testing.T) {
+	repo := newMockOperations()
+	svc := app.NewOperationService(repo)
+
+	op, err := svc.Start(context.Background(), domain.OperationDeletion, "ten_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(op.ID, app.OperationIDPrefix) {
+		t.Errorf("ID = %q, want prefix %q", op.ID, app.OperationIDPrefix)
+	}
+	if op.Kind != domain.OperationDeletion || op.TenantID != "ten_1" || op.Status != domain.OperationPending {
+		t.Errorf("operation = %+v", op)
+	}
+	if _, ok := repo.ops[op.ID]; !ok {
+		t.Error("operation was not stored")
+	}
+}
+
+func TestOperationService_SucceedAndFail(t *testing.T) {
+	svc := app.NewOperationService(newMockOperations())
+	ctx := context.Background()
+
+	op, _ := svc.Start(ctx, domain.OperationProvision, "ten_1")
+	if err := svc.Succeed(ctx, op.ID, "ten_1"); err != nil {
+		t.Fatalf("Succeed: %v", err)
+	}
+
+	// A finished operation is not changed again.
+	if err := svc.Fail(ctx, op.ID, "too late"); err != nil {
+		t.Fatalf("Fail: %v", err)
+	}
+	got, _ := svc.Get(ctx, op.ID)
+	if got.Status != domain.OperationSucceeded || got.Result != "ten_1" || got.Error != "" {
+		t.Errorf("operation = %+v, want succeeded with result", got)
+	}
+}
+
+func TestOperationService_FailUnknown(t *testing.T) {
+	svc := app.NewOperationService(newMockOperations())
+
+	if err := svc.Fail(context.Background(), "op_missing", "x"); !errors.Is(err, domain.ErrOperationNotFound) {
+		t.Errorf("expected ErrOperationNotFound, got %v", err)
+	}
+}
+
+func TestOperationService_List(t *testing.T) {
+	svc := app.NewOperationService(newMockOperations())
+	ctx := context.Background()
+
+	_, _ = svc.Start(ctx, domain.OperationProvision, "ten_1")
+	_, _ = svc.Start(ctx, domain.OperationDeletion, "ten_1")
+	_, _ = svc.Start(ctx, domain.OperationProvision, "ten_2")
+
+	filter := domain.OperationFilter{TenantID: "ten_1", Kinds: []domain.OperationKind{domain.OperationDeletion}}
+	ops, err := svc.List(ctx, filter)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(ops) != 1 || ops[0].Kind != domain.OperationDeletion {
+		t.Errorf("ops = %+v, want the deletion of ten_1", ops)
+	}
+	if n, _ := svc.Count(ctx, domain.OperationFilter{}); n != 3 {
+		t.Errorf("Count = %d, want 3", n)
+	}
+}
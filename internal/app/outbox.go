@@ -0,0 +1,180 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithOutbox stores tenant changes together with their events in outbox,
+// instead of publishing the events after the change is stored. relay
+// publishes them and is woken up after every change.
+func WithOutbox(outbox domain.Outbox, relay *OutboxRelay) Option {
+	return func(s *TenantService) {
+		s.outbox = outbox
+		s.relay = relay
+	}
+}
+
+// WithUnitOfWork stores tenant changes and publishes their events in one
+// transaction of uow, instead of publishing the events after the change is
+// stored. It takes precedence over WithOutbox.
+func WithUnitOfWork(uow domain.UnitOfWork) Option {
+	return func(s *TenantService) { s.uow = uow }
+}
+
+// save persists a tenant change and the events it causes. Without an
+// outbox or a unit of work the events are published once the change is
+// stored, by publish; without events it only persists the change.
+func (s *TenantService) save(ctx context.Context, tenant domain.Tenant, created bool, events ...domain.TenantEvent) error {
+	ctx = s.withPriority(ctx, tenant)
+	switch {
+	case len(events) == 0 || (s.outbox == nil && s.uow == nil):
+		return store(ctx, s.repo, tenant, created)
+	case s.uow != nil:
+		err := s.uow.Do(ctx, func(tenants domain.TenantRepository, publisher domain.EventPublisher) error {
+			if err := store(ctx, tenants, tenant, created); err != nil {
+				return err
+			}
+			for _, e := range events {
+				if err := publisher.Publish(ctx, e); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("storing tenant and its events: %w", err)
+		}
+		return nil
+	}
+
+	msgs := make([]domain.OutboxMessage, len(events))
+	for i, e := range events {
+		id, err := s.eventIDs.New()
+		if err != nil {
+			return fmt.Errorf("generating event id: %w", err)
+		}
+		msgs[i] = domain.OutboxMessage{
+			EventID:   id,
+			Event:     e.Event,
+			Tenant:    e.Tenant,
+			Changes:   e.Changes,
+			Priority:  domain.PriorityFromContext(ctx),
+			CreatedAt: time.Now().UTC(),
+		}
+	}
+	var err error
+	if created {
+		err = s.outbox.CreateTenant(ctx, tenant, msgs...)
+	} else {
+		err = s.outbox.UpdateTenant(ctx, tenant, msgs...)
+	}
+	if err != nil {
+		return fmt.Errorf("storing tenant and its events: %w", err)
+	}
+	s.relay.Notify()
+	return nil
+}
+
+// store creates or updates tenant.
+func store(ctx context.Context, repo domain.TenantRepository, tenant domain.Tenant, created bool) error {
+	if created {
+		if err := repo.Create(ctx, tenant); err != nil {
+			return fmt.Errorf("creating tenant: %w", err)
+		}
+	} else if err := repo.Update(ctx, tenant); err != nil {
+		return fmt.Errorf("updating tenant: %w", err)
+	}
+	return nil
+}
+
+// publish publishes the events of a change to tenant unless save already
+// recorded them in the outbox or the unit of work.
+func (s *TenantService) publish(ctx context.Context, tenant domain.Tenant, events ...domain.TenantEvent) error {
+	if s.outbox != nil || s.uow != nil {
+		return nil
+	}
+	ctx = s.withPriority(ctx, tenant)
+	for _, e := range events {
+		if err := s.publisher.Publish(ctx, e); err != nil {
+			return fmt.Errorf("publishing event %q: %w", e.Event, err)
+		}
+	}
+	return nil
+}
+
+// outboxBatchSize bounds the messages a relay reads at once.
+const outboxBatchSize = 100
+
+// OutboxRelay publishes the events recorded in the outbox, oldest first,
+// and deletes each one once published. A crash between the two publishes
+// the event again under the same ID, so consumers can drop the duplicate.
+type OutboxRelay struct {
+	outbox    domain.Outbox
+	publisher domain.EventPublisher
+	wake      chan struct{}
+}
+
+// NewOutboxRelay creates a relay from outbox to publisher.
+func NewOutboxRelay(outbox domain.Outbox, publisher domain.EventPublisher) *OutboxRelay {
+	return &OutboxRelay{outbox: outbox, publisher: publisher, wake: make(chan struct{}, 1)}
+}
+
+// Notify wakes up Run without waiting for the next poll.
+func (r *OutboxRelay) Notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Drain publishes every recorded event and returns how many it published.
+// It stops at the first failure, so events keep their order; the failed
+// one is retried by the next drain.
+func (r *OutboxRelay) Drain(ctx context.Context) (int, error) {
+	published := 0
+	for {
+		msgs, err := r.outbox.Pending(ctx, outboxBatchSize)
+		if err != nil {
+			return published, fmt.Errorf("reading outbox: %w", err)
+		}
+		for _, msg := range msgs {
+			pctx := domain.WithEventID(ctx, msg.EventID)
+			if msg.Priority != "" {
+				pctx = domain.WithPriority(pctx, msg.Priority)
+			}
+			if err := r.publisher.Publish(pctx, domain.TenantEvent{Event: msg.Event, Tenant: msg.Tenant, Changes: msg.Changes}); err != nil {
+				return published, fmt.Errorf("publishing event %q: %w", msg.Event, err)
+			}
+			if err := r.outbox.Delete(ctx, msg.ID); err != nil {
+				return published, fmt.Errorf("deleting outbox message: %w", err)
+			}
+			published++
+		}
+		if len(msgs) < outboxBatchSize {
+			return published, nil
+		}
+	}
+}
+
+// Run drains the outbox when notified and every interval, until ctx is
+// done. Failures are passed to report and retried.
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration, report func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Drain(ctx); err != nil && ctx.Err() == nil {
+			report(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.wake:
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockOutbox stores tenants in a mockRepo and keeps messages in memory.
+type mockOutbox struct {
+	repo *mockRepo
+	msgs []domain.OutboxMessage
+	next int64
+}
+
+func (m *mockOutbox) CreateTenant(ctx context.Context, tenant domain.Tenant, msgs ...domain.OutboxMessage) error {
+	if err := m.repo.Create(ctx, tenant); err != nil {
+		return err
+	}
+	m.record(msgs)
+	return nil
+}
+
+func (m *mockOutbox) UpdateTenant(ctx context.Context, tenant domain.Tenant, msgs ...domain.OutboxMessage) error {
+	if err := m.repo.Update(ctx, tenant); err != nil {
+		return err
+	}
+	m.record(msgs)
+	return nil
+}
+
+func (m *mockOutbox) record(msgs []domain.OutboxMessage) {
+	for _, msg := range msgs {
+		m.next++
+		msg.ID = m.next
+		m.msgs = append(m.msgs, msg)
+	}
+}
+
+func (m *mockOutbox) Pending(_ context.Context, limit int) ([]domain.OutboxMessage, error) {
+	return slices.Clone(m.msgs[:min(limit, len(m.msgs))]), nil
+}
+
+func (m *mockOutbox) Delete(_ context.Context, id int64) error {
+	for i, msg := range m.msgs {
+		if msg.ID == id {
+			m.msgs = append(m.msgs[:i], m.msgs[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// idPublisher records the event IDs set on the publishing context.
+type idPublisher struct {
+	ids []string
+	err error
+}
+
+func (p *idPublisher) Publish(ctx context.Context, _ domain.TenantEvent) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.ids = append(p.ids, domain.EventIDFromContext(ctx))
+	return nil
+}
+
+func TestOutbox_EventsWaitForTheRelay(t *testing.T) {
+	repo := newMockRepo()
+	pub := &mockPublisher{}
+	outbox := &mockOutbox{repo: repo}
+	relayed := &idPublisher{}
+	svc := app.NewTenantService(repo, pub, &mockValidator{},
+		app.WithOutbox(outbox, app.NewOutboxRelay(outbox, relayed)))
+	ctx := context.Background()
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+	plan := "enterprise"
+	if _, err := svc.Update(ctx, tenant.ID, domain.TenantPatch{Plan: &plan}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if len(pub.events) != 0 {
+		t.Errorf("published %d events directly, want them in the outbox", len(pub.events))
+	}
+	if len(outbox.msgs) != 3 || outbox.msgs[1].Tenant.Status != domain.StatusActive ||
+		outbox.msgs[2].Event != domain.EventPlanChanged || len(outbox.msgs[2].Changes) != 1 {
+		t.Fatalf("outbox = %+v, want the creation, the activation and the plan change", outbox.msgs)
+	}
+	ids := []string{outbox.msgs[0].EventID, outbox.msgs[1].EventID, outbox.msgs[2].EventID}
+
+	n, err := app.NewOutboxRelay(outbox, relayed).Drain(ctx)
+	if err != nil || n != 3 {
+		t.Fatalf("Drain = %d, %v; want 3", n, err)
+	}
+	if !slices.Equal(relayed.ids, ids) {
+		t.Errorf("relayed ids = %v, want %v", relayed.ids, ids)
+	}
+	if len(outbox.msgs) != 0 {
+		t.Errorf("outbox still holds %d messages", len(outbox.msgs))
+	}
+}
+
+func TestOutboxRelay_KeepsFailedEvents(t *testing.T) {
+	outbox := &mockOutbox{repo: newMockRepo()}
+	for _, slug := range []string{"a", "b"} {
+		tenant := domain.NewTenant("ten_"+slug, slug, slug, "free")
+		if err := outbox.CreateTenant(context.Background(), tenant, domain.OutboxMessage{EventID: "evt_" + slug, Event: domain.EventProvisionComplete, Tenant: tenant}); err != nil {
+			t.Fatalf("CreateTenant failed: %v", err)
+		}
+	}
+
+	relay := app.NewOutboxRelay(outbox, &idPublisher{err: errors.New("queue down")})
+	if n, err := relay.Drain(context.Background()); err == nil || n != 0 {
+		t.Fatalf("Drain = %d, %v; want a failure", n, err)
+	}
+	if len(outbox.msgs) != 2 {
+		t.Errorf("outbox holds %d messages, want both kept for the next drain", len(outbox.msgs))
+	}
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// UsageEnabled reports whether usage reporting and plan suggestions are configured.
+func (s *TenantService) UsageEnabled() bool {
+	return s.usage != nil
+}
+
+// ReportUsage records metering data for a tenant.
+func (s *TenantService) ReportUsage(ctx context.Context, id string, usage domain.Usage) error {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return err
+	}
+	if err := s.usage.Record(ctx, id, usage); err != nil {
+		return fmt.Errorf("recording usage: %w", err)
+	}
+	return nil
+}
+
+// Usage returns the latest usage reported for a tenant.
+func (s *TenantService) Usage(ctx context.Context, id string) (domain.Usage, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.usage.Get(ctx, id)
+}
+
+// SuggestionItem is a tenant whose suggested plan changed, or whose
+// suggestion could not be computed.
+type SuggestionItem struct {
+	TenantID string
+	Plan     string
+	// SuggestedPlan is empty when a previous suggestion was cleared.
+	SuggestedPlan string
+	Reason        string
+	Error         string
+}
+
+// SuggestionReport summarizes a plan suggestion run.
+type SuggestionReport struct {
+	Checked int
+	Items   []SuggestionItem
+}
+
+// SuggestPlans matches the usage of every active tenant against the plan
+// catalog and stores the best fitting plan on the tenant. A new suggestion
+// publishes EventPlanSuggested; a suggestion that no longer applies is
+// cleared silently. A failure on one tenant is recorded in the report and
+// does not stop the run.
+func (s *TenantService) SuggestPlans(ctx context.Context) (SuggestionReport, error) {
+	var report SuggestionReport
+
+	tenants, err := s.repo.List(ctx, domain.ListFilter{Statuses: []domain.Status{domain.StatusActive}})
+	if err != nil {
+		return report, fmt.Errorf("listing tenants: %w", err)
+	}
+
+	for _, tenant := range tenants {
+		report.Checked++
+		item, changed, err := s.suggestPlan(ctx, tenant)
+		if err != nil {
+			item.Error = err.Error()
+		}
+		if changed || err != nil {
+			report.Items = append(report.Items, item)
+		}
+	}
+	return report, nil
+}
+
+// suggestPlan updates the tenant's suggested plan and reports whether it changed.
+func (s *TenantService) suggestPlan(ctx context.Context, tenant domain.Tenant) (SuggestionItem, bool, error) {
+	item := SuggestionItem{TenantID: tenant.ID, Plan: tenant.Plan}
+
+	usage, err := s.usage.Get(ctx, tenant.ID)
+	if err != nil {
+		return item, false, fmt.Errorf("getting usage: %w", err)
+	}
+
+	item.SuggestedPlan, item.Reason = s.plans.Suggest(tenant.Plan, usage)
+	if item.SuggestedPlan == tenant.SuggestedPlan {
+		return item, false, nil
+	}
+
+	before := tenant
+	tenant.SuggestedPlan = item.SuggestedPlan
+	tenant.UpdatedAt = time.Now().UTC()
+	if err := s.repo.Update(ctx, tenant); err != nil {
+		return item, false, fmt.Errorf("updating tenant: %w", err)
+	}
+	tenant.Version++
+	if err := s.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditUpdate, &before, &tenant)); err != nil {
+		return item, false, err
+	}
+
+	if tenant.SuggestedPlan != "" {
+		if err := s.publisher.Publish(s.withPriority(ctx, tenant), domain.TenantEvent{Event: domain.EventPlanSuggested, Tenant: tenant}); err != nil {
+			return item, false, fmt.Errorf("publishing event %q: %w", domain.EventPlanSuggested, err)
+		}
+	}
+	return item, true, nil
+}
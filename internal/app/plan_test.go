@@ -0,0 +1,120 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockUsage keeps usage reports in memory.
+type mockUsage struct {
+	usage  map[string]domain.Usage
+	getErr error
+}
+
+func (m *mockUsage) Record(_ context.Context, tenantID string, u domain.Usage) error {
+	if m.usage[tenantID] == nil {
+		m.usage[tenantID] = domain.Usage{}
+	}
+	for k, v := range u {
+		m.usage[tenantID][k] = v
+	}
+	return nil
+}
+
+func (m *mockUsage) Get(_ context.Context, tenantID string) (domain.Usage, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	return m.usage[tenantID], nil
+}
+
+var testCatalog = domain.PlanCatalog{
+	{Plan: "free", Limits: map[string]int64{"seats": 5}},
+	{Plan: "pro", Limits: map[string]int64{"seats": 50}},
+}
+
+func newActiveTenant(t *testing.T, repo *mockRepo, id, plan string) {
+	t.Helper()
+	tenant := domain.NewTenant(id, id, id, plan)
+	tenant.Status = domain.StatusActive
+	repo.set(t, tenant)
+}
+
+func TestSuggestPlans(t *testing.T) {
+	repo := newMockRepo()
+	pub := &mockPublisher{}
+	usage := &mockUsage{usage: map[string]domain.Usage{}}
+	svc := app.NewTenantService(repo, pub, &mockValidator{}, app.WithPlanSuggestions(testCatalog, usage))
+	ctx := context.Background()
+
+	newActiveTenant(t, repo, "ten_big", "free")
+	newActiveTenant(t, repo, "ten_ok", "free")
+	if err := svc.ReportUsage(ctx, "ten_big", domain.Usage{"seats": 12}); err != nil {
+		t.Fatalf("ReportUsage: %v", err)
+	}
+	if err := svc.ReportUsage(ctx, "ten_ok", domain.Usage{"seats": 2}); err != nil {
+		t.Fatalf("ReportUsage: %v", err)
+	}
+
+	report, err := svc.SuggestPlans(ctx)
+	if err != nil {
+		t.Fatalf("SuggestPlans: %v", err)
+	}
+	if report.Checked != 2 || len(report.Items) != 1 || report.Items[0].SuggestedPlan != "pro" {
+		t.Fatalf("report = %+v, want pro suggested for ten_big only", report)
+	}
+	if got := repo.get("ten_big").SuggestedPlan; got != "pro" {
+		t.Errorf("SuggestedPlan = %q, want pro", got)
+	}
+	if len(pub.events) != 1 || pub.events[0].event != domain.EventPlanSuggested {
+		t.Errorf("events = %v, want one plan_suggested", pub.events)
+	}
+
+	// Unchanged suggestions are not published again.
+	if _, err := svc.SuggestPlans(ctx); err != nil {
+		t.Fatalf("SuggestPlans: %v", err)
+	}
+	if len(pub.events) != 1 {
+		t.Errorf("got %d events after rerun, want 1", len(pub.events))
+	}
+
+	// Back within quota: the suggestion is cleared without an event.
+	if err := svc.ReportUsage(ctx, "ten_big", domain.Usage{"seats": 4}); err != nil {
+		t.Fatalf("ReportUsage: %v", err)
+	}
+	report, _ = svc.SuggestPlans(ctx)
+	if len(report.Items) != 1 || report.Items[0].SuggestedPlan != "" {
+		t.Errorf("report = %+v, want the suggestion cleared", report)
+	}
+	if got := repo.get("ten_big").SuggestedPlan; got != "" || len(pub.events) != 1 {
+		t.Errorf("SuggestedPlan = %q with %d events, want cleared silently", got, len(pub.events))
+	}
+}
+
+func TestSuggestPlans_RecordsFailures(t *testing.T) {
+	repo := newMockRepo()
+	usage := &mockUsage{usage: map[string]domain.Usage{}, getErr: errors.New("metering down")}
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{}, app.WithPlanSuggestions(testCatalog, usage))
+	newActiveTenant(t, repo, "ten_1", "free")
+
+	report, err := svc.SuggestPlans(context.Background())
+	if err != nil {
+		t.Fatalf("SuggestPlans: %v", err)
+	}
+	if len(report.Items) != 1 || report.Items[0].Error == "" {
+		t.Errorf("report = %+v, want one failed item", report)
+	}
+}
+
+func TestReportUsage_TenantNotFound(t *testing.T) {
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{},
+		app.WithPlanSuggestions(testCatalog, &mockUsage{usage: map[string]domain.Usage{}}))
+
+	if err := svc.ReportUsage(context.Background(), "ten_missing", domain.Usage{"seats": 1}); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("expected ErrTenantNotFound, got %v", err)
+	}
+}
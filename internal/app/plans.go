@@ -0,0 +1,184 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// PlanService manages the plans tenants can be on. With WithPlanValidation
+// the tenant service only accepts plans defined here.
+type PlanService struct {
+	repo    domain.PlanRepository
+	tenants domain.TenantRepository
+}
+
+// NewPlanService creates a plan service backed by repo. Tenants are
+// consulted so a plan in use is not deleted.
+func NewPlanService(repo domain.PlanRepository, tenants domain.TenantRepository) *PlanService {
+	return &PlanService{repo: repo, tenants: tenants}
+}
+
+// Create defines a new plan, offered in regions or, when there are none,
+// everywhere. Its tenants are held to rateLimit, unless it is zero.
+func (s *PlanService) Create(ctx context.Context, name string, price int64, currency string, limits map[string]int64, features, regions []string, rateLimit domain.RateLimit) (domain.Plan, error) {
+	p, err := newPlan(name, price, currency, limits, features, regions, rateLimit)
+	if err != nil {
+		return domain.Plan{}, err
+	}
+	if err := s.repo.Create(ctx, p); err != nil {
+		return domain.Plan{}, err
+	}
+	return p, nil
+}
+
+// Get returns a plan by name.
+func (s *PlanService) Get(ctx context.Context, name string) (domain.Plan, error) {
+	return s.repo.Get(ctx, name)
+}
+
+// List returns every plan, by name.
+func (s *PlanService) List(ctx context.Context) ([]domain.Plan, error) {
+	return s.repo.List(ctx)
+}
+
+// ListIn returns the plans available to tenants in region, by name: those
+// offered there and those offered everywhere.
+func (s *PlanService) ListIn(ctx context.Context, region string) ([]domain.Plan, error) {
+	if err := domain.ValidateRegion(region); err != nil {
+		return nil, err
+	}
+	plans, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	available := plans[:0]
+	for _, p := range plans {
+		if p.AvailableIn(region) {
+			available = append(available, p)
+		}
+	}
+	return available, nil
+}
+
+// Update replaces a plan's price, limits, features, regions and rate
+// limit. The name cannot change: tenants refer to the plan by it. Tenants
+// already on the plan keep it when their region is no longer offered.
+func (s *PlanService) Update(ctx context.Context, name string, price int64, currency string, limits map[string]int64, features, regions []string, rateLimit domain.RateLimit) (domain.Plan, error) {
+	current, err := s.repo.Get(ctx, name)
+	if err != nil {
+		return domain.Plan{}, err
+	}
+
+	p, err := newPlan(name, price, currency, limits, features, regions, rateLimit)
+	if err != nil {
+		return domain.Plan{}, err
+	}
+	p.CreatedAt = current.CreatedAt
+
+	if err := s.repo.Update(ctx, p); err != nil {
+		return domain.Plan{}, fmt.Errorf("updating plan: %w", err)
+	}
+	return p, nil
+}
+
+// Delete removes a plan no tenant is on; deleted tenants do not count.
+func (s *PlanService) Delete(ctx context.Context, name string) error {
+	if _, err := s.repo.Get(ctx, name); err != nil {
+		return err
+	}
+	n, err := s.tenants.Count(ctx, domain.ListFilter{Plans: []string{name}, Statuses: domain.QuotaStatuses()})
+	if err != nil {
+		return fmt.Errorf("counting tenants on plan: %w", err)
+	}
+	if n > 0 {
+		return &domain.PlanInUseError{Name: name, Tenants: n}
+	}
+	return s.repo.Delete(ctx, name)
+}
+
+// Ensure defines the plans of catalog that are missing, with the catalog's
+// limits and rate limits, free of charge. It returns how many plans it
+// created.
+func (s *PlanService) Ensure(ctx context.Context, catalog domain.PlanCatalog) (int, error) {
+	created := 0
+	for _, q := range catalog {
+		_, err := s.repo.Get(ctx, q.Plan)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, domain.ErrPlanNotFound) {
+			return created, err
+		}
+		if _, err := s.Create(ctx, q.Plan, 0, "USD", q.Limits, nil, nil, q.RateLimit); err != nil {
+			return created, fmt.Errorf("creating plan %q: %w", q.Plan, err)
+		}
+		created++
+	}
+	return created, nil
+}
+
+// Check returns an *UnknownPlanError, suggesting the closest defined plan,
+// when name is not defined.
+func (s *PlanService) Check(ctx context.Context, name string) error {
+	_, err := s.repo.Get(ctx, name)
+	if !errors.Is(err, domain.ErrPlanNotFound) {
+		return err
+	}
+	plans, err := s.repo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing plans: %w", err)
+	}
+	names := make([]string, len(plans))
+	for i, p := range plans {
+		names[i] = p.Name
+	}
+	return &domain.UnknownPlanError{Plan: name, Suggestion: domain.ClosestPlanName(name, names)}
+}
+
+// CheckIn is Check, also returning a *PlanNotInRegionError when the plan
+// is not available to tenants in region.
+func (s *PlanService) CheckIn(ctx context.Context, name, region string) error {
+	p, err := s.repo.Get(ctx, name)
+	if errors.Is(err, domain.ErrPlanNotFound) {
+		return s.Check(ctx, name)
+	}
+	if err != nil {
+		return err
+	}
+	return p.CheckRegion(region)
+}
+
+// newPlan returns the plan with its regions normalized, after validating
+// it.
+func newPlan(name string, price int64, currency string, limits map[string]int64, features, regions []string, rateLimit domain.RateLimit) (domain.Plan, error) {
+	p, err := domain.NewPlan(name, price, currency, limits, features)
+	if err != nil {
+		return domain.Plan{}, err
+	}
+	if p.Regions, err = domain.NormalizeRegions(regions); err != nil {
+		return domain.Plan{}, &domain.InvalidPlanError{Reason: err.Error()}
+	}
+	p.RateLimit = rateLimit
+	return p, p.Validate()
+}
+
+// WithPlanValidation rejects tenants created or updated with a plan that
+// plans does not define, or that is not offered in the tenant's region.
+func WithPlanValidation(plans *PlanService) Option {
+	return func(s *TenantService) {
+		s.planRegistry = plans
+	}
+}
+
+// checkPlan returns an *UnknownPlanError when plan validation is enabled
+// and plan is not defined, and a *PlanNotInRegionError when it is not
+// offered in region.
+func (s *TenantService) checkPlan(ctx context.Context, plan, region string) error {
+	if s.planRegistry == nil {
+		return nil
+	}
+	return s.planRegistry.CheckIn(ctx, plan, region)
+}
@@ -0,0 +1,222 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockPlans keeps plans in memory, keyed by name.
+type mockPlans struct {
+	plans map[string]domain.Plan
+}
+
+func newMockPlans(names ...string) *mockPlans {
+	m := &mockPlans{plans: map[string]domain.Plan{}}
+	for _, n := range names {
+		m.plans[n] = domain.Plan{Name: n, Currency: "USD"}
+	}
+	return m
+}
+
+func (m *mockPlans) Create(_ context.Context, p domain.Plan) error {
+	if _, ok := m.plans[p.Name]; ok {
+		return &domain.PlanConflictError{Name: p.Name}
+	}
+	m.plans[p.Name] = p
+	return nil
+}
+
+func (m *mockPlans) Get(_ context.Context, name string) (domain.Plan, error) {
+	p, ok := m.plans[name]
+	if !ok {
+		return domain.Plan{}, domain.ErrPlanNotFound
+	}
+	return p, nil
+}
+
+func (m *mockPlans) List(context.Context) ([]domain.Plan, error) {
+	var out []domain.Plan
+	for _, p := range m.plans {
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (m *mockPlans) Update(_ context.Context, p domain.Plan) error {
+	if _, ok := m.plans[p.Name]; !ok {
+		return domain.ErrPlanNotFound
+	}
+	m.plans[p.Name] = p
+	return nil
+}
+
+func (m *mockPlans) Delete(_ context.Context, name string) error {
+	if _, ok := m.plans[name]; !ok {
+		return domain.ErrPlanNotFound
+	}
+	delete(m.plans, name)
+	return nil
+}
+
+func TestPlans_CreateAndUpdate(t *testing.T) {
+	ps := app.NewPlanService(newMockPlans(), newMockRepo())
+	ctx := context.Background()
+
+	created, err := ps.Create(ctx, "pro", 4900, "USD", map[string]int64{"seats": 50}, []string{"sso"}, nil, domain.RateLimit{})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	var conflict *domain.PlanConflictError
+	if _, err := ps.Create(ctx, "pro", 0, "USD", nil, nil, nil, domain.RateLimit{}); !errors.As(err, &conflict) {
+		t.Errorf("duplicate Create = %v, want *PlanConflictError", err)
+	}
+	var invalid *domain.InvalidPlanError
+	if _, err := ps.Create(ctx, "Pro Plan", 0, "USD", nil, nil, nil, domain.RateLimit{}); !errors.As(err, &invalid) {
+		t.Errorf("Create with a bad name = %v, want *InvalidPlanError", err)
+	}
+
+	updated, err := ps.Update(ctx, "pro", 5900, "EUR", nil, []string{"sso", "audit"}, nil, domain.RateLimit{})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.Price != 5900 || updated.Currency != "EUR" || len(updated.Features) != 2 || !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Errorf("updated = %+v, want the new terms and the original creation time", updated)
+	}
+	if _, err := ps.Update(ctx, "missing", 0, "USD", nil, nil, nil, domain.RateLimit{}); !errors.Is(err, domain.ErrPlanNotFound) {
+		t.Errorf("Update of a missing plan = %v, want ErrPlanNotFound", err)
+	}
+}
+
+func TestPlans_DeleteRefusesPlansInUse(t *testing.T) {
+	repo := newMockRepo()
+	newActiveTenant(t, repo, "ten_1", "pro")
+	deleted := domain.NewTenant("ten_2", "Gone", "gone", "legacy")
+	deleted.Status = domain.StatusDeleted
+	repo.set(t, deleted)
+
+	plans := newMockPlans("pro", "legacy")
+	ps := app.NewPlanService(plans, repo)
+	ctx := context.Background()
+
+	var inUse *domain.PlanInUseError
+	if err := ps.Delete(ctx, "pro"); !errors.As(err, &inUse) || inUse.Tenants != 1 {
+		t.Errorf("Delete(pro) = %v, want *PlanInUseError for 1 tenant", err)
+	}
+	if err := ps.Delete(ctx, "legacy"); err != nil {
+		t.Errorf("Delete(legacy), only used by a deleted tenant: %v", err)
+	}
+	if _, ok := plans.plans["legacy"]; ok {
+		t.Error("legacy plan still stored")
+	}
+}
+
+func TestPlans_EnsureCreatesMissingCatalogPlans(t *testing.T) {
+	plans := newMockPlans("free")
+	ps := app.NewPlanService(plans, newMockRepo())
+
+	n, err := ps.Ensure(context.Background(), domain.PlanCatalog{
+		{Plan: "free"},
+		{Plan: "pro", Limits: map[string]int64{"seats": 50}},
+	})
+	if err != nil {
+		t.Fatalf("Ensure: %v", err)
+	}
+	if n != 1 || plans.plans["pro"].Limits["seats"] != 50 {
+		t.Errorf("Ensure created %d plans, stored %+v; want pro with its limits", n, plans.plans)
+	}
+}
+
+func TestPlanValidation_RejectsUnknownPlans(t *testing.T) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithPlanValidation(app.NewPlanService(newMockPlans("free", "professional"), repo)))
+	ctx := context.Background()
+
+	_, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "porfessional"})
+	var unknown *domain.UnknownPlanError
+	if !errors.As(err, &unknown) || unknown.Suggestion != "professional" {
+		t.Fatalf("Create with a typo = %v, want *UnknownPlanError suggesting professional", err)
+	}
+	if repo.len() != 0 {
+		t.Error("tenant stored despite its unknown plan")
+	}
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	typo := "porfessional"
+	if _, err := svc.Update(ctx, tenant.ID, domain.TenantPatch{Plan: &typo}); !errors.As(err, &unknown) {
+		t.Errorf("Update to a typo = %v, want *UnknownPlanError", err)
+	}
+	plan := "professional"
+	updated, err := svc.Update(ctx, tenant.ID, domain.TenantPatch{Plan: &plan})
+	if err != nil || updated.Plan != "professional" {
+		t.Errorf("Update = %+v, %v; want the tenant on professional", updated, err)
+	}
+
+	results, err := svc.BatchCreate(ctx, []app.BatchCreateItem{{Name: "Beta", Slug: "beta", Plan: "enterprise"}})
+	if err != nil {
+		t.Fatalf("BatchCreate: %v", err)
+	}
+	if results[0].Status != app.BatchInvalid {
+		t.Errorf("batch item status = %q, want %q", results[0].Status, app.BatchInvalid)
+	}
+
+	if _, err := svc.Apply(ctx, domain.TenantSpec{Slug: "acme", Plan: "enterprise"}); !errors.As(err, &unknown) {
+		t.Errorf("Apply to an unknown plan = %v, want *UnknownPlanError", err)
+	}
+}
+
+func TestPlanValidation_EnforcesRegions(t *testing.T) {
+	repo := newMockRepo()
+	plans := newMockPlans("free")
+	ps := app.NewPlanService(plans, repo)
+	ctx := context.Background()
+	if _, err := ps.Create(ctx, "eu-pro", 4900, "EUR", nil, nil, []string{"eu-west", "eu-central"}, domain.RateLimit{}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{}, app.WithPlanValidation(ps))
+
+	var notInRegion *domain.PlanNotInRegionError
+	_, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "eu-pro", Region: "us-east"})
+	if !errors.As(err, &notInRegion) || notInRegion.Region != "us-east" || len(notInRegion.Regions) != 2 {
+		t.Fatalf("Create in us-east = %v, want *PlanNotInRegionError listing the plan's regions", err)
+	}
+	if _, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "eu-pro"}); !errors.As(err, &notInRegion) {
+		t.Errorf("Create without a region = %v, want *PlanNotInRegionError", err)
+	}
+	var badRegion *domain.InvalidRegionError
+	if _, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free", Region: "EU West"}); !errors.As(err, &badRegion) {
+		t.Errorf("Create in a malformed region = %v, want *InvalidRegionError", err)
+	}
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free", Region: "us-east"})
+	if err != nil || tenant.Region != "us-east" {
+		t.Fatalf("Create = %+v, %v; want the tenant in us-east", tenant, err)
+	}
+	plan := "eu-pro"
+	if _, err := svc.Update(ctx, tenant.ID, domain.TenantPatch{Plan: &plan}); !errors.As(err, &notInRegion) {
+		t.Errorf("Update to a plan not offered in the tenant's region = %v, want *PlanNotInRegionError", err)
+	}
+
+	results, err := svc.BatchCreate(ctx, []app.BatchCreateItem{
+		{Name: "Beta", Slug: "beta", Plan: "eu-pro", Region: "eu-west"},
+		{Name: "Gamma", Slug: "gamma", Plan: "eu-pro", Region: "us-east"},
+	})
+	if err != nil {
+		t.Fatalf("BatchCreate: %v", err)
+	}
+	if results[0].Status != app.BatchCreated || results[1].Status != app.BatchInvalid {
+		t.Errorf("batch statuses = %q, %q; want %q, %q", results[0].Status, results[1].Status, app.BatchCreated, app.BatchInvalid)
+	}
+
+	available, err := ps.ListIn(ctx, "us-east")
+	if err != nil || len(available) != 1 || available[0].Name != "free" {
+		t.Errorf("ListIn(us-east) = %+v, %v; want only free", available, err)
+	}
+}
@@ -0,0 +1,18 @@
+package app
+
+import (
+	"context"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// withPriority returns ctx carrying the priority of the work queued for
+// tenant: the one already set, e.g. by the caller's request, or else the
+// priority of the tenant's plan in the catalog of WithRateLimits or
+// WithPlanSuggestions.
+func (s *TenantService) withPriority(ctx context.Context, tenant domain.Tenant) context.Context {
+	if domain.PriorityFromContext(ctx) != "" {
+		return ctx
+	}
+	return domain.WithPriority(ctx, s.plans.Priority(tenant.Plan))
+}
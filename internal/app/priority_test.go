@@ -0,0 +1,71 @@
+package app_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// priorityPublisher records the priority of every published event.
+type priorityPublisher struct {
+	priorities []domain.Priority
+}
+
+func (p *priorityPublisher) Publish(ctx context.Context, _ domain.TenantEvent) error {
+	p.priorities = append(p.priorities, domain.PriorityFromContext(ctx))
+	return nil
+}
+
+func TestPriority_FollowsPlanUnlessRequested(t *testing.T) {
+	catalog := domain.PlanCatalog{{Plan: "free"}, {Plan: "enterprise", Priority: domain.PriorityHigh}}
+	queue, pub := &mockQueue{}, &priorityPublisher{}
+	svc := app.NewTenantService(newMockRepo(), pub, &mockValidator{},
+		app.WithAsyncOperations(app.NewOperationService(newMockOperations()), queue),
+		app.WithPlanSuggestions(catalog, nil))
+	ctx := context.Background()
+
+	if _, _, err := svc.CreateAsync(ctx, app.CreateInput{Name: "Big", Slug: "big", Plan: "enterprise"}); err != nil {
+		t.Fatalf("CreateAsync failed: %v", err)
+	}
+	if _, _, err := svc.CreateAsync(ctx, app.CreateInput{Name: "Small", Slug: "small", Plan: "free"}); err != nil {
+		t.Fatalf("CreateAsync failed: %v", err)
+	}
+	if _, _, err := svc.CreateAsync(domain.WithPriority(ctx, domain.PriorityLow), app.CreateInput{Name: "Bulk", Slug: "bulk", Plan: "enterprise"}); err != nil {
+		t.Fatalf("CreateAsync failed: %v", err)
+	}
+	want := []domain.Priority{domain.PriorityHigh, domain.PriorityNormal, domain.PriorityLow}
+	if !slices.Equal(queue.priorities, want) {
+		t.Errorf("queued priorities = %v, want %v", queue.priorities, want)
+	}
+
+	if _, err := svc.Create(ctx, app.CreateInput{Name: "Corp", Slug: "corp", Plan: "enterprise"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if !slices.Equal(pub.priorities, []domain.Priority{domain.PriorityHigh}) {
+		t.Errorf("event priorities = %v, want the plan's", pub.priorities)
+	}
+}
+
+func TestOutboxRelay_KeepsPriority(t *testing.T) {
+	repo := newMockRepo()
+	outbox := &mockOutbox{repo: repo}
+	catalog := domain.PlanCatalog{{Plan: "enterprise", Priority: domain.PriorityHigh}}
+	relayed := &priorityPublisher{}
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithOutbox(outbox, app.NewOutboxRelay(outbox, relayed)),
+		app.WithPlanSuggestions(catalog, nil))
+	ctx := context.Background()
+
+	if _, err := svc.Create(ctx, app.CreateInput{Name: "Corp", Slug: "corp", Plan: "enterprise"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := app.NewOutboxRelay(outbox, relayed).Drain(ctx); err != nil {
+		t.Fatalf("Drain failed: %v", err)
+	}
+	if !slices.Equal(relayed.priorities, []domain.Priority{domain.PriorityHigh}) {
+		t.Errorf("relayed priorities = %v, want high", relayed.priorities)
+	}
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// PurgeService permanently removes the tenants that have been deleted for
+// longer than the purge window, so the tenants table does not keep every
+// tenant that ever existed. Each purge is audited and published as
+// EventPurged.
+type PurgeService struct {
+	purger  domain.TenantPurger
+	tenants *TenantService
+	after   time.Duration
+}
+
+// NewPurgeService creates a purge service removing, through purger, the
+// tenants of svc deleted more than after ago.
+func NewPurgeService(purger domain.TenantPurger, svc *TenantService, after time.Duration) *PurgeService {
+	return &PurgeService{purger: purger, tenants: svc, after: after}
+}
+
+// PurgeItem is a tenant that was purged, or that failed to be.
+type PurgeItem struct {
+	TenantID string
+	Slug     string
+	// DeletedAt is when the tenant was last changed, i.e. deleted.
+	DeletedAt time.Time
+	Error     string
+}
+
+// PurgeReport summarizes a purge run.
+type PurgeReport struct {
+	DryRun bool
+	// Before is the cutoff: tenants deleted before it expired.
+	Before time.Time
+	Items  []PurgeItem
+}
+
+// Purge removes the tenants in StatusDeleted last changed before now minus
+// the purge window. With dryRun nothing is removed and the report tells
+// what would be. A failure on one tenant is recorded in the report and
+// does not stop the run; the tenant is retried on the next one.
+func (s *PurgeService) Purge(ctx context.Context, now time.Time, dryRun bool) (PurgeReport, error) {
+	report := PurgeReport{DryRun: dryRun, Before: now.Add(-s.after)}
+
+	tenants, err := s.tenants.repo.List(ctx, domain.ListFilter{Statuses: []domain.Status{domain.StatusDeleted}})
+	if err != nil {
+		return report, fmt.Errorf("listing tenants: %w", err)
+	}
+
+	for _, tenant := range tenants {
+		if !tenant.UpdatedAt.Before(report.Before) {
+			continue
+		}
+		item := PurgeItem{TenantID: tenant.ID, Slug: tenant.Slug, DeletedAt: tenant.UpdatedAt}
+		if !dryRun {
+			if err := s.purge(ctx, tenant); err != nil {
+				item.Error = err.Error()
+			}
+		}
+		report.Items = append(report.Items, item)
+	}
+	return report, nil
+}
+
+// purge removes one tenant, then records and announces its removal.
+func (s *PurgeService) purge(ctx context.Context, tenant domain.Tenant) error {
+	if err := s.purger.Purge(ctx, tenant.ID); err != nil {
+		return fmt.Errorf("purging tenant: %w", err)
+	}
+	if err := s.tenants.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditPurge, &tenant, nil)); err != nil {
+		return err
+	}
+	if err := s.tenants.publisher.Publish(s.tenants.withPriority(ctx, tenant), domain.TenantEvent{Event: domain.EventPurged, Tenant: tenant}); err != nil {
+		return fmt.Errorf("publishing event %q: %w", domain.EventPurged, err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// deletedTenant returns a tenant deleted, i.e. last changed, at deletedAt.
+func deletedTenant(id, slug string, deletedAt time.Time) domain.Tenant {
+	tenant := domain.NewTenant(id, "Tenant "+id, slug, "free")
+	tenant.Status = domain.StatusDeleted
+	tenant.UpdatedAt = deletedAt
+	return tenant
+}
+
+func TestPurge_RemovesTenantsDeletedPastTheWindow(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := newMockRepo()
+	repo.set(t, deletedTenant("ten_old", "old", now.Add(-31*24*time.Hour)))
+	repo.set(t, deletedTenant("ten_recent", "recent", now.Add(-24*time.Hour)))
+	active := domain.NewTenant("ten_active", "Active", "active", "free")
+	active.Status = domain.StatusActive
+	active.UpdatedAt = now.Add(-365 * 24 * time.Hour)
+	repo.set(t, active)
+
+	pub, audit := &mockPublisher{}, &mockAudit{}
+	svc := app.NewTenantService(repo, pub, &mockValidator{}, app.WithAuditLogger(audit))
+	purge := app.NewPurgeService(repo, svc, 30*24*time.Hour)
+
+	report, err := purge.Purge(context.Background(), now, false)
+	if err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if len(report.Items) != 1 || report.Items[0].TenantID != "ten_old" || report.Items[0].Error != "" {
+		t.Fatalf("report items = %+v, want only ten_old purged", report.Items)
+	}
+	if repo.len() != 2 || repo.get("ten_old").ID != "" {
+		t.Errorf("%d tenants left, want ten_old gone and the others kept", repo.len())
+	}
+	if len(pub.events) != 1 || pub.events[0].event != domain.EventPurged || pub.events[0].tenant.ID != "ten_old" {
+		t.Errorf("published %+v, want one purged event for ten_old", pub.events)
+	}
+	if len(audit.entries) != 1 || audit.entries[0].Action != domain.AuditPurge || audit.entries[0].After != nil {
+		t.Errorf("audit entries = %+v, want one purge without an after snapshot", audit.entries)
+	}
+}
+
+func TestPurge_DryRunKeepsTenants(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := newMockRepo()
+	repo.set(t, deletedTenant("ten_old", "old", now.Add(-48*time.Hour)))
+
+	pub := &mockPublisher{}
+	svc := app.NewTenantService(repo, pub, &mockValidator{})
+
+	report, err := app.NewPurgeService(repo, svc, 24*time.Hour).Purge(context.Background(), now, true)
+	if err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if !report.DryRun || len(report.Items) != 1 {
+		t.Errorf("report = %+v, want ten_old reported in a dry run", report)
+	}
+	if repo.len() != 1 || len(pub.events) != 0 {
+		t.Errorf("%d tenants left and %d events published, want nothing changed", repo.len(), len(pub.events))
+	}
+}
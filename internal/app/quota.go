@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// Compile-time check: PlanQuotaChecker implements domain.QuotaChecker.
+var _ domain.QuotaChecker = (*PlanQuotaChecker)(nil)
+
+// PlanQuotaChecker enforces the limits of the defined plans against the
+// usage reported by metering.
+type PlanQuotaChecker struct {
+	plans domain.PlanRepository
+	usage domain.UsageRepository
+}
+
+// NewPlanQuotaChecker creates a checker reading limits from plans and
+// current consumption from usage.
+func NewPlanQuotaChecker(plans domain.PlanRepository, usage domain.UsageRepository) *PlanQuotaChecker {
+	return &PlanQuotaChecker{plans: plans, usage: usage}
+}
+
+// Check returns a *domain.QuotaExceededError when the tenant's reported
+// usage of metric plus amount is over its plan's limit. A tenant on a plan
+// that is not defined is not limited.
+func (c *PlanQuotaChecker) Check(ctx context.Context, tenant domain.Tenant, metric string, amount int64) error {
+	plan, err := c.plans.Get(ctx, tenant.Plan)
+	if errors.Is(err, domain.ErrPlanNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("getting plan: %w", err)
+	}
+	limit, ok := plan.Limit(metric)
+	if !ok {
+		return nil
+	}
+
+	usage, err := c.usage.Get(ctx, tenant.ID)
+	if err != nil {
+		return fmt.Errorf("getting usage: %w", err)
+	}
+	if used := usage[metric]; used+amount > limit {
+		return &domain.QuotaExceededError{
+			TenantID:  tenant.ID,
+			Plan:      plan.Name,
+			Metric:    metric,
+			Limit:     limit,
+			Used:      used,
+			Requested: amount,
+		}
+	}
+	return nil
+}
+
+// WithQuotaChecker enforces plan quotas with q before the operations that
+// consume them (see CheckQuota).
+func WithQuotaChecker(q domain.QuotaChecker) Option {
+	return func(s *TenantService) {
+		s.quotas = q
+	}
+}
+
+// QuotasEnabled reports whether plan quotas are enforced.
+func (s *TenantService) QuotasEnabled() bool {
+	return s.quotas != nil
+}
+
+// CheckQuota returns a *domain.QuotaExceededError when the tenant may not
+// consume amount more of metric on its plan. It is the hook quota-consuming
+// operations call first, here or in the services that own the resources.
+// Without a quota checker everything is allowed.
+func (s *TenantService) CheckQuota(ctx context.Context, id, metric string, amount int64) error {
+	tenant, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.checkQuota(ctx, tenant, metric, amount)
+}
+
+// checkQuota consults the quota checker, if any.
+func (s *TenantService) checkQuota(ctx context.Context, tenant domain.Tenant, metric string, amount int64) error {
+	if s.quotas == nil {
+		return nil
+	}
+	return s.quotas.Check(ctx, tenant, metric, amount)
+}
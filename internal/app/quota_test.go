@@ -0,0 +1,62 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestCheckQuota_EnforcesPlanLimits(t *testing.T) {
+	repo := newMockRepo()
+	repo.set(t, domain.NewTenant("ten_1", "Acme", "acme", "free"))
+
+	plans := newMockPlans()
+	plans.plans["free"] = domain.Plan{Name: "free", Currency: "USD", Limits: map[string]int64{
+		domain.MetricProjects: 3,
+		domain.MetricAPICalls: 1000,
+	}}
+	usage := &mockUsage{usage: map[string]domain.Usage{"ten_1": {domain.MetricProjects: 2, domain.MetricAPICalls: 1000}}}
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithQuotaChecker(app.NewPlanQuotaChecker(plans, usage)))
+	ctx := context.Background()
+
+	if err := svc.CheckQuota(ctx, "ten_1", domain.MetricProjects, 1); err != nil {
+		t.Errorf("third project: %v, want allowed", err)
+	}
+	if err := svc.CheckQuota(ctx, "ten_1", domain.MetricSeats, 100); err != nil {
+		t.Errorf("seats: %v, want allowed as the plan does not limit them", err)
+	}
+
+	var quotaErr *domain.QuotaExceededError
+	err := svc.CheckQuota(ctx, "ten_1", domain.MetricProjects, 2)
+	if !errors.As(err, &quotaErr) || quotaErr.Limit != 3 || quotaErr.Used != 2 || quotaErr.Periodic() {
+		t.Errorf("two more projects: %v, want a non-periodic QuotaExceededError at 3", err)
+	}
+	err = svc.CheckQuota(ctx, "ten_1", domain.MetricAPICalls, 1)
+	if !errors.As(err, &quotaErr) || !quotaErr.Periodic() {
+		t.Errorf("one more API call: %v, want a periodic QuotaExceededError", err)
+	}
+}
+
+func TestCheckQuota_UndefinedPlanOrNoCheckerAllows(t *testing.T) {
+	repo := newMockRepo()
+	repo.set(t, domain.NewTenant("ten_1", "Acme", "acme", "legacy"))
+	ctx := context.Background()
+
+	checked := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithQuotaChecker(app.NewPlanQuotaChecker(newMockPlans(), &mockUsage{usage: map[string]domain.Usage{}})))
+	if err := checked.CheckQuota(ctx, "ten_1", domain.MetricSeats, 1); err != nil {
+		t.Errorf("undefined plan: %v, want allowed", err)
+	}
+
+	unchecked := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{})
+	if unchecked.QuotasEnabled() {
+		t.Error("QuotasEnabled without a quota checker")
+	}
+	if err := unchecked.CheckQuota(ctx, "missing", domain.MetricSeats, 1); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("missing tenant: %v, want ErrTenantNotFound", err)
+	}
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithRateLimits enables per-tenant rate limits for API gateways: each
+// tenant gets the rate limit of its plan unless an override is stored in
+// overrides. The plan's limit is the one defined with the plan (see
+// WithPlanValidation), or else the one in catalog.
+func WithRateLimits(catalog domain.PlanCatalog, overrides domain.RateLimitRepository) Option {
+	return func(s *TenantService) {
+		s.plans = catalog
+		s.rateLimits = overrides
+	}
+}
+
+// RateLimitsEnabled reports whether per-tenant rate limits are configured.
+func (s *TenantService) RateLimitsEnabled() bool {
+	return s.rateLimits != nil
+}
+
+// RateLimit returns the rate limit in effect for a tenant, or false when
+// the tenant is not rate limited.
+func (s *TenantService) RateLimit(ctx context.Context, tenant domain.Tenant) (domain.TenantRateLimit, bool, error) {
+	override, err := s.rateLimits.Get(ctx, tenant.ID)
+	if err != nil {
+		return domain.TenantRateLimit{}, false, fmt.Errorf("getting rate limit: %w", err)
+	}
+	plan, err := s.planRateLimit(ctx, tenant.Plan)
+	if err != nil {
+		return domain.TenantRateLimit{}, false, err
+	}
+	limit, ok := domain.EffectiveRateLimit(tenant, plan, override)
+	return limit, ok, nil
+}
+
+// SetRateLimit overrides the rate limit of a tenant's plan.
+func (s *TenantService) SetRateLimit(ctx context.Context, id string, l domain.RateLimit) (domain.TenantRateLimit, error) {
+	if err := l.Validate(); err != nil {
+		return domain.TenantRateLimit{}, &domain.InvalidRateLimitError{Reason: err.Error()}
+	}
+	tenant, err := s.GetByID(ctx, id)
+	if err != nil {
+		return domain.TenantRateLimit{}, err
+	}
+	if err := s.rateLimits.Set(ctx, id, l); err != nil {
+		return domain.TenantRateLimit{}, fmt.Errorf("setting rate limit: %w", err)
+	}
+	limit, _ := domain.EffectiveRateLimit(tenant, domain.RateLimit{}, l)
+	return limit, nil
+}
+
+// ClearRateLimit removes a tenant's override, so the limit of its plan
+// applies again.
+func (s *TenantService) ClearRateLimit(ctx context.Context, id string) error {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return err
+	}
+	if err := s.rateLimits.Delete(ctx, id); err != nil {
+		return fmt.Errorf("deleting rate limit: %w", err)
+	}
+	return nil
+}
+
+// RateLimits returns the rate limit of every rate-limited active tenant,
+// ordered by slug, for gateways to enforce without calling back per request.
+func (s *TenantService) RateLimits(ctx context.Context) ([]domain.TenantRateLimit, error) {
+	tenants, err := s.repo.List(ctx, domain.ListFilter{Statuses: []domain.Status{domain.StatusActive}})
+	if err != nil {
+		return nil, fmt.Errorf("listing tenants: %w", err)
+	}
+	overrides, err := s.rateLimits.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing rate limits: %w", err)
+	}
+	defined := map[string]domain.RateLimit{}
+	if s.planRegistry != nil {
+		plans, err := s.planRegistry.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing plans: %w", err)
+		}
+		for _, p := range plans {
+			defined[p.Name] = p.RateLimit
+		}
+	}
+
+	limits := make([]domain.TenantRateLimit, 0, len(tenants))
+	for _, t := range tenants {
+		plan := cmp.Or(defined[t.Plan], s.plans.RateLimit(t.Plan))
+		if l, ok := domain.EffectiveRateLimit(t, plan, overrides[t.ID]); ok {
+			limits = append(limits, l)
+		}
+	}
+	slices.SortFunc(limits, func(a, b domain.TenantRateLimit) int { return cmp.Compare(a.Slug, b.Slug) })
+	return limits, nil
+}
+
+// planRateLimit returns the rate limit of plan: the one it was defined
+// with, or else the one of the catalog.
+func (s *TenantService) planRateLimit(ctx context.Context, plan string) (domain.RateLimit, error) {
+	if s.planRegistry != nil {
+		p, err := s.planRegistry.Get(ctx, plan)
+		if err == nil && !p.RateLimit.IsZero() {
+			return p.RateLimit, nil
+		}
+		if err != nil && !errors.Is(err, domain.ErrPlanNotFound) {
+			return domain.RateLimit{}, fmt.Errorf("getting plan: %w", err)
+		}
+	}
+	return s.plans.RateLimit(plan), nil
+}
@@ -0,0 +1,93 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+type mockRateLimits struct {
+	limits map[string]domain.RateLimit
+}
+
+func (m *mockRateLimits) Get(_ context.Context, tenantID string) (domain.RateLimit, error) {
+	return m.limits[tenantID], nil
+}
+
+func (m *mockRateLimits) Set(_ context.Context, tenantID string, l domain.RateLimit) error {
+	m.limits[tenantID] = l
+	return nil
+}
+
+func (m *mockRateLimits) Delete(_ context.Context, tenantID string) error {
+	delete(m.limits, tenantID)
+	return nil
+}
+
+func (m *mockRateLimits) All(context.Context) (map[string]domain.RateLimit, error) {
+	return m.limits, nil
+}
+
+var testRateLimitCatalog = domain.PlanCatalog{
+	{Plan: "free", RateLimit: domain.RateLimit{RequestsPerSecond: 10, Burst: 10}},
+	{Plan: "enterprise"},
+}
+
+func newRateLimitService() (*app.TenantService, *mockRepo) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithRateLimits(testRateLimitCatalog, &mockRateLimits{limits: map[string]domain.RateLimit{}}))
+	return svc, repo
+}
+
+func TestRateLimits_SnapshotOfActiveTenants(t *testing.T) {
+	svc, repo := newRateLimitService()
+	ctx := context.Background()
+	newActiveTenant(t, repo, "zeta", "free")
+	newActiveTenant(t, repo, "alpha", "enterprise")
+	newActiveTenant(t, repo, "beta", "free")
+	repo.set(t, domain.NewTenant("creating", "Creating", "creating", "free"))
+
+	override := domain.RateLimit{RequestsPerSecond: 5, Burst: 20}
+	if _, err := svc.SetRateLimit(ctx, "alpha", override); err != nil {
+		t.Fatalf("SetRateLimit: %v", err)
+	}
+
+	limits, err := svc.RateLimits(ctx)
+	if err != nil {
+		t.Fatalf("RateLimits: %v", err)
+	}
+	var slugs []string
+	for _, l := range limits {
+		slugs = append(slugs, l.Slug)
+	}
+	if len(limits) != 3 || slugs[0] != "alpha" || slugs[1] != "beta" || slugs[2] != "zeta" {
+		t.Fatalf("snapshot slugs = %v, want [alpha beta zeta]", slugs)
+	}
+	if limits[0].Limit != override || limits[0].Source != domain.RateLimitOverride {
+		t.Errorf("alpha = %+v, want the override", limits[0])
+	}
+
+	if err := svc.ClearRateLimit(ctx, "alpha"); err != nil {
+		t.Fatalf("ClearRateLimit: %v", err)
+	}
+	if _, ok, _ := svc.RateLimit(ctx, repo.get("alpha")); ok {
+		t.Error("alpha should be unlimited on its plan once the override is cleared")
+	}
+}
+
+func TestSetRateLimit_Invalid(t *testing.T) {
+	svc, repo := newRateLimitService()
+	newActiveTenant(t, repo, "ten_1", "free")
+
+	var invalid *domain.InvalidRateLimitError
+	if _, err := svc.SetRateLimit(context.Background(), "ten_1", domain.RateLimit{RequestsPerSecond: 10}); !errors.As(err, &invalid) {
+		t.Errorf("expected InvalidRateLimitError, got %v", err)
+	}
+	if _, err := svc.SetRateLimit(context.Background(), "missing", domain.RateLimit{RequestsPerSecond: 1, Burst: 1}); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("expected ErrTenantNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// ReadOnlySwitch holds the read-only mode. While it is enabled the API
+// rejects changes and jobs are paused, so the database can be migrated or
+// failed over without writes. The mode is stored in the database and read
+// on every check, and the pause of the jobs is shared by every worker of
+// the queue, so switching it on one instance switches all of them.
+type ReadOnlySwitch struct {
+	repo domain.ReadOnlyRepository
+	jobs domain.JobPauser
+
+	// mu serializes the switches of this instance.
+	mu sync.Mutex
+}
+
+// NewReadOnlySwitch creates a switch storing the mode in repo and pausing
+// jobs when enabled; jobs may be nil when the instance runs none.
+func NewReadOnlySwitch(repo domain.ReadOnlyRepository, jobs domain.JobPauser) *ReadOnlySwitch {
+	return &ReadOnlySwitch{repo: repo, jobs: jobs}
+}
+
+// State returns the current mode.
+func (s *ReadOnlySwitch) State(ctx context.Context) (domain.ReadOnlyState, error) {
+	state, err := s.repo.Get(ctx)
+	if err != nil {
+		return domain.ReadOnlyState{}, fmt.Errorf("getting read-only mode: %w", err)
+	}
+	return state, nil
+}
+
+// Set switches the mode on or off, attributed to the actor of ctx, who must
+// be an admin. Jobs are paused before the mode is enabled and resumed
+// before it is disabled, even when the mode does not change, so a pause
+// left over by a failed switch can be cleared; if that fails the mode is
+// left as it was.
+func (s *ReadOnlySwitch) Set(ctx context.Context, enabled bool, reason string) (domain.ReadOnlyState, error) {
+	if err := domain.RequireRole(ctx, domain.RoleAdmin, "switching the read-only mode"); err != nil {
+		return domain.ReadOnlyState{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.State(ctx)
+	if err != nil {
+		return domain.ReadOnlyState{}, err
+	}
+	if s.jobs != nil {
+		if enabled {
+			if err := s.jobs.Pause(ctx); err != nil {
+				return current, fmt.Errorf("pausing jobs: %w", err)
+			}
+		} else if err := s.jobs.Resume(ctx); err != nil {
+			return current, fmt.Errorf("resuming jobs: %w", err)
+		}
+	}
+
+	if !enabled {
+		reason = ""
+	}
+	if enabled == current.Enabled && reason == current.Reason {
+		return current, nil
+	}
+	state := domain.ReadOnlyState{
+		Enabled: enabled,
+		Reason:  reason,
+		Since:   time.Now().UTC().Truncate(time.Second),
+		Actor:   domain.ActorFromContext(ctx),
+	}
+	if err := s.repo.Set(ctx, state); err != nil {
+		return current, fmt.Errorf("setting read-only mode: %w", err)
+	}
+	slog.InfoContext(ctx, "read-only mode switched",
+		"enabled", enabled, "reason", reason, "actor", state.Actor)
+	return state, nil
+}
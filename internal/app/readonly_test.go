@@ -0,0 +1,124 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+type recordingPauser struct {
+	calls []string
+	err   error
+}
+
+func (p *recordingPauser) Pause(context.Context) error {
+	p.calls = append(p.calls, "pause")
+	return p.err
+}
+
+func (p *recordingPauser) Resume(context.Context) error {
+	p.calls = append(p.calls, "resume")
+	return p.err
+}
+
+// memReadOnly stores the mode in memory, as one database shared by
+// switches.
+type memReadOnly struct {
+	state domain.ReadOnlyState
+}
+
+func (r *memReadOnly) Get(context.Context) (domain.ReadOnlyState, error) { return r.state, nil }
+
+func (r *memReadOnly) Set(_ context.Context, s domain.ReadOnlyState) error {
+	r.state = s
+	return nil
+}
+
+func enabled(t *testing.T, sw *app.ReadOnlySwitch) bool {
+	t.Helper()
+	state, err := sw.State(context.Background())
+	if err != nil {
+		t.Fatalf("State: %v", err)
+	}
+	return state.Enabled
+}
+
+func TestReadOnlySwitch(t *testing.T) {
+	jobs := &recordingPauser{}
+	sw := app.NewReadOnlySwitch(&memReadOnly{}, jobs)
+	ctx := domain.WithActor(context.Background(), "ops@example.com")
+
+	if enabled(t, sw) {
+		t.Fatal("a new switch must be disabled")
+	}
+
+	state, err := sw.Set(ctx, true, "failover")
+	if err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if !enabled(t, sw) || state.Reason != "failover" || state.Actor != "ops@example.com" || state.Since.IsZero() {
+		t.Errorf("state = %+v, want enabled by ops@example.com for the failover", state)
+	}
+
+	state, err = sw.Set(ctx, false, "ignored")
+	if err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if enabled(t, sw) || state.Reason != "" {
+		t.Errorf("state = %+v, want disabled without a reason", state)
+	}
+	if got := jobs.calls; len(got) != 2 || got[0] != "pause" || got[1] != "resume" {
+		t.Errorf("job calls = %v, want [pause resume]", got)
+	}
+}
+
+func TestReadOnlySwitch_KeepsModeWhenJobsFail(t *testing.T) {
+	jobs := &recordingPauser{err: errors.New("database locked")}
+	sw := app.NewReadOnlySwitch(&memReadOnly{}, jobs)
+
+	if _, err := sw.Set(context.Background(), true, "migration"); err == nil {
+		t.Fatal("expected an error when the jobs cannot be paused")
+	}
+	if enabled(t, sw) {
+		t.Error("the mode must stay disabled while jobs still run")
+	}
+}
+
+func TestReadOnlySwitch_WithoutJobs(t *testing.T) {
+	sw := app.NewReadOnlySwitch(&memReadOnly{}, nil)
+	if _, err := sw.Set(context.Background(), true, ""); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got, _ := sw.State(context.Background()); !got.Enabled || got.Actor != domain.SystemActor {
+		t.Errorf("state = %+v, want enabled by the system", got)
+	}
+}
+
+func TestReadOnlySwitch_RequiresAdmin(t *testing.T) {
+	jobs := &recordingPauser{}
+	sw := app.NewReadOnlySwitch(&memReadOnly{}, jobs)
+	ctx := domain.WithRole(context.Background(), domain.RoleOperator)
+
+	var forbidden *domain.ForbiddenError
+	if _, err := sw.Set(ctx, true, "failover"); !errors.As(err, &forbidden) {
+		t.Fatalf("Set = %v, want a ForbiddenError", err)
+	}
+	if enabled(t, sw) || len(jobs.calls) != 0 {
+		t.Errorf("enabled = %v, job calls = %v; want nothing switched", enabled(t, sw), jobs.calls)
+	}
+}
+
+func TestReadOnlySwitch_SharedByInstances(t *testing.T) {
+	repo := &memReadOnly{}
+	first, second := app.NewReadOnlySwitch(repo, nil), app.NewReadOnlySwitch(repo, nil)
+
+	if _, err := first.Set(context.Background(), true, "failover"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if !enabled(t, second) {
+		t.Error("the mode switched on one instance must apply to the others")
+	}
+}
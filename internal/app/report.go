@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// ReportsEnabled reports whether aggregate reports can be computed; they
+// are built from the status history (see WithStatusHistory).
+func (s *TenantService) ReportsEnabled() bool {
+	return s.history != nil
+}
+
+// GrowthReport counts new, churned and suspended tenants and the active
+// tenants per period from from to to. Periods before the status history
+// was recorded are incomplete.
+func (s *TenantService) GrowthReport(ctx context.Context, period domain.ReportPeriod, from, to time.Time) ([]domain.GrowthPeriod, error) {
+	if err := period.Validate(); err != nil {
+		return nil, err
+	}
+	start := period.Truncate(from)
+
+	tenants, err := s.repo.List(ctx, domain.ListFilter{CreatedAfter: start})
+	if err != nil {
+		return nil, fmt.Errorf("listing tenants: %w", err)
+	}
+	created := make([]time.Time, len(tenants))
+	for i, t := range tenants {
+		created[i] = t.CreatedAt
+	}
+
+	changes, err := s.history.ListSince(ctx, start)
+	if err != nil {
+		return nil, fmt.Errorf("listing status history: %w", err)
+	}
+
+	active := domain.StatusActive
+	n, err := s.repo.Count(ctx, domain.ListFilter{Status: &active})
+	if err != nil {
+		return nil, fmt.Errorf("counting active tenants: %w", err)
+	}
+
+	return domain.Growth(period, from, to, created, changes, n), nil
+}
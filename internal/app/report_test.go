@@ -0,0 +1,49 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestGrowthReport(t *testing.T) {
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{}, app.WithStatusHistory(&mockHistory{}))
+	ctx := context.Background()
+	from := time.Now().UTC()
+
+	var ids []string
+	for _, slug := range []string{"acme", "globex"} {
+		tenant, err := svc.Create(ctx, app.CreateInput{Name: slug, Slug: slug, Plan: "pro"})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+			t.Fatalf("activate: %v", err)
+		}
+		ids = append(ids, tenant.ID)
+	}
+	if _, err := svc.Transition(ctx, ids[0], domain.EventSuspend); err != nil {
+		t.Fatalf("suspend: %v", err)
+	}
+
+	periods, err := svc.GrowthReport(ctx, domain.PeriodDay, from, time.Now().UTC().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("GrowthReport: %v", err)
+	}
+	// Usually today only, two days when run across midnight.
+	var total domain.GrowthPeriod
+	for _, p := range periods {
+		total.New += p.New
+		total.Suspended += p.Suspended
+		total.Net += p.Net
+	}
+	if total.New != 2 || total.Suspended != 1 || total.Net != 1 {
+		t.Errorf("totals = %+v, want 2 new, 1 suspended and net 1", total)
+	}
+	if last := periods[len(periods)-1]; last.Active != 1 {
+		t.Errorf("active = %d, want 1", last.Active)
+	}
+}
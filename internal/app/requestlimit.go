@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// ThrottleObserver is told of every request a RequestLimiter refuses, e.g.
+// to record metrics.
+type ThrottleObserver func(ctx context.Context, limit domain.TenantRateLimit, operation string)
+
+// maxLimitedTenants bounds the tenants a RequestLimiter tracks before it
+// forgets the idle ones.
+const maxLimitedTenants = 10000
+
+// RequestLimiter holds the tenants' own requests to tenantiq, those made
+// with their API keys, to their rate limits (see TenantService.RateLimit):
+// each tenant has a token bucket refilled at its limit's rate. Buckets are
+// kept in memory, so each replica allows the full rate.
+type RequestLimiter struct {
+	svc      *TenantService
+	observer ThrottleObserver
+
+	mu      sync.Mutex
+	buckets map[string]*requestBucket
+}
+
+// requestBucket is the token bucket of a tenant, with the limit it was
+// last refilled at.
+type requestBucket struct {
+	limit    domain.RateLimit
+	tokens   float64
+	refilled time.Time
+}
+
+// NewRequestLimiter creates a limiter enforcing the rate limits of svc,
+// which requires WithRateLimits. observer, when not nil, is told of the
+// refused requests.
+func NewRequestLimiter(svc *TenantService, observer ThrottleObserver) *RequestLimiter {
+	return &RequestLimiter{svc: svc, observer: observer, buckets: make(map[string]*requestBucket)}
+}
+
+// Allow spends a request of the tenant's budget on operation. Over budget,
+// it returns a *domain.RateLimitedError telling when to retry. Tenants
+// without a rate limit always pass.
+func (l *RequestLimiter) Allow(ctx context.Context, tenant domain.Tenant, operation string) error {
+	limit, ok, err := l.svc.RateLimit(ctx, tenant)
+	if err != nil || !ok {
+		return err
+	}
+	wait := l.take(tenant.ID, limit.Limit, time.Now())
+	if wait == 0 {
+		return nil
+	}
+	if l.observer != nil {
+		l.observer(ctx, limit, operation)
+	}
+	return &domain.RateLimitedError{TenantID: tenant.ID, Limit: limit.Limit, RetryAfter: wait}
+}
+
+// take spends a token of the tenant's bucket, or returns how long until
+// one is available, rounded up to the second.
+func (l *RequestLimiter) take(tenantID string, limit domain.RateLimit, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[tenantID]
+	if !ok {
+		l.forgetIdle(now)
+		b = &requestBucket{tokens: float64(limit.Burst), refilled: now}
+		l.buckets[tenantID] = b
+	}
+	rate := float64(limit.RequestsPerSecond)
+	b.tokens = min(float64(limit.Burst), b.tokens+now.Sub(b.refilled).Seconds()*rate)
+	b.limit, b.refilled = limit, now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration(math.Ceil((1-b.tokens)/rate)) * time.Second
+}
+
+// forgetIdle drops, when too many tenants are tracked, the buckets that
+// would be full again: a new one starts full.
+func (l *RequestLimiter) forgetIdle(now time.Time) {
+	if len(l.buckets) < maxLimitedTenants {
+		return
+	}
+	for id, b := range l.buckets {
+		if b.tokens+now.Sub(b.refilled).Seconds()*float64(b.limit.RequestsPerSecond) >= float64(b.limit.Burst) {
+			delete(l.buckets, id)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestRequestLimiter_HoldsTenantsToTheirPlan(t *testing.T) {
+	repo := newMockRepo()
+	plans := newMockPlans()
+	plans.plans["pro"] = domain.Plan{Name: "pro", Currency: "USD", RateLimit: domain.RateLimit{RequestsPerSecond: 1, Burst: 3}}
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithPlanValidation(app.NewPlanService(plans, repo)),
+		app.WithRateLimits(testRateLimitCatalog, &mockRateLimits{limits: map[string]domain.RateLimit{}}))
+	newActiveTenant(t, repo, "acme", "pro")
+	newActiveTenant(t, repo, "beta", "enterprise")
+	ctx := context.Background()
+
+	var throttled []string
+	limiter := app.NewRequestLimiter(svc, func(_ context.Context, limit domain.TenantRateLimit, operation string) {
+		throttled = append(throttled, limit.Plan+"/"+operation)
+	})
+	acme, _ := repo.GetByID(ctx, "acme")
+
+	// The limit defined with the plan wins over the catalog's.
+	for i := range 3 {
+		if err := limiter.Allow(ctx, acme, "verify-api-key"); err != nil {
+			t.Fatalf("request %d within the burst: %v", i+1, err)
+		}
+	}
+	var limited *domain.RateLimitedError
+	if err := limiter.Allow(ctx, acme, "verify-api-key"); !errors.As(err, &limited) {
+		t.Fatalf("request over the burst: err = %v, want *RateLimitedError", err)
+	}
+	if limited.RetryAfter != time.Second || limited.Limit.Burst != 3 {
+		t.Errorf("RateLimitedError = %+v, want the pro limit and a retry in 1s", limited)
+	}
+	if len(throttled) != 1 || throttled[0] != "pro/verify-api-key" {
+		t.Errorf("observed %v, want the refused request", throttled)
+	}
+
+	// Tenants whose plan has no limit are not limited.
+	beta, _ := repo.GetByID(ctx, "beta")
+	for range 20 {
+		if err := limiter.Allow(ctx, beta, "verify-api-key"); err != nil {
+			t.Fatalf("unlimited tenant: %v", err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// ResellerService backs the delegated admin API: a reseller manages only
+// its own tenants. Every tenant lookup is scoped by the reseller in the
+// repository query, so another reseller's tenant is reported as not found
+// rather than fetched and then refused.
+type ResellerService struct {
+	resellers domain.ResellerRepository
+	tenants   *TenantService
+	ids       IDGenerator
+}
+
+// NewResellerService creates a reseller service that manages tenants through svc.
+func NewResellerService(resellers domain.ResellerRepository, svc *TenantService) *ResellerService {
+	return &ResellerService{
+		resellers: resellers,
+		tenants:   svc,
+		ids:       NewIDGenerator(ResellerIDPrefix),
+	}
+}
+
+// Create registers a reseller allowed to manage up to quota tenants.
+func (s *ResellerService) Create(ctx context.Context, name string, quota int) (domain.Reseller, error) {
+	id, err := s.ids.New()
+	if err != nil {
+		return domain.Reseller{}, fmt.Errorf("generating reseller id: %w", err)
+	}
+
+	reseller := domain.NewReseller(id, NormalizeName(name), quota)
+	if err := s.resellers.Create(ctx, reseller); err != nil {
+		return domain.Reseller{}, fmt.Errorf("creating reseller: %w", err)
+	}
+	return reseller, nil
+}
+
+// Get returns a reseller by its identifier.
+func (s *ResellerService) Get(ctx context.Context, id string) (domain.Reseller, error) {
+	return s.resellers.GetByID(ctx, id)
+}
+
+// CreateTenant creates a tenant managed by the reseller, provided the
+// reseller is below its quota. The quota check and the insert are not
+// atomic; concurrent creations may briefly exceed it by a few tenants.
+func (s *ResellerService) CreateTenant(ctx context.Context, resellerID, name, slug, plan string) (domain.Tenant, error) {
+	reseller, err := s.resellers.GetByID(ctx, resellerID)
+	if err != nil {
+		return domain.Tenant{}, err
+	}
+
+	used, err := s.tenants.repo.Count(ctx, domain.ListFilter{ResellerID: resellerID, Statuses: domain.QuotaStatuses()})
+	if err != nil {
+		return domain.Tenant{}, fmt.Errorf("counting reseller tenants: %w", err)
+	}
+	if used >= reseller.TenantQuota {
+		return domain.Tenant{}, &domain.QuotaExceededError{ResellerID: resellerID, Quota: reseller.TenantQuota}
+	}
+
+	return s.tenants.create(ctx, CreateInput{Name: name, Slug: slug, Plan: plan}, resellerID, domain.EventProvisionComplete)
+}
+
+// Tenants lists the reseller's tenants matching filter, with the total
+// count ignoring pagination.
+func (s *ResellerService) Tenants(ctx context.Context, resellerID string, filter domain.ListFilter) ([]domain.Tenant, int, error) {
+	if _, err := s.resellers.GetByID(ctx, resellerID); err != nil {
+		return nil, 0, err
+	}
+
+	filter.ResellerID = resellerID
+	tenants, err := s.tenants.repo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.tenants.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return tenants, total, nil
+}
+
+// Tenant returns one of the reseller's tenants. Tenants of other resellers
+// and directly managed tenants are reported as ErrTenantNotFound.
+func (s *ResellerService) Tenant(ctx context.Context, resellerID, id string) (domain.Tenant, error) {
+	if err := s.tenants.ids.Check(id); err != nil {
+		return domain.Tenant{}, err
+	}
+	tenants, err := s.tenants.repo.List(ctx, domain.ListFilter{ResellerID: resellerID, IDs: []string{id}, Limit: 1})
+	if err != nil {
+		return domain.Tenant{}, err
+	}
+	if len(tenants) == 0 {
+		return domain.Tenant{}, domain.ErrTenantNotFound
+	}
+	return tenants[0], nil
+}
+
+// Suspend suspends one of the reseller's tenants.
+func (s *ResellerService) Suspend(ctx context.Context, resellerID, id string) (domain.Tenant, error) {
+	if _, err := s.Tenant(ctx, resellerID, id); err != nil {
+		return domain.Tenant{}, err
+	}
+	return s.tenants.Transition(ctx, id, domain.EventSuspend)
+}
+
+// Usage reports how much of its quota the reseller uses, by tenant status.
+func (s *ResellerService) Usage(ctx context.Context, resellerID string) (domain.ResellerUsage, error) {
+	reseller, err := s.resellers.GetByID(ctx, resellerID)
+	if err != nil {
+		return domain.ResellerUsage{}, err
+	}
+
+	usage := domain.ResellerUsage{Quota: reseller.TenantQuota, ByStatus: make(map[domain.Status]int)}
+	for _, st := range domain.QuotaStatuses() {
+		n, err := s.tenants.repo.Count(ctx, domain.ListFilter{ResellerID: resellerID, Statuses: []domain.Status{st}})
+		if err != nil {
+			return domain.ResellerUsage{}, fmt.Errorf("counting reseller tenants: %w", err)
+		}
+		usage.ByStatus[st] = n
+		usage.Tenants += n
+	}
+	return usage, nil
+}
@@ -0,0 +1,132 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockResellers keeps resellers in memory.
+type mockResellers struct {
+	resellers map[string]domain.Reseller
+}
+
+func (m *mockResellers) Create(_ context.Context, r domain.Reseller) error {
+	m.resellers[r.ID] = r
+	return nil
+}
+
+func (m *mockResellers) GetByID(_ context.Context, id string) (domain.Reseller, error) {
+	r, ok := m.resellers[id]
+	if !ok {
+		return domain.Reseller{}, domain.ErrResellerNotFound
+	}
+	return r, nil
+}
+
+func newResellerService(t *testing.T) (*app.ResellerService, *mockRepo) {
+	t.Helper()
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{})
+	return app.NewResellerService(&mockResellers{resellers: make(map[string]domain.Reseller)}, svc), repo
+}
+
+func TestReseller_CreateTenantWithinQuota(t *testing.T) {
+	rs, _ := newResellerService(t)
+	ctx := context.Background()
+
+	reseller, err := rs.Create(ctx, "Partner", 1)
+	if err != nil {
+		t.Fatalf("Create reseller: %v", err)
+	}
+
+	tenant, err := rs.CreateTenant(ctx, reseller.ID, "Acme", "acme", "pro")
+	if err != nil {
+		t.Fatalf("CreateTenant: %v", err)
+	}
+	if tenant.ResellerID != reseller.ID {
+		t.Errorf("ResellerID = %q, want %q", tenant.ResellerID, reseller.ID)
+	}
+
+	_, err = rs.CreateTenant(ctx, reseller.ID, "Globex", "globex", "pro")
+	var quotaErr *domain.QuotaExceededError
+	if !errors.As(err, &quotaErr) || quotaErr.Quota != 1 {
+		t.Fatalf("expected QuotaExceededError, got %v", err)
+	}
+}
+
+func TestReseller_CreateTenantUnknownReseller(t *testing.T) {
+	rs, _ := newResellerService(t)
+
+	_, err := rs.CreateTenant(context.Background(), "rsl_missing", "Acme", "acme", "pro")
+	if !errors.Is(err, domain.ErrResellerNotFound) {
+		t.Fatalf("expected ErrResellerNotFound, got %v", err)
+	}
+}
+
+func TestReseller_ScopesTenants(t *testing.T) {
+	rs, repo := newResellerService(t)
+	ctx := context.Background()
+
+	mine, _ := rs.Create(ctx, "Mine", 5)
+	other, _ := rs.Create(ctx, "Other", 5)
+	own, _ := rs.CreateTenant(ctx, mine.ID, "Acme", "acme", "pro")
+	foreign, _ := rs.CreateTenant(ctx, other.ID, "Globex", "globex", "pro")
+	repo.set(t, domain.NewTenant("ten_direct", "Direct", "direct", "pro"))
+
+	tenants, total, err := rs.Tenants(ctx, mine.ID, domain.ListFilter{})
+	if err != nil {
+		t.Fatalf("Tenants: %v", err)
+	}
+	if total != 1 || len(tenants) != 1 || tenants[0].ID != own.ID {
+		t.Errorf("Tenants = %v (total %d), want only %s", tenants, total, own.ID)
+	}
+
+	for _, id := range []string{foreign.ID, "ten_direct"} {
+		if _, err := rs.Tenant(ctx, mine.ID, id); !errors.Is(err, domain.ErrTenantNotFound) {
+			t.Errorf("Tenant(%s) = %v, want ErrTenantNotFound", id, err)
+		}
+		if _, err := rs.Suspend(ctx, mine.ID, id); !errors.Is(err, domain.ErrTenantNotFound) {
+			t.Errorf("Suspend(%s) = %v, want ErrTenantNotFound", id, err)
+		}
+	}
+}
+
+func TestReseller_SuspendAndUsage(t *testing.T) {
+	rs, repo := newResellerService(t)
+	ctx := context.Background()
+
+	reseller, _ := rs.Create(ctx, "Partner", 3)
+	a, _ := rs.CreateTenant(ctx, reseller.ID, "Acme", "acme", "pro")
+	if _, err := rs.CreateTenant(ctx, reseller.ID, "Globex", "globex", "pro"); err != nil {
+		t.Fatalf("CreateTenant: %v", err)
+	}
+
+	// Suspending a tenant that is still being created is not a valid transition.
+	var trErr *domain.TransitionError
+	if _, err := rs.Suspend(ctx, reseller.ID, a.ID); !errors.As(err, &trErr) {
+		t.Fatalf("expected TransitionError, got %v", err)
+	}
+
+	active := repo.get(a.ID)
+	active.Status = domain.StatusActive
+	repo.set(t, active)
+	suspended, err := rs.Suspend(ctx, reseller.ID, a.ID)
+	if err != nil {
+		t.Fatalf("Suspend: %v", err)
+	}
+	if suspended.Status != domain.StatusSuspended {
+		t.Errorf("status = %q, want %q", suspended.Status, domain.StatusSuspended)
+	}
+
+	usage, err := rs.Usage(ctx, reseller.ID)
+	if err != nil {
+		t.Fatalf("Usage: %v", err)
+	}
+	if usage.Quota != 3 || usage.Tenants != 2 || usage.ByStatus[domain.StatusCreating] != 1 || usage.ByStatus[domain.StatusSuspended] != 1 {
+		t.Errorf("usage = %+v, want 1 creating and 1 suspended tenant of 3", usage)
+	}
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// RetentionService prunes the records that outlived the retention policy,
+// so audit, history and job tables do not grow without bound.
+type RetentionService struct {
+	policy  domain.RetentionPolicy
+	pruners map[domain.RecordType]domain.Pruner
+}
+
+// NewRetentionService creates a retention service enforcing policy with
+// the pruner registered for each record type.
+func NewRetentionService(policy domain.RetentionPolicy, pruners map[domain.RecordType]domain.Pruner) *RetentionService {
+	return &RetentionService{policy: policy, pruners: pruners}
+}
+
+// RetentionItem is the outcome of pruning one record type.
+type RetentionItem struct {
+	Record domain.RecordType
+	// Before is the cutoff: records older than it expired.
+	Before time.Time
+	// Pruned is the number of expired records, deleted unless the run was
+	// a dry run.
+	Pruned int
+	Error  string
+}
+
+// RetentionReport summarizes a pruning run.
+type RetentionReport struct {
+	DryRun bool
+	Items  []RetentionItem
+}
+
+// Enforce prunes every record type with a retention at now. With dryRun
+// nothing is deleted and the report tells what would be. A record type
+// that fails is reported and pruned on the next run; it does not stop the
+// others.
+func (s *RetentionService) Enforce(ctx context.Context, now time.Time, dryRun bool) RetentionReport {
+	report := RetentionReport{DryRun: dryRun}
+	for _, record := range domain.RecordTypes() {
+		before, ok := s.policy.Cutoff(record, now)
+		if !ok {
+			continue
+		}
+
+		item := RetentionItem{Record: record, Before: before}
+		pruner, ok := s.pruners[record]
+		if !ok {
+			item.Error = fmt.Sprintf("no pruner for %q", record)
+			report.Items = append(report.Items, item)
+			continue
+		}
+
+		n, err := pruner.Prune(ctx, before, dryRun)
+		if err != nil {
+			item.Error = err.Error()
+		}
+		item.Pruned = n
+		report.Items = append(report.Items, item)
+	}
+	return report
+}
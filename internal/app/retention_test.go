@@ -0,0 +1,65 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockPruner records the cutoffs it was asked to prune.
+type mockPruner struct {
+	expired int
+	err     error
+	calls   []time.Time
+	dryRuns []bool
+}
+
+func (m *mockPruner) Prune(_ context.Context, before time.Time, dryRun bool) (int, error) {
+	m.calls = append(m.calls, before)
+	m.dryRuns = append(m.dryRuns, dryRun)
+	return m.expired, m.err
+}
+
+func TestRetention_Enforce(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	audit := &mockPruner{expired: 3}
+	events := &mockPruner{err: errors.New("database is locked")}
+	history := &mockPruner{}
+	svc := app.NewRetentionService(
+		domain.RetentionPolicy{
+			domain.RecordAudit:             365 * 24 * time.Hour,
+			domain.RecordEvents:            30 * 24 * time.Hour,
+			domain.RecordWebhookDeliveries: 24 * time.Hour,
+		},
+		map[domain.RecordType]domain.Pruner{
+			domain.RecordAudit:         audit,
+			domain.RecordEvents:        events,
+			domain.RecordStatusHistory: history,
+		},
+	)
+
+	report := svc.Enforce(context.Background(), now, true)
+
+	if !report.DryRun || len(report.Items) != 3 {
+		t.Fatalf("report = %+v, want a dry run with 3 items", report)
+	}
+	if got := report.Items[0]; got.Record != domain.RecordAudit || got.Pruned != 3 || !got.Before.Equal(now.Add(-365*24*time.Hour)) {
+		t.Errorf("audit item = %+v", got)
+	}
+	if got := report.Items[1]; got.Record != domain.RecordEvents || got.Error != "database is locked" {
+		t.Errorf("events item = %+v", got)
+	}
+	if got := report.Items[2]; got.Record != domain.RecordWebhookDeliveries || got.Error == "" {
+		t.Errorf("webhook deliveries item = %+v, want a missing pruner error", got)
+	}
+	if len(audit.dryRuns) != 1 || !audit.dryRuns[0] {
+		t.Errorf("audit pruner dry runs = %v, want [true]", audit.dryRuns)
+	}
+	if len(history.calls) != 0 {
+		t.Error("status history has no retention and should be kept")
+	}
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// PutSecret stores the tenant's secret name, sealed with the tenant's key,
+// replacing any previous value. The secret is returned without its value.
+func (s *EncryptionService) PutSecret(ctx context.Context, tenantID, name string, value []byte) (domain.TenantSecret, error) {
+	if err := domain.ValidateSecret(name, value); err != nil {
+		return domain.TenantSecret{}, err
+	}
+	if _, err := s.tenants.GetByID(ctx, tenantID); err != nil {
+		return domain.TenantSecret{}, err
+	}
+	sealed, err := s.Seal(ctx, tenantID, value, []byte(name))
+	if err != nil {
+		return domain.TenantSecret{}, err
+	}
+
+	now := time.Now().UTC()
+	secret, err := s.secrets.Get(ctx, tenantID, name)
+	if errors.Is(err, domain.ErrSecretNotFound) {
+		secret = domain.TenantSecret{TenantID: tenantID, Name: name, CreatedAt: now}
+	} else if err != nil {
+		return domain.TenantSecret{}, fmt.Errorf("getting secret: %w", err)
+	}
+	secret.Value, secret.UpdatedBy, secret.UpdatedAt = sealed, domain.ActorFromContext(ctx), now
+	if err := s.secrets.Put(ctx, secret); err != nil {
+		return domain.TenantSecret{}, fmt.Errorf("storing secret: %w", err)
+	}
+	return secret, nil
+}
+
+// Secret returns the tenant's secret name with its value. It fails with an
+// EncryptionKeyRevokedError while the tenant's key is revoked.
+func (s *EncryptionService) Secret(ctx context.Context, tenantID, name string) (domain.TenantSecret, []byte, error) {
+	if _, err := s.tenants.GetByID(ctx, tenantID); err != nil {
+		return domain.TenantSecret{}, nil, err
+	}
+	secret, err := s.secrets.Get(ctx, tenantID, name)
+	if err != nil {
+		return domain.TenantSecret{}, nil, err
+	}
+	value, err := s.Open(ctx, tenantID, secret.Value, []byte(name))
+	if err != nil {
+		return domain.TenantSecret{}, nil, err
+	}
+	return secret, value, nil
+}
+
+// Secrets returns the tenant's secrets by name, without their values:
+// they are listed even while its key is revoked.
+func (s *EncryptionService) Secrets(ctx context.Context, tenantID string) ([]domain.TenantSecret, error) {
+	if _, err := s.tenants.GetByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	return s.secrets.ListByTenant(ctx, tenantID)
+}
+
+// DeleteSecret removes the tenant's secret name, even while its key is
+// revoked.
+func (s *EncryptionService) DeleteSecret(ctx context.Context, tenantID, name string) error {
+	if _, err := s.tenants.GetByID(ctx, tenantID); err != nil {
+		return err
+	}
+	return s.secrets.Delete(ctx, tenantID, name)
+}
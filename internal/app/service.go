@@ -1,84 +1,666 @@
 package app
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/neomorfeo/tenantiq/internal/domain"
 )
 
 // TenantService orchestrates tenant lifecycle operations.
 type TenantService struct {
-	repo      domain.TenantRepository
-	publisher domain.EventPublisher
-	validator domain.TransitionValidator
+	repo        domain.TenantRepository
+	publisher   domain.EventPublisher
+	validator   domain.TransitionValidator
+	createHooks []domain.CreateHook
+	guardrail   domain.Guardrail
+	policies    domain.TransitionPolicies
+	ids         IDGenerator
+	history     domain.StatusHistoryRepository
+	auditLog    domain.AuditLogger
+	auditReader domain.AuditReader
+
+	// Tenant change feed (optional, see WithChangeFeed).
+	changes domain.ChangeFeed
+
+	// Usage-based plan suggestions (optional, see WithPlanSuggestions). The
+	// catalog also holds the plans' rate limits (see WithRateLimits).
+	plans domain.PlanCatalog
+	usage domain.UsageRepository
+
+	// Defined plans (optional, see WithPlanValidation).
+	planRegistry *PlanService
+
+	// Tenant blueprints (optional, see WithBlueprints).
+	blueprints *BlueprintService
+
+	// Certificates of the custom domains in specs (optional, see
+	// WithDomains).
+	domains domain.CertificateRepository
+
+	// Plan quota enforcement (optional, see WithQuotaChecker).
+	quotas domain.QuotaChecker
+
+	// Maintained per-status counts (optional, see WithStatusCounter).
+	counter domain.StatusCounter
+
+	// Fake provisioning of simulated tenants (optional, see WithSimulator).
+	simulator domain.Provisioner
+
+	// Asynchronous operations (optional, see WithAsyncOperations).
+	operations *OperationService
+	queue      domain.OperationQueue
+
+	// Idempotent steps of the operations (optional, see WithOperationSteps).
+	stepRunner *StepRunner
+	steps      map[domain.OperationKind][]domain.ProvisioningStep
+
+	// Per-tenant rate limit overrides (optional, see WithRateLimits).
+	rateLimits domain.RateLimitRepository
+
+	// Maintenance windows (optional, see WithMaintenanceWindows).
+	maintenance domain.MaintenanceRepository
+
+	// IP allowlists of tenant API keys (optional, see WithIPAllowlists).
+	allowlists domain.IPAllowlistRepository
+
+	// Transactional outbox (optional, see WithOutbox).
+	outbox   domain.Outbox
+	relay    *OutboxRelay
+	eventIDs IDGenerator
+
+	// Transactional event publishing (optional, see WithUnitOfWork).
+	uow domain.UnitOfWork
+
+	// Business metrics (optional, see WithTenantObserver).
+	observer TenantObserver
+
+	// Spans of the service operations (optional, see WithServiceTracer).
+	tracer ServiceTracer
+}
+
+// Option configures optional collaborators of a TenantService.
+type Option func(*TenantService)
+
+// WithCreateHooks registers hooks that run, in order, before a tenant is persisted.
+func WithCreateHooks(hooks ...domain.CreateHook) Option {
+	return func(s *TenantService) {
+		s.createHooks = append(s.createHooks, hooks...)
+	}
+}
+
+// WithGuardrail limits how many tenants a mass operation may suspend or delete.
+func WithGuardrail(g domain.Guardrail) Option {
+	return func(s *TenantService) {
+		s.guardrail = g
+	}
+}
+
+// WithTransitionPolicies restricts lifecycle events per plan. Policies are
+// checked after the validator accepts the transition.
+func WithTransitionPolicies(ps domain.TransitionPolicies) Option {
+	return func(s *TenantService) {
+		s.policies = ps
+	}
+}
+
+// WithIDGenerator overrides the tenant ID strategy (default: "ten_" prefix).
+func WithIDGenerator(g IDGenerator) Option {
+	return func(s *TenantService) {
+		s.ids = g
+	}
+}
+
+// WithStatusHistory records every lifecycle transition in h.
+func WithStatusHistory(h domain.StatusHistoryRepository) Option {
+	return func(s *TenantService) {
+		s.history = h
+	}
+}
+
+// WithAuditLogger records every tenant mutation in l.
+func WithAuditLogger(l domain.AuditLogger) Option {
+	return func(s *TenantService) {
+		s.auditLog = l
+	}
+}
+
+// WithAuditReader enables GetAsOf, which reconstructs past tenant states
+// from the audit trail written by WithAuditLogger.
+func WithAuditReader(r domain.AuditReader) Option {
+	return func(s *TenantService) {
+		s.auditReader = r
+	}
+}
+
+// WithPlanSuggestions enables usage reporting and SuggestPlans, which
+// matches each tenant's usage against the plans of catalog.
+func WithPlanSuggestions(catalog domain.PlanCatalog, usage domain.UsageRepository) Option {
+	return func(s *TenantService) {
+		s.plans = catalog
+		s.usage = usage
+	}
+}
+
+// WithAsyncOperations enables CreateAsync and DeleteAsync: the work is
+// queued and tracked by an operation instead of being reported as done.
+func WithAsyncOperations(ops *OperationService, queue domain.OperationQueue) Option {
+	return func(s *TenantService) {
+		s.operations = ops
+		s.queue = queue
+	}
 }
 
 // NewTenantService creates a service with the given adapters.
-func NewTenantService(repo domain.TenantRepository, publisher domain.EventPublisher, validator domain.TransitionValidator) *TenantService {
-	return &TenantService{
+func NewTenantService(repo domain.TenantRepository, publisher domain.EventPublisher, validator domain.TransitionValidator, opts ...Option) *TenantService {
+	s := &TenantService{
 		repo:      repo,
 		publisher: publisher,
 		validator: validator,
+		ids:       NewIDGenerator(DefaultTenantIDPrefix),
+		eventIDs:  NewIDGenerator(EventIDPrefix),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
+}
+
+// DefaultPlan is the plan of tenants created without a plan or blueprint.
+const DefaultPlan = "free"
+
+// CreateInput describes a tenant to create.
+type CreateInput struct {
+	Name string
+	// Slug is derived from Name when empty.
+	Slug string
+	// Plan defaults to the blueprint's when empty.
+	Plan     string
+	Metadata map[string]string
+	// Region, when set, is where the tenant is hosted (see Tenant.Region).
+	Region string
+	// Blueprint, when set, names the blueprint the tenant is created from
+	// (see WithBlueprints).
+	Blueprint string
+	// Simulated creates a simulated tenant (see WithSimulator).
+	Simulated bool
 }
 
 // Create persists a new tenant and publishes a creation event.
-func (s *TenantService) Create(ctx context.Context, name, slug, plan string) (domain.Tenant, error) {
+func (s *TenantService) Create(ctx context.Context, in CreateInput) (domain.Tenant, error) {
+	ctx, end := s.trace(ctx, "TenantService.Create", "tenant.slug", in.Slug, "tenant.plan", in.Plan)
+	tenant, err := s.create(ctx, in, "", domain.EventProvisionComplete)
+	end(err, "tenant.id", tenant.ID, "tenant.status", tenant.Status)
+	return tenant, err
+}
+
+// create normalizes the name, checks the slug (deriving it from the name
+// when empty), applies the blueprint, checks the region, the plan in that
+// region and the metadata, runs the create hooks and persists the tenant
+// in the "creating" state, managed by resellerID when set. The event, if
+// any, is published once the tenant is stored. A simulated tenant created
+// with EventProvisionComplete is provisioned and activated before
+// returning.
+func (s *TenantService) create(ctx context.Context, in CreateInput, resellerID string, event domain.Event) (domain.Tenant, error) {
+	if in.Simulated && s.simulator == nil {
+		return domain.Tenant{}, domain.ErrSimulationDisabled
+	}
+
+	name := NormalizeName(in.Name)
+	slug, err := resolveSlug(name, in.Slug)
+	if err != nil {
+		return domain.Tenant{}, err
+	}
+
 	// Check slug uniqueness before creating.
 	if _, err := s.repo.GetBySlug(ctx, slug); err == nil {
-		return domain.Tenant{}, &domain.SlugConflictError{Slug: slug}
+		return domain.Tenant{}, s.observeConflict(ctx, &domain.SlugConflictError{Slug: slug})
+	}
+	plan, metadata, err := s.applyBlueprint(ctx, in.Blueprint, in.Plan, in.Metadata)
+	if err != nil {
+		return domain.Tenant{}, err
+	}
+	if in.Region != "" {
+		if err := domain.ValidateRegion(in.Region); err != nil {
+			return domain.Tenant{}, err
+		}
+	}
+	if err := s.checkPlan(ctx, plan, in.Region); err != nil {
+		return domain.Tenant{}, err
+	}
+	if err := domain.ValidateMetadata(metadata); err != nil {
+		return domain.Tenant{}, err
 	}
 
-	id, err := generateID()
+	id, err := s.ids.New()
 	if err != nil {
 		return domain.Tenant{}, fmt.Errorf("generating tenant id: %w", err)
 	}
 
 	tenant := domain.NewTenant(id, name, slug, plan)
+	tenant.ResellerID = resellerID
+	tenant.Simulated = in.Simulated
+	tenant.Metadata = metadata
+	tenant.Region = in.Region
 
-	if err := s.repo.Create(ctx, tenant); err != nil {
-		return domain.Tenant{}, fmt.Errorf("creating tenant: %w", err)
+	for _, hook := range s.createHooks {
+		if err := hook.BeforeCreate(ctx, tenant); err != nil {
+			return domain.Tenant{}, &domain.HookRejectedError{Hook: hook.Name(), Reason: err.Error()}
+		}
 	}
 
-	if err := s.publisher.Publish(ctx, domain.EventProvisionComplete, tenant); err != nil {
-		return domain.Tenant{}, fmt.Errorf("publishing creation event: %w", err)
+	var events []domain.TenantEvent
+	if event != "" {
+		events = append(events, domain.TenantEvent{Event: event, Tenant: tenant})
+	}
+	if err := s.save(ctx, tenant, true, events...); err != nil {
+		// Another request may have taken the slug since it was checked.
+		return domain.Tenant{}, s.observeConflict(ctx, err)
+	}
+	s.observeCreated(ctx, tenant)
+
+	if err := s.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditCreate, nil, &tenant)); err != nil {
+		return domain.Tenant{}, err
 	}
 
+	if err := s.publish(ctx, tenant, events...); err != nil {
+		return domain.Tenant{}, fmt.Errorf("publishing creation event: %w", err)
+	}
+	if tenant.Simulated {
+		return s.completeSimulation(ctx, event, tenant)
+	}
 	return tenant, nil
 }
 
 // GetByID returns a tenant by its unique identifier.
 func (s *TenantService) GetByID(ctx context.Context, id string) (domain.Tenant, error) {
+	if err := s.ids.Check(id); err != nil {
+		return domain.Tenant{}, err
+	}
 	return s.repo.GetByID(ctx, id)
 }
 
+// GetBySlug returns a tenant by its URL-friendly identifier.
+func (s *TenantService) GetBySlug(ctx context.Context, slug string) (domain.Tenant, error) {
+	return s.repo.GetBySlug(ctx, slug)
+}
+
 // List returns tenants matching the given filter.
 func (s *TenantService) List(ctx context.Context, filter domain.ListFilter) ([]domain.Tenant, error) {
-	return s.repo.List(ctx, filter)
+	ctx, end := s.trace(ctx, "TenantService.List", "list.limit", filter.Limit, "list.offset", filter.Offset)
+	tenants, err := s.repo.List(ctx, filter)
+	end(err, "list.count", len(tenants))
+	return tenants, err
+}
+
+// Count returns how many tenants match the filter, ignoring pagination.
+func (s *TenantService) Count(ctx context.Context, filter domain.ListFilter) (int, error) {
+	return s.repo.Count(ctx, filter)
+}
+
+// Update applies a partial update to a tenant's mutable attributes.
+func (s *TenantService) Update(ctx context.Context, id string, patch domain.TenantPatch) (domain.Tenant, error) {
+	ctx, end := s.trace(ctx, "TenantService.Update", "tenant.id", id)
+	tenant, err := s.update(ctx, id, patch)
+	end(err, "tenant.plan", tenant.Plan, "tenant.status", tenant.Status)
+	return tenant, err
+}
+
+// update is Update, within its span.
+func (s *TenantService) update(ctx context.Context, id string, patch domain.TenantPatch) (domain.Tenant, error) {
+	tenant, err := s.GetByID(ctx, id)
+	if err != nil {
+		return domain.Tenant{}, err
+	}
+
+	if patch.Plan != nil && *patch.Plan != tenant.Plan {
+		if err := s.checkPlan(ctx, *patch.Plan, tenant.Region); err != nil {
+			return domain.Tenant{}, err
+		}
+	}
+
+	before := tenant
+	tenant = patch.Apply(tenant)
+	if len(patch.Metadata) > 0 {
+		if err := domain.ValidateMetadata(tenant.Metadata); err != nil {
+			return domain.Tenant{}, err
+		}
+	}
+	if len(patch.AddTags) > 0 {
+		if err := domain.ValidateTags(tenant.Tags); err != nil {
+			return domain.Tenant{}, err
+		}
+	}
+
+	tenant.UpdatedAt = time.Now().UTC()
+	events := changeEvents(before, tenant)
+	if err := s.save(ctx, tenant, false, events...); err != nil {
+		return domain.Tenant{}, err
+	}
+	tenant.Version++
+
+	if err := s.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditUpdate, &before, &tenant)); err != nil {
+		return domain.Tenant{}, err
+	}
+	if err := s.publish(ctx, tenant, events...); err != nil {
+		return domain.Tenant{}, err
+	}
+
+	return tenant, nil
 }
 
 // Transition applies a lifecycle event to a tenant, changing its state.
+// Deleting a simulated tenant also completes its deletion. Deleting takes
+// the admin role.
 func (s *TenantService) Transition(ctx context.Context, id string, event domain.Event) (domain.Tenant, error) {
-	tenant, err := s.repo.GetByID(ctx, id)
+	ctx, end := s.trace(ctx, "TenantService.Transition", "tenant.id", id, "tenant.event", event)
+	tenant, err := s.transition(ctx, id, event)
+	if err == nil && tenant.Simulated {
+		tenant, err = s.completeSimulation(ctx, event, tenant)
+	}
+	end(err, "tenant.plan", tenant.Plan, "tenant.status", tenant.Status)
+	return tenant, err
+}
+
+// transition applies a lifecycle event to a tenant, without answering
+// for the event consumers of simulated tenants.
+func (s *TenantService) transition(ctx context.Context, id string, event domain.Event) (domain.Tenant, error) {
+	if event == domain.EventDelete || event == domain.EventDeletionComplete {
+		if err := domain.RequireRole(ctx, domain.RoleAdmin, "deleting a tenant"); err != nil {
+			return domain.Tenant{}, err
+		}
+	}
+
+	tenant, err := s.GetByID(ctx, id)
 	if err != nil {
 		return domain.Tenant{}, err
 	}
 
-	newStatus, err := s.validator.Apply(ctx, tenant.Status, event)
+	newStatus, err := s.validator.Apply(ctx, tenant, event)
 	if err != nil {
 		return domain.Tenant{}, err
 	}
+	if err := s.policies.Check(ctx, tenant.Plan, event, newStatus); err != nil {
+		return domain.Tenant{}, err
+	}
+	if err := s.checkMaintenance(ctx, tenant.ID, event); err != nil {
+		return domain.Tenant{}, err
+	}
 
+	before := tenant
 	tenant.Status = newStatus
+	tenant.UpdatedAt = time.Now().UTC()
 
-	if err := s.repo.Update(ctx, tenant); err != nil {
-		return domain.Tenant{}, fmt.Errorf("updating tenant: %w", err)
+	if err := s.save(ctx, tenant, false, domain.TenantEvent{Event: event, Tenant: tenant}); err != nil {
+		return domain.Tenant{}, err
+	}
+	tenant.Version++ // as stored by the repository
+	if s.observer != nil {
+		s.observer.TenantTransitioned(ctx, before.Status, event, tenant)
 	}
 
-	if err := s.publisher.Publish(ctx, event, tenant); err != nil {
-		return domain.Tenant{}, fmt.Errorf("publishing event %q: %w", event, err)
+	if s.history != nil {
+		change := domain.StatusChange{
+			TenantID: tenant.ID,
+			From:     before.Status,
+			To:       newStatus,
+			Event:    event,
+			Actor:    domain.ActorFromContext(ctx),
+			At:       time.Now().UTC(),
+		}
+		if err := s.history.Record(ctx, change); err != nil {
+			return domain.Tenant{}, fmt.Errorf("recording status change: %w", err)
+		}
+	}
+
+	action := domain.AuditTransition
+	if event == domain.EventDelete {
+		action = domain.AuditDelete
+	}
+	entry := domain.NewAuditEntry(ctx, action, &before, &tenant)
+	entry.Event = event
+	if err := s.audit(ctx, entry); err != nil {
+		return domain.Tenant{}, err
+	}
+
+	if err := s.publish(ctx, tenant, domain.TenantEvent{Event: event, Tenant: tenant}); err != nil {
+		return domain.Tenant{}, err
 	}
 
 	return tenant, nil
 }
+
+// audit records a mutation when an audit logger is configured. The change
+// is already stored when this fails; the error tells the caller the trail
+// is incomplete.
+func (s *TenantService) audit(ctx context.Context, entry domain.AuditEntry) error {
+	if s.auditLog == nil {
+		return nil
+	}
+	if err := s.auditLog.Log(ctx, entry); err != nil {
+		return fmt.Errorf("writing audit entry: %w", err)
+	}
+	return nil
+}
+
+// changeEvents returns the change events of the attributes that differ
+// between before and after, each with its before/after diff, carrying
+// after as the repository stores it.
+func changeEvents(before, after domain.Tenant) []domain.TenantEvent {
+	after.Version++
+	var events []domain.TenantEvent
+	for _, e := range domain.ChangeEvents(domain.Diff(before, after)) {
+		events = append(events, domain.TenantEvent{Event: e.Event, Tenant: after, Changes: e.Changes})
+	}
+	return events
+}
+
+// History returns the lifecycle transitions of a tenant, oldest first. It
+// is empty when no history is configured.
+func (s *TenantService) History(ctx context.Context, id string) ([]domain.StatusChange, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	if s.history == nil {
+		return nil, nil
+	}
+	return s.history.ListByTenant(ctx, id)
+}
+
+// AsOfEnabled reports whether past tenant states can be reconstructed.
+func (s *TenantService) AsOfEnabled() bool {
+	return s.auditReader != nil
+}
+
+// GetAsOf returns the tenant as it was at the given time, including
+// tenants deleted since. It returns ErrTenantNotFound when the tenant did
+// not exist yet (or predates the audit trail).
+func (s *TenantService) GetAsOf(ctx context.Context, id string, at time.Time) (domain.Tenant, error) {
+	return s.auditReader.TenantAsOf(ctx, id, at)
+}
+
+// ApplyResult describes what Apply changed to converge a tenant to its spec.
+type ApplyResult struct {
+	Tenant  domain.Tenant
+	Created bool
+	Changes []string
+}
+
+// Apply converges the tenant identified by spec.Slug to the desired state.
+// Missing tenants are created, on DefaultPlan unless the spec has a plan;
+// drifted fields are updated, the custom domains converged, and the status
+// is driven through the shortest valid sequence of lifecycle events.
+// Applying the same spec twice is a no-op.
+func (s *TenantService) Apply(ctx context.Context, spec domain.TenantSpec) (ApplyResult, error) {
+	var result ApplyResult
+
+	tenant, err := s.repo.GetBySlug(ctx, spec.Slug)
+	if err != nil && !errors.Is(err, domain.ErrTenantNotFound) {
+		return ApplyResult{}, err
+	}
+	// Domains are checked first, so a conflict leaves the tenant untouched.
+	added, removed, err := s.specDomains(ctx, tenant.ID, spec.Domains)
+	if err != nil {
+		return ApplyResult{}, err
+	}
+
+	if tenant.ID == "" {
+		tenant, err = s.Create(ctx, CreateInput{
+			Name:     spec.Name,
+			Slug:     spec.Slug,
+			Plan:     cmp.Or(spec.Plan, DefaultPlan),
+			Metadata: domain.TenantPatch{Metadata: spec.MetadataPatch()}.Apply(domain.Tenant{}).Metadata,
+		})
+		if err != nil {
+			return ApplyResult{}, err
+		}
+		result.Created = true
+		result.Changes = append(result.Changes, "created")
+	}
+
+	if changes := fieldChanges(tenant, spec); len(changes) > 0 {
+		result.Changes = append(result.Changes, changes...)
+		before := tenant
+		if spec.Name != "" {
+			tenant.Name = spec.Name
+		}
+		if spec.Plan != "" && spec.Plan != tenant.Plan {
+			if err := s.checkPlan(ctx, spec.Plan, tenant.Region); err != nil {
+				return ApplyResult{}, err
+			}
+			tenant.Plan = spec.Plan
+		}
+		tenant.Metadata = domain.TenantPatch{Metadata: spec.MetadataPatch()}.Apply(tenant).Metadata
+		if err := domain.ValidateMetadata(tenant.Metadata); err != nil {
+			return ApplyResult{}, err
+		}
+		tenant.UpdatedAt = time.Now().UTC()
+		events := changeEvents(before, tenant)
+		if err := s.save(ctx, tenant, false, events...); err != nil {
+			return ApplyResult{}, err
+		}
+		tenant.Version++
+		if err := s.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditUpdate, &before, &tenant)); err != nil {
+			return ApplyResult{}, err
+		}
+		if err := s.publish(ctx, tenant, events...); err != nil {
+			return ApplyResult{}, err
+		}
+	}
+
+	for _, name := range added {
+		if err := s.domains.Save(ctx, domain.NewCertificate(name, tenant.ID, time.Now())); err != nil {
+			return ApplyResult{}, fmt.Errorf("saving certificate: %w", err)
+		}
+	}
+	for _, name := range removed {
+		if err := s.domains.Delete(ctx, name); err != nil {
+			return ApplyResult{}, fmt.Errorf("deleting certificate: %w", err)
+		}
+	}
+	result.Changes = append(result.Changes, domainChanges(added, removed)...)
+
+	if spec.Status != "" && spec.Status != tenant.Status {
+		path, ok := domain.PathTo(tenant.Status, spec.Status)
+		if !ok {
+			return ApplyResult{}, &domain.UnreachableStatusError{Current: tenant.Status, Target: spec.Status}
+		}
+		for _, event := range path {
+			tenant, err = s.transition(ctx, tenant.ID, event)
+			if err != nil {
+				return ApplyResult{}, err
+			}
+			result.Changes = append(result.Changes, "event: "+string(event))
+		}
+	}
+
+	result.Tenant = tenant
+	return result, nil
+}
+
+// fieldChanges describes the non-status differences between a tenant and its spec.
+func fieldChanges(tenant domain.Tenant, spec domain.TenantSpec) []string {
+	var changes []string
+	if spec.Name != "" && spec.Name != tenant.Name {
+		changes = append(changes, fmt.Sprintf("name: %q -> %q", tenant.Name, spec.Name))
+	}
+	if spec.Plan != "" && spec.Plan != tenant.Plan {
+		changes = append(changes, fmt.Sprintf("plan: %q -> %q", tenant.Plan, spec.Plan))
+	}
+	patch := spec.MetadataPatch()
+	for _, key := range slices.Sorted(maps.Keys(patch)) {
+		if current := tenant.Metadata[key]; patch[key] != current {
+			changes = append(changes, fmt.Sprintf("metadata.%s: %q -> %q", key, current, patch[key]))
+		}
+	}
+	return changes
+}
+
+// specDomains compares the custom domains of a tenant, empty for a new
+// one, with those of its spec, returning the domains to add and to
+// remove. Nil domains leave the tenant's untouched; a domain of another
+// tenant is a DomainConflictError.
+func (s *TenantService) specDomains(ctx context.Context, tenantID string, domains []string) (added, removed []string, err error) {
+	if domains == nil {
+		return nil, nil, nil
+	}
+	if s.domains == nil {
+		return nil, nil, errors.New("custom domains are not configured")
+	}
+
+	want := make(map[string]bool, len(domains))
+	for _, name := range domains {
+		name = normalizeDomain(name)
+		if err := domain.ValidateDomainName(name); err != nil {
+			return nil, nil, err
+		}
+		if want[name] {
+			continue
+		}
+		want[name] = true
+
+		cert, err := s.domains.Get(ctx, name)
+		switch {
+		case err == nil && cert.TenantID == tenantID:
+		case err == nil:
+			return nil, nil, &domain.DomainConflictError{Domain: name}
+		case errors.Is(err, domain.ErrCertificateNotFound):
+			added = append(added, name)
+		default:
+			return nil, nil, fmt.Errorf("getting certificate: %w", err)
+		}
+	}
+	slices.Sort(added)
+
+	if tenantID == "" {
+		return added, nil, nil
+	}
+	certs, err := s.domains.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing certificates: %w", err)
+	}
+	for _, cert := range certs {
+		if !want[cert.Domain] {
+			removed = append(removed, cert.Domain)
+		}
+	}
+	return added, removed, nil
+}
+
+// domainChanges describes the custom domains added to and removed from a
+// tenant.
+func domainChanges(added, removed []string) []string {
+	var changes []string
+	for _, name := range added {
+		changes = append(changes, fmt.Sprintf("domain: %q added", name))
+	}
+	for _, name := range removed {
+		changes = append(changes, fmt.Sprintf("domain: %q removed", name))
+	}
+	return changes
+}
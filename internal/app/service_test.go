@@ -4,69 +4,73 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/neomorfeo/tenantiq/internal/app"
 	"github.com/neomorfeo/tenantiq/internal/domain"
+	"github.com/neomorfeo/tenantiq/pkg/memory"
 )
 
 // --- Mocks ---
 
+// mockRepo wraps the in-memory repository with injectable write errors.
 type mockRepo struct {
-	tenants   map[string]domain.Tenant
-	slugs     map[string]domain.Tenant
+	*memory.TenantRepository
 	createErr error
 	updateErr error
 }
 
 func newMockRepo() *mockRepo {
-	return &mockRepo{
-		tenants: make(map[string]domain.Tenant),
-		slugs:   make(map[string]domain.Tenant),
-	}
+	return &mockRepo{TenantRepository: memory.NewTenantRepository()}
 }
 
-func (m *mockRepo) Create(_ context.Context, t domain.Tenant) error {
+func (m *mockRepo) Create(ctx context.Context, t domain.Tenant) error {
 	if m.createErr != nil {
 		return m.createErr
 	}
-	m.tenants[t.ID] = t
-	m.slugs[t.Slug] = t
-	return nil
+	return m.TenantRepository.Create(ctx, t)
 }
 
-func (m *mockRepo) GetByID(_ context.Context, id string) (domain.Tenant, error) {
-	t, ok := m.tenants[id]
-	if !ok {
-		return domain.Tenant{}, domain.ErrTenantNotFound
+func (m *mockRepo) CreateMany(ctx context.Context, tenants []domain.Tenant) error {
+	if m.createErr != nil {
+		return m.createErr
 	}
-	return t, nil
+	return m.TenantRepository.CreateMany(ctx, tenants)
 }
 
-func (m *mockRepo) GetBySlug(_ context.Context, slug string) (domain.Tenant, error) {
-	t, ok := m.slugs[slug]
-	if !ok {
-		return domain.Tenant{}, domain.ErrTenantNotFound
+func (m *mockRepo) Update(ctx context.Context, t domain.Tenant) error {
+	if m.updateErr != nil {
+		return m.updateErr
 	}
-	return t, nil
+	return m.TenantRepository.Update(ctx, t)
 }
 
-func (m *mockRepo) List(_ context.Context, _ domain.ListFilter) ([]domain.Tenant, error) {
-	out := make([]domain.Tenant, 0, len(m.tenants))
-	for _, t := range m.tenants {
-		out = append(out, t)
-	}
-	return out, nil
+// get returns the stored tenant, or the zero value when there is none.
+func (m *mockRepo) get(id string) domain.Tenant {
+	t, _ := m.GetByID(context.Background(), id)
+	return t
 }
 
-func (m *mockRepo) Update(_ context.Context, t domain.Tenant) error {
-	if m.updateErr != nil {
-		return m.updateErr
+// set stores t, replacing the tenant with the same ID.
+func (m *mockRepo) set(t *testing.T, tenant domain.Tenant) {
+	t.Helper()
+	ctx := context.Background()
+	err := m.TenantRepository.Update(ctx, tenant)
+	if errors.Is(err, domain.ErrTenantNotFound) {
+		err = m.TenantRepository.Create(ctx, tenant)
+	}
+	if err != nil {
+		t.Fatalf("storing tenant %s: %v", tenant.ID, err)
 	}
-	m.tenants[t.ID] = t
-	m.slugs[t.Slug] = t
-	return nil
+}
+
+// len returns the number of stored tenants.
+func (m *mockRepo) len() int {
+	n, _ := m.Count(context.Background(), domain.ListFilter{})
+	return n
 }
 
 type mockPublisher struct {
@@ -75,15 +79,17 @@ type mockPublisher struct {
 }
 
 type publishedEvent struct {
-	event  domain.Event
-	tenant domain.Tenant
+	event   domain.Event
+	tenant  domain.Tenant
+	changes []domain.FieldChange
+	cert    *domain.Certificate
 }
 
-func (m *mockPublisher) Publish(_ context.Context, e domain.Event, t domain.Tenant) error {
+func (m *mockPublisher) Publish(_ context.Context, e domain.TenantEvent) error {
 	if m.publishErr != nil {
 		return m.publishErr
 	}
-	m.events = append(m.events, publishedEvent{event: e, tenant: t})
+	m.events = append(m.events, publishedEvent{event: e.Event, tenant: e.Tenant, changes: e.Changes, cert: e.Certificate})
 	return nil
 }
 
@@ -91,13 +97,26 @@ func (m *mockPublisher) Publish(_ context.Context, e domain.Event, t domain.Tena
 // domain.Transitions table. This keeps service tests independent of looplab/fsm.
 type mockValidator struct{}
 
-func (m *mockValidator) Apply(_ context.Context, current domain.Status, event domain.Event) (domain.Status, error) {
+func (m *mockValidator) Apply(_ context.Context, tenant domain.Tenant, event domain.Event) (domain.Status, error) {
 	for _, t := range domain.Transitions {
-		if t.Event == event && t.Src == current {
+		if t.Event == event && t.Src == tenant.Status {
 			return t.Dst, nil
 		}
 	}
-	return "", &domain.TransitionError{Event: event, Current: current}
+	return "", &domain.TransitionError{Event: event, Current: tenant.Status}
+}
+
+type rejectingHook struct {
+	reason string
+}
+
+func (h *rejectingHook) Name() string { return "test-hook" }
+
+func (h *rejectingHook) BeforeCreate(_ context.Context, _ domain.Tenant) error {
+	if h.reason != "" {
+		return errors.New(h.reason)
+	}
+	return nil
 }
 
 // --- Tests ---
@@ -107,7 +126,7 @@ func TestCreate_Success(t *testing.T) {
 	pub := &mockPublisher{}
 	svc := app.NewTenantService(repo, pub, &mockValidator{})
 
-	tenant, err := svc.Create(context.Background(), "Acme", "acme", "free")
+	tenant, err := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -145,11 +164,11 @@ func TestCreate_DuplicateSlug(t *testing.T) {
 	pub := &mockPublisher{}
 	svc := app.NewTenantService(repo, pub, &mockValidator{})
 
-	if _, err := svc.Create(context.Background(), "Acme", "acme", "free"); err != nil {
+	if _, err := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"}); err != nil {
 		t.Fatalf("first create failed: %v", err)
 	}
 
-	_, err := svc.Create(context.Background(), "Acme 2", "acme", "pro")
+	_, err := svc.Create(context.Background(), app.CreateInput{Name: "Acme 2", Slug: "acme", Plan: "pro"})
 	var slugErr *domain.SlugConflictError
 	if !errors.As(err, &slugErr) {
 		t.Fatalf("expected SlugConflictError, got %v", err)
@@ -159,12 +178,35 @@ func TestCreate_DuplicateSlug(t *testing.T) {
 	}
 }
 
+func TestCreate_HookRejects(t *testing.T) {
+	repo := newMockRepo()
+	pub := &mockPublisher{}
+	svc := app.NewTenantService(repo, pub, &mockValidator{},
+		app.WithCreateHooks(&rejectingHook{}, &rejectingHook{reason: "reserved slug"}),
+	)
+
+	_, err := svc.Create(context.Background(), app.CreateInput{Name: "Admin", Slug: "admin", Plan: "free"})
+	var hookErr *domain.HookRejectedError
+	if !errors.As(err, &hookErr) {
+		t.Fatalf("expected HookRejectedError, got %v", err)
+	}
+	if hookErr.Reason != "reserved slug" {
+		t.Errorf("Reason = %q, want %q", hookErr.Reason, "reserved slug")
+	}
+	if repo.len() != 0 {
+		t.Error("rejected tenant should not be persisted")
+	}
+	if len(pub.events) != 0 {
+		t.Error("no event should be published for a rejected tenant")
+	}
+}
+
 func TestTransition_HappyPath(t *testing.T) {
 	repo := newMockRepo()
 	pub := &mockPublisher{}
 	svc := app.NewTenantService(repo, pub, &mockValidator{})
 
-	tenant, _ := svc.Create(context.Background(), "Acme", "acme", "free")
+	tenant, _ := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
 
 	// creating → active
 	tenant, err := svc.Transition(context.Background(), tenant.ID, domain.EventProvisionComplete)
@@ -194,12 +236,27 @@ func TestTransition_HappyPath(t *testing.T) {
 	}
 }
 
+func TestTransition_ReturnsStoredVersion(t *testing.T) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{})
+	ctx := context.Background()
+
+	tenant, _ := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
+	tenant, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete)
+	if err != nil {
+		t.Fatalf("provision_complete failed: %v", err)
+	}
+	if stored := repo.get(tenant.ID); tenant.Version != 2 || stored.Version != tenant.Version {
+		t.Errorf("returned version %d, stored %d; want both 2", tenant.Version, stored.Version)
+	}
+}
+
 func TestTransition_InvalidEvent(t *testing.T) {
 	repo := newMockRepo()
 	pub := &mockPublisher{}
 	svc := app.NewTenantService(repo, pub, &mockValidator{})
 
-	tenant, _ := svc.Create(context.Background(), "Acme", "acme", "free")
+	tenant, _ := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
 
 	// Can't suspend from creating.
 	_, err := svc.Transition(context.Background(), tenant.ID, domain.EventSuspend)
@@ -226,6 +283,30 @@ func TestTransition_NotFound(t *testing.T) {
 	}
 }
 
+func TestTransition_DeleteRequiresAdmin(t *testing.T) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{})
+	tenant, _ := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
+	operator := domain.WithRole(context.Background(), domain.RoleOperator)
+
+	if _, err := svc.Transition(operator, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("operator activating: %v", err)
+	}
+	_, err := svc.Transition(operator, tenant.ID, domain.EventDelete)
+	var forbidden *domain.ForbiddenError
+	if !errors.As(err, &forbidden) || forbidden.Required != domain.RoleAdmin {
+		t.Fatalf("operator deleting: expected ForbiddenError requiring admin, got %v", err)
+	}
+	if got, _ := repo.GetByID(context.Background(), tenant.ID); got.Status != domain.StatusActive {
+		t.Errorf("forbidden delete must not write: status = %q", got.Status)
+	}
+
+	admin := domain.WithRole(context.Background(), domain.RoleAdmin)
+	if _, err := svc.Transition(admin, tenant.ID, domain.EventDelete); err != nil {
+		t.Errorf("admin deleting: %v", err)
+	}
+}
+
 // --- GetByID ---
 
 func TestGetByID_Success(t *testing.T) {
@@ -233,7 +314,7 @@ func TestGetByID_Success(t *testing.T) {
 	pub := &mockPublisher{}
 	svc := app.NewTenantService(repo, pub, &mockValidator{})
 
-	created, _ := svc.Create(context.Background(), "Acme", "acme", "free")
+	created, _ := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
 
 	got, err := svc.GetByID(context.Background(), created.ID)
 	if err != nil {
@@ -257,15 +338,30 @@ func TestGetByID_NotFound(t *testing.T) {
 
 // --- List ---
 
+func TestGetBySlug_Success(t *testing.T) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{})
+
+	created, _ := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
+
+	got, err := svc.GetBySlug(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != created.ID {
+		t.Errorf("ID = %q, want %q", got.ID, created.ID)
+	}
+}
+
 func TestList_Success(t *testing.T) {
 	repo := newMockRepo()
 	pub := &mockPublisher{}
 	svc := app.NewTenantService(repo, pub, &mockValidator{})
 
-	if _, err := svc.Create(context.Background(), "Acme", "acme", "free"); err != nil {
+	if _, err := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"}); err != nil {
 		t.Fatalf("create Acme: %v", err)
 	}
-	if _, err := svc.Create(context.Background(), "Globex", "globex", "pro"); err != nil {
+	if _, err := svc.Create(context.Background(), app.CreateInput{Name: "Globex", Slug: "globex", Plan: "pro"}); err != nil {
 		t.Fatalf("create Globex: %v", err)
 	}
 
@@ -286,7 +382,7 @@ func TestCreate_RepoError(t *testing.T) {
 	pub := &mockPublisher{}
 	svc := app.NewTenantService(repo, pub, &mockValidator{})
 
-	_, err := svc.Create(context.Background(), "Acme", "acme", "free")
+	_, err := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -303,7 +399,7 @@ func TestCreate_PublishError(t *testing.T) {
 	// Set publish error after service is wired, before calling Create.
 	pub.publishErr = fmt.Errorf("queue down")
 
-	_, err := svc.Create(context.Background(), "Acme", "acme", "free")
+	_, err := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -317,7 +413,7 @@ func TestTransition_UpdateError(t *testing.T) {
 	pub := &mockPublisher{}
 	svc := app.NewTenantService(repo, pub, &mockValidator{})
 
-	tenant, _ := svc.Create(context.Background(), "Acme", "acme", "free")
+	tenant, _ := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
 
 	repo.updateErr = fmt.Errorf("db locked")
 
@@ -335,7 +431,7 @@ func TestTransition_PublishError(t *testing.T) {
 	pub := &mockPublisher{}
 	svc := app.NewTenantService(repo, pub, &mockValidator{})
 
-	tenant, _ := svc.Create(context.Background(), "Acme", "acme", "free")
+	tenant, _ := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
 
 	// Set publish error after Create succeeds.
 	pub.publishErr = fmt.Errorf("queue down")
@@ -348,3 +444,433 @@ func TestTransition_PublishError(t *testing.T) {
 		t.Errorf("error = %q, want it to contain 'publishing event'", err)
 	}
 }
+
+func TestApply_CreatesAndTransitions(t *testing.T) {
+	repo := newMockRepo()
+	pub := &mockPublisher{}
+	svc := app.NewTenantService(repo, pub, &mockValidator{})
+
+	result, err := svc.Apply(context.Background(), domain.TenantSpec{
+		Slug: "acme", Name: "Acme", Plan: "pro", Status: domain.StatusActive,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Created {
+		t.Error("Created = false, want true")
+	}
+	if result.Tenant.Status != domain.StatusActive {
+		t.Errorf("Status = %q, want %q", result.Tenant.Status, domain.StatusActive)
+	}
+
+	// Applying the same spec again must not change anything.
+	result, err = svc.Apply(context.Background(), domain.TenantSpec{
+		Slug: "acme", Name: "Acme", Plan: "pro", Status: domain.StatusActive,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Created || len(result.Changes) != 0 {
+		t.Errorf("second apply: created = %v, changes = %v, want no-op", result.Created, result.Changes)
+	}
+}
+
+func TestApply_MetadataFeaturesAndDomains(t *testing.T) {
+	repo := newMockRepo()
+	certs := &mockCertificates{certs: map[string]domain.Certificate{}}
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{}, app.WithDomains(certs))
+	ctx := context.Background()
+
+	result, err := svc.Apply(ctx, domain.TenantSpec{
+		Slug: "acme", Name: "Acme",
+		Metadata: map[string]string{"crm_id": "42"},
+		Features: map[string]bool{"sso": true},
+		Domains:  []string{"App.Acme.com"},
+	})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	tenant := result.Tenant
+	if tenant.Plan != app.DefaultPlan || tenant.Metadata["crm_id"] != "42" || tenant.Metadata["feature.sso"] != "true" {
+		t.Errorf("tenant = %+v, want the default plan with the spec's metadata and features", tenant)
+	}
+	if cert, ok := certs.certs["app.acme.com"]; !ok || cert.TenantID != tenant.ID {
+		t.Errorf("certificates = %v, want one for app.acme.com", certs.certs)
+	}
+
+	result, err = svc.Apply(ctx, domain.TenantSpec{
+		Slug: "acme", Name: "Acme",
+		Metadata: map[string]string{"crm_id": ""},
+		Domains:  []string{"www.acme.com"},
+	})
+	if err != nil {
+		t.Fatalf("second Apply: %v", err)
+	}
+	want := []string{`metadata.crm_id: "42" -> ""`, `domain: "www.acme.com" added`, `domain: "app.acme.com" removed`}
+	if !slices.Equal(result.Changes, want) {
+		t.Errorf("Changes = %q, want %q", result.Changes, want)
+	}
+	if _, ok := result.Tenant.Metadata["crm_id"]; ok || result.Tenant.Metadata["feature.sso"] != "true" {
+		t.Errorf("Metadata = %v, want crm_id removed and feature.sso kept", result.Tenant.Metadata)
+	}
+
+	var conflict *domain.DomainConflictError
+	_, err = svc.Apply(ctx, domain.TenantSpec{Slug: "globex", Name: "Globex", Domains: []string{"www.acme.com"}})
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Apply with another tenant's domain = %v, want *DomainConflictError", err)
+	}
+	if _, err := repo.GetBySlug(ctx, "globex"); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("GetBySlug(globex) = %v, want the tenant not created", err)
+	}
+}
+
+func TestApply_UnreachableStatus(t *testing.T) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{})
+
+	_, err := svc.Apply(context.Background(), domain.TenantSpec{
+		Slug: "acme", Name: "Acme", Plan: "free", Status: domain.StatusDeleted,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = svc.Apply(context.Background(), domain.TenantSpec{Slug: "acme", Status: domain.StatusActive})
+	var unreachable *domain.UnreachableStatusError
+	if !errors.As(err, &unreachable) {
+		t.Fatalf("expected UnreachableStatusError, got %v", err)
+	}
+}
+
+func TestSync_ReportsActions(t *testing.T) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{})
+	ctx := context.Background()
+
+	if _, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := svc.Create(ctx, app.CreateInput{Name: "Stale", Slug: "stale", Plan: "free"}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	specs := []domain.TenantSpec{
+		{Slug: "acme", Name: "Acme", Plan: "pro"},
+		{Slug: "globex", Name: "Globex", Plan: "free"},
+	}
+
+	report, err := svc.Sync(ctx, specs, app.SyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if report.Count(app.SyncUpdate) != 1 || report.Count(app.SyncCreate) != 1 || report.Count(app.SyncExtraneous) != 1 {
+		t.Errorf("dry run report = %+v", report.Items)
+	}
+	if _, err := repo.GetBySlug(ctx, "globex"); err == nil {
+		t.Error("dry run should not create tenants")
+	}
+
+	report, err = svc.Sync(ctx, specs, app.SyncOptions{})
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if report.Count(app.SyncCreate) != 1 || report.Count(app.SyncUpdate) != 1 {
+		t.Errorf("report = %+v", report.Items)
+	}
+	if got, _ := repo.GetBySlug(ctx, "acme"); got.Plan != "pro" {
+		t.Errorf("acme plan = %q, want %q", got.Plan, "pro")
+	}
+	if _, err := repo.GetBySlug(ctx, "stale"); err != nil {
+		t.Error("extraneous tenants must not be removed")
+	}
+}
+
+func TestSync_GuardrailBlocksMassSuspension(t *testing.T) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithGuardrail(domain.Guardrail{MaxDisruptedPercent: 10}),
+	)
+	ctx := context.Background()
+
+	var specs []domain.TenantSpec
+	for _, slug := range []string{"a", "b", "c"} {
+		tenant, _ := svc.Create(ctx, app.CreateInput{Name: slug, Slug: slug, Plan: "free"})
+		if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+			t.Fatalf("activate %s: %v", slug, err)
+		}
+		specs = append(specs, domain.TenantSpec{Slug: slug, Status: domain.StatusSuspended})
+	}
+
+	_, err := svc.Sync(ctx, specs, app.SyncOptions{})
+	var guardErr *domain.GuardrailError
+	if !errors.As(err, &guardErr) {
+		t.Fatalf("expected GuardrailError, got %v", err)
+	}
+	if got, _ := repo.GetBySlug(ctx, "a"); got.Status != domain.StatusActive {
+		t.Errorf("blocked sync must not write: status = %q", got.Status)
+	}
+
+	operator := domain.WithRole(ctx, domain.RoleOperator)
+	if _, err := svc.Sync(operator, specs, app.SyncOptions{Force: true}); !errors.As(err, new(*domain.ForbiddenError)) {
+		t.Fatalf("operator forcing: expected ForbiddenError, got %v", err)
+	}
+
+	report, err := svc.Sync(ctx, specs, app.SyncOptions{Force: true})
+	if err != nil {
+		t.Fatalf("forced sync: %v", err)
+	}
+	if report.Count(app.SyncUpdate) != 3 {
+		t.Errorf("forced sync report = %+v", report.Items)
+	}
+}
+
+func TestUpdate_AppliesPatch(t *testing.T) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{})
+
+	created, _ := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
+
+	branch := "tenant/acme"
+	updated, err := svc.Update(context.Background(), created.ID, domain.TenantPatch{
+		GitBranch:    &branch,
+		ExternalRefs: map[string]string{"argocd_app": "acme"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.GitBranch != branch {
+		t.Errorf("GitBranch = %q, want %q", updated.GitBranch, branch)
+	}
+	if stored := repo.get(created.ID); stored.ExternalRefs["argocd_app"] != "acme" {
+		t.Errorf("stored ExternalRefs = %v", stored.ExternalRefs)
+	}
+}
+
+func TestUpdate_PublishesChangeEvents(t *testing.T) {
+	pub := &mockPublisher{}
+	svc := app.NewTenantService(newMockRepo(), pub, &mockValidator{})
+
+	created, _ := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
+	pub.events = nil
+
+	plan, branch := "pro", "tenant/acme"
+	if _, err := svc.Update(context.Background(), created.ID, domain.TenantPatch{
+		Plan:         &plan,
+		GitBranch:    &branch,
+		ExternalRefs: map[string]string{"argocd_app": "acme"},
+	}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if len(pub.events) != 2 {
+		t.Fatalf("published %+v, want plan_changed then metadata_updated", pub.events)
+	}
+	if e := pub.events[0]; e.event != domain.EventPlanChanged || len(e.changes) != 1 || e.changes[0] != (domain.FieldChange{Field: "plan", Before: "free", After: "pro"}) {
+		t.Errorf("first event = %+v, want plan_changed from free to pro", e)
+	}
+	if e := pub.events[1]; e.event != domain.EventMetadataUpdated || len(e.changes) != 2 || e.tenant.GitBranch != branch {
+		t.Errorf("second event = %+v, want metadata_updated with the branch and reference", e)
+	}
+
+	// Nothing changed, nothing published.
+	pub.events = nil
+	if _, err := svc.Update(context.Background(), created.ID, domain.TenantPatch{Plan: &plan}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if len(pub.events) != 0 {
+		t.Errorf("published %+v for a no-op update", pub.events)
+	}
+}
+
+func TestUpdate_NotFound(t *testing.T) {
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{})
+
+	_, err := svc.Update(context.Background(), "missing", domain.TenantPatch{})
+	if !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("expected ErrTenantNotFound, got %v", err)
+	}
+}
+
+// mockHistory records status changes in memory.
+type mockHistory struct {
+	changes   []domain.StatusChange
+	recordErr error
+}
+
+func (m *mockHistory) Record(_ context.Context, c domain.StatusChange) error {
+	if m.recordErr != nil {
+		return m.recordErr
+	}
+	m.changes = append(m.changes, c)
+	return nil
+}
+
+func (m *mockHistory) ListByTenant(_ context.Context, tenantID string) ([]domain.StatusChange, error) {
+	var result []domain.StatusChange
+	for _, c := range m.changes {
+		if c.TenantID == tenantID {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockHistory) ListSince(_ context.Context, since time.Time) ([]domain.StatusChange, error) {
+	var result []domain.StatusChange
+	for _, c := range m.changes {
+		if !c.At.Before(since) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func TestTransition_RecordsHistory(t *testing.T) {
+	history := &mockHistory{}
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{}, app.WithStatusHistory(history))
+	ctx := domain.WithActor(context.Background(), "alice@example.com")
+
+	tenant, _ := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+	if _, err := svc.Transition(context.Background(), tenant.ID, domain.EventSuspend); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+
+	changes, err := svc.History(ctx, tenant.ID)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("got %d changes, want 2", len(changes))
+	}
+	first := changes[0]
+	if first.From != domain.StatusCreating || first.To != domain.StatusActive ||
+		first.Event != domain.EventProvisionComplete || first.Actor != "alice@example.com" || first.At.IsZero() {
+		t.Errorf("first change = %+v", first)
+	}
+	if changes[1].Actor != domain.SystemActor {
+		t.Errorf("second change actor = %q, want %q", changes[1].Actor, domain.SystemActor)
+	}
+}
+
+func TestTransition_HistoryError(t *testing.T) {
+	history := &mockHistory{recordErr: errors.New("disk full")}
+	pub := &mockPublisher{}
+	svc := app.NewTenantService(newMockRepo(), pub, &mockValidator{}, app.WithStatusHistory(history))
+	ctx := context.Background()
+
+	tenant, _ := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if len(pub.events) != 1 {
+		t.Errorf("published %d events, want only the creation event", len(pub.events))
+	}
+}
+
+func TestHistory_NotFound(t *testing.T) {
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{}, app.WithStatusHistory(&mockHistory{}))
+
+	if _, err := svc.History(context.Background(), "ten_missing"); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("expected ErrTenantNotFound, got %v", err)
+	}
+}
+
+// mockAudit records audit entries in memory.
+type mockAudit struct {
+	entries []domain.AuditEntry
+	logErr  error
+}
+
+func (m *mockAudit) Log(_ context.Context, e domain.AuditEntry) error {
+	if m.logErr != nil {
+		return m.logErr
+	}
+	m.entries = append(m.entries, e)
+	return nil
+}
+
+func TestAudit_RecordsMutations(t *testing.T) {
+	audit := &mockAudit{}
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{}, app.WithAuditLogger(audit))
+	ctx := domain.WithRequestID(domain.WithActor(context.Background(), "alice"), "req-1")
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	branch := "feature/acme"
+	if _, err := svc.Update(ctx, tenant.ID, domain.TenantPatch{GitBranch: &branch}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventDelete); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+
+	wantActions := []domain.AuditAction{domain.AuditCreate, domain.AuditUpdate, domain.AuditTransition, domain.AuditDelete}
+	if len(audit.entries) != len(wantActions) {
+		t.Fatalf("got %d entries, want %d", len(audit.entries), len(wantActions))
+	}
+	for i, e := range audit.entries {
+		if e.Action != wantActions[i] || e.TenantID != tenant.ID || e.Actor != "alice" || e.RequestID != "req-1" {
+			t.Errorf("entry %d = %+v", i, e)
+		}
+	}
+
+	if created := audit.entries[0]; created.Before != nil || created.After == nil {
+		t.Errorf("create entry snapshots = %v / %v, want nil / tenant", created.Before, created.After)
+	}
+	if updated := audit.entries[1]; updated.Before.GitBranch != "" || updated.After.GitBranch != branch {
+		t.Errorf("update entry snapshots = %+v / %+v", updated.Before, updated.After)
+	}
+	deleted := audit.entries[3]
+	if deleted.Event != domain.EventDelete || deleted.Before.Status != domain.StatusActive || deleted.After.Status != domain.StatusDeleting {
+		t.Errorf("delete entry = %+v", deleted)
+	}
+}
+
+func TestAudit_ErrorIsReturned(t *testing.T) {
+	svc := app.NewTenantService(newMockRepo(), &mockPublisher{}, &mockValidator{},
+		app.WithAuditLogger(&mockAudit{logErr: errors.New("disk full")}))
+
+	if _, err := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestTransition_PolicyRequiresApproval(t *testing.T) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithTransitionPolicies(domain.TransitionPolicies{
+			{Plan: "enterprise", Event: domain.EventDelete, Effect: domain.PolicyRequireApproval},
+		}),
+	)
+	ctx := domain.WithActor(context.Background(), "alice")
+
+	tenant, _ := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "enterprise"})
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+
+	_, err := svc.Transition(ctx, tenant.ID, domain.EventDelete)
+	var policyErr *domain.PolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("expected PolicyError, got %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, tenant.ID); got.Status != domain.StatusActive {
+		t.Errorf("status = %q, want unchanged %q", got.Status, domain.StatusActive)
+	}
+
+	deleted, err := svc.Transition(domain.WithApprover(ctx, "bob"), tenant.ID, domain.EventDelete)
+	if err != nil {
+		t.Fatalf("approved delete: %v", err)
+	}
+	if deleted.Status != domain.StatusDeleting {
+		t.Errorf("status = %q, want %q", deleted.Status, domain.StatusDeleting)
+	}
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WithSimulator enables simulated tenants, created with a context marked
+// by CreateInput.Simulated. Their provisioning and deletion are carried
+// out by p, which stands in for the consumers of their events.
+func WithSimulator(p domain.Provisioner) Option {
+	return func(s *TenantService) {
+		s.simulator = p
+	}
+}
+
+// SimulationEnabled reports whether simulated tenants can be created.
+func (s *TenantService) SimulationEnabled() bool {
+	return s.simulator != nil
+}
+
+// simulateProvisioning has the simulator provision a tenant and stores
+// the references it returns.
+func (s *TenantService) simulateProvisioning(ctx context.Context, tenant domain.Tenant) (domain.Tenant, error) {
+	p, err := s.simulator.Provision(ctx, tenant)
+	if err != nil {
+		return domain.Tenant{}, fmt.Errorf("simulating provisioning: %w", err)
+	}
+	return s.Update(ctx, tenant.ID, domain.TenantPatch{
+		PRURL:        &p.PRURL,
+		GitBranch:    &p.GitBranch,
+		ExternalRefs: p.ExternalRefs,
+	})
+}
+
+// completeSimulation plays the part of the event consumers after event
+// was applied to a simulated tenant: a created tenant is provisioned and
+// activated, a deleting one is deprovisioned and deleted. Other events
+// need no answer.
+func (s *TenantService) completeSimulation(ctx context.Context, event domain.Event, tenant domain.Tenant) (domain.Tenant, error) {
+	switch {
+	case tenant.Status == domain.StatusCreating && event == domain.EventProvisionComplete:
+		provisioned, err := s.simulateProvisioning(ctx, tenant)
+		if err != nil {
+			return domain.Tenant{}, err
+		}
+		return s.transition(ctx, provisioned.ID, domain.EventProvisionComplete)
+	case tenant.Status == domain.StatusDeleting && event == domain.EventDelete:
+		if err := s.simulator.Deprovision(ctx, tenant); err != nil {
+			return domain.Tenant{}, fmt.Errorf("simulating deprovisioning: %w", err)
+		}
+		return s.transition(ctx, tenant.ID, domain.EventDeletionComplete)
+	default:
+		return tenant, nil
+	}
+}
+
+// simulateOperation carries out the work of an operation on a simulated
+// tenant before the operation completes it. Real tenants and missing
+// ones are left to the operation.
+func (s *TenantService) simulateOperation(ctx context.Context, op domain.Operation) error {
+	tenant, err := s.repo.GetByID(ctx, op.TenantID)
+	if err != nil || !tenant.Simulated || s.simulator == nil {
+		return nil
+	}
+	switch op.Kind {
+	case domain.OperationProvision:
+		if tenant.Status != domain.StatusCreating {
+			return nil
+		}
+		_, err = s.simulateProvisioning(ctx, tenant)
+	case domain.OperationDeletion:
+		if tenant.Status != domain.StatusDeleting {
+			return nil
+		}
+		if err = s.simulator.Deprovision(ctx, tenant); err != nil {
+			err = fmt.Errorf("simulating deprovisioning: %w", err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,132 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockProvisioner records the tenants it provisioned and deprovisioned.
+type mockProvisioner struct {
+	provisioned   []string
+	deprovisioned []string
+}
+
+func (m *mockProvisioner) Provision(_ context.Context, tenant domain.Tenant) (domain.Provisioning, error) {
+	m.provisioned = append(m.provisioned, tenant.ID)
+	return domain.Provisioning{
+		PRURL:        "https://git.test/pull/1",
+		GitBranch:    "tenant/" + tenant.Slug,
+		ExternalRefs: map[string]string{"namespace": "tenant-" + tenant.Slug},
+	}, nil
+}
+
+func (m *mockProvisioner) Deprovision(_ context.Context, tenant domain.Tenant) error {
+	m.deprovisioned = append(m.deprovisioned, tenant.ID)
+	return nil
+}
+
+func TestSimulation_CreateProvisionsAndDeleteCompletes(t *testing.T) {
+	repo, pub, sim := newMockRepo(), &mockPublisher{}, &mockProvisioner{}
+	svc := app.NewTenantService(repo, pub, &mockValidator{}, app.WithSimulator(sim))
+	ctx := context.Background()
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free", Simulated: true})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !tenant.Simulated || tenant.Status != domain.StatusActive || tenant.GitBranch != "tenant/acme" || tenant.ExternalRefs["namespace"] != "tenant-acme" {
+		t.Fatalf("tenant = %+v, want simulated, active and provisioned", tenant)
+	}
+	if len(sim.provisioned) != 1 {
+		t.Errorf("provisioned %v, want the tenant once", sim.provisioned)
+	}
+
+	deleted, err := svc.Transition(context.Background(), tenant.ID, domain.EventDelete)
+	if err != nil {
+		t.Fatalf("Transition(delete): %v", err)
+	}
+	if deleted.Status != domain.StatusDeleted || len(sim.deprovisioned) != 1 {
+		t.Errorf("status = %q after deprovisioning %v, want deleted once deprovisioned", deleted.Status, sim.deprovisioned)
+	}
+
+	var events []domain.Event
+	for _, e := range pub.events {
+		events = append(events, e.event)
+	}
+	want := []domain.Event{domain.EventProvisionComplete, domain.EventMetadataUpdated, domain.EventProvisionComplete, domain.EventDelete, domain.EventDeletionComplete}
+	if len(events) != len(want) {
+		t.Fatalf("published %v, want %v", events, want)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("published %v, want %v", events, want)
+			break
+		}
+	}
+}
+
+func TestSimulation_AsyncOperationProvisions(t *testing.T) {
+	repo, ops, queue, sim := newMockRepo(), newMockOperations(), &mockQueue{}, &mockProvisioner{}
+	opSvc := app.NewOperationService(ops)
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithAsyncOperations(opSvc, queue), app.WithSimulator(sim))
+
+	tenant, op, err := svc.CreateAsync(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free", Simulated: true})
+	if err != nil {
+		t.Fatalf("CreateAsync: %v", err)
+	}
+	if tenant.Status != domain.StatusCreating || len(sim.provisioned) != 0 {
+		t.Fatalf("tenant = %s after %d provisionings, want creating until the operation runs", tenant.Status, len(sim.provisioned))
+	}
+
+	if err := svc.RunOperation(context.Background(), op.ID); err != nil {
+		t.Fatalf("RunOperation: %v", err)
+	}
+	if got := repo.get(tenant.ID); got.Status != domain.StatusActive || got.GitBranch != "tenant/acme" || len(sim.provisioned) != 1 {
+		t.Errorf("tenant = %+v, want active with the simulated references", got)
+	}
+
+	deleting, delOp, err := svc.DeleteAsync(context.Background(), tenant.ID)
+	if err != nil {
+		t.Fatalf("DeleteAsync: %v", err)
+	}
+	if deleting.Status != domain.StatusDeleting {
+		t.Fatalf("status = %q, want deleting until the operation runs", deleting.Status)
+	}
+	if err := svc.RunOperation(context.Background(), delOp.ID); err != nil {
+		t.Fatalf("RunOperation(deletion): %v", err)
+	}
+	if got := repo.get(tenant.ID); got.Status != domain.StatusDeleted || len(sim.deprovisioned) != 1 {
+		t.Errorf("tenant = %s after deprovisioning %v, want deleted", got.Status, sim.deprovisioned)
+	}
+}
+
+func TestSimulation_RealTenantsUntouched(t *testing.T) {
+	repo, sim := newMockRepo(), &mockProvisioner{}
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{}, app.WithSimulator(sim))
+
+	tenant, err := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if tenant.Simulated || tenant.Status != domain.StatusCreating || len(sim.provisioned) != 0 {
+		t.Errorf("tenant = %+v, want a real tenant left creating", tenant)
+	}
+}
+
+func TestSimulation_NotConfigured(t *testing.T) {
+	repo := newMockRepo()
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{})
+
+	if svc.SimulationEnabled() {
+		t.Error("SimulationEnabled() = true without WithSimulator")
+	}
+	_, err := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free", Simulated: true})
+	if !errors.Is(err, domain.ErrSimulationDisabled) || repo.len() != 0 {
+		t.Errorf("Create = %v with %d tenants stored, want ErrSimulationDisabled and none", err, repo.len())
+	}
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// StepRunner runs the provisioning steps of an operation so that running
+// them again, as retried jobs do, repeats none of their changes.
+type StepRunner struct {
+	records domain.StepRepository
+}
+
+// NewStepRunner creates a runner recording the completed steps in
+// records.
+func NewStepRunner(records domain.StepRepository) *StepRunner {
+	return &StepRunner{records: records}
+}
+
+// Run runs steps, in order, for the operation on tenant and returns the
+// references they returned, by step name. A step recorded for the
+// operation with the same fingerprint is skipped; otherwise it is applied,
+// unless Find shows that an earlier attempt already did, and recorded. The
+// first failure stops the run and is returned, for the job to retry.
+func (r *StepRunner) Run(ctx context.Context, op domain.Operation, tenant domain.Tenant, steps []domain.ProvisioningStep) (map[string]string, error) {
+	recs, err := r.records.List(ctx, op.ID)
+	if err != nil {
+		return nil, fmt.Errorf("listing recorded steps: %w", err)
+	}
+	recorded := make(map[string]domain.StepRecord, len(recs))
+	for _, rec := range recs {
+		recorded[rec.Step] = rec
+	}
+
+	state := domain.StepState{Tenant: tenant, Refs: make(map[string]string, len(steps))}
+	for _, step := range steps {
+		name := step.Name()
+		fingerprint := step.Fingerprint(state)
+		if rec, ok := recorded[name]; ok && rec.Fingerprint == fingerprint {
+			state.Refs[name] = rec.Ref
+			continue
+		}
+
+		ref, found, err := step.Find(ctx, state)
+		if err != nil {
+			return nil, fmt.Errorf("step %s: checking for an earlier attempt: %w", name, err)
+		}
+		if !found {
+			if ref, err = step.Apply(ctx, state); err != nil {
+				return nil, fmt.Errorf("step %s: %w", name, err)
+			}
+		}
+		slog.InfoContext(ctx, "provisioning step completed",
+			"operation_id", op.ID,
+			"tenant_id", tenant.ID,
+			"step", name,
+			"already_done", found,
+		)
+
+		err = r.records.Save(ctx, domain.StepRecord{
+			OperationID: op.ID,
+			Step:        name,
+			Fingerprint: fingerprint,
+			Ref:         ref,
+			CompletedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("recording step %s: %w", name, err)
+		}
+		state.Refs[name] = ref
+	}
+	return state.Refs, nil
+}
+
+// WithOperationSteps has the operations of kind run steps through runner
+// before they complete: the steps of a provisioning operation set up what
+// the tenant needs, those of a deletion tear it down. The references of
+// provisioning steps are stored in the tenant's ExternalRefs, under the
+// step names. It requires WithAsyncOperations.
+func WithOperationSteps(runner *StepRunner, kind domain.OperationKind, steps ...domain.ProvisioningStep) Option {
+	return func(s *TenantService) {
+		if s.steps == nil {
+			s.steps = make(map[domain.OperationKind][]domain.ProvisioningStep)
+		}
+		s.stepRunner = runner
+		s.steps[kind] = append(s.steps[kind], steps...)
+	}
+}
+
+// runSteps runs the steps of the operation's kind, if the tenant is still
+// in the state they apply to; otherwise the operation fails when
+// completing it. Simulated tenants are left to the simulator.
+func (s *TenantService) runSteps(ctx context.Context, op domain.Operation) error {
+	steps := s.steps[op.Kind]
+	if len(steps) == 0 {
+		return nil
+	}
+	tenant, err := s.repo.GetByID(ctx, op.TenantID)
+	if err != nil || tenant.Simulated {
+		return nil
+	}
+	want := domain.StatusCreating
+	if op.Kind == domain.OperationDeletion {
+		want = domain.StatusDeleting
+	}
+	if tenant.Status != want {
+		return nil
+	}
+
+	refs, err := s.stepRunner.Run(ctx, op, tenant, steps)
+	if err != nil {
+		return err
+	}
+	if op.Kind != domain.OperationProvision {
+		return nil
+	}
+	maps.DeleteFunc(refs, func(_, ref string) bool { return ref == "" })
+	if len(refs) == 0 {
+		return nil
+	}
+	_, err = s.Update(ctx, tenant.ID, domain.TenantPatch{ExternalRefs: refs})
+	return err
+}
@@ -0,0 +1,138 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockSteps records steps in memory; the first failSaves saves fail, as
+// if the worker died between a change and its record.
+type mockSteps struct {
+	recs      map[string][]domain.StepRecord
+	failSaves int
+}
+
+func (m *mockSteps) List(_ context.Context, operationID string) ([]domain.StepRecord, error) {
+	return m.recs[operationID], nil
+}
+
+func (m *mockSteps) Save(_ context.Context, rec domain.StepRecord) error {
+	if m.failSaves > 0 {
+		m.failSaves--
+		return errors.New("database is locked")
+	}
+	if m.recs == nil {
+		m.recs = make(map[string][]domain.StepRecord)
+	}
+	m.recs[rec.OperationID] = append(m.recs[rec.OperationID], rec)
+	return nil
+}
+
+// pullRequestStep opens a pull request per tenant slug in a fake Git
+// host, failing while err is set.
+type pullRequestStep struct {
+	opened         map[string]string
+	finds, applies int
+	err            error
+}
+
+func (s *pullRequestStep) Name() string { return "git" }
+
+func (s *pullRequestStep) Fingerprint(state domain.StepState) string {
+	return domain.Fingerprint(state.Tenant.Slug)
+}
+
+func (s *pullRequestStep) Find(_ context.Context, state domain.StepState) (string, bool, error) {
+	s.finds++
+	ref, ok := s.opened[state.Tenant.Slug]
+	return ref, ok, nil
+}
+
+func (s *pullRequestStep) Apply(_ context.Context, state domain.StepState) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	s.applies++
+	if s.opened == nil {
+		s.opened = make(map[string]string)
+	}
+	s.opened[state.Tenant.Slug] = "https://git.example.com/pull/" + state.Tenant.Slug
+	return s.opened[state.Tenant.Slug], nil
+}
+
+func TestStepRunner_RetriesRepeatNothing(t *testing.T) {
+	records := &mockSteps{failSaves: 1}
+	step := &pullRequestStep{}
+	runner := app.NewStepRunner(records)
+	op := domain.NewOperation("op_1", domain.OperationProvision, "ten_1")
+	tenant := domain.Tenant{ID: "ten_1", Slug: "acme"}
+	ctx := context.Background()
+
+	if _, err := runner.Run(ctx, op, tenant, []domain.ProvisioningStep{step}); err == nil {
+		t.Fatal("expected the failed record to fail the run")
+	}
+
+	// The retry finds the pull request opened by the failed attempt.
+	refs, err := runner.Run(ctx, op, tenant, []domain.ProvisioningStep{step})
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if step.applies != 1 || refs["git"] != "https://git.example.com/pull/acme" {
+		t.Errorf("retry: applies = %d, refs = %v; want the first pull request", step.applies, refs)
+	}
+
+	// Once recorded, the step is not even looked for.
+	finds := step.finds
+	if refs, err = runner.Run(ctx, op, tenant, []domain.ProvisioningStep{step}); err != nil || refs["git"] == "" {
+		t.Fatalf("recorded run: refs = %v, err = %v", refs, err)
+	}
+	if step.finds != finds || step.applies != 1 {
+		t.Errorf("recorded run: finds = %d, applies = %d; want the step skipped", step.finds-finds, step.applies)
+	}
+
+	// Other inputs make another fingerprint: the step runs again.
+	tenant.Slug = "acme-corp"
+	if _, err := runner.Run(ctx, op, tenant, []domain.ProvisioningStep{step}); err != nil {
+		t.Fatalf("changed inputs: %v", err)
+	}
+	if step.applies != 2 {
+		t.Errorf("changed inputs: applies = %d, want 2", step.applies)
+	}
+}
+
+func TestRunOperation_RunsSteps(t *testing.T) {
+	repo, ops := newMockRepo(), newMockOperations()
+	step := &pullRequestStep{err: errors.New("git host unavailable")}
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithAsyncOperations(app.NewOperationService(ops), &mockQueue{}),
+		app.WithOperationSteps(app.NewStepRunner(&mockSteps{}), domain.OperationProvision, step),
+	)
+	ctx := context.Background()
+
+	tenant, op, err := svc.CreateAsync(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "free"})
+	if err != nil {
+		t.Fatalf("CreateAsync: %v", err)
+	}
+	if err := svc.RunOperation(ctx, op.ID); err == nil {
+		t.Fatal("expected the failed step to fail the run, for the job to retry")
+	}
+	if got, _ := repo.GetByID(ctx, tenant.ID); got.Status != domain.StatusCreating || ops.ops[op.ID].Done() {
+		t.Fatalf("after a failed step: status = %q, operation %q; want both unchanged", got.Status, ops.ops[op.ID].Status)
+	}
+
+	step.err = nil
+	if err := svc.RunOperation(ctx, op.ID); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	got, _ := repo.GetByID(ctx, tenant.ID)
+	if got.Status != domain.StatusActive || got.ExternalRefs["git"] != "https://git.example.com/pull/acme" {
+		t.Errorf("tenant = %+v, want active with the pull request in its references", got)
+	}
+	if ops.ops[op.ID].Status != domain.OperationSucceeded {
+		t.Errorf("operation status = %q, want succeeded", ops.ops[op.ID].Status)
+	}
+}
@@ -0,0 +1,248 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// SyncAction classifies what a reconciliation did (or would do) to a tenant.
+type SyncAction string
+
+const (
+	SyncCreate     SyncAction = "create"
+	SyncUpdate     SyncAction = "update"
+	SyncUnchanged  SyncAction = "unchanged"
+	SyncExtraneous SyncAction = "extraneous"
+	SyncFailed     SyncAction = "failed"
+	// SyncDeferred is an update whose disruptive events wait for the
+	// tenant's maintenance window; a later run completes it.
+	SyncDeferred SyncAction = "deferred"
+)
+
+// SyncItem is the reconciliation outcome for a single tenant.
+type SyncItem struct {
+	Slug    string
+	Action  SyncAction
+	Changes []string
+	Error   string
+}
+
+// SyncReport summarizes a reconciliation run.
+type SyncReport struct {
+	DryRun bool
+	Items  []SyncItem
+}
+
+// Count returns how many items in the report have the given action.
+func (r SyncReport) Count(action SyncAction) int {
+	n := 0
+	for _, item := range r.Items {
+		if item.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// SyncOptions controls a reconciliation run.
+type SyncOptions struct {
+	// DryRun reports the changes without writing anything.
+	DryRun bool
+	// Force bypasses the service's guardrail on mass suspensions/deletions.
+	Force bool
+}
+
+// Sync reconciles stored tenants with the given specs: missing tenants are
+// created, drifted ones are updated, and tenants without a spec are flagged
+// as extraneous (never deleted automatically). With DryRun, nothing is
+// written and the report describes the changes that would be made.
+// A failure on one tenant is recorded in the report and does not stop the run;
+// so is a disruptive event deferred to the tenant's maintenance window.
+//
+// Before writing, the planned suspensions and deletions are checked against
+// the service guardrail. If it is exceeded (and Force is not set) nothing is
+// written: the planned report is returned together with a GuardrailError.
+// Forcing takes the admin role.
+func (s *TenantService) Sync(ctx context.Context, specs []domain.TenantSpec, opts SyncOptions) (SyncReport, error) {
+	if opts.Force {
+		if err := domain.RequireRole(ctx, domain.RoleAdmin, "forcing a sync past the guardrail"); err != nil {
+			return SyncReport{DryRun: opts.DryRun}, err
+		}
+	}
+
+	plans := make([]specPlan, len(specs))
+	declared := make(map[string]bool, len(specs))
+	disrupted := 0
+
+	for i, spec := range specs {
+		declared[spec.Slug] = true
+		plans[i] = s.planSpec(ctx, spec)
+		if plans[i].disruptive() {
+			disrupted++
+		}
+	}
+
+	extraneous, err := s.extraneous(ctx, declared)
+	if err != nil {
+		return SyncReport{DryRun: opts.DryRun}, err
+	}
+
+	if !opts.Force {
+		if err := s.checkGuardrail(ctx, disrupted); err != nil {
+			return plannedReport(plans, extraneous), err
+		}
+	}
+
+	if opts.DryRun {
+		return plannedReport(plans, extraneous), nil
+	}
+
+	report := SyncReport{}
+	for i, spec := range specs {
+		if plans[i].err != nil {
+			report.Items = append(report.Items, plans[i].item())
+			continue
+		}
+		report.Items = append(report.Items, s.applyItem(ctx, spec))
+	}
+	report.Items = append(report.Items, extraneous...)
+	return report, nil
+}
+
+// checkGuardrail verifies that disrupting the given number of tenants stays
+// within the configured guardrail.
+func (s *TenantService) checkGuardrail(ctx context.Context, disrupted int) error {
+	if disrupted == 0 || s.guardrail.MaxDisruptedPercent <= 0 {
+		return nil
+	}
+	active := domain.StatusActive
+	n, err := s.repo.Count(ctx, domain.ListFilter{Status: &active})
+	if err != nil {
+		return fmt.Errorf("counting active tenants: %w", err)
+	}
+	return s.guardrail.Check(disrupted, n)
+}
+
+// extraneous returns report items for stored tenants that have no spec.
+func (s *TenantService) extraneous(ctx context.Context, declared map[string]bool) ([]SyncItem, error) {
+	tenants, err := s.repo.List(ctx, domain.ListFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("listing tenants: %w", err)
+	}
+	var items []SyncItem
+	for _, t := range tenants {
+		if declared[t.Slug] || t.Status == domain.StatusDeleted {
+			continue
+		}
+		items = append(items, SyncItem{Slug: t.Slug, Action: SyncExtraneous})
+	}
+	return items, nil
+}
+
+func (s *TenantService) applyItem(ctx context.Context, spec domain.TenantSpec) SyncItem {
+	item := SyncItem{Slug: spec.Slug}
+	result, err := s.Apply(ctx, spec)
+	var deferred *domain.MaintenanceDeferredError
+	switch {
+	case errors.As(err, &deferred):
+		item.Action, item.Error = SyncDeferred, err.Error()
+	case err != nil:
+		item.Action, item.Error = SyncFailed, err.Error()
+	case result.Created:
+		item.Action, item.Changes = SyncCreate, result.Changes
+	case len(result.Changes) > 0:
+		item.Action, item.Changes = SyncUpdate, result.Changes
+	default:
+		item.Action = SyncUnchanged
+	}
+	return item
+}
+
+func plannedReport(plans []specPlan, extraneous []SyncItem) SyncReport {
+	report := SyncReport{DryRun: true}
+	for _, p := range plans {
+		report.Items = append(report.Items, p.item())
+	}
+	report.Items = append(report.Items, extraneous...)
+	return report
+}
+
+// specPlan is the set of changes Apply would make for one spec.
+type specPlan struct {
+	slug    string
+	exists  bool
+	changes []string
+	events  []domain.Event
+	err     error
+}
+
+// disruptive reports whether the plan suspends or deletes an existing tenant.
+func (p specPlan) disruptive() bool {
+	if !p.exists {
+		return false
+	}
+	for _, e := range p.events {
+		if domain.IsDisruptive(e) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p specPlan) item() SyncItem {
+	item := SyncItem{Slug: p.slug, Changes: p.changes}
+	switch {
+	case p.err != nil:
+		item.Action, item.Error = SyncFailed, p.err.Error()
+	case !p.exists:
+		item.Action = SyncCreate
+	case len(p.changes) > 0:
+		item.Action = SyncUpdate
+	default:
+		item.Action = SyncUnchanged
+	}
+	return item
+}
+
+// planSpec computes the changes Apply would make for spec without writing.
+func (s *TenantService) planSpec(ctx context.Context, spec domain.TenantSpec) specPlan {
+	plan := specPlan{slug: spec.Slug, exists: true}
+
+	tenant, err := s.repo.GetBySlug(ctx, spec.Slug)
+	switch {
+	case errors.Is(err, domain.ErrTenantNotFound):
+		plan.exists = false
+		tenant = domain.NewTenant("", spec.Name, spec.Slug, cmp.Or(spec.Plan, DefaultPlan))
+		plan.changes = append(plan.changes, "created")
+	case err != nil:
+		plan.err = err
+		return plan
+	}
+
+	plan.changes = append(plan.changes, fieldChanges(tenant, spec)...)
+
+	added, removed, err := s.specDomains(ctx, tenant.ID, spec.Domains)
+	if err != nil {
+		plan.err = err
+		return plan
+	}
+	plan.changes = append(plan.changes, domainChanges(added, removed)...)
+
+	if spec.Status != "" && spec.Status != tenant.Status {
+		path, ok := domain.PathTo(tenant.Status, spec.Status)
+		if !ok {
+			plan.err = &domain.UnreachableStatusError{Current: tenant.Status, Target: spec.Status}
+			return plan
+		}
+		plan.events = path
+		for _, event := range path {
+			plan.changes = append(plan.changes, "event: "+string(event))
+		}
+	}
+
+	return plan
+}
@@ -0,0 +1,108 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestSync_GuardrailAndForce(t *testing.T) {
+	suspend := func(slugs ...string) []domain.TenantSpec {
+		var specs []domain.TenantSpec
+		for _, slug := range slugs {
+			specs = append(specs, domain.TenantSpec{Slug: slug, Status: domain.StatusSuspended})
+		}
+		return specs
+	}
+
+	tests := []struct {
+		name        string
+		role        domain.Role
+		specs       []domain.TenantSpec
+		force       bool
+		wantErr     any
+		wantUpdates int
+		wantStatus  domain.Status
+	}{
+		{name: "within guardrail", specs: suspend("ten_1", "ten_2"), wantUpdates: 2, wantStatus: domain.StatusSuspended},
+		{name: "past guardrail", specs: suspend("ten_1", "ten_2", "ten_3"), wantErr: new(*domain.GuardrailError), wantStatus: domain.StatusActive},
+		{name: "operator forcing", role: domain.RoleOperator, specs: suspend("ten_1", "ten_2", "ten_3"), force: true, wantErr: new(*domain.ForbiddenError), wantStatus: domain.StatusActive},
+		{name: "admin forcing", role: domain.RoleAdmin, specs: suspend("ten_1", "ten_2", "ten_3"), force: true, wantUpdates: 3, wantStatus: domain.StatusSuspended},
+		{name: "forcing without a role", specs: suspend("ten_1", "ten_2", "ten_3"), force: true, wantUpdates: 3, wantStatus: domain.StatusSuspended},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepo()
+			svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+				app.WithGuardrail(domain.Guardrail{MaxDisruptedPercent: 50}),
+			)
+			for _, id := range []string{"ten_1", "ten_2", "ten_3", "ten_4"} {
+				newActiveTenant(t, repo, id, "free")
+			}
+			ctx := context.Background()
+			if tt.role != "" {
+				ctx = domain.WithRole(ctx, tt.role)
+			}
+
+			report, err := svc.Sync(ctx, tt.specs, app.SyncOptions{Force: tt.force})
+			if tt.wantErr != nil {
+				if !errors.As(err, tt.wantErr) {
+					t.Fatalf("Sync = %v, want %T", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Sync: %v", err)
+			}
+			if got := report.Count(app.SyncUpdate); err == nil && got != tt.wantUpdates {
+				t.Errorf("updates = %d, want %d (%+v)", got, tt.wantUpdates, report.Items)
+			}
+			if got := repo.get("ten_1"); got.Status != tt.wantStatus {
+				t.Errorf("ten_1 status = %q, want %q", got.Status, tt.wantStatus)
+			}
+			if got := repo.get("ten_4"); got.Status != domain.StatusActive {
+				t.Errorf("ten_4 is not in the spec but status = %q", got.Status)
+			}
+		})
+	}
+}
+
+func TestSync_OmittedPlan(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       domain.TenantSpec
+		wantAction app.SyncAction
+		wantPlan   string
+	}{
+		{name: "existing tenant keeps its plan", spec: domain.TenantSpec{Slug: "ten_1"}, wantAction: app.SyncUnchanged, wantPlan: "pro"},
+		{name: "rename keeps the plan", spec: domain.TenantSpec{Slug: "ten_1", Name: "Renamed"}, wantAction: app.SyncUpdate, wantPlan: "pro"},
+		{name: "explicit plan replaces it", spec: domain.TenantSpec{Slug: "ten_1", Plan: "enterprise"}, wantAction: app.SyncUpdate, wantPlan: "enterprise"},
+		{name: "new tenant gets the default plan", spec: domain.TenantSpec{Slug: "globex", Name: "Globex"}, wantAction: app.SyncCreate, wantPlan: app.DefaultPlan},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepo()
+			svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{})
+			newActiveTenant(t, repo, "ten_1", "pro")
+			ctx := context.Background()
+
+			report, err := svc.Sync(ctx, []domain.TenantSpec{tt.spec}, app.SyncOptions{})
+			if err != nil {
+				t.Fatalf("Sync: %v", err)
+			}
+			if got := report.Count(tt.wantAction); got != 1 {
+				t.Errorf("report = %+v, want one %q", report.Items, tt.wantAction)
+			}
+			got, err := repo.GetBySlug(ctx, tt.spec.Slug)
+			if err != nil {
+				t.Fatalf("GetBySlug: %v", err)
+			}
+			if got.Plan != tt.wantPlan {
+				t.Errorf("plan = %q, want %q", got.Plan, tt.wantPlan)
+			}
+		})
+	}
+}
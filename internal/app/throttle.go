@@ -0,0 +1,170 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// ThrottleConfig sets how many events a single tenant may publish.
+type ThrottleConfig struct {
+	// PerMinute is the sustained rate of a tenant's events (the refill rate
+	// of its token bucket); zero disables rate limiting.
+	PerMinute int
+	// Burst is how many events a tenant may publish at once (the size of its
+	// bucket); it defaults to PerMinute.
+	Burst int
+	// CoalesceWindow is how long an event identical to the tenant's previous
+	// one is dropped; zero disables coalescing.
+	CoalesceWindow time.Duration
+}
+
+// maxThrottledTenants bounds the tenants an EventThrottle tracks before it
+// forgets the idle ones.
+const maxThrottledTenants = 10000
+
+// EventThrottle reduces the noise of a tenant publishing events in storms,
+// e.g. a flapping health check. It is soft: events over a tenant's rate are
+// dropped, and so is an event identical to the tenant's previous one (same
+// event and payload) within the coalescing window. Lifecycle events always
+// pass, since consumers act on every one of them. Dropped events are logged
+// and the publish succeeds.
+//
+// One throttle keeps the state of every publisher it wraps, so events
+// published in transactions (see WithUnitOfWork) share the tenants' budgets.
+type EventThrottle struct {
+	cfg ThrottleConfig
+
+	mu      sync.Mutex
+	tenants map[string]*tenantThrottle
+}
+
+// tenantThrottle is the token bucket and last published event of a tenant.
+type tenantThrottle struct {
+	tokens   float64
+	refilled time.Time
+	last     string
+	lastAt   time.Time
+}
+
+// NewEventThrottle creates a throttle with cfg.
+func NewEventThrottle(cfg ThrottleConfig) *EventThrottle {
+	if cfg.Burst <= 0 {
+		cfg.Burst = cfg.PerMinute
+	}
+	return &EventThrottle{cfg: cfg, tenants: make(map[string]*tenantThrottle)}
+}
+
+// Publisher returns next throttled by t.
+func (t *EventThrottle) Publisher(next domain.EventPublisher) domain.EventPublisher {
+	return &throttledPublisher{throttle: t, next: next}
+}
+
+type throttledPublisher struct {
+	throttle *EventThrottle
+	next     domain.EventPublisher
+}
+
+func (p *throttledPublisher) Publish(ctx context.Context, e domain.TenantEvent) error {
+	key := eventFingerprint(e)
+	if reason := p.throttle.take(e.Event, e.Tenant.ID, key); reason != "" {
+		slog.WarnContext(ctx, "event dropped",
+			"event", e.Event,
+			"tenant_id", e.Tenant.ID,
+			"reason", reason,
+		)
+		return nil
+	}
+	if err := p.next.Publish(ctx, e); err != nil {
+		// A retry must not be taken for a duplicate or pay twice.
+		p.throttle.refund(e.Tenant.ID)
+		return err
+	}
+	p.throttle.published(e.Tenant.ID, key)
+	return nil
+}
+
+// take spends a token of the tenant's bucket on event, or returns why the
+// event is dropped: "coalesced" or "rate_limited".
+func (t *EventThrottle) take(event domain.Event, tenantID, key string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	tt := t.tenant(tenantID, now)
+	lifecycle := slices.Contains(domain.Events(), event)
+	if t.cfg.CoalesceWindow > 0 && !lifecycle && tt.last == key && now.Sub(tt.lastAt) < t.cfg.CoalesceWindow {
+		return "coalesced"
+	}
+	if t.cfg.PerMinute <= 0 {
+		return ""
+	}
+
+	tt.tokens = min(float64(t.cfg.Burst), tt.tokens+now.Sub(tt.refilled).Minutes()*float64(t.cfg.PerMinute))
+	tt.refilled = now
+	if tt.tokens < 1 && !lifecycle {
+		return "rate_limited"
+	}
+	tt.tokens = max(0, tt.tokens-1)
+	return ""
+}
+
+// refund gives back the token of an event that failed to publish.
+func (t *EventThrottle) refund(tenantID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if tt, ok := t.tenants[tenantID]; ok && t.cfg.PerMinute > 0 {
+		tt.tokens = min(float64(t.cfg.Burst), tt.tokens+1)
+	}
+}
+
+// published records the tenant's last published event.
+func (t *EventThrottle) published(tenantID, key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if tt, ok := t.tenants[tenantID]; ok {
+		tt.last, tt.lastAt = key, time.Now()
+	}
+}
+
+// tenant returns the tenant's state, starting with a full bucket. When too
+// many tenants are tracked, the idle ones, whose bucket would be full again
+// and whose last event can no longer be coalesced, are forgotten first.
+func (t *EventThrottle) tenant(id string, now time.Time) *tenantThrottle {
+	if tt, ok := t.tenants[id]; ok {
+		return tt
+	}
+	if len(t.tenants) >= maxThrottledTenants {
+		idle := t.cfg.CoalesceWindow
+		if t.cfg.PerMinute > 0 {
+			idle = max(idle, time.Duration(float64(t.cfg.Burst)/float64(t.cfg.PerMinute)*float64(time.Minute)))
+		}
+		for tid, tt := range t.tenants {
+			if now.Sub(tt.refilled) >= idle && now.Sub(tt.lastAt) >= idle {
+				delete(t.tenants, tid)
+			}
+		}
+	}
+	tt := &tenantThrottle{tokens: float64(t.cfg.Burst), refilled: now}
+	t.tenants[id] = tt
+	return tt
+}
+
+// eventFingerprint identifies an event by its whole payload, leaving out
+// what changes on every publish (event ID, time, tenant version and update
+// time). Maps are printed sorted, so equal payloads print the same.
+func eventFingerprint(e domain.TenantEvent) string {
+	tenant := e.Tenant
+	tenant.Version, tenant.UpdatedAt = 0, time.Time{}
+	var cert domain.Certificate
+	if e.Certificate != nil {
+		cert = *e.Certificate
+		cert.UpdatedAt = time.Time{}
+	}
+	return fmt.Sprintf("%s|%+v|%+v|%+v", e.Event, tenant, e.Changes, cert)
+}
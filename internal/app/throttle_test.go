@@ -0,0 +1,133 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestEventThrottle_RateLimitsNotificationsOnly(t *testing.T) {
+	pub := &mockPublisher{}
+	throttled := app.NewEventThrottle(app.ThrottleConfig{PerMinute: 1, Burst: 2}).Publisher(pub)
+	ctx := context.Background()
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "free")
+
+	for i := range 3 {
+		tenant.Version = i
+		changes := []domain.FieldChange{{Field: "git_branch", After: string(rune('a' + i))}}
+		if err := throttled.Publish(ctx, domain.TenantEvent{Event: domain.EventMetadataUpdated, Tenant: tenant, Changes: changes}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	if err := throttled.Publish(ctx, domain.TenantEvent{Event: domain.EventSuspend, Tenant: tenant}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	// Another tenant has its own budget.
+	if err := throttled.Publish(ctx, domain.TenantEvent{Event: domain.EventMetadataUpdated, Tenant: domain.NewTenant("ten_2", "Globex", "globex", "free")}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	var got []domain.Event
+	for _, e := range pub.events {
+		got = append(got, e.event)
+	}
+	want := []domain.Event{domain.EventMetadataUpdated, domain.EventMetadataUpdated, domain.EventSuspend, domain.EventMetadataUpdated}
+	if len(got) != len(want) {
+		t.Fatalf("published %v, want %v: the third update dropped, the suspension kept", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("published %v, want %v", got, want)
+		}
+	}
+}
+
+func TestEventThrottle_CoalescesIdenticalConsecutiveEvents(t *testing.T) {
+	pub := &mockPublisher{}
+	throttled := app.NewEventThrottle(app.ThrottleConfig{CoalesceWindow: 50 * time.Millisecond}).Publisher(pub)
+	ctx := context.Background()
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "free")
+
+	publish := func(event domain.Event) {
+		t.Helper()
+		tenant.Version++ // identical events still differ in version
+		if err := throttled.Publish(ctx, domain.TenantEvent{Event: event, Tenant: tenant}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	publish(domain.EventPlanSuggested)
+	publish(domain.EventPlanSuggested) // coalesced
+	publish(domain.EventDunningWarning)
+	publish(domain.EventPlanSuggested) // not consecutive
+	time.Sleep(60 * time.Millisecond)
+	publish(domain.EventPlanSuggested) // past the window
+
+	if len(pub.events) != 4 {
+		t.Errorf("published %d events, want 4 with the duplicate coalesced", len(pub.events))
+	}
+}
+
+func TestEventThrottle_CoalescesOnTheWholePayload(t *testing.T) {
+	pub := &mockPublisher{}
+	throttled := app.NewEventThrottle(app.ThrottleConfig{CoalesceWindow: time.Minute}).Publisher(pub)
+	ctx := context.Background()
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "free")
+
+	publish := func(event domain.Event, tenant domain.Tenant) {
+		t.Helper()
+		if err := throttled.Publish(ctx, domain.TenantEvent{Event: event, Tenant: tenant}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	publish(domain.EventMetadataUpdated, tenant)
+	tenant.Metadata = map[string]string{"crm_id": "42"}
+	publish(domain.EventMetadataUpdated, tenant)
+	tenant.Tags = []string{"beta"}
+	publish(domain.EventMetadataUpdated, tenant)
+	tenant.ExternalRefs = map[string]string{"argocd_app": "acme"}
+	publish(domain.EventMetadataUpdated, tenant)
+	publish(domain.EventMetadataUpdated, tenant) // coalesced
+
+	if len(pub.events) != 4 {
+		t.Errorf("published %d events, want 4: only the last one identical", len(pub.events))
+	}
+}
+
+func TestEventThrottle_NeverCoalescesLifecycleEvents(t *testing.T) {
+	pub := &mockPublisher{}
+	throttled := app.NewEventThrottle(app.ThrottleConfig{CoalesceWindow: time.Minute}).Publisher(pub)
+	ctx := context.Background()
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "free")
+	tenant.Status = domain.StatusSuspended
+
+	for range 2 {
+		if err := throttled.Publish(ctx, domain.TenantEvent{Event: domain.EventSuspend, Tenant: tenant}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	if len(pub.events) != 2 {
+		t.Errorf("published %d events, want both suspensions", len(pub.events))
+	}
+}
+
+func TestEventThrottle_FailedPublishIsRetried(t *testing.T) {
+	pub := &mockPublisher{publishErr: errors.New("broker down")}
+	throttled := app.NewEventThrottle(app.ThrottleConfig{PerMinute: 1, CoalesceWindow: time.Minute}).Publisher(pub)
+	ctx := context.Background()
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "free")
+
+	if err := throttled.Publish(ctx, domain.TenantEvent{Event: domain.EventPlanSuggested, Tenant: tenant}); err == nil {
+		t.Fatal("Publish succeeded with a failing publisher")
+	}
+	pub.publishErr = nil
+	if err := throttled.Publish(ctx, domain.TenantEvent{Event: domain.EventPlanSuggested, Tenant: tenant}); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if len(pub.events) != 1 {
+		t.Errorf("published %d events, want the retry neither coalesced nor rate limited", len(pub.events))
+	}
+}
@@ -0,0 +1,32 @@
+package app
+
+import "context"
+
+// SpanEnd ends the span of an operation with its outcome and the
+// attributes known once it ran, as alternating keys and values like
+// slog's.
+type SpanEnd func(err error, kv ...any)
+
+// ServiceTracer traces the operations of TenantService with their
+// business attributes (tenant, plan, event), so traces show the time
+// spent in the service between the request and the repository calls.
+type ServiceTracer interface {
+	// Start begins the span of operation, described by kv, and returns
+	// the context of the calls it makes.
+	Start(ctx context.Context, operation string, kv ...any) (context.Context, SpanEnd)
+}
+
+// WithServiceTracer traces Create, Update, Transition and List with t.
+func WithServiceTracer(t ServiceTracer) Option {
+	return func(s *TenantService) {
+		s.tracer = t
+	}
+}
+
+// trace starts the span of operation when a tracer is configured.
+func (s *TenantService) trace(ctx context.Context, operation string, kv ...any) (context.Context, SpanEnd) {
+	if s.tracer == nil {
+		return ctx, func(error, ...any) {}
+	}
+	return s.tracer.Start(ctx, operation, kv...)
+}
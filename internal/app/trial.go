@@ -0,0 +1,127 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// TrialService ends the free trials that have expired: the tenant is
+// downgraded to a configured plan or, without one, suspended. Either way
+// the trial is cleared, the change is audited and EventTrialExpired is
+// published with it.
+type TrialService struct {
+	tenants *TenantService
+	// downgradeTo is the plan expired trials move to; empty suspends them.
+	downgradeTo string
+}
+
+// NewTrialService creates a trial service acting on the tenants of svc.
+// Expired trials are downgraded to the downgradeTo plan, or suspended when
+// it is empty.
+func NewTrialService(svc *TenantService, downgradeTo string) *TrialService {
+	return &TrialService{tenants: svc, downgradeTo: downgradeTo}
+}
+
+// TrialAction is what happened to a tenant whose trial expired.
+type TrialAction string
+
+const (
+	TrialSuspended  TrialAction = "suspended"
+	TrialDowngraded TrialAction = "downgraded"
+)
+
+// TrialItem is an expired trial that was ended, or failed to be.
+type TrialItem struct {
+	TenantID    string
+	Slug        string
+	TrialEndsAt time.Time
+	Action      TrialAction
+	// DeferredUntil is set when the suspension waits for the tenant's
+	// maintenance window; the trial is ended then.
+	DeferredUntil time.Time
+	Error         string
+}
+
+// TrialReport summarizes a run of Expire.
+type TrialReport struct {
+	DryRun bool
+	Items  []TrialItem
+}
+
+// Expire ends the trials of the active tenants that expired at now. With
+// dryRun nothing is changed and the report tells what would be. A tenant
+// that fails, or whose suspension is deferred to a maintenance window,
+// keeps its trial and is retried on the next run.
+func (s *TrialService) Expire(ctx context.Context, now time.Time, dryRun bool) (TrialReport, error) {
+	report := TrialReport{DryRun: dryRun}
+
+	tenants, err := s.tenants.repo.List(ctx, domain.ListFilter{
+		Statuses:    []domain.Status{domain.StatusActive},
+		TrialEndsBy: now,
+	})
+	if err != nil {
+		return report, fmt.Errorf("listing tenants: %w", err)
+	}
+
+	action := TrialSuspended
+	if s.downgradeTo != "" {
+		action = TrialDowngraded
+	}
+	for _, tenant := range tenants {
+		item := TrialItem{TenantID: tenant.ID, Slug: tenant.Slug, TrialEndsAt: tenant.TrialEndsAt, Action: action}
+		if !dryRun {
+			var deferred *domain.MaintenanceDeferredError
+			err := s.expire(ctx, tenant)
+			switch {
+			case errors.As(err, &deferred):
+				item.DeferredUntil = deferred.Until
+			case err != nil:
+				item.Error = err.Error()
+			}
+		}
+		report.Items = append(report.Items, item)
+	}
+	return report, nil
+}
+
+// expire suspends the tenant when no downgrade plan is set, then clears
+// its trial (downgrading it otherwise) and announces the expiry. The
+// suspension comes first so a deferred or rejected one leaves the trial in
+// place for the next run.
+func (s *TrialService) expire(ctx context.Context, tenant domain.Tenant) error {
+	if s.downgradeTo == "" {
+		suspended, err := s.tenants.Transition(ctx, tenant.ID, domain.EventSuspend)
+		if err != nil {
+			return fmt.Errorf("suspending tenant: %w", err)
+		}
+		tenant = suspended
+	}
+
+	before := tenant
+	tenant.TrialEndsAt = time.Time{}
+	if s.downgradeTo != "" {
+		if err := s.tenants.checkPlan(ctx, s.downgradeTo, tenant.Region); err != nil {
+			return err
+		}
+		tenant.Plan = s.downgradeTo
+	}
+	tenant.UpdatedAt = time.Now().UTC()
+	if err := s.tenants.repo.Update(ctx, tenant); err != nil {
+		return fmt.Errorf("ending trial: %w", err)
+	}
+	tenant.Version++
+
+	if err := s.tenants.audit(ctx, domain.NewAuditEntry(ctx, domain.AuditTrialExpiry, &before, &tenant)); err != nil {
+		return err
+	}
+	ctx = s.tenants.withPriority(ctx, tenant)
+	e := domain.TenantEvent{Event: domain.EventTrialExpired, Tenant: tenant, Changes: domain.Diff(before, tenant)}
+	if err := s.tenants.publisher.Publish(ctx, e); err != nil {
+		return fmt.Errorf("publishing event %q: %w", domain.EventTrialExpired, err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// trialTenant returns an active tenant whose trial ends at endsAt.
+func trialTenant(id string, endsAt time.Time) domain.Tenant {
+	tenant := domain.NewTenant(id, "Tenant "+id, id, "trial")
+	tenant.Status = domain.StatusActive
+	tenant.TrialEndsAt = endsAt
+	return tenant
+}
+
+func TestExpireTrials_SuspendsExpiredTrials(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := newMockRepo()
+	repo.set(t, trialTenant("ten_expired", now.Add(-time.Hour)))
+	repo.set(t, trialTenant("ten_running", now.Add(time.Hour)))
+	repo.set(t, trialTenant("ten_paid", time.Time{}))
+
+	pub, audit := &mockPublisher{}, &mockAudit{}
+	svc := app.NewTenantService(repo, pub, &mockValidator{}, app.WithAuditLogger(audit))
+
+	report, err := app.NewTrialService(svc, "").Expire(context.Background(), now, false)
+	if err != nil {
+		t.Fatalf("Expire: %v", err)
+	}
+	if len(report.Items) != 1 || report.Items[0].TenantID != "ten_expired" || report.Items[0].Action != app.TrialSuspended || report.Items[0].Error != "" {
+		t.Fatalf("report items = %+v, want only ten_expired suspended", report.Items)
+	}
+
+	got := repo.get("ten_expired")
+	if got.Status != domain.StatusSuspended || !got.TrialEndsAt.IsZero() || got.Plan != "trial" {
+		t.Errorf("tenant = %s on %s, trial ends %v; want suspended on trial with the trial cleared", got.Status, got.Plan, got.TrialEndsAt)
+	}
+	if running := repo.get("ten_running"); running.Status != domain.StatusActive || running.TrialEndsAt.IsZero() {
+		t.Errorf("running trial = %+v, want untouched", running)
+	}
+	if len(pub.events) != 2 || pub.events[0].event != domain.EventSuspend || pub.events[1].event != domain.EventTrialExpired {
+		t.Errorf("published %+v, want suspend then trial_expired", pub.events)
+	}
+	if last := audit.entries[len(audit.entries)-1]; last.Action != domain.AuditTrialExpiry || last.Before.TrialEndsAt.IsZero() {
+		t.Errorf("last audit entry = %+v, want a trial expiry with the trial before it", last)
+	}
+}
+
+func TestExpireTrials_DowngradesWhenPlanConfigured(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := newMockRepo()
+	repo.set(t, trialTenant("ten_1", now))
+
+	pub := &mockPublisher{}
+	svc := app.NewTenantService(repo, pub, &mockValidator{})
+
+	report, err := app.NewTrialService(svc, "free").Expire(context.Background(), now, false)
+	if err != nil {
+		t.Fatalf("Expire: %v", err)
+	}
+	if len(report.Items) != 1 || report.Items[0].Action != app.TrialDowngraded || report.Items[0].Error != "" {
+		t.Fatalf("report items = %+v, want ten_1 downgraded", report.Items)
+	}
+
+	got := repo.get("ten_1")
+	if got.Status != domain.StatusActive || got.Plan != "free" || !got.TrialEndsAt.IsZero() {
+		t.Errorf("tenant = %s on %s, trial ends %v; want active on free with the trial cleared", got.Status, got.Plan, got.TrialEndsAt)
+	}
+	if len(pub.events) != 1 || pub.events[0].event != domain.EventTrialExpired || pub.events[0].tenant.Plan != "free" {
+		t.Errorf("published %+v, want one trial_expired with the new plan", pub.events)
+	}
+}
+
+func TestExpireTrials_DeferredSuspensionKeepsTheTrial(t *testing.T) {
+	now := time.Now().UTC()
+	repo := newMockRepo()
+	repo.set(t, trialTenant("ten_1", now.Add(-time.Hour)))
+
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{},
+		app.WithMaintenanceWindows(&mockMaintenance{windows: map[string]domain.MaintenanceSchedule{"ten_1": closedSchedule()}}))
+
+	report, err := app.NewTrialService(svc, "").Expire(domain.WithAutomation(context.Background()), now, false)
+	if err != nil {
+		t.Fatalf("Expire: %v", err)
+	}
+	if len(report.Items) != 1 || report.Items[0].DeferredUntil.IsZero() || report.Items[0].Error != "" {
+		t.Fatalf("report items = %+v, want the suspension deferred", report.Items)
+	}
+	if got := repo.get("ten_1"); got.Status != domain.StatusActive || got.TrialEndsAt.IsZero() {
+		t.Errorf("tenant = %s, trial ends %v; want active with the trial kept for the next run", got.Status, got.TrialEndsAt)
+	}
+}
+
+func TestExpireTrials_DryRunChangesNothing(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := newMockRepo()
+	repo.set(t, trialTenant("ten_1", now.Add(-time.Hour)))
+
+	pub := &mockPublisher{}
+	svc := app.NewTenantService(repo, pub, &mockValidator{})
+
+	report, err := app.NewTrialService(svc, "").Expire(context.Background(), now, true)
+	if err != nil {
+		t.Fatalf("Expire: %v", err)
+	}
+	if !report.DryRun || len(report.Items) != 1 {
+		t.Errorf("report = %+v, want ten_1 reported in a dry run", report)
+	}
+	if got := repo.get("ten_1"); got.Status != domain.StatusActive || got.TrialEndsAt.IsZero() || len(pub.events) != 0 {
+		t.Errorf("tenant = %+v and %d events published, want nothing changed", got, len(pub.events))
+	}
+}
@@ -0,0 +1,71 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockUnitOfWork hands fn the repository and a publisher whose events are
+// kept only when fn succeeds.
+type mockUnitOfWork struct {
+	repo      *mockRepo
+	committed []publishedEvent
+	err       error
+}
+
+func (m *mockUnitOfWork) Do(_ context.Context, fn func(domain.TenantRepository, domain.EventPublisher) error) error {
+	if m.err != nil {
+		return m.err
+	}
+	staged := &mockPublisher{}
+	if err := fn(m.repo, staged); err != nil {
+		return err
+	}
+	m.committed = append(m.committed, staged.events...)
+	return nil
+}
+
+func TestUnitOfWork_PublishesWithTheChange(t *testing.T) {
+	repo := newMockRepo()
+	pub := &mockPublisher{}
+	uow := &mockUnitOfWork{repo: repo}
+	svc := app.NewTenantService(repo, pub, &mockValidator{}, app.WithUnitOfWork(uow))
+	ctx := context.Background()
+
+	tenant, err := svc.Create(ctx, app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := svc.Transition(ctx, tenant.ID, domain.EventProvisionComplete); err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+	plan := "enterprise"
+	if _, err := svc.Update(ctx, tenant.ID, domain.TenantPatch{Plan: &plan}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if len(pub.events) != 0 {
+		t.Errorf("published %d events outside the unit of work", len(pub.events))
+	}
+	if len(uow.committed) != 3 || uow.committed[1].tenant.Status != domain.StatusActive ||
+		uow.committed[2].event != domain.EventPlanChanged || len(uow.committed[2].changes) != 1 {
+		t.Fatalf("committed events = %+v, want the creation, the activation and the plan change", uow.committed)
+	}
+}
+
+func TestUnitOfWork_FailureFailsTheChange(t *testing.T) {
+	repo := newMockRepo()
+	uow := &mockUnitOfWork{repo: repo, err: errors.New("database is locked")}
+	svc := app.NewTenantService(repo, &mockPublisher{}, &mockValidator{}, app.WithUnitOfWork(uow))
+
+	if _, err := svc.Create(context.Background(), app.CreateInput{Name: "Acme", Slug: "acme", Plan: "pro"}); !errors.Is(err, uow.err) {
+		t.Fatalf("Create error = %v, want the unit of work's", err)
+	}
+	if repo.len() != 0 {
+		t.Errorf("repo holds %d tenants, want none", repo.len())
+	}
+}
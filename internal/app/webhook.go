@@ -0,0 +1,145 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// WebhookService manages the subscriptions of outbound webhooks. Delivery
+// itself is asynchronous and handled by the queue adapter, which asks
+// ForEvent who to notify.
+type WebhookService struct {
+	repo domain.WebhookRepository
+	ids  IDGenerator
+}
+
+// NewWebhookService creates a webhook service backed by repo.
+func NewWebhookService(repo domain.WebhookRepository) *WebhookService {
+	return &WebhookService{repo: repo, ids: NewIDGenerator(WebhookIDPrefix)}
+}
+
+// Create registers a subscription for events (every event when empty),
+// delivered as digest says (each on its own when empty).
+func (s *WebhookService) Create(ctx context.Context, url, secret string, events []domain.Event, digest domain.DigestMode) (domain.WebhookSubscription, error) {
+	id, err := s.ids.New()
+	if err != nil {
+		return domain.WebhookSubscription{}, fmt.Errorf("generating webhook id: %w", err)
+	}
+
+	w, err := domain.NewWebhookSubscription(id, url, secret, events)
+	if err == nil {
+		w.Digest = cmp.Or(digest, domain.DigestImmediate)
+		err = w.Validate()
+	}
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	if err := s.repo.Create(ctx, w); err != nil {
+		return domain.WebhookSubscription{}, fmt.Errorf("creating webhook subscription: %w", err)
+	}
+	return w, nil
+}
+
+// Get returns a subscription by its identifier.
+func (s *WebhookService) Get(ctx context.Context, id string) (domain.WebhookSubscription, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// List returns every subscription, oldest first.
+func (s *WebhookService) List(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return s.repo.List(ctx)
+}
+
+// Update replaces a subscription's URL, event filter and digest mode. An
+// empty secret keeps the current one, so the secret need not be resent to
+// change the filter. Events already held for a digest are still delivered
+// together, at once when the subscription stops batching.
+func (s *WebhookService) Update(ctx context.Context, id, url, secret string, events []domain.Event, digest domain.DigestMode) (domain.WebhookSubscription, error) {
+	w, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	w.URL = url
+	w.Events = events
+	w.Digest = cmp.Or(digest, domain.DigestImmediate)
+	if secret != "" {
+		w.Secret = secret
+	}
+	if err := w.Validate(); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	w.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.Update(ctx, w); err != nil {
+		return domain.WebhookSubscription{}, fmt.Errorf("updating webhook subscription: %w", err)
+	}
+	return w, nil
+}
+
+// Delete removes a subscription. Deliveries already queued for it are dropped.
+func (s *WebhookService) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// RecordFailure counts a failed delivery to the subscription. The failure
+// that opens the circuit is logged as an error, for operators to be
+// alerted: deliveries are then held until Resume.
+func (s *WebhookService) RecordFailure(ctx context.Context, id string, cause error) error {
+	now := time.Now().UTC()
+	c, err := s.repo.RecordFailure(ctx, id, cause.Error(), now, domain.WebhookCircuitThreshold)
+	if err != nil {
+		return err
+	}
+	if c.OpenedAt.Equal(now) {
+		slog.ErrorContext(ctx, "webhook circuit opened: deliveries held until resumed",
+			"webhook_id", id,
+			"consecutive_failures", c.ConsecutiveFailures,
+			"last_error", c.LastError,
+		)
+	}
+	return nil
+}
+
+// RecordSuccess resets the failure count of a subscription that had
+// failures. It does not close an open circuit: only Resume does.
+func (s *WebhookService) RecordSuccess(ctx context.Context, w domain.WebhookSubscription) error {
+	if w.Circuit.ConsecutiveFailures == 0 {
+		return nil
+	}
+	return s.repo.RecordSuccess(ctx, w.ID)
+}
+
+// Resume closes the subscription's circuit, so the held deliveries are
+// attempted again.
+func (s *WebhookService) Resume(ctx context.Context, id string) (domain.WebhookSubscription, error) {
+	if err := s.repo.CloseCircuit(ctx, id); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	slog.InfoContext(ctx, "webhook circuit resumed",
+		"webhook_id", id,
+		"actor", domain.ActorFromContext(ctx),
+	)
+	return s.repo.GetByID(ctx, id)
+}
+
+// ForEvent returns the subscriptions whose filter matches event.
+func (s *WebhookService) ForEvent(ctx context.Context, event domain.Event) ([]domain.WebhookSubscription, error) {
+	all, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing webhook subscriptions: %w", err)
+	}
+
+	var matching []domain.WebhookSubscription
+	for _, w := range all {
+		if w.Matches(event) {
+			matching = append(matching, w)
+		}
+	}
+	return matching, nil
+}
@@ -0,0 +1,199 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/app"
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// mockWebhooks keeps subscriptions in memory, in creation order.
+type mockWebhooks struct {
+	subs []domain.WebhookSubscription
+}
+
+func (m *mockWebhooks) Create(_ context.Context, w domain.WebhookSubscription) error {
+	m.subs = append(m.subs, w)
+	return nil
+}
+
+func (m *mockWebhooks) GetByID(_ context.Context, id string) (domain.WebhookSubscription, error) {
+	for _, w := range m.subs {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+	return domain.WebhookSubscription{}, domain.ErrWebhookNotFound
+}
+
+func (m *mockWebhooks) List(context.Context) ([]domain.WebhookSubscription, error) {
+	return m.subs, nil
+}
+
+func (m *mockWebhooks) Update(_ context.Context, w domain.WebhookSubscription) error {
+	for i := range m.subs {
+		if m.subs[i].ID == w.ID {
+			m.subs[i] = w
+			return nil
+		}
+	}
+	return domain.ErrWebhookNotFound
+}
+
+func (m *mockWebhooks) Delete(_ context.Context, id string) error {
+	for i := range m.subs {
+		if m.subs[i].ID == id {
+			m.subs = append(m.subs[:i], m.subs[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrWebhookNotFound
+}
+
+func (m *mockWebhooks) RecordFailure(_ context.Context, id, reason string, at time.Time, threshold int) (domain.WebhookCircuit, error) {
+	for i := range m.subs {
+		if c := &m.subs[i].Circuit; m.subs[i].ID == id {
+			c.ConsecutiveFailures++
+			c.LastError, c.LastFailureAt = reason, at
+			if !c.Open() && c.ConsecutiveFailures >= threshold {
+				c.OpenedAt = at
+			}
+			return *c, nil
+		}
+	}
+	return domain.WebhookCircuit{}, domain.ErrWebhookNotFound
+}
+
+func (m *mockWebhooks) RecordSuccess(_ context.Context, id string) error {
+	for i := range m.subs {
+		if m.subs[i].ID == id {
+			m.subs[i].Circuit.ConsecutiveFailures = 0
+			return nil
+		}
+	}
+	return domain.ErrWebhookNotFound
+}
+
+func (m *mockWebhooks) CloseCircuit(_ context.Context, id string) error {
+	for i := range m.subs {
+		if m.subs[i].ID == id {
+			m.subs[i].Circuit.ConsecutiveFailures = 0
+			m.subs[i].Circuit.OpenedAt = time.Time{}
+			return nil
+		}
+	}
+	return domain.ErrWebhookNotFound
+}
+
+const testWebhookSecret = "0123456789abcdef"
+
+func TestWebhooks_CreateValidates(t *testing.T) {
+	ws := app.NewWebhookService(&mockWebhooks{})
+	ctx := context.Background()
+
+	w, err := ws.Create(ctx, "https://example.com/hook", testWebhookSecret, nil, "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !strings.HasPrefix(w.ID, app.WebhookIDPrefix) {
+		t.Errorf("ID = %q, want prefix %q", w.ID, app.WebhookIDPrefix)
+	}
+
+	_, err = ws.Create(ctx, "not a url", testWebhookSecret, nil, "")
+	var invalid *domain.InvalidWebhookError
+	if !errors.As(err, &invalid) {
+		t.Errorf("Create with bad URL = %v, want InvalidWebhookError", err)
+	}
+}
+
+func TestWebhooks_UpdateKeepsSecretWhenOmitted(t *testing.T) {
+	ws := app.NewWebhookService(&mockWebhooks{})
+	ctx := context.Background()
+
+	w, err := ws.Create(ctx, "https://example.com/hook", testWebhookSecret, nil, "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	updated, err := ws.Update(ctx, w.ID, "https://example.com/v2", "", []domain.Event{domain.EventDelete}, "")
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.Secret != testWebhookSecret || updated.URL != "https://example.com/v2" || len(updated.Events) != 1 {
+		t.Errorf("updated = %+v", updated)
+	}
+
+	if _, err := ws.Update(ctx, "wh_missing", "https://example.com", "", nil, ""); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("Update missing = %v, want ErrWebhookNotFound", err)
+	}
+}
+
+func TestWebhooks_ForEvent(t *testing.T) {
+	ws := app.NewWebhookService(&mockWebhooks{})
+	ctx := context.Background()
+
+	all, _ := ws.Create(ctx, "https://example.com/all", testWebhookSecret, nil, "")
+	deletes, _ := ws.Create(ctx, "https://example.com/deletes", testWebhookSecret, []domain.Event{domain.EventDelete}, "")
+
+	got, err := ws.ForEvent(ctx, domain.EventSuspend)
+	if err != nil {
+		t.Fatalf("ForEvent: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != all.ID {
+		t.Errorf("ForEvent(suspend) = %+v, want only %s", got, all.ID)
+	}
+
+	got, _ = ws.ForEvent(ctx, domain.EventDelete)
+	if len(got) != 2 || got[1].ID != deletes.ID {
+		t.Errorf("ForEvent(delete) = %+v, want both subscriptions", got)
+	}
+}
+
+func TestWebhooks_CircuitOpensAndResumes(t *testing.T) {
+	repo := &mockWebhooks{}
+	ws := app.NewWebhookService(repo)
+	ctx := context.Background()
+	w, err := ws.Create(ctx, "https://example.com/hook", testWebhookSecret, nil, "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	for range domain.WebhookCircuitThreshold - 1 {
+		if err := ws.RecordFailure(ctx, w.ID, errors.New("503 Service Unavailable")); err != nil {
+			t.Fatalf("RecordFailure: %v", err)
+		}
+	}
+	if got, _ := ws.Get(ctx, w.ID); got.Circuit.Open() {
+		t.Fatalf("circuit opened after %d failures", got.Circuit.ConsecutiveFailures)
+	}
+
+	// A success resets the count, so the threshold counts failures in a row.
+	got, _ := ws.Get(ctx, w.ID)
+	if err := ws.RecordSuccess(ctx, got); err != nil {
+		t.Fatalf("RecordSuccess: %v", err)
+	}
+	for range domain.WebhookCircuitThreshold {
+		_ = ws.RecordFailure(ctx, w.ID, errors.New("503 Service Unavailable"))
+	}
+	got, _ = ws.Get(ctx, w.ID)
+	if !got.Circuit.Open() || got.Circuit.ConsecutiveFailures != domain.WebhookCircuitThreshold || got.Circuit.LastError != "503 Service Unavailable" {
+		t.Fatalf("circuit = %+v, want open after %d failures", got.Circuit, domain.WebhookCircuitThreshold)
+	}
+
+	// Only Resume closes it.
+	_ = ws.RecordSuccess(ctx, got)
+	if got, _ := ws.Get(ctx, w.ID); !got.Circuit.Open() {
+		t.Error("a success closed the circuit")
+	}
+	resumed, err := ws.Resume(ctx, w.ID)
+	if err != nil || resumed.Circuit.Open() || resumed.Circuit.ConsecutiveFailures != 0 {
+		t.Errorf("Resume = %+v, %v; want a closed circuit", resumed.Circuit, err)
+	}
+	if _, err := ws.Resume(ctx, "wh_missing"); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("Resume of a missing subscription = %v, want ErrWebhookNotFound", err)
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// AdminKey is a credential of the management API, held by an operator or
+// an automation. Requests made with it are attributed to its name. Like
+// tenant API keys, only a hash of the key is kept.
+type AdminKey struct {
+	ID string
+	// Name identifies the holder, e.g. "alice" or "terraform"; it is the
+	// actor of the changes made with the key.
+	Name string
+	// Prefix is the start of the key, shown to tell keys apart.
+	Prefix string
+	// Hash is the SHA-256 of the key (see HashAPIKey).
+	Hash string
+	// Role is what the holder may do.
+	Role      Role
+	CreatedBy string
+	CreatedAt time.Time
+	// LastUsedAt is when the key was last used, within
+	// APIKeyTouchInterval; zero when it never was.
+	LastUsedAt time.Time
+	// RevokedAt is when the key was revoked; zero while it is not.
+	RevokedAt time.Time
+}
+
+// NewAdminKey returns the admin key for secret, after validating its name
+// and role. The secret is not kept.
+func NewAdminKey(id, name, secret string, role Role, createdBy string) (AdminKey, error) {
+	k := AdminKey{
+		ID:        id,
+		Name:      name,
+		Prefix:    secret[:min(len(secret), APIKeyDisplayLength)],
+		Hash:      HashAPIKey(secret),
+		Role:      role,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now().UTC(),
+	}
+	return k, k.Validate()
+}
+
+// Validate checks the name and the role.
+func (k AdminKey) Validate() error {
+	if k.Name == "" || len(k.Name) > MaxAPIKeyNameLength {
+		return &InvalidAPIKeyError{Reason: fmt.Sprintf("name must be 1 to %d bytes", MaxAPIKeyNameLength)}
+	}
+	if _, err := ParseRole(string(k.Role)); err != nil {
+		return &InvalidAPIKeyError{Reason: err.Error()}
+	}
+	return nil
+}
+
+// Active reports whether the key works: it is not revoked.
+func (k AdminKey) Active() bool {
+	return k.RevokedAt.IsZero()
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+// MaxAllowlistEntries bounds the networks of a tenant's IP allowlist.
+const MaxAllowlistEntries = 100
+
+// IPAllowlist is the networks a tenant's API keys may be used from. An
+// empty list allows every address.
+type IPAllowlist []netip.Prefix
+
+// ParseIPAllowlist parses networks in CIDR notation, such as
+// "203.0.113.0/24"; a bare address stands for itself alone. Networks are
+// masked to their prefix, sorted and deduplicated.
+func ParseIPAllowlist(entries []string) (IPAllowlist, error) {
+	if len(entries) > MaxAllowlistEntries {
+		return nil, &InvalidIPAllowlistError{Reason: fmt.Sprintf("at most %d networks", MaxAllowlistEntries)}
+	}
+	l := make(IPAllowlist, 0, len(entries))
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		p, err := netip.ParsePrefix(e)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(e)
+			if addrErr != nil || addr.Zone() != "" {
+				return nil, &InvalidIPAllowlistError{Reason: fmt.Sprintf("%q is not a CIDR network or an IP address", e)}
+			}
+			addr = addr.Unmap()
+			p = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		l = append(l, p.Masked())
+	}
+	slices.SortFunc(l, func(a, b netip.Prefix) int {
+		return cmp.Or(a.Addr().Compare(b.Addr()), cmp.Compare(a.Bits(), b.Bits()))
+	})
+	return slices.Compact(l), nil
+}
+
+// Allows reports whether addr belongs to one of the networks, or the list
+// is empty.
+func (l IPAllowlist) Allows(addr netip.Addr) bool {
+	if len(l) == 0 {
+		return true
+	}
+	addr = addr.Unmap()
+	return slices.ContainsFunc(l, func(p netip.Prefix) bool { return p.Contains(addr) })
+}
+
+// Strings returns the networks in CIDR notation.
+func (l IPAllowlist) Strings() []string {
+	out := make([]string, len(l))
+	for i, p := range l {
+		out[i] = p.String()
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+package domain_test
+
+import (
+	"errors"
+	"net/netip"
+	"slices"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestParseIPAllowlist(t *testing.T) {
+	l, err := domain.ParseIPAllowlist([]string{"203.0.113.7/24", " 198.51.100.1 ", "2001:db8::/32", "203.0.113.0/24", "::ffff:192.0.2.1"})
+	if err != nil {
+		t.Fatalf("ParseIPAllowlist: %v", err)
+	}
+	want := []string{"192.0.2.1/32", "198.51.100.1/32", "203.0.113.0/24", "2001:db8::/32"}
+	if got := l.Strings(); !slices.Equal(got, want) {
+		t.Errorf("networks = %v, want %v", got, want)
+	}
+
+	for _, entries := range [][]string{
+		{"203.0.113.0/33"},
+		{"example.com"},
+		{"fe80::1%eth0"},
+		make([]string, domain.MaxAllowlistEntries+1),
+	} {
+		var invalid *domain.InvalidIPAllowlistError
+		if _, err := domain.ParseIPAllowlist(entries); !errors.As(err, &invalid) {
+			t.Errorf("ParseIPAllowlist(%.3q) = %v, want InvalidIPAllowlistError", entries, err)
+		}
+	}
+}
+
+func TestIPAllowlist_Allows(t *testing.T) {
+	l, _ := domain.ParseIPAllowlist([]string{"203.0.113.0/24", "2001:db8::/32"})
+	for addr, want := range map[string]bool{
+		"203.0.113.200":        true,
+		"::ffff:203.0.113.200": true,
+		"2001:db8::1":          true,
+		"198.51.100.1":         false,
+		"2001:db9::1":          false,
+	} {
+		if got := l.Allows(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("Allows(%s) = %v, want %v", addr, got, want)
+		}
+	}
+	if !domain.IPAllowlist(nil).Allows(netip.MustParseAddr("198.51.100.1")) {
+		t.Error("an empty allowlist refused an address")
+	}
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Limits of API keys.
+const (
+	MaxAPIKeyNameLength = 100
+	MaxAPIKeyScopes     = 20
+	MaxScopeLength      = 64
+	// APIKeyDisplayLength is how many leading characters of a key are kept
+	// in clear, so people can tell their keys apart.
+	APIKeyDisplayLength = 12
+	// APIKeyTouchInterval is how stale LastUsedAt may get: a key used
+	// more often is only recorded as used once per interval.
+	APIKeyTouchInterval = time.Minute
+)
+
+// ScopeStatusRead lets a key read its tenant's status through the
+// embeddable status widget, the one scope tenantiq grants itself.
+const ScopeStatusRead = "status:read"
+
+// scopePattern accepts scopes such as "read", "tenants:write" or
+// "billing.invoices:read".
+var scopePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*(:[a-z0-9_.*-]+)*$`)
+
+// APIKey is a machine credential of a tenant, which the tenant's
+// applications verify against tenantiq. Only a hash of the key is kept;
+// the key itself is shown once, when it is created.
+type APIKey struct {
+	ID       string
+	TenantID string
+	Name     string
+	// Prefix is the start of the key, shown to tell keys apart.
+	Prefix string
+	// Hash is the SHA-256 of the key (see HashAPIKey).
+	Hash string
+	// Scopes are what the key may do; the applications accepting it
+	// decide what each one grants.
+	Scopes    []string
+	CreatedBy string
+	CreatedAt time.Time
+	// ExpiresAt is when the key stops working; zero when it does not
+	// expire.
+	ExpiresAt time.Time
+	// LastUsedAt is when the key was last verified, within
+	// APIKeyTouchInterval; zero when it never was.
+	LastUsedAt time.Time
+	// RevokedAt is when the key was revoked; zero while it is not.
+	RevokedAt time.Time
+}
+
+// NewAPIKey returns the key of a tenant for secret, after validating its
+// name and scopes. The secret is not kept.
+func NewAPIKey(id, tenantID, name, secret string, scopes []string, expiresAt time.Time, createdBy string) (APIKey, error) {
+	k := APIKey{
+		ID:        id,
+		TenantID:  tenantID,
+		Name:      name,
+		Prefix:    secret[:min(len(secret), APIKeyDisplayLength)],
+		Hash:      HashAPIKey(secret),
+		Scopes:    scopes,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: expiresAt,
+	}
+	return k, k.Validate()
+}
+
+// Validate checks the name, the scopes and that the key does not expire
+// before it is created.
+func (k APIKey) Validate() error {
+	if k.Name == "" || len(k.Name) > MaxAPIKeyNameLength {
+		return &InvalidAPIKeyError{Reason: fmt.Sprintf("name must be 1 to %d bytes", MaxAPIKeyNameLength)}
+	}
+	if len(k.Scopes) > MaxAPIKeyScopes {
+		return &InvalidAPIKeyError{Reason: fmt.Sprintf("at most %d scopes", MaxAPIKeyScopes)}
+	}
+	for _, s := range k.Scopes {
+		if len(s) > MaxScopeLength || !scopePattern.MatchString(s) {
+			return &InvalidAPIKeyError{Reason: fmt.Sprintf("scope %q must be lowercase words separated by colons, such as tenants:read", s)}
+		}
+	}
+	if !k.ExpiresAt.IsZero() && !k.ExpiresAt.After(k.CreatedAt) {
+		return &InvalidAPIKeyError{Reason: "expiry must be in the future"}
+	}
+	return nil
+}
+
+// Active reports whether the key works at now: neither revoked nor
+// expired.
+func (k APIKey) Active(now time.Time) bool {
+	return k.RevokedAt.IsZero() && (k.ExpiresAt.IsZero() || now.Before(k.ExpiresAt))
+}
+
+// HasScope reports whether the key was given scope.
+func (k APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HashAPIKey returns the hex SHA-256 of secret. Keys are random enough for
+// a fast hash: there is nothing to guess from it.
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
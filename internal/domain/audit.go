@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AuditAction is the kind of change an audit entry records.
+type AuditAction string
+
+const (
+	AuditCreate     AuditAction = "create"
+	AuditUpdate     AuditAction = "update"
+	AuditTransition AuditAction = "transition"
+	AuditDelete     AuditAction = "delete"
+	AuditPurge      AuditAction = "purge"
+	// AuditTrialExpiry ends an expired trial, downgrading the plan when
+	// configured; a suspension is audited as its own transition.
+	AuditTrialExpiry AuditAction = "trial_expiry"
+)
+
+// AuditEntry records one mutation of a tenant for the compliance audit
+// trail. Before is nil for creations and After is nil for purges;
+// otherwise After is the tenant once changed.
+type AuditEntry struct {
+	Action    AuditAction
+	TenantID  string
+	Actor     string
+	RequestID string
+	// Subject and Issuer identify the caller at its identity provider when
+	// the request was authenticated with a token.
+	Subject string
+	Issuer  string
+	// ClientCN is the common name of the caller's TLS client certificate,
+	// when the server requires one (mutual TLS).
+	ClientCN string
+	// Event is the lifecycle event of transitions and deletions.
+	Event  Event
+	Before *Tenant
+	After  *Tenant
+	At     time.Time
+}
+
+// NewAuditEntry builds an entry attributed to the actor, request and
+// token claims carried by ctx.
+func NewAuditEntry(ctx context.Context, action AuditAction, before, after *Tenant) AuditEntry {
+	e := AuditEntry{
+		Action:    action,
+		Actor:     ActorFromContext(ctx),
+		RequestID: RequestIDFromContext(ctx),
+		ClientCN:  ClientCNFromContext(ctx),
+		Before:    before,
+		After:     after,
+		At:        time.Now().UTC(),
+	}
+	if c, ok := ClaimsFromContext(ctx); ok {
+		e.Subject, e.Issuer = c.Subject, c.Issuer
+	}
+	switch {
+	case after != nil:
+		e.TenantID = after.ID
+	case before != nil:
+		e.TenantID = before.ID
+	}
+	return e
+}
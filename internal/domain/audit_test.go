@@ -0,0 +1,38 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestNewAuditEntry(t *testing.T) {
+	ctx := domain.WithRequestID(domain.WithActor(context.Background(), "alice"), "req-1")
+	before := domain.NewTenant("ten_1", "Acme", "acme", "free")
+	after := before
+	after.Plan = "pro"
+
+	e := domain.NewAuditEntry(ctx, domain.AuditUpdate, &before, &after)
+	if e.TenantID != "ten_1" || e.Actor != "alice" || e.RequestID != "req-1" || e.At.IsZero() {
+		t.Errorf("entry = %+v", e)
+	}
+	if e.Before.Plan != "free" || e.After.Plan != "pro" {
+		t.Errorf("snapshots = %+v / %+v", e.Before, e.After)
+	}
+
+	created := domain.NewAuditEntry(context.Background(), domain.AuditCreate, nil, &after)
+	if created.TenantID != "ten_1" || created.Actor != domain.SystemActor || created.Before != nil || created.Subject != "" {
+		t.Errorf("create entry = %+v", created)
+	}
+
+	ctx = domain.WithClaims(ctx, domain.Claims{Subject: "user-42", Issuer: "https://id.example.com"})
+	if e := domain.NewAuditEntry(ctx, domain.AuditUpdate, &before, &after); e.Subject != "user-42" || e.Issuer != "https://id.example.com" {
+		t.Errorf("entry with claims = %+v, want the token's subject and issuer", e)
+	}
+
+	ctx = domain.WithClientCN(ctx, "billing.internal")
+	if e := domain.NewAuditEntry(ctx, domain.AuditUpdate, &before, &after); e.ClientCN != "billing.internal" {
+		t.Errorf("entry with client certificate = %+v, want its common name", e)
+	}
+}
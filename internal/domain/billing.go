@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SubscriptionStatus is the state of a subscription at the billing provider.
+type SubscriptionStatus string
+
+const (
+	SubscriptionActive   SubscriptionStatus = "active"
+	SubscriptionPastDue  SubscriptionStatus = "past_due"
+	SubscriptionCanceled SubscriptionStatus = "canceled"
+)
+
+// Subscription is what the billing provider charges a tenant for.
+type Subscription struct {
+	TenantID string
+	Plan     string
+	Status   SubscriptionStatus
+}
+
+// Paying reports whether the customer is (still) being charged. Past-due
+// subscriptions are charged until the provider cancels them.
+func (s Subscription) Paying() bool {
+	return s.Status == SubscriptionActive || s.Status == SubscriptionPastDue
+}
+
+// MismatchKind classifies a disagreement between tenants and billing.
+type MismatchKind string
+
+const (
+	// MismatchMissingSubscription is an active tenant nobody pays for.
+	MismatchMissingSubscription MismatchKind = "missing_subscription"
+	// MismatchPlan is an active tenant billed for another plan.
+	MismatchPlan MismatchKind = "plan_mismatch"
+	// MismatchSuspendedPaying is a suspended tenant whose customer still pays.
+	MismatchSuspendedPaying MismatchKind = "suspended_paying"
+	// MismatchDeletedPaying is a deleted (or deleting) tenant whose customer still pays.
+	MismatchDeletedPaying MismatchKind = "deleted_paying"
+	// MismatchUnknownTenant is a paying subscription for a tenant tenantiq does not know.
+	MismatchUnknownTenant MismatchKind = "unknown_tenant"
+)
+
+// BillingMismatch is one disagreement found by Reconcile. Tenant fields are
+// empty for MismatchUnknownTenant, subscription fields for
+// MismatchMissingSubscription.
+type BillingMismatch struct {
+	Kind               MismatchKind
+	TenantID           string
+	TenantPlan         string
+	TenantStatus       Status
+	SubscriptionPlan   string
+	SubscriptionStatus SubscriptionStatus
+	Detail             string
+}
+
+// Reconcile cross-checks tenants against the billing provider's
+// subscriptions and returns the mismatches, ordered by tenant ID. A tenant
+// with several subscriptions is matched against a paying one when there is
+// one. Tenants still being created are not expected to be billed yet.
+func Reconcile(tenants []Tenant, subs []Subscription) []BillingMismatch {
+	byTenant := make(map[string]Subscription, len(subs))
+	for _, sub := range subs {
+		if prev, ok := byTenant[sub.TenantID]; !ok || !prev.Paying() {
+			byTenant[sub.TenantID] = sub
+		}
+	}
+
+	var mismatches []BillingMismatch
+	known := make(map[string]bool, len(tenants))
+	for _, t := range tenants {
+		known[t.ID] = true
+		sub, ok := byTenant[t.ID]
+		paying := ok && sub.Paying()
+		m := BillingMismatch{
+			TenantID:           t.ID,
+			TenantPlan:         t.Plan,
+			TenantStatus:       t.Status,
+			SubscriptionPlan:   sub.Plan,
+			SubscriptionStatus: sub.Status,
+		}
+
+		switch {
+		case t.Status == StatusActive && !paying:
+			m.Kind = MismatchMissingSubscription
+			m.Detail = "active tenant has no paying subscription"
+		case t.Status == StatusActive && sub.Plan != t.Plan:
+			m.Kind = MismatchPlan
+			m.Detail = fmt.Sprintf("tenant is on plan %s but billed for %s", t.Plan, sub.Plan)
+		case t.Status == StatusSuspended && paying:
+			m.Kind = MismatchSuspendedPaying
+			m.Detail = fmt.Sprintf("suspended tenant has a %s subscription", sub.Status)
+		case (t.Status == StatusDeleting || t.Status == StatusDeleted) && paying:
+			m.Kind = MismatchDeletedPaying
+			m.Detail = fmt.Sprintf("%s tenant has a %s subscription", t.Status, sub.Status)
+		default:
+			continue
+		}
+		mismatches = append(mismatches, m)
+	}
+
+	for id, sub := range byTenant {
+		if known[id] || !sub.Paying() {
+			continue
+		}
+		mismatches = append(mismatches, BillingMismatch{
+			Kind:               MismatchUnknownTenant,
+			TenantID:           id,
+			SubscriptionPlan:   sub.Plan,
+			SubscriptionStatus: sub.Status,
+			Detail:             "subscription references an unknown tenant",
+		})
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool { return mismatches[i].TenantID < mismatches[j].TenantID })
+	return mismatches
+}
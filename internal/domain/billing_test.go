@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestReconcile(t *testing.T) {
+	tenants := []domain.Tenant{
+		{ID: "ten_ok", Plan: "pro", Status: domain.StatusActive},
+		{ID: "ten_unbilled", Plan: "pro", Status: domain.StatusActive},
+		{ID: "ten_canceled", Plan: "pro", Status: domain.StatusActive},
+		{ID: "ten_wrong_plan", Plan: "enterprise", Status: domain.StatusActive},
+		{ID: "ten_suspended", Plan: "pro", Status: domain.StatusSuspended},
+		{ID: "ten_suspended_ok", Plan: "pro", Status: domain.StatusSuspended},
+		{ID: "ten_deleted", Plan: "pro", Status: domain.StatusDeleted},
+		{ID: "ten_creating", Plan: "pro", Status: domain.StatusCreating},
+	}
+	subs := []domain.Subscription{
+		{TenantID: "ten_ok", Plan: "free", Status: domain.SubscriptionCanceled},
+		{TenantID: "ten_ok", Plan: "pro", Status: domain.SubscriptionActive},
+		{TenantID: "ten_canceled", Plan: "pro", Status: domain.SubscriptionCanceled},
+		{TenantID: "ten_wrong_plan", Plan: "pro", Status: domain.SubscriptionActive},
+		{TenantID: "ten_suspended", Plan: "pro", Status: domain.SubscriptionPastDue},
+		{TenantID: "ten_suspended_ok", Plan: "pro", Status: domain.SubscriptionCanceled},
+		{TenantID: "ten_deleted", Plan: "pro", Status: domain.SubscriptionActive},
+		{TenantID: "ten_ghost", Plan: "pro", Status: domain.SubscriptionActive},
+		{TenantID: "ten_ghost_canceled", Plan: "pro", Status: domain.SubscriptionCanceled},
+	}
+
+	want := map[string]domain.MismatchKind{
+		"ten_canceled":   domain.MismatchMissingSubscription,
+		"ten_deleted":    domain.MismatchDeletedPaying,
+		"ten_ghost":      domain.MismatchUnknownTenant,
+		"ten_suspended":  domain.MismatchSuspendedPaying,
+		"ten_unbilled":   domain.MismatchMissingSubscription,
+		"ten_wrong_plan": domain.MismatchPlan,
+	}
+
+	got := domain.Reconcile(tenants, subs)
+	if len(got) != len(want) {
+		t.Fatalf("got %d mismatches, want %d: %+v", len(got), len(want), got)
+	}
+	for i, m := range got {
+		if want[m.TenantID] != m.Kind {
+			t.Errorf("%s: kind = %q, want %q", m.TenantID, m.Kind, want[m.TenantID])
+		}
+		if m.Detail == "" {
+			t.Errorf("%s: no detail", m.TenantID)
+		}
+		if i > 0 && got[i-1].TenantID > m.TenantID {
+			t.Errorf("mismatches not ordered by tenant ID: %s before %s", got[i-1].TenantID, m.TenantID)
+		}
+	}
+}
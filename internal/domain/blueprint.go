@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Keys of the tenant metadata a blueprint sets. Feature flags and template
+// variables are stored as metadata, so provisioning reads them with the
+// rest of the tenant and list filters can select on them
+// (metadata.feature.sso=true).
+const (
+	// BlueprintMetadataKey records the blueprint a tenant was created from.
+	BlueprintMetadataKey = "blueprint"
+	// FeatureMetadataPrefix prefixes feature flags, valued "true" or "false".
+	FeatureMetadataPrefix = "feature."
+	// VariableMetadataPrefix prefixes the variables of the provisioning
+	// templates.
+	VariableMetadataPrefix = "var."
+)
+
+// Blueprint is a reusable tenant configuration: tenants created from it
+// start on its plan with its metadata, feature flags and template
+// variables. The tenant keeps a copy, so later changes to the blueprint
+// only affect tenants created afterwards.
+type Blueprint struct {
+	Name string
+	// Plan is the plan of tenants created without one.
+	Plan     string
+	Metadata map[string]string
+	// Features switches features on or off, by name.
+	Features map[string]bool
+	// Variables are the defaults of the provisioning template variables,
+	// by name.
+	Variables map[string]string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewBlueprint creates a blueprint after validating it.
+func NewBlueprint(name, plan string, metadata map[string]string, features map[string]bool, variables map[string]string) (Blueprint, error) {
+	now := time.Now().UTC()
+	b := Blueprint{
+		Name:      name,
+		Plan:      plan,
+		Metadata:  metadata,
+		Features:  features,
+		Variables: variables,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return b, b.Validate()
+}
+
+// Validate checks the name follows the plan naming rules, a plan is set
+// and the metadata the blueprint gives tenants is valid metadata.
+func (b Blueprint) Validate() error {
+	if len(b.Name) > MaxPlanNameLength || !planNamePattern.MatchString(b.Name) {
+		return &InvalidBlueprintError{Reason: fmt.Sprintf("name %q must be lowercase letters and digits separated by single hyphens or underscores", b.Name)}
+	}
+	if b.Plan == "" {
+		return &InvalidBlueprintError{Reason: "a plan is required"}
+	}
+	if _, ok := b.Metadata[BlueprintMetadataKey]; ok {
+		return &InvalidBlueprintError{Reason: fmt.Sprintf("metadata key %q is set by the blueprint itself", BlueprintMetadataKey)}
+	}
+	if _, ok := b.Features[""]; ok {
+		return &InvalidBlueprintError{Reason: "feature names must not be empty"}
+	}
+	if _, ok := b.Variables[""]; ok {
+		return &InvalidBlueprintError{Reason: "variable names must not be empty"}
+	}
+	if err := ValidateMetadata(b.TenantMetadata()); err != nil {
+		return &InvalidBlueprintError{Reason: err.Error()}
+	}
+	return nil
+}
+
+// TenantMetadata returns the metadata of tenants created from b: its
+// metadata, its feature flags and variables under their prefixes, and its
+// name under BlueprintMetadataKey.
+func (b Blueprint) TenantMetadata() map[string]string {
+	metadata := make(map[string]string, len(b.Metadata)+len(b.Features)+len(b.Variables)+1)
+	for k, v := range b.Metadata {
+		metadata[k] = v
+	}
+	for name, on := range b.Features {
+		metadata[FeatureMetadataPrefix+name] = strconv.FormatBool(on)
+	}
+	for name, v := range b.Variables {
+		metadata[VariableMetadataPrefix+name] = v
+	}
+	metadata[BlueprintMetadataKey] = b.Name
+	return metadata
+}
+
+// Apply returns the plan and metadata of a tenant created from b with the
+// given ones: plan when set, or the blueprint's, and the blueprint's
+// metadata overridden by metadata, where an empty value drops the key.
+func (b Blueprint) Apply(plan string, metadata map[string]string) (string, map[string]string) {
+	if plan == "" {
+		plan = b.Plan
+	}
+	return plan, mergeValues(b.TenantMetadata(), metadata)
+}
@@ -0,0 +1,57 @@
+package domain_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestNewBlueprint_Validation(t *testing.T) {
+	cases := []struct {
+		name      string
+		blueprint string
+		plan      string
+		metadata  map[string]string
+		features  map[string]bool
+		variables map[string]string
+		wantErr   bool
+	}{
+		{"valid", "enterprise-eu", "enterprise", map[string]string{"region": "eu"}, map[string]bool{"sso": true}, map[string]string{"replicas": "3"}, false},
+		{"uppercase name", "Enterprise", "enterprise", nil, nil, nil, true},
+		{"no plan", "enterprise-eu", "", nil, nil, nil, true},
+		{"reserved key", "enterprise-eu", "enterprise", map[string]string{"blueprint": "other"}, nil, nil, true},
+		{"empty feature", "enterprise-eu", "enterprise", nil, map[string]bool{"": true}, nil, true},
+		{"bad variable", "enterprise-eu", "enterprise", nil, nil, map[string]string{"a b": "1"}, true},
+		{"long variable", "enterprise-eu", "enterprise", nil, nil, map[string]string{"v": strings.Repeat("x", domain.MaxMetadataValueLength+1)}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := domain.NewBlueprint(tc.blueprint, tc.plan, tc.metadata, tc.features, tc.variables)
+			var bpErr *domain.InvalidBlueprintError
+			if tc.wantErr != errors.As(err, &bpErr) {
+				t.Errorf("NewBlueprint = %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestBlueprint_Apply(t *testing.T) {
+	b, err := domain.NewBlueprint("eu", "enterprise",
+		map[string]string{"region": "eu"}, map[string]bool{"sso": true, "beta": false}, map[string]string{"replicas": "3"})
+	if err != nil {
+		t.Fatalf("NewBlueprint: %v", err)
+	}
+
+	plan, metadata := b.Apply("", nil)
+	if plan != "enterprise" || metadata["region"] != "eu" || metadata["feature.sso"] != "true" ||
+		metadata["feature.beta"] != "false" || metadata["var.replicas"] != "3" || metadata["blueprint"] != "eu" {
+		t.Errorf("Apply = %q, %v; want the blueprint's plan and metadata", plan, metadata)
+	}
+
+	plan, metadata = b.Apply("pro", map[string]string{"region": "", "var.replicas": "5"})
+	if _, ok := metadata["region"]; plan != "pro" || ok || metadata["var.replicas"] != "5" {
+		t.Errorf("Apply with overrides = %q, %v", plan, metadata)
+	}
+}
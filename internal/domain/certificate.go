@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CertificateStatus is where a domain's certificate stands.
+type CertificateStatus string
+
+const (
+	// CertificatePending has never been issued; the renewal job requests it.
+	CertificatePending CertificateStatus = "pending"
+	// CertificateIssued was obtained by the last attempt.
+	CertificateIssued CertificateStatus = "issued"
+	// CertificateFailed could not be obtained by the last attempt, which is
+	// retried later. A certificate issued before stays valid until NotAfter.
+	CertificateFailed CertificateStatus = "failed"
+)
+
+// Timing of certificate renewals.
+const (
+	// CertificateRenewBefore is how long before expiry a certificate is
+	// renewed, capped at a third of its lifetime.
+	CertificateRenewBefore = 30 * 24 * time.Hour
+	// CertificateRetryAfter is how long a failed attempt waits to be
+	// retried, keeping under the certificate authority's rate limits.
+	CertificateRetryAfter = time.Hour
+)
+
+// Certificate is the TLS certificate of a tenant's custom domain, obtained
+// from a certificate authority over ACME. Only its metadata is kept here;
+// the certificate and its key are stored by the CertificateIssuer.
+type Certificate struct {
+	// Domain is the host name the certificate is for; one tenant owns it.
+	Domain   string
+	TenantID string
+	Status   CertificateStatus
+	// Issuer, Serial, NotBefore and NotAfter describe the certificate last
+	// issued; they are empty until one is.
+	Issuer    string
+	Serial    string
+	NotBefore time.Time
+	NotAfter  time.Time
+	// RenewAt is when the certificate is next requested.
+	RenewAt time.Time
+	// LastError is why the last attempt failed, if it did.
+	LastError string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewCertificate returns a pending certificate requested at now.
+func NewCertificate(domainName, tenantID string, now time.Time) Certificate {
+	now = now.UTC()
+	return Certificate{
+		Domain:    domainName,
+		TenantID:  tenantID,
+		Status:    CertificatePending,
+		RenewAt:   now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IssuedCertificate describes a certificate a CertificateIssuer obtained.
+type IssuedCertificate struct {
+	Issuer    string
+	Serial    string
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// Issued records cert as obtained at now and schedules its renewal.
+func (c *Certificate) Issued(cert IssuedCertificate, now time.Time) {
+	c.Status = CertificateIssued
+	c.Issuer = cert.Issuer
+	c.Serial = cert.Serial
+	c.NotBefore = cert.NotBefore.UTC()
+	c.NotAfter = cert.NotAfter.UTC()
+	c.RenewAt = c.NotAfter.Add(-min(CertificateRenewBefore, c.NotAfter.Sub(c.NotBefore)/3))
+	c.LastError = ""
+	c.UpdatedAt = now.UTC()
+}
+
+// Failed records an attempt that failed at now and schedules its retry.
+func (c *Certificate) Failed(err error, now time.Time) {
+	c.Status = CertificateFailed
+	c.LastError = err.Error()
+	c.RenewAt = now.UTC().Add(CertificateRetryAfter)
+	c.UpdatedAt = now.UTC()
+}
+
+// InvalidDomainError is returned when a domain name cannot have a
+// certificate.
+type InvalidDomainError struct {
+	Domain string
+	Reason string
+}
+
+func (e *InvalidDomainError) Error() string {
+	return fmt.Sprintf("invalid domain %q: %s", e.Domain, e.Reason)
+}
+
+// DomainConflictError is returned when a domain already belongs to another
+// tenant.
+type DomainConflictError struct {
+	Domain string
+}
+
+func (e *DomainConflictError) Error() string {
+	return fmt.Sprintf("domain %q is used by another tenant", e.Domain)
+}
+
+// ValidateDomainName checks name is a lowercase, fully qualified host name
+// a public certificate authority can issue for: at least two labels of
+// letters, digits and inner hyphens, no wildcard and no IP address.
+func ValidateDomainName(name string) error {
+	if len(name) > 253 {
+		return &InvalidDomainError{Domain: name, Reason: "longer than 253 bytes"}
+	}
+	labels := strings.Split(name, ".")
+	if len(labels) < 2 {
+		return &InvalidDomainError{Domain: name, Reason: "not a fully qualified name"}
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > 63 {
+			return &InvalidDomainError{Domain: name, Reason: "labels must be 1 to 63 bytes"}
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return &InvalidDomainError{Domain: name, Reason: fmt.Sprintf("label %q starts or ends with a hyphen", label)}
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+				return &InvalidDomainError{Domain: name, Reason: fmt.Sprintf("unexpected %q (lowercase letters, digits and hyphens only)", r)}
+			}
+		}
+	}
+	if last := labels[len(labels)-1]; strings.Trim(last, "0123456789") == "" {
+		return &InvalidDomainError{Domain: name, Reason: "IP addresses cannot have certificates"}
+	}
+	return nil
+}
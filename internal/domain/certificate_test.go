@@ -0,0 +1,61 @@
+package domain_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestValidateDomainName(t *testing.T) {
+	cases := []struct {
+		name    string
+		domain  string
+		wantErr bool
+	}{
+		{"valid", "app.acme.com", false},
+		{"digits and hyphens", "eu-1.acme2.io", false},
+		{"single label", "localhost", true},
+		{"empty label", "app..acme.com", true},
+		{"uppercase", "App.acme.com", true},
+		{"wildcard", "*.acme.com", true},
+		{"underscore", "a_b.acme.com", true},
+		{"leading hyphen", "-app.acme.com", true},
+		{"ipv4", "10.0.0.1", true},
+		{"long label", strings.Repeat("a", 64) + ".com", true},
+		{"long name", strings.Repeat("a.", 127) + "com", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := domain.ValidateDomainName(tc.domain)
+			var domainErr *domain.InvalidDomainError
+			if tc.wantErr != errors.As(err, &domainErr) {
+				t.Errorf("ValidateDomainName(%q) = %v, want error %v", tc.domain, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCertificate_RenewalSchedule(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cert := domain.NewCertificate("app.acme.com", "ten_1", now)
+
+	cert.Failed(errors.New("timeout"), now)
+	if cert.Status != domain.CertificateFailed || !cert.RenewAt.Equal(now.Add(domain.CertificateRetryAfter)) {
+		t.Errorf("after failure = %+v", cert)
+	}
+
+	// A 90-day certificate renews 30 days before expiry.
+	cert.Issued(domain.IssuedCertificate{NotBefore: now, NotAfter: now.AddDate(0, 0, 90)}, now)
+	if cert.LastError != "" || !cert.RenewAt.Equal(now.AddDate(0, 0, 60)) {
+		t.Errorf("90-day certificate: %+v, want renewal after 60 days", cert)
+	}
+
+	// A short-lived one renews after two thirds of its lifetime.
+	cert.Issued(domain.IssuedCertificate{NotBefore: now, NotAfter: now.AddDate(0, 0, 6)}, now)
+	if !cert.RenewAt.Equal(now.AddDate(0, 0, 4)) {
+		t.Errorf("6-day certificate renews at %v, want after 4 days", cert.RenewAt)
+	}
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Change events are published when a tenant's attributes change outside
+// of its lifecycle, so consumers learn about more than status transitions.
+// They are not part of Transitions.
+const (
+	// EventRenamed is published when the tenant's name changes.
+	EventRenamed Event = "renamed"
+	// EventPlanChanged is published when the tenant moves to another plan.
+	EventPlanChanged Event = "plan_changed"
+	// EventMetadataUpdated is published when any other attribute changes:
+	// references, metadata, tags, pull request, branch or trial.
+	EventMetadataUpdated Event = "metadata_updated"
+)
+
+// FieldChange is the before and after value of a changed tenant attribute.
+// Before is empty for an attribute that was set, After for one that was
+// cleared. External references and metadata are diffed per key, as
+// "external_refs.<key>" and "metadata.<key>"; tags as a whole, as "tags"
+// with comma-separated values.
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// Diff returns the changes between two states of a tenant's mutable
+// attributes, in a stable order. Status and bookkeeping (version,
+// timestamps) are left out.
+func Diff(before, after Tenant) []FieldChange {
+	var changes []FieldChange
+	add := func(field, b, a string) {
+		if b != a {
+			changes = append(changes, FieldChange{Field: field, Before: b, After: a})
+		}
+	}
+	add("name", before.Name, after.Name)
+	add("plan", before.Plan, after.Plan)
+	add("pr_url", before.PRURL, after.PRURL)
+	add("git_branch", before.GitBranch, after.GitBranch)
+
+	for _, k := range unionKeys(before.ExternalRefs, after.ExternalRefs) {
+		add("external_refs."+k, before.ExternalRefs[k], after.ExternalRefs[k])
+	}
+	for _, k := range unionKeys(before.Metadata, after.Metadata) {
+		add("metadata."+k, before.Metadata[k], after.Metadata[k])
+	}
+	add("tags", strings.Join(before.Tags, ","), strings.Join(after.Tags, ","))
+
+	add("trial_ends_at", formatTime(before.TrialEndsAt), formatTime(after.TrialEndsAt))
+	return changes
+}
+
+// unionKeys returns the keys of a and b, sorted.
+func unionKeys(a, b map[string]string) []string {
+	keys := slices.Collect(maps.Keys(a))
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// formatTime formats t as RFC 3339, or returns "" for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ChangeEvent is a change event and the changes it announces.
+type ChangeEvent struct {
+	Event   Event
+	Changes []FieldChange
+}
+
+// ChangeEvents groups changes by the change event that announces them:
+// EventRenamed for the name, EventPlanChanged for the plan and
+// EventMetadataUpdated for the rest, in that order. Events without changes
+// are omitted.
+func ChangeEvents(changes []FieldChange) []ChangeEvent {
+	var renamed, plan, metadata []FieldChange
+	for _, c := range changes {
+		switch c.Field {
+		case "name":
+			renamed = append(renamed, c)
+		case "plan":
+			plan = append(plan, c)
+		default:
+			metadata = append(metadata, c)
+		}
+	}
+
+	var events []ChangeEvent
+	for _, e := range []ChangeEvent{
+		{Event: EventRenamed, Changes: renamed},
+		{Event: EventPlanChanged, Changes: plan},
+		{Event: EventMetadataUpdated, Changes: metadata},
+	} {
+		if len(e.Changes) > 0 {
+			events = append(events, e)
+		}
+	}
+	return events
+}
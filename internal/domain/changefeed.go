@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// ChangeType is how a change of the feed affects a downstream copy of the
+// tenant.
+type ChangeType string
+
+const (
+	ChangeCreated ChangeType = "created"
+	// ChangeUpdated covers every later change, lifecycle transitions and
+	// deletions (to deleting, then deleted) included.
+	ChangeUpdated ChangeType = "updated"
+	// ChangeDeleted is the purge of the tenant, which is then gone.
+	ChangeDeleted ChangeType = "deleted"
+)
+
+// ChangeTypeOf returns the change type of an audit action.
+func ChangeTypeOf(action AuditAction) ChangeType {
+	switch action {
+	case AuditCreate:
+		return ChangeCreated
+	case AuditPurge:
+		return ChangeDeleted
+	}
+	return ChangeUpdated
+}
+
+// TenantChange is one entry of the tenant change feed, read from the audit
+// trail so downstream caches can sync incrementally.
+type TenantChange struct {
+	// Cursor orders the feed: changes after a cursor are the ones recorded
+	// later.
+	Cursor   int64
+	Type     ChangeType
+	TenantID string
+	// Version is the tenant's version after the change; for a deletion,
+	// its last version.
+	Version int
+	// Tenant is the tenant after the change; nil for a deletion.
+	Tenant *Tenant
+	At     time.Time
+}
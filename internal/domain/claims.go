@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+// Claims are what an identity provider asserts about the caller of a
+// request authenticated with one of its tokens.
+type Claims struct {
+	Subject string
+	Issuer  string
+	// Values holds every claim of the token, as decoded from JSON, for
+	// handlers that need more than the subject, such as an email or groups.
+	Values map[string]any
+}
+
+// String returns the claim named name when it is a string, or "".
+func (c Claims) String(name string) string {
+	s, _ := c.Values[name].(string)
+	return s
+}
+
+// Strings returns the claim at path, a name or a dotted path into nested
+// objects such as realm_access.roles, when it is a string or a list of
+// strings; nil otherwise.
+func (c Claims) Strings(path string) []string {
+	var v any = c.Values
+	for name := range strings.SplitSeq(path, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = obj[name]
+	}
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var out []string
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	case []string:
+		return v
+	}
+	return nil
+}
+
+type claimsKey struct{}
+
+// WithClaims returns a context carrying the claims of the caller's token.
+func WithClaims(ctx context.Context, c Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, c)
+}
+
+// ClaimsFromContext returns the claims set by WithClaims, and whether
+// there are any: requests authenticated otherwise carry none.
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	c, ok := ctx.Value(claimsKey{}).(Claims)
+	return c, ok
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"context"
+	"net/netip"
+)
+
+// SystemActor is the actor of changes made without an identified caller.
+const SystemActor = "system"
+
+type (
+	actorKey     struct{}
+	requestIDKey struct{}
+)
+
+// WithActor returns a context attributing the changes made with it to actor.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, or SystemActor.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return SystemActor
+}
+
+// WithRequestID returns a context carrying the ID of the request that
+// causes the changes made with it.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the ID set by WithRequestID, or "".
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+type approverKey struct{}
+
+// WithApprover returns a context recording that approver signed off the
+// changes made with it, for transitions that require approval.
+func WithApprover(ctx context.Context, approver string) context.Context {
+	return context.WithValue(ctx, approverKey{}, approver)
+}
+
+// ApproverFromContext returns the approver set by WithApprover, or "".
+func ApproverFromContext(ctx context.Context) string {
+	approver, _ := ctx.Value(approverKey{}).(string)
+	return approver
+}
+
+type automatedKey struct{}
+
+// WithAutomation returns a context marking the changes made with it as
+// scheduled by automation rather than requested by someone, so disruptive
+// ones wait for the tenant's maintenance windows.
+func WithAutomation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, automatedKey{}, true)
+}
+
+// IsAutomated reports whether ctx was marked by WithAutomation.
+func IsAutomated(ctx context.Context) bool {
+	automated, _ := ctx.Value(automatedKey{}).(bool)
+	return automated
+}
+
+type eventIDKey struct{}
+
+// WithEventID returns a context publishing its event under id instead of
+// a fresh one, so an event published again keeps its identity.
+func WithEventID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, eventIDKey{}, id)
+}
+
+// EventIDFromContext returns the ID set by WithEventID, or "".
+func EventIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(eventIDKey{}).(string)
+	return id
+}
+
+type clientIPKey struct{}
+
+// WithClientIP returns a context recording the address the request that
+// causes its changes came from, for the tenants' IP allowlists.
+func WithClientIP(ctx context.Context, addr netip.Addr) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, addr)
+}
+
+// ClientIPFromContext returns the address set by WithClientIP, or false
+// when the work was not requested over the network.
+func ClientIPFromContext(ctx context.Context) (netip.Addr, bool) {
+	addr, ok := ctx.Value(clientIPKey{}).(netip.Addr)
+	return addr, ok && addr.IsValid()
+}
+
+type clientCNKey struct{}
+
+// WithClientCN returns a context recording the common name of the TLS
+// client certificate the request that causes its changes was made with.
+func WithClientCN(ctx context.Context, cn string) context.Context {
+	return context.WithValue(ctx, clientCNKey{}, cn)
+}
+
+// ClientCNFromContext returns the common name set by WithClientCN, or ""
+// when the caller presented no client certificate.
+func ClientCNFromContext(ctx context.Context) string {
+	cn, _ := ctx.Value(clientCNKey{}).(string)
+	return cn
+}
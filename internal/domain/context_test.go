@@ -0,0 +1,34 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestActorFromContext(t *testing.T) {
+	ctx := context.Background()
+	if got := domain.ActorFromContext(ctx); got != domain.SystemActor {
+		t.Errorf("ActorFromContext() = %q, want %q", got, domain.SystemActor)
+	}
+
+	ctx = domain.WithActor(ctx, "alice@example.com")
+	if got := domain.ActorFromContext(ctx); got != "alice@example.com" {
+		t.Errorf("ActorFromContext() = %q, want alice@example.com", got)
+	}
+
+	if got := domain.ActorFromContext(domain.WithActor(ctx, "")); got != domain.SystemActor {
+		t.Errorf("empty actor: ActorFromContext() = %q, want %q", got, domain.SystemActor)
+	}
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	ctx := context.Background()
+	if got := domain.RequestIDFromContext(ctx); got != "" {
+		t.Errorf("RequestIDFromContext() = %q, want empty", got)
+	}
+	if got := domain.RequestIDFromContext(domain.WithRequestID(ctx, "req-1")); got != "req-1" {
+		t.Errorf("RequestIDFromContext() = %q, want req-1", got)
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "time"
+
+// DigestMode is how often a webhook subscription receives the events it
+// matches: one delivery per event, or one summary an hour or a day, for
+// fleets large enough that per-event notifications overwhelm their admins.
+type DigestMode string
+
+const (
+	DigestImmediate DigestMode = "immediate"
+	DigestHourly    DigestMode = "hourly"
+	DigestDaily     DigestMode = "daily"
+)
+
+// DigestModes returns every digest mode.
+func DigestModes() []DigestMode {
+	return []DigestMode{DigestImmediate, DigestHourly, DigestDaily}
+}
+
+// Batched reports whether events are held for a digest rather than
+// delivered on their own.
+func (m DigestMode) Batched() bool {
+	return m == DigestHourly || m == DigestDaily
+}
+
+// WindowStart returns the start of the digest window t falls in: the hour,
+// or the day in UTC. Events held before it are due. Without batching,
+// every window ends at once.
+func (m DigestMode) WindowStart(t time.Time) time.Time {
+	t = t.UTC()
+	switch m {
+	case DigestHourly:
+		return t.Truncate(time.Hour)
+	case DigestDaily:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	default:
+		return t
+	}
+}
+
+// DigestEntry is an event held for the next digest of a subscription.
+type DigestEntry struct {
+	WebhookID string
+	// EventID identifies the event; an event is held once per
+	// subscription, however often it is processed.
+	EventID  string
+	Event    Event
+	TenantID string
+	// Payload is the event as it would have been delivered on its own.
+	Payload    []byte
+	OccurredAt time.Time
+}
+
+// Digest is the summary of the events held for a subscription until the
+// end of a window.
+type Digest struct {
+	Webhook WebhookSubscription
+	// Until is the end of the last window the digest covers: it holds the
+	// events that occurred before.
+	Until   time.Time
+	Entries []DigestEntry
+}
+
+// Counts returns how many of the digest's events there are of each event.
+func (d Digest) Counts() map[Event]int {
+	counts := make(map[Event]int)
+	for _, e := range d.Entries {
+		counts[e.Event]++
+	}
+	return counts
+}
@@ -0,0 +1,48 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestDigestMode_WindowStart(t *testing.T) {
+	at := time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC)
+	for mode, want := range map[domain.DigestMode]time.Time{
+		domain.DigestImmediate: at,
+		domain.DigestHourly:    time.Date(2026, 3, 14, 15, 0, 0, 0, time.UTC),
+		domain.DigestDaily:     time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC),
+	} {
+		// Windows are in UTC whatever the location of t.
+		if got := mode.WindowStart(at.In(time.FixedZone("UTC-10", -10*3600))); !got.Equal(want) {
+			t.Errorf("%s window start = %v, want %v", mode, got, want)
+		}
+	}
+	if domain.DigestImmediate.Batched() || !domain.DigestDaily.Batched() {
+		t.Error("only hourly and daily digests batch events")
+	}
+}
+
+func TestWebhookSubscription_ValidatesDigest(t *testing.T) {
+	w, _ := domain.NewWebhookSubscription("wh_1", "https://example.com/hook", "0123456789abcdef", nil)
+	w.Digest = domain.DigestHourly
+	if err := w.Validate(); err != nil {
+		t.Errorf("hourly digest: %v", err)
+	}
+	w.Digest = "weekly"
+	var invalid *domain.InvalidWebhookError
+	if err := w.Validate(); !errors.As(err, &invalid) {
+		t.Errorf("weekly digest = %v, want InvalidWebhookError", err)
+	}
+}
+
+func TestDigest_Counts(t *testing.T) {
+	d := domain.Digest{Entries: []domain.DigestEntry{
+		{Event: domain.EventSuspend}, {Event: domain.EventDelete}, {Event: domain.EventSuspend},
+	}}
+	if got := d.Counts(); len(got) != 2 || got[domain.EventSuspend] != 2 || got[domain.EventDelete] != 1 {
+		t.Errorf("Counts = %v", got)
+	}
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// DunningStage is how far the collection of an unpaid invoice has gone.
+type DunningStage string
+
+const (
+	// DunningWarned: the customer was told the payment failed.
+	DunningWarned DunningStage = "warned"
+	// DunningGrace: the customer got a final notice before suspension.
+	DunningGrace DunningStage = "grace"
+	// DunningSuspended: the tenant was suspended for non-payment.
+	DunningSuspended DunningStage = "suspended"
+)
+
+// DunningPolicy paces the dunning flow: a failed payment is warned about
+// at once, followed by a final notice after WarningPeriod and by the
+// suspension GracePeriod later.
+type DunningPolicy struct {
+	WarningPeriod time.Duration
+	GracePeriod   time.Duration
+}
+
+// Validate checks both periods are positive.
+func (p DunningPolicy) Validate() error {
+	if p.WarningPeriod <= 0 || p.GracePeriod <= 0 {
+		return fmt.Errorf("dunning periods must be positive (warning %s, grace %s)", p.WarningPeriod, p.GracePeriod)
+	}
+	return nil
+}
+
+// Dunning tracks the collection of a tenant's unpaid invoice. A tenant has
+// at most one; it ends when the invoice is paid.
+type Dunning struct {
+	TenantID  string
+	InvoiceID string
+	Stage     DunningStage
+	// SuspendedTenant is set when the flow suspended the tenant itself, so
+	// only those suspensions are lifted on payment.
+	SuspendedTenant bool
+	StartedAt       time.Time
+	// NextStepAt is when the flow moves on; zero once the tenant is suspended.
+	NextStepAt time.Time
+	UpdatedAt  time.Time
+}
+
+// StartDunning opens the flow for a failed payment, at the warned stage.
+func StartDunning(tenantID, invoiceID string, p DunningPolicy, now time.Time) Dunning {
+	return Dunning{
+		TenantID:   tenantID,
+		InvoiceID:  invoiceID,
+		Stage:      DunningWarned,
+		StartedAt:  now,
+		NextStepAt: now.Add(p.WarningPeriod),
+		UpdatedAt:  now,
+	}
+}
+
+// Due reports whether the next step should run at now.
+func (d Dunning) Due(now time.Time) bool {
+	return !d.NextStepAt.IsZero() && !now.Before(d.NextStepAt)
+}
+
+// Advance moves the flow one stage forward and returns the new stage:
+// warned becomes grace, grace becomes suspended. A suspended flow does
+// not move.
+func (d *Dunning) Advance(p DunningPolicy, now time.Time) DunningStage {
+	switch d.Stage {
+	case DunningWarned:
+		d.Stage = DunningGrace
+		d.NextStepAt = now.Add(p.GracePeriod)
+	case DunningGrace:
+		d.Stage = DunningSuspended
+		d.NextStepAt = time.Time{}
+	}
+	d.UpdatedAt = now
+	return d.Stage
+}
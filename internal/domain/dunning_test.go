@@ -0,0 +1,45 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestDunning_Advance(t *testing.T) {
+	policy := domain.DunningPolicy{WarningPeriod: 72 * time.Hour, GracePeriod: 7 * 24 * time.Hour}
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	d := domain.StartDunning("ten_1", "in_1", policy, start)
+	if d.Stage != domain.DunningWarned || d.Due(start.Add(71*time.Hour)) || !d.Due(start.Add(72*time.Hour)) {
+		t.Fatalf("started dunning = %+v, want warned and due after the warning period", d)
+	}
+
+	finalNotice := start.Add(72 * time.Hour)
+	if stage := d.Advance(policy, finalNotice); stage != domain.DunningGrace {
+		t.Fatalf("stage = %q, want grace", stage)
+	}
+	if !d.NextStepAt.Equal(finalNotice.Add(policy.GracePeriod)) {
+		t.Errorf("NextStepAt = %v, want end of grace period", d.NextStepAt)
+	}
+
+	if stage := d.Advance(policy, d.NextStepAt); stage != domain.DunningSuspended {
+		t.Fatalf("stage = %q, want suspended", stage)
+	}
+	if d.Due(start.Add(365 * 24 * time.Hour)) {
+		t.Error("suspended dunning should never be due")
+	}
+	if stage := d.Advance(policy, start); stage != domain.DunningSuspended {
+		t.Errorf("suspended dunning advanced to %q", stage)
+	}
+}
+
+func TestDunningPolicy_Validate(t *testing.T) {
+	if err := (domain.DunningPolicy{WarningPeriod: time.Hour}).Validate(); err == nil {
+		t.Error("Validate accepted a zero grace period")
+	}
+	if err := (domain.DunningPolicy{WarningPeriod: time.Hour, GracePeriod: time.Hour}).Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+package domain
+
+// Egress is how tenantiq reaches other systems over HTTP, for the
+// operators of those systems to allowlist it.
+type Egress struct {
+	// ProxyURL is the proxy outbound requests go through, without its
+	// credentials; empty when they go out directly.
+	ProxyURL string
+	// NoProxy are the hosts and domains reached directly even so.
+	NoProxy []string
+	// SourceIPs are the addresses outbound requests come from, as
+	// declared by the operator: the proxy's, or the NAT gateway's.
+	SourceIPs []string
+	// Adapters are the integrations whose requests follow this route,
+	// such as "webhooks" or "billing".
+	Adapters []string
+}
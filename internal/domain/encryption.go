@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// FeatureBYOK is the plan feature letting tenants bring their own
+// encryption key.
+const FeatureBYOK = "byok"
+
+// Limits of encryption keys and secrets.
+const (
+	MaxKeyRefLength     = 2048
+	MaxSecretNameLength = 128
+	MaxSecretValueSize  = 64 << 10
+)
+
+// secretNamePattern accepts names such as "DATABASE_URL" or "stripe.key".
+var secretNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// EncryptionKey is the key a tenant brought, held in a key management
+// service (KMS), which tenantiq encrypts the tenant's data with. Tenants
+// without one have their data encrypted with tenantiq's own key.
+type EncryptionKey struct {
+	TenantID string
+	// KeyRef identifies the key at the KMS, such as an AWS KMS key ARN.
+	KeyRef    string
+	CreatedAt time.Time
+	// RotatedAt is when KeyRef last changed, or the key was set again
+	// after being revoked; zero when it never was.
+	RotatedAt time.Time
+	// RevokedAt is when the key was found or reported revoked, which
+	// suspends access to the tenant's data; zero while it is usable.
+	RevokedAt time.Time
+}
+
+// Revoked reports whether access to the tenant's data is suspended.
+func (k EncryptionKey) Revoked() bool {
+	return !k.RevokedAt.IsZero()
+}
+
+// ValidateKeyRef checks that ref can name a key: not empty, at most
+// MaxKeyRefLength bytes, and without spaces or control characters.
+func ValidateKeyRef(ref string) error {
+	if ref == "" || len(ref) > MaxKeyRefLength {
+		return &InvalidEncryptionKeyError{Reason: fmt.Sprintf("key reference must be 1 to %d bytes", MaxKeyRefLength)}
+	}
+	if strings.ContainsFunc(ref, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) {
+		return &InvalidEncryptionKeyError{Reason: "key reference must not contain spaces or control characters"}
+	}
+	return nil
+}
+
+// Sealed is data encrypted for a tenant (envelope encryption): the data is
+// encrypted with a data key of its own, and the data key with the key
+// KeyRef at the KMS. Rotating the key only re-encrypts data keys.
+type Sealed struct {
+	KeyRef string
+	// WrappedKey is the data key, encrypted by the KMS.
+	WrappedKey []byte
+	// Ciphertext is the nonce followed by the AES-256-GCM ciphertext.
+	Ciphertext []byte
+}
+
+// KeyManager encrypts data keys with the keys of a KMS. Both methods
+// return an error wrapping ErrKeyRevoked when the key can no longer be
+// used.
+type KeyManager interface {
+	Wrap(ctx context.Context, keyRef string, dataKey []byte) ([]byte, error)
+	Unwrap(ctx context.Context, keyRef string, wrapped []byte) ([]byte, error)
+}
+
+// TenantSecret is a secret of a tenant, such as a database password its
+// provisioning needs, kept sealed with the tenant's key.
+type TenantSecret struct {
+	TenantID  string
+	Name      string
+	Value     Sealed
+	UpdatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ValidateSecret checks a secret's name and the size of its value.
+func ValidateSecret(name string, value []byte) error {
+	if len(name) > MaxSecretNameLength || !secretNamePattern.MatchString(name) {
+		return &InvalidSecretError{Reason: fmt.Sprintf("name must be 1 to %d letters, digits, '_', '.' or '-', not starting with a digit", MaxSecretNameLength)}
+	}
+	if len(value) == 0 || len(value) > MaxSecretValueSize {
+		return &InvalidSecretError{Reason: fmt.Sprintf("value must be 1 to %d bytes", MaxSecretValueSize)}
+	}
+	return nil
+}
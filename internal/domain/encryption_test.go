@@ -0,0 +1,45 @@
+package domain_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestValidateKeyRef(t *testing.T) {
+	for ref, valid := range map[string]bool{
+		"arn:aws:kms:eu-west-1:123456789012:key/1234abcd": true,
+		"local:acme": true,
+		"":           false,
+		"arn acme":   false,
+		"arn:\nacme": false,
+		strings.Repeat("k", domain.MaxKeyRefLength+1): false,
+	} {
+		err := domain.ValidateKeyRef(ref)
+		var invalid *domain.InvalidEncryptionKeyError
+		if valid != (err == nil) || (err != nil && !errors.As(err, &invalid)) {
+			t.Errorf("ValidateKeyRef(%.20q) = %v, want valid %v", ref, err, valid)
+		}
+	}
+}
+
+func TestValidateSecret(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		value []byte
+		valid bool
+	}{
+		{"DATABASE_URL", []byte("postgres://"), true},
+		{"stripe.api-key", []byte("sk"), true},
+		{"1PASSWORD", []byte("x"), false},
+		{"with space", []byte("x"), false},
+		{"EMPTY", nil, false},
+		{"LARGE", make([]byte, domain.MaxSecretValueSize+1), false},
+	} {
+		if err := domain.ValidateSecret(tc.name, tc.value); tc.valid != (err == nil) {
+			t.Errorf("ValidateSecret(%q) = %v, want valid %v", tc.name, err, tc.valid)
+		}
+	}
+}
@@ -3,11 +3,51 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"net/netip"
+	"strings"
+	"time"
 )
 
 // Sentinel errors for simple conditions without extra context.
 var (
-	ErrTenantNotFound = errors.New("tenant not found")
+	ErrTenantNotFound      = errors.New("tenant not found")
+	ErrOperationNotFound   = errors.New("operation not found")
+	ErrResellerNotFound    = errors.New("reseller not found")
+	ErrWebhookNotFound     = errors.New("webhook subscription not found")
+	ErrDunningNotFound     = errors.New("tenant is not in dunning")
+	ErrPlanNotFound        = errors.New("plan not found")
+	ErrCertificateNotFound = errors.New("certificate not found")
+	ErrBlueprintNotFound   = errors.New("blueprint not found")
+	ErrMemberNotFound      = errors.New("member not found")
+	ErrAPIKeyNotFound      = errors.New("API key not found")
+	// ErrAPIKeyInvalid is returned when a key presented for verification
+	// is unknown, revoked or expired, or its tenant is gone.
+	ErrAPIKeyInvalid = errors.New("invalid API key")
+	// ErrAdminKeyNotFound is returned when an admin key of the management
+	// API does not exist.
+	ErrAdminKeyNotFound = errors.New("admin key not found")
+	// ErrTokenInvalid is returned when a bearer token is malformed,
+	// expired, or not signed by the identity provider.
+	ErrTokenInvalid = errors.New("invalid token")
+	// ErrConcurrentModification is returned when a tenant changed since it
+	// was read; read it again and retry.
+	ErrConcurrentModification = errors.New("tenant was modified concurrently")
+	// ErrSimulationDisabled is returned when a simulated tenant is
+	// requested but no simulation provisioner is configured.
+	ErrSimulationDisabled = errors.New("simulation is not configured")
+	// ErrBillingUnavailable wraps failures to reach the billing provider.
+	ErrBillingUnavailable = errors.New("billing provider unavailable")
+	// ErrCursorExpired is returned when the changes after a change feed
+	// cursor were pruned from the audit trail; list the tenants again.
+	ErrCursorExpired = errors.New("cursor expired: the changes after it were pruned; list the tenants again")
+	// ErrEncryptionKeyNotFound is returned when a tenant has not brought
+	// its own encryption key: its data is encrypted with tenantiq's.
+	ErrEncryptionKeyNotFound = errors.New("tenant has no encryption key of its own")
+	ErrSecretNotFound        = errors.New("secret not found")
+	// ErrKeyRevoked is returned by a KeyManager when a key can no longer be
+	// used: disabled, scheduled for deletion, or no longer granted to
+	// tenantiq.
+	ErrKeyRevoked = errors.New("encryption key revoked")
 )
 
 // SlugConflictError is returned when a tenant slug is already in use.
@@ -19,6 +59,47 @@ func (e *SlugConflictError) Error() string {
 	return fmt.Sprintf("slug %q is already in use", e.Slug)
 }
 
+// IDConflictError is returned when an imported tenant's ID is already in
+// use.
+type IDConflictError struct {
+	ID string
+}
+
+func (e *IDConflictError) Error() string {
+	return fmt.Sprintf("id %q is already in use", e.ID)
+}
+
+// InvalidImportError is returned when the original identity of an
+// imported tenant (ID or timestamps) is not acceptable.
+type InvalidImportError struct {
+	ID     string
+	Reason string
+}
+
+func (e *InvalidImportError) Error() string {
+	return fmt.Sprintf("cannot import tenant %q: %s", e.ID, e.Reason)
+}
+
+// InvalidSlugError is returned when a slug is not lowercase alphanumeric
+// words separated by hyphens. Reason names the offending part and
+// Suggestion, when set, is a valid slug derived from the input.
+type InvalidSlugError struct {
+	Slug       string
+	Reason     string
+	Suggestion string
+}
+
+func (e *InvalidSlugError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("slug %q is invalid (lowercase letters, digits and single hyphens only)", e.Slug)
+	}
+	msg := fmt.Sprintf("slug %q is invalid: %s", e.Slug, e.Reason)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (try %q)", e.Suggestion)
+	}
+	return msg
+}
+
 // TransitionError is returned when a state transition is not allowed.
 type TransitionError struct {
 	Event   Event
@@ -28,3 +109,416 @@ type TransitionError struct {
 func (e *TransitionError) Error() string {
 	return fmt.Sprintf("event %q is not valid from state %q", e.Event, e.Current)
 }
+
+// GuardError is returned when a transition guard refuses an event the
+// tenant's status allows.
+type GuardError struct {
+	Guard   string
+	Event   Event
+	Current Status
+	Reason  string
+}
+
+func (e *GuardError) Error() string {
+	return fmt.Sprintf("event %q is not allowed from state %q by guard %q: %s", e.Event, e.Current, e.Guard, e.Reason)
+}
+
+// HookRejectedError is returned when a CreateHook refuses a tenant.
+type HookRejectedError struct {
+	Hook   string
+	Reason string
+}
+
+func (e *HookRejectedError) Error() string {
+	return fmt.Sprintf("rejected by hook %q: %s", e.Hook, e.Reason)
+}
+
+// EventTransformError is returned when an EventTransform fails on an
+// event payload.
+type EventTransformError struct {
+	Transform string
+	Reason    string
+}
+
+func (e *EventTransformError) Error() string {
+	return fmt.Sprintf("event transform %q failed: %s", e.Transform, e.Reason)
+}
+
+// UnreachableStatusError is returned when no sequence of transitions leads
+// from the current status to a requested one.
+type UnreachableStatusError struct {
+	Current Status
+	Target  Status
+}
+
+func (e *UnreachableStatusError) Error() string {
+	return fmt.Sprintf("status %q is not reachable from %q", e.Target, e.Current)
+}
+
+// GuardrailError is returned when a mass operation would disrupt more
+// tenants than the configured guardrail allows.
+type GuardrailError struct {
+	Disrupted int
+	Active    int
+	Limit     int
+}
+
+func (e *GuardrailError) Error() string {
+	return fmt.Sprintf("operation would suspend or delete %d of %d active tenants (limit %d); use force to override",
+		e.Disrupted, e.Active, e.Limit)
+}
+
+// InvalidIDError is returned when an identifier carries the wrong type prefix.
+type InvalidIDError struct {
+	ID     string
+	Prefix string
+}
+
+func (e *InvalidIDError) Error() string {
+	return fmt.Sprintf("id %q is not a valid identifier (expected prefix %q)", e.ID, e.Prefix)
+}
+
+// BatchTooLargeError is returned when a batch exceeds the maximum size.
+type BatchTooLargeError struct {
+	Size int
+	Max  int
+}
+
+func (e *BatchTooLargeError) Error() string {
+	return fmt.Sprintf("batch of %d items exceeds the maximum of %d", e.Size, e.Max)
+}
+
+// PolicyError is returned when a transition policy refuses an event for
+// the tenant's plan.
+type PolicyError struct {
+	Plan   string
+	Event  Event
+	Effect PolicyEffect
+	Reason string
+}
+
+func (e *PolicyError) Error() string {
+	var msg string
+	if e.Effect == PolicyRequireApproval {
+		msg = fmt.Sprintf("event %q requires approval for plan %q", e.Event, e.Plan)
+	} else {
+		msg = fmt.Sprintf("event %q is not allowed for plan %q", e.Event, e.Plan)
+	}
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+// QuotaExceededError is returned when an operation would go over a quota:
+// either a reseller already manages as many tenants as its quota allows
+// (ResellerID and Quota), or a tenant would consume more of a metric than
+// its plan allows (the other fields).
+type QuotaExceededError struct {
+	ResellerID string
+	Quota      int
+
+	TenantID string
+	Plan     string
+	Metric   string
+	Limit    int64
+	// Used is the tenant's usage of Metric and Requested the amount the
+	// operation would add to it.
+	Used      int64
+	Requested int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	if e.Metric != "" {
+		return fmt.Sprintf("tenant %q would exceed the %s limit of plan %q: %d used + %d requested > %d",
+			e.TenantID, e.Metric, e.Plan, e.Used, e.Requested, e.Limit)
+	}
+	return fmt.Sprintf("reseller %q has reached its quota of %d tenants", e.ResellerID, e.Quota)
+}
+
+// Periodic reports whether the exceeded limit resets with the metering
+// period (see PeriodicMetric), so the operation may succeed later.
+func (e *QuotaExceededError) Periodic() bool {
+	return PeriodicMetric(e.Metric)
+}
+
+// InvalidWebhookError is returned when a webhook subscription is malformed.
+type InvalidWebhookError struct {
+	Reason string
+}
+
+func (e *InvalidWebhookError) Error() string {
+	return "invalid webhook subscription: " + e.Reason
+}
+
+// InvalidPlanError is returned when a plan definition is malformed.
+type InvalidPlanError struct {
+	Reason string
+}
+
+func (e *InvalidPlanError) Error() string {
+	return "invalid plan: " + e.Reason
+}
+
+// PlanConflictError is returned when a plan name is already in use.
+type PlanConflictError struct {
+	Name string
+}
+
+func (e *PlanConflictError) Error() string {
+	return fmt.Sprintf("plan %q already exists", e.Name)
+}
+
+// PlanInUseError is returned when deleting a plan tenants are still on.
+type PlanInUseError struct {
+	Name    string
+	Tenants int
+}
+
+func (e *PlanInUseError) Error() string {
+	return fmt.Sprintf("plan %q is used by %d tenants; move them to another plan first", e.Name, e.Tenants)
+}
+
+// UnknownPlanError is returned when a tenant is given a plan that is not
+// defined. Suggestion, when set, is a defined plan with a similar name.
+type UnknownPlanError struct {
+	Plan       string
+	Suggestion string
+}
+
+func (e *UnknownPlanError) Error() string {
+	msg := fmt.Sprintf("plan %q does not exist", e.Plan)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", e.Suggestion)
+	}
+	return msg
+}
+
+// PlanNotInRegionError is returned when a tenant is given a plan that is
+// not offered in the tenant's region. Regions are those the plan is
+// offered in.
+type PlanNotInRegionError struct {
+	Plan    string
+	Region  string
+	Regions []string
+}
+
+func (e *PlanNotInRegionError) Error() string {
+	offered := strings.Join(e.Regions, ", ")
+	if e.Region == "" {
+		return fmt.Sprintf("plan %q is only offered to tenants in %s; the tenant has no region", e.Plan, offered)
+	}
+	return fmt.Sprintf("plan %q is not offered in region %q, only in %s", e.Plan, e.Region, offered)
+}
+
+// InvalidRegionError is returned for a malformed region name.
+type InvalidRegionError struct {
+	Region string
+}
+
+func (e *InvalidRegionError) Error() string {
+	return fmt.Sprintf("invalid region %q: must be lowercase letters and digits separated by hyphens, such as eu or us-east", e.Region)
+}
+
+// InvalidCursorError is returned for a change feed cursor that was never
+// handed out.
+type InvalidCursorError struct {
+	Cursor string
+}
+
+func (e *InvalidCursorError) Error() string {
+	return fmt.Sprintf("invalid cursor %q", e.Cursor)
+}
+
+// InvalidBlueprintError is returned when a blueprint definition is
+// malformed.
+type InvalidBlueprintError struct {
+	Reason string
+}
+
+func (e *InvalidBlueprintError) Error() string {
+	return "invalid blueprint: " + e.Reason
+}
+
+// BlueprintConflictError is returned when a blueprint name is already in
+// use.
+type BlueprintConflictError struct {
+	Name string
+}
+
+func (e *BlueprintConflictError) Error() string {
+	return fmt.Sprintf("blueprint %q already exists", e.Name)
+}
+
+// UnknownBlueprintError is returned when a tenant is created from a
+// blueprint that is not defined.
+type UnknownBlueprintError struct {
+	Name string
+}
+
+func (e *UnknownBlueprintError) Error() string {
+	return fmt.Sprintf("blueprint %q does not exist", e.Name)
+}
+
+// InvalidMemberError is returned when a member's email or role is
+// invalid.
+type InvalidMemberError struct {
+	Reason string
+}
+
+func (e *InvalidMemberError) Error() string {
+	return "invalid member: " + e.Reason
+}
+
+// MemberConflictError is returned when an email address is already a
+// member of the tenant.
+type MemberConflictError struct {
+	TenantID string
+	Email    string
+}
+
+func (e *MemberConflictError) Error() string {
+	return fmt.Sprintf("%s is already a member of tenant %q", e.Email, e.TenantID)
+}
+
+// InvalidAPIKeyError is returned when an API key's name, scopes or expiry
+// are invalid.
+type InvalidAPIKeyError struct {
+	Reason string
+}
+
+func (e *InvalidAPIKeyError) Error() string {
+	return "invalid API key: " + e.Reason
+}
+
+// ForbiddenError is returned when the caller's role does not allow an
+// action.
+type ForbiddenError struct {
+	Role     Role
+	Required Role
+	Action   string
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("%s requires the %s role; the caller is %s", e.Action, e.Required, e.Role)
+}
+
+// MissingScopeError is returned when a valid API key lacks the scope an
+// operation requires.
+type MissingScopeError struct {
+	Scope string
+}
+
+func (e *MissingScopeError) Error() string {
+	return fmt.Sprintf("the API key lacks the %s scope", e.Scope)
+}
+
+// InvalidMaintenanceWindowError is returned when declared maintenance
+// windows are malformed.
+type InvalidMaintenanceWindowError struct {
+	Reason string
+}
+
+func (e *InvalidMaintenanceWindowError) Error() string {
+	return "invalid maintenance window: " + e.Reason
+}
+
+// InvalidRateLimitError is returned when a rate limit override is malformed.
+type InvalidRateLimitError struct {
+	Reason string
+}
+
+func (e *InvalidRateLimitError) Error() string {
+	return "invalid rate limit: " + e.Reason
+}
+
+// MaintenanceDeferredError is returned when automation attempts a
+// disruptive event outside the tenant's maintenance windows. The change
+// should be retried at Until, when the next window opens.
+type MaintenanceDeferredError struct {
+	TenantID string
+	Event    Event
+	Until    time.Time
+}
+
+func (e *MaintenanceDeferredError) Error() string {
+	return fmt.Sprintf("event %q on tenant %q deferred to its maintenance window at %s",
+		e.Event, e.TenantID, e.Until.Format(time.RFC3339))
+}
+
+// RateLimitedError is returned when a tenant's request goes over its rate
+// limit. The request may be retried after RetryAfter.
+type RateLimitedError struct {
+	TenantID   string
+	Limit      RateLimit
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("tenant %q exceeded its rate limit of %d requests per second (burst %d); retry in %s",
+		e.TenantID, e.Limit.RequestsPerSecond, e.Limit.Burst, e.RetryAfter)
+}
+
+// InvalidEncryptionKeyError is returned when a tenant's encryption key
+// reference is malformed or the key cannot be used.
+type InvalidEncryptionKeyError struct {
+	Reason string
+}
+
+func (e *InvalidEncryptionKeyError) Error() string {
+	return "invalid encryption key: " + e.Reason
+}
+
+// EncryptionKeyRevokedError is returned when the tenant's data is read or
+// written after its encryption key was revoked. Access resumes once the
+// tenant sets a usable key.
+type EncryptionKeyRevokedError struct {
+	TenantID string
+	KeyRef   string
+}
+
+func (e *EncryptionKeyRevokedError) Error() string {
+	return fmt.Sprintf("encryption key %q of tenant %q is revoked; its data cannot be accessed until a usable key is set", e.KeyRef, e.TenantID)
+}
+
+// InvalidSecretError is returned when a tenant secret's name or value is
+// malformed.
+type InvalidSecretError struct {
+	Reason string
+}
+
+func (e *InvalidSecretError) Error() string {
+	return "invalid secret: " + e.Reason
+}
+
+// FeatureNotInPlanError is returned when a tenant uses a feature its plan
+// does not include.
+type FeatureNotInPlanError struct {
+	Plan    string
+	Feature string
+}
+
+func (e *FeatureNotInPlanError) Error() string {
+	return fmt.Sprintf("plan %q does not include the %s feature", e.Plan, e.Feature)
+}
+
+// InvalidIPAllowlistError is returned when a tenant's IP allowlist is
+// malformed.
+type InvalidIPAllowlistError struct {
+	Reason string
+}
+
+func (e *InvalidIPAllowlistError) Error() string {
+	return "invalid IP allowlist: " + e.Reason
+}
+
+// IPNotAllowedError is returned when a tenant's API key is used from an
+// address outside the tenant's IP allowlist.
+type IPNotAllowedError struct {
+	TenantID string
+	IP       netip.Addr
+}
+
+func (e *IPNotAllowedError) Error() string {
+	return fmt.Sprintf("requests from %s are not allowed by the IP allowlist of tenant %q", e.IP, e.TenantID)
+}
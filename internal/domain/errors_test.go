@@ -24,3 +24,59 @@ func TestTransitionError_Error(t *testing.T) {
 		t.Errorf("Error() = %q, want %q", got, want)
 	}
 }
+
+func TestHookRejectedError_Error(t *testing.T) {
+	err := &domain.HookRejectedError{Hook: "naming", Reason: "reserved slug"}
+	want := `rejected by hook "naming": reserved slug`
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestUnreachableStatusError_Error(t *testing.T) {
+	err := &domain.UnreachableStatusError{Current: domain.StatusDeleted, Target: domain.StatusActive}
+	want := `status "active" is not reachable from "deleted"`
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestGuardrailError_Error(t *testing.T) {
+	err := &domain.GuardrailError{Disrupted: 5, Active: 20, Limit: 2}
+	want := "operation would suspend or delete 5 of 20 active tenants (limit 2); use force to override"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestInvalidIDError_Error(t *testing.T) {
+	err := &domain.InvalidIDError{ID: "prj_1", Prefix: "ten_"}
+	want := `id "prj_1" is not a valid identifier (expected prefix "ten_")`
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestInvalidSlugError_Error(t *testing.T) {
+	err := &domain.InvalidSlugError{Slug: "Acme"}
+	want := `slug "Acme" is invalid (lowercase letters, digits and single hyphens only)`
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestInvalidSlugError_ErrorWithReason(t *testing.T) {
+	err := &domain.InvalidSlugError{Slug: "Zürich", Reason: "uppercase letters are not allowed", Suggestion: "zurich"}
+	want := `slug "Zürich" is invalid: uppercase letters are not allowed (try "zurich")`
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestBatchTooLargeError_Error(t *testing.T) {
+	err := &domain.BatchTooLargeError{Size: 150, Max: 100}
+	want := "batch of 150 items exceeds the maximum of 100"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
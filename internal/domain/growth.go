@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReportPeriod is the bucket size of aggregate reports.
+type ReportPeriod string
+
+const (
+	PeriodDay   ReportPeriod = "day"
+	PeriodWeek  ReportPeriod = "week"
+	PeriodMonth ReportPeriod = "month"
+)
+
+// MaxReportPeriods bounds how many periods one report may cover.
+const MaxReportPeriods = 400
+
+// Validate checks the period is one of the known sizes.
+func (p ReportPeriod) Validate() error {
+	switch p {
+	case PeriodDay, PeriodWeek, PeriodMonth:
+		return nil
+	}
+	return fmt.Errorf("unknown report period %q", p)
+}
+
+// Truncate returns the start of the period containing t, in UTC. Weeks
+// start on Monday.
+func (p ReportPeriod) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch p {
+	case PeriodWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case PeriodMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	return day
+}
+
+// Add returns the start of the period n periods after start.
+func (p ReportPeriod) Add(start time.Time, n int) time.Time {
+	switch p {
+	case PeriodWeek:
+		return start.AddDate(0, 0, 7*n)
+	case PeriodMonth:
+		return start.AddDate(0, n, 0)
+	}
+	return start.AddDate(0, 0, n)
+}
+
+// GrowthPeriod summarizes how the tenant base changed over [Start, End).
+type GrowthPeriod struct {
+	Start time.Time
+	End   time.Time
+	// New counts tenants created in the period.
+	New int
+	// Churned counts tenants deleted in the period.
+	Churned int
+	// Suspended counts suspensions in the period.
+	Suspended int
+	// Net is the change in the number of active tenants over the period.
+	Net int
+	// Active is the number of active tenants at the end of the period.
+	Active int
+}
+
+// Growth builds the report of the periods covering from to to. created are
+// the creation times of the tenants created since from; changes are every
+// status change since from, and active the number of active tenants now,
+// from which the past counts are worked back.
+func Growth(p ReportPeriod, from, to time.Time, created []time.Time, changes []StatusChange, active int) []GrowthPeriod {
+	var periods []GrowthPeriod
+	for start := p.Truncate(from); start.Before(to) && len(periods) < MaxReportPeriods; start = p.Add(start, 1) {
+		periods = append(periods, GrowthPeriod{Start: start, End: p.Add(start, 1)})
+	}
+
+	// index returns the period containing t, or -1 (or len) outside them.
+	index := func(t time.Time) int {
+		for i := range periods {
+			if t.Before(periods[i].End) {
+				if t.Before(periods[i].Start) {
+					return -1
+				}
+				return i
+			}
+		}
+		return len(periods)
+	}
+
+	for _, t := range created {
+		if i := index(t); i >= 0 && i < len(periods) {
+			periods[i].New++
+		}
+	}
+
+	// after[i] is the change in active tenants since the end of period i.
+	after := make([]int, len(periods))
+	for _, c := range changes {
+		delta := 0
+		if c.To == StatusActive {
+			delta++
+		}
+		if c.From == StatusActive {
+			delta--
+		}
+
+		i := index(c.At)
+		if i < 0 {
+			continue
+		}
+		for j := 0; j < i && j < len(periods); j++ {
+			after[j] += delta
+		}
+		if i == len(periods) {
+			continue
+		}
+		periods[i].Net += delta
+		switch c.Event {
+		case EventDelete:
+			periods[i].Churned++
+		case EventSuspend:
+			periods[i].Suspended++
+		}
+	}
+
+	for i := range periods {
+		periods[i].Active = active - after[i]
+	}
+	return periods
+}
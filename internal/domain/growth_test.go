@@ -0,0 +1,58 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestReportPeriod_Truncate(t *testing.T) {
+	at := time.Date(2026, time.October, 17, 15, 4, 5, 0, time.UTC) // a Saturday
+	tests := []struct {
+		period domain.ReportPeriod
+		want   time.Time
+	}{
+		{domain.PeriodDay, time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)},
+		{domain.PeriodWeek, time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)},
+		{domain.PeriodMonth, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := tt.period.Truncate(at); !got.Equal(tt.want) {
+			t.Errorf("%s: Truncate() = %s, want %s", tt.period, got, tt.want)
+		}
+	}
+}
+
+func TestGrowth(t *testing.T) {
+	month := func(m time.Month, day int) time.Time { return time.Date(2026, m, day, 12, 0, 0, 0, time.UTC) }
+	created := []time.Time{month(time.January, 5), month(time.January, 20), month(time.February, 3)}
+	changes := []domain.StatusChange{
+		{TenantID: "a", From: domain.StatusCreating, To: domain.StatusActive, Event: domain.EventProvisionComplete, At: month(time.January, 5)},
+		{TenantID: "b", From: domain.StatusCreating, To: domain.StatusActive, Event: domain.EventProvisionComplete, At: month(time.January, 21)},
+		{TenantID: "c", From: domain.StatusCreating, To: domain.StatusActive, Event: domain.EventProvisionComplete, At: month(time.February, 3)},
+		{TenantID: "a", From: domain.StatusActive, To: domain.StatusSuspended, Event: domain.EventSuspend, At: month(time.February, 10)},
+		{TenantID: "a", From: domain.StatusSuspended, To: domain.StatusDeleting, Event: domain.EventDelete, At: month(time.March, 1)},
+	}
+
+	// b and c are active now.
+	got := domain.Growth(domain.PeriodMonth, month(time.January, 1), month(time.March, 15), created, changes, 2)
+
+	want := []struct{ new, churned, suspended, net, active int }{
+		{2, 0, 0, 2, 2}, // January
+		{1, 0, 1, 0, 2}, // February: c activated, a suspended
+		{0, 1, 0, 0, 2}, // March: a deleted while suspended
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d periods, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.New != w.new || g.Churned != w.churned || g.Suspended != w.suspended || g.Net != w.net || g.Active != w.active {
+			t.Errorf("period %s = %+v, want %+v", g.Start.Format("2006-01"), g, w)
+		}
+	}
+	if !got[1].Start.Equal(time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)) || !got[1].End.Equal(got[2].Start) {
+		t.Errorf("February = [%s, %s), want calendar month", got[1].Start, got[1].End)
+	}
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "math"
+
+// disruptiveEvents are the events that take a working tenant out of service.
+var disruptiveEvents = map[Event]bool{
+	EventSuspend: true,
+	EventDelete:  true,
+}
+
+// IsDisruptive reports whether the event takes a tenant out of service.
+func IsDisruptive(e Event) bool {
+	return disruptiveEvents[e]
+}
+
+// Guardrail limits how many tenants a single mass operation (bulk endpoint,
+// declarative sync) may suspend or delete, so a broken automation run cannot
+// take the whole fleet down. Operations can bypass it with an explicit force.
+type Guardrail struct {
+	// MaxDisruptedPercent is the share of active tenants (0-100) one operation
+	// may disrupt. Zero disables the guardrail. At least one tenant is always
+	// allowed so small fleets remain manageable.
+	MaxDisruptedPercent float64
+}
+
+// Check returns a GuardrailError if disrupting the given number of tenants
+// out of active exceeds the limit.
+func (g Guardrail) Check(disrupted, active int) error {
+	if g.MaxDisruptedPercent <= 0 || disrupted == 0 {
+		return nil
+	}
+	limit := int(math.Ceil(float64(active) * g.MaxDisruptedPercent / 100))
+	if limit < 1 {
+		limit = 1
+	}
+	if disrupted > limit {
+		return &GuardrailError{Disrupted: disrupted, Active: active, Limit: limit}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestGuardrail_Check(t *testing.T) {
+	cases := []struct {
+		name      string
+		percent   float64
+		disrupted int
+		active    int
+		wantErr   bool
+	}{
+		{"disabled", 0, 100, 100, false},
+		{"nothing disrupted", 10, 0, 100, false},
+		{"within limit", 10, 10, 100, false},
+		{"over limit", 10, 11, 100, true},
+		{"small fleet allows one", 10, 1, 3, false},
+		{"small fleet blocks two", 10, 2, 3, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := domain.Guardrail{MaxDisruptedPercent: tc.percent}.Check(tc.disrupted, tc.active)
+			var gErr *domain.GuardrailError
+			if got := errors.As(err, &gErr); got != tc.wantErr {
+				t.Errorf("Check(%d, %d) = %v, wantErr %v", tc.disrupted, tc.active, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsDisruptive(t *testing.T) {
+	if !domain.IsDisruptive(domain.EventSuspend) || !domain.IsDisruptive(domain.EventDelete) {
+		t.Error("suspend and delete must be disruptive")
+	}
+	if domain.IsDisruptive(domain.EventReactivate) {
+		t.Error("reactivate must not be disruptive")
+	}
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// StatusChange records one lifecycle transition of a tenant, so support can
+// answer when and why a tenant reached a status.
+type StatusChange struct {
+	TenantID string
+	From     Status
+	To       Status
+	Event    Event
+	// Actor identifies who triggered the transition (see WithActor).
+	Actor string
+	At    time.Time
+}
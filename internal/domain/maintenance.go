@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// week is the period maintenance windows repeat over.
+const week = 7 * 24 * time.Hour
+
+// MaintenanceWindow is a weekly period, in UTC, during which a tenant
+// accepts disruptive changes made by automation.
+type MaintenanceWindow struct {
+	Weekday time.Weekday
+	// Start is the offset of the window from midnight UTC.
+	Start    time.Duration
+	Duration time.Duration
+}
+
+// Validate checks the window starts within its day and lasts at most a week.
+func (w MaintenanceWindow) Validate() error {
+	if w.Weekday < time.Sunday || w.Weekday > time.Saturday {
+		return fmt.Errorf("unknown weekday %d", w.Weekday)
+	}
+	if w.Start < 0 || w.Start >= 24*time.Hour {
+		return fmt.Errorf("start %s is not within a day", w.Start)
+	}
+	if w.Duration <= 0 || w.Duration > week {
+		return fmt.Errorf("duration %s must be positive and at most a week", w.Duration)
+	}
+	return nil
+}
+
+// latestStart returns the start of the window's occurrence that began at
+// or before now.
+func (w MaintenanceWindow) latestStart(now time.Time) time.Time {
+	now = now.UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	days := (int(now.Weekday()) - int(w.Weekday) + 7) % 7
+	start := midnight.AddDate(0, 0, -days).Add(w.Start)
+	if start.After(now) {
+		start = start.Add(-week)
+	}
+	return start
+}
+
+// MaintenanceSchedule is the set of windows a tenant declared. A tenant
+// without windows accepts changes at any time.
+type MaintenanceSchedule []MaintenanceWindow
+
+// Validate checks every window of the schedule.
+func (s MaintenanceSchedule) Validate() error {
+	for i, w := range s {
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("window %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Open reports whether now falls within one of the windows.
+func (s MaintenanceSchedule) Open(now time.Time) bool {
+	if len(s) == 0 {
+		return true
+	}
+	for _, w := range s {
+		if now.Before(w.latestStart(now).Add(w.Duration)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns when the schedule is next open: now when it already is,
+// otherwise the start of the earliest upcoming window.
+func (s MaintenanceSchedule) Next(now time.Time) time.Time {
+	if s.Open(now) {
+		return now
+	}
+	var next time.Time
+	for _, w := range s {
+		start := w.latestStart(now).Add(week)
+		if next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+	return next
+}
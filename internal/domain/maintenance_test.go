@@ -0,0 +1,69 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestMaintenanceSchedule_OpenAndNext(t *testing.T) {
+	// Sundays 02:00-06:00 UTC, and Wednesday 22:00 to Thursday 01:00.
+	s := domain.MaintenanceSchedule{
+		{Weekday: time.Sunday, Start: 2 * time.Hour, Duration: 4 * time.Hour},
+		{Weekday: time.Wednesday, Start: 22 * time.Hour, Duration: 3 * time.Hour},
+	}
+	at := func(day, hour int) time.Time { // October 2026: the 4th is a Sunday
+		return time.Date(2026, time.October, day, hour, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		open bool
+		next time.Time
+	}{
+		{"sunday inside", at(4, 3), true, at(4, 3)},
+		{"sunday before", at(4, 1), false, time.Date(2026, time.October, 4, 2, 0, 0, 0, time.UTC)},
+		{"sunday after", at(4, 7), false, time.Date(2026, time.October, 7, 22, 0, 0, 0, time.UTC)},
+		{"past midnight", at(8, 0), true, at(8, 0)},
+		{"thursday after", at(8, 2), false, time.Date(2026, time.October, 11, 2, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Open(tt.now); got != tt.open {
+				t.Errorf("Open() = %v, want %v", got, tt.open)
+			}
+			if got := s.Next(tt.now); !got.Equal(tt.next) {
+				t.Errorf("Next() = %s, want %s", got, tt.next)
+			}
+		})
+	}
+}
+
+func TestMaintenanceSchedule_EmptyIsAlwaysOpen(t *testing.T) {
+	if !domain.MaintenanceSchedule(nil).Open(time.Now()) {
+		t.Error("Open() = false, want true without windows")
+	}
+}
+
+func TestMaintenanceWindow_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		w       domain.MaintenanceWindow
+		wantErr bool
+	}{
+		{"valid", domain.MaintenanceWindow{Weekday: time.Monday, Start: time.Hour, Duration: time.Hour}, false},
+		{"start past the day", domain.MaintenanceWindow{Start: 24 * time.Hour, Duration: time.Hour}, true},
+		{"no duration", domain.MaintenanceWindow{Start: time.Hour}, true},
+		{"longer than a week", domain.MaintenanceWindow{Duration: 8 * 24 * time.Hour}, true},
+		{"unknown weekday", domain.MaintenanceWindow{Weekday: 7, Duration: time.Hour}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.w.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"net/mail"
+	"time"
+)
+
+// MemberRole is what a member may do in their tenant. Tenantiq records it;
+// the tenant's applications enforce it.
+type MemberRole string
+
+const (
+	// MemberRoleOwner manages the tenant itself: its plan, billing and
+	// deletion.
+	MemberRoleOwner MemberRole = "owner"
+	// MemberRoleAdmin manages the tenant's members and configuration.
+	MemberRoleAdmin MemberRole = "admin"
+	// MemberRoleMember uses the tenant's applications.
+	MemberRoleMember MemberRole = "member"
+)
+
+// MemberRoles lists the valid roles, most privileged first.
+var MemberRoles = []MemberRole{MemberRoleOwner, MemberRoleAdmin, MemberRoleMember}
+
+// MemberStatus is where a member's invitation stands.
+type MemberStatus string
+
+const (
+	// MemberInvited has been invited and has not accepted yet.
+	MemberInvited MemberStatus = "invited"
+	// MemberActive accepted their invitation.
+	MemberActive MemberStatus = "active"
+)
+
+// Member is a person attached to a tenant, identified within it by their
+// email address.
+type Member struct {
+	ID       string
+	TenantID string
+	// Email is lowercase; a tenant has at most one member per address.
+	Email  string
+	Role   MemberRole
+	Status MemberStatus
+	// InvitedBy is the actor who invited the member.
+	InvitedBy string
+	// AcceptedAt is when the invitation was accepted; zero until it is.
+	AcceptedAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewMember invites email to a tenant with the given role, after
+// validating them.
+func NewMember(id, tenantID, email string, role MemberRole, invitedBy string) (Member, error) {
+	now := time.Now().UTC()
+	m := Member{
+		ID:        id,
+		TenantID:  tenantID,
+		Email:     email,
+		Role:      role,
+		Status:    MemberInvited,
+		InvitedBy: invitedBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return m, m.Validate()
+}
+
+// Validate checks the email is a bare address and the role is known.
+func (m Member) Validate() error {
+	addr, err := mail.ParseAddress(m.Email)
+	if err != nil || addr.Address != m.Email || addr.Name != "" {
+		return &InvalidMemberError{Reason: fmt.Sprintf("email %q is not a valid address", m.Email)}
+	}
+	for _, r := range MemberRoles {
+		if m.Role == r {
+			return nil
+		}
+	}
+	return &InvalidMemberError{Reason: fmt.Sprintf("role %q must be one of %v", m.Role, MemberRoles)}
+}
+
+// Accept records the member's invitation as accepted at now. Accepting
+// again keeps the first acceptance.
+func (m *Member) Accept(now time.Time) {
+	if m.Status == MemberActive {
+		return
+	}
+	m.Status = MemberActive
+	m.AcceptedAt = now.UTC()
+	m.UpdatedAt = now.UTC()
+}
@@ -0,0 +1,34 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestNewMember_Validates(t *testing.T) {
+	cases := []struct {
+		email   string
+		role    domain.MemberRole
+		wantErr bool
+	}{
+		{"bob@acme.com", domain.MemberRoleOwner, false},
+		{"bob+ops@mail.acme.com", domain.MemberRoleMember, false},
+		{"bob", domain.MemberRoleMember, true},
+		{"Bob <bob@acme.com>", domain.MemberRoleMember, true},
+		{"", domain.MemberRoleMember, true},
+		{"bob@acme.com", "", true},
+		{"bob@acme.com", "root", true},
+	}
+	for _, tc := range cases {
+		m, err := domain.NewMember("mem_1", "ten_1", tc.email, tc.role, "api")
+		var memberErr *domain.InvalidMemberError
+		if tc.wantErr != errors.As(err, &memberErr) {
+			t.Errorf("NewMember(%q, %q) = %v, want error %v", tc.email, tc.role, err, tc.wantErr)
+		}
+		if err == nil && m.Status != domain.MemberInvited {
+			t.Errorf("NewMember status = %q, want invited", m.Status)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+)
+
+// Limits of a tenant's metadata, which is stored with the tenant and
+// returned with it everywhere.
+const (
+	MaxMetadataEntries     = 50
+	MaxMetadataKeyLength   = 64
+	MaxMetadataValueLength = 512
+)
+
+// InvalidMetadataError is returned when tenant metadata is over its limits
+// or has a malformed key.
+type InvalidMetadataError struct {
+	// Key is the offending key, or empty when the metadata as a whole is
+	// invalid.
+	Key    string
+	Reason string
+}
+
+func (e *InvalidMetadataError) Error() string {
+	if e.Key == "" {
+		return "invalid metadata: " + e.Reason
+	}
+	return fmt.Sprintf("invalid metadata key %q: %s", e.Key, e.Reason)
+}
+
+// ValidateMetadata checks metadata against its limits. Keys are letters,
+// digits, '_', '-' and '.', starting with a letter or digit, so they can
+// be named in list filters (metadata.<key>=<value>).
+func ValidateMetadata(metadata map[string]string) error {
+	if len(metadata) > MaxMetadataEntries {
+		return &InvalidMetadataError{Reason: fmt.Sprintf("%d entries, at most %d allowed", len(metadata), MaxMetadataEntries)}
+	}
+	for k, v := range metadata {
+		if err := validateMetadataKey(k); err != nil {
+			return err
+		}
+		if len(v) > MaxMetadataValueLength {
+			return &InvalidMetadataError{Key: k, Reason: fmt.Sprintf("value longer than %d bytes", MaxMetadataValueLength)}
+		}
+	}
+	return nil
+}
+
+func validateMetadataKey(key string) error {
+	if key == "" {
+		return &InvalidMetadataError{Key: key, Reason: "empty key"}
+	}
+	if len(key) > MaxMetadataKeyLength {
+		return &InvalidMetadataError{Key: key, Reason: fmt.Sprintf("longer than %d bytes", MaxMetadataKeyLength)}
+	}
+	for i, r := range key {
+		alnum := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+		if alnum || i > 0 && (r == '_' || r == '-' || r == '.') {
+			continue
+		}
+		return &InvalidMetadataError{Key: key, Reason: fmt.Sprintf("unexpected %q (letters, digits, '_', '-' and '.' only, starting with a letter or digit)", r)}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package domain_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestValidateMetadata(t *testing.T) {
+	tooMany := make(map[string]string)
+	for i := range domain.MaxMetadataEntries + 1 {
+		tooMany[fmt.Sprintf("k%d", i)] = "v"
+	}
+
+	cases := []struct {
+		name     string
+		metadata map[string]string
+		wantErr  bool
+	}{
+		{"none", nil, false},
+		{"valid", map[string]string{"crm_id": "42", "hubspot.deal-id": "d_1", "9lives": "x"}, false},
+		{"empty key", map[string]string{"": "x"}, true},
+		{"space", map[string]string{"crm id": "42"}, true},
+		{"leading dot", map[string]string{".crm": "42"}, true},
+		{"long key", map[string]string{strings.Repeat("k", domain.MaxMetadataKeyLength+1): "x"}, true},
+		{"long value", map[string]string{"note": strings.Repeat("v", domain.MaxMetadataValueLength+1)}, true},
+		{"too many", tooMany, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := domain.ValidateMetadata(tc.metadata)
+			var metaErr *domain.InvalidMetadataError
+			if tc.wantErr != errors.As(err, &metaErr) {
+				t.Errorf("ValidateMetadata = %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "time"
+
+// OperationStatus is the progress of a long-running operation.
+type OperationStatus string
+
+const (
+	OperationPending   OperationStatus = "pending"
+	OperationSucceeded OperationStatus = "succeeded"
+	OperationFailed    OperationStatus = "failed"
+)
+
+// OperationKind identifies the asynchronous work an operation tracks.
+type OperationKind string
+
+const (
+	OperationProvision OperationKind = "provision"
+	OperationDeletion  OperationKind = "deletion"
+)
+
+// Operation tracks asynchronous work on a tenant, so clients can poll for
+// its outcome instead of assuming the request completed it.
+type Operation struct {
+	ID       string
+	Kind     OperationKind
+	TenantID string
+	Status   OperationStatus
+	// Error explains a failed operation.
+	Error string
+	// Result identifies what a succeeded operation produced, e.g. the ID
+	// of the provisioned tenant. Empty when there is nothing to point at.
+	Result string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewOperation creates a pending operation.
+func NewOperation(id string, kind OperationKind, tenantID string) Operation {
+	now := time.Now().UTC()
+	return Operation{
+		ID:        id,
+		Kind:      kind,
+		TenantID:  tenantID,
+		Status:    OperationPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Done reports whether the operation has reached a final status.
+func (o Operation) Done() bool {
+	return o.Status != OperationPending
+}
+
+// Succeed returns a copy of o marked as succeeded with the given result.
+func (o Operation) Succeed(result string) Operation {
+	o.Status = OperationSucceeded
+	o.Error = ""
+	o.Result = result
+	o.UpdatedAt = time.Now().UTC()
+	return o
+}
+
+// Fail returns a copy of o marked as failed with the given reason.
+func (o Operation) Fail(reason string) Operation {
+	o.Status = OperationFailed
+	o.Error = reason
+	o.UpdatedAt = time.Now().UTC()
+	return o
+}
@@ -0,0 +1,38 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestNewOperation(t *testing.T) {
+	op := domain.NewOperation("op_1", domain.OperationProvision, "ten_1")
+
+	if op.Status != domain.OperationPending {
+		t.Errorf("Status = %q, want %q", op.Status, domain.OperationPending)
+	}
+	if op.Done() {
+		t.Error("a new operation should not be done")
+	}
+	if op.CreatedAt.IsZero() || !op.CreatedAt.Equal(op.UpdatedAt) {
+		t.Errorf("CreatedAt = %v, UpdatedAt = %v", op.CreatedAt, op.UpdatedAt)
+	}
+}
+
+func TestOperation_SucceedAndFail(t *testing.T) {
+	op := domain.NewOperation("op_1", domain.OperationProvision, "ten_1")
+
+	failed := op.Fail("tenant was deleted")
+	if failed.Status != domain.OperationFailed || failed.Error != "tenant was deleted" || !failed.Done() {
+		t.Errorf("failed = %+v", failed)
+	}
+	if op.Status != domain.OperationPending {
+		t.Error("Fail must not mutate the original operation")
+	}
+
+	succeeded := failed.Succeed("ten_1")
+	if succeeded.Status != domain.OperationSucceeded || succeeded.Error != "" || succeeded.Result != "ten_1" {
+		t.Errorf("succeeded = %+v", succeeded)
+	}
+}
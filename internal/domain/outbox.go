@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// OutboxMessage is an event stored in the same transaction as the tenant
+// change that caused it, waiting to be relayed to the event publisher.
+type OutboxMessage struct {
+	// ID orders the messages; it is assigned by the outbox.
+	ID int64
+	// EventID identifies the event once published (the CloudEvent id), so
+	// consumers recognize a message relayed twice.
+	EventID string
+	Event   Event
+	Tenant  Tenant
+	// Changes are the attributes a change event changed.
+	Changes []FieldChange
+	// Priority is the priority the event's jobs are queued at.
+	Priority  Priority
+	CreatedAt time.Time
+}
@@ -0,0 +1,280 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Plan is a subscription plan tenants can be on. Its name is what
+// Tenant.Plan holds.
+type Plan struct {
+	Name string
+	// Price is the monthly price in the smallest unit of Currency (e.g.
+	// cents); zero for free plans.
+	Price    int64
+	Currency string
+	// Limits caps the usage allowed on the plan per metric, like
+	// PlanQuota.Limits.
+	Limits map[string]int64
+	// Features lists the features the plan includes, sorted.
+	Features []string
+	// Regions lists the regions the plan is offered in, sorted; empty
+	// means every region.
+	Regions []string
+	// RateLimit is the request rate of the plan's tenants, unless
+	// overridden per tenant; zero leaves them unlimited.
+	RateLimit RateLimit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+var (
+	planNamePattern = regexp.MustCompile(`^[a-z0-9]+([_-][a-z0-9]+)*$`)
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// MaxPlanNameLength is the longest plan name accepted.
+const MaxPlanNameLength = 63
+
+// NewPlan creates a plan after validating it. Features are sorted and
+// deduplicated.
+func NewPlan(name string, price int64, currency string, limits map[string]int64, features []string) (Plan, error) {
+	now := time.Now().UTC()
+	p := Plan{
+		Name:      name,
+		Price:     price,
+		Currency:  currency,
+		Limits:    limits,
+		Features:  normalizeFeatures(features),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return p, p.Validate()
+}
+
+// Validate checks the name is lowercase words separated by hyphens or
+// underscores, the price and limits are not negative, the currency is an
+// ISO 4217 code and the rate limit, when set, is valid.
+func (p Plan) Validate() error {
+	if len(p.Name) > MaxPlanNameLength || !planNamePattern.MatchString(p.Name) {
+		return &InvalidPlanError{Reason: fmt.Sprintf("name %q must be lowercase letters and digits separated by single hyphens or underscores", p.Name)}
+	}
+	if p.Price < 0 {
+		return &InvalidPlanError{Reason: "price must not be negative"}
+	}
+	if !currencyPattern.MatchString(p.Currency) {
+		return &InvalidPlanError{Reason: fmt.Sprintf("currency %q must be an ISO 4217 code such as USD", p.Currency)}
+	}
+	for metric, limit := range p.Limits {
+		if metric == "" || limit < 0 {
+			return &InvalidPlanError{Reason: fmt.Sprintf("limit %q must be a named metric with a non-negative value", metric)}
+		}
+	}
+	for _, f := range p.Features {
+		if f == "" {
+			return &InvalidPlanError{Reason: "feature names must not be empty"}
+		}
+	}
+	for _, r := range p.Regions {
+		if err := ValidateRegion(r); err != nil {
+			return &InvalidPlanError{Reason: err.Error()}
+		}
+	}
+	if !p.RateLimit.IsZero() {
+		if err := p.RateLimit.Validate(); err != nil {
+			return &InvalidPlanError{Reason: "rate limit: " + err.Error()}
+		}
+	}
+	return nil
+}
+
+// AvailableIn reports whether tenants in region can be on the plan. A plan
+// limited to some regions is not available to tenants without a region.
+func (p Plan) AvailableIn(region string) bool {
+	return len(p.Regions) == 0 || slices.Contains(p.Regions, region)
+}
+
+// CheckRegion returns a *PlanNotInRegionError when the plan is not
+// available in region.
+func (p Plan) CheckRegion(region string) error {
+	if p.AvailableIn(region) {
+		return nil
+	}
+	return &PlanNotInRegionError{Plan: p.Name, Region: region, Regions: p.Regions}
+}
+
+// normalizeFeatures returns features sorted and without duplicates.
+func normalizeFeatures(features []string) []string {
+	if len(features) == 0 {
+		return nil
+	}
+	out := slices.Clone(features)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// ClosestPlanName returns the name among names nearest to name, when it is
+// close enough to be a typo (at most two edits away), or "".
+func ClosestPlanName(name string, names []string) string {
+	best, bestDist := "", 3
+	for _, n := range names {
+		if d := editDistance(name, n); d < bestDist {
+			best, bestDist = n, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// Usage is a tenant's consumption per metric (e.g. "seats", "storage_gb"),
+// as reported by metering.
+type Usage map[string]int64
+
+// Well-known metrics plans commonly limit. Any other metric name can be
+// limited and reported too.
+const (
+	MetricProjects = "projects"
+	MetricAPICalls = "api_calls"
+	MetricSeats    = "seats"
+)
+
+// PeriodicMetric reports whether metric counts consumption over the
+// metering period, like MetricAPICalls, rather than resources the tenant
+// holds: its usage resets with the period.
+func PeriodicMetric(metric string) bool {
+	return metric == MetricAPICalls
+}
+
+// Limit returns the plan's limit on metric, or false when the metric is
+// unlimited on the plan.
+func (p Plan) Limit(metric string) (int64, bool) {
+	limit, ok := p.Limits[metric]
+	return limit, ok
+}
+
+// PlanQuota caps the usage allowed on a plan. Metrics without a limit are
+// unlimited on that plan.
+type PlanQuota struct {
+	Plan   string
+	Limits map[string]int64
+	// RateLimit is the default request rate of the plan's tenants; zero
+	// leaves them unlimited.
+	RateLimit RateLimit
+	// Priority is the priority of the asynchronous work of the plan's
+	// tenants; empty means PriorityNormal.
+	Priority Priority
+}
+
+// Exceeded describes the metrics of u over the quota's limits, in metric
+// order, or returns nil when u fits.
+func (q PlanQuota) Exceeded(u Usage) []string {
+	var over []string
+	for metric, value := range u {
+		if limit, ok := q.Limits[metric]; ok && value > limit {
+			over = append(over, fmt.Sprintf("%s %d > %d", metric, value, limit))
+		}
+	}
+	sort.Strings(over)
+	return over
+}
+
+// PlanCatalog lists the plans usage is matched against, from the smallest
+// to the largest.
+type PlanCatalog []PlanQuota
+
+// Validate checks that every plan is named once and that rate limits and
+// priorities, when set, are valid.
+func (c PlanCatalog) Validate() error {
+	seen := make(map[string]bool, len(c))
+	for _, q := range c {
+		if q.Plan == "" {
+			return fmt.Errorf("plan quota has no plan")
+		}
+		if seen[q.Plan] {
+			return fmt.Errorf("plan %q is declared twice", q.Plan)
+		}
+		if !q.RateLimit.IsZero() {
+			if err := q.RateLimit.Validate(); err != nil {
+				return fmt.Errorf("plan %q rate limit: %w", q.Plan, err)
+			}
+		}
+		if q.Priority != "" {
+			if _, err := ParsePriority(string(q.Priority)); err != nil {
+				return fmt.Errorf("plan %q: %w", q.Plan, err)
+			}
+		}
+		seen[q.Plan] = true
+	}
+	return nil
+}
+
+// Suggest returns the smallest plan whose limits fit u, or "" when u is
+// empty, the current plan is not in the catalog, no plan fits, or the best
+// fit is the current plan. Reason explains the suggestion.
+func (c PlanCatalog) Suggest(current string, u Usage) (plan, reason string) {
+	cur, ok := c.quota(current)
+	if len(u) == 0 || !ok {
+		return "", ""
+	}
+	for _, q := range c {
+		if q.Exceeded(u) != nil {
+			continue
+		}
+		if q.Plan == current {
+			return "", ""
+		}
+		if over := cur.Exceeded(u); over != nil {
+			return q.Plan, fmt.Sprintf("usage exceeds plan %s: %s", current, strings.Join(over, ", "))
+		}
+		return q.Plan, fmt.Sprintf("usage fits the smaller plan %s", q.Plan)
+	}
+	return "", ""
+}
+
+// RateLimit returns the rate limit of the plan, zero when it sets none.
+func (c PlanCatalog) RateLimit(plan string) RateLimit {
+	q, _ := c.quota(plan)
+	return q.RateLimit
+}
+
+// Priority returns the priority of the plan's work: PriorityNormal unless
+// the plan sets one.
+func (c PlanCatalog) Priority(plan string) Priority {
+	if q, ok := c.quota(plan); ok && q.Priority != "" {
+		return q.Priority
+	}
+	return PriorityNormal
+}
+
+func (c PlanCatalog) quota(plan string) (PlanQuota, bool) {
+	for _, q := range c {
+		if q.Plan == plan {
+			return q, true
+		}
+	}
+	return PlanQuota{}, false
+}
@@ -0,0 +1,115 @@
+package domain_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestPlanCatalog_Suggest(t *testing.T) {
+	catalog := domain.PlanCatalog{
+		{Plan: "free", Limits: map[string]int64{"seats": 5, "storage_gb": 10}},
+		{Plan: "pro", Limits: map[string]int64{"seats": 50}},
+		{Plan: "enterprise"},
+	}
+
+	cases := []struct {
+		name       string
+		current    string
+		usage      domain.Usage
+		want       string
+		wantReason string
+	}{
+		{"fits current", "free", domain.Usage{"seats": 3}, "", ""},
+		{"upgrade", "free", domain.Usage{"seats": 12}, "pro", "seats 12 > 5"},
+		{"upgrade skips too small plans", "free", domain.Usage{"seats": 80}, "enterprise", "seats 80 > 5"},
+		{"downgrade", "enterprise", domain.Usage{"seats": 2}, "free", "smaller plan free"},
+		{"no usage reported", "free", nil, "", ""},
+		{"unknown current plan", "legacy", domain.Usage{"seats": 80}, "", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, reason := catalog.Suggest(tc.current, tc.usage)
+			if got != tc.want {
+				t.Errorf("Suggest = %q, want %q", got, tc.want)
+			}
+			if !strings.Contains(reason, tc.wantReason) {
+				t.Errorf("reason = %q, want it to mention %q", reason, tc.wantReason)
+			}
+		})
+	}
+}
+
+func TestPlanCatalog_Validate(t *testing.T) {
+	if err := (domain.PlanCatalog{{Plan: "free"}, {Plan: "free"}}).Validate(); err == nil {
+		t.Error("expected error for duplicate plan")
+	}
+	if err := (domain.PlanCatalog{{}}).Validate(); err == nil {
+		t.Error("expected error for unnamed plan")
+	}
+	if err := (domain.PlanCatalog{{Plan: "free", Priority: "urgent"}}).Validate(); err == nil {
+		t.Error("expected error for unknown priority")
+	}
+}
+
+func TestPlanCatalog_Priority(t *testing.T) {
+	catalog := domain.PlanCatalog{{Plan: "free"}, {Plan: "enterprise", Priority: domain.PriorityHigh}}
+
+	for plan, want := range map[string]domain.Priority{
+		"enterprise": domain.PriorityHigh,
+		"free":       domain.PriorityNormal,
+		"unknown":    domain.PriorityNormal,
+	} {
+		if got := catalog.Priority(plan); got != want {
+			t.Errorf("Priority(%q) = %q, want %q", plan, got, want)
+		}
+	}
+	if got := domain.PlanCatalog(nil).Priority("free"); got != domain.PriorityNormal {
+		t.Errorf("nil catalog Priority = %q, want normal", got)
+	}
+}
+
+func TestNewPlan(t *testing.T) {
+	p, err := domain.NewPlan("pro", 4900, "USD", map[string]int64{"seats": 50}, []string{"sso", "api", "sso"})
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	if strings.Join(p.Features, ",") != "api,sso" || p.CreatedAt.IsZero() {
+		t.Errorf("plan = %+v, want sorted unique features and timestamps", p)
+	}
+
+	for name, plan := range map[string]domain.Plan{
+		"uppercase name":  {Name: "Pro", Currency: "USD"},
+		"trailing hyphen": {Name: "pro-", Currency: "USD"},
+		"negative price":  {Name: "pro", Price: -1, Currency: "USD"},
+		"bad currency":    {Name: "pro", Currency: "usd"},
+		"negative limit":  {Name: "pro", Currency: "USD", Limits: map[string]int64{"seats": -1}},
+		"empty feature":   {Name: "pro", Currency: "USD", Features: []string{""}},
+	} {
+		var invalid *domain.InvalidPlanError
+		if err := plan.Validate(); !errors.As(err, &invalid) {
+			t.Errorf("%s: Validate() = %v, want *InvalidPlanError", name, err)
+		}
+	}
+}
+
+func TestClosestPlanName(t *testing.T) {
+	names := []string{"free", "professional", "enterprise"}
+
+	for name, want := range map[string]string{
+		"porfessional": "professional",
+		"fre":          "free",
+		"startup":      "",
+	} {
+		if got := domain.ClosestPlanName(name, names); got != want {
+			t.Errorf("ClosestPlanName(%q) = %q, want %q", name, got, want)
+		}
+	}
+	err := &domain.UnknownPlanError{Plan: "porfessional", Suggestion: "professional"}
+	if !strings.Contains(err.Error(), `did you mean "professional"`) {
+		t.Errorf("error = %q, want the suggestion", err)
+	}
+}
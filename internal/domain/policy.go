@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// PolicyEffect is what a transition policy does to a matching transition.
+type PolicyEffect string
+
+const (
+	// PolicyDeny refuses the transition outright.
+	PolicyDeny PolicyEffect = "deny"
+	// PolicyRequireApproval allows the transition only when someone other
+	// than the actor approved it (see WithApprover).
+	PolicyRequireApproval PolicyEffect = "require_approval"
+)
+
+// TransitionPolicy restricts lifecycle transitions of tenants on one plan.
+// Event and To narrow the transitions it matches; an empty one matches
+// any event or destination status.
+type TransitionPolicy struct {
+	Plan   string
+	Event  Event
+	To     Status
+	Effect PolicyEffect
+	// Reason is shown to callers whose transition the policy refuses.
+	Reason string
+}
+
+// Validate checks that the policy names a plan, a known effect, and only
+// events and statuses that exist in Transitions.
+func (p TransitionPolicy) Validate() error {
+	if p.Plan == "" {
+		return fmt.Errorf("policy has no plan")
+	}
+	if p.Effect != PolicyDeny && p.Effect != PolicyRequireApproval {
+		return fmt.Errorf("policy for plan %q has unknown effect %q", p.Plan, p.Effect)
+	}
+	if p.Event != "" && !slices.Contains(Events(), p.Event) {
+		return fmt.Errorf("policy for plan %q names unknown event %q", p.Plan, p.Event)
+	}
+	if p.To != "" && !slices.ContainsFunc(Transitions, func(t Transition) bool { return t.Dst == p.To }) {
+		return fmt.Errorf("policy for plan %q names unreachable status %q", p.Plan, p.To)
+	}
+	return nil
+}
+
+func (p TransitionPolicy) matches(plan string, event Event, to Status) bool {
+	return p.Plan == plan &&
+		(p.Event == "" || p.Event == event) &&
+		(p.To == "" || p.To == to)
+}
+
+// TransitionPolicies is the set of per-plan restrictions checked after a
+// transition is known to be valid for the tenant's status.
+type TransitionPolicies []TransitionPolicy
+
+// Check returns a PolicyError for the first policy that refuses moving a
+// tenant on plan to status to with event. Approval comes from ctx.
+func (ps TransitionPolicies) Check(ctx context.Context, plan string, event Event, to Status) error {
+	for _, p := range ps {
+		if !p.matches(plan, event, to) {
+			continue
+		}
+		if p.Effect == PolicyRequireApproval && approved(ctx) {
+			continue
+		}
+		return &PolicyError{Plan: plan, Event: event, Effect: p.Effect, Reason: p.Reason}
+	}
+	return nil
+}
+
+// approved reports whether ctx carries an approver other than the actor.
+func approved(ctx context.Context) bool {
+	approver := ApproverFromContext(ctx)
+	return approver != "" && approver != ActorFromContext(ctx)
+}
@@ -0,0 +1,70 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestTransitionPolicies_Check(t *testing.T) {
+	policies := domain.TransitionPolicies{
+		{Plan: "free", To: domain.StatusSuspended, Effect: domain.PolicyDeny, Reason: "free tenants are deleted, not suspended"},
+		{Plan: "enterprise", Event: domain.EventDelete, Effect: domain.PolicyRequireApproval},
+	}
+	alice := domain.WithActor(context.Background(), "alice")
+
+	cases := []struct {
+		name       string
+		ctx        context.Context
+		plan       string
+		event      domain.Event
+		to         domain.Status
+		wantEffect domain.PolicyEffect
+	}{
+		{"other plan", alice, "pro", domain.EventSuspend, domain.StatusSuspended, ""},
+		{"denied destination", alice, "free", domain.EventSuspend, domain.StatusSuspended, domain.PolicyDeny},
+		{"other destination", alice, "free", domain.EventDelete, domain.StatusDeleting, ""},
+		{"approval missing", alice, "enterprise", domain.EventDelete, domain.StatusDeleting, domain.PolicyRequireApproval},
+		{"self approval", domain.WithApprover(alice, "alice"), "enterprise", domain.EventDelete, domain.StatusDeleting, domain.PolicyRequireApproval},
+		{"approved", domain.WithApprover(alice, "bob"), "enterprise", domain.EventDelete, domain.StatusDeleting, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := policies.Check(tc.ctx, tc.plan, tc.event, tc.to)
+			var pErr *domain.PolicyError
+			switch {
+			case tc.wantEffect == "" && err != nil:
+				t.Errorf("Check = %v, want nil", err)
+			case tc.wantEffect != "" && !errors.As(err, &pErr):
+				t.Errorf("Check = %v, want PolicyError", err)
+			case tc.wantEffect != "" && pErr.Effect != tc.wantEffect:
+				t.Errorf("effect = %q, want %q", pErr.Effect, tc.wantEffect)
+			}
+		})
+	}
+}
+
+func TestTransitionPolicy_Validate(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  domain.TransitionPolicy
+		wantErr bool
+	}{
+		{"valid", domain.TransitionPolicy{Plan: "free", Event: domain.EventSuspend, Effect: domain.PolicyDeny}, false},
+		{"no plan", domain.TransitionPolicy{Effect: domain.PolicyDeny}, true},
+		{"unknown effect", domain.TransitionPolicy{Plan: "free", Effect: "allow"}, true},
+		{"unknown event", domain.TransitionPolicy{Plan: "free", Event: "archive", Effect: domain.PolicyDeny}, true},
+		{"unknown status", domain.TransitionPolicy{Plan: "free", To: "archived", Effect: domain.PolicyDeny}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.policy.Validate(); (err != nil) != tc.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -1,31 +1,506 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // TenantRepository defines the persistence contract for tenants.
 type TenantRepository interface {
 	Create(ctx context.Context, tenant Tenant) error
+	// CreateMany persists all tenants atomically: on error none is stored.
+	CreateMany(ctx context.Context, tenants []Tenant) error
 	GetByID(ctx context.Context, id string) (Tenant, error)
 	GetBySlug(ctx context.Context, slug string) (Tenant, error)
 	List(ctx context.Context, filter ListFilter) ([]Tenant, error)
+	Count(ctx context.Context, filter ListFilter) (int, error)
+	// Update stores tenant and increments its version, provided the stored
+	// tenant is still at tenant.Version; otherwise it returns
+	// ErrConcurrentModification. Callers keeping tenant increment Version.
 	Update(ctx context.Context, tenant Tenant) error
 }
 
 // ListFilter holds optional criteria for listing tenants.
+// Count ignores Limit and Offset.
 type ListFilter struct {
 	Status *Status
+	// Statuses and Plans match any of the listed values when non-empty.
+	Statuses []Status
+	Plans    []string
+	// IDs restricts the result to the listed tenants when non-empty.
+	IDs []string
+	// ResellerID restricts the result to one reseller's tenants when set.
+	ResellerID string
+	// CreatedAfter (inclusive) and CreatedBefore (exclusive) bound the
+	// creation time when non-zero.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// Simulated restricts the result to simulated (true) or real (false)
+	// tenants when set.
+	Simulated *bool
+	// TrialEndsBy restricts the result to tenants on a trial ending at or
+	// before it when non-zero.
+	TrialEndsBy time.Time
+	// Metadata restricts the result to tenants whose metadata has every
+	// listed key with the listed value.
+	Metadata map[string]string
+	// Tags restricts the result to tenants with every listed tag.
+	Tags   []string
 	Limit  int
 	Offset int
 }
 
+// StatusCounter keeps the number of tenants in each status up to date as
+// tenants change, so dashboards read it without counting the tenants.
+type StatusCounter interface {
+	// Counts returns the maintained number of tenants per status that has
+	// any.
+	Counts(ctx context.Context) (map[Status]int, error)
+	// Reconcile recounts the tenants, corrects the maintained counts and
+	// returns the drift it corrected per status (maintained minus actual),
+	// empty when the counts were right.
+	Reconcile(ctx context.Context) (map[Status]int, error)
+}
+
+// TenantPurger permanently removes tenants, which TenantRepository never
+// does: deleting a tenant is a status change.
+type TenantPurger interface {
+	// Purge removes the tenant and the records that only exist for it
+	// (usage, maintenance windows, rate limit overrides, dunning). The
+	// audit trail and status history are kept. It returns
+	// ErrTenantNotFound when the tenant does not exist.
+	Purge(ctx context.Context, id string) error
+}
+
+// ResellerRepository persists the resellers of the delegated admin API.
+type ResellerRepository interface {
+	Create(ctx context.Context, reseller Reseller) error
+	GetByID(ctx context.Context, id string) (Reseller, error)
+}
+
+// UsageRepository stores the usage metering reports for each tenant.
+type UsageRepository interface {
+	// Record stores the reported metrics, replacing previous values of the
+	// same metrics and keeping the others.
+	Record(ctx context.Context, tenantID string, usage Usage) error
+	// Get returns the latest value of every metric reported for the tenant.
+	Get(ctx context.Context, tenantID string) (Usage, error)
+}
+
+// QuotaChecker enforces the limits of tenants' plans. The service consults
+// it before operations that consume quota.
+type QuotaChecker interface {
+	// Check returns a *QuotaExceededError when tenant consuming amount more
+	// of metric would go over its plan's limit. Metrics the plan does not
+	// limit always pass.
+	Check(ctx context.Context, tenant Tenant, metric string, amount int64) error
+}
+
+// WebhookRepository persists the subscriptions of outbound webhooks.
+type WebhookRepository interface {
+	Create(ctx context.Context, w WebhookSubscription) error
+	GetByID(ctx context.Context, id string) (WebhookSubscription, error)
+	// List returns every subscription, oldest first.
+	List(ctx context.Context) ([]WebhookSubscription, error)
+	// Update replaces the URL, secret and event filter of w.ID; its
+	// circuit is left to the methods below.
+	Update(ctx context.Context, w WebhookSubscription) error
+	Delete(ctx context.Context, id string) error
+	// RecordFailure counts a failed delivery at at, opening the circuit
+	// when it makes threshold failures in a row, and returns the circuit.
+	// Concurrent deliveries must not lose counts.
+	RecordFailure(ctx context.Context, id, reason string, at time.Time, threshold int) (WebhookCircuit, error)
+	// RecordSuccess resets the failure count. An open circuit stays open.
+	RecordSuccess(ctx context.Context, id string) error
+	// CloseCircuit resets the failure count and closes the circuit.
+	CloseCircuit(ctx context.Context, id string) error
+}
+
+// WebhookDigestRepository holds the events of batched webhook
+// subscriptions until their digest is delivered.
+type WebhookDigestRepository interface {
+	// Add holds entry; an event already held for the subscription is
+	// ignored, so redelivered events are not counted twice.
+	Add(ctx context.Context, entry DigestEntry) error
+	// Pending returns the entries held for the subscription that occurred
+	// before before, oldest first.
+	Pending(ctx context.Context, webhookID string, before time.Time) ([]DigestEntry, error)
+	// Remove drops the subscription's entries of the given events.
+	Remove(ctx context.Context, webhookID string, eventIDs []string) error
+}
+
+// Provisioner sets up and tears down the infrastructure of simulated
+// tenants. Real tenants are provisioned by the consumers of their events;
+// simulated ones never reach those, so a Provisioner plays their part.
+type Provisioner interface {
+	// Provision returns the references of what it set up for the tenant,
+	// keyed like Tenant.ExternalRefs, with the provisioning Git branch and
+	// pull request.
+	Provision(ctx context.Context, tenant Tenant) (Provisioning, error)
+	Deprovision(ctx context.Context, tenant Tenant) error
+}
+
+// Provisioning describes what a Provisioner set up for a tenant.
+type Provisioning struct {
+	PRURL        string
+	GitBranch    string
+	ExternalRefs map[string]string
+}
+
+// PlanRepository persists the plans tenants can be on, keyed by name.
+type PlanRepository interface {
+	// Create stores a new plan, or returns a *PlanConflictError when the
+	// name is taken.
+	Create(ctx context.Context, p Plan) error
+	Get(ctx context.Context, name string) (Plan, error)
+	// List returns every plan, by name.
+	List(ctx context.Context) ([]Plan, error)
+	Update(ctx context.Context, p Plan) error
+	Delete(ctx context.Context, name string) error
+}
+
+// BlueprintRepository persists tenant blueprints, keyed by name.
+type BlueprintRepository interface {
+	// Create stores a new blueprint, or returns a *BlueprintConflictError
+	// when the name is taken.
+	Create(ctx context.Context, b Blueprint) error
+	Get(ctx context.Context, name string) (Blueprint, error)
+	// List returns every blueprint, by name.
+	List(ctx context.Context) ([]Blueprint, error)
+	Update(ctx context.Context, b Blueprint) error
+	Delete(ctx context.Context, name string) error
+}
+
+// DunningRepository persists the dunning flow of tenants with unpaid invoices.
+type DunningRepository interface {
+	// Save creates or replaces the tenant's dunning.
+	Save(ctx context.Context, d Dunning) error
+	Get(ctx context.Context, tenantID string) (Dunning, error)
+	// ListDue returns the dunnings whose next step is due at now, oldest first.
+	ListDue(ctx context.Context, now time.Time) ([]Dunning, error)
+	Delete(ctx context.Context, tenantID string) error
+}
+
+// CertificateRepository persists the certificates of tenants' domains.
+type CertificateRepository interface {
+	// Save creates or replaces the certificate of cert.Domain.
+	Save(ctx context.Context, cert Certificate) error
+	Get(ctx context.Context, domain string) (Certificate, error)
+	// ListByTenant returns the tenant's certificates ordered by domain.
+	ListByTenant(ctx context.Context, tenantID string) ([]Certificate, error)
+	// ListDue returns at most limit certificates to request at now,
+	// soonest due first.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]Certificate, error)
+	Delete(ctx context.Context, domain string) error
+}
+
+// CertificateIssuer obtains certificates from a certificate authority.
+type CertificateIssuer interface {
+	// Issue proves control of domain to the authority, obtains a new
+	// certificate for it and stores the certificate with its private key
+	// where the ingress serves it from.
+	Issue(ctx context.Context, domain string) (IssuedCertificate, error)
+}
+
+// MemberRepository persists the members of tenants.
+type MemberRepository interface {
+	// Create stores a new member, or returns a MemberConflictError when
+	// the tenant already has a member with its email.
+	Create(ctx context.Context, m Member) error
+	Get(ctx context.Context, tenantID, id string) (Member, error)
+	// ListByTenant returns the tenant's members, oldest first.
+	ListByTenant(ctx context.Context, tenantID string) ([]Member, error)
+	Update(ctx context.Context, m Member) error
+	Delete(ctx context.Context, tenantID, id string) error
+}
+
+// APIKeyRepository persists the API keys of tenants.
+type APIKeyRepository interface {
+	Create(ctx context.Context, k APIKey) error
+	Get(ctx context.Context, tenantID, id string) (APIKey, error)
+	// GetByHash returns the key whose Hash is hash.
+	GetByHash(ctx context.Context, hash string) (APIKey, error)
+	// ListByTenant returns the tenant's keys, newest first.
+	ListByTenant(ctx context.Context, tenantID string) ([]APIKey, error)
+	// Revoke records the key as revoked at at, unless it already is.
+	Revoke(ctx context.Context, tenantID, id string, at time.Time) error
+	// Touch records the key as used at at.
+	Touch(ctx context.Context, id string, at time.Time) error
+}
+
+// IPAllowlistRepository persists the IP allowlists of tenants.
+type IPAllowlistRepository interface {
+	// Get returns an empty list when the tenant has none.
+	Get(ctx context.Context, tenantID string) (IPAllowlist, error)
+	// Set replaces the tenant's list; an empty one removes it.
+	Set(ctx context.Context, tenantID string, l IPAllowlist) error
+}
+
+// EncryptionKeyRepository persists the encryption keys tenants brought.
+type EncryptionKeyRepository interface {
+	// Get returns ErrEncryptionKeyNotFound when the tenant has no key.
+	Get(ctx context.Context, tenantID string) (EncryptionKey, error)
+	// Save creates or replaces the tenant's key.
+	Save(ctx context.Context, k EncryptionKey) error
+}
+
+// SecretRepository persists the sealed secrets of tenants.
+type SecretRepository interface {
+	// Put creates or replaces the secret.
+	Put(ctx context.Context, s TenantSecret) error
+	Get(ctx context.Context, tenantID, name string) (TenantSecret, error)
+	// ListByTenant returns the tenant's secrets, by name.
+	ListByTenant(ctx context.Context, tenantID string) ([]TenantSecret, error)
+	Delete(ctx context.Context, tenantID, name string) error
+}
+
+// AdminKeyRepository persists the keys of the management API.
+type AdminKeyRepository interface {
+	Create(ctx context.Context, k AdminKey) error
+	// GetByHash returns the key whose Hash is hash.
+	GetByHash(ctx context.Context, hash string) (AdminKey, error)
+	// List returns every key, newest first.
+	List(ctx context.Context) ([]AdminKey, error)
+	// Revoke records the key as revoked at at, unless it already is.
+	Revoke(ctx context.Context, id string, at time.Time) error
+	// Touch records the key as used at at.
+	Touch(ctx context.Context, id string, at time.Time) error
+}
+
+// TokenVerifier checks the bearer tokens of an identity provider. A token
+// that cannot be accepted is ErrTokenInvalid; other errors, such as the
+// provider's keys being unreachable, say nothing about the token.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (Claims, error)
+}
+
+// Outbox persists tenant changes together with the events they cause, in
+// one transaction, so an event is recorded if and only if its change is.
+type Outbox interface {
+	// CreateTenant inserts tenant and records msgs.
+	CreateTenant(ctx context.Context, tenant Tenant, msgs ...OutboxMessage) error
+	// UpdateTenant updates tenant and records msgs.
+	UpdateTenant(ctx context.Context, tenant Tenant, msgs ...OutboxMessage) error
+	// Pending returns up to limit recorded messages, oldest first.
+	Pending(ctx context.Context, limit int) ([]OutboxMessage, error)
+	// Delete removes a message once it has been published.
+	Delete(ctx context.Context, id int64) error
+}
+
+// UnitOfWork runs a tenant change and the publishing of its events in one
+// database transaction, for publishers that store events in the same
+// database as tenants (e.g. a job queue): both are committed or neither is.
+type UnitOfWork interface {
+	// Do calls fn with a repository and a publisher bound to a new
+	// transaction, which is committed when fn returns nil and rolled back
+	// otherwise.
+	Do(ctx context.Context, fn func(tenants TenantRepository, events EventPublisher) error) error
+}
+
+// MaintenanceRepository stores the maintenance windows declared by tenants.
+type MaintenanceRepository interface {
+	// Get returns the tenant's windows; empty when it declared none.
+	Get(ctx context.Context, tenantID string) (MaintenanceSchedule, error)
+	// Set replaces the tenant's windows.
+	Set(ctx context.Context, tenantID string, s MaintenanceSchedule) error
+}
+
+// RateLimitRepository stores per-tenant rate limit overrides, which take
+// precedence over the rate limit of the tenant's plan.
+type RateLimitRepository interface {
+	// Get returns the tenant's override, or a zero RateLimit when none is set.
+	Get(ctx context.Context, tenantID string) (RateLimit, error)
+	// Set replaces the tenant's override.
+	Set(ctx context.Context, tenantID string, l RateLimit) error
+	// Delete removes the tenant's override; it is a no-op when none is set.
+	Delete(ctx context.Context, tenantID string) error
+	// All returns every override keyed by tenant ID.
+	All(ctx context.Context) (map[string]RateLimit, error)
+}
+
+// NonceStore remembers the nonces of single-use signed URLs.
+type NonceStore interface {
+	// Use records nonce and reports whether it was unused. The store may
+	// forget the nonce after expiresAt, when its URL is no longer valid.
+	Use(ctx context.Context, nonce string, expiresAt time.Time) (bool, error)
+}
+
+// BillingProvider reads subscriptions from the system that charges customers.
+type BillingProvider interface {
+	// Subscriptions returns every subscription tied to a tenant, whatever
+	// its status.
+	Subscriptions(ctx context.Context) ([]Subscription, error)
+}
+
+// AuditLogger durably records tenant mutations for compliance, independently
+// of traces and logs.
+type AuditLogger interface {
+	Log(ctx context.Context, entry AuditEntry) error
+}
+
+// AuditReader reconstructs tenants from the audit trail.
+type AuditReader interface {
+	// TenantAsOf returns the tenant as recorded by the latest audit entry
+	// at or before at, or ErrTenantNotFound when it had none by then.
+	TenantAsOf(ctx context.Context, tenantID string, at time.Time) (Tenant, error)
+}
+
+// ChangeFeed reads the tenant change feed from the audit trail.
+type ChangeFeed interface {
+	// ChangesAfter returns up to limit changes recorded after cursor,
+	// oldest first.
+	ChangesAfter(ctx context.Context, cursor int64, limit int) ([]TenantChange, error)
+	// Cursors returns the cursor before the oldest change still stored,
+	// the changes after older cursors having been pruned, and the cursor
+	// of the latest change.
+	Cursors(ctx context.Context) (oldest, latest int64, err error)
+}
+
+// StatusHistoryRepository persists the lifecycle transitions of tenants.
+type StatusHistoryRepository interface {
+	Record(ctx context.Context, change StatusChange) error
+	// ListByTenant returns a tenant's changes, oldest first.
+	ListByTenant(ctx context.Context, tenantID string) ([]StatusChange, error)
+	// ListSince returns every tenant's changes at or after since, oldest first.
+	ListSince(ctx context.Context, since time.Time) ([]StatusChange, error)
+}
+
+// OperationFilter narrows an operation listing. Kinds and Statuses match
+// any of the listed values when non-empty.
+type OperationFilter struct {
+	TenantID string
+	Kinds    []OperationKind
+	Statuses []OperationStatus
+	Limit    int
+	Offset   int
+}
+
+// OperationRepository persists long-running operations.
+type OperationRepository interface {
+	Create(ctx context.Context, op Operation) error
+	GetByID(ctx context.Context, id string) (Operation, error)
+	Update(ctx context.Context, op Operation) error
+	// List returns matching operations, newest first.
+	List(ctx context.Context, filter OperationFilter) ([]Operation, error)
+	Count(ctx context.Context, filter OperationFilter) (int, error)
+}
+
+// StepRepository records the provisioning steps operations completed, so
+// that a retried operation skips them.
+type StepRepository interface {
+	// List returns the steps recorded for an operation.
+	List(ctx context.Context, operationID string) ([]StepRecord, error)
+	// Save records a step, replacing an earlier record of it.
+	Save(ctx context.Context, rec StepRecord) error
+}
+
+// OperationQueue schedules the asynchronous work an operation tracks.
+// The work reports its outcome on the operation.
+type OperationQueue interface {
+	Enqueue(ctx context.Context, op Operation) error
+}
+
+// TenantEvent is a domain event as it is published: the event, the tenant
+// after it and the data some events carry besides the tenant.
+type TenantEvent struct {
+	Event  Event
+	Tenant Tenant
+	// Changes are the attributes a change event changed (see ChangeEvents).
+	Changes []FieldChange
+	// Certificate is the certificate a certificate event is about.
+	Certificate *Certificate
+}
+
 // EventPublisher defines the contract for emitting domain events.
 type EventPublisher interface {
-	Publish(ctx context.Context, event Event, tenant Tenant) error
+	Publish(ctx context.Context, e TenantEvent) error
 }
 
 // TransitionValidator checks if a state transition is valid and returns
 // the destination status. Implementations may use an FSM library or
-// any other mechanism to enforce the rules defined in Transitions.
+// any other mechanism to enforce the rules defined in Transitions. They
+// are given the whole tenant, not only its status, so guards can judge
+// more than the status.
 type TransitionValidator interface {
-	Apply(ctx context.Context, current Status, event Event) (Status, error)
+	Apply(ctx context.Context, tenant Tenant, event Event) (Status, error)
+}
+
+// TransitionGuard is a condition an event needs on top of the tenant's
+// status, such as having no active projects before deletion or billing
+// being current before reactivation. Check returns why the tenant does
+// not meet it, or "" when it does; an error means the condition could not
+// be evaluated. A refusal is reported to the caller as a GuardError.
+type TransitionGuard interface {
+	Name() string
+	Check(ctx context.Context, tenant Tenant) (string, error)
+}
+
+// SpecSource provides the desired state of all declaratively managed
+// tenants (e.g., spec files checked out from a Git repository).
+type SpecSource interface {
+	Specs(ctx context.Context) ([]TenantSpec, error)
+}
+
+// CreateHook inspects a tenant before it is persisted and may reject it.
+// Hooks are deployment-specific policy (naming rules, plan restrictions,
+// etc.) plugged in without changing the service; a non-nil error aborts
+// the creation and is reported to the caller as a HookRejectedError.
+type CreateHook interface {
+	Name() string
+	BeforeCreate(ctx context.Context, tenant Tenant) error
+}
+
+// EventTransform rewrites the payload of an outbound event before it is
+// delivered, e.g. to drop or rename fields for a deployment's consumers.
+// The payload is the CloudEvent as JSON; a non-nil error fails the
+// delivery, which is retried, and is reported as an EventTransformError.
+type EventTransform interface {
+	Name() string
+	Transform(ctx context.Context, event Event, payload []byte) ([]byte, error)
+}
+
+// QueueStats describes the backlog of asynchronous jobs waiting for a worker.
+type QueueStats struct {
+	// Available is the number of jobs ready to run but not yet picked up.
+	Available int
+	// OldestAvailableAge is how long the oldest available job has waited.
+	OldestAvailableAge time.Duration
+}
+
+// QueueMonitor reports the job backlog so readiness checks and autoscalers
+// can detect worker saturation.
+type QueueMonitor interface {
+	Stats(ctx context.Context) (QueueStats, error)
+	// Loads returns the load of every queue that has jobs, ordered by name.
+	Loads(ctx context.Context) ([]QueueLoad, error)
+}
+
+// Pruner deletes the expired records of one RecordType.
+type Pruner interface {
+	// Prune deletes the records older than before and returns how many it
+	// deleted. With dryRun it only counts them.
+	Prune(ctx context.Context, before time.Time, dryRun bool) (int, error)
+}
+
+// Archiver moves the old records of one RecordType out of the tables hot
+// queries read into cold archive tables, where retention still reaches them.
+type Archiver interface {
+	// Archive moves the records older than before and returns how many it
+	// moved. With dryRun it only counts them.
+	Archive(ctx context.Context, before time.Time, dryRun bool) (int, error)
+}
+
+// JobPauser stops and restarts the pickup of asynchronous jobs. Jobs
+// already running finish; jobs enqueued while paused wait.
+type JobPauser interface {
+	Pause(ctx context.Context) error
+	Resume(ctx context.Context) error
+}
+
+// ReadOnlyRepository persists the read-only mode, so every instance
+// sharing the database agrees on it.
+type ReadOnlyRepository interface {
+	// Get returns the mode, disabled when it was never set.
+	Get(ctx context.Context) (ReadOnlyState, error)
+	Set(ctx context.Context, s ReadOnlyState) error
 }
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+)
+
+// Priority ranks asynchronous work: the jobs of a higher priority waiting
+// in a queue run before those of a lower one.
+type Priority string
+
+const (
+	PriorityHigh   Priority = "high"
+	PriorityNormal Priority = "normal"
+	PriorityLow    Priority = "low"
+)
+
+// Priorities lists the priorities from the highest to the lowest.
+func Priorities() []Priority {
+	return []Priority{PriorityHigh, PriorityNormal, PriorityLow}
+}
+
+// ParsePriority returns the priority named s.
+func ParsePriority(s string) (Priority, error) {
+	switch p := Priority(s); p {
+	case PriorityHigh, PriorityNormal, PriorityLow:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown priority %q (want high, normal or low)", s)
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose asynchronous work runs at p.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the priority set by WithPriority, or "" when
+// none was set.
+func PriorityFromContext(ctx context.Context) Priority {
+	p, _ := ctx.Value(priorityKey{}).(Priority)
+	return p
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "fmt"
+
+// RateLimit is the request rate an API gateway allows a tenant, as a token
+// bucket refilled at RequestsPerSecond that holds up to Burst requests.
+type RateLimit struct {
+	RequestsPerSecond int
+	Burst             int
+}
+
+// IsZero reports whether no limit is set.
+func (l RateLimit) IsZero() bool {
+	return l == RateLimit{}
+}
+
+// Validate checks the rate and the burst are positive.
+func (l RateLimit) Validate() error {
+	if l.RequestsPerSecond <= 0 {
+		return fmt.Errorf("requests per second must be positive, got %d", l.RequestsPerSecond)
+	}
+	if l.Burst <= 0 {
+		return fmt.Errorf("burst must be positive, got %d", l.Burst)
+	}
+	return nil
+}
+
+// RateLimitSource tells where a tenant's effective rate limit comes from.
+type RateLimitSource string
+
+const (
+	RateLimitFromPlan RateLimitSource = "plan"
+	RateLimitOverride RateLimitSource = "override"
+)
+
+// TenantRateLimit is the rate limit in effect for a tenant.
+type TenantRateLimit struct {
+	TenantID string
+	Slug     string
+	Plan     string
+	Limit    RateLimit
+	Source   RateLimitSource
+}
+
+// EffectiveRateLimit returns the tenant's override when set, or else the
+// limit of its plan. It returns false when neither is set: the tenant is
+// not rate limited.
+func EffectiveRateLimit(t Tenant, plan, override RateLimit) (TenantRateLimit, bool) {
+	limit := TenantRateLimit{TenantID: t.ID, Slug: t.Slug, Plan: t.Plan, Limit: override, Source: RateLimitOverride}
+	if !override.IsZero() {
+		return limit, true
+	}
+	if !plan.IsZero() {
+		limit.Limit, limit.Source = plan, RateLimitFromPlan
+		return limit, true
+	}
+	return TenantRateLimit{}, false
+}
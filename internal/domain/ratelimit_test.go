@@ -0,0 +1,52 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestRateLimit_Validate(t *testing.T) {
+	if err := (domain.RateLimit{RequestsPerSecond: 10, Burst: 20}).Validate(); err != nil {
+		t.Errorf("valid limit: %v", err)
+	}
+	for _, l := range []domain.RateLimit{{}, {RequestsPerSecond: 10}, {RequestsPerSecond: -1, Burst: 1}} {
+		if err := l.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want error", l)
+		}
+	}
+}
+
+func TestEffectiveRateLimit(t *testing.T) {
+	catalog := domain.PlanCatalog{
+		{Plan: "free", RateLimit: domain.RateLimit{RequestsPerSecond: 10, Burst: 10}},
+		{Plan: "enterprise"},
+	}
+	free := domain.NewTenant("ten_1", "Acme", "acme", "free")
+	override := domain.RateLimit{RequestsPerSecond: 50, Burst: 100}
+
+	cases := []struct {
+		name     string
+		tenant   domain.Tenant
+		override domain.RateLimit
+		want     domain.RateLimit
+		source   domain.RateLimitSource
+		limited  bool
+	}{
+		{"plan default", free, domain.RateLimit{}, catalog[0].RateLimit, domain.RateLimitFromPlan, true},
+		{"override wins", free, override, override, domain.RateLimitOverride, true},
+		{"unlimited plan", domain.NewTenant("ten_2", "Big", "big", "enterprise"), domain.RateLimit{}, domain.RateLimit{}, "", false},
+		{"plan not in catalog", domain.NewTenant("ten_3", "Odd", "odd", "legacy"), domain.RateLimit{}, domain.RateLimit{}, "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := domain.EffectiveRateLimit(tc.tenant, catalog.RateLimit(tc.tenant.Plan), tc.override)
+			if ok != tc.limited || got.Limit != tc.want || got.Source != tc.source {
+				t.Errorf("EffectiveRateLimit = %+v, %v; want %+v from %q, %v", got, ok, tc.want, tc.source, tc.limited)
+			}
+			if ok && (got.TenantID != tc.tenant.ID || got.Slug != tc.tenant.Slug) {
+				t.Errorf("limit not tied to the tenant: %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// ReadOnlyState is whether the service rejects changes, as during database
+// migrations and disaster recovery failovers. Reads keep working.
+type ReadOnlyState struct {
+	Enabled bool
+	// Reason tells callers why changes are rejected, e.g. "failover to
+	// eu-west".
+	Reason string
+	// Since is when the mode or its reason last changed; zero if they never
+	// did.
+	Since time.Time
+	// Actor is who made that change.
+	Actor string
+}
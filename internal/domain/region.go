@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+)
+
+// MaxRegionLength is the longest region name accepted.
+const MaxRegionLength = 32
+
+// regionPattern accepts regions such as "eu", "us-east" or "ap-southeast-2".
+var regionPattern = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// ValidateRegion checks region is lowercase letters and digits separated by
+// single hyphens, starting with a letter.
+func ValidateRegion(region string) error {
+	if len(region) > MaxRegionLength || !regionPattern.MatchString(region) {
+		return &InvalidRegionError{Region: region}
+	}
+	return nil
+}
+
+// NormalizeRegions validates regions and returns them sorted and without
+// duplicates, or nil when there are none.
+func NormalizeRegions(regions []string) ([]string, error) {
+	if len(regions) == 0 {
+		return nil, nil
+	}
+	for _, r := range regions {
+		if err := ValidateRegion(r); err != nil {
+			return nil, err
+		}
+	}
+	out := slices.Clone(regions)
+	slices.Sort(out)
+	return slices.Compact(out), nil
+}
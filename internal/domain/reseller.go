@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// Reseller is a partner organization that manages its own child tenants
+// through the delegated admin API.
+type Reseller struct {
+	ID   string
+	Name string
+	// TenantQuota is how many tenants (not yet deleted) the reseller may
+	// manage at once. Zero means no tenant may be created.
+	TenantQuota int
+	CreatedAt   time.Time
+}
+
+// NewReseller creates a reseller with the given tenant quota.
+func NewReseller(id, name string, quota int) Reseller {
+	return Reseller{
+		ID:          id,
+		Name:        name,
+		TenantQuota: quota,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+// ResellerUsage summarizes a reseller's tenants against its quota.
+type ResellerUsage struct {
+	Quota int
+	// Tenants counts the tenants that use quota, i.e. all but deleted ones.
+	Tenants  int
+	ByStatus map[Status]int
+}
+
+// QuotaStatuses are the statuses of tenants that count against a quota.
+func QuotaStatuses() []Status {
+	return []Status{StatusCreating, StatusActive, StatusSuspended, StatusDeleting}
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// RecordType names a kind of record that grows with every change and is
+// subject to a retention policy.
+type RecordType string
+
+const (
+	RecordAudit             RecordType = "audit"
+	RecordStatusHistory     RecordType = "status_history"
+	RecordOperations        RecordType = "operations"
+	RecordEvents            RecordType = "events"
+	RecordWebhookDeliveries RecordType = "webhook_deliveries"
+)
+
+// RecordTypes lists every record type in the order retention reports them.
+func RecordTypes() []RecordType {
+	return []RecordType{RecordAudit, RecordStatusHistory, RecordOperations, RecordEvents, RecordWebhookDeliveries}
+}
+
+// RetentionPolicy is how long each record type is kept. Record types
+// without an entry are kept forever.
+type RetentionPolicy map[RecordType]time.Duration
+
+// Validate checks that the policy only names known record types with a
+// positive retention.
+func (p RetentionPolicy) Validate() error {
+	for record, d := range p {
+		known := false
+		for _, t := range RecordTypes() {
+			known = known || t == record
+		}
+		if !known {
+			return fmt.Errorf("unknown record type %q", record)
+		}
+		if d <= 0 {
+			return fmt.Errorf("retention of %q must be positive, got %s", record, d)
+		}
+	}
+	return nil
+}
+
+// Cutoff returns the instant before which records of the given type expire
+// at now, and false when the type is kept forever.
+func (p RetentionPolicy) Cutoff(record RecordType, now time.Time) (time.Time, bool) {
+	d, ok := p[record]
+	if !ok {
+		return time.Time{}, false
+	}
+	return now.Add(-d), true
+}
@@ -0,0 +1,38 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestRetentionPolicy_Validate(t *testing.T) {
+	valid := domain.RetentionPolicy{domain.RecordAudit: 24 * time.Hour, domain.RecordWebhookDeliveries: time.Hour}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate = %v, want nil", err)
+	}
+
+	invalid := map[string]domain.RetentionPolicy{
+		"unknown record": {"tenants": time.Hour},
+		"zero":           {domain.RecordEvents: 0},
+		"negative":       {domain.RecordEvents: -time.Hour},
+	}
+	for name, p := range invalid {
+		if err := p.Validate(); err == nil {
+			t.Errorf("%s: Validate = nil, want error", name)
+		}
+	}
+}
+
+func TestRetentionPolicy_Cutoff(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	p := domain.RetentionPolicy{domain.RecordEvents: 48 * time.Hour}
+
+	if before, ok := p.Cutoff(domain.RecordEvents, now); !ok || !before.Equal(now.Add(-48*time.Hour)) {
+		t.Errorf("Cutoff(events) = %v, %v", before, ok)
+	}
+	if _, ok := p.Cutoff(domain.RecordAudit, now); ok {
+		t.Error("audit has no retention and should be kept forever")
+	}
+}
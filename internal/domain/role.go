@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Role is what a caller of the management API may do. Each role includes
+// the ones below it.
+type Role string
+
+const (
+	// RoleViewer reads tenants and their configuration.
+	RoleViewer Role = "viewer"
+	// RoleOperator also creates tenants, changes them and drives their
+	// lifecycle, short of deleting them.
+	RoleOperator Role = "operator"
+	// RoleAdmin may do anything: delete tenants and other resources,
+	// force changes past the guardrails and manage admin keys.
+	RoleAdmin Role = "admin"
+)
+
+// Roles lists the roles from the least to the most privileged.
+var Roles = []Role{RoleViewer, RoleOperator, RoleAdmin}
+
+// ParseRole returns the role named s.
+func ParseRole(s string) (Role, error) {
+	if r := Role(s); slices.Contains(Roles, r) {
+		return r, nil
+	}
+	return "", fmt.Errorf("unknown role %q, want viewer, operator or admin", s)
+}
+
+// Includes reports whether r may do what required may.
+func (r Role) Includes(required Role) bool {
+	return slices.Index(Roles, r) >= slices.Index(Roles, required)
+}
+
+// HighestRole returns the most privileged of the roles named in names,
+// ignoring the other names, and false when there is none.
+func HighestRole(names []string) (Role, bool) {
+	best := -1
+	for _, n := range names {
+		best = max(best, slices.Index(Roles, Role(n)))
+	}
+	if best < 0 {
+		return "", false
+	}
+	return Roles[best], true
+}
+
+type roleKey struct{}
+
+// WithRole returns a context whose changes are made by a caller with role.
+func WithRole(ctx context.Context, r Role) context.Context {
+	return context.WithValue(ctx, roleKey{}, r)
+}
+
+// RoleFromContext returns the role set by WithRole, and whether there is
+// one: background jobs, and requests when authentication is disabled,
+// carry none and are trusted.
+func RoleFromContext(ctx context.Context) (Role, bool) {
+	r, ok := ctx.Value(roleKey{}).(Role)
+	return r, ok
+}
+
+// RequireRole returns a ForbiddenError when the caller of ctx has a role
+// below required, for action. Callers without a role pass.
+func RequireRole(ctx context.Context, required Role, action string) error {
+	r, ok := RoleFromContext(ctx)
+	if !ok || r.Includes(required) {
+		return nil
+	}
+	return &ForbiddenError{Role: r, Required: required, Action: action}
+}
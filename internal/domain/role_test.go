@@ -0,0 +1,77 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestRole_Includes(t *testing.T) {
+	tests := []struct {
+		role, required domain.Role
+		want           bool
+	}{
+		{domain.RoleViewer, domain.RoleViewer, true},
+		{domain.RoleViewer, domain.RoleOperator, false},
+		{domain.RoleOperator, domain.RoleViewer, true},
+		{domain.RoleOperator, domain.RoleAdmin, false},
+		{domain.RoleAdmin, domain.RoleOperator, true},
+		{domain.Role(""), domain.RoleViewer, false},
+	}
+	for _, tc := range tests {
+		if got := tc.role.Includes(tc.required); got != tc.want {
+			t.Errorf("%q.Includes(%q) = %v, want %v", tc.role, tc.required, got, tc.want)
+		}
+	}
+}
+
+func TestHighestRole(t *testing.T) {
+	if r, ok := domain.HighestRole([]string{"billing", "viewer", "operator"}); !ok || r != domain.RoleOperator {
+		t.Errorf("HighestRole = %q, %v; want operator", r, ok)
+	}
+	if _, ok := domain.HighestRole([]string{"billing"}); ok {
+		t.Error("no known role must report false")
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	if err := domain.RequireRole(context.Background(), domain.RoleAdmin, "deleting"); err != nil {
+		t.Errorf("a context without a role is trusted, got %v", err)
+	}
+
+	ctx := domain.WithRole(context.Background(), domain.RoleViewer)
+	err := domain.RequireRole(ctx, domain.RoleOperator, "creating a tenant")
+	var forbidden *domain.ForbiddenError
+	if !errors.As(err, &forbidden) || forbidden.Role != domain.RoleViewer || forbidden.Required != domain.RoleOperator {
+		t.Fatalf("expected ForbiddenError, got %v", err)
+	}
+	if want := "creating a tenant requires the operator role; the caller is viewer"; err.Error() != want {
+		t.Errorf("message = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestClaims_Strings(t *testing.T) {
+	c := domain.Claims{Values: map[string]any{
+		"role":         "admin",
+		"groups":       []any{"ops", 7, "viewer"},
+		"realm_access": map[string]any{"roles": []any{"operator"}},
+	}}
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"role", []string{"admin"}},
+		{"groups", []string{"ops", "viewer"}},
+		{"realm_access.roles", []string{"operator"}},
+		{"realm_access.missing", nil},
+		{"role.nested", nil},
+	}
+	for _, tc := range tests {
+		if got := c.Strings(tc.path); !slices.Equal(got, tc.want) {
+			t.Errorf("Strings(%q) = %v, want %v", tc.path, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+// QueueLoad is the work pending and recently done on one job queue.
+type QueueLoad struct {
+	Queue     string
+	Available int
+	Running   int
+	// CompletedPerMinute is the recent processing rate of the queue.
+	CompletedPerMinute float64
+	// Priorities splits Available and Running by priority, from the
+	// highest; priorities without such jobs are left out.
+	Priorities []PriorityLoad
+}
+
+// PriorityLoad is the part of a queue's load at one priority.
+type PriorityLoad struct {
+	Priority  Priority
+	Available int
+	Running   int
+}
+
+// Backlog is the number of jobs waiting for or holding a worker.
+func (l QueueLoad) Backlog() int {
+	return l.Available + l.Running
+}
+
+// ScalingPolicy turns queue load into a suggested number of workers for an
+// external autoscaler (e.g., KEDA scaling the worker deployment).
+type ScalingPolicy struct {
+	// JobsPerWorker is the backlog one worker is expected to absorb.
+	JobsPerWorker int
+	// MinWorkers and MaxWorkers clamp the suggestion. Zero MaxWorkers means
+	// no upper bound.
+	MinWorkers int
+	MaxWorkers int
+}
+
+// SuggestWorkers returns how many workers are needed to keep the total
+// backlog of all queues within JobsPerWorker per worker.
+func (p ScalingPolicy) SuggestWorkers(loads []QueueLoad) int {
+	backlog := 0
+	for _, l := range loads {
+		backlog += l.Backlog()
+	}
+
+	perWorker := p.JobsPerWorker
+	if perWorker < 1 {
+		perWorker = 1
+	}
+	n := (backlog + perWorker - 1) / perWorker
+
+	if n < p.MinWorkers {
+		n = p.MinWorkers
+	}
+	if p.MaxWorkers > 0 && n > p.MaxWorkers {
+		n = p.MaxWorkers
+	}
+	return n
+}
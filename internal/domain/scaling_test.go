@@ -0,0 +1,42 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestScalingPolicy_SuggestWorkers(t *testing.T) {
+	policy := domain.ScalingPolicy{JobsPerWorker: 10, MinWorkers: 1, MaxWorkers: 5}
+
+	tests := []struct {
+		name  string
+		loads []domain.QueueLoad
+		want  int
+	}{
+		{"idle keeps the minimum", nil, 1},
+		{"rounds up", []domain.QueueLoad{{Queue: "default", Available: 11}}, 2},
+		{"sums queues and running jobs", []domain.QueueLoad{
+			{Queue: "default", Available: 15, Running: 5},
+			{Queue: "sync", Available: 10},
+		}, 3},
+		{"clamped to the maximum", []domain.QueueLoad{{Queue: "default", Available: 1000}}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.SuggestWorkers(tt.loads); got != tt.want {
+				t.Errorf("SuggestWorkers() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScalingPolicy_SuggestWorkers_Unbounded(t *testing.T) {
+	policy := domain.ScalingPolicy{}
+
+	got := policy.SuggestWorkers([]domain.QueueLoad{{Available: 7}})
+	if got != 7 {
+		t.Errorf("SuggestWorkers() = %d, want 7 (one job per worker, no bounds)", got)
+	}
+}
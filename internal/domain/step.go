@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// StepState is what a provisioning step works from: the tenant, and the
+// references returned by the steps before it in the operation, keyed by
+// step name.
+type StepState struct {
+	Tenant Tenant
+	Refs   map[string]string
+}
+
+// ProvisioningStep is one change an operation makes outside tenantiq,
+// such as opening a pull request, creating a DNS record or a bucket. Jobs
+// are retried, possibly after the change was made but before it was
+// recorded, so steps are written to be idempotent by construction: the
+// runner (see app.StepRunner) skips the steps recorded with the same
+// fingerprint, and looks with Find before it calls Apply.
+type ProvisioningStep interface {
+	// Name identifies the step within its operation. Steps are recorded
+	// under it, so it must not change between releases.
+	Name() string
+	// Fingerprint summarizes the inputs of the step (see Fingerprint). A
+	// step recorded with another fingerprint, such as for a tenant renamed
+	// since, runs again.
+	Fingerprint(state StepState) string
+	// Find looks for what an earlier attempt may have done, and returns
+	// its reference and true when it is there. It must only recognize what
+	// the step itself would have done, not something else by the same
+	// name.
+	Find(ctx context.Context, state StepState) (string, bool, error)
+	// Apply makes the change and returns its reference, such as a pull
+	// request URL or a record ID; "" when there is none.
+	Apply(ctx context.Context, state StepState) (string, error)
+}
+
+// StepRecord is the outcome of a step of an operation, recorded once the
+// step completed.
+type StepRecord struct {
+	OperationID string
+	Step        string
+	Fingerprint string
+	Ref         string
+	CompletedAt time.Time
+}
+
+// Fingerprint returns a digest of the inputs of a step, for
+// ProvisioningStep.Fingerprint.
+func Fingerprint(inputs ...string) string {
+	h := sha256.New()
+	for _, in := range inputs {
+		// The separator keeps ("ab", "c") apart from ("a", "bc").
+		h.Write([]byte(in))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
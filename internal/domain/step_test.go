@@ -0,0 +1,16 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestFingerprint(t *testing.T) {
+	if domain.Fingerprint("acme", "eu") != domain.Fingerprint("acme", "eu") {
+		t.Error("the same inputs must give the same fingerprint")
+	}
+	if domain.Fingerprint("ab", "c") == domain.Fingerprint("a", "bc") {
+		t.Error("inputs must not run into each other")
+	}
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Limits of a tenant's tags.
+const (
+	MaxTags      = 20
+	MaxTagLength = 64
+)
+
+// InvalidTagError is returned when a tag is malformed or a tenant would
+// have too many.
+type InvalidTagError struct {
+	// Tag is the offending tag, or empty when the tags as a whole are
+	// invalid.
+	Tag    string
+	Reason string
+}
+
+func (e *InvalidTagError) Error() string {
+	if e.Tag == "" {
+		return "invalid tags: " + e.Reason
+	}
+	return fmt.Sprintf("invalid tag %q: %s", e.Tag, e.Reason)
+}
+
+// ValidateTag checks a tag is well formed: lowercase letters, digits, '_',
+// '-', '.' and ':', starting with a letter or digit, e.g. "tier:gold" or
+// "cohort-2026-q1".
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return &InvalidTagError{Tag: tag, Reason: "empty tag"}
+	}
+	if len(tag) > MaxTagLength {
+		return &InvalidTagError{Tag: tag, Reason: fmt.Sprintf("longer than %d bytes", MaxTagLength)}
+	}
+	for i, r := range tag {
+		alnum := r >= 'a' && r <= 'z' || r >= '0' && r <= '9'
+		if alnum || i > 0 && strings.ContainsRune("_-.:", r) {
+			continue
+		}
+		return &InvalidTagError{Tag: tag, Reason: fmt.Sprintf("unexpected %q (lowercase letters, digits, '_', '-', '.' and ':' only, starting with a letter or digit)", r)}
+	}
+	return nil
+}
+
+// ValidateTags checks every tag and that there are at most MaxTags.
+func ValidateTags(tags []string) error {
+	if len(tags) > MaxTags {
+		return &InvalidTagError{Reason: fmt.Sprintf("%d tags, at most %d allowed", len(tags), MaxTags)}
+	}
+	for _, tag := range tags {
+		if err := ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyTags returns tags with add added and remove removed, sorted and
+// without duplicates.
+func applyTags(tags, add, remove []string) []string {
+	result := make([]string, 0, len(tags)+len(add))
+	for _, tag := range slices.Concat(tags, add) {
+		if !slices.Contains(remove, tag) {
+			result = append(result, tag)
+		}
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
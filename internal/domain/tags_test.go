@@ -0,0 +1,58 @@
+package domain_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestValidateTags(t *testing.T) {
+	var tooMany []string
+	for i := range domain.MaxTags + 1 {
+		tooMany = append(tooMany, fmt.Sprintf("t%d", i))
+	}
+
+	cases := []struct {
+		name    string
+		tags    []string
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"valid", []string{"beta", "tier:gold", "cohort-2026.q1", "9_lives"}, false},
+		{"empty", []string{""}, true},
+		{"uppercase", []string{"Beta"}, true},
+		{"space", []string{"tier gold"}, true},
+		{"leading colon", []string{":gold"}, true},
+		{"comma", []string{"a,b"}, true},
+		{"long", []string{strings.Repeat("t", domain.MaxTagLength+1)}, true},
+		{"too many", tooMany, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := domain.ValidateTags(tc.tags)
+			var tagErr *domain.InvalidTagError
+			if tc.wantErr != errors.As(err, &tagErr) {
+				t.Errorf("ValidateTags = %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTenantPatch_Tags(t *testing.T) {
+	tenant := domain.Tenant{Tags: []string{"beta", "tier:silver"}}
+
+	got := domain.TenantPatch{AddTags: []string{"tier:gold", "beta", "tier:gold"}, RemoveTags: []string{"tier:silver", "absent"}}.Apply(tenant)
+	if fmt.Sprint(got.Tags) != "[beta tier:gold]" {
+		t.Errorf("Tags = %v, want [beta tier:gold]", got.Tags)
+	}
+	if fmt.Sprint(tenant.Tags) != "[beta tier:silver]" {
+		t.Errorf("original Tags = %v, want them untouched", tenant.Tags)
+	}
+
+	if got := (domain.TenantPatch{}).Apply(tenant); fmt.Sprint(got.Tags) != "[beta tier:silver]" {
+		t.Errorf("empty patch changed Tags to %v", got.Tags)
+	}
+}
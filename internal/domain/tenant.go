@@ -1,6 +1,12 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+	"unicode"
+)
 
 // Status represents the lifecycle state of a tenant.
 type Status string
@@ -24,6 +30,37 @@ const (
 	EventDeletionComplete  Event = "deletion_complete"
 )
 
+// EventPlanSuggested is published when the plan suggestion job recommends
+// a different plan for a tenant. It notifies the sales pipeline and does
+// not change the tenant's status, so it is not part of Transitions.
+const EventPlanSuggested Event = "plan_suggested"
+
+// Dunning notifications are published as a tenant's unpaid invoice moves
+// through the dunning flow; the suspension that ends it is a regular
+// EventSuspend.
+const (
+	EventDunningWarning     Event = "dunning_warning"
+	EventDunningFinalNotice Event = "dunning_final_notice"
+)
+
+// EventPurged is published when the purge job permanently removes a tenant
+// that has been deleted for longer than the purge window. The tenant in
+// the event is its last stored state.
+const EventPurged Event = "purged"
+
+// EventTrialExpired is published when the trial job ends a tenant's expired
+// trial. The tenant in the event is its state afterwards: suspended, or on
+// the plan it was downgraded to.
+const EventTrialExpired Event = "trial_expired"
+
+// Certificate notifications are published when the renewal job obtains the
+// certificate of a tenant's domain, or fails to. The certificate is set on
+// the event (see TenantEvent).
+const (
+	EventCertificateIssued Event = "certificate_issued"
+	EventCertificateFailed Event = "certificate_failed"
+)
+
 // Transition defines a valid state change: an event moves a tenant from Src to Dst.
 type Transition struct {
 	Event Event
@@ -32,7 +69,9 @@ type Transition struct {
 }
 
 // Transitions defines all valid state changes in the tenant lifecycle.
-// This is domain knowledge consumed by the FSM adapter.
+// This is domain knowledge consumed by the FSM adapter. It may be replaced
+// at startup, before any use, by transitions that pass
+// ValidateTransitions, e.g. to add custom statuses.
 var Transitions = []Transition{
 	{Event: EventProvisionComplete, Src: StatusCreating, Dst: StatusActive},
 	{Event: EventSuspend, Src: StatusActive, Dst: StatusSuspended},
@@ -42,17 +81,311 @@ var Transitions = []Transition{
 	{Event: EventDeletionComplete, Src: StatusDeleting, Dst: StatusDeleted},
 }
 
+// Events returns every event that appears in Transitions, in first-seen order.
+func Events() []Event {
+	seen := make(map[Event]bool, len(Transitions))
+	var events []Event
+	for _, t := range Transitions {
+		if !seen[t.Event] {
+			seen[t.Event] = true
+			events = append(events, t.Event)
+		}
+	}
+	return events
+}
+
+// Statuses returns every status that appears in Transitions, in first-seen
+// order.
+func Statuses() []Status {
+	seen := make(map[Status]bool, len(Transitions))
+	var statuses []Status
+	for _, t := range Transitions {
+		for _, s := range []Status{t.Src, t.Dst} {
+			if !seen[s] {
+				seen[s] = true
+				statuses = append(statuses, s)
+			}
+		}
+	}
+	return statuses
+}
+
+// PublishedEvents returns every event the service publishes: the lifecycle
+// events of Transitions followed by the notifications and change events
+// outside of them.
+func PublishedEvents() []Event {
+	return append(Events(), EventPlanSuggested, EventDunningWarning, EventDunningFinalNotice, EventPurged, EventTrialExpired,
+		EventCertificateIssued, EventCertificateFailed, EventRenamed, EventPlanChanged, EventMetadataUpdated)
+}
+
+// PathTo returns the shortest sequence of events that moves a tenant from
+// one status to another according to Transitions. It returns an empty path
+// when from equals to, and false when the target is unreachable.
+func PathTo(from, to Status) ([]Event, bool) {
+	if from == to {
+		return nil, true
+	}
+
+	type step struct {
+		status Status
+		path   []Event
+	}
+	visited := map[Status]bool{from: true}
+	queue := []step{{status: from}}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for _, t := range Transitions {
+			if t.Src != cur.status || visited[t.Dst] {
+				continue
+			}
+			path := append(append([]Event{}, cur.path...), t.Event)
+			if t.Dst == to {
+				return path, true
+			}
+			visited[t.Dst] = true
+			queue = append(queue, step{status: t.Dst, path: path})
+		}
+	}
+	return nil, false
+}
+
+// ValidateTransitions checks transitions meant to replace Transitions.
+// They must keep the statuses and events the service relies on, such as
+// StatusCreating for new tenants and EventDelete, move a tenant to a
+// single status for each event and status, and reach every status from
+// StatusCreating.
+func ValidateTransitions(ts []Transition) error {
+	dst := make(map[Transition]Status, len(ts))
+	statuses := make(map[Status]bool)
+	events := make(map[Event]bool)
+	for _, t := range ts {
+		if t.Event == "" || t.Src == "" || t.Dst == "" {
+			return fmt.Errorf("transition %q from %q to %q is incomplete", t.Event, t.Src, t.Dst)
+		}
+		key := Transition{Event: t.Event, Src: t.Src}
+		if d, ok := dst[key]; ok && d != t.Dst {
+			return fmt.Errorf("event %q moves %q tenants to both %q and %q", t.Event, t.Src, d, t.Dst)
+		}
+		dst[key] = t.Dst
+		statuses[t.Src], statuses[t.Dst], events[t.Event] = true, true, true
+	}
+
+	for _, s := range []Status{StatusCreating, StatusActive, StatusSuspended, StatusDeleting, StatusDeleted} {
+		if !statuses[s] {
+			return fmt.Errorf("status %q is missing: the service relies on it", s)
+		}
+	}
+	for _, e := range []Event{EventProvisionComplete, EventSuspend, EventReactivate, EventDelete, EventDeletionComplete} {
+		if !events[e] {
+			return fmt.Errorf("event %q is missing: the service relies on it", e)
+		}
+	}
+
+	reached := map[Status]bool{StatusCreating: true}
+	for queue := []Status{StatusCreating}; len(queue) > 0; queue = queue[1:] {
+		for _, t := range ts {
+			if t.Src == queue[0] && !reached[t.Dst] {
+				reached[t.Dst] = true
+				queue = append(queue, t.Dst)
+			}
+		}
+	}
+	for _, t := range ts {
+		if !reached[t.Src] {
+			return fmt.Errorf("status %q cannot be reached from %q", t.Src, StatusCreating)
+		}
+	}
+	return nil
+}
+
+// TenantSpec is the desired state of a tenant, used for declarative
+// (GitOps-style) management. Empty fields are left untouched when the
+// spec is applied to an existing tenant.
+type TenantSpec struct {
+	Slug   string
+	Name   string
+	Plan   string
+	Status Status
+	// Metadata sets metadata keys of the tenant; an empty value removes
+	// the key and keys it does not list are left untouched.
+	Metadata map[string]string
+	// Features switches feature flags on or off, by name. They are kept
+	// in the metadata under FeatureMetadataPrefix, like a blueprint's.
+	Features map[string]bool
+	// Domains, when not nil, are all the custom domains of the tenant:
+	// missing ones get a certificate requested and the others are removed.
+	Domains []string
+}
+
+// MetadataPatch returns the metadata the spec sets, its feature flags
+// included, or nil when it sets none.
+func (s TenantSpec) MetadataPatch() map[string]string {
+	if len(s.Metadata) == 0 && len(s.Features) == 0 {
+		return nil
+	}
+	patch := make(map[string]string, len(s.Metadata)+len(s.Features))
+	for k, v := range s.Metadata {
+		patch[k] = v
+	}
+	for name, on := range s.Features {
+		patch[FeatureMetadataPrefix+name] = strconv.FormatBool(on)
+	}
+	return patch
+}
+
 // Tenant is the core domain entity representing an organization using the platform.
 type Tenant struct {
-	ID        string
-	Name      string
-	Slug      string
-	Status    Status
-	Plan      string
+	ID     string
+	Name   string
+	Slug   string
+	Status Status
+	Plan   string
+
+	// PRURL and GitBranch point at the provisioning change for this tenant.
+	PRURL     string
+	GitBranch string
+	// ExternalRefs links the tenant to other systems (e.g., "argocd_app",
+	// "billing_customer"), keyed by system name.
+	ExternalRefs map[string]string
+	// Metadata holds the integrator's own data about the tenant (CRM IDs,
+	// internal references, ...), which tenantiq stores without using it.
+	Metadata map[string]string
+	// Tags group tenants for operators (campaign, cohort, support tier,
+	// ...), sorted and without duplicates.
+	Tags []string
+	// ResellerID is the reseller that manages the tenant through the
+	// delegated admin API, or empty for directly managed tenants.
+	ResellerID string
+	// SuggestedPlan is the plan that best fits the tenant's reported usage,
+	// set by the plan suggestion job when it differs from Plan.
+	SuggestedPlan string
+	// TrialEndsAt is when the tenant's free trial expires, or zero when the
+	// tenant is not on trial. The trial job suspends or downgrades tenants
+	// past it.
+	TrialEndsAt time.Time
+	// Region is where the tenant is hosted, such as "eu", or empty when
+	// unspecified. It is set at creation and never changes; plans limited
+	// to some regions are only available to tenants in them.
+	Region string
+	// Simulated tenants run the whole lifecycle against fake provisioning
+	// (no Git, DNS or Kubernetes), for testing flows end to end. It is set
+	// at creation and never changes.
+	Simulated bool
+	// Version counts the stored changes of the tenant, starting at 1. An
+	// update applies only to the version it was read at, so concurrent
+	// changes cannot overwrite each other.
+	Version int
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// TenantPatch describes a partial update of a tenant's mutable attributes.
+// Nil fields are left untouched. In ExternalRefs and Metadata, an empty
+// value removes the key; a zero TrialEndsAt takes the tenant off trial.
+// AddTags and RemoveTags add and remove tags, ignoring those already
+// present or absent.
+type TenantPatch struct {
+	Plan         *string
+	PRURL        *string
+	GitBranch    *string
+	ExternalRefs map[string]string
+	Metadata     map[string]string
+	AddTags      []string
+	RemoveTags   []string
+	TrialEndsAt  *time.Time
+}
+
+// Apply returns a copy of t with the patch applied.
+func (p TenantPatch) Apply(t Tenant) Tenant {
+	if p.Plan != nil {
+		t.Plan = *p.Plan
+	}
+	if p.PRURL != nil {
+		t.PRURL = *p.PRURL
+	}
+	if p.GitBranch != nil {
+		t.GitBranch = *p.GitBranch
+	}
+	if len(p.ExternalRefs) > 0 {
+		t.ExternalRefs = mergeValues(t.ExternalRefs, p.ExternalRefs)
+	}
+	if len(p.Metadata) > 0 {
+		t.Metadata = mergeValues(t.Metadata, p.Metadata)
+	}
+	if len(p.AddTags) > 0 || len(p.RemoveTags) > 0 {
+		t.Tags = applyTags(t.Tags, p.AddTags, p.RemoveTags)
+	}
+	if p.TrialEndsAt != nil {
+		t.TrialEndsAt = p.TrialEndsAt.UTC()
+	}
+	return t
+}
+
+// mergeValues returns a copy of values with patch merged in, where an
+// empty value removes the key.
+func mergeValues(values, patch map[string]string) map[string]string {
+	merged := make(map[string]string, len(values)+len(patch))
+	for k, v := range values {
+		merged[k] = v
+	}
+	for k, v := range patch {
+		if v == "" {
+			delete(merged, k)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// TrialExpired reports whether t is on a trial that has ended at now.
+func (t Tenant) TrialExpired(now time.Time) bool {
+	return !t.TrialEndsAt.IsZero() && !now.Before(t.TrialEndsAt)
+}
+
+// slugPattern matches lowercase alphanumeric words separated by single hyphens.
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// MaxSlugLength is the longest slug a tenant may have.
+const MaxSlugLength = 100
+
+// ValidateSlug reports whether slug is a well-formed tenant slug. The
+// returned *InvalidSlugError explains what is wrong with it.
+func ValidateSlug(slug string) error {
+	if len(slug) <= MaxSlugLength && slugPattern.MatchString(slug) {
+		return nil
+	}
+	return &InvalidSlugError{Slug: slug, Reason: slugProblem(slug)}
+}
+
+// slugProblem describes why slug does not match slugPattern.
+func slugProblem(slug string) string {
+	switch {
+	case slug == "":
+		return "it is empty"
+	case len(slug) > MaxSlugLength:
+		return fmt.Sprintf("it is longer than %d bytes", MaxSlugLength)
+	}
+	for _, r := range slug {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+		case r >= 'A' && r <= 'Z':
+			return "uppercase letters are not allowed"
+		case unicode.IsSpace(r):
+			return "spaces are not allowed"
+		case r > unicode.MaxASCII:
+			return fmt.Sprintf("non-ASCII character %q is not allowed", r)
+		default:
+			return fmt.Sprintf("character %q is not allowed", r)
+		}
+	}
+	return "hyphens may only separate words (no leading, trailing or repeated hyphens)"
+}
+
 // NewTenant creates a tenant in the initial "creating" state.
 func NewTenant(id, name, slug, plan string) Tenant {
 	now := time.Now().UTC()
@@ -62,7 +395,15 @@ func NewTenant(id, name, slug, plan string) Tenant {
 		Slug:      slug,
 		Status:    StatusCreating,
 		Plan:      plan,
+		Version:   1,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 }
+
+// TenantCount is the number of tenants in one status on one plan.
+type TenantCount struct {
+	Status Status
+	Plan   string
+	Count  int
+}
@@ -1,6 +1,9 @@
 package domain_test
 
 import (
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -110,3 +113,270 @@ func TestTransitions_InvalidPaths(t *testing.T) {
 		}
 	}
 }
+
+func TestEvents(t *testing.T) {
+	want := []domain.Event{
+		domain.EventProvisionComplete,
+		domain.EventSuspend,
+		domain.EventReactivate,
+		domain.EventDelete,
+		domain.EventDeletionComplete,
+	}
+	if got := domain.Events(); !slices.Equal(got, want) {
+		t.Errorf("Events() = %v, want %v", got, want)
+	}
+}
+
+func TestStatuses(t *testing.T) {
+	want := []domain.Status{
+		domain.StatusCreating,
+		domain.StatusActive,
+		domain.StatusSuspended,
+		domain.StatusDeleting,
+		domain.StatusDeleted,
+	}
+	if got := domain.Statuses(); !slices.Equal(got, want) {
+		t.Errorf("Statuses() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateTransitions(t *testing.T) {
+	if err := domain.ValidateTransitions(domain.Transitions); err != nil {
+		t.Fatalf("built-in transitions rejected: %v", err)
+	}
+
+	grace := domain.Status("grace_period")
+	custom := append(slices.Clone(domain.Transitions),
+		domain.Transition{Event: "payment_overdue", Src: domain.StatusActive, Dst: grace},
+		domain.Transition{Event: "payment_received", Src: grace, Dst: domain.StatusActive},
+		domain.Transition{Event: domain.EventSuspend, Src: grace, Dst: domain.StatusSuspended},
+	)
+	if err := domain.ValidateTransitions(custom); err != nil {
+		t.Errorf("custom status rejected: %v", err)
+	}
+
+	tests := map[string]struct {
+		transitions []domain.Transition
+		want        string
+	}{
+		"missing built-in event": {
+			transitions: slices.DeleteFunc(slices.Clone(domain.Transitions), func(t domain.Transition) bool {
+				return t.Event == domain.EventReactivate
+			}),
+			want: `event "reactivate" is missing`,
+		},
+		"missing built-in status": {
+			transitions: slices.DeleteFunc(slices.Clone(domain.Transitions), func(t domain.Transition) bool {
+				return t.Dst == domain.StatusDeleted
+			}),
+			want: `status "deleted" is missing`,
+		},
+		"ambiguous": {
+			transitions: append(slices.Clone(domain.Transitions),
+				domain.Transition{Event: domain.EventSuspend, Src: domain.StatusActive, Dst: grace}),
+			want: `moves "active" tenants to both`,
+		},
+		"unreachable": {
+			transitions: append(slices.Clone(domain.Transitions),
+				domain.Transition{Event: domain.EventSuspend, Src: grace, Dst: domain.StatusSuspended}),
+			want: `"grace_period" cannot be reached`,
+		},
+		"incomplete": {
+			transitions: append(slices.Clone(domain.Transitions),
+				domain.Transition{Event: domain.EventSuspend, Src: domain.StatusCreating}),
+			want: "incomplete",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := domain.ValidateTransitions(tt.transitions)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathTo(t *testing.T) {
+	cases := []struct {
+		from, to domain.Status
+		want     []domain.Event
+		ok       bool
+	}{
+		{domain.StatusActive, domain.StatusActive, nil, true},
+		{domain.StatusCreating, domain.StatusActive, []domain.Event{domain.EventProvisionComplete}, true},
+		{domain.StatusCreating, domain.StatusDeleted, []domain.Event{domain.EventProvisionComplete, domain.EventDelete, domain.EventDeletionComplete}, true},
+		{domain.StatusSuspended, domain.StatusDeleting, []domain.Event{domain.EventDelete}, true},
+		{domain.StatusDeleted, domain.StatusActive, nil, false},
+	}
+
+	for _, tc := range cases {
+		got, ok := domain.PathTo(tc.from, tc.to)
+		if ok != tc.ok {
+			t.Errorf("PathTo(%q, %q) ok = %v, want %v", tc.from, tc.to, ok, tc.ok)
+			continue
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("PathTo(%q, %q) = %v, want %v", tc.from, tc.to, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("PathTo(%q, %q) = %v, want %v", tc.from, tc.to, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestTenantPatch_Apply(t *testing.T) {
+	tenant := domain.NewTenant("id-1", "Acme", "acme", "pro")
+	tenant.ExternalRefs = map[string]string{"argocd_app": "acme", "billing_customer": "cus_1"}
+
+	pr := "https://github.com/org/infra/pull/42"
+	patched := domain.TenantPatch{
+		PRURL:        &pr,
+		ExternalRefs: map[string]string{"billing_customer": "", "crm": "42"},
+	}.Apply(tenant)
+
+	if patched.PRURL != pr {
+		t.Errorf("PRURL = %q, want %q", patched.PRURL, pr)
+	}
+	if patched.GitBranch != "" {
+		t.Errorf("GitBranch = %q, want untouched", patched.GitBranch)
+	}
+	want := map[string]string{"argocd_app": "acme", "crm": "42"}
+	if len(patched.ExternalRefs) != len(want) {
+		t.Fatalf("ExternalRefs = %v, want %v", patched.ExternalRefs, want)
+	}
+	for k, v := range want {
+		if patched.ExternalRefs[k] != v {
+			t.Errorf("ExternalRefs[%q] = %q, want %q", k, patched.ExternalRefs[k], v)
+		}
+	}
+	if _, ok := tenant.ExternalRefs["crm"]; ok {
+		t.Error("Apply must not mutate the original tenant")
+	}
+}
+
+func TestTenantPatch_ApplyTrial(t *testing.T) {
+	tenant := domain.NewTenant("id-1", "Acme", "acme", "pro")
+	ends := time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	patched := domain.TenantPatch{TrialEndsAt: &ends}.Apply(tenant)
+	if !patched.TrialEndsAt.Equal(ends) || patched.TrialEndsAt.Location() != time.UTC {
+		t.Errorf("TrialEndsAt = %v, want %v in UTC", patched.TrialEndsAt, ends)
+	}
+
+	var none time.Time
+	if ended := (domain.TenantPatch{TrialEndsAt: &none}).Apply(patched); !ended.TrialEndsAt.IsZero() {
+		t.Errorf("TrialEndsAt = %v, want zero", ended.TrialEndsAt)
+	}
+	if kept := (domain.TenantPatch{}).Apply(patched); !kept.TrialEndsAt.Equal(ends) {
+		t.Errorf("TrialEndsAt = %v, want untouched", kept.TrialEndsAt)
+	}
+}
+
+func TestTenant_TrialExpired(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		ends time.Time
+		want bool
+	}{
+		"not on trial": {time.Time{}, false},
+		"running":      {now.Add(time.Minute), false},
+		"ends now":     {now, true},
+		"ended":        {now.Add(-time.Hour), true},
+	}
+	for name, tc := range cases {
+		tenant := domain.Tenant{TrialEndsAt: tc.ends}
+		if got := tenant.TrialExpired(now); got != tc.want {
+			t.Errorf("%s: TrialExpired = %v, want %v", name, got, tc.want)
+		}
+	}
+}
+
+func TestValidateSlug(t *testing.T) {
+	valid := []string{"acme", "acme-corp", "a1-b2-c3"}
+	for _, slug := range valid {
+		if err := domain.ValidateSlug(slug); err != nil {
+			t.Errorf("ValidateSlug(%q) = %v, want nil", slug, err)
+		}
+	}
+
+	invalid := []string{"", "Acme", "acme--corp", "-acme", "acme_corp", strings.Repeat("a", domain.MaxSlugLength+1)}
+	for _, slug := range invalid {
+		var slugErr *domain.InvalidSlugError
+		if err := domain.ValidateSlug(slug); !errors.As(err, &slugErr) {
+			t.Errorf("ValidateSlug(%q) = %v, want InvalidSlugError", slug, err)
+		}
+	}
+}
+
+func TestValidateSlug_Reason(t *testing.T) {
+	cases := map[string]string{
+		"":           "it is empty",
+		"Acme":       "uppercase letters are not allowed",
+		"acme corp":  "spaces are not allowed",
+		"zürich":     `non-ASCII character 'ü' is not allowed`,
+		"acme_corp":  `character '_' is not allowed`,
+		"acme--corp": "hyphens may only separate words (no leading, trailing or repeated hyphens)",
+	}
+	for slug, want := range cases {
+		var slugErr *domain.InvalidSlugError
+		if err := domain.ValidateSlug(slug); !errors.As(err, &slugErr) {
+			t.Fatalf("ValidateSlug(%q) = %v, want InvalidSlugError", slug, err)
+		}
+		if slugErr.Reason != want {
+			t.Errorf("ValidateSlug(%q) reason = %q, want %q", slug, slugErr.Reason, want)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := domain.NewTenant("ten_1", "Acme", "acme", "free")
+	before.ExternalRefs = map[string]string{"argocd": "acme", "stripe": "cus_1"}
+	after := before
+	after.Name = "Acme Inc"
+	after.ExternalRefs = map[string]string{"argocd": "acme", "billing": "b_1"}
+	after.Metadata = map[string]string{"crm_id": "42"}
+	after.Tags = []string{"beta", "tier:gold"}
+	after.TrialEndsAt = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	got := domain.Diff(before, after)
+	want := []domain.FieldChange{
+		{Field: "name", Before: "Acme", After: "Acme Inc"},
+		{Field: "external_refs.billing", After: "b_1"},
+		{Field: "external_refs.stripe", Before: "cus_1"},
+		{Field: "metadata.crm_id", After: "42"},
+		{Field: "tags", After: "beta,tier:gold"},
+		{Field: "trial_ends_at", After: "2026-03-01T00:00:00Z"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Diff = %+v, want %+v", got, want)
+	}
+	if changes := domain.Diff(before, before); len(changes) != 0 {
+		t.Errorf("Diff of an unchanged tenant = %+v, want none", changes)
+	}
+}
+
+func TestChangeEvents(t *testing.T) {
+	events := domain.ChangeEvents([]domain.FieldChange{
+		{Field: "git_branch", After: "main"},
+		{Field: "plan", Before: "free", After: "pro"},
+		{Field: "name", Before: "Acme", After: "Acme Inc"},
+		{Field: "external_refs.argocd", After: "acme"},
+	})
+
+	var got []domain.Event
+	for _, e := range events {
+		got = append(got, e.Event)
+	}
+	want := []domain.Event{domain.EventRenamed, domain.EventPlanChanged, domain.EventMetadataUpdated}
+	if !slices.Equal(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	if len(events[2].Changes) != 2 {
+		t.Errorf("metadata_updated changes = %+v, want the branch and the reference", events[2].Changes)
+	}
+}
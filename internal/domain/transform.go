@@ -0,0 +1,17 @@
+package domain
+
+import "context"
+
+// TransformEvent runs the payload of an outbound event through transforms
+// in order, each getting the previous one's output. The first failure
+// stops the chain and is returned as an EventTransformError.
+func TransformEvent(ctx context.Context, transforms []EventTransform, event Event, payload []byte) ([]byte, error) {
+	for _, t := range transforms {
+		out, err := t.Transform(ctx, event, payload)
+		if err != nil {
+			return nil, &EventTransformError{Transform: t.Name(), Reason: err.Error()}
+		}
+		payload = out
+	}
+	return payload, nil
+}
@@ -0,0 +1,39 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// suffixTransform appends its name to payloads, or fails with err.
+type suffixTransform struct {
+	name string
+	err  error
+}
+
+func (t suffixTransform) Name() string { return t.name }
+
+func (t suffixTransform) Transform(_ context.Context, _ domain.Event, payload []byte) ([]byte, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return append(payload, t.name...), nil
+}
+
+func TestTransformEvent(t *testing.T) {
+	ctx := context.Background()
+
+	got, err := domain.TransformEvent(ctx, []domain.EventTransform{suffixTransform{name: "a"}, suffixTransform{name: "b"}}, domain.EventSuspend, []byte("x"))
+	if err != nil || string(got) != "xab" {
+		t.Errorf("TransformEvent = %q, %v; want the transforms applied in order", got, err)
+	}
+
+	_, err = domain.TransformEvent(ctx, []domain.EventTransform{suffixTransform{name: "a"}, suffixTransform{name: "b", err: errors.New("trap")}}, domain.EventSuspend, []byte("x"))
+	var tErr *domain.EventTransformError
+	if !errors.As(err, &tErr) || tErr.Transform != "b" || tErr.Reason != "trap" {
+		t.Errorf("err = %v, want an EventTransformError from b", err)
+	}
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"net/url"
+	"slices"
+	"time"
+)
+
+// MinWebhookSecretLength is the shortest secret accepted for signing deliveries.
+const MinWebhookSecretLength = 16
+
+// WebhookCircuitThreshold is how many deliveries in a row must fail for a
+// subscription's circuit to open.
+const WebhookCircuitThreshold = 10
+
+// WebhookSubscription asks for the events matching its filter to be POSTed
+// to URL, signed with Secret.
+type WebhookSubscription struct {
+	ID     string
+	URL    string
+	Secret string
+	// Events limits deliveries to the listed events; empty means every event.
+	Events []Event
+	// Digest batches the matching events into one delivery an hour or a
+	// day; empty or DigestImmediate delivers each on its own.
+	Digest DigestMode
+	// Circuit is the health of the endpoint, kept by the deliveries.
+	Circuit   WebhookCircuit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WebhookCircuit tracks the failures of a subscription's endpoint. After
+// WebhookCircuitThreshold failures in a row the circuit opens: deliveries
+// are held, without using up their attempts, until an operator resumes
+// them, so a dead endpoint does not hold up the queue with retries.
+type WebhookCircuit struct {
+	// ConsecutiveFailures counts the failed deliveries since the last
+	// successful one.
+	ConsecutiveFailures int
+	LastError           string
+	LastFailureAt       time.Time
+	// OpenedAt is when the circuit opened; zero while it is closed.
+	OpenedAt time.Time
+}
+
+// Open reports whether deliveries are held.
+func (c WebhookCircuit) Open() bool {
+	return !c.OpenedAt.IsZero()
+}
+
+// NewWebhookSubscription creates a subscription after validating it.
+func NewWebhookSubscription(id, rawURL, secret string, events []Event) (WebhookSubscription, error) {
+	now := time.Now().UTC()
+	w := WebhookSubscription{
+		ID:        id,
+		URL:       rawURL,
+		Secret:    secret,
+		Events:    events,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return w, w.Validate()
+}
+
+// Validate checks the URL is absolute http(s), the secret is long enough,
+// the filter only names published events and the digest mode is known.
+func (w WebhookSubscription) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return &InvalidWebhookError{Reason: "url must be an absolute http or https URL"}
+	}
+	if len(w.Secret) < MinWebhookSecretLength {
+		return &InvalidWebhookError{Reason: "secret must be at least 16 characters"}
+	}
+	for _, e := range w.Events {
+		if !slices.Contains(PublishedEvents(), e) {
+			return &InvalidWebhookError{Reason: "unknown event " + string(e)}
+		}
+	}
+	if w.Digest != "" && !slices.Contains(DigestModes(), w.Digest) {
+		return &InvalidWebhookError{Reason: "unknown digest mode " + string(w.Digest)}
+	}
+	return nil
+}
+
+// Matches reports whether event passes the subscription's filter.
+func (w WebhookSubscription) Matches(event Event) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, event)
+}
@@ -0,0 +1,48 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+func TestNewWebhookSubscription_Validates(t *testing.T) {
+	const secret = "0123456789abcdef"
+
+	cases := []struct {
+		name    string
+		url     string
+		secret  string
+		events  []domain.Event
+		wantErr bool
+	}{
+		{"valid", "https://example.com/hook", secret, []domain.Event{domain.EventSuspend, domain.EventPlanSuggested}, false},
+		{"relative url", "/hook", secret, nil, true},
+		{"other scheme", "ftp://example.com/hook", secret, nil, true},
+		{"short secret", "https://example.com/hook", "short", nil, true},
+		{"unknown event", "https://example.com/hook", secret, []domain.Event{"exploded"}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := domain.NewWebhookSubscription("wh_1", tc.url, tc.secret, tc.events)
+			var invalid *domain.InvalidWebhookError
+			if got := errors.As(err, &invalid); got != tc.wantErr {
+				t.Errorf("err = %v, want invalid: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookSubscription_Matches(t *testing.T) {
+	all := domain.WebhookSubscription{}
+	if !all.Matches(domain.EventDelete) {
+		t.Error("subscription without filter should match every event")
+	}
+
+	filtered := domain.WebhookSubscription{Events: []domain.Event{domain.EventSuspend}}
+	if !filtered.Matches(domain.EventSuspend) || filtered.Matches(domain.EventDelete) {
+		t.Error("filtered subscription should match only its events")
+	}
+}
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// NonceStore implements domain.NonceStore in memory, forgetting nonces once
+// they expire. Single-use URLs are then single-use per process only: use a
+// shared store when several replicas serve them.
+type NonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time // nonce -> expiry
+}
+
+// NewNonceStore returns an empty store.
+func NewNonceStore() *NonceStore {
+	return &NonceStore{nonces: make(map[string]time.Time)}
+}
+
+func (s *NonceStore) Use(_ context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for n, exp := range s.nonces {
+		if now.After(exp) {
+			delete(s.nonces, n)
+		}
+	}
+	if _, used := s.nonces[nonce]; used {
+		return false, nil
+	}
+	s.nonces[nonce] = expiresAt
+	return true, nil
+}
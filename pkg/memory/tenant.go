@@ -0,0 +1,236 @@
+// Package memory provides in-memory implementations of the domain ports,
+// for embedding the service in tests and single-process tools without a
+// database.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+)
+
+// TenantRepository implements domain.TenantRepository in memory. It is safe
+// for concurrent use and mirrors the SQLite adapter: slugs are unique, List
+// orders by creation time (newest first) and Update checks and increments
+// the version and leaves CreatedAt and ResellerID untouched.
+type TenantRepository struct {
+	mu      sync.RWMutex
+	tenants map[string]domain.Tenant
+	slugs   map[string]string // slug -> tenant ID
+}
+
+// NewTenantRepository returns an empty repository.
+func NewTenantRepository() *TenantRepository {
+	return &TenantRepository{
+		tenants: make(map[string]domain.Tenant),
+		slugs:   make(map[string]string),
+	}
+}
+
+func (r *TenantRepository) Create(_ context.Context, t domain.Tenant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkInsert(t); err != nil {
+		return err
+	}
+	r.put(t)
+	return nil
+}
+
+// CreateMany stores all tenants or, when any of them conflicts, none.
+func (r *TenantRepository) CreateMany(_ context.Context, tenants []domain.Tenant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make(map[string]bool, len(tenants))
+	slugs := make(map[string]bool, len(tenants))
+	for _, t := range tenants {
+		if err := r.checkInsert(t); err != nil {
+			return err
+		}
+		if ids[t.ID] {
+			return fmt.Errorf("inserting tenant: duplicate id %q", t.ID)
+		}
+		if slugs[t.Slug] {
+			return &domain.SlugConflictError{Slug: t.Slug}
+		}
+		ids[t.ID], slugs[t.Slug] = true, true
+	}
+
+	for _, t := range tenants {
+		r.put(t)
+	}
+	return nil
+}
+
+// checkInsert reports whether t can be stored as a new tenant.
+func (r *TenantRepository) checkInsert(t domain.Tenant) error {
+	if _, ok := r.tenants[t.ID]; ok {
+		return fmt.Errorf("inserting tenant: duplicate id %q", t.ID)
+	}
+	if _, ok := r.slugs[t.Slug]; ok {
+		return &domain.SlugConflictError{Slug: t.Slug}
+	}
+	return nil
+}
+
+func (r *TenantRepository) GetByID(_ context.Context, id string) (domain.Tenant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.tenants[id]
+	if !ok {
+		return domain.Tenant{}, domain.ErrTenantNotFound
+	}
+	return clone(t), nil
+}
+
+func (r *TenantRepository) GetBySlug(_ context.Context, slug string) (domain.Tenant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.slugs[slug]
+	if !ok {
+		return domain.Tenant{}, domain.ErrTenantNotFound
+	}
+	return clone(r.tenants[id]), nil
+}
+
+func (r *TenantRepository) List(_ context.Context, filter domain.ListFilter) ([]domain.Tenant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tenants := r.match(filter)
+	slices.SortFunc(tenants, func(a, b domain.Tenant) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	// Like SQLite, a negative offset is ignored.
+	tenants = tenants[min(max(0, filter.Offset), len(tenants)):]
+	if filter.Limit > 0 && filter.Limit < len(tenants) {
+		tenants = tenants[:filter.Limit]
+	}
+	for i := range tenants {
+		tenants[i] = clone(tenants[i])
+	}
+	return tenants, nil
+}
+
+func (r *TenantRepository) Count(_ context.Context, filter domain.ListFilter) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.match(filter)), nil
+}
+
+func (r *TenantRepository) Update(_ context.Context, t domain.Tenant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tenants[t.ID]
+	if !ok {
+		return domain.ErrTenantNotFound
+	}
+	if t.Version != stored.Version {
+		return domain.ErrConcurrentModification
+	}
+	if id, ok := r.slugs[t.Slug]; ok && id != t.ID {
+		return &domain.SlugConflictError{Slug: t.Slug}
+	}
+
+	t.CreatedAt, t.ResellerID = stored.CreatedAt, stored.ResellerID
+	t.Version++
+	delete(r.slugs, stored.Slug)
+	r.put(t)
+	return nil
+}
+
+// Delete removes a tenant. It is not part of domain.TenantRepository and
+// exists for tests that need a tenant to disappear.
+func (r *TenantRepository) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.tenants[id]
+	if !ok {
+		return domain.ErrTenantNotFound
+	}
+	delete(r.tenants, id)
+	delete(r.slugs, t.Slug)
+	return nil
+}
+
+// Purge removes a tenant, like Delete. The memory repository holds no
+// other records of the tenant.
+func (r *TenantRepository) Purge(ctx context.Context, id string) error {
+	return r.Delete(ctx, id)
+}
+
+// put stores a copy of t. The caller holds the write lock.
+func (r *TenantRepository) put(t domain.Tenant) {
+	r.tenants[t.ID] = clone(t)
+	r.slugs[t.Slug] = t.ID
+}
+
+// match returns the tenants selected by the filter, ignoring Limit and
+// Offset. The caller holds the lock.
+func (r *TenantRepository) match(f domain.ListFilter) []domain.Tenant {
+	var out []domain.Tenant
+	for _, t := range r.tenants {
+		if matches(t, f) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// matches applies the criteria of a filter the way the SQL adapter's WHERE
+// clause does.
+func matches(t domain.Tenant, f domain.ListFilter) bool {
+	switch {
+	case f.Status != nil && t.Status != *f.Status:
+		return false
+	case len(f.Statuses) > 0 && !slices.Contains(f.Statuses, t.Status):
+		return false
+	case len(f.Plans) > 0 && !slices.Contains(f.Plans, t.Plan):
+		return false
+	case len(f.IDs) > 0 && !slices.Contains(f.IDs, t.ID):
+		return false
+	case f.ResellerID != "" && t.ResellerID != f.ResellerID:
+		return false
+	case !f.CreatedAfter.IsZero() && t.CreatedAt.Before(f.CreatedAfter):
+		return false
+	case !f.CreatedBefore.IsZero() && !t.CreatedAt.Before(f.CreatedBefore):
+		return false
+	case !f.TrialEndsBy.IsZero() && !t.TrialExpired(f.TrialEndsBy):
+		return false
+	case f.Simulated != nil && t.Simulated != *f.Simulated:
+		return false
+	}
+	for k, v := range f.Metadata {
+		if value, ok := t.Metadata[k]; !ok || value != v {
+			return false
+		}
+	}
+	for _, tag := range f.Tags {
+		if !slices.Contains(t.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// clone copies the tenant's external refs, metadata and tags so callers
+// cannot mutate stored state through them.
+func clone(t domain.Tenant) domain.Tenant {
+	t.ExternalRefs = maps.Clone(t.ExternalRefs)
+	t.Metadata = maps.Clone(t.Metadata)
+	t.Tags = slices.Clone(t.Tags)
+	return t
+}
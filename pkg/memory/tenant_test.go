@@ -0,0 +1,209 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neomorfeo/tenantiq/internal/domain"
+	"github.com/neomorfeo/tenantiq/pkg/memory"
+)
+
+var _ domain.TenantRepository = (*memory.TenantRepository)(nil)
+
+func newTenant(id, slug, plan string, created time.Time) domain.Tenant {
+	t := domain.NewTenant(id, id, slug, plan)
+	t.CreatedAt, t.UpdatedAt = created, created
+	return t
+}
+
+func TestCreate_SlugConflict(t *testing.T) {
+	repo := memory.NewTenantRepository()
+	ctx := context.Background()
+
+	if err := repo.Create(ctx, domain.NewTenant("ten_1", "Acme", "acme", "free")); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	var conflict *domain.SlugConflictError
+	if err := repo.Create(ctx, domain.NewTenant("ten_2", "Acme", "acme", "free")); !errors.As(err, &conflict) {
+		t.Fatalf("expected SlugConflictError, got %v", err)
+	}
+}
+
+func TestCreateMany_IsAtomic(t *testing.T) {
+	repo := memory.NewTenantRepository()
+	ctx := context.Background()
+
+	err := repo.CreateMany(ctx, []domain.Tenant{
+		domain.NewTenant("ten_1", "A", "a", "free"),
+		domain.NewTenant("ten_2", "B", "a", "free"),
+	})
+	if err == nil {
+		t.Fatal("expected an error for a duplicate slug in the batch")
+	}
+	if n, _ := repo.Count(ctx, domain.ListFilter{}); n != 0 {
+		t.Errorf("count = %d, want 0 after a failed batch", n)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	repo := memory.NewTenantRepository()
+	ctx := context.Background()
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := repo.Update(ctx, domain.NewTenant("ten_404", "A", "a", "free")); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Fatalf("expected ErrTenantNotFound, got %v", err)
+	}
+
+	a, b := newTenant("ten_a", "a", "free", created), newTenant("ten_b", "b", "free", created)
+	if err := repo.CreateMany(ctx, []domain.Tenant{a, b}); err != nil {
+		t.Fatalf("CreateMany: %v", err)
+	}
+
+	b.Slug = "a"
+	var conflict *domain.SlugConflictError
+	if err := repo.Update(ctx, b); !errors.As(err, &conflict) {
+		t.Fatalf("expected SlugConflictError, got %v", err)
+	}
+
+	a.Slug, a.CreatedAt = "renamed", time.Now()
+	if err := repo.Update(ctx, a); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := repo.Update(ctx, a); !errors.Is(err, domain.ErrConcurrentModification) {
+		t.Errorf("stale Update: expected ErrConcurrentModification, got %v", err)
+	}
+	if _, err := repo.GetBySlug(ctx, "a"); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("old slug should be released, got %v", err)
+	}
+	got, err := repo.GetBySlug(ctx, "renamed")
+	if err != nil {
+		t.Fatalf("GetBySlug: %v", err)
+	}
+	if !got.CreatedAt.Equal(created) {
+		t.Errorf("CreatedAt = %v, want %v unchanged", got.CreatedAt, created)
+	}
+}
+
+func TestGetByID_ReturnsCopy(t *testing.T) {
+	repo := memory.NewTenantRepository()
+	ctx := context.Background()
+
+	tenant := domain.NewTenant("ten_1", "Acme", "acme", "free")
+	tenant.ExternalRefs = map[string]string{"argocd_app": "acme"}
+	if err := repo.Create(ctx, tenant); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	tenant.ExternalRefs["argocd_app"] = "changed"
+
+	got, _ := repo.GetByID(ctx, "ten_1")
+	got.ExternalRefs["argocd_app"] = "changed"
+
+	if again, _ := repo.GetByID(ctx, "ten_1"); again.ExternalRefs["argocd_app"] != "acme" {
+		t.Errorf("stored refs = %v, want them isolated from callers", again.ExternalRefs)
+	}
+}
+
+func TestList_FiltersAndPaginates(t *testing.T) {
+	repo := memory.NewTenantRepository()
+	ctx := context.Background()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := range 5 {
+		plan := "free"
+		if i%2 == 1 {
+			plan = "pro"
+		}
+		id := fmt.Sprintf("ten_%d", i)
+		if err := repo.Create(ctx, newTenant(id, id, plan, base.Add(time.Duration(i)*time.Hour))); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	page, _ := repo.List(ctx, domain.ListFilter{Limit: 2, Offset: 1})
+	if len(page) != 2 || page[0].ID != "ten_3" || page[1].ID != "ten_2" {
+		t.Errorf("page = %v, want ten_3, ten_2 (newest first)", ids(page))
+	}
+
+	free, _ := repo.List(ctx, domain.ListFilter{Plans: []string{"free"}})
+	if got := ids(free); fmt.Sprint(got) != "[ten_4 ten_2 ten_0]" {
+		t.Errorf("free tenants = %v", got)
+	}
+
+	window := domain.ListFilter{CreatedAfter: base.Add(time.Hour), CreatedBefore: base.Add(3 * time.Hour)}
+	if n, _ := repo.Count(ctx, window); n != 2 {
+		t.Errorf("count in window = %d, want 2", n)
+	}
+
+	trial, _ := repo.GetByID(ctx, "ten_1")
+	trial.TrialEndsAt = base
+	if err := repo.Update(ctx, trial); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if expired, _ := repo.List(ctx, domain.ListFilter{TrialEndsBy: base}); fmt.Sprint(ids(expired)) != "[ten_1]" {
+		t.Errorf("expired trials = %v, want [ten_1]", ids(expired))
+	}
+
+	tagged, _ := repo.GetByID(ctx, "ten_2")
+	tagged.Metadata = map[string]string{"crm_id": "42", "region": "eu"}
+	if err := repo.Update(ctx, tagged); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if crm, _ := repo.List(ctx, domain.ListFilter{Metadata: map[string]string{"crm_id": "42", "region": "eu"}}); fmt.Sprint(ids(crm)) != "[ten_2]" {
+		t.Errorf("tenants with metadata = %v, want [ten_2]", ids(crm))
+	}
+	if n, _ := repo.Count(ctx, domain.ListFilter{Metadata: map[string]string{"crm_id": "7"}}); n != 0 {
+		t.Errorf("count with other metadata = %d, want 0", n)
+	}
+
+	tagged.Version++
+	tagged.Tags = []string{"beta", "tier:gold"}
+	if err := repo.Update(ctx, tagged); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if gold, _ := repo.List(ctx, domain.ListFilter{Tags: []string{"tier:gold", "beta"}}); fmt.Sprint(ids(gold)) != "[ten_2]" {
+		t.Errorf("tenants with tags = %v, want [ten_2]", ids(gold))
+	}
+	if n, _ := repo.Count(ctx, domain.ListFilter{Tags: []string{"beta", "churned"}}); n != 0 {
+		t.Errorf("count with a missing tag = %d, want 0", n)
+	}
+
+	if past, _ := repo.List(ctx, domain.ListFilter{Offset: 10}); len(past) != 0 {
+		t.Errorf("offset past the end returned %d tenants", len(past))
+	}
+	if all, err := repo.List(ctx, domain.ListFilter{Limit: -1, Offset: -1}); err != nil || len(all) != 5 {
+		t.Errorf("negative pagination returned %d tenants, %v; want all 5", len(all), err)
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	repo := memory.NewTenantRepository()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Go(func() {
+			id := fmt.Sprintf("ten_%d", i)
+			if err := repo.Create(ctx, domain.NewTenant(id, id, id, "free")); err != nil {
+				t.Errorf("Create: %v", err)
+			}
+			_, _ = repo.List(ctx, domain.ListFilter{})
+		})
+	}
+	wg.Wait()
+
+	if n, _ := repo.Count(ctx, domain.ListFilter{}); n != 20 {
+		t.Errorf("count = %d, want 20", n)
+	}
+}
+
+func ids(tenants []domain.Tenant) []string {
+	out := make([]string, len(tenants))
+	for i, t := range tenants {
+		out[i] = t.ID
+	}
+	return out
+}